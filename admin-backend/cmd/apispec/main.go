@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"yflow/internal/apispec"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag/gen"
+)
+
+// apispec 从 swag 注释生成 docs/ 下的 docs.go、swagger.json 和 swagger.yaml
+//
+// 与 `swag init` 的结果相同，但无需安装 swag 命令行工具，扫描范围与契约测试一致。
+// 同时按路由注册时的权限中间件生成 docs/openapi/<audience>.json，只包含对应凭证可以调用的接口：
+//
+//	go run ./cmd/apispec
+func main() {
+	root := flag.String("root", ".", "admin-backend 根目录")
+	output := flag.String("output", "docs", "输出目录（相对于根目录）")
+	flag.Parse()
+
+	config := &gen.Config{
+		SearchDir:       strings.Join(apispec.AbsSearchDirs(*root), ","),
+		MainAPIFile:     apispec.MainAPIFile,
+		OutputDir:       filepath.Join(*root, *output),
+		OutputTypes:     []string{"go", "json", "yaml"},
+		ParseDepth:      apispec.ParseDepth,
+		ParseDependency: 0,
+		Strict:          true,
+		PackageName:     "docs",
+	}
+
+	if err := gen.New().Build(config); err != nil {
+		log.Fatalf("生成 API 文档失败: %v", err)
+	}
+	if err := writeAudienceSpecs(*root, filepath.Join(*root, *output)); err != nil {
+		log.Fatalf("生成筛选后的 API 文档失败: %v", err)
+	}
+}
+
+// writeAudienceSpecs 按 apispec.Audiences 筛选 outputDir 下的 swagger.json，写入 outputDir/openapi
+func writeAudienceSpecs(root, outputDir string) error {
+	data, err := os.ReadFile(filepath.Join(outputDir, "swagger.json"))
+	if err != nil {
+		return err
+	}
+	var swagger spec.Swagger
+	if err := json.Unmarshal(data, &swagger); err != nil {
+		return err
+	}
+	access, err := apispec.RouteAccess(root)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(outputDir, "openapi")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, audience := range apispec.Audiences {
+		filtered, err := apispec.Filter(&swagger, access, audience)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(filtered, "", "    ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, audience.Name+".json"), append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
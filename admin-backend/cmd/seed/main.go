@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"yflow/internal/config"
+	"yflow/internal/di"
+	"yflow/internal/domain"
+	"yflow/internal/seed"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// seed 生成演示和压测用的假数据
+//
+// 数据通过服务层写入（与 API 使用相同的带缓存服务），缓存失效等行为与真实操作一致。
+//
+// 用法：
+//
+//	go run ./cmd/seed --projects 10 --keys 50000 --languages 12
+func main() {
+	var opts seed.Options
+	flag.IntVar(&opts.Projects, "projects", 3, "生成的项目数量")
+	flag.IntVar(&opts.Keys, "keys", 1000, "每个项目的翻译键数量")
+	flag.IntVar(&opts.Languages, "languages", 5, "使用的语言数量（已存在的语言会被复用）")
+	flag.Float64Var(&opts.FillRate, "fill", 0.85, "非默认语言的翻译完成率 (0-1)")
+	flag.StringVar(&opts.ProjectPrefix, "prefix", "Seed Project", "项目名称前缀")
+	flag.IntVar(&opts.BatchSize, "batch", 1000, "每批写入的翻译条数")
+	flag.Int64Var(&opts.RandomSeed, "seed", 1, "随机数种子，相同种子生成相同数据")
+	flag.StringVar(&opts.Username, "user", "admin", "作为创建者的用户名")
+	flag.Parse()
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Fatalf("加载配置失败: %v", err)
+	}
+
+	var params seedParams
+	app := fx.New(
+		fx.NopLogger,
+		fx.Supply(cfg),
+		di.AppModule,
+		fx.Invoke(func(p seedParams) { params = p }),
+	)
+	if err := app.Err(); err != nil {
+		log.Fatalf("初始化失败: %v", err)
+	}
+
+	ctx := context.Background()
+	startCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := app.Start(startCtx); err != nil {
+		log.Fatalf("启动失败: %v", err)
+	}
+	defer app.Stop(context.Background())
+
+	seeder := seed.NewSeeder(params.ProjectService, params.LanguageService, params.TranslationService, params.UserRepo, params.Logger)
+	if err := seeder.Run(ctx, opts); err != nil {
+		params.Logger.Error("生成数据失败", zap.Error(err))
+		os.Exit(1)
+	}
+}
+
+// seedParams 生成数据所需的依赖
+type seedParams struct {
+	fx.In
+
+	ProjectService     domain.ProjectService
+	LanguageService    domain.LanguageService
+	TranslationService domain.TranslationService
+	UserRepo           domain.UserRepository
+	Logger             *zap.Logger
+}
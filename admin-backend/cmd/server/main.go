@@ -13,9 +13,11 @@ import (
 	"go.uber.org/zap"
 )
 
+//go:generate go run ../apispec -root ../..
+
 // @title           YFlow API
 // @version         1.0
-// @description     语流是一个用于管理多语言翻译的系统。
+// @description     语流是一个用于管理多语言翻译的系统。本文档描述 API v1，未带版本号的 /api 路径为 v1 的别名；v2 中不兼容的变更见 docs/api/endpoints.md。
 // @termsOfService  http://swagger.io/terms/
 
 // @contact.name   API Support
@@ -26,12 +28,17 @@ import (
 // @license.url   https://opensource.org/licenses/MIT
 
 // @host      localhost:8080
-// @BasePath  /api
+// @BasePath  /api/v1
 
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
 // @description 输入格式: Bearer {token}
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description CLI 使用的 API Key
 func main() {
 	// 加载配置
 	cfg, err := config.GetConfig()
@@ -52,6 +59,9 @@ func setupMiddleware(router *gin.Engine, monitor *internal_utils.SimpleMonitor,
 	// 请求ID中间件（最先设置，确保所有后续中间件都能使用请求ID）
 	router.Use(middleware.RequestIDMiddleware())
 
+	// 请求级缓存中间件，同一请求内复用语言列表和项目查询结果
+	router.Use(middleware.RequestCacheMiddleware())
+
 	// 统一日志中间件（第二个设置，确保所有请求都能被记录，并包含请求ID）
 	// 集成监控，用于记录请求指标
 	if monitor != nil {
@@ -76,11 +86,11 @@ func setupMiddleware(router *gin.Engine, monitor *internal_utils.SimpleMonitor,
 	// SQL安全中间件（跳过 swagger 路径）
 	router.Use(middleware.SkipForSwagger(middleware.SQLSecurityMiddleware(logger)))
 
-	// 增强输入验证中间件（跳过 swagger 路径）
-	router.Use(middleware.SkipForSwagger(middleware.EnhancedInputValidationMiddleware()))
+	// 增强输入验证中间件（跳过 swagger 路径和入站 Webhook）
+	router.Use(middleware.SkipForSwagger(middleware.SkipForInboundWebhook(middleware.EnhancedInputValidationMiddleware())))
 
-	// XSS防护中间件
-	router.Use(middleware.XSSProtectionMiddleware(logger))
+	// XSS防护中间件（入站 Webhook 需要保留原始请求体用于签名校验）
+	router.Use(middleware.SkipForInboundWebhook(middleware.XSSProtectionMiddleware(logger)))
 
 	// CSP违规报告中间件
 	router.Use(middleware.CSPViolationReportMiddleware(logger))
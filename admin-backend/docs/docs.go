@@ -24,14 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/cli/auth": {
+        "/admin/audit-logs": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "验证CLI API Key",
+                "description": "分页获取不属于任何项目的系统级审计记录（如用户离职处理 user.offboard，project_id 为 0），最新的在前。提供 cursor 或 limit 时使用游标分页，meta.next_cursor 为下一页的游标，没有下一页时不返回",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,18 +39,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CLI"
+                    "系统管理"
+                ],
+                "summary": "获取系统审计日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标分页：上一页返回的 meta.next_cursor，为空时获取第一页",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "游标分页：每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "CLI身份验证",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "$ref": "#/definitions/dto.AuditLogListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
@@ -58,14 +87,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cli/keys": {
-            "post": {
+        "/admin/compliance-report": {
+            "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "从CLI推送新的翻译键",
+                "description": "按项目和月份汇总每个用户的翻译变更（按操作类型）、审计日志记录的操作和数据导出次数，用于合规审计。from 和 to 为月份，包含起止月份，最多 24 个月；不指定 project_id 时包含全部项目",
                 "consumes": [
                     "application/json"
                 ],
@@ -73,25 +102,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CLI"
+                    "系统管理"
                 ],
-                "summary": "推送翻译键",
+                "summary": "获取合规报告",
                 "parameters": [
                     {
-                        "description": "推送键请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.PushKeysRequest"
-                        }
+                        "type": "string",
+                        "example": "2026-01",
+                        "description": "开始月份",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-06",
+                        "description": "结束月份",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "$ref": "#/definitions/dto.ComplianceReportResponse"
                         }
                     },
                     "400": {
@@ -100,6 +142,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -109,14 +157,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cli/translations": {
+        "/admin/dead-letters": {
             "get": {
                 "security": [
                     {
-                        "ApiKeyAuth": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "获取项目翻译数据供CLI使用",
+                "description": "列出超过最大投递次数仍未投递成功的事件（发件箱中状态为 failed），最近写入的在前。事件进入死信状态时会发布 outbox.dead_lettered 告警事件",
                 "consumes": [
                     "application/json"
                 ],
@@ -124,20 +172,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CLI"
+                    "系统管理"
                 ],
-                "summary": "获取翻译数据",
+                "summary": "获取死信事件列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "项目ID",
-                        "name": "project_id",
+                        "description": "事件类型，如 translation.updated",
+                        "name": "event_type",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "语言代码",
-                        "name": "locale",
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -145,17 +201,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.DeadLetterResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
@@ -163,14 +228,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/dashboard/stats": {
-            "get": {
+        "/admin/dead-letters/requeue": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取项目、语言、翻译等统计信息",
+                "description": "将指定的死信事件（或 all 为 true 时全部死信事件）重置为待投递，投递器在下一次轮询时投递，并重新获得完整的重试次数。重复投递时事件 ID 不变",
                 "consumes": [
                     "application/json"
                 ],
@@ -178,70 +243,109 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "仪表板"
+                    "系统管理"
+                ],
+                "summary": "重新投递死信事件",
+                "parameters": [
+                    {
+                        "description": "要重新投递的事件",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RequeueDeadLettersRequest"
+                        }
+                    }
                 ],
-                "summary": "获取仪表板统计信息",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.DashboardStats"
+                            "$ref": "#/definitions/dto.RequeueDeadLettersResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/exports/project/{project_id}": {
+        "/admin/role-reviews": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "导出项目翻译数据",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "列出项目 owner 角色的复核记录，最近加入的在前。用户的全局角色被降级时，其全部项目 owner 成员关系自动加入复核队列（reason=global_role_downgraded）；同步时发现已不是管理员的用户仍保留同步添加的 owner 成员关系时同样加入（reason=role_sync_leftover）",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "翻译管理"
+                    "系统管理"
                 ],
-                "summary": "导出翻译",
+                "summary": "获取角色复核队列",
                 "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "kept",
+                            "downgraded",
+                            "removed"
+                        ],
+                        "type": "string",
+                        "description": "复核状态，为空时返回全部",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "项目ID",
-                        "name": "project_id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.RoleReview"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
@@ -249,14 +353,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/imports/project/{project_id}": {
+        "/admin/role-reviews/{id}/resolve": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "导入项目翻译数据",
+                "description": "keep 保留 owner 角色；downgrade 将成员角色降为 role（editor 或 viewer）；remove 移除该项目成员关系。保留或降级后该成员关系不再视为同步添加，之后的同步不会再次加入复核队列；成员关系已不存在时记为 removed",
                 "consumes": [
                     "application/json"
                 ],
@@ -264,45 +368,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "翻译管理"
+                    "系统管理"
                 ],
-                "summary": "导入翻译",
+                "summary": "处理角色复核",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "项目ID",
-                        "name": "project_id",
+                        "description": "复核记录ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "翻译数据，格式为 {\\",
-                        "name": "data",
+                        "description": "复核操作",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "object",
-                                "additionalProperties": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/dto.ResolveRoleReviewRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "default": "\"json\"",
-                        "description": "导入格式",
-                        "name": "format",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RoleReview"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -311,61 +414,78 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
                     }
                 }
             }
         },
-        "/languages": {
-            "get": {
+        "/admin/role-sync": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取所有语言列表",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "为每个启用的全局管理员补充缺少的项目成员关系（角色 owner，来源 role_sync），使管理员出现在各项目的成员列表中，管理员已有的成员关系保持不变；同时将已不是全局管理员的用户由同步添加的 owner 成员关系加入复核队列。设置 ROLE_SYNC_INTERVAL 后会定期自动执行",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "语言管理"
+                    "系统管理"
                 ],
-                "summary": "获取语言列表",
+                "summary": "同步全局角色与项目成员",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Language"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RoleSyncResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/stale-projects": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "创建新的语言",
+                "description": "列出超过指定天数没有内容变更的活跃项目，最久未变更的在前。从未变更的项目按创建时间计算；同时返回最近访问时间（Web 或 CLI），便于判断项目是否仍在使用",
                 "consumes": [
                     "application/json"
                 ],
@@ -373,56 +493,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "语言管理"
+                    "系统管理"
                 ],
-                "summary": "创建语言",
+                "summary": "获取闲置项目报告",
                 "parameters": [
                     {
-                        "description": "语言信息",
-                        "name": "language",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateLanguageRequest"
-                        }
+                        "type": "integer",
+                        "description": "闲置天数，默认使用 PROJECT_STALE_DAYS 配置",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Language"
+                            "$ref": "#/definitions/dto.StaleProjectListResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/languages/{id}": {
-            "put": {
+        "/admin/stale-projects/archive": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新语言信息",
+                "description": "归档闲置项目报告中选中的项目。归档前按最新的活动时间重新检查，报告生成后又有内容变更、已归档或不存在的项目会被跳过。每个归档的项目记录一条 project.archive 审计日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -430,24 +555,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "语言管理"
+                    "系统管理"
                 ],
-                "summary": "更新语言",
+                "summary": "批量归档闲置项目",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "语言ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "语言信息",
-                        "name": "language",
+                        "description": "要归档的项目",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateLanguageRequest"
+                            "$ref": "#/definitions/dto.ArchiveStaleProjectsRequest"
                         }
                     }
                 ],
@@ -455,83 +573,32 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Language"
+                            "$ref": "#/definitions/dto.ArchiveStaleProjectsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/storage-health": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "删除指定的语言",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "语言管理"
-                ],
-                "summary": "删除语言",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "语言ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "使用用户名和密码获取访问令牌",
+                "description": "返回主要数据表的行数和占用空间，以及超过软限制的告警和处理建议。默认返回最近一次定期检查的结果，refresh=true 时立即检查",
                 "consumes": [
                     "application/json"
                 ],
@@ -539,56 +606,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户认证"
+                    "系统管理"
                 ],
-                "summary": "用户登录",
+                "summary": "获取数据库增长检查结果",
                 "parameters": [
                     {
-                        "description": "登录凭证",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.LoginRequest"
-                        }
+                        "type": "boolean",
+                        "description": "立即重新检查",
+                        "name": "refresh",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.StorageHealthResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/projects": {
+        "/api-keys": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取项目列表，支持分页和关键词搜索",
+                "description": "返回所有 API Key 的权限范围、可访问的项目和最近使用时间，不包含 API Key 本身",
                 "consumes": [
                     "application/json"
                 ],
@@ -596,46 +648,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
-                ],
-                "summary": "获取项目列表",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "搜索关键词",
-                        "name": "keyword",
-                        "in": "query"
-                    }
+                    "API Key"
                 ],
+                "summary": "获取 API Key 列表",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.APIKeyResponse"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
@@ -646,7 +675,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "创建新的翻译项目",
+                "description": "创建 CLI 使用的 API Key，可以限定可访问的项目和权限范围：read 只能拉取翻译和监听变更，write 还可以推送键和导入翻译。\nCLI 在 X-API-Key 头中携带 API Key；API Key 只在创建时返回一次。不能使用个人访问令牌调用此接口",
                 "consumes": [
                     "application/json"
                 ],
@@ -654,17 +683,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
+                    "API Key"
                 ],
-                "summary": "创建项目",
+                "summary": "创建 API Key",
                 "parameters": [
                     {
-                        "description": "项目信息",
-                        "name": "project",
+                        "description": "API Key 信息",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateProjectRequest"
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -672,38 +701,38 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.Project"
+                            "$ref": "#/definitions/dto.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/projects/delete/{id}": {
+        "/api-keys/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "删除指定的项目",
+                "description": "撤销 API Key，撤销后立即失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -711,13 +740,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
+                    "API Key"
                 ],
-                "summary": "删除项目",
+                "summary": "撤销 API Key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "项目ID",
+                        "description": "API Key ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -730,146 +759,144 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/projects/detail/{id}": {
+        "/auth/oidc/callback": {
             "get": {
-                "security": [
+                "description": "校验回调并登录，成功后跳转到前端登录页并在 URL 片段中携带 refresh_token，失败时携带 sso_error",
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "单点登录回调",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "授权请求的 state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权码",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "身份提供方返回的错误",
+                        "name": "error",
+                        "in": "query"
                     }
                 ],
-                "description": "根据项目ID获取项目详细信息",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "生成授权请求并跳转到身份提供方，登录会话保存在 HttpOnly Cookie 中，10 分钟内有效",
                 "tags": [
-                    "项目管理"
+                    "用户认证"
                 ],
-                "summary": "获取项目详情",
+                "summary": "单点登录",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "项目ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "身份提供方名称",
+                        "name": "provider",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Project"
-                        }
+                    "302": {
+                        "description": "Found"
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/projects/update/{id}": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "更新项目信息",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "返回 SSO_PROVIDERS 中配置的身份提供方，登录页据此显示单点登录按钮",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
-                ],
-                "summary": "更新项目",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "项目ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "项目信息",
-                        "name": "project",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateProjectRequest"
-                        }
-                    }
+                    "用户认证"
                 ],
+                "summary": "获取单点登录提供方",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Project"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.SSOProvider"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/refresh": {
-            "post": {
-                "description": "使用刷新令牌获取新的访问令牌",
+        "/cli/auth": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "验证CLI API Key。使用管理员创建的 API Key 时返回其权限范围（scope）和可访问的项目（project_ids，为空时可访问所有项目）",
                 "consumes": [
                     "application/json"
                 ],
@@ -877,56 +904,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户认证"
-                ],
-                "summary": "刷新访问令牌",
-                "parameters": [
-                    {
-                        "description": "刷新令牌",
-                        "name": "refresh_token",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.RefreshRequest"
-                        }
-                    }
+                    "CLI"
                 ],
+                "summary": "CLI身份验证",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/translations": {
+        "/cli/keys": {
             "post": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "创建新的翻译",
+                "description": "从CLI推送新的翻译键，或批量导入/更新翻译数据。需要 write 权限范围的 API Key",
                 "consumes": [
                     "application/json"
                 ],
@@ -934,47 +938,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "翻译管理"
+                    "CLI"
                 ],
-                "summary": "创建翻译",
+                "summary": "推送翻译键或批量导入翻译",
                 "parameters": [
                     {
-                        "description": "翻译信息",
-                        "name": "translation",
+                        "description": "推送键请求",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateTranslationRequest"
+                            "$ref": "#/definitions/handlers.PushKeysRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Translation"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.PushKeysResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/translations/batch": {
-            "post": {
+        "/cli/translations": {
+            "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "批量创建多个翻译，支持两种格式：数组格式和前端对象格式",
+                "description": "获取项目翻译数据供CLI使用。可按语言和命名空间（键名中第一个 \".\" 之前的部分）筛选；\n不分页时 data 为 键名 -\u003e 语言代码 -\u003e 翻译值 的映射；指定 limit 或 cursor 时按键名分页返回 TranslationsPage，next_cursor 用于获取下一页。\n不分页时键数量超过服务端上限返回 TOO_MANY_KEYS，客户端应改为分页拉取。支持 gzip 压缩。",
                 "consumes": [
                     "application/json"
                 ],
@@ -982,25 +1007,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "翻译管理"
+                    "CLI"
                 ],
-                "summary": "批量创建翻译",
+                "summary": "获取翻译数据",
                 "parameters": [
                     {
-                        "description": "批量翻译请求",
-                        "name": "translations",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.BatchTranslationRequest"
-                        }
+                        "type": "string",
+                        "description": "项目ID或项目标识（slug），旧标识同样可用",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "语言代码；auto 时按 Accept-Language 从启用的语言中协商，选择的语言在 Content-Language 响应头中返回",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "命名空间，只返回以 namespace. 开头的键",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "发布版本标签，指定时返回该版本冻结的翻译，用于可重现的构建",
+                        "name": "release",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "分页模式下每页的键数量，最大 5000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "上一页返回的 next_cursor",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.TranslationsPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "locale=auto 时协商出的语言代码"
+                            },
+                            "X-YFlow-Locale-Fallback": {
+                                "type": "string",
+                                "description": "locale=auto 且没有匹配的语言、使用默认语言时为 true"
+                            }
                         }
                     },
                     "400": {
@@ -1008,96 +1083,62 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.APIResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/translations/batch-delete": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "批量删除多个翻译",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "翻译管理"
-                ],
-                "summary": "批量删除翻译",
-                "parameters": [
-                    {
-                        "description": "翻译ID列表",
-                        "name": "ids",
-                        "in": "body",
-                        "required": true,
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/translations/by-project/{project_id}": {
+        "/cli/translations/delta": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "根据项目ID获取翻译列表",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "返回同步起点之后变更的键，供移动端 SDK 和 CLI 增量更新本地翻译，不需要每次下载全部翻译。\nsince 为 RFC 3339 时间或发布版本标签（从该版本的发布时间开始）；响应中的 synced_at 作为下次同步的 since。\ntranslations 为变更的键在各语言的当前翻译，客户端应按键整体替换；deleted 为已删除、废弃或被重命名的键。\n变更的键超过服务端上限时返回 TOO_MANY_CHANGES，客户端应改为全量拉取 /cli/translations。支持 gzip 压缩。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "翻译管理"
+                    "CLI"
                 ],
-                "summary": "获取项目翻译",
+                "summary": "增量同步翻译",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "项目ID",
+                        "type": "string",
+                        "description": "项目ID或项目标识（slug），旧标识同样可用",
                         "name": "project_id",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
-                        "name": "page",
+                        "type": "string",
+                        "description": "同步起点：RFC 3339 时间（如 2026-03-01T12:00:00Z）或发布版本标签",
+                        "name": "since",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "语言代码；auto 时按 Accept-Language 从启用的语言中协商",
+                        "name": "locale",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "每页数量",
-                        "name": "page_size",
+                        "type": "string",
+                        "description": "命名空间，只返回以 namespace. 开头的键",
+                        "name": "namespace",
                         "in": "query"
                     }
                 ],
@@ -1105,152 +1146,174 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.TranslationDelta"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/translations/matrix/by-project/{project_id}": {
+        "/cli/watch": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth": []
+                        "ApiKeyAuth": []
                     }
                 ],
-                "description": "获取项目的翻译矩阵（键-语言映射），支持分页",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "升级为 WebSocket 连接后推送项目的翻译变更，供 ` + "`" + `yflow watch` + "`" + ` 在本地开发时热更新翻译，不需要轮询 /cli/translations。\n每条消息为 JSON：连接建立后先发送 ready，之后发送 changed（包含变更的键和语言）和定期的 ping；\n收到 resync 或连接断开时变更可能丢失，客户端应全量拉取后重新连接。客户端发送的消息会被忽略。",
                 "tags": [
-                    "翻译管理"
+                    "CLI"
                 ],
-                "summary": "获取翻译矩阵",
+                "summary": "监听翻译变更（WebSocket）",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "项目ID",
+                        "type": "string",
+                        "description": "项目ID或项目标识（slug），旧标识同样可用",
                         "name": "project_id",
-                        "in": "path",
+                        "in": "query",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "每页数量",
-                        "name": "page_size",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "搜索关键词",
-                        "name": "keyword",
+                        "description": "只接收这些语言的变更，逗号分隔，如 en,zh-CN",
+                        "name": "locales",
                         "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/domain.WatchEvent"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/translations/{id}": {
+        "/dashboard/activity": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "根据翻译ID获取翻译详细信息",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按翻译变更历史统计最近 30 或 90 天（包括今天）每天、每个操作人和每个项目新增和修改的翻译数，用于绘制活动图表。新增为 create 变更，修改为 update、machine_translate 和 rollback 变更；daily 每天一项，没有变更的日期为 0，operators 和 projects 按变更总数降序。结果缓存，翻译变化时清除。仅管理员可用",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "翻译管理"
+                    "仪表板"
                 ],
-                "summary": "获取翻译详情",
+                "summary": "获取翻译活动趋势",
                 "parameters": [
                     {
+                        "enum": [
+                            30,
+                            90
+                        ],
                         "type": "integer",
-                        "description": "翻译ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 30,
+                        "description": "统计天数",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Translation"
+                            "$ref": "#/definitions/domain.DashboardActivity"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/dashboard/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目、语言、翻译等统计信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "仪表板"
+                ],
+                "summary": "获取仪表板统计信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DashboardStats"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1259,128 +1322,290 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/exports/project/{project_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新翻译信息",
+                "description": "导出项目翻译数据。format=json（默认）时格式为 {\"key\": {\"en\": \"value\"}}，与导入格式相同，json 类型的键输出解析后的数组或对象，其余格式中按字符串输出；format=xliff 时以默认语言为源语言下载 XLIFF 文件，1.2 版本每种目标语言为一个 file 元素，2.0 版本只能包含一种目标语言（用 target_language 指定）；format=yaml 时下载 Rails 风格的 YAML 文件，每种语言一个根节点，键名按 . 嵌套；format=po 时以默认语言的翻译为 msgid、键的上下文说明为 msgctxt 下载 gettext PO 文件，只能包含一种目标语言（用 target_language 指定），键名以 .one/.other 等复数类别结尾的一组键以及只包含一个 plural 参数的 ICU 消息导出为复数词条；format=pot 时下载不含译文的 POT 模板；format=android-xml、apple-strings、apple-stringsdict 时下载一种语言（用 target_language 指定，默认为默认语言）的 Android strings.xml、iOS .strings 或 .stringsdict 文件，按各平台的规则转义，复数键和只包含一个 plural 参数的 ICU 消息导出为 plurals 或 stringsdict 条目；format=arb 时下载一种语言（同上）的 Flutter ARB 文件，键的上下文说明和 ICU 消息中的占位符定义写入 @key 元数据块；format=properties 时下载一种语言（同上）的 Java .properties 文件，非 ASCII 字符转义为 \\uXXXX，上下文说明写为 # 注释；format=resx 时下载一种语言（同上）的 .NET .resx 文件（UTF-8），上下文说明写入 comment。download=zip 时不返回 JSON 或单个文件，而是按语言拆分打包为 ZIP 下载（如 en.json、zh-CN.json），与按文件导出接口相同，便于 CI 作为构建产物使用。only_status=approved 时只导出审核状态（review_status）为 approved 的翻译；missing 指定缺失翻译的处理方式：skip 不输出、empty 输出空字符串、source_fallback 使用默认语言的翻译",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/x-xliff+xml",
+                    "application/x-yaml",
+                    "text/x-gettext-translation",
+                    "application/xml",
+                    "text/plain",
+                    "application/x-plist",
+                    "text/x-java-properties",
+                    "application/zip"
                 ],
                 "tags": [
                     "翻译管理"
                 ],
-                "summary": "更新翻译",
+                "summary": "导出翻译",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "翻译ID",
-                        "name": "id",
+                        "description": "项目ID",
+                        "name": "project_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "翻译信息",
-                        "name": "translation",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateTranslationRequest"
-                        }
+                        "enum": [
+                            "json",
+                            "xliff",
+                            "yaml",
+                            "po",
+                            "pot",
+                            "android-xml",
+                            "apple-strings",
+                            "apple-stringsdict",
+                            "arb",
+                            "properties",
+                            "resx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "导出格式",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "approved"
+                        ],
+                        "type": "string",
+                        "description": "只导出该状态的翻译",
+                        "name": "only_status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "empty",
+                            "source_fallback"
+                        ],
+                        "type": "string",
+                        "default": "skip",
+                        "description": "缺失翻译的处理方式",
+                        "name": "missing",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1.2",
+                            "2.0"
+                        ],
+                        "type": "string",
+                        "default": "1.2",
+                        "description": "XLIFF 版本",
+                        "name": "xliff_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "XLIFF 和 PO 只导出该目标语言；移动端格式、ARB、properties 和 resx 导出该语言",
+                        "name": "target_language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只导出该命名空间中的键",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只导出带有其中任一标签的键，逗号分隔的标签名称（如 mobile,beta）",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "导出该发布版本冻结的翻译（版本标签），不能与 only_status、namespace、tags 同时使用",
+                        "name": "release",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "zip"
+                        ],
+                        "type": "string",
+                        "description": "按语言拆分打包为 ZIP 下载",
+                        "name": "download",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Translation"
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/exports/project/{project_id}/files": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "删除指定的翻译",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按语言拆分导出项目翻译，文件路径由项目的导出文件命名模板决定（如 {locale}/{namespace}.json，{locale_underscore} 为 zh_CN 形式的语言代码），打包为 ZIP 下载。format=xliff 时每种目标语言导出一个 .xlf 文件（源语言为默认语言）；format=yaml 时每种语言导出一个 YAML 文件，yaml_style=rails（默认）以语言代码为根节点、扩展名为 .yml，yaml_style=symfony 没有根节点、扩展名为 .yaml；format=po 时每种目标语言导出一个 .po 文件（msgid 为默认语言的翻译）；format=android-xml、apple-strings、apple-stringsdict 时每种语言导出一个 .xml、.strings 或 .stringsdict 文件，可将命名模板设为 values-{locale}/strings.{ext} 或 {locale}.lproj/Localizable.{ext}；format=arb 时每种语言导出一个 .arb 文件，{locale} 为 Flutter 使用的 zh_CN 形式，可将命名模板设为 app_{locale}.{ext}；format=properties 时每种语言导出一个 .properties 文件，{locale} 为 Java 使用的 zh_CN 形式，可将命名模板设为 messages_{locale}.{ext}；format=resx 时每种语言导出一个 .resx 文件，可将命名模板设为 Resources.{locale}.{ext}。only_status、missing、xliff_version 和 target_language 与导出翻译接口相同",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
                     "翻译管理"
                 ],
-                "summary": "删除翻译",
+                "summary": "导出翻译文件",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "翻译ID",
-                        "name": "id",
+                        "description": "项目ID",
+                        "name": "project_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xliff",
+                            "yaml",
+                            "po",
+                            "android-xml",
+                            "apple-strings",
+                            "apple-stringsdict",
+                            "arb",
+                            "properties",
+                            "resx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "导出格式",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "approved"
+                        ],
+                        "type": "string",
+                        "description": "只导出该状态的翻译",
+                        "name": "only_status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "empty",
+                            "source_fallback"
+                        ],
+                        "type": "string",
+                        "default": "skip",
+                        "description": "缺失翻译的处理方式",
+                        "name": "missing",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1.2",
+                            "2.0"
+                        ],
+                        "type": "string",
+                        "default": "1.2",
+                        "description": "XLIFF 版本",
+                        "name": "xliff_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "XLIFF 和 PO 只导出该目标语言；移动端格式、ARB、properties 和 resx 只导出该语言",
+                        "name": "target_language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "rails",
+                            "symfony"
+                        ],
+                        "type": "string",
+                        "default": "rails",
+                        "description": "YAML 文件风格",
+                        "name": "yaml_style",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只导出该命名空间中的键，文件命名模板中的 {namespace} 为该命名空间（未指定时为 messages）",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只导出带有其中任一标签的键，逗号分隔的标签名称（如 mobile,beta）",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "导出该发布版本冻结的翻译（版本标签），不能与 only_status、namespace、tags 同时使用",
+                        "name": "release",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.APIResponse"
                         }
                     }
                 }
             }
         },
-        "/user/info": {
-            "get": {
+        "/graphql": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取已登录用户的详细信息",
+                "description": "在一次请求中获取项目、语言和翻译矩阵，模式见 internal/graph/schema.graphqls，支持内省查询。权限与 REST 接口一致：projects 返回当前用户可访问的项目（管理员为所有项目），project 需要项目查看权限。列表字段的复杂度按每页数量计算，单次查询复杂度超过 5000 时拒绝执行。出错时 HTTP 状态码仍为 200（请求格式错误时为 4xx），errors[].extensions.code 为与 REST 接口一致的错误码",
                 "consumes": [
                     "application/json"
                 ],
@@ -1388,303 +1613,17204 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "GraphQL"
+                ],
+                "summary": "GraphQL 查询",
+                "parameters": [
+                    {
+                        "description": "GraphQL 查询",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GraphQLRequest"
+                        }
+                    }
                 ],
-                "summary": "获取当前用户信息",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.User"
+                            "$ref": "#/definitions/dto.GraphQLResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/project/{project_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "导入项目翻译数据。format=json（默认）时值为数组或对象的键按 json 类型导入，已是 json 类型的键的值按 JSON 值读取，与导出格式对应；format=xliff 时请求体为 XLIFF 1.2 或 2.0 文件（版本自动识别），按文件的目标语言导入译文，备注写入上下文，源文不导入；format=yaml 时请求体为 Rails 风格（以语言代码为根节点）的 YAML 文件，嵌套的键以 . 连接为键名，Symfony 风格（没有语言根节点）的文件需要用 language 指定语言；format=arb 时请求体为 Flutter ARB 文件，按 @@locale（或 language）导入一种语言，@key 元数据块的 description 写入上下文。strategy 指定与已有翻译冲突时的处理方式：skip 跳过项目中已存在的键，overwrite 覆盖已有译文，merge 只写入没有译文或译文为空的语言，fail 在任一语言已有翻译时整个导入失败（409，不写入任何翻译）；未指定时 json 为 fail，其余格式为 overwrite。译文没有变化的翻译不会写入，返回每个键新建、更新还是跳过；format=csv 或 xlsx 时第一列为键名，其余列的表头为语言代码，只写入新增或有变化的译文，返回每一行将新建、更新还是跳过，dry_run=true 时只返回预览不写入。文件也可以通过 multipart/form-data 的 file 字段上传，未指定 format 时按文件扩展名识别",
+                "consumes": [
+                    "application/json",
+                    "application/x-xliff+xml",
+                    "application/x-yaml",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "导入翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译数据，格式为 {\\",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "xliff",
+                            "yaml",
+                            "arb",
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "导入格式，默认按上传文件的扩展名识别，无法识别时为 json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YAML 文件没有语言根节点或 ARB 文件没有 @@locale 时文件内容所属的语言代码",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "overwrite",
+                            "merge",
+                            "fail"
+                        ],
+                        "type": "string",
+                        "description": "与已有翻译冲突时的处理方式（表格导入不支持）",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件中的键（包括跳过的已有键）归入该命名空间（表格导入不支持）",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "表格导入只返回预览，不写入",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "每个键的处理结果；表格导入返回 domain.SpreadsheetUploadResult",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "fail 策略下与已有翻译冲突",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/project/{project_id}/migrate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "直接上传 Lokalise、Crowdin 或 POEditor 的原生导出内容（zip 导出包或 JSON 文件），已存在的翻译会被覆盖",
+                "consumes": [
+                    "application/zip",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "从外部 TMS 迁移",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "来源系统: lokalise, crowdin, poeditor",
+                        "name": "source",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "单个 JSON 文件对应的语言代码",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MigrationImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取邀请码列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "邀请管理"
+                ],
+                "summary": "获取邀请列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvitationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "管理员创建新的邀请码。max_uses 大于 1 时同一个邀请码可以注册多个用户（如团队邀请链接），用完后状态变为 used。指定 email 时向该邮箱发送包含邀请链接的邮件（需要配置 SMTP_HOST），发送失败时邀请码仍然有效，email_status 为 failed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "邀请管理"
+                ],
+                "summary": "创建邀请码",
+                "parameters": [
+                    {
+                        "description": "邀请信息",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
                                 "type": "string"
                             }
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.BatchTranslationRequest": {
+        },
+        "/invitations/batches": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "管理员一次创建多个邀请码，默认以 CSV 返回邀请码和邀请链接（format=json 时返回 JSON）。指定 emails 或 recipients 时为每个邮箱创建一个邀请码并发送邀请邮件（需要配置 SMTP_HOST），recipients 可以为每个收件人指定角色，skip_email 为 true 时只记录邮箱、不发送邮件；邮件发送失败的邀请码仍然有效，email_status 为 failed。同一批次的邀请码带有相同的 batch_label，可通过 DELETE /invitations/batches/{label} 整批撤销。一次最多 200 个",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "邀请管理"
+                ],
+                "summary": "批量创建邀请码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "返回格式：csv（默认）或 json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "description": "批量邀请信息",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateInvitationBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.InvitationBatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/batches/{label}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销批次中所有仍然有效的邀请码，已使用的邀请码不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "邀请管理"
+                ],
+                "summary": "撤销邀请批次",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "批次标签",
+                        "name": "label",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevokeInvitationBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据邀请码获取邀请详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "邀请管理"
+                ],
+                "summary": "获取邀请详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "邀请码",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InvitationResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销指定的邀请码，被撤销的邀请码将无法继续使用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "邀请管理"
+                ],
+                "summary": "撤销邀请码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "邀请码",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{code}/validate": {
+            "get": {
+                "description": "验证邀请码是否有效，返回邀请信息供前端展示",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公开接口"
+                ],
+                "summary": "验证邀请码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "邀请码",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidateInvitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/languages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取所有语言列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "语言管理"
+                ],
+                "summary": "获取语言列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Language"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建新的语言",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "语言管理"
+                ],
+                "summary": "创建语言",
+                "parameters": [
+                    {
+                        "description": "语言信息",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLanguageRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "所属组织ID，不指定时为共享语言",
+                        "name": "org_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Language"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/languages/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新语言信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "语言管理"
+                ],
+                "summary": "更新语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "语言ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "语言信息",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLanguageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Language"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除指定的语言",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "语言管理"
+                ],
+                "summary": "删除语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "语言ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "使用用户名和密码获取访问令牌",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "登录凭证",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回当前用户所在的组织，管理员返回所有组织",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "获取组织列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OrganizationResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建组织（工作空间），创建者成为组织的 owner。组织下的项目、语言和 API Key 与其他组织隔离；\n创建时复制共享语言列表中启用的语言作为组织的语言，之后两者互不影响",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "创建组织",
+                "parameters": [
+                    {
+                        "description": "组织信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取组织信息，需要是组织成员",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "获取组织",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrganizationResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新组织名称和描述，需要是组织的 owner 或 admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "更新组织",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "组织信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OrganizationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除组织及其成员、语言和 API Key；组织下还有项目时不能删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "删除组织",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回组织的 API Key，不包含 API Key 本身。需要是组织的 owner 或 admin，不能使用个人访问令牌调用此接口",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "获取组织的 API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.APIKeyResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建只能访问组织项目的 API Key；project_ids 为空时可访问组织的所有项目，指定时项目需要属于该组织。\nAPI Key 只在创建时返回一次。需要是组织的 owner 或 admin，不能使用个人访问令牌调用此接口",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "创建组织的 API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API Key 信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销组织的 API Key，撤销后立即失效；其他组织的 API Key 视为不存在。需要是组织的 owner 或 admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "撤销组织的 API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "API Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/languages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取组织的语言列表，组织的项目只能使用这些语言。需要是组织成员",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "获取组织的语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Language"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在组织的语言列表中添加语言，语言代码在组织内唯一。需要是组织的 owner 或 admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "创建组织的语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "语言信息",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLanguageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Language"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/languages/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新组织的语言，其他组织的语言视为不存在。需要是组织的 owner 或 admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "更新组织的语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "语言ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "语言信息",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateLanguageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Language"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除组织的语言，不能删除默认语言。需要是组织的 owner 或 admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "删除组织的语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "语言ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取组织成员及其组织角色，需要是组织成员",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "获取组织成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OrganizationMemberResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按用户ID或邮箱添加组织成员，角色为 owner、admin 或 member（默认）。只有组织成员可以被添加为组织项目的成员，\n组织的 owner 和 admin 拥有组织下所有项目的 owner 权限。需要是组织的 owner 或 admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "添加组织成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新组织成员的角色，组织至少保留一个 owner。需要是组织的 owner 或 admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "更新组织成员角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "角色",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOrganizationMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "移除组织成员，同时移除该用户在组织下所有项目的成员关系；组织至少保留一个 owner。需要是组织的 owner 或 admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "移除组织成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{org_id}/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取组织下的所有项目，需要是组织的 owner 或 admin。在组织中创建项目使用 POST /projects 并指定 organization_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "组织"
+                ],
+                "summary": "获取组织的项目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "org_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Project"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password-reset": {
+            "post": {
+                "description": "向邮箱对应的用户发送重置密码链接（需要配置 SMTP_HOST），链接在 PASSWORD_RESET_TTL 分钟内有效且只能使用一次。邮箱未注册或用户已禁用时同样返回成功",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "申请重置密码",
+                "parameters": [
+                    {
+                        "description": "注册邮箱",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password-reset/confirm": {
+            "post": {
+                "description": "使用重置密码邮件链接中的 token 设置新密码，token 使用后立即失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "设置新密码",
+                "parameters": [
+                    {
+                        "description": "令牌和新密码",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordResetConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目列表，支持分页和关键词搜索。sort 指定排序（如 updated_at:desc，可排序字段：id、name、status、created_at、updated_at），fields 只返回指定的字段（如 id,name,slug）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取项目列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "搜索关键词",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序，字段:asc|desc，多个用逗号分隔",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "返回的字段，逗号分隔",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建新的翻译项目；可指定自定义标识，auto_suffix 为 true 时标识冲突自动追加 -2、-3 等后缀。\n指定 organization_id 时在组织中创建项目，需要是系统管理员或组织的 owner、admin。\nsource_language 为项目的源语言，源语言译文修改后其他语言的译文标记为 outdated；为空时使用默认语言",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "创建项目",
+                "parameters": [
+                    {
+                        "description": "项目信息",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/accessible": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据用户权限返回可访问的项目列表，管理员返回所有项目，普通用户返回参与的项目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取用户可访问的项目列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "搜索关键词",
+                        "name": "keyword",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/delete/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除指定的项目，开启删除保护的项目需要先关闭保护",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "删除项目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/delivery/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "开启后应用可以不经认证通过 GET /delivery/{project_slug}/{locale}.json 获取项目最新发布版本的语言包，只分发已发布的版本；每次变更记录审计日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "开启或关闭项目公开分发",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "是否开启公开分发",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetProjectDeliveryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/detail/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据项目ID获取项目详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取项目详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/protection/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "开启删除保护后不能删除项目，也不能批量删除项目的翻译（按 ID 批量删除、按条件批量删除、按前缀删除键，以及删除翻译的推送），直到项目所有者关闭保护；每次变更记录审计日志",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "开启或关闭项目删除保护",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "是否开启删除保护",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetProjectProtectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/slug/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改项目标识（slug），旧标识保留为别名，CLI 等按旧标识访问时仍指向该项目；新标识与当前标识相同时不做修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "修改项目标识",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新项目标识",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RenameProjectSlugRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/update/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新项目信息；source_language 为空字符串时改为使用默认语言，修改源语言不影响已有译文的审核状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "更新项目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "项目信息",
+                        "name": "project",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Project"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/assignments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中分配给成员的翻译和审核工作，key_name 为空的分配包含该语言的所有键",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务分配"
+                ],
+                "summary": "获取任务分配",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AssignmentResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将一种语言（key_names 为空时）或其中的键的翻译或审核工作分配给项目的编辑者或所有者，最多 1000 个键。同一语言、键和工作类型只分配给一个人，已分配给其他人的改为分配给该成员；分配到具体键的优先于分配整个语言的。返回项目的全部分配",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务分配"
+                ],
+                "summary": "分配任务",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分配内容",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AssignRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AssignmentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/assignments/{assignment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除任务分配，已有的翻译不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务分配"
+                ],
+                "summary": "取消任务分配",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "任务分配ID",
+                        "name": "assignment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中翻译键的附件（如界面截图），可按键名筛选",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "附件"
+                ],
+                "summary": "获取附件列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名",
+                        "name": "key_name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AttachmentResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "请求体为 PNG、JPEG、GIF 或 WebP 图片（最大 5 MB），作为键的视觉上下文，同一键的所有语言共用，每个键最多 20 个附件。附件按键名关联，键重命名后不随之变化",
+                "consumes": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "附件"
+                ],
+                "summary": "上传附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名",
+                        "name": "key_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名，为空时按格式生成",
+                        "name": "file_name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/attachments/{attachment_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "附件"
+                ],
+                "summary": "删除附件",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "附件ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/attachments/{attachment_id}/content": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "附件"
+                ],
+                "summary": "获取附件内容",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "附件ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/audit-logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取项目的批量操作审计记录，最新的在前。提供 cursor 或 limit 时使用游标分页，meta.next_cursor 为下一页的游标，没有下一页时不返回，此时忽略 page 和 page_size",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "审计日志"
+                ],
+                "summary": "获取项目审计日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标分页：上一页返回的 meta.next_cursor，为空时获取第一页",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "游标分页：每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuditLogListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/auto-fill-language": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "使用机器翻译自动填充项目的某个语言的所有缺失翻译",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "自动填充语言",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "填充请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AutoFillLanguageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AutoFillLanguageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/branches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目的翻译分支，按名称排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "获取分支",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BranchResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为发布分支等场景创建翻译分支。分支采用写时复制：创建时不复制翻译，分支的翻译为主线的当前翻译叠加分支中修改过的翻译。名称不能为 main（主线）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "创建分支",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "分支",
+                        "name": "branch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BranchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BranchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/branches/{branch_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除分支及其中修改过的翻译，主线的翻译不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "删除分支",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "分支ID",
+                        "name": "branch_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/branches/{branch_id}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "计算将分支合并到目标分支时的差异（按键名和语言代码排序）。目标为 main（默认）时与主线比较，并标记冲突：分支修改某条翻译后主线也修改了它，base_value 为分支修改前主线的值",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "比较分支",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "分支ID",
+                        "name": "branch_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "main",
+                        "description": "目标分支名称",
+                        "name": "target",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BranchDiff"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/branches/{branch_id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将分支与主线的差异写入主线，分支标记为已合并。冲突必须在 resolutions 中逐条指定处理方式：branch 使用分支的值，main 保留主线的值，custom 使用 value；有未处理的冲突时返回 409 且不做任何修改，错误详情列出冲突的翻译（最多 20 条）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "合并分支",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "分支ID",
+                        "name": "branch_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "冲突处理方式",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BranchMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BranchMergeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/branches/{branch_id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回分支的全部翻译（主线的当前翻译叠加分支中修改过的翻译），格式为 键名 -\u003e 语言代码 -\u003e 翻译值",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "获取分支的翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "分支ID",
+                        "name": "branch_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在分支中修改或删除翻译（每次最多 1000 条），主线不受影响。首次修改某条翻译时记录主线当时的值，合并时据此判断冲突。已合并的分支不能修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "分支"
+                ],
+                "summary": "修改分支中的翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "分支ID",
+                        "name": "branch_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译修改",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetBranchTranslationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/changes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "汇总时间范围内项目中每条翻译的净变化（added、modified、removed、renamed），比较范围开始和结束时的值并给出词级差异，用于编写发布说明。范围内创建后又删除、或修改后又改回原值的翻译不列出。from 和 to 可以是日期（to 包含当天）或 RFC3339 时间；变化超过 5000 条时只返回按键名排序的前 5000 条，truncated 为 true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取翻译变化报告",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "开始时间",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-31",
+                        "description": "结束时间",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TranslationChangeReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/config": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "导出项目的设置、启用的语言、入站 Webhook（不含签名密钥）和成员角色，不包含翻译内容。导出的文档可以纳入代码审查，再导入到其他项目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目配置"
+                ],
+                "summary": "导出项目配置",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProjectConfigDocument"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/config/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将配置文档应用到项目：设置中的非空字段覆盖当前值；Webhook 按名称、成员按用户名匹配，只新增或更新，不删除文档中没有的项。新建的 Webhook 会生成新的签名密钥。语言在实例内全局管理，未启用的语言只在结果中列出。dry_run=true 时只返回将要执行的变更",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目配置"
+                ],
+                "summary": "导入项目配置",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只预览变更",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "项目配置文档",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProjectConfigDocument"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProjectConfigImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/consistency": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "默认语言中文本相同（忽略首尾空白）的多个键视为同一术语，统计每个启用语言中这些键的译文是否相同。每个术语以使用最多的译文为建议译文，与之相同的译文计为一致，得分 = 一致的译文数 / 参与比较的译文数；未翻译的键不参与比较，没有可比较的术语时得分为 100",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取术语一致性得分",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ConsistencyReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/consistency/{language}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "列出该语言中译文不一致的术语，包括默认语言的文本、建议译文（使用最多的译文，次数相同时按译文排序取第一个）以及每种译文对应的键。最多列出 500 个术语",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取译文不一致的术语",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "语言代码",
+                        "name": "language",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ConsistencyDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/duplicate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将项目的设置（描述、源语言、导出文件命名模板、所属组织）、命名空间、发布门槛和翻译复制到新项目，include_members 为 true 时同时复制成员及其角色，发起人成为新项目的 owner。\n翻译不超过 PROJECT_DUPLICATE_SYNC_LIMIT 条时在请求中复制完成并返回 201，任务状态为 completed；否则返回 202，翻译在后台按批复制，通过任务查询进度",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "复制项目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新项目信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectDuplicateJob"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectDuplicateJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/duplicate/{job_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "查询从项目发起的复制任务的状态和进度（已复制的翻译条数）。任务失败时已复制的翻译保留在新项目中",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取项目复制任务",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectDuplicateJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "以 Server-Sent Events 推送项目的翻译变更，多人同时编辑时编辑器可以实时看到其他人的修改，不需要轮询翻译矩阵。\n每条消息的 event 为消息类型，data 为 JSON：连接建立后先发送 ready，之后发送 changed（包含变更的键、语言和操作人 user_id）和定期的 ping；\n收到 resync 或连接断开时变更可能丢失，编辑器应重新加载翻译矩阵后重新连接。",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "订阅项目的翻译变更（SSE）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "只接收这些语言的变更，逗号分隔，如 en,zh-CN",
+                        "name": "locales",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.WatchEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/figma/frames": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "列出项目中同步过的画框及其文本图层，每个图层对应一个翻译键。key 不为空时只返回引用了该键的画框，file_key 不为空时只返回该 Figma 文件中的画框",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Figma 插件"
+                ],
+                "summary": "查询设计稿引用",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "翻译键名",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Figma 文件标识",
+                        "name": "file_key",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.FigmaFrame"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "供 Figma 插件使用：按文件标识和节点ID创建或更新画框，并用请求中的文本图层替换画框的全部图层。不存在的键以图层文本作为指定语言（默认为默认语言）的翻译创建；已存在的键不修改翻译，图层文本与当前翻译不同的键在 changed_keys 中列出。截图通过上传截图接口单独上传",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Figma 插件"
+                ],
+                "summary": "同步设计稿画框",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "画框和文本图层",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FigmaFrameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FigmaSyncResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/figma/frames/{frame_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除画框、截图和图层引用，已创建的翻译键保留",
+                "tags": [
+                    "Figma 插件"
+                ],
+                "summary": "删除设计稿画框",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "画框ID",
+                        "name": "frame_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/figma/frames/{frame_id}/screenshot": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "image/png",
+                    "image/jpeg"
+                ],
+                "tags": [
+                    "Figma 插件"
+                ],
+                "summary": "获取画框截图",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "画框ID",
+                        "name": "frame_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "请求体为 PNG 或 JPEG 图片（最大 5 MB），替换画框原有的截图。截图在翻译时作为视觉上下文显示",
+                "consumes": [
+                    "image/png",
+                    "image/jpeg"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Figma 插件"
+                ],
+                "summary": "上传画框截图",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "画框ID",
+                        "name": "frame_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FigmaFrame"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/figma/values": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "供 Figma 插件在设计稿中预览各语言的翻译，返回 {\"key\": {\"en\": \"value\"}}，不存在的键不返回",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Figma 插件"
+                ],
+                "summary": "获取键的当前翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名，多个用逗号分隔，最多 500 个",
+                        "name": "keys",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/glossary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中的所有术语及各语言的规定译文和禁用译文，按术语排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "术语表"
+                ],
+                "summary": "获取术语表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GlossaryTermResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在项目术语表中添加术语（以默认语言书写），并设置各语言的规定译文和禁用译文。QA 报告按术语表检查翻译：原文包含术语而译文没有使用规定译文时报告 glossary_mismatch，译文包含禁用译文时报告 forbidden_term。同一项目中的术语不区分大小写不能重复",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "术语表"
+                ],
+                "summary": "创建术语",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "术语",
+                        "name": "term",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GlossaryTermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GlossaryTermResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/glossary/{term_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取术语及各语言的规定译文和禁用译文",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "术语表"
+                ],
+                "summary": "获取术语",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "术语ID",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GlossaryTermResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "未提供的字段保持不变；提供 translations 时替换全部语言的译文",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "术语表"
+                ],
+                "summary": "更新术语",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "术语ID",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "术语",
+                        "name": "term",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GlossaryTermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GlossaryTermResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除术语及其各语言的译文，翻译不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "术语表"
+                ],
+                "summary": "删除术语",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "术语ID",
+                        "name": "term_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/history/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按时间范围流式导出项目的翻译变更历史（source=translations）或审计日志（source=audit），格式为 CSV 或 JSONL。from 和 to 可以是日期（to 包含当天）或 RFC3339 时间。导出操作本身会记录到审计日志",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "审计日志"
+                ],
+                "summary": "导出历史记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "开始时间",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-31",
+                        "description": "结束时间",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "translations",
+                            "audit"
+                        ],
+                        "type": "string",
+                        "default": "translations",
+                        "description": "导出来源",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "jsonl"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "导出格式",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/import-profiles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目下保存的所有 CSV/XLSX 列映射配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表格导入"
+                ],
+                "summary": "获取导入映射配置列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ImportProfileResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "保存 CSV/XLSX 的列映射：按表头将列映射为键名、上下文和语言，之后的导入可直接复用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表格导入"
+                ],
+                "summary": "创建导入映射配置",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "映射配置",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/import-profiles/suggest": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "读取上传的 CSV/XLSX 文件的表头，按列名推测键名列（key、id、键名等）、上下文列（context、description、备注等）和语言列（语言代码、语言名称或括号中的语言代码），结果可直接用于创建映射配置。XLSX 只读取第一个工作表，CSV 需使用 UTF-8 编码，分隔符自动识别",
+                "consumes": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表格导入"
+                ],
+                "summary": "推测列映射",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "文件格式，默认按文件内容识别",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "表头所在行",
+                        "name": "header_row",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ImportMappingSuggestion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/import-profiles/{profile_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "未提供的字段保持不变；提供 language_columns 时替换全部语言列",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表格导入"
+                ],
+                "summary": "更新导入映射配置",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "映射配置ID",
+                        "name": "profile_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "映射配置",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除映射配置，已导入的翻译不受影响",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表格导入"
+                ],
+                "summary": "删除导入映射配置",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "映射配置ID",
+                        "name": "profile_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/import-profiles/{profile_id}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按映射配置导入 CSV/XLSX 文件。表头按列名匹配（忽略大小写），配置中的列缺失时不导入并返回缺失的列；已存在的翻译会被覆盖，空单元格和键名为空的行跳过",
+                "consumes": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "表格导入"
+                ],
+                "summary": "按映射配置导入表格",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "映射配置ID",
+                        "name": "profile_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "文件格式，默认按文件内容识别",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SpreadsheetImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/inbound-webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目下配置的所有入站 Webhook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "获取入站 Webhook 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.InboundWebhookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为项目创建外部 TMS 推送入口，响应中包含仅返回一次的签名密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "创建入站 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook 配置",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/inbound-webhooks/{webhook_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新语言映射、冲突策略或启用状态，未提供的字段保持不变",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "更新入站 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook 配置",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除入站 Webhook，之后的推送将被拒绝",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "删除入站 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/inbound-webhooks/{webhook_id}/logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取入站 Webhook 的导入记录，包括被拒绝的请求",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "获取入站导入日志",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookLogListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/inbound-webhooks/{webhook_id}/rotate-secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "生成新的签名密钥并立即使旧密钥失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "重置签名密钥",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/key-prefix/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除项目中以指定前缀开头的所有键的翻译；preview 为 true 时只返回受影响的键。项目开启删除保护时只能预览",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "按键名前缀批量删除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "前缀",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/key-prefix/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "导出项目中以指定前缀开头的所有有效翻译；preview 为 true 时只返回匹配的键数量",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "按键名前缀导出",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名前缀",
+                        "name": "prefix",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只预览",
+                        "name": "preview",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/key-prefix/rename": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将以 prefix 开头的键改为以 new_prefix 开头，如 checkout.* → payment.*；重命名后与已有键冲突时返回 409，预览结果中列出冲突的键",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "按键名前缀批量重命名",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "原前缀和新前缀",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixRenameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/key-prefix/status": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将项目中以指定前缀开头的所有键的翻译标记为 active 或 deprecated；preview 为 true 时只返回受影响的键",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "按键名前缀批量修改状态",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "前缀和状态",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyPrefixResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/copy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将源项目中的键连同所有语言的翻译复制到项目，需要能查看源项目。任一键在源项目中不存在时不复制并返回 404；项目中已有的键默认跳过，overwrite 为 true 时用源项目的翻译覆盖。\nlink 为 true 时链接复制的键：之后源项目中这些键的翻译新增或修改时自动写入项目（删除不同步），适合共享的组件库文案",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "从其他项目复制键",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "源项目和键名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KeyCopyResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/links": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目作为源项目（键被其他项目链接）或目标项目（键从其他项目链接而来）的键链接，按键名排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "获取键链接",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.KeyLink"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "取消项目中从其他项目链接而来的键，之后不再同步；已复制的翻译保留",
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "取消键链接",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名",
+                        "name": "key_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/locks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中有效的编辑锁，按键名排序，编辑器据此在单元格上显示谁正在编辑",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取正在编辑的键",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.KeyLock"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "编辑期间定期续期编辑锁（建议每 20 秒一次）。锁已过期或已被其他用户获取时返回 409 KEY_LOCK_NOT_HELD，\n期间译文可能已被修改，编辑器应重新获取锁并加载最新的译文",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "续期键的编辑锁",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名",
+                        "name": "key_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KeyLock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "开始编辑键时获取编辑锁，有效期 60 秒，编辑期间通过续期接口保持；已持有时续期。\n其他用户正在编辑时返回 409 KEY_LOCKED，data 为其持有的锁。编辑锁只用于提示，不阻止写入，并发修改由更新翻译时的 version 检测",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取键的编辑锁",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名",
+                        "name": "key_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KeyLock"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.KeyLock"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "结束编辑时释放编辑锁；没有持有该键的锁时同样返回 204",
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "释放键的编辑锁",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "键名",
+                        "name": "key_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/metadata": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "设置键在所有语言的最大长度（max_length，按字符计算，0 表示不限制）、标签（tags，最多 10 个，每个最多 40 个字符，不能包含逗号）和使用平台（platform：web、ios、android、desktop，为空时不限）。未提供的字段清除。已有的非空译文（json 类型除外）超过最大长度时返回 400，不做修改；之后写入该键的译文超过最大长度时同样返回 400 VALUE_TOO_LONG",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "修改键的元数据",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "键名和元数据",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetKeyMetadataRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SetKeyMetadataResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/namespace": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将键在所有语言中的翻译归入命名空间，namespace 为空时移出命名空间。任一键在项目中不存在时不做修改，错误详情列出这些键",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "命名空间"
+                ],
+                "summary": "设置键的命名空间",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "键名和命名空间",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AssignNamespaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AssignNamespaceResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/rename": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在一个事务中将键在所有语言中的翻译改为新键名，同时修改未合并分支中的翻译，以及引用该键的附件、任务分配和设计稿图层。每条翻译记录一条 rename 变更历史，并清除翻译缓存。新键名已存在时返回 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "键名前缀"
+                ],
+                "summary": "重命名键",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "原键名和新键名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.KeyRenameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.KeyRenameResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/keys/value-type": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将键在所有语言的值类型改为 string、json、markdown 或 html。json 类型的值必须是合法的 JSON，指定 value_schema 时还需要符合该 JSON Schema（支持 type、enum、const、properties、required、additionalProperties、items、minItems、maxItems、minLength、maxLength、minimum、maximum）；已有的值不符合时返回 400，不做修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "修改键的值类型",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "键名和值类型",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetValueTypeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SetValueTypeResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取指定项目的所有成员信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "获取项目成员列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ProjectMemberInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将用户添加到项目中并分配角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "添加项目成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员信息",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddProjectMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/members/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按用户ID或邮箱一次添加最多 500 个成员，成员未指定角色时使用请求中的 role。单个成员失败（用户不存在、已禁用、角色无效或重复）不影响其他成员，已是项目成员的用户跳过且角色不变。notify 为 true 时向添加的成员发送通知邮件，未配置邮件服务时返回 400",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "批量添加项目成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "成员列表",
+                        "name": "members",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkAddProjectMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BulkAddMembersResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/members/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "上传 CSV/XLSX 文件批量添加成员，第一行为表头（忽略大小写）：user 列填写用户ID或邮箱，也可以分别使用 user_id 和 email 列；role 列为空或没有 role 列时使用 default_role。结果中的 index 为表格中的行号，其余规则与批量添加相同",
+                "consumes": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "从表格导入项目成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "文件格式，默认按文件内容识别",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "owner",
+                            "editor",
+                            "viewer"
+                        ],
+                        "type": "string",
+                        "description": "role 列为空时使用的角色",
+                        "name": "default_role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "向添加的成员发送通知邮件",
+                        "name": "notify",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.BulkAddMembersResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新项目成员的角色权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "更新项目成员角色",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "角色信息",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateProjectMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "从项目中移除指定成员",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "移除项目成员",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/members/{user_id}/permission": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "检查用户在指定项目中是否具有所需权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "检查用户项目权限",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "viewer",
+                            "editor",
+                            "owner"
+                        ],
+                        "type": "string",
+                        "description": "所需角色",
+                        "name": "required_role",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/namespaces": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中的命名空间及每个命名空间中的键数量，按名称排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "命名空间"
+                ],
+                "summary": "获取命名空间",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.NamespaceResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在项目中创建命名空间（如 checkout、settings），用于按功能模块划分键。名称只能包含字母、数字、点、下划线和连字符，同一项目中不能重复。矩阵、导出和导入可以按命名空间筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "命名空间"
+                ],
+                "summary": "创建命名空间",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NamespaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NamespaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/namespaces/{namespace_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改命名空间的名称或说明，未提供的字段保持不变，键的归属不变",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "命名空间"
+                ],
+                "summary": "更新命名空间",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "命名空间ID",
+                        "name": "namespace_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "命名空间",
+                        "name": "namespace",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NamespaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NamespaceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除命名空间，其中的键不会被删除，回到未划分命名空间的状态",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "命名空间"
+                ],
+                "summary": "删除命名空间",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "命名空间ID",
+                        "name": "namespace_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/promotions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取项目的推送记录，不包含差异内容",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "环境推送"
+                ],
+                "summary": "获取环境推送记录列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromotionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "从源环境（远程 YFlow 实例或上传的快照）获取翻译，计算与本项目的差异并保存为待审核的推送记录。远程实例通过其 CLI 接口拉取，api_key 不会保存",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "环境推送"
+                ],
+                "summary": "计算环境差异",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "源环境",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromotionDiffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/promotions/{promotion_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回全部差异和已应用的差异；已应用的记录同时校验签名，signature_valid 为 false 表示记录被篡改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "环境推送"
+                ],
+                "summary": "获取环境推送记录详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "推送记录ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/promotions/{promotion_id}/apply": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将审核通过的差异写入本项目并生成签名的推送记录。计算差异后相关翻译被修改过时返回 409，需要重新计算差异；包含删除的差异应用到开启删除保护的项目时返回 403",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "环境推送"
+                ],
+                "summary": "应用环境差异",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "推送记录ID",
+                        "name": "promotion_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审核通过的差异",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromotionApplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/qa-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "检查值类型为 string、markdown 或 html 的键的有效翻译（json 类型不检查）：markup_invalid 表示标记无法正确解析（标签未关闭或没有开始标签、代码块或行内代码未闭合、链接语法不完整，string 类型只检查 HTML 标签）；disallowed_markup 表示 markdown 或 html 包含不允许的标签（如 script、iframe）、属性（如 onclick）或链接协议（如 javascript:），suggestion 为移除这些内容后的译文；link_mismatch 表示链接与默认语言的译文不一致；placeholder_mismatch 表示占位符（{{name}}、printf 格式如 %s 和 %1$d、ICU 参数如 {0} 和 {name}）与默认语言的译文不一致；tag_mismatch 表示 string 类型文本中的 HTML 标签与默认语言的译文不一致；trailing_whitespace 表示末尾空白与默认语言的译文不一致，suggestion 为改正后的译文；length_exceeded 表示译文字符数超过原文的 max_length_ratio 倍（原文较短时至少允许多 10 个字符）；string 类型包含 plural、select 等 ICU 参数时，icu_invalid 表示 ICU 语法错误，plural_category 表示复数选项缺少该语言的 CLDR 复数类别或包含该语言没有的类别，select_mismatch 表示 select 选项与默认语言的译文不一致。项目有术语表时还检查：glossary_mismatch 表示原文包含术语而译文没有使用规定译文，forbidden_term 表示译文包含禁用译文，有规定译文时 suggestion 为替换后的译文。只报告问题，不修改翻译。问题最多列出 1000 条，counts 为全部数量",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取 QA 报告",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "只检查该语言代码的翻译",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "markup_invalid",
+                            "disallowed_markup",
+                            "link_mismatch",
+                            "placeholder_mismatch",
+                            "glossary_mismatch",
+                            "forbidden_term",
+                            "tag_mismatch",
+                            "trailing_whitespace",
+                            "length_exceeded",
+                            "icu_invalid",
+                            "plural_category",
+                            "select_mismatch"
+                        ],
+                        "type": "string",
+                        "description": "只列出该类型的问题",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 2,
+                        "description": "译文长度最多为原文的倍数（1 到 10）",
+                        "name": "max_length_ratio",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.QAReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/release-readiness": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按发布门槛检查各启用语言的完成率和审核通过率。未达标的语言列出缺少翻译的键，审核通过率未达标时还列出未审核通过的键（每类最多 500 个）；没有设置门槛的语言只统计，不会阻止发布",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布门槛"
+                ],
+                "summary": "检查发布门槛",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReleaseReadiness"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/release-thresholds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中各语言的最低完成率和最低审核通过率",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布门槛"
+                ],
+                "summary": "获取发布门槛",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReleaseThresholdResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "替换项目的全部发布门槛，传入空列表表示不设门槛。完成率为有翻译的键占项目有效键的百分比，审核通过率为审核通过的键占项目有效键的百分比，低于门槛的语言会阻止发布",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布门槛"
+                ],
+                "summary": "设置发布门槛",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "发布门槛",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetReleaseThresholdsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ReleaseThresholdResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/releases": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取项目的发布版本，最新的在前",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布版本"
+                ],
+                "summary": "获取发布版本列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将项目当前的翻译（有效语言的有效翻译及键的值类型）冻结为不可修改的快照。之后 CLI 拉取和导出接口可以通过 release 参数指定版本标签获取该快照，用于可重现的构建。同一项目中的版本标签不能重复。发布前检查发布门槛，有语言未达标时返回 409，data 为门槛检查结果，列出未达标的语言和键；项目所有者可以设置 override 覆盖门槛继续发布。rollout_percentage 大于 0 时灰度发布：公开分发时只有该百分比的客户端获取新版本；项目有进行中的灰度发布时返回 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布版本"
+                ],
+                "summary": "发布版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "版本标签、说明和是否覆盖发布门槛",
+                        "name": "release",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ReleaseReadiness"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/releases/{release_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取发布版本的信息，翻译内容通过导出接口或 CLI 按版本标签获取",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布版本"
+                ],
+                "summary": "获取发布版本",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "发布版本ID",
+                        "name": "release_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/releases/{release_id}/rollout": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "提高灰度发布的分发流量百分比，百分比只能提高，已获取灰度版本的客户端保持不变；设为 100 时全量发布，之后所有请求获取该版本",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布版本"
+                ],
+                "summary": "推进灰度发布",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "发布版本ID",
+                        "name": "release_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新的灰度百分比",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseRolloutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "中止进行中的灰度发布，之后公开分发不再返回该版本，所有请求获取最新的全量版本。版本快照保留，CLI 和导出仍可按版本标签获取",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "发布版本"
+                ],
+                "summary": "中止灰度发布",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "发布版本ID",
+                        "name": "release_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/review/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将待审核（in_review）的翻译标记为审核通过（approved），记录审核人和审核时间。之后修改译文时审核状态回到 draft",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译审核"
+                ],
+                "summary": "审核通过翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译ID列表",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReviewTransitionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/review/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将待审核（in_review）或已通过（approved）的翻译驳回为草稿（draft），comment 为驳回原因（最多 500 个字符）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译审核"
+                ],
+                "summary": "驳回翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译ID列表和驳回原因",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReviewTransitionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/review/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将草稿（draft）状态的翻译提交审核（in_review），译文不能为空。任一翻译不属于该项目时返回 404，状态不允许时返回 400 INVALID_REVIEW_TRANSITION，均不做修改",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译审核"
+                ],
+                "summary": "提交翻译审核",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译ID列表",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewTransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReviewTransitionResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将项目中此后有变更的翻译恢复为指定时刻的值，并记录操作类型为 rollback 的变更历史。之后才创建的翻译不会删除，当时已删除或当前已删除的翻译不会恢复，这些翻译计入 skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "回滚项目翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "回滚时刻",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProjectRollbackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectRollbackResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "统计项目中每个启用语言已翻译（译文不为空且未过期）、过期（源语言修改后等待重新翻译）和未翻译的键数量，三者之和为项目有效的键数量；percentage 为已翻译占键数量的百分比，项目没有键时为 100。已废弃的翻译不计入",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取翻译完成情况",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProjectStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目中的标签及带有每个标签的键数量，按名称排序",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签"
+                ],
+                "summary": "获取标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TagResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在项目中创建标签（如 mobile、beta），用于按用途标记键。名称不能包含逗号，最多 40 个字符，同一项目中不能重复；颜色格式为 #RRGGBB。矩阵、搜索和导出可以按标签筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签"
+                ],
+                "summary": "创建标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/tags/{tag_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改标签的名称、颜色或说明，未提供的字段保持不变；改名后键的 tags 字段同步为新名称",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签"
+                ],
+                "summary": "更新标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "标签",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除标签并移除键上的该标签，键和翻译不会被删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签"
+                ],
+                "summary": "删除标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/tags/{tag_id}/keys": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为键添加标签，已带有该标签的键不变。任一键在项目中不存在或会超过 10 个标签时不做修改，错误详情列出这些键",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签"
+                ],
+                "summary": "为键添加标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "键名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagKeysRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TagKeysResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "移除键上的标签，没有该标签的键不变",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签"
+                ],
+                "summary": "移除键上的标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "键名",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TagKeysRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TagKeysResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/translations/bulk-delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "校验确认令牌后在服务端分批删除符合条件的翻译，每批在独立的事务中执行，并写入审计日志。筛选条件需要与预览时一致；匹配的翻译在预览后发生变化时返回 409，需要重新预览；项目开启删除保护时返回 403",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "批量删除"
+                ],
+                "summary": "按条件批量删除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "筛选条件和确认令牌",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/translations/bulk-delete/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "统计符合条件（键名前缀、语言、状态、最后修改时间，至少一个）的翻译，返回部分匹配的键和确认令牌。令牌 10 分钟内有效，只能由同一用户使用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "批量删除"
+                ],
+                "summary": "预览按条件批量删除",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "筛选条件",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkDeleteFilterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BulkDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/translations/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取项目中已删除的翻译，最近删除的在前。删除的翻译保留 TRASH_RETENTION_DAYS 天后永久删除，purge_at 为永久删除的时间",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取回收站",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TranslationTrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "统计最近若干天（按 UTC 日期，含今天）CLI 拉取翻译和导出接口的成功请求数，按语言、调用方和渠道汇总并给出每日趋势。语言为请求中指定的语言代码，能对应到已启用语言的按语言代码合并，空字符串表示拉取全部语言；调用方为 token:\u003c令牌ID\u003e、api_key:\u003cAPI Key 哈希前缀\u003e 或 user:\u003c用户ID\u003e，请求带 X-YFlow-Client 头时追加 /\u003c客户端名称\u003e。unused_locales 列出统计期间从未被单独拉取的启用语言，便于清理不再使用的语言",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "获取翻译拉取用量",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "统计天数，1 到 365",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UsageReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/webhook-templates/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "用示例事件渲染消息模板，返回将要发送的消息体。模板使用 Go text/template 语法，可用数据：.EventID、.EventType、.OccurredAt、.Project（.ID、.Name、.Slug）、.Summary（事件的简短描述）、.Payload（事件内容）；可用函数：json、upper、lower、trim、join、truncate、default、formatTime。渲染结果必须是合法的 JSON，字符串值请使用 json 函数输出。未指定模板时依次使用内置模板 preset（generic、slack、teams）和 webhook_id 对应 Webhook 保存的模板，都未指定时使用 generic 模板",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "预览 Webhook 消息模板",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "模板和示例事件",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookTemplatePreviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookTemplatePreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目下配置的所有出站 Webhook",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取 Webhook 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "订阅的事件（translation.created、translation.updated、translation.deleted、import.completed、release.published）发生时向 URL 推送签名的 JSON 消息，消息体按 template（Go 模板）或 preset（generic、slack、teams）渲染，都未指定时使用 generic 模板。未指定事件时订阅全部事件，响应中包含仅返回一次的签名密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "创建 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook 配置",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/webhooks/{webhook_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取出站 Webhook 的配置，不包含签名密钥",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新名称、地址、订阅的事件、消息模板或启用状态，未提供的字段保持不变，template 为空字符串时恢复为 generic 模板。停用后不再推送，尚未完成的重试也会停止",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "更新 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook 配置",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除出站 Webhook 及其推送记录",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "删除 Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取推送记录，最新的在前，包括推送的请求体、尝试次数、最后一次响应状态码和错误。推送成功的记录保留 7 天",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取 Webhook 推送记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects/{project_id}/webhooks/{webhook_id}/rotate-secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "生成新的签名密钥，之后的推送（包括尚未完成的重试）都使用新密钥签名",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "重置 Webhook 签名密钥",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "使用刷新令牌获取新的访问令牌",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "刷新访问令牌",
+                "parameters": [
+                    {
+                        "description": "刷新令牌",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "通过邀请码创建新用户账户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "公开接口"
+                ],
+                "summary": "使用邀请码注册",
+                "parameters": [
+                    {
+                        "description": "注册信息",
+                        "name": "registration",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterWithInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在当前用户可访问的项目（管理员为所有项目）中按键名和译文搜索翻译。exact 模式查找包含查询文本的键名或译文（区分大小写）；regex 模式按正则表达式匹配（忽略大小写）；fuzzy 模式查找包含查询文本中任一单词的键名或译文（忽略大小写和重音），适合查找相近的文案。结果按相关度（0-1，键名和译文中较高的一个，matched_field 为对应的字段）和更新时间排序；匹配的翻译超过 1000 条时只对其中 1000 条（逐行匹配时为最近更新的，使用全文索引时 fuzzy 模式为全文相关度最高的）排序，truncated 为 true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "搜索翻译",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "查询文本，regex 模式下为正则表达式，最长 200 个字符",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "exact",
+                            "regex",
+                            "fuzzy"
+                        ],
+                        "type": "string",
+                        "default": "fuzzy",
+                        "description": "搜索模式",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "只搜索该项目",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "语言代码",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "deprecated"
+                        ],
+                        "type": "string",
+                        "description": "翻译状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "in_review",
+                            "approved",
+                            "outdated"
+                        ],
+                        "type": "string",
+                        "description": "审核状态",
+                        "name": "review_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最后修改人ID",
+                        "name": "updated_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只搜索带有其中任一标签的键，逗号分隔的标签名称",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间起点，日期（2006-01-02）或 RFC3339 时间",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "更新时间终点，日期包含当天",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "每页数量，最大 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "偏移量",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tm/suggest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在当前用户可访问的项目（管理员为所有项目）中查找源语言文本与 text 相似的已有翻译，返回这些键在目标语言中的译文及相似度（0-1，忽略大小写和多余空白后按编辑距离计算，1 为完全相同），供编辑时参考。只使用有效的翻译；原文和译文都相同的键合并为一条，occurrences 为使用次数，project_id 和 key_name 为其中最近更新的键。按相似度、使用次数和更新时间排序。编辑某个键时传入 project_id 和 key_name 可排除该键自身",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "查询翻译记忆",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "需要翻译的源语言文本，最长 500 个字符",
+                        "name": "text",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "源语言代码，默认为默认语言",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "目标语言代码",
+                        "name": "target",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.5,
+                        "description": "最低相似度，大于 0 且不大于 1",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "最多返回的建议数量，最大 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "正在编辑的键所在项目",
+                        "name": "project_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "正在编辑的键名，不出现在建议中",
+                        "name": "key_name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TMSuggestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translation-history/{id}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回一条翻译变更历史中旧值和新值的词级差异（segments，按顺序拼接 equal 和 delete 为旧值，拼接 equal 和 insert 为新值）和按行的 unified diff（值没有变化时为空）。中日韩文字按单个字符比较。需要翻译所属项目的查看权限",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取变更历史差异",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "变更历史ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TranslationHistoryDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建新的翻译",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "创建翻译",
+                "parameters": [
+                    {
+                        "description": "翻译信息",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/batch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "批量创建多个翻译，支持两种格式：数组格式和前端对象格式",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "批量创建翻译",
+                "parameters": [
+                    {
+                        "description": "批量翻译请求",
+                        "name": "translations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BatchTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/batch-delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "批量删除多个翻译，翻译所属的项目开启删除保护时不删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "批量删除翻译",
+                "parameters": [
+                    {
+                        "description": "翻译ID列表",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/by-project/{project_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据项目ID获取翻译列表，review_status 不为空时只返回该审核状态的翻译。sort 指定排序（如 updated_at:desc，可排序字段：id、key_name、value、status、created_at、updated_at），fields 只返回指定的字段（如 key_name,value）。提供 cursor 或 limit 时使用游标分页：翻译按ID排序（不能指定 sort），meta.next_cursor 为下一页的游标，没有下一页时不返回，此时忽略 page 和 page_size",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取项目翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "游标分页：上一页返回的 meta.next_cursor，为空时获取第一页",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "游标分页：每页数量",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "in_review",
+                            "approved",
+                            "outdated"
+                        ],
+                        "type": "string",
+                        "description": "审核状态",
+                        "name": "review_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序，字段:asc|desc，多个用逗号分隔",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "返回的字段，逗号分隔",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/icu/format": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将解析接口返回格式的元素序列化为 ICU MessageFormat 消息，文本中的 {、}（复数选项中还有 #）按需用撇号引用。参数名无效、选项无效或重复、plural、selectordinal 和 select 缺少 other 选项时返回 400",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "生成 ICU 消息",
+                "parameters": [
+                    {
+                        "description": "消息的元素",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.FormatICUMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.FormatICUMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/icu/parse": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将 ICU MessageFormat 消息解析为结构化的元素供编辑器使用：text、argument（如 {name}、{count, number}）、pound（复数选项中的 #）以及带选项的 plural、selectordinal 和 select。撇号按 ICU 的默认规则处理。语法错误时 valid 为 false，error 和 error_offset 说明错误及位置（字符序号，从 0 开始）。指定 language 时返回该语言的 CLDR 复数类别（plural_categories 为基数，ordinal_categories 为序数），并在 issues 中列出缺少或语言没有的复数类别（=0、=1、=2 可以代替 zero、one、two）。只解析请求中的文本，不读写翻译",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "解析 ICU 消息",
+                "parameters": [
+                    {
+                        "description": "ICU 消息和语言",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ParseICUMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ICUMessageInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/machine-translate/health": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "检查机器翻译服务是否可用",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "健康检查",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/machine-translate/languages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取机器翻译支持的语言列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取支持的语言",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.MachineTranslationLanguage"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/machine-translate/project/{project_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "使用配置的机器翻译服务商（MT_PROVIDER：libretranslate、deepl 或 google）将源语言的文本翻译到目标语言，只填充目标语言中没有翻译的键，已有的翻译（包括已废弃的）不会被覆盖。key_names 为空时处理项目中的所有键；源语言没有文本或值类型为 json 的键计入 skipped。所有目标语言翻译完成后一次写入，写入的翻译在变更历史中记为 machine_translate；服务商调用失败时返回 502，不写入任何翻译。一次最多翻译 2000 条",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "机器翻译填充缺失翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "键名和语言",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MachineTranslateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.AutoTranslateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/machine-translate/project/{project_id}/pre-translate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "扫描项目中所有键，使用机器翻译填充 target_languages 中选择的语言里没有翻译的单元格，已有的翻译（包括已废弃的）不会被覆盖；源语言没有文本或值类型为 json 的键计入 skipped。按 MT_BATCH_SIZE 分批调用服务商，两批之间间隔 MT_REQUEST_INTERVAL 毫秒；每批翻译完成后立即写入（变更历史中记为 machine_translate），某一批调用失败时这批键计入 failed_keys 并继续下一批。响应中按语言列出已填充和失败的键。一次最多翻译 20000 条",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "机器翻译预翻译项目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "源语言和需要预翻译的语言",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PreTranslateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.PreTranslateResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/matrix/by-project/{project_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取项目的翻译矩阵（键-语言映射），支持分页、排序和搜索。返回对象的键按排序结果排列；collation 指定排序使用的区域设置（如 de、sv、zh、ja），为空时按字节顺序排序。每个单元格包含翻译的状态（status）、审核状态（review_status）、最后修改人ID（updated_by）和用户名（updated_by_username），qa_issues 为按 QA 报告规则（默认长度倍数，不含术语表检查）发现的问题类型",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取翻译矩阵",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "搜索关键词（匹配键名和翻译值）",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "accent_insensitive",
+                            "case_insensitive",
+                            "exact"
+                        ],
+                        "type": "string",
+                        "description": "搜索比较规则",
+                        "name": "search_collation",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "key",
+                            "value"
+                        ],
+                        "type": "string",
+                        "default": "key",
+                        "description": "排序字段",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按翻译值排序时使用的语言代码",
+                        "name": "sort_language",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "排序方向",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序区域设置（BCP 47）",
+                        "name": "collation",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "draft",
+                            "in_review",
+                            "approved",
+                            "outdated"
+                        ],
+                        "type": "string",
+                        "description": "只返回有该审核状态翻译的键",
+                        "name": "review_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回该命名空间中的键",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只返回带有其中任一标签的键，逗号分隔的标签名称",
+                        "name": "tags",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据翻译ID获取翻译详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "获取翻译详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "翻译ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新翻译信息。传入 version（读取翻译时的版本号）时，翻译在此之后已被他人修改则返回 409 TRANSLATION_CONFLICT，data 为当前的翻译",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "更新翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "翻译ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "翻译信息",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Translation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除指定的翻译",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "删除翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "翻译ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "恢复已删除的翻译，翻译值、状态和审核状态保持删除时的内容，并记录操作类型为 restore 的变更历史。该键在其他语言还有翻译时，值类型、最大长度、标签、平台和命名空间使用其他语言的设置",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "恢复翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "翻译ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/translations/{id}/rollback/{history_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将翻译的值恢复为变更历史记录的版本（该次变更后的值），键名和状态保持不变。值变化时译文回到草稿，并记录操作类型为 rollback 的变更历史；不能回滚到删除操作",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "翻译管理"
+                ],
+                "summary": "回滚翻译",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "翻译ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "变更历史ID",
+                        "name": "history_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user-projects/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取指定用户参与的所有项目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目成员管理"
+                ],
+                "summary": "获取用户参与的项目列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/change-password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "用户修改自己的密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "修改用户密码",
+                "parameters": [
+                    {
+                        "description": "密码信息",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取已登录用户的详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取当前用户信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前用户的显示名称、头像地址、界面语言和时区",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取个人资料",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "整体替换显示名称（最多 100 个字符）、界面语言（BCP 47 语言标签，保存为规范形式）和时区（IANA 时区），空值表示清除。显示名称和头像在项目成员列表和变更历史的操作人中展示",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新个人资料",
+                "parameters": [
+                    {
+                        "description": "个人资料",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "请求体为 PNG、JPEG、GIF 或 WebP 图片（最大 1 MB），替换原有头像",
+                "consumes": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "上传头像",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除头像",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserProfileResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回当前用户的有效登录会话（设备、IP 和最近访问时间），最近访问的在前，current 标记发起本次请求的会话；不能使用访问令牌调用此接口",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录会话"
+                ],
+                "summary": "获取登录会话列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SessionResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/sessions/{session_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销当前用户的一个登录会话，该会话的访问令牌和刷新令牌立即失效；撤销当前会话即退出登录",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录会话"
+                ],
+                "summary": "撤销登录会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "返回当前用户的所有访问令牌，包括过期时间和最近使用时间，不包含令牌本身",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人访问令牌"
+                ],
+                "summary": "获取个人访问令牌列表",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AccessTokenResponse"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "为当前用户创建访问令牌。脚本在 Authorization 头中以 Bearer 方式携带令牌，以当前用户的身份和权限访问 API。令牌只在创建时返回一次；不能使用访问令牌调用此接口",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人访问令牌"
+                ],
+                "summary": "创建个人访问令牌",
+                "parameters": [
+                    {
+                        "description": "令牌信息",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAccessTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销当前用户的访问令牌，撤销后立即失效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "个人访问令牌"
+                ],
+                "summary": "撤销个人访问令牌",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "令牌ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "分页获取用户列表，支持关键词搜索。sort 指定排序（如 created_at:desc，可排序字段：id、username、email、status、created_at、updated_at），fields 只返回指定的字段（如 id,username）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "每页数量",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "搜索关键词",
+                        "name": "keyword",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序，字段:asc|desc，多个用逗号分隔",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "返回的字段，逗号分隔",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "管理员创建新用户账户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "创建新用户",
+                "parameters": [
+                    {
+                        "description": "用户信息",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按分配给当前用户的任务统计待处理的工作：翻译任务为该语言缺少译文或译文为草稿的键，审核任务为该语言待审核的键。每个项目、语言和工作类型为一项任务，列出最多 100 个待处理的键；没有待处理键的任务、已停用的语言以及用户已不是编辑者或所有者的项目不列出",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "任务分配"
+                ],
+                "summary": "获取我的待办任务",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.UserTaskList"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "根据用户ID获取用户详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新用户的基本信息和角色状态",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户信息",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "用户信息",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除指定的用户账户，按默认方式进行离职处理。用户仍是项目唯一的 owner 或有有效的邀请码时返回 409 USER_OWNS_RESOURCES，需要先通过 POST /users/{id}/transfer-ownership 转移；只需禁止登录时使用 POST /users/{id}/deactivate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/avatar": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "任何登录用户都可以获取，用于在成员列表和变更历史中展示",
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户头像",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将用户状态改为 disabled 并撤销其全部登录会话：用户不能再登录或刷新令牌，个人访问令牌被拒绝。用户、内容引用和项目成员关系都保留，通过 PUT /users/{id} 将 status 改回 active 即可恢复。不能停用管理员用户。操作以 project_id 为 0 的审计日志记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "停用用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "停用原因",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeactivateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.User"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "撤销用户的全部登录会话，已签发的访问令牌和刷新令牌立即失效（仅管理员）。个人访问令牌不受影响",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录会话"
+                ],
+                "summary": "强制用户下线",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevokeSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/offboard": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除用户并处理其留下的引用：reassign_to 指定的用户接收内容的创建人、修改人等引用和用户作为 owner 的项目；未指定时引用置为 0，移除后没有其他 owner 的项目列在 projects_without_owner 中。同时删除用户的项目成员关系和个人访问令牌，用户的登录令牌随之失效。翻译历史和审计日志中的操作人默认保留，anonymize=true 时一并置为 0（GDPR 匿名化）。操作以 project_id 为 0 的审计日志记录，可通过 GET /admin/audit-logs 查看",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "用户离职处理",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "离职处理选项",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.OffboardUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OffboardUserResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/reset-password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "管理员重置指定用户的密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "重置用户密码",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新密码信息",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/transfer-ownership": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将用户作为 owner 的项目和作为邀请人的全部邀请码转给 to_user_id（必须是其他启用的用户）：接收人成为这些项目的 owner，原 owner 降为 editor。用于停用或删除用户之前，操作以 project_id 为 0 的审计日志记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "转移用户所有权",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "接收所有权的用户",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TransferOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OwnershipTransferResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/inbound/{webhook_id}": {
+            "post": {
+                "description": "外部系统使用 HMAC-SHA256 对原始请求体签名，通过 X-YFlow-Signature: sha256=\u003chex\u003e 传递",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "入站Webhook"
+                ],
+                "summary": "接收外部 TMS 推送",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "请求体签名",
+                        "name": "X-YFlow-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "推送内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.InboundWebhookPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.InboundWebhookLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.ActivityTrendCount": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AssignNamespaceResult": {
+            "type": "object",
+            "properties": {
+                "key_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "修改的翻译条数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AutoTranslateLanguageResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "description": "机器翻译没有返回结果的键数量",
+                    "type": "integer"
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "缺少翻译但源语言没有文本、键不存在或值类型为 json 的键数量",
+                    "type": "integer"
+                },
+                "translated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AutoTranslateResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.AutoTranslateLanguageResult"
+                    }
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "translated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.BranchChange": {
+            "type": "object",
+            "properties": {
+                "base_value": {
+                    "description": "冲突时分支修改前主线的值",
+                    "type": "string"
+                },
+                "conflict": {
+                    "description": "分支修改该翻译后主线也修改了它，合并时需要指定处理方式",
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "added, modified, removed",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BranchDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "按键名和语言代码排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BranchChange"
+                    }
+                },
+                "conflicts": {
+                    "description": "冲突的差异数量，只在目标为主线时计算",
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BulkAddMembersResult": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "email_failed": {
+                    "description": "通知邮件发送失败的数量，成员仍已添加",
+                    "type": "integer"
+                },
+                "emailed": {
+                    "description": "通知邮件发送成功的数量",
+                    "type": "integer"
+                },
+                "existing": {
+                    "description": "已是项目成员而跳过的数量，角色不变",
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.BulkMemberResult"
+                    }
+                }
+            }
+        },
+        "domain.BulkMemberResult": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "email_status": {
+                    "description": "sent 或 failed，未要求通知时为空",
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "失败原因，已是成员时为现有角色",
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "added、existing 或 failed",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ConsistencyDetail": {
+            "type": "object",
+            "properties": {
+                "language_code": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InconsistentTerm"
+                    }
+                },
+                "truncated": {
+                    "description": "不一致的术语过多时只列出前面的部分",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ConsistencyReport": {
+            "type": "object",
+            "properties": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LanguageConsistency"
+                    }
+                },
+                "source_language": {
+                    "type": "string"
+                },
+                "terms": {
+                    "description": "默认语言中被多个键使用的文本数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DailyActivityTrend": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.DashboardActivity": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "每天一项，没有变更的日期计数为 0",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DailyActivityTrend"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "统计的第一天（2006-01-02）",
+                    "type": "string"
+                },
+                "operators": {
+                    "description": "按变更总数降序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.OperatorActivityTrend"
+                    }
+                },
+                "projects": {
+                    "description": "按变更总数降序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ProjectActivityTrend"
+                    }
+                },
+                "to": {
+                    "description": "统计的最后一天，即今天",
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/domain.ActivityTrendCount"
+                }
+            }
+        },
+        "domain.DiffSegment": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "description": "equal, insert, delete",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FigmaFrame": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "file_key": {
+                    "description": "Figma 文件标识",
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "layers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FigmaLayer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "description": "画框的节点ID",
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "screenshot_type": {
+                    "description": "截图的媒体类型，为空时没有截图",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.FigmaLayer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "frame_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "同步时图层中的文本",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.FigmaSyncResult": {
+            "type": "object",
+            "properties": {
+                "changed_keys": {
+                    "description": "已存在且图层文本与当前翻译不同的键，可能是设计稿或翻译需要更新",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_keys": {
+                    "description": "以图层文本新建的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "existing_keys": {
+                    "description": "已存在的键，翻译保持不变",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "frame": {
+                    "$ref": "#/definitions/domain.FigmaFrame"
+                }
+            }
+        },
+        "domain.ICUElement": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "简单参数的类型，如 number、date",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "参数名",
+                    "type": "string"
+                },
+                "offset": {
+                    "description": "plural 和 selectordinal 的 offset",
+                    "type": "integer"
+                },
+                "options": {
+                    "description": "plural、selectordinal 和 select 的选项",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ICUOption"
+                    }
+                },
+                "style": {
+                    "description": "简单参数的样式，如 percent、short",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "text 的文本（已去除引用）",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ICUMessageInfo": {
+            "type": "object",
+            "properties": {
+                "elements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ICUElement"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_offset": {
+                    "description": "出错位置（字符序号，从 0 开始）",
+                    "type": "integer"
+                },
+                "issues": {
+                    "description": "复数选项与语言的复数类别不符等问题",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QAIssue"
+                    }
+                },
+                "language": {
+                    "type": "string"
+                },
+                "ordinal_categories": {
+                    "description": "语言的 CLDR 序数复数类别",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "plural_categories": {
+                    "description": "语言的 CLDR 基数复数类别",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ICUOption": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ICUElement"
+                    }
+                },
+                "selector": {
+                    "description": "复数类别（如 one、other）、=N 或 select 的值",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ImportKeyResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "languages": {
+                    "description": "写入的语言",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reason": {
+                    "description": "跳过或冲突的原因",
+                    "type": "string"
+                },
+                "skipped_languages": {
+                    "description": "按策略未写入或译文没有变化的语言",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.ImportMappingSuggestion": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "表头中的所有列",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "context_column": {
+                    "description": "未识别时为空",
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "header_row": {
+                    "type": "integer"
+                },
+                "key_column": {
+                    "description": "未识别时为空",
+                    "type": "string"
+                },
+                "language_columns": {
+                    "description": "表头 -\u003e 语言代码",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "unmapped_columns": {
+                    "description": "未识别的列",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.ImportResult": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "fail 策略下冲突的键数量，不为 0 时没有写入",
+                    "type": "integer"
+                },
+                "created": {
+                    "description": "新建的键数量",
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "keys": {
+                    "description": "每个键的处理方式，按键名排序，超过上限时截断",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ImportKeyResult"
+                    }
+                },
+                "namespace": {
+                    "description": "文件中的键归入的命名空间",
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "跳过的键数量",
+                    "type": "integer"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "写入的翻译条数",
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "keys 是否被截断",
+                    "type": "boolean"
+                },
+                "updated": {
+                    "description": "更新的键数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.InboundWebhookPayload": {
+            "type": "object",
+            "properties": {
+                "project_id": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InboundWebhookTranslation"
+                    }
+                }
+            }
+        },
+        "domain.InboundWebhookTranslation": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "language": {
+                    "description": "对方系统的语言代码",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InconsistentTerm": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "默认语言的文本",
+                    "type": "string"
+                },
+                "suggestion": {
+                    "description": "建议统一使用的译文（使用最多的译文）",
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "各种译文及使用它的键，按使用次数从多到少排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TermTranslation"
+                    }
+                }
+            }
+        },
+        "domain.InvitationBatchItem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_status": {
+                    "description": "sent 或 failed，没有邮箱时为空",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "invitation_url": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InvitationBatchResult": {
+            "type": "object",
+            "properties": {
+                "batch_label": {
+                    "type": "string"
+                },
+                "email_failed": {
+                    "description": "邀请邮件发送失败的数量，邀请码仍然有效",
+                    "type": "integer"
+                },
+                "emailed": {
+                    "description": "邀请邮件发送成功的数量",
+                    "type": "integer"
+                },
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.InvitationBatchItem"
+                    }
+                }
+            }
+        },
+        "domain.KeyCopyResult": {
+            "type": "object",
+            "properties": {
+                "copied": {
+                    "description": "复制的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "linked": {
+                    "description": "链接的键数量",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "项目中已存在且未覆盖的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_project_id": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "description": "新增或修改的翻译条数，值相同的翻译不计入",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.KeyLink": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_name": {
+                    "description": "目标项目中的键只能链接一个源项目",
+                    "type": "string"
+                },
+                "last_synced_at": {
+                    "description": "最近一次把源项目的修改写入目标项目的时间",
+                    "type": "string"
+                },
+                "source_project_id": {
+                    "type": "integer"
+                },
+                "target_project_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.KeyLock": {
+            "type": "object",
+            "properties": {
+                "acquired_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "到期时间，之前未续期则锁失效",
+                    "type": "string"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.KeyRenameResult": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "integer"
+                },
+                "attachments": {
+                    "type": "integer"
+                },
+                "branch_translations": {
+                    "description": "未合并分支中修改过的翻译",
+                    "type": "integer"
+                },
+                "figma_layers": {
+                    "description": "引用该键的设计稿图层",
+                    "type": "integer"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "new_key_name": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.Language": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "语言代码，如 en, zh-CN",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "description": "是否为默认语言",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "语言名称，如 English, 简体中文",
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织，0 表示不属于任何组织",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：active, inactive",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LanguageConsistency": {
+            "type": "object",
+            "properties": {
+                "consistent": {
+                    "description": "与建议译文相同的译文数量",
+                    "type": "integer"
+                },
+                "inconsistent_terms": {
+                    "description": "译文不一致的术语数量",
+                    "type": "integer"
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "description": "参与比较的译文数量",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "一致性得分（百分比），没有可比较的术语时为 100",
+                    "type": "number"
+                },
+                "terms": {
+                    "description": "至少有两个键已翻译、参与比较的术语数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.LanguageReadiness": {
+            "type": "object",
+            "properties": {
+                "approval": {
+                    "description": "审核通过率（百分比）",
+                    "type": "number"
+                },
+                "approved": {
+                    "type": "integer"
+                },
+                "blocking": {
+                    "type": "boolean"
+                },
+                "completion": {
+                    "description": "完成率（百分比）",
+                    "type": "number"
+                },
+                "keys_truncated": {
+                    "description": "键列表是否被截断",
+                    "type": "boolean"
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "min_approval": {
+                    "description": "未设置门槛时为 0",
+                    "type": "integer"
+                },
+                "min_completion": {
+                    "description": "未设置门槛时为 0",
+                    "type": "integer"
+                },
+                "missing_keys": {
+                    "description": "缺少翻译的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translated": {
+                    "type": "integer"
+                },
+                "unapproved_keys": {
+                    "description": "有翻译但未审核通过的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.LanguageStats": {
+            "type": "object",
+            "properties": {
+                "language_code": {
+                    "type": "string"
+                },
+                "language_name": {
+                    "type": "string"
+                },
+                "outdated": {
+                    "description": "源语言译文修改后等待重新翻译",
+                    "type": "integer"
+                },
+                "percentage": {
+                    "description": "translated 占键数量的百分比，保留两位小数",
+                    "type": "number"
+                },
+                "translated": {
+                    "description": "译文不为空且未过期",
+                    "type": "integer"
+                },
+                "untranslated": {
+                    "description": "没有翻译或译文为空",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.MachineTranslationLanguage": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.MigrationImportResult": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "description": "导入的键数量",
+                    "type": "integer"
+                },
+                "screenshots": {
+                    "description": "导出内容中附带的截图数量，截图不导入",
+                    "type": "integer"
+                },
+                "skipped_entries": {
+                    "description": "因语言无法匹配或键名为空跳过的条目",
+                    "type": "integer"
+                },
+                "skipped_tags": {
+                    "description": "名称无效或超出每个键标签数量上限而未导入的标签数量",
+                    "type": "integer"
+                },
+                "tagged_keys": {
+                    "description": "带有导入标签的键数量",
+                    "type": "integer"
+                },
+                "translations": {
+                    "description": "导入的翻译条数",
+                    "type": "integer"
+                },
+                "unmapped_languages": {
+                    "description": "无法匹配的外部语言代码",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "description": "未能导入的内容，如 skipped: screenshots",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.OffboardUserResult": {
+            "type": "object",
+            "properties": {
+                "access_tokens_revoked": {
+                    "type": "integer"
+                },
+                "activity_anonymized": {
+                    "description": "匿名化的翻译历史和审计日志记录数",
+                    "type": "integer"
+                },
+                "anonymized": {
+                    "type": "boolean"
+                },
+                "memberships_removed": {
+                    "description": "删除的项目成员关系数量",
+                    "type": "integer"
+                },
+                "ownership_transferred": {
+                    "description": "所有权转给 reassigned_to 的项目",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "projects_without_owner": {
+                    "description": "移除后没有其他 owner 的项目，需要管理员指定",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reassigned_to": {
+                    "description": "0 表示内容引用已匿名",
+                    "type": "integer"
+                },
+                "references": {
+                    "description": "按“表.字段”统计改写的行数",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OperatorActivityTrend": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OrganizationMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "owner, admin, member",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.OwnershipTransferResult": {
+            "type": "object",
+            "properties": {
+                "invitations_reassigned": {
+                    "description": "邀请人改为 transferred_to 的邀请码数量",
+                    "type": "integer"
+                },
+                "projects": {
+                    "description": "所有权转给 transferred_to 的项目，原 owner 降为 editor",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "transferred_to": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PreTranslateLanguageResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "服务商返回的错误（去重）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed": {
+                    "description": "服务商调用失败或没有返回结果的键数量",
+                    "type": "integer"
+                },
+                "failed_keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "filled": {
+                    "type": "integer"
+                },
+                "filled_keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "missing": {
+                    "description": "目标语言中没有翻译的键数量",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "源语言没有文本或值类型为 json 的键数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PreTranslateResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "filled": {
+                    "type": "integer"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PreTranslateLanguageResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "source_language": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Project": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "delivery_enabled": {
+                    "description": "公开分发：开启时应用可以不经认证通过 /delivery 获取最新发布版本",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "项目描述",
+                    "type": "string"
+                },
+                "export_file_template": {
+                    "description": "导出文件命名模板，如 {locale}/{namespace}.json",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_accessed_at": {
+                    "description": "最近一次访问时间（Web 或 CLI），由活动跟踪定期写入",
+                    "type": "string"
+                },
+                "last_changed_at": {
+                    "description": "最近一次内容变更时间，由活动跟踪定期写入",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "项目名称",
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织，0 表示不属于任何组织",
+                    "type": "integer"
+                },
+                "protected": {
+                    "description": "删除保护：开启时不能删除项目或批量删除翻译",
+                    "type": "boolean"
+                },
+                "slug": {
+                    "description": "项目标识，用于URL",
+                    "type": "string"
+                },
+                "source_language": {
+                    "description": "源语言代码，为空时使用默认语言；源语言译文修改后其他语言的译文标记为 outdated",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "项目状态：active, archived",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProjectActivityTrend": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_name": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProjectDuplicateJob": {
+            "type": "object",
+            "properties": {
+                "copied": {
+                    "description": "已复制的翻译条数",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "error": {
+                    "description": "失败原因",
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "include_members": {
+                    "type": "boolean"
+                },
+                "project_id": {
+                    "description": "新项目",
+                    "type": "integer"
+                },
+                "source_project_id": {
+                    "description": "被复制的项目",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "running, completed, failed",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "需要复制的翻译条数（开始时统计）",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ProjectMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "owner, editor, viewer",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "为空时为手动添加，role_sync 为管理员同步自动添加",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProjectRollbackResult": {
+            "type": "object",
+            "properties": {
+                "skipped": {
+                    "description": "无法恢复的翻译数量：当时尚未创建、当时已删除或当前已删除",
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "恢复的翻译数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ProjectStats": {
+            "type": "object",
+            "properties": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LanguageStats"
+                    }
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "total_keys": {
+                    "description": "项目中有效的键数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PushKeysResult": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "existed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.QAIssue": {
+            "type": "object",
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "suggestion": {
+                    "description": "disallowed_markup 为移除不允许的内容后的译文，forbidden_term 为替换为规定译文后的译文，trailing_whitespace 为改用源语言末尾空白后的译文",
+                    "type": "string"
+                },
+                "translation_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.QAReport": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "按问题类型统计的问题数量",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.QAIssue"
+                    }
+                },
+                "keys": {
+                    "description": "检查的键数量",
+                    "type": "integer"
+                },
+                "source_language": {
+                    "description": "比较链接和占位符时使用的源语言（默认语言），为空时不做比较",
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "检查的翻译数量",
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "问题过多时只列出前面的部分，counts 仍为全部数量",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ReleaseReadiness": {
+            "type": "object",
+            "properties": {
+                "blocking": {
+                    "description": "未达标的语言代码",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.LanguageReadiness"
+                    }
+                },
+                "overridden": {
+                    "description": "未达标但发布时显式覆盖",
+                    "type": "boolean"
+                },
+                "ready": {
+                    "description": "所有设置了门槛的语言都达标",
+                    "type": "boolean"
+                },
+                "total_keys": {
+                    "description": "项目中有效的键数量，作为完成率的分母",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ReviewTransitionResult": {
+            "type": "object",
+            "properties": {
+                "review_status": {
+                    "description": "操作后的审核状态",
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "修改的翻译条数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RoleReview": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "global_role": {
+                    "description": "进入复核时的全局角色",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "global_role_downgraded 或 role_sync_leftover",
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "resolved_by": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "进入复核时的项目角色",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending, kept, downgraded, removed",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.RoleSyncResult": {
+            "type": "object",
+            "properties": {
+                "admins": {
+                    "description": "参与同步的启用的全局管理员数量",
+                    "type": "integer"
+                },
+                "members_added": {
+                    "description": "为管理员补充的 owner 成员关系数量",
+                    "type": "integer"
+                },
+                "projects": {
+                    "description": "项目数量",
+                    "type": "integer"
+                },
+                "reviews_queued": {
+                    "description": "新加入复核队列的 owner 成员关系数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SSOProvider": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "description": "登录按钮上显示的名称",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "配置中的名称，登录地址的 provider 参数",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "google, github, oidc",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SearchHit": {
+            "type": "object",
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "matched_field": {
+                    "description": "相关度更高的字段：key 或 value",
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_name": {
+                    "type": "string"
+                },
+                "review_status": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "相关度（0-1），1 为键名或译文与查询文本完全相同",
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "translation_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SearchResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.SearchHit"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "匹配的翻译过多，只对搜索后端返回的前一部分排序",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.SetKeyMetadataResult": {
+            "type": "object",
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "max_length": {
+                    "type": "integer"
+                },
+                "platform": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "translations": {
+                    "description": "修改的翻译条数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.SetValueTypeResult": {
+            "type": "object",
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "修改的翻译条数",
+                    "type": "integer"
+                },
+                "value_schema": {
+                    "type": "object"
+                },
+                "value_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.SpreadsheetImportResult": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "description": "导入的键数量",
+                    "type": "integer"
+                },
+                "rows": {
+                    "description": "表头之后的数据行数",
+                    "type": "integer"
+                },
+                "skipped_rows": {
+                    "description": "键名为空的行",
+                    "type": "integer"
+                },
+                "translations": {
+                    "description": "导入的翻译条数",
+                    "type": "integer"
+                },
+                "unmapped_languages": {
+                    "description": "映射配置中已不存在的语言代码，对应的列被跳过",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.TMSuggestResult": {
+            "type": "object",
+            "properties": {
+                "source_language": {
+                    "type": "string"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TMSuggestion"
+                    }
+                },
+                "target_language": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TMSuggestion": {
+            "type": "object",
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "description": "使用这对原文和译文的键数量",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "description": "最近更新的一个键",
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "与查询文本的相似度，1 为完全相同",
+                    "type": "number"
+                },
+                "source_text": {
+                    "type": "string"
+                },
+                "target_text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TagKeysResult": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "description": "新增或移除了标签的键数量",
+                    "type": "integer"
+                },
+                "key_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.TermTranslation": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.Translation": {
+            "type": "object",
+            "properties": {
+                "context": {
+                    "description": "上下文说明",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_name": {
+                    "description": "翻译键名",
+                    "type": "string"
+                },
+                "language_id": {
+                    "description": "语言ID",
+                    "type": "integer"
+                },
+                "max_length": {
+                    "description": "译文最多字符数，0 表示不限制，同一键的所有语言保持一致",
+                    "type": "integer"
+                },
+                "namespace_id": {
+                    "description": "键所属的命名空间ID，0 表示未划分命名空间，同一键的所有语言保持一致",
+                    "type": "integer"
+                },
+                "platform": {
+                    "description": "使用键的平台：web, ios, android, desktop，为空时不限，同一键的所有语言保持一致",
+                    "type": "string"
+                },
+                "project_id": {
+                    "description": "关联的项目ID",
+                    "type": "integer"
+                },
+                "review_comment": {
+                    "description": "驳回原因",
+                    "type": "string"
+                },
+                "review_status": {
+                    "description": "审核状态：draft, in_review, approved, outdated，译文修改后回到 draft",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "description": "最后一次审核操作的时间",
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "description": "最后一次审核操作（提交、通过、驳回）的用户ID",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：active, deprecated",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "键的标签，逗号分隔，同一键的所有语言保持一致",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "翻译值",
+                    "type": "string"
+                },
+                "value_schema": {
+                    "description": "json 类型的值需要符合的 JSON Schema，为空时只检查是否为合法的 JSON",
+                    "type": "string"
+                },
+                "value_type": {
+                    "description": "值类型：string, json, markdown, html，同一键的所有语言保持一致",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "版本号，更新翻译或写入不同的译文时加 1，用于检测并发修改",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TranslationChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "added, modified, removed, renamed",
+                    "type": "string"
+                },
+                "key_name": {
+                    "description": "结束时的键名",
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "previous_key": {
+                    "description": "范围内重命名过时为开始时的键名",
+                    "type": "string"
+                },
+                "segments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DiffSegment"
+                    }
+                },
+                "translation_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.TranslationChangeReport": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "changes": {
+                    "description": "按键名和语言代码排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.TranslationChange"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "modified": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "integer"
+                },
+                "renamed": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "变化超过上限时只返回前面的部分，统计数量仍为全部",
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.TranslationDelta": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "description": "已删除、废弃或被重命名的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "synced_at": {
+                    "description": "下次同步时作为 since 传入",
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "键名 -\u003e 语言代码 -\u003e 翻译值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "domain.TranslationHistoryDiff": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "history_id": {
+                    "type": "integer"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "operator": {
+                    "description": "操作人，用户已删除或为系统操作时为空",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserSummary"
+                        }
+                    ]
+                },
+                "previous_key": {
+                    "type": "string"
+                },
+                "segments": {
+                    "description": "词级差异，值没有变化时只有一个 equal 片段",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.DiffSegment"
+                    }
+                },
+                "translation_id": {
+                    "type": "integer"
+                },
+                "unified": {
+                    "description": "按行的 unified diff，值没有变化时为空",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.UsageBreakdown": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "last_pulled_at": {
+                    "description": "最近一次拉取的日期（UTC，2006-01-02）",
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.UsageDailyPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "2006-01-02",
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.UsageReport": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "按渠道汇总",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UsageBreakdown"
+                    }
+                },
+                "consumers": {
+                    "description": "按调用方汇总",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UsageBreakdown"
+                    }
+                },
+                "daily": {
+                    "description": "每天的拉取次数，没有拉取的日期为 0",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UsageDailyPoint"
+                    }
+                },
+                "days": {
+                    "type": "integer"
+                },
+                "locales": {
+                    "description": "按语言汇总，key 为空表示请求未指定语言",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UsageBreakdown"
+                    }
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "since": {
+                    "description": "统计起始日期（UTC，2006-01-02）",
+                    "type": "string"
+                },
+                "unused_locales": {
+                    "description": "期间没有被单独拉取过的启用语言",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "domain.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "display_name": {
+                    "description": "显示名称，为空时显示用户名",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "界面语言，为空时跟随浏览器",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "admin, member, viewer",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "active, disabled",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA 时区，为空时跟随浏览器",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UserSummary": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UserTask": {
+            "type": "object",
+            "properties": {
+                "language_code": {
+                    "type": "string"
+                },
+                "pending": {
+                    "description": "待处理的键数量",
+                    "type": "integer"
+                },
+                "pending_keys": {
+                    "description": "待处理的键，按键名排序，最多 100 个",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "translate, review",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.UserTaskList": {
+            "type": "object",
+            "properties": {
+                "review": {
+                    "description": "待审核的键总数",
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.UserTask"
+                    }
+                },
+                "translate": {
+                    "description": "待翻译的键总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.WatchEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "key_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locales": {
+                    "description": "变更涉及的语言，为空表示未知或全部语言",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "变更的操作人，0 表示系统、CLI 或未知",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_prefix": {
+                    "description": "API Key 开头的几位，用于识别",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织，组织的 API Key 只能访问该组织的项目",
+                    "type": "integer"
+                },
+                "project_ids": {
+                    "description": "为空时可访问所有项目（组织的 API Key 为组织的所有项目）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "token_prefix": {
+                    "description": "令牌开头的几位，用于识别令牌",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AddOrganizationMemberRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "默认 member",
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AddProjectMemberRequest": {
+            "type": "object",
+            "required": [
+                "role",
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ArchiveStaleProjectsRequest": {
+            "type": "object",
+            "required": [
+                "project_ids"
+            ],
+            "properties": {
+                "days": {
+                    "description": "归档前按该天数重新检查是否闲置，0 表示使用默认值",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "project_ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.ArchiveStaleProjectsResponse": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "skipped": {
+                    "description": "不存在、已归档或已不再闲置的项目",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.AssignNamespaceRequest": {
+            "type": "object",
+            "required": [
+                "key_names"
+            ],
+            "properties": {
+                "key_names": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "namespace": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.AssignRequest": {
+            "type": "object",
+            "required": [
+                "language_code",
+                "type",
+                "user_id"
+            ],
+            "properties": {
+                "key_names": {
+                    "description": "为空时分配该语言的所有键",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "translate 翻译，review 审核",
+                    "type": "string",
+                    "enum": [
+                        "translate",
+                        "review"
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "key_name": {
+                    "description": "为空时分配该语言的所有键",
+                    "type": "string"
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "description": "附件内容的访问地址，需要认证",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditLogListResponse": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditLogResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AutoFillLanguageRequest": {
+            "type": "object",
+            "required": [
+                "target_lang"
+            ],
+            "properties": {
+                "source_lang": {
+                    "description": "可选，默认为默认语言",
+                    "type": "string"
+                },
+                "target_lang": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AutoFillLanguageResponse": {
+            "type": "object",
+            "properties": {
+                "failed_count": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success_count": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BatchTranslationRequest": {
+            "type": "object",
+            "required": [
+                "key_name",
+                "project_id",
+                "translations"
+            ],
+            "properties": {
+                "context": {
+                    "type": "string"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.BranchMergeRequest": {
+            "type": "object",
+            "properties": {
+                "resolutions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BranchResolutionItem"
+                    }
+                }
+            }
+        },
+        "dto.BranchMergeResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "写入主线的修改",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromotionChangeResponse"
+                    }
+                },
+                "branch": {
+                    "$ref": "#/definitions/dto.BranchResponse"
+                },
+                "skipped": {
+                    "description": "保留主线值的差异数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BranchRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.BranchResolutionItem": {
+            "type": "object",
+            "required": [
+                "key",
+                "language",
+                "resolution"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "branch",
+                        "main",
+                        "custom"
+                    ]
+                },
+                "value": {
+                    "description": "resolution 为 custom 时写入主线的值",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BranchResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "merged_at": {
+                    "type": "string"
+                },
+                "merged_by": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "open, merged",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BranchTranslationItem": {
+            "type": "object",
+            "required": [
+                "key_name",
+                "language_code"
+            ],
+            "properties": {
+                "deleted": {
+                    "description": "在分支中删除该翻译，忽略 value",
+                    "type": "boolean"
+                },
+                "key_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BulkAddProjectMembersRequest": {
+            "type": "object",
+            "required": [
+                "members"
+            ],
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkProjectMemberItem"
+                    }
+                },
+                "notify": {
+                    "description": "向添加的成员发送通知邮件，需要配置邮件服务",
+                    "type": "boolean"
+                },
+                "role": {
+                    "description": "成员未指定角色时使用的角色",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BulkDeleteFilterRequest": {
+            "type": "object",
+            "properties": {
+                "key_prefix": {
+                    "description": "键名前缀，如 checkout. 或 checkout.*",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "language": {
+                    "description": "语言代码",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "older_than": {
+                    "description": "最后修改时间早于该时间（RFC3339）",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "翻译状态",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "deprecated"
+                    ]
+                }
+            }
+        },
+        "dto.BulkDeleteRequest": {
+            "type": "object",
+            "required": [
+                "confirmation_token"
+            ],
+            "properties": {
+                "confirmation_token": {
+                    "description": "预览返回的确认令牌",
+                    "type": "string"
+                },
+                "key_prefix": {
+                    "description": "键名前缀，如 checkout. 或 checkout.*",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "language": {
+                    "description": "语言代码",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "older_than": {
+                    "description": "最后修改时间早于该时间（RFC3339）",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "翻译状态",
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "deprecated"
+                    ]
+                }
+            }
+        },
+        "dto.BulkDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "description": "执行删除的事务批次数",
+                    "type": "integer"
+                },
+                "confirmation_token": {
+                    "description": "确认令牌（预览）",
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "已删除的翻译条数",
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "description": "确认令牌的过期时间（预览）",
+                    "type": "string"
+                },
+                "key_prefix": {
+                    "type": "string"
+                },
+                "keys": {
+                    "description": "匹配的键数量",
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "older_than": {
+                    "type": "string"
+                },
+                "sample_keys": {
+                    "description": "部分匹配的键（预览）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "匹配的翻译条数",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BulkProjectMemberItem": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "owner、editor 或 viewer",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ComplianceMonthResponse": {
+            "type": "object",
+            "properties": {
+                "month": {
+                    "type": "string",
+                    "example": "2026-01"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ComplianceUserActivityResponse"
+                    }
+                }
+            }
+        },
+        "dto.ComplianceProjectResponse": {
+            "type": "object",
+            "properties": {
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ComplianceMonthResponse"
+                    }
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "project_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ComplianceReportResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2026-01"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ComplianceProjectResponse"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-06"
+                }
+            }
+        },
+        "dto.ComplianceUserActivityResponse": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "审计日志记录的操作次数，按操作类型",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "changes": {
+                    "description": "翻译变更次数，按操作类型",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "exports": {
+                    "description": "导出数据的次数",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "0 表示系统或 CLI",
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "project_ids": {
+                    "description": "可访问的项目，为空时可访问所有项目",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scope": {
+                    "description": "read 只能拉取翻译，write 还可以推送键，默认 read",
+                    "type": "string",
+                    "enum": [
+                        "read",
+                        "write"
+                    ]
+                }
+            }
+        },
+        "dto.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "明文 API Key，只在创建时返回一次",
+                    "type": "string"
+                },
+                "key_prefix": {
+                    "description": "API Key 开头的几位，用于识别",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "所属组织，组织的 API Key 只能访问该组织的项目",
+                    "type": "integer"
+                },
+                "project_ids": {
+                    "description": "为空时可访问所有项目（组织的 API Key 为组织的所有项目）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "scope": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAccessTokenRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "有效天数，默认 90 天",
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CreateAccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "last_used_ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "明文令牌，只在创建时返回一次",
+                    "type": "string"
+                },
+                "token_prefix": {
+                    "description": "令牌开头的几位，用于识别令牌",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateInvitationBatchRequest": {
+            "type": "object",
+            "properties": {
+                "batch_label": {
+                    "description": "为空时按创建时间生成",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "count": {
+                    "description": "邀请码数量，指定 emails 或 recipients 时可省略",
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 1
+                },
+                "description": {
+                    "type": "string"
+                },
+                "emails": {
+                    "description": "为每个邮箱创建一个邀请码并发送邀请邮件",
+                    "type": "array",
+                    "maxItems": 200,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_in_days": {
+                    "type": "integer"
+                },
+                "recipients": {
+                    "description": "为每个收件人创建一个邀请码，可分别指定角色",
+                    "type": "array",
+                    "maxItems": 200,
+                    "items": {
+                        "$ref": "#/definitions/dto.InvitationRecipientRequest"
+                    }
+                },
+                "role": {
+                    "description": "默认角色",
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "viewer"
+                    ]
+                },
+                "skip_email": {
+                    "description": "只记录邮箱，不发送邀请邮件",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.CreateInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "description": "不为空时向该邮箱发送邀请邮件",
+                    "type": "string"
+                },
+                "expires_in_days": {
+                    "type": "integer"
+                },
+                "max_uses": {
+                    "description": "最多可使用的次数，默认 1；大于 1 时可作为团队邀请链接",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "dto.CreateInvitationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_status": {
+                    "description": "邀请邮件的发送结果：sent、failed",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "invitation_url": {
+                    "type": "string"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateLanguageRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CreateProjectRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "auto_suffix": {
+                    "description": "标识冲突时自动追加 -2、-3 等后缀",
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "OrganizationID 所属组织，为空时项目不属于任何组织；需要是系统管理员或组织的 owner、admin",
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "自定义项目标识，为空时根据名称生成",
+                    "type": "string"
+                },
+                "source_language": {
+                    "description": "源语言代码，为空时使用默认语言",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateTranslationRequest": {
+            "type": "object",
+            "required": [
+                "key_name",
+                "language_id",
+                "project_id",
+                "value"
+            ],
+            "properties": {
+                "context": {
+                    "type": "string"
+                },
+                "key_name": {
+                    "type": "string"
+                },
+                "language_id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "value_type": {
+                    "description": "新键的值类型，默认 string；已有的键须与其值类型一致",
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "json",
+                        "markdown",
+                        "html"
+                    ]
+                },
+                "version": {
+                    "description": "只用于更新：编辑所基于的翻译版本号，翻译已被他人修改时返回 409；不传时不检查",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "viewer"
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DashboardStats": {
+            "type": "object",
+            "properties": {
+                "outdated_translations": {
+                    "description": "源语言译文修改后等待重新翻译的译文数",
+                    "type": "integer"
+                },
+                "total_keys": {
+                    "type": "integer"
+                },
+                "total_languages": {
+                    "type": "integer"
+                },
+                "total_projects": {
+                    "type": "integer"
+                },
+                "total_translations": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DeactivateUserRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.DeadLetterResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "description": "完整的事件内容",
+                    "type": "object"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DuplicateProjectRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "auto_suffix": {
+                    "description": "标识冲突时自动追加 -2、-3 等后缀",
+                    "type": "boolean"
+                },
+                "description": {
+                    "description": "为空时使用原项目的描述",
+                    "type": "string"
+                },
+                "include_members": {
+                    "description": "是否复制项目成员及其角色",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "slug": {
+                    "description": "新项目标识，为空时根据名称生成",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FigmaFrameRequest": {
+            "type": "object",
+            "required": [
+                "file_key",
+                "node_id"
+            ],
+            "properties": {
+                "file_key": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "language": {
+                    "description": "图层文本所属的语言代码，为空时为默认语言",
+                    "type": "string"
+                },
+                "layers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FigmaLayerRequest"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FigmaLayerRequest": {
+            "type": "object",
+            "required": [
+                "key",
+                "node_id"
+            ],
+            "properties": {
+                "context": {
+                    "description": "新建键时写入的上下文说明",
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "description": "图层中的文本，键不存在时作为翻译创建",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.FormatICUMessageRequest": {
+            "type": "object",
+            "required": [
+                "elements"
+            ],
+            "properties": {
+                "elements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ICUElement"
+                    }
+                }
+            }
+        },
+        "dto.FormatICUMessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GlossaryTermRequest": {
+            "type": "object",
+            "properties": {
+                "case_sensitive": {
+                    "type": "boolean"
+                },
+                "definition": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "term": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "translations": {
+                    "description": "提供时替换全部语言的译文",
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/dto.GlossaryTranslationRequest"
+                    }
+                }
+            }
+        },
+        "dto.GlossaryTermResponse": {
+            "type": "object",
+            "properties": {
+                "case_sensitive": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "definition": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "term": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GlossaryTranslationResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GlossaryTranslationRequest": {
+            "type": "object",
+            "required": [
+                "language_code"
+            ],
+            "properties": {
+                "forbidden": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "translation": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.GlossaryTranslationResponse": {
+            "type": "object",
+            "properties": {
+                "forbidden": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "language_code": {
+                    "type": "string"
+                },
+                "translation": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ project(id: \"web\") { name languages { code } matrix(pageSize: 20) { total rows { keyName cells { languageCode value } } } } }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "dto.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
+                }
+            }
+        },
+        "dto.ImportProfileRequest": {
+            "type": "object",
+            "properties": {
+                "context_column": {
+                    "description": "空字符串表示不导入上下文",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "header_row": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "key_column": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "language_columns": {
+                    "description": "表头 -\u003e 语言代码",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.ImportProfileResponse": {
+            "type": "object",
+            "properties": {
+                "context_column": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "header_row": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_column": {
+                    "type": "string"
+                },
+                "language_columns": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InboundWebhookLogListResponse": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InboundWebhookLogResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InboundWebhookLogResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InboundWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "conflict_strategy": {
+                    "type": "string",
+                    "enum": [
+                        "overwrite",
+                        "skip",
+                        "fill_empty"
+                    ]
+                },
+                "external_project_id": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "language_mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "provider": {
+                    "type": "string",
+                    "enum": [
+                        "generic",
+                        "crowdin",
+                        "lokalise"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "disabled"
+                    ]
+                }
+            }
+        },
+        "dto.InboundWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "conflict_strategy": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "external_project_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language_mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "仅在创建和重置密钥时返回",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "对方系统需要配置的推送地址",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InvitationInviter": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.InvitationListResponse": {
+            "type": "object",
+            "properties": {
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.InvitationResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.InvitationRecipientRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "为空时使用 role",
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "dto.InvitationResponse": {
+            "type": "object",
+            "properties": {
+                "batch_label": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inviter": {
+                    "$ref": "#/definitions/dto.InvitationInviter"
+                },
+                "inviter_id": {
+                    "type": "integer"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "use_count": {
+                    "type": "integer"
+                },
+                "used_at": {
+                    "description": "最近一次使用的时间",
+                    "type": "string"
+                },
+                "used_by": {
+                    "description": "最近一次使用的用户",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.KeyCopyRequest": {
+            "type": "object",
+            "required": [
+                "key_names",
+                "source_project_id"
+            ],
+            "properties": {
+                "key_names": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "link": {
+                    "description": "是否保持同步：源项目中的翻译新增或修改后写入项目",
+                    "type": "boolean"
+                },
+                "overwrite": {
+                    "description": "项目中已有的键是否覆盖，默认跳过",
+                    "type": "boolean"
+                },
+                "source_project_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.KeyPrefixRenameRequest": {
+            "type": "object",
+            "required": [
+                "new_prefix",
+                "prefix"
+            ],
+            "properties": {
+                "new_prefix": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "prefix": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "preview": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.KeyPrefixRequest": {
+            "type": "object",
+            "required": [
+                "prefix"
+            ],
+            "properties": {
+                "prefix": {
+                    "description": "键名前缀，如 checkout. 或 checkout.*",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "preview": {
+                    "description": "为 true 时只返回受影响的键，不执行操作",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.KeyPrefixResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "description": "重命名后与已有键冲突的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "data": {
+                    "description": "导出的翻译：键名 -\u003e 语言代码 -\u003e 翻译值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "keys": {
+                    "description": "匹配的键数量",
+                    "type": "integer"
+                },
+                "new_prefix": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "preview": {
+                    "type": "boolean"
+                },
+                "sample_keys": {
+                    "description": "部分匹配的键",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "受影响的翻译条数",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.KeyPrefixStatusRequest": {
+            "type": "object",
+            "required": [
+                "prefix",
+                "status"
+            ],
+            "properties": {
+                "prefix": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "preview": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "deprecated"
+                    ]
+                }
+            }
+        },
+        "dto.KeyRenameRequest": {
+            "type": "object",
+            "required": [
+                "key_name",
+                "new_key_name"
+            ],
+            "properties": {
+                "key_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "new_key_name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {}
+            }
+        },
+        "dto.MachineTranslateRequest": {
+            "type": "object",
+            "required": [
+                "target_languages"
+            ],
+            "properties": {
+                "key_names": {
+                    "description": "为空时处理项目中的所有键",
+                    "type": "array",
+                    "maxItems": 1000,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source_language": {
+                    "description": "语言代码，为空时使用默认语言",
+                    "type": "string"
+                },
+                "target_languages": {
+                    "description": "语言代码",
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.NamespaceRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.NamespaceResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "description": "命名空间中的键数量",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OffboardUserRequest": {
+            "type": "object",
+            "properties": {
+                "anonymize": {
+                    "description": "同时匿名化翻译历史和审计日志中的操作人",
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "reassign_to": {
+                    "description": "接收内容引用和项目所有权的用户ID，为空时引用置为 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OrganizationMemberResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OrganizationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ParseICUMessageRequest": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "language": {
+                    "description": "语言代码，用于返回复数类别并检查复数选项，为空时不检查",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PasswordResetConfirmRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "token": {
+                    "description": "重置链接中的 token 参数",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PasswordResetRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PreTranslateRequest": {
+            "type": "object",
+            "required": [
+                "target_languages"
+            ],
+            "properties": {
+                "source_language": {
+                    "description": "语言代码，为空时使用默认语言",
+                    "type": "string"
+                },
+                "target_languages": {
+                    "description": "需要预翻译的语言代码",
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ProjectConfigChangeDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create, update",
+                    "type": "string"
+                },
+                "new": {
+                    "type": "string"
+                },
+                "old": {
+                    "type": "string"
+                },
+                "section": {
+                    "description": "settings, webhooks, members",
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProjectConfigDocument": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProjectConfigMemberDTO"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/dto.ProjectConfigSettingsDTO"
+                },
+                "source": {
+                    "description": "导出时的项目标识，导入时忽略",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "文档版本，当前为 1",
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProjectConfigWebhookDTO"
+                    }
+                }
+            }
+        },
+        "dto.ProjectConfigImportResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProjectConfigChangeDTO"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "missing_languages": {
+                    "description": "本实例不存在或未启用的语言，需要管理员单独启用",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unknown_users": {
+                    "description": "本实例不存在的用户，已跳过",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ProjectConfigMemberDTO": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProjectConfigSettingsDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "export_file_template": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProjectConfigWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "conflict_strategy": {
+                    "type": "string"
+                },
+                "external_project_id": {
+                    "type": "string"
+                },
+                "language_mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProjectMemberInfo": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "description": "没有头像时为空",
+                    "type": "string"
+                },
+                "display_name": {
+                    "description": "为空时显示用户名",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "成员的界面语言偏好",
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "role_sync 表示由管理员同步自动添加",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "成员的时区偏好",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProjectRollbackRequest": {
+            "type": "object",
+            "required": [
+                "to"
+            ],
+            "properties": {
+                "to": {
+                    "description": "回滚到的时刻，RFC3339 格式",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromotionApplyRequest": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "description": "应用全部差异",
+                    "type": "boolean"
+                },
+                "approved": {
+                    "description": "审核通过的差异",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromotionChangeRef"
+                    }
+                }
+            }
+        },
+        "dto.PromotionChangeRef": {
+            "type": "object",
+            "required": [
+                "key",
+                "language"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromotionChangeResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "added, modified, removed",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PromotionDiffRequest": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "源实例的 CLI API Key，不会保存",
+                    "type": "string"
+                },
+                "include_removals": {
+                    "description": "源环境中不存在的翻译计为删除",
+                    "type": "boolean"
+                },
+                "remote_url": {
+                    "description": "源实例地址，如 https://staging.example.com",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "snapshot": {
+                    "description": "键名 -\u003e 语言代码 -\u003e 翻译值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "source_project": {
+                    "description": "源实例中的项目ID或标识",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.PromotionListResponse": {
+            "type": "object",
+            "properties": {
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromotionResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromotionChangeResponse"
+                    }
+                },
+                "applied_at": {
+                    "type": "string"
+                },
+                "applied_by": {
+                    "type": "integer"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PromotionChangeResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "missing_languages": {
+                    "description": "本实例不存在、未计入差异的语言",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signature_valid": {
+                    "type": "boolean"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "source_project": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/dto.PromotionSummary"
+                }
+            }
+        },
+        "dto.PromotionSummary": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "modified": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "dto.RegisterWithInvitationRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.ReleaseCreateResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "gate_overridden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_count": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "readiness": {
+                    "$ref": "#/definitions/domain.ReleaseReadiness"
+                },
+                "rollout_percentage": {
+                    "type": "integer"
+                },
+                "rollout_status": {
+                    "description": "active, completed, aborted，直接全量发布时为空",
+                    "type": "string"
+                },
+                "translation_count": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReleaseListResponse": {
+            "type": "object",
+            "properties": {
+                "releases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReleaseResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ReleaseRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "override": {
+                    "description": "未达到发布门槛时仍然发布，需要项目所有者权限",
+                    "type": "boolean"
+                },
+                "rollout_percentage": {
+                    "description": "灰度发布：公开分发时只有该百分比的客户端获取新版本，0 表示直接全量发布",
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0
+                },
+                "version": {
+                    "description": "版本标签，如 v1.2.0",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.ReleaseResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "gate_overridden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key_count": {
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "rollout_percentage": {
+                    "type": "integer"
+                },
+                "rollout_status": {
+                    "description": "active, completed, aborted，直接全量发布时为空",
+                    "type": "string"
+                },
+                "translation_count": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReleaseRolloutRequest": {
+            "type": "object",
+            "required": [
+                "percentage"
+            ],
+            "properties": {
+                "percentage": {
+                    "description": "100 表示全量发布",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.ReleaseThresholdItem": {
+            "type": "object",
+            "required": [
+                "language_code"
+            ],
+            "properties": {
+                "language_code": {
+                    "type": "string"
+                },
+                "min_approval": {
+                    "description": "最低审核通过率（百分比）",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "min_completion": {
+                    "description": "最低完成率（百分比）",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                }
+            }
+        },
+        "dto.ReleaseThresholdResponse": {
+            "type": "object",
+            "properties": {
+                "language_code": {
+                    "type": "string"
+                },
+                "min_approval": {
+                    "type": "integer"
+                },
+                "min_completion": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RenameProjectSlugRequest": {
+            "type": "object",
+            "required": [
+                "slug"
+            ],
+            "properties": {
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RequeueDeadLettersRequest": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "description": "重新投递全部死信事件，此时忽略 ids",
+                    "type": "boolean"
+                },
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.RequeueDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "requeued": {
+                    "description": "重新投递的数量，已被重新投递或不存在的事件不计入",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "dto.ResolveRoleReviewRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "keep",
+                        "downgrade",
+                        "remove"
+                    ]
+                },
+                "role": {
+                    "description": "action 为 downgrade 时的新角色",
+                    "type": "string",
+                    "enum": [
+                        "editor",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "dto.ReviewTransitionRequest": {
+            "type": "object",
+            "required": [
+                "translation_ids"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "驳回原因，只在驳回时保存",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "translation_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.RevokeInvitationBatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_label": {
+                    "type": "string"
+                },
+                "revoked": {
+                    "description": "撤销的有效邀请码数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RevokeSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "revoked": {
+                    "description": "撤销的会话数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "是否为发起本次请求的会话",
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "最近一次请求的来源 IP",
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "description": "登录时的 User-Agent",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SetBranchTranslationsRequest": {
+            "type": "object",
+            "required": [
+                "translations"
+            ],
+            "properties": {
+                "translations": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.BranchTranslationItem"
+                    }
+                }
+            }
+        },
+        "dto.SetKeyMetadataRequest": {
+            "type": "object",
+            "required": [
+                "key_name"
+            ],
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "max_length": {
+                    "description": "译文最多字符数，0 表示不限制",
+                    "type": "integer",
+                    "maximum": 100000,
+                    "minimum": 0
+                },
+                "platform": {
+                    "description": "为空时不限平台",
+                    "type": "string",
+                    "enum": [
+                        "web",
+                        "ios",
+                        "android",
+                        "desktop"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.SetProjectDeliveryRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.SetProjectProtectionRequest": {
+            "type": "object",
+            "required": [
+                "protected"
+            ],
+            "properties": {
+                "protected": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.SetReleaseThresholdsRequest": {
+            "type": "object",
+            "properties": {
+                "thresholds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReleaseThresholdItem"
+                    }
+                }
+            }
+        },
+        "dto.SetValueTypeRequest": {
+            "type": "object",
+            "required": [
+                "key_name",
+                "value_type"
+            ],
+            "properties": {
+                "key_name": {
+                    "type": "string"
+                },
+                "value_schema": {
+                    "description": "json 类型的值需要符合的 JSON Schema，为空时只检查是否为合法的 JSON",
+                    "type": "object"
+                },
+                "value_type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "json",
+                        "markdown",
+                        "html"
+                    ]
+                }
+            }
+        },
+        "dto.StaleProjectListResponse": {
+            "type": "object",
+            "properties": {
+                "before": {
+                    "description": "最近变更早于该时间的项目视为闲置",
+                    "type": "string"
+                },
+                "days": {
+                    "description": "闲置天数",
+                    "type": "integer"
+                },
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StaleProjectResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StaleProjectResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "idle_days": {
+                    "description": "距最近变更（从未变更时为创建时间）的天数",
+                    "type": "integer"
+                },
+                "last_accessed_at": {
+                    "description": "最近一次访问时间（Web 或 CLI），未记录时为空",
+                    "type": "string"
+                },
+                "last_changed_at": {
+                    "description": "最近一次内容变更时间，从未变更时为空",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StorageAlertResponse": {
+            "type": "object",
+            "properties": {
+                "metric": {
+                    "description": "rows 或 bytes",
+                    "type": "string"
+                },
+                "recommendation": {
+                    "type": "string"
+                },
+                "table": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.StorageHealthResponse": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StorageAlertResponse"
+                    }
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "ok 或 warning",
+                    "type": "string"
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TableStatsResponse"
+                    }
+                }
+            }
+        },
+        "dto.TableStatsResponse": {
+            "type": "object",
+            "properties": {
+                "data_bytes": {
+                    "type": "integer"
+                },
+                "index_bytes": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "description": "InnoDB 下为估算值",
+                    "type": "integer"
+                },
+                "table": {
+                    "type": "string"
+                },
+                "total_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TagKeysRequest": {
+            "type": "object",
+            "required": [
+                "key_names"
+            ],
+            "properties": {
+                "key_names": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.TagRequest": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "maxLength": 7
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 40
+                }
+            }
+        },
+        "dto.TagResponse": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "description": "带有该标签的键数量",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TransferOwnershipRequest": {
+            "type": "object",
+            "required": [
+                "to_user_id"
+            ],
+            "properties": {
+                "to_user_id": {
+                    "description": "接收所有权的用户ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TranslationTrashResponse": {
+            "type": "object",
+            "properties": {
+                "retention_days": {
+                    "description": "删除后保留的天数，0 表示不自动清除",
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrashedTranslationResponse"
+                    }
+                }
+            }
+        },
+        "dto.TrashedTranslationResponse": {
             "type": "object",
-            "required": [
-                "key_name",
-                "project_id",
-                "translations"
-            ],
             "properties": {
-                "context": {
+                "deleted_at": {
                     "type": "string"
                 },
+                "deleted_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
                 "key_name": {
                     "type": "string"
                 },
-                "project_id": {
+                "language_code": {
+                    "type": "string"
+                },
+                "language_id": {
                     "type": "integer"
                 },
-                "translations": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "purge_at": {
+                    "description": "永久删除的时间，不自动清除时为空",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
                 }
             }
         },
-        "domain.CreateLanguageRequest": {
+        "dto.UpdateOrganizationMemberRequest": {
             "type": "object",
             "required": [
-                "code",
-                "name"
+                "role"
             ],
             "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "is_default": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "admin",
+                        "member"
+                    ]
                 }
             }
         },
-        "domain.CreateProjectRequest": {
+        "dto.UpdateOrganizationRequest": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 500
                 },
                 "name": {
-                    "type": "string"
+                    "description": "为空时不修改名称",
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
-        "domain.CreateTranslationRequest": {
+        "dto.UpdateProjectMemberRequest": {
             "type": "object",
             "required": [
-                "key_name",
-                "language_id",
-                "project_id",
-                "value"
+                "role"
             ],
             "properties": {
-                "context": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "dto.UpdateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "key_name": {
+                "export_file_template": {
+                    "description": "导出文件命名模板，如 {locale}/{namespace}.json",
                     "type": "string"
                 },
-                "language_id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "project_id": {
-                    "type": "integer"
+                "source_language": {
+                    "description": "源语言代码，为空字符串时改为使用默认语言",
+                    "type": "string"
                 },
-                "value": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "domain.DashboardStats": {
+        "dto.UpdateUserProfileRequest": {
             "type": "object",
             "properties": {
-                "total_keys": {
-                    "type": "integer"
+                "display_name": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "total_languages": {
-                    "type": "integer"
+                "language": {
+                    "description": "界面语言，BCP 47 语言标签，为空时跟随浏览器",
+                    "type": "string",
+                    "example": "zh-CN"
                 },
-                "total_projects": {
-                    "type": "integer"
+                "timezone": {
+                    "description": "IANA 时区，为空时跟随浏览器",
+                    "type": "string",
+                    "example": "Asia/Shanghai"
+                }
+            }
+        },
+        "dto.UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
                 },
-                "total_translations": {
-                    "type": "integer"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member",
+                        "viewer"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "disabled"
+                    ]
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "domain.Language": {
+        "dto.UserProfileResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "语言代码，如 en, zh-CN",
+                "avatar_url": {
+                    "description": "没有头像时为空",
                     "type": "string"
                 },
-                "created_at": {
+                "display_name": {
+                    "type": "string"
+                },
+                "email": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_default": {
-                    "description": "是否为默认语言",
-                    "type": "boolean"
-                },
-                "name": {
-                    "description": "语言名称，如 English, 简体中文",
+                "language": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "状态：active, inactive",
+                "timezone": {
                     "type": "string"
                 },
-                "updated_at": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "domain.LoginRequest": {
+        "dto.ValidateInvitationResponse": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "password"
+                "expires_at": {
+                    "type": "string"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "admin"
+                "inviter": {
+                    "$ref": "#/definitions/dto.InvitationInviter"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "remaining_uses": {
+                    "description": "邀请码剩余的使用次数",
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
                 }
             }
         },
-        "domain.LoginResponse": {
+        "dto.WebhookDeliveryListResponse": {
             "type": "object",
             "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                    }
                 },
-                "user": {
-                    "$ref": "#/definitions/domain.User"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.Project": {
+        "dto.WebhookDeliveryResponse": {
             "type": "object",
             "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
-                    "description": "项目描述",
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "event_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "description": "项目名称",
+                "last_error": {
                     "type": "string"
                 },
-                "slug": {
-                    "description": "项目标识，用于URL",
+                "next_attempt_at": {
+                    "description": "待重试时的下次推送时间",
                     "type": "string"
                 },
-                "status": {
-                    "description": "项目状态：active, archived",
+                "payload": {
+                    "description": "推送的请求体",
                     "type": "string"
                 },
-                "updated_at": {
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, delivered, failed",
                     "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.RefreshRequest": {
+        "dto.WebhookRequest": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
+                "events": {
+                    "description": "订阅的事件，创建时为空表示全部事件，更新时为空表示不修改",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "maxLength": 100
+                },
+                "preset": {
+                    "description": "使用内置模板，不能与 template 同时指定",
+                    "type": "string",
+                    "enum": [
+                        "generic",
+                        "slack",
+                        "teams"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "disabled"
+                    ]
+                },
+                "template": {
+                    "description": "消息模板（Go 模板），为空字符串表示使用内置 generic 模板，不传表示不修改",
+                    "type": "string",
+                    "maxLength": 16384
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
-        "domain.Translation": {
+        "dto.WebhookResponse": {
             "type": "object",
             "properties": {
-                "context": {
-                    "description": "上下文说明",
-                    "type": "string"
-                },
                 "created_at": {
                     "type": "string"
                 },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
-                "key_name": {
-                    "description": "翻译键名",
+                "name": {
                     "type": "string"
                 },
-                "language_id": {
-                    "description": "语言ID",
-                    "type": "integer"
-                },
                 "project_id": {
-                    "description": "关联的项目ID",
                     "type": "integer"
                 },
+                "secret": {
+                    "description": "仅在创建和重置密钥时返回",
+                    "type": "string"
+                },
                 "status": {
-                    "description": "状态：active, deprecated",
+                    "type": "string"
+                },
+                "template": {
+                    "description": "消息模板，为空表示使用内置 generic 模板",
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 },
-                "value": {
-                    "description": "翻译值",
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "domain.UpdateProjectRequest": {
+        "dto.WebhookTemplatePreviewRequest": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "event_type": {
+                    "description": "示例事件类型，默认 translation.updated",
+                    "type": "string",
+                    "enum": [
+                        "translation.updated",
+                        "translation.created",
+                        "translation.deleted",
+                        "project.created",
+                        "import.completed",
+                        "release.published"
+                    ]
                 },
-                "name": {
-                    "type": "string"
+                "payload": {
+                    "description": "自定义示例事件内容，为空时使用内置示例",
+                    "type": "object"
                 },
-                "status": {
+                "preset": {
+                    "description": "内置模板",
+                    "type": "string",
+                    "enum": [
+                        "generic",
+                        "slack",
+                        "teams"
+                    ]
+                },
+                "template": {
+                    "description": "Go 模板，为空时使用内置模板",
                     "type": "string"
+                },
+                "webhook_id": {
+                    "description": "未指定 template 和 preset 时使用该 Webhook 保存的模板",
+                    "type": "integer"
                 }
             }
         },
-        "domain.User": {
+        "dto.WebhookTemplatePreviewResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "body": {
+                    "description": "将要发送的消息体",
+                    "type": "object"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                },
-                "username": {
+                "event_type": {
                     "type": "string"
                 }
             }
@@ -1692,28 +18818,51 @@ const docTemplate = `{
         "handlers.PushKeysRequest": {
             "type": "object",
             "required": [
-                "keys",
                 "project_id"
             ],
             "properties": {
                 "defaults": {
-                    "description": "保持向后兼容（已废弃）",
+                    "description": "已废弃，保持向后兼容",
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
                     }
                 },
                 "keys": {
+                    "description": "可选：如果为空且提供了 Translations，则执行批量导入",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
                 "project_id": {
+                    "description": "项目ID或项目标识（slug）",
+                    "type": "string"
+                },
+                "translations": {
+                    "description": "语言代码 -\u003e 键值对映射",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "handlers.TranslationsPage": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "description": "获取下一页时传入的 cursor",
                     "type": "string"
                 },
                 "translations": {
-                    "description": "新增：语言代码 -\u003e 键值对映射",
+                    "description": "键名 -\u003e 语言代码 -\u003e 翻译值",
                     "type": "object",
                     "additionalProperties": {
                         "type": "object",
@@ -1756,7 +18905,12 @@ const docTemplate = `{
         "response.Meta": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "description": "游标分页时下一页的游标，没有下一页时不返回",
+                    "type": "string"
+                },
                 "page": {
+                    "description": "游标分页时不返回",
                     "type": "integer"
                 },
                 "page_size": {
@@ -1772,6 +18926,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "CLI 使用的 API Key",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "输入格式: Bearer {token}",
             "type": "apiKey",
@@ -1785,10 +18945,10 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:8080",
-	BasePath:         "/api",
+	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "YFlow API",
-	Description:      "语流是一个用于管理多语言翻译的系统。",
+	Description:      "语流是一个用于管理多语言翻译的系统。本文档描述 API v1，未带版本号的 /api 路径为 v1 的别名；v2 中不兼容的变更见 docs/api/endpoints.md。",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
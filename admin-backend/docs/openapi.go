@@ -0,0 +1,20 @@
+package docs
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// openAPIFiles 按调用方凭证筛选的规范，由 cmd/apispec 生成
+//
+//go:embed openapi/*.json
+var openAPIFiles embed.FS
+
+// AudienceSpec 返回筛选规范 audience（如 cli、viewer）的 JSON，不存在时返回 false
+func AudienceSpec(audience string) ([]byte, bool) {
+	data, err := fs.ReadFile(openAPIFiles, "openapi/"+audience+".json")
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AccessTokenHandler 个人访问令牌处理器
+type AccessTokenHandler struct {
+	tokenService domain.PersonalAccessTokenService
+	logger       *zap.Logger
+}
+
+// NewAccessTokenHandler 创建个人访问令牌处理器
+func NewAccessTokenHandler(tokenService domain.PersonalAccessTokenService, logger *zap.Logger) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		tokenService: tokenService,
+		logger:       logger,
+	}
+}
+
+// Create 创建个人访问令牌
+// @Summary      创建个人访问令牌
+// @Description  为当前用户创建访问令牌。脚本在 Authorization 头中以 Bearer 方式携带令牌，以当前用户的身份和权限访问 API。令牌只在创建时返回一次；不能使用访问令牌调用此接口
+// @Tags         个人访问令牌
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateAccessTokenRequest  true  "令牌信息"
+// @Success      201      {object}  dto.CreateAccessTokenResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/tokens [post]
+func (h *AccessTokenHandler) Create(ctx *gin.Context) {
+	var req dto.CreateAccessTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	token, plain, err := h.tokenService.Create(ctx.Request.Context(), userID.(uint64), domain.PersonalAccessTokenParams{
+		Name:          req.Name,
+		ExpiresInDays: req.ExpiresInDays,
+	})
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return
+		}
+		h.logger.Error("Failed to create access token", zap.Uint64("user_id", userID.(uint64)), zap.Error(err))
+		response.InternalServerError(ctx, "创建访问令牌失败")
+		return
+	}
+
+	h.logger.Info("Access token created",
+		zap.Uint64("token_id", token.ID),
+		zap.Uint64("user_id", token.UserID),
+		zap.String("token_prefix", token.TokenPrefix),
+	)
+
+	response.Created(ctx, &dto.CreateAccessTokenResponse{
+		AccessTokenResponse: *toAccessTokenResponse(token),
+		Token:               plain,
+	})
+}
+
+// List 获取当前用户的个人访问令牌
+// @Summary      获取个人访问令牌列表
+// @Description  返回当前用户的所有访问令牌，包括过期时间和最近使用时间，不包含令牌本身
+// @Tags         个人访问令牌
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   dto.AccessTokenResponse
+// @Failure      403  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/tokens [get]
+func (h *AccessTokenHandler) List(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	tokens, err := h.tokenService.GetByUserID(ctx.Request.Context(), userID.(uint64))
+	if err != nil {
+		response.InternalServerError(ctx, "获取访问令牌失败")
+		return
+	}
+
+	resp := make([]*dto.AccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		resp = append(resp, toAccessTokenResponse(token))
+	}
+	response.Success(ctx, resp)
+}
+
+// Revoke 撤销个人访问令牌
+// @Summary      撤销个人访问令牌
+// @Description  撤销当前用户的访问令牌，撤销后立即失效
+// @Tags         个人访问令牌
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "令牌ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/tokens/{id} [delete]
+func (h *AccessTokenHandler) Revoke(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的令牌ID")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	if err := h.tokenService.Revoke(ctx.Request.Context(), userID.(uint64), id); err != nil {
+		if err == domain.ErrAccessTokenNotFound {
+			response.NotFound(ctx, domain.ErrAccessTokenNotFound.Message)
+			return
+		}
+		response.InternalServerError(ctx, "撤销访问令牌失败")
+		return
+	}
+
+	h.logger.Info("Access token revoked", zap.Uint64("token_id", id), zap.Uint64("user_id", userID.(uint64)))
+
+	response.NoContent(ctx)
+}
+
+// toAccessTokenResponse Domain -> DTO
+func toAccessTokenResponse(token *domain.PersonalAccessToken) *dto.AccessTokenResponse {
+	resp := &dto.AccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		ExpiresAt:   token.ExpiresAt.Format(time.RFC3339),
+		Expired:     token.IsExpired(),
+		LastUsedIP:  token.LastUsedIP,
+		CreatedAt:   token.CreatedAt.Format(time.RFC3339),
+	}
+	if token.LastUsedAt != nil {
+		resp.LastUsedAt = token.LastUsedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler CLI API Key 处理器
+type APIKeyHandler struct {
+	keyService domain.APIKeyService
+	logger     *zap.Logger
+}
+
+// NewAPIKeyHandler 创建 API Key 处理器
+func NewAPIKeyHandler(keyService domain.APIKeyService, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		keyService: keyService,
+		logger:     logger,
+	}
+}
+
+// Create 创建 API Key
+// @Summary      创建 API Key
+// @Description  创建 CLI 使用的 API Key，可以限定可访问的项目和权限范围：read 只能拉取翻译和监听变更，write 还可以推送键和导入翻译。
+// @Description  CLI 在 X-API-Key 头中携带 API Key；API Key 只在创建时返回一次。不能使用个人访问令牌调用此接口
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateAPIKeyRequest  true  "API Key 信息"
+// @Success      201      {object}  dto.CreateAPIKeyResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) Create(ctx *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	key, plain, err := h.keyService.Create(ctx.Request.Context(), domain.APIKeyParams{
+		Name:       req.Name,
+		Scope:      req.Scope,
+		ProjectIDs: req.ProjectIDs,
+	}, userID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "创建 API Key 失败") {
+			h.logger.Error("Failed to create API key", zap.Uint64("user_id", userID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("API key created",
+		zap.Uint64("api_key_id", key.ID),
+		zap.Uint64("user_id", userID),
+		zap.String("key_prefix", key.KeyPrefix),
+		zap.String("scope", key.Scope),
+	)
+
+	response.Created(ctx, &dto.CreateAPIKeyResponse{
+		APIKeyResponse: *toAPIKeyResponse(key),
+		Key:            plain,
+	})
+}
+
+// List 获取 API Key 列表
+// @Summary      获取 API Key 列表
+// @Description  返回所有 API Key 的权限范围、可访问的项目和最近使用时间，不包含 API Key 本身
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   dto.APIKeyResponse
+// @Failure      403  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) List(ctx *gin.Context) {
+	keys, err := h.keyService.GetAll(ctx.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list API keys", zap.Error(err))
+		response.InternalServerError(ctx, "获取 API Key 失败")
+		return
+	}
+
+	resp := make([]*dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, toAPIKeyResponse(key))
+	}
+	response.Success(ctx, resp)
+}
+
+// Revoke 撤销 API Key
+// @Summary      撤销 API Key
+// @Description  撤销 API Key，撤销后立即失效
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "API Key ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.APIResponse
+// @Failure      403  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的 API Key ID")
+		return
+	}
+
+	if err := h.keyService.Revoke(ctx.Request.Context(), id); err != nil {
+		if !response.HandleError(ctx, err, "撤销 API Key 失败") {
+			h.logger.Error("Failed to revoke API key", zap.Uint64("api_key_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("API key revoked", zap.Uint64("api_key_id", id), zap.Uint64("user_id", ctx.GetUint64("userID")))
+
+	response.NoContent(ctx)
+}
+
+// toAPIKeyResponse Domain -> DTO
+func toAPIKeyResponse(key *domain.APIKey) *dto.APIKeyResponse {
+	projectIDs := key.Projects()
+	if projectIDs == nil {
+		projectIDs = []uint64{}
+	}
+	resp := &dto.APIKeyResponse{
+		ID:             key.ID,
+		Name:           key.Name,
+		KeyPrefix:      key.KeyPrefix,
+		Scope:          key.Scope,
+		ProjectIDs:     projectIDs,
+		OrganizationID: key.OrganizationID,
+		LastUsedIP:     key.LastUsedIP,
+		CreatedBy:      key.CreatedBy,
+		CreatedAt:      key.CreatedAt.Format(time.RFC3339),
+	}
+	if key.LastUsedAt != nil {
+		resp.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+	return resp
+}
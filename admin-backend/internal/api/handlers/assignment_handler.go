@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AssignmentHandler 任务分配处理器
+type AssignmentHandler struct {
+	assignmentService domain.AssignmentService
+	logger            *zap.Logger
+}
+
+// NewAssignmentHandler 创建任务分配处理器
+func NewAssignmentHandler(assignmentService domain.AssignmentService, logger *zap.Logger) *AssignmentHandler {
+	return &AssignmentHandler{
+		assignmentService: assignmentService,
+		logger:            logger,
+	}
+}
+
+// GetByProjectID 获取项目的任务分配
+// @Summary      获取任务分配
+// @Description  获取项目中分配给成员的翻译和审核工作，key_name 为空的分配包含该语言的所有键
+// @Tags         任务分配
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.AssignmentResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/assignments [get]
+func (h *AssignmentHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	assignments, err := h.assignmentService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取任务分配失败")
+		return
+	}
+
+	response.Success(ctx, toAssignmentResponses(assignments))
+}
+
+// Assign 分配任务
+// @Summary      分配任务
+// @Description  将一种语言（key_names 为空时）或其中的键的翻译或审核工作分配给项目的编辑者或所有者，最多 1000 个键。同一语言、键和工作类型只分配给一个人，已分配给其他人的改为分配给该成员；分配到具体键的优先于分配整个语言的。返回项目的全部分配
+// @Tags         任务分配
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                true  "项目ID"
+// @Param        request     body      dto.AssignRequest  true  "分配内容"
+// @Success      200         {object}  []dto.AssignmentResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/assignments [post]
+func (h *AssignmentHandler) Assign(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.AssignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.AssignParams{
+		UserID:       req.UserID,
+		LanguageCode: req.LanguageCode,
+		KeyNames:     req.KeyNames,
+		Type:         req.Type,
+	}
+	assignments, err := h.assignmentService.Assign(ctx.Request.Context(), projectID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "分配任务失败")
+		return
+	}
+
+	h.logger.Info("Work assigned",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("assignee_id", req.UserID),
+		zap.String("language", req.LanguageCode),
+		zap.String("type", req.Type),
+		zap.Int("keys", len(req.KeyNames)),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, toAssignmentResponses(assignments))
+}
+
+// Delete 取消任务分配
+// @Summary      取消任务分配
+// @Description  删除任务分配，已有的翻译不受影响
+// @Tags         任务分配
+// @Produce      json
+// @Param        project_id     path      int  true  "项目ID"
+// @Param        assignment_id  path      int  true  "任务分配ID"
+// @Success      200            {object}  response.APIResponse
+// @Failure      404            {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/assignments/{assignment_id} [delete]
+func (h *AssignmentHandler) Delete(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+	assignmentID, err := strconv.ParseUint(ctx.Param("assignment_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的任务分配ID")
+		return
+	}
+
+	if err := h.assignmentService.Delete(ctx.Request.Context(), projectID, assignmentID); err != nil {
+		response.HandleError(ctx, err, "取消任务分配失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// GetMyTasks 获取当前用户的待办任务
+// @Summary      获取我的待办任务
+// @Description  按分配给当前用户的任务统计待处理的工作：翻译任务为该语言缺少译文或译文为草稿的键，审核任务为该语言待审核的键。每个项目、语言和工作类型为一项任务，列出最多 100 个待处理的键；没有待处理键的任务、已停用的语言以及用户已不是编辑者或所有者的项目不列出
+// @Tags         任务分配
+// @Produce      json
+// @Success      200  {object}  domain.UserTaskList
+// @Security     BearerAuth
+// @Router       /users/me/tasks [get]
+func (h *AssignmentHandler) GetMyTasks(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	tasks, err := h.assignmentService.GetUserTasks(ctx.Request.Context(), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "获取待办任务失败")
+		return
+	}
+
+	response.Success(ctx, tasks)
+}
+
+// toAssignmentResponses 转换为响应格式
+func toAssignmentResponses(assignments []*domain.Assignment) []dto.AssignmentResponse {
+	responses := make([]dto.AssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		responses = append(responses, dto.AssignmentResponse{
+			ID:           assignment.ID,
+			UserID:       assignment.UserID,
+			Username:     assignment.User.Username,
+			LanguageCode: assignment.Language.Code,
+			KeyName:      assignment.KeyName,
+			Type:         assignment.Type,
+			UpdatedBy:    assignment.UpdatedBy,
+			UpdatedAt:    assignment.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return responses
+}
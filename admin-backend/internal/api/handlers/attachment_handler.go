@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AttachmentHandler 翻译键附件处理器
+type AttachmentHandler struct {
+	attachmentService domain.AttachmentService
+	logger            *zap.Logger
+}
+
+// NewAttachmentHandler 创建翻译键附件处理器
+func NewAttachmentHandler(attachmentService domain.AttachmentService, logger *zap.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		logger:            logger,
+	}
+}
+
+// GetByProjectID 获取项目的附件
+// @Summary      获取附件列表
+// @Description  获取项目中翻译键的附件（如界面截图），可按键名筛选
+// @Tags         附件
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        key_name    query     string  false  "键名"
+// @Success      200         {object}  []dto.AttachmentResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/attachments [get]
+func (h *AttachmentHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	attachments, err := h.attachmentService.GetByProjectID(ctx.Request.Context(), projectID, ctx.Query("key_name"))
+	if err != nil {
+		h.handleError(ctx, err, "获取附件失败")
+		return
+	}
+
+	responses := make([]dto.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		responses = append(responses, toAttachmentResponse(attachment))
+	}
+	response.Success(ctx, responses)
+}
+
+// Upload 上传附件
+// @Summary      上传附件
+// @Description  请求体为 PNG、JPEG、GIF 或 WebP 图片（最大 5 MB），作为键的视觉上下文，同一键的所有语言共用，每个键最多 20 个附件。附件按键名关联，键重命名后不随之变化
+// @Tags         附件
+// @Accept       image/png
+// @Accept       image/jpeg
+// @Accept       image/gif
+// @Accept       image/webp
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        key_name    query     string  true   "键名"
+// @Param        file_name   query     string  false  "文件名，为空时按格式生成"
+// @Success      200         {object}  dto.AttachmentResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/attachments [post]
+func (h *AttachmentHandler) Upload(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	// 多读一个字节以便服务层识别超出大小限制的附件
+	data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, service.MaxAttachmentSize+1))
+	if err != nil {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	attachment, err := h.attachmentService.Upload(ctx.Request.Context(), projectID, domain.AttachmentUploadParams{
+		KeyName:  ctx.Query("key_name"),
+		FileName: ctx.Query("file_name"),
+		Data:     data,
+	}, userID.(uint64))
+	if err != nil {
+		h.handleError(ctx, err, "上传附件失败")
+		return
+	}
+
+	response.Success(ctx, toAttachmentResponse(attachment))
+}
+
+// GetContent 获取附件内容
+// @Summary      获取附件内容
+// @Tags         附件
+// @Produce      image/png
+// @Produce      image/jpeg
+// @Produce      image/gif
+// @Produce      image/webp
+// @Param        project_id     path      int  true  "项目ID"
+// @Param        attachment_id  path      int  true  "附件ID"
+// @Success      200            {file}    file
+// @Failure      404            {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/attachments/{attachment_id}/content [get]
+func (h *AttachmentHandler) GetContent(ctx *gin.Context) {
+	projectID, attachmentID, ok := h.parseParams(ctx)
+	if !ok {
+		return
+	}
+
+	data, contentType, err := h.attachmentService.GetContent(ctx.Request.Context(), projectID, attachmentID)
+	if err != nil {
+		h.handleError(ctx, err, "获取附件内容失败")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+// Delete 删除附件
+// @Summary      删除附件
+// @Tags         附件
+// @Produce      json
+// @Param        project_id     path      int  true  "项目ID"
+// @Param        attachment_id  path      int  true  "附件ID"
+// @Success      200            {object}  response.APIResponse
+// @Failure      404            {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) Delete(ctx *gin.Context) {
+	projectID, attachmentID, ok := h.parseParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentService.Delete(ctx.Request.Context(), projectID, attachmentID); err != nil {
+		h.handleError(ctx, err, "删除附件失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "附件已删除"})
+}
+
+// parseParams 解析项目ID和附件ID
+func (h *AttachmentHandler) parseParams(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	attachmentID, err := strconv.ParseUint(ctx.Param("attachment_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的附件ID")
+		return 0, 0, false
+	}
+	return projectID, attachmentID, true
+}
+
+// handleError 将领域错误映射为HTTP响应
+func (h *AttachmentHandler) handleError(ctx *gin.Context, err error, fallback string) {
+	if !response.HandleError(ctx, err, fallback) {
+		h.logger.Error("Attachment request failed", zap.Error(err))
+	}
+}
+
+// toAttachmentResponse 转换为响应格式
+func toAttachmentResponse(attachment *domain.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          attachment.ID,
+		KeyName:     attachment.KeyName,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		URL:         domain.AttachmentContentURL(attachment.ProjectID, attachment.ID),
+		CreatedBy:   attachment.CreatedBy,
+		CreatedAt:   attachment.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogHandler 审计日志处理器
+type AuditLogHandler struct {
+	auditLogService domain.AuditLogService
+}
+
+// NewAuditLogHandler 创建审计日志处理器
+func NewAuditLogHandler(auditLogService domain.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{auditLogService: auditLogService}
+}
+
+// GetByProjectID 获取项目的审计日志
+// @Summary      获取项目审计日志
+// @Description  分页获取项目的批量操作审计记录，最新的在前。提供 cursor 或 limit 时使用游标分页，meta.next_cursor 为下一页的游标，没有下一页时不返回，此时忽略 page 和 page_size
+// @Tags         审计日志
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        page        query     int     false  "页码"      default(1)
+// @Param        page_size   query     int     false  "每页数量"  default(10)
+// @Param        cursor      query     string  false  "游标分页：上一页返回的 meta.next_cursor，为空时获取第一页"
+// @Param        limit       query     int     false  "游标分页：每页数量"  default(10)
+// @Success      200         {object}  dto.AuditLogListResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/audit-logs [get]
+func (h *AuditLogHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	if cp, ok := parseCursorPage(ctx); ok {
+		h.getPage(ctx, projectID, cp)
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	logs, total, err := h.auditLogService.GetByProjectID(ctx.Request.Context(), projectID, pageSize, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			response.NotFound(ctx, "项目不存在")
+			return
+		}
+		response.InternalServerError(ctx, "获取审计日志失败")
+		return
+	}
+	writeAuditLogs(ctx, logs, total, page, pageSize)
+}
+
+// GetSystemLogs 获取系统级审计日志
+// @Summary      获取系统审计日志
+// @Description  分页获取不属于任何项目的系统级审计记录（如用户离职处理 user.offboard，project_id 为 0），最新的在前。提供 cursor 或 limit 时使用游标分页，meta.next_cursor 为下一页的游标，没有下一页时不返回
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        page       query     int     false  "页码"      default(1)
+// @Param        page_size  query     int     false  "每页数量"  default(10)
+// @Param        cursor     query     string  false  "游标分页：上一页返回的 meta.next_cursor，为空时获取第一页"
+// @Param        limit      query     int     false  "游标分页：每页数量"  default(10)
+// @Success      200        {object}  dto.AuditLogListResponse
+// @Failure      400        {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/audit-logs [get]
+func (h *AuditLogHandler) GetSystemLogs(ctx *gin.Context) {
+	if cp, ok := parseCursorPage(ctx); ok {
+		h.getPage(ctx, 0, cp)
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	logs, total, err := h.auditLogService.GetSystemLogs(ctx.Request.Context(), pageSize, (page-1)*pageSize)
+	if err != nil {
+		response.InternalServerError(ctx, "获取审计日志失败")
+		return
+	}
+	writeAuditLogs(ctx, logs, total, page, pageSize)
+}
+
+// getPage 游标分页获取审计日志，projectID 为 0 时为系统级审计日志
+func (h *AuditLogHandler) getPage(ctx *gin.Context, projectID uint64, cp cursorPage) {
+	query := domain.AuditLogPageQuery{ProjectID: projectID, Limit: cp.limit}
+	if cp.cursor != "" {
+		createdAt, id, err := decodeTimeCursor(cp.cursor)
+		if err != nil {
+			response.BadRequest(ctx, "无效的分页游标")
+			return
+		}
+		query.After = &domain.AuditLogCursor{CreatedAt: createdAt, ID: id}
+	}
+
+	page, err := h.auditLogService.GetPage(ctx.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			response.NotFound(ctx, "项目不存在")
+			return
+		}
+		response.InternalServerError(ctx, "获取审计日志失败")
+		return
+	}
+
+	var next string
+	if page.HasMore && len(page.Logs) > 0 {
+		last := page.Logs[len(page.Logs)-1]
+		next = encodeTimeCursor(last.CreatedAt, last.ID)
+	}
+	response.SuccessWithMeta(ctx, auditLogList(page.Logs, page.Total), cursorMeta(cp.limit, page.Total, next))
+}
+
+// writeAuditLogs 返回分页的审计日志列表
+func writeAuditLogs(ctx *gin.Context, logs []*domain.AuditLog, total int64, page, pageSize int) {
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, auditLogList(logs, total), meta)
+}
+
+// auditLogList 将审计日志转换为列表响应
+func auditLogList(logs []*domain.AuditLog, total int64) dto.AuditLogListResponse {
+	resp := dto.AuditLogListResponse{
+		Logs:  make([]*dto.AuditLogResponse, 0, len(logs)),
+		Total: total,
+	}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, &dto.AuditLogResponse{
+			ID:        l.ID,
+			ProjectID: l.ProjectID,
+			UserID:    l.UserID,
+			Action:    l.Action,
+			Details:   l.Details,
+			CreatedAt: l.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
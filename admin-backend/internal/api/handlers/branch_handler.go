@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BranchHandler 翻译分支处理器
+type BranchHandler struct {
+	branchService domain.BranchService
+	logger        *zap.Logger
+}
+
+// NewBranchHandler 创建翻译分支处理器
+func NewBranchHandler(branchService domain.BranchService, logger *zap.Logger) *BranchHandler {
+	return &BranchHandler{
+		branchService: branchService,
+		logger:        logger,
+	}
+}
+
+// GetByProjectID 获取项目的分支
+// @Summary      获取分支
+// @Description  获取项目的翻译分支，按名称排序
+// @Tags         分支
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.BranchResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches [get]
+func (h *BranchHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	branches, err := h.branchService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取分支失败")
+		return
+	}
+
+	responses := make([]*dto.BranchResponse, 0, len(branches))
+	for _, branch := range branches {
+		responses = append(responses, toBranchResponse(branch))
+	}
+	response.Success(ctx, responses)
+}
+
+// Create 创建分支
+// @Summary      创建分支
+// @Description  为发布分支等场景创建翻译分支。分支采用写时复制：创建时不复制翻译，分支的翻译为主线的当前翻译叠加分支中修改过的翻译。名称不能为 main（主线）
+// @Tags         分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                true  "项目ID"
+// @Param        branch      body      dto.BranchRequest  true  "分支"
+// @Success      201         {object}  dto.BranchResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches [post]
+func (h *BranchHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.BranchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	branch, err := h.branchService.Create(ctx.Request.Context(), projectID, domain.BranchParams{
+		Name:        req.Name,
+		Description: req.Description,
+	}, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "创建分支失败")
+		return
+	}
+
+	response.Created(ctx, toBranchResponse(branch))
+}
+
+// Delete 删除分支
+// @Summary      删除分支
+// @Description  删除分支及其中修改过的翻译，主线的翻译不受影响
+// @Tags         分支
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        branch_id   path      int  true  "分支ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches/{branch_id} [delete]
+func (h *BranchHandler) Delete(ctx *gin.Context) {
+	projectID, branchID, ok := parseBranchPath(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.branchService.Delete(ctx.Request.Context(), projectID, branchID); err != nil {
+		response.HandleError(ctx, err, "删除分支失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// GetValues 获取分支的翻译
+// @Summary      获取分支的翻译
+// @Description  返回分支的全部翻译（主线的当前翻译叠加分支中修改过的翻译），格式为 键名 -> 语言代码 -> 翻译值
+// @Tags         分支
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        branch_id   path      int  true  "分支ID"
+// @Success      200         {object}  map[string]map[string]string
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches/{branch_id}/translations [get]
+func (h *BranchHandler) GetValues(ctx *gin.Context) {
+	projectID, branchID, ok := parseBranchPath(ctx)
+	if !ok {
+		return
+	}
+
+	values, err := h.branchService.GetValues(ctx.Request.Context(), projectID, branchID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取分支翻译失败")
+		return
+	}
+
+	response.Success(ctx, values)
+}
+
+// SetTranslations 修改分支中的翻译
+// @Summary      修改分支中的翻译
+// @Description  在分支中修改或删除翻译（每次最多 1000 条），主线不受影响。首次修改某条翻译时记录主线当时的值，合并时据此判断冲突。已合并的分支不能修改
+// @Tags         分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                               true  "项目ID"
+// @Param        branch_id   path      int                               true  "分支ID"
+// @Param        request     body      dto.SetBranchTranslationsRequest  true  "翻译修改"
+// @Success      200         {object}  response.APIResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches/{branch_id}/translations [put]
+func (h *BranchHandler) SetTranslations(ctx *gin.Context) {
+	projectID, branchID, ok := parseBranchPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.SetBranchTranslationsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := make([]domain.BranchTranslationParams, 0, len(req.Translations))
+	for _, item := range req.Translations {
+		params = append(params, domain.BranchTranslationParams{
+			KeyName:      item.KeyName,
+			LanguageCode: item.LanguageCode,
+			Value:        item.Value,
+			Deleted:      item.Deleted,
+		})
+	}
+
+	count, err := h.branchService.SetTranslations(ctx.Request.Context(), projectID, branchID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "修改分支翻译失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"translations": count})
+}
+
+// Diff 比较分支
+// @Summary      比较分支
+// @Description  计算将分支合并到目标分支时的差异（按键名和语言代码排序）。目标为 main（默认）时与主线比较，并标记冲突：分支修改某条翻译后主线也修改了它，base_value 为分支修改前主线的值
+// @Tags         分支
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        branch_id   path      int     true   "分支ID"
+// @Param        target      query     string  false  "目标分支名称"  default(main)
+// @Success      200         {object}  domain.BranchDiff
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches/{branch_id}/diff [get]
+func (h *BranchHandler) Diff(ctx *gin.Context) {
+	projectID, branchID, ok := parseBranchPath(ctx)
+	if !ok {
+		return
+	}
+
+	diff, err := h.branchService.Diff(ctx.Request.Context(), projectID, branchID, ctx.Query("target"))
+	if err != nil {
+		response.HandleError(ctx, err, "比较分支失败")
+		return
+	}
+
+	response.Success(ctx, diff)
+}
+
+// Merge 将分支合并到主线
+// @Summary      合并分支
+// @Description  将分支与主线的差异写入主线，分支标记为已合并。冲突必须在 resolutions 中逐条指定处理方式：branch 使用分支的值，main 保留主线的值，custom 使用 value；有未处理的冲突时返回 409 且不做任何修改，错误详情列出冲突的翻译（最多 20 条）
+// @Tags         分支
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                     true  "项目ID"
+// @Param        branch_id   path      int                     true  "分支ID"
+// @Param        request     body      dto.BranchMergeRequest  true  "冲突处理方式"
+// @Success      200         {object}  dto.BranchMergeResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/branches/{branch_id}/merge [post]
+func (h *BranchHandler) Merge(ctx *gin.Context) {
+	projectID, branchID, ok := parseBranchPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.BranchMergeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.BranchMergeParams{Resolutions: make([]domain.BranchConflictResolution, 0, len(req.Resolutions))}
+	for _, item := range req.Resolutions {
+		params.Resolutions = append(params.Resolutions, domain.BranchConflictResolution{
+			Key:        item.Key,
+			Language:   item.Language,
+			Resolution: item.Resolution,
+			Value:      item.Value,
+		})
+	}
+
+	result, err := h.branchService.Merge(ctx.Request.Context(), projectID, branchID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "合并分支失败")
+		return
+	}
+
+	h.logger.Info("Branch merged",
+		zap.Uint64("project_id", projectID),
+		zap.String("branch", result.Branch.Name),
+		zap.Int("applied", len(result.Applied)),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	applied := make([]dto.PromotionChangeResponse, 0, len(result.Applied))
+	for _, change := range result.Applied {
+		applied = append(applied, dto.PromotionChangeResponse{
+			Key:      change.Key,
+			Language: change.Language,
+			Type:     change.Type,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+	response.Success(ctx, dto.BranchMergeResponse{
+		Branch:  toBranchResponse(result.Branch),
+		Applied: applied,
+		Skipped: result.Skipped,
+	})
+}
+
+// parseBranchPath 解析项目ID和分支ID路径参数
+func parseBranchPath(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	branchID, err := strconv.ParseUint(ctx.Param("branch_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的分支ID")
+		return 0, 0, false
+	}
+	return projectID, branchID, true
+}
+
+// toBranchResponse 转换为响应格式
+func toBranchResponse(branch *domain.Branch) *dto.BranchResponse {
+	resp := &dto.BranchResponse{
+		ID:          branch.ID,
+		ProjectID:   branch.ProjectID,
+		Name:        branch.Name,
+		Description: branch.Description,
+		Status:      branch.Status,
+		CreatedBy:   branch.CreatedBy,
+		MergedBy:    branch.MergedBy,
+		CreatedAt:   branch.CreatedAt.Format(time.RFC3339),
+	}
+	if branch.MergedAt != nil {
+		resp.MergedAt = branch.MergedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// BulkDeleteHandler 按条件批量删除翻译处理器
+type BulkDeleteHandler struct {
+	bulkDeleteService domain.BulkDeleteService
+	logger            *zap.Logger
+}
+
+// NewBulkDeleteHandler 创建按条件批量删除翻译处理器
+func NewBulkDeleteHandler(bulkDeleteService domain.BulkDeleteService, logger *zap.Logger) *BulkDeleteHandler {
+	return &BulkDeleteHandler{
+		bulkDeleteService: bulkDeleteService,
+		logger:            logger,
+	}
+}
+
+// Preview 预览按条件批量删除
+// @Summary      预览按条件批量删除
+// @Description  统计符合条件（键名前缀、语言、状态、最后修改时间，至少一个）的翻译，返回部分匹配的键和确认令牌。令牌 10 分钟内有效，只能由同一用户使用
+// @Tags         批量删除
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                          true  "项目ID"
+// @Param        request     body      dto.BulkDeleteFilterRequest  true  "筛选条件"
+// @Success      200         {object}  dto.BulkDeleteResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/translations/bulk-delete/preview [post]
+func (h *BulkDeleteHandler) Preview(ctx *gin.Context) {
+	projectID, userID, ok := h.parseRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.BulkDeleteFilterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+	params, ok := toBulkDeleteParams(ctx, req)
+	if !ok {
+		return
+	}
+
+	result, err := h.bulkDeleteService.Preview(ctx.Request.Context(), projectID, params, userID)
+	if err != nil {
+		h.handleError(ctx, err, "预览批量删除失败")
+		return
+	}
+	response.Success(ctx, toBulkDeleteResponse(result))
+}
+
+// Delete 按条件批量删除
+// @Summary      按条件批量删除
+// @Description  校验确认令牌后在服务端分批删除符合条件的翻译，每批在独立的事务中执行，并写入审计日志。筛选条件需要与预览时一致；匹配的翻译在预览后发生变化时返回 409，需要重新预览；项目开启删除保护时返回 403
+// @Tags         批量删除
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                    true  "项目ID"
+// @Param        request     body      dto.BulkDeleteRequest  true  "筛选条件和确认令牌"
+// @Success      200         {object}  dto.BulkDeleteResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/translations/bulk-delete [post]
+func (h *BulkDeleteHandler) Delete(ctx *gin.Context) {
+	projectID, userID, ok := h.parseRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.BulkDeleteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+	params, ok := toBulkDeleteParams(ctx, req.BulkDeleteFilterRequest)
+	if !ok {
+		return
+	}
+	params.ConfirmationToken = req.ConfirmationToken
+
+	result, err := h.bulkDeleteService.Delete(ctx.Request.Context(), projectID, params, userID)
+	if err != nil {
+		h.handleError(ctx, err, "批量删除失败")
+		return
+	}
+
+	h.logger.Info("Bulk delete executed",
+		zap.Uint64("project_id", projectID),
+		zap.Int64("deleted", result.Deleted),
+		zap.Int("batches", result.Batches),
+		zap.Uint64("operator_id", userID),
+	)
+	response.Success(ctx, toBulkDeleteResponse(result))
+}
+
+// parseRequest 解析项目ID和当前用户
+func (h *BulkDeleteHandler) parseRequest(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return 0, 0, false
+	}
+	return projectID, userID.(uint64), true
+}
+
+// handleError 将领域错误映射为HTTP响应
+func (h *BulkDeleteHandler) handleError(ctx *gin.Context, err error, fallback string) {
+	if !response.HandleError(ctx, err, fallback) {
+		h.logger.Error(fallback, zap.Error(err))
+	}
+}
+
+// toBulkDeleteParams DTO -> Domain，解析失败时输出错误响应
+func toBulkDeleteParams(ctx *gin.Context, req dto.BulkDeleteFilterRequest) (domain.BulkDeleteParams, bool) {
+	params := domain.BulkDeleteParams{
+		KeyPrefix: req.KeyPrefix,
+		Language:  req.Language,
+		Status:    req.Status,
+	}
+	if req.OlderThan != "" {
+		olderThan, err := time.Parse(time.RFC3339, req.OlderThan)
+		if err != nil {
+			response.ValidationError(ctx, "older_than 需要为 RFC3339 格式的时间")
+			return params, false
+		}
+		params.OlderThan = &olderThan
+	}
+	return params, true
+}
+
+// toBulkDeleteResponse Domain -> DTO
+func toBulkDeleteResponse(result *domain.BulkDeleteResult) dto.BulkDeleteResponse {
+	resp := dto.BulkDeleteResponse{
+		KeyPrefix:         result.KeyPrefix,
+		Language:          result.Language,
+		Status:            result.Status,
+		Keys:              result.Keys,
+		Translations:      result.Translations,
+		SampleKeys:        result.SampleKeys,
+		ConfirmationToken: result.ConfirmationToken,
+		Deleted:           result.Deleted,
+		Batches:           result.Batches,
+	}
+	if result.OlderThan != nil {
+		resp.OlderThan = result.OlderThan.Format(time.RFC3339)
+	}
+	if result.ExpiresAt != nil {
+		resp.ExpiresAt = result.ExpiresAt.Format(time.RFC3339)
+	}
+	return resp
+}
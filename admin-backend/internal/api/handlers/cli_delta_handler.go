@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"yflow/internal/api/middleware"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CLIDeltaHandler CLI 增量同步处理器
+type CLIDeltaHandler struct {
+	projectService domain.ProjectService
+	deltaService   domain.TranslationDeltaService
+	maxKeys        int
+}
+
+// NewCLIDeltaHandler 创建 CLI 增量同步处理器
+// maxKeys 为一次增量同步允许返回的最大键数量，不大于 0 时使用不分页拉取的默认上限
+func NewCLIDeltaHandler(projectService domain.ProjectService, deltaService domain.TranslationDeltaService, maxKeys int) *CLIDeltaHandler {
+	if maxKeys <= 0 {
+		maxKeys = defaultCLIPullMaxKeys
+	}
+	return &CLIDeltaHandler{
+		projectService: projectService,
+		deltaService:   deltaService,
+		maxKeys:        maxKeys,
+	}
+}
+
+// GetDelta 增量同步翻译
+// @Summary      增量同步翻译
+// @Description  返回同步起点之后变更的键，供移动端 SDK 和 CLI 增量更新本地翻译，不需要每次下载全部翻译。
+// @Description  since 为 RFC 3339 时间或发布版本标签（从该版本的发布时间开始）；响应中的 synced_at 作为下次同步的 since。
+// @Description  translations 为变更的键在各语言的当前翻译，客户端应按键整体替换；deleted 为已删除、废弃或被重命名的键。
+// @Description  变更的键超过服务端上限时返回 TOO_MANY_CHANGES，客户端应改为全量拉取 /cli/translations。支持 gzip 压缩。
+// @Tags         CLI
+// @Produce      json
+// @Param        project_id  query     string  true   "项目ID或项目标识（slug），旧标识同样可用"
+// @Param        since       query     string  true   "同步起点：RFC 3339 时间（如 2026-03-01T12:00:00Z）或发布版本标签"
+// @Param        locale      query     string  false  "语言代码；auto 时按 Accept-Language 从启用的语言中协商"
+// @Param        namespace   query     string  false  "命名空间，只返回以 namespace. 开头的键"
+// @Success      200         {object}  response.APIResponse{data=domain.TranslationDelta}
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     ApiKeyAuth
+// @Router       /cli/translations/delta [get]
+func (h *CLIDeltaHandler) GetDelta(ctx *gin.Context) {
+	projectIDStr := ctx.Query("project_id")
+	if projectIDStr == "" {
+		response.BadRequest(ctx, "project_id is required")
+		return
+	}
+
+	project, err := h.projectService.GetByIdentifier(ctx.Request.Context(), projectIDStr)
+	if err != nil {
+		response.HandleError(ctx, err, "获取项目失败")
+		return
+	}
+	if err := middleware.AuthorizeAPIKey(ctx, project.ID, domain.APIKeyScopeRead); err != nil {
+		response.HandleError(ctx, err, "API Key 校验失败")
+		return
+	}
+	ctx.Set("projectID", project.ID)
+
+	query := domain.TranslationDeltaQuery{
+		ProjectID: project.ID,
+		Since:     ctx.Query("since"),
+		Locale:    middleware.NegotiatedLocale(ctx, "locale"),
+		MaxKeys:   h.maxKeys,
+	}
+	if namespace := strings.TrimSuffix(ctx.Query("namespace"), "."); namespace != "" {
+		query.KeyPrefix = namespace + "."
+	}
+
+	delta, err := h.deltaService.GetDelta(ctx.Request.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrTooManyChanges) {
+			response.Error(ctx, http.StatusBadRequest, domain.ErrTooManyChanges.Code,
+				fmt.Sprintf("more than %d keys changed since %s, pull all translations instead", h.maxKeys, query.Since))
+			return
+		}
+		response.HandleError(ctx, err, "获取增量翻译失败")
+		return
+	}
+	response.Success(ctx, delta)
+}
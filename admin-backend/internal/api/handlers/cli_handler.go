@@ -1,36 +1,58 @@
 package handlers
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"yflow/internal/api/middleware"
 	"yflow/internal/api/response"
 	"yflow/internal/domain"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// defaultCLIPullMaxKeys 未配置时不分页拉取翻译允许的最大键数量
+	defaultCLIPullMaxKeys = 10000
+	// defaultCLIPageSize 分页拉取翻译时的默认每页键数量
+	defaultCLIPageSize = 1000
+	// maxCLIPageSize 分页拉取翻译时的最大每页键数量
+	maxCLIPageSize = 5000
+)
+
 // CLIHandler CLI处理器
 type CLIHandler struct {
 	translationService domain.TranslationService
 	projectService     domain.ProjectService
-	languageService    domain.LanguageService
+	keyPushService     domain.KeyPushService
+	pullMaxKeys        int
 }
 
 // NewCLIHandler 创建CLI处理器
+// pullMaxKeys 为不分页拉取翻译时允许的最大键数量，不大于 0 时使用默认值
 func NewCLIHandler(
 	translationService domain.TranslationService,
 	projectService domain.ProjectService,
-	languageService domain.LanguageService,
+	keyPushService domain.KeyPushService,
+	pullMaxKeys int,
 ) *CLIHandler {
+	if pullMaxKeys <= 0 {
+		pullMaxKeys = defaultCLIPullMaxKeys
+	}
 	return &CLIHandler{
 		translationService: translationService,
 		projectService:     projectService,
-		languageService:    languageService,
+		keyPushService:     keyPushService,
+		pullMaxKeys:        pullMaxKeys,
 	}
 }
 
 // Auth CLI身份验证
 // @Summary      CLI身份验证
-// @Description  验证CLI API Key
+// @Description  验证CLI API Key。使用管理员创建的 API Key 时返回其权限范围（scope）和可访问的项目（project_ids，为空时可访问所有项目）
 // @Tags         CLI
 // @Accept       json
 // @Produce      json
@@ -40,28 +62,42 @@ func NewCLIHandler(
 // @Router       /cli/auth [get]
 func (h *CLIHandler) Auth(ctx *gin.Context) {
 	// API Key认证由中间件处理，能到这里说明认证成功
-	response.Success(ctx, gin.H{
+	result := gin.H{
 		"status":  "ok",
 		"message": "CLI authentication successful",
-	})
+		"scope":   domain.APIKeyScopeWrite,
+	}
+	if key := middleware.APIKeyFromContext(ctx); key != nil {
+		result["scope"] = key.Scope
+		result["project_ids"] = key.Projects()
+	}
+	response.Success(ctx, result)
 }
 
 // GetTranslations 获取翻译数据
 // @Summary      获取翻译数据
-// @Description  获取项目翻译数据供CLI使用
+// @Description  获取项目翻译数据供CLI使用。可按语言和命名空间（键名中第一个 "." 之前的部分）筛选；
+// @Description  不分页时 data 为 键名 -> 语言代码 -> 翻译值 的映射；指定 limit 或 cursor 时按键名分页返回 TranslationsPage，next_cursor 用于获取下一页。
+// @Description  不分页时键数量超过服务端上限返回 TOO_MANY_KEYS，客户端应改为分页拉取。支持 gzip 压缩。
 // @Tags         CLI
 // @Accept       json
 // @Produce      json
-// @Param        project_id  query     string  false  "项目ID"
-// @Param        locale      query     string  false  "语言代码"
-// @Success      200         {object}  response.APIResponse
+// @Param        project_id  query     string  false  "项目ID或项目标识（slug），旧标识同样可用"
+// @Param        locale      query     string  false  "语言代码；auto 时按 Accept-Language 从启用的语言中协商，选择的语言在 Content-Language 响应头中返回"
+// @Param        namespace   query     string  false  "命名空间，只返回以 namespace. 开头的键"
+// @Param        release     query     string  false  "发布版本标签，指定时返回该版本冻结的翻译，用于可重现的构建"
+// @Param        limit       query     int     false  "分页模式下每页的键数量，最大 5000"  default(1000)
+// @Param        cursor      query     string  false  "上一页返回的 next_cursor"
+// @Success      200         {object}  response.APIResponse{data=TranslationsPage}
+// @Header       200         {string}  Content-Language         "locale=auto 时协商出的语言代码"
+// @Header       200         {string}  X-YFlow-Locale-Fallback  "locale=auto 且没有匹配的语言、使用默认语言时为 true"
 // @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
 // @Failure      404         {object}  response.APIResponse
 // @Security     ApiKeyAuth
 // @Router       /cli/translations [get]
 func (h *CLIHandler) GetTranslations(ctx *gin.Context) {
 	projectIDStr := ctx.Query("project_id")
-	locale := ctx.Query("locale")
 
 	// 如果没有指定项目ID，返回错误
 	if projectIDStr == "" {
@@ -69,14 +105,8 @@ func (h *CLIHandler) GetTranslations(ctx *gin.Context) {
 		return
 	}
 
-	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
-	if err != nil {
-		response.BadRequest(ctx, "invalid project_id")
-		return
-	}
-
-	// 验证项目是否存在
-	_, err = h.projectService.GetByID(ctx.Request.Context(), projectID)
+	// 按项目ID或slug查找项目
+	project, err := h.projectService.GetByIdentifier(ctx.Request.Context(), projectIDStr)
 	if err != nil {
 		switch err {
 		case domain.ErrProjectNotFound:
@@ -86,63 +116,122 @@ func (h *CLIHandler) GetTranslations(ctx *gin.Context) {
 		}
 		return
 	}
-
-	// 获取翻译矩阵数据（不分页，获取所有数据）
-	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
-	if err != nil {
-		response.InternalServerError(ctx, "获取翻译数据失败")
+	// API Key 限定了项目时，按解析后的项目ID检查
+	if err := middleware.AuthorizeAPIKey(ctx, project.ID, domain.APIKeyScopeRead); err != nil {
+		response.HandleError(ctx, err, "API Key 校验失败")
 		return
 	}
+	// 项目ID可能是 slug，记录项目访问时使用解析后的ID
+	ctx.Set("projectID", project.ID)
 
-	// 转换为简单格式 (key -> language -> value)
-	simpleMatrix := make(map[string]map[string]string)
-	for key, langs := range matrix {
-		simpleMatrix[key] = make(map[string]string)
-		for lang, cell := range langs {
-			simpleMatrix[key][lang] = cell.Value
+	query := domain.TranslationKeyPageQuery{
+		ProjectID: project.ID,
+		Locale:    middleware.NegotiatedLocale(ctx, "locale"),
+		Release:   ctx.Query("release"),
+	}
+	// 命名空间为键名中第一个 "." 之前的部分
+	if namespace := strings.TrimSuffix(ctx.Query("namespace"), "."); namespace != "" {
+		query.KeyPrefix = namespace + "."
+	}
+
+	limitStr, cursor := ctx.Query("limit"), ctx.Query("cursor")
+	if limitStr == "" && cursor == "" {
+		// 不分页：一次返回全部翻译，键数量超过上限时要求客户端分页
+		query.Limit = h.pullMaxKeys
+		page, err := h.translationService.GetKeyPage(ctx.Request.Context(), query)
+		if err != nil {
+			h.handleGetTranslationsError(ctx, err)
+			return
+		}
+		if page.HasMore {
+			response.Error(ctx, http.StatusBadRequest, "TOO_MANY_KEYS",
+				fmt.Sprintf("project has more than %d keys, use limit and cursor to paginate", h.pullMaxKeys))
+			return
 		}
+		response.Success(ctx, page.Translations)
+		return
 	}
 
-	// 如果指定了locale，只返回该语言的数据
-	if locale != "" {
-		filteredMatrix := make(map[string]map[string]string)
-		for key, translations := range simpleMatrix {
-			if value, exists := translations[locale]; exists {
-				filteredMatrix[key] = map[string]string{locale: value}
-			}
+	// 分页：按键名顺序返回，cursor 为上一页最后一个键名
+	query.Limit = defaultCLIPageSize
+	if limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			response.BadRequest(ctx, "invalid limit")
+			return
 		}
-		response.Success(ctx, filteredMatrix)
+		query.Limit = min(limit, maxCLIPageSize)
+	}
+	if cursor != "" {
+		afterKey, err := decodeTranslationsCursor(cursor)
+		if err != nil {
+			response.BadRequest(ctx, "invalid cursor")
+			return
+		}
+		query.AfterKey = afterKey
+	}
+
+	page, err := h.translationService.GetKeyPage(ctx.Request.Context(), query)
+	if err != nil {
+		h.handleGetTranslationsError(ctx, err)
 		return
 	}
 
-	// 返回完整的翻译矩阵
-	response.Success(ctx, simpleMatrix)
+	result := TranslationsPage{Translations: page.Translations, HasMore: page.HasMore}
+	if page.HasMore {
+		result.NextCursor = encodeTranslationsCursor(page.LastKey)
+	}
+	response.Success(ctx, result)
+}
+
+// handleGetTranslationsError 拉取翻译失败时的响应，指定的发布版本不存在时返回 404
+func (h *CLIHandler) handleGetTranslationsError(ctx *gin.Context, err error) {
+	if errors.Is(err, domain.ErrReleaseNotFound) {
+		response.NotFound(ctx, err.Error())
+		return
+	}
+	response.InternalServerError(ctx, "获取翻译数据失败")
+}
+
+// TranslationsPage 分页拉取翻译的响应
+type TranslationsPage struct {
+	Translations map[string]map[string]string `json:"translations"`          // 键名 -> 语言代码 -> 翻译值
+	NextCursor   string                       `json:"next_cursor,omitempty"` // 获取下一页时传入的 cursor
+	HasMore      bool                         `json:"has_more"`
+}
+
+// encodeTranslationsCursor 将键名编码为分页游标
+func encodeTranslationsCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeTranslationsCursor 解析分页游标
+func decodeTranslationsCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) == 0 {
+		return "", errors.New("invalid cursor")
+	}
+	return string(key), nil
 }
 
 // PushKeysRequest 推送键请求
 type PushKeysRequest struct {
-	ProjectID    string                       `json:"project_id" binding:"required"`
+	ProjectID    string                       `json:"project_id" binding:"required"` // 项目ID或项目标识（slug）
 	Keys         []string                     `json:"keys"`                  // 可选：如果为空且提供了 Translations，则执行批量导入
 	Defaults     map[string]string            `json:"defaults"`              // 已废弃，保持向后兼容
 	Translations map[string]map[string]string `json:"translations"`          // 语言代码 -> 键值对映射
 }
 
-// PushKeysResponse 推送键响应
-type PushKeysResponse struct {
-	Added   []string `json:"added"`
-	Existed []string `json:"existed"`
-	Failed  []string `json:"failed"`
-}
-
 // PushKeys 推送翻译键
 // @Summary      推送翻译键或批量导入翻译
-// @Description  从CLI推送新的翻译键，或批量导入/更新翻译数据
+// @Description  从CLI推送新的翻译键，或批量导入/更新翻译数据。需要 write 权限范围的 API Key
 // @Tags         CLI
 // @Accept       json
 // @Produce      json
 // @Param        request  body      PushKeysRequest  true  "推送键请求"
-// @Success      200      {object}  response.APIResponse
+// @Success      200      {object}  response.APIResponse{data=domain.PushKeysResult}
 // @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
 // @Failure      404      {object}  response.APIResponse
 // @Security     ApiKeyAuth
 // @Router       /cli/keys [post]
@@ -153,14 +242,8 @@ func (h *CLIHandler) PushKeys(ctx *gin.Context) {
 		return
 	}
 
-	projectID, err := strconv.ParseUint(req.ProjectID, 10, 64)
-	if err != nil {
-		response.BadRequest(ctx, "invalid project_id")
-		return
-	}
-
-	// 验证项目是否存在
-	_, err = h.projectService.GetByID(ctx.Request.Context(), projectID)
+	// 按项目ID或slug查找项目
+	project, err := h.projectService.GetByIdentifier(ctx.Request.Context(), req.ProjectID)
 	if err != nil {
 		switch err {
 		case domain.ErrProjectNotFound:
@@ -170,208 +253,24 @@ func (h *CLIHandler) PushKeys(ctx *gin.Context) {
 		}
 		return
 	}
-
-	// 获取所有语言
-	languages, err := h.languageService.GetAll(ctx.Request.Context())
-	if err != nil {
-		response.InternalServerError(ctx, "获取语言列表失败")
-		return
-	}
-
-	// 创建语言代码到ID的映射
-	languageCodeToID := make(map[string]uint64)
-	for _, lang := range languages {
-		languageCodeToID[lang.Code] = lang.ID
-	}
-
-	// 判断操作类型：批量导入或推送键
-	if len(req.Keys) == 0 && req.Translations != nil && len(req.Translations) > 0 {
-		// 批量导入模式
-		h.handleBulkImport(ctx, projectID, req.Translations, languageCodeToID)
-		return
-	}
-
-	// 推送键模式（原逻辑）
-	h.handlePushKeys(ctx, projectID, req, languages, languageCodeToID)
-}
-
-// handleBulkImport 处理批量导入翻译
-func (h *CLIHandler) handleBulkImport(
-	ctx *gin.Context,
-	projectID uint64,
-	translations map[string]map[string]string,
-	languageCodeToID map[string]uint64,
-) {
-	// 获取现有的翻译键，用于判断新增或更新
-	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
-	if err != nil {
-		response.InternalServerError(ctx, "获取现有翻译失败")
-		return
-	}
-
-	var added []string
-	var existed []string
-	var failed []string
-
-	// 收集所有要导入的翻译
-	var inputs []domain.TranslationInput
-
-	for langCode, langTranslations := range translations {
-		langID, exists := languageCodeToID[langCode]
-		if !exists {
-			// 忽略未知语言
-			continue
-		}
-
-		for key, value := range langTranslations {
-			// 跳过空值
-			if value == "" {
-				continue
-			}
-
-			// 判断是新增还是更新
-			if _, keyExists := matrix[key]; keyExists {
-				if !containsString(existed, key) {
-					existed = append(existed, key)
-				}
-			} else {
-				if !containsString(added, key) && !containsString(existed, key) {
-					added = append(added, key)
-				}
-			}
-
-			inputs = append(inputs, domain.TranslationInput{
-				ProjectID:  projectID,
-				KeyName:    key,
-				LanguageID: langID,
-				Value:      value,
-			})
-		}
-	}
-
-	if len(inputs) == 0 {
-		response.Success(ctx, PushKeysResponse{
-			Added:   []string{},
-			Existed: existed,
-			Failed:  []string{},
-		})
+	projectID := project.ID
+	// 项目在请求体中指定，按项目所属的组织读取语言和检查 API Key
+	middleware.SetOrganizationScope(ctx, project.OrganizationID)
+	if err := middleware.AuthorizeAPIKey(ctx, projectID, domain.APIKeyScopeWrite); err != nil {
+		response.HandleError(ctx, err, "API Key 校验失败")
 		return
 	}
+	ctx.Set("projectID", projectID)
 
-	// 使用 UpsertBatch 进行批量导入/更新
-	err = h.translationService.UpsertBatch(ctx.Request.Context(), inputs)
-	if err != nil {
-		// 如果失败，标记所有键为失败
-		for _, key := range added {
-			failed = append(failed, key)
-		}
-		added = []string{}
-	}
-
-	result := PushKeysResponse{
-		Added:   added,
-		Existed: existed,
-		Failed:  failed,
-	}
-
-	response.Success(ctx, result)
-}
-
-// handlePushKeys 处理推送键（原逻辑）
-func (h *CLIHandler) handlePushKeys(
-	ctx *gin.Context,
-	projectID uint64,
-	req PushKeysRequest,
-	languages []*domain.Language,
-	languageCodeToID map[string]uint64,
-) {
-	// 获取现有的翻译键
-	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
+	result, err := h.keyPushService.Push(ctx.Request.Context(), domain.PushKeysParams{
+		ProjectID:    projectID,
+		Keys:         req.Keys,
+		Defaults:     req.Defaults,
+		Translations: req.Translations,
+	})
 	if err != nil {
-		response.InternalServerError(ctx, "获取现有翻译失败")
+		response.InternalServerError(ctx, "推送翻译键失败")
 		return
 	}
-
-	// 找到默认语言
-	var defaultLanguage *domain.Language
-	for _, lang := range languages {
-		if lang.IsDefault {
-			defaultLanguage = lang
-			break
-		}
-	}
-	if defaultLanguage == nil && len(languages) > 0 {
-		defaultLanguage = languages[0]
-	}
-
-	var added []string
-	var existed []string
-	var failed []string
-
-	// 处理每个键
-	for _, key := range req.Keys {
-		if _, exists := matrix[key]; exists {
-			existed = append(existed, key)
-			continue
-		}
-
-		// 为所有语言创建新的翻译记录
-		keyAdded := false
-		keyFailed := false
-
-		for _, language := range languages {
-			// 确定翻译值
-			var value string
-
-			// 优先使用新的多语言数据结构
-			if req.Translations != nil {
-				if langTranslations, exists := req.Translations[language.Code]; exists {
-					value = langTranslations[key]
-				}
-			} else {
-				// 向后兼容：使用旧的 Defaults 字段
-				if language.Code == defaultLanguage.Code {
-					value = req.Defaults[key]
-				}
-			}
-
-			input := domain.TranslationInput{
-				ProjectID:  projectID,
-				KeyName:    key,
-				LanguageID: language.ID,
-				Value:      value,
-			}
-
-			_, err := h.translationService.Create(ctx.Request.Context(), input, 1)
-			if err != nil {
-				keyFailed = true
-			} else if !keyAdded {
-				keyAdded = true
-			}
-		}
-
-		if keyFailed && !keyAdded {
-			failed = append(failed, key)
-		} else if keyAdded {
-			added = append(added, key)
-		}
-	}
-
-	result := PushKeysResponse{
-		Added:   added,
-		Existed: existed,
-		Failed:  failed,
-	}
-
 	response.Success(ctx, result)
 }
-
-// containsString 检查字符串是否在切片中
-func containsString(slice []string, target string) bool {
-	for _, s := range slice {
-		if s == target {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+	"yflow/internal/api/middleware"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// watchPingInterval 心跳间隔，用于保持连接和检测断开的客户端
+	watchPingInterval = 30 * time.Second
+	// watchWriteTimeout 单条消息的写入超时
+	watchWriteTimeout = 10 * time.Second
+)
+
+// CLIWatchHandler CLI 监听模式处理器
+type CLIWatchHandler struct {
+	projectService domain.ProjectService
+	watchService   domain.WatchService
+	logger         *zap.Logger
+}
+
+// NewCLIWatchHandler 创建 CLI 监听模式处理器
+func NewCLIWatchHandler(projectService domain.ProjectService, watchService domain.WatchService, logger *zap.Logger) *CLIWatchHandler {
+	return &CLIWatchHandler{
+		projectService: projectService,
+		watchService:   watchService,
+		logger:         logger,
+	}
+}
+
+// Watch 监听翻译变更
+// @Summary      监听翻译变更（WebSocket）
+// @Description  升级为 WebSocket 连接后推送项目的翻译变更，供 `yflow watch` 在本地开发时热更新翻译，不需要轮询 /cli/translations。
+// @Description  每条消息为 JSON：连接建立后先发送 ready，之后发送 changed（包含变更的键和语言）和定期的 ping；
+// @Description  收到 resync 或连接断开时变更可能丢失，客户端应全量拉取后重新连接。客户端发送的消息会被忽略。
+// @Tags         CLI
+// @Param        project_id  query     string  true   "项目ID或项目标识（slug），旧标识同样可用"
+// @Param        locales     query     string  false  "只接收这些语言的变更，逗号分隔，如 en,zh-CN"
+// @Success      101         {object}  domain.WatchEvent
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     ApiKeyAuth
+// @Router       /cli/watch [get]
+func (h *CLIWatchHandler) Watch(ctx *gin.Context) {
+	projectIDStr := ctx.Query("project_id")
+	if projectIDStr == "" {
+		response.BadRequest(ctx, "project_id is required")
+		return
+	}
+
+	project, err := h.projectService.GetByIdentifier(ctx.Request.Context(), projectIDStr)
+	if err != nil {
+		switch err {
+		case domain.ErrProjectNotFound:
+			response.NotFound(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "获取项目失败")
+		}
+		return
+	}
+	if err := middleware.AuthorizeAPIKey(ctx, project.ID, domain.APIKeyScopeRead); err != nil {
+		response.HandleError(ctx, err, "API Key 校验失败")
+		return
+	}
+	ctx.Set("projectID", project.ID)
+
+	var locales []string
+	for _, locale := range strings.Split(ctx.Query("locales"), ",") {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+
+	// CLI 不发送 Origin，API Key 只能通过请求头传递，浏览器无法跨站发起认证的连接，因此不校验 Origin
+	server := websocket.Server{Handler: func(conn *websocket.Conn) {
+		h.serve(conn, project.ID, locales)
+	}}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// serve 推送变更直到客户端断开或订阅被关闭
+func (h *CLIWatchHandler) serve(conn *websocket.Conn, projectID uint64, locales []string) {
+	events, cancel := h.watchService.Watch(projectID, locales)
+	defer cancel()
+
+	// 读取并丢弃客户端消息，读取失败表示客户端已断开
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for websocket.Message.Receive(conn, &discard) == nil {
+		}
+	}()
+
+	h.logger.Info("CLI watch connected", zap.Uint64("project_id", projectID), zap.Strings("locales", locales))
+	defer h.logger.Info("CLI watch disconnected", zap.Uint64("project_id", projectID))
+
+	if !h.send(conn, domain.WatchEvent{Type: domain.WatchMessageReady, ProjectID: projectID, OccurredAt: time.Now()}) {
+		return
+	}
+
+	ticker := time.NewTicker(watchPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				h.send(conn, domain.WatchEvent{Type: domain.WatchMessageResync, ProjectID: projectID, OccurredAt: time.Now()})
+				return
+			}
+			if !h.send(conn, event) {
+				return
+			}
+		case <-ticker.C:
+			if !h.send(conn, domain.WatchEvent{Type: domain.WatchMessagePing, ProjectID: projectID, OccurredAt: time.Now()}) {
+				return
+			}
+		}
+	}
+}
+
+// send 发送一条消息，失败时返回 false
+func (h *CLIWatchHandler) send(conn *websocket.Conn, event domain.WatchEvent) bool {
+	if err := conn.SetWriteDeadline(time.Now().Add(watchWriteTimeout)); err != nil {
+		return false
+	}
+	return websocket.JSON.Send(conn, event) == nil
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ComplianceHandler 历史导出和合规报告处理器
+type ComplianceHandler struct {
+	complianceService domain.ComplianceService
+	logger            *zap.Logger
+}
+
+// NewComplianceHandler 创建历史导出和合规报告处理器
+func NewComplianceHandler(complianceService domain.ComplianceService, logger *zap.Logger) *ComplianceHandler {
+	return &ComplianceHandler{
+		complianceService: complianceService,
+		logger:            logger,
+	}
+}
+
+// ExportHistory 导出项目的翻译变更历史或审计日志
+// @Summary      导出历史记录
+// @Description  按时间范围流式导出项目的翻译变更历史（source=translations）或审计日志（source=audit），格式为 CSV 或 JSONL。from 和 to 可以是日期（to 包含当天）或 RFC3339 时间。导出操作本身会记录到审计日志
+// @Tags         审计日志
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        from        query     string  true   "开始时间"  example(2026-01-01)
+// @Param        to          query     string  true   "结束时间"  example(2026-03-31)
+// @Param        source      query     string  false  "导出来源"  Enums(translations, audit)  default(translations)
+// @Param        format      query     string  false  "导出格式"  Enums(csv, jsonl)  default(csv)
+// @Success      200         {file}    file
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/history/export [get]
+func (h *ComplianceHandler) ExportHistory(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	from, fromOK := parseHistoryTime(ctx.Query("from"), false)
+	to, toOK := parseHistoryTime(ctx.Query("to"), true)
+	if !fromOK || !toOK {
+		response.BadRequest(ctx, domain.ErrInvalidHistoryRange.Message)
+		return
+	}
+
+	params := domain.HistoryExportParams{
+		ProjectID: projectID,
+		Source:    ctx.DefaultQuery("source", domain.HistorySourceTranslations),
+		Format:    ctx.DefaultQuery("format", domain.HistoryFormatCSV),
+		From:      from,
+		To:        to,
+	}
+	contentType := "text/csv; charset=utf-8"
+	if params.Format == domain.HistoryFormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+	out := &streamWriter{
+		ctx:         ctx,
+		contentType: contentType,
+		filename: fmt.Sprintf("project-%d-%s-%s-%s.%s", projectID, params.Source,
+			from.Format("20060102"), to.Add(-time.Second).Format("20060102"), params.Format),
+	}
+
+	err = h.complianceService.ExportHistory(ctx.Request.Context(), params, userID.(uint64), out)
+	if err != nil && !out.started {
+		response.HandleError(ctx, err, "导出历史记录失败")
+		return
+	}
+	if err != nil {
+		// 已开始输出，无法再返回错误响应，中断连接让客户端得到不完整的文件
+		h.logger.Error("History export interrupted",
+			zap.Uint64("project_id", projectID),
+			zap.String("source", params.Source),
+			zap.Error(err),
+		)
+		ctx.Abort()
+		return
+	}
+	out.start()
+}
+
+// GetReport 获取合规报告
+// @Summary      获取合规报告
+// @Description  按项目和月份汇总每个用户的翻译变更（按操作类型）、审计日志记录的操作和数据导出次数，用于合规审计。from 和 to 为月份，包含起止月份，最多 24 个月；不指定 project_id 时包含全部项目
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        from        query     string  true   "开始月份"  example(2026-01)
+// @Param        to          query     string  true   "结束月份"  example(2026-06)
+// @Param        project_id  query     int     false  "项目ID"
+// @Success      200         {object}  dto.ComplianceReportResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/compliance-report [get]
+func (h *ComplianceHandler) GetReport(ctx *gin.Context) {
+	var projectID uint64
+	if raw := ctx.Query("project_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			response.ValidationError(ctx, "无效的项目ID")
+			return
+		}
+		projectID = id
+	}
+
+	report, err := h.complianceService.GetReport(ctx.Request.Context(), domain.ComplianceReportParams{
+		ProjectID: projectID,
+		From:      ctx.Query("from"),
+		To:        ctx.Query("to"),
+	})
+	if err != nil {
+		response.HandleError(ctx, err, "获取合规报告失败")
+		return
+	}
+
+	resp := dto.ComplianceReportResponse{
+		From:     report.From,
+		To:       report.To,
+		Projects: make([]*dto.ComplianceProjectResponse, 0, len(report.Projects)),
+	}
+	for _, project := range report.Projects {
+		projectResp := &dto.ComplianceProjectResponse{
+			ProjectID:   project.ProjectID,
+			ProjectName: project.ProjectName,
+			Months:      make([]*dto.ComplianceMonthResponse, 0, len(project.Months)),
+		}
+		for _, month := range project.Months {
+			monthResp := &dto.ComplianceMonthResponse{
+				Month: month.Month,
+				Users: make([]*dto.ComplianceUserActivityResponse, 0, len(month.Users)),
+			}
+			for _, user := range month.Users {
+				monthResp.Users = append(monthResp.Users, &dto.ComplianceUserActivityResponse{
+					UserID:   user.UserID,
+					Username: user.Username,
+					Changes:  user.Changes,
+					Actions:  user.Actions,
+					Exports:  user.Exports,
+				})
+			}
+			projectResp.Months = append(projectResp.Months, monthResp)
+		}
+		resp.Projects = append(resp.Projects, projectResp)
+	}
+
+	response.Success(ctx, resp)
+}
+
+// parseHistoryTime 解析日期（2006-01-02）或 RFC3339 时间
+// 作为结束时间的日期包含当天，返回次日零点
+func parseHistoryTime(value string, end bool) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, true
+}
+
+// streamWriter 流式响应的写入器，第一次写入时才发送响应头
+// 写入前出错时仍可以返回 JSON 错误响应
+type streamWriter struct {
+	ctx         *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+// start 发送响应头
+func (w *streamWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+	w.ctx.Header("Content-Type", w.contentType)
+	w.ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.filename))
+	w.ctx.Status(http.StatusOK)
+	w.ctx.Writer.WriteHeaderNow()
+}
+
+// Write 写入响应体
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.start()
+	return w.ctx.Writer.Write(p)
+}
+
+// Flush 将已写入的内容发送给客户端
+func (w *streamWriter) Flush() {
+	if w.started {
+		w.ctx.Writer.Flush()
+	}
+}
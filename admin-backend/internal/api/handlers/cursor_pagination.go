@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"yflow/internal/api/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errInvalidCursor 分页游标无法解析
+var errInvalidCursor = errors.New("invalid cursor")
+
+// cursorPage 游标分页参数
+type cursorPage struct {
+	cursor string // 上一页返回的 next_cursor，为空时从第一条开始
+	limit  int
+}
+
+// parseCursorPage 解析 cursor 和 limit 参数，两者都未提供时返回 false，表示使用 page/page_size 分页
+// limit 超出 1..100 时使用默认值 10，与 page_size 一致
+func parseCursorPage(ctx *gin.Context) (cursorPage, bool) {
+	cursor, hasCursor := ctx.GetQuery("cursor")
+	limitStr, hasLimit := ctx.GetQuery("limit")
+	if !hasCursor && !hasLimit {
+		return cursorPage{}, false
+	}
+	limit, _ := strconv.Atoi(limitStr)
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return cursorPage{cursor: cursor, limit: limit}, true
+}
+
+// cursorMeta 游标分页的响应元数据，next 为空时表示没有下一页
+func cursorMeta(limit int, total int64, next string) *response.Meta {
+	return &response.Meta{
+		PageSize:   limit,
+		TotalCount: total,
+		TotalPages: (total + int64(limit) - 1) / int64(limit),
+		NextCursor: next,
+	}
+}
+
+// encodeIDCursor 将记录ID编码为分页游标
+func encodeIDCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
+
+// decodeIDCursor 解析 encodeIDCursor 生成的游标，空游标返回 0
+func decodeIDCursor(cursor string) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	return id, nil
+}
+
+// encodeTimeCursor 将记录的创建时间和ID编码为分页游标
+func encodeTimeCursor(t time.Time, id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatUint(id, 10)))
+}
+
+// decodeTimeCursor 解析 encodeTimeCursor 生成的游标
+func decodeTimeCursor(cursor string) (time.Time, uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	ts, idStr, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errInvalidCursor
+	}
+	return t, id, nil
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"strconv"
 	"yflow/internal/api/response"
 	"yflow/internal/domain"
 
@@ -38,3 +39,30 @@ func (h *DashboardHandler) GetStats(ctx *gin.Context) {
 
 	response.Success(ctx, stats)
 }
+
+// GetActivity 获取翻译活动趋势
+// @Summary      获取翻译活动趋势
+// @Description  按翻译变更历史统计最近 30 或 90 天（包括今天）每天、每个操作人和每个项目新增和修改的翻译数，用于绘制活动图表。新增为 create 变更，修改为 update、machine_translate 和 rollback 变更；daily 每天一项，没有变更的日期为 0，operators 和 projects 按变更总数降序。结果缓存，翻译变化时清除。仅管理员可用
+// @Tags         仪表板
+// @Produce      json
+// @Param        days  query     int  false  "统计天数"  Enums(30, 90)  default(30)
+// @Success      200   {object}  domain.DashboardActivity
+// @Failure      400   {object}  response.APIResponse
+// @Failure      403   {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /dashboard/activity [get]
+func (h *DashboardHandler) GetActivity(ctx *gin.Context) {
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "30"))
+	if err != nil {
+		response.ValidationError(ctx, domain.ErrInvalidActivityDays.Message)
+		return
+	}
+
+	activity, err := h.dashboardService.GetActivity(ctx.Request.Context(), days)
+	if err != nil {
+		response.HandleError(ctx, err, "获取活动趋势失败")
+		return
+	}
+
+	response.Success(ctx, activity)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DeadLetterHandler 发件箱死信处理器
+type DeadLetterHandler struct {
+	deadLetterService domain.DeadLetterService
+	logger            *zap.Logger
+}
+
+// NewDeadLetterHandler 创建发件箱死信处理器
+func NewDeadLetterHandler(deadLetterService domain.DeadLetterService, logger *zap.Logger) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterService: deadLetterService,
+		logger:            logger,
+	}
+}
+
+// List 获取死信事件列表
+// @Summary      获取死信事件列表
+// @Description  列出超过最大投递次数仍未投递成功的事件（发件箱中状态为 failed），最近写入的在前。事件进入死信状态时会发布 outbox.dead_lettered 告警事件
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        event_type  query     string  false  "事件类型，如 translation.updated"
+// @Param        page        query     int     false  "页码"      default(1)
+// @Param        page_size   query     int     false  "每页数量"  default(20)
+// @Success      200         {object}  response.APIResponse{data=[]dto.DeadLetterResponse}
+// @Failure      403         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/dead-letters [get]
+func (h *DeadLetterHandler) List(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	events, total, err := h.deadLetterService.List(ctx.Request.Context(), domain.DeadLetterListParams{
+		EventType: ctx.Query("event_type"),
+		Limit:     pageSize,
+		Offset:    (page - 1) * pageSize,
+	})
+	if err != nil {
+		response.InternalServerError(ctx, "获取死信事件失败")
+		return
+	}
+
+	items := make([]*dto.DeadLetterResponse, 0, len(events))
+	for _, event := range events {
+		item := &dto.DeadLetterResponse{
+			ID:        event.ID,
+			EventID:   event.EventID,
+			EventType: event.EventType,
+			ProjectID: event.ProjectID,
+			Attempts:  event.Attempts,
+			LastError: event.LastError,
+			CreatedAt: event.CreatedAt.Format(time.RFC3339),
+		}
+		if json.Valid([]byte(event.Payload)) {
+			item.Event = json.RawMessage(event.Payload)
+		}
+		items = append(items, item)
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, items, meta)
+}
+
+// Requeue 重新投递死信事件
+// @Summary      重新投递死信事件
+// @Description  将指定的死信事件（或 all 为 true 时全部死信事件）重置为待投递，投递器在下一次轮询时投递，并重新获得完整的重试次数。重复投递时事件 ID 不变
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.RequeueDeadLettersRequest  true  "要重新投递的事件"
+// @Success      200      {object}  dto.RequeueDeadLettersResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/dead-letters/requeue [post]
+func (h *DeadLetterHandler) Requeue(ctx *gin.Context) {
+	var req dto.RequeueDeadLettersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	requeued, err := h.deadLetterService.Requeue(ctx.Request.Context(), domain.RequeueDeadLettersParams{
+		IDs: req.IDs,
+		All: req.All,
+	}, userID.(uint64))
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return
+		}
+		h.logger.Error("Failed to requeue dead letter events", zap.Uint64s("ids", req.IDs), zap.Error(err))
+		response.InternalServerError(ctx, "重新投递死信事件失败")
+		return
+	}
+
+	response.Success(ctx, dto.RequeueDeadLettersResponse{Requeued: requeued})
+}
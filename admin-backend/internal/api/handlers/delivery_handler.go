@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"strings"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deliveryReleaseHeader 响应头中的发布版本标签，便于应用和排查问题时确认语言包的来源
+const deliveryReleaseHeader = "X-YFlow-Release"
+
+// DeliveryHandler 公开分发处理器
+// 不经认证向应用分发已发布的语言包，与管理 API 分开部署在 /delivery 下，可以放在 CDN 之后
+type DeliveryHandler struct {
+	deliveryService domain.DeliveryService
+	maxAge          int
+}
+
+// NewDeliveryHandler 创建公开分发处理器
+// maxAge 为客户端和 CDN 可直接复用语言包的秒数，不大于 0 时每次都需要用 ETag 重新验证
+func NewDeliveryHandler(deliveryService domain.DeliveryService, maxAge int) *DeliveryHandler {
+	return &DeliveryHandler{
+		deliveryService: deliveryService,
+		maxAge:          maxAge,
+	}
+}
+
+// GetBundle 获取语言包
+// GET /delivery/:project_slug/:locale.json?client_id=
+// 返回项目最新发布版本中该语言的翻译（键名 -> 翻译值），带 ETag 和 Cache-Control，If-None-Match 匹配时返回 304；
+// 有进行中的灰度发布时按 client_id 分桶，落在灰度百分比内的客户端获取灰度版本
+func (h *DeliveryHandler) GetBundle(ctx *gin.Context) {
+	file := ctx.Param("file")
+	locale := strings.TrimSuffix(file, ".json")
+	if locale == file {
+		response.HandleError(ctx, domain.ErrDeliveryNotFound, "获取语言包失败")
+		return
+	}
+
+	bundle, err := h.deliveryService.GetBundle(ctx.Request.Context(), ctx.Param("project_slug"), locale, ctx.Query("client_id"))
+	if err != nil {
+		response.HandleError(ctx, err, "获取语言包失败")
+		return
+	}
+
+	// 用量统计按项目和语言记录
+	ctx.Set("projectID", bundle.ProjectID)
+	middleware.SetRequestLocale(ctx, bundle.Locale)
+
+	ctx.Header(deliveryReleaseHeader, bundle.Release)
+	middleware.WriteETagResponse(ctx, bundle.ETag, "application/json; charset=utf-8", bundle.Body, h.maxAge)
+}
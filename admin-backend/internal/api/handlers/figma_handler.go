@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// FigmaHandler Figma 插件处理器
+type FigmaHandler struct {
+	figmaService domain.FigmaService
+	logger       *zap.Logger
+}
+
+// NewFigmaHandler 创建 Figma 插件处理器
+func NewFigmaHandler(figmaService domain.FigmaService, logger *zap.Logger) *FigmaHandler {
+	return &FigmaHandler{
+		figmaService: figmaService,
+		logger:       logger,
+	}
+}
+
+// SyncFrame 同步画框及其文本图层
+// @Summary      同步设计稿画框
+// @Description  供 Figma 插件使用：按文件标识和节点ID创建或更新画框，并用请求中的文本图层替换画框的全部图层。不存在的键以图层文本作为指定语言（默认为默认语言）的翻译创建；已存在的键不修改翻译，图层文本与当前翻译不同的键在 changed_keys 中列出。截图通过上传截图接口单独上传
+// @Tags         Figma 插件
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                    true  "项目ID"
+// @Param        request     body      dto.FigmaFrameRequest  true  "画框和文本图层"
+// @Success      200         {object}  domain.FigmaSyncResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/figma/frames [post]
+func (h *FigmaHandler) SyncFrame(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.FigmaFrameRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.FigmaFrameParams{
+		FileKey:  req.FileKey,
+		FileName: req.FileName,
+		NodeID:   req.NodeID,
+		Name:     req.Name,
+		Language: req.Language,
+		Layers:   make([]domain.FigmaLayerParams, 0, len(req.Layers)),
+	}
+	for _, layer := range req.Layers {
+		params.Layers = append(params.Layers, domain.FigmaLayerParams{
+			NodeID:  layer.NodeID,
+			Name:    layer.Name,
+			KeyName: layer.Key,
+			Text:    layer.Text,
+			Context: layer.Context,
+		})
+	}
+
+	result, err := h.figmaService.SyncFrame(ctx.Request.Context(), projectID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "同步画框失败")
+		return
+	}
+
+	h.logger.Info("Figma frame synced",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("frame_id", result.Frame.ID),
+		zap.Int("layers", len(params.Layers)),
+		zap.Int("created_keys", len(result.CreatedKeys)),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// GetReferences 查询引用翻译键的画框
+// @Summary      查询设计稿引用
+// @Description  列出项目中同步过的画框及其文本图层，每个图层对应一个翻译键。key 不为空时只返回引用了该键的画框，file_key 不为空时只返回该 Figma 文件中的画框
+// @Tags         Figma 插件
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        key         query     string  false  "翻译键名"
+// @Param        file_key    query     string  false  "Figma 文件标识"
+// @Success      200         {object}  []domain.FigmaFrame
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/figma/frames [get]
+func (h *FigmaHandler) GetReferences(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	frames, err := h.figmaService.GetReferences(ctx.Request.Context(), projectID, ctx.Query("file_key"), ctx.Query("key"))
+	if err != nil {
+		response.HandleError(ctx, err, "查询设计稿引用失败")
+		return
+	}
+
+	response.Success(ctx, frames)
+}
+
+// DeleteFrame 删除画框
+// @Summary      删除设计稿画框
+// @Description  删除画框、截图和图层引用，已创建的翻译键保留
+// @Tags         Figma 插件
+// @Param        project_id  path  int  true  "项目ID"
+// @Param        frame_id    path  int  true  "画框ID"
+// @Success      204
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/figma/frames/{frame_id} [delete]
+func (h *FigmaHandler) DeleteFrame(ctx *gin.Context) {
+	projectID, frameID, ok := h.parseFrameParams(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.figmaService.DeleteFrame(ctx.Request.Context(), projectID, frameID); err != nil {
+		response.HandleError(ctx, err, "删除画框失败")
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+// UploadScreenshot 上传画框截图
+// @Summary      上传画框截图
+// @Description  请求体为 PNG 或 JPEG 图片（最大 5 MB），替换画框原有的截图。截图在翻译时作为视觉上下文显示
+// @Tags         Figma 插件
+// @Accept       image/png
+// @Accept       image/jpeg
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        frame_id    path      int  true  "画框ID"
+// @Success      200         {object}  domain.FigmaFrame
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/figma/frames/{frame_id}/screenshot [put]
+func (h *FigmaHandler) UploadScreenshot(ctx *gin.Context) {
+	projectID, frameID, ok := h.parseFrameParams(ctx)
+	if !ok {
+		return
+	}
+
+	// 多读一个字节以便服务层识别超出大小限制的截图
+	data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, service.MaxFigmaScreenshotSize+1))
+	if err != nil {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	frame, err := h.figmaService.UploadScreenshot(ctx.Request.Context(), projectID, frameID, data, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "上传截图失败")
+		return
+	}
+
+	response.Success(ctx, frame)
+}
+
+// GetScreenshot 获取画框截图
+// @Summary      获取画框截图
+// @Tags         Figma 插件
+// @Produce      image/png
+// @Produce      image/jpeg
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        frame_id    path      int  true  "画框ID"
+// @Success      200         {file}    file
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/figma/frames/{frame_id}/screenshot [get]
+func (h *FigmaHandler) GetScreenshot(ctx *gin.Context) {
+	projectID, frameID, ok := h.parseFrameParams(ctx)
+	if !ok {
+		return
+	}
+
+	data, mediaType, err := h.figmaService.GetScreenshot(ctx.Request.Context(), projectID, frameID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取截图失败")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Data(http.StatusOK, mediaType, data)
+}
+
+// GetValues 获取翻译键的当前翻译
+// @Summary      获取键的当前翻译
+// @Description  供 Figma 插件在设计稿中预览各语言的翻译，返回 {"key": {"en": "value"}}，不存在的键不返回
+// @Tags         Figma 插件
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        keys        query     string  true  "键名，多个用逗号分隔，最多 500 个"
+// @Success      200         {object}  map[string]map[string]string
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/figma/values [get]
+func (h *FigmaHandler) GetValues(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var keys []string
+	for _, key := range strings.Split(ctx.Query("keys"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		response.ValidationError(ctx, "请指定键名")
+		return
+	}
+
+	values, err := h.figmaService.GetValues(ctx.Request.Context(), projectID, keys)
+	if err != nil {
+		response.HandleError(ctx, err, "获取翻译失败")
+		return
+	}
+
+	response.Success(ctx, values)
+}
+
+// parseFrameParams 解析路径中的项目ID和画框ID
+func (h *FigmaHandler) parseFrameParams(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	frameID, err := strconv.ParseUint(ctx.Param("frame_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的画框ID")
+		return 0, 0, false
+	}
+	return projectID, frameID, true
+}
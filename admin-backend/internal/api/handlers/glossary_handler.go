@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// GlossaryHandler 术语表处理器
+type GlossaryHandler struct {
+	glossaryService domain.GlossaryService
+	logger          *zap.Logger
+}
+
+// NewGlossaryHandler 创建术语表处理器
+func NewGlossaryHandler(glossaryService domain.GlossaryService, logger *zap.Logger) *GlossaryHandler {
+	return &GlossaryHandler{
+		glossaryService: glossaryService,
+		logger:          logger,
+	}
+}
+
+// Create 创建术语
+// @Summary      创建术语
+// @Description  在项目术语表中添加术语（以默认语言书写），并设置各语言的规定译文和禁用译文。QA 报告按术语表检查翻译：原文包含术语而译文没有使用规定译文时报告 glossary_mismatch，译文包含禁用译文时报告 forbidden_term。同一项目中的术语不区分大小写不能重复
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        term        body      dto.GlossaryTermRequest  true  "术语"
+// @Success      201         {object}  dto.GlossaryTermResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/glossary [post]
+func (h *GlossaryHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.GlossaryTermRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	term, err := h.glossaryService.Create(ctx.Request.Context(), projectID, toGlossaryTermParams(req), userID.(uint64))
+	if err != nil {
+		h.handleError(ctx, err, "创建术语失败")
+		return
+	}
+
+	response.Created(ctx, toGlossaryTermResponse(term))
+}
+
+// GetByProjectID 获取项目的术语表
+// @Summary      获取术语表
+// @Description  获取项目中的所有术语及各语言的规定译文和禁用译文，按术语排序
+// @Tags         术语表
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.GlossaryTermResponse
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/glossary [get]
+func (h *GlossaryHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	terms, err := h.glossaryService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		h.logger.Error("Failed to get glossary", zap.Uint64("project_id", projectID), zap.Error(err))
+		response.InternalServerError(ctx, "获取术语表失败")
+		return
+	}
+
+	result := make([]*dto.GlossaryTermResponse, 0, len(terms))
+	for _, term := range terms {
+		result = append(result, toGlossaryTermResponse(term))
+	}
+
+	response.Success(ctx, result)
+}
+
+// GetByID 获取术语
+// @Summary      获取术语
+// @Description  获取术语及各语言的规定译文和禁用译文
+// @Tags         术语表
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        term_id     path      int  true  "术语ID"
+// @Success      200         {object}  dto.GlossaryTermResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/glossary/{term_id} [get]
+func (h *GlossaryHandler) GetByID(ctx *gin.Context) {
+	term, ok := h.loadProjectTerm(ctx)
+	if !ok {
+		return
+	}
+
+	response.Success(ctx, toGlossaryTermResponse(term))
+}
+
+// Update 更新术语
+// @Summary      更新术语
+// @Description  未提供的字段保持不变；提供 translations 时替换全部语言的译文
+// @Tags         术语表
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        term_id     path      int                      true  "术语ID"
+// @Param        term        body      dto.GlossaryTermRequest  true  "术语"
+// @Success      200         {object}  dto.GlossaryTermResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/glossary/{term_id} [put]
+func (h *GlossaryHandler) Update(ctx *gin.Context) {
+	term, ok := h.loadProjectTerm(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.GlossaryTermRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	updated, err := h.glossaryService.Update(ctx.Request.Context(), term.ID, toGlossaryTermParams(req), userID.(uint64))
+	if err != nil {
+		h.handleError(ctx, err, "更新术语失败")
+		return
+	}
+
+	response.Success(ctx, toGlossaryTermResponse(updated))
+}
+
+// Delete 删除术语
+// @Summary      删除术语
+// @Description  删除术语及其各语言的译文，翻译不受影响
+// @Tags         术语表
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        term_id     path      int  true  "术语ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/glossary/{term_id} [delete]
+func (h *GlossaryHandler) Delete(ctx *gin.Context) {
+	term, ok := h.loadProjectTerm(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.glossaryService.Delete(ctx.Request.Context(), term.ID); err != nil {
+		h.handleError(ctx, err, "删除术语失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// loadProjectTerm 解析路径参数并确认术语属于当前项目
+func (h *GlossaryHandler) loadProjectTerm(ctx *gin.Context) (*domain.GlossaryTerm, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return nil, false
+	}
+	termID, err := strconv.ParseUint(ctx.Param("term_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的术语ID")
+		return nil, false
+	}
+
+	term, err := h.glossaryService.GetByID(ctx.Request.Context(), termID)
+	if err != nil || term.ProjectID != projectID {
+		if err != nil && err != domain.ErrGlossaryTermNotFound {
+			response.InternalServerError(ctx, "获取术语失败")
+			return nil, false
+		}
+		response.NotFound(ctx, domain.ErrGlossaryTermNotFound.Message)
+		return nil, false
+	}
+
+	return term, true
+}
+
+// handleError 将领域错误映射为HTTP响应，校验错误附带详情
+func (h *GlossaryHandler) handleError(ctx *gin.Context, err error, fallback string) {
+	if !response.HandleError(ctx, err, fallback) {
+		h.logger.Error("Failed to save glossary term", zap.Error(err))
+	}
+}
+
+// toGlossaryTermParams DTO -> Domain params
+func toGlossaryTermParams(req dto.GlossaryTermRequest) domain.GlossaryTermParams {
+	params := domain.GlossaryTermParams{
+		Term:          req.Term,
+		Definition:    req.Definition,
+		CaseSensitive: req.CaseSensitive,
+	}
+	if req.Translations != nil {
+		params.Translations = make([]domain.GlossaryTranslationParams, 0, len(req.Translations))
+		for _, translation := range req.Translations {
+			params.Translations = append(params.Translations, domain.GlossaryTranslationParams{
+				LanguageCode: translation.LanguageCode,
+				Translation:  translation.Translation,
+				Forbidden:    translation.Forbidden,
+			})
+		}
+	}
+	return params
+}
+
+// toGlossaryTermResponse 转换为响应格式，已删除语言的译文不返回
+func toGlossaryTermResponse(term *domain.GlossaryTerm) *dto.GlossaryTermResponse {
+	translations := make([]dto.GlossaryTranslationResponse, 0, len(term.Translations))
+	for _, translation := range term.Translations {
+		if translation.Language.Code == "" {
+			continue
+		}
+		translations = append(translations, dto.GlossaryTranslationResponse{
+			LanguageCode: translation.Language.Code,
+			Translation:  translation.Translation,
+			Forbidden:    service.ParseGlossaryForbidden(translation.Forbidden),
+		})
+	}
+	return &dto.GlossaryTermResponse{
+		ID:            term.ID,
+		ProjectID:     term.ProjectID,
+		Term:          term.Term,
+		Definition:    term.Definition,
+		CaseSensitive: term.CaseSensitive,
+		Translations:  translations,
+		UpdatedBy:     term.UpdatedBy,
+		CreatedAt:     term.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     term.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"yflow/internal/graph"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler GraphQL 查询处理器
+type GraphQLHandler struct {
+	server *handler.Server
+}
+
+// NewGraphQLHandler 创建 GraphQL 查询处理器
+func NewGraphQLHandler(server *handler.Server) *GraphQLHandler {
+	return &GraphQLHandler{server: server}
+}
+
+// Query 执行 GraphQL 查询
+// @Summary      GraphQL 查询
+// @Description  在一次请求中获取项目、语言和翻译矩阵，模式见 internal/graph/schema.graphqls，支持内省查询。权限与 REST 接口一致：projects 返回当前用户可访问的项目（管理员为所有项目），project 需要项目查看权限。列表字段的复杂度按每页数量计算，单次查询复杂度超过 5000 时拒绝执行。出错时 HTTP 状态码仍为 200（请求格式错误时为 4xx），errors[].extensions.code 为与 REST 接口一致的错误码
+// @Tags         GraphQL
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.GraphQLRequest  true  "GraphQL 查询"
+// @Success      200      {object}  dto.GraphQLResponse
+// @Failure      401      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /graphql [post]
+func (h *GraphQLHandler) Query(ctx *gin.Context) {
+	reqCtx := graph.WithUser(ctx.Request.Context(), ctx.GetUint64("userID"), ctx.GetString("userRole"))
+	h.server.ServeHTTP(ctx.Writer, ctx.Request.WithContext(reqCtx))
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HistoryHandler 翻译变更历史差异处理器
+type HistoryHandler struct {
+	historyService domain.TranslationHistoryService
+}
+
+// NewHistoryHandler 创建翻译变更历史差异处理器
+func NewHistoryHandler(historyService domain.TranslationHistoryService) *HistoryHandler {
+	return &HistoryHandler{historyService: historyService}
+}
+
+// GetDiff 获取一条变更历史的差异
+// @Summary      获取变更历史差异
+// @Description  返回一条翻译变更历史中旧值和新值的词级差异（segments，按顺序拼接 equal 和 delete 为旧值，拼接 equal 和 insert 为新值）和按行的 unified diff（值没有变化时为空）。中日韩文字按单个字符比较。需要翻译所属项目的查看权限
+// @Tags         翻译管理
+// @Produce      json
+// @Param        id   path      int  true  "变更历史ID"
+// @Success      200  {object}  domain.TranslationHistoryDiff
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translation-history/{id}/diff [get]
+func (h *HistoryHandler) GetDiff(ctx *gin.Context) {
+	historyID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的变更历史ID")
+		return
+	}
+
+	diff, err := h.historyService.GetDiff(ctx.Request.Context(), historyID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取变更历史差异失败")
+		return
+	}
+
+	response.Success(ctx, diff)
+}
+
+// GetChanges 获取时间范围内项目翻译的变化
+// @Summary      获取翻译变化报告
+// @Description  汇总时间范围内项目中每条翻译的净变化（added、modified、removed、renamed），比较范围开始和结束时的值并给出词级差异，用于编写发布说明。范围内创建后又删除、或修改后又改回原值的翻译不列出。from 和 to 可以是日期（to 包含当天）或 RFC3339 时间；变化超过 5000 条时只返回按键名排序的前 5000 条，truncated 为 true
+// @Tags         翻译管理
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        from        query     string  true  "开始时间"  example(2026-01-01)
+// @Param        to          query     string  true  "结束时间"  example(2026-03-31)
+// @Success      200         {object}  domain.TranslationChangeReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/changes [get]
+func (h *HistoryHandler) GetChanges(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	from, fromOK := parseHistoryTime(ctx.Query("from"), false)
+	to, toOK := parseHistoryTime(ctx.Query("to"), true)
+	if !fromOK || !toOK {
+		response.BadRequest(ctx, domain.ErrInvalidHistoryRange.Message)
+		return
+	}
+
+	report, err := h.historyService.GetChanges(ctx.Request.Context(), domain.TranslationChangesParams{
+		ProjectID: projectID,
+		From:      from,
+		To:        to,
+	})
+	if err != nil {
+		response.HandleError(ctx, err, "获取翻译变化失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
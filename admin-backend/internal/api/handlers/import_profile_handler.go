@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ImportProfileHandler 表格导入映射配置处理器
+type ImportProfileHandler struct {
+	profileService domain.ImportProfileService
+	logger         *zap.Logger
+}
+
+// NewImportProfileHandler 创建表格导入映射配置处理器
+func NewImportProfileHandler(profileService domain.ImportProfileService, logger *zap.Logger) *ImportProfileHandler {
+	return &ImportProfileHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// Create 创建表格导入映射配置
+// @Summary      创建导入映射配置
+// @Description  保存 CSV/XLSX 的列映射：按表头将列映射为键名、上下文和语言，之后的导入可直接复用
+// @Tags         表格导入
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                       true  "项目ID"
+// @Param        profile     body      dto.ImportProfileRequest  true  "映射配置"
+// @Success      201         {object}  dto.ImportProfileResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/import-profiles [post]
+func (h *ImportProfileHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.ImportProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	profile, err := h.profileService.Create(ctx.Request.Context(), projectID, toImportProfileParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "创建导入映射配置失败")
+		return
+	}
+
+	response.Created(ctx, toImportProfileResponse(profile))
+}
+
+// GetByProjectID 获取项目的表格导入映射配置列表
+// @Summary      获取导入映射配置列表
+// @Description  获取项目下保存的所有 CSV/XLSX 列映射配置
+// @Tags         表格导入
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.ImportProfileResponse
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/import-profiles [get]
+func (h *ImportProfileHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	profiles, err := h.profileService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.InternalServerError(ctx, "获取导入映射配置失败")
+		return
+	}
+
+	result := make([]*dto.ImportProfileResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		result = append(result, toImportProfileResponse(profile))
+	}
+
+	response.Success(ctx, result)
+}
+
+// Update 更新表格导入映射配置
+// @Summary      更新导入映射配置
+// @Description  未提供的字段保持不变；提供 language_columns 时替换全部语言列
+// @Tags         表格导入
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                       true  "项目ID"
+// @Param        profile_id  path      int                       true  "映射配置ID"
+// @Param        profile     body      dto.ImportProfileRequest  true  "映射配置"
+// @Success      200         {object}  dto.ImportProfileResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/import-profiles/{profile_id} [put]
+func (h *ImportProfileHandler) Update(ctx *gin.Context) {
+	profile, ok := h.loadProjectProfile(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ImportProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	updated, err := h.profileService.Update(ctx.Request.Context(), profile.ID, toImportProfileParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "更新导入映射配置失败")
+		return
+	}
+
+	response.Success(ctx, toImportProfileResponse(updated))
+}
+
+// Delete 删除表格导入映射配置
+// @Summary      删除导入映射配置
+// @Description  删除映射配置，已导入的翻译不受影响
+// @Tags         表格导入
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        profile_id  path      int  true  "映射配置ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/import-profiles/{profile_id} [delete]
+func (h *ImportProfileHandler) Delete(ctx *gin.Context) {
+	profile, ok := h.loadProjectProfile(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.profileService.Delete(ctx.Request.Context(), profile.ID); err != nil {
+		response.HandleError(ctx, err, "删除导入映射配置失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// Suggest 按上传文件的表头推测列映射
+// @Summary      推测列映射
+// @Description  读取上传的 CSV/XLSX 文件的表头，按列名推测键名列（key、id、键名等）、上下文列（context、description、备注等）和语言列（语言代码、语言名称或括号中的语言代码），结果可直接用于创建映射配置。XLSX 只读取第一个工作表，CSV 需使用 UTF-8 编码，分隔符自动识别
+// @Tags         表格导入
+// @Accept       text/csv
+// @Accept       application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        format      query     string  false  "文件格式，默认按文件内容识别"  Enums(csv, xlsx)
+// @Param        header_row  query     int     false  "表头所在行"  default(1)
+// @Success      200         {object}  domain.ImportMappingSuggestion
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/import-profiles/suggest [post]
+func (h *ImportProfileHandler) Suggest(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	headerRow, err := strconv.Atoi(ctx.DefaultQuery("header_row", "1"))
+	if err != nil {
+		response.ValidationError(ctx, "无效的表头行")
+		return
+	}
+
+	data, err := ctx.GetRawData()
+	if err != nil || len(data) == 0 {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	suggestion, err := h.profileService.Suggest(ctx.Request.Context(), projectID, domain.ImportMappingSuggestParams{
+		Format:    ctx.Query("format"),
+		HeaderRow: headerRow,
+		Data:      data,
+	})
+	if err != nil {
+		response.HandleError(ctx, err, "推测列映射失败")
+		return
+	}
+
+	response.Success(ctx, suggestion)
+}
+
+// Import 按映射配置导入表格
+// @Summary      按映射配置导入表格
+// @Description  按映射配置导入 CSV/XLSX 文件。表头按列名匹配（忽略大小写），配置中的列缺失时不导入并返回缺失的列；已存在的翻译会被覆盖，空单元格和键名为空的行跳过
+// @Tags         表格导入
+// @Accept       text/csv
+// @Accept       application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        profile_id  path      int     true   "映射配置ID"
+// @Param        format      query     string  false  "文件格式，默认按文件内容识别"  Enums(csv, xlsx)
+// @Success      200         {object}  domain.SpreadsheetImportResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/import-profiles/{profile_id}/import [post]
+func (h *ImportProfileHandler) Import(ctx *gin.Context) {
+	profile, ok := h.loadProjectProfile(ctx)
+	if !ok {
+		return
+	}
+
+	data, err := ctx.GetRawData()
+	if err != nil || len(data) == 0 {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	result, err := h.profileService.Import(ctx.Request.Context(), profile.ID, domain.SpreadsheetImportParams{
+		Format: ctx.Query("format"),
+		Data:   data,
+	})
+	if err != nil {
+		response.HandleError(ctx, err, "表格导入失败")
+		return
+	}
+
+	operatorID, exists := ctx.Get("userID")
+	if !exists {
+		operatorID = uint64(0)
+	}
+	h.logger.Info("Spreadsheet imported",
+		zap.Uint64("project_id", profile.ProjectID),
+		zap.Uint64("profile_id", profile.ID),
+		zap.Int("data_size", len(data)),
+		zap.Int("rows", result.Rows),
+		zap.Int("translations", result.Translations),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// loadProjectProfile 解析路径参数并确认映射配置属于当前项目
+func (h *ImportProfileHandler) loadProjectProfile(ctx *gin.Context) (*domain.ImportProfile, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return nil, false
+	}
+	profileID, err := strconv.ParseUint(ctx.Param("profile_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的映射配置ID")
+		return nil, false
+	}
+
+	profile, err := h.profileService.GetByID(ctx.Request.Context(), profileID)
+	if err != nil || profile.ProjectID != projectID {
+		if err != nil && err != domain.ErrImportProfileNotFound {
+			response.InternalServerError(ctx, "获取导入映射配置失败")
+			return nil, false
+		}
+		response.NotFound(ctx, domain.ErrImportProfileNotFound.Message)
+		return nil, false
+	}
+
+	return profile, true
+}
+
+// toImportProfileParams DTO -> Domain params
+func toImportProfileParams(req dto.ImportProfileRequest) domain.ImportProfileParams {
+	return domain.ImportProfileParams{
+		Name:            req.Name,
+		HeaderRow:       req.HeaderRow,
+		KeyColumn:       req.KeyColumn,
+		ContextColumn:   req.ContextColumn,
+		LanguageColumns: req.LanguageColumns,
+	}
+}
+
+// toImportProfileResponse 转换为响应格式
+func toImportProfileResponse(profile *domain.ImportProfile) *dto.ImportProfileResponse {
+	return &dto.ImportProfileResponse{
+		ID:              profile.ID,
+		ProjectID:       profile.ProjectID,
+		Name:            profile.Name,
+		HeaderRow:       profile.HeaderRow,
+		KeyColumn:       profile.KeyColumn,
+		ContextColumn:   profile.ContextColumn,
+		LanguageColumns: service.ParseLanguageMapping(profile.LanguageColumns),
+		CreatedAt:       profile.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       profile.UpdatedAt.Format(time.RFC3339),
+	}
+}
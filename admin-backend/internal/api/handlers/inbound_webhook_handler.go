@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// InboundWebhookSignatureHeader 入站 Webhook 签名请求头
+const InboundWebhookSignatureHeader = "X-YFlow-Signature"
+
+// maxInboundWebhookBodySize 入站 Webhook 请求体大小上限 (10MB)
+const maxInboundWebhookBodySize = 10 << 20
+
+// InboundWebhookHandler 入站 Webhook 处理器
+type InboundWebhookHandler struct {
+	webhookService domain.InboundWebhookService
+	logger         *zap.Logger
+}
+
+// NewInboundWebhookHandler 创建入站 Webhook 处理器
+func NewInboundWebhookHandler(webhookService domain.InboundWebhookService, logger *zap.Logger) *InboundWebhookHandler {
+	return &InboundWebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// Create 创建入站 Webhook
+// @Summary      创建入站 Webhook
+// @Description  为项目创建外部 TMS 推送入口，响应中包含仅返回一次的签名密钥
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                        true  "项目ID"
+// @Param        webhook     body      dto.InboundWebhookRequest  true  "Webhook 配置"
+// @Success      201         {object}  dto.InboundWebhookResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/inbound-webhooks [post]
+func (h *InboundWebhookHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.InboundWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	webhook, err := h.webhookService.Create(ctx.Request.Context(), projectID, toInboundWebhookParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "创建 Webhook 失败")
+		return
+	}
+
+	h.logger.Info("Inbound webhook created",
+		zap.Uint64("webhook_id", webhook.ID),
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Created(ctx, toInboundWebhookResponse(webhook, true))
+}
+
+// GetByProjectID 获取项目的入站 Webhook 列表
+// @Summary      获取入站 Webhook 列表
+// @Description  获取项目下配置的所有入站 Webhook
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.InboundWebhookResponse
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/inbound-webhooks [get]
+func (h *InboundWebhookHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	webhooks, err := h.webhookService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.InternalServerError(ctx, "获取 Webhook 列表失败")
+		return
+	}
+
+	result := make([]*dto.InboundWebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, toInboundWebhookResponse(webhook, false))
+	}
+
+	response.Success(ctx, result)
+}
+
+// Update 更新入站 Webhook
+// @Summary      更新入站 Webhook
+// @Description  更新语言映射、冲突策略或启用状态，未提供的字段保持不变
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                        true  "项目ID"
+// @Param        webhook_id  path      int                        true  "Webhook ID"
+// @Param        webhook     body      dto.InboundWebhookRequest  true  "Webhook 配置"
+// @Success      200         {object}  dto.InboundWebhookResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/inbound-webhooks/{webhook_id} [put]
+func (h *InboundWebhookHandler) Update(ctx *gin.Context) {
+	webhook, ok := h.loadProjectWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.InboundWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	updated, err := h.webhookService.Update(ctx.Request.Context(), webhook.ID, toInboundWebhookParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "更新 Webhook 失败")
+		return
+	}
+
+	response.Success(ctx, toInboundWebhookResponse(updated, false))
+}
+
+// Delete 删除入站 Webhook
+// @Summary      删除入站 Webhook
+// @Description  删除入站 Webhook，之后的推送将被拒绝
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        webhook_id  path      int  true  "Webhook ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/inbound-webhooks/{webhook_id} [delete]
+func (h *InboundWebhookHandler) Delete(ctx *gin.Context) {
+	webhook, ok := h.loadProjectWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.Delete(ctx.Request.Context(), webhook.ID); err != nil {
+		response.HandleError(ctx, err, "删除 Webhook 失败")
+		return
+	}
+
+	if operatorID, exists := ctx.Get("userID"); exists {
+		h.logger.Info("Inbound webhook deleted",
+			zap.Uint64("webhook_id", webhook.ID),
+			zap.Uint64("project_id", webhook.ProjectID),
+			zap.Uint64("operator_id", operatorID.(uint64)),
+		)
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// RotateSecret 重置入站 Webhook 签名密钥
+// @Summary      重置签名密钥
+// @Description  生成新的签名密钥并立即使旧密钥失效
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        webhook_id  path      int  true  "Webhook ID"
+// @Success      200         {object}  dto.InboundWebhookResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/inbound-webhooks/{webhook_id}/rotate-secret [post]
+func (h *InboundWebhookHandler) RotateSecret(ctx *gin.Context) {
+	webhook, ok := h.loadProjectWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	rotated, err := h.webhookService.RotateSecret(ctx.Request.Context(), webhook.ID, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "重置密钥失败")
+		return
+	}
+
+	response.Success(ctx, toInboundWebhookResponse(rotated, true))
+}
+
+// GetLogs 获取入站导入日志
+// @Summary      获取入站导入日志
+// @Description  分页获取入站 Webhook 的导入记录，包括被拒绝的请求
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        webhook_id  path      int  true   "Webhook ID"
+// @Param        page        query     int  false  "页码"      default(1)
+// @Param        page_size   query     int  false  "每页数量"  default(10)
+// @Success      200         {object}  dto.InboundWebhookLogListResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/inbound-webhooks/{webhook_id}/logs [get]
+func (h *InboundWebhookHandler) GetLogs(ctx *gin.Context) {
+	webhook, ok := h.loadProjectWebhook(ctx)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	logs, total, err := h.webhookService.GetLogs(ctx.Request.Context(), webhook.ID, pageSize, offset)
+	if err != nil {
+		response.InternalServerError(ctx, "获取导入日志失败")
+		return
+	}
+
+	resp := dto.InboundWebhookLogListResponse{
+		Logs:  make([]*dto.InboundWebhookLogResponse, 0, len(logs)),
+		Total: total,
+	}
+	for _, l := range logs {
+		resp.Logs = append(resp.Logs, &dto.InboundWebhookLogResponse{
+			ID:        l.ID,
+			WebhookID: l.WebhookID,
+			Status:    l.Status,
+			Received:  l.Received,
+			Created:   l.Created,
+			Updated:   l.Updated,
+			Skipped:   l.Skipped,
+			Message:   l.Message,
+			CreatedAt: l.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, resp, meta)
+}
+
+// Receive 接收外部系统推送
+// @Summary      接收外部 TMS 推送
+// @Description  外部系统使用 HMAC-SHA256 对原始请求体签名，通过 X-YFlow-Signature: sha256=<hex> 传递
+// @Tags         入站Webhook
+// @Accept       json
+// @Produce      json
+// @Param        webhook_id          path      int                           true  "Webhook ID"
+// @Param        X-YFlow-Signature   header    string                        true  "请求体签名"
+// @Param        payload             body      domain.InboundWebhookPayload  true  "推送内容"
+// @Success      200                 {object}  dto.InboundWebhookLogResponse
+// @Failure      400                 {object}  response.APIResponse
+// @Failure      401                 {object}  response.APIResponse
+// @Failure      404                 {object}  response.APIResponse
+// @Router       /webhooks/inbound/{webhook_id} [post]
+func (h *InboundWebhookHandler) Receive(ctx *gin.Context) {
+	webhookID, err := strconv.ParseUint(ctx.Param("webhook_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的 Webhook ID")
+		return
+	}
+
+	// 签名基于原始请求体计算，必须在任何解析之前读取
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxInboundWebhookBodySize+1))
+	if err != nil {
+		response.BadRequest(ctx, "无法读取请求体")
+		return
+	}
+	if len(body) > maxInboundWebhookBodySize {
+		response.BadRequest(ctx, fmt.Sprintf("请求体过大，最大支持 %d bytes", maxInboundWebhookBodySize))
+		return
+	}
+
+	importLog, err := h.webhookService.Ingest(ctx.Request.Context(), webhookID, body, ctx.GetHeader(InboundWebhookSignatureHeader))
+	if err != nil {
+		h.logger.Warn("Inbound webhook rejected",
+			zap.Uint64("webhook_id", webhookID),
+			zap.String("ip", ctx.ClientIP()),
+			zap.Error(err),
+		)
+		response.HandleError(ctx, err, "导入失败")
+		return
+	}
+
+	h.logger.Info("Inbound webhook processed",
+		zap.Uint64("webhook_id", webhookID),
+		zap.Uint64("project_id", importLog.ProjectID),
+		zap.Int("received", importLog.Received),
+		zap.Int("created", importLog.Created),
+		zap.Int("updated", importLog.Updated),
+		zap.Int("skipped", importLog.Skipped),
+	)
+
+	response.Success(ctx, dto.InboundWebhookLogResponse{
+		ID:        importLog.ID,
+		WebhookID: importLog.WebhookID,
+		Status:    importLog.Status,
+		Received:  importLog.Received,
+		Created:   importLog.Created,
+		Updated:   importLog.Updated,
+		Skipped:   importLog.Skipped,
+		CreatedAt: importLog.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// loadProjectWebhook 解析路径参数并确认 Webhook 属于当前项目
+func (h *InboundWebhookHandler) loadProjectWebhook(ctx *gin.Context) (*domain.InboundWebhook, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return nil, false
+	}
+	webhookID, err := strconv.ParseUint(ctx.Param("webhook_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的 Webhook ID")
+		return nil, false
+	}
+
+	webhook, err := h.webhookService.GetByID(ctx.Request.Context(), webhookID)
+	if err != nil || webhook.ProjectID != projectID {
+		if err != nil && err != domain.ErrWebhookNotFound {
+			response.InternalServerError(ctx, "获取 Webhook 失败")
+			return nil, false
+		}
+		response.NotFound(ctx, domain.ErrWebhookNotFound.Message)
+		return nil, false
+	}
+
+	return webhook, true
+}
+
+// toInboundWebhookParams DTO -> Domain params
+func toInboundWebhookParams(req dto.InboundWebhookRequest) domain.InboundWebhookParams {
+	return domain.InboundWebhookParams{
+		Name:              req.Name,
+		Provider:          req.Provider,
+		ExternalProjectID: req.ExternalProjectID,
+		LanguageMapping:   req.LanguageMapping,
+		ConflictStrategy:  req.ConflictStrategy,
+		Status:            req.Status,
+	}
+}
+
+// toInboundWebhookResponse 转换为响应格式，withSecret 为 true 时包含签名密钥
+func toInboundWebhookResponse(webhook *domain.InboundWebhook, withSecret bool) *dto.InboundWebhookResponse {
+	resp := &dto.InboundWebhookResponse{
+		ID:                webhook.ID,
+		ProjectID:         webhook.ProjectID,
+		Name:              webhook.Name,
+		Provider:          webhook.Provider,
+		ExternalProjectID: webhook.ExternalProjectID,
+		LanguageMapping:   service.ParseLanguageMapping(webhook.LanguageMapping),
+		ConflictStrategy:  webhook.ConflictStrategy,
+		Status:            webhook.Status,
+		URL:               fmt.Sprintf("/api/webhooks/inbound/%d", webhook.ID),
+		CreatedAt:         webhook.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         webhook.UpdatedAt.Format(time.RFC3339),
+	}
+	if withSecret {
+		resp.Secret = webhook.Secret
+	}
+	return resp
+}
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"bytes"
+	"fmt"
+	"net/http"
 	"time"
 	"yflow/internal/api/response"
 	"yflow/internal/domain"
 	"yflow/internal/dto"
+	"yflow/internal/service"
 	"yflow/internal/utils"
 	"strconv"
 
@@ -37,7 +41,7 @@ func NewInvitationHandler(
 
 // CreateInvitation 创建邀请码
 // @Summary      创建邀请码
-// @Description  管理员创建新的邀请码
+// @Description  管理员创建新的邀请码。max_uses 大于 1 时同一个邀请码可以注册多个用户（如团队邀请链接），用完后状态变为 used。指定 email 时向该邮箱发送包含邀请链接的邮件（需要配置 SMTP_HOST），发送失败时邀请码仍然有效，email_status 为 failed
 // @Tags         邀请管理
 // @Accept       json
 // @Produce      json
@@ -47,7 +51,7 @@ func NewInvitationHandler(
 // @Failure      401         {object}  map[string]string
 // @Failure      403         {object}  map[string]string
 // @Security     BearerAuth
-// @Router       /api/v1/invitations [post]
+// @Router       /invitations [post]
 func (h *InvitationHandler) CreateInvitation(ctx *gin.Context) {
 	var req dto.CreateInvitationRequest
 
@@ -69,13 +73,16 @@ func (h *InvitationHandler) CreateInvitation(ctx *gin.Context) {
 		Role:          req.Role,
 		ExpiresInDays: req.ExpiresInDays,
 		Description:   req.Description,
+		Email:         req.Email,
+		MaxUses:       req.MaxUses,
 	}
 
 	// 创建邀请码
 	invitation, invitationURL, err := h.invitationService.CreateInvitation(ctx.Request.Context(), userID.(uint64), params)
 	if err != nil {
-		h.logger.Error("Failed to create invitation", zap.Error(err))
-		response.InternalServerError(ctx, "创建邀请码失败")
+		if !response.HandleError(ctx, err, "创建邀请码失败") {
+			h.logger.Error("Failed to create invitation", zap.Error(err))
+		}
 		return
 	}
 
@@ -101,9 +108,130 @@ func (h *InvitationHandler) CreateInvitation(ctx *gin.Context) {
 		Role:          invitation.Role,
 		ExpiresAt:     invitation.ExpiresAt.Format(time.RFC3339),
 		Description:   invitation.Description,
+		Email:         invitation.Email,
+		EmailStatus:   invitation.EmailStatus,
+		MaxUses:       invitation.MaxUses,
 	})
 }
 
+// CreateInvitationBatch 批量创建邀请码
+// @Summary      批量创建邀请码
+// @Description  管理员一次创建多个邀请码，默认以 CSV 返回邀请码和邀请链接（format=json 时返回 JSON）。指定 emails 或 recipients 时为每个邮箱创建一个邀请码并发送邀请邮件（需要配置 SMTP_HOST），recipients 可以为每个收件人指定角色，skip_email 为 true 时只记录邮箱、不发送邮件；邮件发送失败的邀请码仍然有效，email_status 为 failed。同一批次的邀请码带有相同的 batch_label，可通过 DELETE /invitations/batches/{label} 整批撤销。一次最多 200 个
+// @Tags         邀请管理
+// @Accept       json
+// @Produce      text/csv,json
+// @Param        format      query     string                            false  "返回格式：csv（默认）或 json"
+// @Param        invitation  body      dto.CreateInvitationBatchRequest  true   "批量邀请信息"
+// @Success      201         {object}  domain.InvitationBatchResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      401         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /invitations/batches [post]
+func (h *InvitationHandler) CreateInvitationBatch(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		response.BadRequest(ctx, "不支持的返回格式，可选值：csv、json")
+		return
+	}
+
+	var req dto.CreateInvitationBatchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "用户未登录")
+		return
+	}
+
+	params := domain.CreateInvitationBatchParams{
+		Count:         req.Count,
+		Emails:        req.Emails,
+		SkipEmail:     req.SkipEmail,
+		Role:          req.Role,
+		ExpiresInDays: req.ExpiresInDays,
+		Description:   req.Description,
+		BatchLabel:    req.BatchLabel,
+	}
+	for _, recipient := range req.Recipients {
+		params.Recipients = append(params.Recipients, domain.InvitationRecipient{Email: recipient.Email, Role: recipient.Role})
+	}
+	result, err := h.invitationService.CreateInvitationBatch(ctx.Request.Context(), userID.(uint64), params)
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return
+		}
+		h.logger.Error("Failed to create invitation batch", zap.Error(err))
+		response.InternalServerError(ctx, "批量创建邀请码失败")
+		return
+	}
+
+	h.logger.Info("Invitation batch created",
+		zap.String("batch_label", result.BatchLabel),
+		zap.Int("count", len(result.Invitations)),
+		zap.Int("emailed", result.Emailed),
+		zap.Int("email_failed", result.EmailFailed),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	if format == "json" {
+		response.Created(ctx, result)
+		return
+	}
+	var buf bytes.Buffer
+	if err := service.WriteInvitationBatchCSV(&buf, result); err != nil {
+		h.logger.Error("Failed to write invitation batch CSV", zap.Error(err))
+		response.InternalServerError(ctx, "生成 CSV 失败")
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="invitations-%s.csv"`, result.BatchLabel))
+	ctx.Data(http.StatusCreated, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// RevokeInvitationBatch 撤销邀请批次
+// @Summary      撤销邀请批次
+// @Description  撤销批次中所有仍然有效的邀请码，已使用的邀请码不受影响
+// @Tags         邀请管理
+// @Produce      json
+// @Param        label  path      string  true  "批次标签"
+// @Success      200    {object}  dto.RevokeInvitationBatchResponse
+// @Failure      400    {object}  response.APIResponse
+// @Failure      404    {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /invitations/batches/{label} [delete]
+func (h *InvitationHandler) RevokeInvitationBatch(ctx *gin.Context) {
+	label := ctx.Param("label")
+	revoked, err := h.invitationService.RevokeInvitationBatch(ctx.Request.Context(), label)
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeValidation:
+				response.BadRequest(ctx, appErr.Message)
+				return
+			}
+		}
+		h.logger.Error("Failed to revoke invitation batch", zap.String("batch_label", label), zap.Error(err))
+		response.InternalServerError(ctx, "撤销邀请批次失败")
+		return
+	}
+
+	operatorID, _ := ctx.Get("userID")
+	h.logger.Info("Invitation batch revoked",
+		zap.String("batch_label", label),
+		zap.Int64("revoked", revoked),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, dto.RevokeInvitationBatchResponse{BatchLabel: label, Revoked: revoked})
+}
+
 // GetInvitations 获取邀请列表
 // @Summary      获取邀请列表
 // @Description  分页获取邀请码列表
@@ -115,7 +243,7 @@ func (h *InvitationHandler) CreateInvitation(ctx *gin.Context) {
 // @Success      200       {object}  dto.InvitationListResponse
 // @Failure      400       {object}  map[string]string
 // @Security     BearerAuth
-// @Router       /api/v1/invitations [get]
+// @Router       /invitations [get]
 func (h *InvitationHandler) GetInvitations(ctx *gin.Context) {
 	// 从上下文中获取用户ID
 	userID, exists := ctx.Get("userID")
@@ -159,7 +287,11 @@ func (h *InvitationHandler) GetInvitations(ctx *gin.Context) {
 			Role:        inv.Role,
 			Status:      inv.Status,
 			ExpiresAt:   inv.ExpiresAt.Format(time.RFC3339),
+			MaxUses:     inv.MaxUses,
+			UseCount:    inv.UseCount,
 			Description: inv.Description,
+			BatchLabel:  inv.BatchLabel,
+			Email:       inv.Email,
 			CreatedAt:   inv.CreatedAt.Format(time.RFC3339),
 		}
 
@@ -202,7 +334,7 @@ func (h *InvitationHandler) GetInvitations(ctx *gin.Context) {
 // @Success      200    {object}  dto.InvitationResponse
 // @Failure      404    {object}  map[string]string
 // @Security     BearerAuth
-// @Router       /api/v1/invitations/{code} [get]
+// @Router       /invitations/{code} [get]
 func (h *InvitationHandler) GetInvitation(ctx *gin.Context) {
 	code := ctx.Param("code")
 	if code == "" {
@@ -228,7 +360,11 @@ func (h *InvitationHandler) GetInvitation(ctx *gin.Context) {
 		Role:        invitation.Role,
 		Status:      invitation.Status,
 		ExpiresAt:   invitation.ExpiresAt.Format(time.RFC3339),
+		MaxUses:     invitation.MaxUses,
+		UseCount:    invitation.UseCount,
 		Description: invitation.Description,
+		BatchLabel:  invitation.BatchLabel,
+		Email:       invitation.Email,
 		CreatedAt:   invitation.CreatedAt.Format(time.RFC3339),
 	}
 
@@ -262,7 +398,7 @@ func (h *InvitationHandler) GetInvitation(ctx *gin.Context) {
 // @Failure      400    {object}  map[string]string
 // @Failure      404    {object}  map[string]string
 // @Security     BearerAuth
-// @Router       /api/v1/invitations/{code} [delete]
+// @Router       /invitations/{code} [delete]
 func (h *InvitationHandler) RevokeInvitation(ctx *gin.Context) {
 	code := ctx.Param("code")
 	if code == "" {
@@ -309,7 +445,7 @@ func (h *InvitationHandler) RevokeInvitation(ctx *gin.Context) {
 // @Success      200    {object}  dto.ValidateInvitationResponse
 // @Failure      400    {object}  map[string]string
 // @Failure      404    {object}  map[string]string
-// @Router       /api/v1/invitations/{code}/validate [get]
+// @Router       /invitations/{code}/validate [get]
 func (h *InvitationHandler) ValidateInvitation(ctx *gin.Context) {
 	code := ctx.Param("code")
 	if code == "" {
@@ -329,9 +465,10 @@ func (h *InvitationHandler) ValidateInvitation(ctx *gin.Context) {
 	}
 
 	resp := dto.ValidateInvitationResponse{
-		Valid:     true,
-		Role:      invitation.Role,
-		ExpiresAt: invitation.ExpiresAt.Format(time.RFC3339),
+		Valid:         true,
+		Role:          invitation.Role,
+		ExpiresAt:     invitation.ExpiresAt.Format(time.RFC3339),
+		RemainingUses: invitation.RemainingUses(),
 	}
 
 	if invitation.Inviter != nil {
@@ -356,7 +493,7 @@ func (h *InvitationHandler) ValidateInvitation(ctx *gin.Context) {
 // @Success      201           {object}  domain.User
 // @Failure      400           {object}  map[string]string
 // @Failure      409           {object}  map[string]string
-// @Router       /api/v1/register [post]
+// @Router       /register [post]
 func (h *InvitationHandler) RegisterWithInvitation(ctx *gin.Context) {
 	var req dto.RegisterWithInvitationRequest
 
@@ -366,9 +503,8 @@ func (h *InvitationHandler) RegisterWithInvitation(ctx *gin.Context) {
 		return
 	}
 
-	// 验证邀请码
-	invitation, err := h.invitationService.ValidateInvitation(ctx.Request.Context(), req.Code)
-	if err != nil {
+	// 验证邀请码，注册时会再次在事务中检查
+	if _, err := h.invitationService.ValidateInvitation(ctx.Request.Context(), req.Code); err != nil {
 		switch err {
 		case domain.ErrInvitationNotFound:
 			response.NotFound(ctx, "邀请码不存在")
@@ -396,37 +532,34 @@ func (h *InvitationHandler) RegisterWithInvitation(ctx *gin.Context) {
 		return
 	}
 
-	// 检查用户名是否已存在
-	if _, err := h.userService.GetUserByID(ctx.Request.Context(), 0); err == nil {
-		// 用户已存在，需要用GetByUsername检查
-	}
-
-	// 创建用户
+	// 创建用户并占用邀请码，邀请码在此期间被用完时注册失败，不会留下用户
 	params := domain.CreateUserParams{
 		Username: req.Username,
 		Email:    req.Email,
 		Password: req.Password,
-		Role:     invitation.Role, // 使用邀请码指定的角色
 	}
 
-	user, err := h.userService.CreateUser(ctx.Request.Context(), params)
+	user, err := h.invitationService.RegisterWithInvitation(ctx.Request.Context(), req.Code, params)
 	if err != nil {
 		switch err {
 		case domain.ErrUserExists:
 			response.Conflict(ctx, "用户名已存在")
 		case domain.ErrEmailExists:
 			response.Conflict(ctx, "邮箱已存在")
+		case domain.ErrInvitationNotFound:
+			response.NotFound(ctx, "邀请码不存在")
+		case domain.ErrInvitationUsed:
+			response.Conflict(ctx, "邀请码已被使用")
+		case domain.ErrInvitationExpired:
+			response.BadRequest(ctx, "邀请码已过期")
+		case domain.ErrInvitationRevoked:
+			response.BadRequest(ctx, "邀请码已被撤销")
 		default:
 			response.InternalServerError(ctx, "创建用户失败")
 		}
 		return
 	}
 
-	// 标记邀请码为已使用
-	if err := h.invitationService.UseInvitation(ctx.Request.Context(), req.Code, user.ID); err != nil {
-		h.logger.Error("Failed to mark invitation as used", zap.Error(err))
-	}
-
 	// 注册成功日志
 	h.logger.Info("User registered via invitation",
 		zap.Uint64("user_id", user.ID),
@@ -441,17 +574,7 @@ func (h *InvitationHandler) RegisterWithInvitation(ctx *gin.Context) {
 	})
 }
 
-// DeleteInvitation 删除邀请码
-// @Summary      删除邀请码
-// @Description  彻底删除邀请码记录
-// @Tags         邀请管理
-// @Accept       json
-// @Produce      json
-// @Param        code   path      string  true  "邀请码"
-// @Success      200    {object}  map[string]string
-// @Failure      404    {object}  map[string]string
-// @Security     BearerAuth
-// @Router       /api/v1/invitations/{code} [delete]
+// DeleteInvitation 删除邀请码（彻底删除邀请码记录，目前未注册路由）
 func (h *InvitationHandler) DeleteInvitation(ctx *gin.Context) {
 	code := ctx.Param("code")
 	if code == "" {
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"strconv"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyCopyHandler 跨项目复制键处理器
+type KeyCopyHandler struct {
+	keyCopyService domain.KeyCopyService
+	logger         *zap.Logger
+}
+
+// NewKeyCopyHandler 创建跨项目复制键处理器
+func NewKeyCopyHandler(keyCopyService domain.KeyCopyService, logger *zap.Logger) *KeyCopyHandler {
+	return &KeyCopyHandler{
+		keyCopyService: keyCopyService,
+		logger:         logger,
+	}
+}
+
+// Copy 从其他项目复制键
+// @Summary      从其他项目复制键
+// @Description  将源项目中的键连同所有语言的翻译复制到项目，需要能查看源项目。任一键在源项目中不存在时不复制并返回 404；项目中已有的键默认跳过，overwrite 为 true 时用源项目的翻译覆盖。
+// @Description  link 为 true 时链接复制的键：之后源项目中这些键的翻译新增或修改时自动写入项目（删除不同步），适合共享的组件库文案
+// @Tags         键名前缀
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                 true  "项目ID"
+// @Param        request     body      dto.KeyCopyRequest  true  "源项目和键名"
+// @Success      200         {object}  domain.KeyCopyResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/copy [post]
+func (h *KeyCopyHandler) Copy(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.KeyCopyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	result, err := h.keyCopyService.Copy(ctx.Request.Context(), projectID, domain.KeyCopyParams{
+		SourceProjectID: req.SourceProjectID,
+		KeyNames:        req.KeyNames,
+		Overwrite:       req.Overwrite,
+		Link:            req.Link,
+	}, userID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "复制键失败") {
+			h.logger.Error("Failed to copy keys", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Keys copied",
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("source_project_id", result.SourceProjectID),
+		zap.Int("keys", len(result.Copied)),
+		zap.Int64("translations", result.Translations),
+		zap.Int("linked", result.Linked),
+		zap.Uint64("operator_id", userID),
+	)
+	response.Success(ctx, result)
+}
+
+// ListLinks 获取键链接
+// @Summary      获取键链接
+// @Description  获取项目作为源项目（键被其他项目链接）或目标项目（键从其他项目链接而来）的键链接，按键名排序
+// @Tags         键名前缀
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {array}   domain.KeyLink
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/links [get]
+func (h *KeyCopyHandler) ListLinks(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	links, err := h.keyCopyService.ListLinks(ctx.Request.Context(), projectID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "获取键链接失败") {
+			h.logger.Error("Failed to list key links", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+	response.Success(ctx, links)
+}
+
+// Unlink 取消键链接
+// @Summary      取消键链接
+// @Description  取消项目中从其他项目链接而来的键，之后不再同步；已复制的翻译保留
+// @Tags         键名前缀
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        key_name    query     string  true  "键名"
+// @Success      204         "No Content"
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/links [delete]
+func (h *KeyCopyHandler) Unlink(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	keyName := ctx.Query("key_name")
+	if err := h.keyCopyService.Unlink(ctx.Request.Context(), projectID, keyName, userID); err != nil {
+		if !response.HandleError(ctx, err, "取消键链接失败") {
+			h.logger.Error("Failed to unlink key", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Key unlinked",
+		zap.Uint64("project_id", projectID),
+		zap.String("key_name", keyName),
+		zap.Uint64("operator_id", userID),
+	)
+	response.NoContent(ctx)
+}
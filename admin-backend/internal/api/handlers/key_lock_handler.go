@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyLockHandler 键编辑锁处理器
+type KeyLockHandler struct {
+	keyLockService domain.KeyLockService
+	logger         *zap.Logger
+}
+
+// NewKeyLockHandler 创建键编辑锁处理器
+func NewKeyLockHandler(keyLockService domain.KeyLockService, logger *zap.Logger) *KeyLockHandler {
+	return &KeyLockHandler{
+		keyLockService: keyLockService,
+		logger:         logger,
+	}
+}
+
+// List 获取正在编辑的键
+// @Summary      获取正在编辑的键
+// @Description  获取项目中有效的编辑锁，按键名排序，编辑器据此在单元格上显示谁正在编辑
+// @Tags         翻译管理
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {array}   domain.KeyLock
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/locks [get]
+func (h *KeyLockHandler) List(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	locks, err := h.keyLockService.List(ctx.Request.Context(), projectID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "获取编辑锁失败") {
+			h.logger.Error("Failed to list key locks", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+	response.Success(ctx, locks)
+}
+
+// Acquire 获取键的编辑锁
+// @Summary      获取键的编辑锁
+// @Description  开始编辑键时获取编辑锁，有效期 60 秒，编辑期间通过续期接口保持；已持有时续期。
+// @Description  其他用户正在编辑时返回 409 KEY_LOCKED，data 为其持有的锁。编辑锁只用于提示，不阻止写入，并发修改由更新翻译时的 version 检测
+// @Tags         翻译管理
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        key_name    query     string  true  "键名"
+// @Success      200         {object}  domain.KeyLock
+// @Failure      400         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse{data=domain.KeyLock}
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/locks [post]
+func (h *KeyLockHandler) Acquire(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	lock, err := h.keyLockService.Acquire(ctx.Request.Context(), projectID, ctx.Query("key_name"), userID, ctx.GetString("username"))
+	if err != nil {
+		if errors.Is(err, domain.ErrKeyLocked) {
+			response.ErrorWithData(ctx, http.StatusConflict, domain.ErrKeyLocked.Code, domain.ErrKeyLocked.Message, "", lock)
+			return
+		}
+		if !response.HandleError(ctx, err, "获取编辑锁失败") {
+			h.logger.Error("Failed to acquire key lock", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+	response.Success(ctx, lock)
+}
+
+// Heartbeat 续期键的编辑锁
+// @Summary      续期键的编辑锁
+// @Description  编辑期间定期续期编辑锁（建议每 20 秒一次）。锁已过期或已被其他用户获取时返回 409 KEY_LOCK_NOT_HELD，
+// @Description  期间译文可能已被修改，编辑器应重新获取锁并加载最新的译文
+// @Tags         翻译管理
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        key_name    query     string  true  "键名"
+// @Success      200         {object}  domain.KeyLock
+// @Failure      400         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/locks [put]
+func (h *KeyLockHandler) Heartbeat(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	lock, err := h.keyLockService.Heartbeat(ctx.Request.Context(), projectID, ctx.Query("key_name"), ctx.GetUint64("userID"))
+	if err != nil {
+		if !response.HandleError(ctx, err, "续期编辑锁失败") {
+			h.logger.Error("Failed to renew key lock", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+	response.Success(ctx, lock)
+}
+
+// Release 释放键的编辑锁
+// @Summary      释放键的编辑锁
+// @Description  结束编辑时释放编辑锁；没有持有该键的锁时同样返回 204
+// @Tags         翻译管理
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        key_name    query     string  true  "键名"
+// @Success      204         "No Content"
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/locks [delete]
+func (h *KeyLockHandler) Release(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	if err := h.keyLockService.Release(ctx.Request.Context(), projectID, ctx.Query("key_name"), ctx.GetUint64("userID")); err != nil {
+		if !response.HandleError(ctx, err, "释放编辑锁失败") {
+			h.logger.Error("Failed to release key lock", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+	response.NoContent(ctx)
+}
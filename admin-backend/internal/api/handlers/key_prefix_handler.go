@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyPrefixHandler 按键名前缀批量操作处理器
+type KeyPrefixHandler struct {
+	keyPrefixService domain.KeyPrefixService
+	logger           *zap.Logger
+}
+
+// NewKeyPrefixHandler 创建按键名前缀批量操作处理器
+func NewKeyPrefixHandler(keyPrefixService domain.KeyPrefixService, logger *zap.Logger) *KeyPrefixHandler {
+	return &KeyPrefixHandler{
+		keyPrefixService: keyPrefixService,
+		logger:           logger,
+	}
+}
+
+// Delete 按键名前缀批量删除
+// @Summary      按键名前缀批量删除
+// @Description  删除项目中以指定前缀开头的所有键的翻译；preview 为 true 时只返回受影响的键。项目开启删除保护时只能预览
+// @Tags         键名前缀
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                   true  "项目ID"
+// @Param        request     body      dto.KeyPrefixRequest  true  "前缀"
+// @Success      200         {object}  dto.KeyPrefixResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/key-prefix/delete [post]
+func (h *KeyPrefixHandler) Delete(ctx *gin.Context) {
+	projectID, userID, ok := h.parseRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.KeyPrefixRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.keyPrefixService.Delete(ctx.Request.Context(), projectID, domain.KeyPrefixParams{
+		Prefix:  req.Prefix,
+		Preview: req.Preview,
+	}, userID)
+	h.respond(ctx, result, err, projectID, userID, "按前缀删除失败")
+}
+
+// ChangeStatus 按键名前缀批量修改状态
+// @Summary      按键名前缀批量修改状态
+// @Description  将项目中以指定前缀开头的所有键的翻译标记为 active 或 deprecated；preview 为 true 时只返回受影响的键
+// @Tags         键名前缀
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                         true  "项目ID"
+// @Param        request     body      dto.KeyPrefixStatusRequest  true  "前缀和状态"
+// @Success      200         {object}  dto.KeyPrefixResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/key-prefix/status [post]
+func (h *KeyPrefixHandler) ChangeStatus(ctx *gin.Context) {
+	projectID, userID, ok := h.parseRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.KeyPrefixStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.keyPrefixService.ChangeStatus(ctx.Request.Context(), projectID, domain.KeyPrefixParams{
+		Prefix:  req.Prefix,
+		Status:  req.Status,
+		Preview: req.Preview,
+	}, userID)
+	h.respond(ctx, result, err, projectID, userID, "按前缀修改状态失败")
+}
+
+// Rename 按键名前缀批量重命名
+// @Summary      按键名前缀批量重命名
+// @Description  将以 prefix 开头的键改为以 new_prefix 开头，如 checkout.* → payment.*；重命名后与已有键冲突时返回 409，预览结果中列出冲突的键
+// @Tags         键名前缀
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                         true  "项目ID"
+// @Param        request     body      dto.KeyPrefixRenameRequest  true  "原前缀和新前缀"
+// @Success      200         {object}  dto.KeyPrefixResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/key-prefix/rename [post]
+func (h *KeyPrefixHandler) Rename(ctx *gin.Context) {
+	projectID, userID, ok := h.parseRequest(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.KeyPrefixRenameRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.keyPrefixService.Rename(ctx.Request.Context(), projectID, domain.KeyPrefixParams{
+		Prefix:    req.Prefix,
+		NewPrefix: req.NewPrefix,
+		Preview:   req.Preview,
+	}, userID)
+	h.respond(ctx, result, err, projectID, userID, "按前缀重命名失败")
+}
+
+// Export 按键名前缀导出
+// @Summary      按键名前缀导出
+// @Description  导出项目中以指定前缀开头的所有有效翻译；preview 为 true 时只返回匹配的键数量
+// @Tags         键名前缀
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        prefix      query     string  true   "键名前缀"
+// @Param        preview     query     bool    false  "只预览"
+// @Success      200         {object}  dto.KeyPrefixResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/key-prefix/export [get]
+func (h *KeyPrefixHandler) Export(ctx *gin.Context) {
+	projectID, userID, ok := h.parseRequest(ctx)
+	if !ok {
+		return
+	}
+
+	preview, _ := strconv.ParseBool(ctx.DefaultQuery("preview", "false"))
+	result, err := h.keyPrefixService.Export(ctx.Request.Context(), projectID, domain.KeyPrefixParams{
+		Prefix:  ctx.Query("prefix"),
+		Preview: preview,
+	}, userID)
+	h.respond(ctx, result, err, projectID, userID, "按前缀导出失败")
+}
+
+// parseRequest 解析项目ID和当前用户
+func (h *KeyPrefixHandler) parseRequest(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return 0, 0, false
+	}
+	return projectID, userID.(uint64), true
+}
+
+// respond 输出操作结果，执行（非预览）的操作记录日志
+func (h *KeyPrefixHandler) respond(ctx *gin.Context, result *domain.KeyPrefixResult, err error, projectID, userID uint64, fallback string) {
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
+				response.BadRequest(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeConflict:
+				response.Conflict(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeForbidden:
+				response.Forbidden(ctx, appErr.Message)
+				return
+			}
+		}
+		response.InternalServerError(ctx, fallback)
+		return
+	}
+
+	if !result.Preview {
+		h.logger.Info("Key prefix operation executed",
+			zap.String("path", ctx.FullPath()),
+			zap.Uint64("project_id", projectID),
+			zap.String("prefix", result.Prefix),
+			zap.Int64("translations", result.Translations),
+			zap.Uint64("operator_id", userID),
+		)
+	}
+
+	response.Success(ctx, dto.KeyPrefixResponse{
+		Prefix:       result.Prefix,
+		NewPrefix:    result.NewPrefix,
+		Status:       result.Status,
+		Preview:      result.Preview,
+		Keys:         result.Keys,
+		Translations: result.Translations,
+		SampleKeys:   result.SampleKeys,
+		Conflicts:    result.Conflicts,
+		Data:         result.Data,
+	})
+}
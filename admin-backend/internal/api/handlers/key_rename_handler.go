@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"strconv"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// KeyRenameHandler 翻译键重命名处理器
+type KeyRenameHandler struct {
+	keyRenameService domain.KeyRenameService
+	logger           *zap.Logger
+}
+
+// NewKeyRenameHandler 创建翻译键重命名处理器
+func NewKeyRenameHandler(keyRenameService domain.KeyRenameService, logger *zap.Logger) *KeyRenameHandler {
+	return &KeyRenameHandler{
+		keyRenameService: keyRenameService,
+		logger:           logger,
+	}
+}
+
+// Rename 重命名键
+// @Summary      重命名键
+// @Description  在一个事务中将键在所有语言中的翻译改为新键名，同时修改未合并分支中的翻译，以及引用该键的附件、任务分配和设计稿图层。每条翻译记录一条 rename 变更历史，并清除翻译缓存。新键名已存在时返回 409
+// @Tags         键名前缀
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                   true  "项目ID"
+// @Param        request     body      dto.KeyRenameRequest  true  "原键名和新键名"
+// @Success      200         {object}  domain.KeyRenameResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/rename [post]
+func (h *KeyRenameHandler) Rename(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.KeyRenameRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	result, err := h.keyRenameService.Rename(ctx.Request.Context(), projectID, domain.KeyRenameParams{
+		KeyName:    req.KeyName,
+		NewKeyName: req.NewKeyName,
+	}, userID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "重命名键失败") {
+			h.logger.Error("Failed to rename key", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Key renamed",
+		zap.Uint64("project_id", projectID),
+		zap.String("key_name", result.KeyName),
+		zap.String("new_key_name", result.NewKeyName),
+		zap.Int64("translations", result.Translations),
+		zap.Uint64("operator_id", userID),
+	)
+	response.Success(ctx, result)
+}
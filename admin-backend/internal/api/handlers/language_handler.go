@@ -29,9 +29,11 @@ func NewLanguageHandler(languageService domain.LanguageService) *LanguageHandler
 // @Accept       json
 // @Produce      json
 // @Param        language  body      dto.CreateLanguageRequest  true  "语言信息"
+// @Param        org_id    query     int                        false "所属组织ID，不指定时为共享语言"
 // @Success      201       {object}  domain.Language
 // @Failure      400       {object}  map[string]string
 // @Failure      409       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /languages [post]
 func (h *LanguageHandler) Create(ctx *gin.Context) {
@@ -99,10 +101,11 @@ func (h *LanguageHandler) GetAll(ctx *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id        path      int                            true  "语言ID"
-// @Param        language  body      domain.CreateLanguageRequest  true  "语言信息"
+// @Param        language  body      dto.CreateLanguageRequest     true  "语言信息"
 // @Success      200       {object}  domain.Language
 // @Failure      400       {object}  map[string]string
 // @Failure      404       {object}  map[string]string
+// @Failure      403       {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /languages/{id} [put]
 func (h *LanguageHandler) Update(ctx *gin.Context) {
@@ -159,6 +162,7 @@ func (h *LanguageHandler) Update(ctx *gin.Context) {
 // @Success      204  {object}  nil
 // @Failure      400  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      403       {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /languages/{id} [delete]
 func (h *LanguageHandler) Delete(ctx *gin.Context) {
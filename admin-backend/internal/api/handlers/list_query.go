@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"yflow/internal/api/middleware"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// listQuery 列表端点的排序和字段选择参数
+type listQuery struct {
+	sort   []domain.SortField // 为空时使用默认排序
+	fields []string           // 为空时返回全部字段
+}
+
+// listFields 列表端点可以排序和选择的字段
+type listFields struct {
+	sortable   []string        // 可以排序的字段，同时须在 SQLSecurityConfig.AllowedSortFields 中
+	selectable map[string]bool // 可以选择的字段，为列表元素的 JSON 字段
+}
+
+// newListFields 创建列表字段定义，model 为列表元素（结构体或结构体指针）
+func newListFields(model interface{}, sortable ...string) listFields {
+	allowed := middleware.DefaultSQLSecurityConfig().AllowedSortFields
+	fields := listFields{selectable: make(map[string]bool)}
+	for _, field := range sortable {
+		for _, a := range allowed {
+			if field == a {
+				fields.sortable = append(fields.sortable, field)
+				break
+			}
+		}
+	}
+	collectJSONFields(reflect.TypeOf(model), fields.selectable)
+	return fields
+}
+
+// collectJSONFields 收集结构体的 JSON 字段名，包括嵌入结构体的字段
+func collectJSONFields(t reflect.Type, names map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if f.Anonymous && tag == "" {
+			collectJSONFields(f.Type, names)
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+}
+
+// parseListQuery 解析 sort（如 updated_at:desc,id）和 fields（如 key_name,value）参数
+// 参数无效时返回 400 并返回 false
+func parseListQuery(ctx *gin.Context, fields listFields) (listQuery, bool) {
+	var q listQuery
+	if value := ctx.Query("sort"); value != "" {
+		sort, err := middleware.ParseSortFields(value, fields.sortable)
+		if err != nil {
+			response.BadRequest(ctx, err.Error())
+			return q, false
+		}
+		q.sort = sort
+	}
+	if value := ctx.Query("fields"); value != "" {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			if !fields.selectable[field] {
+				response.BadRequest(ctx, fmt.Sprintf("不支持的字段: %s", field))
+				return q, false
+			}
+			q.fields = append(q.fields, field)
+		}
+	}
+	return q, true
+}
+
+// selectFields 只保留列表元素中 fields 指定的 JSON 字段，fields 为空时原样返回
+func selectFields(items interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return items, nil
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+
+	selected := make([]map[string]json.RawMessage, 0, len(rows))
+	for _, row := range rows {
+		item := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := row[field]; ok {
+				item[field] = value
+			}
+		}
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// NamespaceHandler 键命名空间处理器
+type NamespaceHandler struct {
+	namespaceService domain.NamespaceService
+	logger           *zap.Logger
+}
+
+// NewNamespaceHandler 创建键命名空间处理器
+func NewNamespaceHandler(namespaceService domain.NamespaceService, logger *zap.Logger) *NamespaceHandler {
+	return &NamespaceHandler{
+		namespaceService: namespaceService,
+		logger:           logger,
+	}
+}
+
+// GetByProjectID 获取项目的命名空间
+// @Summary      获取命名空间
+// @Description  获取项目中的命名空间及每个命名空间中的键数量，按名称排序
+// @Tags         命名空间
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.NamespaceResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/namespaces [get]
+func (h *NamespaceHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	namespaces, err := h.namespaceService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取命名空间失败")
+		return
+	}
+
+	responses := make([]*dto.NamespaceResponse, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		responses = append(responses, toNamespaceResponse(namespace))
+	}
+	response.Success(ctx, responses)
+}
+
+// Create 创建命名空间
+// @Summary      创建命名空间
+// @Description  在项目中创建命名空间（如 checkout、settings），用于按功能模块划分键。名称只能包含字母、数字、点、下划线和连字符，同一项目中不能重复。矩阵、导出和导入可以按命名空间筛选
+// @Tags         命名空间
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                   true  "项目ID"
+// @Param        namespace   body      dto.NamespaceRequest  true  "命名空间"
+// @Success      201         {object}  dto.NamespaceResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/namespaces [post]
+func (h *NamespaceHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.NamespaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	namespace, err := h.namespaceService.Create(ctx.Request.Context(), projectID, toNamespaceParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "创建命名空间失败")
+		return
+	}
+
+	response.Created(ctx, toNamespaceResponse(namespace))
+}
+
+// Update 更新命名空间
+// @Summary      更新命名空间
+// @Description  修改命名空间的名称或说明，未提供的字段保持不变，键的归属不变
+// @Tags         命名空间
+// @Accept       json
+// @Produce      json
+// @Param        project_id    path      int                   true  "项目ID"
+// @Param        namespace_id  path      int                   true  "命名空间ID"
+// @Param        namespace     body      dto.NamespaceRequest  true  "命名空间"
+// @Success      200           {object}  dto.NamespaceResponse
+// @Failure      400           {object}  response.APIResponse
+// @Failure      404           {object}  response.APIResponse
+// @Failure      409           {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/namespaces/{namespace_id} [put]
+func (h *NamespaceHandler) Update(ctx *gin.Context) {
+	projectID, namespaceID, ok := parseNamespacePath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.NamespaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	namespace, err := h.namespaceService.Update(ctx.Request.Context(), projectID, namespaceID, toNamespaceParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "更新命名空间失败")
+		return
+	}
+
+	response.Success(ctx, toNamespaceResponse(namespace))
+}
+
+// Delete 删除命名空间
+// @Summary      删除命名空间
+// @Description  删除命名空间，其中的键不会被删除，回到未划分命名空间的状态
+// @Tags         命名空间
+// @Produce      json
+// @Param        project_id    path      int  true  "项目ID"
+// @Param        namespace_id  path      int  true  "命名空间ID"
+// @Success      200           {object}  response.APIResponse
+// @Failure      404           {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/namespaces/{namespace_id} [delete]
+func (h *NamespaceHandler) Delete(ctx *gin.Context) {
+	projectID, namespaceID, ok := parseNamespacePath(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.namespaceService.Delete(ctx.Request.Context(), projectID, namespaceID); err != nil {
+		response.HandleError(ctx, err, "删除命名空间失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// AssignKeys 将键归入命名空间
+// @Summary      设置键的命名空间
+// @Description  将键在所有语言中的翻译归入命名空间，namespace 为空时移出命名空间。任一键在项目中不存在时不做修改，错误详情列出这些键
+// @Tags         命名空间
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                         true  "项目ID"
+// @Param        request     body      dto.AssignNamespaceRequest  true  "键名和命名空间"
+// @Success      200         {object}  domain.AssignNamespaceResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/namespace [put]
+func (h *NamespaceHandler) AssignKeys(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.AssignNamespaceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	result, err := h.namespaceService.AssignKeys(ctx.Request.Context(), projectID, domain.AssignNamespaceParams{
+		KeyNames:  req.KeyNames,
+		Namespace: req.Namespace,
+	}, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "设置键的命名空间失败")
+		return
+	}
+
+	h.logger.Info("Key namespace assigned",
+		zap.Uint64("project_id", projectID),
+		zap.String("namespace", result.Namespace),
+		zap.Int("keys", len(result.KeyNames)),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// parseNamespacePath 解析项目ID和命名空间ID路径参数
+func parseNamespacePath(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	namespaceID, err := strconv.ParseUint(ctx.Param("namespace_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的命名空间ID")
+		return 0, 0, false
+	}
+	return projectID, namespaceID, true
+}
+
+// toNamespaceParams DTO -> Domain params
+func toNamespaceParams(req dto.NamespaceRequest) domain.NamespaceParams {
+	return domain.NamespaceParams{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
+
+// toNamespaceResponse 转换为响应格式
+func toNamespaceResponse(namespace *domain.Namespace) *dto.NamespaceResponse {
+	return &dto.NamespaceResponse{
+		ID:          namespace.ID,
+		ProjectID:   namespace.ProjectID,
+		Name:        namespace.Name,
+		Description: namespace.Description,
+		Keys:        namespace.Keys,
+		UpdatedBy:   namespace.UpdatedBy,
+		CreatedAt:   namespace.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   namespace.UpdatedAt.Format(time.RFC3339),
+	}
+}
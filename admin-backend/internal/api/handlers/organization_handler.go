@@ -0,0 +1,651 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OrganizationHandler 组织处理器
+// 组织的语言和 API Key 接口由组织范围中间件按路由参数 org_id 设置当前组织后调用语言和 API Key 服务
+type OrganizationHandler struct {
+	organizationService domain.OrganizationService
+	languageService     domain.LanguageService
+	keyService          domain.APIKeyService
+	logger              *zap.Logger
+}
+
+// NewOrganizationHandler 创建组织处理器
+func NewOrganizationHandler(
+	organizationService domain.OrganizationService,
+	languageService domain.LanguageService,
+	keyService domain.APIKeyService,
+	logger *zap.Logger,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		languageService:     languageService,
+		keyService:          keyService,
+		logger:              logger,
+	}
+}
+
+// Create 创建组织
+// @Summary      创建组织
+// @Description  创建组织（工作空间），创建者成为组织的 owner。组织下的项目、语言和 API Key 与其他组织隔离；
+// @Description  创建时复制共享语言列表中启用的语言作为组织的语言，之后两者互不影响
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateOrganizationRequest  true  "组织信息"
+// @Success      201      {object}  dto.OrganizationResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      409      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations [post]
+func (h *OrganizationHandler) Create(ctx *gin.Context) {
+	var req dto.CreateOrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	organization, err := h.organizationService.Create(ctx.Request.Context(), domain.OrganizationParams{
+		Name:        req.Name,
+		Description: req.Description,
+	}, userID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "创建组织失败") {
+			h.logger.Error("Failed to create organization", zap.Uint64("user_id", userID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Organization created", zap.Uint64("organization_id", organization.ID), zap.Uint64("user_id", userID))
+
+	response.Created(ctx, toOrganizationResponse(organization))
+}
+
+// List 获取组织列表
+// @Summary      获取组织列表
+// @Description  返回当前用户所在的组织，管理员返回所有组织
+// @Tags         组织
+// @Produce      json
+// @Success      200  {array}   dto.OrganizationResponse
+// @Failure      401  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations [get]
+func (h *OrganizationHandler) List(ctx *gin.Context) {
+	var (
+		organizations []*domain.Organization
+		err           error
+	)
+	if ctx.GetString("userRole") == "admin" {
+		organizations, err = h.organizationService.GetAll(ctx.Request.Context())
+	} else {
+		organizations, err = h.organizationService.GetUserOrganizations(ctx.Request.Context(), ctx.GetUint64("userID"))
+	}
+	if err != nil {
+		h.logger.Error("Failed to list organizations", zap.Error(err))
+		response.InternalServerError(ctx, "获取组织列表失败")
+		return
+	}
+
+	resp := make([]*dto.OrganizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		resp = append(resp, toOrganizationResponse(organization))
+	}
+	response.Success(ctx, resp)
+}
+
+// GetByID 获取组织
+// @Summary      获取组织
+// @Description  获取组织信息，需要是组织成员
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path      int  true  "组织ID"
+// @Success      200     {object}  dto.OrganizationResponse
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id} [get]
+func (h *OrganizationHandler) GetByID(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	organization, err := h.organizationService.GetByID(ctx.Request.Context(), organizationID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "获取组织失败") {
+			h.logger.Error("Failed to get organization", zap.Uint64("organization_id", organizationID), zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, toOrganizationResponse(organization))
+}
+
+// Update 更新组织
+// @Summary      更新组织
+// @Description  更新组织名称和描述，需要是组织的 owner 或 admin
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        org_id   path      int                            true  "组织ID"
+// @Param        request  body      dto.UpdateOrganizationRequest  true  "组织信息"
+// @Success      200      {object}  dto.OrganizationResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Failure      409      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id} [put]
+func (h *OrganizationHandler) Update(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateOrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	organization, err := h.organizationService.Update(ctx.Request.Context(), organizationID, domain.OrganizationParams{
+		Name:        req.Name,
+		Description: req.Description,
+	}, ctx.GetUint64("userID"))
+	if err != nil {
+		if !response.HandleError(ctx, err, "更新组织失败") {
+			h.logger.Error("Failed to update organization", zap.Uint64("organization_id", organizationID), zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, toOrganizationResponse(organization))
+}
+
+// Delete 删除组织
+// @Summary      删除组织
+// @Description  删除组织及其成员、语言和 API Key；组织下还有项目时不能删除
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path  int  true  "组织ID"
+// @Success      204     "No Content"
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Failure      409     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id} [delete]
+func (h *OrganizationHandler) Delete(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.Delete(ctx.Request.Context(), organizationID); err != nil {
+		if !response.HandleError(ctx, err, "删除组织失败") {
+			h.logger.Error("Failed to delete organization", zap.Uint64("organization_id", organizationID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Organization deleted", zap.Uint64("organization_id", organizationID), zap.Uint64("user_id", ctx.GetUint64("userID")))
+
+	response.NoContent(ctx)
+}
+
+// GetMembers 获取组织成员
+// @Summary      获取组织成员
+// @Description  获取组织成员及其组织角色，需要是组织成员
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path      int  true  "组织ID"
+// @Success      200     {array}   dto.OrganizationMemberResponse
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/members [get]
+func (h *OrganizationHandler) GetMembers(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	members, err := h.organizationService.GetMembers(ctx.Request.Context(), organizationID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "获取组织成员失败") {
+			h.logger.Error("Failed to get organization members", zap.Uint64("organization_id", organizationID), zap.Error(err))
+		}
+		return
+	}
+
+	resp := make([]*dto.OrganizationMemberResponse, 0, len(members))
+	for _, member := range members {
+		resp = append(resp, &dto.OrganizationMemberResponse{
+			UserID:   member.UserID,
+			Username: member.Username,
+			Email:    member.Email,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	response.Success(ctx, resp)
+}
+
+// AddMember 添加组织成员
+// @Summary      添加组织成员
+// @Description  按用户ID或邮箱添加组织成员，角色为 owner、admin 或 member（默认）。只有组织成员可以被添加为组织项目的成员，
+// @Description  组织的 owner 和 admin 拥有组织下所有项目的 owner 权限。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        org_id   path      int                               true  "组织ID"
+// @Param        request  body      dto.AddOrganizationMemberRequest  true  "成员信息"
+// @Success      201      {object}  domain.OrganizationMember
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Failure      409      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/members [post]
+func (h *OrganizationHandler) AddMember(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.AddOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	member, err := h.organizationService.AddMember(ctx.Request.Context(), organizationID, domain.AddOrganizationMemberParams{
+		UserID: req.UserID,
+		Email:  req.Email,
+		Role:   req.Role,
+	}, ctx.GetUint64("userID"))
+	if err != nil {
+		if !response.HandleError(ctx, err, "添加组织成员失败") {
+			h.logger.Error("Failed to add organization member", zap.Uint64("organization_id", organizationID), zap.Error(err))
+		}
+		return
+	}
+
+	response.Created(ctx, member)
+}
+
+// UpdateMember 更新组织成员角色
+// @Summary      更新组织成员角色
+// @Description  更新组织成员的角色，组织至少保留一个 owner。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        org_id   path      int                                  true  "组织ID"
+// @Param        user_id  path      int                                  true  "用户ID"
+// @Param        request  body      dto.UpdateOrganizationMemberRequest  true  "角色"
+// @Success      200      {object}  domain.OrganizationMember
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/members/{user_id} [put]
+func (h *OrganizationHandler) UpdateMember(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	var req dto.UpdateOrganizationMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	member, err := h.organizationService.UpdateMemberRole(ctx.Request.Context(), organizationID, userID, req.Role)
+	if err != nil {
+		if !response.HandleError(ctx, err, "更新组织成员失败") {
+			h.logger.Error("Failed to update organization member", zap.Uint64("organization_id", organizationID), zap.Uint64("user_id", userID), zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, member)
+}
+
+// RemoveMember 移除组织成员
+// @Summary      移除组织成员
+// @Description  移除组织成员，同时移除该用户在组织下所有项目的成员关系；组织至少保留一个 owner。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Produce      json
+// @Param        org_id   path  int  true  "组织ID"
+// @Param        user_id  path  int  true  "用户ID"
+// @Success      204      "No Content"
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/members/{user_id} [delete]
+func (h *OrganizationHandler) RemoveMember(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(ctx.Request.Context(), organizationID, userID); err != nil {
+		if !response.HandleError(ctx, err, "移除组织成员失败") {
+			h.logger.Error("Failed to remove organization member", zap.Uint64("organization_id", organizationID), zap.Uint64("user_id", userID), zap.Error(err))
+		}
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+// GetProjects 获取组织的项目
+// @Summary      获取组织的项目
+// @Description  获取组织下的所有项目，需要是组织的 owner 或 admin。在组织中创建项目使用 POST /projects 并指定 organization_id
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path      int  true  "组织ID"
+// @Success      200     {array}   domain.Project
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/projects [get]
+func (h *OrganizationHandler) GetProjects(ctx *gin.Context) {
+	organizationID, ok := organizationIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	projects, err := h.organizationService.GetProjects(ctx.Request.Context(), organizationID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "获取组织项目失败") {
+			h.logger.Error("Failed to get organization projects", zap.Uint64("organization_id", organizationID), zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, projects)
+}
+
+// GetLanguages 获取组织的语言
+// @Summary      获取组织的语言
+// @Description  获取组织的语言列表，组织的项目只能使用这些语言。需要是组织成员
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path      int  true  "组织ID"
+// @Success      200     {array}   domain.Language
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/languages [get]
+func (h *OrganizationHandler) GetLanguages(ctx *gin.Context) {
+	languages, err := h.languageService.GetAll(ctx.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to get organization languages", zap.String("org_id", ctx.Param("org_id")), zap.Error(err))
+		response.InternalServerError(ctx, "获取语言列表失败")
+		return
+	}
+
+	response.Success(ctx, languages)
+}
+
+// CreateLanguage 创建组织的语言
+// @Summary      创建组织的语言
+// @Description  在组织的语言列表中添加语言，语言代码在组织内唯一。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        org_id    path      int                        true  "组织ID"
+// @Param        language  body      dto.CreateLanguageRequest  true  "语言信息"
+// @Success      201       {object}  domain.Language
+// @Failure      400       {object}  response.APIResponse
+// @Failure      403       {object}  response.APIResponse
+// @Failure      409       {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/languages [post]
+func (h *OrganizationHandler) CreateLanguage(ctx *gin.Context) {
+	var req dto.CreateLanguageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	language, err := h.languageService.Create(ctx.Request.Context(), domain.CreateLanguageParams{
+		Code:      req.Code,
+		Name:      req.Name,
+		IsDefault: req.IsDefault,
+	}, ctx.GetUint64("userID"))
+	if err != nil {
+		if !response.HandleError(ctx, err, "创建语言失败") {
+			h.logger.Error("Failed to create organization language", zap.String("org_id", ctx.Param("org_id")), zap.Error(err))
+		}
+		return
+	}
+
+	response.Created(ctx, language)
+}
+
+// UpdateLanguage 更新组织的语言
+// @Summary      更新组织的语言
+// @Description  更新组织的语言，其他组织的语言视为不存在。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        org_id    path      int                        true  "组织ID"
+// @Param        id        path      int                        true  "语言ID"
+// @Param        language  body      dto.CreateLanguageRequest  true  "语言信息"
+// @Success      200       {object}  domain.Language
+// @Failure      400       {object}  response.APIResponse
+// @Failure      403       {object}  response.APIResponse
+// @Failure      404       {object}  response.APIResponse
+// @Failure      409       {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/languages/{id} [put]
+func (h *OrganizationHandler) UpdateLanguage(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的语言ID")
+		return
+	}
+
+	var req dto.CreateLanguageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	language, err := h.languageService.Update(ctx.Request.Context(), id, domain.CreateLanguageParams{
+		Code:      req.Code,
+		Name:      req.Name,
+		IsDefault: req.IsDefault,
+	}, ctx.GetUint64("userID"))
+	if err != nil {
+		if !response.HandleError(ctx, err, "更新语言失败") {
+			h.logger.Error("Failed to update organization language", zap.Uint64("language_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, language)
+}
+
+// DeleteLanguage 删除组织的语言
+// @Summary      删除组织的语言
+// @Description  删除组织的语言，不能删除默认语言。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path  int  true  "组织ID"
+// @Param        id      path  int  true  "语言ID"
+// @Success      204     "No Content"
+// @Failure      400     {object}  response.APIResponse
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/languages/{id} [delete]
+func (h *OrganizationHandler) DeleteLanguage(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的语言ID")
+		return
+	}
+
+	if err := h.languageService.Delete(ctx.Request.Context(), id); err != nil {
+		if !response.HandleError(ctx, err, "删除语言失败") {
+			h.logger.Error("Failed to delete organization language", zap.Uint64("language_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	response.NoContent(ctx)
+}
+
+// ListAPIKeys 获取组织的 API Key
+// @Summary      获取组织的 API Key
+// @Description  返回组织的 API Key，不包含 API Key 本身。需要是组织的 owner 或 admin，不能使用个人访问令牌调用此接口
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path      int  true  "组织ID"
+// @Success      200     {array}   dto.APIKeyResponse
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/api-keys [get]
+func (h *OrganizationHandler) ListAPIKeys(ctx *gin.Context) {
+	keys, err := h.keyService.GetAll(ctx.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to list organization API keys", zap.String("org_id", ctx.Param("org_id")), zap.Error(err))
+		response.InternalServerError(ctx, "获取 API Key 失败")
+		return
+	}
+
+	resp := make([]*dto.APIKeyResponse, 0, len(keys))
+	for _, key := range keys {
+		resp = append(resp, toAPIKeyResponse(key))
+	}
+	response.Success(ctx, resp)
+}
+
+// CreateAPIKey 创建组织的 API Key
+// @Summary      创建组织的 API Key
+// @Description  创建只能访问组织项目的 API Key；project_ids 为空时可访问组织的所有项目，指定时项目需要属于该组织。
+// @Description  API Key 只在创建时返回一次。需要是组织的 owner 或 admin，不能使用个人访问令牌调用此接口
+// @Tags         组织
+// @Accept       json
+// @Produce      json
+// @Param        org_id   path      int                      true  "组织ID"
+// @Param        request  body      dto.CreateAPIKeyRequest  true  "API Key 信息"
+// @Success      201      {object}  dto.CreateAPIKeyResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/api-keys [post]
+func (h *OrganizationHandler) CreateAPIKey(ctx *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	key, plain, err := h.keyService.Create(ctx.Request.Context(), domain.APIKeyParams{
+		Name:       req.Name,
+		Scope:      req.Scope,
+		ProjectIDs: req.ProjectIDs,
+	}, userID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "创建 API Key 失败") {
+			h.logger.Error("Failed to create organization API key", zap.String("org_id", ctx.Param("org_id")), zap.Uint64("user_id", userID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Organization API key created",
+		zap.Uint64("api_key_id", key.ID),
+		zap.Uint64("organization_id", key.OrganizationID),
+		zap.Uint64("user_id", userID),
+		zap.String("key_prefix", key.KeyPrefix),
+	)
+
+	response.Created(ctx, &dto.CreateAPIKeyResponse{
+		APIKeyResponse: *toAPIKeyResponse(key),
+		Key:            plain,
+	})
+}
+
+// RevokeAPIKey 撤销组织的 API Key
+// @Summary      撤销组织的 API Key
+// @Description  撤销组织的 API Key，撤销后立即失效；其他组织的 API Key 视为不存在。需要是组织的 owner 或 admin
+// @Tags         组织
+// @Produce      json
+// @Param        org_id  path  int  true  "组织ID"
+// @Param        id      path  int  true  "API Key ID"
+// @Success      204     "No Content"
+// @Failure      400     {object}  response.APIResponse
+// @Failure      403     {object}  response.APIResponse
+// @Failure      404     {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /organizations/{org_id}/api-keys/{id} [delete]
+func (h *OrganizationHandler) RevokeAPIKey(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的 API Key ID")
+		return
+	}
+
+	if err := h.keyService.Revoke(ctx.Request.Context(), id); err != nil {
+		if !response.HandleError(ctx, err, "撤销 API Key 失败") {
+			h.logger.Error("Failed to revoke organization API key", zap.Uint64("api_key_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Organization API key revoked", zap.Uint64("api_key_id", id), zap.Uint64("user_id", ctx.GetUint64("userID")))
+
+	response.NoContent(ctx)
+}
+
+// organizationIDParam 解析路由参数 org_id，无效时返回参数错误
+func organizationIDParam(ctx *gin.Context) (uint64, bool) {
+	organizationID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的组织ID")
+		return 0, false
+	}
+	return organizationID, true
+}
+
+// toOrganizationResponse Domain -> DTO
+func toOrganizationResponse(organization *domain.Organization) *dto.OrganizationResponse {
+	return &dto.OrganizationResponse{
+		ID:          organization.ID,
+		Name:        organization.Name,
+		Description: organization.Description,
+		CreatedBy:   organization.CreatedBy,
+		CreatedAt:   organization.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   organization.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PasswordResetHandler 找回密码处理器
+type PasswordResetHandler struct {
+	resetService domain.PasswordResetService
+	logger       *zap.Logger
+}
+
+// NewPasswordResetHandler 创建找回密码处理器
+func NewPasswordResetHandler(resetService domain.PasswordResetService, logger *zap.Logger) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		resetService: resetService,
+		logger:       logger,
+	}
+}
+
+// Request 申请重置密码
+// @Summary      申请重置密码
+// @Description  向邮箱对应的用户发送重置密码链接（需要配置 SMTP_HOST），链接在 PASSWORD_RESET_TTL 分钟内有效且只能使用一次。邮箱未注册或用户已禁用时同样返回成功
+// @Tags         用户认证
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.PasswordResetRequest  true  "注册邮箱"
+// @Success      200      {object}  response.APIResponse
+// @Failure      400      {object}  response.APIResponse
+// @Router       /password-reset [post]
+func (h *PasswordResetHandler) Request(ctx *gin.Context) {
+	var req dto.PasswordResetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	if err := h.resetService.RequestReset(ctx.Request.Context(), req.Email); err != nil {
+		if !response.HandleError(ctx, err, "发送重置密码邮件失败") {
+			h.logger.Error("Failed to send password reset email", zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "如果该邮箱已注册，重置密码链接已发送"})
+}
+
+// Confirm 设置新密码
+// @Summary      设置新密码
+// @Description  使用重置密码邮件链接中的 token 设置新密码，token 使用后立即失效
+// @Tags         用户认证
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.PasswordResetConfirmRequest  true  "令牌和新密码"
+// @Success      200      {object}  response.APIResponse
+// @Failure      400      {object}  response.APIResponse
+// @Router       /password-reset/confirm [post]
+func (h *PasswordResetHandler) Confirm(ctx *gin.Context) {
+	var req dto.PasswordResetConfirmRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	if err := h.resetService.ConfirmReset(ctx.Request.Context(), req.Token, req.NewPassword); err != nil {
+		if !response.HandleError(ctx, err, "重置密码失败") {
+			h.logger.Error("Failed to reset password", zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "密码已重置，请使用新密码登录"})
+}
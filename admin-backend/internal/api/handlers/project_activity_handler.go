@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ProjectActivityHandler 闲置项目处理器
+type ProjectActivityHandler struct {
+	activityService domain.ProjectActivityService
+	logger          *zap.Logger
+}
+
+// NewProjectActivityHandler 创建闲置项目处理器
+func NewProjectActivityHandler(activityService domain.ProjectActivityService, logger *zap.Logger) *ProjectActivityHandler {
+	return &ProjectActivityHandler{
+		activityService: activityService,
+		logger:          logger,
+	}
+}
+
+// GetStaleProjects 获取闲置项目报告
+// @Summary      获取闲置项目报告
+// @Description  列出超过指定天数没有内容变更的活跃项目，最久未变更的在前。从未变更的项目按创建时间计算；同时返回最近访问时间（Web 或 CLI），便于判断项目是否仍在使用
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        days       query     int  false  "闲置天数，默认使用 PROJECT_STALE_DAYS 配置"
+// @Param        page       query     int  false  "页码"      default(1)
+// @Param        page_size  query     int  false  "每页数量"  default(20)
+// @Success      200        {object}  dto.StaleProjectListResponse
+// @Failure      400        {object}  response.APIResponse
+// @Failure      403        {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/stale-projects [get]
+func (h *ProjectActivityHandler) GetStaleProjects(ctx *gin.Context) {
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "0"))
+	if err != nil {
+		response.ValidationError(ctx, "无效的闲置天数")
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	report, err := h.activityService.GetStaleProjects(ctx.Request.Context(), domain.StaleProjectParams{
+		Days:   days,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	})
+	if err != nil {
+		response.HandleError(ctx, err, "获取闲置项目失败")
+		return
+	}
+
+	now := time.Now()
+	resp := dto.StaleProjectListResponse{
+		Days:     report.Days,
+		Before:   report.Before.Format(time.RFC3339),
+		Projects: make([]*dto.StaleProjectResponse, 0, len(report.Projects)),
+		Total:    report.Total,
+	}
+	for _, project := range report.Projects {
+		lastChanged := project.CreatedAt
+		item := &dto.StaleProjectResponse{
+			ID:        project.ID,
+			Name:      project.Name,
+			Slug:      project.Slug,
+			CreatedAt: project.CreatedAt.Format(time.RFC3339),
+		}
+		if project.LastChangedAt != nil {
+			lastChanged = *project.LastChangedAt
+			item.LastChangedAt = project.LastChangedAt.Format(time.RFC3339)
+		}
+		if project.LastAccessedAt != nil {
+			item.LastAccessedAt = project.LastAccessedAt.Format(time.RFC3339)
+		}
+		item.IdleDays = int(now.Sub(lastChanged).Hours() / 24)
+		resp.Projects = append(resp.Projects, item)
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: report.Total,
+		TotalPages: (report.Total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, resp, meta)
+}
+
+// ArchiveStaleProjects 批量归档闲置项目
+// @Summary      批量归档闲置项目
+// @Description  归档闲置项目报告中选中的项目。归档前按最新的活动时间重新检查，报告生成后又有内容变更、已归档或不存在的项目会被跳过。每个归档的项目记录一条 project.archive 审计日志
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ArchiveStaleProjectsRequest  true  "要归档的项目"
+// @Success      200      {object}  dto.ArchiveStaleProjectsResponse
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/stale-projects/archive [post]
+func (h *ProjectActivityHandler) ArchiveStaleProjects(ctx *gin.Context) {
+	var req dto.ArchiveStaleProjectsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	result, err := h.activityService.ArchiveStaleProjects(ctx.Request.Context(), domain.ArchiveStaleProjectsParams{
+		ProjectIDs: req.ProjectIDs,
+		Days:       req.Days,
+	}, userID.(uint64))
+	if err != nil {
+		h.logger.Error("Failed to archive stale projects", zap.Uint64s("project_ids", req.ProjectIDs), zap.Error(err))
+		response.HandleError(ctx, err, "归档闲置项目失败")
+		return
+	}
+
+	response.Success(ctx, dto.ArchiveStaleProjectsResponse{
+		Archived: result.Archived,
+		Skipped:  result.Skipped,
+	})
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ProjectConfigHandler 项目配置导出/导入处理器
+type ProjectConfigHandler struct {
+	configService domain.ProjectConfigService
+	logger        *zap.Logger
+}
+
+// NewProjectConfigHandler 创建项目配置处理器
+func NewProjectConfigHandler(configService domain.ProjectConfigService, logger *zap.Logger) *ProjectConfigHandler {
+	return &ProjectConfigHandler{
+		configService: configService,
+		logger:        logger,
+	}
+}
+
+// Export 导出项目配置
+// @Summary      导出项目配置
+// @Description  导出项目的设置、启用的语言、入站 Webhook（不含签名密钥）和成员角色，不包含翻译内容。导出的文档可以纳入代码审查，再导入到其他项目
+// @Tags         项目配置
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  dto.ProjectConfigDocument
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/config [get]
+func (h *ProjectConfigHandler) Export(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	config, err := h.configService.Export(ctx.Request.Context(), projectID)
+	if err != nil {
+		h.handleError(ctx, err, "导出项目配置失败")
+		return
+	}
+
+	response.Success(ctx, toProjectConfigDocument(config))
+}
+
+// Import 导入项目配置
+// @Summary      导入项目配置
+// @Description  将配置文档应用到项目：设置中的非空字段覆盖当前值；Webhook 按名称、成员按用户名匹配，只新增或更新，不删除文档中没有的项。新建的 Webhook 会生成新的签名密钥。语言在实例内全局管理，未启用的语言只在结果中列出。dry_run=true 时只返回将要执行的变更
+// @Tags         项目配置
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                        true   "项目ID"
+// @Param        dry_run     query     bool                       false  "只预览变更"
+// @Param        request     body      dto.ProjectConfigDocument  true   "项目配置文档"
+// @Success      200         {object}  dto.ProjectConfigImportResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/config/import [post]
+func (h *ProjectConfigHandler) Import(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.ProjectConfigDocument
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	result, err := h.configService.Import(ctx.Request.Context(), projectID, domain.ProjectConfigImportParams{
+		Config: toProjectConfig(&req),
+		DryRun: ctx.Query("dry_run") == "true",
+	}, userID.(uint64))
+	if err != nil {
+		h.handleError(ctx, err, "导入项目配置失败")
+		return
+	}
+
+	if !result.DryRun {
+		h.logger.Info("Project config imported",
+			zap.Uint64("project_id", projectID),
+			zap.String("source", req.Source),
+			zap.Int("changes", len(result.Changes)),
+			zap.Uint64("operator_id", userID.(uint64)),
+		)
+	}
+
+	resp := &dto.ProjectConfigImportResponse{
+		DryRun:           result.DryRun,
+		Changes:          make([]*dto.ProjectConfigChangeDTO, 0, len(result.Changes)),
+		MissingLanguages: result.MissingLanguages,
+		UnknownUsers:     result.UnknownUsers,
+	}
+	for _, change := range result.Changes {
+		resp.Changes = append(resp.Changes, &dto.ProjectConfigChangeDTO{
+			Section: change.Section,
+			Target:  change.Target,
+			Action:  change.Action,
+			Old:     change.Old,
+			New:     change.New,
+		})
+	}
+	response.Success(ctx, resp)
+}
+
+// handleError 将领域错误映射为HTTP响应
+func (h *ProjectConfigHandler) handleError(ctx *gin.Context, err error, fallback string) {
+	if !response.HandleError(ctx, err, fallback) {
+		h.logger.Error(fallback, zap.Error(err))
+	}
+}
+
+// toProjectConfigDocument Domain -> DTO
+func toProjectConfigDocument(config *domain.ProjectConfig) *dto.ProjectConfigDocument {
+	doc := &dto.ProjectConfigDocument{
+		Version: config.Version,
+		Source:  config.Source,
+		Settings: dto.ProjectConfigSettingsDTO{
+			Description:        config.Settings.Description,
+			Status:             config.Settings.Status,
+			ExportFileTemplate: config.Settings.ExportFileTemplate,
+		},
+		Languages: config.Languages,
+		Webhooks:  make([]dto.ProjectConfigWebhookDTO, 0, len(config.Webhooks)),
+		Members:   make([]dto.ProjectConfigMemberDTO, 0, len(config.Members)),
+	}
+	for _, webhook := range config.Webhooks {
+		doc.Webhooks = append(doc.Webhooks, dto.ProjectConfigWebhookDTO{
+			Name:              webhook.Name,
+			Provider:          webhook.Provider,
+			ExternalProjectID: webhook.ExternalProjectID,
+			LanguageMapping:   webhook.LanguageMapping,
+			ConflictStrategy:  webhook.ConflictStrategy,
+			Status:            webhook.Status,
+		})
+	}
+	for _, member := range config.Members {
+		doc.Members = append(doc.Members, dto.ProjectConfigMemberDTO{Username: member.Username, Role: member.Role})
+	}
+	return doc
+}
+
+// toProjectConfig DTO -> Domain
+func toProjectConfig(doc *dto.ProjectConfigDocument) domain.ProjectConfig {
+	config := domain.ProjectConfig{
+		Version: doc.Version,
+		Source:  doc.Source,
+		Settings: domain.ProjectConfigSettings{
+			Description:        doc.Settings.Description,
+			Status:             doc.Settings.Status,
+			ExportFileTemplate: doc.Settings.ExportFileTemplate,
+		},
+		Languages: doc.Languages,
+	}
+	for _, webhook := range doc.Webhooks {
+		config.Webhooks = append(config.Webhooks, domain.ProjectConfigWebhook{
+			Name:              webhook.Name,
+			Provider:          webhook.Provider,
+			ExternalProjectID: webhook.ExternalProjectID,
+			LanguageMapping:   webhook.LanguageMapping,
+			ConflictStrategy:  webhook.ConflictStrategy,
+			Status:            webhook.Status,
+		})
+	}
+	for _, member := range doc.Members {
+		config.Members = append(config.Members, domain.ProjectConfigMember{Username: member.Username, Role: member.Role})
+	}
+	return config
+}
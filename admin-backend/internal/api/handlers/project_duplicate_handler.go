@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ProjectDuplicateHandler 项目复制处理器
+type ProjectDuplicateHandler struct {
+	duplicateService domain.ProjectDuplicateService
+	logger           *zap.Logger
+}
+
+// NewProjectDuplicateHandler 创建项目复制处理器
+func NewProjectDuplicateHandler(duplicateService domain.ProjectDuplicateService, logger *zap.Logger) *ProjectDuplicateHandler {
+	return &ProjectDuplicateHandler{
+		duplicateService: duplicateService,
+		logger:           logger,
+	}
+}
+
+// Duplicate 复制项目
+// @Summary      复制项目
+// @Description  将项目的设置（描述、源语言、导出文件命名模板、所属组织）、命名空间、发布门槛和翻译复制到新项目，include_members 为 true 时同时复制成员及其角色，发起人成为新项目的 owner。
+// @Description  翻译不超过 PROJECT_DUPLICATE_SYNC_LIMIT 条时在请求中复制完成并返回 201，任务状态为 completed；否则返回 202，翻译在后台按批复制，通过任务查询进度
+// @Tags         项目管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                          true  "项目ID"
+// @Param        request     body      dto.DuplicateProjectRequest  true  "新项目信息"
+// @Success      201         {object}  domain.ProjectDuplicateJob
+// @Success      202         {object}  domain.ProjectDuplicateJob
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/duplicate [post]
+func (h *ProjectDuplicateHandler) Duplicate(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.DuplicateProjectRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	job, err := h.duplicateService.Duplicate(ctx.Request.Context(), projectID, domain.ProjectDuplicateParams{
+		Name:           req.Name,
+		Slug:           req.Slug,
+		AutoSuffix:     req.AutoSuffix,
+		Description:    req.Description,
+		IncludeMembers: req.IncludeMembers,
+	}, userID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "复制项目失败") {
+			h.logger.Error("Failed to duplicate project", zap.Uint64("project_id", projectID), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Project duplicated",
+		zap.Uint64("source_project_id", projectID),
+		zap.Uint64("project_id", job.ProjectID),
+		zap.Uint64("job_id", job.ID),
+		zap.String("status", job.Status),
+		zap.Int64("translations", job.Total),
+		zap.Uint64("operator_id", userID),
+	)
+	if job.Status == domain.ProjectDuplicateStatusRunning {
+		response.SuccessWithStatus(ctx, http.StatusAccepted, job)
+		return
+	}
+	response.Created(ctx, job)
+}
+
+// GetJob 获取项目复制任务
+// @Summary      获取项目复制任务
+// @Description  查询从项目发起的复制任务的状态和进度（已复制的翻译条数）。任务失败时已复制的翻译保留在新项目中
+// @Tags         项目管理
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        job_id      path      int  true  "任务ID"
+// @Success      200         {object}  domain.ProjectDuplicateJob
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/duplicate/{job_id} [get]
+func (h *ProjectDuplicateHandler) GetJob(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+	jobID, err := strconv.ParseUint(ctx.Param("job_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的任务ID")
+		return
+	}
+
+	job, err := h.duplicateService.GetJob(ctx.Request.Context(), projectID, jobID)
+	if err != nil {
+		if !response.HandleError(ctx, err, "获取项目复制任务失败") {
+			h.logger.Error("Failed to get project duplicate job", zap.Uint64("job_id", jobID), zap.Error(err))
+		}
+		return
+	}
+	response.Success(ctx, job)
+}
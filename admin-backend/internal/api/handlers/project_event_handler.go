@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ProjectEventHandler 编辑器实时事件处理器
+// 与 CLI 监听模式共用变更分发中心，通过 Server-Sent Events 推送，浏览器可以携带 Authorization 头用 fetch 读取
+type ProjectEventHandler struct {
+	watchService domain.WatchService
+	logger       *zap.Logger
+}
+
+// NewProjectEventHandler 创建编辑器实时事件处理器
+func NewProjectEventHandler(watchService domain.WatchService, logger *zap.Logger) *ProjectEventHandler {
+	return &ProjectEventHandler{
+		watchService: watchService,
+		logger:       logger,
+	}
+}
+
+// Stream 订阅项目的翻译变更
+// @Summary      订阅项目的翻译变更（SSE）
+// @Description  以 Server-Sent Events 推送项目的翻译变更，多人同时编辑时编辑器可以实时看到其他人的修改，不需要轮询翻译矩阵。
+// @Description  每条消息的 event 为消息类型，data 为 JSON：连接建立后先发送 ready，之后发送 changed（包含变更的键、语言和操作人 user_id）和定期的 ping；
+// @Description  收到 resync 或连接断开时变更可能丢失，编辑器应重新加载翻译矩阵后重新连接。
+// @Tags         项目管理
+// @Produce      text/event-stream
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        locales     query     string  false  "只接收这些语言的变更，逗号分隔，如 en,zh-CN"
+// @Success      200         {object}  domain.WatchEvent
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/events [get]
+func (h *ProjectEventHandler) Stream(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var locales []string
+	for _, locale := range strings.Split(ctx.Query("locales"), ",") {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+
+	events, cancel := h.watchService.Watch(projectID, locales)
+	defer cancel()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// 关闭反向代理的响应缓冲，变更立即送达
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	userID := ctx.GetUint64("userID")
+	h.logger.Info("Project event stream connected", zap.Uint64("project_id", projectID), zap.Uint64("user_id", userID))
+	defer h.logger.Info("Project event stream disconnected", zap.Uint64("project_id", projectID), zap.Uint64("user_id", userID))
+
+	h.send(ctx, domain.WatchEvent{Type: domain.WatchMessageReady, ProjectID: projectID, OccurredAt: time.Now()})
+
+	ticker := time.NewTicker(watchPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				h.send(ctx, domain.WatchEvent{Type: domain.WatchMessageResync, ProjectID: projectID, OccurredAt: time.Now()})
+				return
+			}
+			h.send(ctx, event)
+		case <-ticker.C:
+			h.send(ctx, domain.WatchEvent{Type: domain.WatchMessagePing, ProjectID: projectID, OccurredAt: time.Now()})
+		}
+	}
+}
+
+// send 发送一条消息并立即刷新；写入失败时请求的 context 随连接关闭而结束
+func (h *ProjectEventHandler) send(ctx *gin.Context, event domain.WatchEvent) {
+	ctx.SSEvent(event.Type, event)
+	ctx.Writer.Flush()
+}
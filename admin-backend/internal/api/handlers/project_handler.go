@@ -26,13 +26,17 @@ func NewProjectHandler(projectService domain.ProjectService, logger *zap.Logger)
 
 // Create 创建项目
 // @Summary      创建项目
-// @Description  创建新的翻译项目
+// @Description  创建新的翻译项目；可指定自定义标识，auto_suffix 为 true 时标识冲突自动追加 -2、-3 等后缀。
+// @Description  指定 organization_id 时在组织中创建项目，需要是系统管理员或组织的 owner、admin。
+// @Description  source_language 为项目的源语言，源语言译文修改后其他语言的译文标记为 outdated；为空时使用默认语言
 // @Tags         项目管理
 // @Accept       json
 // @Produce      json
 // @Param        project  body      dto.CreateProjectRequest  true  "项目信息"
 // @Success      201      {object}  domain.Project
 // @Failure      400      {object}  map[string]string
+// @Failure      403      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
 // @Failure      409      {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /projects [post]
@@ -53,19 +57,23 @@ func (h *ProjectHandler) Create(ctx *gin.Context) {
 
 	// DTO -> Domain params
 	params := domain.CreateProjectParams{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:           req.Name,
+		Description:    req.Description,
+		Slug:           req.Slug,
+		AutoSuffix:     req.AutoSuffix,
+		OrganizationID: req.OrganizationID,
+		SourceLanguage: req.SourceLanguage,
 	}
 
 	project, err := h.projectService.Create(ctx.Request.Context(), params, userID.(uint64))
 	if err != nil {
 		switch err {
-		case domain.ErrProjectExists:
+		case domain.ErrProjectExists, domain.ErrSlugExists:
 			response.Conflict(ctx, err.Error())
 		case domain.ErrInvalidSlug:
 			response.BadRequest(ctx, err.Error())
 		default:
-			response.InternalServerError(ctx, "创建项目失败")
+			response.HandleError(ctx, err, "创建项目失败")
 		}
 		return
 	}
@@ -124,13 +132,15 @@ func (h *ProjectHandler) GetByID(ctx *gin.Context) {
 
 // GetAll 获取项目列表
 // @Summary      获取项目列表
-// @Description  获取项目列表，支持分页和关键词搜索
+// @Description  获取项目列表，支持分页和关键词搜索。sort 指定排序（如 updated_at:desc，可排序字段：id、name、status、created_at、updated_at），fields 只返回指定的字段（如 id,name,slug）
 // @Tags         项目管理
 // @Accept       json
 // @Produce      json
 // @Param        page      query     int     false  "页码"  default(1)
 // @Param        page_size query     int     false  "每页数量"  default(10)
 // @Param        keyword   query     string  false  "搜索关键词"
+// @Param        sort      query     string  false  "排序，字段:asc|desc，多个用逗号分隔"
+// @Param        fields    query     string  false  "返回的字段，逗号分隔"
 // @Success      200       {object}  map[string]interface{}
 // @Failure      400       {object}  map[string]string
 // @Security     BearerAuth
@@ -150,7 +160,12 @@ func (h *ProjectHandler) GetAll(ctx *gin.Context) {
 
 	offset := (page - 1) * pageSize
 
-	projects, total, err := h.projectService.GetAll(ctx.Request.Context(), pageSize, offset, keyword)
+	list, ok := parseListQuery(ctx, projectListFields)
+	if !ok {
+		return
+	}
+
+	projects, total, err := h.projectService.GetAll(ctx.Request.Context(), pageSize, offset, keyword, list.sort)
 	if err != nil {
 		response.InternalServerError(ctx, "获取项目列表失败")
 		return
@@ -163,9 +178,17 @@ func (h *ProjectHandler) GetAll(ctx *gin.Context) {
 		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
 	}
 
-	response.SuccessWithMeta(ctx, projects, meta)
+	data, err := selectFields(projects, list.fields)
+	if err != nil {
+		response.InternalServerError(ctx, "获取项目列表失败")
+		return
+	}
+	response.SuccessWithMeta(ctx, data, meta)
 }
 
+// projectListFields 项目列表可以排序和选择的字段
+var projectListFields = newListFields(domain.Project{}, "id", "name", "status", "created_at", "updated_at")
+
 // GetAccessibleProjects 获取用户可访问的项目列表
 // @Summary      获取用户可访问的项目列表
 // @Description  根据用户权限返回可访问的项目列表，管理员返回所有项目，普通用户返回参与的项目
@@ -219,12 +242,12 @@ func (h *ProjectHandler) GetAccessibleProjects(ctx *gin.Context) {
 
 // Update 更新项目
 // @Summary      更新项目
-// @Description  更新项目信息
+// @Description  更新项目信息；source_language 为空字符串时改为使用默认语言，修改源语言不影响已有译文的审核状态
 // @Tags         项目管理
 // @Accept       json
 // @Produce      json
 // @Param        id       path      int                           true  "项目ID"
-// @Param        project  body      domain.UpdateProjectRequest  true  "项目信息"
+// @Param        project  body      dto.UpdateProjectRequest     true  "项目信息"
 // @Success      200      {object}  domain.Project
 // @Failure      400      {object}  map[string]string
 // @Failure      404      {object}  map[string]string
@@ -253,9 +276,11 @@ func (h *ProjectHandler) Update(ctx *gin.Context) {
 
 	// DTO -> Domain params
 	params := domain.UpdateProjectParams{
-		Name:        req.Name,
-		Description: req.Description,
-		Status:      req.Status,
+		Name:               req.Name,
+		Description:        req.Description,
+		Status:             req.Status,
+		ExportFileTemplate: req.ExportFileTemplate,
+		SourceLanguage:     req.SourceLanguage,
 	}
 
 	project, err := h.projectService.Update(ctx.Request.Context(), id, params, userID.(uint64))
@@ -263,10 +288,10 @@ func (h *ProjectHandler) Update(ctx *gin.Context) {
 		switch err {
 		case domain.ErrProjectNotFound:
 			response.NotFound(ctx, err.Error())
-		case domain.ErrProjectExists, domain.ErrInvalidInput:
+		case domain.ErrProjectExists, domain.ErrInvalidInput, domain.ErrInvalidExportTemplate:
 			response.BadRequest(ctx, err.Error())
 		default:
-			response.InternalServerError(ctx, "更新项目失败")
+			response.HandleError(ctx, err, "更新项目失败")
 		}
 		return
 	}
@@ -288,15 +313,174 @@ func (h *ProjectHandler) Update(ctx *gin.Context) {
 	response.Success(ctx, project)
 }
 
+// RenameSlug 修改项目标识
+// @Summary      修改项目标识
+// @Description  修改项目标识（slug），旧标识保留为别名，CLI 等按旧标识访问时仍指向该项目；新标识与当前标识相同时不做修改
+// @Tags         项目管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "项目ID"
+// @Param        request  body      dto.RenameProjectSlugRequest  true  "新项目标识"
+// @Success      200      {object}  domain.Project
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Failure      409      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /projects/slug/{id} [put]
+func (h *ProjectHandler) RenameSlug(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.RenameProjectSlugRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	project, err := h.projectService.RenameSlug(ctx.Request.Context(), id, req.Slug, userID.(uint64))
+	if err != nil {
+		switch err {
+		case domain.ErrProjectNotFound:
+			response.NotFound(ctx, err.Error())
+		case domain.ErrInvalidSlug:
+			response.BadRequest(ctx, err.Error())
+		case domain.ErrSlugExists:
+			response.Conflict(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "修改项目标识失败")
+		}
+		return
+	}
+
+	h.logger.Info("Project slug renamed",
+		zap.Uint64("project_id", id),
+		zap.String("project_slug", project.Slug),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, project)
+}
+
+// SetProtection 开启或关闭项目删除保护
+// @Summary      开启或关闭项目删除保护
+// @Description  开启删除保护后不能删除项目，也不能批量删除项目的翻译（按 ID 批量删除、按条件批量删除、按前缀删除键，以及删除翻译的推送），直到项目所有者关闭保护；每次变更记录审计日志
+// @Tags         项目管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                              true  "项目ID"
+// @Param        request  body      dto.SetProjectProtectionRequest  true  "是否开启删除保护"
+// @Success      200      {object}  domain.Project
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /projects/protection/{id} [put]
+func (h *ProjectHandler) SetProtection(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.SetProjectProtectionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	project, err := h.projectService.SetProtection(ctx.Request.Context(), id, *req.Protected, userID.(uint64))
+	if err != nil {
+		switch err {
+		case domain.ErrProjectNotFound:
+			response.NotFound(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "修改项目删除保护失败")
+		}
+		return
+	}
+
+	h.logger.Info("Project protection changed",
+		zap.Uint64("project_id", id),
+		zap.Bool("protected", project.Protected),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, project)
+}
+
+// SetDelivery 开启或关闭项目公开分发
+// @Summary      开启或关闭项目公开分发
+// @Description  开启后应用可以不经认证通过 GET /delivery/{project_slug}/{locale}.json 获取项目最新发布版本的语言包，只分发已发布的版本；每次变更记录审计日志
+// @Tags         项目管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                            true  "项目ID"
+// @Param        request  body      dto.SetProjectDeliveryRequest  true  "是否开启公开分发"
+// @Success      200      {object}  domain.Project
+// @Failure      400      {object}  map[string]string
+// @Failure      404      {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /projects/delivery/{id} [put]
+func (h *ProjectHandler) SetDelivery(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.SetProjectDeliveryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	project, err := h.projectService.SetDelivery(ctx.Request.Context(), id, *req.Enabled, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "修改项目公开分发失败")
+		return
+	}
+
+	h.logger.Info("Project delivery changed",
+		zap.Uint64("project_id", id),
+		zap.Bool("delivery_enabled", project.DeliveryEnabled),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, project)
+}
+
 // Delete 删除项目
 // @Summary      删除项目
-// @Description  删除指定的项目
+// @Description  删除指定的项目，开启删除保护的项目需要先关闭保护
 // @Tags         项目管理
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "项目ID"
 // @Success      204  {object}  nil
 // @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /projects/delete/{id} [delete]
@@ -313,6 +497,8 @@ func (h *ProjectHandler) Delete(ctx *gin.Context) {
 		switch err {
 		case domain.ErrProjectNotFound:
 			response.NotFound(ctx, err.Error())
+		case domain.ErrProjectProtected:
+			response.Forbidden(ctx, err.Error())
 		default:
 			response.InternalServerError(ctx, "删除项目失败")
 		}
@@ -84,6 +84,134 @@ func (h *ProjectMemberHandler) AddMember(ctx *gin.Context) {
 	response.Created(ctx, member)
 }
 
+// BulkAddMembers 批量添加项目成员
+// @Summary      批量添加项目成员
+// @Description  按用户ID或邮箱一次添加最多 500 个成员，成员未指定角色时使用请求中的 role。单个成员失败（用户不存在、已禁用、角色无效或重复）不影响其他成员，已是项目成员的用户跳过且角色不变。notify 为 true 时向添加的成员发送通知邮件，未配置邮件服务时返回 400
+// @Tags         项目成员管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                                true  "项目ID"
+// @Param        members     body      dto.BulkAddProjectMembersRequest  true  "成员列表"
+// @Success      200         {object}  domain.BulkAddMembersResult
+// @Failure      400         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/members/bulk [post]
+func (h *ProjectMemberHandler) BulkAddMembers(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.BulkAddProjectMembersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	currentUserID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	// DTO -> Domain params
+	params := domain.BulkAddMembersParams{
+		Members: make([]domain.BulkMemberEntry, 0, len(req.Members)),
+		Notify:  req.Notify,
+	}
+	for _, member := range req.Members {
+		role := member.Role
+		if role == "" {
+			role = req.Role
+		}
+		params.Members = append(params.Members, domain.BulkMemberEntry{
+			UserID: member.UserID,
+			Email:  member.Email,
+			Role:   role,
+		})
+	}
+
+	result, err := h.projectMemberService.BulkAddMembers(ctx.Request.Context(), projectID, params, currentUserID.(uint64))
+	if err != nil {
+		handleBulkMemberError(ctx, err, "批量添加项目成员失败")
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// ImportMembers 从表格导入项目成员
+// @Summary      从表格导入项目成员
+// @Description  上传 CSV/XLSX 文件批量添加成员，第一行为表头（忽略大小写）：user 列填写用户ID或邮箱，也可以分别使用 user_id 和 email 列；role 列为空或没有 role 列时使用 default_role。结果中的 index 为表格中的行号，其余规则与批量添加相同
+// @Tags         项目成员管理
+// @Accept       text/csv
+// @Accept       application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce      json
+// @Param        project_id    path      int     true   "项目ID"
+// @Param        format        query     string  false  "文件格式，默认按文件内容识别"  Enums(csv, xlsx)
+// @Param        default_role  query     string  false  "role 列为空时使用的角色"  Enums(owner, editor, viewer)
+// @Param        notify        query     bool    false  "向添加的成员发送通知邮件"
+// @Success      200           {object}  domain.BulkAddMembersResult
+// @Failure      400           {object}  map[string]string
+// @Failure      404           {object}  map[string]string
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/members/import [post]
+func (h *ProjectMemberHandler) ImportMembers(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	notify, err := strconv.ParseBool(ctx.DefaultQuery("notify", "false"))
+	if err != nil {
+		response.ValidationError(ctx, "无效的 notify 参数")
+		return
+	}
+
+	data, err := ctx.GetRawData()
+	if err != nil || len(data) == 0 {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	currentUserID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	result, err := h.projectMemberService.ImportMembers(ctx.Request.Context(), projectID, domain.MemberImportParams{
+		Format:      ctx.Query("format"),
+		Data:        data,
+		DefaultRole: ctx.Query("default_role"),
+		Notify:      notify,
+	}, currentUserID.(uint64))
+	if err != nil {
+		handleBulkMemberError(ctx, err, "导入项目成员失败")
+		return
+	}
+
+	response.Success(ctx, result)
+}
+
+// handleBulkMemberError 将批量添加成员的领域错误映射为HTTP响应，表格错误附带详情
+func handleBulkMemberError(ctx *gin.Context, err error, fallback string) {
+	if appErr, ok := domain.IsAppError(err); ok {
+		switch appErr.Type {
+		case domain.ErrorTypeNotFound:
+			response.NotFound(ctx, appErr.Message)
+			return
+		case domain.ErrorTypeValidation:
+			response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			return
+		}
+	}
+	response.InternalServerError(ctx, fallback)
+}
+
 // GetProjectMembers 获取项目成员列表
 // @Summary      获取项目成员列表
 // @Description  获取指定项目的所有成员信息
@@ -117,7 +245,22 @@ func (h *ProjectMemberHandler) GetProjectMembers(ctx *gin.Context) {
 		return
 	}
 
-	response.Success(ctx, members)
+	responses := make([]dto.ProjectMemberInfo, 0, len(members))
+	for _, member := range members {
+		responses = append(responses, dto.ProjectMemberInfo{
+			ID:          member.ID,
+			UserID:      member.UserID,
+			Username:    member.Username,
+			DisplayName: member.DisplayName,
+			AvatarURL:   member.AvatarURL,
+			Email:       member.Email,
+			Language:    member.Language,
+			Timezone:    member.Timezone,
+			Role:        member.Role,
+			Source:      member.Source,
+		})
+	}
+	response.Success(ctx, responses)
 }
 
 // GetUserProjects 获取用户参与的项目列表
@@ -131,7 +274,7 @@ func (h *ProjectMemberHandler) GetProjectMembers(ctx *gin.Context) {
 // @Failure      400      {object}  map[string]string
 // @Failure      404      {object}  map[string]string
 // @Security     BearerAuth
-// @Router       /users/{user_id}/projects [get]
+// @Router       /user-projects/{user_id} [get]
 func (h *ProjectMemberHandler) GetUserProjects(ctx *gin.Context) {
 	// 解析用户ID
 	userIDStr := ctx.Param("user_id")
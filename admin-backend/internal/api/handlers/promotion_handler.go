@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// PromotionHandler 环境推送处理器
+type PromotionHandler struct {
+	promotionService domain.PromotionService
+	logger           *zap.Logger
+}
+
+// NewPromotionHandler 创建环境推送处理器
+func NewPromotionHandler(promotionService domain.PromotionService, logger *zap.Logger) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+		logger:           logger,
+	}
+}
+
+// Create 计算环境差异
+// @Summary      计算环境差异
+// @Description  从源环境（远程 YFlow 实例或上传的快照）获取翻译，计算与本项目的差异并保存为待审核的推送记录。远程实例通过其 CLI 接口拉取，api_key 不会保存
+// @Tags         环境推送
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                       true  "项目ID"
+// @Param        request     body      dto.PromotionDiffRequest  true  "源环境"
+// @Success      201         {object}  dto.PromotionResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/promotions [post]
+func (h *PromotionHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.PromotionDiffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	detail, err := h.promotionService.CreateDiff(ctx.Request.Context(), projectID, domain.PromotionSourceParams{
+		RemoteURL:       req.RemoteURL,
+		APIKey:          req.APIKey,
+		SourceProject:   req.SourceProject,
+		Snapshot:        req.Snapshot,
+		IncludeRemovals: req.IncludeRemovals,
+	}, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "计算环境差异失败")
+		return
+	}
+
+	response.Created(ctx, toPromotionResponse(detail.Promotion, detail))
+}
+
+// GetByProjectID 获取项目的推送记录列表
+// @Summary      获取环境推送记录列表
+// @Description  分页获取项目的推送记录，不包含差异内容
+// @Tags         环境推送
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        page        query     int  false  "页码"      default(1)
+// @Param        page_size   query     int  false  "每页数量"  default(10)
+// @Success      200         {object}  dto.PromotionListResponse
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/promotions [get]
+func (h *PromotionHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	promotions, total, err := h.promotionService.GetByProjectID(ctx.Request.Context(), projectID, pageSize, offset)
+	if err != nil {
+		response.InternalServerError(ctx, "获取推送记录失败")
+		return
+	}
+
+	resp := dto.PromotionListResponse{
+		Promotions: make([]*dto.PromotionResponse, 0, len(promotions)),
+		Total:      total,
+	}
+	for _, promotion := range promotions {
+		resp.Promotions = append(resp.Promotions, toPromotionResponse(promotion, nil))
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, resp, meta)
+}
+
+// GetByID 获取推送记录详情
+// @Summary      获取环境推送记录详情
+// @Description  返回全部差异和已应用的差异；已应用的记录同时校验签名，signature_valid 为 false 表示记录被篡改
+// @Tags         环境推送
+// @Accept       json
+// @Produce      json
+// @Param        project_id    path      int  true  "项目ID"
+// @Param        promotion_id  path      int  true  "推送记录ID"
+// @Success      200           {object}  dto.PromotionResponse
+// @Failure      400           {object}  response.APIResponse
+// @Failure      404           {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/promotions/{promotion_id} [get]
+func (h *PromotionHandler) GetByID(ctx *gin.Context) {
+	detail, ok := h.loadProjectPromotion(ctx)
+	if !ok {
+		return
+	}
+
+	response.Success(ctx, toPromotionResponse(detail.Promotion, detail))
+}
+
+// Apply 应用审核通过的差异
+// @Summary      应用环境差异
+// @Description  将审核通过的差异写入本项目并生成签名的推送记录。计算差异后相关翻译被修改过时返回 409，需要重新计算差异；包含删除的差异应用到开启删除保护的项目时返回 403
+// @Tags         环境推送
+// @Accept       json
+// @Produce      json
+// @Param        project_id    path      int                        true  "项目ID"
+// @Param        promotion_id  path      int                        true  "推送记录ID"
+// @Param        request       body      dto.PromotionApplyRequest  true  "审核通过的差异"
+// @Success      200           {object}  dto.PromotionResponse
+// @Failure      400           {object}  response.APIResponse
+// @Failure      403           {object}  response.APIResponse
+// @Failure      404           {object}  response.APIResponse
+// @Failure      409           {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/promotions/{promotion_id}/apply [post]
+func (h *PromotionHandler) Apply(ctx *gin.Context) {
+	current, ok := h.loadProjectPromotion(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.PromotionApplyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.PromotionApplyParams{All: req.All}
+	for _, ref := range req.Approved {
+		params.Approved = append(params.Approved, domain.PromotionChangeRef{Key: ref.Key, Language: ref.Language})
+	}
+
+	detail, err := h.promotionService.Apply(ctx.Request.Context(), current.Promotion.ID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "应用环境差异失败")
+		return
+	}
+
+	h.logger.Info("Promotion applied",
+		zap.Uint64("promotion_id", detail.Promotion.ID),
+		zap.Uint64("project_id", detail.Promotion.ProjectID),
+		zap.String("source", detail.Promotion.Source),
+		zap.Int("applied", len(detail.Applied)),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, toPromotionResponse(detail.Promotion, detail))
+}
+
+// loadProjectPromotion 加载路径中的推送记录并校验其属于路径中的项目
+func (h *PromotionHandler) loadProjectPromotion(ctx *gin.Context) (*domain.PromotionDetail, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return nil, false
+	}
+	promotionID, err := strconv.ParseUint(ctx.Param("promotion_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的推送记录ID")
+		return nil, false
+	}
+
+	detail, err := h.promotionService.GetByID(ctx.Request.Context(), promotionID)
+	if err != nil || detail.Promotion.ProjectID != projectID {
+		if err != nil && err != domain.ErrPromotionNotFound {
+			response.InternalServerError(ctx, "获取推送记录失败")
+			return nil, false
+		}
+		response.NotFound(ctx, domain.ErrPromotionNotFound.Message)
+		return nil, false
+	}
+
+	return detail, true
+}
+
+// toPromotionResponse Domain -> DTO，detail 为空时不包含差异内容
+func toPromotionResponse(promotion *domain.Promotion, detail *domain.PromotionDetail) *dto.PromotionResponse {
+	resp := &dto.PromotionResponse{
+		ID:               promotion.ID,
+		ProjectID:        promotion.ProjectID,
+		Source:           promotion.Source,
+		SourceProject:    promotion.SourceProject,
+		Status:           promotion.Status,
+		MissingLanguages: []string{},
+		Signature:        promotion.Signature,
+		CreatedBy:        promotion.CreatedBy,
+		AppliedBy:        promotion.AppliedBy,
+		CreatedAt:        promotion.CreatedAt.Format(time.RFC3339),
+	}
+	if promotion.MissingLanguages != "" {
+		resp.MissingLanguages = strings.Split(promotion.MissingLanguages, ",")
+	}
+	if promotion.AppliedAt != nil {
+		resp.AppliedAt = promotion.AppliedAt.Format(time.RFC3339)
+	}
+	if detail == nil {
+		return resp
+	}
+
+	resp.SignatureValid = detail.SignatureValid
+	resp.Summary = &dto.PromotionSummary{}
+	resp.Changes = make([]*dto.PromotionChangeResponse, 0, len(detail.Changes))
+	for _, change := range detail.Changes {
+		switch change.Type {
+		case domain.PromotionChangeAdded:
+			resp.Summary.Added++
+		case domain.PromotionChangeModified:
+			resp.Summary.Modified++
+		case domain.PromotionChangeRemoved:
+			resp.Summary.Removed++
+		}
+		resp.Changes = append(resp.Changes, toPromotionChangeResponse(change))
+	}
+	for _, change := range detail.Applied {
+		resp.Applied = append(resp.Applied, toPromotionChangeResponse(change))
+	}
+	return resp
+}
+
+// toPromotionChangeResponse Domain -> DTO
+func toPromotionChangeResponse(change domain.PromotionChange) *dto.PromotionChangeResponse {
+	return &dto.PromotionChangeResponse{
+		Key:      change.Key,
+		Language: change.Language,
+		Type:     change.Type,
+		OldValue: change.OldValue,
+		NewValue: change.NewValue,
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// QAHandler 翻译质量检查处理器
+type QAHandler struct {
+	qaService domain.QAService
+	logger    *zap.Logger
+}
+
+// NewQAHandler 创建翻译质量检查处理器
+func NewQAHandler(qaService domain.QAService, logger *zap.Logger) *QAHandler {
+	return &QAHandler{
+		qaService: qaService,
+		logger:    logger,
+	}
+}
+
+// GetReport 获取项目的 QA 报告
+// @Summary      获取 QA 报告
+// @Description  检查值类型为 string、markdown 或 html 的键的有效翻译（json 类型不检查）：markup_invalid 表示标记无法正确解析（标签未关闭或没有开始标签、代码块或行内代码未闭合、链接语法不完整，string 类型只检查 HTML 标签）；disallowed_markup 表示 markdown 或 html 包含不允许的标签（如 script、iframe）、属性（如 onclick）或链接协议（如 javascript:），suggestion 为移除这些内容后的译文；link_mismatch 表示链接与默认语言的译文不一致；placeholder_mismatch 表示占位符（{{name}}、printf 格式如 %s 和 %1$d、ICU 参数如 {0} 和 {name}）与默认语言的译文不一致；tag_mismatch 表示 string 类型文本中的 HTML 标签与默认语言的译文不一致；trailing_whitespace 表示末尾空白与默认语言的译文不一致，suggestion 为改正后的译文；length_exceeded 表示译文字符数超过原文的 max_length_ratio 倍（原文较短时至少允许多 10 个字符）；string 类型包含 plural、select 等 ICU 参数时，icu_invalid 表示 ICU 语法错误，plural_category 表示复数选项缺少该语言的 CLDR 复数类别或包含该语言没有的类别，select_mismatch 表示 select 选项与默认语言的译文不一致。项目有术语表时还检查：glossary_mismatch 表示原文包含术语而译文没有使用规定译文，forbidden_term 表示译文包含禁用译文，有规定译文时 suggestion 为替换后的译文。只报告问题，不修改翻译。问题最多列出 1000 条，counts 为全部数量
+// @Tags         项目管理
+// @Produce      json
+// @Param        project_id        path      int     true   "项目ID"
+// @Param        language          query     string  false  "只检查该语言代码的翻译"
+// @Param        type              query     string  false  "只列出该类型的问题"  Enums(markup_invalid, disallowed_markup, link_mismatch, placeholder_mismatch, glossary_mismatch, forbidden_term, tag_mismatch, trailing_whitespace, length_exceeded, icu_invalid, plural_category, select_mismatch)
+// @Param        max_length_ratio  query     number  false  "译文长度最多为原文的倍数（1 到 10）"  default(2)
+// @Success      200               {object}  domain.QAReport
+// @Failure      400               {object}  response.APIResponse
+// @Failure      404               {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/qa-report [get]
+func (h *QAHandler) GetReport(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var maxLengthRatio float64
+	if raw := ctx.Query("max_length_ratio"); raw != "" {
+		if maxLengthRatio, err = strconv.ParseFloat(raw, 64); err != nil {
+			response.ValidationError(ctx, domain.ErrInvalidQALengthRatio.Message)
+			return
+		}
+	}
+
+	report, err := h.qaService.GetReport(ctx.Request.Context(), projectID, domain.QAReportQuery{
+		Language:       ctx.Query("language"),
+		Type:           ctx.Query("type"),
+		MaxLengthRatio: maxLengthRatio,
+	})
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// GetConsistency 获取项目各语言的术语一致性得分
+// @Summary      获取术语一致性得分
+// @Description  默认语言中文本相同（忽略首尾空白）的多个键视为同一术语，统计每个启用语言中这些键的译文是否相同。每个术语以使用最多的译文为建议译文，与之相同的译文计为一致，得分 = 一致的译文数 / 参与比较的译文数；未翻译的键不参与比较，没有可比较的术语时得分为 100
+// @Tags         项目管理
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ConsistencyReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/consistency [get]
+func (h *QAHandler) GetConsistency(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	report, err := h.qaService.GetConsistency(ctx.Request.Context(), projectID)
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// GetInconsistencies 列出某一语言中译文不一致的术语
+// @Summary      获取译文不一致的术语
+// @Description  列出该语言中译文不一致的术语，包括默认语言的文本、建议译文（使用最多的译文，次数相同时按译文排序取第一个）以及每种译文对应的键。最多列出 500 个术语
+// @Tags         项目管理
+// @Produce      json
+// @Param        project_id  path      int     true  "项目ID"
+// @Param        language    path      string  true  "语言代码"
+// @Success      200         {object}  domain.ConsistencyDetail
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/consistency/{language} [get]
+func (h *QAHandler) GetInconsistencies(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	detail, err := h.qaService.GetInconsistencies(ctx.Request.Context(), projectID, ctx.Param("language"))
+	if err != nil {
+		h.handleError(ctx, err)
+		return
+	}
+
+	response.Success(ctx, detail)
+}
+
+// handleError 将领域错误映射为HTTP响应
+func (h *QAHandler) handleError(ctx *gin.Context, err error) {
+	if !response.HandleError(ctx, err, "翻译质量检查失败") {
+		h.logger.Error("Failed to check translation quality", zap.Error(err))
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReleaseGateHandler 发布门槛处理器
+type ReleaseGateHandler struct {
+	gateService domain.ReleaseGateService
+	logger      *zap.Logger
+}
+
+// NewReleaseGateHandler 创建发布门槛处理器
+func NewReleaseGateHandler(gateService domain.ReleaseGateService, logger *zap.Logger) *ReleaseGateHandler {
+	return &ReleaseGateHandler{
+		gateService: gateService,
+		logger:      logger,
+	}
+}
+
+// GetThresholds 获取项目的发布门槛
+// @Summary      获取发布门槛
+// @Description  获取项目中各语言的最低完成率和最低审核通过率
+// @Tags         发布门槛
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.ReleaseThresholdResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/release-thresholds [get]
+func (h *ReleaseGateHandler) GetThresholds(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	thresholds, err := h.gateService.GetThresholds(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取发布门槛失败")
+		return
+	}
+
+	response.Success(ctx, toReleaseThresholdResponses(thresholds))
+}
+
+// SetThresholds 设置项目的发布门槛
+// @Summary      设置发布门槛
+// @Description  替换项目的全部发布门槛，传入空列表表示不设门槛。完成率为有翻译的键占项目有效键的百分比，审核通过率为审核通过的键占项目有效键的百分比，低于门槛的语言会阻止发布
+// @Tags         发布门槛
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                              true  "项目ID"
+// @Param        request     body      dto.SetReleaseThresholdsRequest  true  "发布门槛"
+// @Success      200         {object}  []dto.ReleaseThresholdResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/release-thresholds [put]
+func (h *ReleaseGateHandler) SetThresholds(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.SetReleaseThresholdsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := make([]domain.ReleaseThresholdParams, 0, len(req.Thresholds))
+	for _, item := range req.Thresholds {
+		params = append(params, domain.ReleaseThresholdParams{
+			LanguageCode:  item.LanguageCode,
+			MinCompletion: item.MinCompletion,
+			MinApproval:   item.MinApproval,
+		})
+	}
+
+	thresholds, err := h.gateService.SetThresholds(ctx.Request.Context(), projectID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "设置发布门槛失败")
+		return
+	}
+
+	h.logger.Info("Release thresholds updated",
+		zap.Uint64("project_id", projectID),
+		zap.Int("languages", len(thresholds)),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, toReleaseThresholdResponses(thresholds))
+}
+
+// Check 检查项目是否达到发布门槛
+// @Summary      检查发布门槛
+// @Description  按发布门槛检查各启用语言的完成率和审核通过率。未达标的语言列出缺少翻译的键，审核通过率未达标时还列出未审核通过的键（每类最多 500 个）；没有设置门槛的语言只统计，不会阻止发布
+// @Tags         发布门槛
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ReleaseReadiness
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/release-readiness [get]
+func (h *ReleaseGateHandler) Check(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	readiness, err := h.gateService.Check(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "检查发布门槛失败")
+		return
+	}
+
+	response.Success(ctx, readiness)
+}
+
+// toReleaseThresholdResponses 转换为响应格式
+func toReleaseThresholdResponses(thresholds []*domain.ReleaseThreshold) []dto.ReleaseThresholdResponse {
+	responses := make([]dto.ReleaseThresholdResponse, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		responses = append(responses, dto.ReleaseThresholdResponse{
+			LanguageCode:  threshold.Language.Code,
+			MinCompletion: threshold.MinCompletion,
+			MinApproval:   threshold.MinApproval,
+			UpdatedBy:     threshold.UpdatedBy,
+			UpdatedAt:     threshold.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return responses
+}
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ReleaseHandler 发布版本处理器
+type ReleaseHandler struct {
+	releaseService domain.ReleaseService
+	memberService  domain.ProjectMemberService
+	logger         *zap.Logger
+}
+
+// NewReleaseHandler 创建发布版本处理器
+func NewReleaseHandler(releaseService domain.ReleaseService, memberService domain.ProjectMemberService, logger *zap.Logger) *ReleaseHandler {
+	return &ReleaseHandler{
+		releaseService: releaseService,
+		memberService:  memberService,
+		logger:         logger,
+	}
+}
+
+// GetByProjectID 获取项目的发布版本列表
+// @Summary      获取发布版本列表
+// @Description  分页获取项目的发布版本，最新的在前
+// @Tags         发布版本
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        page        query     int  false  "页码"      default(1)
+// @Param        page_size   query     int  false  "每页数量"  default(10)
+// @Success      200         {object}  dto.ReleaseListResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/releases [get]
+func (h *ReleaseHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	releases, total, err := h.releaseService.GetByProjectID(ctx.Request.Context(), projectID, pageSize, offset)
+	if err != nil {
+		response.HandleError(ctx, err, "获取发布版本失败")
+		return
+	}
+
+	resp := dto.ReleaseListResponse{
+		Releases: make([]*dto.ReleaseResponse, 0, len(releases)),
+		Total:    total,
+	}
+	for _, release := range releases {
+		resp.Releases = append(resp.Releases, toReleaseResponse(release))
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, resp, meta)
+}
+
+// GetByID 获取发布版本详情
+// @Summary      获取发布版本
+// @Description  获取发布版本的信息，翻译内容通过导出接口或 CLI 按版本标签获取
+// @Tags         发布版本
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        release_id  path      int  true  "发布版本ID"
+// @Success      200         {object}  dto.ReleaseResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/releases/{release_id} [get]
+func (h *ReleaseHandler) GetByID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+	releaseID, err := strconv.ParseUint(ctx.Param("release_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的发布版本ID")
+		return
+	}
+
+	release, err := h.releaseService.GetByID(ctx.Request.Context(), projectID, releaseID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取发布版本失败")
+		return
+	}
+
+	response.Success(ctx, toReleaseResponse(release))
+}
+
+// Create 发布版本
+// @Summary      发布版本
+// @Description  将项目当前的翻译（有效语言的有效翻译及键的值类型）冻结为不可修改的快照。之后 CLI 拉取和导出接口可以通过 release 参数指定版本标签获取该快照，用于可重现的构建。同一项目中的版本标签不能重复。发布前检查发布门槛，有语言未达标时返回 409，data 为门槛检查结果，列出未达标的语言和键；项目所有者可以设置 override 覆盖门槛继续发布。rollout_percentage 大于 0 时灰度发布：公开分发时只有该百分比的客户端获取新版本；项目有进行中的灰度发布时返回 409
+// @Tags         发布版本
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                 true  "项目ID"
+// @Param        release     body      dto.ReleaseRequest  true  "版本标签、说明和是否覆盖发布门槛"
+// @Success      201         {object}  dto.ReleaseCreateResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse{data=domain.ReleaseReadiness}
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/releases [post]
+func (h *ReleaseHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.ReleaseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	// 覆盖发布门槛需要项目所有者权限
+	if req.Override {
+		isOwner, err := h.memberService.CheckPermission(ctx.Request.Context(), userID.(uint64), projectID, "owner")
+		if err != nil {
+			response.InternalServerError(ctx, "权限检查失败")
+			return
+		}
+		if !isOwner {
+			response.Forbidden(ctx, "只有项目所有者可以覆盖发布门槛")
+			return
+		}
+	}
+
+	release, readiness, err := h.releaseService.Create(ctx.Request.Context(), projectID, domain.ReleaseParams{
+		Version:           req.Version,
+		Description:       req.Description,
+		Override:          req.Override,
+		RolloutPercentage: req.RolloutPercentage,
+	}, userID.(uint64))
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Code == domain.ErrReleaseBlocked.Code {
+			response.ErrorWithData(ctx, http.StatusConflict, "CONFLICT", appErr.Message, appErr.Details, readiness)
+			return
+		}
+		response.HandleError(ctx, err, "发布版本失败")
+		return
+	}
+
+	h.logger.Info("Release created",
+		zap.Uint64("project_id", projectID),
+		zap.String("version", release.Version),
+		zap.Int("translations", release.TranslationCount),
+		zap.Bool("gate_overridden", release.GateOverridden),
+		zap.Int("rollout_percentage", release.RolloutPercentage),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Created(ctx, dto.ReleaseCreateResponse{
+		ReleaseResponse: *toReleaseResponse(release),
+		Readiness:       readiness,
+	})
+}
+
+// AdvanceRollout 提高灰度发布百分比
+// @Summary      推进灰度发布
+// @Description  提高灰度发布的分发流量百分比，百分比只能提高，已获取灰度版本的客户端保持不变；设为 100 时全量发布，之后所有请求获取该版本
+// @Tags         发布版本
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                        true  "项目ID"
+// @Param        release_id  path      int                        true  "发布版本ID"
+// @Param        request     body      dto.ReleaseRolloutRequest  true  "新的灰度百分比"
+// @Success      200         {object}  dto.ReleaseResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/releases/{release_id}/rollout [put]
+func (h *ReleaseHandler) AdvanceRollout(ctx *gin.Context) {
+	projectID, releaseID, ok := parseReleasePath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.ReleaseRolloutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	release, err := h.releaseService.AdvanceRollout(ctx.Request.Context(), projectID, releaseID, req.Percentage, userID)
+	if err != nil {
+		response.HandleError(ctx, err, "推进灰度发布失败")
+		return
+	}
+
+	h.logger.Info("Release rollout advanced",
+		zap.Uint64("project_id", projectID),
+		zap.String("version", release.Version),
+		zap.Int("rollout_percentage", release.RolloutPercentage),
+		zap.Uint64("operator_id", userID),
+	)
+
+	response.Success(ctx, toReleaseResponse(release))
+}
+
+// AbortRollout 中止灰度发布
+// @Summary      中止灰度发布
+// @Description  中止进行中的灰度发布，之后公开分发不再返回该版本，所有请求获取最新的全量版本。版本快照保留，CLI 和导出仍可按版本标签获取
+// @Tags         发布版本
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        release_id  path      int  true  "发布版本ID"
+// @Success      200         {object}  dto.ReleaseResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/releases/{release_id}/rollout [delete]
+func (h *ReleaseHandler) AbortRollout(ctx *gin.Context) {
+	projectID, releaseID, ok := parseReleasePath(ctx)
+	if !ok {
+		return
+	}
+
+	userID := ctx.GetUint64("userID")
+	release, err := h.releaseService.AbortRollout(ctx.Request.Context(), projectID, releaseID, userID)
+	if err != nil {
+		response.HandleError(ctx, err, "中止灰度发布失败")
+		return
+	}
+
+	h.logger.Info("Release rollout aborted",
+		zap.Uint64("project_id", projectID),
+		zap.String("version", release.Version),
+		zap.Int("rollout_percentage", release.RolloutPercentage),
+		zap.Uint64("operator_id", userID),
+	)
+
+	response.Success(ctx, toReleaseResponse(release))
+}
+
+// parseReleasePath 解析项目ID和发布版本ID路径参数
+func parseReleasePath(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	releaseID, err := strconv.ParseUint(ctx.Param("release_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的发布版本ID")
+		return 0, 0, false
+	}
+	return projectID, releaseID, true
+}
+
+// toReleaseResponse 转换为响应格式
+func toReleaseResponse(release *domain.Release) *dto.ReleaseResponse {
+	return &dto.ReleaseResponse{
+		ID:                release.ID,
+		ProjectID:         release.ProjectID,
+		Version:           release.Version,
+		Description:       release.Description,
+		KeyCount:          release.KeyCount,
+		TranslationCount:  release.TranslationCount,
+		GateOverridden:    release.GateOverridden,
+		RolloutStatus:     release.RolloutStatus,
+		RolloutPercentage: release.RolloutPercentage,
+		CreatedBy:         release.CreatedBy,
+		CreatedAt:         release.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RoleSyncHandler 全局角色与项目成员同步处理器
+type RoleSyncHandler struct {
+	roleSyncService domain.RoleSyncService
+	logger          *zap.Logger
+}
+
+// NewRoleSyncHandler 创建角色同步处理器
+func NewRoleSyncHandler(roleSyncService domain.RoleSyncService, logger *zap.Logger) *RoleSyncHandler {
+	return &RoleSyncHandler{
+		roleSyncService: roleSyncService,
+		logger:          logger,
+	}
+}
+
+// Sync 同步全局角色与项目成员
+// @Summary      同步全局角色与项目成员
+// @Description  为每个启用的全局管理员补充缺少的项目成员关系（角色 owner，来源 role_sync），使管理员出现在各项目的成员列表中，管理员已有的成员关系保持不变；同时将已不是全局管理员的用户由同步添加的 owner 成员关系加入复核队列。设置 ROLE_SYNC_INTERVAL 后会定期自动执行
+// @Tags         系统管理
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=domain.RoleSyncResult}
+// @Failure      403  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/role-sync [post]
+func (h *RoleSyncHandler) Sync(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	result, err := h.roleSyncService.Sync(ctx.Request.Context(), userID.(uint64))
+	if err != nil {
+		h.logger.Error("Failed to sync roles", zap.Error(err))
+		response.InternalServerError(ctx, "同步角色失败")
+		return
+	}
+
+	h.logger.Info("Roles synced",
+		zap.Uint64("operator_id", userID.(uint64)),
+		zap.Int("members_added", result.MembersAdded),
+		zap.Int("reviews_queued", result.ReviewsQueued),
+	)
+	response.Success(ctx, result)
+}
+
+// ListReviews 获取角色复核队列
+// @Summary      获取角色复核队列
+// @Description  列出项目 owner 角色的复核记录，最近加入的在前。用户的全局角色被降级时，其全部项目 owner 成员关系自动加入复核队列（reason=global_role_downgraded）；同步时发现已不是管理员的用户仍保留同步添加的 owner 成员关系时同样加入（reason=role_sync_leftover）
+// @Tags         系统管理
+// @Produce      json
+// @Param        status     query     string  false  "复核状态，为空时返回全部"  Enums(pending, kept, downgraded, removed)
+// @Param        page       query     int     false  "页码"      default(1)
+// @Param        page_size  query     int     false  "每页数量"  default(20)
+// @Success      200        {object}  response.APIResponse{data=[]domain.RoleReview}
+// @Failure      403        {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/role-reviews [get]
+func (h *RoleSyncHandler) ListReviews(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	reviews, total, err := h.roleSyncService.ListReviews(ctx.Request.Context(), ctx.Query("status"), pageSize, (page-1)*pageSize)
+	if err != nil {
+		response.InternalServerError(ctx, "获取角色复核队列失败")
+		return
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+	response.SuccessWithMeta(ctx, reviews, meta)
+}
+
+// ResolveReview 处理角色复核
+// @Summary      处理角色复核
+// @Description  keep 保留 owner 角色；downgrade 将成员角色降为 role（editor 或 viewer）；remove 移除该项目成员关系。保留或降级后该成员关系不再视为同步添加，之后的同步不会再次加入复核队列；成员关系已不存在时记为 removed
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "复核记录ID"
+// @Param        request  body      dto.ResolveRoleReviewRequest  true  "复核操作"
+// @Success      200      {object}  response.APIResponse{data=domain.RoleReview}
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Failure      409      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/role-reviews/{id}/resolve [post]
+func (h *RoleSyncHandler) ResolveReview(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的复核记录ID")
+		return
+	}
+
+	var req dto.ResolveRoleReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	review, err := h.roleSyncService.ResolveReview(ctx.Request.Context(), id, domain.ResolveRoleReviewParams{
+		Action: req.Action,
+		Role:   req.Role,
+	}, userID.(uint64))
+	if err != nil {
+		switch err {
+		case domain.ErrRoleReviewNotFound:
+			response.NotFound(ctx, domain.ErrRoleReviewNotFound.Message)
+		case domain.ErrRoleReviewResolved:
+			response.Conflict(ctx, domain.ErrRoleReviewResolved.Message)
+		case domain.ErrInvalidRoleReviewAction:
+			response.BadRequest(ctx, domain.ErrInvalidRoleReviewAction.Message)
+		default:
+			h.logger.Error("Failed to resolve role review", zap.Uint64("review_id", id), zap.Error(err))
+			response.InternalServerError(ctx, "处理角色复核失败")
+		}
+		return
+	}
+
+	h.logger.Info("Role review resolved",
+		zap.Uint64("review_id", review.ID),
+		zap.Uint64("project_id", review.ProjectID),
+		zap.Uint64("user_id", review.UserID),
+		zap.String("status", review.Status),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+	response.Success(ctx, review)
+}
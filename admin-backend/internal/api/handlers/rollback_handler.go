@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RollbackHandler 翻译回滚处理器
+type RollbackHandler struct {
+	rollbackService domain.TranslationRollbackService
+	logger          *zap.Logger
+}
+
+// NewRollbackHandler 创建翻译回滚处理器
+func NewRollbackHandler(rollbackService domain.TranslationRollbackService, logger *zap.Logger) *RollbackHandler {
+	return &RollbackHandler{
+		rollbackService: rollbackService,
+		logger:          logger,
+	}
+}
+
+// RollbackTranslation 将翻译回滚到历史版本
+// @Summary      回滚翻译
+// @Description  将翻译的值恢复为变更历史记录的版本（该次变更后的值），键名和状态保持不变。值变化时译文回到草稿，并记录操作类型为 rollback 的变更历史；不能回滚到删除操作
+// @Tags         翻译管理
+// @Produce      json
+// @Param        id          path      int  true  "翻译ID"
+// @Param        history_id  path      int  true  "变更历史ID"
+// @Success      200         {object}  domain.Translation
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/{id}/rollback/{history_id} [post]
+func (h *RollbackHandler) RollbackTranslation(ctx *gin.Context) {
+	translationID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的翻译ID")
+		return
+	}
+	historyID, err := strconv.ParseUint(ctx.Param("history_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的变更历史ID")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	translation, err := h.rollbackService.RollbackTranslation(ctx.Request.Context(), translationID, historyID, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "回滚翻译失败")
+		return
+	}
+
+	response.Success(ctx, translation)
+}
+
+// RollbackProject 将项目的翻译回滚到指定时刻
+// @Summary      回滚项目翻译
+// @Description  将项目中此后有变更的翻译恢复为指定时刻的值，并记录操作类型为 rollback 的变更历史。之后才创建的翻译不会删除，当时已删除或当前已删除的翻译不会恢复，这些翻译计入 skipped
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                         true  "项目ID"
+// @Param        request     body      dto.ProjectRollbackRequest  true  "回滚时刻"
+// @Success      200         {object}  domain.ProjectRollbackResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/rollback [post]
+func (h *RollbackHandler) RollbackProject(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.ProjectRollbackRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+	to, err := time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		response.ValidationError(ctx, "无效的回滚时刻，格式为 RFC3339")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	result, err := h.rollbackService.RollbackProject(ctx.Request.Context(), projectID, domain.ProjectRollbackParams{To: to}, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "回滚项目翻译失败")
+		return
+	}
+
+	h.logger.Info("Project translations rolled back",
+		zap.Uint64("project_id", projectID),
+		zap.Time("to", to),
+		zap.Int("translations", result.Translations),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
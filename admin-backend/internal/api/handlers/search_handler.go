@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"strings"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SearchHandler 翻译搜索处理器
+type SearchHandler struct {
+	searchService domain.SearchService
+	logger        *zap.Logger
+}
+
+// NewSearchHandler 创建翻译搜索处理器
+func NewSearchHandler(searchService domain.SearchService, logger *zap.Logger) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        logger,
+	}
+}
+
+// Search 搜索翻译
+// @Summary      搜索翻译
+// @Description  在当前用户可访问的项目（管理员为所有项目）中按键名和译文搜索翻译。exact 模式查找包含查询文本的键名或译文（区分大小写）；regex 模式按正则表达式匹配（忽略大小写）；fuzzy 模式查找包含查询文本中任一单词的键名或译文（忽略大小写和重音），适合查找相近的文案。结果按相关度（0-1，键名和译文中较高的一个，matched_field 为对应的字段）和更新时间排序；匹配的翻译超过 1000 条时只对其中 1000 条（逐行匹配时为最近更新的，使用全文索引时 fuzzy 模式为全文相关度最高的）排序，truncated 为 true
+// @Tags         翻译管理
+// @Produce      json
+// @Param        q              query     string  true   "查询文本，regex 模式下为正则表达式，最长 200 个字符"
+// @Param        mode           query     string  false  "搜索模式"  Enums(exact, regex, fuzzy)  default(fuzzy)
+// @Param        project_id     query     int     false  "只搜索该项目"
+// @Param        language       query     string  false  "语言代码"
+// @Param        status         query     string  false  "翻译状态"  Enums(active, deprecated)
+// @Param        review_status  query     string  false  "审核状态"  Enums(draft, in_review, approved, outdated)
+// @Param        updated_by     query     int     false  "最后修改人ID"
+// @Param        tags           query     string  false  "只搜索带有其中任一标签的键，逗号分隔的标签名称"
+// @Param        from           query     string  false  "更新时间起点，日期（2006-01-02）或 RFC3339 时间"
+// @Param        to             query     string  false  "更新时间终点，日期包含当天"
+// @Param        limit          query     int     false  "每页数量，最大 100"  default(20)
+// @Param        offset         query     int     false  "偏移量"  default(0)
+// @Success      200            {object}  domain.SearchResult
+// @Failure      400            {object}  response.APIResponse
+// @Failure      403            {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /search [get]
+func (h *SearchHandler) Search(ctx *gin.Context) {
+	var req dto.SearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	params := domain.SearchParams{
+		Query:        req.Query,
+		Mode:         req.Mode,
+		ProjectID:    req.ProjectID,
+		Language:     req.Language,
+		Status:       req.Status,
+		ReviewStatus: req.ReviewStatus,
+		UpdatedBy:    req.UpdatedBy,
+		Tags:         strings.Split(req.Tags, ","),
+		Limit:        req.Limit,
+		Offset:       req.Offset,
+	}
+	if req.From != "" {
+		from, ok := parseHistoryTime(req.From, false)
+		if !ok {
+			response.BadRequest(ctx, domain.ErrInvalidHistoryRange.Message)
+			return
+		}
+		params.From = &from
+	}
+	if req.To != "" {
+		to, ok := parseHistoryTime(req.To, true)
+		if !ok {
+			response.BadRequest(ctx, domain.ErrInvalidHistoryRange.Message)
+			return
+		}
+		params.To = &to
+	}
+
+	result, err := h.searchService.Search(ctx.Request.Context(), ctx.GetUint64("userID"), params)
+	if err != nil {
+		if !response.HandleError(ctx, err, "搜索翻译失败") {
+			h.logger.Error("Failed to search translations", zap.Error(err))
+		}
+		return
+	}
+
+	response.Success(ctx, result)
+}
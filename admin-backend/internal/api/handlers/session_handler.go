@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SessionHandler 登录会话处理器
+type SessionHandler struct {
+	sessionService domain.SessionService
+	userService    domain.UserService
+	logger         *zap.Logger
+}
+
+// NewSessionHandler 创建登录会话处理器
+func NewSessionHandler(sessionService domain.SessionService, userService domain.UserService, logger *zap.Logger) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		userService:    userService,
+		logger:         logger,
+	}
+}
+
+// List 获取当前用户的登录会话
+// @Summary      获取登录会话列表
+// @Description  返回当前用户的有效登录会话（设备、IP 和最近访问时间），最近访问的在前，current 标记发起本次请求的会话；不能使用访问令牌调用此接口
+// @Tags         登录会话
+// @Produce      json
+// @Success      200  {array}   dto.SessionResponse
+// @Failure      403  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/sessions [get]
+func (h *SessionHandler) List(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	sessions, err := h.sessionService.List(ctx.Request.Context(), userID.(uint64))
+	if err != nil {
+		h.logger.Error("Failed to list sessions", zap.Uint64("user_id", userID.(uint64)), zap.Error(err))
+		response.InternalServerError(ctx, "获取登录会话失败")
+		return
+	}
+
+	currentID := ctx.GetString("sessionID")
+	resp := make([]*dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, toSessionResponse(session, currentID))
+	}
+	response.Success(ctx, resp)
+}
+
+// Revoke 撤销当前用户的登录会话
+// @Summary      撤销登录会话
+// @Description  撤销当前用户的一个登录会话，该会话的访问令牌和刷新令牌立即失效；撤销当前会话即退出登录
+// @Tags         登录会话
+// @Produce      json
+// @Param        session_id  path      string  true  "会话ID"
+// @Success      204         "No Content"
+// @Failure      403         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/sessions/{session_id} [delete]
+func (h *SessionHandler) Revoke(ctx *gin.Context) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	sessionID := ctx.Param("session_id")
+	if err := h.sessionService.Revoke(ctx.Request.Context(), userID.(uint64), sessionID); err != nil {
+		if !response.HandleError(ctx, err, "撤销登录会话失败") {
+			h.logger.Error("Failed to revoke session", zap.Uint64("user_id", userID.(uint64)), zap.Error(err))
+		}
+		return
+	}
+
+	h.logger.Info("Session revoked", zap.Uint64("user_id", userID.(uint64)), zap.Bool("current", sessionID == ctx.GetString("sessionID")))
+
+	response.NoContent(ctx)
+}
+
+// ForceLogout 强制用户下线
+// @Summary      强制用户下线
+// @Description  撤销用户的全部登录会话，已签发的访问令牌和刷新令牌立即失效（仅管理员）。个人访问令牌不受影响
+// @Tags         登录会话
+// @Produce      json
+// @Param        id   path      int  true  "用户ID"
+// @Success      200  {object}  dto.RevokeSessionsResponse
+// @Failure      400  {object}  response.APIResponse
+// @Failure      403  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /users/{id}/logout [post]
+func (h *SessionHandler) ForceLogout(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	if _, err := h.userService.GetUserInfo(ctx.Request.Context(), id); err != nil {
+		if !response.HandleError(ctx, err, "获取用户失败") {
+			h.logger.Error("Failed to get user", zap.Uint64("user_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	revoked, err := h.sessionService.RevokeAll(ctx.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("Failed to revoke user sessions", zap.Uint64("user_id", id), zap.Error(err))
+		response.InternalServerError(ctx, "强制下线失败")
+		return
+	}
+
+	operatorID, _ := ctx.Get("userID")
+	h.logger.Info("User sessions revoked by admin",
+		zap.Uint64("user_id", id),
+		zap.Any("operator_id", operatorID),
+		zap.Int("revoked", revoked),
+	)
+
+	response.Success(ctx, &dto.RevokeSessionsResponse{Revoked: revoked})
+}
+
+// toSessionResponse Domain -> DTO
+func toSessionResponse(session *domain.Session, currentID string) *dto.SessionResponse {
+	return &dto.SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+		LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+		ExpiresAt:  session.ExpiresAt.Format(time.RFC3339),
+		Current:    session.ID == currentID,
+	}
+}
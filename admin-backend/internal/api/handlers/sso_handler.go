@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// ssoSessionCookie 保存单点登录会话的 Cookie 名称
+	ssoSessionCookie = "yflow_sso"
+	// ssoCookiePath Cookie 只在单点登录路由下发送
+	ssoCookiePath = "/api/auth/oidc"
+	// ssoCookieMaxAge 与登录会话有效期一致（秒）
+	ssoCookieMaxAge = 600
+)
+
+// SSOHandler 单点登录处理器
+type SSOHandler struct {
+	ssoService  domain.SSOService
+	frontendURL string
+	logger      *zap.Logger
+}
+
+// NewSSOHandler 创建单点登录处理器，登录完成后跳转回 frontendURL 的登录页
+func NewSSOHandler(ssoService domain.SSOService, frontendURL string, logger *zap.Logger) *SSOHandler {
+	return &SSOHandler{
+		ssoService:  ssoService,
+		frontendURL: frontendURL,
+		logger:      logger,
+	}
+}
+
+// Providers 获取可用的身份提供方
+// @Summary      获取单点登录提供方
+// @Description  返回 SSO_PROVIDERS 中配置的身份提供方，登录页据此显示单点登录按钮
+// @Tags         用户认证
+// @Produce      json
+// @Success      200  {object}  response.APIResponse{data=[]domain.SSOProvider}
+// @Router       /auth/oidc/providers [get]
+func (h *SSOHandler) Providers(ctx *gin.Context) {
+	response.Success(ctx, h.ssoService.Providers())
+}
+
+// Login 跳转到身份提供方登录
+// @Summary      单点登录
+// @Description  生成授权请求并跳转到身份提供方，登录会话保存在 HttpOnly Cookie 中，10 分钟内有效
+// @Tags         用户认证
+// @Param        provider  query     string  true  "身份提供方名称"
+// @Success      302
+// @Failure      400       {object}  response.APIResponse
+// @Failure      404       {object}  response.APIResponse
+// @Router       /auth/oidc/login [get]
+func (h *SSOHandler) Login(ctx *gin.Context) {
+	provider := ctx.Query("provider")
+	if provider == "" {
+		response.BadRequest(ctx, "缺少 provider 参数")
+		return
+	}
+
+	authRequest, err := h.ssoService.Begin(ctx.Request.Context(), provider)
+	if err != nil {
+		if !response.HandleError(ctx, err, "发起单点登录失败") {
+			h.logger.Error("Failed to begin SSO login", zap.String("provider", provider), zap.Error(err))
+		}
+		return
+	}
+
+	h.setSessionCookie(ctx, authRequest.Session, ssoCookieMaxAge)
+	ctx.Redirect(http.StatusFound, authRequest.AuthURL)
+}
+
+// Callback 身份提供方回调
+// @Summary      单点登录回调
+// @Description  校验回调并登录，成功后跳转到前端登录页并在 URL 片段中携带 refresh_token，失败时携带 sso_error
+// @Tags         用户认证
+// @Param        state  query     string  true   "授权请求的 state"
+// @Param        code   query     string  true   "授权码"
+// @Param        error  query     string  false  "身份提供方返回的错误"
+// @Success      302
+// @Failure      400    {object}  response.APIResponse
+// @Router       /auth/oidc/callback [get]
+func (h *SSOHandler) Callback(ctx *gin.Context) {
+	if idpError := ctx.Query("error"); idpError != "" {
+		h.clearSessionCookie(ctx)
+		message := ctx.Query("error_description")
+		if message == "" {
+			message = idpError
+		}
+		h.logger.Warn("SSO provider returned error", zap.String("error", idpError), zap.String("description", message))
+		h.redirectToLogin(ctx, "sso_error", message)
+		return
+	}
+
+	state, code := ctx.Query("state"), ctx.Query("code")
+	if state == "" || code == "" {
+		response.BadRequest(ctx, "缺少 state 或 code 参数")
+		return
+	}
+
+	session, _ := ctx.Cookie(ssoSessionCookie)
+	h.clearSessionCookie(ctx)
+
+	result, err := h.ssoService.Complete(domain.WithClientInfo(ctx.Request.Context(), ctx.ClientIP(), ctx.Request.UserAgent()), session, state, code)
+	if err != nil {
+		appErr, ok := domain.IsAppError(err)
+		if !ok {
+			h.logger.Error("Failed to complete SSO login", zap.Error(err))
+			h.redirectToLogin(ctx, "sso_error", domain.ErrSSOLoginFailed.Message)
+			return
+		}
+		if appErr.Cause != nil {
+			h.logger.Warn("SSO login failed", zap.String("code", appErr.Code), zap.Error(appErr.Cause))
+		}
+		h.redirectToLogin(ctx, "sso_error", appErr.Message)
+		return
+	}
+
+	// 访问令牌由前端用 refresh_token 换取，避免出现在浏览器历史中
+	h.redirectToLogin(ctx, "refresh_token", result.RefreshToken)
+}
+
+// redirectToLogin 跳转到前端登录页，参数放在 URL 片段中不会发送到服务器
+func (h *SSOHandler) redirectToLogin(ctx *gin.Context, key, value string) {
+	fragment := url.Values{key: {value}}.Encode()
+	ctx.Redirect(http.StatusFound, h.frontendURL+"/login#"+fragment)
+}
+
+// setSessionCookie 写入登录会话 Cookie
+func (h *SSOHandler) setSessionCookie(ctx *gin.Context, value string, maxAge int) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	secure := ctx.Request.TLS != nil || strings.EqualFold(ctx.GetHeader("X-Forwarded-Proto"), "https")
+	ctx.SetCookie(ssoSessionCookie, value, maxAge, ssoCookiePath, "", secure, true)
+}
+
+// clearSessionCookie 删除登录会话 Cookie
+func (h *SSOHandler) clearSessionCookie(ctx *gin.Context) {
+	h.setSessionCookie(ctx, "", -1)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageHandler 数据库增长监控处理器
+type StorageHandler struct {
+	storageService domain.StorageMonitorService
+}
+
+// NewStorageHandler 创建数据库增长监控处理器
+func NewStorageHandler(storageService domain.StorageMonitorService) *StorageHandler {
+	return &StorageHandler{storageService: storageService}
+}
+
+// GetHealth 获取数据库增长检查结果
+// @Summary      获取数据库增长检查结果
+// @Description  返回主要数据表的行数和占用空间，以及超过软限制的告警和处理建议。默认返回最近一次定期检查的结果，refresh=true 时立即检查
+// @Tags         系统管理
+// @Accept       json
+// @Produce      json
+// @Param        refresh  query     bool  false  "立即重新检查"
+// @Success      200      {object}  dto.StorageHealthResponse
+// @Failure      403      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /admin/storage-health [get]
+func (h *StorageHandler) GetHealth(ctx *gin.Context) {
+	report, err := h.storageService.GetReport(ctx.Request.Context(), ctx.Query("refresh") == "true")
+	if err != nil {
+		response.InternalServerError(ctx, "获取数据库增长检查结果失败")
+		return
+	}
+
+	resp := dto.StorageHealthResponse{
+		Status:    report.Status,
+		CheckedAt: report.CheckedAt.Format(time.RFC3339),
+		Tables:    make([]*dto.TableStatsResponse, 0, len(report.Tables)),
+		Alerts:    make([]*dto.StorageAlertResponse, 0, len(report.Alerts)),
+	}
+	for _, stat := range report.Tables {
+		resp.Tables = append(resp.Tables, &dto.TableStatsResponse{
+			Table:      stat.Table,
+			Rows:       stat.Rows,
+			DataBytes:  stat.DataBytes,
+			IndexBytes: stat.IndexBytes,
+			TotalBytes: stat.DataBytes + stat.IndexBytes,
+		})
+	}
+	for _, alert := range report.Alerts {
+		resp.Alerts = append(resp.Alerts, &dto.StorageAlertResponse{
+			Table:          alert.Table,
+			Metric:         alert.Metric,
+			Value:          alert.Value,
+			Threshold:      alert.Threshold,
+			Recommendation: alert.Recommendation,
+		})
+	}
+
+	response.Success(ctx, resp)
+}
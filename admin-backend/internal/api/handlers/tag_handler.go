@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TagHandler 键标签处理器
+type TagHandler struct {
+	tagService domain.TagService
+	logger     *zap.Logger
+}
+
+// NewTagHandler 创建键标签处理器
+func NewTagHandler(tagService domain.TagService, logger *zap.Logger) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		logger:     logger,
+	}
+}
+
+// GetByProjectID 获取项目的标签
+// @Summary      获取标签
+// @Description  获取项目中的标签及带有每个标签的键数量，按名称排序
+// @Tags         标签
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.TagResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/tags [get]
+func (h *TagHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	tags, err := h.tagService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取标签失败")
+		return
+	}
+
+	responses := make([]*dto.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		responses = append(responses, toTagResponse(tag))
+	}
+	response.Success(ctx, responses)
+}
+
+// Create 创建标签
+// @Summary      创建标签
+// @Description  在项目中创建标签（如 mobile、beta），用于按用途标记键。名称不能包含逗号，最多 40 个字符，同一项目中不能重复；颜色格式为 #RRGGBB。矩阵、搜索和导出可以按标签筛选
+// @Tags         标签
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int             true  "项目ID"
+// @Param        tag         body      dto.TagRequest  true  "标签"
+// @Success      201         {object}  dto.TagResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/tags [post]
+func (h *TagHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.TagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	tag, err := h.tagService.Create(ctx.Request.Context(), projectID, toTagParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "创建标签失败")
+		return
+	}
+
+	response.Created(ctx, toTagResponse(tag))
+}
+
+// Update 更新标签
+// @Summary      更新标签
+// @Description  修改标签的名称、颜色或说明，未提供的字段保持不变；改名后键的 tags 字段同步为新名称
+// @Tags         标签
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int             true  "项目ID"
+// @Param        tag_id      path      int             true  "标签ID"
+// @Param        tag         body      dto.TagRequest  true  "标签"
+// @Success      200         {object}  dto.TagResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/tags/{tag_id} [put]
+func (h *TagHandler) Update(ctx *gin.Context) {
+	projectID, tagID, ok := parseTagPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.TagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	tag, err := h.tagService.Update(ctx.Request.Context(), projectID, tagID, toTagParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "更新标签失败")
+		return
+	}
+
+	response.Success(ctx, toTagResponse(tag))
+}
+
+// Delete 删除标签
+// @Summary      删除标签
+// @Description  删除标签并移除键上的该标签，键和翻译不会被删除
+// @Tags         标签
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        tag_id      path      int  true  "标签ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/tags/{tag_id} [delete]
+func (h *TagHandler) Delete(ctx *gin.Context) {
+	projectID, tagID, ok := parseTagPath(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.tagService.Delete(ctx.Request.Context(), projectID, tagID); err != nil {
+		response.HandleError(ctx, err, "删除标签失败")
+		return
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// TagKeys 为键添加标签
+// @Summary      为键添加标签
+// @Description  为键添加标签，已带有该标签的键不变。任一键在项目中不存在或会超过 10 个标签时不做修改，错误详情列出这些键
+// @Tags         标签
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                 true  "项目ID"
+// @Param        tag_id      path      int                 true  "标签ID"
+// @Param        request     body      dto.TagKeysRequest  true  "键名"
+// @Success      200         {object}  domain.TagKeysResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/tags/{tag_id}/keys [post]
+func (h *TagHandler) TagKeys(ctx *gin.Context) {
+	h.changeKeys(ctx, true)
+}
+
+// UntagKeys 移除键上的标签
+// @Summary      移除键上的标签
+// @Description  移除键上的标签，没有该标签的键不变
+// @Tags         标签
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                 true  "项目ID"
+// @Param        tag_id      path      int                 true  "标签ID"
+// @Param        request     body      dto.TagKeysRequest  true  "键名"
+// @Success      200         {object}  domain.TagKeysResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/tags/{tag_id}/keys [delete]
+func (h *TagHandler) UntagKeys(ctx *gin.Context) {
+	h.changeKeys(ctx, false)
+}
+
+// changeKeys 为键添加（add 为 true）或移除标签
+func (h *TagHandler) changeKeys(ctx *gin.Context, add bool) {
+	projectID, tagID, ok := parseTagPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.TagKeysRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	var result *domain.TagKeysResult
+	var err error
+	if add {
+		result, err = h.tagService.TagKeys(ctx.Request.Context(), projectID, tagID, req.KeyNames)
+	} else {
+		result, err = h.tagService.UntagKeys(ctx.Request.Context(), projectID, tagID, req.KeyNames)
+	}
+	if err != nil {
+		response.HandleError(ctx, err, "修改键的标签失败")
+		return
+	}
+
+	h.logger.Info("Key tags changed",
+		zap.Uint64("project_id", projectID),
+		zap.String("tag", result.Tag),
+		zap.Bool("added", add),
+		zap.Int64("keys", result.Changed),
+		zap.Uint64("operator_id", ctx.GetUint64("userID")),
+	)
+
+	response.Success(ctx, result)
+}
+
+// parseTagPath 解析项目ID和标签ID路径参数
+func parseTagPath(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	tagID, err := strconv.ParseUint(ctx.Param("tag_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的标签ID")
+		return 0, 0, false
+	}
+	return projectID, tagID, true
+}
+
+// toTagParams DTO -> Domain params
+func toTagParams(req dto.TagRequest) domain.TagParams {
+	return domain.TagParams{
+		Name:        req.Name,
+		Color:       req.Color,
+		Description: req.Description,
+	}
+}
+
+// toTagResponse 转换为响应格式
+func toTagResponse(tag *domain.Tag) *dto.TagResponse {
+	return &dto.TagResponse{
+		ID:          tag.ID,
+		ProjectID:   tag.ProjectID,
+		Name:        tag.Name,
+		Color:       tag.Color,
+		Description: tag.Description,
+		Keys:        tag.Keys,
+		UpdatedBy:   tag.UpdatedBy,
+		CreatedAt:   tag.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   tag.UpdatedAt.Format(time.RFC3339),
+	}
+}
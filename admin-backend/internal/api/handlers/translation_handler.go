@@ -1,7 +1,17 @@
 package handlers
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
 	"strconv"
+	"strings"
 	"yflow/internal/api/response"
 	"yflow/internal/domain"
 	"yflow/internal/dto"
@@ -14,7 +24,8 @@ import (
 // TranslationHandler 翻译处理器
 type TranslationHandler struct {
 	translationService       domain.TranslationService
-	machineTranslationService *service.LibreTranslateService
+	machineTranslationService domain.MachineTranslationService
+	autoTranslateService     domain.AutoTranslateService
 	languageRepo             domain.LanguageRepository
 	logger                   *zap.Logger
 }
@@ -22,13 +33,15 @@ type TranslationHandler struct {
 // NewTranslationHandler 创建翻译处理器
 func NewTranslationHandler(
 	translationService domain.TranslationService,
-	machineTranslationService *service.LibreTranslateService,
+	machineTranslationService domain.MachineTranslationService,
+	autoTranslateService domain.AutoTranslateService,
 	languageRepo domain.LanguageRepository,
 	logger *zap.Logger,
 ) *TranslationHandler {
 	return &TranslationHandler{
 		translationService:       translationService,
 		machineTranslationService: machineTranslationService,
+		autoTranslateService:     autoTranslateService,
 		languageRepo:             languageRepo,
 		logger:                   logger,
 	}
@@ -66,6 +79,7 @@ func (h *TranslationHandler) Create(ctx *gin.Context) {
 		Context:    req.Context,
 		LanguageID: req.LanguageID,
 		Value:      req.Value,
+		ValueType:  req.ValueType,
 	}
 
 	translation, err := h.translationService.Create(ctx.Request.Context(), input, userID.(uint64))
@@ -78,7 +92,7 @@ func (h *TranslationHandler) Create(ctx *gin.Context) {
 			case domain.ErrorTypeConflict:
 				response.Conflict(ctx, appErr.Message)
 			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-				response.BadRequest(ctx, appErr.Message)
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
 			default:
 				response.InternalServerError(ctx, "创建翻译失败")
 			}
@@ -147,7 +161,7 @@ func (h *TranslationHandler) CreateBatch(ctx *gin.Context) {
 				case domain.ErrorTypeConflict:
 					response.Conflict(ctx, appErr.Message)
 				case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-					response.BadRequest(ctx, appErr.Message)
+					response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
 				default:
 					response.InternalServerError(ctx, "批量创建翻译失败")
 				}
@@ -183,6 +197,7 @@ func (h *TranslationHandler) CreateBatch(ctx *gin.Context) {
 			Context:    req.Context,
 			LanguageID: req.LanguageID,
 			Value:      req.Value,
+			ValueType:  req.ValueType,
 		}
 	}
 
@@ -196,7 +211,7 @@ func (h *TranslationHandler) CreateBatch(ctx *gin.Context) {
 			case domain.ErrorTypeConflict:
 				response.Conflict(ctx, appErr.Message)
 			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-				response.BadRequest(ctx, appErr.Message)
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
 			default:
 				response.InternalServerError(ctx, "批量创建翻译失败")
 			}
@@ -218,16 +233,21 @@ func (h *TranslationHandler) CreateBatch(ctx *gin.Context) {
 
 // GetByProjectID 根据项目ID获取翻译
 // @Summary      获取项目翻译
-// @Description  根据项目ID获取翻译列表
+// @Description  根据项目ID获取翻译列表，review_status 不为空时只返回该审核状态的翻译。sort 指定排序（如 updated_at:desc，可排序字段：id、key_name、value、status、created_at、updated_at），fields 只返回指定的字段（如 key_name,value）。提供 cursor 或 limit 时使用游标分页：翻译按ID排序（不能指定 sort），meta.next_cursor 为下一页的游标，没有下一页时不返回，此时忽略 page 和 page_size
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
-// @Param        project_id  path      int  true   "项目ID"
-// @Param        page        query     int  false  "页码"  default(1)
-// @Param        page_size   query     int  false  "每页数量"  default(10)
-// @Success      200         {object}  map[string]interface{}
-// @Failure      400         {object}  map[string]string
-// @Failure      404         {object}  map[string]string
+// @Param        project_id     path      int     true   "项目ID"
+// @Param        page           query     int     false  "页码"  default(1)
+// @Param        page_size      query     int     false  "每页数量"  default(10)
+// @Param        cursor         query     string  false  "游标分页：上一页返回的 meta.next_cursor，为空时获取第一页"
+// @Param        limit          query     int     false  "游标分页：每页数量"  default(10)
+// @Param        review_status  query     string  false  "审核状态"  Enums(draft, in_review, approved, outdated)
+// @Param        sort           query     string  false  "排序，字段:asc|desc，多个用逗号分隔"
+// @Param        fields         query     string  false  "返回的字段，逗号分隔"
+// @Success      200            {object}  map[string]interface{}
+// @Failure      400            {object}  map[string]string
+// @Failure      404            {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /translations/by-project/{project_id} [get]
 func (h *TranslationHandler) GetByProjectID(ctx *gin.Context) {
@@ -238,6 +258,20 @@ func (h *TranslationHandler) GetByProjectID(ctx *gin.Context) {
 		return
 	}
 
+	list, ok := parseListQuery(ctx, translationListFields)
+	if !ok {
+		return
+	}
+
+	if cp, ok := parseCursorPage(ctx); ok {
+		if len(list.sort) > 0 {
+			response.BadRequest(ctx, "游标分页不支持 sort 参数")
+			return
+		}
+		h.getPageByProjectID(ctx, projectID, cp, list.fields)
+		return
+	}
+
 	// 解析分页参数
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
@@ -251,11 +285,13 @@ func (h *TranslationHandler) GetByProjectID(ctx *gin.Context) {
 
 	offset := (page - 1) * pageSize
 
-	translations, total, err := h.translationService.GetByProjectID(ctx.Request.Context(), projectID, pageSize, offset)
+	translations, total, err := h.translationService.GetByProjectID(ctx.Request.Context(), projectID, pageSize, offset, ctx.Query("review_status"), list.sort)
 	if err != nil {
 		switch err {
 		case domain.ErrProjectNotFound:
 			response.NotFound(ctx, err.Error())
+		case domain.ErrInvalidReviewStatus:
+			response.BadRequest(ctx, err.Error())
 		default:
 			response.InternalServerError(ctx, "获取翻译列表失败")
 		}
@@ -269,30 +305,113 @@ func (h *TranslationHandler) GetByProjectID(ctx *gin.Context) {
 		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
 	}
 
-	response.SuccessWithMeta(ctx, translations, meta)
+	data, err := selectFields(translations, list.fields)
+	if err != nil {
+		response.InternalServerError(ctx, "获取翻译列表失败")
+		return
+	}
+	response.SuccessWithMeta(ctx, data, meta)
+}
+
+// translationListFields 翻译列表可以排序和选择的字段
+var translationListFields = newListFields(domain.Translation{}, "id", "key_name", "value", "status", "created_at", "updated_at")
+
+// getPageByProjectID 游标分页获取项目的翻译，fields 不为空时只返回指定的字段
+func (h *TranslationHandler) getPageByProjectID(ctx *gin.Context, projectID uint64, cp cursorPage, fields []string) {
+	afterID, err := decodeIDCursor(cp.cursor)
+	if err != nil {
+		response.BadRequest(ctx, "无效的分页游标")
+		return
+	}
+
+	page, err := h.translationService.GetPageByProjectID(ctx.Request.Context(), domain.TranslationPageQuery{
+		ProjectID:    projectID,
+		ReviewStatus: ctx.Query("review_status"),
+		AfterID:      afterID,
+		Limit:        cp.limit,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrProjectNotFound:
+			response.NotFound(ctx, err.Error())
+		case domain.ErrInvalidReviewStatus:
+			response.BadRequest(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "获取翻译列表失败")
+		}
+		return
+	}
+
+	var next string
+	if page.HasMore && len(page.Translations) > 0 {
+		next = encodeIDCursor(page.Translations[len(page.Translations)-1].ID)
+	}
+	data, err := selectFields(page.Translations, fields)
+	if err != nil {
+		response.InternalServerError(ctx, "获取翻译列表失败")
+		return
+	}
+	response.SuccessWithMeta(ctx, data, cursorMeta(cp.limit, page.Total, next))
 }
 
 // GetMatrix 获取翻译矩阵
 // @Summary      获取翻译矩阵
-// @Description  获取项目的翻译矩阵（键-语言映射），支持分页
+// @Description  获取项目的翻译矩阵（键-语言映射），支持分页、排序和搜索。返回对象的键按排序结果排列；collation 指定排序使用的区域设置（如 de、sv、zh、ja），为空时按字节顺序排序。每个单元格包含翻译的状态（status）、审核状态（review_status）、最后修改人ID（updated_by）和用户名（updated_by_username），qa_issues 为按 QA 报告规则（默认长度倍数，不含术语表检查）发现的问题类型
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
-// @Param        project_id  path      int     true   "项目ID"
-// @Param        page        query     int     false  "页码"  default(1)
-// @Param        page_size   query     int     false  "每页数量"  default(10)
-// @Param        keyword     query     string  false  "搜索关键词"
-// @Success      200         {object}  map[string]interface{}
-// @Failure      400         {object}  map[string]string
-// @Failure      404         {object}  map[string]string
+// @Param        project_id        path      int     true   "项目ID"
+// @Param        page              query     int     false  "页码"  default(1)
+// @Param        page_size         query     int     false  "每页数量"  default(10)
+// @Param        keyword           query     string  false  "搜索关键词（匹配键名和翻译值）"
+// @Param        search_collation  query     string  false  "搜索比较规则"  Enums(accent_insensitive, case_insensitive, exact)
+// @Param        sort              query     string  false  "排序字段"  Enums(key, value)  default(key)
+// @Param        sort_language     query     string  false  "按翻译值排序时使用的语言代码"
+// @Param        order             query     string  false  "排序方向"  Enums(asc, desc)  default(asc)
+// @Param        collation         query     string  false  "排序区域设置（BCP 47）"
+// @Param        review_status     query     string  false  "只返回有该审核状态翻译的键"  Enums(draft, in_review, approved, outdated)
+// @Param        namespace         query     string  false  "只返回该命名空间中的键"
+// @Param        tags              query     string  false  "只返回带有其中任一标签的键，逗号分隔的标签名称"
+// @Success      200               {object}  map[string]interface{}
+// @Failure      400               {object}  map[string]string
+// @Failure      404               {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /translations/matrix/by-project/{project_id} [get]
 func (h *TranslationHandler) GetMatrix(ctx *gin.Context) {
+	result, meta, ok := h.getMatrixPage(ctx)
+	if !ok {
+		return
+	}
+	response.SuccessWithMeta(ctx, orderedMatrix(*result), meta)
+}
+
+// GetMatrixRows 获取翻译矩阵（API v2）
+// 查询参数与 v1 的 GetMatrix 相同，data 为按排序结果排列的行数组，不再依赖 JSON 对象中键的顺序
+func (h *TranslationHandler) GetMatrixRows(ctx *gin.Context) {
+	result, meta, ok := h.getMatrixPage(ctx)
+	if !ok {
+		return
+	}
+
+	rows := make([]dto.TranslationMatrixRow, 0, len(result.Keys))
+	for _, key := range result.Keys {
+		// 与 v1 一致，只有停用语言翻译的键不出现在结果中
+		cells, ok := result.Matrix[key]
+		if !ok {
+			continue
+		}
+		rows = append(rows, dto.TranslationMatrixRow{KeyName: key, Cells: cells})
+	}
+	response.SuccessWithMeta(ctx, rows, meta)
+}
+
+// getMatrixPage 按查询参数获取一页翻译矩阵，失败时写入错误响应并返回 false
+func (h *TranslationHandler) getMatrixPage(ctx *gin.Context) (*domain.TranslationMatrixPage, *response.Meta, bool) {
 	projectIDStr := ctx.Param("project_id")
 	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
 	if err != nil {
 		response.BadRequest(ctx, "无效的项目ID")
-		return
+		return nil, nil, false
 	}
 
 	// 解析分页参数
@@ -309,25 +428,106 @@ func (h *TranslationHandler) GetMatrix(ctx *gin.Context) {
 
 	offset := (page - 1) * pageSize
 
-	matrix, total, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, pageSize, offset, keyword)
+	order := ctx.DefaultQuery("order", "asc")
+	if order != "asc" && order != "desc" {
+		response.BadRequest(ctx, "无效的排序方向")
+		return nil, nil, false
+	}
+
+	result, err := h.translationService.GetMatrixPage(ctx.Request.Context(), domain.TranslationMatrixQuery{
+		ProjectID:       projectID,
+		Limit:           pageSize,
+		Offset:          offset,
+		Keyword:         keyword,
+		SearchCollation: ctx.Query("search_collation"),
+		SortBy:          ctx.Query("sort"),
+		SortLanguage:    ctx.Query("sort_language"),
+		Collation:       ctx.Query("collation"),
+		ReviewStatus:    ctx.Query("review_status"),
+		Namespace:       ctx.Query("namespace"),
+		Tags:            strings.Split(ctx.Query("tags"), ","),
+		Descending:      order == "desc",
+	})
 	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return nil, nil, false
+		}
 		switch err {
-		case domain.ErrProjectNotFound:
+		case domain.ErrProjectNotFound, domain.ErrNamespaceNotFound:
 			response.NotFound(ctx, err.Error())
 		default:
 			response.InternalServerError(ctx, "获取翻译矩阵失败")
 		}
-		return
+		return nil, nil, false
 	}
 
 	meta := &response.Meta{
 		Page:       page,
 		PageSize:   pageSize,
-		TotalCount: total,
-		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+		TotalCount: result.Total,
+		TotalPages: (result.Total + int64(pageSize) - 1) / int64(pageSize),
 	}
+	return result, meta, true
+}
 
-	response.SuccessWithMeta(ctx, matrix, meta)
+// orderedMatrix 按 Keys 的顺序序列化为 JSON 对象（map 序列化时会按字节顺序重排键）
+type orderedMatrix domain.TranslationMatrixPage
+
+// MarshalJSON 实现 json.Marshaler
+func (m orderedMatrix) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, key := range m.Keys {
+		// 与未排序的矩阵一致，只有停用语言翻译的键不出现在结果中
+		row, ok := m.Matrix[key]
+		if !ok {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		cells, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(cells)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// GetProjectStats 获取项目各语言的翻译完成情况
+// @Summary      获取翻译完成情况
+// @Description  统计项目中每个启用语言已翻译（译文不为空且未过期）、过期（源语言修改后等待重新翻译）和未翻译的键数量，三者之和为项目有效的键数量；percentage 为已翻译占键数量的百分比，项目没有键时为 100。已废弃的翻译不计入
+// @Tags         项目管理
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  domain.ProjectStats
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/stats [get]
+func (h *TranslationHandler) GetProjectStats(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	stats, err := h.translationService.GetProjectStats(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取翻译完成情况失败")
+		return
+	}
+
+	response.Success(ctx, stats)
 }
 
 // GetByID 根据ID获取翻译
@@ -366,15 +566,16 @@ func (h *TranslationHandler) GetByID(ctx *gin.Context) {
 
 // Update 更新翻译
 // @Summary      更新翻译
-// @Description  更新翻译信息
+// @Description  更新翻译信息。传入 version（读取翻译时的版本号）时，翻译在此之后已被他人修改则返回 409 TRANSLATION_CONFLICT，data 为当前的翻译
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
 // @Param        id           path      int                               true  "翻译ID"
-// @Param        translation  body      domain.CreateTranslationRequest  true  "翻译信息"
+// @Param        translation  body      dto.CreateTranslationRequest     true  "翻译信息"
 // @Success      200          {object}  domain.Translation
 // @Failure      400          {object}  map[string]string
 // @Failure      404          {object}  map[string]string
+// @Failure      409          {object}  response.APIResponse{data=domain.Translation}
 // @Security     BearerAuth
 // @Router       /translations/{id} [put]
 func (h *TranslationHandler) Update(ctx *gin.Context) {
@@ -404,10 +605,22 @@ func (h *TranslationHandler) Update(ctx *gin.Context) {
 		Context:    req.Context,
 		LanguageID: req.LanguageID,
 		Value:      req.Value,
+		ValueType:  req.ValueType,
+		Version:    req.Version,
 	}
 
 	translation, err := h.translationService.Update(ctx.Request.Context(), id, input, userID.(uint64))
 	if err != nil {
+		// 翻译已被他人修改：返回当前的翻译，由编辑者决定保留哪个版本
+		if errors.Is(err, domain.ErrTranslationConflict) {
+			current, getErr := h.translationService.GetByID(ctx.Request.Context(), id)
+			if getErr != nil {
+				response.HandleError(ctx, getErr, "更新翻译失败")
+				return
+			}
+			response.ErrorWithData(ctx, http.StatusConflict, domain.ErrTranslationConflict.Code, domain.ErrTranslationConflict.Message, "", current)
+			return
+		}
 		// 检查是否是AppError类型
 		if appErr, ok := domain.IsAppError(err); ok {
 			switch appErr.Type {
@@ -416,7 +629,7 @@ func (h *TranslationHandler) Update(ctx *gin.Context) {
 			case domain.ErrorTypeConflict:
 				response.Conflict(ctx, appErr.Message)
 			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
-				response.BadRequest(ctx, appErr.Message)
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
 			default:
 				response.InternalServerError(ctx, "更新翻译失败")
 			}
@@ -453,6 +666,281 @@ func (h *TranslationHandler) Update(ctx *gin.Context) {
 	response.Success(ctx, translation)
 }
 
+// ParseICUMessage 解析 ICU 消息
+// @Summary      解析 ICU 消息
+// @Description  将 ICU MessageFormat 消息解析为结构化的元素供编辑器使用：text、argument（如 {name}、{count, number}）、pound（复数选项中的 #）以及带选项的 plural、selectordinal 和 select。撇号按 ICU 的默认规则处理。语法错误时 valid 为 false，error 和 error_offset 说明错误及位置（字符序号，从 0 开始）。指定 language 时返回该语言的 CLDR 复数类别（plural_categories 为基数，ordinal_categories 为序数），并在 issues 中列出缺少或语言没有的复数类别（=0、=1、=2 可以代替 zero、one、two）。只解析请求中的文本，不读写翻译
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.ParseICUMessageRequest  true  "ICU 消息和语言"
+// @Success      200      {object}  domain.ICUMessageInfo
+// @Failure      400      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/icu/parse [post]
+func (h *TranslationHandler) ParseICUMessage(ctx *gin.Context) {
+	var req dto.ParseICUMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	response.Success(ctx, service.AnalyzeICUMessage(req.Message, strings.TrimSpace(req.Language)))
+}
+
+// FormatICUMessage 由结构化元素生成 ICU 消息
+// @Summary      生成 ICU 消息
+// @Description  将解析接口返回格式的元素序列化为 ICU MessageFormat 消息，文本中的 {、}（复数选项中还有 #）按需用撇号引用。参数名无效、选项无效或重复、plural、selectordinal 和 select 缺少 other 选项时返回 400
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.FormatICUMessageRequest  true  "消息的元素"
+// @Success      200      {object}  dto.FormatICUMessageResponse
+// @Failure      400      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/icu/format [post]
+func (h *TranslationHandler) FormatICUMessage(ctx *gin.Context) {
+	var req dto.FormatICUMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	message, err := service.FormatICUMessage(req.Elements)
+	if err != nil {
+		response.BadRequestWithDetails(ctx, domain.ErrInvalidICUMessage.Message, err.Error())
+		return
+	}
+	response.Success(ctx, dto.FormatICUMessageResponse{Message: message})
+}
+
+// SetValueType 修改键的值类型
+// @Summary      修改键的值类型
+// @Description  将键在所有语言的值类型改为 string、json、markdown 或 html。json 类型的值必须是合法的 JSON，指定 value_schema 时还需要符合该 JSON Schema（支持 type、enum、const、properties、required、additionalProperties、items、minItems、maxItems、minLength、maxLength、minimum、maximum）；已有的值不符合时返回 400，不做修改
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        request     body      dto.SetValueTypeRequest  true  "键名和值类型"
+// @Success      200         {object}  domain.SetValueTypeResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/value-type [put]
+func (h *TranslationHandler) SetValueType(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.SetValueTypeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.SetValueTypeParams{KeyName: req.KeyName, ValueType: req.ValueType}
+	if schema := string(req.ValueSchema); schema != "null" {
+		params.ValueSchema = schema
+	}
+	result, err := h.translationService.SetValueType(ctx.Request.Context(), projectID, params, userID.(uint64))
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			default:
+				response.InternalServerError(ctx, "修改值类型失败")
+			}
+			return
+		}
+		response.InternalServerError(ctx, "修改值类型失败")
+		return
+	}
+
+	h.logger.Info("Translation value type changed",
+		zap.Uint64("project_id", projectID),
+		zap.String("translation_key", result.KeyName),
+		zap.String("value_type", result.ValueType),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// SetKeyMetadata 修改键的元数据
+// @Summary      修改键的元数据
+// @Description  设置键在所有语言的最大长度（max_length，按字符计算，0 表示不限制）、标签（tags，最多 10 个，每个最多 40 个字符，不能包含逗号）和使用平台（platform：web、ios、android、desktop，为空时不限）。未提供的字段清除。已有的非空译文（json 类型除外）超过最大长度时返回 400，不做修改；之后写入该键的译文超过最大长度时同样返回 400 VALUE_TOO_LONG
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                        true  "项目ID"
+// @Param        request     body      dto.SetKeyMetadataRequest  true  "键名和元数据"
+// @Success      200         {object}  domain.SetKeyMetadataResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/keys/metadata [put]
+func (h *TranslationHandler) SetKeyMetadata(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.SetKeyMetadataRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.SetKeyMetadataParams{KeyName: req.KeyName, MaxLength: req.MaxLength, Tags: req.Tags, Platform: req.Platform}
+	result, err := h.translationService.SetKeyMetadata(ctx.Request.Context(), projectID, params, userID.(uint64))
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			default:
+				response.InternalServerError(ctx, "修改键元数据失败")
+			}
+			return
+		}
+		response.InternalServerError(ctx, "修改键元数据失败")
+		return
+	}
+
+	h.logger.Info("Translation key metadata changed",
+		zap.Uint64("project_id", projectID),
+		zap.String("translation_key", result.KeyName),
+		zap.Int("max_length", result.MaxLength),
+		zap.Strings("tags", result.Tags),
+		zap.String("platform", result.Platform),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// SubmitForReview 提交翻译审核
+// @Summary      提交翻译审核
+// @Description  将草稿（draft）状态的翻译提交审核（in_review），译文不能为空。任一翻译不属于该项目时返回 404，状态不允许时返回 400 INVALID_REVIEW_TRANSITION，均不做修改
+// @Tags         翻译审核
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                          true  "项目ID"
+// @Param        request     body      dto.ReviewTransitionRequest  true  "翻译ID列表"
+// @Success      200         {object}  domain.ReviewTransitionResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/review/submit [post]
+func (h *TranslationHandler) SubmitForReview(ctx *gin.Context) {
+	h.transitionReview(ctx, h.translationService.SubmitForReview, "提交审核失败")
+}
+
+// ApproveTranslations 审核通过翻译
+// @Summary      审核通过翻译
+// @Description  将待审核（in_review）的翻译标记为审核通过（approved），记录审核人和审核时间。之后修改译文时审核状态回到 draft
+// @Tags         翻译审核
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                          true  "项目ID"
+// @Param        request     body      dto.ReviewTransitionRequest  true  "翻译ID列表"
+// @Success      200         {object}  domain.ReviewTransitionResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/review/approve [post]
+func (h *TranslationHandler) ApproveTranslations(ctx *gin.Context) {
+	h.transitionReview(ctx, h.translationService.ApproveTranslations, "审核通过失败")
+}
+
+// RejectTranslations 驳回翻译
+// @Summary      驳回翻译
+// @Description  将待审核（in_review）或已通过（approved）的翻译驳回为草稿（draft），comment 为驳回原因（最多 500 个字符）
+// @Tags         翻译审核
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                          true  "项目ID"
+// @Param        request     body      dto.ReviewTransitionRequest  true  "翻译ID列表和驳回原因"
+// @Success      200         {object}  domain.ReviewTransitionResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/review/reject [post]
+func (h *TranslationHandler) RejectTranslations(ctx *gin.Context) {
+	h.transitionReview(ctx, h.translationService.RejectTranslations, "驳回翻译失败")
+}
+
+// transitionReview 解析审核请求并调用 transition 修改审核状态
+func (h *TranslationHandler) transitionReview(ctx *gin.Context, transition func(context.Context, uint64, domain.ReviewTransitionParams, uint64) (*domain.ReviewTransitionResult, error), failure string) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.ReviewTransitionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := domain.ReviewTransitionParams{TranslationIDs: req.TranslationIDs, Comment: req.Comment}
+	result, err := transition(ctx.Request.Context(), projectID, params, userID.(uint64))
+	if err != nil {
+		if err == domain.ErrProjectNotFound {
+			response.NotFound(ctx, err.Error())
+			return
+		}
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			default:
+				response.InternalServerError(ctx, failure)
+			}
+			return
+		}
+		response.InternalServerError(ctx, failure)
+		return
+	}
+
+	h.logger.Info("Translation review status changed",
+		zap.Uint64("project_id", projectID),
+		zap.String("review_status", result.ReviewStatus),
+		zap.Int64("translations", result.Translations),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
 // Delete 删除翻译
 // @Summary      删除翻译
 // @Description  删除指定的翻译
@@ -506,13 +994,14 @@ func (h *TranslationHandler) Delete(ctx *gin.Context) {
 
 // DeleteBatch 批量删除翻译
 // @Summary      批量删除翻译
-// @Description  批量删除多个翻译
+// @Description  批量删除多个翻译，翻译所属的项目开启删除保护时不删除
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
 // @Param        ids  body      []uint64  true  "翻译ID列表"
 // @Success      204  {object}  nil
 // @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
 // @Security     BearerAuth
 // @Router       /translations/batch-delete [post]
 func (h *TranslationHandler) DeleteBatch(ctx *gin.Context) {
@@ -525,6 +1014,10 @@ func (h *TranslationHandler) DeleteBatch(ctx *gin.Context) {
 
 	err := h.translationService.DeleteBatch(ctx.Request.Context(), ids)
 	if err != nil {
+		if err == domain.ErrProjectProtected {
+			response.Forbidden(ctx, err.Error())
+			return
+		}
 		response.InternalServerError(ctx, "批量删除翻译失败")
 		return
 	}
@@ -551,12 +1044,29 @@ func (h *TranslationHandler) DeleteBatch(ctx *gin.Context) {
 
 // Export 导出翻译
 // @Summary      导出翻译
-// @Description  导出项目翻译数据
+// @Description  导出项目翻译数据。format=json（默认）时格式为 {"key": {"en": "value"}}，与导入格式相同，json 类型的键输出解析后的数组或对象，其余格式中按字符串输出；format=xliff 时以默认语言为源语言下载 XLIFF 文件，1.2 版本每种目标语言为一个 file 元素，2.0 版本只能包含一种目标语言（用 target_language 指定）；format=yaml 时下载 Rails 风格的 YAML 文件，每种语言一个根节点，键名按 . 嵌套；format=po 时以默认语言的翻译为 msgid、键的上下文说明为 msgctxt 下载 gettext PO 文件，只能包含一种目标语言（用 target_language 指定），键名以 .one/.other 等复数类别结尾的一组键以及只包含一个 plural 参数的 ICU 消息导出为复数词条；format=pot 时下载不含译文的 POT 模板；format=android-xml、apple-strings、apple-stringsdict 时下载一种语言（用 target_language 指定，默认为默认语言）的 Android strings.xml、iOS .strings 或 .stringsdict 文件，按各平台的规则转义，复数键和只包含一个 plural 参数的 ICU 消息导出为 plurals 或 stringsdict 条目；format=arb 时下载一种语言（同上）的 Flutter ARB 文件，键的上下文说明和 ICU 消息中的占位符定义写入 @key 元数据块；format=properties 时下载一种语言（同上）的 Java .properties 文件，非 ASCII 字符转义为 \uXXXX，上下文说明写为 # 注释；format=resx 时下载一种语言（同上）的 .NET .resx 文件（UTF-8），上下文说明写入 comment。download=zip 时不返回 JSON 或单个文件，而是按语言拆分打包为 ZIP 下载（如 en.json、zh-CN.json），与按文件导出接口相同，便于 CI 作为构建产物使用。only_status=approved 时只导出审核状态（review_status）为 approved 的翻译；missing 指定缺失翻译的处理方式：skip 不输出、empty 输出空字符串、source_fallback 使用默认语言的翻译
 // @Tags         翻译管理
 // @Accept       json
 // @Produce      json
-// @Param        project_id  path      int     true   "项目ID"
-// @Success      200         {object}  response.APIResponse
+// @Produce      application/x-xliff+xml
+// @Produce      application/x-yaml
+// @Produce      text/x-gettext-translation
+// @Produce      application/xml
+// @Produce      text/plain
+// @Produce      application/x-plist
+// @Produce      text/x-java-properties
+// @Produce      application/zip
+// @Param        project_id       path      int     true   "项目ID"
+// @Param        format           query     string  false  "导出格式"  Enums(json, xliff, yaml, po, pot, android-xml, apple-strings, apple-stringsdict, arb, properties, resx)  default(json)
+// @Param        only_status      query     string  false  "只导出该状态的翻译"  Enums(approved)
+// @Param        missing          query     string  false  "缺失翻译的处理方式"  Enums(skip, empty, source_fallback)  default(skip)
+// @Param        xliff_version    query     string  false  "XLIFF 版本"  Enums(1.2, 2.0)  default(1.2)
+// @Param        target_language  query     string  false  "XLIFF 和 PO 只导出该目标语言；移动端格式、ARB、properties 和 resx 导出该语言"
+// @Param        namespace        query     string  false  "只导出该命名空间中的键"
+// @Param        tags             query     string  false  "只导出带有其中任一标签的键，逗号分隔的标签名称（如 mobile,beta）"
+// @Param        release          query     string  false  "导出该发布版本冻结的翻译（版本标签），不能与 only_status、namespace、tags 同时使用"
+// @Param        download         query     string  false  "按语言拆分打包为 ZIP 下载"  Enums(zip)
+// @Success      200          {object}  response.APIResponse
 // @Failure      400         {object}  response.APIResponse
 // @Failure      404         {object}  response.APIResponse
 // @Security     BearerAuth
@@ -569,11 +1079,82 @@ func (h *TranslationHandler) Export(ctx *gin.Context) {
 		return
 	}
 
-	// 获取翻译矩阵数据
-	matrix, _, err := h.translationService.GetMatrix(ctx.Request.Context(), projectID, -1, 0, "")
+	switch download := ctx.Query("download"); download {
+	case "":
+	case "zip":
+		h.ExportFiles(ctx)
+		return
+	default:
+		response.BadRequest(ctx, "不支持的下载方式: "+download)
+		return
+	}
+
+	switch format := ctx.DefaultQuery("format", "json"); format {
+	case "json":
+	case "xliff":
+		h.exportAttachment(ctx, projectID, format, "xlf", "application/x-xliff+xml")
+		return
+	case "yaml":
+		h.exportAttachment(ctx, projectID, format, "yml", "application/x-yaml")
+		return
+	case "po":
+		h.exportAttachment(ctx, projectID, format, "po", "text/x-gettext-translation")
+		return
+	case "pot":
+		h.exportAttachment(ctx, projectID, format, "pot", "text/x-gettext-translation-template")
+		return
+	case service.FormatAndroidXML:
+		h.exportAttachment(ctx, projectID, format, "xml", "application/xml; charset=utf-8")
+		return
+	case service.FormatAppleStrings:
+		h.exportAttachment(ctx, projectID, format, "strings", "text/plain; charset=utf-8")
+		return
+	case service.FormatAppleStringsDict:
+		h.exportAttachment(ctx, projectID, format, "stringsdict", "application/x-plist")
+		return
+	case service.FormatARB:
+		h.exportAttachment(ctx, projectID, format, "arb", "application/json; charset=utf-8")
+		return
+	case service.FormatProperties:
+		h.exportAttachment(ctx, projectID, format, "properties", "text/x-java-properties; charset=iso-8859-1")
+		return
+	case service.FormatResx:
+		h.exportAttachment(ctx, projectID, format, "resx", "application/xml; charset=utf-8")
+		return
+	default:
+		response.BadRequest(ctx, "不支持的导出格式: "+format)
+		return
+	}
+
+	data, err := h.translationService.Export(ctx.Request.Context(), projectID, "json", exportOptionsFromQuery(ctx))
 	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return
+		}
 		switch err {
-		case domain.ErrProjectNotFound:
+		case domain.ErrProjectNotFound, domain.ErrNamespaceNotFound, domain.ErrReleaseNotFound:
+			response.NotFound(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "导出翻译失败")
+		}
+		return
+	}
+
+	// 返回翻译数据，json 类型的键为解析后的值
+	response.Success(ctx, json.RawMessage(data))
+}
+
+// exportAttachment 以附件形式返回 XLIFF、YAML、PO 等文件格式的导出内容
+func (h *TranslationHandler) exportAttachment(ctx *gin.Context, projectID uint64, format, ext, contentType string) {
+	data, err := h.translationService.Export(ctx.Request.Context(), projectID, format, exportOptionsFromQuery(ctx))
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return
+		}
+		switch err {
+		case domain.ErrProjectNotFound, domain.ErrLanguageNotFound, domain.ErrNamespaceNotFound, domain.ErrReleaseNotFound:
 			response.NotFound(ctx, err.Error())
 		default:
 			response.InternalServerError(ctx, "导出翻译失败")
@@ -581,22 +1162,113 @@ func (h *TranslationHandler) Export(ctx *gin.Context) {
 		return
 	}
 
-	// 返回翻译数据
-	response.Success(ctx, matrix)
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="project-%d.%s"`, projectID, ext))
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+// exportOptionsFromQuery 从查询参数读取导出选项
+func exportOptionsFromQuery(ctx *gin.Context) domain.ExportOptions {
+	return domain.ExportOptions{
+		OnlyStatus:     ctx.Query("only_status"),
+		Missing:        ctx.Query("missing"),
+		XLIFFVersion:   ctx.Query("xliff_version"),
+		TargetLanguage: ctx.Query("target_language"),
+		YAMLStyle:      ctx.Query("yaml_style"),
+		Namespace:      ctx.Query("namespace"),
+		Tags:           strings.Split(ctx.Query("tags"), ","),
+		Release:        ctx.Query("release"),
+	}
+}
+
+// ExportFiles 以 ZIP 文件形式导出翻译
+// @Summary      导出翻译文件
+// @Description  按语言拆分导出项目翻译，文件路径由项目的导出文件命名模板决定（如 {locale}/{namespace}.json，{locale_underscore} 为 zh_CN 形式的语言代码），打包为 ZIP 下载。format=xliff 时每种目标语言导出一个 .xlf 文件（源语言为默认语言）；format=yaml 时每种语言导出一个 YAML 文件，yaml_style=rails（默认）以语言代码为根节点、扩展名为 .yml，yaml_style=symfony 没有根节点、扩展名为 .yaml；format=po 时每种目标语言导出一个 .po 文件（msgid 为默认语言的翻译）；format=android-xml、apple-strings、apple-stringsdict 时每种语言导出一个 .xml、.strings 或 .stringsdict 文件，可将命名模板设为 values-{locale}/strings.{ext} 或 {locale}.lproj/Localizable.{ext}；format=arb 时每种语言导出一个 .arb 文件，{locale} 为 Flutter 使用的 zh_CN 形式，可将命名模板设为 app_{locale}.{ext}；format=properties 时每种语言导出一个 .properties 文件，{locale} 为 Java 使用的 zh_CN 形式，可将命名模板设为 messages_{locale}.{ext}；format=resx 时每种语言导出一个 .resx 文件，可将命名模板设为 Resources.{locale}.{ext}。only_status、missing、xliff_version 和 target_language 与导出翻译接口相同
+// @Tags         翻译管理
+// @Produce      application/zip
+// @Param        project_id       path      int     true   "项目ID"
+// @Param        format           query     string  false  "导出格式"  Enums(json, xliff, yaml, po, android-xml, apple-strings, apple-stringsdict, arb, properties, resx)  default(json)
+// @Param        only_status      query     string  false  "只导出该状态的翻译"  Enums(approved)
+// @Param        missing          query     string  false  "缺失翻译的处理方式"  Enums(skip, empty, source_fallback)  default(skip)
+// @Param        xliff_version    query     string  false  "XLIFF 版本"  Enums(1.2, 2.0)  default(1.2)
+// @Param        target_language  query     string  false  "XLIFF 和 PO 只导出该目标语言；移动端格式、ARB、properties 和 resx 只导出该语言"
+// @Param        yaml_style       query     string  false  "YAML 文件风格"  Enums(rails, symfony)  default(rails)
+// @Param        namespace        query     string  false  "只导出该命名空间中的键，文件命名模板中的 {namespace} 为该命名空间（未指定时为 messages）"
+// @Param        tags             query     string  false  "只导出带有其中任一标签的键，逗号分隔的标签名称（如 mobile,beta）"
+// @Param        release          query     string  false  "导出该发布版本冻结的翻译（版本标签），不能与 only_status、namespace、tags 同时使用"
+// @Success      200          {file}    file
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /exports/project/{project_id}/files [get]
+func (h *TranslationHandler) ExportFiles(ctx *gin.Context) {
+	projectIDStr := ctx.Param("project_id")
+	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+
+	files, err := h.translationService.ExportFiles(ctx.Request.Context(), projectID, format, exportOptionsFromQuery(ctx))
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequest(ctx, appErr.Message)
+			return
+		}
+		switch err {
+		case domain.ErrProjectNotFound, domain.ErrLanguageNotFound, domain.ErrNamespaceNotFound, domain.ErrReleaseNotFound:
+			response.NotFound(ctx, err.Error())
+		default:
+			response.BadRequest(ctx, "导出翻译失败: "+err.Error())
+		}
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for _, file := range files {
+		writer, err := zipWriter.Create(file.Name)
+		if err != nil {
+			response.InternalServerError(ctx, "生成导出文件失败")
+			return
+		}
+		if _, err := writer.Write(file.Content); err != nil {
+			response.InternalServerError(ctx, "生成导出文件失败")
+			return
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		response.InternalServerError(ctx, "生成导出文件失败")
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="project-%d-%s.zip"`, projectID, format))
+	ctx.Data(http.StatusOK, "application/zip", buf.Bytes())
 }
 
 // Import 导入翻译
 // @Summary      导入翻译
-// @Description  导入项目翻译数据
+// @Description  导入项目翻译数据。format=json（默认）时值为数组或对象的键按 json 类型导入，已是 json 类型的键的值按 JSON 值读取，与导出格式对应；format=xliff 时请求体为 XLIFF 1.2 或 2.0 文件（版本自动识别），按文件的目标语言导入译文，备注写入上下文，源文不导入；format=yaml 时请求体为 Rails 风格（以语言代码为根节点）的 YAML 文件，嵌套的键以 . 连接为键名，Symfony 风格（没有语言根节点）的文件需要用 language 指定语言；format=arb 时请求体为 Flutter ARB 文件，按 @@locale（或 language）导入一种语言，@key 元数据块的 description 写入上下文。strategy 指定与已有翻译冲突时的处理方式：skip 跳过项目中已存在的键，overwrite 覆盖已有译文，merge 只写入没有译文或译文为空的语言，fail 在任一语言已有翻译时整个导入失败（409，不写入任何翻译）；未指定时 json 为 fail，其余格式为 overwrite。译文没有变化的翻译不会写入，返回每个键新建、更新还是跳过；format=csv 或 xlsx 时第一列为键名，其余列的表头为语言代码，只写入新增或有变化的译文，返回每一行将新建、更新还是跳过，dry_run=true 时只返回预览不写入。文件也可以通过 multipart/form-data 的 file 字段上传，未指定 format 时按文件扩展名识别
 // @Tags         翻译管理
 // @Accept       json
+// @Accept       application/x-xliff+xml
+// @Accept       application/x-yaml
+// @Accept       text/csv
+// @Accept       application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Accept       multipart/form-data
 // @Produce      json
 // @Param        project_id  path      int                                       true  "项目ID"
 // @Param        data        body      map[string]map[string]string             true  "翻译数据，格式为 {\"key1\": {\"en\": \"value1\", \"zh\": \"值1\"}}"
-// @Param        format      query     string                                   false "导入格式" default("json")
-// @Success      200         {object}  response.APIResponse
+// @Param        format      query     string                                   false "导入格式，默认按上传文件的扩展名识别，无法识别时为 json" Enums(json, xliff, yaml, arb, csv, xlsx)
+// @Param        language    query     string                                   false "YAML 文件没有语言根节点或 ARB 文件没有 @@locale 时文件内容所属的语言代码"
+// @Param        strategy    query     string                                   false "与已有翻译冲突时的处理方式（表格导入不支持）" Enums(skip, overwrite, merge, fail)
+// @Param        namespace   query     string                                   false "文件中的键（包括跳过的已有键）归入该命名空间（表格导入不支持）"
+// @Param        dry_run     query     bool                                     false "表格导入只返回预览，不写入"
+// @Success      200         {object}  domain.ImportResult                     "每个键的处理结果；表格导入返回 domain.SpreadsheetUploadResult"
 // @Failure      400         {object}  response.APIResponse
 // @Failure      404         {object}  response.APIResponse
+// @Failure      409         {object}  response.APIResponse                   "fail 策略下与已有翻译冲突"
 // @Security     BearerAuth
 // @Router       /imports/project/{project_id} [post]
 func (h *TranslationHandler) Import(ctx *gin.Context) {
@@ -607,19 +1279,39 @@ func (h *TranslationHandler) Import(ctx *gin.Context) {
 		return
 	}
 
-	format := ctx.DefaultQuery("format", "json")
-
-	// 读取请求体
-	data, err := ctx.GetRawData()
+	data, fileName, err := readImportData(ctx)
 	if err != nil {
 		response.BadRequest(ctx, "读取请求数据失败")
 		return
 	}
 
-	err = h.translationService.Import(ctx.Request.Context(), projectID, data, format)
+	format := ctx.Query("format")
+	if format == "" {
+		format = importFormatFromFileName(fileName)
+	}
+	if format == service.SpreadsheetFormatCSV || format == service.SpreadsheetFormatXLSX {
+		h.importSpreadsheet(ctx, projectID, format, data)
+		return
+	}
+
+	result, err := h.translationService.Import(ctx.Request.Context(), projectID, data, format, domain.ImportOptions{
+		Language:  ctx.Query("language"),
+		Strategy:  ctx.Query("strategy"),
+		Namespace: ctx.Query("namespace"),
+	})
 	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeValidation:
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+				return
+			case domain.ErrorTypeConflict:
+				response.ErrorWithDetails(ctx, http.StatusConflict, appErr.Code, appErr.Message, appErr.Details)
+				return
+			}
+		}
 		switch err {
-		case domain.ErrProjectNotFound:
+		case domain.ErrProjectNotFound, domain.ErrNamespaceNotFound:
 			response.NotFound(ctx, err.Error())
 		default:
 			response.InternalServerError(ctx, "导入翻译失败: "+err.Error())
@@ -641,12 +1333,169 @@ func (h *TranslationHandler) Import(ctx *gin.Context) {
 	h.logger.Info("Translation imported",
 		zap.Uint64("project_id", projectID),
 		zap.String("format", format),
+		zap.String("strategy", result.Strategy),
 		zap.Int("data_size", len(data)),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("skipped", result.Skipped),
 		zap.Uint64("operator_id", operatorID.(uint64)),
 		zap.String("operator", operatorName),
 	)
 
-	response.Success(ctx, gin.H{"message": "导入翻译成功"})
+	response.Success(ctx, result)
+}
+
+// importSpreadsheet 导入第一列为键名、其余列为语言代码的表格
+func (h *TranslationHandler) importSpreadsheet(ctx *gin.Context, projectID uint64, format string, data []byte) {
+	if len(data) == 0 {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	result, err := h.translationService.ImportSpreadsheet(ctx.Request.Context(), projectID, domain.SpreadsheetUploadParams{
+		Format: format,
+		Data:   data,
+		DryRun: ctx.Query("dry_run") == "true",
+	})
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok && appErr.Type == domain.ErrorTypeValidation {
+			response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+			return
+		}
+		switch err {
+		case domain.ErrProjectNotFound:
+			response.NotFound(ctx, err.Error())
+		default:
+			response.InternalServerError(ctx, "导入翻译失败: "+err.Error())
+		}
+		return
+	}
+
+	operatorID, exists := ctx.Get("userID")
+	if !exists {
+		operatorID = uint64(0)
+	}
+	h.logger.Info("Spreadsheet translations imported",
+		zap.Uint64("project_id", projectID),
+		zap.String("format", result.Format),
+		zap.Bool("dry_run", result.DryRun),
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("skipped", result.Skipped),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// readImportData 读取导入的文件内容：multipart/form-data 请求读取 file 字段，同时返回文件名；否则读取整个请求体
+func readImportData(ctx *gin.Context) ([]byte, string, error) {
+	if ctx.ContentType() != "multipart/form-data" {
+		data, err := ctx.GetRawData()
+		return data, "", err
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	return data, fileHeader.Filename, err
+}
+
+// importFormatFromFileName 按文件扩展名识别导入格式，无法识别时为 json
+func importFormatFromFileName(fileName string) string {
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".xlf", ".xliff":
+		return "xliff"
+	case ".yml", ".yaml":
+		return "yaml"
+	case ".arb":
+		return service.FormatARB
+	case ".csv":
+		return service.SpreadsheetFormatCSV
+	case ".xlsx":
+		return service.SpreadsheetFormatXLSX
+	default:
+		return "json"
+	}
+}
+
+// ImportMigration 从外部 TMS 迁移翻译
+// @Summary      从外部 TMS 迁移
+// @Description  直接上传 Lokalise、Crowdin 或 POEditor 的原生导出内容（zip 导出包或 JSON 文件），已存在的翻译会被覆盖
+// @Tags         翻译管理
+// @Accept       application/zip
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int     true   "项目ID"
+// @Param        source      query     string  true   "来源系统: lokalise, crowdin, poeditor"
+// @Param        language    query     string  false  "单个 JSON 文件对应的语言代码"
+// @Success      200         {object}  domain.MigrationImportResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /imports/project/{project_id}/migrate [post]
+func (h *TranslationHandler) ImportMigration(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	data, err := ctx.GetRawData()
+	if err != nil || len(data) == 0 {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	operatorID, exists := ctx.Get("userID")
+	if !exists {
+		operatorID = uint64(0)
+	}
+
+	params := domain.MigrationImportParams{
+		Source:   ctx.Query("source"),
+		Language: ctx.Query("language"),
+		Data:     data,
+		UserID:   operatorID.(uint64),
+	}
+
+	result, err := h.translationService.ImportMigration(ctx.Request.Context(), projectID, params)
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeValidation:
+				response.BadRequest(ctx, appErr.Message)
+				return
+			}
+		}
+		response.InternalServerError(ctx, "迁移导入失败")
+		return
+	}
+
+	h.logger.Info("Translation migration imported",
+		zap.Uint64("project_id", projectID),
+		zap.String("source", params.Source),
+		zap.Int("data_size", len(data)),
+		zap.Int("keys", result.Keys),
+		zap.Int("translations", result.Translations),
+		zap.Int("skipped", result.SkippedEntries),
+		zap.Int("tagged_keys", result.TaggedKeys),
+		zap.Strings("warnings", result.Warnings),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, result)
 }
 
 // AutoFillLanguage 自动填充语言翻译
@@ -820,9 +1669,124 @@ func (h *TranslationHandler) GetSupportedLanguages(ctx *gin.Context) {
 // @Tags         翻译管理
 // @Produce      json
 // @Success      200 {object} map[string]bool
+// @Security     BearerAuth
 // @Router       /translations/machine-translate/health [get]
 func (h *TranslationHandler) HealthCheck(ctx *gin.Context) {
 	available := h.machineTranslationService.IsAvailable(ctx.Request.Context())
 	response.Success(ctx, gin.H{"available": available})
 }
 
+// MachineTranslate 使用机器翻译填充缺失的翻译
+// @Summary      机器翻译填充缺失翻译
+// @Description  使用配置的机器翻译服务商（MT_PROVIDER：libretranslate、deepl 或 google）将源语言的文本翻译到目标语言，只填充目标语言中没有翻译的键，已有的翻译（包括已废弃的）不会被覆盖。key_names 为空时处理项目中的所有键；源语言没有文本或值类型为 json 的键计入 skipped。所有目标语言翻译完成后一次写入，写入的翻译在变更历史中记为 machine_translate；服务商调用失败时返回 502，不写入任何翻译。一次最多翻译 2000 条
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                          true  "项目ID"
+// @Param        request     body      dto.MachineTranslateRequest  true  "键名和语言"
+// @Success      200         {object}  domain.AutoTranslateResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Failure      502         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/machine-translate/project/{project_id} [post]
+func (h *TranslationHandler) MachineTranslate(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.MachineTranslateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.autoTranslateService.FillMissing(ctx.Request.Context(), projectID, domain.AutoTranslateParams{
+		KeyNames:        req.KeyNames,
+		SourceLanguage:  req.SourceLanguage,
+		TargetLanguages: req.TargetLanguages,
+	})
+	if err != nil {
+		appErr, ok := domain.IsAppError(err)
+		switch {
+		case ok && appErr.Type == domain.ErrorTypeNotFound:
+			response.NotFound(ctx, appErr.Message)
+		case ok && (appErr.Type == domain.ErrorTypeValidation || appErr.Type == domain.ErrorTypeBadRequest):
+			response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+		case ok && appErr.Code == domain.ErrMachineTranslationFailed.Code:
+			h.logger.Error("Machine translation provider failed", zap.Uint64("project_id", projectID), zap.Error(err))
+			response.Error(ctx, http.StatusBadGateway, appErr.Code, appErr.Message)
+		default:
+			h.logger.Error("Failed to fill translations by machine translation", zap.Uint64("project_id", projectID), zap.Error(err))
+			response.InternalServerError(ctx, "机器翻译填充失败")
+		}
+		return
+	}
+
+	h.logger.Info("Missing translations filled by machine translation",
+		zap.Uint64("project_id", projectID),
+		zap.String("source_language", result.SourceLanguage),
+		zap.Int("translated", result.Translated),
+		zap.Int("failed", result.Failed),
+	)
+
+	response.Success(ctx, result)
+}
+
+// PreTranslate 使用机器翻译预翻译整个项目
+// @Summary      机器翻译预翻译项目
+// @Description  扫描项目中所有键，使用机器翻译填充 target_languages 中选择的语言里没有翻译的单元格，已有的翻译（包括已废弃的）不会被覆盖；源语言没有文本或值类型为 json 的键计入 skipped。按 MT_BATCH_SIZE 分批调用服务商，两批之间间隔 MT_REQUEST_INTERVAL 毫秒；每批翻译完成后立即写入（变更历史中记为 machine_translate），某一批调用失败时这批键计入 failed_keys 并继续下一批。响应中按语言列出已填充和失败的键。一次最多翻译 20000 条
+// @Tags         翻译管理
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                      true  "项目ID"
+// @Param        request     body      dto.PreTranslateRequest  true  "源语言和需要预翻译的语言"
+// @Success      200         {object}  domain.PreTranslateResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/machine-translate/project/{project_id}/pre-translate [post]
+func (h *TranslationHandler) PreTranslate(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.BadRequest(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.PreTranslateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.autoTranslateService.PreTranslate(ctx.Request.Context(), projectID, domain.PreTranslateParams{
+		SourceLanguage:  req.SourceLanguage,
+		TargetLanguages: req.TargetLanguages,
+	})
+	if err != nil {
+		appErr, ok := domain.IsAppError(err)
+		switch {
+		case ok && appErr.Type == domain.ErrorTypeNotFound:
+			response.NotFound(ctx, appErr.Message)
+		case ok && (appErr.Type == domain.ErrorTypeValidation || appErr.Type == domain.ErrorTypeBadRequest):
+			response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+		default:
+			h.logger.Error("Failed to pre-translate project", zap.Uint64("project_id", projectID), zap.Error(err))
+			response.InternalServerError(ctx, "项目预翻译失败")
+		}
+		return
+	}
+
+	h.logger.Info("Project pre-translated by machine translation",
+		zap.Uint64("project_id", projectID),
+		zap.String("source_language", result.SourceLanguage),
+		zap.Int("filled", result.Filled),
+		zap.Int("failed", result.Failed),
+		zap.Int("skipped", result.Skipped),
+	)
+
+	response.Success(ctx, result)
+}
+
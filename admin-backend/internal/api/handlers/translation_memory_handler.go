@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TranslationMemoryHandler 翻译记忆库处理器
+type TranslationMemoryHandler struct {
+	memoryService domain.TranslationMemoryService
+	logger        *zap.Logger
+}
+
+// NewTranslationMemoryHandler 创建翻译记忆库处理器
+func NewTranslationMemoryHandler(memoryService domain.TranslationMemoryService, logger *zap.Logger) *TranslationMemoryHandler {
+	return &TranslationMemoryHandler{
+		memoryService: memoryService,
+		logger:        logger,
+	}
+}
+
+// Suggest 查询翻译记忆
+// @Summary      查询翻译记忆
+// @Description  在当前用户可访问的项目（管理员为所有项目）中查找源语言文本与 text 相似的已有翻译，返回这些键在目标语言中的译文及相似度（0-1，忽略大小写和多余空白后按编辑距离计算，1 为完全相同），供编辑时参考。只使用有效的翻译；原文和译文都相同的键合并为一条，occurrences 为使用次数，project_id 和 key_name 为其中最近更新的键。按相似度、使用次数和更新时间排序。编辑某个键时传入 project_id 和 key_name 可排除该键自身
+// @Tags         翻译管理
+// @Produce      json
+// @Param        text        query     string  true   "需要翻译的源语言文本，最长 500 个字符"
+// @Param        source      query     string  false  "源语言代码，默认为默认语言"
+// @Param        target      query     string  true   "目标语言代码"
+// @Param        min_score   query     number  false  "最低相似度，大于 0 且不大于 1"  default(0.5)
+// @Param        limit       query     int     false  "最多返回的建议数量，最大 50"  default(10)
+// @Param        project_id  query     int     false  "正在编辑的键所在项目"
+// @Param        key_name    query     string  false  "正在编辑的键名，不出现在建议中"
+// @Success      200         {object}  domain.TMSuggestResult
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /tm/suggest [get]
+func (h *TranslationMemoryHandler) Suggest(ctx *gin.Context) {
+	var req dto.TMSuggestRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.memoryService.Suggest(ctx.Request.Context(), ctx.GetUint64("userID"), domain.TMSuggestQuery{
+		Text:             req.Text,
+		SourceLanguage:   req.Source,
+		TargetLanguage:   req.Target,
+		MinScore:         req.MinScore,
+		Limit:            req.Limit,
+		ExcludeProjectID: req.ProjectID,
+		ExcludeKey:       req.KeyName,
+	})
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeValidation, domain.ErrorTypeBadRequest:
+				response.BadRequest(ctx, appErr.Message)
+				return
+			}
+		}
+		h.logger.Error("Failed to query translation memory", zap.Error(err))
+		response.InternalServerError(ctx, "查询翻译记忆失败")
+		return
+	}
+
+	response.Success(ctx, result)
+}
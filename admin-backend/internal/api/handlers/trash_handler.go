@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TrashHandler 翻译回收站处理器
+type TrashHandler struct {
+	trashService domain.TranslationTrashService
+	logger       *zap.Logger
+}
+
+// NewTrashHandler 创建翻译回收站处理器
+func NewTrashHandler(trashService domain.TranslationTrashService, logger *zap.Logger) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+		logger:       logger,
+	}
+}
+
+// GetTrash 获取项目回收站中的翻译
+// @Summary      获取回收站
+// @Description  分页获取项目中已删除的翻译，最近删除的在前。删除的翻译保留 TRASH_RETENTION_DAYS 天后永久删除，purge_at 为永久删除的时间
+// @Tags         翻译管理
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        page        query     int  false  "页码"      default(1)
+// @Param        page_size   query     int  false  "每页数量"  default(10)
+// @Success      200         {object}  dto.TranslationTrashResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/translations/trash [get]
+func (h *TrashHandler) GetTrash(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	trash, err := h.trashService.GetTrash(ctx.Request.Context(), projectID, pageSize, offset)
+	if err != nil {
+		response.HandleError(ctx, err, "获取回收站失败")
+		return
+	}
+
+	resp := dto.TranslationTrashResponse{
+		Translations:  make([]*dto.TrashedTranslationResponse, 0, len(trash.Translations)),
+		Total:         trash.Total,
+		RetentionDays: int(trash.Retention / (24 * time.Hour)),
+	}
+	for _, translation := range trash.Translations {
+		item := &dto.TrashedTranslationResponse{
+			ID:           translation.ID,
+			KeyName:      translation.KeyName,
+			LanguageID:   translation.LanguageID,
+			LanguageCode: translation.Language.Code,
+			Value:        translation.Value,
+			Status:       translation.Status,
+			DeletedBy:    translation.UpdatedBy,
+			DeletedAt:    translation.DeletedAt.Time.Format(time.RFC3339),
+		}
+		if trash.Retention > 0 {
+			item.PurgeAt = translation.DeletedAt.Time.Add(trash.Retention).Format(time.RFC3339)
+		}
+		resp.Translations = append(resp.Translations, item)
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: trash.Total,
+		TotalPages: (trash.Total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, resp, meta)
+}
+
+// Restore 从回收站恢复翻译
+// @Summary      恢复翻译
+// @Description  恢复已删除的翻译，翻译值、状态和审核状态保持删除时的内容，并记录操作类型为 restore 的变更历史。该键在其他语言还有翻译时，值类型、最大长度、标签、平台和命名空间使用其他语言的设置
+// @Tags         翻译管理
+// @Produce      json
+// @Param        id   path      int  true  "翻译ID"
+// @Success      200  {object}  domain.Translation
+// @Failure      400  {object}  response.APIResponse
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /translations/{id}/restore [post]
+func (h *TrashHandler) Restore(ctx *gin.Context) {
+	translationID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的翻译ID")
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	translation, err := h.trashService.Restore(ctx.Request.Context(), translationID, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "恢复翻译失败")
+		return
+	}
+
+	h.logger.Info("Translation restored",
+		zap.Uint64("translation_id", translation.ID),
+		zap.Uint64("project_id", translation.ProjectID),
+		zap.String("key_name", translation.KeyName),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Success(ctx, translation)
+}
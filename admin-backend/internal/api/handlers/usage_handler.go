@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UsageHandler 翻译拉取用量统计处理器
+type UsageHandler struct {
+	usageService domain.UsageService
+	logger       *zap.Logger
+}
+
+// NewUsageHandler 创建翻译拉取用量统计处理器
+func NewUsageHandler(usageService domain.UsageService, logger *zap.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+		logger:       logger,
+	}
+}
+
+// GetReport 获取项目的翻译拉取用量
+// @Summary      获取翻译拉取用量
+// @Description  统计最近若干天（按 UTC 日期，含今天）CLI 拉取翻译和导出接口的成功请求数，按语言、调用方和渠道汇总并给出每日趋势。语言为请求中指定的语言代码，能对应到已启用语言的按语言代码合并，空字符串表示拉取全部语言；调用方为 token:<令牌ID>、api_key:<API Key 哈希前缀> 或 user:<用户ID>，请求带 X-YFlow-Client 头时追加 /<客户端名称>。unused_locales 列出统计期间从未被单独拉取的启用语言，便于清理不再使用的语言
+// @Tags         项目管理
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        days        query     int  false  "统计天数，1 到 365"  default(30)
+// @Success      200         {object}  domain.UsageReport
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/usage [get]
+func (h *UsageHandler) GetReport(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "30"))
+	if err != nil {
+		response.ValidationError(ctx, "无效的统计天数")
+		return
+	}
+
+	report, err := h.usageService.GetReport(ctx.Request.Context(), projectID, days)
+	if err != nil {
+		h.handleError(ctx, err, "获取用量统计失败")
+		return
+	}
+
+	response.Success(ctx, report)
+}
+
+// handleError 将领域错误映射为HTTP响应
+func (h *UsageHandler) handleError(ctx *gin.Context, err error, fallback string) {
+	if !response.HandleError(ctx, err, fallback) {
+		h.logger.Error("Failed to get usage report", zap.Error(err))
+	}
+}
@@ -34,6 +34,7 @@ func NewUserHandler(userService domain.UserService, logger *zap.Logger) *UserHan
 // @Success      200          {object}  dto.LoginResponse
 // @Failure      400          {object}  map[string]string
 // @Failure      401          {object}  map[string]string
+// @Failure      403          {object}  map[string]string
 // @Router       /login [post]
 func (h *UserHandler) Login(ctx *gin.Context) {
 	var req dto.LoginRequest
@@ -51,7 +52,7 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 	}
 
 	// 调用登录服务
-	result, err := h.userService.Login(ctx.Request.Context(), params)
+	result, err := h.userService.Login(domain.WithClientInfo(ctx.Request.Context(), ctx.ClientIP(), ctx.Request.UserAgent()), params)
 	if err != nil {
 		// 根据错误类型返回不同状态码
 		switch err {
@@ -63,6 +64,13 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 				zap.String("user_agent", ctx.Request.UserAgent()),
 			)
 			response.Unauthorized(ctx, err.Error())
+		case domain.ErrUserDisabled:
+			h.logger.Info("User login failed",
+				zap.String("username", req.Username),
+				zap.String("reason", "user_disabled"),
+				zap.String("client_ip", ctx.ClientIP()),
+			)
+			response.Forbidden(ctx, err.Error())
 		default:
 			h.logger.Info("User login failed",
 				zap.String("username", req.Username),
@@ -109,6 +117,7 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 // @Success      200            {object}  dto.LoginResponse
 // @Failure      400            {object}  map[string]string
 // @Failure      401            {object}  map[string]string
+// @Failure      403            {object}  map[string]string
 // @Router       /refresh [post]
 func (h *UserHandler) RefreshToken(ctx *gin.Context) {
 	var req dto.RefreshRequest
@@ -120,11 +129,13 @@ func (h *UserHandler) RefreshToken(ctx *gin.Context) {
 	}
 
 	// 调用刷新服务
-	result, err := h.userService.RefreshToken(ctx.Request.Context(), req.RefreshToken)
+	result, err := h.userService.RefreshToken(domain.WithClientInfo(ctx.Request.Context(), ctx.ClientIP(), ctx.Request.UserAgent()), req.RefreshToken)
 	if err != nil {
 		switch err {
 		case domain.ErrInvalidToken:
 			response.InvalidToken(ctx, err.Error())
+		case domain.ErrUserDisabled:
+			response.Forbidden(ctx, err.Error())
 		default:
 			response.InternalServerError(ctx, "刷新token失败")
 		}
@@ -234,13 +245,15 @@ func (h *UserHandler) CreateUser(ctx *gin.Context) {
 
 // GetUsers 获取用户列表
 // @Summary      获取用户列表
-// @Description  分页获取用户列表，支持关键词搜索
+// @Description  分页获取用户列表，支持关键词搜索。sort 指定排序（如 created_at:desc，可排序字段：id、username、email、status、created_at、updated_at），fields 只返回指定的字段（如 id,username）
 // @Tags         用户管理
 // @Accept       json
 // @Produce      json
 // @Param        page      query     int     false  "页码"        default(1)
 // @Param        page_size query     int     false  "每页数量"     default(10)
 // @Param        keyword   query     string  false  "搜索关键词"
+// @Param        sort      query     string  false  "排序，字段:asc|desc，多个用逗号分隔"
+// @Param        fields    query     string  false  "返回的字段，逗号分隔"
 // @Success      200       {object}  map[string]interface{}
 // @Failure      400       {object}  map[string]string
 // @Security     BearerAuth
@@ -260,8 +273,13 @@ func (h *UserHandler) GetUsers(ctx *gin.Context) {
 
 	offset := (page - 1) * pageSize
 
+	list, ok := parseListQuery(ctx, userListFields)
+	if !ok {
+		return
+	}
+
 	// 获取用户列表
-	users, total, err := h.userService.GetAllUsers(ctx.Request.Context(), pageSize, offset, keyword)
+	users, total, err := h.userService.GetAllUsers(ctx.Request.Context(), pageSize, offset, keyword, list.sort)
 	if err != nil {
 		response.InternalServerError(ctx, "获取用户列表失败")
 		return
@@ -274,9 +292,17 @@ func (h *UserHandler) GetUsers(ctx *gin.Context) {
 		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
 	}
 
-	response.SuccessWithMeta(ctx, users, meta)
+	data, err := selectFields(users, list.fields)
+	if err != nil {
+		response.InternalServerError(ctx, "获取用户列表失败")
+		return
+	}
+	response.SuccessWithMeta(ctx, data, meta)
 }
 
+// userListFields 用户列表可以排序和选择的字段
+var userListFields = newListFields(domain.User{}, "id", "username", "email", "status", "created_at", "updated_at")
+
 // GetUser 获取用户详情
 // @Summary      获取用户详情
 // @Description  根据用户ID获取用户详细信息
@@ -495,7 +521,7 @@ func (h *UserHandler) ResetPassword(ctx *gin.Context) {
 
 // DeleteUser 删除用户
 // @Summary      删除用户
-// @Description  删除指定的用户账户
+// @Description  删除指定的用户账户，按默认方式进行离职处理。用户仍是项目唯一的 owner 或有有效的邀请码时返回 409 USER_OWNS_RESOURCES，需要先通过 POST /users/{id}/transfer-ownership 转移；只需禁止登录时使用 POST /users/{id}/deactivate
 // @Tags         用户管理
 // @Accept       json
 // @Produce      json
@@ -504,6 +530,7 @@ func (h *UserHandler) ResetPassword(ctx *gin.Context) {
 // @Failure      400  {object}  map[string]string
 // @Failure      403  {object}  map[string]string
 // @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  response.APIResponse
 // @Security     BearerAuth
 // @Router       /users/{id} [delete]
 func (h *UserHandler) DeleteUser(ctx *gin.Context) {
@@ -523,7 +550,10 @@ func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 		case domain.ErrCannotDeleteAdmin:
 			response.Forbidden(ctx, "不能删除管理员用户")
 		default:
-			response.InternalServerError(ctx, "删除用户失败")
+			// ErrUserOwnsResources 带有需要转移的项目和邀请码
+			if !response.HandleError(ctx, err, "删除用户失败") {
+				h.logger.Error("Failed to delete user", zap.Uint64("user_id", id), zap.Error(err))
+			}
 		}
 		return
 	}
@@ -544,3 +574,154 @@ func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 
 	response.Success(ctx, map[string]string{"message": "用户删除成功"})
 }
+
+// OffboardUser 用户离职处理
+// @Summary      用户离职处理
+// @Description  删除用户并处理其留下的引用：reassign_to 指定的用户接收内容的创建人、修改人等引用和用户作为 owner 的项目；未指定时引用置为 0，移除后没有其他 owner 的项目列在 projects_without_owner 中。同时删除用户的项目成员关系和个人访问令牌，用户的登录令牌随之失效。翻译历史和审计日志中的操作人默认保留，anonymize=true 时一并置为 0（GDPR 匿名化）。操作以 project_id 为 0 的审计日志记录，可通过 GET /admin/audit-logs 查看
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                      true  "用户ID"
+// @Param        request  body      dto.OffboardUserRequest  true  "离职处理选项"
+// @Success      200      {object}  domain.OffboardUserResult
+// @Failure      400      {object}  response.APIResponse
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /users/{id}/offboard [post]
+func (h *UserHandler) OffboardUser(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	var req dto.OffboardUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	params := domain.OffboardUserParams{
+		ReassignTo: req.ReassignTo,
+		Anonymize:  req.Anonymize,
+		Reason:     req.Reason,
+	}
+	result, err := h.userService.OffboardUser(ctx.Request.Context(), id, params)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			response.NotFound(ctx, "用户不存在")
+		case domain.ErrCannotDeleteAdmin:
+			response.Forbidden(ctx, "不能删除管理员用户")
+		case domain.ErrInvalidReassignTarget:
+			response.BadRequest(ctx, domain.ErrInvalidReassignTarget.Message)
+		default:
+			h.logger.Error("Failed to offboard user", zap.Uint64("user_id", id), zap.Error(err))
+			response.InternalServerError(ctx, "用户离职处理失败")
+		}
+		return
+	}
+
+	operatorID, _ := ctx.Get("userID")
+	h.logger.Info("User offboarded",
+		zap.Uint64("user_id", id),
+		zap.Uint64("reassigned_to", req.ReassignTo),
+		zap.Bool("anonymized", req.Anonymize),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
+
+// DeactivateUser 停用用户
+// @Summary      停用用户
+// @Description  将用户状态改为 disabled 并撤销其全部登录会话：用户不能再登录或刷新令牌，个人访问令牌被拒绝。用户、内容引用和项目成员关系都保留，通过 PUT /users/{id} 将 status 改回 active 即可恢复。不能停用管理员用户。操作以 project_id 为 0 的审计日志记录
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                        true   "用户ID"
+// @Param        request  body      dto.DeactivateUserRequest  false  "停用原因"
+// @Success      200      {object}  domain.User
+// @Failure      403      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	// 请求体可以为空
+	var req dto.DeactivateUserRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(ctx, err.Error())
+			return
+		}
+	}
+
+	user, err := h.userService.DeactivateUser(ctx.Request.Context(), id, domain.DeactivateUserParams{Reason: req.Reason})
+	if err != nil {
+		if !response.HandleError(ctx, err, "停用用户失败") {
+			h.logger.Error("Failed to deactivate user", zap.Uint64("user_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	operatorID, _ := ctx.Get("userID")
+	h.logger.Info("User deactivated",
+		zap.Uint64("user_id", id),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, user)
+}
+
+// TransferOwnership 转移用户所有权
+// @Summary      转移用户所有权
+// @Description  将用户作为 owner 的项目和作为邀请人的全部邀请码转给 to_user_id（必须是其他启用的用户）：接收人成为这些项目的 owner，原 owner 降为 editor。用于停用或删除用户之前，操作以 project_id 为 0 的审计日志记录
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                           true  "用户ID"
+// @Param        request  body      dto.TransferOwnershipRequest  true  "接收所有权的用户"
+// @Success      200      {object}  domain.OwnershipTransferResult
+// @Failure      400      {object}  response.APIResponse
+// @Failure      404      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /users/{id}/transfer-ownership [post]
+func (h *UserHandler) TransferOwnership(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	var req dto.TransferOwnershipRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	result, err := h.userService.TransferOwnership(ctx.Request.Context(), id, domain.TransferOwnershipParams{ToUserID: req.ToUserID})
+	if err != nil {
+		if !response.HandleError(ctx, err, "转移用户所有权失败") {
+			h.logger.Error("Failed to transfer user ownership", zap.Uint64("user_id", id), zap.Error(err))
+		}
+		return
+	}
+
+	operatorID, _ := ctx.Get("userID")
+	h.logger.Info("User ownership transferred",
+		zap.Uint64("user_id", id),
+		zap.Uint64("transferred_to", req.ToUserID),
+		zap.Int("projects", len(result.Projects)),
+		zap.Int64("invitations", result.InvitationsReassigned),
+		zap.Uint64("operator_id", operatorID.(uint64)),
+	)
+
+	response.Success(ctx, result)
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UserProfileHandler 个人资料处理器
+type UserProfileHandler struct {
+	profileService domain.UserProfileService
+	logger         *zap.Logger
+}
+
+// NewUserProfileHandler 创建个人资料处理器
+func NewUserProfileHandler(profileService domain.UserProfileService, logger *zap.Logger) *UserProfileHandler {
+	return &UserProfileHandler{
+		profileService: profileService,
+		logger:         logger,
+	}
+}
+
+// GetProfile 获取当前用户的个人资料
+// @Summary      获取个人资料
+// @Description  获取当前用户的显示名称、头像地址、界面语言和时区
+// @Tags         用户管理
+// @Produce      json
+// @Success      200  {object}  dto.UserProfileResponse
+// @Failure      401  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/profile [get]
+func (h *UserProfileHandler) GetProfile(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.profileService.GetProfile(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ctx, err, "获取个人资料失败")
+		return
+	}
+
+	response.Success(ctx, toUserProfileResponse(user))
+}
+
+// UpdateProfile 更新当前用户的个人资料
+// @Summary      更新个人资料
+// @Description  整体替换显示名称（最多 100 个字符）、界面语言（BCP 47 语言标签，保存为规范形式）和时区（IANA 时区），空值表示清除。显示名称和头像在项目成员列表和变更历史的操作人中展示
+// @Tags         用户管理
+// @Accept       json
+// @Produce      json
+// @Param        profile  body      dto.UpdateUserProfileRequest  true  "个人资料"
+// @Success      200      {object}  dto.UserProfileResponse
+// @Failure      400      {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/profile [put]
+func (h *UserProfileHandler) UpdateProfile(ctx *gin.Context) {
+	var req dto.UpdateUserProfileRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.profileService.UpdateProfile(ctx.Request.Context(), userID, domain.UpdateUserProfileParams{
+		DisplayName: req.DisplayName,
+		Language:    req.Language,
+		Timezone:    req.Timezone,
+	})
+	if err != nil {
+		h.handleError(ctx, err, "更新个人资料失败")
+		return
+	}
+
+	response.Success(ctx, toUserProfileResponse(user))
+}
+
+// UploadAvatar 上传当前用户的头像
+// @Summary      上传头像
+// @Description  请求体为 PNG、JPEG、GIF 或 WebP 图片（最大 1 MB），替换原有头像
+// @Tags         用户管理
+// @Accept       image/png
+// @Accept       image/jpeg
+// @Accept       image/gif
+// @Accept       image/webp
+// @Produce      json
+// @Success      200  {object}  dto.UserProfileResponse
+// @Failure      400  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /user/profile/avatar [put]
+func (h *UserProfileHandler) UploadAvatar(ctx *gin.Context) {
+	// 多读一个字节以便服务层识别超出大小限制的头像
+	data, err := io.ReadAll(io.LimitReader(ctx.Request.Body, service.MaxAvatarSize+1))
+	if err != nil {
+		response.BadRequest(ctx, "读取请求数据失败")
+		return
+	}
+
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.profileService.UploadAvatar(ctx.Request.Context(), userID, data)
+	if err != nil {
+		h.handleError(ctx, err, "上传头像失败")
+		return
+	}
+
+	response.Success(ctx, toUserProfileResponse(user))
+}
+
+// DeleteAvatar 删除当前用户的头像
+// @Summary      删除头像
+// @Tags         用户管理
+// @Produce      json
+// @Success      200  {object}  dto.UserProfileResponse
+// @Security     BearerAuth
+// @Router       /user/profile/avatar [delete]
+func (h *UserProfileHandler) DeleteAvatar(ctx *gin.Context) {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return
+	}
+
+	user, err := h.profileService.DeleteAvatar(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ctx, err, "删除头像失败")
+		return
+	}
+
+	response.Success(ctx, toUserProfileResponse(user))
+}
+
+// GetAvatar 获取用户头像
+// @Summary      获取用户头像
+// @Description  任何登录用户都可以获取，用于在成员列表和变更历史中展示
+// @Tags         用户管理
+// @Produce      image/png
+// @Produce      image/jpeg
+// @Produce      image/gif
+// @Produce      image/webp
+// @Param        id   path      int  true  "用户ID"
+// @Success      200  {file}    file
+// @Failure      404  {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /users/{id}/avatar [get]
+func (h *UserProfileHandler) GetAvatar(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的用户ID")
+		return
+	}
+
+	data, contentType, err := h.profileService.GetAvatar(ctx.Request.Context(), userID)
+	if err != nil {
+		h.handleError(ctx, err, "获取头像失败")
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+// handleError 将领域错误映射为HTTP响应
+func (h *UserProfileHandler) handleError(ctx *gin.Context, err error, fallback string) {
+	if !response.HandleError(ctx, err, fallback) {
+		h.logger.Error("User profile request failed", zap.Error(err))
+	}
+}
+
+// currentUserID 获取当前登录用户的ID，未登录时返回 401
+func currentUserID(ctx *gin.Context) (uint64, bool) {
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return 0, false
+	}
+	return userID.(uint64), true
+}
+
+// toUserProfileResponse 转换为响应格式
+func toUserProfileResponse(user *domain.User) dto.UserProfileResponse {
+	return dto.UserProfileResponse{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL(),
+		Language:    user.Language,
+		Timezone:    user.Timezone,
+	}
+}
@@ -0,0 +1,359 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+	"yflow/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WebhookHandler 出站 Webhook 处理器
+type WebhookHandler struct {
+	webhookService domain.WebhookService
+	logger         *zap.Logger
+}
+
+// NewWebhookHandler 创建出站 Webhook 处理器
+func NewWebhookHandler(webhookService domain.WebhookService, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// Create 创建出站 Webhook
+// @Summary      创建 Webhook
+// @Description  订阅的事件（translation.created、translation.updated、translation.deleted、import.completed、release.published）发生时向 URL 推送签名的 JSON 消息，消息体按 template（Go 模板）或 preset（generic、slack、teams）渲染，都未指定时使用 generic 模板。未指定事件时订阅全部事件，响应中包含仅返回一次的签名密钥
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                 true  "项目ID"
+// @Param        webhook     body      dto.WebhookRequest  true  "Webhook 配置"
+// @Success      201         {object}  dto.WebhookResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks [post]
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.WebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	webhook, err := h.webhookService.Create(ctx.Request.Context(), projectID, toWebhookParams(req), userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "创建 Webhook 失败")
+		return
+	}
+
+	h.logger.Info("Webhook created",
+		zap.Uint64("webhook_id", webhook.ID),
+		zap.Uint64("project_id", projectID),
+		zap.Uint64("operator_id", userID.(uint64)),
+	)
+
+	response.Created(ctx, toWebhookResponse(webhook, true))
+}
+
+// GetByProjectID 获取项目的出站 Webhook 列表
+// @Summary      获取 Webhook 列表
+// @Description  获取项目下配置的所有出站 Webhook
+// @Tags         Webhook
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Success      200         {object}  []dto.WebhookResponse
+// @Failure      400         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks [get]
+func (h *WebhookHandler) GetByProjectID(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	webhooks, err := h.webhookService.GetByProjectID(ctx.Request.Context(), projectID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取 Webhook 列表失败")
+		return
+	}
+
+	result := make([]*dto.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, toWebhookResponse(webhook, false))
+	}
+
+	response.Success(ctx, result)
+}
+
+// GetByID 获取出站 Webhook
+// @Summary      获取 Webhook
+// @Description  获取出站 Webhook 的配置，不包含签名密钥
+// @Tags         Webhook
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        webhook_id  path      int  true  "Webhook ID"
+// @Success      200         {object}  dto.WebhookResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks/{webhook_id} [get]
+func (h *WebhookHandler) GetByID(ctx *gin.Context) {
+	projectID, webhookID, ok := parseWebhookPath(ctx)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetByID(ctx.Request.Context(), projectID, webhookID)
+	if err != nil {
+		response.HandleError(ctx, err, "获取 Webhook 失败")
+		return
+	}
+
+	response.Success(ctx, toWebhookResponse(webhook, false))
+}
+
+// Update 更新出站 Webhook
+// @Summary      更新 Webhook
+// @Description  更新名称、地址、订阅的事件、消息模板或启用状态，未提供的字段保持不变，template 为空字符串时恢复为 generic 模板。停用后不再推送，尚未完成的重试也会停止
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                 true  "项目ID"
+// @Param        webhook_id  path      int                 true  "Webhook ID"
+// @Param        webhook     body      dto.WebhookRequest  true  "Webhook 配置"
+// @Success      200         {object}  dto.WebhookResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks/{webhook_id} [put]
+func (h *WebhookHandler) Update(ctx *gin.Context) {
+	projectID, webhookID, ok := parseWebhookPath(ctx)
+	if !ok {
+		return
+	}
+
+	var req dto.WebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	params := toWebhookParams(req)
+	if len(req.Events) == 0 {
+		// 更新时未提供事件表示不修改
+		params.Events = nil
+	}
+	webhook, err := h.webhookService.Update(ctx.Request.Context(), projectID, webhookID, params, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "更新 Webhook 失败")
+		return
+	}
+
+	response.Success(ctx, toWebhookResponse(webhook, false))
+}
+
+// Delete 删除出站 Webhook
+// @Summary      删除 Webhook
+// @Description  删除出站 Webhook 及其推送记录
+// @Tags         Webhook
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        webhook_id  path      int  true  "Webhook ID"
+// @Success      200         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks/{webhook_id} [delete]
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	projectID, webhookID, ok := parseWebhookPath(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.Delete(ctx.Request.Context(), projectID, webhookID); err != nil {
+		response.HandleError(ctx, err, "删除 Webhook 失败")
+		return
+	}
+
+	if operatorID, exists := ctx.Get("userID"); exists {
+		h.logger.Info("Webhook deleted",
+			zap.Uint64("webhook_id", webhookID),
+			zap.Uint64("project_id", projectID),
+			zap.Uint64("operator_id", operatorID.(uint64)),
+		)
+	}
+
+	response.Success(ctx, gin.H{"message": "删除成功"})
+}
+
+// RotateSecret 重置出站 Webhook 签名密钥
+// @Summary      重置 Webhook 签名密钥
+// @Description  生成新的签名密钥，之后的推送（包括尚未完成的重试）都使用新密钥签名
+// @Tags         Webhook
+// @Produce      json
+// @Param        project_id  path      int  true  "项目ID"
+// @Param        webhook_id  path      int  true  "Webhook ID"
+// @Success      200         {object}  dto.WebhookResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks/{webhook_id}/rotate-secret [post]
+func (h *WebhookHandler) RotateSecret(ctx *gin.Context) {
+	projectID, webhookID, ok := parseWebhookPath(ctx)
+	if !ok {
+		return
+	}
+
+	userID, exists := ctx.Get("userID")
+	if !exists {
+		response.Unauthorized(ctx, "未找到用户信息")
+		return
+	}
+
+	webhook, err := h.webhookService.RotateSecret(ctx.Request.Context(), projectID, webhookID, userID.(uint64))
+	if err != nil {
+		response.HandleError(ctx, err, "重置密钥失败")
+		return
+	}
+
+	response.Success(ctx, toWebhookResponse(webhook, true))
+}
+
+// GetDeliveries 获取出站 Webhook 的推送记录
+// @Summary      获取 Webhook 推送记录
+// @Description  分页获取推送记录，最新的在前，包括推送的请求体、尝试次数、最后一次响应状态码和错误。推送成功的记录保留 7 天
+// @Tags         Webhook
+// @Produce      json
+// @Param        project_id  path      int  true   "项目ID"
+// @Param        webhook_id  path      int  true   "Webhook ID"
+// @Param        page        query     int  false  "页码"      default(1)
+// @Param        page_size   query     int  false  "每页数量"  default(10)
+// @Success      200         {object}  dto.WebhookDeliveryListResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhooks/{webhook_id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(ctx *gin.Context) {
+	projectID, webhookID, ok := parseWebhookPath(ctx)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	offset := (page - 1) * pageSize
+
+	deliveries, total, err := h.webhookService.GetDeliveries(ctx.Request.Context(), projectID, webhookID, pageSize, offset)
+	if err != nil {
+		response.HandleError(ctx, err, "获取推送记录失败")
+		return
+	}
+
+	resp := dto.WebhookDeliveryListResponse{
+		Deliveries: make([]*dto.WebhookDeliveryResponse, 0, len(deliveries)),
+		Total:      total,
+	}
+	for _, delivery := range deliveries {
+		item := &dto.WebhookDeliveryResponse{
+			ID:             delivery.ID,
+			WebhookID:      delivery.WebhookID,
+			EventID:        delivery.EventID,
+			Event:          delivery.Event,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			LastError:      delivery.LastError,
+			CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+			Payload:        delivery.Payload,
+		}
+		if delivery.Status == domain.WebhookDeliveryPending {
+			item.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+		}
+		if delivery.DeliveredAt != nil {
+			item.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+		}
+		resp.Deliveries = append(resp.Deliveries, item)
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+	}
+
+	response.SuccessWithMeta(ctx, resp, meta)
+}
+
+// parseWebhookPath 解析项目ID和 Webhook ID 路径参数
+func parseWebhookPath(ctx *gin.Context) (uint64, uint64, bool) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return 0, 0, false
+	}
+	webhookID, err := strconv.ParseUint(ctx.Param("webhook_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的 Webhook ID")
+		return 0, 0, false
+	}
+	return projectID, webhookID, true
+}
+
+// toWebhookParams DTO -> Domain params
+func toWebhookParams(req dto.WebhookRequest) domain.WebhookParams {
+	return domain.WebhookParams{
+		Name:     req.Name,
+		URL:      req.URL,
+		Events:   req.Events,
+		Status:   req.Status,
+		Template: req.Template,
+		Preset:   req.Preset,
+	}
+}
+
+// toWebhookResponse 转换为响应格式，withSecret 为 true 时包含签名密钥
+func toWebhookResponse(webhook *domain.Webhook, withSecret bool) *dto.WebhookResponse {
+	resp := &dto.WebhookResponse{
+		ID:        webhook.ID,
+		ProjectID: webhook.ProjectID,
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Events:    service.ParseWebhookEvents(webhook.Events),
+		Status:    webhook.Status,
+		Template:  webhook.Template,
+		CreatedAt: webhook.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: webhook.UpdatedAt.Format(time.RFC3339),
+	}
+	if withSecret {
+		resp.Secret = webhook.Secret
+	}
+	return resp
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+	"yflow/internal/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookTemplateHandler Webhook 消息模板处理器
+type WebhookTemplateHandler struct {
+	templateService domain.WebhookTemplateService
+}
+
+// NewWebhookTemplateHandler 创建 Webhook 消息模板处理器
+func NewWebhookTemplateHandler(templateService domain.WebhookTemplateService) *WebhookTemplateHandler {
+	return &WebhookTemplateHandler{templateService: templateService}
+}
+
+// Preview 预览 Webhook 消息模板
+// @Summary      预览 Webhook 消息模板
+// @Description  用示例事件渲染消息模板，返回将要发送的消息体。模板使用 Go text/template 语法，可用数据：.EventID、.EventType、.OccurredAt、.Project（.ID、.Name、.Slug）、.Summary（事件的简短描述）、.Payload（事件内容）；可用函数：json、upper、lower、trim、join、truncate、default、formatTime。渲染结果必须是合法的 JSON，字符串值请使用 json 函数输出。未指定模板时依次使用内置模板 preset（generic、slack、teams）和 webhook_id 对应 Webhook 保存的模板，都未指定时使用 generic 模板
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        project_id  path      int                                true  "项目ID"
+// @Param        request     body      dto.WebhookTemplatePreviewRequest  true  "模板和示例事件"
+// @Success      200         {object}  dto.WebhookTemplatePreviewResponse
+// @Failure      400         {object}  response.APIResponse
+// @Failure      404         {object}  response.APIResponse
+// @Security     BearerAuth
+// @Router       /projects/{project_id}/webhook-templates/preview [post]
+func (h *WebhookTemplateHandler) Preview(ctx *gin.Context) {
+	projectID, err := strconv.ParseUint(ctx.Param("project_id"), 10, 64)
+	if err != nil {
+		response.ValidationError(ctx, "无效的项目ID")
+		return
+	}
+
+	var req dto.WebhookTemplatePreviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(ctx, err.Error())
+		return
+	}
+
+	preview, err := h.templateService.Preview(ctx.Request.Context(), projectID, domain.WebhookTemplatePreviewParams{
+		Template:  req.Template,
+		Preset:    req.Preset,
+		WebhookID: req.WebhookID,
+		EventType: domain.EventType(req.EventType),
+		Payload:   req.Payload,
+	})
+	if err != nil {
+		if appErr, ok := domain.IsAppError(err); ok {
+			switch appErr.Type {
+			case domain.ErrorTypeNotFound:
+				response.NotFound(ctx, appErr.Message)
+				return
+			case domain.ErrorTypeValidation:
+				response.BadRequestWithDetails(ctx, appErr.Message, appErr.Details)
+				return
+			}
+		}
+		response.InternalServerError(ctx, "预览 Webhook 消息模板失败")
+		return
+	}
+
+	response.Success(ctx, dto.WebhookTemplatePreviewResponse{
+		EventType: string(preview.EventType),
+		Body:      preview.Body,
+	})
+}
@@ -1,13 +1,20 @@
 package middleware
 
 import (
-	"yflow/internal/api/response"
 	"os"
+	"strconv"
+	"strings"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
 
+// apiKeyContextKey 上下文中保存通过认证的 API Key 的键
+const apiKeyContextKey = "apiKey"
+
 // APIKeyAuthMiddleware API Key认证中间件
+// 接受 CLI_API_KEY 和管理员创建的 API Key（yfk_ 开头）；CLI_API_KEY 可以访问所有项目，拥有读写权限
 func (f *MiddlewareFactory) APIKeyAuthMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		// 从环境变量获取API Key
@@ -25,6 +32,23 @@ func (f *MiddlewareFactory) APIKeyAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// 管理员创建的 API Key，权限范围由 API Key 决定
+		if f.apiKeyService != nil && strings.HasPrefix(apiKey, domain.APIKeyPrefix) {
+			key, err := f.apiKeyService.Authenticate(c.Request.Context(), apiKey, c.ClientIP())
+			if err != nil {
+				if _, ok := domain.IsAppError(err); !ok {
+					response.InternalServerError(c, "API Key 校验失败")
+				} else {
+					response.Unauthorized(c, "Invalid API Key")
+				}
+				c.Abort()
+				return
+			}
+			c.Set(apiKeyContextKey, key)
+			c.Next()
+			return
+		}
+
 		// 验证API Key
 		if apiKey != expectedAPIKey {
 			response.Unauthorized(c, "Invalid API Key")
@@ -35,4 +59,45 @@ func (f *MiddlewareFactory) APIKeyAuthMiddleware() gin.HandlerFunc {
 		// 验证通过，继续处理请求
 		c.Next()
 	})
-}
\ No newline at end of file
+}
+
+// RequireAPIKeyScope 返回检查 API Key 权限范围的中间件，param 为项目ID的查询参数名
+// 在响应缓存之前拒绝权限不足的 API Key；项目标识（slug）由处理器解析后再检查
+func (f *MiddlewareFactory) RequireAPIKeyScope(scope, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, _ := strconv.ParseUint(c.Query(param), 10, 64)
+		if err := AuthorizeAPIKey(c, projectID, scope); err != nil {
+			response.HandleError(c, err, "API Key 校验失败")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// APIKeyFromContext 获取请求使用的 API Key，使用 CLI_API_KEY 认证时返回 nil
+func APIKeyFromContext(c *gin.Context) *domain.APIKey {
+	if value, ok := c.Get(apiKeyContextKey); ok {
+		if key, ok := value.(*domain.APIKey); ok {
+			return key
+		}
+	}
+	return nil
+}
+
+// AuthorizeAPIKey 检查请求使用的 API Key 是否可以对项目执行 scope 范围的操作
+// projectID 为 0 时只检查权限范围；使用 CLI_API_KEY 认证时不受限制。
+// 组织的 API Key 只能访问该组织的项目，项目所属的组织取自 OrganizationScope 或 SetOrganizationScope 设置的当前组织
+func AuthorizeAPIKey(c *gin.Context, projectID uint64, scope string) error {
+	key := APIKeyFromContext(c)
+	if key == nil {
+		return nil
+	}
+	if !key.AllowsScope(scope) || (projectID != 0 && !key.AllowsProject(projectID)) {
+		return domain.ErrAPIKeyScopeDenied
+	}
+	if projectID != 0 && key.OrganizationID != 0 && domain.OrganizationFromContext(c.Request.Context()) != key.OrganizationID {
+		return domain.ErrAPIKeyScopeDenied
+	}
+	return nil
+}
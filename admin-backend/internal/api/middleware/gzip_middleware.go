@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GzipMiddleware 响应压缩中间件
+// 客户端声明支持 gzip 时压缩成功响应的响应体；304、204 等无响应体的响应不受影响。
+// 压缩后的表示与未压缩时不同，强 ETag 会被转换为弱 ETag，条件请求仍可正常匹配。
+func GzipMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Vary", "Accept-Encoding")
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+			c.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() {
+			writer.close()
+			c.Writer = writer.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// acceptsGzip 检查 Accept-Encoding 是否允许 gzip
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(fields[0]), "gzip") {
+			continue
+		}
+		// gzip;q=0 表示明确拒绝
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter 在首次写入响应体时决定是否压缩
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	gz      *gzip.Writer
+	decided bool
+}
+
+// Write 写入响应体
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.decide()
+	}
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.gz.Write(data)
+}
+
+// WriteString 写入字符串响应体
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush 刷新已压缩的数据
+func (w *gzipResponseWriter) Flush() {
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide 只压缩成功响应，且不重复压缩已编码的响应
+func (w *gzipResponseWriter) decide() {
+	w.decided = true
+	status := w.Status()
+	header := w.Header()
+	if status < http.StatusOK || status >= http.StatusMultipleChoices || status == http.StatusNoContent {
+		return
+	}
+	if header.Get("Content-Encoding") != "" {
+		return
+	}
+
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+}
+
+// close 写出 gzip 尾部
+func (w *gzipResponseWriter) close() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
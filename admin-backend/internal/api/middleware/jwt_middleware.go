@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"yflow/internal/api/response"
 	"yflow/internal/domain"
 	"strings"
@@ -8,9 +9,17 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// 认证方式常量，保存在上下文的 authMethod 中
+const (
+	AuthMethodJWT                 = "jwt"
+	AuthMethodPersonalAccessToken = "personal_access_token"
+)
+
 // JWTAuthMiddleware JWT鉴权中间件
 // 接受authService和userService作为参数，支持依赖注入
-func JWTAuthMiddleware(authService domain.AuthService, userService domain.UserService) gin.HandlerFunc {
+// 以 yfp_ 开头的 Bearer token 作为个人访问令牌校验，以令牌所属用户的身份和权限继续处理请求
+// sessionService 不为空时，JWT 所属的登录会话已撤销（会话黑名单）或已过期时拒绝请求
+func JWTAuthMiddleware(authService domain.AuthService, userService domain.UserService, tokenService domain.PersonalAccessTokenService, sessionService domain.SessionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从Authorization头获取token
 		authHeader := c.GetHeader("Authorization")
@@ -28,18 +37,53 @@ func JWTAuthMiddleware(authService domain.AuthService, userService domain.UserSe
 
 		// 验证token
 		tokenString := parts[1]
-		user, err := authService.ValidateToken(c.Request.Context(), tokenString)
-		if err != nil {
-			if strings.Contains(err.Error(), "expired") {
-				response.TokenExpired(c, "token已过期")
-			} else {
-				response.InvalidToken(c, "无效的token")
+		var userID uint64
+		if tokenService != nil && strings.HasPrefix(tokenString, domain.PersonalAccessTokenPrefix) {
+			token, err := tokenService.Authenticate(c.Request.Context(), tokenString, c.ClientIP())
+			if err != nil {
+				if err == domain.ErrAccessTokenExpired {
+					response.TokenExpired(c, "访问令牌已过期")
+				} else {
+					response.InvalidToken(c, "无效的访问令牌")
+				}
+				return
 			}
-			return
+			userID = token.UserID
+			c.Set("authMethod", AuthMethodPersonalAccessToken)
+			c.Set("accessTokenID", token.ID)
+		} else {
+			user, err := authService.ValidateToken(c.Request.Context(), tokenString)
+			if err != nil {
+				if strings.Contains(err.Error(), "expired") {
+					response.TokenExpired(c, "token已过期")
+				} else {
+					response.InvalidToken(c, "无效的token")
+				}
+				return
+			}
+			userID = user.ID
+			if sessionService != nil {
+				ctx := domain.WithClientInfo(c.Request.Context(), c.ClientIP(), c.Request.UserAgent())
+				sessionID, err := authService.SessionID(ctx, tokenString)
+				if err != nil {
+					response.InvalidToken(c, "无效的token")
+					return
+				}
+				if err := sessionService.Validate(ctx, userID, sessionID); err != nil {
+					if errors.Is(err, domain.ErrSessionRevoked) {
+						response.InvalidToken(c, domain.ErrSessionRevoked.Message)
+					} else {
+						response.InternalServerError(c, "登录会话校验失败")
+					}
+					return
+				}
+				c.Set("sessionID", sessionID)
+			}
+			c.Set("authMethod", AuthMethodJWT)
 		}
 
 		// 获取完整的用户信息以获取角色
-		fullUser, err := userService.GetUserInfo(c.Request.Context(), user.ID)
+		fullUser, err := userService.GetUserInfo(c.Request.Context(), userID)
 		if err != nil {
 			response.Unauthorized(c, "用户信息获取失败")
 			return
@@ -50,6 +94,8 @@ func JWTAuthMiddleware(authService domain.AuthService, userService domain.UserSe
 		c.Set("username", fullUser.Username)
 		c.Set("userRole", fullUser.Role)
 		c.Set("userStatus", fullUser.Status)
+		// 同时记录到请求 context 中，供仓储层记录翻译变更历史的操作人
+		c.Request = c.Request.WithContext(domain.WithActor(c.Request.Context(), fullUser.ID))
 
 		// 检查用户状态
 		if fullUser.Status != "active" {
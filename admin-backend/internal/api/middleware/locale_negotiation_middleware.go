@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// LocaleAuto 语言参数为该值时按 Accept-Language 协商语言
+	LocaleAuto = "auto"
+	// LocaleFallbackHeader 没有匹配的语言、使用默认语言时返回的响应头
+	LocaleFallbackHeader = "X-YFlow-Locale-Fallback"
+	// negotiatedLocaleKey gin 上下文中保存协商结果的键
+	negotiatedLocaleKey = "negotiatedLocale"
+)
+
+// localeAliases 中文脚本子标签与地区代码互为回退
+// 语言代码通常只使用其中一种写法（zh-CN 或 zh-Hans），回退时两种写法都尝试
+var localeAliases = map[string][]string{
+	"zh-hans": {"zh-cn", "zh-sg"},
+	"zh-hant": {"zh-tw", "zh-hk"},
+	"zh-cn":   {"zh-hans"},
+	"zh-sg":   {"zh-hans", "zh-cn"},
+	"zh-tw":   {"zh-hant"},
+	"zh-hk":   {"zh-hant", "zh-tw"},
+	"zh-mo":   {"zh-hant", "zh-hk", "zh-tw"},
+}
+
+// LocaleNegotiation 语言协商中间件
+// 查询参数 param 为 auto 时按 Accept-Language 从启用的语言中选择语言，处理器和后续中间件通过 NegotiatedLocale 获取；
+// 选择的语言在 Content-Language 响应头中返回，没有匹配的语言时使用默认语言并返回 X-YFlow-Locale-Fallback: true。
+// 参数为其他值的请求不受影响
+func LocaleNegotiation(languageService domain.LanguageService, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query(param) != LocaleAuto {
+			c.Next()
+			return
+		}
+
+		languages, err := languageService.GetAll(c.Request.Context())
+		if err != nil {
+			response.InternalServerError(c, "获取语言列表失败")
+			c.Abort()
+			return
+		}
+		var available []string
+		var defaultLocale string
+		for _, language := range languages {
+			if language.Status != "active" {
+				continue
+			}
+			available = append(available, language.Code)
+			if language.IsDefault {
+				defaultLocale = language.Code
+			}
+		}
+
+		locale, matched := NegotiateLocale(c.GetHeader("Accept-Language"), available, defaultLocale)
+		if locale == "" {
+			response.NotFound(c, "没有可用的语言")
+			c.Abort()
+			return
+		}
+		c.Set(negotiatedLocaleKey, locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Header("Content-Language", locale)
+		if !matched {
+			c.Header(LocaleFallbackHeader, "true")
+		}
+		c.Next()
+	}
+}
+
+// NegotiatedLocale 返回协商出的语言；没有进行协商时返回查询参数 param 的值
+func NegotiatedLocale(c *gin.Context, param string) string {
+	if locale := c.GetString(negotiatedLocaleKey); locale != "" {
+		return locale
+	}
+	return c.Query(param)
+}
+
+// SetRequestLocale 记录从路径等位置解析出的语言代码，之后 NegotiatedLocale 返回该语言（如用量统计）
+func SetRequestLocale(c *gin.Context, locale string) {
+	c.Set(negotiatedLocaleKey, locale)
+}
+
+// NegotiatedLocaleVariant 用作 ResponseCacheConfig.Variant，按协商出的语言区分缓存条目
+func NegotiatedLocaleVariant(c *gin.Context) string {
+	return c.GetString(negotiatedLocaleKey)
+}
+
+// NegotiateLocale 按 Accept-Language 从可用语言中选择最匹配的语言
+// 按 q 值从高到低依次尝试每个语言范围：先完全匹配（不区分大小写，_ 视为 -），然后按回退链逐级去掉最后一个子标签
+// （zh-Hant-TW → zh-Hant → zh，中文的脚本和地区写法互相回退），最后匹配主语言相同的其他语言（de-CH → de-DE），
+// 有多个时优先默认语言，其次按 available 的顺序。* 匹配默认语言。
+// 都不匹配时返回默认语言，matched 为 false
+func NegotiateLocale(acceptLanguage string, available []string, defaultLocale string) (locale string, matched bool) {
+	byTag := make(map[string]string, len(available))
+	for _, code := range available {
+		tag := normalizeLocaleTag(code)
+		if _, exists := byTag[tag]; !exists {
+			byTag[tag] = code
+		}
+	}
+
+	for _, requested := range parseAcceptLanguage(acceptLanguage) {
+		if requested == "*" {
+			if defaultLocale != "" {
+				return defaultLocale, true
+			}
+			continue
+		}
+		for _, candidate := range localeFallbackChain(requested) {
+			if code, ok := byTag[candidate]; ok {
+				return code, true
+			}
+		}
+		if code := samePrimaryLocale(requested, available, defaultLocale); code != "" {
+			return code, true
+		}
+	}
+	return defaultLocale, false
+}
+
+// parseAcceptLanguage 解析 Accept-Language，按 q 值从高到低返回规范化的语言范围，q=0 的语言范围不返回
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := normalizeLocaleTag(fields[0])
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+		if q > 0 {
+			tags = append(tags, weightedTag{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.tag
+	}
+	return result
+}
+
+// localeFallbackChain 返回语言范围的回退链：逐级去掉最后一个子标签，每一级之后尝试其别名
+func localeFallbackChain(tag string) []string {
+	var chain []string
+	for tag != "" {
+		chain = append(chain, tag)
+		chain = append(chain, localeAliases[tag]...)
+		index := strings.LastIndex(tag, "-")
+		if index < 0 {
+			break
+		}
+		tag = tag[:index]
+	}
+	return chain
+}
+
+// samePrimaryLocale 返回主语言与 tag 相同的可用语言，优先默认语言
+func samePrimaryLocale(tag string, available []string, defaultLocale string) string {
+	primary, _, _ := strings.Cut(tag, "-")
+	samePrimary := func(code string) bool {
+		codePrimary, _, _ := strings.Cut(normalizeLocaleTag(code), "-")
+		return codePrimary == primary
+	}
+	if defaultLocale != "" && samePrimary(defaultLocale) {
+		return defaultLocale
+	}
+	for _, code := range available {
+		if samePrimary(code) {
+			return code
+		}
+	}
+	return ""
+}
+
+// normalizeLocaleTag 将语言代码转换为小写、以连字符分隔的形式
+func normalizeLocaleTag(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
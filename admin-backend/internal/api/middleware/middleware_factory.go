@@ -12,6 +12,16 @@ type MiddlewareFactory struct {
 	authService          domain.AuthService
 	userService          domain.UserService
 	projectMemberService domain.ProjectMemberService
+	tokenService         domain.PersonalAccessTokenService
+	apiKeyService        domain.APIKeyService
+	sessionService       domain.SessionService
+	activityService      domain.ProjectActivityService
+	usageService         domain.UsageService
+	languageService      domain.LanguageService
+	translationRepo      domain.TranslationRepository
+	projectService       domain.ProjectService
+	organizationService  domain.OrganizationService
+	historyRepo          domain.TranslationHistoryRepository
 }
 
 // NewMiddlewareFactory 创建中间件工厂
@@ -19,17 +29,42 @@ func NewMiddlewareFactory(
 	authService domain.AuthService,
 	userService domain.UserService,
 	projectMemberService domain.ProjectMemberService,
+	tokenService domain.PersonalAccessTokenService,
+	apiKeyService domain.APIKeyService,
+	sessionService domain.SessionService,
+	activityService domain.ProjectActivityService,
+	usageService domain.UsageService,
+	languageService domain.LanguageService,
+	translationRepo domain.TranslationRepository,
+	projectService domain.ProjectService,
+	organizationService domain.OrganizationService,
+	historyRepo domain.TranslationHistoryRepository,
 ) *MiddlewareFactory {
 	return &MiddlewareFactory{
 		authService:          authService,
 		userService:          userService,
 		projectMemberService: projectMemberService,
+		tokenService:         tokenService,
+		apiKeyService:        apiKeyService,
+		sessionService:       sessionService,
+		activityService:      activityService,
+		usageService:         usageService,
+		languageService:      languageService,
+		translationRepo:      translationRepo,
+		projectService:       projectService,
+		organizationService:  organizationService,
+		historyRepo:          historyRepo,
 	}
 }
 
-// JWTAuthMiddleware 返回配置好的JWT认证中间件
+// JWTAuthMiddleware 返回配置好的JWT认证中间件（同时接受个人访问令牌）
 func (f *MiddlewareFactory) JWTAuthMiddleware() gin.HandlerFunc {
-	return JWTAuthMiddleware(f.authService, f.userService)
+	return JWTAuthMiddleware(f.authService, f.userService, f.tokenService, f.sessionService)
+}
+
+// RequireSessionAuth 返回要求通过登录会话（而非个人访问令牌）认证的中间件
+func (f *MiddlewareFactory) RequireSessionAuth() gin.HandlerFunc {
+	return RequireSessionAuth()
 }
 
 // RequireAdminRole 返回要求管理员角色的中间件
@@ -57,6 +92,71 @@ func (f *MiddlewareFactory) RequireProjectViewer() gin.HandlerFunc {
 	return RequireProjectViewer(f.projectMemberService)
 }
 
+// RequireTranslationViewer 返回要求对路由参数中的翻译所属项目有查看权限的中间件
+func (f *MiddlewareFactory) RequireTranslationViewer() gin.HandlerFunc {
+	return RequireTranslationPermission("viewer", f.projectMemberService, TranslationFromParam(f.translationRepo))
+}
+
+// RequireTranslationEditor 返回要求对路由参数中的翻译所属项目有编辑权限的中间件
+func (f *MiddlewareFactory) RequireTranslationEditor() gin.HandlerFunc {
+	return RequireTranslationPermission("editor", f.projectMemberService, TranslationFromParam(f.translationRepo))
+}
+
+// RequireDeletedTranslationEditor 返回要求对路由参数中回收站里的翻译所属项目有编辑权限的中间件
+func (f *MiddlewareFactory) RequireDeletedTranslationEditor() gin.HandlerFunc {
+	return RequireTranslationPermission("editor", f.projectMemberService, DeletedTranslationFromParam(f.translationRepo))
+}
+
+// RequireTranslationHistoryViewer 返回要求对路由参数中的变更历史所属项目有查看权限的中间件
+func (f *MiddlewareFactory) RequireTranslationHistoryViewer() gin.HandlerFunc {
+	return RequireTranslationPermission("viewer", f.projectMemberService, TranslationHistoryFromParam(f.historyRepo))
+}
+
+// RequireTranslationBodyEditor 返回要求对请求体中 project_id 指定的项目有编辑权限的中间件
+func (f *MiddlewareFactory) RequireTranslationBodyEditor() gin.HandlerFunc {
+	return RequireTranslationPermission("editor", f.projectMemberService, TranslationsFromBody)
+}
+
+// RequireTranslationIDsEditor 返回要求对请求体中的翻译所属的项目有编辑权限的中间件
+func (f *MiddlewareFactory) RequireTranslationIDsEditor() gin.HandlerFunc {
+	return RequireTranslationPermission("editor", f.projectMemberService, TranslationIDsFromBody(f.translationRepo))
+}
+
+// OrganizationScope 返回按请求访问的组织或项目设置当前组织的中间件
+func (f *MiddlewareFactory) OrganizationScope() gin.HandlerFunc {
+	return OrganizationScope(f.projectService, f.projectMemberService, f.organizationService)
+}
+
+// RequireOrganizationMember 返回要求是路由参数 org_id 指定的组织成员的中间件
+func (f *MiddlewareFactory) RequireOrganizationMember() gin.HandlerFunc {
+	return RequireOrganizationRole(domain.OrganizationRoleMember, f.organizationService)
+}
+
+// RequireOrganizationAdmin 返回要求是路由参数 org_id 指定的组织的 owner 或 admin 的中间件
+func (f *MiddlewareFactory) RequireOrganizationAdmin() gin.HandlerFunc {
+	return RequireOrganizationRole(domain.OrganizationRoleAdmin, f.organizationService)
+}
+
+// RequireScopedOrganizationAdmin 返回要求是当前组织的 owner 或 admin 的中间件，共享范围要求系统管理员
+func (f *MiddlewareFactory) RequireScopedOrganizationAdmin() gin.HandlerFunc {
+	return RequireScopedOrganizationRole(domain.OrganizationRoleAdmin, f.organizationService)
+}
+
+// TrackProjectAccess 返回记录项目访问的中间件，param 为项目ID的路由参数或查询参数名
+func (f *MiddlewareFactory) TrackProjectAccess(param string) gin.HandlerFunc {
+	return TrackProjectAccess(f.activityService, param)
+}
+
+// TrackUsage 返回记录翻译拉取用量的中间件，localeParam 为语言代码的查询参数名
+func (f *MiddlewareFactory) TrackUsage(channel, param, localeParam string) gin.HandlerFunc {
+	return TrackUsage(f.usageService, channel, param, localeParam)
+}
+
+// LocaleNegotiation 返回按 Accept-Language 协商语言的中间件，param 为语言代码的查询参数名
+func (f *MiddlewareFactory) LocaleNegotiation(param string) gin.HandlerFunc {
+	return LocaleNegotiation(f.languageService, param)
+}
+
 // RequireSelfOrAdmin 返回要求是本人或管理员的中间件
 func (f *MiddlewareFactory) RequireSelfOrAdmin() gin.HandlerFunc {
 	return RequireSelfOrAdmin()
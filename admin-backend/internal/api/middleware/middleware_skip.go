@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -10,6 +12,40 @@ func IsSwaggerPath(c *gin.Context) bool {
 	return len(path) >= 8 && path[:8] == "/swagger"
 }
 
+// UnversionedPath 去掉请求路径中的 API 版本号，如 /api/v1/login 返回 /api/login，
+// 使按路径判断的中间件对各 API 版本和未带版本号的别名一致处理
+func UnversionedPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/v")
+	if !ok {
+		return path
+	}
+	i := 0
+	for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+		i++
+	}
+	if i == 0 || i < len(rest) && rest[i] != '/' {
+		return path
+	}
+	return "/api" + rest[i:]
+}
+
+// IsInboundWebhookPath 检查请求路径是否为入站 Webhook 推送地址
+func IsInboundWebhookPath(c *gin.Context) bool {
+	return strings.HasPrefix(UnversionedPath(c.Request.URL.Path), "/api/webhooks/inbound/")
+}
+
+// SkipForInboundWebhook 创建一个跳过入站 Webhook 路径的中间件包装器
+// 入站请求需要基于原始请求体校验签名，不能被重新序列化
+func SkipForInboundWebhook(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsInboundWebhookPath(c) {
+			c.Next()
+			return
+		}
+		handler(c)
+	}
+}
+
 // SkipForSwagger 创建一个跳过 Swagger 路径的中间件包装器
 func SkipForSwagger(handler gin.HandlerFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
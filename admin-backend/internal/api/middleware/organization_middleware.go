@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationScope 按请求访问的组织或项目设置当前组织，语言和 API Key 的读写限定在该组织内
+// 组织ID取路由参数或查询参数 org_id；否则按路由参数或查询参数 project_id（数字ID或项目标识）取项目所属的组织。
+// 登录用户需要是组织成员或项目成员（系统管理员除外），否则返回 403，不会使用所访问项目的组织；
+// API Key 请求不在此检查，由 RequireAPIKeyScope 检查 Key 所属的组织。
+// 项目不存在时不设置，由处理器返回错误；请求体中指定项目的处理器需要自行调用 SetOrganizationScope
+func OrganizationScope(projectService domain.ProjectService, projectMemberService domain.ProjectMemberService, organizationService domain.OrganizationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, authenticated := c.Get("userID")
+
+		rawOrganizationID := c.Param("org_id")
+		if rawOrganizationID == "" {
+			rawOrganizationID = c.Query("org_id")
+		}
+		if organizationID, err := strconv.ParseUint(rawOrganizationID, 10, 64); err == nil {
+			if authenticated {
+				allowed, err := organizationService.CheckRole(c.Request.Context(), organizationID, userID.(uint64), domain.OrganizationRoleMember)
+				if !checkOrganizationScope(c, allowed, err) {
+					return
+				}
+			}
+			SetOrganizationScope(c, organizationID)
+			c.Next()
+			return
+		}
+
+		identifier := c.Param("project_id")
+		if identifier == "" {
+			identifier = c.Query("project_id")
+		}
+		if identifier != "" && projectService != nil {
+			if project, err := projectService.GetByIdentifier(c.Request.Context(), identifier); err == nil {
+				// 共享范围的语言对所有登录用户可见，不需要检查
+				if authenticated && project.OrganizationID != 0 {
+					allowed, err := canAccessProjectOrganization(c, project, userID.(uint64), projectMemberService, organizationService)
+					if !checkOrganizationScope(c, allowed, err) {
+						return
+					}
+				}
+				SetOrganizationScope(c, project.OrganizationID)
+			}
+		}
+		c.Next()
+	}
+}
+
+// canAccessProjectOrganization 检查用户是否是项目成员或项目所属组织的成员
+func canAccessProjectOrganization(c *gin.Context, project *domain.Project, userID uint64, projectMemberService domain.ProjectMemberService, organizationService domain.OrganizationService) (bool, error) {
+	if projectMemberService != nil {
+		allowed, err := projectMemberService.CheckPermission(c.Request.Context(), userID, project.ID, "viewer")
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+	return organizationService.CheckRole(c.Request.Context(), project.OrganizationID, userID, domain.OrganizationRoleMember)
+}
+
+// checkOrganizationScope 处理组织范围的权限检查结果，不允许时返回错误响应并中止请求
+func checkOrganizationScope(c *gin.Context, allowed bool, err error) bool {
+	if err != nil {
+		response.HandleError(c, err, "权限检查失败")
+		c.Abort()
+		return false
+	}
+	if !allowed {
+		response.Forbidden(c, "无权访问该组织")
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// SetOrganizationScope 设置请求的当前组织
+func SetOrganizationScope(c *gin.Context, organizationID uint64) {
+	c.Set("organizationID", organizationID)
+	c.Request = c.Request.WithContext(domain.WithOrganization(c.Request.Context(), organizationID))
+}
+
+// RequireOrganizationRole 要求用户在路由参数 org_id 指定的组织中的角色不低于 requiredRole
+// 系统管理员拥有所有组织的权限
+func RequireOrganizationRole(requiredRole string, organizationService domain.OrganizationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, exists := ctx.Get("userID")
+		if !exists {
+			response.Unauthorized(ctx, "用户未登录")
+			ctx.Abort()
+			return
+		}
+
+		organizationID, err := strconv.ParseUint(ctx.Param("org_id"), 10, 64)
+		if err != nil {
+			response.ValidationError(ctx, "无效的组织ID")
+			ctx.Abort()
+			return
+		}
+
+		allowed, err := organizationService.CheckRole(ctx.Request.Context(), organizationID, userID.(uint64), requiredRole)
+		if err != nil {
+			response.HandleError(ctx, err, "权限检查失败")
+			ctx.Abort()
+			return
+		}
+		if !allowed {
+			response.Forbidden(ctx, "组织权限不足")
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequireScopedOrganizationRole 要求用户在 OrganizationScope 限定的当前组织中的角色不低于 requiredRole
+// 未限定组织时为共享范围，要求系统管理员
+func RequireScopedOrganizationRole(requiredRole string, organizationService domain.OrganizationService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, exists := ctx.Get("userID")
+		if !exists {
+			response.Unauthorized(ctx, "用户未登录")
+			ctx.Abort()
+			return
+		}
+
+		organizationID := domain.OrganizationFromContext(ctx.Request.Context())
+		if organizationID == 0 {
+			if role, _ := ctx.Get("userRole"); role != "admin" {
+				response.Forbidden(ctx, "权限不足")
+				ctx.Abort()
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		allowed, err := organizationService.CheckRole(ctx.Request.Context(), organizationID, userID.(uint64), requiredRole)
+		if err != nil {
+			response.HandleError(ctx, err, "权限检查失败")
+			ctx.Abort()
+			return
+		}
+		if !allowed {
+			response.Forbidden(ctx, "组织权限不足")
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -36,6 +36,20 @@ func RequireAdminRole() gin.HandlerFunc {
 	return RequireRole("admin")
 }
 
+// RequireSessionAuth 要求通过登录会话认证
+// 个人访问令牌不能用于管理令牌本身，避免令牌泄露后被用来签发新的令牌
+func RequireSessionAuth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if method, _ := ctx.Get("authMethod"); method == AuthMethodPersonalAccessToken {
+			response.Forbidden(ctx, "个人访问令牌不能执行此操作，请登录后操作")
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
 // RequireProjectPermission 要求项目权限
 func RequireProjectPermission(requiredRole string, projectMemberService domain.ProjectMemberService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -81,26 +95,31 @@ func RequireProjectPermission(requiredRole string, projectMemberService domain.P
 		}
 
 		// 检查项目权限
-		hasPermission, err := projectMemberService.CheckPermission(
-			ctx.Request.Context(),
-			userID.(uint64),
-			uint64(projectID),
-			requiredRole,
-		)
+		if !checkProjectPermissions(ctx, projectMemberService, userID.(uint64), requiredRole, uint64(projectID)) {
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// checkProjectPermissions 检查用户在每个项目中都有指定权限，没有权限时写入错误响应并中止请求
+func checkProjectPermissions(ctx *gin.Context, projectMemberService domain.ProjectMemberService, userID uint64, requiredRole string, projectIDs ...uint64) bool {
+	for _, projectID := range projectIDs {
+		hasPermission, err := projectMemberService.CheckPermission(ctx.Request.Context(), userID, projectID, requiredRole)
 		if err != nil {
 			response.InternalServerError(ctx, "权限检查失败")
 			ctx.Abort()
-			return
+			return false
 		}
 
 		if !hasPermission {
 			response.Forbidden(ctx, "项目权限不足")
 			ctx.Abort()
-			return
+			return false
 		}
-
-		ctx.Next()
 	}
+	return true
 }
 
 // RequireProjectOwner 要求项目所有者权限
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrackProjectAccess 请求成功后记录项目访问，用于闲置项目报告
+// 项目ID依次取处理器设置的 projectID、路由参数 param 和查询参数 param（查询参数只识别数字ID），
+// 未访问具体项目的请求不记录
+func TrackProjectAccess(recorder domain.ProjectActivityService, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if recorder == nil || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		if projectID := accessedProjectID(c, param); projectID != 0 {
+			recorder.RecordAccess(projectID)
+		}
+	}
+}
+
+// accessedProjectID 解析请求访问的项目ID，无法确定时返回 0
+func accessedProjectID(c *gin.Context, param string) uint64 {
+	if projectID := c.GetUint64("projectID"); projectID != 0 {
+		return projectID
+	}
+
+	value := c.Param(param)
+	if value == "" {
+		value = c.Query(param)
+	}
+	projectID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return projectID
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestCacheMiddleware 请求级缓存中间件
+// 为每个请求安装独立的 domain.RequestCache，处理器和服务通过 ctx.Request.Context() 访问仓储时，
+// 语言列表和项目在同一请求内只查询一次数据库
+func RequestCacheMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(domain.WithRequestCache(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"bytes"
 	"crypto/rand"
 	"encoding/hex"
 	"time"
@@ -10,16 +9,15 @@ import (
 )
 
 // responseWriter 包装响应写入器以获取状态码和响应大小
+// 不保存响应体，事件流等长连接的内存占用不随响应增长
 type ResponseWriter struct {
 	gin.ResponseWriter
-	body       *bytes.Buffer
 	statusCode int
 	size       int
 }
 
 func (w *ResponseWriter) Write(b []byte) (int, error) {
 	size, err := w.ResponseWriter.Write(b)
-	w.body.Write(b)
 	w.size += size
 	return size, err
 }
@@ -55,6 +53,7 @@ func ShouldLogRequestBody(path string) bool {
 		"/api/register",
 	}
 
+	path = UnversionedPath(path)
 	for _, endpoint := range sensitiveEndpoints {
 		if path == endpoint {
 			return false
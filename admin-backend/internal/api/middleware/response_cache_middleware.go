@@ -0,0 +1,247 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseCacheConfig HTTP 响应缓存配置
+type ResponseCacheConfig struct {
+	// Scope 返回缓存作用域，用于按项目或资源失效；返回空字符串时不缓存
+	Scope func(c *gin.Context) string
+	// TTL Redis 中缓存条目的过期时间
+	TTL time.Duration
+	// MaxAge 客户端可直接复用响应的秒数，0 表示每次都需要重新验证
+	MaxAge int
+	// Private 是否禁止共享缓存（CDN、代理）存储，需要认证的接口应设置为 true
+	Private bool
+	// Variant 返回追加到缓存键的变体，用于 URL 相同但由请求头决定内容的响应（如按 Accept-Language 协商语言），可以为空
+	Variant func(c *gin.Context) string
+}
+
+// cachedResponse Redis 中保存的响应
+type cachedResponse struct {
+	Body        []byte `json:"body"`
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+}
+
+// StaticResponseScope 返回固定作用域
+func StaticResponseScope(scope string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		return scope
+	}
+}
+
+// ProjectQueryResponseScope 根据查询参数中的项目ID确定作用域
+// 参数为项目标识（slug）时不缓存，因为无法在不查询数据库的情况下确定所属项目
+func ProjectQueryResponseScope(param string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		projectID, err := strconv.ParseUint(c.Query(param), 10, 64)
+		if err != nil || projectID == 0 {
+			return ""
+		}
+		return domain.ProjectResponseScope(projectID)
+	}
+}
+
+// ResponseCacheMiddleware HTTP 响应缓存中间件
+// 为 GET 请求设置 Cache-Control 和 ETag，从 Redis 返回缓存的响应并处理 If-None-Match 条件请求。
+// 缓存条目由各服务的缓存失效逻辑按作用域清除。
+func ResponseCacheMiddleware(cacheService domain.CacheService, config ResponseCacheConfig) gin.HandlerFunc {
+	if config.TTL <= 0 {
+		config.TTL = domain.DefaultExpiration
+	}
+	cacheControl := buildCacheControl(config)
+
+	return func(c *gin.Context) {
+		if cacheService == nil || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		scope := config.Scope(c)
+		if scope == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		cacheKey := domain.CacheKeys.Response(scope) + responseCacheKeySuffix(c.Request.URL)
+		if config.Variant != nil {
+			if variant := config.Variant(c); variant != "" {
+				cacheKey += ":" + variant
+			}
+		}
+
+		// 命中缓存时直接返回
+		var cached cachedResponse
+		if err := cacheService.GetJSON(ctx, cacheKey, &cached); err == nil && cached.ETag != "" {
+			writeCachedResponse(c, &cached, cacheControl)
+			return
+		}
+
+		// 缓冲响应，以便在写出前设置 ETag
+		writer := &bufferedResponseWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if writer.status != http.StatusOK {
+			writer.flush()
+			return
+		}
+
+		cached = cachedResponse{
+			Body:        writer.body.Bytes(),
+			ContentType: writer.Header().Get("Content-Type"),
+			ETag:        computeETag(writer.body.Bytes()),
+		}
+		storeCachedResponse(ctx, cacheService, cacheKey, &cached, config.TTL)
+		writeCachedResponse(c, &cached, cacheControl)
+	}
+}
+
+// writeCachedResponse 写出响应，客户端 ETag 匹配时返回 304
+func writeCachedResponse(c *gin.Context, cached *cachedResponse, cacheControl string) {
+	c.Header("ETag", cached.ETag)
+	c.Header("Cache-Control", cacheControl)
+
+	if etagMatches(c.GetHeader("If-None-Match"), cached.ETag) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, cached.ContentType, cached.Body)
+	c.Abort()
+}
+
+// WriteETagResponse 写出带 ETag 的公开响应，客户端 ETag 匹配时返回 304
+// 用于自行计算和缓存 ETag 的处理器（如公开分发接口），maxAge 的含义与 ResponseCacheConfig.MaxAge 相同
+func WriteETagResponse(c *gin.Context, etag, contentType string, body []byte, maxAge int) {
+	cached := &cachedResponse{Body: body, ContentType: contentType, ETag: etag}
+	writeCachedResponse(c, cached, buildCacheControl(ResponseCacheConfig{MaxAge: maxAge}))
+}
+
+// storeCachedResponse 保存响应到缓存，失败时不影响本次请求
+func storeCachedResponse(ctx context.Context, cacheService domain.CacheService, key string, cached *cachedResponse, ttl time.Duration) {
+	_ = cacheService.SetJSON(ctx, key, cached, ttl)
+}
+
+// buildCacheControl 生成 Cache-Control 头
+func buildCacheControl(config ResponseCacheConfig) string {
+	visibility := "public"
+	if config.Private {
+		visibility = "private"
+	}
+	if config.MaxAge <= 0 {
+		return visibility + ", no-cache"
+	}
+	return fmt.Sprintf("%s, max-age=%d", visibility, config.MaxAge)
+}
+
+// responseCacheKeySuffix 按路径和排序后的查询参数生成缓存键后缀
+func responseCacheKeySuffix(u *url.URL) string {
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(u.Path)
+	for i, k := range keys {
+		if i == 0 {
+			b.WriteString("?")
+		} else {
+			b.WriteString("&")
+		}
+		values := query[k]
+		sort.Strings(values)
+		b.WriteString(url.QueryEscape(k))
+		b.WriteString("=")
+		b.WriteString(url.QueryEscape(strings.Join(values, ",")))
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+// computeETag 根据响应体计算强 ETag
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches 检查 If-None-Match 是否包含指定 ETag
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter 缓冲处理器写出的响应
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+// WriteHeader 记录状态码，延迟到 flush 时写出
+func (w *bufferedResponseWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+// WriteHeaderNow 延迟到 flush 时写出
+func (w *bufferedResponseWriter) WriteHeaderNow() {}
+
+// Write 写入缓冲区
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+// WriteString 写入缓冲区
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// Status 返回记录的状态码
+func (w *bufferedResponseWriter) Status() int {
+	return w.status
+}
+
+// Size 返回已缓冲的字节数
+func (w *bufferedResponseWriter) Size() int {
+	return w.body.Len()
+}
+
+// Written 响应在 flush 前不会真正写出
+func (w *bufferedResponseWriter) Written() bool {
+	return false
+}
+
+// flush 将缓冲的响应原样写出（用于不缓存的响应）
+func (w *bufferedResponseWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -55,9 +55,9 @@ func SecurityHeadersMiddleware() gin.HandlerFunc {
 		c.Header("X-Powered-By", "")
 
 		// 防止缓存敏感信息
-		if c.Request.URL.Path == "/api/login" ||
-			c.Request.URL.Path == "/api/refresh" ||
-			c.Request.URL.Path == "/api/user/info" {
+		if path := UnversionedPath(c.Request.URL.Path); path == "/api/login" ||
+			path == "/api/refresh" ||
+			path == "/api/user/info" {
 			c.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
 			c.Header("Pragma", "no-cache")
 			c.Header("Expires", "0")
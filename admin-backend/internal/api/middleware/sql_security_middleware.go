@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"yflow/internal/api/response"
+	"yflow/internal/domain"
 	log_utils "yflow/utils"
 	"regexp"
 	"strings"
@@ -173,26 +175,48 @@ func containsForbiddenKeywords(input string, keywords []string) bool {
 
 // isAllowedSortField 检查是否为允许的排序字段
 func isAllowedSortField(field string, allowedFields []string) bool {
-	// 处理带方向的排序字段 (例如: "name DESC", "id ASC")
-	parts := strings.Fields(strings.ToLower(field))
-	if len(parts) > 2 {
-		return false
-	}
+	_, err := ParseSortFields(field, allowedFields)
+	return err == nil
+}
 
-	fieldName := parts[0]
+// ParseSortFields 解析排序参数，多个字段用逗号分隔，方向用冒号或空格指定（例如: "updated_at:desc,id"、"name DESC"）
+// 字段名必须在 allowedFields 中，未指定方向时为升序
+func ParseSortFields(value string, allowedFields []string) ([]domain.SortField, error) {
+	var fields []domain.SortField
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Fields(strings.ReplaceAll(strings.ToLower(item), ":", " "))
+		if len(parts) == 0 || len(parts) > 2 {
+			return nil, fmt.Errorf("无效的排序参数: %s", item)
+		}
 
-	// 检查字段名是否在白名单中
-	for _, allowed := range allowedFields {
-		if fieldName == strings.ToLower(allowed) {
-			// 如果有排序方向，验证是否为 ASC 或 DESC
-			if len(parts) == 2 {
-				direction := parts[1]
-				return direction == "asc" || direction == "desc"
+		field := domain.SortField{Field: parts[0]}
+		if len(parts) == 2 {
+			switch parts[1] {
+			case "asc":
+			case "desc":
+				field.Desc = true
+			default:
+				return nil, fmt.Errorf("无效的排序方向: %s", parts[1])
 			}
-			return true
 		}
+		if !containsFold(allowedFields, field.Field) {
+			return nil, fmt.Errorf("不允许的排序字段: %s", field.Field)
+		}
+		fields = append(fields, field)
 	}
+	if len(fields) == 0 {
+		return nil, errors.New("排序参数为空")
+	}
+	return fields, nil
+}
 
+// containsFold 检查 list 中是否有与 s 忽略大小写相等的元素
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TranslationProjectResolver 解析请求涉及的翻译所属的项目ID
+// 请求格式不正确时返回空列表，交给处理器返回参数错误
+type TranslationProjectResolver func(ctx *gin.Context) ([]uint64, error)
+
+// RequireTranslationPermission 要求对请求涉及的每个项目都有指定权限
+// 翻译接口按翻译ID或请求体中的项目ID操作，不能像项目路由那样直接读取 project_id 路由参数
+func RequireTranslationPermission(requiredRole string, projectMemberService domain.ProjectMemberService, resolve TranslationProjectResolver) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userID, exists := ctx.Get("userID")
+		if !exists {
+			response.Unauthorized(ctx, "用户未登录")
+			ctx.Abort()
+			return
+		}
+
+		userRole, exists := ctx.Get("userRole")
+		if !exists {
+			response.Forbidden(ctx, "无法获取用户角色信息")
+			ctx.Abort()
+			return
+		}
+
+		// 管理员拥有所有权限，不需要查询翻译所属的项目
+		if userRole.(string) == "admin" {
+			ctx.Next()
+			return
+		}
+
+		projectIDs, err := resolve(ctx)
+		if err != nil {
+			response.HandleError(ctx, err, "权限检查失败")
+			ctx.Abort()
+			return
+		}
+
+		if !checkProjectPermissions(ctx, projectMemberService, userID.(uint64), requiredRole, projectIDs...) {
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// TranslationFromParam 按路由参数 id 查找翻译所属的项目
+func TranslationFromParam(translationRepo domain.TranslationRepository) TranslationProjectResolver {
+	return translationFromParam(func(ctx context.Context, id uint64) (*domain.Translation, error) {
+		return translationRepo.GetByID(ctx, id)
+	})
+}
+
+// DeletedTranslationFromParam 按路由参数 id 查找回收站中的翻译所属的项目
+func DeletedTranslationFromParam(translationRepo domain.TranslationRepository) TranslationProjectResolver {
+	return translationFromParam(func(ctx context.Context, id uint64) (*domain.Translation, error) {
+		return translationRepo.GetDeletedByID(ctx, id)
+	})
+}
+
+// translationFromParam 按路由参数 id 用 lookup 查找翻译所属的项目
+func translationFromParam(lookup func(ctx context.Context, id uint64) (*domain.Translation, error)) TranslationProjectResolver {
+	return func(ctx *gin.Context) ([]uint64, error) {
+		translationID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+		if err != nil {
+			return nil, nil
+		}
+
+		translation, err := lookup(ctx.Request.Context(), translationID)
+		if err != nil {
+			return nil, err
+		}
+		return []uint64{translation.ProjectID}, nil
+	}
+}
+
+// TranslationHistoryFromParam 按路由参数 id 查找变更历史所属的项目
+func TranslationHistoryFromParam(historyRepo domain.TranslationHistoryRepository) TranslationProjectResolver {
+	return func(ctx *gin.Context) ([]uint64, error) {
+		historyID, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+		if err != nil {
+			return nil, nil
+		}
+
+		entry, err := historyRepo.GetByID(ctx.Request.Context(), historyID)
+		if err != nil {
+			return nil, err
+		}
+		return []uint64{entry.ProjectID}, nil
+	}
+}
+
+// TranslationsFromBody 按请求体中的 project_id 解析项目，请求体可以是单个对象或对象数组
+func TranslationsFromBody(ctx *gin.Context) ([]uint64, error) {
+	body, err := peekRequestBody(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type projectRef struct {
+		ProjectID uint64 `json:"project_id"`
+	}
+	var refs []projectRef
+	if err := json.Unmarshal(body, &refs); err != nil {
+		var ref projectRef
+		if err := json.Unmarshal(body, &ref); err != nil {
+			return nil, nil
+		}
+		refs = []projectRef{ref}
+	}
+
+	projectIDs := make([]uint64, 0, len(refs))
+	for _, ref := range refs {
+		projectIDs = append(projectIDs, ref.ProjectID)
+	}
+	return uniqueProjectIDs(projectIDs), nil
+}
+
+// TranslationIDsFromBody 按请求体中的翻译ID列表查找翻译所属的项目，不存在的翻译不参与检查
+func TranslationIDsFromBody(translationRepo domain.TranslationRepository) TranslationProjectResolver {
+	return func(ctx *gin.Context) ([]uint64, error) {
+		body, err := peekRequestBody(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var ids []uint64
+		if err := json.Unmarshal(body, &ids); err != nil || len(ids) == 0 {
+			return nil, nil
+		}
+
+		translations, err := translationRepo.GetByIDs(ctx.Request.Context(), ids)
+		if err != nil {
+			return nil, err
+		}
+		projectIDs := make([]uint64, 0, len(translations))
+		for _, translation := range translations {
+			projectIDs = append(projectIDs, translation.ProjectID)
+		}
+		return uniqueProjectIDs(projectIDs), nil
+	}
+}
+
+// peekRequestBody 读取请求体后放回，处理器仍可以正常绑定
+func peekRequestBody(ctx *gin.Context) ([]byte, error) {
+	if ctx.Request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+	return body, nil
+}
+
+// uniqueProjectIDs 去掉重复和为零的项目ID，保持原有顺序
+func uniqueProjectIDs(projectIDs []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(projectIDs))
+	unique := make([]uint64, 0, len(projectIDs))
+	for _, projectID := range projectIDs {
+		if projectID == 0 || seen[projectID] {
+			continue
+		}
+		seen[projectID] = true
+		unique = append(unique, projectID)
+	}
+	return unique
+}
@@ -41,7 +41,6 @@ func LoggingMiddleware(logger *zap.Logger, opts ...LoggingOptions) gin.HandlerFu
 		// 包装响应写入器
 		rw := &ResponseWriter{
 			ResponseWriter: c.Writer,
-			body:           bytes.NewBufferString(""),
 			statusCode:     200,
 		}
 		c.Writer = rw
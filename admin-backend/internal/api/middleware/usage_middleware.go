@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// usageClientHeader 调用方自报的客户端名称（如 web-app、ios-app），追加到调用方标识后
+const usageClientHeader = "X-YFlow-Client"
+
+// TrackUsage 请求成功后记录一次翻译拉取，用于用量统计
+// channel 为拉取渠道，param 为项目ID参数名（解析方式与 TrackProjectAccess 相同），localeParam 为语言代码的查询参数名，
+// 按 Accept-Language 协商语言时记录协商出的语言
+func TrackUsage(recorder domain.UsageService, channel, param, localeParam string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if recorder == nil || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		projectID := accessedProjectID(c, param)
+		if projectID == 0 {
+			return
+		}
+		recorder.Record(c.Request.Context(), domain.UsageRecord{
+			ProjectID: projectID,
+			Channel:   channel,
+			Locale:    NegotiatedLocale(c, localeParam),
+			Consumer:  usageConsumer(c),
+		})
+	}
+}
+
+// usageConsumer 识别调用方：个人访问令牌、API Key（只保留哈希前缀）或登录用户
+func usageConsumer(c *gin.Context) string {
+	var consumer string
+	if tokenID := c.GetUint64("accessTokenID"); tokenID != 0 {
+		consumer = "token:" + strconv.FormatUint(tokenID, 10)
+	} else if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		sum := sha256.Sum256([]byte(apiKey))
+		consumer = "api_key:" + hex.EncodeToString(sum[:])[:12]
+	} else if userID := c.GetUint64("userID"); userID != 0 {
+		consumer = "user:" + strconv.FormatUint(userID, 10)
+	} else {
+		consumer = "anonymous"
+	}
+	if client := strings.TrimSpace(c.GetHeader(usageClientHeader)); client != "" {
+		consumer += "/" + client
+	}
+	return consumer
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"fmt"
+	"mime"
 	"yflow/internal/api/response"
 	"yflow/utils"
 	"net/http"
@@ -16,7 +17,7 @@ func RequestValidationMiddleware() gin.HandlerFunc {
 		// 检查Content-Type（对于POST、PUT请求）
 		if c.Request.Method == http.MethodPost || c.Request.Method == http.MethodPut {
 			contentType := c.GetHeader("Content-Type")
-			if contentType != "" && contentType != "application/json" && contentType != "multipart/form-data" {
+			if contentType != "" && !isAllowedContentType(contentType) {
 				response.BadRequest(c, fmt.Sprintf("不支持的Content-Type: %s", contentType))
 				return
 			}
@@ -26,6 +27,35 @@ func RequestValidationMiddleware() gin.HandlerFunc {
 	}
 }
 
+// allowedContentTypes 允许的请求体媒体类型
+// zip 和 octet-stream 用于上传导出包等二进制内容，XML 类型用于导入 XLIFF 文件，YAML 类型用于导入 Rails/Symfony 语言文件，CSV 和 XLSX 用于表格导入，PNG 和 JPEG 用于上传设计稿截图
+var allowedContentTypes = map[string]bool{
+	"application/json":         true,
+	"multipart/form-data":      true,
+	"application/zip":          true,
+	"application/octet-stream": true,
+	"application/x-xliff+xml":  true,
+	"application/xliff+xml":    true,
+	"application/xml":          true,
+	"text/xml":                 true,
+	"application/x-yaml":       true,
+	"application/yaml":         true,
+	"text/yaml":                true,
+	"text/csv":                 true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true,
+	"image/png":  true,
+	"image/jpeg": true,
+}
+
+// isAllowedContentType 检查Content-Type是否允许，忽略 charset、boundary 等参数
+func isAllowedContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return allowedContentTypes[mediaType]
+}
+
 // RequestSizeLimitMiddleware 请求大小限制中间件
 // 限制请求体的最大大小（默认32MB）
 func RequestSizeLimitMiddleware(maxSize int64) gin.HandlerFunc {
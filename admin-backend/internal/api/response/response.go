@@ -2,6 +2,7 @@ package response
 
 import (
 	"net/http"
+	"yflow/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,10 +24,11 @@ type ErrorInfo struct {
 
 // Meta 元数据（用于分页等）
 type Meta struct {
-	Page       int   `json:"page"`
-	PageSize   int   `json:"page_size"`
-	TotalCount int64 `json:"total_count"`
-	TotalPages int64 `json:"total_pages"`
+	Page       int    `json:"page,omitempty"` // 游标分页时不返回
+	PageSize   int    `json:"page_size"`
+	TotalCount int64  `json:"total_count"`
+	TotalPages int64  `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // 游标分页时下一页的游标，没有下一页时不返回
 }
 
 // Success 成功响应
@@ -79,6 +81,48 @@ func ErrorWithDetails(c *gin.Context, status int, code, message, details string)
 	c.Abort()
 }
 
+// ErrorWithData 带详细信息和数据的错误响应，用于需要返回检查结果等内容的错误
+func ErrorWithData(c *gin.Context, status int, code, message, details string, data interface{}) {
+	c.JSON(status, APIResponse{
+		Success: false,
+		Data:    data,
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+			Details: details,
+		},
+	})
+	c.Abort()
+}
+
+// HandleError 将服务返回的错误映射为响应：领域错误按类型返回对应的 4xx 状态码、消息和详细信息，
+// 其他错误和内部错误类型返回 500 和 fallback 消息，不向调用方暴露错误内容。
+// 返回 false 表示响应了 500，调用方需要时可以据此记录日志
+func HandleError(c *gin.Context, err error, fallback string) bool {
+	appErr, ok := domain.IsAppError(err)
+	if !ok || appErr.Type == domain.ErrorTypeInternal {
+		InternalServerError(c, fallback)
+		return false
+	}
+	status := appErr.HTTPStatus()
+	code, ok := errorCodes[status]
+	if !ok {
+		InternalServerError(c, fallback)
+		return false
+	}
+	ErrorWithDetails(c, status, code, appErr.Message, appErr.Details)
+	return true
+}
+
+// errorCodes 领域错误对应状态码使用的错误码，与下面的预定义错误响应一致
+var errorCodes = map[int]string{
+	http.StatusBadRequest:   "BAD_REQUEST",
+	http.StatusUnauthorized: "UNAUTHORIZED",
+	http.StatusForbidden:    "FORBIDDEN",
+	http.StatusNotFound:     "NOT_FOUND",
+	http.StatusConflict:     "CONFLICT",
+}
+
 // 预定义的错误响应函数
 func BadRequest(c *gin.Context, message string) {
 	Error(c, http.StatusBadRequest, "BAD_REQUEST", message)
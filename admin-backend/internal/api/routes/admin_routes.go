@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupAdminRoutes 设置系统管理路由
+func (r *Router) setupAdminRoutes(authRoutes *gin.RouterGroup) {
+	// 系统管理接口仅管理员可以访问
+	adminRoutes := authRoutes.Group("/admin")
+	adminRoutes.Use(r.middlewareFactory.RequireAdminRole())
+	{
+		adminRoutes.GET("/storage-health", r.StorageHandler.GetHealth)
+		adminRoutes.GET("/audit-logs", r.AuditLogHandler.GetSystemLogs)
+		adminRoutes.GET("/compliance-report", r.ComplianceHandler.GetReport)
+		adminRoutes.GET("/stale-projects", r.ProjectActivityHandler.GetStaleProjects)
+		adminRoutes.POST("/stale-projects/archive", r.ProjectActivityHandler.ArchiveStaleProjects)
+		adminRoutes.GET("/dead-letters", r.DeadLetterHandler.List)
+		adminRoutes.POST("/dead-letters/requeue", r.DeadLetterHandler.Requeue)
+		adminRoutes.POST("/role-sync", r.RoleSyncHandler.Sync)
+		adminRoutes.GET("/role-reviews", r.RoleSyncHandler.ListReviews)
+		adminRoutes.POST("/role-reviews/:id/resolve", r.RoleSyncHandler.ResolveReview)
+	}
+}
@@ -0,0 +1,16 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupAPIKeyRoutes 设置 API Key 管理路由
+func (r *Router) setupAPIKeyRoutes(authRoutes *gin.RouterGroup) {
+	// API Key 管理需要管理员权限，且只接受登录会话
+	apiKeyRoutes := authRoutes.Group("/api-keys")
+	apiKeyRoutes.Use(r.middlewareFactory.RequireAdminRole())
+	apiKeyRoutes.Use(r.middlewareFactory.RequireSessionAuth())
+	{
+		apiKeyRoutes.GET("", r.APIKeyHandler.List)
+		apiKeyRoutes.POST("", r.APIKeyHandler.Create)
+		apiKeyRoutes.DELETE("/:id", r.APIKeyHandler.Revoke)
+	}
+}
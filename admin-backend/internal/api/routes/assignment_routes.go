@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupAssignmentRoutes 设置任务分配路由
+func (r *Router) setupAssignmentRoutes(authRoutes *gin.RouterGroup) {
+	// 当前用户的待办任务，按用户自己的任务分配统计
+	authRoutes.GET("/users/me/tasks", r.AssignmentHandler.GetMyTasks)
+
+	projectRoutes := authRoutes.Group("/projects/:project_id/assignments")
+
+	// 查看分配只需要查看权限
+	viewerRoutes := projectRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("", r.AssignmentHandler.GetByProjectID)
+	}
+
+	// 分配和取消分配需要项目所有者权限
+	ownerRoutes := projectRoutes.Group("")
+	ownerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		ownerRoutes.POST("", r.AssignmentHandler.Assign)
+		ownerRoutes.DELETE("/:assignment_id", r.AssignmentHandler.Delete)
+	}
+}
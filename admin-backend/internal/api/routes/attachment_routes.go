@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupAttachmentRoutes 设置翻译键附件路由
+func (r *Router) setupAttachmentRoutes(authRoutes *gin.RouterGroup) {
+	attachmentRoutes := authRoutes.Group("/projects/:project_id/attachments")
+
+	viewerRoutes := attachmentRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("", r.AttachmentHandler.GetByProjectID)
+		viewerRoutes.GET("/:attachment_id/content", r.AttachmentHandler.GetContent)
+	}
+
+	editorRoutes := attachmentRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.DELETE("/:attachment_id", r.AttachmentHandler.Delete)
+	}
+
+	// 上传附件应用批量操作限流
+	uploadRoutes := editorRoutes.Group("")
+	uploadRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	{
+		uploadRoutes.POST("", r.AttachmentHandler.Upload)
+	}
+}
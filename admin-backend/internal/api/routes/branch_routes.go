@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupBranchRoutes 设置翻译分支路由
+func (r *Router) setupBranchRoutes(authRoutes *gin.RouterGroup) {
+	branchRoutes := authRoutes.Group("/projects/:project_id/branches")
+
+	viewerRoutes := branchRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("", r.BranchHandler.GetByProjectID)
+		viewerRoutes.GET("/:branch_id/translations", r.BranchHandler.GetValues)
+		viewerRoutes.GET("/:branch_id/diff", r.BranchHandler.Diff)
+	}
+
+	// 修改分支和合并到主线需要编辑权限
+	editorRoutes := branchRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.POST("", r.BranchHandler.Create)
+		editorRoutes.DELETE("/:branch_id", r.BranchHandler.Delete)
+		editorRoutes.PUT("/:branch_id/translations", r.BranchHandler.SetTranslations)
+		editorRoutes.POST("/:branch_id/merge", r.BranchHandler.Merge)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupBulkDeleteRoutes 设置按条件批量删除翻译路由
+func (r *Router) setupBulkDeleteRoutes(authRoutes *gin.RouterGroup) {
+	bulkDeleteRoutes := authRoutes.Group("/projects/:project_id/translations/bulk-delete")
+	bulkDeleteRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	bulkDeleteRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		bulkDeleteRoutes.POST("/preview", r.BulkDeleteHandler.Preview)
+		bulkDeleteRoutes.POST("", r.BulkDeleteHandler.Delete)
+	}
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,19 +12,34 @@ func (r *Router) setupCLIRoutes(rg *gin.RouterGroup) {
 	// CLI路由使用API Key认证和API限流
 	cliRoutes := rg.Group("/cli")
 	cliRoutes.Use(r.middlewareFactory.APIKeyAuthMiddleware())
-	cliRoutes.Use(middleware.TollboothAPIRateLimitMiddleware())
+	cliRoutes.Use(r.cliRateLimit)
+	cliRoutes.Use(r.middlewareFactory.TrackProjectAccess("project_id"))
+	cliRoutes.Use(r.middlewareFactory.OrganizationScope())
 	{
 		// CLI身份验证
 		cliRoutes.GET("/auth", r.CLIHandler.Auth)
 
-		// 获取翻译数据
-		cliRoutes.GET("/translations", r.CLIHandler.GetTranslations)
+		// 获取翻译数据，支持 ETag 条件请求和 gzip 压缩，翻译变更时按项目失效
+		// 缓存命中（含 304）也计入拉取用量；locale=auto 时按 Accept-Language 协商语言，按协商结果缓存
+		// 返回缓存前先检查 API Key 的项目范围
+		cliRoutes.GET("/translations", r.middlewareFactory.RequireAPIKeyScope(domain.APIKeyScopeRead, "project_id"), r.middlewareFactory.TrackUsage(domain.UsageChannelCLI, "project_id", "locale"), middleware.GzipMiddleware(), r.middlewareFactory.LocaleNegotiation("locale"), middleware.ResponseCacheMiddleware(r.cacheService, middleware.ResponseCacheConfig{
+			Scope:   middleware.ProjectQueryResponseScope("project_id"),
+			Private: true,
+			Variant: middleware.NegotiatedLocaleVariant,
+		}), r.CLIHandler.GetTranslations)
+
+		// 增量同步：返回同步起点之后变更的键，不缓存响应
+		cliRoutes.GET("/translations/delta", r.middlewareFactory.RequireAPIKeyScope(domain.APIKeyScopeRead, "project_id"), r.middlewareFactory.TrackUsage(domain.UsageChannelCLI, "project_id", "locale"), middleware.GzipMiddleware(), r.middlewareFactory.LocaleNegotiation("locale"), r.CLIDeltaHandler.GetDelta)
+
+		// 监听翻译变更（WebSocket 长连接）
+		cliRoutes.GET("/watch", r.CLIWatchHandler.Watch)
 	}
 
 	// 推送翻译键（批量操作，应用批量操作限流）
 	batchCliRoutes := rg.Group("/cli")
 	batchCliRoutes.Use(r.middlewareFactory.APIKeyAuthMiddleware())
 	batchCliRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	batchCliRoutes.Use(r.middlewareFactory.TrackProjectAccess("project_id"))
 	{
 		batchCliRoutes.POST("/keys", r.CLIHandler.PushKeys)
 	}
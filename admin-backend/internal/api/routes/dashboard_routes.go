@@ -7,5 +7,6 @@ func (r *Router) setupDashboardRoutes(authRoutes *gin.RouterGroup) {
 	dashboardRoutes := authRoutes.Group("/dashboard")
 	{
 		dashboardRoutes.GET("/stats", r.DashboardHandler.GetStats)
+		dashboardRoutes.GET("/activity", r.middlewareFactory.RequireAdminRole(), r.DashboardHandler.GetActivity)
 	}
 }
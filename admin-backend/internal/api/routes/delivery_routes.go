@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDeliveryRoutes 设置公开分发路由
+// 位于 /api 之外，不经认证，只分发开启了公开分发的项目的已发布版本；响应可以被 CDN 缓存
+func (r *Router) setupDeliveryRoutes(engine *gin.Engine) {
+	deliveryRoutes := engine.Group("/delivery")
+	{
+		// 语言包支持 ETag 条件请求和 gzip 压缩，请求成功（含 304）计入分发用量
+		deliveryRoutes.GET("/:project_slug/:file", r.middlewareFactory.TrackUsage(domain.UsageChannelDelivery, "project_id", "locale"), middleware.GzipMiddleware(), r.DeliveryHandler.GetBundle)
+	}
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupFigmaRoutes 设置 Figma 插件路由
+// 插件使用个人访问令牌认证，权限与项目中的翻译操作一致
+func (r *Router) setupFigmaRoutes(authRoutes *gin.RouterGroup) {
+	figmaRoutes := authRoutes.Group("/projects/:project_id/figma")
+
+	viewerRoutes := figmaRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("/frames", r.FigmaHandler.GetReferences)
+		viewerRoutes.GET("/frames/:frame_id/screenshot", r.FigmaHandler.GetScreenshot)
+		viewerRoutes.GET("/values", r.FigmaHandler.GetValues)
+	}
+
+	editorRoutes := figmaRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.DELETE("/frames/:frame_id", r.FigmaHandler.DeleteFrame)
+	}
+
+	// 同步画框和上传截图应用批量操作限流
+	uploadRoutes := editorRoutes.Group("")
+	uploadRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	{
+		uploadRoutes.POST("/frames", r.FigmaHandler.SyncFrame)
+		uploadRoutes.PUT("/frames/:frame_id/screenshot", r.FigmaHandler.UploadScreenshot)
+	}
+}
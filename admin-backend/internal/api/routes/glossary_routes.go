@@ -0,0 +1,25 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupGlossaryRoutes 设置术语表路由
+func (r *Router) setupGlossaryRoutes(authRoutes *gin.RouterGroup) {
+	glossaryRoutes := authRoutes.Group("/projects/:project_id/glossary")
+
+	// 查看术语表只需要查看权限
+	viewerRoutes := glossaryRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("", r.GlossaryHandler.GetByProjectID)
+		viewerRoutes.GET("/:term_id", r.GlossaryHandler.GetByID)
+	}
+
+	// 维护术语表与编辑翻译的权限一致
+	editorRoutes := glossaryRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.POST("", r.GlossaryHandler.Create)
+		editorRoutes.PUT("/:term_id", r.GlossaryHandler.Update)
+		editorRoutes.DELETE("/:term_id", r.GlossaryHandler.Delete)
+	}
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupGraphQLRoutes 设置 GraphQL 路由
+// 项目权限由解析器按查询的项目检查
+func (r *Router) setupGraphQLRoutes(authRoutes *gin.RouterGroup) {
+	authRoutes.POST("/graphql", r.GraphQLHandler.Query)
+}
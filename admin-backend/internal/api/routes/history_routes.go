@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupHistoryRoutes 设置翻译变更历史差异路由，需要项目查看权限
+func (r *Router) setupHistoryRoutes(authRoutes *gin.RouterGroup) {
+	historyRoutes := authRoutes.Group("/translation-history")
+	historyRoutes.Use(r.middlewareFactory.RequireTranslationHistoryViewer())
+	{
+		historyRoutes.GET("/:id/diff", r.HistoryHandler.GetDiff)
+	}
+
+	projectRoutes := authRoutes.Group("/projects/:project_id")
+	projectRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		projectRoutes.GET("/changes", r.HistoryHandler.GetChanges)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupImportProfileRoutes 设置表格导入映射配置路由
+func (r *Router) setupImportProfileRoutes(authRoutes *gin.RouterGroup) {
+	// 映射配置只用于导入，与导入翻译的权限一致
+	profileRoutes := authRoutes.Group("/projects/:project_id/import-profiles")
+	profileRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		profileRoutes.POST("", r.ImportProfileHandler.Create)
+		profileRoutes.GET("", r.ImportProfileHandler.GetByProjectID)
+		profileRoutes.PUT("/:profile_id", r.ImportProfileHandler.Update)
+		profileRoutes.DELETE("/:profile_id", r.ImportProfileHandler.Delete)
+	}
+
+	// 上传文件的操作应用批量操作限流
+	uploadRoutes := profileRoutes.Group("")
+	uploadRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	{
+		uploadRoutes.POST("/suggest", r.ImportProfileHandler.Suggest)
+		uploadRoutes.POST("/:profile_id/import", r.ImportProfileHandler.Import)
+	}
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupInboundWebhookRoutes 设置入站 Webhook 管理路由
+func (r *Router) setupInboundWebhookRoutes(authRoutes *gin.RouterGroup) {
+	// 入站 Webhook 会直接写入翻译，仅项目所有者可以管理
+	webhookRoutes := authRoutes.Group("/projects/:project_id/inbound-webhooks")
+	webhookRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		webhookRoutes.POST("", r.InboundWebhookHandler.Create)
+		webhookRoutes.GET("", r.InboundWebhookHandler.GetByProjectID)
+		webhookRoutes.PUT("/:webhook_id", r.InboundWebhookHandler.Update)
+		webhookRoutes.DELETE("/:webhook_id", r.InboundWebhookHandler.Delete)
+		webhookRoutes.POST("/:webhook_id/rotate-secret", r.InboundWebhookHandler.RotateSecret)
+		webhookRoutes.GET("/:webhook_id/logs", r.InboundWebhookHandler.GetLogs)
+	}
+
+	// Webhook 消息模板预览，与 Webhook 管理权限一致
+	templateRoutes := authRoutes.Group("/projects/:project_id/webhook-templates")
+	templateRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		templateRoutes.POST("/preview", r.WebhookTemplateHandler.Preview)
+	}
+}
+
+// setupPublicWebhookRoutes 设置外部系统推送入口（通过签名认证，不需要登录）
+func (r *Router) setupPublicWebhookRoutes(rg *gin.RouterGroup) {
+	inboundRoutes := rg.Group("/webhooks/inbound")
+	inboundRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	{
+		inboundRoutes.POST("/:webhook_id", r.InboundWebhookHandler.Receive)
+	}
+}
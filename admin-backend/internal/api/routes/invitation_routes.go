@@ -9,6 +9,8 @@ func (r *Router) setupInvitationRoutes(authRoutes *gin.RouterGroup) {
 	invitationRoutes.Use(r.middlewareFactory.RequireAdminRole()) // 邀请管理需要管理员权限
 	{
 		invitationRoutes.POST("", r.InvitationHandler.CreateInvitation)
+		invitationRoutes.POST("/batches", r.InvitationHandler.CreateInvitationBatch)
+		invitationRoutes.DELETE("/batches/:label", r.InvitationHandler.RevokeInvitationBatch)
 		invitationRoutes.GET("", r.InvitationHandler.GetInvitations)
 		invitationRoutes.GET("/:code", r.InvitationHandler.GetInvitation)
 		invitationRoutes.DELETE("/:code", r.InvitationHandler.RevokeInvitation)
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupKeyLockRoutes 设置键编辑锁路由
+func (r *Router) setupKeyLockRoutes(authRoutes *gin.RouterGroup) {
+	lockRoutes := authRoutes.Group("/projects/:project_id/keys/locks")
+
+	// 查看谁正在编辑只需要查看权限
+	viewerRoutes := lockRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("", r.KeyLockHandler.List)
+	}
+
+	// 获取、续期和释放编辑锁需要编辑权限
+	editorRoutes := lockRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.POST("", r.KeyLockHandler.Acquire)
+		editorRoutes.PUT("", r.KeyLockHandler.Heartbeat)
+		editorRoutes.DELETE("", r.KeyLockHandler.Release)
+	}
+}
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupKeyPrefixRoutes 设置键名前缀批量操作、审计日志和历史导出路由
+func (r *Router) setupKeyPrefixRoutes(authRoutes *gin.RouterGroup) {
+	// 批量修改需要编辑权限
+	keyPrefixEditRoutes := authRoutes.Group("/projects/:project_id/key-prefix")
+	keyPrefixEditRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	keyPrefixEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		keyPrefixEditRoutes.POST("/delete", r.KeyPrefixHandler.Delete)
+		keyPrefixEditRoutes.POST("/status", r.KeyPrefixHandler.ChangeStatus)
+		keyPrefixEditRoutes.POST("/rename", r.KeyPrefixHandler.Rename)
+	}
+
+	// 重命名单个键、从其他项目复制键和管理键链接
+	keyRoutes := authRoutes.Group("/projects/:project_id/keys")
+	keyRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	keyRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		keyRoutes.POST("/rename", r.KeyRenameHandler.Rename)
+		keyRoutes.POST("/copy", r.KeyCopyHandler.Copy)
+		keyRoutes.DELETE("/links", r.KeyCopyHandler.Unlink)
+	}
+
+	// 查看键链接只需要查看权限
+	keyLinkRoutes := authRoutes.Group("/projects/:project_id/keys")
+	keyLinkRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		keyLinkRoutes.GET("/links", r.KeyCopyHandler.ListLinks)
+	}
+
+	// 导出只需要查看权限
+	keyPrefixExportRoutes := authRoutes.Group("/projects/:project_id/key-prefix")
+	keyPrefixExportRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	keyPrefixExportRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		keyPrefixExportRoutes.GET("/export", r.KeyPrefixHandler.Export)
+	}
+
+	// 审计日志仅项目所有者可以查看
+	auditLogRoutes := authRoutes.Group("/projects/:project_id/audit-logs")
+	auditLogRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		auditLogRoutes.GET("", r.AuditLogHandler.GetByProjectID)
+	}
+
+	// 导出变更历史和审计日志同样仅项目所有者可以访问
+	historyRoutes := authRoutes.Group("/projects/:project_id/history")
+	historyRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	historyRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		historyRoutes.GET("/export", r.ComplianceHandler.ExportHistory)
+	}
+}
@@ -1,16 +1,26 @@
 package routes
 
-import "github.com/gin-gonic/gin"
+import (
+	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
 
 // setupLanguageRoutes 设置语言相关路由
 func (r *Router) setupLanguageRoutes(authRoutes *gin.RouterGroup) {
 	languageRoutes := authRoutes.Group("/languages")
 	{
-		languageRoutes.GET("", r.LanguageHandler.GetAll) // 所有用户都可以查看语言列表
+		// 所有用户都可以查看语言列表，响应按用户私有缓存并在语言变更时失效
+		languageRoutes.GET("", middleware.ResponseCacheMiddleware(r.cacheService, middleware.ResponseCacheConfig{
+			Scope:   middleware.StaticResponseScope(domain.ResponseScopeLanguages),
+			TTL:     domain.LongExpiration,
+			Private: true,
+		}), r.LanguageHandler.GetAll)
 
-		// 语言管理需要管理员权限
+		// 组织的语言由组织的 owner 和 admin 管理（通过 org_id 或 project_id 指定组织），共享语言需要系统管理员权限
 		languageAdminRoutes := languageRoutes.Group("")
-		languageAdminRoutes.Use(r.middlewareFactory.RequireAdminRole())
+		languageAdminRoutes.Use(r.middlewareFactory.RequireScopedOrganizationAdmin())
 		{
 			languageAdminRoutes.POST("", r.LanguageHandler.Create)
 			languageAdminRoutes.PUT("/:id", r.LanguageHandler.Update)
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupNamespaceRoutes 设置键命名空间路由
+func (r *Router) setupNamespaceRoutes(authRoutes *gin.RouterGroup) {
+	projectRoutes := authRoutes.Group("/projects/:project_id")
+
+	viewerRoutes := projectRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("/namespaces", r.NamespaceHandler.GetByProjectID)
+	}
+
+	// 管理命名空间和调整键的归属需要编辑权限
+	editorRoutes := projectRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.POST("/namespaces", r.NamespaceHandler.Create)
+		editorRoutes.PUT("/namespaces/:namespace_id", r.NamespaceHandler.Update)
+		editorRoutes.DELETE("/namespaces/:namespace_id", r.NamespaceHandler.Delete)
+		editorRoutes.PUT("/keys/namespace", r.NamespaceHandler.AssignKeys)
+	}
+}
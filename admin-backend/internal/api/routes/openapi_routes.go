@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+
+	"yflow/docs"
+	"yflow/internal/api/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupOpenAPIRoutes 设置按调用方凭证筛选的 OpenAPI 规范路由
+// 如 /openapi/cli.json 只包含 CLI API Key 可以调用的接口，/openapi/viewer.json 只包含项目查看者可以调用的只读接口
+func (r *Router) setupOpenAPIRoutes(engine *gin.Engine) {
+	engine.GET("/openapi/:audience", func(c *gin.Context) {
+		data, ok := docs.AudienceSpec(strings.TrimSuffix(c.Param("audience"), ".json"))
+		if !ok {
+			response.NotFound(c, "API 文档不存在")
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+	})
+}
@@ -0,0 +1,48 @@
+package routes
+
+import "github.com/gin-gonic/gin"
+
+// setupOrganizationRoutes 设置组织路由
+func (r *Router) setupOrganizationRoutes(authRoutes *gin.RouterGroup) {
+	organizationRoutes := authRoutes.Group("/organizations")
+	{
+		// 登录用户查看自己所在的组织，管理员查看所有组织
+		organizationRoutes.GET("", r.OrganizationHandler.List)
+		// 创建和删除组织需要管理员权限
+		organizationRoutes.POST("", r.middlewareFactory.RequireAdminRole(), r.OrganizationHandler.Create)
+		organizationRoutes.DELETE("/:org_id", r.middlewareFactory.RequireAdminRole(), r.OrganizationHandler.Delete)
+
+		// 组织成员可以查看组织、成员和组织的语言
+		memberRoutes := organizationRoutes.Group("/:org_id")
+		memberRoutes.Use(r.middlewareFactory.RequireOrganizationMember())
+		{
+			memberRoutes.GET("", r.OrganizationHandler.GetByID)
+			memberRoutes.GET("/members", r.OrganizationHandler.GetMembers)
+			memberRoutes.GET("/languages", r.OrganizationHandler.GetLanguages)
+		}
+
+		// 组织的 owner 和 admin 管理组织、成员、项目和语言
+		adminRoutes := organizationRoutes.Group("/:org_id")
+		adminRoutes.Use(r.middlewareFactory.RequireOrganizationAdmin())
+		{
+			adminRoutes.PUT("", r.OrganizationHandler.Update)
+			adminRoutes.POST("/members", r.OrganizationHandler.AddMember)
+			adminRoutes.PUT("/members/:user_id", r.OrganizationHandler.UpdateMember)
+			adminRoutes.DELETE("/members/:user_id", r.OrganizationHandler.RemoveMember)
+			adminRoutes.GET("/projects", r.OrganizationHandler.GetProjects)
+			adminRoutes.POST("/languages", r.OrganizationHandler.CreateLanguage)
+			adminRoutes.PUT("/languages/:id", r.OrganizationHandler.UpdateLanguage)
+			adminRoutes.DELETE("/languages/:id", r.OrganizationHandler.DeleteLanguage)
+		}
+
+		// 组织的 API Key 只接受登录会话
+		apiKeyRoutes := organizationRoutes.Group("/:org_id/api-keys")
+		apiKeyRoutes.Use(r.middlewareFactory.RequireOrganizationAdmin())
+		apiKeyRoutes.Use(r.middlewareFactory.RequireSessionAuth())
+		{
+			apiKeyRoutes.GET("", r.OrganizationHandler.ListAPIKeys)
+			apiKeyRoutes.POST("", r.OrganizationHandler.CreateAPIKey)
+			apiKeyRoutes.DELETE("/:id", r.OrganizationHandler.RevokeAPIKey)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupProjectEventRoutes 设置编辑器实时事件路由
+func (r *Router) setupProjectEventRoutes(authRoutes *gin.RouterGroup) {
+	eventRoutes := authRoutes.Group("/projects/:project_id/events")
+	eventRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		// 长连接，不经过 gzip 和响应缓存
+		eventRoutes.GET("", r.ProjectEventHandler.Stream)
+	}
+}
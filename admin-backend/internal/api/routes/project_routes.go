@@ -5,6 +5,8 @@ import "github.com/gin-gonic/gin"
 // setupProjectRoutes 设置项目相关路由
 func (r *Router) setupProjectRoutes(authRoutes *gin.RouterGroup) {
 	projectRoutes := authRoutes.Group("/projects")
+	// 项目详情、更新等路由以 :id 作为项目ID
+	projectRoutes.Use(r.middlewareFactory.TrackProjectAccess("id"))
 	{
 		// 项目基本操作
 		projectRoutes.POST("", r.ProjectHandler.Create)
@@ -18,6 +20,11 @@ func (r *Router) setupProjectRoutes(authRoutes *gin.RouterGroup) {
 			projectViewRoutes.GET("/detail/:id", r.ProjectHandler.GetByID)
 			projectViewRoutes.GET("/:project_id/members", r.ProjectMemberHandler.GetProjectMembers)
 			projectViewRoutes.GET("/:project_id/members/:user_id/permission", r.ProjectMemberHandler.CheckPermission)
+			projectViewRoutes.GET("/:project_id/usage", r.UsageHandler.GetReport)
+			projectViewRoutes.GET("/:project_id/stats", r.TranslationHandler.GetProjectStats)
+			projectViewRoutes.GET("/:project_id/qa-report", r.QAHandler.GetReport)
+			projectViewRoutes.GET("/:project_id/consistency", r.QAHandler.GetConsistency)
+			projectViewRoutes.GET("/:project_id/consistency/:language", r.QAHandler.GetInconsistencies)
 		}
 
 		// 需要项目编辑权限的操作
@@ -32,9 +39,18 @@ func (r *Router) setupProjectRoutes(authRoutes *gin.RouterGroup) {
 		projectOwnerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
 		{
 			projectOwnerRoutes.DELETE("/delete/:id", r.ProjectHandler.Delete)
+			projectOwnerRoutes.PUT("/slug/:id", r.ProjectHandler.RenameSlug)
+			projectOwnerRoutes.PUT("/protection/:id", r.ProjectHandler.SetProtection)
+			projectOwnerRoutes.PUT("/delivery/:id", r.ProjectHandler.SetDelivery)
 			projectOwnerRoutes.POST("/:project_id/members", r.ProjectMemberHandler.AddMember)
+			projectOwnerRoutes.POST("/:project_id/members/bulk", r.ProjectMemberHandler.BulkAddMembers)
+			projectOwnerRoutes.POST("/:project_id/members/import", r.ProjectMemberHandler.ImportMembers)
 			projectOwnerRoutes.PUT("/:project_id/members/:user_id", r.ProjectMemberHandler.UpdateMemberRole)
 			projectOwnerRoutes.DELETE("/:project_id/members/:user_id", r.ProjectMemberHandler.RemoveMember)
+			projectOwnerRoutes.GET("/:project_id/config", r.ProjectConfigHandler.Export)
+			projectOwnerRoutes.POST("/:project_id/config/import", r.ProjectConfigHandler.Import)
+			projectOwnerRoutes.POST("/:project_id/duplicate", r.ProjectDuplicateHandler.Duplicate)
+			projectOwnerRoutes.GET("/:project_id/duplicate/:job_id", r.ProjectDuplicateHandler.GetJob)
 		}
 	}
 }
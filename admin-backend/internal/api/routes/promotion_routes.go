@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"yflow/internal/api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPromotionRoutes 设置环境推送路由
+func (r *Router) setupPromotionRoutes(authRoutes *gin.RouterGroup) {
+	// 环境推送会批量写入翻译，仅项目所有者可以操作
+	promotionRoutes := authRoutes.Group("/projects/:project_id/promotions")
+	promotionRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
+	promotionRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		promotionRoutes.POST("", r.PromotionHandler.Create)
+		promotionRoutes.GET("", r.PromotionHandler.GetByProjectID)
+		promotionRoutes.GET("/:promotion_id", r.PromotionHandler.GetByID)
+		promotionRoutes.POST("/:promotion_id/apply", r.PromotionHandler.Apply)
+	}
+}
@@ -1,8 +1,6 @@
 package routes
 
 import (
-	"yflow/internal/api/middleware"
-
 	"github.com/gin-gonic/gin"
 )
 
@@ -10,10 +8,23 @@ import (
 func (r *Router) setupPublicRoutes(rg *gin.RouterGroup) {
 	// 登录路由组（应用登录限流中间件）
 	loginRoutes := rg.Group("")
-	loginRoutes.Use(middleware.TollboothLoginRateLimitMiddleware())
+	loginRoutes.Use(r.loginRateLimit)
 	{
 		// 公开的认证路由（每秒5个请求，突发10个）
 		loginRoutes.POST("/login", r.UserHandler.Login)
 		loginRoutes.POST("/refresh", r.UserHandler.RefreshToken)
+
+		// 找回密码与登录共用限流，避免被用于批量发送邮件
+		loginRoutes.POST("/password-reset", r.PasswordResetHandler.Request)
+		loginRoutes.POST("/password-reset/confirm", r.PasswordResetHandler.Confirm)
+	}
+
+	// 单点登录路由单独限流，跳转和回调不占用密码登录的配额
+	ssoRoutes := rg.Group("/auth/oidc")
+	ssoRoutes.Use(r.ssoRateLimit)
+	{
+		ssoRoutes.GET("/providers", r.SSOHandler.Providers)
+		ssoRoutes.GET("/login", r.SSOHandler.Login)
+		ssoRoutes.GET("/callback", r.SSOHandler.Callback)
 	}
 }
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupReleaseGateRoutes 设置发布门槛路由
+func (r *Router) setupReleaseGateRoutes(authRoutes *gin.RouterGroup) {
+	projectRoutes := authRoutes.Group("/projects/:project_id")
+
+	// 查看门槛和检查结果只需要查看权限
+	viewerRoutes := projectRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("/release-thresholds", r.ReleaseGateHandler.GetThresholds)
+		viewerRoutes.GET("/release-readiness", r.ReleaseGateHandler.Check)
+	}
+
+	// 修改门槛需要项目所有者权限
+	ownerRoutes := projectRoutes.Group("")
+	ownerRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		ownerRoutes.PUT("/release-thresholds", r.ReleaseGateHandler.SetThresholds)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupReleaseRoutes 设置发布版本路由
+func (r *Router) setupReleaseRoutes(authRoutes *gin.RouterGroup) {
+	releaseRoutes := authRoutes.Group("/projects/:project_id/releases")
+
+	viewerRoutes := releaseRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("", r.ReleaseHandler.GetByProjectID)
+		viewerRoutes.GET("/:release_id", r.ReleaseHandler.GetByID)
+	}
+
+	// 发布版本和推进、中止灰度发布需要编辑权限
+	editorRoutes := releaseRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.POST("", r.ReleaseHandler.Create)
+		editorRoutes.PUT("/:release_id/rollout", r.ReleaseHandler.AdvanceRollout)
+		editorRoutes.DELETE("/:release_id/rollout", r.ReleaseHandler.AbortRollout)
+	}
+}
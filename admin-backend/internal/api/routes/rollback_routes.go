@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupRollbackRoutes 设置翻译回滚路由，回滚需要编辑权限
+func (r *Router) setupRollbackRoutes(authRoutes *gin.RouterGroup) {
+	translationRoutes := authRoutes.Group("/translations")
+	translationRoutes.Use(r.middlewareFactory.RequireTranslationEditor())
+	{
+		translationRoutes.POST("/:id/rollback/:history_id", r.RollbackHandler.RollbackTranslation)
+	}
+
+	projectRoutes := authRoutes.Group("/projects/:project_id")
+	projectRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		projectRoutes.POST("/rollback", r.RollbackHandler.RollbackProject)
+	}
+}
@@ -16,52 +16,223 @@ import (
 
 // Router 路由器
 type Router struct {
-	UserHandler          *handlers.UserHandler
-	ProjectHandler       *handlers.ProjectHandler
-	LanguageHandler      *handlers.LanguageHandler
-	TranslationHandler   *handlers.TranslationHandler
-	DashboardHandler     *handlers.DashboardHandler
-	ProjectMemberHandler *handlers.ProjectMemberHandler
-	CLIHandler           *handlers.CLIHandler
-	InvitationHandler    *handlers.InvitationHandler
-	middlewareFactory    *middleware.MiddlewareFactory
-	Logger               *zap.Logger
+	UserHandler              *handlers.UserHandler
+	ProjectHandler           *handlers.ProjectHandler
+	LanguageHandler          *handlers.LanguageHandler
+	TranslationHandler       *handlers.TranslationHandler
+	DashboardHandler         *handlers.DashboardHandler
+	ProjectMemberHandler     *handlers.ProjectMemberHandler
+	CLIHandler               *handlers.CLIHandler
+	InvitationHandler        *handlers.InvitationHandler
+	InboundWebhookHandler    *handlers.InboundWebhookHandler
+	WebhookHandler           *handlers.WebhookHandler
+	KeyPrefixHandler         *handlers.KeyPrefixHandler
+	KeyRenameHandler         *handlers.KeyRenameHandler
+	KeyCopyHandler           *handlers.KeyCopyHandler
+	AuditLogHandler          *handlers.AuditLogHandler
+	PromotionHandler         *handlers.PromotionHandler
+	StorageHandler           *handlers.StorageHandler
+	AccessTokenHandler       *handlers.AccessTokenHandler
+	PasswordResetHandler     *handlers.PasswordResetHandler
+	SSOHandler               *handlers.SSOHandler
+	SessionHandler           *handlers.SessionHandler
+	UserProfileHandler       *handlers.UserProfileHandler
+	APIKeyHandler            *handlers.APIKeyHandler
+	OrganizationHandler      *handlers.OrganizationHandler
+	ProjectConfigHandler     *handlers.ProjectConfigHandler
+	ProjectDuplicateHandler  *handlers.ProjectDuplicateHandler
+	BulkDeleteHandler        *handlers.BulkDeleteHandler
+	CLIWatchHandler          *handlers.CLIWatchHandler
+	CLIDeltaHandler          *handlers.CLIDeltaHandler
+	ProjectEventHandler      *handlers.ProjectEventHandler
+	KeyLockHandler           *handlers.KeyLockHandler
+	ComplianceHandler        *handlers.ComplianceHandler
+	ProjectActivityHandler   *handlers.ProjectActivityHandler
+	WebhookTemplateHandler   *handlers.WebhookTemplateHandler
+	DeadLetterHandler        *handlers.DeadLetterHandler
+	ImportProfileHandler     *handlers.ImportProfileHandler
+	ReleaseGateHandler       *handlers.ReleaseGateHandler
+	FigmaHandler             *handlers.FigmaHandler
+	RoleSyncHandler          *handlers.RoleSyncHandler
+	UsageHandler             *handlers.UsageHandler
+	QAHandler                *handlers.QAHandler
+	GlossaryHandler          *handlers.GlossaryHandler
+	TranslationMemoryHandler *handlers.TranslationMemoryHandler
+	SearchHandler            *handlers.SearchHandler
+	AssignmentHandler        *handlers.AssignmentHandler
+	AttachmentHandler        *handlers.AttachmentHandler
+	NamespaceHandler         *handlers.NamespaceHandler
+	TagHandler               *handlers.TagHandler
+	BranchHandler            *handlers.BranchHandler
+	RollbackHandler          *handlers.RollbackHandler
+	HistoryHandler           *handlers.HistoryHandler
+	TrashHandler             *handlers.TrashHandler
+	ReleaseHandler           *handlers.ReleaseHandler
+	DeliveryHandler          *handlers.DeliveryHandler
+	GraphQLHandler           *handlers.GraphQLHandler
+	middlewareFactory        *middleware.MiddlewareFactory
+	cacheService             domain.CacheService
+	Logger                   *zap.Logger
+
+	// 各 API 版本共用的限流中间件，同一客户端通过不同版本的路径访问时共用配额
+	loginRateLimit gin.HandlerFunc
+	ssoRateLimit   gin.HandlerFunc
+	apiRateLimit   gin.HandlerFunc
+	cliRateLimit   gin.HandlerFunc
 }
 
 // RouterDeps 定义 Router 的依赖（用于 fx.In）
 type RouterDeps struct {
 	fx.In
-	UserHandler          *handlers.UserHandler
-	ProjectHandler       *handlers.ProjectHandler
-	LanguageHandler      *handlers.LanguageHandler
-	TranslationHandler   *handlers.TranslationHandler
-	DashboardHandler     *handlers.DashboardHandler
-	ProjectMemberHandler *handlers.ProjectMemberHandler
-	CLIHandler           *handlers.CLIHandler
-	InvitationHandler    *handlers.InvitationHandler
-	AuthService          domain.AuthService
-	UserService          domain.UserService
-	ProjectMemberService domain.ProjectMemberService
-	Logger               *zap.Logger
+	UserHandler              *handlers.UserHandler
+	ProjectHandler           *handlers.ProjectHandler
+	LanguageHandler          *handlers.LanguageHandler
+	TranslationHandler       *handlers.TranslationHandler
+	DashboardHandler         *handlers.DashboardHandler
+	ProjectMemberHandler     *handlers.ProjectMemberHandler
+	CLIHandler               *handlers.CLIHandler
+	InvitationHandler        *handlers.InvitationHandler
+	InboundWebhookHandler    *handlers.InboundWebhookHandler
+	WebhookHandler           *handlers.WebhookHandler
+	KeyPrefixHandler         *handlers.KeyPrefixHandler
+	KeyRenameHandler         *handlers.KeyRenameHandler
+	KeyCopyHandler           *handlers.KeyCopyHandler
+	AuditLogHandler          *handlers.AuditLogHandler
+	PromotionHandler         *handlers.PromotionHandler
+	StorageHandler           *handlers.StorageHandler
+	AccessTokenHandler       *handlers.AccessTokenHandler
+	PasswordResetHandler     *handlers.PasswordResetHandler
+	SSOHandler               *handlers.SSOHandler
+	SessionHandler           *handlers.SessionHandler
+	UserProfileHandler       *handlers.UserProfileHandler
+	APIKeyHandler            *handlers.APIKeyHandler
+	OrganizationHandler      *handlers.OrganizationHandler
+	ProjectConfigHandler     *handlers.ProjectConfigHandler
+	ProjectDuplicateHandler  *handlers.ProjectDuplicateHandler
+	BulkDeleteHandler        *handlers.BulkDeleteHandler
+	CLIWatchHandler          *handlers.CLIWatchHandler
+	CLIDeltaHandler          *handlers.CLIDeltaHandler
+	ProjectEventHandler      *handlers.ProjectEventHandler
+	KeyLockHandler           *handlers.KeyLockHandler
+	ComplianceHandler        *handlers.ComplianceHandler
+	ProjectActivityHandler   *handlers.ProjectActivityHandler
+	WebhookTemplateHandler   *handlers.WebhookTemplateHandler
+	DeadLetterHandler        *handlers.DeadLetterHandler
+	ImportProfileHandler     *handlers.ImportProfileHandler
+	ReleaseGateHandler       *handlers.ReleaseGateHandler
+	FigmaHandler             *handlers.FigmaHandler
+	RoleSyncHandler          *handlers.RoleSyncHandler
+	UsageHandler             *handlers.UsageHandler
+	QAHandler                *handlers.QAHandler
+	GlossaryHandler          *handlers.GlossaryHandler
+	TranslationMemoryHandler *handlers.TranslationMemoryHandler
+	SearchHandler            *handlers.SearchHandler
+	AssignmentHandler        *handlers.AssignmentHandler
+	AttachmentHandler        *handlers.AttachmentHandler
+	NamespaceHandler         *handlers.NamespaceHandler
+	TagHandler               *handlers.TagHandler
+	BranchHandler            *handlers.BranchHandler
+	RollbackHandler          *handlers.RollbackHandler
+	HistoryHandler           *handlers.HistoryHandler
+	TrashHandler             *handlers.TrashHandler
+	ReleaseHandler           *handlers.ReleaseHandler
+	DeliveryHandler          *handlers.DeliveryHandler
+	GraphQLHandler           *handlers.GraphQLHandler
+	AuthService              domain.AuthService
+	UserService              domain.UserService
+	ProjectMemberService     domain.ProjectMemberService
+	TokenService             domain.PersonalAccessTokenService
+	APIKeyService            domain.APIKeyService
+	SessionService           domain.SessionService
+	ActivityService          domain.ProjectActivityService
+	UsageService             domain.UsageService
+	LanguageService          domain.LanguageService
+	TranslationRepository    domain.TranslationRepository
+	ProjectService           domain.ProjectService
+	OrganizationService      domain.OrganizationService
+	HistoryRepository        domain.TranslationHistoryRepository
+	CacheService             domain.CacheService
+	Logger                   *zap.Logger
 }
 
 // NewRouter 创建路由器
 func NewRouter(deps RouterDeps) *Router {
 	return &Router{
-		UserHandler:          deps.UserHandler,
-		ProjectHandler:       deps.ProjectHandler,
-		LanguageHandler:      deps.LanguageHandler,
-		TranslationHandler:   deps.TranslationHandler,
-		DashboardHandler:     deps.DashboardHandler,
-		ProjectMemberHandler: deps.ProjectMemberHandler,
-		CLIHandler:           deps.CLIHandler,
-		InvitationHandler:    deps.InvitationHandler,
+		UserHandler:              deps.UserHandler,
+		ProjectHandler:           deps.ProjectHandler,
+		LanguageHandler:          deps.LanguageHandler,
+		TranslationHandler:       deps.TranslationHandler,
+		DashboardHandler:         deps.DashboardHandler,
+		ProjectMemberHandler:     deps.ProjectMemberHandler,
+		CLIHandler:               deps.CLIHandler,
+		InvitationHandler:        deps.InvitationHandler,
+		InboundWebhookHandler:    deps.InboundWebhookHandler,
+		WebhookHandler:           deps.WebhookHandler,
+		KeyPrefixHandler:         deps.KeyPrefixHandler,
+		KeyRenameHandler:         deps.KeyRenameHandler,
+		KeyCopyHandler:           deps.KeyCopyHandler,
+		AuditLogHandler:          deps.AuditLogHandler,
+		PromotionHandler:         deps.PromotionHandler,
+		StorageHandler:           deps.StorageHandler,
+		AccessTokenHandler:       deps.AccessTokenHandler,
+		PasswordResetHandler:     deps.PasswordResetHandler,
+		SSOHandler:               deps.SSOHandler,
+		SessionHandler:           deps.SessionHandler,
+		UserProfileHandler:       deps.UserProfileHandler,
+		APIKeyHandler:            deps.APIKeyHandler,
+		OrganizationHandler:      deps.OrganizationHandler,
+		ProjectConfigHandler:     deps.ProjectConfigHandler,
+		ProjectDuplicateHandler:  deps.ProjectDuplicateHandler,
+		BulkDeleteHandler:        deps.BulkDeleteHandler,
+		CLIWatchHandler:          deps.CLIWatchHandler,
+		CLIDeltaHandler:          deps.CLIDeltaHandler,
+		ProjectEventHandler:      deps.ProjectEventHandler,
+		KeyLockHandler:           deps.KeyLockHandler,
+		ComplianceHandler:        deps.ComplianceHandler,
+		ProjectActivityHandler:   deps.ProjectActivityHandler,
+		WebhookTemplateHandler:   deps.WebhookTemplateHandler,
+		DeadLetterHandler:        deps.DeadLetterHandler,
+		ImportProfileHandler:     deps.ImportProfileHandler,
+		ReleaseGateHandler:       deps.ReleaseGateHandler,
+		FigmaHandler:             deps.FigmaHandler,
+		RoleSyncHandler:          deps.RoleSyncHandler,
+		UsageHandler:             deps.UsageHandler,
+		QAHandler:                deps.QAHandler,
+		GlossaryHandler:          deps.GlossaryHandler,
+		TranslationMemoryHandler: deps.TranslationMemoryHandler,
+		SearchHandler:            deps.SearchHandler,
+		AssignmentHandler:        deps.AssignmentHandler,
+		AttachmentHandler:        deps.AttachmentHandler,
+		NamespaceHandler:         deps.NamespaceHandler,
+		TagHandler:               deps.TagHandler,
+		BranchHandler:            deps.BranchHandler,
+		RollbackHandler:          deps.RollbackHandler,
+		HistoryHandler:           deps.HistoryHandler,
+		TrashHandler:             deps.TrashHandler,
+		ReleaseHandler:           deps.ReleaseHandler,
+		DeliveryHandler:          deps.DeliveryHandler,
+		GraphQLHandler:           deps.GraphQLHandler,
 		middlewareFactory: middleware.NewMiddlewareFactory(
 			deps.AuthService,
 			deps.UserService,
 			deps.ProjectMemberService,
+			deps.TokenService,
+			deps.APIKeyService,
+			deps.SessionService,
+			deps.ActivityService,
+			deps.UsageService,
+			deps.LanguageService,
+			deps.TranslationRepository,
+			deps.ProjectService,
+			deps.OrganizationService,
+			deps.HistoryRepository,
 		),
-		Logger: deps.Logger,
+		cacheService:   deps.CacheService,
+		Logger:         deps.Logger,
+		loginRateLimit: middleware.TollboothLoginRateLimitMiddleware(),
+		ssoRateLimit:   middleware.TollboothLoginRateLimitMiddleware(),
+		apiRateLimit:   middleware.TollboothAPIRateLimitMiddleware(),
+		cliRateLimit:   middleware.TollboothAPIRateLimitMiddleware(),
 	}
 }
 
@@ -78,23 +249,42 @@ func (r *Router) SetupRoutes(engine *gin.Engine, monitor *internal_utils.SimpleM
 	// Swagger 文档
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// API 路由组
+	// 按调用方凭证筛选的 OpenAPI 规范
+	r.setupOpenAPIRoutes(engine)
+
+	// 公开分发的语言包，与管理 API 分开
+	r.setupDeliveryRoutes(engine)
+
+	// 带版本号的 API 路由组
+	v1 := engine.Group("/api/v1")
+	r.setupAPIRoutes(v1, apiV1)
+	v2 := engine.Group("/api/v2")
+	r.setupAPIRoutes(v2, apiV2)
+
+	// 未带版本号的路径为 v1 的别名，兼容现有客户端
 	api := engine.Group("/api")
-	{
-		r.setupPublicRoutes(api)
-		r.setupPublicInvitationRoutes(api)
-		r.setupPublicRegisterRoutes(api)
-		r.setupAuthenticatedRoutes(api)
-		r.setupCLIRoutes(api)
-	}
+	r.setupAPIRoutes(api, apiV1)
+}
+
+// setupAPIRoutes 设置一个 API 版本的全部路由
+func (r *Router) setupAPIRoutes(api *gin.RouterGroup, version apiVersion) {
+	r.setupPublicRoutes(api)
+	r.setupPublicInvitationRoutes(api)
+	r.setupPublicRegisterRoutes(api)
+	r.setupPublicWebhookRoutes(api)
+	r.setupAuthenticatedRoutes(api, version)
+	r.setupCLIRoutes(api)
 }
 
 // setupAuthenticatedRoutes 设置需要认证的路由
-func (r *Router) setupAuthenticatedRoutes(rg *gin.RouterGroup) {
+func (r *Router) setupAuthenticatedRoutes(rg *gin.RouterGroup, version apiVersion) {
 	// 应用JWT认证中间件和API限流中间件
 	authRoutes := rg.Group("")
 	authRoutes.Use(r.middlewareFactory.JWTAuthMiddleware())
-	authRoutes.Use(middleware.TollboothAPIRateLimitMiddleware())
+	authRoutes.Use(r.apiRateLimit)
+	authRoutes.Use(r.middlewareFactory.TrackProjectAccess("project_id"))
+	// 按访问的组织或项目限定语言和 API Key 所属的组织
+	authRoutes.Use(r.middlewareFactory.OrganizationScope())
 
 	// 用户相关路由
 	r.setupUserRoutes(authRoutes)
@@ -106,13 +296,77 @@ func (r *Router) setupAuthenticatedRoutes(rg *gin.RouterGroup) {
 	r.setupLanguageRoutes(authRoutes)
 
 	// 翻译相关路由
-	r.setupTranslationRoutes(authRoutes)
+	r.setupTranslationRoutes(authRoutes, version)
+
+	// 翻译记忆库路由
+	r.setupTranslationMemoryRoutes(authRoutes)
+
+	// 翻译搜索路由
+	r.setupSearchRoutes(authRoutes)
+
+	// GraphQL 查询路由
+	r.setupGraphQLRoutes(authRoutes)
 
 	// 仪表板相关路由
 	r.setupDashboardRoutes(authRoutes)
 
 	// 邀请管理路由
 	r.setupInvitationRoutes(authRoutes)
+
+	// API Key 管理路由
+	r.setupAPIKeyRoutes(authRoutes)
+
+	// 组织路由
+	r.setupOrganizationRoutes(authRoutes)
+
+	// 入站 Webhook 管理和消息模板预览路由
+	r.setupInboundWebhookRoutes(authRoutes)
+
+	// 出站 Webhook 路由
+	r.setupWebhookRoutes(authRoutes)
+
+	// 表格导入映射配置路由
+	r.setupImportProfileRoutes(authRoutes)
+
+	// 发布门槛路由
+	r.setupReleaseGateRoutes(authRoutes)
+
+	// 任务分配和个人待办路由
+	r.setupAssignmentRoutes(authRoutes)
+	r.setupAttachmentRoutes(authRoutes)
+
+	// 键命名空间路由
+	r.setupNamespaceRoutes(authRoutes)
+
+	// 键标签路由
+	r.setupTagRoutes(authRoutes)
+
+	// 翻译分支路由
+	r.setupBranchRoutes(authRoutes)
+	r.setupRollbackRoutes(authRoutes)
+	r.setupHistoryRoutes(authRoutes)
+	r.setupTrashRoutes(authRoutes)
+	r.setupReleaseRoutes(authRoutes)
+	r.setupProjectEventRoutes(authRoutes)
+	r.setupKeyLockRoutes(authRoutes)
+
+	// Figma 插件路由
+	r.setupFigmaRoutes(authRoutes)
+
+	// 术语表路由
+	r.setupGlossaryRoutes(authRoutes)
+
+	// 键名前缀批量操作、审计日志和历史导出路由
+	r.setupKeyPrefixRoutes(authRoutes)
+
+	// 按条件批量删除路由
+	r.setupBulkDeleteRoutes(authRoutes)
+
+	// 环境推送路由
+	r.setupPromotionRoutes(authRoutes)
+
+	// 系统管理路由
+	r.setupAdminRoutes(authRoutes)
 }
 
 // RouterModule 定义路由模块
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupSearchRoutes 设置翻译搜索路由
+// 只搜索用户可访问的项目，不需要额外的项目权限
+func (r *Router) setupSearchRoutes(authRoutes *gin.RouterGroup) {
+	authRoutes.GET("/search", r.SearchHandler.Search)
+}
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupTagRoutes 设置键标签路由
+func (r *Router) setupTagRoutes(authRoutes *gin.RouterGroup) {
+	projectRoutes := authRoutes.Group("/projects/:project_id")
+
+	viewerRoutes := projectRoutes.Group("")
+	viewerRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		viewerRoutes.GET("/tags", r.TagHandler.GetByProjectID)
+	}
+
+	// 管理标签和为键添加标签需要编辑权限
+	editorRoutes := projectRoutes.Group("")
+	editorRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		editorRoutes.POST("/tags", r.TagHandler.Create)
+		editorRoutes.PUT("/tags/:tag_id", r.TagHandler.Update)
+		editorRoutes.DELETE("/tags/:tag_id", r.TagHandler.Delete)
+		editorRoutes.POST("/tags/:tag_id/keys", r.TagHandler.TagKeys)
+		editorRoutes.DELETE("/tags/:tag_id/keys", r.TagHandler.UntagKeys)
+	}
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupTranslationMemoryRoutes 设置翻译记忆库路由
+// 建议只来自用户可访问的项目，不需要额外的项目权限
+func (r *Router) setupTranslationMemoryRoutes(authRoutes *gin.RouterGroup) {
+	tmRoutes := authRoutes.Group("/tm")
+	{
+		tmRoutes.GET("/suggest", r.TranslationMemoryHandler.Suggest)
+	}
+}
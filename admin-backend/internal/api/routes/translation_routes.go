@@ -2,48 +2,52 @@ package routes
 
 import (
 	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
 
 // setupTranslationRoutes 设置翻译相关路由
-func (r *Router) setupTranslationRoutes(authRoutes *gin.RouterGroup) {
+func (r *Router) setupTranslationRoutes(authRoutes *gin.RouterGroup, version apiVersion) {
 	translationRoutes := authRoutes.Group("/translations")
 	{
+		// ICU 消息的结构化编辑，只处理请求中的文本，任意登录用户可用
+		translationRoutes.POST("/icu/parse", r.TranslationHandler.ParseICUMessage)
+		translationRoutes.POST("/icu/format", r.TranslationHandler.FormatICUMessage)
+
 		// 需要项目查看权限的操作
 		translationViewRoutes := translationRoutes.Group("")
 		translationViewRoutes.Use(r.middlewareFactory.RequireProjectViewer())
 		{
 			translationViewRoutes.GET("/by-project/:project_id", r.TranslationHandler.GetByProjectID)
-			translationViewRoutes.GET("/matrix/by-project/:project_id", r.TranslationHandler.GetMatrix)
-			translationViewRoutes.GET("/:id", r.TranslationHandler.GetByID)
+			translationViewRoutes.GET("/matrix/by-project/:project_id", version.since(apiV2, r.TranslationHandler.GetMatrixRows, r.TranslationHandler.GetMatrix))
 		}
 
-		// 需要项目编辑权限的操作
-		translationEditRoutes := translationRoutes.Group("")
-		translationEditRoutes.Use(r.middlewareFactory.RequireProjectEditor())
-		{
-			translationEditRoutes.POST("", r.TranslationHandler.Create)
-			translationEditRoutes.PUT("/:id", r.TranslationHandler.Update)
-			translationEditRoutes.DELETE("/:id", r.TranslationHandler.Delete)
-		}
+		// 按翻译ID操作时检查翻译所属项目的权限
+		translationRoutes.GET("/:id", r.middlewareFactory.RequireTranslationViewer(), r.TranslationHandler.GetByID)
+		translationRoutes.PUT("/:id", r.middlewareFactory.RequireTranslationEditor(), r.TranslationHandler.Update)
+		translationRoutes.DELETE("/:id", r.middlewareFactory.RequireTranslationEditor(), r.TranslationHandler.Delete)
+
+		// 创建翻译时检查请求体中项目的编辑权限
+		translationRoutes.POST("", r.middlewareFactory.RequireTranslationBodyEditor(), r.TranslationHandler.Create)
 	}
 
-	// 批量操作路由组（应用批量操作限流中间件和项目编辑权限）
+	// 批量操作路由组（应用批量操作限流中间件，检查请求体中项目或翻译所属项目的编辑权限）
 	batchRoutes := authRoutes.Group("/translations")
 	batchRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
-	batchRoutes.Use(r.middlewareFactory.RequireProjectEditor())
 	{
-		batchRoutes.POST("/batch", r.TranslationHandler.CreateBatch)
-		batchRoutes.POST("/batch-delete", r.TranslationHandler.DeleteBatch)
+		batchRoutes.POST("/batch", r.middlewareFactory.RequireTranslationBodyEditor(), r.TranslationHandler.CreateBatch)
+		batchRoutes.POST("/batch-delete", r.middlewareFactory.RequireTranslationIDsEditor(), r.TranslationHandler.DeleteBatch)
 	}
 
 	// 导出路由（应用批量操作限流中间件和项目查看权限）
 	exportRoutes := authRoutes.Group("/exports")
 	exportRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
 	exportRoutes.Use(r.middlewareFactory.RequireProjectViewer()) // 导出只需要查看权限
+	exportRoutes.Use(r.middlewareFactory.TrackUsage(domain.UsageChannelExport, "project_id", "target_language"))
 	{
 		exportRoutes.GET("/project/:project_id", r.TranslationHandler.Export)
+		exportRoutes.GET("/project/:project_id/files", r.TranslationHandler.ExportFiles)
 	}
 
 	// 导入路由（应用批量操作限流中间件和项目编辑权限）
@@ -52,15 +56,40 @@ func (r *Router) setupTranslationRoutes(authRoutes *gin.RouterGroup) {
 	importRoutes.Use(r.middlewareFactory.RequireProjectEditor()) // 导入需要编辑权限
 	{
 		importRoutes.POST("/project/:project_id", r.TranslationHandler.Import)
+		importRoutes.POST("/project/:project_id/migrate", r.TranslationHandler.ImportMigration)
 	}
 
-	// 机器翻译路由（应用限流中间件和项目编辑权限）
+	// 机器翻译路由（应用限流中间件，翻译项目需要项目编辑权限）
 	machineTranslateRoutes := authRoutes.Group("/translations/machine-translate")
 	machineTranslateRoutes.Use(middleware.TollboothBatchOperationRateLimitMiddleware())
-	machineTranslateRoutes.Use(r.middlewareFactory.RequireProjectEditor())
 	{
+		// 支持的语言和服务状态与项目无关，任意登录用户可用
 		machineTranslateRoutes.GET("/languages", r.TranslationHandler.GetSupportedLanguages)
 		machineTranslateRoutes.GET("/health", r.TranslationHandler.HealthCheck)
+
+		machineTranslateProjectRoutes := machineTranslateRoutes.Group("/project/:project_id")
+		machineTranslateProjectRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+		{
+			machineTranslateProjectRoutes.POST("", r.TranslationHandler.MachineTranslate)
+			machineTranslateProjectRoutes.POST("/pre-translate", r.TranslationHandler.PreTranslate)
+		}
+	}
+
+	// 键的值类型和元数据（需要项目编辑权限）
+	keyRoutes := authRoutes.Group("/projects/:project_id/keys")
+	keyRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		keyRoutes.PUT("/value-type", r.TranslationHandler.SetValueType)
+		keyRoutes.PUT("/metadata", r.TranslationHandler.SetKeyMetadata)
+	}
+
+	// 翻译审核（需要项目编辑权限）
+	reviewRoutes := authRoutes.Group("/projects/:project_id/review")
+	reviewRoutes.Use(r.middlewareFactory.RequireProjectEditor())
+	{
+		reviewRoutes.POST("/submit", r.TranslationHandler.SubmitForReview)
+		reviewRoutes.POST("/approve", r.TranslationHandler.ApproveTranslations)
+		reviewRoutes.POST("/reject", r.TranslationHandler.RejectTranslations)
 	}
 
 	// 自动填充语言路由
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupTrashRoutes 设置翻译回收站路由
+func (r *Router) setupTrashRoutes(authRoutes *gin.RouterGroup) {
+	projectRoutes := authRoutes.Group("/projects/:project_id/translations")
+	projectRoutes.Use(r.middlewareFactory.RequireProjectViewer())
+	{
+		projectRoutes.GET("/trash", r.TrashHandler.GetTrash)
+	}
+
+	// 恢复翻译需要已删除翻译所属项目的编辑权限
+	translationRoutes := authRoutes.Group("/translations")
+	translationRoutes.Use(r.middlewareFactory.RequireDeletedTranslationEditor())
+	{
+		translationRoutes.POST("/:id/restore", r.TrashHandler.Restore)
+	}
+}
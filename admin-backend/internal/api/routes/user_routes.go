@@ -9,6 +9,30 @@ func (r *Router) setupUserRoutes(authRoutes *gin.RouterGroup) {
 	{
 		userRoutes.GET("/info", r.UserHandler.GetUserInfo)
 		userRoutes.POST("/change-password", r.UserHandler.ChangePassword)
+		userRoutes.GET("/profile", r.UserProfileHandler.GetProfile)
+		userRoutes.PUT("/profile", r.UserProfileHandler.UpdateProfile)
+		userRoutes.PUT("/profile/avatar", r.UserProfileHandler.UploadAvatar)
+		userRoutes.DELETE("/profile/avatar", r.UserProfileHandler.DeleteAvatar)
+	}
+
+	// 用户头像，所有登录用户都可以获取
+	authRoutes.GET("/users/:id/avatar", r.UserProfileHandler.GetAvatar)
+
+	// 个人访问令牌路由，只能在登录会话中管理
+	tokenRoutes := userRoutes.Group("/tokens")
+	tokenRoutes.Use(r.middlewareFactory.RequireSessionAuth())
+	{
+		tokenRoutes.GET("", r.AccessTokenHandler.List)
+		tokenRoutes.POST("", r.AccessTokenHandler.Create)
+		tokenRoutes.DELETE("/:id", r.AccessTokenHandler.Revoke)
+	}
+
+	// 登录会话路由，只能在登录会话中管理
+	sessionRoutes := userRoutes.Group("/sessions")
+	sessionRoutes.Use(r.middlewareFactory.RequireSessionAuth())
+	{
+		sessionRoutes.GET("", r.SessionHandler.List)
+		sessionRoutes.DELETE("/:session_id", r.SessionHandler.Revoke)
 	}
 
 	// 用户管理路由（管理员功能）
@@ -21,6 +45,10 @@ func (r *Router) setupUserRoutes(authRoutes *gin.RouterGroup) {
 		usersRoutes.PUT("/:id", r.UserHandler.UpdateUser)
 		usersRoutes.POST("/:id/reset-password", r.UserHandler.ResetPassword)
 		usersRoutes.DELETE("/:id", r.UserHandler.DeleteUser)
+		usersRoutes.POST("/:id/offboard", r.UserHandler.OffboardUser)
+		usersRoutes.POST("/:id/deactivate", r.UserHandler.DeactivateUser)
+		usersRoutes.POST("/:id/transfer-ownership", r.UserHandler.TransferOwnership)
+		usersRoutes.POST("/:id/logout", r.SessionHandler.ForceLogout)
 	}
 
 	// 用户项目关联路由（单独的路由组避免冲突）
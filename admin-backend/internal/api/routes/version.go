@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// apiVersion API 版本，每个版本在 /api/{版本号} 下注册全部接口
+// 已发布版本的请求和响应格式保持不变；需要不兼容的修改（如新的响应结构）时在新版本中注册新的处理器，
+// 其余接口各版本共用同一个处理器
+type apiVersion int
+
+const (
+	apiV1 apiVersion = iota + 1 // 稳定版本，未带版本号的 /api 为 v1 的别名
+	apiV2                       // 翻译矩阵按行返回
+)
+
+// since 返回该版本使用的处理器：版本不低于 version 时为 changed，否则为 previous
+func (v apiVersion) since(version apiVersion, changed, previous gin.HandlerFunc) gin.HandlerFunc {
+	if v >= version {
+		return changed
+	}
+	return previous
+}
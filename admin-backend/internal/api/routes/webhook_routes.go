@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupWebhookRoutes 设置出站 Webhook 管理路由
+func (r *Router) setupWebhookRoutes(authRoutes *gin.RouterGroup) {
+	// Webhook 会把项目数据推送到外部地址，仅项目所有者可以管理
+	webhookRoutes := authRoutes.Group("/projects/:project_id/webhooks")
+	webhookRoutes.Use(r.middlewareFactory.RequireProjectOwner())
+	{
+		webhookRoutes.POST("", r.WebhookHandler.Create)
+		webhookRoutes.GET("", r.WebhookHandler.GetByProjectID)
+		webhookRoutes.GET("/:webhook_id", r.WebhookHandler.GetByID)
+		webhookRoutes.PUT("/:webhook_id", r.WebhookHandler.Update)
+		webhookRoutes.DELETE("/:webhook_id", r.WebhookHandler.Delete)
+		webhookRoutes.POST("/:webhook_id/rotate-secret", r.WebhookHandler.RotateSecret)
+		webhookRoutes.GET("/:webhook_id/deliveries", r.WebhookHandler.GetDeliveries)
+	}
+}
@@ -0,0 +1,299 @@
+package apispec
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RoutesDir 相对于 admin-backend 根目录的路由注册目录
+const RoutesDir = "internal/api/routes"
+
+// 接口的访问级别，由注册路由时使用的认证和权限中间件决定
+const (
+	AccessPublic = "public" // 无需认证
+	AccessUser   = "user"   // 任意登录用户
+	AccessViewer = "viewer" // 项目查看者
+	AccessEditor = "editor" // 项目编辑者
+	AccessOwner  = "owner"  // 项目所有者
+	AccessAdmin  = "admin"  // 系统管理员
+	AccessCLI    = "cli"    // CLI API Key
+)
+
+// accessRank 登录用户访问级别的高低，级别高的权限中间件覆盖级别低的
+var accessRank = map[string]int{
+	AccessPublic: 0,
+	AccessUser:   1,
+	AccessViewer: 2,
+	AccessEditor: 3,
+	AccessOwner:  4,
+	AccessAdmin:  5,
+}
+
+// middlewareAccess 中间件工厂方法对应的访问级别
+var middlewareAccess = map[string]string{
+	"JWTAuthMiddleware":    AccessUser,
+	"APIKeyAuthMiddleware": AccessCLI,
+	"RequireProjectViewer": AccessViewer,
+	"RequireProjectEditor": AccessEditor,
+	"RequireProjectOwner":  AccessOwner,
+	"RequireAdminRole":     AccessAdmin,
+	// 按翻译ID或请求体解析项目的权限中间件
+	"RequireTranslationViewer":        AccessViewer,
+	"RequireTranslationEditor":        AccessEditor,
+	"RequireDeletedTranslationEditor": AccessEditor,
+	"RequireTranslationBodyEditor":    AccessEditor,
+	"RequireTranslationIDsEditor":     AccessEditor,
+	// 组织成员的权限分别与项目查看者和所有者对应
+	"RequireOrganizationMember": AccessViewer,
+	"RequireOrganizationAdmin":  AccessOwner,
+}
+
+// routeMethods 注册路由的 gin 方法
+var routeMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// ginPathParam gin 路由参数，如 :project_id 或 *any
+var ginPathParam = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// routeGroup 路由组的路径前缀和访问级别，与 gin.RouterGroup 一样在 Group 时复制、Use 时修改
+type routeGroup struct {
+	prefix string
+	access string
+}
+
+// use 应用中间件，不影响访问级别的中间件忽略
+func (g *routeGroup) use(expr ast.Expr) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	access, ok := middlewareAccess[selector.Sel.Name]
+	if !ok {
+		return
+	}
+	// API Key 认证的路由只能使用 API Key 调用，不受其他权限中间件影响
+	if g.access == AccessCLI {
+		return
+	}
+	if access == AccessCLI || accessRank[access] > accessRank[g.access] {
+		g.access = access
+	}
+}
+
+// routeWalker 从 SetupRoutes 开始按注册顺序遍历路由设置方法
+type routeWalker struct {
+	fset    *token.FileSet
+	methods map[string]*ast.FuncDecl
+	access  map[string]string
+}
+
+// RouteAccess 解析 rootDir（admin-backend 根目录）下的路由注册代码，返回每个接口的访问级别
+// 键为方法和规范格式的完整路径，如 "GET /api/projects/{project_id}/members"。
+// 路由路径必须是字符串字面量，路由设置方法之间传递的路由组必须是变量（其他参数如 API 版本忽略）
+func RouteAccess(rootDir string) (map[string]string, error) {
+	dir := filepath.Join(rootDir, RoutesDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	walker := &routeWalker{
+		fset:    token.NewFileSet(),
+		methods: make(map[string]*ast.FuncDecl),
+		access:  make(map[string]string),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(walker.fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Body != nil {
+				walker.methods[fn.Name.Name] = fn
+			}
+		}
+	}
+
+	setup, ok := walker.methods["SetupRoutes"]
+	if !ok {
+		return nil, fmt.Errorf("%s 中没有 SetupRoutes 方法", RoutesDir)
+	}
+	engine := &routeGroup{access: AccessPublic}
+	if err := walker.walkFunc(setup, []*routeGroup{engine}); err != nil {
+		return nil, err
+	}
+	return walker.access, nil
+}
+
+// walkFunc 遍历路由设置方法，groups 为按位置传入的路由组（非路由组参数为 nil）
+func (w *routeWalker) walkFunc(fn *ast.FuncDecl, groups []*routeGroup) error {
+	vars := make(map[string]*routeGroup)
+	i := 0
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			if i < len(groups) && groups[i] != nil {
+				vars[name.Name] = groups[i]
+			}
+			i++
+		}
+	}
+	return w.walkBlock(fn.Body.List, vars)
+}
+
+func (w *routeWalker) walkBlock(stmts []ast.Stmt, vars map[string]*routeGroup) error {
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.BlockStmt:
+			if err := w.walkBlock(stmt.List, vars); err != nil {
+				return err
+			}
+		case *ast.AssignStmt:
+			if err := w.walkAssign(stmt, vars); err != nil {
+				return err
+			}
+		case *ast.ExprStmt:
+			if call, ok := stmt.X.(*ast.CallExpr); ok {
+				if err := w.walkCall(call, vars); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// walkAssign 处理 x := y.Group("/path", middleware...)
+func (w *routeWalker) walkAssign(stmt *ast.AssignStmt, vars map[string]*routeGroup) error {
+	if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+		return nil
+	}
+	name, ok := stmt.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	call, ok := stmt.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	parent, method := w.receiver(call, vars)
+	if parent == nil || method != "Group" || len(call.Args) == 0 {
+		return nil
+	}
+
+	relative, err := w.pathLiteral(call.Args[0])
+	if err != nil {
+		return err
+	}
+	group := &routeGroup{prefix: joinRoutePath(parent.prefix, relative), access: parent.access}
+	for _, arg := range call.Args[1:] {
+		group.use(arg)
+	}
+	vars[name.Name] = group
+	return nil
+}
+
+// walkCall 处理 x.Use(...)、x.GET("/path", ...) 和 r.setupXxx(x)
+func (w *routeWalker) walkCall(call *ast.CallExpr, vars map[string]*routeGroup) error {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	if group, method := w.receiver(call, vars); group != nil {
+		switch {
+		case method == "Use":
+			for _, arg := range call.Args {
+				group.use(arg)
+			}
+		case routeMethods[method] && len(call.Args) > 0:
+			relative, err := w.pathLiteral(call.Args[0])
+			if err != nil {
+				return err
+			}
+			route := &routeGroup{access: group.access}
+			for _, arg := range call.Args[1:] {
+				route.use(arg)
+			}
+			fullPath := ginPathParam.ReplaceAllString(joinRoutePath(group.prefix, relative), "{$1}")
+			w.access[method+" "+fullPath] = route.access
+		}
+		return nil
+	}
+
+	fn, ok := w.methods[selector.Sel.Name]
+	if !ok {
+		return nil
+	}
+	groups := make([]*routeGroup, len(call.Args))
+	for i, arg := range call.Args {
+		if ident, ok := arg.(*ast.Ident); ok {
+			groups[i] = vars[ident.Name]
+		}
+	}
+	return w.walkFunc(fn, groups)
+}
+
+// receiver 返回调用的接收者路由组和方法名，接收者不是已知路由组时返回 nil
+func (w *routeWalker) receiver(call *ast.CallExpr, vars map[string]*routeGroup) (*routeGroup, string) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ""
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok {
+		return nil, ""
+	}
+	return vars[ident.Name], selector.Sel.Name
+}
+
+func (w *routeWalker) pathLiteral(expr ast.Expr) (string, error) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", fmt.Errorf("%s: 路由路径不是字符串字面量", w.fset.Position(expr.Pos()))
+	}
+	return strconv.Unquote(literal.Value)
+}
+
+// joinRoutePath 与 gin 相同地拼接路由组前缀和相对路径
+func joinRoutePath(prefix, relative string) string {
+	if relative == "" {
+		return prefix
+	}
+	joined := path.Join(prefix, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
+// sortedKeys 按字母顺序返回 map 的键
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
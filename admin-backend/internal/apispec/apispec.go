@@ -0,0 +1,55 @@
+// Package apispec 从处理器的 swag 注释生成 OpenAPI (Swagger 2.0) 规范
+//
+// 直接调用 swag 的解析器，不依赖 swag 命令行工具，供 cmd/apispec 生成文档和契约测试使用。
+package apispec
+
+import (
+	"path/filepath"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// SearchDirs 相对于 admin-backend 根目录的注释扫描目录
+// 显式列出模型所在的包，避免解析全部依赖（速度快且结果稳定）
+var SearchDirs = []string{
+	"cmd/server",
+	"internal/api/handlers",
+	"internal/api/response",
+	"internal/dto",
+	"internal/domain",
+}
+
+// MainAPIFile 包含通用 API 信息（@title、@BasePath 等）的文件
+const MainAPIFile = "main.go"
+
+// ParseDepth 依赖解析深度
+const ParseDepth = 100
+
+// silentDebugger 丢弃 swag 解析过程中的日志
+type silentDebugger struct{}
+
+func (silentDebugger) Printf(format string, v ...interface{}) {}
+
+// Build 解析 rootDir（admin-backend 根目录）下的注释并返回规范
+func Build(rootDir string) (*spec.Swagger, error) {
+	parser := swag.New(
+		swag.SetParseDependency(0),
+		swag.SetDebugger(silentDebugger{}),
+		swag.SetStrict(true),
+	)
+
+	if err := parser.ParseAPIMultiSearchDir(AbsSearchDirs(rootDir), MainAPIFile, ParseDepth); err != nil {
+		return nil, err
+	}
+	return parser.GetSwagger(), nil
+}
+
+// AbsSearchDirs 返回 rootDir 下的扫描目录
+func AbsSearchDirs(rootDir string) []string {
+	dirs := make([]string, 0, len(SearchDirs))
+	for _, dir := range SearchDirs {
+		dirs = append(dirs, filepath.Join(rootDir, dir))
+	}
+	return dirs
+}
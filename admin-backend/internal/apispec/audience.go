@@ -0,0 +1,160 @@
+package apispec
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Audience 按调用方凭证筛选的规范
+type Audience struct {
+	Name        string
+	Description string
+	// Access 包含的访问级别
+	Access []string
+	// ReadOnly 只包含 GET 接口
+	ReadOnly bool
+}
+
+// Audiences 可以生成的筛选规范，按权限从低到高排列
+var Audiences = []Audience{
+	{Name: "cli", Description: "CLI API Key 可以调用的接口", Access: []string{AccessCLI}},
+	{Name: "viewer", Description: "项目查看者可以调用的只读接口", Access: []string{AccessPublic, AccessUser, AccessViewer}, ReadOnly: true},
+	{Name: "editor", Description: "项目编辑者可以调用的接口", Access: []string{AccessPublic, AccessUser, AccessViewer, AccessEditor}},
+	{Name: "owner", Description: "项目所有者可以调用的接口", Access: []string{AccessPublic, AccessUser, AccessViewer, AccessEditor, AccessOwner}},
+	{Name: "admin", Description: "系统管理员可以调用的接口（不含 CLI 接口）", Access: []string{AccessPublic, AccessUser, AccessViewer, AccessEditor, AccessOwner, AccessAdmin}},
+}
+
+// definitionRefPattern 规范中对模型定义的引用
+var definitionRefPattern = regexp.MustCompile(`"#/definitions/([^"]+)"`)
+
+// Filter 返回只包含 audience 可以调用的接口的规范，swagger 不被修改
+// access 为 RouteAccess 的结果，规范中的接口在 access 中找不到时返回错误。
+// 不再被引用的模型定义和认证方式一并移除
+func Filter(swagger *spec.Swagger, access map[string]string, audience Audience) (*spec.Swagger, error) {
+	data, err := json.Marshal(swagger)
+	if err != nil {
+		return nil, err
+	}
+	var filtered spec.Swagger
+	if err := json.Unmarshal(data, &filtered); err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool, len(audience.Access))
+	for _, level := range audience.Access {
+		allowed[level] = true
+	}
+
+	paths := make(map[string]spec.PathItem)
+	if filtered.Paths != nil {
+		for _, route := range sortedKeys(filtered.Paths.Paths) {
+			item := filtered.Paths.Paths[route]
+			for method, op := range map[string]**spec.Operation{
+				http.MethodGet:    &item.Get,
+				http.MethodPost:   &item.Post,
+				http.MethodPut:    &item.Put,
+				http.MethodPatch:  &item.Patch,
+				http.MethodDelete: &item.Delete,
+			} {
+				if *op == nil {
+					continue
+				}
+				level, ok := access[method+" "+filtered.BasePath+route]
+				if !ok {
+					return nil, fmt.Errorf("接口 %s %s 没有注册路由，无法确定访问级别", method, route)
+				}
+				if !allowed[level] || audience.ReadOnly && method != http.MethodGet {
+					*op = nil
+				}
+			}
+			if item.Get != nil || item.Post != nil || item.Put != nil || item.Patch != nil || item.Delete != nil {
+				paths[route] = item
+			}
+		}
+		filtered.Paths.Paths = paths
+	}
+
+	if err := pruneDefinitions(&filtered); err != nil {
+		return nil, err
+	}
+	pruneSecurityDefinitions(&filtered)
+	if filtered.Info != nil {
+		filtered.Info.Title += " (" + audience.Name + ")"
+		filtered.Info.Description = strings.TrimSpace(filtered.Info.Description + "\n\n" + audience.Description + "。")
+	}
+	return &filtered, nil
+}
+
+// pruneDefinitions 移除接口不再直接或间接引用的模型定义
+func pruneDefinitions(swagger *spec.Swagger) error {
+	data, err := json.Marshal(swagger.Paths)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	pending := definitionRefs(data)
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		schema, ok := swagger.Definitions[name]
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(schema)
+		if err != nil {
+			return err
+		}
+		pending = append(pending, definitionRefs(data)...)
+	}
+
+	for name := range swagger.Definitions {
+		if !used[name] {
+			delete(swagger.Definitions, name)
+		}
+	}
+	return nil
+}
+
+// definitionRefs 返回 JSON 中引用的模型定义名称
+func definitionRefs(data []byte) []string {
+	var names []string
+	for _, match := range definitionRefPattern.FindAllSubmatch(data, -1) {
+		name := strings.NewReplacer("~1", "/", "~0", "~").Replace(string(match[1]))
+		names = append(names, name)
+	}
+	return names
+}
+
+// pruneSecurityDefinitions 移除接口不再使用的认证方式
+func pruneSecurityDefinitions(swagger *spec.Swagger) {
+	used := make(map[string]bool)
+	if swagger.Paths != nil {
+		for _, item := range swagger.Paths.Paths {
+			for _, op := range []*spec.Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+				if op == nil {
+					continue
+				}
+				for _, requirement := range op.Security {
+					for name := range requirement {
+						used[name] = true
+					}
+				}
+			}
+		}
+	}
+	for name := range swagger.SecurityDefinitions {
+		if !used[name] {
+			delete(swagger.SecurityDefinitions, name)
+		}
+	}
+}
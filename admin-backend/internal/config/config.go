@@ -28,6 +28,11 @@ type JWTConfig struct {
 	RefreshExpirationHours int
 }
 
+// GRPCConfig gRPC 服务配置
+type GRPCConfig struct {
+	Port int // gRPC 服务监听端口，0 表示不启动 gRPC 服务
+}
+
 // RedisConfig Redis配置
 type RedisConfig struct {
 	Host     string
@@ -39,7 +44,8 @@ type RedisConfig struct {
 
 // CLIConfig CLI配置
 type CLIConfig struct {
-	APIKey string
+	APIKey      string
+	PullMaxKeys int // 不分页拉取翻译时允许的最大键数量，超过时要求客户端分页拉取
 }
 
 // LibreTranslateConfig LibreTranslate 机器翻译配置
@@ -48,6 +54,134 @@ type LibreTranslateConfig struct {
 	APIKey string
 }
 
+// MachineTranslationConfig 机器翻译服务商配置
+type MachineTranslationConfig struct {
+	Provider        string // libretranslate（默认）、deepl 或 google
+	DeepLAPIKey     string
+	DeepLURL        string // 为空时按 API Key 选择 DeepL 免费版（以 :fx 结尾）或专业版的地址
+	GoogleAPIKey    string // Google Cloud Translation（v2）的 API Key
+	GoogleURL       string
+	BatchSize       int // 预翻译时每次调用服务商翻译的文本数量
+	RequestInterval int // 预翻译时两次调用服务商之间的间隔（毫秒）
+}
+
+// EventBusConfig 事件总线配置
+type EventBusConfig struct {
+	Backend  string // memory（进程内，默认）或 redis（Redis Streams）
+	Stream   string // Redis 流名称（自动加 Redis 键前缀）
+	Group    string // Redis 消费组名称
+	Consumer string // 当前实例的消费者名称，默认使用主机名
+}
+
+// PromotionConfig 环境推送配置
+type PromotionConfig struct {
+	SigningKey           string   // 推送记录的签名密钥，为空时使用 JWT 密钥
+	RemoteTimeout        int      // 从源实例拉取翻译的超时时间（秒）
+	AllowedHosts         []string // 允许作为远程源的实例主机名，为空时只能使用快照
+	AllowPrivateNetworks bool     // 是否允许连接内网、回环和链路本地地址的源实例
+}
+
+// WebhookConfig 出站 Webhook 配置
+type WebhookConfig struct {
+	Timeout              int  // 单次推送的超时时间（秒）
+	AllowPrivateNetworks bool // 是否允许推送到内网、回环和链路本地地址
+}
+
+// StorageMonitorConfig 数据库增长监控配置
+type StorageMonitorConfig struct {
+	Interval        int // 检查间隔（分钟），0 表示不定期检查
+	TranslationRows int // translations 表行数软限制
+	HistoryRows     int // 历史记录表（审计日志、翻译变更历史、Webhook 日志、发件箱）行数软限制
+	TableSizeMB     int // 单表占用空间软限制（MB）
+}
+
+// ProjectActivityConfig 项目活动跟踪配置
+type ProjectActivityConfig struct {
+	FlushInterval int // 访问和变更时间写入数据库的间隔（秒）
+	StaleDays     int // 闲置项目报告默认的天数：超过该天数没有内容变更的项目视为闲置
+}
+
+// RoleSyncConfig 全局角色与项目成员同步配置
+type RoleSyncConfig struct {
+	Interval int // 定期同步间隔（分钟），0 表示只通过管理接口手动同步
+}
+
+// TrashConfig 翻译回收站配置
+type TrashConfig struct {
+	RetentionDays int // 删除的翻译保留天数，超过后永久删除，0 表示不自动清除
+	PurgeInterval int // 定期清除的间隔（分钟），0 表示不定期清除
+}
+
+// UsageConfig 翻译拉取用量统计配置
+type UsageConfig struct {
+	FlushInterval int // Redis 中的拉取计数写入数据库的间隔（秒）
+}
+
+// ProjectDuplicateConfig 项目复制配置
+type ProjectDuplicateConfig struct {
+	SyncLimit int // 翻译不超过该条数时在请求中复制完成，否则在后台复制；0 表示总是在后台复制
+}
+
+// DeliveryConfig 公开分发配置
+type DeliveryConfig struct {
+	MaxAge int // 客户端和 CDN 可直接复用语言包的秒数，0 表示每次都需要用 ETag 重新验证
+}
+
+// InvitationConfig 邀请配置
+type InvitationConfig struct {
+	FrontendURL    string // 邀请链接使用的前端地址
+	ExpireInterval int    // 将过期邀请码标记为 expired 的间隔（分钟），0 表示不定期标记
+}
+
+// MailConfig 邮件发送（SMTP）配置
+type MailConfig struct {
+	Host     string // 为空时不发送邮件
+	Port     int
+	Username string // 为空时不进行认证
+	Password string
+	From     string // 发件人地址
+
+	TemplateDir      string // 通知邮件模板目录，<事件类型>.tmpl 覆盖对应的内置模板，为空时全部使用内置模板
+	DigestInterval   int    // 待翻译键摘要的发送间隔（小时），0 表示不发送
+	PasswordResetTTL int    // 重置密码链接的有效期（分钟）
+}
+
+// SSOConfig 单点登录（OAuth2 / OIDC）配置
+type SSOConfig struct {
+	Providers      []SSOProviderConfig
+	BaseURL        string   // 后端对外地址，回调地址为 <BaseURL>/api/auth/oidc/callback
+	DefaultRole    string   // 自动创建的用户的全局角色
+	AutoProvision  bool     // 没有对应用户时是否自动创建
+	AllowedDomains []string // 允许登录的邮箱域名，为空时不限制
+}
+
+// SSOProviderConfig 单点登录身份提供方配置
+type SSOProviderConfig struct {
+	Name         string   // 提供方名称，登录地址的 provider 参数
+	Type         string   // google, github 或 oidc
+	DisplayName  string   // 登录按钮上显示的名称
+	ClientID     string
+	ClientSecret string
+	Issuer       string   // OIDC 签发者地址，用于自动发现端点；google 默认为 https://accounts.google.com
+	Scopes       []string // 为空时使用提供方的默认范围
+}
+
+// AttachmentConfig 翻译键附件（截图）存储配置
+type AttachmentConfig struct {
+	Storage     string // local（本地磁盘，默认）或 s3
+	Dir         string // 本地存储目录
+	S3Endpoint  string // S3 兼容服务地址，为空时使用 AWS 区域地址
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// SearchConfig 翻译搜索配置
+type SearchConfig struct {
+	Backend string // like（逐行匹配，默认）或 fulltext（使用 MySQL ngram 全文索引，启动时创建索引）
+}
+
 // LogConfig 日志配置
 type LogConfig struct {
 	Level      string `json:"level"`       // 全局日志级别
@@ -63,13 +197,30 @@ type LogConfig struct {
 
 // Config 应用配置
 type Config struct {
-	Env              string
-	DB               DBConfig
-	JWT              JWTConfig
-	CLI              CLIConfig
-	Log              LogConfig
-	Redis            RedisConfig
-	LibreTranslate   LibreTranslateConfig
+	Env                string
+	DB                 DBConfig
+	JWT                JWTConfig
+	CLI                CLIConfig
+	GRPC               GRPCConfig
+	Log                LogConfig
+	Redis              RedisConfig
+	LibreTranslate     LibreTranslateConfig
+	MachineTranslation MachineTranslationConfig
+	EventBus           EventBusConfig
+	Promotion          PromotionConfig
+	Webhook            WebhookConfig
+	StorageMonitor     StorageMonitorConfig
+	ProjectActivity    ProjectActivityConfig
+	RoleSync           RoleSyncConfig
+	Trash              TrashConfig
+	Usage              UsageConfig
+	ProjectDuplicate   ProjectDuplicateConfig
+	Delivery           DeliveryConfig
+	Invitation         InvitationConfig
+	Mail               MailConfig
+	Attachment         AttachmentConfig
+	SSO                SSOConfig
+	Search             SearchConfig
 }
 
 // Load 加载配置
@@ -96,7 +247,11 @@ func Load() (*Config, error) {
 			RefreshExpirationHours: getEnvAsInt("JWT_REFRESH_EXPIRATION_HOURS", 168),
 		},
 		CLI: CLIConfig{
-			APIKey: getEnv("CLI_API_KEY", "testapikey"),
+			APIKey:      getEnv("CLI_API_KEY", "testapikey"),
+			PullMaxKeys: getEnvAsInt("CLI_PULL_MAX_KEYS", 10000),
+		},
+		GRPC: GRPCConfig{
+			Port: getEnvAsInt("GRPC_PORT", 9090),
 		},
 		Redis: RedisConfig{
 			Host:     getEnv("REDIS_HOST", "localhost"),
@@ -120,6 +275,91 @@ func Load() (*Config, error) {
 			URL:   getEnv("LIBRE_TRANSLATE_URL", "http://localhost:5000"),
 			APIKey: getEnv("LIBRE_TRANSLATE_API_KEY", ""),
 		},
+		MachineTranslation: MachineTranslationConfig{
+			Provider:        getEnv("MT_PROVIDER", "libretranslate"),
+			DeepLAPIKey:     getEnv("DEEPL_API_KEY", ""),
+			DeepLURL:        getEnv("DEEPL_API_URL", ""),
+			GoogleAPIKey:    getEnv("GOOGLE_TRANSLATE_API_KEY", ""),
+			GoogleURL:       getEnv("GOOGLE_TRANSLATE_API_URL", "https://translation.googleapis.com"),
+			BatchSize:       getEnvAsInt("MT_BATCH_SIZE", 50),
+			RequestInterval: getEnvAsInt("MT_REQUEST_INTERVAL", 200),
+		},
+		EventBus: EventBusConfig{
+			Backend:  getEnv("EVENT_BUS_BACKEND", "memory"),
+			Stream:   getEnv("EVENT_BUS_STREAM", "events"),
+			Group:    getEnv("EVENT_BUS_GROUP", "yflow"),
+			Consumer: getEnv("EVENT_BUS_CONSUMER", defaultConsumerName()),
+		},
+		Promotion: PromotionConfig{
+			SigningKey:           getEnv("PROMOTION_SIGNING_KEY", ""),
+			RemoteTimeout:        getEnvAsInt("PROMOTION_REMOTE_TIMEOUT", 60),
+			AllowedHosts:         getEnvAsList("PROMOTION_ALLOWED_HOSTS"),
+			AllowPrivateNetworks: getEnvAsBool("PROMOTION_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		Webhook: WebhookConfig{
+			Timeout:              getEnvAsInt("WEBHOOK_TIMEOUT", 10),
+			AllowPrivateNetworks: getEnvAsBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		},
+		StorageMonitor: StorageMonitorConfig{
+			Interval:        getEnvAsInt("STORAGE_MONITOR_INTERVAL", 60),
+			TranslationRows: getEnvAsInt("STORAGE_TRANSLATION_ROWS_LIMIT", 5000000),
+			HistoryRows:     getEnvAsInt("STORAGE_HISTORY_ROWS_LIMIT", 10000000),
+			TableSizeMB:     getEnvAsInt("STORAGE_TABLE_SIZE_LIMIT_MB", 10240),
+		},
+		ProjectActivity: ProjectActivityConfig{
+			FlushInterval: getEnvAsInt("PROJECT_ACTIVITY_FLUSH_INTERVAL", 60),
+			StaleDays:     getEnvAsInt("PROJECT_STALE_DAYS", 90),
+		},
+		RoleSync: RoleSyncConfig{
+			Interval: getEnvAsInt("ROLE_SYNC_INTERVAL", 0),
+		},
+		Trash: TrashConfig{
+			RetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+			PurgeInterval: getEnvAsInt("TRASH_PURGE_INTERVAL", 60),
+		},
+		Usage: UsageConfig{
+			FlushInterval: getEnvAsInt("USAGE_FLUSH_INTERVAL", 60),
+		},
+		ProjectDuplicate: ProjectDuplicateConfig{
+			SyncLimit: getEnvAsInt("PROJECT_DUPLICATE_SYNC_LIMIT", 2000),
+		},
+		Delivery: DeliveryConfig{
+			MaxAge: getEnvAsInt("DELIVERY_MAX_AGE", 60),
+		},
+		Invitation: InvitationConfig{
+			FrontendURL:    strings.TrimRight(getEnv("FRONTEND_URL", "http://localhost:3000"), "/"),
+			ExpireInterval: getEnvAsInt("INVITATION_EXPIRE_INTERVAL", 60),
+		},
+		Mail: MailConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnvAsInt("SMTP_PORT", 587),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+
+			TemplateDir:      getEnv("MAIL_TEMPLATE_DIR", ""),
+			DigestInterval:   getEnvAsInt("MAIL_DIGEST_INTERVAL", 0),
+			PasswordResetTTL: getEnvAsInt("PASSWORD_RESET_TTL", 60),
+		},
+		Attachment: AttachmentConfig{
+			Storage:     getEnv("ATTACHMENT_STORAGE", "local"),
+			Dir:         getEnv("ATTACHMENT_DIR", "data/attachments"),
+			S3Endpoint:  getEnv("S3_ENDPOINT", ""),
+			S3Region:    getEnv("S3_REGION", "us-east-1"),
+			S3Bucket:    getEnv("S3_BUCKET", ""),
+			S3AccessKey: getEnv("S3_ACCESS_KEY_ID", ""),
+			S3SecretKey: getEnv("S3_SECRET_ACCESS_KEY", ""),
+		},
+		SSO: SSOConfig{
+			Providers:      loadSSOProviders(),
+			BaseURL:        strings.TrimRight(getEnv("SSO_BASE_URL", "http://localhost:8080"), "/"),
+			DefaultRole:    getEnv("SSO_DEFAULT_ROLE", "viewer"),
+			AutoProvision:  getEnvAsBool("SSO_AUTO_PROVISION", true),
+			AllowedDomains: getEnvAsList("SSO_ALLOWED_DOMAINS"),
+		},
+		Search: SearchConfig{
+			Backend: getEnv("SEARCH_BACKEND", "like"),
+		},
 	}
 
 	if err := config.Validate(); err != nil {
@@ -195,6 +435,144 @@ func (c *Config) Validate() error {
 	if len(c.CLI.APIKey) < 16 {
 		return errors.New("CLI API key must be at least 16 characters long")
 	}
+	if c.CLI.PullMaxKeys <= 0 {
+		return errors.New("CLI pull max keys must be positive")
+	}
+
+	// gRPC 配置验证
+	if c.GRPC.Port < 0 || c.GRPC.Port > 65535 {
+		return errors.New("gRPC port must be between 0 and 65535")
+	}
+
+	// 环境推送配置验证
+	if c.Promotion.RemoteTimeout <= 0 {
+		return errors.New("promotion remote timeout must be positive")
+	}
+
+	// 出站 Webhook 配置验证
+	if c.Webhook.Timeout <= 0 {
+		return errors.New("webhook timeout must be positive")
+	}
+
+	// 数据库增长监控配置验证
+	if c.StorageMonitor.Interval < 0 || c.StorageMonitor.TranslationRows < 0 ||
+		c.StorageMonitor.HistoryRows < 0 || c.StorageMonitor.TableSizeMB < 0 {
+		return errors.New("storage monitor settings must not be negative")
+	}
+
+	// 项目活动跟踪配置验证
+	if c.ProjectActivity.FlushInterval <= 0 || c.ProjectActivity.StaleDays <= 0 {
+		return errors.New("project activity flush interval and stale days must be positive")
+	}
+
+	// 角色同步配置验证
+	if c.RoleSync.Interval < 0 {
+		return errors.New("role sync interval must not be negative")
+	}
+
+	// 回收站配置验证
+	if c.Trash.RetentionDays < 0 || c.Trash.PurgeInterval < 0 {
+		return errors.New("trash retention days and purge interval must not be negative")
+	}
+
+	// 邀请配置验证
+	if c.Invitation.ExpireInterval < 0 {
+		return errors.New("invitation expire interval must not be negative")
+	}
+
+	// 用量统计配置验证
+	if c.Usage.FlushInterval <= 0 {
+		return errors.New("usage flush interval must be positive")
+	}
+
+	// 项目复制配置验证
+	if c.ProjectDuplicate.SyncLimit < 0 {
+		return errors.New("project duplicate sync limit must not be negative")
+	}
+
+	// 邮件配置验证
+	if c.Mail.Host != "" {
+		if c.Mail.Port <= 0 || c.Mail.Port > 65535 {
+			return errors.New("SMTP port must be between 1 and 65535")
+		}
+		if c.Mail.From == "" {
+			return errors.New("SMTP from address is required when SMTP host is set")
+		}
+	}
+	if c.Mail.DigestInterval < 0 {
+		return errors.New("mail digest interval must not be negative")
+	}
+	if c.Mail.PasswordResetTTL <= 0 {
+		return errors.New("password reset TTL must be positive")
+	}
+
+	// 单点登录配置验证
+	switch c.SSO.DefaultRole {
+	case "admin", "member", "viewer":
+	default:
+		return errors.New("SSO default role must be one of: admin, member, viewer")
+	}
+	seenProviders := make(map[string]bool, len(c.SSO.Providers))
+	for _, provider := range c.SSO.Providers {
+		if seenProviders[provider.Name] {
+			return fmt.Errorf("SSO provider %s is configured more than once", provider.Name)
+		}
+		seenProviders[provider.Name] = true
+		if provider.ClientID == "" || provider.ClientSecret == "" {
+			return fmt.Errorf("SSO provider %s requires client ID and client secret", provider.Name)
+		}
+		switch provider.Type {
+		case "google", "github":
+		case "oidc":
+			if provider.Issuer == "" {
+				return fmt.Errorf("SSO provider %s requires an issuer", provider.Name)
+			}
+		default:
+			return fmt.Errorf("SSO provider %s type must be one of: google, github, oidc", provider.Name)
+		}
+	}
+
+	// 附件存储配置验证
+	switch c.Attachment.Storage {
+	case "local":
+		if c.Attachment.Dir == "" {
+			return errors.New("attachment directory is required when ATTACHMENT_STORAGE is local")
+		}
+	case "s3":
+		if c.Attachment.S3Bucket == "" || c.Attachment.S3AccessKey == "" || c.Attachment.S3SecretKey == "" {
+			return errors.New("S3 bucket and credentials are required when ATTACHMENT_STORAGE is s3")
+		}
+	default:
+		return errors.New("attachment storage must be one of: local, s3")
+	}
+
+	// 翻译搜索配置验证
+	switch c.Search.Backend {
+	case "like", "fulltext":
+	default:
+		return errors.New("search backend must be one of: like, fulltext")
+	}
+
+	// 机器翻译配置验证
+	switch c.MachineTranslation.Provider {
+	case "libretranslate":
+	case "deepl":
+		if c.MachineTranslation.DeepLAPIKey == "" {
+			return errors.New("DeepL API key is required when MT_PROVIDER is deepl")
+		}
+	case "google":
+		if c.MachineTranslation.GoogleAPIKey == "" {
+			return errors.New("Google Translate API key is required when MT_PROVIDER is google")
+		}
+	default:
+		return errors.New("machine translation provider must be one of: libretranslate, deepl, google")
+	}
+	if c.MachineTranslation.BatchSize <= 0 || c.MachineTranslation.BatchSize > 1000 {
+		return errors.New("machine translation batch size must be between 1 and 1000")
+	}
+	if c.MachineTranslation.RequestInterval < 0 {
+		return errors.New("machine translation request interval must not be negative")
+	}
 
 	// Redis配置验证
 	if c.Redis.Host == "" {
@@ -239,6 +617,11 @@ func (c *Config) Validate() error {
 		return errors.New("log max backups must be between 0 and 100")
 	}
 
+	// 事件总线配置验证
+	if c.EventBus.Backend != "memory" && c.EventBus.Backend != "redis" {
+		return errors.New("event bus backend must be one of: memory, redis")
+	}
+
 	return nil
 }
 
@@ -276,6 +659,55 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return value == "true" || value == "1"
 }
 
+// getEnvAsList 读取逗号分隔的列表，忽略空项
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// loadSSOProviders 读取 SSO_PROVIDERS 中列出的身份提供方，每个提供方的配置以 SSO_<名称>_ 为前缀
+// 名称为 google 或 github 时类型默认与名称相同，其余默认为 oidc
+func loadSSOProviders() []SSOProviderConfig {
+	var providers []SSOProviderConfig
+	for _, name := range getEnvAsList("SSO_PROVIDERS") {
+		name = strings.ToLower(name)
+		prefix := "SSO_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		providerType := "oidc"
+		if name == "google" || name == "github" {
+			providerType = name
+		}
+		provider := SSOProviderConfig{
+			Name:         name,
+			Type:         strings.ToLower(getEnv(prefix+"TYPE", providerType)),
+			DisplayName:  getEnv(prefix+"DISPLAY_NAME", name),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Issuer:       strings.TrimRight(getEnv(prefix+"ISSUER", ""), "/"),
+			Scopes:       getEnvAsList(prefix + "SCOPES"),
+		}
+		if provider.Type == "google" && provider.Issuer == "" {
+			provider.Issuer = "https://accounts.google.com"
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// defaultConsumerName 默认的事件消费者名称（主机名）
+func defaultConsumerName() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "yflow"
+	}
+	return hostname
+}
+
 // isStrongKey 检查密钥强度
 func isStrongKey(key string) bool {
 	if len(key) < 16 {
@@ -2,11 +2,14 @@ package container
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"yflow/internal/api/routes"
 	"yflow/internal/config"
@@ -88,6 +91,48 @@ func RunServer(lc fx.Lifecycle, params ServerParams) {
 	})
 }
 
+// RunGRPCServer 运行 gRPC 服务器（FX 生命周期管理），GRPC_PORT 为 0 时不启动
+func RunGRPCServer(lc fx.Lifecycle, cfg *config.Config, server *grpc.Server, logger *zap.Logger) {
+	if cfg.GRPC.Port == 0 {
+		return
+	}
+	address := fmt.Sprintf(":%d", cfg.GRPC.Port)
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// 启动时监听端口，端口被占用时应用启动失败
+			listener, err := net.Listen("tcp", address)
+			if err != nil {
+				return fmt.Errorf("gRPC server listen on %s: %w", address, err)
+			}
+			logger.Info("gRPC server starting", zap.String("address", address))
+
+			go func() {
+				if err := server.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+					logger.Error("gRPC server failed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("gRPC server shutting down...")
+
+			// 等待进行中的请求完成，超时后强制关闭
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				server.Stop()
+			}
+			return nil
+		},
+	})
+}
+
 // MiddlewareSetupFunc 中间件设置函数类型
 type MiddlewareSetupFunc func(*gin.Engine, *internal_utils.SimpleMonitor, *zap.Logger)
 
@@ -114,6 +159,7 @@ func NewApp(cfg *config.Config, setupMiddleware MiddlewareSetupFunc) *fx.App {
 
 		// 服务器生命周期管理
 		fx.Invoke(RunServer),
+		fx.Invoke(RunGRPCServer),
 	)
 }
 
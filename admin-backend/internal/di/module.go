@@ -5,7 +5,8 @@ import (
 	"yflow/internal/api/routes"
 	"yflow/internal/config"
 	"yflow/internal/domain"
-	"yflow/internal/service"
+	"yflow/internal/graph"
+	"yflow/internal/grpcapi"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -20,6 +21,15 @@ var AppModule = fx.Module("app",
 	// 缓存服务
 	fx.Provide(NewCacheService),
 
+	// 事务与事件总线（事务发件箱）及订阅方
+	fx.Provide(NewTransactor),
+	fx.Provide(NewOutboxRepository),
+	fx.Provide(NewEventBus),
+	fx.Invoke(RegisterEventSubscribers),
+	fx.Invoke(RegisterWebhookDispatcher),
+	fx.Invoke(RegisterUntranslatedDigest),
+	fx.Invoke(RegisterInvitationExpiry),
+
 	// 监控器
 	fx.Provide(NewSimpleMonitor),
 
@@ -29,41 +39,180 @@ var AppModule = fx.Module("app",
 	fx.Provide(NewLanguageRepository),
 	fx.Provide(NewTranslationRepository),
 	fx.Provide(NewProjectMemberRepository),
+	fx.Provide(NewRoleReviewRepository),
 	fx.Provide(NewInvitationRepository),
+	fx.Provide(NewInboundWebhookRepository),
+	fx.Provide(NewWebhookRepository),
+	fx.Provide(NewImportProfileRepository),
+	fx.Provide(NewReleaseThresholdRepository),
+	fx.Provide(NewAssignmentRepository),
+	fx.Provide(NewAttachmentRepository),
+	fx.Provide(NewNamespaceRepository),
+	fx.Provide(NewTagRepository),
+	fx.Provide(NewBranchRepository),
+	fx.Provide(NewReleaseRepository),
+	fx.Provide(NewFigmaRepository),
+	fx.Provide(NewAuditLogRepository),
+	fx.Provide(NewUserOffboardingRepository),
+	fx.Provide(NewTranslationHistoryRepository),
+	fx.Provide(NewPromotionRepository),
+	fx.Provide(NewKeyLinkRepository),
+	fx.Provide(NewProjectDuplicateJobRepository),
+	fx.Provide(NewStorageStatsRepository),
+	fx.Provide(NewPersonalAccessTokenRepository),
+	fx.Provide(NewAPIKeyRepository),
+	fx.Provide(NewOrganizationRepository),
+	fx.Provide(NewOrganizationMemberRepository),
+	fx.Provide(NewUserIdentityRepository),
+	fx.Provide(NewPasswordResetRepository),
+	fx.Provide(NewUsageStatRepository),
+	fx.Provide(NewTranslationMemoryRepository),
+	fx.Provide(NewTranslationSearchRepository),
+	fx.Provide(NewGlossaryRepository),
 
 	// Auth Service (无缓存)
 	fx.Provide(NewAuthService),
 
 	// Services (带缓存装饰器)
 	fx.Provide(NewUserService),
+	fx.Provide(NewUserProfileService),
 	fx.Provide(NewProjectService),
 	fx.Provide(NewLanguageService),
 	fx.Provide(NewTranslationService),
 	fx.Provide(NewDashboardService),
 	fx.Provide(NewProjectMemberService),
+	fx.Provide(NewRoleSyncService),
 	fx.Provide(NewInvitationService),
+	fx.Provide(NewInboundWebhookService),
+	fx.Provide(NewWebhookService),
+	fx.Provide(NewImportProfileService),
+	fx.Provide(NewReleaseGateService),
+	fx.Provide(NewAssignmentService),
+	fx.Provide(NewAttachmentStorage),
+	fx.Provide(NewAttachmentService),
+	fx.Provide(NewNamespaceService),
+	fx.Provide(NewTagService),
+	fx.Provide(NewBranchService),
+	fx.Provide(NewTranslationRollbackService),
+	fx.Provide(NewTranslationTrashService),
+	fx.Provide(NewReleaseService),
+	fx.Provide(NewDeliveryService),
+	fx.Provide(NewTranslationDeltaService),
+	fx.Provide(NewKeyLockService),
+	fx.Provide(NewKeyPushService),
+	fx.Provide(NewGlossaryService),
+	fx.Provide(NewQAService),
+	fx.Provide(NewTranslationMemoryService),
+	fx.Provide(NewSearchService),
+	fx.Provide(NewFigmaService),
+	fx.Provide(NewKeyPrefixService),
+	fx.Provide(NewKeyRenameService),
+	fx.Provide(NewKeyCopyService),
+	fx.Provide(NewAuditLogService),
+	fx.Provide(NewPromotionService),
+	fx.Provide(NewStorageMonitorService),
+	fx.Provide(NewPersonalAccessTokenService),
+	fx.Provide(NewAPIKeyService),
+	fx.Provide(NewOrganizationService),
+	fx.Provide(NewPasswordResetService),
+	fx.Provide(NewSSOService),
+	fx.Provide(NewSessionService),
+	fx.Provide(NewProjectConfigService),
+	fx.Provide(NewProjectDuplicateService),
+	fx.Provide(NewBulkDeleteService),
+	fx.Provide(NewWatchService),
+	fx.Provide(NewComplianceService),
+	fx.Provide(NewTranslationHistoryService),
+	fx.Provide(NewProjectActivityService),
+	fx.Provide(NewUsageService),
+	fx.Provide(NewWebhookTemplateService),
+	fx.Provide(NewDeadLetterService),
 
 	// Machine Translation Service
-	fx.Provide(func(cfg *config.Config) *config.LibreTranslateConfig {
-		return &cfg.LibreTranslate
-	}),
-	fx.Provide(service.NewLibreTranslateService),
+	fx.Provide(NewMachineTranslationService),
+	fx.Provide(NewAutoTranslateService),
+
+	// Mailer
+	fx.Provide(NewMailer),
+	fx.Provide(NewEmailNotifier),
 
 	// Handlers
 	fx.Provide(handlers.NewUserHandler),
 	fx.Provide(handlers.NewProjectHandler),
 	fx.Provide(handlers.NewLanguageHandler),
-	fx.Provide(func(repo domain.LanguageRepository, ts domain.TranslationService, mt *service.LibreTranslateService, logger *zap.Logger) *handlers.TranslationHandler {
-		return handlers.NewTranslationHandler(ts, mt, repo, logger)
+	fx.Provide(func(repo domain.LanguageRepository, ts domain.TranslationService, mt domain.MachineTranslationService, at domain.AutoTranslateService, logger *zap.Logger) *handlers.TranslationHandler {
+		return handlers.NewTranslationHandler(ts, mt, at, repo, logger)
 	}),
 	fx.Provide(handlers.NewProjectMemberHandler),
-	fx.Provide(handlers.NewCLIHandler),
+	fx.Provide(handlers.NewRoleSyncHandler),
+	fx.Provide(func(ts domain.TranslationService, ps domain.ProjectService, ks domain.KeyPushService, cfg *config.Config) *handlers.CLIHandler {
+		return handlers.NewCLIHandler(ts, ps, ks, cfg.CLI.PullMaxKeys)
+	}),
 	fx.Provide(handlers.NewDashboardHandler),
 	fx.Provide(handlers.NewInvitationHandler),
+	fx.Provide(handlers.NewInboundWebhookHandler),
+	fx.Provide(handlers.NewWebhookHandler),
+	fx.Provide(handlers.NewKeyPrefixHandler),
+	fx.Provide(handlers.NewKeyRenameHandler),
+	fx.Provide(handlers.NewKeyCopyHandler),
+	fx.Provide(handlers.NewAuditLogHandler),
+	fx.Provide(handlers.NewPromotionHandler),
+	fx.Provide(handlers.NewStorageHandler),
+	fx.Provide(handlers.NewAccessTokenHandler),
+	fx.Provide(handlers.NewAPIKeyHandler),
+	fx.Provide(handlers.NewOrganizationHandler),
+	fx.Provide(handlers.NewPasswordResetHandler),
+	fx.Provide(handlers.NewSessionHandler),
+	fx.Provide(handlers.NewUserProfileHandler),
+	fx.Provide(func(ss domain.SSOService, cfg *config.Config, logger *zap.Logger) *handlers.SSOHandler {
+		return handlers.NewSSOHandler(ss, cfg.Invitation.FrontendURL, logger)
+	}),
+	fx.Provide(handlers.NewProjectConfigHandler),
+	fx.Provide(handlers.NewProjectDuplicateHandler),
+	fx.Provide(handlers.NewBulkDeleteHandler),
+	fx.Provide(handlers.NewCLIWatchHandler),
+	fx.Provide(handlers.NewProjectEventHandler),
+	fx.Provide(handlers.NewKeyLockHandler),
+	fx.Provide(func(ps domain.ProjectService, ds domain.TranslationDeltaService, cfg *config.Config) *handlers.CLIDeltaHandler {
+		return handlers.NewCLIDeltaHandler(ps, ds, cfg.CLI.PullMaxKeys)
+	}),
+	fx.Provide(handlers.NewComplianceHandler),
+	fx.Provide(handlers.NewProjectActivityHandler),
+	fx.Provide(handlers.NewUsageHandler),
+	fx.Provide(handlers.NewWebhookTemplateHandler),
+	fx.Provide(handlers.NewDeadLetterHandler),
+	fx.Provide(handlers.NewImportProfileHandler),
+	fx.Provide(handlers.NewReleaseGateHandler),
+	fx.Provide(handlers.NewAssignmentHandler),
+	fx.Provide(handlers.NewAttachmentHandler),
+	fx.Provide(handlers.NewNamespaceHandler),
+	fx.Provide(handlers.NewTagHandler),
+	fx.Provide(handlers.NewBranchHandler),
+	fx.Provide(handlers.NewRollbackHandler),
+	fx.Provide(handlers.NewHistoryHandler),
+	fx.Provide(handlers.NewTrashHandler),
+	fx.Provide(handlers.NewReleaseHandler),
+	fx.Provide(func(ds domain.DeliveryService, cfg *config.Config) *handlers.DeliveryHandler {
+		return handlers.NewDeliveryHandler(ds, cfg.Delivery.MaxAge)
+	}),
+	fx.Provide(handlers.NewFigmaHandler),
+	fx.Provide(handlers.NewQAHandler),
+	fx.Provide(handlers.NewGlossaryHandler),
+	fx.Provide(handlers.NewTranslationMemoryHandler),
+	fx.Provide(handlers.NewSearchHandler),
+	fx.Provide(handlers.NewGraphQLHandler),
 
 	// Router
 	fx.Provide(routes.NewRouter),
 
+	// GraphQL 服务
+	fx.Provide(graph.NewResolver),
+	fx.Provide(graph.NewServer),
+
+	// gRPC 服务
+	fx.Provide(grpcapi.NewServer),
+	fx.Provide(grpcapi.NewGRPCServer),
+
 	// Logger
 	fx.Provide(NewLogger),
 
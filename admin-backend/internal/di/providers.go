@@ -2,6 +2,7 @@ package di
 
 import (
 	"fmt"
+	"time"
 
 	"yflow/internal/config"
 	"yflow/internal/domain"
@@ -34,6 +35,75 @@ func NewCacheService(client *repository.RedisClient) domain.CacheService {
 	return service.NewCacheService(client)
 }
 
+// NewTransactor 提供事务管理器
+func NewTransactor(db *gorm.DB) domain.Transactor {
+	return repository.NewTransactor(db)
+}
+
+// NewOutboxRepository 提供事务发件箱仓储
+func NewOutboxRepository(db *gorm.DB) domain.OutboxRepository {
+	return repository.NewOutboxRepository(db)
+}
+
+// NewEventBus 提供事件总线
+// 服务发布的事件先写入事务发件箱，提交后投递到 memory 或 redis 后端；发件箱投递器随应用启停
+func NewEventBus(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	client *repository.RedisClient,
+	outboxRepo domain.OutboxRepository,
+	transactor domain.Transactor,
+	logger *zap.Logger,
+) domain.EventBus {
+	delivery := newDeliveryEventBus(lc, cfg, client, logger)
+
+	dispatcher := service.NewOutboxDispatcher(outboxRepo, delivery, logger)
+	lc.Append(fx.Hook{
+		OnStart: dispatcher.Start,
+		OnStop:  dispatcher.Stop,
+	})
+
+	return service.NewOutboxEventBus(outboxRepo, transactor, delivery, logger)
+}
+
+// newDeliveryEventBus 创建实际投递事件的总线
+// redis 后端在应用启动时开始消费事件，停止时等待正在处理的事件完成
+func newDeliveryEventBus(lc fx.Lifecycle, cfg *config.Config, client *repository.RedisClient, logger *zap.Logger) domain.EventBus {
+	if cfg.EventBus.Backend != "redis" {
+		return service.NewInMemoryEventBus(logger)
+	}
+
+	bus := service.NewRedisStreamEventBus(client, cfg.EventBus.Stream, cfg.EventBus.Group, cfg.EventBus.Consumer, logger)
+	lc.Append(fx.Hook{
+		OnStart: bus.Start,
+		OnStop:  bus.Stop,
+	})
+	return bus
+}
+
+// RegisterEventSubscribers 注册事件订阅方
+func RegisterEventSubscribers(bus domain.EventBus, cache domain.CacheService) {
+	if cache != nil {
+		service.RegisterCacheInvalidationSubscribers(bus, cache)
+	}
+}
+
+// NewWatchService 提供 CLI 监听模式服务
+// 使用 redis 事件总线时通过 Redis Pub/Sub 将变更广播给所有实例
+func NewWatchService(lc fx.Lifecycle, cfg *config.Config, bus domain.EventBus, languageRepo domain.LanguageRepository, client *repository.RedisClient, logger *zap.Logger) domain.WatchService {
+	var relay *repository.RedisClient
+	if cfg.EventBus.Backend == "redis" {
+		relay = client
+	}
+	hub := service.NewWatchHub(languageRepo, relay, logger)
+	bus.Subscribe(domain.EventTranslationUpdated, "cli-watch", hub.HandleEvent)
+	lc.Append(fx.Hook{
+		OnStart: hub.Start,
+		OnStop:  hub.Stop,
+	})
+	return hub
+}
+
 // NewUserRepository 提供用户仓储
 func NewUserRepository(db *gorm.DB) domain.UserRepository {
 	return repository.NewUserRepository(db)
@@ -59,11 +129,51 @@ func NewProjectMemberRepository(db *gorm.DB) domain.ProjectMemberRepository {
 	return repository.NewProjectMemberRepository(db)
 }
 
+// NewRoleReviewRepository 提供角色复核仓储
+func NewRoleReviewRepository(db *gorm.DB) domain.RoleReviewRepository {
+	return repository.NewRoleReviewRepository(db)
+}
+
 // NewInvitationRepository 提供邀请码仓储
 func NewInvitationRepository(db *gorm.DB) domain.InvitationRepository {
 	return repository.NewInvitationRepository(db)
 }
 
+// NewInboundWebhookRepository 提供入站 Webhook 仓储
+func NewInboundWebhookRepository(db *gorm.DB) domain.InboundWebhookRepository {
+	return repository.NewInboundWebhookRepository(db)
+}
+
+// NewWebhookRepository 提供出站 Webhook 仓储
+func NewWebhookRepository(db *gorm.DB) domain.WebhookRepository {
+	return repository.NewWebhookRepository(db)
+}
+
+// NewPromotionRepository 提供环境推送记录仓储
+func NewPromotionRepository(db *gorm.DB) domain.PromotionRepository {
+	return repository.NewPromotionRepository(db)
+}
+
+// NewKeyLinkRepository 提供跨项目键链接仓储
+func NewKeyLinkRepository(db *gorm.DB) domain.KeyLinkRepository {
+	return repository.NewKeyLinkRepository(db)
+}
+
+// NewProjectDuplicateJobRepository 提供项目复制任务仓储
+func NewProjectDuplicateJobRepository(db *gorm.DB) domain.ProjectDuplicateJobRepository {
+	return repository.NewProjectDuplicateJobRepository(db)
+}
+
+// NewUserOffboardingRepository 提供用户离职处理仓储
+func NewUserOffboardingRepository(db *gorm.DB) domain.UserOffboardingRepository {
+	return repository.NewUserOffboardingRepository(db)
+}
+
+// NewAuditLogRepository 提供审计日志仓储
+func NewAuditLogRepository(db *gorm.DB) domain.AuditLogRepository {
+	return repository.NewAuditLogRepository(db)
+}
+
 // NewAuthService 提供认证服务
 func NewAuthService(cfg *config.Config) domain.AuthService {
 	return service.NewAuthService(cfg.JWT)
@@ -73,9 +183,15 @@ func NewAuthService(cfg *config.Config) domain.AuthService {
 func NewUserService(
 	repo domain.UserRepository,
 	auth domain.AuthService,
+	roleSync domain.RoleSyncService,
+	transactor domain.Transactor,
+	memberRepo domain.ProjectMemberRepository,
+	offboardingRepo domain.UserOffboardingRepository,
+	auditRepo domain.AuditLogRepository,
+	sessions domain.SessionService,
 	cache domain.CacheService,
 ) domain.UserService {
-	base := service.NewUserService(repo, auth)
+	base := service.NewUserService(repo, auth, roleSync, transactor, memberRepo, offboardingRepo, auditRepo, sessions)
 	if cache != nil {
 		return service.NewCachedUserService(base, cache)
 	}
@@ -87,9 +203,14 @@ func NewProjectService(
 	projectRepo domain.ProjectRepository,
 	userRepo domain.UserRepository,
 	memberRepo domain.ProjectMemberRepository,
+	organizationService domain.OrganizationService,
+	languageRepo domain.LanguageRepository,
+	auditLogRepo domain.AuditLogRepository,
 	cache domain.CacheService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
 ) domain.ProjectService {
-	base := service.NewProjectService(projectRepo, userRepo, memberRepo)
+	base := service.NewProjectService(projectRepo, userRepo, memberRepo, organizationService, languageRepo, auditLogRepo, eventBus, transactor)
 	if cache != nil {
 		return service.NewCachedProjectService(base, cache)
 	}
@@ -113,9 +234,13 @@ func NewTranslationService(
 	translationRepo domain.TranslationRepository,
 	projectRepo domain.ProjectRepository,
 	languageRepo domain.LanguageRepository,
+	namespaceRepo domain.NamespaceRepository,
+	releaseRepo domain.ReleaseRepository,
 	cache domain.CacheService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
 ) domain.TranslationService {
-	base := service.NewTranslationService(translationRepo, projectRepo, languageRepo)
+	base := service.NewTranslationService(translationRepo, projectRepo, languageRepo, namespaceRepo, releaseRepo, eventBus, transactor)
 	if cache != nil {
 		return service.NewCachedTranslationService(base, cache)
 	}
@@ -127,9 +252,11 @@ func NewDashboardService(
 	projectRepo domain.ProjectRepository,
 	languageRepo domain.LanguageRepository,
 	translationRepo domain.TranslationRepository,
+	historyRepo domain.TranslationHistoryRepository,
+	userRepo domain.UserRepository,
 	cache domain.CacheService,
 ) domain.DashboardService {
-	base := service.NewDashboardService(projectRepo, languageRepo, translationRepo)
+	base := service.NewDashboardService(projectRepo, languageRepo, translationRepo, historyRepo, userRepo)
 	if cache != nil {
 		return service.NewCachedDashboardService(base, cache)
 	}
@@ -141,18 +268,761 @@ func NewProjectMemberService(
 	memberRepo domain.ProjectMemberRepository,
 	userRepo domain.UserRepository,
 	projectRepo domain.ProjectRepository,
+	orgMemberRepo domain.OrganizationMemberRepository,
+	notifier domain.EmailNotifier,
 ) domain.ProjectMemberService {
-	return service.NewProjectMemberService(memberRepo, userRepo, projectRepo)
+	return service.NewProjectMemberService(memberRepo, userRepo, projectRepo, orgMemberRepo, notifier)
 }
 
 // NewInvitationService 提供邀请码服务
 func NewInvitationService(
 	invitationRepo domain.InvitationRepository,
 	userRepo domain.UserRepository,
+	userService domain.UserService,
+	notifier domain.EmailNotifier,
+	transactor domain.Transactor,
 	cfg *config.Config,
 ) domain.InvitationService {
-	frontendURL := "" // 可以从配置中读取
-	return service.NewInvitationService(invitationRepo, userRepo, frontendURL)
+	return service.NewInvitationService(invitationRepo, userRepo, userService, notifier, transactor, cfg.Invitation.FrontendURL)
+}
+
+// NewMailer 提供 SMTP 邮件发送服务，未配置 SMTP_HOST 时不可用
+func NewMailer(cfg *config.Config) domain.Mailer {
+	return service.NewSMTPMailer(cfg.Mail.Host, cfg.Mail.Port, cfg.Mail.Username, cfg.Mail.Password, cfg.Mail.From)
+}
+
+// NewEmailNotifier 提供通知邮件服务，启动时加载 MAIL_TEMPLATE_DIR 中的邮件模板
+func NewEmailNotifier(cfg *config.Config, mailer domain.Mailer) (domain.EmailNotifier, error) {
+	templates, err := service.NewEmailTemplates(cfg.Mail.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+	return service.NewEmailNotifier(mailer, templates), nil
+}
+
+// NewPasswordResetRepository 提供找回密码令牌仓储
+func NewPasswordResetRepository(db *gorm.DB) domain.PasswordResetRepository {
+	return repository.NewPasswordResetRepository(db)
+}
+
+// NewUserIdentityRepository 提供单点登录账号关联仓储
+func NewUserIdentityRepository(db *gorm.DB) domain.UserIdentityRepository {
+	return repository.NewUserIdentityRepository(db)
+}
+
+// NewSSOService 提供单点登录服务，登录会话使用 JWT 密钥派生的密钥签名
+func NewSSOService(
+	userRepo domain.UserRepository,
+	identityRepo domain.UserIdentityRepository,
+	authService domain.AuthService,
+	sessions domain.SessionService,
+	cfg *config.Config,
+	logger *zap.Logger,
+) (domain.SSOService, error) {
+	return service.NewSSOService(cfg.SSO, cfg.JWT.Secret, userRepo, identityRepo, authService, sessions, logger)
+}
+
+// NewSessionService 提供登录会话服务，会话保存在 Redis 中，有效期与刷新令牌一致
+func NewSessionService(cache domain.CacheService, cfg *config.Config, logger *zap.Logger) domain.SessionService {
+	return service.NewSessionService(cache,
+		time.Duration(cfg.JWT.ExpirationHours)*time.Hour,
+		time.Duration(cfg.JWT.RefreshExpirationHours)*time.Hour,
+		logger)
+}
+
+// NewPasswordResetService 提供找回密码服务
+func NewPasswordResetService(
+	userRepo domain.UserRepository,
+	resetRepo domain.PasswordResetRepository,
+	notifier domain.EmailNotifier,
+	cfg *config.Config,
+) domain.PasswordResetService {
+	return service.NewPasswordResetService(userRepo, resetRepo, notifier, cfg.Invitation.FrontendURL,
+		time.Duration(cfg.Mail.PasswordResetTTL)*time.Minute)
+}
+
+// RegisterUntranslatedDigest 定期发送待翻译键摘要邮件，随应用启停
+func RegisterUntranslatedDigest(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	userRepo domain.UserRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	notifier domain.EmailNotifier,
+	logger *zap.Logger,
+) {
+	digest := service.NewUntranslatedDigest(projectRepo, memberRepo, userRepo, languageRepo, translationRepo, notifier,
+		cfg.Invitation.FrontendURL, time.Duration(cfg.Mail.DigestInterval)*time.Hour, logger)
+	lc.Append(fx.Hook{
+		OnStart: digest.Start,
+		OnStop:  digest.Stop,
+	})
+}
+
+// RegisterInvitationExpiry 定期将过期的邀请码标记为 expired，随应用启停
+func RegisterInvitationExpiry(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	invitationRepo domain.InvitationRepository,
+	auditRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	logger *zap.Logger,
+) {
+	expiry := service.NewInvitationExpiry(invitationRepo, auditRepo, eventBus, transactor,
+		time.Duration(cfg.Invitation.ExpireInterval)*time.Minute, logger)
+	lc.Append(fx.Hook{
+		OnStart: expiry.Start,
+		OnStop:  expiry.Stop,
+	})
+}
+
+// NewImportProfileRepository 提供表格导入映射配置仓储
+func NewImportProfileRepository(db *gorm.DB) domain.ImportProfileRepository {
+	return repository.NewImportProfileRepository(db)
+}
+
+// NewImportProfileService 提供表格导入映射配置服务
+func NewImportProfileService(
+	profileRepo domain.ImportProfileRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationService domain.TranslationService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.ImportProfileService {
+	return service.NewImportProfileService(profileRepo, projectRepo, languageRepo, translationService, eventBus, transactor)
+}
+
+// NewReleaseThresholdRepository 提供发布门槛仓储
+func NewReleaseThresholdRepository(db *gorm.DB) domain.ReleaseThresholdRepository {
+	return repository.NewReleaseThresholdRepository(db)
+}
+
+// NewReleaseGateService 提供发布门槛服务
+func NewReleaseGateService(
+	thresholdRepo domain.ReleaseThresholdRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	transactor domain.Transactor,
+) domain.ReleaseGateService {
+	return service.NewReleaseGateService(thresholdRepo, projectRepo, languageRepo, translationRepo, transactor)
+}
+
+// NewAssignmentRepository 提供任务分配仓储
+func NewAssignmentRepository(db *gorm.DB) domain.AssignmentRepository {
+	return repository.NewAssignmentRepository(db)
+}
+
+// NewAssignmentService 提供任务分配服务
+func NewAssignmentService(
+	assignmentRepo domain.AssignmentRepository,
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	transactor domain.Transactor,
+) domain.AssignmentService {
+	return service.NewAssignmentService(assignmentRepo, projectRepo, memberRepo, languageRepo, translationRepo, transactor)
+}
+
+// NewAttachmentRepository 提供附件仓储
+func NewAttachmentRepository(db *gorm.DB) domain.AttachmentRepository {
+	return repository.NewAttachmentRepository(db)
+}
+
+// NewAttachmentStorage 按 ATTACHMENT_STORAGE 提供附件存储
+func NewAttachmentStorage(cfg *config.Config) domain.AttachmentStorage {
+	switch cfg.Attachment.Storage {
+	case "s3":
+		return service.NewS3AttachmentStorage(&cfg.Attachment)
+	default:
+		return service.NewLocalAttachmentStorage(cfg.Attachment.Dir)
+	}
+}
+
+// NewUserProfileService 提供个人资料服务，头像保存在附件存储中
+func NewUserProfileService(
+	userRepo domain.UserRepository,
+	storage domain.AttachmentStorage,
+	cache domain.CacheService,
+) domain.UserProfileService {
+	return service.NewUserProfileService(userRepo, storage, cache)
+}
+
+// NewAttachmentService 提供附件服务
+func NewAttachmentService(
+	attachmentRepo domain.AttachmentRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	storage domain.AttachmentStorage,
+	cache domain.CacheService,
+) domain.AttachmentService {
+	return service.NewAttachmentService(attachmentRepo, projectRepo, translationRepo, storage, cache)
+}
+
+// NewNamespaceRepository 提供命名空间仓储
+func NewNamespaceRepository(db *gorm.DB) domain.NamespaceRepository {
+	return repository.NewNamespaceRepository(db)
+}
+
+// NewNamespaceService 提供键命名空间服务
+func NewNamespaceService(
+	namespaceRepo domain.NamespaceRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.NamespaceService {
+	return service.NewNamespaceService(namespaceRepo, projectRepo, translationRepo, eventBus, transactor)
+}
+
+// NewTagRepository 提供标签仓储
+func NewTagRepository(db *gorm.DB) domain.TagRepository {
+	return repository.NewTagRepository(db)
+}
+
+// NewTagService 提供键标签服务
+func NewTagService(
+	tagRepo domain.TagRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.TagService {
+	return service.NewTagService(tagRepo, projectRepo, translationRepo, eventBus, transactor)
+}
+
+// NewBranchRepository 提供翻译分支仓储
+func NewBranchRepository(db *gorm.DB) domain.BranchRepository {
+	return repository.NewBranchRepository(db)
+}
+
+// NewBranchService 提供翻译分支服务
+func NewBranchService(
+	branchRepo domain.BranchRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	auditLogRepo domain.AuditLogRepository,
+	transactor domain.Transactor,
+) domain.BranchService {
+	return service.NewBranchService(branchRepo, projectRepo, languageRepo, translationRepo, translationService, auditLogRepo, transactor)
+}
+
+// NewReleaseRepository 提供发布版本仓储
+func NewReleaseRepository(db *gorm.DB) domain.ReleaseRepository {
+	return repository.NewReleaseRepository(db)
+}
+
+// NewReleaseService 提供发布版本服务
+func NewReleaseService(
+	releaseRepo domain.ReleaseRepository,
+	projectRepo domain.ProjectRepository,
+	auditLogRepo domain.AuditLogRepository,
+	gateService domain.ReleaseGateService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.ReleaseService {
+	return service.NewReleaseService(releaseRepo, projectRepo, auditLogRepo, gateService, eventBus, transactor)
+}
+
+// NewDeliveryService 提供公开分发服务
+func NewDeliveryService(
+	projectRepo domain.ProjectRepository,
+	releaseRepo domain.ReleaseRepository,
+	cacheService domain.CacheService,
+) domain.DeliveryService {
+	return service.NewDeliveryService(projectRepo, releaseRepo, cacheService)
+}
+
+// NewTranslationDeltaService 提供增量同步服务
+func NewTranslationDeltaService(
+	projectRepo domain.ProjectRepository,
+	releaseRepo domain.ReleaseRepository,
+	translationRepo domain.TranslationRepository,
+	historyRepo domain.TranslationHistoryRepository,
+) domain.TranslationDeltaService {
+	return service.NewTranslationDeltaService(projectRepo, releaseRepo, translationRepo, historyRepo)
+}
+
+// NewKeyLockService 提供键编辑锁服务，编辑锁保存在 Redis 中
+func NewKeyLockService(cache domain.CacheService) domain.KeyLockService {
+	return service.NewKeyLockService(cache)
+}
+
+// NewKeyPushService 提供 CLI 推送键服务
+func NewKeyPushService(translationService domain.TranslationService, languageService domain.LanguageService) domain.KeyPushService {
+	return service.NewKeyPushService(translationService, languageService)
+}
+
+// NewTranslationRollbackService 提供翻译回滚服务
+func NewTranslationRollbackService(
+	translationRepo domain.TranslationRepository,
+	historyRepo domain.TranslationHistoryRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.TranslationRollbackService {
+	return service.NewTranslationRollbackService(translationRepo, historyRepo, projectRepo, languageRepo, auditLogRepo, eventBus, transactor)
+}
+
+// NewMachineTranslationService 按 MT_PROVIDER 提供机器翻译服务商
+func NewMachineTranslationService(cfg *config.Config) domain.MachineTranslationService {
+	switch cfg.MachineTranslation.Provider {
+	case "deepl":
+		return service.NewDeepLService(&cfg.MachineTranslation)
+	case "google":
+		return service.NewGoogleTranslateService(&cfg.MachineTranslation)
+	default:
+		return service.NewLibreTranslateService(&cfg.LibreTranslate)
+	}
+}
+
+// NewAutoTranslateService 提供机器翻译填充服务
+func NewAutoTranslateService(
+	machineTranslation domain.MachineTranslationService,
+	translationService domain.TranslationService,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	cfg *config.Config,
+) domain.AutoTranslateService {
+	return service.NewAutoTranslateService(machineTranslation, translationService, translationRepo, projectRepo, languageRepo,
+		cfg.MachineTranslation.BatchSize, time.Duration(cfg.MachineTranslation.RequestInterval)*time.Millisecond)
+}
+
+// NewGlossaryRepository 提供术语表仓储
+func NewGlossaryRepository(db *gorm.DB) domain.GlossaryRepository {
+	return repository.NewGlossaryRepository(db)
+}
+
+// NewGlossaryService 提供术语表服务
+func NewGlossaryService(
+	glossaryRepo domain.GlossaryRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	transactor domain.Transactor,
+) domain.GlossaryService {
+	return service.NewGlossaryService(glossaryRepo, projectRepo, languageRepo, transactor)
+}
+
+// NewQAService 提供翻译质量检查服务
+func NewQAService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	glossaryRepo domain.GlossaryRepository,
+) domain.QAService {
+	return service.NewQAService(translationRepo, projectRepo, languageRepo, glossaryRepo)
+}
+
+// NewTranslationMemoryRepository 提供翻译记忆库仓储
+func NewTranslationMemoryRepository(db *gorm.DB) domain.TranslationMemoryRepository {
+	return repository.NewTranslationMemoryRepository(db)
+}
+
+// NewTranslationMemoryService 提供翻译记忆库服务
+func NewTranslationMemoryService(
+	memoryRepo domain.TranslationMemoryRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+	memberRepo domain.ProjectMemberRepository,
+) domain.TranslationMemoryService {
+	return service.NewTranslationMemoryService(memoryRepo, languageRepo, userRepo, memberRepo)
+}
+
+// NewTranslationSearchRepository 提供翻译搜索仓储，SEARCH_BACKEND 为 fulltext 时使用全文索引
+func NewTranslationSearchRepository(db *gorm.DB, cfg *config.Config) domain.TranslationSearchRepository {
+	if cfg.Search.Backend == "fulltext" {
+		return repository.NewFullTextSearchRepository(db)
+	}
+	return repository.NewTranslationSearchRepository(db)
+}
+
+// NewSearchService 提供翻译搜索服务
+func NewSearchService(
+	searchRepo domain.TranslationSearchRepository,
+	userRepo domain.UserRepository,
+	memberRepo domain.ProjectMemberRepository,
+) domain.SearchService {
+	return service.NewSearchService(searchRepo, userRepo, memberRepo)
+}
+
+// NewFigmaRepository 提供 Figma 设计稿仓储
+func NewFigmaRepository(db *gorm.DB) domain.FigmaRepository {
+	return repository.NewFigmaRepository(db)
+}
+
+// NewFigmaService 提供 Figma 插件服务
+func NewFigmaService(
+	figmaRepo domain.FigmaRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	transactor domain.Transactor,
+) domain.FigmaService {
+	return service.NewFigmaService(figmaRepo, projectRepo, languageRepo, translationRepo, translationService, transactor)
+}
+
+// NewInboundWebhookService 提供入站 Webhook 服务
+func NewInboundWebhookService(
+	webhookRepo domain.InboundWebhookRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.InboundWebhookService {
+	return service.NewInboundWebhookService(webhookRepo, projectRepo, languageRepo, translationRepo, translationService, eventBus, transactor)
+}
+
+// NewKeyPrefixService 提供按键名前缀批量操作服务
+func NewKeyPrefixService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.KeyPrefixService {
+	return service.NewKeyPrefixService(translationRepo, projectRepo, auditLogRepo, eventBus, transactor)
+}
+
+// NewKeyRenameService 提供翻译键重命名服务
+func NewKeyRenameService(
+	translationRepo domain.TranslationRepository,
+	branchRepo domain.BranchRepository,
+	attachmentRepo domain.AttachmentRepository,
+	assignmentRepo domain.AssignmentRepository,
+	figmaRepo domain.FigmaRepository,
+	projectRepo domain.ProjectRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) domain.KeyRenameService {
+	return service.NewKeyRenameService(translationRepo, branchRepo, attachmentRepo, assignmentRepo, figmaRepo, projectRepo, auditLogRepo, eventBus, transactor)
+}
+
+// NewKeyCopyService 提供跨项目复制键服务，订阅翻译变更事件同步链接的键
+func NewKeyCopyService(
+	translationRepo domain.TranslationRepository,
+	linkRepo domain.KeyLinkRepository,
+	projectRepo domain.ProjectRepository,
+	memberService domain.ProjectMemberService,
+	auditLogRepo domain.AuditLogRepository,
+	bus domain.EventBus,
+	transactor domain.Transactor,
+	logger *zap.Logger,
+) domain.KeyCopyService {
+	keyCopy := service.NewKeyCopyService(translationRepo, linkRepo, projectRepo, memberService, auditLogRepo, bus, transactor, logger)
+	bus.Subscribe(domain.EventTranslationUpdated, "key-links", keyCopy.HandleEvent)
+	return keyCopy
+}
+
+// NewBulkDeleteService 提供按条件批量删除翻译服务
+// 确认令牌使用 JWT 密钥签名
+func NewBulkDeleteService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	cfg *config.Config,
+) domain.BulkDeleteService {
+	return service.NewBulkDeleteService(translationRepo, projectRepo, languageRepo, auditLogRepo, eventBus, transactor, cfg.JWT.Secret)
+}
+
+// NewPromotionService 提供环境推送服务
+// 未配置签名密钥时使用 JWT 密钥签名推送记录，远程源只允许 PROMOTION_ALLOWED_HOSTS 中的实例
+func NewPromotionService(
+	promotionRepo domain.PromotionRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	auditLogRepo domain.AuditLogRepository,
+	transactor domain.Transactor,
+	cfg *config.Config,
+) domain.PromotionService {
+	signingKey := cfg.Promotion.SigningKey
+	if signingKey == "" {
+		signingKey = cfg.JWT.Secret
+	}
+	return service.NewPromotionService(
+		promotionRepo, projectRepo, languageRepo, translationRepo, translationService, auditLogRepo, transactor,
+		signingKey, service.PromotionRemoteOptions{
+			AllowedHosts:         cfg.Promotion.AllowedHosts,
+			AllowPrivateNetworks: cfg.Promotion.AllowPrivateNetworks,
+			Timeout:              time.Duration(cfg.Promotion.RemoteTimeout) * time.Second,
+		},
+	)
+}
+
+// NewAuditLogService 提供审计日志服务
+func NewAuditLogService(auditLogRepo domain.AuditLogRepository, projectRepo domain.ProjectRepository) domain.AuditLogService {
+	return service.NewAuditLogService(auditLogRepo, projectRepo)
+}
+
+// NewTranslationHistoryRepository 提供翻译变更历史仓储
+func NewTranslationHistoryRepository(db *gorm.DB) domain.TranslationHistoryRepository {
+	return repository.NewTranslationHistoryRepository(db)
+}
+
+// NewComplianceService 提供历史导出和合规报告服务
+func NewComplianceService(
+	historyRepo domain.TranslationHistoryRepository,
+	auditLogRepo domain.AuditLogRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+) domain.ComplianceService {
+	return service.NewComplianceService(historyRepo, auditLogRepo, projectRepo, languageRepo, userRepo)
+}
+
+// NewTranslationHistoryService 提供翻译变更历史查询服务
+func NewTranslationHistoryService(
+	historyRepo domain.TranslationHistoryRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+) domain.TranslationHistoryService {
+	return service.NewTranslationHistoryService(historyRepo, projectRepo, languageRepo, userRepo)
+}
+
+// NewStorageStatsRepository 提供数据表统计信息仓储
+func NewStorageStatsRepository(db *gorm.DB) domain.StorageStatsRepository {
+	return repository.NewStorageStatsRepository(db)
+}
+
+// NewStorageMonitorService 提供数据库增长监控服务，定期检查随应用启停
+func NewStorageMonitorService(
+	lc fx.Lifecycle,
+	repo domain.StorageStatsRepository,
+	eventBus domain.EventBus,
+	cfg *config.Config,
+	logger *zap.Logger,
+) domain.StorageMonitorService {
+	monitor := service.NewStorageMonitor(repo, eventBus, service.StorageThresholds{
+		TranslationRows: int64(cfg.StorageMonitor.TranslationRows),
+		HistoryRows:     int64(cfg.StorageMonitor.HistoryRows),
+		TableBytes:      int64(cfg.StorageMonitor.TableSizeMB) << 20,
+	}, time.Duration(cfg.StorageMonitor.Interval)*time.Minute, logger)
+	lc.Append(fx.Hook{
+		OnStart: monitor.Start,
+		OnStop:  monitor.Stop,
+	})
+	return monitor
+}
+
+// NewRoleSyncService 提供全局角色与项目成员同步服务，定期同步随应用启停
+func NewRoleSyncService(
+	lc fx.Lifecycle,
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	reviewRepo domain.RoleReviewRepository,
+	transactor domain.Transactor,
+	cfg *config.Config,
+	logger *zap.Logger,
+) domain.RoleSyncService {
+	roleSync := service.NewRoleSyncService(userRepo, projectRepo, memberRepo, reviewRepo, transactor,
+		time.Duration(cfg.RoleSync.Interval)*time.Minute, logger)
+	lc.Append(fx.Hook{
+		OnStart: roleSync.Start,
+		OnStop:  roleSync.Stop,
+	})
+	return roleSync
+}
+
+// NewTranslationTrashService 提供翻译回收站服务，定期清除随应用启停
+func NewTranslationTrashService(
+	lc fx.Lifecycle,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	cfg *config.Config,
+	logger *zap.Logger,
+) domain.TranslationTrashService {
+	trash := service.NewTranslationTrashService(translationRepo, projectRepo, eventBus, transactor,
+		time.Duration(cfg.Trash.RetentionDays)*24*time.Hour, time.Duration(cfg.Trash.PurgeInterval)*time.Minute, logger)
+	lc.Append(fx.Hook{
+		OnStart: trash.Start,
+		OnStop:  trash.Stop,
+	})
+	return trash
+}
+
+// NewProjectActivityService 提供项目活动跟踪和闲置项目服务
+// 订阅翻译变更和导入完成事件记录内容变更时间，定期写入随应用启停，停止时写入剩余的活动时间
+func NewProjectActivityService(
+	lc fx.Lifecycle,
+	projectRepo domain.ProjectRepository,
+	projectService domain.ProjectService,
+	auditLogRepo domain.AuditLogRepository,
+	bus domain.EventBus,
+	cfg *config.Config,
+	logger *zap.Logger,
+) domain.ProjectActivityService {
+	activity := service.NewProjectActivityService(projectRepo, projectService, auditLogRepo,
+		cfg.ProjectActivity.StaleDays, time.Duration(cfg.ProjectActivity.FlushInterval)*time.Second, logger)
+	bus.Subscribe(domain.EventTranslationUpdated, "project-activity", activity.HandleEvent)
+	bus.Subscribe(domain.EventImportCompleted, "project-activity", activity.HandleEvent)
+	lc.Append(fx.Hook{
+		OnStart: activity.Start,
+		OnStop:  activity.Stop,
+	})
+	return activity
+}
+
+// NewUsageStatRepository 提供翻译拉取用量统计仓储
+func NewUsageStatRepository(db *gorm.DB) domain.UsageStatRepository {
+	return repository.NewUsageStatRepository(db)
+}
+
+// NewUsageService 提供翻译拉取用量统计服务，定期将 Redis 中的计数写入数据库，停止时写入剩余的计数
+func NewUsageService(
+	lc fx.Lifecycle,
+	cache domain.CacheService,
+	usageRepo domain.UsageStatRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	cfg *config.Config,
+	logger *zap.Logger,
+) domain.UsageService {
+	usage := service.NewUsageService(cache, usageRepo, projectRepo, languageRepo,
+		time.Duration(cfg.Usage.FlushInterval)*time.Second, logger)
+	lc.Append(fx.Hook{
+		OnStart: usage.Start,
+		OnStop:  usage.Stop,
+	})
+	return usage
+}
+
+// NewWebhookService 提供出站 Webhook 服务
+func NewWebhookService(webhookRepo domain.WebhookRepository, projectRepo domain.ProjectRepository) domain.WebhookService {
+	return service.NewWebhookService(webhookRepo, projectRepo)
+}
+
+// RegisterWebhookDispatcher 订阅出站 Webhook 支持的事件，后台推送随应用启停
+func RegisterWebhookDispatcher(
+	lc fx.Lifecycle,
+	cfg *config.Config,
+	bus domain.EventBus,
+	webhookRepo domain.WebhookRepository,
+	projectRepo domain.ProjectRepository,
+	logger *zap.Logger,
+) {
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, projectRepo, service.WebhookDeliveryOptions{
+		Timeout:              time.Duration(cfg.Webhook.Timeout) * time.Second,
+		AllowPrivateNetworks: cfg.Webhook.AllowPrivateNetworks,
+	}, logger)
+	for _, eventType := range []domain.EventType{domain.EventTranslationUpdated, domain.EventImportCompleted, domain.EventReleasePublished} {
+		bus.Subscribe(eventType, "webhooks", dispatcher.HandleEvent)
+	}
+	lc.Append(fx.Hook{
+		OnStart: dispatcher.Start,
+		OnStop:  dispatcher.Stop,
+	})
+}
+
+// NewWebhookTemplateService 提供 Webhook 消息模板服务
+func NewWebhookTemplateService(projectRepo domain.ProjectRepository, webhookRepo domain.WebhookRepository) domain.WebhookTemplateService {
+	return service.NewWebhookTemplateService(projectRepo, webhookRepo)
+}
+
+// NewDeadLetterService 提供发件箱死信管理服务
+func NewDeadLetterService(outboxRepo domain.OutboxRepository, logger *zap.Logger) domain.DeadLetterService {
+	return service.NewDeadLetterService(outboxRepo, logger)
+}
+
+// NewPersonalAccessTokenRepository 提供个人访问令牌仓储
+func NewPersonalAccessTokenRepository(db *gorm.DB) domain.PersonalAccessTokenRepository {
+	return repository.NewPersonalAccessTokenRepository(db)
+}
+
+// NewPersonalAccessTokenService 提供个人访问令牌服务
+func NewPersonalAccessTokenService(tokenRepo domain.PersonalAccessTokenRepository) domain.PersonalAccessTokenService {
+	return service.NewPersonalAccessTokenService(tokenRepo)
+}
+
+// NewAPIKeyRepository 提供 API Key 仓储
+func NewAPIKeyRepository(db *gorm.DB) domain.APIKeyRepository {
+	return repository.NewAPIKeyRepository(db)
+}
+
+// NewAPIKeyService 提供 API Key 服务
+func NewAPIKeyService(keyRepo domain.APIKeyRepository, projectRepo domain.ProjectRepository) domain.APIKeyService {
+	return service.NewAPIKeyService(keyRepo, projectRepo)
+}
+
+// NewOrganizationRepository 提供组织仓储
+func NewOrganizationRepository(db *gorm.DB) domain.OrganizationRepository {
+	return repository.NewOrganizationRepository(db)
+}
+
+// NewOrganizationMemberRepository 提供组织成员仓储
+func NewOrganizationMemberRepository(db *gorm.DB) domain.OrganizationMemberRepository {
+	return repository.NewOrganizationMemberRepository(db)
+}
+
+// NewOrganizationService 提供组织服务
+func NewOrganizationService(
+	orgRepo domain.OrganizationRepository,
+	orgMemberRepo domain.OrganizationMemberRepository,
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	projectMemberRepo domain.ProjectMemberRepository,
+	languageRepo domain.LanguageRepository,
+	transactor domain.Transactor,
+) domain.OrganizationService {
+	return service.NewOrganizationService(orgRepo, orgMemberRepo, userRepo, projectRepo, projectMemberRepo, languageRepo, transactor)
+}
+
+// NewProjectConfigService 提供项目配置导出/导入服务
+func NewProjectConfigService(
+	projectService domain.ProjectService,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+	memberService domain.ProjectMemberService,
+	webhookService domain.InboundWebhookService,
+	auditRepo domain.AuditLogRepository,
+	transactor domain.Transactor,
+) domain.ProjectConfigService {
+	return service.NewProjectConfigService(projectService, languageRepo, userRepo, memberService, webhookService, auditRepo, transactor)
+}
+
+// NewProjectDuplicateService 提供项目复制服务，停止时中断后台复制并将任务标记为失败
+func NewProjectDuplicateService(
+	lc fx.Lifecycle,
+	projectService domain.ProjectService,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	namespaceRepo domain.NamespaceRepository,
+	thresholdRepo domain.ReleaseThresholdRepository,
+	memberRepo domain.ProjectMemberRepository,
+	jobRepo domain.ProjectDuplicateJobRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	cfg *config.Config,
+	logger *zap.Logger,
+) domain.ProjectDuplicateService {
+	duplicate := service.NewProjectDuplicateService(projectService, projectRepo, translationRepo, namespaceRepo, thresholdRepo,
+		memberRepo, jobRepo, auditLogRepo, eventBus, transactor, cfg.ProjectDuplicate.SyncLimit, logger)
+	lc.Append(fx.Hook{
+		OnStop: duplicate.Stop,
+	})
+	return duplicate
 }
 
 // NewSimpleMonitor 提供简单监控器
@@ -0,0 +1,32 @@
+package domain
+
+import "context"
+
+// actorContextKey 当前操作人在 context 中的键
+type actorContextKey struct{}
+
+// WithActor 在 context 中记录当前操作人，供没有显式传入用户的写操作记录变更历史
+func WithActor(ctx context.Context, userID uint64) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, userID)
+}
+
+// ActorFromContext 获取当前操作人，未记录时返回 0
+func ActorFromContext(ctx context.Context) uint64 {
+	userID, _ := ctx.Value(actorContextKey{}).(uint64)
+	return userID
+}
+
+// historyOperationContextKey 变更历史操作类型在 context 中的键
+type historyOperationContextKey struct{}
+
+// WithHistoryOperation 在 context 中指定写入翻译值时记录的变更历史操作类型（如 machine_translate），
+// 替代按写入结果判断的 create、update 和 restore
+func WithHistoryOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, historyOperationContextKey{}, operation)
+}
+
+// HistoryOperationFromContext 获取指定的变更历史操作类型，未指定时返回空字符串
+func HistoryOperationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(historyOperationContextKey{}).(string)
+	return operation
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+)
+
+// AttachmentStorage 附件文件存储接口
+type AttachmentStorage interface {
+	// Put 保存文件，同一 key 已存在时覆盖
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get 读取文件，文件不存在时返回 ErrAttachmentFileMissing
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete 删除文件，文件不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// AttachmentContentURL 附件内容的访问地址（相对于服务根地址）
+func AttachmentContentURL(projectID, attachmentID uint64) string {
+	return "/api/projects/" + strconv.FormatUint(projectID, 10) + "/attachments/" + strconv.FormatUint(attachmentID, 10) + "/content"
+}
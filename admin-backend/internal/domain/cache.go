@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -29,15 +32,11 @@ type CacheService interface {
 	HGet(ctx context.Context, key, field string) (string, error)
 	HGetAll(ctx context.Context, key string) (map[string]string, error)
 	HDel(ctx context.Context, key string, fields ...string) error
+	HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error)
+
+	// 设置过期时间
+	Expire(ctx context.Context, key string, expiration time.Duration) error
 
-	// 缓存键生成
-	GetTranslationKey(projectID uint64) string
-	GetTranslationMatrixKey(projectID uint64, keyword string) string
-	GetDashboardStatsKey() string
-	GetLanguagesKey() string
-	GetProjectKey(projectID uint64) string
-	GetProjectsKey() string
-	
 	// 添加随机过期时间防止雪崩
 	AddRandomExpiration(baseExpiration time.Duration) time.Duration
 }
@@ -49,15 +48,197 @@ const (
 	LongExpiration    = 12 * time.Hour
 	ShortExpiration   = 5 * time.Minute // 用于空值缓存
 
-	// 缓存键前缀
-	TranslationKeyPrefix    = "translation:"
-	TranslationMatrixPrefix = "translation_matrix:"
-	DashboardStatsKey       = "dashboard:stats"
-	LanguagesKey            = "languages"
-	ProjectKeyPrefix        = "project:"
-	ProjectsKey             = "projects"
+	// HTTP 响应缓存作用域
+	ResponseScopeLanguages = "languages"
 )
 
+// ProjectResponseScope 项目级 HTTP 响应缓存作用域
+func ProjectResponseScope(projectID uint64) string {
+	return fmt.Sprintf("project:%d", projectID)
+}
+
+// cacheKeySeparator 缓存键各部分之间的分隔符
+const cacheKeySeparator = ":"
+
+// CacheKeyBuilder 缓存键构建器
+// 项目级缓存键位于 project:{id}: 命名空间下，通配符模式只由构建器生成且总是以分隔符结尾，
+// 因此一个项目的模式不会匹配其他项目的键（project:1:* 不会匹配 project:12:...）。
+// 调用方不应自行拼接键名或在键名后追加 *。
+type CacheKeyBuilder struct{}
+
+// CacheKeys 缓存键构建器
+var CacheKeys CacheKeyBuilder
+
+// Project 项目级缓存键
+func (CacheKeyBuilder) Project(projectID uint64) ProjectCacheKeys {
+	return ProjectCacheKeys{namespace: fmt.Sprintf("project:%d:", projectID)}
+}
+
+// AllProjectsPattern 匹配所有项目命名空间下 section 部分的缓存（如语言变化时清除所有项目的矩阵）
+func (CacheKeyBuilder) AllProjectsPattern(section string) string {
+	return "project:*:" + section + cacheKeySeparator + "*"
+}
+
+// ProjectList 项目列表缓存键，parts 为分页和搜索参数
+func (CacheKeyBuilder) ProjectList(parts ...string) string {
+	return joinCacheKey("projects", parts)
+}
+
+// ProjectListPattern 匹配所有项目列表缓存
+func (CacheKeyBuilder) ProjectListPattern() string {
+	return "projects" + cacheKeySeparator + "*"
+}
+
+// User 用户缓存键
+func (CacheKeyBuilder) User(userID uint64) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// AccessToken 访问令牌验证结果缓存键
+func (CacheKeyBuilder) AccessToken(token string) string {
+	return "token:" + token
+}
+
+// RefreshToken 刷新令牌验证结果缓存键
+func (CacheKeyBuilder) RefreshToken(token string) string {
+	return "refresh_token:" + token
+}
+
+// UserSessions 用户登录会话的哈希表键，字段为会话ID
+func (CacheKeyBuilder) UserSessions(userID uint64) string {
+	return fmt.Sprintf("sessions:user:%d", userID)
+}
+
+// RevokedSession 已撤销会话的黑名单键，保留到该会话签发的访问令牌全部过期
+func (CacheKeyBuilder) RevokedSession(sessionID string) string {
+	return "sessions:revoked:" + sessionID
+}
+
+// KeyLocks 项目中键编辑锁的哈希表键，字段为键名
+// 不在项目命名空间下，清除项目缓存时不影响正在编辑的用户
+func (CacheKeyBuilder) KeyLocks(projectID uint64) string {
+	return fmt.Sprintf("key_locks:project:%d", projectID)
+}
+
+// Languages 语言列表缓存键
+func (CacheKeyBuilder) Languages() string {
+	return "languages"
+}
+
+// OrganizationLanguages 组织的语言列表缓存键，organizationID 为 0 时为共享语言列表的缓存键
+func (k CacheKeyBuilder) OrganizationLanguages(organizationID uint64) string {
+	if organizationID == 0 {
+		return k.Languages()
+	}
+	return fmt.Sprintf("languages:org:%d", organizationID)
+}
+
+// DashboardStats 仪表板统计缓存键
+func (CacheKeyBuilder) DashboardStats() string {
+	return "dashboard:stats"
+}
+
+// DashboardActivity 仪表板最近 days 天活动趋势的缓存键
+func (CacheKeyBuilder) DashboardActivity(days int) string {
+	return fmt.Sprintf("dashboard:activity:%d", days)
+}
+
+// DashboardActivityPattern 匹配所有活动趋势缓存
+func (CacheKeyBuilder) DashboardActivityPattern() string {
+	return "dashboard:activity:*"
+}
+
+// UsageCounters 翻译拉取用量计数的哈希表键，date 为 UTC 日期（20060102）
+func (CacheKeyBuilder) UsageCounters(date string) string {
+	return "usage:" + date
+}
+
+// Response HTTP 响应缓存键前缀，后接请求路径和参数
+func (CacheKeyBuilder) Response(scope string) string {
+	return "response:" + scope + cacheKeySeparator
+}
+
+// ResponsePattern 匹配作用域下的所有 HTTP 响应缓存
+func (b CacheKeyBuilder) ResponsePattern(scope string) string {
+	return b.Response(scope) + "*"
+}
+
+// AllResponsesPattern 匹配所有 HTTP 响应缓存
+func (CacheKeyBuilder) AllResponsesPattern() string {
+	return "response:*"
+}
+
+// 项目命名空间下的缓存分区
+const (
+	CacheSectionTranslations = "translations"
+	CacheSectionMatrix       = "matrix"
+	CacheSectionStats        = "stats"
+)
+
+// ProjectCacheKeys 项目命名空间下的缓存键
+type ProjectCacheKeys struct {
+	namespace string
+}
+
+// Namespace 项目命名空间前缀，以分隔符结尾
+func (k ProjectCacheKeys) Namespace() string {
+	return k.namespace
+}
+
+// Pattern 匹配项目的所有缓存
+func (k ProjectCacheKeys) Pattern() string {
+	return k.namespace + "*"
+}
+
+// Info 项目详情缓存键
+func (k ProjectCacheKeys) Info() string {
+	return k.namespace + "info"
+}
+
+// Translations 翻译列表缓存键，parts 为分页参数
+func (k ProjectCacheKeys) Translations(parts ...string) string {
+	return joinCacheKey(k.namespace+CacheSectionTranslations, parts)
+}
+
+// TranslationsPattern 匹配项目的所有翻译列表缓存
+func (k ProjectCacheKeys) TranslationsPattern() string {
+	return k.namespace + CacheSectionTranslations + cacheKeySeparator + "*"
+}
+
+// Matrix 翻译矩阵缓存键，parts 为分页、搜索和排序参数
+func (k ProjectCacheKeys) Matrix(parts ...string) string {
+	return joinCacheKey(k.namespace+CacheSectionMatrix, parts)
+}
+
+// MatrixPattern 匹配项目的所有翻译矩阵缓存
+func (k ProjectCacheKeys) MatrixPattern() string {
+	return k.namespace + CacheSectionMatrix + cacheKeySeparator + "*"
+}
+
+// Stats 项目各语言翻译完成情况的缓存键
+func (k ProjectCacheKeys) Stats() string {
+	return joinCacheKey(k.namespace+CacheSectionStats, []string{"languages"})
+}
+
+// StatsPattern 匹配项目的所有统计缓存
+func (k ProjectCacheKeys) StatsPattern() string {
+	return k.namespace + CacheSectionStats + cacheKeySeparator + "*"
+}
+
+// Delivery 公开分发的语言包缓存键
+// 发布版本不可修改，因此按发布版本ID缓存，发布新版本后自然使用新的键
+func (k ProjectCacheKeys) Delivery(releaseID uint64, locale string) string {
+	return joinCacheKey(k.namespace+"delivery", []string{strconv.FormatUint(releaseID, 10), locale})
+}
+
+// joinCacheKey 以分隔符连接缓存键各部分
+func joinCacheKey(base string, parts []string) string {
+	if len(parts) == 0 {
+		return base
+	}
+	return base + cacheKeySeparator + strings.Join(parts, cacheKeySeparator)
+}
+
 // ErrCacheMiss 缓存未命中错误
 var ErrCacheMiss = CacheError("cache miss")
 
@@ -110,18 +110,28 @@ func NewAppErrorWithContext(errType ErrorType, code, message string, context map
 // 预定义的领域错误
 var (
 	// 用户相关错误
-	ErrUserNotFound      = NewAppError(ErrorTypeNotFound, "USER_NOT_FOUND", "用户不存在")
-	ErrInvalidPassword   = NewAppError(ErrorTypeUnauthorized, "INVALID_PASSWORD", "密码错误")
-	ErrUserExists        = NewAppError(ErrorTypeConflict, "USER_EXISTS", "用户已存在")
-	ErrEmailExists       = NewAppError(ErrorTypeConflict, "EMAIL_EXISTS", "邮箱已存在")
-	ErrInvalidToken      = NewAppError(ErrorTypeUnauthorized, "INVALID_TOKEN", "无效的令牌")
-	ErrInvalidRole       = NewAppError(ErrorTypeValidation, "INVALID_ROLE", "无效的角色")
-	ErrCannotDeleteAdmin = NewAppError(ErrorTypeForbidden, "CANNOT_DELETE_ADMIN", "不能删除管理员用户")
+	ErrUserNotFound          = NewAppError(ErrorTypeNotFound, "USER_NOT_FOUND", "用户不存在")
+	ErrInvalidPassword       = NewAppError(ErrorTypeUnauthorized, "INVALID_PASSWORD", "密码错误")
+	ErrUserExists            = NewAppError(ErrorTypeConflict, "USER_EXISTS", "用户已存在")
+	ErrEmailExists           = NewAppError(ErrorTypeConflict, "EMAIL_EXISTS", "邮箱已存在")
+	ErrInvalidToken          = NewAppError(ErrorTypeUnauthorized, "INVALID_TOKEN", "无效的令牌")
+	ErrInvalidRole           = NewAppError(ErrorTypeValidation, "INVALID_ROLE", "无效的角色")
+	ErrCannotDeleteAdmin     = NewAppError(ErrorTypeForbidden, "CANNOT_DELETE_ADMIN", "不能删除管理员用户")
+	ErrCannotDeactivateAdmin = NewAppError(ErrorTypeForbidden, "CANNOT_DEACTIVATE_ADMIN", "不能停用管理员用户")
+	ErrUserOwnsResources     = NewAppError(ErrorTypeConflict, "USER_OWNS_RESOURCES", "用户仍是项目唯一的 owner 或有有效的邀请码，请先转移所有权")
+	ErrInvalidReassignTarget = NewAppError(ErrorTypeValidation, "INVALID_REASSIGN_TARGET", "接收内容的用户必须是其他启用的用户")
+	ErrInvalidUserProfile    = NewAppError(ErrorTypeValidation, "INVALID_USER_PROFILE", "无效的个人资料")
+	ErrInvalidAvatar         = NewAppError(ErrorTypeValidation, "INVALID_AVATAR", "无效的头像")
+	ErrAvatarNotFound        = NewAppError(ErrorTypeNotFound, "AVATAR_NOT_FOUND", "用户没有头像")
 
 	// 项目相关错误
-	ErrProjectNotFound = NewAppError(ErrorTypeNotFound, "PROJECT_NOT_FOUND", "项目不存在")
-	ErrProjectExists   = NewAppError(ErrorTypeConflict, "PROJECT_EXISTS", "项目已存在")
-	ErrInvalidSlug     = NewAppError(ErrorTypeValidation, "INVALID_SLUG", "无效的项目标识")
+	ErrProjectNotFound       = NewAppError(ErrorTypeNotFound, "PROJECT_NOT_FOUND", "项目不存在")
+	ErrProjectExists         = NewAppError(ErrorTypeConflict, "PROJECT_EXISTS", "项目已存在")
+	ErrInvalidSlug           = NewAppError(ErrorTypeValidation, "INVALID_SLUG", "无效的项目标识")
+	ErrSlugExists            = NewAppError(ErrorTypeConflict, "SLUG_EXISTS", "项目标识已被占用")
+	ErrInvalidExportTemplate = NewAppError(ErrorTypeValidation, "INVALID_EXPORT_TEMPLATE", "无效的导出文件命名模板")
+	ErrProjectProtected      = NewAppError(ErrorTypeForbidden, "PROJECT_PROTECTED", "项目已开启删除保护，需要项目所有者先关闭保护")
+	ErrInvalidSourceLanguage = NewAppError(ErrorTypeValidation, "INVALID_SOURCE_LANGUAGE", "源语言不存在或未启用")
 
 	// 语言相关错误
 	ErrLanguageNotFound = NewAppError(ErrorTypeNotFound, "LANGUAGE_NOT_FOUND", "语言不存在")
@@ -129,15 +139,108 @@ var (
 	ErrInvalidLanguage  = NewAppError(ErrorTypeValidation, "INVALID_LANGUAGE", "无效的语言代码")
 
 	// 翻译相关错误
-	ErrTranslationNotFound = NewAppError(ErrorTypeNotFound, "TRANSLATION_NOT_FOUND", "翻译不存在")
-	ErrTranslationExists   = NewAppError(ErrorTypeConflict, "TRANSLATION_EXISTS", "翻译已存在")
-	ErrInvalidKey          = NewAppError(ErrorTypeValidation, "INVALID_KEY", "无效的翻译键")
+	ErrTranslationNotFound   = NewAppError(ErrorTypeNotFound, "TRANSLATION_NOT_FOUND", "翻译不存在")
+	ErrTranslationExists     = NewAppError(ErrorTypeConflict, "TRANSLATION_EXISTS", "翻译已存在")
+	ErrTranslationConflict   = NewAppError(ErrorTypeConflict, "TRANSLATION_CONFLICT", "翻译已被其他人修改，请基于最新的译文重新编辑")
+	ErrInvalidKey            = NewAppError(ErrorTypeValidation, "INVALID_KEY", "无效的翻译键")
+	ErrInvalidKeyPrefix      = NewAppError(ErrorTypeValidation, "INVALID_KEY_PREFIX", "无效的键名前缀")
+	ErrKeyPrefixConflict     = NewAppError(ErrorTypeConflict, "KEY_PREFIX_CONFLICT", "重命名后的键名与已有键冲突")
+	ErrKeyNotFound           = NewAppError(ErrorTypeNotFound, "KEY_NOT_FOUND", "翻译键不存在")
+	ErrKeyExists             = NewAppError(ErrorTypeConflict, "KEY_EXISTS", "键名已存在")
+	ErrKeyLinkNotFound       = NewAppError(ErrorTypeNotFound, "KEY_LINK_NOT_FOUND", "键链接不存在")
+	ErrInvalidCopySource     = NewAppError(ErrorTypeValidation, "INVALID_COPY_SOURCE", "源项目不能是目标项目")
+	ErrCrossOrganizationCopy = NewAppError(ErrorTypeValidation, "CROSS_ORGANIZATION_COPY", "不能在不同组织的项目之间复制键")
+
+	// 按条件批量删除相关错误
+	ErrInvalidBulkDeleteFilter = NewAppError(ErrorTypeValidation, "INVALID_BULK_DELETE_FILTER", "至少需要指定一个有效的筛选条件")
+	ErrBulkDeleteTokenRequired = NewAppError(ErrorTypeValidation, "BULK_DELETE_TOKEN_REQUIRED", "请先预览并提交确认令牌")
+	ErrBulkDeleteTokenInvalid  = NewAppError(ErrorTypeConflict, "BULK_DELETE_TOKEN_INVALID", "确认令牌无效、已过期或匹配的翻译已变化，请重新预览")
+
+	// 迁移导入相关错误
+	ErrUnsupportedMigrationSource = NewAppError(ErrorTypeValidation, "UNSUPPORTED_MIGRATION_SOURCE", "不支持的迁移来源")
+	ErrInvalidMigrationBundle     = NewAppError(ErrorTypeValidation, "INVALID_MIGRATION_BUNDLE", "无法解析的导出包")
+
+	// 项目配置相关错误
+	ErrInvalidProjectConfig = NewAppError(ErrorTypeValidation, "INVALID_PROJECT_CONFIG", "无效的项目配置")
+
+	// 导出相关错误
+	ErrInvalidExportStatus  = NewAppError(ErrorTypeValidation, "INVALID_EXPORT_STATUS", "无效的导出状态，可选值：approved")
+	ErrInvalidExportMissing = NewAppError(ErrorTypeValidation, "INVALID_EXPORT_MISSING", "无效的缺失翻译处理方式，可选值：skip、empty、source_fallback")
+	ErrExportSourceLanguage = NewAppError(ErrorTypeValidation, "EXPORT_SOURCE_LANGUAGE_MISSING", "未设置默认语言，无法使用源语言回退")
+
+	// XLIFF 相关错误
+	ErrInvalidXLIFF            = NewAppError(ErrorTypeValidation, "INVALID_XLIFF", "无法解析的 XLIFF 文件")
+	ErrUnsupportedXLIFFVersion = NewAppError(ErrorTypeValidation, "UNSUPPORTED_XLIFF_VERSION", "不支持的 XLIFF 版本，可选值：1.2、2.0")
+	ErrXLIFFSourceLanguage     = NewAppError(ErrorTypeValidation, "XLIFF_SOURCE_LANGUAGE_MISSING", "未设置默认语言，无法确定 XLIFF 的源语言")
+	ErrXLIFFNoTargetLanguage   = NewAppError(ErrorTypeValidation, "XLIFF_NO_TARGET_LANGUAGE", "没有可导出的目标语言")
+	ErrXLIFFMultipleTargets    = NewAppError(ErrorTypeValidation, "XLIFF_MULTIPLE_TARGETS", "XLIFF 2.0 文件只能包含一种目标语言，请指定目标语言或按文件导出")
+	ErrXLIFFNoTranslations     = NewAppError(ErrorTypeValidation, "XLIFF_NO_TRANSLATIONS", "XLIFF 文件中没有可导入的译文")
+
+	// YAML 相关错误
+	ErrInvalidYAML          = NewAppError(ErrorTypeValidation, "INVALID_YAML", "无法解析的 YAML 文件")
+	ErrUnsupportedYAMLStyle = NewAppError(ErrorTypeValidation, "UNSUPPORTED_YAML_STYLE", "不支持的 YAML 文件风格，可选值：rails、symfony")
+	ErrYAMLNoTranslations   = NewAppError(ErrorTypeValidation, "YAML_NO_TRANSLATIONS", "YAML 文件中没有可导入的翻译")
+
+	// Gettext 相关错误
+	ErrGettextSourceLanguage   = NewAppError(ErrorTypeValidation, "GETTEXT_SOURCE_LANGUAGE_MISSING", "未设置默认语言，无法确定 gettext 的 msgid")
+	ErrGettextNoTargetLanguage = NewAppError(ErrorTypeValidation, "GETTEXT_NO_TARGET_LANGUAGE", "没有可导出的目标语言")
+	ErrGettextMultipleTargets  = NewAppError(ErrorTypeValidation, "GETTEXT_MULTIPLE_TARGETS", "PO 文件只能包含一种目标语言，请指定目标语言或按文件导出")
+
+	// 单语言文件（移动端格式、ARB）导出相关错误
+	ErrExportLanguageMissing = NewAppError(ErrorTypeValidation, "EXPORT_LANGUAGE_MISSING", "未设置默认语言，请通过 target_language 指定要导出的语言")
+
+	// ARB 相关错误
+	ErrInvalidARB        = NewAppError(ErrorTypeValidation, "INVALID_ARB", "无法解析的 ARB 文件")
+	ErrARBLocaleMissing  = NewAppError(ErrorTypeValidation, "ARB_LOCALE_MISSING", "ARB 文件没有 @@locale，请通过 language 指定语言")
+	ErrARBNoTranslations = NewAppError(ErrorTypeValidation, "ARB_NO_TRANSLATIONS", "ARB 文件中没有可导入的翻译")
+
+	// 导入冲突策略相关错误
+	ErrInvalidImportStrategy = NewAppError(ErrorTypeValidation, "INVALID_IMPORT_STRATEGY", "不支持的导入冲突策略，可选值：skip、overwrite、merge、fail")
+	ErrImportConflict        = NewAppError(ErrorTypeConflict, "IMPORT_CONFLICT", "导入的翻译已存在，未写入任何翻译")
+
+	// 翻译值类型相关错误
+	ErrInvalidValueType     = NewAppError(ErrorTypeValidation, "INVALID_VALUE_TYPE", "不支持的值类型，可选值：string、json、markdown、html")
+	ErrInvalidValueSchema   = NewAppError(ErrorTypeValidation, "INVALID_VALUE_SCHEMA", "无效的 JSON Schema")
+	ErrInvalidJSONValue     = NewAppError(ErrorTypeValidation, "INVALID_JSON_VALUE", "翻译值不是合法的 JSON 或不符合键的 JSON Schema")
+	ErrValueTypeMismatch    = NewAppError(ErrorTypeValidation, "VALUE_TYPE_MISMATCH", "翻译值的类型与键的值类型不一致")
+	ErrInvalidQAIssueType   = NewAppError(ErrorTypeValidation, "INVALID_QA_ISSUE_TYPE", "不支持的问题类型，可选值：markup_invalid、disallowed_markup、link_mismatch、placeholder_mismatch、glossary_mismatch、forbidden_term、tag_mismatch、trailing_whitespace、length_exceeded、icu_invalid、plural_category、select_mismatch")
+	ErrInvalidQALengthRatio = NewAppError(ErrorTypeValidation, "INVALID_QA_LENGTH_RATIO", "长度倍数必须在 1 到 10 之间")
+	ErrNoSourceLanguage     = NewAppError(ErrorTypeValidation, "NO_SOURCE_LANGUAGE", "没有设置默认语言，无法比较译文")
+	ErrIsSourceLanguage     = NewAppError(ErrorTypeValidation, "IS_SOURCE_LANGUAGE", "目标语言不能是源语言")
+	ErrInvalidICUMessage    = NewAppError(ErrorTypeValidation, "INVALID_ICU_MESSAGE", "翻译值不是合法的 ICU 消息")
+
+	// 翻译审核相关错误
+	ErrInvalidReviewStatus     = NewAppError(ErrorTypeValidation, "INVALID_REVIEW_STATUS", "不支持的审核状态，可选值：draft、in_review、approved、outdated")
+	ErrInvalidReviewTransition = NewAppError(ErrorTypeValidation, "INVALID_REVIEW_TRANSITION", "翻译的审核状态不允许此操作")
+
+	// 键元数据相关错误
+	ErrValueTooLong       = NewAppError(ErrorTypeValidation, "VALUE_TOO_LONG", "翻译值超过键的最大长度")
+	ErrInvalidKeyPlatform = NewAppError(ErrorTypeValidation, "INVALID_KEY_PLATFORM", "不支持的平台，可选值：web、ios、android、desktop")
+	ErrInvalidKeyTags     = NewAppError(ErrorTypeValidation, "INVALID_KEY_TAGS", "无效的键标签")
+
+	// 机器翻译相关错误
+	ErrTooManyAutoTranslations  = NewAppError(ErrorTypeValidation, "TOO_MANY_AUTO_TRANSLATIONS", "需要机器翻译的文本过多，请指定键名分批翻译")
+	ErrMachineTranslationFailed = NewAppError(ErrorTypeInternal, "MACHINE_TRANSLATION_FAILED", "机器翻译服务调用失败")
 
 	// 项目成员相关错误
-	ErrMemberNotFound    = NewAppError(ErrorTypeNotFound, "MEMBER_NOT_FOUND", "项目成员不存在")
-	ErrMemberExists      = NewAppError(ErrorTypeConflict, "MEMBER_EXISTS", "用户已是项目成员")
-	ErrInsufficientPerm  = NewAppError(ErrorTypeForbidden, "INSUFFICIENT_PERMISSION", "权限不足")
-	ErrCannotRemoveOwner = NewAppError(ErrorTypeForbidden, "CANNOT_REMOVE_OWNER", "不能移除项目所有者")
+	ErrMemberNotFound     = NewAppError(ErrorTypeNotFound, "MEMBER_NOT_FOUND", "项目成员不存在")
+	ErrMemberExists       = NewAppError(ErrorTypeConflict, "MEMBER_EXISTS", "用户已是项目成员")
+	ErrInsufficientPerm   = NewAppError(ErrorTypeForbidden, "INSUFFICIENT_PERMISSION", "权限不足")
+	ErrCannotRemoveOwner  = NewAppError(ErrorTypeForbidden, "CANNOT_REMOVE_OWNER", "不能移除项目所有者")
+	ErrInvalidBulkMembers = NewAppError(ErrorTypeValidation, "INVALID_BULK_MEMBERS", "批量添加的成员数量必须在 1 到 500 之间")
+
+	// 角色同步与复核相关错误
+	ErrRoleReviewNotFound      = NewAppError(ErrorTypeNotFound, "ROLE_REVIEW_NOT_FOUND", "角色复核记录不存在")
+	ErrRoleReviewResolved      = NewAppError(ErrorTypeConflict, "ROLE_REVIEW_RESOLVED", "该角色复核已处理")
+	ErrInvalidRoleReviewAction = NewAppError(ErrorTypeValidation, "INVALID_ROLE_REVIEW_ACTION", "无效的复核操作，可选值：keep、downgrade、remove；downgrade 时角色为 editor 或 viewer")
+
+	// 增量同步相关错误
+	ErrInvalidDeltaSince = NewAppError(ErrorTypeValidation, "INVALID_DELTA_SINCE", "无效的同步起点，应为 RFC 3339 时间或发布版本标签")
+	ErrTooManyChanges    = NewAppError(ErrorTypeValidation, "TOO_MANY_CHANGES", "同步起点之后变更的键过多，请全量拉取")
+
+	// 键编辑锁相关错误
+	ErrKeyLocked      = NewAppError(ErrorTypeConflict, "KEY_LOCKED", "其他用户正在编辑该键")
+	ErrKeyLockNotHeld = NewAppError(ErrorTypeConflict, "KEY_LOCK_NOT_HELD", "编辑锁已过期或已被其他用户获取，请重新获取")
 
 	// 通用错误
 	ErrInvalidInput  = NewAppError(ErrorTypeValidation, "INVALID_INPUT", "无效的输入参数")
@@ -146,12 +249,159 @@ var (
 	ErrForbidden     = NewAppError(ErrorTypeForbidden, "FORBIDDEN", "禁止访问")
 
 	// 邀请相关错误
-	ErrInvitationNotFound   = NewAppError(ErrorTypeNotFound, "INVITATION_NOT_FOUND", "邀请码不存在")
-	ErrInvitationUsed       = NewAppError(ErrorTypeConflict, "INVITATION_USED", "邀请码已被使用")
-	ErrInvitationExpired    = NewAppError(ErrorTypeBadRequest, "INVITATION_EXPIRED", "邀请码已过期")
-	ErrInvitationRevoked    = NewAppError(ErrorTypeBadRequest, "INVITATION_REVOKED", "邀请码已被撤销")
-	ErrInvalidInvitation    = NewAppError(ErrorTypeValidation, "INVALID_INVITATION", "无效的邀请码")
-	ErrInvitationCodeExists = NewAppError(ErrorTypeConflict, "INVITATION_CODE_EXISTS", "邀请码已存在")
+	ErrInvitationNotFound      = NewAppError(ErrorTypeNotFound, "INVITATION_NOT_FOUND", "邀请码不存在")
+	ErrInvitationUsed          = NewAppError(ErrorTypeConflict, "INVITATION_USED", "邀请码已被使用")
+	ErrInvitationExpired       = NewAppError(ErrorTypeBadRequest, "INVITATION_EXPIRED", "邀请码已过期")
+	ErrInvitationRevoked       = NewAppError(ErrorTypeBadRequest, "INVITATION_REVOKED", "邀请码已被撤销")
+	ErrInvalidInvitation       = NewAppError(ErrorTypeValidation, "INVALID_INVITATION", "无效的邀请码")
+	ErrInvitationCodeExists    = NewAppError(ErrorTypeConflict, "INVITATION_CODE_EXISTS", "邀请码已存在")
+	ErrInvalidInvitationCount  = NewAppError(ErrorTypeValidation, "INVALID_INVITATION_COUNT", "邀请码数量必须在 1 到 200 之间，指定邮箱时与邮箱数量一致")
+	ErrInvalidBatchLabel       = NewAppError(ErrorTypeValidation, "INVALID_BATCH_LABEL", "批次标签只能包含字母、数字、点、下划线和连字符，最长 100 个字符")
+	ErrInvitationBatchNotFound = NewAppError(ErrorTypeNotFound, "INVITATION_BATCH_NOT_FOUND", "邀请批次不存在")
+	ErrInvalidInvitationUses   = NewAppError(ErrorTypeValidation, "INVALID_INVITATION_MAX_USES", "邀请码最多使用次数必须在 1 到 1000 之间")
+	ErrMailNotConfigured       = NewAppError(ErrorTypeValidation, "MAIL_NOT_CONFIGURED", "未配置邮件服务（SMTP_HOST），无法发送邮件")
+
+	// 个人访问令牌相关错误
+	ErrAccessTokenNotFound = NewAppError(ErrorTypeNotFound, "ACCESS_TOKEN_NOT_FOUND", "访问令牌不存在")
+	ErrAccessTokenExpired  = NewAppError(ErrorTypeUnauthorized, "ACCESS_TOKEN_EXPIRED", "访问令牌已过期")
+	ErrAccessTokenLimit    = NewAppError(ErrorTypeValidation, "ACCESS_TOKEN_LIMIT", "访问令牌数量已达上限")
+	ErrSessionNotFound     = NewAppError(ErrorTypeNotFound, "SESSION_NOT_FOUND", "登录会话不存在")
+	ErrSessionRevoked      = NewAppError(ErrorTypeUnauthorized, "SESSION_REVOKED", "登录会话已失效，请重新登录")
+
+	// API Key 相关错误
+	ErrAPIKeyNotFound    = NewAppError(ErrorTypeNotFound, "API_KEY_NOT_FOUND", "API Key 不存在")
+	ErrAPIKeyScopeDenied = NewAppError(ErrorTypeForbidden, "API_KEY_SCOPE_DENIED", "API Key 无权访问该项目或执行该操作")
+
+	// 单点登录相关错误
+	ErrSSOProviderNotFound   = NewAppError(ErrorTypeNotFound, "SSO_PROVIDER_NOT_FOUND", "单点登录提供方不存在")
+	ErrSSOStateMismatch      = NewAppError(ErrorTypeValidation, "SSO_STATE_MISMATCH", "单点登录请求已过期或无效，请重新登录")
+	ErrSSOLoginFailed        = NewAppError(ErrorTypeUnauthorized, "SSO_LOGIN_FAILED", "单点登录失败")
+	ErrSSOEmailRequired      = NewAppError(ErrorTypeForbidden, "SSO_EMAIL_REQUIRED", "身份提供方没有返回已验证的邮箱")
+	ErrSSODomainNotAllowed   = NewAppError(ErrorTypeForbidden, "SSO_DOMAIN_NOT_ALLOWED", "该邮箱域名不允许登录")
+	ErrSSOIdentityNotFound   = NewAppError(ErrorTypeNotFound, "SSO_IDENTITY_NOT_FOUND", "单点登录账号未关联用户")
+	ErrSSOUserNotProvisioned = NewAppError(ErrorTypeForbidden, "SSO_USER_NOT_PROVISIONED", "该账号尚未开通，请联系管理员")
+	ErrUserDisabled          = NewAppError(ErrorTypeForbidden, "USER_DISABLED", "用户已被禁用")
+
+	// 找回密码相关错误
+	ErrInvalidResetToken = NewAppError(ErrorTypeValidation, "INVALID_RESET_TOKEN", "重置链接无效或已过期")
+
+	// 入站 Webhook 相关错误
+	ErrWebhookNotFound         = NewAppError(ErrorTypeNotFound, "WEBHOOK_NOT_FOUND", "Webhook 不存在")
+	ErrWebhookDisabled         = NewAppError(ErrorTypeForbidden, "WEBHOOK_DISABLED", "Webhook 已停用")
+	ErrInvalidWebhookSignature = NewAppError(ErrorTypeUnauthorized, "INVALID_WEBHOOK_SIGNATURE", "Webhook 签名无效")
+	ErrInvalidWebhookPayload   = NewAppError(ErrorTypeValidation, "INVALID_WEBHOOK_PAYLOAD", "无效的 Webhook 数据")
+	ErrInvalidConflictStrategy = NewAppError(ErrorTypeValidation, "INVALID_CONFLICT_STRATEGY", "无效的冲突策略")
+
+	// 出站 Webhook 相关错误
+	ErrInvalidWebhookURL    = NewAppError(ErrorTypeValidation, "INVALID_WEBHOOK_URL", "无效的 Webhook 地址，只支持 http 和 https")
+	ErrInvalidWebhookEvents = NewAppError(ErrorTypeValidation, "INVALID_WEBHOOK_EVENTS", "无效的 Webhook 事件")
+
+	// 环境推送相关错误
+	ErrPromotionNotFound     = NewAppError(ErrorTypeNotFound, "PROMOTION_NOT_FOUND", "推送记录不存在")
+	ErrPromotionApplied      = NewAppError(ErrorTypeConflict, "PROMOTION_APPLIED", "推送已应用")
+	ErrPromotionStale        = NewAppError(ErrorTypeConflict, "PROMOTION_STALE", "目标环境的翻译已变更，请重新计算差异")
+	ErrPromotionSourceFailed = NewAppError(ErrorTypeBadRequest, "PROMOTION_SOURCE_FAILED", "无法获取源环境的翻译")
+
+	// 历史导出和合规报告相关错误
+	ErrInvalidHistoryRange  = NewAppError(ErrorTypeValidation, "INVALID_HISTORY_RANGE", "无效的时间范围")
+	ErrInvalidHistorySource = NewAppError(ErrorTypeValidation, "INVALID_HISTORY_SOURCE", "无效的导出来源，可选值：translations、audit")
+	ErrInvalidHistoryFormat = NewAppError(ErrorTypeValidation, "INVALID_HISTORY_FORMAT", "无效的导出格式，可选值：csv、jsonl")
+	ErrInvalidActivityDays  = NewAppError(ErrorTypeValidation, "INVALID_ACTIVITY_DAYS", "无效的统计天数，可选值：30、90")
+
+	// 翻译回滚相关错误
+	ErrHistoryNotFound = NewAppError(ErrorTypeNotFound, "HISTORY_NOT_FOUND", "变更历史不存在")
+	ErrInvalidRollback = NewAppError(ErrorTypeValidation, "INVALID_ROLLBACK", "无法回滚到该版本")
+
+	// Webhook 消息模板相关错误
+	ErrInvalidWebhookTemplate = NewAppError(ErrorTypeValidation, "INVALID_WEBHOOK_TEMPLATE", "无效的 Webhook 消息模板")
+	ErrUnknownWebhookPreset   = NewAppError(ErrorTypeValidation, "UNKNOWN_WEBHOOK_PRESET", "未知的内置模板，可选值：generic、slack、teams")
+	ErrUnsupportedEventType   = NewAppError(ErrorTypeValidation, "UNSUPPORTED_EVENT_TYPE", "不支持的事件类型")
+
+	// 闲置项目相关错误
+	ErrInvalidStaleDays = NewAppError(ErrorTypeValidation, "INVALID_STALE_DAYS", "闲置天数必须为正数")
+
+	// 用量统计相关错误
+	ErrInvalidUsageDays = NewAppError(ErrorTypeValidation, "INVALID_USAGE_DAYS", "统计天数必须在 1 到 365 之间")
+
+	// 表格导入相关错误
+	ErrImportProfileNotFound        = NewAppError(ErrorTypeNotFound, "IMPORT_PROFILE_NOT_FOUND", "导入映射配置不存在")
+	ErrInvalidImportProfile         = NewAppError(ErrorTypeValidation, "INVALID_IMPORT_PROFILE", "无效的导入映射配置")
+	ErrUnsupportedSpreadsheetFormat = NewAppError(ErrorTypeValidation, "UNSUPPORTED_SPREADSHEET_FORMAT", "不支持的表格格式，可选值：csv、xlsx")
+	ErrInvalidSpreadsheet           = NewAppError(ErrorTypeValidation, "INVALID_SPREADSHEET", "无法解析表格文件")
+	ErrImportColumnNotFound         = NewAppError(ErrorTypeValidation, "IMPORT_COLUMN_NOT_FOUND", "表头中缺少映射配置的列")
+
+	// 死信相关错误
+	ErrNoDeadLettersSelected = NewAppError(ErrorTypeValidation, "NO_DEAD_LETTERS_SELECTED", "请指定要重新投递的事件或选择全部")
+
+	// 发布门槛相关错误
+	ErrInvalidReleaseThreshold = NewAppError(ErrorTypeValidation, "INVALID_RELEASE_THRESHOLD", "无效的发布门槛")
+	ErrReleaseBlocked          = NewAppError(ErrorTypeConflict, "RELEASE_BLOCKED", "有语言未达到发布门槛")
+
+	// 任务分配相关错误
+	ErrAssignmentNotFound = NewAppError(ErrorTypeNotFound, "ASSIGNMENT_NOT_FOUND", "任务分配不存在")
+	ErrInvalidAssignment  = NewAppError(ErrorTypeValidation, "INVALID_ASSIGNMENT", "无效的任务分配")
+
+	// 附件相关错误
+	ErrAttachmentNotFound    = NewAppError(ErrorTypeNotFound, "ATTACHMENT_NOT_FOUND", "附件不存在")
+	ErrAttachmentFileMissing = NewAppError(ErrorTypeNotFound, "ATTACHMENT_FILE_MISSING", "附件文件不存在")
+	ErrInvalidAttachment     = NewAppError(ErrorTypeValidation, "INVALID_ATTACHMENT", "无效的附件")
+
+	// 命名空间相关错误
+	ErrNamespaceNotFound = NewAppError(ErrorTypeNotFound, "NAMESPACE_NOT_FOUND", "命名空间不存在")
+	ErrNamespaceExists   = NewAppError(ErrorTypeConflict, "NAMESPACE_EXISTS", "命名空间已存在")
+	ErrInvalidNamespace  = NewAppError(ErrorTypeValidation, "INVALID_NAMESPACE", "无效的命名空间")
+
+	// 标签相关错误
+	ErrTagNotFound = NewAppError(ErrorTypeNotFound, "TAG_NOT_FOUND", "标签不存在")
+	ErrTagExists   = NewAppError(ErrorTypeConflict, "TAG_EXISTS", "标签已存在")
+	ErrInvalidTag  = NewAppError(ErrorTypeValidation, "INVALID_TAG", "无效的标签")
+
+	// 分支相关错误
+	ErrBranchNotFound      = NewAppError(ErrorTypeNotFound, "BRANCH_NOT_FOUND", "分支不存在")
+	ErrBranchExists        = NewAppError(ErrorTypeConflict, "BRANCH_EXISTS", "分支已存在")
+	ErrInvalidBranch       = NewAppError(ErrorTypeValidation, "INVALID_BRANCH", "无效的分支")
+	ErrBranchMerged        = NewAppError(ErrorTypeConflict, "BRANCH_MERGED", "分支已合并")
+	ErrBranchMergeConflict = NewAppError(ErrorTypeConflict, "BRANCH_MERGE_CONFLICT", "分支与主线存在冲突，请指定处理方式")
+
+	// 发布版本相关错误
+	ErrReleaseNotFound = NewAppError(ErrorTypeNotFound, "RELEASE_NOT_FOUND", "发布版本不存在")
+	ErrReleaseExists   = NewAppError(ErrorTypeConflict, "RELEASE_EXISTS", "发布版本已存在")
+	ErrInvalidRelease  = NewAppError(ErrorTypeValidation, "INVALID_RELEASE", "无效的发布版本")
+	ErrRolloutActive   = NewAppError(ErrorTypeConflict, "ROLLOUT_ACTIVE", "项目有进行中的灰度发布，请先全量发布或中止")
+	ErrRolloutInactive = NewAppError(ErrorTypeConflict, "ROLLOUT_INACTIVE", "发布版本没有进行中的灰度发布")
+
+	// 公开分发相关错误
+	// 项目不存在、未开启公开分发、没有发布版本或发布版本中没有该语言时都返回该错误，不暴露项目是否存在
+	ErrDeliveryNotFound = NewAppError(ErrorTypeNotFound, "DELIVERY_NOT_FOUND", "分发内容不存在")
+
+	// Figma 插件相关错误
+	ErrFigmaFrameNotFound     = NewAppError(ErrorTypeNotFound, "FIGMA_FRAME_NOT_FOUND", "设计稿画框不存在")
+	ErrFigmaScreenshotMissing = NewAppError(ErrorTypeNotFound, "FIGMA_SCREENSHOT_NOT_FOUND", "画框没有截图")
+	ErrInvalidFigmaFrame      = NewAppError(ErrorTypeValidation, "INVALID_FIGMA_FRAME", "无效的设计稿画框")
+	ErrInvalidFigmaScreenshot = NewAppError(ErrorTypeValidation, "INVALID_FIGMA_SCREENSHOT", "无效的画框截图")
+	ErrFigmaSourceLanguage    = NewAppError(ErrorTypeValidation, "FIGMA_SOURCE_LANGUAGE_MISSING", "未设置默认语言，请指定图层文本的语言")
+
+	// 术语表相关错误
+	ErrGlossaryTermNotFound = NewAppError(ErrorTypeNotFound, "GLOSSARY_TERM_NOT_FOUND", "术语不存在")
+	ErrGlossaryTermExists   = NewAppError(ErrorTypeConflict, "GLOSSARY_TERM_EXISTS", "术语已存在")
+	ErrInvalidGlossaryTerm  = NewAppError(ErrorTypeValidation, "INVALID_GLOSSARY_TERM", "无效的术语")
+
+	// 组织相关错误
+	ErrOrganizationNotFound       = NewAppError(ErrorTypeNotFound, "ORGANIZATION_NOT_FOUND", "组织不存在")
+	ErrOrganizationExists         = NewAppError(ErrorTypeConflict, "ORGANIZATION_EXISTS", "组织名称已存在")
+	ErrOrganizationNotEmpty       = NewAppError(ErrorTypeConflict, "ORGANIZATION_NOT_EMPTY", "组织下还有项目，不能删除")
+	ErrOrganizationMemberNotFound = NewAppError(ErrorTypeNotFound, "ORGANIZATION_MEMBER_NOT_FOUND", "组织成员不存在")
+	ErrOrganizationMemberExists   = NewAppError(ErrorTypeConflict, "ORGANIZATION_MEMBER_EXISTS", "用户已是组织成员")
+	ErrNotOrganizationMember      = NewAppError(ErrorTypeValidation, "NOT_ORGANIZATION_MEMBER", "用户不是项目所属组织的成员")
+	ErrLastOrganizationOwner      = NewAppError(ErrorTypeValidation, "LAST_ORGANIZATION_OWNER", "组织至少需要保留一个 owner")
+	ErrInvalidOrganizationRole    = NewAppError(ErrorTypeValidation, "INVALID_ORGANIZATION_ROLE", "无效的组织角色")
+
+	// 翻译搜索相关错误
+	ErrInvalidSearchMode  = NewAppError(ErrorTypeValidation, "INVALID_SEARCH_MODE", "不支持的搜索模式，可选值：exact、regex、fuzzy")
+	ErrInvalidSearchRegex = NewAppError(ErrorTypeValidation, "INVALID_SEARCH_REGEX", "无效的正则表达式")
+
+	// 项目复制相关错误
+	ErrDuplicateJobNotFound = NewAppError(ErrorTypeNotFound, "DUPLICATE_JOB_NOT_FOUND", "项目复制任务不存在")
 )
 
 // IsAppError 检查是否为应用程序错误
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType 领域事件类型
+type EventType string
+
+// 领域事件类型
+const (
+	EventTranslationUpdated EventType = "translation.updated"
+	EventProjectCreated     EventType = "project.created"
+	EventImportCompleted    EventType = "import.completed"
+	EventReleasePublished   EventType = "release.published"
+	// EventStorageThresholdExceeded 数据增长超过软限制（系统级事件，ProjectID 为 0）
+	EventStorageThresholdExceeded EventType = "storage.threshold_exceeded"
+	// EventOutboxDeadLettered 事件超过最大投递次数进入死信状态（系统级事件，ProjectID 为原事件的项目）
+	EventOutboxDeadLettered EventType = "outbox.dead_lettered"
+	// EventInvitationsExpired 定期任务将过期的邀请码标记为 expired（系统级事件，ProjectID 为 0）
+	EventInvitationsExpired EventType = "invitation.expired"
+)
+
+// 翻译变更动作
+const (
+	TranslationActionCreated  = "created"
+	TranslationActionUpdated  = "updated"
+	TranslationActionUpserted = "upserted"
+	TranslationActionDeleted  = "deleted"
+	TranslationActionRenamed  = "renamed"
+	TranslationActionReviewed = "reviewed" // 审核状态变化，翻译值不变
+	TranslationActionRestored = "restored" // 从回收站恢复
+)
+
+// 导入来源
+const (
+	ImportSourceFile    = "file"
+	ImportSourceWebhook = "webhook"
+)
+
+// Event 领域事件
+// 服务在写操作成功后发布事件，Webhook、缓存失效、通知、统计等副作用通过订阅事件实现
+type Event struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	ProjectID  uint64          `json:"project_id"`
+	ActorID    uint64          `json:"actor_id,omitempty"` // 触发事件的用户，0 表示系统、CLI 或未知
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewEvent 创建领域事件，payload 序列化为 JSON
+func NewEvent(eventType EventType, projectID uint64, payload interface{}) (Event, error) {
+	event := Event{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		ProjectID:  projectID,
+		OccurredAt: time.Now(),
+	}
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return Event{}, err
+		}
+		event.Payload = data
+	}
+	return event, nil
+}
+
+// DecodePayload 将事件内容解析到 dest
+func (e Event) DecodePayload(dest interface{}) error {
+	if len(e.Payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(e.Payload, dest)
+}
+
+// TranslationUpdatedPayload 翻译变更事件内容
+type TranslationUpdatedPayload struct {
+	Action      string   `json:"action"`
+	KeyNames    []string `json:"key_names,omitempty"`
+	LanguageIDs []uint64 `json:"language_ids,omitempty"`
+	Count       int      `json:"count"`
+}
+
+// ProjectCreatedPayload 项目创建事件内容
+type ProjectCreatedPayload struct {
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	CreatedBy uint64 `json:"created_by"`
+}
+
+// ImportCompletedPayload 导入完成事件内容
+type ImportCompletedPayload struct {
+	Source       string `json:"source"`           // file、webhook 或迁移来源（lokalise、crowdin、poeditor）
+	Format       string `json:"format,omitempty"` // 文件格式或 Webhook 来源系统
+	Keys         int    `json:"keys"`
+	Translations int    `json:"translations"`
+}
+
+// ReleasePublishedPayload 发布版本事件内容
+type ReleasePublishedPayload struct {
+	ReleaseID        uint64 `json:"release_id"`
+	Version          string `json:"version"`
+	Description      string `json:"description,omitempty"`
+	KeyCount         int    `json:"key_count"`
+	TranslationCount int    `json:"translation_count"`
+	GateOverridden   bool   `json:"gate_overridden"`
+	CreatedBy        uint64 `json:"created_by"`
+}
+
+// StorageThresholdExceededPayload 数据增长告警事件内容
+type StorageThresholdExceededPayload struct {
+	Table          string `json:"table"`
+	Metric         string `json:"metric"`
+	Value          int64  `json:"value"`
+	Threshold      int64  `json:"threshold"`
+	Recommendation string `json:"recommendation"`
+}
+
+// OutboxDeadLetteredPayload 死信告警事件内容
+type OutboxDeadLetteredPayload struct {
+	OutboxID  uint64 `json:"outbox_id"`
+	EventID   string `json:"event_id"`
+	EventType string `json:"event_type"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
+}
+
+// InvitationsExpiredPayload 邀请码过期事件内容
+type InvitationsExpiredPayload struct {
+	Invitations []ExpiredInvitation `json:"invitations"`
+	Count       int                 `json:"count"`
+}
+
+// ExpiredInvitation 过期的邀请码，不包含邀请码本身
+type ExpiredInvitation struct {
+	ID         uint64    `json:"id"`
+	InviterID  uint64    `json:"inviter_id"`
+	Email      string    `json:"email,omitempty"`
+	BatchLabel string    `json:"batch_label,omitempty"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CLI 监听和编辑器实时事件流的消息类型
+const (
+	WatchMessageReady   = "ready"   // 连接建立，客户端应先全量拉取一次
+	WatchMessageChanged = "changed" // 翻译发生变更
+	WatchMessagePing    = "ping"    // 心跳
+	WatchMessageResync  = "resync"  // 变更可能丢失（客户端处理过慢或服务重启），客户端应全量拉取后重新连接
+)
+
+// WatchEvent 推送给 CLI 监听连接和编辑器实时事件流的翻译变更通知
+type WatchEvent struct {
+	Type       string    `json:"type"`
+	EventID    string    `json:"event_id,omitempty"`
+	ProjectID  uint64    `json:"project_id"`
+	UserID     uint64    `json:"user_id,omitempty"` // 变更的操作人，0 表示系统、CLI 或未知
+	Action     string    `json:"action,omitempty"`
+	KeyNames   []string  `json:"key_names,omitempty"`
+	Locales    []string  `json:"locales,omitempty"` // 变更涉及的语言，为空表示未知或全部语言
+	Count      int       `json:"count,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventHandler 事件处理函数
+type EventHandler func(ctx context.Context, event Event) error
+
+// EventBus 事件总线接口
+type EventBus interface {
+	// Publish 发布事件，处理函数的错误不会返回给发布方
+	Publish(ctx context.Context, event Event) error
+	// Subscribe 订阅事件，name 用于日志中标识订阅方
+	Subscribe(eventType EventType, name string, handler EventHandler)
+}
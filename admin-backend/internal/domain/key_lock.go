@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// KeyLock 键的编辑锁
+// 编辑者打开键的编辑框时获取，编辑期间定期续期，关闭时释放；未续期的锁到期后自动失效
+type KeyLock struct {
+	ProjectID  uint64    `json:"project_id"`
+	KeyName    string    `json:"key_name"`
+	UserID     uint64    `json:"user_id"`
+	Username   string    `json:"username"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"` // 到期时间，之前未续期则锁失效
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Mailer 邮件发送接口
+type Mailer interface {
+	// Enabled 是否已配置邮件服务
+	Enabled() bool
+	// Send 向单个收件人发送纯文本邮件
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// EmailEvent 通知邮件的事件类型，每种事件使用各自的邮件模板
+type EmailEvent string
+
+// 通知邮件事件类型
+const (
+	EmailEventInvitation         EmailEvent = "invitation"          // 邀请注册，数据为 InvitationEmailData
+	EmailEventMemberAdded        EmailEvent = "member_added"        // 被添加为项目成员，数据为 MemberAddedEmailData
+	EmailEventPasswordReset      EmailEvent = "password_reset"      // 找回密码，数据为 PasswordResetEmailData
+	EmailEventUntranslatedDigest EmailEvent = "untranslated_digest" // 待翻译键摘要，数据为 UntranslatedDigestEmailData
+)
+
+// EmailEvents 全部通知邮件事件类型
+var EmailEvents = []EmailEvent{
+	EmailEventInvitation,
+	EmailEventMemberAdded,
+	EmailEventPasswordReset,
+	EmailEventUntranslatedDigest,
+}
+
+// EmailNotifier 通知邮件发送接口，按事件模板渲染主题和正文后通过 Mailer 发送
+type EmailNotifier interface {
+	// Enabled 是否已配置邮件服务
+	Enabled() bool
+	// Notify 按事件模板向单个收件人发送通知邮件
+	Notify(ctx context.Context, to string, event EmailEvent, data interface{}) error
+}
+
+// InvitationEmailData 邀请邮件模板数据
+type InvitationEmailData struct {
+	InvitationURL string
+	Code          string
+	Role          string
+	ExpiresAt     time.Time
+	Description   string // 邀请说明，可能为空
+}
+
+// MemberAddedEmailData 项目成员通知邮件模板数据
+type MemberAddedEmailData struct {
+	ProjectName string
+	Role        string
+}
+
+// PasswordResetEmailData 找回密码邮件模板数据
+type PasswordResetEmailData struct {
+	Username  string
+	ResetURL  string
+	ExpiresAt time.Time
+}
+
+// UntranslatedDigestEmailData 待翻译键摘要邮件模板数据，每个项目发送一封
+type UntranslatedDigestEmailData struct {
+	Username    string
+	ProjectName string
+	ProjectURL  string                       // 翻译页面地址
+	Total       int                          // 所有语言待翻译的键数之和
+	Languages   []UntranslatedLanguageDigest // 有待翻译键的语言，按语言代码排序
+}
+
+// UntranslatedLanguageDigest 单个语言的待翻译键
+type UntranslatedLanguageDigest struct {
+	Code    string
+	Name    string
+	Pending int      // 待翻译的键数
+	Keys    []string // 按键名排序的前几个键
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,63 +11,95 @@ import (
 
 // User 用户领域模型
 type User struct {
-	ID        uint64    `gorm:"primaryKey" json:"id"`
-	Username  string    `gorm:"unique;size:50;not null" json:"username"`
-	Email     string    `gorm:"unique;size:100" json:"email"`
-	Password  string    `gorm:"not null" json:"password"`
-	Role      string    `gorm:"size:20;default:member;index:idx_user_role" json:"role"`     // admin, member, viewer
-	Status    string    `gorm:"size:20;default:active;index:idx_user_status" json:"status"` // active, disabled
-	CreatedBy uint64    `json:"created_by"`
-	UpdatedBy uint64    `json:"updated_by"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	Username    string    `gorm:"unique;size:50;not null" json:"username"`
+	Email       string    `gorm:"unique;size:100" json:"email"`
+	Password    string    `gorm:"not null" json:"password"`
+	Role        string    `gorm:"size:20;default:member;index:idx_user_role" json:"role"`     // admin, member, viewer
+	Status      string    `gorm:"size:20;default:active;index:idx_user_status" json:"status"` // active, disabled
+	DisplayName string    `gorm:"size:100" json:"display_name"`                               // 显示名称，为空时显示用户名
+	AvatarKey   string    `gorm:"size:255" json:"-"`                                          // 头像在附件存储中的路径，为空时没有头像
+	Language    string    `gorm:"size:10" json:"language"`                                    // 界面语言，为空时跟随浏览器
+	Timezone    string    `gorm:"size:50" json:"timezone"`                                    // IANA 时区，为空时跟随浏览器
+	CreatedBy   uint64    `json:"created_by"`
+	UpdatedBy   uint64    `json:"updated_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AvatarURL 头像的访问地址（相对于服务根地址），没有头像时为空
+func (u *User) AvatarURL() string {
+	if u.AvatarKey == "" {
+		return ""
+	}
+	return "/api/users/" + strconv.FormatUint(u.ID, 10) + "/avatar"
 }
 
 // Project 项目领域模型
 type Project struct {
-	ID           uint64         `gorm:"primaryKey" json:"id"`
-	Name         string         `gorm:"size:100;not null;unique;index:idx_project_search" json:"name"` // 项目名称
-	Description  string         `gorm:"size:500;index:idx_project_search" json:"description"`          // 项目描述
-	Slug         string         `gorm:"size:100;not null;unique;index" json:"slug"`                    // 项目标识，用于URL
-	Status       string         `gorm:"size:20;default:active;index:idx_project_status" json:"status"` // 项目状态：active, archived
-	CreatedBy    uint64         `json:"created_by"`
-	UpdatedBy    uint64         `json:"updated_by"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
-	Translations []Translation  `gorm:"foreignKey:ProjectID" json:"-"` // 关联的翻译
+	ID                 uint64         `gorm:"primaryKey" json:"id"`
+	Name               string         `gorm:"size:100;not null;unique;index:idx_project_search" json:"name"` // 项目名称
+	Description        string         `gorm:"size:500;index:idx_project_search" json:"description"`          // 项目描述
+	Slug               string         `gorm:"size:100;not null;unique;index" json:"slug"`                    // 项目标识，用于URL
+	Status             string         `gorm:"size:20;default:active;index:idx_project_status" json:"status"` // 项目状态：active, archived
+	ExportFileTemplate string         `gorm:"size:255" json:"export_file_template"`                          // 导出文件命名模板，如 {locale}/{namespace}.json
+	Protected          bool           `gorm:"default:false" json:"protected"`                                // 删除保护：开启时不能删除项目或批量删除翻译
+	DeliveryEnabled    bool           `gorm:"default:false" json:"delivery_enabled"`                         // 公开分发：开启时应用可以不经认证通过 /delivery 获取最新发布版本
+	OrganizationID     uint64         `gorm:"not null;default:0;index" json:"organization_id"`               // 所属组织，0 表示不属于任何组织
+	SourceLanguage     string         `gorm:"size:10" json:"source_language"`                                // 源语言代码，为空时使用默认语言；源语言译文修改后其他语言的译文标记为 outdated
+	LastChangedAt      *time.Time     `gorm:"->" json:"last_changed_at,omitempty"`                           // 最近一次内容变更时间，由活动跟踪定期写入
+	LastAccessedAt     *time.Time     `gorm:"->" json:"last_accessed_at,omitempty"`                          // 最近一次访问时间（Web 或 CLI），由活动跟踪定期写入
+	CreatedBy          uint64         `json:"created_by"`
+	UpdatedBy          uint64         `json:"updated_by"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
+	Translations       []Translation  `gorm:"foreignKey:ProjectID" json:"-"` // 关联的翻译
 }
 
 // Language 语言领域模型
+// 每个组织有自己的语言列表，OrganizationID 为 0 的语言供不属于组织的项目使用；语言代码在同一组织内唯一
 type Language struct {
-	ID        uint64         `gorm:"primaryKey" json:"id"`
-	Code      string         `gorm:"size:10;not null;unique" json:"code"`  // 语言代码，如 en, zh-CN
-	Name      string         `gorm:"size:50;not null" json:"name"`         // 语言名称，如 English, 简体中文
-	IsDefault bool           `gorm:"default:false" json:"is_default"`      // 是否为默认语言
-	Status    string         `gorm:"size:20;default:active" json:"status"` // 状态：active, inactive
-	CreatedBy uint64         `json:"created_by"`
-	UpdatedBy uint64         `json:"updated_by"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             uint64         `gorm:"primaryKey" json:"id"`
+	OrganizationID uint64         `gorm:"not null;default:0;uniqueIndex:idx_language_org_code,priority:1" json:"organization_id"` // 所属组织，0 表示不属于任何组织
+	Code           string         `gorm:"size:10;not null;uniqueIndex:idx_language_org_code,priority:2" json:"code"`              // 语言代码，如 en, zh-CN
+	Name           string         `gorm:"size:50;not null" json:"name"`                                                           // 语言名称，如 English, 简体中文
+	IsDefault      bool           `gorm:"default:false" json:"is_default"`                                                        // 是否为默认语言
+	Status         string         `gorm:"size:20;default:active" json:"status"`                                                   // 状态：active, inactive
+	CreatedBy      uint64         `json:"created_by"`
+	UpdatedBy      uint64         `json:"updated_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Translation 翻译领域模型
 type Translation struct {
-	ID         uint64         `gorm:"primaryKey" json:"id"`
-	ProjectID  uint64         `gorm:"not null;index:idx_translation_project;uniqueIndex:idx_translation_unique,priority:1" json:"project_id"`    // 关联的项目ID
-	KeyName    string         `gorm:"size:255;not null;index:idx_translation_key;uniqueIndex:idx_translation_unique,priority:2" json:"key_name"` // 翻译键名
-	Context    string         `gorm:"size:500" json:"context"`                                                                                   // 上下文说明
-	LanguageID uint64         `gorm:"not null;index:idx_translation_language;uniqueIndex:idx_translation_unique,priority:3" json:"language_id"`  // 语言ID
-	Value      string         `gorm:"type:text" json:"value"`                                                                                    // 翻译值
-	Status     string         `gorm:"size:20;default:active;index:idx_translation_status" json:"status"`                                         // 状态：active, deprecated
-	CreatedBy  uint64         `json:"created_by"`
-	UpdatedBy  uint64         `json:"updated_by"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
-
-	Project  Project  `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`   // 关联的项目
+	ID            uint64         `gorm:"primaryKey" json:"id"`
+	ProjectID     uint64         `gorm:"not null;index:idx_translation_project;uniqueIndex:idx_translation_unique,priority:1" json:"project_id"`    // 关联的项目ID
+	KeyName       string         `gorm:"size:255;not null;index:idx_translation_key;uniqueIndex:idx_translation_unique,priority:2" json:"key_name"` // 翻译键名
+	Context       string         `gorm:"size:500" json:"context"`                                                                                   // 上下文说明
+	LanguageID    uint64         `gorm:"not null;index:idx_translation_language;uniqueIndex:idx_translation_unique,priority:3" json:"language_id"`  // 语言ID
+	Value         string         `gorm:"type:text" json:"value"`                                                                                    // 翻译值
+	ValueType     string         `gorm:"size:10;not null;default:string" json:"value_type"`                                                         // 值类型：string, json, markdown, html，同一键的所有语言保持一致
+	ValueSchema   string         `gorm:"type:text" json:"value_schema,omitempty"`                                                                   // json 类型的值需要符合的 JSON Schema，为空时只检查是否为合法的 JSON
+	MaxLength     int            `gorm:"not null;default:0" json:"max_length,omitempty"`                                                            // 译文最多字符数，0 表示不限制，同一键的所有语言保持一致
+	Tags          string         `gorm:"size:500" json:"tags,omitempty"`                                                                            // 键的标签，逗号分隔，同一键的所有语言保持一致
+	Platform      string         `gorm:"size:20;index:idx_translation_platform" json:"platform,omitempty"`                                          // 使用键的平台：web, ios, android, desktop，为空时不限，同一键的所有语言保持一致
+	NamespaceID   uint64         `gorm:"not null;default:0;index:idx_translation_namespace" json:"namespace_id,omitempty"`                          // 键所属的命名空间ID，0 表示未划分命名空间，同一键的所有语言保持一致
+	Status        string         `gorm:"size:20;default:active;index:idx_translation_status" json:"status"`                                         // 状态：active, deprecated
+	ReviewStatus  string         `gorm:"size:20;not null;default:draft;index:idx_translation_review_status" json:"review_status"`                   // 审核状态：draft, in_review, approved, outdated，译文修改后回到 draft
+	ReviewedBy    uint64         `json:"reviewed_by,omitempty"`                                                                                     // 最后一次审核操作（提交、通过、驳回）的用户ID
+	ReviewedAt    *time.Time     `json:"reviewed_at,omitempty"`                                                                                     // 最后一次审核操作的时间
+	ReviewComment string         `gorm:"size:500" json:"review_comment,omitempty"`                                                                  // 驳回原因
+	Version       uint64         `gorm:"not null;default:1" json:"version"`                                                                         // 版本号，更新翻译或写入不同的译文时加 1，用于检测并发修改
+	CreatedBy     uint64         `json:"created_by"`
+	UpdatedBy     uint64         `json:"updated_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Project  Project  `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`  // 关联的项目
 	Language Language `gorm:"foreignKey:LanguageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"` // 关联的语言
 }
 
@@ -74,6 +109,7 @@ type ProjectMember struct {
 	ProjectID uint64         `gorm:"not null;index:idx_project_member;uniqueIndex:idx_project_member_unique,priority:1" json:"project_id"`
 	UserID    uint64         `gorm:"not null;index:idx_project_member;uniqueIndex:idx_project_member_unique,priority:2" json:"user_id"`
 	Role      string         `gorm:"size:20;default:viewer;index:idx_project_member_role" json:"role"` // owner, editor, viewer
+	Source    string         `gorm:"size:20;index" json:"source,omitempty"`                            // 为空时为手动添加，role_sync 为管理员同步自动添加
 	CreatedBy uint64         `json:"created_by"`
 	UpdatedBy uint64         `json:"updated_by"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -84,6 +120,91 @@ type ProjectMember struct {
 	User    User    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
 
+// 项目成员来源
+const (
+	ProjectMemberSourceRoleSync = "role_sync" // 管理员同步为全局管理员自动添加的 owner 成员
+)
+
+// Organization 组织（工作空间）
+// 组织下的项目、语言和 API Key 与其他组织隔离，同一实例可以服务多个互不相关的团队
+type Organization struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"size:100;not null;unique" json:"name"`
+	Description string    `gorm:"size:500" json:"description"`
+	CreatedBy   uint64    `json:"created_by"`
+	UpdatedBy   uint64    `json:"updated_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OrganizationMember 组织成员
+// 组织的 owner 和 admin 可以管理组织成员、语言和 API Key，并拥有组织下所有项目的 owner 权限
+type OrganizationMember struct {
+	ID             uint64    `gorm:"primaryKey" json:"id"`
+	OrganizationID uint64    `gorm:"not null;uniqueIndex:idx_organization_member,priority:1" json:"organization_id"`
+	UserID         uint64    `gorm:"not null;uniqueIndex:idx_organization_member,priority:2;index" json:"user_id"`
+	Role           string    `gorm:"size:20;not null;default:member" json:"role"` // owner, admin, member
+	CreatedBy      uint64    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Organization Organization `gorm:"foreignKey:OrganizationID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	User         User         `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// 组织角色，权限从低到高
+const (
+	OrganizationRoleMember = "member" // 可以查看组织和组织的语言，项目权限由项目成员决定
+	OrganizationRoleAdmin  = "admin"  // 管理组织成员、语言、API Key 和项目
+	OrganizationRoleOwner  = "owner"  // 与 admin 相同，组织至少保留一个 owner
+)
+
+// OrganizationRoleLevel 组织角色的权限级别，未知角色为 0
+func OrganizationRoleLevel(role string) int {
+	switch role {
+	case OrganizationRoleMember:
+		return 1
+	case OrganizationRoleAdmin:
+		return 2
+	case OrganizationRoleOwner:
+		return 3
+	}
+	return 0
+}
+
+// RoleReview 项目所有者角色复核
+// 用户的全局角色降级后，其项目 owner 成员关系进入复核队列，由管理员决定保留、降级或移除，避免残留权限
+type RoleReview struct {
+	ID         uint64     `gorm:"primaryKey" json:"id"`
+	ProjectID  uint64     `gorm:"not null;index:idx_role_review_member" json:"project_id"`
+	UserID     uint64     `gorm:"not null;index:idx_role_review_member" json:"user_id"`
+	Role       string     `gorm:"size:20;not null" json:"role"`                         // 进入复核时的项目角色
+	Reason     string     `gorm:"size:30;not null" json:"reason"`                       // global_role_downgraded 或 role_sync_leftover
+	GlobalRole string     `gorm:"size:20;not null" json:"global_role"`                  // 进入复核时的全局角色
+	Status     string     `gorm:"size:20;not null;default:pending;index" json:"status"` // pending, kept, downgraded, removed
+	ResolvedBy uint64     `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	User    User    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// 角色复核原因
+const (
+	RoleReviewReasonDowngraded = "global_role_downgraded" // 全局角色降级
+	RoleReviewReasonLeftover   = "role_sync_leftover"     // 管理员同步添加的 owner 成员，用户已不是全局管理员
+)
+
+// 角色复核状态
+const (
+	RoleReviewStatusPending    = "pending"
+	RoleReviewStatusKept       = "kept"
+	RoleReviewStatusDowngraded = "downgraded"
+	RoleReviewStatusRemoved    = "removed"
+)
+
 // Invitation 邀请码领域模型
 type Invitation struct {
 	ID          uint64         `gorm:"primaryKey" json:"id"`
@@ -92,9 +213,14 @@ type Invitation struct {
 	Role        string         `gorm:"size:20;default:member" json:"role"`                                              // 赋予被邀请人的角色: admin, member, viewer
 	Status      string         `gorm:"size:20;default:active;index:idx_invitation_status" json:"status"`                // 状态: active, used, revoked, expired
 	ExpiresAt   time.Time      `gorm:"not null;index:idx_invitation_expires" json:"expires_at"`                         // 过期时间
-	UsedAt      *time.Time     `json:"used_at,omitempty"`                                                                // 使用时间
-	UsedBy      *uint64        `json:"used_by,omitempty"`                                                                // 被邀请人ID
+	MaxUses     int            `gorm:"not null;default:1" json:"max_uses"`                                              // 最多可使用的次数，用完后状态变为 used
+	UseCount    int            `gorm:"not null;default:0" json:"use_count"`                                             // 已使用的次数
+	UsedAt      *time.Time     `json:"used_at,omitempty"`                                                                // 最近一次使用的时间
+	UsedBy      *uint64        `json:"used_by,omitempty"`                                                                // 最近一次使用的被邀请人ID
 	Description string         `gorm:"size:255" json:"description,omitempty"`                                            // 邀请描述
+	BatchLabel  string         `gorm:"size:100;index:idx_invitation_batch" json:"batch_label,omitempty"`                 // 批量创建时的批次标签，可按批次整体撤销
+	Email       string         `gorm:"size:255" json:"email,omitempty"`                                                  // 发送邀请邮件的地址
+	EmailStatus string         `gorm:"-" json:"email_status,omitempty"`                                                  // 创建时邀请邮件的发送结果：sent、failed，不保存
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 
@@ -117,5 +243,770 @@ func (i *Invitation) IsValid() bool {
 	if time.Now().After(i.ExpiresAt) {
 		return false
 	}
-	return true
+	return i.UseCount < i.MaxUses
+}
+
+// RemainingUses 邀请码剩余的使用次数
+func (i *Invitation) RemainingUses() int {
+	if i.UseCount >= i.MaxUses {
+		return 0
+	}
+	return i.MaxUses - i.UseCount
+}
+
+// ProjectSlugAlias 项目标识别名
+// 修改项目标识后保留旧标识，按旧标识访问时重定向到该项目
+type ProjectSlugAlias struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID uint64    `gorm:"not null;index" json:"project_id"`
+	Slug      string    `gorm:"size:100;not null;unique" json:"slug"` // 旧项目标识
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InboundWebhook 外部 TMS 入站 Webhook 配置
+type InboundWebhook struct {
+	ID                uint64         `gorm:"primaryKey" json:"id"`
+	ProjectID         uint64         `gorm:"not null;index:idx_inbound_webhook_project" json:"project_id"` // 写入的目标项目
+	Name              string         `gorm:"size:100;not null" json:"name"`                                // 配置名称
+	Provider          string         `gorm:"size:20;default:generic" json:"provider"`                      // 来源系统：generic, crowdin, lokalise
+	ExternalProjectID string         `gorm:"size:100" json:"external_project_id"`                          // 对方系统中的项目ID，为空时不校验
+	Secret            string         `gorm:"size:128;not null" json:"-"`                                   // HMAC 签名密钥
+	LanguageMapping   string         `gorm:"type:text" json:"-"`                                           // 对方语言代码 -> 本系统语言代码（JSON）
+	ConflictStrategy  string         `gorm:"size:20;default:overwrite" json:"conflict_strategy"`           // 冲突策略：overwrite, skip, fill_empty
+	Status            string         `gorm:"size:20;default:active" json:"status"`                         // 状态：active, disabled
+	CreatedBy         uint64         `json:"created_by"`
+	UpdatedBy         uint64         `json:"updated_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// InboundWebhookLog 入站 Webhook 导入日志
+type InboundWebhookLog struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	WebhookID uint64    `gorm:"not null;index:idx_inbound_webhook_log_webhook" json:"webhook_id"`
+	ProjectID uint64    `gorm:"not null;index" json:"project_id"`
+	Status    string    `gorm:"size:20;not null" json:"status"` // success, rejected, failed
+	Received  int       `json:"received"`                       // 收到的条目数
+	Created   int       `json:"created"`                        // 新增的翻译数
+	Updated   int       `json:"updated"`                        // 覆盖的翻译数
+	Skipped   int       `json:"skipped"`                        // 因冲突策略或映射缺失跳过的条目数
+	Message   string    `gorm:"size:500" json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 冲突策略常量
+const (
+	ConflictStrategyOverwrite = "overwrite"  // 覆盖已有翻译
+	ConflictStrategySkip      = "skip"       // 保留已有翻译
+	ConflictStrategyFillEmpty = "fill_empty" // 仅填充空值
+)
+
+// 入站 Webhook 状态常量
+const (
+	InboundWebhookStatusActive   = "active"
+	InboundWebhookStatusDisabled = "disabled"
+)
+
+// 入站 Webhook 日志状态常量
+const (
+	InboundWebhookLogSuccess  = "success"
+	InboundWebhookLogRejected = "rejected"
+	InboundWebhookLogFailed   = "failed"
+)
+
+// Webhook 项目的出站 Webhook：订阅的事件发生时向 URL 推送签名的 JSON 消息
+type Webhook struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID uint64    `gorm:"not null;index:idx_webhook_project" json:"project_id"`
+	Name      string    `gorm:"size:100;not null" json:"name"`
+	URL       string    `gorm:"size:500;not null" json:"url"`         // 接收推送的地址
+	Secret    string    `gorm:"size:128;not null" json:"-"`           // HMAC 签名密钥
+	Events    string    `gorm:"size:500;not null" json:"-"`           // 订阅的事件，逗号分隔
+	Template  string    `gorm:"type:text" json:"-"`                   // 消息模板（Go 模板），为空时使用内置 generic 模板
+	Status    string    `gorm:"size:20;default:active" json:"status"` // 状态：active, disabled
+	CreatedBy uint64    `json:"created_by"`
+	UpdatedBy uint64    `json:"updated_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// WebhookDelivery 出站 Webhook 的一次推送，记录消息内容、重试次数和最后一次响应
+type WebhookDelivery struct {
+	ID             uint64     `gorm:"primaryKey" json:"id"`
+	WebhookID      uint64     `gorm:"not null;uniqueIndex:idx_webhook_delivery_event,priority:1" json:"webhook_id"`
+	EventID        string     `gorm:"size:36;not null;uniqueIndex:idx_webhook_delivery_event,priority:2" json:"event_id"` // 领域事件ID，重复投递的事件只推送一次
+	ProjectID      uint64     `gorm:"not null;index" json:"project_id"`
+	Event          string     `gorm:"size:50;not null" json:"event"`
+	Payload        string     `gorm:"type:mediumtext;not null" json:"-"` // 推送的请求体
+	Status         string     `gorm:"size:20;not null;default:pending;index:idx_webhook_delivery_due,priority:1" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_delivery_due,priority:2" json:"next_attempt_at"`
+	ResponseStatus int        `json:"response_status,omitempty"` // 最后一次推送的 HTTP 状态码，未收到响应时为 0
+	LastError      string     `gorm:"size:500" json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+
+	Webhook Webhook `gorm:"foreignKey:WebhookID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
+
+// 出站 Webhook 状态常量
+const (
+	WebhookStatusActive   = "active"
+	WebhookStatusDisabled = "disabled"
+)
+
+// 出站 Webhook 推送状态常量
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed" // 超过最大推送次数
+)
+
+// 出站 Webhook 可订阅的事件
+const (
+	WebhookEventTranslationCreated = "translation.created"
+	WebhookEventTranslationUpdated = "translation.updated"
+	WebhookEventTranslationDeleted = "translation.deleted"
+	WebhookEventImportCompleted    = "import.completed"
+	WebhookEventReleasePublished   = "release.published"
+)
+
+// WebhookEvents 出站 Webhook 可订阅的全部事件
+var WebhookEvents = []string{
+	WebhookEventTranslationCreated,
+	WebhookEventTranslationUpdated,
+	WebhookEventTranslationDeleted,
+	WebhookEventImportCompleted,
+	WebhookEventReleasePublished,
+}
+
+// ImportProfile 表格导入的列映射配置
+// 将 CSV/XLSX 的列按表头映射为键名、上下文和语言，同一项目的多次导入可复用
+type ImportProfile struct {
+	ID              uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID       uint64    `gorm:"not null;index" json:"project_id"`
+	Name            string    `gorm:"size:100;not null" json:"name"`
+	HeaderRow       int       `gorm:"not null;default:1" json:"header_row"` // 表头所在行（从 1 开始），之前的行被忽略
+	KeyColumn       string    `gorm:"size:100;not null" json:"key_column"`  // 键名列的表头
+	ContextColumn   string    `gorm:"size:100" json:"context_column"`       // 上下文列的表头，为空时不导入上下文
+	LanguageColumns string    `gorm:"type:text" json:"-"`                   // 表头 -> 语言代码（JSON）
+	CreatedBy       uint64    `json:"created_by"`
+	UpdatedBy       uint64    `json:"updated_by"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// ReleaseThreshold 项目中某种语言的发布门槛
+// 完成率或审核通过率低于门槛的语言会阻止发布，发布时可显式覆盖
+type ReleaseThreshold struct {
+	ID            uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID     uint64    `gorm:"not null;uniqueIndex:idx_release_threshold_language,priority:1" json:"project_id"`
+	LanguageID    uint64    `gorm:"not null;uniqueIndex:idx_release_threshold_language,priority:2" json:"language_id"`
+	MinCompletion int       `gorm:"not null;default:0" json:"min_completion"` // 最低完成率（百分比）
+	MinApproval   int       `gorm:"not null;default:0" json:"min_approval"`   // 最低审核通过率（百分比）
+	CreatedBy     uint64    `json:"created_by"`
+	UpdatedBy     uint64    `json:"updated_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Project  Project  `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Language Language `gorm:"foreignKey:LanguageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// Assignment 分配给项目成员的翻译或审核工作
+// KeyName 为空时分配该语言的所有键，分配到具体键的优先；同一语言、键和工作类型只分配给一个人
+type Assignment struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID  uint64    `gorm:"not null;uniqueIndex:idx_assignment_scope,priority:1" json:"project_id"`
+	LanguageID uint64    `gorm:"not null;uniqueIndex:idx_assignment_scope,priority:2" json:"language_id"`
+	KeyName    string    `gorm:"size:255;not null;default:'';uniqueIndex:idx_assignment_scope,priority:3" json:"key_name"`
+	Type       string    `gorm:"size:20;not null;uniqueIndex:idx_assignment_scope,priority:4" json:"type"` // translate, review
+	UserID     uint64    `gorm:"not null;index" json:"user_id"`
+	CreatedBy  uint64    `json:"created_by"`
+	UpdatedBy  uint64    `json:"updated_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Project  Project  `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Language Language `gorm:"foreignKey:LanguageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	User     User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// Attachment 翻译键的附件（如界面截图），同一键的所有语言共用
+// 文件内容保存在附件存储（本地磁盘或 S3）中，StorageKey 为存储中的路径
+type Attachment struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64    `gorm:"not null;index:idx_attachment_key,priority:1" json:"project_id"`
+	KeyName     string    `gorm:"size:255;not null;index:idx_attachment_key,priority:2" json:"key_name"`
+	FileName    string    `gorm:"size:255" json:"file_name"`
+	ContentType string    `gorm:"size:50;not null" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`
+	StorageKey  string    `gorm:"size:255;not null" json:"-"`
+	CreatedBy   uint64    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// Namespace 项目中的键命名空间（如 checkout、emails），用于按功能或文件对键分组，可按命名空间导出和导入
+// 键通过 Translation.NamespaceID 归属命名空间，删除命名空间时其中的键回到未划分状态
+type Namespace struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64    `gorm:"not null;uniqueIndex:idx_namespace_name,priority:1" json:"project_id"`
+	Name        string    `gorm:"size:100;not null;uniqueIndex:idx_namespace_name,priority:2" json:"name"`
+	Description string    `gorm:"size:500" json:"description"`
+	Keys        int64     `gorm:"-" json:"keys"` // 命名空间中的键数量，只在列表中填充
+	CreatedBy   uint64    `json:"created_by"`
+	UpdatedBy   uint64    `json:"updated_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// Tag 项目中的键标签，用于按用途（如 mobile、beta）筛选和导出键
+// 键与标签多对多关联（见 KeyTag），翻译的 tags 列保存键的标签名称，随关联同步修改
+type Tag struct {
+	ID          uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64    `gorm:"not null;uniqueIndex:idx_tag_name,priority:1" json:"project_id"`
+	Name        string    `gorm:"size:40;not null;uniqueIndex:idx_tag_name,priority:2" json:"name"`
+	Color       string    `gorm:"size:7" json:"color"` // #RRGGBB，为空时由前端决定
+	Description string    `gorm:"size:500" json:"description"`
+	Keys        int64     `gorm:"-" json:"keys"` // 带有该标签的键数量，只在列表中填充
+	CreatedBy   uint64    `json:"created_by"`
+	UpdatedBy   uint64    `json:"updated_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// KeyTag 键与标签的关联，键按项目和键名标识，与翻译的语言无关
+type KeyTag struct {
+	ProjectID uint64    `gorm:"primaryKey;autoIncrement:false" json:"project_id"`
+	KeyName   string    `gorm:"primaryKey;size:255" json:"key_name"`
+	TagID     uint64    `gorm:"primaryKey;autoIncrement:false;index:idx_key_tag_tag" json:"tag_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Branch 项目的翻译分支，用于为发布分支单独维护翻译
+// 分支采用写时复制：创建时不复制翻译，读取时以主线的当前翻译为基础叠加分支中修改过的翻译
+type Branch struct {
+	ID          uint64     `gorm:"primaryKey" json:"id"`
+	ProjectID   uint64     `gorm:"not null;uniqueIndex:idx_branch_name,priority:1" json:"project_id"`
+	Name        string     `gorm:"size:100;not null;uniqueIndex:idx_branch_name,priority:2" json:"name"`
+	Description string     `gorm:"size:500" json:"description"`
+	Status      string     `gorm:"size:20;not null;default:open" json:"status"` // open, merged
+	CreatedBy   uint64     `json:"created_by"`
+	MergedBy    uint64     `json:"merged_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	MergedAt    *time.Time `json:"merged_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// BranchTranslation 分支中修改过的翻译
+// BaseValue 和 BaseExists 记录首次修改时主线的翻译，合并时据此判断主线是否也修改过该翻译
+type BranchTranslation struct {
+	ID         uint64    `gorm:"primaryKey" json:"id"`
+	BranchID   uint64    `gorm:"not null;uniqueIndex:idx_branch_translation,priority:1" json:"branch_id"`
+	KeyName    string    `gorm:"size:255;not null;uniqueIndex:idx_branch_translation,priority:2" json:"key_name"`
+	LanguageID uint64    `gorm:"not null;uniqueIndex:idx_branch_translation,priority:3" json:"language_id"`
+	Value      string    `gorm:"type:text" json:"value"`
+	Deleted    bool      `gorm:"not null;default:false" json:"deleted"` // 分支中删除了该翻译
+	BaseValue  string    `gorm:"type:text" json:"base_value"`
+	BaseExists bool      `gorm:"not null;default:false" json:"base_exists"`
+	UpdatedBy  uint64    `json:"updated_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	Branch Branch `gorm:"foreignKey:BranchID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// BranchMain 主线的名称，即项目中的翻译，不能用作分支名称
+const BranchMain = "main"
+
+// 分支状态常量
+const (
+	BranchStatusOpen   = "open"
+	BranchStatusMerged = "merged"
+)
+
+// BranchChange 两个分支之间的一条翻译差异，为将源分支合并到目标分支时的修改
+type BranchChange struct {
+	Key       string `json:"key"`
+	Language  string `json:"language"`
+	Type      string `json:"type"` // added, modified, removed
+	OldValue  string `json:"old_value,omitempty"`
+	NewValue  string `json:"new_value,omitempty"`
+	Conflict  bool   `json:"conflict,omitempty"`   // 分支修改该翻译后主线也修改了它，合并时需要指定处理方式
+	BaseValue string `json:"base_value,omitempty"` // 冲突时分支修改前主线的值
+}
+
+// Release 项目的发布版本：发布时冻结的项目翻译快照，创建后翻译不可修改
+// CLI 拉取和导出可以指定发布版本而不是当前翻译，用于可重现的构建；
+// 灰度发布的版本在公开分发时只分发给客户端ID落在 RolloutPercentage 内的请求，其余请求获取最新的全量版本
+type Release struct {
+	ID                uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID         uint64    `gorm:"not null;uniqueIndex:idx_release_version,priority:1" json:"project_id"`
+	Version           string    `gorm:"size:100;not null;uniqueIndex:idx_release_version,priority:2" json:"version"` // 版本标签，如 v1.2.0
+	Description       string    `gorm:"size:500" json:"description"`
+	KeyCount          int       `gorm:"not null;default:0" json:"key_count"`
+	TranslationCount  int       `gorm:"not null;default:0" json:"translation_count"`
+	GateOverridden    bool      `gorm:"not null;default:false" json:"gate_overridden"`               // 发布时未达到发布门槛，由项目所有者覆盖
+	RolloutStatus     string    `gorm:"size:20;not null;default:''" json:"rollout_status,omitempty"` // 灰度发布状态，空表示直接全量发布
+	RolloutPercentage int       `gorm:"not null;default:0" json:"rollout_percentage,omitempty"`      // 灰度发布时获取该版本的分发流量百分比
+	CreatedBy         uint64    `json:"created_by"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	Project Project `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// 发布版本的灰度发布状态常量
+const (
+	RolloutStatusActive    = "active"    // 灰度中，同一项目最多一个
+	RolloutStatusCompleted = "completed" // 已推进到 100%，与直接全量发布的版本相同
+	RolloutStatusAborted   = "aborted"   // 已中止，不再分发
+)
+
+// ReleaseTranslation 发布版本中的一条翻译
+// 语言按代码保存，之后删除或停用语言不影响已发布的版本；ValueType 为发布时键的值类型
+type ReleaseTranslation struct {
+	ID           uint64 `gorm:"primaryKey" json:"id"`
+	ReleaseID    uint64 `gorm:"not null;uniqueIndex:idx_release_translation,priority:1" json:"release_id"`
+	KeyName      string `gorm:"size:255;not null;uniqueIndex:idx_release_translation,priority:2" json:"key_name"`
+	LanguageCode string `gorm:"size:10;not null;uniqueIndex:idx_release_translation,priority:3" json:"language_code"`
+	Value        string `gorm:"type:text" json:"value"`
+	ValueType    string `gorm:"size:20;not null;default:string" json:"value_type"`
+	ValueSchema  string `gorm:"type:text" json:"value_schema,omitempty"`
+
+	Release Release `gorm:"foreignKey:ReleaseID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// FigmaFrame Figma 插件同步的设计稿画框
+// 截图作为翻译的视觉上下文保存在数据库中，同一文件中的同一画框只保存一份
+type FigmaFrame struct {
+	ID             uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID      uint64    `gorm:"not null;uniqueIndex:idx_figma_frame_node,priority:1" json:"project_id"`
+	FileKey        string    `gorm:"size:100;not null;uniqueIndex:idx_figma_frame_node,priority:2" json:"file_key"` // Figma 文件标识
+	NodeID         string    `gorm:"size:100;not null;uniqueIndex:idx_figma_frame_node,priority:3" json:"node_id"`  // 画框的节点ID
+	FileName       string    `gorm:"size:255" json:"file_name"`
+	Name           string    `gorm:"size:255" json:"name"`
+	Screenshot     []byte    `gorm:"type:mediumblob" json:"-"`
+	ScreenshotType string    `gorm:"size:50" json:"screenshot_type"` // 截图的媒体类型，为空时没有截图
+	CreatedBy      uint64    `json:"created_by"`
+	UpdatedBy      uint64    `json:"updated_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	Project Project      `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Layers  []FigmaLayer `gorm:"foreignKey:FrameID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"layers"`
+}
+
+// FigmaLayer 画框中引用翻译键的文本图层
+type FigmaLayer struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	FrameID   uint64    `gorm:"not null;uniqueIndex:idx_figma_layer_node,priority:1" json:"frame_id"`
+	NodeID    string    `gorm:"size:100;not null;uniqueIndex:idx_figma_layer_node,priority:2" json:"node_id"`
+	ProjectID uint64    `gorm:"not null;index:idx_figma_layer_key,priority:1" json:"project_id"`
+	KeyName   string    `gorm:"size:255;not null;index:idx_figma_layer_key,priority:2" json:"key_name"`
+	Name      string    `gorm:"size:255" json:"name"`
+	Text      string    `gorm:"type:text" json:"text"` // 同步时图层中的文本
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GlossaryTerm 项目术语表中的术语
+// 术语以默认语言书写，各语言的规定译文和禁用译文在 QA 报告中检查
+type GlossaryTerm struct {
+	ID            uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID     uint64    `gorm:"not null;uniqueIndex:idx_glossary_term,priority:1" json:"project_id"`
+	Term          string    `gorm:"size:255;not null;uniqueIndex:idx_glossary_term,priority:2" json:"term"`
+	Definition    string    `gorm:"type:text" json:"definition"`                  // 术语的含义和使用说明
+	CaseSensitive bool      `gorm:"not null;default:false" json:"case_sensitive"` // 匹配原文和译文时是否区分大小写
+	CreatedBy     uint64    `json:"created_by"`
+	UpdatedBy     uint64    `json:"updated_by"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	Project      Project               `gorm:"foreignKey:ProjectID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Translations []GlossaryTranslation `gorm:"foreignKey:TermID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"translations"`
+}
+
+// GlossaryTranslation 术语在某一语言中的规定译文和禁用译文
+type GlossaryTranslation struct {
+	ID          uint64 `gorm:"primaryKey" json:"id"`
+	TermID      uint64 `gorm:"not null;uniqueIndex:idx_glossary_translation_language,priority:1" json:"term_id"`
+	LanguageID  uint64 `gorm:"not null;uniqueIndex:idx_glossary_translation_language,priority:2" json:"language_id"`
+	Translation string `gorm:"size:255" json:"translation"` // 规定译文，为空时只检查禁用译文
+	Forbidden   string `gorm:"type:text" json:"-"`          // 禁用译文（JSON 数组）
+
+	Language Language `gorm:"foreignKey:LanguageID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// OutboxEvent 事务发件箱中的事件
+// 与业务数据在同一事务中写入，由投递器至少一次地投递到事件总线
+type OutboxEvent struct {
+	ID            uint64     `gorm:"primaryKey" json:"id"`
+	EventID       string     `gorm:"size:36;not null;uniqueIndex" json:"event_id"` // 去重键，重复投递时不变
+	EventType     string     `gorm:"size:50;not null" json:"event_type"`
+	ProjectID     uint64     `gorm:"index" json:"project_id"`
+	Payload       string     `gorm:"type:mediumtext;not null" json:"-"` // 完整事件的 JSON
+	Status        string     `gorm:"size:20;not null;default:pending;index:idx_outbox_due,priority:1" json:"status"`
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_due,priority:2" json:"next_attempt_at"`
+	LastError     string     `gorm:"size:500" json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+}
+
+// 发件箱事件状态常量
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	OutboxStatusFailed    = "failed" // 超过最大重试次数（死信），可由管理员重新投递
+)
+
+// AuditLog 项目操作审计日志
+type AuditLog struct {
+	ID        uint64          `gorm:"primaryKey" json:"id"`
+	ProjectID uint64          `gorm:"not null;index:idx_audit_project_time,priority:1" json:"project_id"`
+	UserID    uint64          `gorm:"index" json:"user_id"` // 操作人
+	Action    string          `gorm:"size:50;not null;index" json:"action"`
+	Details   json.RawMessage `gorm:"type:text" json:"details"` // 操作参数和结果的 JSON
+	CreatedAt time.Time       `gorm:"index:idx_audit_project_time,priority:2" json:"created_at"`
+}
+
+// 审计操作类型常量
+const (
+	AuditActionKeyPrefixDelete  = "key_prefix.delete"
+	AuditActionKeyPrefixStatus  = "key_prefix.status"
+	AuditActionKeyPrefixRename  = "key_prefix.rename"
+	AuditActionKeyPrefixExport  = "key_prefix.export"
+	AuditActionKeyRename        = "key.rename"
+	AuditActionKeyCopy          = "key.copy"
+	AuditActionKeyUnlink        = "key.unlink"
+	AuditActionPromotionApply   = "promotion.apply"
+	AuditActionBranchMerge      = "branch.merge"
+	AuditActionReleaseCreate    = "release.create"
+	AuditActionRolloutAdvance   = "release.rollout_advance"
+	AuditActionRolloutAbort     = "release.rollout_abort"
+	AuditActionProjectRollback  = "translation.rollback"
+	AuditActionConfigImport     = "project_config.import"
+	AuditActionBulkDelete       = "translation.bulk_delete"
+	AuditActionHistoryExport    = "history.export"
+	AuditActionProjectArchive   = "project.archive"
+	AuditActionProjectProtect   = "project.protect"
+	AuditActionProjectUnprotect = "project.unprotect"
+	AuditActionDeliveryEnable   = "project.delivery_enable"
+	AuditActionDeliveryDisable  = "project.delivery_disable"
+	AuditActionProjectDuplicate = "project.duplicate"
+	AuditActionUserOffboard     = "user.offboard"     // 系统级操作，project_id 为 0
+	AuditActionUserDeactivate   = "user.deactivate"   // 系统级操作，project_id 为 0
+	AuditActionUserTransfer     = "user.transfer"     // 系统级操作，project_id 为 0
+	AuditActionInvitationExpire = "invitation.expire" // 系统级操作，project_id 为 0
+)
+
+// TranslationHistory 翻译变更历史
+// 由翻译仓储在写入翻译的同一事务中记录，用于追溯修改和合规审计
+type TranslationHistory struct {
+	ID            uint64    `gorm:"primaryKey" json:"id"`
+	TranslationID uint64    `gorm:"not null;index" json:"translation_id"`
+	ProjectID     uint64    `gorm:"not null;index:idx_history_project_time,priority:1" json:"project_id"`
+	KeyName       string    `gorm:"size:255;not null" json:"key_name"`      // 变更后的键名
+	PreviousKey   string    `gorm:"size:255" json:"previous_key,omitempty"` // 重命名前的键名
+	LanguageID    uint64    `gorm:"not null" json:"language_id"`
+	Operation     string    `gorm:"size:20;not null" json:"operation"` // create, update, delete, restore, rename, status, machine_translate, review
+	OldValue      string    `gorm:"type:text" json:"old_value"`
+	NewValue      string    `gorm:"type:text" json:"new_value"`
+	OldStatus     string    `gorm:"size:20" json:"old_status,omitempty"` // review 操作时为审核状态
+	NewStatus     string    `gorm:"size:20" json:"new_status,omitempty"`
+	Comment       string    `gorm:"size:500" json:"comment,omitempty"` // 驳回原因，只用于 review 操作
+	UserID        uint64    `gorm:"index" json:"user_id"` // 操作人，0 表示系统或 CLI
+	CreatedAt     time.Time `gorm:"index:idx_history_project_time,priority:2" json:"created_at"`
+}
+
+// 翻译变更操作类型常量
+const (
+	HistoryOperationCreate           = "create"
+	HistoryOperationUpdate           = "update"
+	HistoryOperationDelete           = "delete"
+	HistoryOperationRestore          = "restore" // 从回收站恢复，或创建时恢复了已软删除的同键翻译
+	HistoryOperationRename           = "rename"
+	HistoryOperationStatus           = "status"
+	HistoryOperationMachineTranslate = "machine_translate" // 机器翻译填充的翻译值
+	HistoryOperationReview           = "review"            // 审核状态变化：提交、通过或驳回
+	HistoryOperationRollback         = "rollback"          // 回滚到历史版本的翻译值
+)
+
+// ActivityCount 按项目、月份、操作人和操作类型汇总的次数
+type ActivityCount struct {
+	ProjectID uint64
+	Month     string // 2006-01
+	UserID    uint64
+	Action    string
+	Count     int64
+}
+
+// DailyActivityCount 按日期、项目、操作人和操作类型汇总的次数
+type DailyActivityCount struct {
+	Date      string // 2006-01-02
+	ProjectID uint64
+	UserID    uint64
+	Action    string
+	Count     int64
+}
+
+// Promotion 环境推送记录
+// 从源环境（远程 YFlow 实例或上传的快照）计算与本实例项目的差异，审核后应用到本实例
+type Promotion struct {
+	ID               uint64     `gorm:"primaryKey" json:"id"`
+	ProjectID        uint64     `gorm:"not null;index" json:"project_id"`               // 目标项目（本实例）
+	Source           string     `gorm:"size:500;not null" json:"source"`                // 源实例地址，上传快照时为 snapshot
+	SourceProject    string     `gorm:"size:100" json:"source_project"`                 // 源实例中的项目ID或标识
+	Status           string     `gorm:"size:20;not null;default:pending" json:"status"` // pending, applied
+	Changes          string     `gorm:"type:mediumtext" json:"-"`                       // 差异的 JSON（[]PromotionChange）
+	AppliedChanges   string     `gorm:"type:mediumtext" json:"-"`                       // 已应用差异的 JSON（[]PromotionChange）
+	MissingLanguages string     `gorm:"size:500" json:"missing_languages"`              // 本实例不存在的语言代码，逗号分隔
+	Signature        string     `gorm:"size:64" json:"signature"`                       // 应用记录的 HMAC-SHA256 签名
+	CreatedBy        uint64     `json:"created_by"`
+	AppliedBy        uint64     `json:"applied_by"`
+	CreatedAt        time.Time  `json:"created_at"`
+	AppliedAt        *time.Time `json:"applied_at"`
+}
+
+// PromotionChange 环境之间的一条翻译差异
+type PromotionChange struct {
+	Key      string `json:"key"`
+	Language string `json:"language"`
+	Type     string `json:"type"` // added, modified, removed
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+// 环境推送状态常量
+const (
+	PromotionStatusPending = "pending"
+	PromotionStatusApplied = "applied"
+)
+
+// ProjectDuplicateJob 项目复制任务
+// 复制项目时先同步创建新项目并复制设置、命名空间和成员，翻译按批复制；翻译较多时在后台复制，通过任务查询进度
+type ProjectDuplicateJob struct {
+	ID              uint64     `gorm:"primaryKey" json:"id"`
+	SourceProjectID uint64     `gorm:"not null;index" json:"source_project_id"`        // 被复制的项目
+	ProjectID       uint64     `gorm:"not null;index" json:"project_id"`               // 新项目
+	Status          string     `gorm:"size:20;not null;default:running" json:"status"` // running, completed, failed
+	IncludeMembers  bool       `gorm:"not null;default:false" json:"include_members"`
+	Total           int64      `gorm:"not null;default:0" json:"total"`  // 需要复制的翻译条数（开始时统计）
+	Copied          int64      `gorm:"not null;default:0" json:"copied"` // 已复制的翻译条数
+	Error           string     `gorm:"size:500" json:"error,omitempty"`  // 失败原因
+	CreatedBy       uint64     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+}
+
+// 项目复制任务状态常量
+const (
+	ProjectDuplicateStatusRunning   = "running"
+	ProjectDuplicateStatusCompleted = "completed"
+	ProjectDuplicateStatusFailed    = "failed" // 复制翻译出错或服务停止时中断，已复制的翻译保留在新项目中
+)
+
+// KeyLink 跨项目链接的键
+// 键从源项目复制到目标项目后保持同步：源项目中该键的翻译新增或修改时写入目标项目，删除不同步。
+// 两个项目中使用相同的键名，重命名任一侧的键后不再同步
+type KeyLink struct {
+	ID              uint64     `gorm:"primaryKey" json:"id"`
+	SourceProjectID uint64     `gorm:"not null;index:idx_key_link_source,priority:1" json:"source_project_id"`
+	TargetProjectID uint64     `gorm:"not null;uniqueIndex:idx_key_link_target,priority:1" json:"target_project_id"`
+	KeyName         string     `gorm:"size:255;not null;index:idx_key_link_source,priority:2;uniqueIndex:idx_key_link_target,priority:2" json:"key_name"` // 目标项目中的键只能链接一个源项目
+	CreatedBy       uint64     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	LastSyncedAt    *time.Time `json:"last_synced_at,omitempty"` // 最近一次把源项目的修改写入目标项目的时间
+}
+
+// 环境推送差异类型常量
+const (
+	PromotionChangeAdded    = "added"
+	PromotionChangeModified = "modified"
+	PromotionChangeRemoved  = "removed"
+)
+
+// PromotionSourceSnapshot 上传快照时 Promotion.Source 的值
+const PromotionSourceSnapshot = "snapshot"
+
+// PersonalAccessToken 个人访问令牌
+// 脚本使用令牌以创建者的身份访问 API，权限与创建者相同；只保存令牌的哈希值
+type PersonalAccessToken struct {
+	ID          uint64     `gorm:"primaryKey" json:"id"`
+	UserID      uint64     `gorm:"not null;index" json:"user_id"`
+	Name        string     `gorm:"size:100;not null" json:"name"`          // 令牌名称，如 CI 同步脚本
+	TokenHash   string     `gorm:"size:64;not null;unique" json:"-"`       // 令牌的 SHA-256 哈希
+	TokenPrefix string     `gorm:"size:16;not null" json:"token_prefix"`   // 令牌开头的几位，用于识别令牌
+	ExpiresAt   time.Time  `gorm:"not null" json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIP  string     `gorm:"size:45" json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// PasswordResetToken 找回密码令牌
+// 令牌通过邮件中的链接发送给用户，只保存哈希值，使用一次后失效
+type PasswordResetToken struct {
+	ID        uint64     `gorm:"primaryKey" json:"id"`
+	UserID    uint64     `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"size:64;not null;unique" json:"-"` // 令牌的 SHA-256 哈希
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// PersonalAccessTokenPrefix 个人访问令牌的固定前缀，用于和 JWT 区分
+const PersonalAccessTokenPrefix = "yfp_"
+
+// IsExpired 检查令牌是否已过期
+func (t *PersonalAccessToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// UserIdentity 用户在单点登录（SSO）身份提供方的账号
+// 同一提供方的同一账号（subject）只能关联一个用户
+type UserIdentity struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	UserID    uint64    `gorm:"not null;index" json:"user_id"`
+	Provider  string    `gorm:"size:50;not null;uniqueIndex:idx_user_identity_subject,priority:1" json:"provider"` // 配置中的提供方名称，如 google
+	Subject   string    `gorm:"size:255;not null;uniqueIndex:idx_user_identity_subject,priority:2" json:"subject"` // 提供方中的账号ID
+	Email     string    `gorm:"size:100" json:"email"`                                                          // 最近一次登录时提供方返回的邮箱
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// APIKey CLI 使用的 API Key
+// 可以限定可访问的项目和权限范围；只保存 API Key 的哈希值
+type APIKey struct {
+	ID             uint64     `gorm:"primaryKey" json:"id"`
+	Name           string     `gorm:"size:100;not null" json:"name"`                   // 名称，如 web 前端 CI
+	KeyHash        string     `gorm:"size:64;not null;unique" json:"-"`                // API Key 的 SHA-256 哈希
+	KeyPrefix      string     `gorm:"size:16;not null" json:"key_prefix"`              // API Key 开头的几位，用于识别
+	Scope          string     `gorm:"size:20;not null;default:read" json:"scope"`      // 权限范围：read, write
+	ProjectIDs     string     `gorm:"size:1000" json:"-"`                              // 可访问的项目ID，逗号分隔，为空时可访问所有项目
+	OrganizationID uint64     `gorm:"not null;default:0;index" json:"organization_id"` // 所属组织，不为 0 时只能访问该组织的项目
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	LastUsedIP     string     `gorm:"size:45" json:"last_used_ip"`
+	CreatedBy      uint64     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// APIKeyPrefix 数据库中管理的 API Key 的固定前缀，用于和 CLI_API_KEY 区分
+const APIKeyPrefix = "yfk_"
+
+// API Key 的权限范围
+const (
+	APIKeyScopeRead  = "read"  // 只能拉取翻译和监听变更
+	APIKeyScopeWrite = "write" // 还可以推送键和导入翻译
+)
+
+// Projects 可访问的项目ID，为空时可访问所有项目
+func (k *APIKey) Projects() []uint64 {
+	var ids []uint64
+	for _, part := range strings.Split(k.ProjectIDs, ",") {
+		if id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64); err == nil && id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AllowsProject 是否可以访问项目
+func (k *APIKey) AllowsProject(projectID uint64) bool {
+	projects := k.Projects()
+	if len(projects) == 0 {
+		return true
+	}
+	for _, id := range projects {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsScope 是否具有权限范围，write 包含 read
+func (k *APIKey) AllowsScope(scope string) bool {
+	return k.Scope == APIKeyScopeWrite || k.Scope == scope
+}
+
+// TableStats 数据表的行数和占用空间
+// 行数来自 information_schema，InnoDB 下为估算值
+type TableStats struct {
+	Table      string `json:"table"`
+	Rows       int64  `json:"rows"`
+	DataBytes  int64  `json:"data_bytes"`
+	IndexBytes int64  `json:"index_bytes"`
+}
+
+// StorageAlert 数据增长超过软限制的告警
+type StorageAlert struct {
+	Table          string `json:"table"`
+	Metric         string `json:"metric"` // rows 或 bytes
+	Value          int64  `json:"value"`
+	Threshold      int64  `json:"threshold"`
+	Recommendation string `json:"recommendation"`
+}
+
+// StorageReport 数据库增长检查结果
+type StorageReport struct {
+	Status    string          `json:"status"` // ok 或 warning
+	CheckedAt time.Time       `json:"checked_at"`
+	Tables    []*TableStats   `json:"tables"`
+	Alerts    []*StorageAlert `json:"alerts"`
+}
+
+// 数据库增长检查状态
+const (
+	StorageStatusOK      = "ok"
+	StorageStatusWarning = "warning"
+)
+
+// 数据库增长告警指标
+const (
+	StorageMetricRows  = "rows"
+	StorageMetricBytes = "bytes"
+)
+
+// UsageStat 翻译拉取用量的日汇总
+// 每个项目、日期、渠道、语言和调用方一行，计数先在 Redis 中累加后定期写入；Locale 为空表示请求未指定语言
+type UsageStat struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	ProjectID uint64    `gorm:"not null;uniqueIndex:idx_usage_stat,priority:1" json:"project_id"`
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_usage_stat,priority:2" json:"date"`               // UTC 日期
+	Channel   string    `gorm:"size:20;not null;uniqueIndex:idx_usage_stat,priority:3" json:"channel"`              // cli 或 export
+	Locale    string    `gorm:"size:20;not null;default:'';uniqueIndex:idx_usage_stat,priority:4" json:"locale"`    // 请求的语言代码
+	Consumer  string    `gorm:"size:100;not null;default:'';uniqueIndex:idx_usage_stat,priority:5" json:"consumer"` // 调用方，如 api_key:3f2a9c1b7d4e、token:12、user:3
+	Requests  int64     `gorm:"not null;default:0" json:"requests"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// 用量统计渠道
+const (
+	UsageChannelCLI      = "cli"      // CLI 拉取翻译
+	UsageChannelExport   = "export"   // 导出翻译文件
+	UsageChannelDelivery = "delivery" // 应用通过公开分发接口获取发布版本
+	UsageChannelGRPC     = "grpc"     // 通过 gRPC 接口拉取翻译
+)
@@ -0,0 +1,21 @@
+package domain
+
+import "context"
+
+// organizationContextKey 当前组织在 context 中的键
+type organizationContextKey struct{}
+
+// WithOrganization 在 context 中指定当前组织，语言和 API Key 的读写限定在该组织内
+// 请求级缓存中的语言列表按组织分别保存，切换组织时继续共用同一个请求级缓存
+func WithOrganization(ctx context.Context, organizationID uint64) context.Context {
+	if OrganizationFromContext(ctx) == organizationID {
+		return ctx
+	}
+	return context.WithValue(ctx, organizationContextKey{}, organizationID)
+}
+
+// OrganizationFromContext 获取当前组织，未指定时返回 0，即不属于任何组织的共享范围
+func OrganizationFromContext(ctx context.Context) uint64 {
+	organizationID, _ := ctx.Value(organizationContextKey{}).(uint64)
+	return organizationID
+}
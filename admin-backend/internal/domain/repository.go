@@ -9,24 +9,60 @@ type UserRepository interface {
 	GetByIDs(ctx context.Context, ids []uint64) ([]*User, error)
 	GetByUsername(ctx context.Context, username string) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
-	GetAll(ctx context.Context, limit, offset int, keyword string) ([]*User, int64, error)
+	GetAll(ctx context.Context, limit, offset int, keyword string, sort []SortField) ([]*User, int64, error)
+	// GetByRole 获取指定全局角色的全部用户
+	GetByRole(ctx context.Context, role string) ([]*User, error)
 	Create(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) error
+	// UpdateProfile 只更新个人资料字段（显示名称、头像、界面语言和时区）
+	UpdateProfile(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uint64) error
 }
 
+// UserOffboardingRepository 用户离职处理数据访问接口
+type UserOffboardingRepository interface {
+	// ReassignReferences 将内容的创建人、修改人等引用从 fromID 改为 toID（0 表示匿名），邀请码的邀请人改为 inviterID
+	ReassignReferences(ctx context.Context, fromID, toID, inviterID uint64) (map[string]int64, error)
+	// AnonymizeActivity 将翻译变更历史和审计日志中的操作人置为 0，清除邀请码中的被邀请人和邮箱
+	AnonymizeActivity(ctx context.Context, userID uint64, email string) (int64, error)
+	// RemoveMemberships 删除用户的项目成员关系、角色复核记录和任务分配
+	RemoveMemberships(ctx context.Context, userID uint64) (int64, error)
+	DeleteAccessTokens(ctx context.Context, userID uint64) (int64, error)
+	// ReassignInvitations 将用户作为邀请人的全部邀请码改为 toID
+	ReassignInvitations(ctx context.Context, fromID, toID uint64) (int64, error)
+	// CountActiveInvitations 统计用户作为邀请人的有效邀请码
+	CountActiveInvitations(ctx context.Context, inviterID uint64) (int64, error)
+}
+
 // ProjectRepository 项目数据访问接口
 type ProjectRepository interface {
 	GetByID(ctx context.Context, id uint64) (*Project, error)
 	GetByIDs(ctx context.Context, ids []uint64) ([]*Project, error)
 	GetBySlug(ctx context.Context, slug string) (*Project, error)
-	GetAll(ctx context.Context, limit, offset int, keyword string) ([]*Project, int64, error)
+	GetBySlugAlias(ctx context.Context, slug string) (*Project, error)
+	SlugTaken(ctx context.Context, slug string, excludeProjectID uint64) (bool, error)
+	CreateSlugAlias(ctx context.Context, alias *ProjectSlugAlias) error
+	DeleteSlugAlias(ctx context.Context, projectID uint64, slug string) error
+	GetAll(ctx context.Context, limit, offset int, keyword string, sort []SortField) ([]*Project, int64, error)
+	GetByOrganization(ctx context.Context, organizationID uint64) ([]*Project, error)
 	Create(ctx context.Context, project *Project) error
 	Update(ctx context.Context, project *Project) error
 	Delete(ctx context.Context, id uint64) error
+	// RecordActivity 写入项目最近的变更和访问时间，只会把时间往后推
+	RecordActivity(ctx context.Context, activities []*ProjectActivity) error
+	// GetStale 获取最近变更（从未变更时为创建时间）早于 before 的活跃项目，最久未变更的在前
+	GetStale(ctx context.Context, before time.Time, limit, offset int) ([]*Project, int64, error)
+}
+
+// ProjectActivity 项目最近的活动时间，零值表示期间没有对应的活动
+type ProjectActivity struct {
+	ProjectID      uint64
+	LastChangedAt  time.Time
+	LastAccessedAt time.Time
 }
 
 // LanguageRepository 语言数据访问接口
+// GetByCode、GetAll 和 GetDefault 只返回 context 中当前组织的语言
 type LanguageRepository interface {
 	GetByID(ctx context.Context, id uint64) (*Language, error)
 	GetByIDs(ctx context.Context, ids []uint64) ([]*Language, error)
@@ -41,11 +77,69 @@ type LanguageRepository interface {
 // TranslationRepository 翻译数据访问接口
 type TranslationRepository interface {
 	GetByID(ctx context.Context, id uint64) (*Translation, error)
-	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Translation, int64, error)
+	GetByIDs(ctx context.Context, ids []uint64) ([]*Translation, error)
+	// GetByProjectID 分页获取项目的翻译，reviewStatus 不为空时只返回该审核状态的翻译，sort 为空时不指定顺序
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int, reviewStatus string, sort []SortField) ([]*Translation, int64, error)
+	// GetPageByProjectID 按 ID 顺序游标分页获取项目的翻译，大偏移量时不需要扫描之前的行
+	GetPageByProjectID(ctx context.Context, query TranslationPageQuery) (*TranslationPage, error)
+	// StreamByProject 按 ID 顺序分批读取项目中未删除的翻译，fn 返回错误时停止
+	StreamByProject(ctx context.Context, projectID uint64, batchSize int, fn func([]*Translation) error) error
 	GetByProjectAndLanguage(ctx context.Context, projectID, languageID uint64) ([]*Translation, error)
 	GetByProjectKeyLanguage(ctx context.Context, projectID uint64, keyName string, languageID uint64) (*Translation, error)
 	GetByProjectKeyLanguages(ctx context.Context, keys []TranslationKey) ([]*Translation, error)
 	GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]TranslationCell, int64, error)
+	GetKeyPage(ctx context.Context, query TranslationKeyPageQuery) (*TranslationKeyPage, error)
+	// 翻译矩阵的组成部分，用于在服务层按区域设置排序后分页
+	SearchKeyNames(ctx context.Context, projectID uint64, keyword, searchCollation, reviewStatus string) ([]string, error)
+	GetKeyValues(ctx context.Context, projectID uint64, languageCode string) (map[string]string, error)
+	GetMatrixCells(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]TranslationCell, error)
+	GetValuesByStatus(ctx context.Context, projectID uint64, filter TranslationStatusFilter) (map[string]map[string]string, error)
+	// GetKeyContexts 获取每个键的上下文说明（取任一语言中最早写入的非空上下文）
+	GetKeyContexts(ctx context.Context, projectID uint64) (map[string]string, error)
+	// 按键名前缀批量操作，包括所有状态的翻译
+	GetKeyNamesByPrefix(ctx context.Context, projectID uint64, prefix string) ([]string, error)
+	CountByPrefix(ctx context.Context, projectID uint64, prefix string) (int64, error)
+	// FindExistingKeyNames 返回已存在的键名，不包括已软删除的翻译（重命名时会被清除）
+	FindExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error)
+	// GetKeyValueTypes 获取非 string 类型的键的值类型，keyNames 为空时返回项目中的所有键
+	GetKeyValueTypes(ctx context.Context, projectID uint64, keyNames []string) (map[string]KeyValueType, error)
+	// GetByProjectKey 获取键在各语言的翻译
+	GetByProjectKey(ctx context.Context, projectID uint64, keyName string) ([]*Translation, error)
+	// GetActiveByValueTypes 获取项目中指定值类型的有效翻译，不包括已废弃的翻译
+	GetActiveByValueTypes(ctx context.Context, projectID uint64, valueTypes []string) ([]*Translation, error)
+	// UpdateKeyValueType 修改键在所有语言的值类型，返回修改的条数
+	UpdateKeyValueType(ctx context.Context, projectID uint64, keyName string, valueType KeyValueType, userID uint64) (int64, error)
+	// GetKeyMetadata 获取设置了最大长度、标签、平台或命名空间的键的元数据，keyNames 为空时返回项目中的所有键
+	GetKeyMetadata(ctx context.Context, projectID uint64, keyNames []string) (map[string]KeyMetadata, error)
+	// UpdateReviewStatus 修改翻译的审核状态并记录操作人和驳回原因，每条翻译写入一条 review 变更历史，返回修改的条数
+	UpdateReviewStatus(ctx context.Context, ids []uint64, reviewStatus string, userID uint64, comment string) (int64, error)
+	// MarkOutdated 将键在源语言以外的非空译文的审核状态改为 outdated，不修改 updated_at，返回修改的条数
+	MarkOutdated(ctx context.Context, projectID, sourceLanguageID uint64, keyNames []string) (int64, error)
+	// CountByReviewStatus 统计所有项目中该审核状态的有效翻译数
+	CountByReviewStatus(ctx context.Context, reviewStatus string) (int64, error)
+	// CountByLanguage 在数据库中按语言聚合统计项目的有效翻译，返回项目有效的键数量和各语言的计数
+	CountByLanguage(ctx context.Context, projectID uint64) (int64, []*LanguageTranslationCount, error)
+	// GetPendingKeys 统计某语言待翻译或待审核的键，返回总数和按键名排序的前 Limit 个键
+	GetPendingKeys(ctx context.Context, query PendingKeyQuery) (int64, []string, error)
+	// GetKeyNamesByNamespace 获取命名空间中的键名（按键名排序），包括所有状态的翻译
+	GetKeyNamesByNamespace(ctx context.Context, projectID, namespaceID uint64) ([]string, error)
+	// GetKeyNamesByTags 获取带有任一标签（按名称）的键名，按键名排序
+	GetKeyNamesByTags(ctx context.Context, projectID uint64, tags []string) ([]string, error)
+	// UpdateKeyNamespace 将键在所有语言的翻译归入命名空间（namespaceID 为 0 时移出命名空间），返回修改的条数
+	UpdateKeyNamespace(ctx context.Context, projectID uint64, keyNames []string, namespaceID, userID uint64) (int64, error)
+	// UpdateKeyMetadata 修改键在所有语言的最大长度、标签和平台，不修改命名空间，返回修改的条数
+	// 键与标签的关联同时改为 metadata.Tags 中的标签，项目中没有的标签自动创建
+	UpdateKeyMetadata(ctx context.Context, projectID uint64, keyName string, metadata KeyMetadata, userID uint64) (int64, error)
+	DeleteByPrefix(ctx context.Context, projectID uint64, prefix string, userID uint64) (int64, error)
+	UpdateStatusByPrefix(ctx context.Context, projectID uint64, prefix, status string, userID uint64) (int64, error)
+	RenamePrefix(ctx context.Context, projectID uint64, prefix, newPrefix string, userID uint64) (int64, error)
+	// RenameKey 将键在所有语言的翻译改为新键名并记录变更历史，返回修改的条数
+	RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string, userID uint64) (int64, error)
+	// 按条件批量删除
+	CountByFilter(ctx context.Context, filter TranslationFilter) (translations int64, keys int64, err error)
+	GetKeyNamesByFilter(ctx context.Context, filter TranslationFilter, limit int) ([]string, error)
+	FindByFilter(ctx context.Context, filter TranslationFilter, limit int) ([]*Translation, error)
+	DeleteByIDs(ctx context.Context, ids []uint64, userID uint64) (int64, error)
 	GetStats(ctx context.Context) (totalTranslations int, totalKeys int, err error)
 	Create(ctx context.Context, translation *Translation) error
 	CreateBatch(ctx context.Context, translations []*Translation) error
@@ -53,6 +147,22 @@ type TranslationRepository interface {
 	Update(ctx context.Context, translation *Translation) error
 	Delete(ctx context.Context, id uint64) error
 	DeleteBatch(ctx context.Context, ids []uint64) error
+	// 回收站：已软删除的翻译
+	// GetDeleted 分页获取项目中已删除的翻译，最近删除的在前
+	GetDeleted(ctx context.Context, projectID uint64, limit, offset int) ([]*Translation, int64, error)
+	// GetDeletedByID 获取已删除的翻译，不存在或未删除时返回 ErrTranslationNotFound
+	GetDeletedByID(ctx context.Context, id uint64) (*Translation, error)
+	// Restore 恢复已删除的翻译，同时写入键级别的元数据（值类型、最大长度、标签、平台、命名空间），记录 restore 变更历史
+	Restore(ctx context.Context, translation *Translation, userID uint64) error
+	// PurgeDeleted 永久删除删除时间早于 before 的翻译，最多 limit 条，返回删除的条数
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// LanguageTranslationCount 项目中一种语言的有效翻译计数
+type LanguageTranslationCount struct {
+	LanguageID uint64
+	Translated int64 // 译文不为空且审核状态不是 outdated
+	Outdated   int64 // 译文不为空且审核状态为 outdated
 }
 
 // TranslationKey 用于批量查询的翻译键
@@ -62,11 +172,102 @@ type TranslationKey struct {
 	LanguageID uint64
 }
 
+// TranslationFilter 按条件批量操作翻译的筛选条件，字段为空时不限制
+type TranslationFilter struct {
+	ProjectID  uint64
+	KeyPrefix  string
+	LanguageID uint64
+	Status     string
+	OlderThan  *time.Time // updated_at 早于该时间
+}
+
+// HistoryQuery 按时间范围查询历史记录的条件，时间范围为 [From, To)，ProjectID 为 0 时不限制项目
+type HistoryQuery struct {
+	ProjectID uint64
+	From      time.Time
+	To        time.Time
+}
+
+// AuditLogPageQuery 按时间倒序游标分页查询审计日志的条件，ProjectID 为 0 时查询系统级审计日志
+type AuditLogPageQuery struct {
+	ProjectID uint64
+	After     *AuditLogCursor // 上一页最后一条日志的位置，为 nil 时从最新的日志开始
+	Limit     int
+}
+
+// AuditLogCursor 审计日志在 created_at DESC, id DESC 排序中的位置
+type AuditLogCursor struct {
+	CreatedAt time.Time
+	ID        uint64
+}
+
+// AuditLogPage 游标分页的审计日志
+type AuditLogPage struct {
+	Logs    []*AuditLog
+	Total   int64 // 符合条件的日志总数（不受游标影响）
+	HasMore bool  // 是否还有下一页
+}
+
+// PendingKeyQuery 查询某语言待处理键的条件
+// 只统计在启用语言中有有效翻译的键；Review 为 false 时待翻译指该语言缺少有效译文、译文为空、为草稿或已过期（outdated），
+// 为 true 时待审核指该语言的有效翻译处于 in_review
+type PendingKeyQuery struct {
+	ProjectID   uint64
+	LanguageID  uint64
+	Review      bool
+	KeyNames    []string // 不为空时只统计这些键
+	ExcludeKeys []string // 不统计的键
+	Limit       int      // 最多返回的键数量，不影响总数
+}
+
+// TranslationPageQuery 按 ID 顺序游标分页查询项目翻译的条件
+type TranslationPageQuery struct {
+	ProjectID    uint64
+	ReviewStatus string // 只返回该审核状态的翻译，为空时不限制
+	AfterID      uint64 // 只返回 ID 大于该值的翻译，用于键集分页
+	Limit        int
+}
+
+// TranslationPage 游标分页的翻译
+type TranslationPage struct {
+	Translations []*Translation
+	Total        int64 // 符合条件的翻译总数（不受游标影响）
+	HasMore      bool  // 是否还有下一页
+}
+
+// TranslationKeyPageQuery 按键名分页查询翻译的条件
+type TranslationKeyPageQuery struct {
+	ProjectID uint64
+	Locale    string   // 只返回该语言的翻译，为空时返回所有语言
+	KeyPrefix string   // 只返回以该前缀开头的键，为空时不限制
+	AfterKey  string   // 只返回键名大于该值的键，用于键集分页
+	KeyNames  []string // 不为空时只返回这些键
+	Limit     int      // 本页最多返回的键数量
+	Release   string   // 发布版本标签，指定时从该版本的快照中读取，为空时读取当前翻译
+}
+
+// TranslationKeyPage 按键名分页的翻译数据
+type TranslationKeyPage struct {
+	Translations map[string]map[string]string // key -> language_code -> value
+	LastKey      string                       // 本页最后一个键名，作为下一页的 AfterKey
+	HasMore      bool                         // 是否还有下一页
+}
+
 // TranslationCell 翻译矩阵单元格数据
 type TranslationCell struct {
-	ID        uint64    `json:"id"`
-	Value     string    `json:"value"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                uint64    `json:"id"`
+	Value             string    `json:"value"`
+	ValueType         string    `json:"value_type"`
+	Status            string    `json:"status"`
+	UpdatedBy         uint64    `json:"updated_by"`          // 最后修改人ID，为 0 时未记录
+	UpdatedByUsername string    `json:"updated_by_username"` // 最后修改人的用户名，用户不存在时为空
+	UpdatedAt         time.Time `json:"updated_at"`
+	QAIssues          []string  `json:"qa_issues,omitempty"`       // 与默认语言的译文比较发现的问题类型，见 QAIssue* 常量
+	MaxLength         int       `json:"max_length,omitempty"`      // 键的最大字符数，0 表示不限制
+	ReviewStatus      string    `json:"review_status"`             // 审核状态：draft, in_review, approved, outdated
+	Version           uint64    `json:"version"`                   // 翻译的版本号，更新单元格时作为 version 提交
+	AttachmentURLs    []string  `json:"attachment_urls,omitempty"` // 键的附件（截图）地址，同一键的所有语言相同
+	Outdated          int       `json:"outdated,omitempty"`        // 只用于项目源语言的单元格：该键在其他语言中 outdated 的译文数
 }
 
 // ProjectMemberRepository 项目成员数据访问接口
@@ -74,11 +275,26 @@ type ProjectMemberRepository interface {
 	GetByProjectAndUser(ctx context.Context, projectID, userID uint64) (*ProjectMember, error)
 	GetByProjectID(ctx context.Context, projectID uint64) ([]*ProjectMember, error)
 	GetByUserID(ctx context.Context, userID uint64) ([]*ProjectMember, error)
+	// GetBySource 获取指定来源和角色的全部成员关系
+	GetBySource(ctx context.Context, source, role string) ([]*ProjectMember, error)
 	Create(ctx context.Context, member *ProjectMember) error
+	// CreateOrRestore 创建成员关系，同一项目和用户已被移除的成员关系恢复并使用新的角色和来源
+	CreateOrRestore(ctx context.Context, member *ProjectMember) error
 	Update(ctx context.Context, member *ProjectMember) error
 	Delete(ctx context.Context, projectID, userID uint64) error
 }
 
+// RoleReviewRepository 项目所有者角色复核数据访问接口
+type RoleReviewRepository interface {
+	GetByID(ctx context.Context, id uint64) (*RoleReview, error)
+	// GetPendingProjectIDs 获取用户待复核的项目ID
+	GetPendingProjectIDs(ctx context.Context, userID uint64) ([]uint64, error)
+	// List 按创建时间倒序获取复核记录，status 为空时不过滤
+	List(ctx context.Context, status string, limit, offset int) ([]*RoleReview, int64, error)
+	CreateBatch(ctx context.Context, reviews []*RoleReview) error
+	Update(ctx context.Context, review *RoleReview) error
+}
+
 // InvitationRepository 邀请码数据访问接口
 type InvitationRepository interface {
 	GetByID(ctx context.Context, id uint64) (*Invitation, error)
@@ -91,4 +307,365 @@ type InvitationRepository interface {
 	Revoke(ctx context.Context, code string) error
 	Delete(ctx context.Context, code string) error
 	DeleteByID(ctx context.Context, id uint64) error
+	CreateBatch(ctx context.Context, invitations []*Invitation) error
+	RevokeByBatch(ctx context.Context, batchLabel string) (int64, error)
+	// ExpireActive 将 expires_at 不晚于 now 的有效邀请码标记为 expired，每次最多 limit 条，返回标记的邀请码
+	ExpireActive(ctx context.Context, now time.Time, limit int) ([]*Invitation, error)
+	CountByBatch(ctx context.Context, batchLabel string) (int64, error)
+}
+
+// InboundWebhookRepository 入站 Webhook 数据访问接口
+type InboundWebhookRepository interface {
+	GetByID(ctx context.Context, id uint64) (*InboundWebhook, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*InboundWebhook, error)
+	Create(ctx context.Context, webhook *InboundWebhook) error
+	Update(ctx context.Context, webhook *InboundWebhook) error
+	Delete(ctx context.Context, id uint64) error
+	CreateLog(ctx context.Context, log *InboundWebhookLog) error
+	GetLogs(ctx context.Context, webhookID uint64, limit, offset int) ([]*InboundWebhookLog, int64, error)
+}
+
+// WebhookRepository 出站 Webhook 数据访问接口
+type WebhookRepository interface {
+	GetByID(ctx context.Context, id uint64) (*Webhook, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Webhook, error)
+	Create(ctx context.Context, webhook *Webhook) error
+	Update(ctx context.Context, webhook *Webhook) error
+	// Delete 删除 Webhook 及其推送记录
+	Delete(ctx context.Context, id uint64) error
+	// CreateDeliveries 写入待推送的记录，同一 Webhook 已记录过的事件跳过
+	CreateDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error
+	// GetDueDeliveries 获取到期待推送的记录，按写入顺序返回
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error)
+	// ClaimDelivery 领取推送：尝试次数加一并推迟下次推送时间，尝试次数不匹配说明已被其他实例领取
+	ClaimDelivery(ctx context.Context, id uint64, attempts int, leaseUntil time.Time) (bool, error)
+	MarkDelivered(ctx context.Context, id uint64, responseStatus int) error
+	// MarkFailed 记录推送失败，status 为 pending 时在 nextAttemptAt 后重试
+	MarkFailed(ctx context.Context, id uint64, status string, nextAttemptAt time.Time, responseStatus int, lastError string) error
+	// GetDeliveries 分页获取 Webhook 的推送记录，最新的在前
+	GetDeliveries(ctx context.Context, webhookID uint64, limit, offset int) ([]*WebhookDelivery, int64, error)
+	// DeleteDeliveredBefore 删除指定时间之前推送成功的记录
+	DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ImportProfileRepository 表格导入映射配置数据访问接口
+type ImportProfileRepository interface {
+	GetByID(ctx context.Context, id uint64) (*ImportProfile, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*ImportProfile, error)
+	Create(ctx context.Context, profile *ImportProfile) error
+	Update(ctx context.Context, profile *ImportProfile) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// GlossaryRepository 术语表数据访问接口，查询术语时包含各语言的译文
+type GlossaryRepository interface {
+	GetByID(ctx context.Context, id uint64) (*GlossaryTerm, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*GlossaryTerm, error)
+	// GetByTerm 获取项目中的术语，按数据库的排序规则比较（不区分大小写）
+	GetByTerm(ctx context.Context, projectID uint64, term string) (*GlossaryTerm, error)
+	Create(ctx context.Context, term *GlossaryTerm) error
+	Update(ctx context.Context, term *GlossaryTerm) error
+	// ReplaceTranslations 替换术语在各语言的译文，调用方负责在事务中执行
+	ReplaceTranslations(ctx context.Context, termID uint64, translations []GlossaryTranslation) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// FigmaRepository Figma 设计稿画框和图层数据访问接口
+// 除 GetScreenshot 外，查询画框时不读取截图内容
+type FigmaRepository interface {
+	GetFrameByID(ctx context.Context, id uint64) (*FigmaFrame, error)
+	GetFrameByNode(ctx context.Context, projectID uint64, fileKey, nodeID string) (*FigmaFrame, error)
+	// FindFrames 查询项目中的画框及其图层，keyName 不为空时只返回引用了该键的画框
+	FindFrames(ctx context.Context, projectID uint64, fileKey, keyName string) ([]*FigmaFrame, error)
+	GetScreenshot(ctx context.Context, id uint64) (data []byte, mediaType string, err error)
+	CreateFrame(ctx context.Context, frame *FigmaFrame) error
+	// UpdateFrame 更新画框的名称和更新人，不修改截图
+	UpdateFrame(ctx context.Context, frame *FigmaFrame) error
+	UpdateScreenshot(ctx context.Context, id uint64, data []byte, mediaType string, userID uint64) error
+	// ReplaceLayers 用 layers 替换画框的全部图层
+	ReplaceLayers(ctx context.Context, frameID uint64, layers []*FigmaLayer) error
+	DeleteFrame(ctx context.Context, id uint64) error
+	// RenameKey 将引用该键的图层改为新键名，返回修改的条数
+	RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error)
+}
+
+// AssignmentRepository 任务分配数据访问接口
+type AssignmentRepository interface {
+	GetByID(ctx context.Context, id uint64) (*Assignment, error)
+	// GetByProjectID 获取项目的任务分配，包含对应的语言和用户
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Assignment, error)
+	// GetByUserID 获取分配给用户的任务，包含对应的项目和语言
+	GetByUserID(ctx context.Context, userID uint64) ([]*Assignment, error)
+	// Upsert 写入任务分配，同一语言、键和工作类型已有分配时改为新的用户
+	Upsert(ctx context.Context, assignments []*Assignment) error
+	Delete(ctx context.Context, id uint64) error
+	// RenameKey 将分配到键的任务改为新键名，返回修改的条数
+	RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error)
+}
+
+// AttachmentRepository 附件数据访问接口
+type AttachmentRepository interface {
+	GetByID(ctx context.Context, id uint64) (*Attachment, error)
+	// GetByProjectID 获取项目的附件，keyName 不为空时只返回该键的附件
+	GetByProjectID(ctx context.Context, projectID uint64, keyName string) ([]*Attachment, error)
+	CountByKey(ctx context.Context, projectID uint64, keyName string) (int64, error)
+	Create(ctx context.Context, attachment *Attachment) error
+	Delete(ctx context.Context, id uint64) error
+	// RenameKey 将键的附件改为新键名，返回修改的条数
+	RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error)
+}
+
+// TagRepository 标签数据访问接口
+// 修改键的标签或标签名称时同步翻译的 tags 列
+type TagRepository interface {
+	GetByID(ctx context.Context, id uint64) (*Tag, error)
+	GetByName(ctx context.Context, projectID uint64, name string) (*Tag, error)
+	// GetByProjectID 获取项目的标签（按名称排序），包含每个标签的键数量
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Tag, error)
+	Create(ctx context.Context, tag *Tag) error
+	// Update 更新标签，名称变化时同步带有该标签的键的 tags 列
+	Update(ctx context.Context, tag *Tag) error
+	// Delete 删除标签并移除键上的该标签
+	Delete(ctx context.Context, id uint64) error
+	// GetKeyNames 获取带有标签的键名（按键名排序）
+	GetKeyNames(ctx context.Context, tagID uint64) ([]string, error)
+	// CountKeyTags 统计键的标签数量，没有标签的键不在结果中
+	CountKeyTags(ctx context.Context, projectID uint64, keyNames []string) (map[string]int, error)
+	// AddKeys 为键添加标签，返回新增了标签的键数量
+	AddKeys(ctx context.Context, tag *Tag, keyNames []string) (int64, error)
+	// RemoveKeys 移除键上的标签，返回移除了标签的键数量
+	RemoveKeys(ctx context.Context, tag *Tag, keyNames []string) (int64, error)
+}
+
+// NamespaceRepository 命名空间数据访问接口
+type NamespaceRepository interface {
+	GetByID(ctx context.Context, id uint64) (*Namespace, error)
+	GetByName(ctx context.Context, projectID uint64, name string) (*Namespace, error)
+	// GetByProjectID 获取项目的命名空间（按名称排序），包含每个命名空间的键数量
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Namespace, error)
+	Create(ctx context.Context, namespace *Namespace) error
+	Update(ctx context.Context, namespace *Namespace) error
+	// Delete 删除命名空间，其中的键回到未划分状态
+	Delete(ctx context.Context, id uint64) error
+}
+
+// BranchRepository 翻译分支数据访问接口
+type BranchRepository interface {
+	GetByID(ctx context.Context, id uint64) (*Branch, error)
+	GetByName(ctx context.Context, projectID uint64, name string) (*Branch, error)
+	// GetByProjectID 获取项目的分支，按名称排序
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Branch, error)
+	Create(ctx context.Context, branch *Branch) error
+	Update(ctx context.Context, branch *Branch) error
+	// Delete 删除分支及其中修改过的翻译
+	Delete(ctx context.Context, id uint64) error
+	// GetTranslations 获取分支中修改过的翻译，按键名和语言排序
+	GetTranslations(ctx context.Context, branchID uint64) ([]*BranchTranslation, error)
+	// UpsertTranslations 写入分支中的翻译，已有记录只更新值和删除标记，保留首次修改时记录的主线值
+	UpsertTranslations(ctx context.Context, translations []*BranchTranslation) error
+	// RenameKey 将项目未合并分支中该键的翻译改为新键名，返回修改的条数
+	RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error)
+}
+
+// ReleaseRepository 发布版本数据访问接口
+type ReleaseRepository interface {
+	// Create 创建发布版本并冻结项目当前的翻译，同时写入键数量和翻译数量
+	Create(ctx context.Context, release *Release) error
+	GetByID(ctx context.Context, id uint64) (*Release, error)
+	GetByVersion(ctx context.Context, projectID uint64, version string) (*Release, error)
+	// GetLatest 获取项目最新的全量发布版本（直接全量发布或灰度已完成），没有时返回 ErrReleaseNotFound
+	GetLatest(ctx context.Context, projectID uint64) (*Release, error)
+	// GetActiveRollout 获取项目进行中的灰度发布版本，没有时返回 ErrReleaseNotFound
+	GetActiveRollout(ctx context.Context, projectID uint64) (*Release, error)
+	// UpdateRollout 保存发布版本的灰度发布状态和百分比
+	UpdateRollout(ctx context.Context, release *Release) error
+	// GetByProjectID 分页获取项目的发布版本，最新的在前
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Release, int64, error)
+	// GetValues 获取发布版本的翻译：键名 -> 语言代码 -> 翻译值
+	GetValues(ctx context.Context, releaseID uint64) (map[string]map[string]string, error)
+	// GetValueTypes 获取发布版本中非 string 类型的键的值类型
+	GetValueTypes(ctx context.Context, releaseID uint64) (map[string]KeyValueType, error)
+	// GetLocaleTranslations 获取发布版本中一种语言的翻译（键名、值和值类型）
+	GetLocaleTranslations(ctx context.Context, releaseID uint64, languageCode string) ([]*ReleaseTranslation, error)
+	// GetKeyPage 按键名分页获取发布版本的翻译，忽略 query.ProjectID
+	GetKeyPage(ctx context.Context, releaseID uint64, query TranslationKeyPageQuery) (*TranslationKeyPage, error)
+}
+
+// ReleaseThresholdRepository 发布门槛数据访问接口
+type ReleaseThresholdRepository interface {
+	// GetByProjectID 获取项目的发布门槛，包含对应的语言
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*ReleaseThreshold, error)
+	// ReplaceByProjectID 用 thresholds 替换项目的全部发布门槛
+	ReplaceByProjectID(ctx context.Context, projectID uint64, thresholds []*ReleaseThreshold) error
+}
+
+// AuditLogRepository 审计日志数据访问接口
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *AuditLog) error
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*AuditLog, int64, error)
+	// GetPage 按时间倒序游标分页获取审计日志
+	GetPage(ctx context.Context, query AuditLogPageQuery) (*AuditLogPage, error)
+	// Stream 按 ID 顺序分批读取时间范围内的审计日志，fn 返回错误时停止
+	Stream(ctx context.Context, query HistoryQuery, batchSize int, fn func([]*AuditLog) error) error
+	// CountActivity 按项目、月份、操作人和操作类型统计时间范围内的审计日志
+	CountActivity(ctx context.Context, query HistoryQuery) ([]*ActivityCount, error)
+}
+
+// TranslationHistoryRepository 翻译变更历史数据访问接口
+// 历史记录由 TranslationRepository 在写入翻译时记录，这里只提供查询
+type TranslationHistoryRepository interface {
+	// Stream 按 ID 顺序分批读取时间范围内的变更历史，fn 返回错误时停止
+	Stream(ctx context.Context, query HistoryQuery, batchSize int, fn func([]*TranslationHistory) error) error
+	// CountActivity 按项目、月份、操作人和操作类型统计时间范围内的变更历史
+	CountActivity(ctx context.Context, query HistoryQuery) ([]*ActivityCount, error)
+	// CountDailyActivity 按日期、项目、操作人和操作类型统计时间范围内 operations 中操作类型的变更历史
+	CountDailyActivity(ctx context.Context, query HistoryQuery, operations []string) ([]*DailyActivityCount, error)
+	GetByID(ctx context.Context, id uint64) (*TranslationHistory, error)
+	// GetVersionsAt 获取项目中 at 之后有变更的翻译在 at 时刻的版本（at 及之前最后一条变更历史），
+	// 按翻译ID索引；at 之后才创建的翻译没有当时的版本，值为 nil
+	GetVersionsAt(ctx context.Context, projectID uint64, at time.Time) (map[uint64]*TranslationHistory, error)
+	// GetChangedKeys 获取时间范围内有变更的键名，包括重命名前的键名，按键名排序
+	GetChangedKeys(ctx context.Context, query HistoryQuery) ([]string, error)
+}
+
+// PromotionRepository 环境推送记录数据访问接口
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *Promotion) error
+	GetByID(ctx context.Context, id uint64) (*Promotion, error)
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Promotion, int64, error)
+	Update(ctx context.Context, promotion *Promotion) error
+}
+
+// KeyLinkRepository 跨项目键链接数据访问接口
+type KeyLinkRepository interface {
+	// GetBySource 获取从源项目链接出去的键，keyNames 为空时返回项目的全部链接
+	GetBySource(ctx context.Context, sourceProjectID uint64, keyNames []string) ([]*KeyLink, error)
+	// GetByProject 获取项目作为源项目或目标项目的链接，按键名排序
+	GetByProject(ctx context.Context, projectID uint64) ([]*KeyLink, error)
+	// Upsert 写入链接，目标项目中的键已有链接时改为新的源项目
+	Upsert(ctx context.Context, links []*KeyLink) error
+	// MarkSynced 记录链接的同步时间
+	MarkSynced(ctx context.Context, ids []uint64, syncedAt time.Time) error
+	// Delete 删除目标项目中键的链接，不存在时返回 ErrKeyLinkNotFound
+	Delete(ctx context.Context, targetProjectID uint64, keyName string) error
+}
+
+// ProjectDuplicateJobRepository 项目复制任务数据访问接口
+type ProjectDuplicateJobRepository interface {
+	Create(ctx context.Context, job *ProjectDuplicateJob) error
+	GetByID(ctx context.Context, id uint64) (*ProjectDuplicateJob, error)
+	Update(ctx context.Context, job *ProjectDuplicateJob) error
+}
+
+// PersonalAccessTokenRepository 个人访问令牌数据访问接口
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *PersonalAccessToken) error
+	GetByID(ctx context.Context, id uint64) (*PersonalAccessToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	GetByUserID(ctx context.Context, userID uint64) ([]*PersonalAccessToken, error)
+	CountByUserID(ctx context.Context, userID uint64) (int64, error)
+	UpdateLastUsed(ctx context.Context, id uint64, usedAt time.Time, ip string) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// UserIdentityRepository 单点登录账号关联数据访问接口
+type UserIdentityRepository interface {
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*UserIdentity, error)
+	Create(ctx context.Context, identity *UserIdentity) error
+	Update(ctx context.Context, identity *UserIdentity) error
+}
+
+// APIKeyRepository API Key 数据访问接口
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id uint64) (*APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	GetAll(ctx context.Context) ([]*APIKey, error)
+	GetByOrganization(ctx context.Context, organizationID uint64) ([]*APIKey, error)
+	UpdateLastUsed(ctx context.Context, id uint64, usedAt time.Time, ip string) error
+	Delete(ctx context.Context, id uint64) error
+}
+
+// PasswordResetRepository 找回密码令牌数据访问接口
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *PasswordResetToken) error
+	GetByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	// MarkUsed 将未使用的令牌标记为已使用，令牌已被使用时返回 false
+	MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error)
+	// DeleteExpiredBefore 删除在 before 之前过期的令牌
+	DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// StorageStatsRepository 数据表统计信息访问接口
+type StorageStatsRepository interface {
+	// GetTableStats 获取当前数据库中指定表的统计信息，不存在的表不返回
+	GetTableStats(ctx context.Context, tables []string) ([]*TableStats, error)
+}
+
+// Transactor 事务管理接口
+// 事务通过 context 传递，在 fn 中调用的仓储方法自动加入同一事务
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// AfterCommit 注册事务提交后的回调，不在事务中时立即执行
+	AfterCommit(ctx context.Context, fn func(ctx context.Context))
+}
+
+// OutboxRepository 事务发件箱数据访问接口
+type OutboxRepository interface {
+	Create(ctx context.Context, event *OutboxEvent) error
+	// GetDue 获取到期待投递的事件
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*OutboxEvent, error)
+	// Claim 以尝试次数作为版本号领取事件，返回是否领取成功（防止多实例重复投递）
+	Claim(ctx context.Context, id uint64, attempts int, leaseUntil time.Time) (bool, error)
+	MarkDelivered(ctx context.Context, id uint64) error
+	MarkFailed(ctx context.Context, id uint64, status string, nextAttemptAt time.Time, lastError string) error
+	// DeleteDeliveredBefore 清理已投递的历史事件
+	DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error)
+	// GetFailed 获取死信事件，eventType 为空时不过滤
+	GetFailed(ctx context.Context, eventType string, limit, offset int) ([]*OutboxEvent, int64, error)
+	// Requeue 将死信事件重置为待投递并清零尝试次数，ids 为空时重置全部死信事件，返回重置的数量
+	Requeue(ctx context.Context, ids []uint64, nextAttemptAt time.Time) (int64, error)
+}
+
+// UsageStatRepository 翻译拉取用量数据访问接口
+type UsageStatRepository interface {
+	// Increment 将计数累加到对应的日汇总行，行不存在时创建
+	Increment(ctx context.Context, stats []*UsageStat) error
+	// GetByProjectSince 获取项目自 since（含）以来的日汇总
+	GetByProjectSince(ctx context.Context, projectID uint64, since time.Time) ([]*UsageStat, error)
+}
+
+// OrganizationRepository 组织数据访问接口
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *Organization) error
+	GetByID(ctx context.Context, id uint64) (*Organization, error)
+	GetByName(ctx context.Context, name string) (*Organization, error)
+	GetAll(ctx context.Context) ([]*Organization, error)
+	// GetByUser 获取用户所在的组织
+	GetByUser(ctx context.Context, userID uint64) ([]*Organization, error)
+	Update(ctx context.Context, organization *Organization) error
+	// Delete 删除组织及其成员、语言和 API Key
+	Delete(ctx context.Context, id uint64) error
+}
+
+// OrganizationMemberRepository 组织成员数据访问接口
+type OrganizationMemberRepository interface {
+	Create(ctx context.Context, member *OrganizationMember) error
+	Get(ctx context.Context, organizationID, userID uint64) (*OrganizationMember, error)
+	GetByOrganization(ctx context.Context, organizationID uint64) ([]*OrganizationMember, error)
+	Update(ctx context.Context, member *OrganizationMember) error
+	Delete(ctx context.Context, organizationID, userID uint64) error
+}
+
+// TranslationMemoryRepository 翻译记忆库数据访问接口
+type TranslationMemoryRepository interface {
+	// FindCandidates 获取原文长度和内容符合条件的原文-译文对，原文完全相同的在前，其余按译文更新时间倒序
+	FindCandidates(ctx context.Context, query TMCandidateQuery) ([]*TMCandidate, error)
+}
+
+// TranslationSearchRepository 翻译搜索数据访问接口
+type TranslationSearchRepository interface {
+	// FindCandidates 获取键名或译文符合搜索条件的翻译，最多 Limit 条
+	// 逐行匹配的实现按更新时间倒序；使用全文索引的实现在 fuzzy 模式下按全文相关度倒序
+	FindCandidates(ctx context.Context, query TranslationSearchQuery) ([]*TranslationSearchCandidate, error)
 }
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// requestCacheContextKey 请求级缓存在 context 中的键
+type requestCacheContextKey struct{}
+
+// RequestCache 请求级缓存
+// 在单个请求内缓存语言列表和项目，同一请求中的重复查询只访问一次数据库。
+// 语言列表按组织分别缓存，同一请求访问多个组织时互不影响。
+// 缓存随请求结束而丢弃，不需要跨请求失效；请求内的写入会立即使对应条目失效。
+// 读写时复制数据，调用方修改返回的对象不会影响缓存。
+type RequestCache struct {
+	mu        sync.Mutex
+	languages map[uint64][]*Language
+	projects  map[uint64]*Project
+}
+
+// WithRequestCache 返回带有请求级缓存的 context，已存在时原样返回
+func WithRequestCache(ctx context.Context) context.Context {
+	if RequestCacheFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestCacheContextKey{}, newRequestCache())
+}
+
+// newRequestCache 创建空的请求级缓存
+func newRequestCache() *RequestCache {
+	return &RequestCache{
+		languages: make(map[uint64][]*Language),
+		projects:  make(map[uint64]*Project),
+	}
+}
+
+// RequestCacheFromContext 获取 context 中的请求级缓存，不存在时返回 nil
+// 返回的缓存所有方法都可以在 nil 上调用
+func RequestCacheFromContext(ctx context.Context) *RequestCache {
+	cache, _ := ctx.Value(requestCacheContextKey{}).(*RequestCache)
+	return cache
+}
+
+// Languages 获取缓存的组织语言列表
+func (c *RequestCache) Languages(organizationID uint64) ([]*Language, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	languages, ok := c.languages[organizationID]
+	if !ok {
+		return nil, false
+	}
+	return copyLanguages(languages), true
+}
+
+// SetLanguages 缓存组织语言列表
+func (c *RequestCache) SetLanguages(organizationID uint64, languages []*Language) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.languages[organizationID] = copyLanguages(languages)
+}
+
+// InvalidateLanguages 使所有组织的语言列表失效
+// 按 ID 写入语言时不一定知道所属组织，全部丢弃最稳妥
+func (c *RequestCache) InvalidateLanguages() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.languages = make(map[uint64][]*Language)
+}
+
+// Project 获取缓存的项目
+func (c *RequestCache) Project(id uint64) (*Project, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	project, ok := c.projects[id]
+	if !ok {
+		return nil, false
+	}
+	copied := *project
+	return &copied, true
+}
+
+// SetProject 缓存项目
+func (c *RequestCache) SetProject(project *Project) {
+	if c == nil || project == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	copied := *project
+	c.projects[project.ID] = &copied
+}
+
+// InvalidateProject 使项目失效
+func (c *RequestCache) InvalidateProject(id uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.projects, id)
+}
+
+// copyLanguages 复制语言列表，nil 复制为空列表以区分"未缓存"
+func copyLanguages(languages []*Language) []*Language {
+	copied := make([]*Language, 0, len(languages))
+	for _, language := range languages {
+		l := *language
+		copied = append(copied, &l)
+	}
+	return copied
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -13,21 +14,41 @@ type UserService interface {
 
 	// 用户管理
 	CreateUser(ctx context.Context, params CreateUserParams) (*User, error)
-	GetAllUsers(ctx context.Context, limit, offset int, keyword string) ([]*User, int64, error)
+	GetAllUsers(ctx context.Context, limit, offset int, keyword string, sort []SortField) ([]*User, int64, error)
 	GetUserByID(ctx context.Context, id uint64) (*User, error)
 	UpdateUser(ctx context.Context, id uint64, params UpdateUserParams) (*User, error)
 	ChangePassword(ctx context.Context, userID uint64, params ChangePasswordParams) error
 	ResetPassword(ctx context.Context, userID uint64, newPassword string) error
 	DeleteUser(ctx context.Context, id uint64) error
+	OffboardUser(ctx context.Context, id uint64, params OffboardUserParams) (*OffboardUserResult, error)
+	// DeactivateUser 停用用户：禁止登录并撤销登录会话，保留用户及其内容引用
+	DeactivateUser(ctx context.Context, id uint64, params DeactivateUserParams) (*User, error)
+	// TransferOwnership 将用户作为 owner 的项目和作为邀请人的邀请码转给其他用户
+	TransferOwnership(ctx context.Context, id uint64, params TransferOwnershipParams) (*OwnershipTransferResult, error)
+}
+
+// UserProfileService 个人资料服务接口
+type UserProfileService interface {
+	GetProfile(ctx context.Context, userID uint64) (*User, error)
+	UpdateProfile(ctx context.Context, userID uint64, params UpdateUserProfileParams) (*User, error)
+	// UploadAvatar 上传头像，替换原有头像
+	UploadAvatar(ctx context.Context, userID uint64, data []byte) (*User, error)
+	DeleteAvatar(ctx context.Context, userID uint64) (*User, error)
+	// GetAvatar 获取头像的文件内容和格式，没有头像时返回 ErrAvatarNotFound
+	GetAvatar(ctx context.Context, userID uint64) ([]byte, string, error)
 }
 
 // ProjectService 项目服务接口
 type ProjectService interface {
 	Create(ctx context.Context, params CreateProjectParams, userID uint64) (*Project, error)
 	GetByID(ctx context.Context, id uint64) (*Project, error)
-	GetAll(ctx context.Context, limit, offset int, keyword string) ([]*Project, int64, error)
+	GetByIdentifier(ctx context.Context, identifier string) (*Project, error)
+	GetAll(ctx context.Context, limit, offset int, keyword string, sort []SortField) ([]*Project, int64, error)
 	GetAccessibleProjects(ctx context.Context, userID uint64, limit, offset int, keyword string) ([]*Project, int64, error)
 	Update(ctx context.Context, id uint64, params UpdateProjectParams, userID uint64) (*Project, error)
+	RenameSlug(ctx context.Context, id uint64, newSlug string, userID uint64) (*Project, error)
+	SetProtection(ctx context.Context, id uint64, protected bool, userID uint64) (*Project, error)
+	SetDelivery(ctx context.Context, id uint64, enabled bool, userID uint64) (*Project, error)
 	Delete(ctx context.Context, id uint64) error
 }
 
@@ -47,18 +68,36 @@ type TranslationService interface {
 	CreateBatchFromRequest(ctx context.Context, params BatchTranslationParams) error
 	UpsertBatch(ctx context.Context, inputs []TranslationInput) error
 	GetByID(ctx context.Context, id uint64) (*Translation, error)
-	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Translation, int64, error)
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int, reviewStatus string, sort []SortField) ([]*Translation, int64, error)
+	// GetPageByProjectID 按 ID 顺序游标分页获取项目的翻译
+	GetPageByProjectID(ctx context.Context, query TranslationPageQuery) (*TranslationPage, error)
 	GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]TranslationCell, int64, error)
+	GetMatrixPage(ctx context.Context, query TranslationMatrixQuery) (*TranslationMatrixPage, error)
+	GetKeyPage(ctx context.Context, query TranslationKeyPageQuery) (*TranslationKeyPage, error)
+	// GetProjectStats 统计项目各启用语言已翻译、未翻译和过期的键数量
+	GetProjectStats(ctx context.Context, projectID uint64) (*ProjectStats, error)
 	Update(ctx context.Context, id uint64, input TranslationInput, userID uint64) (*Translation, error)
 	Delete(ctx context.Context, id uint64) error
 	DeleteBatch(ctx context.Context, ids []uint64) error
-	Export(ctx context.Context, projectID uint64, format string) ([]byte, error)
-	Import(ctx context.Context, projectID uint64, data []byte, format string) error
+	SetValueType(ctx context.Context, projectID uint64, params SetValueTypeParams, userID uint64) (*SetValueTypeResult, error)
+	SetKeyMetadata(ctx context.Context, projectID uint64, params SetKeyMetadataParams, userID uint64) (*SetKeyMetadataResult, error)
+	// 审核流程：提交（draft → in_review）、通过（in_review → approved）、驳回（in_review 或 approved → draft）
+	SubmitForReview(ctx context.Context, projectID uint64, params ReviewTransitionParams, userID uint64) (*ReviewTransitionResult, error)
+	ApproveTranslations(ctx context.Context, projectID uint64, params ReviewTransitionParams, userID uint64) (*ReviewTransitionResult, error)
+	RejectTranslations(ctx context.Context, projectID uint64, params ReviewTransitionParams, userID uint64) (*ReviewTransitionResult, error)
+	GetExportValues(ctx context.Context, projectID uint64, options ExportOptions) (map[string]map[string]string, error)
+	Export(ctx context.Context, projectID uint64, format string, options ExportOptions) ([]byte, error)
+	ExportFiles(ctx context.Context, projectID uint64, format string, options ExportOptions) ([]*ExportFile, error)
+	Import(ctx context.Context, projectID uint64, data []byte, format string, options ImportOptions) (*ImportResult, error)
+	ImportSpreadsheet(ctx context.Context, projectID uint64, params SpreadsheetUploadParams) (*SpreadsheetUploadResult, error)
+	ImportMigration(ctx context.Context, projectID uint64, params MigrationImportParams) (*MigrationImportResult, error)
 }
 
 // DashboardService 仪表板服务接口
 type DashboardService interface {
 	GetStats(ctx context.Context) (*DashboardStats, error)
+	// GetActivity 统计最近 days 天（30 或 90）每天、每个操作人和每个项目新增和修改的翻译数
+	GetActivity(ctx context.Context, days int) (*DashboardActivity, error)
 }
 
 // AuthService 认证服务接口
@@ -67,11 +106,19 @@ type AuthService interface {
 	GenerateRefreshToken(ctx context.Context, user *User) (string, error)
 	ValidateToken(ctx context.Context, token string) (*User, error)
 	ValidateRefreshToken(ctx context.Context, token string) (*User, error)
+	// SessionID 返回访问令牌所属的登录会话ID，令牌没有会话时返回空字符串
+	SessionID(ctx context.Context, token string) (string, error)
+	// RefreshSessionID 返回刷新令牌所属的登录会话ID
+	RefreshSessionID(ctx context.Context, token string) (string, error)
 }
 
 // ProjectMemberService 项目成员服务接口
 type ProjectMemberService interface {
 	AddMember(ctx context.Context, projectID uint64, params AddMemberParams, createdBy uint64) (*ProjectMember, error)
+	// BulkAddMembers 批量添加成员，单项失败不影响其他成员
+	BulkAddMembers(ctx context.Context, projectID uint64, params BulkAddMembersParams, createdBy uint64) (*BulkAddMembersResult, error)
+	// ImportMembers 从 CSV/XLSX 导入成员，按 BulkAddMembers 添加
+	ImportMembers(ctx context.Context, projectID uint64, params MemberImportParams, createdBy uint64) (*BulkAddMembersResult, error)
 	GetProjectMembers(ctx context.Context, projectID uint64) ([]*ProjectMemberInfo, error)
 	GetUserProjects(ctx context.Context, userID uint64) ([]*Project, error)
 	UpdateMemberRole(ctx context.Context, projectID, userID uint64, params UpdateMemberRoleParams) (*ProjectMember, error)
@@ -80,6 +127,17 @@ type ProjectMemberService interface {
 	GetMemberRole(ctx context.Context, userID, projectID uint64) (string, error)
 }
 
+// RoleSyncService 全局角色与项目成员同步服务接口
+type RoleSyncService interface {
+	// Sync 为启用的全局管理员补充缺少的项目 owner 成员关系，
+	// 并将已不是全局管理员的用户由同步添加的 owner 成员关系加入复核队列
+	Sync(ctx context.Context, operatorID uint64) (*RoleSyncResult, error)
+	// QueueDowngradeReviews 全局角色降级时将用户的项目 owner 成员关系加入复核队列，返回加入的数量
+	QueueDowngradeReviews(ctx context.Context, userID uint64, previousRole, newRole string) (int, error)
+	ListReviews(ctx context.Context, status string, limit, offset int) ([]*RoleReview, int64, error)
+	ResolveReview(ctx context.Context, id uint64, params ResolveRoleReviewParams, userID uint64) (*RoleReview, error)
+}
+
 // InvitationService 邀请码服务接口
 type InvitationService interface {
 	CreateInvitation(ctx context.Context, inviterID uint64, params CreateInvitationParams) (*Invitation, string, error)
@@ -87,8 +145,11 @@ type InvitationService interface {
 	GetInvitationsByInviter(ctx context.Context, inviterID uint64, limit, offset int) ([]*Invitation, int64, error)
 	ValidateInvitation(ctx context.Context, code string) (*Invitation, error)
 	UseInvitation(ctx context.Context, code string, userID uint64) error
+	RegisterWithInvitation(ctx context.Context, code string, params CreateUserParams) (*User, error)
 	RevokeInvitation(ctx context.Context, code string) error
 	DeleteInvitation(ctx context.Context, code string) error
+	CreateInvitationBatch(ctx context.Context, inviterID uint64, params CreateInvitationBatchParams) (*InvitationBatchResult, error)
+	RevokeInvitationBatch(ctx context.Context, batchLabel string) (int64, error)
 }
 
 // CreateInvitationParams 创建邀请参数
@@ -96,6 +157,8 @@ type CreateInvitationParams struct {
 	Role           string `json:"role" binding:"omitempty,oneof=admin member viewer"`
 	ExpiresInDays  int    `json:"expires_in_days"`
 	Description    string `json:"description"`
+	Email          string `json:"email"`    // 不为空时向该邮箱发送邀请邮件
+	MaxUses        int    `json:"max_uses"` // 最多可使用的次数，0 表示 1 次
 }
 
 // InvitationResult 邀请结果
@@ -134,3 +197,427 @@ type MachineTranslationLanguage struct {
 	Code  string `json:"code"`
 	Name  string `json:"name"`
 }
+
+// AutoTranslateService 机器翻译填充服务接口
+type AutoTranslateService interface {
+	// FillMissing 使用机器翻译填充选定键在目标语言中缺失的翻译
+	FillMissing(ctx context.Context, projectID uint64, params AutoTranslateParams) (*AutoTranslateResult, error)
+	// PreTranslate 使用机器翻译分批填充整个项目在选定语言中的空白翻译
+	PreTranslate(ctx context.Context, projectID uint64, params PreTranslateParams) (*PreTranslateResult, error)
+}
+
+// InboundWebhookService 入站 Webhook 服务接口
+type InboundWebhookService interface {
+	Create(ctx context.Context, projectID uint64, params InboundWebhookParams, userID uint64) (*InboundWebhook, error)
+	GetByID(ctx context.Context, id uint64) (*InboundWebhook, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*InboundWebhook, error)
+	Update(ctx context.Context, id uint64, params InboundWebhookParams, userID uint64) (*InboundWebhook, error)
+	Delete(ctx context.Context, id uint64) error
+	RotateSecret(ctx context.Context, id uint64, userID uint64) (*InboundWebhook, error)
+	GetLogs(ctx context.Context, webhookID uint64, limit, offset int) ([]*InboundWebhookLog, int64, error)
+	Ingest(ctx context.Context, webhookID uint64, body []byte, signature string) (*InboundWebhookLog, error)
+}
+
+// WebhookService 出站 Webhook 服务接口
+// 按ID操作时确认 Webhook 属于该项目，否则返回 ErrWebhookNotFound
+type WebhookService interface {
+	// Create 创建 Webhook 并生成签名密钥
+	Create(ctx context.Context, projectID uint64, params WebhookParams, userID uint64) (*Webhook, error)
+	GetByID(ctx context.Context, projectID, webhookID uint64) (*Webhook, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Webhook, error)
+	// Update 更新 Webhook 配置，空字段保持不变
+	Update(ctx context.Context, projectID, webhookID uint64, params WebhookParams, userID uint64) (*Webhook, error)
+	Delete(ctx context.Context, projectID, webhookID uint64) error
+	// RotateSecret 重新生成签名密钥，旧密钥立即失效
+	RotateSecret(ctx context.Context, projectID, webhookID uint64, userID uint64) (*Webhook, error)
+	// GetDeliveries 分页获取推送记录，最新的在前
+	GetDeliveries(ctx context.Context, projectID, webhookID uint64, limit, offset int) ([]*WebhookDelivery, int64, error)
+}
+
+// ImportProfileService 表格导入映射配置服务接口
+type ImportProfileService interface {
+	Create(ctx context.Context, projectID uint64, params ImportProfileParams, userID uint64) (*ImportProfile, error)
+	GetByID(ctx context.Context, id uint64) (*ImportProfile, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*ImportProfile, error)
+	Update(ctx context.Context, id uint64, params ImportProfileParams, userID uint64) (*ImportProfile, error)
+	Delete(ctx context.Context, id uint64) error
+	// Suggest 读取上传文件的表头，按列名推测键名、上下文和语言列，结果可直接保存为映射配置
+	Suggest(ctx context.Context, projectID uint64, params ImportMappingSuggestParams) (*ImportMappingSuggestion, error)
+	// Import 按映射配置导入表格，已存在的翻译会被覆盖，空单元格跳过
+	Import(ctx context.Context, profileID uint64, params SpreadsheetImportParams) (*SpreadsheetImportResult, error)
+}
+
+// AssignmentService 任务分配服务接口
+type AssignmentService interface {
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Assignment, error)
+	// Assign 将语言或其中的键分配给项目成员，已分配给其他人的改为分配给该成员，返回项目的全部分配
+	Assign(ctx context.Context, projectID uint64, params AssignParams, operatorID uint64) ([]*Assignment, error)
+	Delete(ctx context.Context, projectID, assignmentID uint64) error
+	// GetUserTasks 按用户的任务分配统计待翻译和待审核的工作
+	GetUserTasks(ctx context.Context, userID uint64) (*UserTaskList, error)
+}
+
+// AttachmentService 翻译键附件服务接口
+type AttachmentService interface {
+	GetByProjectID(ctx context.Context, projectID uint64, keyName string) ([]*Attachment, error)
+	// Upload 为键上传附件，返回创建的附件
+	Upload(ctx context.Context, projectID uint64, params AttachmentUploadParams, userID uint64) (*Attachment, error)
+	// GetContent 获取附件的内容和媒体类型
+	GetContent(ctx context.Context, projectID, attachmentID uint64) ([]byte, string, error)
+	Delete(ctx context.Context, projectID, attachmentID uint64) error
+}
+
+// TagService 键标签服务接口
+type TagService interface {
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Tag, error)
+	Create(ctx context.Context, projectID uint64, params TagParams, userID uint64) (*Tag, error)
+	Update(ctx context.Context, projectID, tagID uint64, params TagParams, userID uint64) (*Tag, error)
+	// Delete 删除标签并移除键上的该标签，翻译不受影响
+	Delete(ctx context.Context, projectID, tagID uint64) error
+	// TagKeys 为键添加标签，每个键最多 MaxKeyTags 个标签
+	TagKeys(ctx context.Context, projectID, tagID uint64, keyNames []string) (*TagKeysResult, error)
+	// UntagKeys 移除键上的标签
+	UntagKeys(ctx context.Context, projectID, tagID uint64, keyNames []string) (*TagKeysResult, error)
+}
+
+// NamespaceService 键命名空间服务接口
+type NamespaceService interface {
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Namespace, error)
+	Create(ctx context.Context, projectID uint64, params NamespaceParams, userID uint64) (*Namespace, error)
+	Update(ctx context.Context, projectID, namespaceID uint64, params NamespaceParams, userID uint64) (*Namespace, error)
+	// Delete 删除命名空间，其中的键回到未划分状态，翻译不受影响
+	Delete(ctx context.Context, projectID, namespaceID uint64) error
+	// AssignKeys 将键在所有语言的翻译归入命名空间，Namespace 为空时移出命名空间
+	AssignKeys(ctx context.Context, projectID uint64, params AssignNamespaceParams, userID uint64) (*AssignNamespaceResult, error)
+}
+
+// BranchService 翻译分支服务接口
+type BranchService interface {
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*Branch, error)
+	Create(ctx context.Context, projectID uint64, params BranchParams, userID uint64) (*Branch, error)
+	// Delete 删除分支，主线的翻译不受影响
+	Delete(ctx context.Context, projectID, branchID uint64) error
+	// GetValues 获取分支的翻译：主线的当前翻译叠加分支中修改过的翻译，键名 -> 语言代码 -> 翻译值
+	GetValues(ctx context.Context, projectID, branchID uint64) (map[string]map[string]string, error)
+	// SetTranslations 在分支中修改或删除翻译，返回写入的条数
+	SetTranslations(ctx context.Context, projectID, branchID uint64, params []BranchTranslationParams, userID uint64) (int, error)
+	// Diff 计算将分支合并到目标分支（main 为主线）时的差异，目标为主线时标记冲突
+	Diff(ctx context.Context, projectID, branchID uint64, target string) (*BranchDiff, error)
+	// Merge 将分支合并到主线，冲突必须全部指定处理方式
+	Merge(ctx context.Context, projectID, branchID uint64, params BranchMergeParams, userID uint64) (*BranchMergeResult, error)
+}
+
+// TranslationRollbackService 翻译回滚服务接口
+// 回滚写入的翻译同样记录变更历史，操作类型为 rollback
+type TranslationRollbackService interface {
+	// RollbackTranslation 将翻译的值和状态恢复为变更历史记录的版本
+	RollbackTranslation(ctx context.Context, translationID, historyID, userID uint64) (*Translation, error)
+	// RollbackProject 将项目中此后有变更的翻译恢复为指定时刻的版本
+	RollbackProject(ctx context.Context, projectID uint64, params ProjectRollbackParams, userID uint64) (*ProjectRollbackResult, error)
+}
+
+// TranslationHistoryService 翻译变更历史查询服务接口
+type TranslationHistoryService interface {
+	// GetDiff 获取一条变更历史中旧值和新值的词级差异和 unified diff
+	GetDiff(ctx context.Context, historyID uint64) (*TranslationHistoryDiff, error)
+	// GetChanges 汇总时间范围内项目中每条翻译的净变化
+	GetChanges(ctx context.Context, params TranslationChangesParams) (*TranslationChangeReport, error)
+}
+
+// TranslationTrashService 翻译回收站服务接口
+type TranslationTrashService interface {
+	// GetTrash 分页获取项目中已删除的翻译，最近删除的在前
+	GetTrash(ctx context.Context, projectID uint64, limit, offset int) (*TranslationTrash, error)
+	// Restore 恢复已删除的翻译，键级别的元数据与该键在其他语言的翻译保持一致
+	Restore(ctx context.Context, translationID, userID uint64) (*Translation, error)
+	// Purge 永久删除超过保留期限的已删除翻译，返回删除的条数
+	Purge(ctx context.Context) (int64, error)
+}
+
+// ReleaseService 发布版本服务接口
+type ReleaseService interface {
+	// Create 发布版本：冻结项目当前的翻译
+	// 发布前检查发布门槛，返回的检查结果在未达标被阻止时同样返回，以便列出阻止发布的语言和键
+	Create(ctx context.Context, projectID uint64, params ReleaseParams, userID uint64) (*Release, *ReleaseReadiness, error)
+	GetByID(ctx context.Context, projectID, releaseID uint64) (*Release, error)
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Release, int64, error)
+	// AdvanceRollout 提高灰度发布的百分比，达到 100 时全量发布
+	AdvanceRollout(ctx context.Context, projectID, releaseID uint64, percentage int, userID uint64) (*Release, error)
+	// AbortRollout 中止灰度发布，所有请求回到最新的全量版本
+	AbortRollout(ctx context.Context, projectID, releaseID uint64, userID uint64) (*Release, error)
+}
+
+// DeliveryService 公开分发服务接口
+type DeliveryService interface {
+	// GetBundle 获取开启公开分发的项目中一种语言的语言包
+	// 有进行中的灰度发布时按 clientID 分桶选择灰度版本或最新的全量版本，clientID 为空时总是获取全量版本
+	GetBundle(ctx context.Context, projectSlug, locale, clientID string) (*DeliveryBundle, error)
+}
+
+// TranslationDeltaService 增量同步服务接口
+type TranslationDeltaService interface {
+	// GetDelta 根据翻译变更历史获取同步起点之后变更的键，query.Since 为发布版本标签且不存在时返回 ErrReleaseNotFound
+	GetDelta(ctx context.Context, query TranslationDeltaQuery) (*TranslationDelta, error)
+}
+
+// KeyPushService CLI 推送键服务接口，HTTP 和 gRPC 接口共用
+type KeyPushService interface {
+	// Push 推送新键或批量导入翻译：未指定键名且提供了翻译时批量导入，否则为每个新键在项目所属组织的所有语言中创建翻译
+	Push(ctx context.Context, params PushKeysParams) (*PushKeysResult, error)
+}
+
+// ReleaseGateService 发布门槛服务接口
+type ReleaseGateService interface {
+	GetThresholds(ctx context.Context, projectID uint64) ([]*ReleaseThreshold, error)
+	// SetThresholds 替换项目的全部发布门槛，传入空列表表示不设门槛
+	SetThresholds(ctx context.Context, projectID uint64, params []ReleaseThresholdParams, userID uint64) ([]*ReleaseThreshold, error)
+	// Check 按发布门槛检查各语言的完成率和审核通过率
+	Check(ctx context.Context, projectID uint64) (*ReleaseReadiness, error)
+	// Ensure 发布前检查：未达到门槛时返回 ErrReleaseBlocked 和检查结果，override 为 true 时放行并标记为已覆盖
+	Ensure(ctx context.Context, projectID uint64, override bool) (*ReleaseReadiness, error)
+}
+
+// FigmaService Figma 插件服务接口
+type FigmaService interface {
+	// SyncFrame 同步画框及其中引用翻译键的文本图层，不存在的键以图层文本创建，已存在的键不修改翻译
+	SyncFrame(ctx context.Context, projectID uint64, params FigmaFrameParams, userID uint64) (*FigmaSyncResult, error)
+	UploadScreenshot(ctx context.Context, projectID, frameID uint64, data []byte, userID uint64) (*FigmaFrame, error)
+	GetScreenshot(ctx context.Context, projectID, frameID uint64) (data []byte, mediaType string, err error)
+	DeleteFrame(ctx context.Context, projectID, frameID uint64) error
+	// GetValues 获取指定键在各启用语言中的当前翻译，返回 键名 -> 语言代码 -> 值
+	GetValues(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]string, error)
+	// GetReferences 查询引用翻译键的画框，keyName 不为空时只返回引用了该键的画框
+	GetReferences(ctx context.Context, projectID uint64, fileKey, keyName string) ([]*FigmaFrame, error)
+}
+
+// WebhookTemplateService Webhook 消息模板服务接口
+type WebhookTemplateService interface {
+	// Preview 用示例事件渲染模板，返回将要发送的消息体
+	Preview(ctx context.Context, projectID uint64, params WebhookTemplatePreviewParams) (*WebhookTemplatePreview, error)
+}
+
+// KeyPrefixService 按键名前缀批量操作服务接口
+// 每个操作都支持预览；执行时写入审计日志
+type KeyPrefixService interface {
+	Delete(ctx context.Context, projectID uint64, params KeyPrefixParams, userID uint64) (*KeyPrefixResult, error)
+	ChangeStatus(ctx context.Context, projectID uint64, params KeyPrefixParams, userID uint64) (*KeyPrefixResult, error)
+	Rename(ctx context.Context, projectID uint64, params KeyPrefixParams, userID uint64) (*KeyPrefixResult, error)
+	Export(ctx context.Context, projectID uint64, params KeyPrefixParams, userID uint64) (*KeyPrefixResult, error)
+}
+
+// KeyRenameService 翻译键重命名服务接口
+type KeyRenameService interface {
+	// Rename 将键在所有语言和未合并分支中改为新键名，同时更新引用该键的附件、任务分配和设计稿图层
+	Rename(ctx context.Context, projectID uint64, params KeyRenameParams, userID uint64) (*KeyRenameResult, error)
+}
+
+// KeyCopyService 跨项目复制键服务接口
+type KeyCopyService interface {
+	// Copy 将源项目中的键连同所有语言的翻译复制到项目，Link 为 true 时保持同步
+	Copy(ctx context.Context, projectID uint64, params KeyCopyParams, userID uint64) (*KeyCopyResult, error)
+	// ListLinks 获取项目作为源项目或目标项目的链接
+	ListLinks(ctx context.Context, projectID uint64) ([]*KeyLink, error)
+	// Unlink 取消项目中键的链接，已复制的翻译保留
+	Unlink(ctx context.Context, projectID uint64, keyName string, userID uint64) error
+}
+
+// WatchService CLI 监听模式服务接口
+type WatchService interface {
+	// Watch 订阅项目的翻译变更，locales 为空时接收所有语言的变更
+	// 订阅方处理过慢或服务停止时通道被关闭，客户端应全量拉取后重新连接；cancel 用于结束订阅
+	Watch(projectID uint64, locales []string) (events <-chan WatchEvent, cancel func())
+}
+
+// KeyLockService 键编辑锁服务接口
+// 编辑锁是软锁，只用于提示其他编辑者有人正在编辑该键，不阻止写入；并发修改由翻译的版本号检测
+type KeyLockService interface {
+	// Acquire 获取键的编辑锁，已持有时续期；其他用户持有时返回其持有的锁和 ErrKeyLocked
+	Acquire(ctx context.Context, projectID uint64, keyName string, userID uint64, username string) (*KeyLock, error)
+	// Heartbeat 续期用户持有的编辑锁，锁已过期或已被其他用户获取时返回 ErrKeyLockNotHeld
+	Heartbeat(ctx context.Context, projectID uint64, keyName string, userID uint64) (*KeyLock, error)
+	// Release 释放用户持有的编辑锁，未持有时不做处理
+	Release(ctx context.Context, projectID uint64, keyName string, userID uint64) error
+	// List 获取项目中有效的编辑锁，按键名排序
+	List(ctx context.Context, projectID uint64) ([]*KeyLock, error)
+}
+
+// BulkDeleteService 按条件批量删除翻译服务接口
+// 预览返回匹配数量和确认令牌，执行时校验令牌后分批删除并写入审计日志
+type BulkDeleteService interface {
+	Preview(ctx context.Context, projectID uint64, params BulkDeleteParams, userID uint64) (*BulkDeleteResult, error)
+	Delete(ctx context.Context, projectID uint64, params BulkDeleteParams, userID uint64) (*BulkDeleteResult, error)
+}
+
+// ComplianceService 历史导出和合规报告服务接口
+type ComplianceService interface {
+	// ExportHistory 校验参数并记录审计日志后，将时间范围内的记录流式写入 w
+	// 参数无效时在写入任何内容之前返回错误
+	ExportHistory(ctx context.Context, params HistoryExportParams, userID uint64, w io.Writer) error
+	GetReport(ctx context.Context, params ComplianceReportParams) (*ComplianceReport, error)
+}
+
+// ProjectActivityService 项目活动跟踪和闲置项目服务接口
+type ProjectActivityService interface {
+	// RecordAccess 记录项目被访问，同一项目的访问在内存中合并后定期写入数据库
+	RecordAccess(projectID uint64)
+	// GetStaleProjects 获取超过指定天数没有内容变更的活跃项目
+	GetStaleProjects(ctx context.Context, params StaleProjectParams) (*StaleProjectReport, error)
+	// ArchiveStaleProjects 归档指定的闲置项目，执行时重新检查，已不再闲置的项目会被跳过
+	ArchiveStaleProjects(ctx context.Context, params ArchiveStaleProjectsParams, userID uint64) (*ArchiveStaleProjectsResult, error)
+}
+
+// DeadLetterService 发件箱死信管理服务接口
+type DeadLetterService interface {
+	List(ctx context.Context, params DeadLetterListParams) ([]*OutboxEvent, int64, error)
+	// Requeue 将死信事件重新交给投递器，返回重新投递的数量
+	Requeue(ctx context.Context, params RequeueDeadLettersParams, userID uint64) (int64, error)
+}
+
+// AuditLogService 审计日志服务接口
+type AuditLogService interface {
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*AuditLog, int64, error)
+	GetSystemLogs(ctx context.Context, limit, offset int) ([]*AuditLog, int64, error)
+	// GetPage 按时间倒序游标分页获取项目（ProjectID 为 0 时为系统级）的审计日志
+	GetPage(ctx context.Context, query AuditLogPageQuery) (*AuditLogPage, error)
+}
+
+// PromotionService 环境推送服务接口
+type PromotionService interface {
+	CreateDiff(ctx context.Context, projectID uint64, params PromotionSourceParams, userID uint64) (*PromotionDetail, error)
+	GetByID(ctx context.Context, id uint64) (*PromotionDetail, error)
+	GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*Promotion, int64, error)
+	Apply(ctx context.Context, id uint64, params PromotionApplyParams, userID uint64) (*PromotionDetail, error)
+}
+
+// ProjectConfigService 项目配置导出/导入服务接口
+type ProjectConfigService interface {
+	Export(ctx context.Context, projectID uint64) (*ProjectConfig, error)
+	// Import 将配置应用到项目：设置被覆盖，Webhook 和成员只新增或更新，不删除配置中没有的项
+	Import(ctx context.Context, projectID uint64, params ProjectConfigImportParams, userID uint64) (*ProjectConfigImportResult, error)
+}
+
+// ProjectDuplicateService 项目复制服务接口
+type ProjectDuplicateService interface {
+	// Duplicate 复制项目的设置、命名空间、发布门槛、成员（可选）和翻译到新项目
+	// 翻译较少时在返回前复制完成，否则在后台复制，返回的任务状态为 running
+	Duplicate(ctx context.Context, projectID uint64, params ProjectDuplicateParams, userID uint64) (*ProjectDuplicateJob, error)
+	// GetJob 获取从项目发起的复制任务
+	GetJob(ctx context.Context, projectID, jobID uint64) (*ProjectDuplicateJob, error)
+}
+
+// PersonalAccessTokenService 个人访问令牌服务接口
+type PersonalAccessTokenService interface {
+	// Create 创建令牌，返回的明文令牌只在创建时可见
+	Create(ctx context.Context, userID uint64, params PersonalAccessTokenParams) (*PersonalAccessToken, string, error)
+	GetByUserID(ctx context.Context, userID uint64) ([]*PersonalAccessToken, error)
+	Revoke(ctx context.Context, userID, id uint64) error
+	// Authenticate 校验明文令牌并记录最近使用时间和来源 IP
+	Authenticate(ctx context.Context, token, clientIP string) (*PersonalAccessToken, error)
+}
+
+// SessionService 登录会话服务接口
+type SessionService interface {
+	// Start 为用户创建会话，设备信息取自 context 中的请求方信息
+	Start(ctx context.Context, userID uint64) (*Session, error)
+	// Validate 检查会话未被撤销并记录最近访问时间，已撤销或已过期时返回 ErrSessionRevoked
+	Validate(ctx context.Context, userID uint64, sessionID string) error
+	// List 用户的有效会话，最近访问的在前
+	List(ctx context.Context, userID uint64) ([]*Session, error)
+	// Revoke 撤销用户的一个会话，该会话签发的令牌立即失效
+	Revoke(ctx context.Context, userID uint64, sessionID string) error
+	// RevokeAll 撤销用户的全部会话，返回撤销的数量
+	RevokeAll(ctx context.Context, userID uint64) (int, error)
+}
+
+// SSOService 单点登录服务接口
+type SSOService interface {
+	// Providers 已配置的身份提供方
+	Providers() []SSOProvider
+	// Begin 开始登录，返回跳转到身份提供方的地址和需要保存在浏览器 Cookie 中的登录会话
+	Begin(ctx context.Context, provider string) (*SSOAuthRequest, error)
+	// Complete 校验回调参数，关联或创建用户并签发令牌
+	Complete(ctx context.Context, session, state, code string) (*LoginResult, error)
+}
+
+// APIKeyService CLI API Key 服务接口
+type APIKeyService interface {
+	// Create 创建 API Key，返回的明文 API Key 只在创建时可见
+	Create(ctx context.Context, params APIKeyParams, userID uint64) (*APIKey, string, error)
+	GetAll(ctx context.Context) ([]*APIKey, error)
+	Revoke(ctx context.Context, id uint64) error
+	// Authenticate 校验明文 API Key 并记录最近使用时间和来源 IP
+	Authenticate(ctx context.Context, key, clientIP string) (*APIKey, error)
+}
+
+// PasswordResetService 找回密码服务接口
+type PasswordResetService interface {
+	// RequestReset 向邮箱对应的用户发送重置密码链接，邮箱不存在或用户未启用时不发送也不返回错误
+	RequestReset(ctx context.Context, email string) error
+	// ConfirmReset 使用重置链接中的令牌设置新密码
+	ConfirmReset(ctx context.Context, token, newPassword string) error
+}
+
+// StorageMonitorService 数据库增长监控服务接口
+type StorageMonitorService interface {
+	// GetReport 获取最近一次检查结果，refresh 为 true 或尚未检查时立即检查
+	GetReport(ctx context.Context, refresh bool) (*StorageReport, error)
+}
+
+// UsageService 翻译拉取用量统计服务接口
+type UsageService interface {
+	// Record 记录一次翻译拉取，计数在 Redis 中累加后定期写入数据库
+	Record(ctx context.Context, record UsageRecord)
+	// GetReport 获取项目最近若干天按语言、调用方和日期汇总的拉取次数
+	GetReport(ctx context.Context, projectID uint64, days int) (*UsageReport, error)
+}
+
+// GlossaryService 术语表服务接口
+type GlossaryService interface {
+	Create(ctx context.Context, projectID uint64, params GlossaryTermParams, userID uint64) (*GlossaryTerm, error)
+	GetByID(ctx context.Context, id uint64) (*GlossaryTerm, error)
+	GetByProjectID(ctx context.Context, projectID uint64) ([]*GlossaryTerm, error)
+	Update(ctx context.Context, id uint64, params GlossaryTermParams, userID uint64) (*GlossaryTerm, error)
+	Delete(ctx context.Context, id uint64) error
+}
+
+// QAService 翻译质量检查服务接口
+type QAService interface {
+	// GetReport 检查项目中 markdown 和 html 类型的翻译以及术语表规则，返回发现的问题
+	GetReport(ctx context.Context, projectID uint64, query QAReportQuery) (*QAReport, error)
+	// GetConsistency 统计各语言的术语一致性得分
+	GetConsistency(ctx context.Context, projectID uint64) (*ConsistencyReport, error)
+	// GetInconsistencies 列出某一语言中译文不一致的术语及建议译文
+	GetInconsistencies(ctx context.Context, projectID uint64, languageCode string) (*ConsistencyDetail, error)
+}
+
+// OrganizationService 组织服务接口
+type OrganizationService interface {
+	// Create 创建组织，创建者成为 owner，并复制共享语言列表中启用的语言作为组织的语言
+	Create(ctx context.Context, params OrganizationParams, userID uint64) (*Organization, error)
+	GetByID(ctx context.Context, id uint64) (*Organization, error)
+	GetAll(ctx context.Context) ([]*Organization, error)
+	GetUserOrganizations(ctx context.Context, userID uint64) ([]*Organization, error)
+	Update(ctx context.Context, id uint64, params OrganizationParams, userID uint64) (*Organization, error)
+	// Delete 删除没有项目的组织
+	Delete(ctx context.Context, id uint64) error
+	GetMembers(ctx context.Context, id uint64) ([]*OrganizationMemberInfo, error)
+	AddMember(ctx context.Context, id uint64, params AddOrganizationMemberParams, createdBy uint64) (*OrganizationMember, error)
+	UpdateMemberRole(ctx context.Context, id, userID uint64, role string) (*OrganizationMember, error)
+	// RemoveMember 移除组织成员，同时移除该用户在组织下所有项目的成员关系
+	RemoveMember(ctx context.Context, id, userID uint64) error
+	GetProjects(ctx context.Context, id uint64) ([]*Project, error)
+	// CheckRole 检查用户在组织中的角色是否不低于 requiredRole
+	CheckRole(ctx context.Context, id, userID uint64, requiredRole string) (bool, error)
+}
+
+// TranslationMemoryService 翻译记忆库服务接口
+type TranslationMemoryService interface {
+	// Suggest 在用户可访问的项目中查找与文本相似的已有翻译
+	Suggest(ctx context.Context, userID uint64, query TMSuggestQuery) (*TMSuggestResult, error)
+}
+
+// SearchService 翻译搜索服务接口
+type SearchService interface {
+	// Search 在用户可访问的项目中按键名和译文搜索翻译，按相关度排序
+	Search(ctx context.Context, userID uint64, params SearchParams) (*SearchResult, error)
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // ========== User Service Params ==========
 
 // LoginParams 登录参数
@@ -31,6 +36,21 @@ type UpdateUserParams struct {
 	Status   string
 }
 
+// UpdateUserProfileParams 更新个人资料参数，整体替换，空值表示清除
+type UpdateUserProfileParams struct {
+	DisplayName string
+	Language    string // 界面语言，BCP 47 语言标签，如 zh-CN
+	Timezone    string // IANA 时区，如 Asia/Shanghai
+}
+
+// UserSummary 用于展示操作人等的用户公开信息
+type UserSummary struct {
+	ID          uint64 `json:"id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+}
+
 // ChangePasswordParams 修改密码参数
 type ChangePasswordParams struct {
 	OldPassword string
@@ -43,13 +63,20 @@ type ChangePasswordParams struct {
 type CreateProjectParams struct {
 	Name        string
 	Description string
+	Slug        string // 自定义项目标识，为空时根据名称生成
+	AutoSuffix  bool   // 标识冲突时自动追加 -2、-3 等后缀，而不是返回错误
+	// OrganizationID 所属组织，0 表示不属于任何组织；指定时创建者需要是系统管理员或组织的 owner、admin
+	OrganizationID uint64
+	SourceLanguage string // 源语言代码，为空时使用默认语言
 }
 
 // UpdateProjectParams 更新项目参数
 type UpdateProjectParams struct {
-	Name        string
-	Description string
-	Status      string
+	Name               string
+	Description        string
+	Status             string
+	ExportFileTemplate *string // 为 nil 时不修改
+	SourceLanguage     *string // 源语言代码，为 nil 时不修改，为空时改为使用默认语言
 }
 
 // ========== Language Service Params ==========
@@ -70,6 +97,105 @@ type TranslationInput struct {
 	KeyName    string
 	Context    string
 	Value      string
+	ValueType  string // 新键的值类型，为空时为 string；已有的键沿用其值类型
+	Version    uint64 // 更新时客户端编辑所基于的版本号，与当前版本不一致时拒绝更新；0 表示不检查
+}
+
+// 翻译值类型
+const (
+	ValueTypeString   = "string"   // 普通文本，在 QA 报告中检查占位符、HTML 标签、末尾空白和长度
+	ValueTypeJSON     = "json"     // JSON 值（数组、对象等结构化内容），导出为 JSON 时原样输出
+	ValueTypeMarkdown = "markdown" // Markdown 文本，在 QA 报告中检查标记结构、链接和占位符
+	ValueTypeHTML     = "html"     // HTML 片段，在 QA 报告中检查标记结构、链接和占位符
+)
+
+// KeyValueType 键的值类型和 JSON Schema
+type KeyValueType struct {
+	ValueType   string
+	ValueSchema string
+}
+
+// SetValueTypeParams 修改键的值类型参数
+type SetValueTypeParams struct {
+	KeyName     string
+	ValueType   string
+	ValueSchema string // 只用于 json 类型
+}
+
+// SetValueTypeResult 修改键的值类型结果
+type SetValueTypeResult struct {
+	KeyName      string          `json:"key_name"`
+	ValueType    string          `json:"value_type"`
+	ValueSchema  json.RawMessage `json:"value_schema,omitempty" swaggertype:"object"`
+	Translations int64           `json:"translations"` // 修改的翻译条数
+}
+
+// 翻译的审核状态
+const (
+	ReviewStatusDraft    = "draft"     // 草稿，新建或修改后的译文
+	ReviewStatusInReview = "in_review" // 已提交，等待审核
+	ReviewStatusApproved = "approved"  // 审核通过
+	ReviewStatusOutdated = "outdated"  // 源语言译文已修改，需要重新翻译或确认后再提交审核
+)
+
+// ReviewTransitionParams 提交、通过或驳回翻译的参数
+type ReviewTransitionParams struct {
+	TranslationIDs []uint64
+	Comment        string // 驳回原因，只用于驳回
+}
+
+// ReviewTransitionResult 审核操作结果
+type ReviewTransitionResult struct {
+	ReviewStatus string `json:"review_status"` // 操作后的审核状态
+	Translations int64  `json:"translations"`  // 修改的翻译条数
+}
+
+// TranslationStatusFilter 按状态和审核状态筛选翻译
+type TranslationStatusFilter struct {
+	Statuses       []string
+	ReviewStatuses []string // 为空时不限审核状态
+	NamespaceID    uint64   // 只包含该命名空间中的键，为 0 时不限命名空间
+	Tags           []string // 只包含带有其中任一标签的键，为空时不限标签
+}
+
+// 键的使用平台
+const (
+	KeyPlatformWeb     = "web"
+	KeyPlatformIOS     = "ios"
+	KeyPlatformAndroid = "android"
+	KeyPlatformDesktop = "desktop"
+)
+
+const (
+	// MaxKeyTags 键最多的标签数
+	MaxKeyTags = 10
+	// MaxKeyTagLength 标签最多字符数
+	MaxKeyTagLength = 40
+)
+
+// KeyMetadata 键的最大长度、标签、平台和命名空间，按翻译存储，Tags 逗号分隔
+type KeyMetadata struct {
+	MaxLength   int
+	Tags        string
+	Platform    string
+	NamespaceID uint64
+}
+
+// SetKeyMetadataParams 修改键的元数据参数
+type SetKeyMetadataParams struct {
+	KeyName   string
+	MaxLength int // 0 表示不限制
+	Tags      []string
+	Platform  string // 为空时不限平台
+}
+
+// SetKeyMetadataResult 修改键的元数据结果
+type SetKeyMetadataResult struct {
+	KeyName      string   `json:"key_name"`
+	MaxLength    int      `json:"max_length"`
+	Tags         []string `json:"tags"`
+	Platform     string   `json:"platform"`
+	Translations int64    `json:"translations"` // 修改的翻译条数
 }
 
 // BatchTranslationParams 批量翻译参数
@@ -80,14 +206,1024 @@ type BatchTranslationParams struct {
 	Translations map[string]string // language_code -> value
 }
 
+// TranslationMatrixQuery 翻译矩阵查询参数
+type TranslationMatrixQuery struct {
+	ProjectID       uint64
+	Limit           int
+	Offset          int
+	Keyword         string // 在键名和翻译值中搜索
+	SearchCollation string // 搜索使用的比较规则，见 SearchCollation* 常量，为空时使用数据库默认规则
+	SortBy          string // key（默认）或 value
+	SortLanguage    string // 按翻译值排序时使用的语言代码
+	Collation       string // 排序使用的区域设置（BCP 47，如 de、sv、zh、ja），为空时按字节顺序排序
+	Descending      bool
+	ReviewStatus    string   // 只返回有该审核状态的翻译的键，为空时不限
+	Namespace       string   // 只返回该命名空间中的键，为空时不限
+	Tags            []string // 只返回带有其中任一标签的键，为空时不限
+}
+
+// TranslationMatrixPage 按指定顺序分页的翻译矩阵
+type TranslationMatrixPage struct {
+	Keys   []string                              `json:"keys"`   // 本页的键名，按排序结果排列
+	Matrix map[string]map[string]TranslationCell `json:"matrix"` // key -> language_code -> cell
+	Total  int64                                 `json:"total"`
+}
+
+// SortField 列表排序字段，Field 为数据库列名，须经过白名单校验
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// String 返回 "字段:asc" 或 "字段:desc"，用于缓存键
+func (s SortField) String() string {
+	if s.Desc {
+		return s.Field + ":desc"
+	}
+	return s.Field + ":asc"
+}
+
+// 翻译矩阵排序字段
+const (
+	MatrixSortByKey   = "key"
+	MatrixSortByValue = "value"
+)
+
+// 搜索比较规则
+const (
+	SearchCollationAccentInsensitive = "accent_insensitive" // 不区分重音和大小写，cafe 可匹配 Café
+	SearchCollationCaseInsensitive   = "case_insensitive"   // 区分重音，不区分大小写
+	SearchCollationExact             = "exact"              // 区分重音和大小写
+)
+
+// MigrationImportParams 从外部 TMS 迁移导入参数
+type MigrationImportParams struct {
+	Source   string // lokalise, crowdin, poeditor
+	Language string // 单文件导出不含语言信息时指定
+	Data     []byte
+	UserID   uint64 // 操作人，记录在导入时新建的标签上
+}
+
+// MigrationImportResult 迁移导入结果
+type MigrationImportResult struct {
+	Keys              int      `json:"keys"`               // 导入的键数量
+	Translations      int      `json:"translations"`       // 导入的翻译条数
+	SkippedEntries    int      `json:"skipped_entries"`    // 因语言无法匹配或键名为空跳过的条目
+	UnmappedLanguages []string `json:"unmapped_languages"` // 无法匹配的外部语言代码
+	TaggedKeys        int      `json:"tagged_keys"`        // 带有导入标签的键数量
+	SkippedTags       int      `json:"skipped_tags"`       // 名称无效或超出每个键标签数量上限而未导入的标签数量
+	Screenshots       int      `json:"screenshots"`        // 导出内容中附带的截图数量，截图不导入
+	Warnings          []string `json:"warnings"`           // 未能导入的内容，如 skipped: screenshots
+}
+
+// 迁移导入结果中的警告
+const (
+	MigrationWarningSkippedScreenshots = "skipped: screenshots"
+)
+
+// 导出时只包含的翻译状态
+const (
+	ExportOnlyStatusApproved = "approved"
+)
+
+// 导出时缺失翻译的处理方式
+const (
+	ExportMissingSkip           = "skip"            // 不输出缺失的键
+	ExportMissingEmpty          = "empty"           // 输出空字符串
+	ExportMissingSourceFallback = "source_fallback" // 使用源语言（默认语言）的翻译
+)
+
+// ExportOptions 导出选项，OnlyStatus 和 Missing 对所有导出格式生效
+type ExportOptions struct {
+	OnlyStatus     string   // 只导出该状态的翻译，为空时导出全部有效翻译
+	Missing        string   // 缺失翻译的处理方式，为空时为 skip
+	XLIFFVersion   string   // XLIFF 版本（1.2 或 2.0），为空时为 1.2
+	TargetLanguage string   // XLIFF 和 PO 只导出该目标语言，为空时导出默认语言以外的全部语言；移动端格式和 ARB 为空时单文件导出默认语言
+	YAMLStyle      string   // 按文件导出 YAML 时的文件风格（rails 或 symfony），为空时为 rails
+	Namespace      string   // 只导出该命名空间中的键，并作为文件命名模板中的 {namespace}，为空时导出全部键
+	Tags           []string // 只导出带有其中任一标签的键，为空时不限标签
+	Release        string   // 导出该发布版本冻结的翻译，为空时导出当前翻译；不能与 OnlyStatus、Namespace、Tags 同时使用
+}
+
+// ExportFile 导出的单个文件
+type ExportFile struct {
+	Name    string // 按项目导出模板渲染后的文件路径
+	Locale  string
+	Content []byte
+}
+
+// ImportOptions 导入选项
+type ImportOptions struct {
+	Language  string // YAML 文件没有语言根节点时（Symfony 风格）或 ARB 文件没有 @@locale 时文件内容所属的语言代码，ARB 文件中指定时优先
+	Strategy  string // 与已有翻译冲突时的处理方式，为空时 json 格式为 fail，其余格式为 overwrite
+	Namespace string // 文件中的键归入该命名空间，为空时新键不划分命名空间，已有的键保持原命名空间
+}
+
+// 导入冲突策略
+const (
+	ImportStrategySkip      = "skip"      // 跳过项目中已存在的键，只导入新键
+	ImportStrategyOverwrite = "overwrite" // 覆盖已有的译文
+	ImportStrategyMerge     = "merge"     // 只写入没有译文或译文为空的语言，已有的译文保持不变
+	ImportStrategyFail      = "fail"      // 任一语言已有翻译时整个导入失败，不写入任何翻译
+)
+
+// 导入结果中每个键的处理方式
+const (
+	ImportKeyCreate   = "create"   // 键名不存在，已新建
+	ImportKeyUpdate   = "update"   // 键名已存在，至少写入了一种语言
+	ImportKeySkip     = "skip"     // 没有写入，原因见 Reason
+	ImportKeyConflict = "conflict" // fail 策略下与已有翻译冲突
+)
+
+// ImportKeyResult 导入结果中一个键的处理方式
+type ImportKeyResult struct {
+	Key              string   `json:"key"`
+	Action           string   `json:"action"`
+	Languages        []string `json:"languages,omitempty"`         // 写入的语言
+	SkippedLanguages []string `json:"skipped_languages,omitempty"` // 按策略未写入或译文没有变化的语言
+	Reason           string   `json:"reason,omitempty"`            // 跳过或冲突的原因
+}
+
+// ImportResult 文件导入结果
+type ImportResult struct {
+	Format       string            `json:"format"`
+	Strategy     string            `json:"strategy"`
+	Namespace    string            `json:"namespace,omitempty"` // 文件中的键归入的命名空间
+	Created      int               `json:"created"`             // 新建的键数量
+	Updated      int               `json:"updated"`             // 更新的键数量
+	Skipped      int               `json:"skipped"`             // 跳过的键数量
+	Conflicts    int               `json:"conflicts"`           // fail 策略下冲突的键数量，不为 0 时没有写入
+	Translations int               `json:"translations"`        // 写入的翻译条数
+	Keys         []ImportKeyResult `json:"keys"`                // 每个键的处理方式，按键名排序，超过上限时截断
+	Truncated    bool              `json:"truncated"`           // keys 是否被截断
+}
+
+// SpreadsheetUploadParams 通过导入接口上传表格的参数，第一列为键名，其余列的表头为语言代码
+type SpreadsheetUploadParams struct {
+	Format string // csv 或 xlsx，为空时按文件内容识别
+	Data   []byte
+	DryRun bool // 只返回每一行的处理预览，不写入
+}
+
+// 表格导入中每一行的处理方式
+const (
+	SpreadsheetRowCreate = "create" // 键名不存在，将新建
+	SpreadsheetRowUpdate = "update" // 键名已存在，至少一种语言的译文有变化
+	SpreadsheetRowSkip   = "skip"   // 不写入，原因见 Reason
+)
+
+// SpreadsheetRowPreview 表格中一行的处理预览
+type SpreadsheetRowPreview struct {
+	Row       int      `json:"row"` // 表格中的行号，从 1 开始，第 1 行为表头
+	Key       string   `json:"key"`
+	Action    string   `json:"action"`
+	Languages []string `json:"languages,omitempty"` // 将写入的语言
+	Reason    string   `json:"reason,omitempty"`    // 跳过的原因
+}
+
+// SpreadsheetUploadResult 表格导入结果，DryRun 时为预览，不会写入
+type SpreadsheetUploadResult struct {
+	Format            string                  `json:"format"`
+	DryRun            bool                    `json:"dry_run"`
+	Created           int                     `json:"created"`            // 新建的键数量
+	Updated           int                     `json:"updated"`            // 更新的键数量
+	Skipped           int                     `json:"skipped"`            // 跳过的行数
+	Translations      int                     `json:"translations"`       // 写入的翻译条数，不包括译文未变化的单元格
+	UnmappedLanguages []string                `json:"unmapped_languages"` // 表头中无法匹配的语言代码，对应的列被跳过
+	Rows              []SpreadsheetRowPreview `json:"rows"`               // 每一行的处理方式，超过上限时截断
+	Truncated         bool                    `json:"truncated"`          // rows 是否被截断
+}
+
+// PersonalAccessTokenParams 创建个人访问令牌参数
+type PersonalAccessTokenParams struct {
+	Name          string
+	ExpiresInDays int // 有效天数，为 0 时使用默认值
+}
+
+// SSOProvider 单点登录身份提供方
+type SSOProvider struct {
+	Name        string `json:"name"`         // 配置中的名称，登录地址的 provider 参数
+	Type        string `json:"type"`         // google, github, oidc
+	DisplayName string `json:"display_name"` // 登录按钮上显示的名称
+}
+
+// SSOAuthRequest 开始单点登录的结果
+type SSOAuthRequest struct {
+	AuthURL string // 身份提供方的授权地址
+	Session string // 签名的登录会话，回调时用于校验 state 并取回 PKCE 校验码
+}
+
+// SSOIdentity 身份提供方返回的用户信息
+type SSOIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Username      string // 提供方中的用户名（如 GitHub login），可以为空
+}
+
+// APIKeyParams 创建 API Key 参数
+type APIKeyParams struct {
+	Name       string
+	Scope      string   // read 或 write，为空时为 read
+	ProjectIDs []uint64 // 可访问的项目，为空时可访问所有项目
+}
+
+// ========== Project Duplicate Service Params ==========
+
+// ProjectDuplicateParams 复制项目参数
+type ProjectDuplicateParams struct {
+	Name           string
+	Slug           string // 新项目标识，为空时根据名称生成
+	AutoSuffix     bool   // 标识冲突时自动追加 -2、-3 等后缀
+	Description    string // 为空时使用原项目的描述
+	IncludeMembers bool   // 是否复制项目成员及其角色
+}
+
+// ========== Project Config Service Params ==========
+
+// ProjectConfigVersion 当前项目配置文档的版本
+const ProjectConfigVersion = 1
+
+// ProjectConfig 项目配置文档（不包含翻译内容），用于在项目之间复制配置
+type ProjectConfig struct {
+	Version   int
+	Source    string // 导出时的项目标识，导入时忽略
+	Settings  ProjectConfigSettings
+	Languages []string // 启用的语言代码
+	Webhooks  []ProjectConfigWebhook
+	Members   []ProjectConfigMember
+}
+
+// ProjectConfigSettings 项目设置，导入时空字段不修改
+type ProjectConfigSettings struct {
+	Description        string
+	Status             string
+	ExportFileTemplate string
+}
+
+// ProjectConfigWebhook 入站 Webhook 配置（不包含签名密钥），导入时按名称匹配
+type ProjectConfigWebhook struct {
+	Name              string
+	Provider          string
+	ExternalProjectID string
+	LanguageMapping   map[string]string
+	ConflictStrategy  string
+	Status            string
+}
+
+// ProjectConfigMember 项目成员角色，导入时按用户名匹配
+type ProjectConfigMember struct {
+	Username string
+	Role     string
+}
+
+// ProjectConfigImportParams 导入项目配置参数
+type ProjectConfigImportParams struct {
+	Config ProjectConfig
+	DryRun bool // 为 true 时只返回将要执行的变更
+}
+
+// 项目配置变更类型
+const (
+	ProjectConfigActionCreate = "create"
+	ProjectConfigActionUpdate = "update"
+)
+
+// ProjectConfigChange 导入配置产生的一项变更
+type ProjectConfigChange struct {
+	Section string `json:"section"` // settings, webhooks, members
+	Target  string `json:"target"`  // 设置项名称、Webhook 名称或用户名
+	Action  string `json:"action"`  // create, update
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// ProjectConfigImportResult 导入项目配置结果
+type ProjectConfigImportResult struct {
+	DryRun           bool
+	Changes          []ProjectConfigChange
+	MissingLanguages []string // 本实例不存在或未启用的语言代码，语言需要由管理员单独启用
+	UnknownUsers     []string // 本实例不存在的用户名，已跳过
+}
+
+// ========== Inbound Webhook Service Params ==========
+
+// InboundWebhookParams 创建/更新入站 Webhook 参数
+type InboundWebhookParams struct {
+	Name              string
+	Provider          string
+	ExternalProjectID string
+	LanguageMapping   map[string]string // 对方语言代码 -> 本系统语言代码
+	ConflictStrategy  string
+	Status            string
+}
+
+// InboundWebhookPayload 入站 Webhook 请求体
+type InboundWebhookPayload struct {
+	ExternalProjectID string                      `json:"project_id"`
+	Translations      []InboundWebhookTranslation `json:"translations"`
+}
+
+// InboundWebhookTranslation 入站 Webhook 推送的单条翻译
+type InboundWebhookTranslation struct {
+	Key      string `json:"key"`
+	Language string `json:"language"` // 对方系统的语言代码
+	Value    string `json:"value"`
+	Context  string `json:"context,omitempty"`
+}
+
+// ========== Webhook Service Params ==========
+
+// WebhookParams 创建/更新出站 Webhook 参数
+type WebhookParams struct {
+	Name   string
+	URL    string
+	Events   []string // 订阅的事件，nil 表示不修改
+	Template *string  // 消息模板，nil 表示不修改，空字符串表示使用内置 generic 模板
+	Preset   string   // 使用内置模板：generic、slack、teams，不能与 Template 同时指定
+	Status   string
+}
+
+// ========== Import Profile Service Params ==========
+
+// ImportProfileParams 创建/更新表格导入映射配置参数
+type ImportProfileParams struct {
+	Name            string
+	HeaderRow       int
+	KeyColumn       string
+	ContextColumn   *string           // 为 nil 时保持不变，空字符串表示不导入上下文
+	LanguageColumns map[string]string // 表头 -> 语言代码
+}
+
+// ImportMappingSuggestParams 推测列映射参数
+type ImportMappingSuggestParams struct {
+	Format    string // csv 或 xlsx，为空时按文件内容识别
+	HeaderRow int    // 表头所在行，默认第 1 行
+	Data      []byte
+}
+
+// ImportMappingSuggestion 按表头推测的列映射
+type ImportMappingSuggestion struct {
+	Format          string            `json:"format"`
+	HeaderRow       int               `json:"header_row"`
+	Columns         []string          `json:"columns"`          // 表头中的所有列
+	KeyColumn       string            `json:"key_column"`       // 未识别时为空
+	ContextColumn   string            `json:"context_column"`   // 未识别时为空
+	LanguageColumns map[string]string `json:"language_columns"` // 表头 -> 语言代码
+	UnmappedColumns []string          `json:"unmapped_columns"` // 未识别的列
+}
+
+// SpreadsheetImportParams 按映射配置导入表格参数
+type SpreadsheetImportParams struct {
+	Format string // csv 或 xlsx，为空时按文件内容识别
+	Data   []byte
+}
+
+// SpreadsheetImportResult 表格导入结果
+type SpreadsheetImportResult struct {
+	Rows              int      `json:"rows"`               // 表头之后的数据行数
+	Keys              int      `json:"keys"`               // 导入的键数量
+	Translations      int      `json:"translations"`       // 导入的翻译条数
+	SkippedRows       int      `json:"skipped_rows"`       // 键名为空的行
+	UnmappedLanguages []string `json:"unmapped_languages"` // 映射配置中已不存在的语言代码，对应的列被跳过
+}
+
+// ========== Assignment Service Params ==========
+
+// 任务分配的工作类型
+const (
+	AssignmentTypeTranslate = "translate" // 翻译：补充缺失或草稿状态的译文
+	AssignmentTypeReview    = "review"    // 审核：处理待审核的译文
+)
+
+// MaxAssignmentKeys 一次最多分配的键数量
+const MaxAssignmentKeys = 1000
+
+// AssignParams 分配任务的参数
+type AssignParams struct {
+	UserID       uint64
+	LanguageCode string
+	KeyNames     []string // 为空时分配该语言的所有键
+	Type         string
+}
+
+// UserTask 用户在某个项目和语言中待处理的一类工作
+type UserTask struct {
+	ProjectID    uint64   `json:"project_id"`
+	ProjectName  string   `json:"project_name"`
+	LanguageCode string   `json:"language_code"`
+	Type         string   `json:"type"`         // translate, review
+	Pending      int      `json:"pending"`      // 待处理的键数量
+	PendingKeys  []string `json:"pending_keys"` // 待处理的键，按键名排序，最多 100 个
+}
+
+// UserTaskList 用户的待办工作，只包含有待处理键的任务
+type UserTaskList struct {
+	Tasks     []UserTask `json:"tasks"`
+	Translate int        `json:"translate"` // 待翻译的键总数
+	Review    int        `json:"review"`    // 待审核的键总数
+}
+
+// ========== Namespace Service Params ==========
+
+// NamespaceParams 创建/更新命名空间参数
+type NamespaceParams struct {
+	Name        string  // 更新时为空表示不修改
+	Description *string // 为 nil 时不修改
+}
+
+// AssignNamespaceParams 将键归入命名空间的参数
+type AssignNamespaceParams struct {
+	KeyNames  []string
+	Namespace string // 为空时将键移出命名空间
+}
+
+// AssignNamespaceResult 将键归入命名空间的结果
+type AssignNamespaceResult struct {
+	Namespace    string   `json:"namespace"`
+	KeyNames     []string `json:"key_names"`
+	Translations int64    `json:"translations"` // 修改的翻译条数
+}
+
+// ========== Tag Service Params ==========
+
+// TagParams 创建/更新标签参数
+type TagParams struct {
+	Name        string  // 更新时为空表示不修改
+	Color       *string // 为 nil 时不修改
+	Description *string // 为 nil 时不修改
+}
+
+// TagKeysResult 为键添加或移除标签的结果
+type TagKeysResult struct {
+	Tag      string   `json:"tag"`
+	KeyNames []string `json:"key_names"`
+	Changed  int64    `json:"changed"` // 新增或移除了标签的键数量
+}
+
+// ========== Delivery Service Params ==========
+
+// DeliveryBundle 公开分发的语言包：发布版本中一种语言的翻译，按键名输出为 JSON 对象
+type DeliveryBundle struct {
+	ProjectID uint64 `json:"project_id"`
+	Release   string `json:"release"` // 发布版本标签
+	Locale    string `json:"locale"`
+	Body      []byte `json:"body"`
+	ETag      string `json:"etag"` // 根据 Body 计算的强 ETag
+}
+
+// ========== Translation Delta Service Params ==========
+
+// TranslationDeltaQuery 增量同步的条件
+type TranslationDeltaQuery struct {
+	ProjectID uint64
+	Since     string // 同步起点：RFC 3339 时间或发布版本标签（该版本的发布时间）
+	Locale    string // 只返回该语言的翻译，为空时返回所有语言
+	KeyPrefix string // 只返回以该前缀开头的键，为空时不限制
+	MaxKeys   int    // 变更的键超过该数量时返回 ErrTooManyChanges
+}
+
+// TranslationDelta 同步起点之后变更的键
+// 变更的键返回其在各语言的当前翻译，客户端按键整体替换；键没有有效翻译时列入 Deleted
+type TranslationDelta struct {
+	Since        time.Time                    `json:"since"`
+	SyncedAt     time.Time                    `json:"synced_at"`    // 下次同步时作为 since 传入
+	Translations map[string]map[string]string `json:"translations"` // 键名 -> 语言代码 -> 翻译值
+	Deleted      []string                     `json:"deleted"`      // 已删除、废弃或被重命名的键
+}
+
+// ========== Key Push Service Params ==========
+
+// PushKeysParams 推送键参数
+type PushKeysParams struct {
+	ProjectID    uint64
+	Keys         []string
+	Defaults     map[string]string            // 已废弃：未提供 Translations 时作为新键在默认语言中的翻译值
+	Translations map[string]map[string]string // 语言代码 -> 键名 -> 翻译值
+}
+
+// PushKeysResult 推送键结果
+type PushKeysResult struct {
+	Added   []string `json:"added"`
+	Existed []string `json:"existed"`
+	Failed  []string `json:"failed"`
+}
+
+// ========== Branch Service Params ==========
+
+// BranchParams 创建分支参数
+type BranchParams struct {
+	Name        string
+	Description string
+}
+
+// BranchTranslationParams 分支中的一条翻译修改
+type BranchTranslationParams struct {
+	KeyName      string
+	LanguageCode string
+	Value        string
+	Deleted      bool // 在分支中删除该翻译，忽略 Value
+}
+
+// BranchDiff 两个分支之间的差异
+type BranchDiff struct {
+	Source    string         `json:"source"`
+	Target    string         `json:"target"`
+	Changes   []BranchChange `json:"changes"`   // 按键名和语言代码排序
+	Conflicts int            `json:"conflicts"` // 冲突的差异数量，只在目标为主线时计算
+}
+
+// 合并冲突的处理方式
+const (
+	BranchResolutionBranch = "branch" // 使用分支中的值
+	BranchResolutionMain   = "main"   // 保留主线的值
+	BranchResolutionCustom = "custom" // 使用指定的值
+)
+
+// BranchConflictResolution 一条差异的处理方式
+type BranchConflictResolution struct {
+	Key        string
+	Language   string
+	Resolution string // branch, main, custom
+	Value      string // Resolution 为 custom 时写入主线的值
+}
+
+// BranchMergeParams 合并分支参数
+type BranchMergeParams struct {
+	Resolutions []BranchConflictResolution // 冲突必须指定处理方式，也可以用于不冲突的差异
+}
+
+// BranchMergeResult 合并分支的结果
+type BranchMergeResult struct {
+	Branch  *Branch           `json:"branch"`
+	Applied []PromotionChange `json:"applied"` // 写入主线的修改
+	Skipped int               `json:"skipped"` // 保留主线值的差异数量
+}
+
+// ========== Translation Rollback Service Params ==========
+
+// ProjectRollbackParams 项目回滚的参数
+type ProjectRollbackParams struct {
+	To time.Time // 回滚到的时刻，不能晚于当前时间
+}
+
+// ProjectRollbackResult 项目回滚的结果
+type ProjectRollbackResult struct {
+	To           time.Time `json:"to"`
+	Translations int       `json:"translations"` // 恢复的翻译数量
+	Skipped      int       `json:"skipped"`      // 无法恢复的翻译数量：当时尚未创建、当时已删除或当前已删除
+}
+
+// ========== Translation Trash Service Params ==========
+
+// TranslationTrash 回收站中的翻译
+type TranslationTrash struct {
+	Translations []*Translation // 已删除的翻译，DeletedAt 为删除时间，UpdatedBy 为删除人
+	Total        int64
+	Retention    time.Duration // 删除后保留的时间，超过后永久删除，0 表示不自动清除
+}
+
+// ========== Attachment Service Params ==========
+
+// AttachmentUploadParams 上传附件的参数
+type AttachmentUploadParams struct {
+	KeyName  string
+	FileName string // 为空时按格式生成，如 screenshot.png
+	Data     []byte
+}
+
+// ========== Release Service Params ==========
+
+// ReleaseParams 发布版本的参数
+type ReleaseParams struct {
+	Version     string
+	Description string
+	Override    bool // 未达到发布门槛时仍然发布
+	// RolloutPercentage 灰度发布的分发流量百分比（1-99），0 表示直接全量发布
+	RolloutPercentage int
+}
+
+// ========== Release Gate Service Params ==========
+
+// ReleaseThresholdParams 单个语言的发布门槛
+type ReleaseThresholdParams struct {
+	LanguageCode  string
+	MinCompletion int // 最低完成率（百分比，0-100）
+	MinApproval   int // 最低审核通过率（百分比，0-100）
+}
+
+// ReleaseReadiness 发布门槛检查结果
+type ReleaseReadiness struct {
+	Ready      bool                `json:"ready"`      // 所有设置了门槛的语言都达标
+	Overridden bool                `json:"overridden"` // 未达标但发布时显式覆盖
+	TotalKeys  int                 `json:"total_keys"` // 项目中有效的键数量，作为完成率的分母
+	Blocking   []string            `json:"blocking"`   // 未达标的语言代码
+	Languages  []LanguageReadiness `json:"languages"`
+}
+
+// LanguageReadiness 单个语言的完成情况
+// 未达标的语言列出缺少翻译和未审核通过的键，超过上限时截断，计数不受影响
+type LanguageReadiness struct {
+	LanguageCode   string   `json:"language_code"`
+	Translated     int      `json:"translated"`
+	Approved       int      `json:"approved"`
+	Completion     float64  `json:"completion"`     // 完成率（百分比）
+	Approval       float64  `json:"approval"`       // 审核通过率（百分比）
+	MinCompletion  int      `json:"min_completion"` // 未设置门槛时为 0
+	MinApproval    int      `json:"min_approval"`   // 未设置门槛时为 0
+	Blocking       bool     `json:"blocking"`
+	MissingKeys    []string `json:"missing_keys"`    // 缺少翻译的键
+	UnapprovedKeys []string `json:"unapproved_keys"` // 有翻译但未审核通过的键
+	KeysTruncated  bool     `json:"keys_truncated"`  // 键列表是否被截断
+}
+
+// ========== Figma Service Params ==========
+
+// FigmaFrameParams Figma 插件同步画框的参数
+type FigmaFrameParams struct {
+	FileKey  string
+	FileName string
+	NodeID   string
+	Name     string
+	Language string // 图层文本所属的语言代码，为空时为默认语言
+	Layers   []FigmaLayerParams
+}
+
+// FigmaLayerParams 画框中引用翻译键的文本图层
+type FigmaLayerParams struct {
+	NodeID  string
+	Name    string
+	KeyName string
+	Text    string
+	Context string // 新建键时写入的上下文说明
+}
+
+// FigmaSyncResult 同步画框的结果
+type FigmaSyncResult struct {
+	Frame        *FigmaFrame `json:"frame"`
+	CreatedKeys  []string    `json:"created_keys"`  // 以图层文本新建的键
+	ExistingKeys []string    `json:"existing_keys"` // 已存在的键，翻译保持不变
+	ChangedKeys  []string    `json:"changed_keys"`  // 已存在且图层文本与当前翻译不同的键，可能是设计稿或翻译需要更新
+}
+
+// ========== Key Prefix Service Params ==========
+
+// KeyPrefixParams 按键名前缀批量操作参数
+type KeyPrefixParams struct {
+	Prefix    string // 键名前缀，末尾的 * 可省略，如 checkout.* 与 checkout. 等价
+	NewPrefix string // 重命名后的前缀
+	Status    string // 修改后的状态：active, deprecated
+	Preview   bool   // 只预览受影响的键，不执行操作
+}
+
+// KeyPrefixResult 按键名前缀批量操作的结果
+type KeyPrefixResult struct {
+	Prefix       string                       `json:"prefix"`
+	NewPrefix    string                       `json:"new_prefix,omitempty"`
+	Status       string                       `json:"status,omitempty"`
+	Preview      bool                         `json:"preview"`
+	Keys         int                          `json:"keys"`                // 匹配的键数量
+	Translations int64                        `json:"translations"`        // 受影响的翻译条数
+	SampleKeys   []string                     `json:"sample_keys"`         // 部分匹配的键，用于预览确认
+	Conflicts    []string                     `json:"conflicts,omitempty"` // 重命名后与已有键冲突的键
+	Data         map[string]map[string]string `json:"data,omitempty"`      // 导出的翻译：键名 -> 语言代码 -> 翻译值
+}
+
+// KeyRenameParams 翻译键重命名参数
+type KeyRenameParams struct {
+	KeyName    string
+	NewKeyName string
+}
+
+// KeyRenameResult 翻译键重命名结果，各字段为修改的记录数
+type KeyRenameResult struct {
+	KeyName            string `json:"key_name"`
+	NewKeyName         string `json:"new_key_name"`
+	Translations       int64  `json:"translations"`
+	BranchTranslations int64  `json:"branch_translations"` // 未合并分支中修改过的翻译
+	Attachments        int64  `json:"attachments"`
+	Assignments        int64  `json:"assignments"`
+	FigmaLayers        int64  `json:"figma_layers"` // 引用该键的设计稿图层
+}
+
+// KeyCopyParams 跨项目复制键参数
+type KeyCopyParams struct {
+	SourceProjectID uint64
+	KeyNames        []string
+	Overwrite       bool // 项目中已有的键是否用源项目的翻译覆盖，为 false 时跳过
+	Link            bool // 是否链接复制的键，源项目中的翻译修改后同步到项目
+}
+
+// KeyCopyResult 跨项目复制键结果
+type KeyCopyResult struct {
+	SourceProjectID uint64   `json:"source_project_id"`
+	Copied          []string `json:"copied"`            // 复制的键
+	Skipped         []string `json:"skipped,omitempty"` // 项目中已存在且未覆盖的键
+	Translations    int64    `json:"translations"`      // 新增或修改的翻译条数，值相同的翻译不计入
+	Linked          int      `json:"linked"`            // 链接的键数量
+}
+
+// ========== Bulk Delete Service Params ==========
+
+// BulkDeleteParams 按条件批量删除翻译参数，至少需要指定一个筛选条件
+type BulkDeleteParams struct {
+	KeyPrefix         string     // 键名前缀，末尾的 * 可省略
+	Language          string     // 语言代码
+	Status            string     // 翻译状态：active, deprecated
+	OlderThan         *time.Time // 最后修改时间早于该时间
+	ConfirmationToken string     // 执行删除时必填，来自预览结果
+}
+
+// HistoryExportParams 导出历史记录参数，时间范围为 [From, To)
+type HistoryExportParams struct {
+	ProjectID uint64
+	Source    string // translations（翻译变更历史）或 audit（审计日志）
+	Format    string // csv 或 jsonl
+	From      time.Time
+	To        time.Time
+}
+
+// 历史记录导出来源和格式常量
+const (
+	HistorySourceTranslations = "translations"
+	HistorySourceAudit        = "audit"
+	HistoryFormatCSV          = "csv"
+	HistoryFormatJSONL        = "jsonl"
+)
+
+// 词级差异片段类型
+const (
+	DiffOpEqual  = "equal"
+	DiffOpInsert = "insert"
+	DiffOpDelete = "delete"
+)
+
+// DiffSegment 词级差异中的一段文本，按顺序拼接 equal 和 delete 得到旧值，拼接 equal 和 insert 得到新值
+type DiffSegment struct {
+	Op   string `json:"op"` // equal, insert, delete
+	Text string `json:"text"`
+}
+
+// TranslationHistoryDiff 一条变更历史中翻译值的差异
+type TranslationHistoryDiff struct {
+	HistoryID     uint64        `json:"history_id"`
+	TranslationID uint64        `json:"translation_id"`
+	KeyName       string        `json:"key_name"`
+	PreviousKey   string        `json:"previous_key,omitempty"`
+	Language      string        `json:"language"`
+	Operation     string        `json:"operation"`
+	OldValue      string        `json:"old_value"`
+	NewValue      string        `json:"new_value"`
+	Segments      []DiffSegment `json:"segments"` // 词级差异，值没有变化时只有一个 equal 片段
+	Unified       string        `json:"unified"`  // 按行的 unified diff，值没有变化时为空
+	UserID        uint64        `json:"user_id"`
+	Operator      *UserSummary  `json:"operator,omitempty"` // 操作人，用户已删除或为系统操作时为空
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// TranslationChangesParams 查询时间范围 [From, To) 内翻译变化的参数
+type TranslationChangesParams struct {
+	ProjectID uint64
+	From      time.Time
+	To        time.Time
+}
+
+// 翻译在时间范围内的变化类型
+const (
+	TranslationChangeAdded    = "added"
+	TranslationChangeModified = "modified"
+	TranslationChangeRemoved  = "removed"
+	TranslationChangeRenamed  = "renamed" // 只修改了键名
+)
+
+// TranslationChange 一条翻译在时间范围内的净变化：开始时的值与结束时的值比较，中间的修改不单独列出
+type TranslationChange struct {
+	TranslationID uint64        `json:"translation_id"`
+	KeyName       string        `json:"key_name"`               // 结束时的键名
+	PreviousKey   string        `json:"previous_key,omitempty"` // 范围内重命名过时为开始时的键名
+	Language      string        `json:"language"`
+	Change        string        `json:"change"` // added, modified, removed, renamed
+	OldValue      string        `json:"old_value"`
+	NewValue      string        `json:"new_value"`
+	Segments      []DiffSegment `json:"segments"`
+}
+
+// TranslationChangeReport 时间范围内项目翻译的变化，用于编写发布说明
+type TranslationChangeReport struct {
+	ProjectID uint64              `json:"project_id"`
+	From      time.Time           `json:"from"`
+	To        time.Time           `json:"to"`
+	Added     int                 `json:"added"`
+	Modified  int                 `json:"modified"`
+	Removed   int                 `json:"removed"`
+	Renamed   int                 `json:"renamed"`
+	Changes   []TranslationChange `json:"changes"`   // 按键名和语言代码排序
+	Truncated bool                `json:"truncated"` // 变化超过上限时只返回前面的部分，统计数量仍为全部
+}
+
+// ComplianceReportParams 合规报告参数，月份格式为 2006-01，包含起止月份
+type ComplianceReportParams struct {
+	ProjectID uint64 // 为 0 时包含全部项目
+	From      string
+	To        string
+}
+
+// ComplianceReport 合规报告：按项目和月份汇总每个用户的操作
+type ComplianceReport struct {
+	From     string
+	To       string
+	Projects []*ComplianceProjectReport
+}
+
+// ComplianceProjectReport 单个项目的合规报告
+type ComplianceProjectReport struct {
+	ProjectID   uint64
+	ProjectName string
+	Months      []*ComplianceMonthReport
+}
+
+// ComplianceMonthReport 单个项目单月的用户操作汇总
+type ComplianceMonthReport struct {
+	Month string
+	Users []*ComplianceUserActivity
+}
+
+// ComplianceUserActivity 用户在一个月内对项目的操作次数
+type ComplianceUserActivity struct {
+	UserID   uint64
+	Username string           // 用户不存在时为空，UserID 为 0 表示系统或 CLI
+	Changes  map[string]int64 // 翻译变更次数，按操作类型（create、update 等）
+	Actions  map[string]int64 // 审计日志记录的操作次数，按操作类型
+	Exports  int64            // 导出数据的次数（审计日志中以 .export 结尾的操作）
+}
+
+// WebhookTemplatePreviewParams 预览 Webhook 消息模板参数
+type WebhookTemplatePreviewParams struct {
+	Template  string          // Go 模板，为空时使用 Preset
+	Preset    string          // 内置模板：generic、slack、teams
+	WebhookID uint64          // 未指定 Template 和 Preset 时使用该 Webhook 保存的模板
+	EventType EventType       // 示例事件类型，可以是领域事件或 Webhook 事件名称
+	Payload   json.RawMessage // 自定义示例事件内容，为空时使用内置示例
+}
+
+// WebhookTemplatePreview Webhook 消息模板预览结果
+type WebhookTemplatePreview struct {
+	EventType EventType
+	Body      json.RawMessage // 渲染结果，必须是合法的 JSON
+}
+
+// StaleProjectParams 闲置项目查询参数
+type StaleProjectParams struct {
+	Days   int // 超过该天数没有内容变更视为闲置，0 表示使用默认值
+	Limit  int
+	Offset int
+}
+
+// StaleProjectReport 闲置项目报告
+type StaleProjectReport struct {
+	Days     int
+	Before   time.Time // 最近变更早于该时间的项目视为闲置
+	Projects []*Project
+	Total    int64
+}
+
+// ArchiveStaleProjectsParams 批量归档闲置项目参数
+type ArchiveStaleProjectsParams struct {
+	ProjectIDs []uint64
+	Days       int // 归档前按该天数重新检查是否闲置，0 表示使用默认值
+}
+
+// ArchiveStaleProjectsResult 批量归档闲置项目结果
+type ArchiveStaleProjectsResult struct {
+	Archived []uint64
+	Skipped  []uint64 // 不存在、已归档或已不再闲置的项目
+}
+
+// DeadLetterListParams 死信事件查询参数
+type DeadLetterListParams struct {
+	EventType string // 为空时不过滤
+	Limit     int
+	Offset    int
+}
+
+// RequeueDeadLettersParams 重新投递死信事件参数
+type RequeueDeadLettersParams struct {
+	IDs []uint64
+	All bool // 重新投递全部死信事件，此时忽略 IDs
+}
+
+// BulkDeleteResult 按条件批量删除翻译的预览或执行结果
+type BulkDeleteResult struct {
+	KeyPrefix         string     `json:"key_prefix,omitempty"`
+	Language          string     `json:"language,omitempty"`
+	Status            string     `json:"status,omitempty"`
+	OlderThan         *time.Time `json:"older_than,omitempty"`
+	Keys              int64      `json:"keys"`                         // 匹配的键数量
+	Translations      int64      `json:"translations"`                 // 匹配的翻译条数
+	SampleKeys        []string   `json:"sample_keys,omitempty"`        // 部分匹配的键，用于预览确认
+	ConfirmationToken string     `json:"confirmation_token,omitempty"` // 预览时返回，执行删除时提交
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`         // 确认令牌的过期时间
+	Deleted           int64      `json:"deleted"`                      // 已删除的翻译条数
+	Batches           int        `json:"batches"`                      // 执行删除的事务批次数
+}
+
+// ========== Promotion Service Params ==========
+
+// PromotionSourceParams 环境推送的源环境
+// 指定 RemoteURL 时通过源实例的 CLI 接口拉取翻译，否则使用上传的 Snapshot
+type PromotionSourceParams struct {
+	RemoteURL       string                       // 源实例地址，如 https://staging.example.com
+	APIKey          string                       // 源实例的 CLI API Key，不会保存
+	SourceProject   string                       // 源实例中的项目ID或标识
+	Snapshot        map[string]map[string]string // 键名 -> 语言代码 -> 翻译值
+	IncludeRemovals bool                         // 是否将源环境中不存在的翻译计为删除
+}
+
+// PromotionApplyParams 应用环境推送参数
+type PromotionApplyParams struct {
+	All      bool                 // 应用全部差异
+	Approved []PromotionChangeRef // 审核通过的差异
+}
+
+// PromotionDetail 推送记录详情
+type PromotionDetail struct {
+	Promotion      *Promotion
+	Changes        []PromotionChange // 计算出的全部差异
+	Applied        []PromotionChange // 已应用的差异
+	SignatureValid bool              // 已应用记录的签名是否有效
+}
+
+// PromotionChangeRef 引用一条差异
+type PromotionChangeRef struct {
+	Key      string
+	Language string
+}
+
 // ========== Dashboard Service Params ==========
 
 // DashboardStats 仪表板统计结果
 type DashboardStats struct {
-	TotalProjects     int    `json:"total_projects"`
-	TotalLanguages    int    `json:"total_languages"`
-	TotalTranslations int    `json:"total_translations"`
-	TotalKeys         int    `json:"total_keys"`
+	TotalProjects        int    `json:"total_projects"`
+	TotalLanguages       int    `json:"total_languages"`
+	TotalTranslations    int    `json:"total_translations"`
+	TotalKeys            int    `json:"total_keys"`
+	OutdatedTranslations int    `json:"outdated_translations"` // 源语言译文修改后等待重新翻译的译文数
+}
+
+// DashboardActivity 最近若干天的翻译变更趋势，按天、操作人和项目汇总
+// 新增为 create 变更历史；修改为 update、machine_translate 和 rollback 变更历史
+type DashboardActivity struct {
+	Days      int                     `json:"days"`
+	From      string                  `json:"from"` // 统计的第一天（2006-01-02）
+	To        string                  `json:"to"`   // 统计的最后一天，即今天
+	Total     ActivityTrendCount      `json:"total"`
+	Daily     []DailyActivityTrend    `json:"daily"`     // 每天一项，没有变更的日期计数为 0
+	Operators []OperatorActivityTrend `json:"operators"` // 按变更总数降序
+	Projects  []ProjectActivityTrend  `json:"projects"`  // 按变更总数降序
+}
+
+// ActivityTrendCount 新增和修改的翻译数
+type ActivityTrendCount struct {
+	Added   int64 `json:"added"`
+	Updated int64 `json:"updated"`
+}
+
+// DailyActivityTrend 一天的翻译变更数
+type DailyActivityTrend struct {
+	Date string `json:"date"`
+	ActivityTrendCount
+}
+
+// OperatorActivityTrend 一个操作人的翻译变更数，user_id 为 0 表示系统或 CLI
+type OperatorActivityTrend struct {
+	UserID   uint64 `json:"user_id"`
+	Username string `json:"username"`
+	ActivityTrendCount
+}
+
+// ProjectActivityTrend 一个项目的翻译变更数
+type ProjectActivityTrend struct {
+	ProjectID   uint64 `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	ActivityTrendCount
+}
+
+// ProjectStats 项目各启用语言的翻译完成情况
+type ProjectStats struct {
+	ProjectID uint64          `json:"project_id"`
+	TotalKeys int             `json:"total_keys"` // 项目中有效的键数量
+	Languages []LanguageStats `json:"languages"`
+}
+
+// LanguageStats 单个语言的翻译完成情况，translated、outdated、untranslated 之和为项目的键数量
+type LanguageStats struct {
+	LanguageCode string  `json:"language_code"`
+	LanguageName string  `json:"language_name"`
+	Translated   int     `json:"translated"`   // 译文不为空且未过期
+	Outdated     int     `json:"outdated"`     // 源语言译文修改后等待重新翻译
+	Untranslated int     `json:"untranslated"` // 没有翻译或译文为空
+	Percentage   float64 `json:"percentage"`   // translated 占键数量的百分比，保留两位小数
 }
 
 // ========== Project Member Service Params ==========
@@ -98,6 +1234,57 @@ type AddMemberParams struct {
 	Role         string
 }
 
+// BulkAddMembersParams 批量添加成员参数
+type BulkAddMembersParams struct {
+	Members []BulkMemberEntry
+	Notify  bool // 向添加的成员发送通知邮件
+}
+
+// BulkMemberEntry 批量添加的单个成员，按用户ID或邮箱指定用户（同时提供时使用用户ID）
+type BulkMemberEntry struct {
+	Index  int // 结果中的序号，为 0 时使用从 1 开始的位置；CSV 导入时为表格中的行号
+	UserID uint64
+	Email  string
+	Role   string
+}
+
+// MemberImportParams 从 CSV/XLSX 导入成员参数
+type MemberImportParams struct {
+	Format      string // csv 或 xlsx，为空时按文件内容识别
+	Data        []byte
+	DefaultRole string // role 列为空时使用的角色
+	Notify      bool
+}
+
+// BulkAddMembersResult 批量添加成员结果
+type BulkAddMembersResult struct {
+	Added       int                `json:"added"`
+	Existing    int                `json:"existing"` // 已是项目成员而跳过的数量，角色不变
+	Failed      int                `json:"failed"`
+	Emailed     int                `json:"emailed"`      // 通知邮件发送成功的数量
+	EmailFailed int                `json:"email_failed"` // 通知邮件发送失败的数量，成员仍已添加
+	Results     []BulkMemberResult `json:"results"`
+}
+
+// BulkMemberResult 单个成员的添加结果
+type BulkMemberResult struct {
+	Index       int    `json:"index"`
+	UserID      uint64 `json:"user_id,omitempty"`
+	Username    string `json:"username,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Role        string `json:"role"`
+	Status      string `json:"status"`                 // added、existing 或 failed
+	Message     string `json:"message,omitempty"`      // 失败原因，已是成员时为现有角色
+	EmailStatus string `json:"email_status,omitempty"` // sent 或 failed，未要求通知时为空
+}
+
+// 批量添加成员的单项状态
+const (
+	BulkMemberAdded    = "added"
+	BulkMemberExisting = "existing"
+	BulkMemberFailed   = "failed"
+)
+
 // UpdateMemberRoleParams 更新成员角色参数
 type UpdateMemberRoleParams struct {
 	Role string
@@ -105,9 +1292,488 @@ type UpdateMemberRoleParams struct {
 
 // ProjectMemberInfo 项目成员信息
 type ProjectMemberInfo struct {
-	ID       uint64
-	UserID   uint64
-	Username string
-	Email    string
-	Role     string
+	ID          uint64
+	UserID      uint64
+	Username    string
+	DisplayName string
+	AvatarURL   string
+	Email       string
+	Language    string
+	Timezone    string
+	Role        string
+	Source      string // 为空时为手动添加，role_sync 为管理员同步自动添加
+}
+
+// ========== Organization Service Params ==========
+
+// OrganizationParams 创建或更新组织参数
+type OrganizationParams struct {
+	Name        string
+	Description string
+}
+
+// AddOrganizationMemberParams 添加组织成员参数，按用户ID或邮箱指定用户
+type AddOrganizationMemberParams struct {
+	UserID uint64
+	Email  string
+	Role   string // owner、admin 或 member，为空时为 member
+}
+
+// OrganizationMemberInfo 组织成员信息
+type OrganizationMemberInfo struct {
+	UserID    uint64
+	Username  string
+	Email     string
+	Role      string
+	CreatedAt time.Time
+}
+
+// RoleSyncResult 全局角色与项目成员同步结果
+type RoleSyncResult struct {
+	Admins        int `json:"admins"`         // 参与同步的启用的全局管理员数量
+	Projects      int `json:"projects"`       // 项目数量
+	MembersAdded  int `json:"members_added"`  // 为管理员补充的 owner 成员关系数量
+	ReviewsQueued int `json:"reviews_queued"` // 新加入复核队列的 owner 成员关系数量
+}
+
+// 角色复核操作
+const (
+	RoleReviewActionKeep      = "keep"      // 保留 owner 角色
+	RoleReviewActionDowngrade = "downgrade" // 降级为 editor 或 viewer
+	RoleReviewActionRemove    = "remove"    // 移除成员关系
+)
+
+// ResolveRoleReviewParams 处理角色复核参数
+type ResolveRoleReviewParams struct {
+	Action string
+	Role   string // Action 为 downgrade 时的新角色：editor 或 viewer
+}
+
+// UsageRecord 一次翻译拉取
+type UsageRecord struct {
+	ProjectID uint64
+	Channel   string // 渠道，见 UsageChannel* 常量
+	Locale    string // 请求的语言代码，为空表示未指定
+	Consumer  string // 调用方标识
+}
+
+// UsageReport 项目的翻译拉取用量报告
+type UsageReport struct {
+	Days          int                `json:"days"`
+	Since         string             `json:"since"` // 统计起始日期（UTC，2006-01-02）
+	Requests      int64              `json:"requests"`
+	Locales       []*UsageBreakdown  `json:"locales"`        // 按语言汇总，key 为空表示请求未指定语言
+	Consumers     []*UsageBreakdown  `json:"consumers"`      // 按调用方汇总
+	Channels      []*UsageBreakdown  `json:"channels"`       // 按渠道汇总
+	Daily         []*UsageDailyPoint `json:"daily"`          // 每天的拉取次数，没有拉取的日期为 0
+	UnusedLocales []string           `json:"unused_locales"` // 期间没有被单独拉取过的启用语言
+}
+
+// UsageBreakdown 按某一维度汇总的拉取次数
+type UsageBreakdown struct {
+	Key          string `json:"key"`
+	Requests     int64  `json:"requests"`
+	LastPulledAt string `json:"last_pulled_at"` // 最近一次拉取的日期（UTC，2006-01-02）
+}
+
+// UsageDailyPoint 某一天的拉取次数
+type UsageDailyPoint struct {
+	Date     string `json:"date"` // 2006-01-02
+	Requests int64  `json:"requests"`
+}
+
+// GlossaryTermParams 创建/更新术语参数
+type GlossaryTermParams struct {
+	Term          string
+	Definition    *string                     // 为 nil 时保持不变
+	CaseSensitive *bool                       // 为 nil 时保持不变，创建时默认不区分大小写
+	Translations  []GlossaryTranslationParams // 为 nil 时保持不变，否则替换全部语言的译文
+}
+
+// GlossaryTranslationParams 术语在某一语言中的译文
+type GlossaryTranslationParams struct {
+	LanguageCode string
+	Translation  string   // 规定译文
+	Forbidden    []string // 禁用译文
+}
+
+// QA 报告中的问题类型
+const (
+	QAIssueMarkupInvalid       = "markup_invalid"       // 标记无法正确解析，如标签未关闭、代码块未闭合
+	QAIssueDisallowedMarkup    = "disallowed_markup"    // 包含不允许的标签、属性或链接协议
+	QAIssueLinkMismatch        = "link_mismatch"        // 链接与源语言不一致
+	QAIssuePlaceholderMismatch = "placeholder_mismatch" // 占位符与源语言不一致
+	QAIssueGlossaryMismatch    = "glossary_mismatch"    // 原文包含术语，译文没有使用规定译文
+	QAIssueForbiddenTerm       = "forbidden_term"       // 译文包含术语表中禁用的译文
+	QAIssueTagMismatch         = "tag_mismatch"         // 文本中的 HTML 标签与源语言不一致
+	QAIssueTrailingWhitespace  = "trailing_whitespace"  // 末尾空白与源语言不一致
+	QAIssueLengthExceeded      = "length_exceeded"      // 译文长度超过原文的指定倍数
+	QAIssueICUInvalid          = "icu_invalid"          // 包含 plural、select 等参数，但不是合法的 ICU 消息
+	QAIssuePluralCategory      = "plural_category"      // 复数选项与语言的 CLDR 复数类别不符
+	QAIssueSelectMismatch      = "select_mismatch"      // select 参数的选项与源语言不一致
+)
+
+// QAReportQuery QA 报告的筛选条件，字段为空时不限制
+type QAReportQuery struct {
+	Language       string  // 语言代码
+	Type           string  // 问题类型
+	MaxLengthRatio float64 // 译文长度最多为原文的倍数，为 0 时使用默认值
+}
+
+// QAReport 项目的翻译质量检查报告
+type QAReport struct {
+	SourceLanguage string         `json:"source_language"` // 比较链接和占位符时使用的源语言（默认语言），为空时不做比较
+	Keys           int            `json:"keys"`            // 检查的键数量
+	Translations   int            `json:"translations"`    // 检查的翻译数量
+	Counts         map[string]int `json:"counts"`          // 按问题类型统计的问题数量
+	Issues         []QAIssue      `json:"issues"`
+	Truncated      bool           `json:"truncated"` // 问题过多时只列出前面的部分，counts 仍为全部数量
+}
+
+// QAIssue QA 报告中的一个问题
+type QAIssue struct {
+	KeyName       string `json:"key_name"`
+	Language      string `json:"language"`
+	TranslationID uint64 `json:"translation_id"`
+	Type          string `json:"type"`
+	Message       string `json:"message"`
+	Suggestion    string `json:"suggestion,omitempty"` // disallowed_markup 为移除不允许的内容后的译文，forbidden_term 为替换为规定译文后的译文，trailing_whitespace 为改用源语言末尾空白后的译文
+}
+
+// ICU 消息元素的类型
+const (
+	ICUElementText          = "text"          // 普通文本
+	ICUElementArgument      = "argument"      // 简单参数，如 {name}、{count, number}
+	ICUElementPound         = "pound"         // 复数选项中的 #，表示参数的值
+	ICUElementPlural        = "plural"        // 基数复数参数
+	ICUElementSelectOrdinal = "selectordinal" // 序数复数参数
+	ICUElementSelect        = "select"        // 选择参数
+)
+
+// ICUElement ICU 消息的一个元素
+type ICUElement struct {
+	Type    string      `json:"type"`
+	Value   string      `json:"value,omitempty"`   // text 的文本（已去除引用）
+	Name    string      `json:"name,omitempty"`    // 参数名
+	Format  string      `json:"format,omitempty"`  // 简单参数的类型，如 number、date
+	Style   string      `json:"style,omitempty"`   // 简单参数的样式，如 percent、short
+	Offset  int         `json:"offset,omitempty"`  // plural 和 selectordinal 的 offset
+	Options []ICUOption `json:"options,omitempty"` // plural、selectordinal 和 select 的选项
+}
+
+// ICUOption 复杂参数的一个选项
+type ICUOption struct {
+	Selector string       `json:"selector"` // 复数类别（如 one、other）、=N 或 select 的值
+	Message  []ICUElement `json:"message"`
+}
+
+// ICUMessageInfo ICU 消息的解析结果，供结构化编辑
+type ICUMessageInfo struct {
+	Valid             bool         `json:"valid"`
+	Error             string       `json:"error,omitempty"`
+	ErrorOffset       *int         `json:"error_offset,omitempty"` // 出错位置（字符序号，从 0 开始）
+	Elements          []ICUElement `json:"elements"`
+	Language          string       `json:"language,omitempty"`
+	PluralCategories  []string     `json:"plural_categories"`  // 语言的 CLDR 基数复数类别
+	OrdinalCategories []string     `json:"ordinal_categories"` // 语言的 CLDR 序数复数类别
+	Issues            []QAIssue    `json:"issues"`             // 复数选项与语言的复数类别不符等问题
+}
+
+// ConsistencyReport 项目各语言的术语一致性
+// 默认语言中文本相同的多个键视为同一术语，译文相同的比例为一致性得分
+type ConsistencyReport struct {
+	SourceLanguage string                `json:"source_language"`
+	Terms          int                   `json:"terms"` // 默认语言中被多个键使用的文本数量
+	Languages      []LanguageConsistency `json:"languages"`
+}
+
+// LanguageConsistency 单个语言的术语一致性
+type LanguageConsistency struct {
+	LanguageCode      string  `json:"language_code"`
+	Terms             int     `json:"terms"`              // 至少有两个键已翻译、参与比较的术语数量
+	InconsistentTerms int     `json:"inconsistent_terms"` // 译文不一致的术语数量
+	Occurrences       int     `json:"occurrences"`        // 参与比较的译文数量
+	Consistent        int     `json:"consistent"`         // 与建议译文相同的译文数量
+	Score             float64 `json:"score"`              // 一致性得分（百分比），没有可比较的术语时为 100
+}
+
+// ConsistencyDetail 单个语言中译文不一致的术语
+type ConsistencyDetail struct {
+	SourceLanguage string             `json:"source_language"`
+	LanguageCode   string             `json:"language_code"`
+	Score          float64            `json:"score"`
+	Terms          []InconsistentTerm `json:"terms"`
+	Truncated      bool               `json:"truncated"` // 不一致的术语过多时只列出前面的部分
+}
+
+// InconsistentTerm 译文不一致的术语
+type InconsistentTerm struct {
+	Source       string            `json:"source"`       // 默认语言的文本
+	Suggestion   string            `json:"suggestion"`   // 建议统一使用的译文（使用最多的译文）
+	Translations []TermTranslation `json:"translations"` // 各种译文及使用它的键，按使用次数从多到少排序
+}
+
+// TermTranslation 术语的一种译文
+type TermTranslation struct {
+	Value string   `json:"value"`
+	Keys  []string `json:"keys"`
+}
+
+// AutoTranslateParams 使用机器翻译填充缺失翻译的参数
+type AutoTranslateParams struct {
+	KeyNames        []string // 为空时处理项目中的所有键
+	SourceLanguage  string   // 为空时使用默认语言
+	TargetLanguages []string
+}
+
+// AutoTranslateResult 使用机器翻译填充缺失翻译的结果
+type AutoTranslateResult struct {
+	SourceLanguage string                        `json:"source_language"`
+	Translated     int                           `json:"translated"`
+	Failed         int                           `json:"failed"`
+	Languages      []AutoTranslateLanguageResult `json:"languages"`
+}
+
+// AutoTranslateLanguageResult 单个目标语言的填充结果
+type AutoTranslateLanguageResult struct {
+	LanguageCode string `json:"language_code"`
+	Translated   int    `json:"translated"`
+	Failed       int    `json:"failed"`  // 机器翻译没有返回结果的键数量
+	Skipped      int    `json:"skipped"` // 缺少翻译但源语言没有文本、键不存在或值类型为 json 的键数量
+}
+
+// PreTranslateParams 项目预翻译参数
+type PreTranslateParams struct {
+	SourceLanguage  string   // 为空时使用默认语言
+	TargetLanguages []string // 需要预翻译的语言，只处理明确选择的语言
+}
+
+// PreTranslateResult 项目预翻译结果
+type PreTranslateResult struct {
+	SourceLanguage string                       `json:"source_language"`
+	Filled         int                          `json:"filled"`
+	Failed         int                          `json:"failed"`
+	Skipped        int                          `json:"skipped"`
+	Languages      []PreTranslateLanguageResult `json:"languages"`
+}
+
+// PreTranslateLanguageResult 单个目标语言的预翻译结果
+type PreTranslateLanguageResult struct {
+	LanguageCode string   `json:"language_code"`
+	Missing      int      `json:"missing"` // 目标语言中没有翻译的键数量
+	Filled       int      `json:"filled"`
+	Failed       int      `json:"failed"`  // 服务商调用失败或没有返回结果的键数量
+	Skipped      int      `json:"skipped"` // 源语言没有文本或值类型为 json 的键数量
+	FilledKeys   []string `json:"filled_keys"`
+	FailedKeys   []string `json:"failed_keys"`
+	Errors       []string `json:"errors,omitempty"` // 服务商返回的错误（去重）
+}
+
+// CreateInvitationBatchParams 批量创建邀请码参数
+type CreateInvitationBatchParams struct {
+	Count         int                   // 邀请码数量，指定邮箱时可以为 0
+	Emails        []string              // 为每个邮箱创建一个邀请码并发送邀请邮件，角色为 Role
+	Recipients    []InvitationRecipient // 为每个收件人创建一个邀请码，可分别指定角色，与 Emails 合并
+	SkipEmail     bool                  // 只在邀请码上记录邮箱，不发送邀请邮件
+	Role          string                // 收件人未指定角色时的默认角色
+	ExpiresInDays int
+	Description   string
+	BatchLabel    string // 为空时按创建时间生成
+}
+
+// InvitationRecipient 批量邀请的收件人
+type InvitationRecipient struct {
+	Email string
+	Role  string // 为空时使用批次的角色
+}
+
+// InvitationBatchResult 批量创建邀请码结果
+type InvitationBatchResult struct {
+	BatchLabel  string                `json:"batch_label"`
+	Emailed     int                   `json:"emailed"`      // 邀请邮件发送成功的数量
+	EmailFailed int                   `json:"email_failed"` // 邀请邮件发送失败的数量，邀请码仍然有效
+	Invitations []InvitationBatchItem `json:"invitations"`
+}
+
+// InvitationBatchItem 批量创建的单个邀请码
+type InvitationBatchItem struct {
+	Code          string    `json:"code"`
+	InvitationURL string    `json:"invitation_url"`
+	Email         string    `json:"email,omitempty"`
+	Role          string    `json:"role"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	EmailStatus   string    `json:"email_status,omitempty"` // sent 或 failed，没有邮箱时为空
+}
+
+// 邀请邮件发送状态
+const (
+	InvitationEmailSent   = "sent"
+	InvitationEmailFailed = "failed"
+)
+
+// OffboardUserParams 用户离职处理参数
+type OffboardUserParams struct {
+	ReassignTo uint64 // 接收内容引用和项目所有权的用户，为 0 时引用置为 0（匿名）
+	Anonymize  bool   // 同时匿名化翻译变更历史和审计日志中的操作人
+	Reason     string
+}
+
+// OffboardUserResult 用户离职处理结果
+type OffboardUserResult struct {
+	UserID               uint64           `json:"user_id"`
+	ReassignedTo         uint64           `json:"reassigned_to"` // 0 表示内容引用已匿名
+	Anonymized           bool             `json:"anonymized"`
+	References           map[string]int64 `json:"references"`             // 按“表.字段”统计改写的行数
+	MembershipsRemoved   int64            `json:"memberships_removed"`    // 删除的项目成员关系数量
+	OwnershipTransferred []uint64         `json:"ownership_transferred"`  // 所有权转给 reassigned_to 的项目
+	ProjectsWithoutOwner []uint64         `json:"projects_without_owner"` // 移除后没有其他 owner 的项目，需要管理员指定
+	AccessTokensRevoked  int64            `json:"access_tokens_revoked"`
+	ActivityAnonymized   int64            `json:"activity_anonymized"` // 匿名化的翻译历史和审计日志记录数
+}
+
+// DeactivateUserParams 停用用户参数
+type DeactivateUserParams struct {
+	Reason string
+}
+
+// TransferOwnershipParams 转移用户所有权参数
+type TransferOwnershipParams struct {
+	ToUserID uint64 // 接收所有权的用户，必须是其他启用的用户
+}
+
+// OwnershipTransferResult 转移用户所有权结果
+type OwnershipTransferResult struct {
+	UserID                uint64   `json:"user_id"`
+	TransferredTo         uint64   `json:"transferred_to"`
+	Projects              []uint64 `json:"projects"`               // 所有权转给 transferred_to 的项目，原 owner 降为 editor
+	InvitationsReassigned int64    `json:"invitations_reassigned"` // 邀请人改为 transferred_to 的邀请码数量
+}
+
+// TMSuggestQuery 翻译记忆库查询参数
+type TMSuggestQuery struct {
+	Text             string
+	SourceLanguage   string  // 为空时使用默认语言
+	TargetLanguage   string
+	MinScore         float64 // 最低相似度（0-1），不大于 0 时为 0.5
+	Limit            int     // 最多返回的建议数量，不大于 0 时为 10
+	ExcludeProjectID uint64  // 与 ExcludeKey 一起排除正在编辑的键
+	ExcludeKey       string
+}
+
+// TMSuggestResult 翻译记忆库查询结果
+type TMSuggestResult struct {
+	SourceLanguage string         `json:"source_language"`
+	TargetLanguage string         `json:"target_language"`
+	Suggestions    []TMSuggestion `json:"suggestions"`
+}
+
+// TMSuggestion 一条翻译记忆建议，原文（忽略大小写和多余空白）和译文都相同的翻译合并为一条
+type TMSuggestion struct {
+	SourceText  string    `json:"source_text"`
+	TargetText  string    `json:"target_text"`
+	Score       float64   `json:"score"`       // 与查询文本的相似度，1 为完全相同
+	Occurrences int       `json:"occurrences"` // 使用这对原文和译文的键数量
+	ProjectID   uint64    `json:"project_id"`  // 最近更新的一个键
+	KeyName     string    `json:"key_name"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TMCandidateQuery 翻译记忆库候选翻译查询条件
+type TMCandidateQuery struct {
+	SourceLanguageID uint64
+	TargetLanguageID uint64
+	Statuses         []string
+	ProjectIDs       []uint64 // 为 nil 时不限项目
+	MinLength        int      // 原文的字符数范围
+	MaxLength        int
+	Terms            []string // 原文至少包含其中一个片段，为空时不限
+	Text             string   // 原文与之完全相同的候选翻译排在最前
+	ExcludeProjectID uint64
+	ExcludeKey       string
+	Limit            int
+}
+
+// TMCandidate 翻译记忆库中的一对原文和译文
+type TMCandidate struct {
+	ProjectID  uint64
+	KeyName    string
+	SourceText string
+	TargetText string
+	UpdatedAt  time.Time
+}
+
+// 翻译搜索模式
+const (
+	SearchModeExact = "exact" // 键名或译文包含查询文本（区分大小写）
+	SearchModeRegex = "regex" // 键名或译文匹配正则表达式（忽略大小写）
+	SearchModeFuzzy = "fuzzy" // 键名或译文包含查询文本中的任一单词（忽略大小写和重音），按相似度排序
+)
+
+// SearchParams 翻译搜索参数
+type SearchParams struct {
+	Query        string
+	Mode         string // exact, regex, fuzzy，为空时为 fuzzy
+	ProjectID    uint64 // 为 0 时搜索所有可访问的项目
+	Language     string // 语言代码
+	Status       string // 翻译状态：active, deprecated
+	ReviewStatus string
+	UpdatedBy    uint64
+	Tags         []string   // 只搜索带有其中任一标签的键，为空时不限标签；按名称匹配各项目中的标签
+	From         *time.Time // 更新时间范围
+	To           *time.Time
+	Limit        int
+	Offset       int
+}
+
+// SearchResult 翻译搜索结果
+type SearchResult struct {
+	Items     []SearchHit `json:"items"`
+	Total     int         `json:"total"`
+	Truncated bool        `json:"truncated"` // 匹配的翻译过多，只对搜索后端返回的前一部分排序
+}
+
+// SearchHit 一条搜索结果
+type SearchHit struct {
+	TranslationID uint64    `json:"translation_id"`
+	ProjectID     uint64    `json:"project_id"`
+	ProjectName   string    `json:"project_name"`
+	KeyName       string    `json:"key_name"`
+	LanguageCode  string    `json:"language_code"`
+	Value         string    `json:"value"`
+	Status        string    `json:"status"`
+	ReviewStatus  string    `json:"review_status"`
+	UpdatedBy     uint64    `json:"updated_by"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	MatchedField  string    `json:"matched_field"` // 相关度更高的字段：key 或 value
+	Score         float64   `json:"score"`         // 相关度（0-1），1 为键名或译文与查询文本完全相同
+}
+
+// TranslationSearchQuery 翻译搜索候选查询条件
+type TranslationSearchQuery struct {
+	Mode         string
+	Text         string   // exact 模式为包含的文本，regex 模式为正则表达式
+	Terms        []string // fuzzy 模式下键名或译文至少包含其中一个片段
+	ProjectIDs   []uint64 // 为 nil 时不限项目
+	LanguageCode string
+	Status       string
+	ReviewStatus string
+	UpdatedBy    uint64
+	Tags         []string
+	From         *time.Time
+	To           *time.Time
+	Limit        int
+}
+
+// TranslationSearchCandidate 符合搜索条件的一条翻译
+type TranslationSearchCandidate struct {
+	TranslationID uint64
+	ProjectID     uint64
+	ProjectName   string
+	KeyName       string
+	LanguageCode  string
+	Value         string
+	Status        string
+	ReviewStatus  string
+	UpdatedBy     uint64
+	UpdatedAt     time.Time
 }
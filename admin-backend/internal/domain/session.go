@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Session 登录会话
+// 每次密码登录或单点登录创建一个会话，访问令牌和刷新令牌的 jti 为会话ID，刷新令牌时沿用原会话
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     uint64    `json:"user_id"`
+	UserAgent  string    `json:"user_agent"` // 登录时的 User-Agent，用于识别设备
+	IP         string    `json:"ip"`         // 最近一次请求的来源 IP
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"` // 刷新令牌的过期时间，之后会话不能再续期
+}
+
+// sessionContextKey 签发令牌时使用的会话ID在 context 中的键
+type sessionContextKey struct{}
+
+// WithSessionID 在 context 中指定签发令牌时写入的会话ID
+func WithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, sessionID)
+}
+
+// SessionIDFromContext 获取签发令牌时写入的会话ID，未指定时返回空字符串
+func SessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionContextKey{}).(string)
+	return sessionID
+}
+
+// ClientInfo 请求方信息，创建会话和记录最近访问时使用
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// clientInfoContextKey 请求方信息在 context 中的键
+type clientInfoContextKey struct{}
+
+// WithClientInfo 在 context 中记录请求方的 IP 和 User-Agent
+func WithClientInfo(ctx context.Context, ip, userAgent string) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey{}, ClientInfo{IP: ip, UserAgent: userAgent})
+}
+
+// ClientInfoFromContext 获取请求方信息，未记录时返回零值
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoContextKey{}).(ClientInfo)
+	return info
+}
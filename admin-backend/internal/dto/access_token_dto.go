@@ -0,0 +1,25 @@
+package dto
+
+// CreateAccessTokenRequest 创建个人访问令牌请求
+type CreateAccessTokenRequest struct {
+	Name          string `json:"name" binding:"required,max=100"`
+	ExpiresInDays int    `json:"expires_in_days" binding:"omitempty,min=1,max=365"` // 有效天数，默认 90 天
+}
+
+// AccessTokenResponse 个人访问令牌响应，不包含令牌本身
+type AccessTokenResponse struct {
+	ID          uint64 `json:"id"`
+	Name        string `json:"name"`
+	TokenPrefix string `json:"token_prefix"` // 令牌开头的几位，用于识别令牌
+	ExpiresAt   string `json:"expires_at"`
+	Expired     bool   `json:"expired"`
+	LastUsedAt  string `json:"last_used_at,omitempty"`
+	LastUsedIP  string `json:"last_used_ip,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// CreateAccessTokenResponse 创建个人访问令牌响应
+type CreateAccessTokenResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"` // 明文令牌，只在创建时返回一次
+}
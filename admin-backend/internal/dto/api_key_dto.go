@@ -0,0 +1,28 @@
+package dto
+
+// CreateAPIKeyRequest 创建 API Key 请求
+type CreateAPIKeyRequest struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	Scope      string   `json:"scope" binding:"omitempty,oneof=read write"` // read 只能拉取翻译，write 还可以推送键，默认 read
+	ProjectIDs []uint64 `json:"project_ids" binding:"omitempty,max=50"`     // 可访问的项目，为空时可访问所有项目
+}
+
+// APIKeyResponse API Key 响应，不包含 API Key 本身
+type APIKeyResponse struct {
+	ID             uint64   `json:"id"`
+	Name           string   `json:"name"`
+	KeyPrefix      string   `json:"key_prefix"` // API Key 开头的几位，用于识别
+	Scope          string   `json:"scope"`
+	ProjectIDs     []uint64 `json:"project_ids"`               // 为空时可访问所有项目（组织的 API Key 为组织的所有项目）
+	OrganizationID uint64   `json:"organization_id,omitempty"` // 所属组织，组织的 API Key 只能访问该组织的项目
+	LastUsedAt     string   `json:"last_used_at,omitempty"`
+	LastUsedIP     string   `json:"last_used_ip,omitempty"`
+	CreatedBy      uint64   `json:"created_by"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+// CreateAPIKeyResponse 创建 API Key 响应
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"` // 明文 API Key，只在创建时返回一次
+}
@@ -0,0 +1,21 @@
+package dto
+
+// AssignRequest 分配任务请求
+type AssignRequest struct {
+	UserID       uint64   `json:"user_id" binding:"required"`
+	LanguageCode string   `json:"language_code" binding:"required"`
+	KeyNames     []string `json:"key_names" binding:"max=1000"`                   // 为空时分配该语言的所有键
+	Type         string   `json:"type" binding:"required,oneof=translate review"` // translate 翻译，review 审核
+}
+
+// AssignmentResponse 任务分配响应
+type AssignmentResponse struct {
+	ID           uint64 `json:"id"`
+	UserID       uint64 `json:"user_id"`
+	Username     string `json:"username"`
+	LanguageCode string `json:"language_code"`
+	KeyName      string `json:"key_name,omitempty"` // 为空时分配该语言的所有键
+	Type         string `json:"type"`
+	UpdatedBy    uint64 `json:"updated_by"`
+	UpdatedAt    string `json:"updated_at"`
+}
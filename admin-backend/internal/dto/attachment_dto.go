@@ -0,0 +1,13 @@
+package dto
+
+// AttachmentResponse 附件响应
+type AttachmentResponse struct {
+	ID          uint64 `json:"id"`
+	KeyName     string `json:"key_name"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"` // 附件内容的访问地址，需要认证
+	CreatedBy   uint64 `json:"created_by"`
+	CreatedAt   string `json:"created_at"`
+}
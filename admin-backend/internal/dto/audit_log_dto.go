@@ -0,0 +1,19 @@
+package dto
+
+import "encoding/json"
+
+// AuditLogResponse 审计日志响应
+type AuditLogResponse struct {
+	ID        uint64          `json:"id"`
+	ProjectID uint64          `json:"project_id"`
+	UserID    uint64          `json:"user_id"`
+	Action    string          `json:"action"`
+	Details   json.RawMessage `json:"details" swaggertype:"object"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// AuditLogListResponse 审计日志列表响应
+type AuditLogListResponse struct {
+	Logs  []*AuditLogResponse `json:"logs"`
+	Total int64               `json:"total"`
+}
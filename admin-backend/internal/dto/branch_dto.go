@@ -0,0 +1,53 @@
+package dto
+
+// BranchRequest 创建分支请求
+type BranchRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// BranchTranslationItem 分支中的一条翻译修改
+type BranchTranslationItem struct {
+	KeyName      string `json:"key_name" binding:"required,max=255"`
+	LanguageCode string `json:"language_code" binding:"required"`
+	Value        string `json:"value"`
+	Deleted      bool   `json:"deleted"` // 在分支中删除该翻译，忽略 value
+}
+
+// SetBranchTranslationsRequest 修改分支中的翻译请求
+type SetBranchTranslationsRequest struct {
+	Translations []BranchTranslationItem `json:"translations" binding:"required,min=1,max=1000,dive"`
+}
+
+// BranchResolutionItem 合并时一条差异的处理方式
+type BranchResolutionItem struct {
+	Key        string `json:"key" binding:"required"`
+	Language   string `json:"language" binding:"required"`
+	Resolution string `json:"resolution" binding:"required,oneof=branch main custom"`
+	Value      string `json:"value"` // resolution 为 custom 时写入主线的值
+}
+
+// BranchMergeRequest 合并分支请求
+type BranchMergeRequest struct {
+	Resolutions []BranchResolutionItem `json:"resolutions" binding:"dive"`
+}
+
+// BranchResponse 分支响应
+type BranchResponse struct {
+	ID          uint64 `json:"id"`
+	ProjectID   uint64 `json:"project_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"` // open, merged
+	CreatedBy   uint64 `json:"created_by"`
+	MergedBy    uint64 `json:"merged_by,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	MergedAt    string `json:"merged_at,omitempty"`
+}
+
+// BranchMergeResponse 合并分支响应
+type BranchMergeResponse struct {
+	Branch  *BranchResponse           `json:"branch"`
+	Applied []PromotionChangeResponse `json:"applied"` // 写入主线的修改
+	Skipped int                       `json:"skipped"` // 保留主线值的差异数量
+}
@@ -0,0 +1,30 @@
+package dto
+
+// BulkDeleteFilterRequest 按条件批量删除的筛选条件，至少需要指定一个
+type BulkDeleteFilterRequest struct {
+	KeyPrefix string `json:"key_prefix" binding:"max=255"`                       // 键名前缀，如 checkout. 或 checkout.*
+	Language  string `json:"language" binding:"max=10"`                          // 语言代码
+	Status    string `json:"status" binding:"omitempty,oneof=active deprecated"` // 翻译状态
+	OlderThan string `json:"older_than"`                                         // 最后修改时间早于该时间（RFC3339）
+}
+
+// BulkDeleteRequest 按条件批量删除请求，筛选条件需要与预览时一致
+type BulkDeleteRequest struct {
+	BulkDeleteFilterRequest
+	ConfirmationToken string `json:"confirmation_token" binding:"required"` // 预览返回的确认令牌
+}
+
+// BulkDeleteResponse 按条件批量删除的预览或执行结果
+type BulkDeleteResponse struct {
+	KeyPrefix         string   `json:"key_prefix,omitempty"`
+	Language          string   `json:"language,omitempty"`
+	Status            string   `json:"status,omitempty"`
+	OlderThan         string   `json:"older_than,omitempty"`
+	Keys              int64    `json:"keys"`                         // 匹配的键数量
+	Translations      int64    `json:"translations"`                 // 匹配的翻译条数
+	SampleKeys        []string `json:"sample_keys,omitempty"`        // 部分匹配的键（预览）
+	ConfirmationToken string   `json:"confirmation_token,omitempty"` // 确认令牌（预览）
+	ExpiresAt         string   `json:"expires_at,omitempty"`         // 确认令牌的过期时间（预览）
+	Deleted           int64    `json:"deleted"`                      // 已删除的翻译条数
+	Batches           int      `json:"batches"`                      // 执行删除的事务批次数
+}
@@ -0,0 +1,30 @@
+package dto
+
+// ComplianceUserActivityResponse 用户在一个月内对项目的操作次数
+type ComplianceUserActivityResponse struct {
+	UserID   uint64           `json:"user_id"` // 0 表示系统或 CLI
+	Username string           `json:"username"`
+	Changes  map[string]int64 `json:"changes"` // 翻译变更次数，按操作类型
+	Actions  map[string]int64 `json:"actions"` // 审计日志记录的操作次数，按操作类型
+	Exports  int64            `json:"exports"` // 导出数据的次数
+}
+
+// ComplianceMonthResponse 单个项目单月的用户操作汇总
+type ComplianceMonthResponse struct {
+	Month string                            `json:"month" example:"2026-01"`
+	Users []*ComplianceUserActivityResponse `json:"users"`
+}
+
+// ComplianceProjectResponse 单个项目的合规报告
+type ComplianceProjectResponse struct {
+	ProjectID   uint64                     `json:"project_id"`
+	ProjectName string                     `json:"project_name"`
+	Months      []*ComplianceMonthResponse `json:"months"`
+}
+
+// ComplianceReportResponse 合规报告响应
+type ComplianceReportResponse struct {
+	From     string                       `json:"from" example:"2026-01"`
+	To       string                       `json:"to" example:"2026-06"`
+	Projects []*ComplianceProjectResponse `json:"projects"`
+}
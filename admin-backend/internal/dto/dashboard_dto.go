@@ -2,8 +2,9 @@ package dto
 
 // DashboardStats 仪表板统计
 type DashboardStats struct {
-	TotalProjects     int `json:"total_projects"`
-	TotalLanguages    int `json:"total_languages"`
-	TotalTranslations int `json:"total_translations"`
-	TotalKeys         int `json:"total_keys"`
+	TotalProjects        int `json:"total_projects"`
+	TotalLanguages       int `json:"total_languages"`
+	TotalTranslations    int `json:"total_translations"`
+	TotalKeys            int `json:"total_keys"`
+	OutdatedTranslations int `json:"outdated_translations"` // 源语言译文修改后等待重新翻译的译文数
 }
@@ -0,0 +1,26 @@
+package dto
+
+import "encoding/json"
+
+// DeadLetterResponse 死信事件
+type DeadLetterResponse struct {
+	ID        uint64          `json:"id"`
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	ProjectID uint64          `json:"project_id"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error"`
+	CreatedAt string          `json:"created_at"`
+	Event     json.RawMessage `json:"event" swaggertype:"object"` // 完整的事件内容
+}
+
+// RequeueDeadLettersRequest 重新投递死信事件请求
+type RequeueDeadLettersRequest struct {
+	IDs []uint64 `json:"ids" binding:"max=100"`
+	All bool     `json:"all"` // 重新投递全部死信事件，此时忽略 ids
+}
+
+// RequeueDeadLettersResponse 重新投递死信事件结果
+type RequeueDeadLettersResponse struct {
+	Requeued int64 `json:"requeued"` // 重新投递的数量，已被重新投递或不存在的事件不计入
+}
@@ -0,0 +1,20 @@
+package dto
+
+// FigmaLayerRequest 画框中引用翻译键的文本图层
+type FigmaLayerRequest struct {
+	NodeID  string `json:"node_id" binding:"required"`
+	Name    string `json:"name"`
+	Key     string `json:"key" binding:"required"`
+	Text    string `json:"text"`    // 图层中的文本，键不存在时作为翻译创建
+	Context string `json:"context"` // 新建键时写入的上下文说明
+}
+
+// FigmaFrameRequest 同步画框请求
+type FigmaFrameRequest struct {
+	FileKey  string              `json:"file_key" binding:"required"`
+	FileName string              `json:"file_name"`
+	NodeID   string              `json:"node_id" binding:"required"`
+	Name     string              `json:"name"`
+	Language string              `json:"language"` // 图层文本所属的语言代码，为空时为默认语言
+	Layers   []FigmaLayerRequest `json:"layers" binding:"dive"`
+}
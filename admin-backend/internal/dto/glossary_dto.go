@@ -0,0 +1,36 @@
+package dto
+
+// GlossaryTermRequest 创建/更新术语请求
+type GlossaryTermRequest struct {
+	Term          string                       `json:"term" binding:"omitempty,max=255"`
+	Definition    *string                      `json:"definition" binding:"omitempty,max=2000"`
+	CaseSensitive *bool                        `json:"case_sensitive"`
+	Translations  []GlossaryTranslationRequest `json:"translations" binding:"omitempty,max=100,dive"` // 提供时替换全部语言的译文
+}
+
+// GlossaryTranslationRequest 术语在某一语言中的规定译文和禁用译文
+type GlossaryTranslationRequest struct {
+	LanguageCode string   `json:"language_code" binding:"required"`
+	Translation  string   `json:"translation" binding:"max=255"`
+	Forbidden    []string `json:"forbidden" binding:"max=20"`
+}
+
+// GlossaryTermResponse 术语响应
+type GlossaryTermResponse struct {
+	ID            uint64                        `json:"id"`
+	ProjectID     uint64                        `json:"project_id"`
+	Term          string                        `json:"term"`
+	Definition    string                        `json:"definition"`
+	CaseSensitive bool                          `json:"case_sensitive"`
+	Translations  []GlossaryTranslationResponse `json:"translations"`
+	UpdatedBy     uint64                        `json:"updated_by"`
+	CreatedAt     string                        `json:"created_at"`
+	UpdatedAt     string                        `json:"updated_at"`
+}
+
+// GlossaryTranslationResponse 术语在某一语言中的译文
+type GlossaryTranslationResponse struct {
+	LanguageCode string   `json:"language_code"`
+	Translation  string   `json:"translation"`
+	Forbidden    []string `json:"forbidden"`
+}
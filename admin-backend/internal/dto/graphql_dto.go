@@ -0,0 +1,14 @@
+package dto
+
+// GraphQLRequest GraphQL 查询请求，由 GraphQL 服务解析，仅用于接口文档
+type GraphQLRequest struct {
+	Query         string                 `json:"query" example:"{ project(id: \"web\") { name languages { code } matrix(pageSize: 20) { total rows { keyName cells { languageCode value } } } } }"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse GraphQL 查询结果，errors[].extensions.code 为与 REST 接口一致的错误码
+type GraphQLResponse struct {
+	Data   map[string]interface{}   `json:"data"`
+	Errors []map[string]interface{} `json:"errors,omitempty"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+// ImportProfileRequest 创建/更新表格导入映射配置请求
+type ImportProfileRequest struct {
+	Name            string            `json:"name" binding:"omitempty,max=100"`
+	HeaderRow       int               `json:"header_row" binding:"omitempty,min=1,max=100"`
+	KeyColumn       string            `json:"key_column" binding:"omitempty,max=100"`
+	ContextColumn   *string           `json:"context_column" binding:"omitempty,max=100"` // 空字符串表示不导入上下文
+	LanguageColumns map[string]string `json:"language_columns"`                           // 表头 -> 语言代码
+}
+
+// ImportProfileResponse 表格导入映射配置响应
+type ImportProfileResponse struct {
+	ID              uint64            `json:"id"`
+	ProjectID       uint64            `json:"project_id"`
+	Name            string            `json:"name"`
+	HeaderRow       int               `json:"header_row"`
+	KeyColumn       string            `json:"key_column"`
+	ContextColumn   string            `json:"context_column"`
+	LanguageColumns map[string]string `json:"language_columns"`
+	CreatedAt       string            `json:"created_at"`
+	UpdatedAt       string            `json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+// InboundWebhookRequest 创建/更新入站 Webhook 请求
+type InboundWebhookRequest struct {
+	Name              string            `json:"name" binding:"omitempty,max=100"`
+	Provider          string            `json:"provider" binding:"omitempty,oneof=generic crowdin lokalise"`
+	ExternalProjectID string            `json:"external_project_id" binding:"omitempty,max=100"`
+	LanguageMapping   map[string]string `json:"language_mapping"`
+	ConflictStrategy  string            `json:"conflict_strategy" binding:"omitempty,oneof=overwrite skip fill_empty"`
+	Status            string            `json:"status" binding:"omitempty,oneof=active disabled"`
+}
+
+// InboundWebhookResponse 入站 Webhook 响应
+type InboundWebhookResponse struct {
+	ID                uint64            `json:"id"`
+	ProjectID         uint64            `json:"project_id"`
+	Name              string            `json:"name"`
+	Provider          string            `json:"provider"`
+	ExternalProjectID string            `json:"external_project_id"`
+	LanguageMapping   map[string]string `json:"language_mapping"`
+	ConflictStrategy  string            `json:"conflict_strategy"`
+	Status            string            `json:"status"`
+	URL               string            `json:"url"`              // 对方系统需要配置的推送地址
+	Secret            string            `json:"secret,omitempty"` // 仅在创建和重置密钥时返回
+	CreatedAt         string            `json:"created_at"`
+	UpdatedAt         string            `json:"updated_at"`
+}
+
+// InboundWebhookLogResponse 入站导入日志响应
+type InboundWebhookLogResponse struct {
+	ID        uint64 `json:"id"`
+	WebhookID uint64 `json:"webhook_id"`
+	Status    string `json:"status"`
+	Received  int    `json:"received"`
+	Created   int    `json:"created"`
+	Updated   int    `json:"updated"`
+	Skipped   int    `json:"skipped"`
+	Message   string `json:"message,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// InboundWebhookLogListResponse 入站导入日志列表响应
+type InboundWebhookLogListResponse struct {
+	Logs  []*InboundWebhookLogResponse `json:"logs"`
+	Total int64                        `json:"total"`
+}
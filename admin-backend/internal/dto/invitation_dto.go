@@ -5,6 +5,8 @@ type CreateInvitationRequest struct {
 	Role           string `json:"role" binding:"omitempty,oneof=admin member viewer"`
 	ExpiresInDays  int    `json:"expires_in_days"`
 	Description    string `json:"description"`
+	Email          string `json:"email" binding:"omitempty,email"`              // 不为空时向该邮箱发送邀请邮件
+	MaxUses        int    `json:"max_uses" binding:"omitempty,min=1,max=1000"` // 最多可使用的次数，默认 1；大于 1 时可作为团队邀请链接
 }
 
 // CreateInvitationResponse 创建邀请响应
@@ -14,6 +16,33 @@ type CreateInvitationResponse struct {
 	Role          string `json:"role"`
 	ExpiresAt     string `json:"expires_at"`
 	Description   string `json:"description,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailStatus   string `json:"email_status,omitempty"` // 邀请邮件的发送结果：sent、failed
+	MaxUses       int    `json:"max_uses"`
+}
+
+// CreateInvitationBatchRequest 批量创建邀请请求
+type CreateInvitationBatchRequest struct {
+	Count         int                          `json:"count" binding:"omitempty,min=1,max=200"`            // 邀请码数量，指定 emails 或 recipients 时可省略
+	Emails        []string                     `json:"emails" binding:"omitempty,max=200,dive,email"`      // 为每个邮箱创建一个邀请码并发送邀请邮件
+	Recipients    []InvitationRecipientRequest `json:"recipients" binding:"omitempty,max=200,dive"`        // 为每个收件人创建一个邀请码，可分别指定角色
+	SkipEmail     bool                         `json:"skip_email"`                                         // 只记录邮箱，不发送邀请邮件
+	Role          string                       `json:"role" binding:"omitempty,oneof=admin member viewer"` // 默认角色
+	ExpiresInDays int                          `json:"expires_in_days"`
+	Description   string                       `json:"description"`
+	BatchLabel    string                       `json:"batch_label" binding:"omitempty,max=100"` // 为空时按创建时间生成
+}
+
+// InvitationRecipientRequest 批量邀请的收件人
+type InvitationRecipientRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=admin member viewer"` // 为空时使用 role
+}
+
+// RevokeInvitationBatchResponse 撤销邀请批次响应
+type RevokeInvitationBatchResponse struct {
+	BatchLabel string `json:"batch_label"`
+	Revoked    int64  `json:"revoked"` // 撤销的有效邀请码数量
 }
 
 // InvitationInviter 邀请人信息
@@ -33,9 +62,13 @@ type InvitationResponse struct {
 	Role        string             `json:"role"`
 	Status      string             `json:"status"`
 	ExpiresAt   string             `json:"expires_at"`
-	UsedAt      *string            `json:"used_at,omitempty"`
-	UsedBy      *uint64            `json:"used_by,omitempty"`
+	MaxUses     int                `json:"max_uses"`
+	UseCount    int                `json:"use_count"`
+	UsedAt      *string            `json:"used_at,omitempty"` // 最近一次使用的时间
+	UsedBy      *uint64            `json:"used_by,omitempty"` // 最近一次使用的用户
 	Description string             `json:"description,omitempty"`
+	BatchLabel  string             `json:"batch_label,omitempty"`
+	Email       string             `json:"email,omitempty"`
 	CreatedAt   string             `json:"created_at"`
 }
 
@@ -47,11 +80,12 @@ type InvitationListResponse struct {
 
 // ValidateInvitationResponse 验证邀请响应
 type ValidateInvitationResponse struct {
-	Valid     bool               `json:"valid"`
-	Inviter   *InvitationInviter `json:"inviter,omitempty"`
-	Role      string             `json:"role"`
-	ExpiresAt string             `json:"expires_at"`
-	Message   string             `json:"message,omitempty"`
+	Valid         bool               `json:"valid"`
+	Inviter       *InvitationInviter `json:"inviter,omitempty"`
+	Role          string             `json:"role"`
+	ExpiresAt     string             `json:"expires_at"`
+	RemainingUses int                `json:"remaining_uses,omitempty"` // 邀请码剩余的使用次数
+	Message       string             `json:"message,omitempty"`
 }
 
 // RegisterWithInvitationRequest 使用邀请码注册请求
@@ -0,0 +1,48 @@
+package dto
+
+// KeyPrefixRequest 按键名前缀批量删除请求
+type KeyPrefixRequest struct {
+	Prefix  string `json:"prefix" binding:"required,max=255"` // 键名前缀，如 checkout. 或 checkout.*
+	Preview bool   `json:"preview"`                           // 为 true 时只返回受影响的键，不执行操作
+}
+
+// KeyPrefixStatusRequest 按键名前缀批量修改状态请求
+type KeyPrefixStatusRequest struct {
+	Prefix  string `json:"prefix" binding:"required,max=255"`
+	Status  string `json:"status" binding:"required,oneof=active deprecated"`
+	Preview bool   `json:"preview"`
+}
+
+// KeyPrefixRenameRequest 按键名前缀批量重命名请求
+type KeyPrefixRenameRequest struct {
+	Prefix    string `json:"prefix" binding:"required,max=255"`
+	NewPrefix string `json:"new_prefix" binding:"required,max=255"`
+	Preview   bool   `json:"preview"`
+}
+
+// KeyPrefixResponse 按键名前缀批量操作响应
+type KeyPrefixResponse struct {
+	Prefix       string                       `json:"prefix"`
+	NewPrefix    string                       `json:"new_prefix,omitempty"`
+	Status       string                       `json:"status,omitempty"`
+	Preview      bool                         `json:"preview"`
+	Keys         int                          `json:"keys"`                // 匹配的键数量
+	Translations int64                        `json:"translations"`        // 受影响的翻译条数
+	SampleKeys   []string                     `json:"sample_keys"`         // 部分匹配的键
+	Conflicts    []string                     `json:"conflicts,omitempty"` // 重命名后与已有键冲突的键
+	Data         map[string]map[string]string `json:"data,omitempty"`      // 导出的翻译：键名 -> 语言代码 -> 翻译值
+}
+
+// KeyRenameRequest 重命名单个键请求
+type KeyRenameRequest struct {
+	KeyName    string `json:"key_name" binding:"required,max=255"`
+	NewKeyName string `json:"new_key_name" binding:"required,max=255"`
+}
+
+// KeyCopyRequest 从其他项目复制键请求
+type KeyCopyRequest struct {
+	SourceProjectID uint64   `json:"source_project_id" binding:"required"`
+	KeyNames        []string `json:"key_names" binding:"required,min=1,max=1000,dive,required,max=255"`
+	Overwrite       bool     `json:"overwrite"` // 项目中已有的键是否覆盖，默认跳过
+	Link            bool     `json:"link"`      // 是否保持同步：源项目中的翻译新增或修改后写入项目
+}
@@ -13,3 +13,16 @@ type AutoFillLanguageResponse struct {
 	FailedCount  int    `json:"failed_count"`
 	Message      string `json:"message"`
 }
+
+// MachineTranslateRequest 使用机器翻译填充缺失翻译请求
+type MachineTranslateRequest struct {
+	KeyNames        []string `json:"key_names" binding:"max=1000"`                     // 为空时处理项目中的所有键
+	SourceLanguage  string   `json:"source_language"`                                  // 语言代码，为空时使用默认语言
+	TargetLanguages []string `json:"target_languages" binding:"required,min=1,max=50"` // 语言代码
+}
+
+// PreTranslateRequest 项目预翻译请求
+type PreTranslateRequest struct {
+	SourceLanguage  string   `json:"source_language"`                                  // 语言代码，为空时使用默认语言
+	TargetLanguages []string `json:"target_languages" binding:"required,min=1,max=50"` // 需要预翻译的语言代码
+}
@@ -6,6 +6,20 @@ type AddProjectMemberRequest struct {
 	Role   string `json:"role" binding:"required,oneof=owner editor viewer"`
 }
 
+// BulkAddProjectMembersRequest 批量添加项目成员请求
+type BulkAddProjectMembersRequest struct {
+	Members []BulkProjectMemberItem `json:"members" binding:"required,min=1,max=500"`
+	Role    string                  `json:"role"`   // 成员未指定角色时使用的角色
+	Notify  bool                    `json:"notify"` // 向添加的成员发送通知邮件，需要配置邮件服务
+}
+
+// BulkProjectMemberItem 批量添加的成员，按 user_id 或 email 指定用户
+type BulkProjectMemberItem struct {
+	UserID uint64 `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"` // owner、editor 或 viewer
+}
+
 // UpdateProjectMemberRequest 更新项目成员请求
 type UpdateProjectMemberRequest struct {
 	Role string `json:"role" binding:"required,oneof=owner editor viewer"`
@@ -13,9 +27,14 @@ type UpdateProjectMemberRequest struct {
 
 // ProjectMemberInfo 项目成员信息
 type ProjectMemberInfo struct {
-	ID       uint64 `json:"id"`
-	UserID   uint64 `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	ID          uint64 `json:"id"`
+	UserID      uint64 `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`         // 为空时显示用户名
+	AvatarURL   string `json:"avatar_url,omitempty"` // 没有头像时为空
+	Email       string `json:"email"`
+	Language    string `json:"language,omitempty"` // 成员的界面语言偏好
+	Timezone    string `json:"timezone,omitempty"` // 成员的时区偏好
+	Role        string `json:"role"`
+	Source      string `json:"source,omitempty"` // role_sync 表示由管理员同步自动添加
 }
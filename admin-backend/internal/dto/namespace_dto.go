@@ -0,0 +1,25 @@
+package dto
+
+// NamespaceRequest 创建/更新命名空间请求
+type NamespaceRequest struct {
+	Name        string  `json:"name" binding:"omitempty,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+}
+
+// AssignNamespaceRequest 将键归入命名空间请求，namespace 为空时移出命名空间
+type AssignNamespaceRequest struct {
+	KeyNames  []string `json:"key_names" binding:"required,min=1,max=1000"`
+	Namespace string   `json:"namespace" binding:"max=100"`
+}
+
+// NamespaceResponse 命名空间响应
+type NamespaceResponse struct {
+	ID          uint64 `json:"id"`
+	ProjectID   uint64 `json:"project_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Keys        int64  `json:"keys"` // 命名空间中的键数量
+	UpdatedBy   uint64 `json:"updated_by"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+// CreateOrganizationRequest 创建组织请求
+type CreateOrganizationRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=500"`
+}
+
+// UpdateOrganizationRequest 更新组织请求
+type UpdateOrganizationRequest struct {
+	Name        string `json:"name" binding:"max=100"` // 为空时不修改名称
+	Description string `json:"description" binding:"max=500"`
+}
+
+// OrganizationResponse 组织响应
+type OrganizationResponse struct {
+	ID          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedBy   uint64 `json:"created_by"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// AddOrganizationMemberRequest 添加组织成员请求，按 user_id 或 email 指定用户
+type AddOrganizationMemberRequest struct {
+	UserID uint64 `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role" binding:"omitempty,oneof=owner admin member"` // 默认 member
+}
+
+// UpdateOrganizationMemberRequest 更新组织成员角色请求
+type UpdateOrganizationMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member"`
+}
+
+// OrganizationMemberResponse 组织成员响应
+type OrganizationMemberResponse struct {
+	UserID   uint64 `json:"user_id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// PasswordResetRequest 申请重置密码请求
+type PasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// PasswordResetConfirmRequest 设置新密码请求
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" binding:"required"` // 重置链接中的 token 参数
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// StaleProjectResponse 闲置项目
+type StaleProjectResponse struct {
+	ID             uint64 `json:"id"`
+	Name           string `json:"name"`
+	Slug           string `json:"slug"`
+	CreatedAt      string `json:"created_at"`
+	LastChangedAt  string `json:"last_changed_at,omitempty"`  // 最近一次内容变更时间，从未变更时为空
+	LastAccessedAt string `json:"last_accessed_at,omitempty"` // 最近一次访问时间（Web 或 CLI），未记录时为空
+	IdleDays       int    `json:"idle_days"`                  // 距最近变更（从未变更时为创建时间）的天数
+}
+
+// StaleProjectListResponse 闲置项目报告
+type StaleProjectListResponse struct {
+	Days     int                     `json:"days"`   // 闲置天数
+	Before   string                  `json:"before"` // 最近变更早于该时间的项目视为闲置
+	Projects []*StaleProjectResponse `json:"projects"`
+	Total    int64                   `json:"total"`
+}
+
+// ArchiveStaleProjectsRequest 批量归档闲置项目请求
+type ArchiveStaleProjectsRequest struct {
+	ProjectIDs []uint64 `json:"project_ids" binding:"required,min=1,max=100"`
+	Days       int      `json:"days" binding:"min=0"` // 归档前按该天数重新检查是否闲置，0 表示使用默认值
+}
+
+// ArchiveStaleProjectsResponse 批量归档闲置项目结果
+type ArchiveStaleProjectsResponse struct {
+	Archived []uint64 `json:"archived"`
+	Skipped  []uint64 `json:"skipped"` // 不存在、已归档或已不再闲置的项目
+}
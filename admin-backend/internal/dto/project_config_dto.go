@@ -0,0 +1,51 @@
+package dto
+
+// ProjectConfigDocument 项目配置文档（不包含翻译内容）
+type ProjectConfigDocument struct {
+	Version   int                       `json:"version" binding:"required"` // 文档版本，当前为 1
+	Source    string                    `json:"source,omitempty"`           // 导出时的项目标识，导入时忽略
+	Settings  ProjectConfigSettingsDTO  `json:"settings"`
+	Languages []string                  `json:"languages"`
+	Webhooks  []ProjectConfigWebhookDTO `json:"webhooks"`
+	Members   []ProjectConfigMemberDTO  `json:"members"`
+}
+
+// ProjectConfigSettingsDTO 项目设置，导入时空字段不修改
+type ProjectConfigSettingsDTO struct {
+	Description        string `json:"description"`
+	Status             string `json:"status"`
+	ExportFileTemplate string `json:"export_file_template"`
+}
+
+// ProjectConfigWebhookDTO 入站 Webhook 配置（不包含签名密钥）
+type ProjectConfigWebhookDTO struct {
+	Name              string            `json:"name"`
+	Provider          string            `json:"provider"`
+	ExternalProjectID string            `json:"external_project_id"`
+	LanguageMapping   map[string]string `json:"language_mapping"`
+	ConflictStrategy  string            `json:"conflict_strategy"`
+	Status            string            `json:"status"`
+}
+
+// ProjectConfigMemberDTO 项目成员角色
+type ProjectConfigMemberDTO struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// ProjectConfigChangeDTO 导入配置产生的一项变更
+type ProjectConfigChangeDTO struct {
+	Section string `json:"section"` // settings, webhooks, members
+	Target  string `json:"target"`
+	Action  string `json:"action"` // create, update
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// ProjectConfigImportResponse 导入项目配置响应
+type ProjectConfigImportResponse struct {
+	DryRun           bool                      `json:"dry_run"`
+	Changes          []*ProjectConfigChangeDTO `json:"changes"`
+	MissingLanguages []string                  `json:"missing_languages"` // 本实例不存在或未启用的语言，需要管理员单独启用
+	UnknownUsers     []string                  `json:"unknown_users"`     // 本实例不存在的用户，已跳过
+}
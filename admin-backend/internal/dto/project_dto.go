@@ -4,11 +4,42 @@ package dto
 type CreateProjectRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	Slug        string `json:"slug"`        // 自定义项目标识，为空时根据名称生成
+	AutoSuffix  bool   `json:"auto_suffix"` // 标识冲突时自动追加 -2、-3 等后缀
+	// OrganizationID 所属组织，为空时项目不属于任何组织；需要是系统管理员或组织的 owner、admin
+	OrganizationID uint64 `json:"organization_id"`
+	SourceLanguage string `json:"source_language"` // 源语言代码，为空时使用默认语言
+}
+
+// DuplicateProjectRequest 复制项目请求
+type DuplicateProjectRequest struct {
+	Name           string `json:"name" binding:"required,max=100"`
+	Slug           string `json:"slug"`            // 新项目标识，为空时根据名称生成
+	AutoSuffix     bool   `json:"auto_suffix"`     // 标识冲突时自动追加 -2、-3 等后缀
+	Description    string `json:"description"`     // 为空时使用原项目的描述
+	IncludeMembers bool   `json:"include_members"` // 是否复制项目成员及其角色
 }
 
 // UpdateProjectRequest 更新项目请求
 type UpdateProjectRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	Name               string  `json:"name"`
+	Description        string  `json:"description"`
+	Status             string  `json:"status"`
+	ExportFileTemplate *string `json:"export_file_template"` // 导出文件命名模板，如 {locale}/{namespace}.json
+	SourceLanguage     *string `json:"source_language"`      // 源语言代码，为空字符串时改为使用默认语言
+}
+
+// RenameProjectSlugRequest 修改项目标识请求
+type RenameProjectSlugRequest struct {
+	Slug string `json:"slug" binding:"required"`
+}
+
+// SetProjectProtectionRequest 开启或关闭项目删除保护请求
+type SetProjectProtectionRequest struct {
+	Protected *bool `json:"protected" binding:"required"`
+}
+
+// SetProjectDeliveryRequest 开启或关闭项目公开分发请求
+type SetProjectDeliveryRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
 }
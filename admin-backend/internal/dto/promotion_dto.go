@@ -0,0 +1,64 @@
+package dto
+
+// PromotionDiffRequest 计算环境差异请求
+// 指定 remote_url 时从源实例拉取翻译，否则使用 snapshot
+type PromotionDiffRequest struct {
+	RemoteURL       string                       `json:"remote_url" binding:"omitempty,url,max=500"` // 源实例地址，如 https://staging.example.com
+	APIKey          string                       `json:"api_key"`                                    // 源实例的 CLI API Key，不会保存
+	SourceProject   string                       `json:"source_project" binding:"max=100"`           // 源实例中的项目ID或标识
+	Snapshot        map[string]map[string]string `json:"snapshot"`                                   // 键名 -> 语言代码 -> 翻译值
+	IncludeRemovals bool                         `json:"include_removals"`                           // 源环境中不存在的翻译计为删除
+}
+
+// PromotionApplyRequest 应用环境差异请求
+type PromotionApplyRequest struct {
+	All      bool                 `json:"all"`      // 应用全部差异
+	Approved []PromotionChangeRef `json:"approved"` // 审核通过的差异
+}
+
+// PromotionChangeRef 引用一条差异
+type PromotionChangeRef struct {
+	Key      string `json:"key" binding:"required"`
+	Language string `json:"language" binding:"required"`
+}
+
+// PromotionChangeResponse 环境差异
+type PromotionChangeResponse struct {
+	Key      string `json:"key"`
+	Language string `json:"language"`
+	Type     string `json:"type"` // added, modified, removed
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+// PromotionSummary 差异统计
+type PromotionSummary struct {
+	Added    int `json:"added"`
+	Modified int `json:"modified"`
+	Removed  int `json:"removed"`
+}
+
+// PromotionResponse 环境推送记录响应
+type PromotionResponse struct {
+	ID               uint64                     `json:"id"`
+	ProjectID        uint64                     `json:"project_id"`
+	Source           string                     `json:"source"`
+	SourceProject    string                     `json:"source_project"`
+	Status           string                     `json:"status"`
+	MissingLanguages []string                   `json:"missing_languages"` // 本实例不存在、未计入差异的语言
+	Summary          *PromotionSummary          `json:"summary,omitempty"`
+	Changes          []*PromotionChangeResponse `json:"changes,omitempty"`
+	Applied          []*PromotionChangeResponse `json:"applied,omitempty"`
+	Signature        string                     `json:"signature,omitempty"`
+	SignatureValid   bool                       `json:"signature_valid"`
+	CreatedBy        uint64                     `json:"created_by"`
+	AppliedBy        uint64                     `json:"applied_by,omitempty"`
+	CreatedAt        string                     `json:"created_at"`
+	AppliedAt        string                     `json:"applied_at,omitempty"`
+}
+
+// PromotionListResponse 环境推送记录列表响应
+type PromotionListResponse struct {
+	Promotions []*PromotionResponse `json:"promotions"`
+	Total      int64                `json:"total"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "yflow/internal/domain"
+
+// ReleaseRequest 发布版本请求
+type ReleaseRequest struct {
+	Version           string `json:"version" binding:"required,max=100"` // 版本标签，如 v1.2.0
+	Description       string `json:"description" binding:"max=500"`
+	Override          bool   `json:"override"`                                  // 未达到发布门槛时仍然发布，需要项目所有者权限
+	RolloutPercentage int    `json:"rollout_percentage" binding:"min=0,max=99"` // 灰度发布：公开分发时只有该百分比的客户端获取新版本，0 表示直接全量发布
+}
+
+// ReleaseRolloutRequest 提高灰度发布百分比请求
+type ReleaseRolloutRequest struct {
+	Percentage int `json:"percentage" binding:"required,min=1,max=100"` // 100 表示全量发布
+}
+
+// ReleaseResponse 发布版本响应
+type ReleaseResponse struct {
+	ID                uint64 `json:"id"`
+	ProjectID         uint64 `json:"project_id"`
+	Version           string `json:"version"`
+	Description       string `json:"description"`
+	KeyCount          int    `json:"key_count"`
+	TranslationCount  int    `json:"translation_count"`
+	GateOverridden    bool   `json:"gate_overridden"`
+	RolloutStatus     string `json:"rollout_status,omitempty"` // active, completed, aborted，直接全量发布时为空
+	RolloutPercentage int    `json:"rollout_percentage,omitempty"`
+	CreatedBy         uint64 `json:"created_by"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// ReleaseCreateResponse 发布版本的响应，包含发布时的门槛检查结果
+type ReleaseCreateResponse struct {
+	ReleaseResponse
+	Readiness *domain.ReleaseReadiness `json:"readiness"`
+}
+
+// ReleaseListResponse 发布版本列表响应
+type ReleaseListResponse struct {
+	Releases []*ReleaseResponse `json:"releases"`
+	Total    int64              `json:"total"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// ReleaseThresholdItem 单个语言的发布门槛
+type ReleaseThresholdItem struct {
+	LanguageCode  string `json:"language_code" binding:"required"`
+	MinCompletion int    `json:"min_completion" binding:"min=0,max=100"` // 最低完成率（百分比）
+	MinApproval   int    `json:"min_approval" binding:"min=0,max=100"`   // 最低审核通过率（百分比）
+}
+
+// SetReleaseThresholdsRequest 设置发布门槛请求，替换项目的全部门槛
+type SetReleaseThresholdsRequest struct {
+	Thresholds []ReleaseThresholdItem `json:"thresholds" binding:"dive"`
+}
+
+// ReleaseThresholdResponse 发布门槛响应
+type ReleaseThresholdResponse struct {
+	LanguageCode  string `json:"language_code"`
+	MinCompletion int    `json:"min_completion"`
+	MinApproval   int    `json:"min_approval"`
+	UpdatedBy     uint64 `json:"updated_by"`
+	UpdatedAt     string `json:"updated_at"`
+}
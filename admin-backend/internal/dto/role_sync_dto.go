@@ -0,0 +1,7 @@
+package dto
+
+// ResolveRoleReviewRequest 处理角色复核请求
+type ResolveRoleReviewRequest struct {
+	Action string `json:"action" binding:"required,oneof=keep downgrade remove"`
+	Role   string `json:"role" binding:"omitempty,oneof=editor viewer"` // action 为 downgrade 时的新角色
+}
@@ -0,0 +1,6 @@
+package dto
+
+// ProjectRollbackRequest 项目回滚请求
+type ProjectRollbackRequest struct {
+	To string `json:"to" binding:"required"` // 回滚到的时刻，RFC3339 格式
+}
@@ -0,0 +1,17 @@
+package dto
+
+// SearchRequest 翻译搜索参数
+type SearchRequest struct {
+	Query        string `form:"q" binding:"required,max=200"`
+	Mode         string `form:"mode" binding:"omitempty,oneof=exact regex fuzzy"`
+	ProjectID    uint64 `form:"project_id"`
+	Language     string `form:"language"`
+	Status       string `form:"status" binding:"omitempty,oneof=active deprecated"`
+	ReviewStatus string `form:"review_status"`
+	UpdatedBy    uint64 `form:"updated_by"`
+	Tags         string `form:"tags"` // 逗号分隔的标签名称
+	From         string `form:"from"` // 日期（2006-01-02）或 RFC3339 时间
+	To           string `form:"to"`
+	Limit        int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset       int    `form:"offset" binding:"omitempty,min=0"`
+}
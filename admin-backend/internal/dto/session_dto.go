@@ -0,0 +1,17 @@
+package dto
+
+// SessionResponse 登录会话响应
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserAgent  string `json:"user_agent"` // 登录时的 User-Agent
+	IP         string `json:"ip"`         // 最近一次请求的来源 IP
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"` // 是否为发起本次请求的会话
+}
+
+// RevokeSessionsResponse 强制下线响应
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"` // 撤销的会话数量
+}
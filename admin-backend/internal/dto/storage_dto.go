@@ -0,0 +1,27 @@
+package dto
+
+// TableStatsResponse 数据表统计信息响应
+type TableStatsResponse struct {
+	Table      string `json:"table"`
+	Rows       int64  `json:"rows"` // InnoDB 下为估算值
+	DataBytes  int64  `json:"data_bytes"`
+	IndexBytes int64  `json:"index_bytes"`
+	TotalBytes int64  `json:"total_bytes"`
+}
+
+// StorageAlertResponse 数据增长告警响应
+type StorageAlertResponse struct {
+	Table          string `json:"table"`
+	Metric         string `json:"metric"` // rows 或 bytes
+	Value          int64  `json:"value"`
+	Threshold      int64  `json:"threshold"`
+	Recommendation string `json:"recommendation"`
+}
+
+// StorageHealthResponse 数据库增长检查响应
+type StorageHealthResponse struct {
+	Status    string                  `json:"status"` // ok 或 warning
+	CheckedAt string                  `json:"checked_at"`
+	Tables    []*TableStatsResponse   `json:"tables"`
+	Alerts    []*StorageAlertResponse `json:"alerts"`
+}
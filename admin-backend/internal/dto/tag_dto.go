@@ -0,0 +1,26 @@
+package dto
+
+// TagRequest 创建/更新标签请求
+type TagRequest struct {
+	Name        string  `json:"name" binding:"omitempty,max=40"`
+	Color       *string `json:"color" binding:"omitempty,max=7"`
+	Description *string `json:"description" binding:"omitempty,max=500"`
+}
+
+// TagKeysRequest 为键添加或移除标签请求
+type TagKeysRequest struct {
+	KeyNames []string `json:"key_names" binding:"required,min=1,max=1000"`
+}
+
+// TagResponse 标签响应
+type TagResponse struct {
+	ID          uint64 `json:"id"`
+	ProjectID   uint64 `json:"project_id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+	Keys        int64  `json:"keys"` // 带有该标签的键数量
+	UpdatedBy   uint64 `json:"updated_by"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"encoding/json"
+
+	"yflow/internal/domain"
+)
+
 // CreateTranslationRequest 创建翻译请求
 type CreateTranslationRequest struct {
 	ProjectID  uint64 `json:"project_id" binding:"required"`
@@ -7,6 +13,45 @@ type CreateTranslationRequest struct {
 	Context    string `json:"context"`
 	LanguageID uint64 `json:"language_id" binding:"required"`
 	Value      string `json:"value" binding:"required"`
+	ValueType  string `json:"value_type" binding:"omitempty,oneof=string json markdown html"` // 新键的值类型，默认 string；已有的键须与其值类型一致
+	Version    uint64 `json:"version"`                                                        // 只用于更新：编辑所基于的翻译版本号，翻译已被他人修改时返回 409；不传时不检查
+}
+
+// SetValueTypeRequest 修改键的值类型请求
+type SetValueTypeRequest struct {
+	KeyName     string          `json:"key_name" binding:"required"`
+	ValueType   string          `json:"value_type" binding:"required,oneof=string json markdown html"`
+	ValueSchema json.RawMessage `json:"value_schema" swaggertype:"object"` // json 类型的值需要符合的 JSON Schema，为空时只检查是否为合法的 JSON
+}
+
+// SetKeyMetadataRequest 修改键的元数据请求，未提供的字段清除
+type SetKeyMetadataRequest struct {
+	KeyName   string   `json:"key_name" binding:"required"`
+	MaxLength int      `json:"max_length" binding:"min=0,max=100000"` // 译文最多字符数，0 表示不限制
+	Tags      []string `json:"tags"`
+	Platform  string   `json:"platform" binding:"omitempty,oneof=web ios android desktop"` // 为空时不限平台
+}
+
+// ReviewTransitionRequest 修改翻译审核状态请求
+type ReviewTransitionRequest struct {
+	TranslationIDs []uint64 `json:"translation_ids" binding:"required,min=1,max=1000"`
+	Comment        string   `json:"comment" binding:"max=500"` // 驳回原因，只在驳回时保存
+}
+
+// ParseICUMessageRequest 解析 ICU 消息请求
+type ParseICUMessageRequest struct {
+	Message  string `json:"message" binding:"required"`
+	Language string `json:"language"` // 语言代码，用于返回复数类别并检查复数选项，为空时不检查
+}
+
+// FormatICUMessageRequest 由结构化元素生成 ICU 消息请求
+type FormatICUMessageRequest struct {
+	Elements []domain.ICUElement `json:"elements" binding:"required"`
+}
+
+// FormatICUMessageResponse 生成的 ICU 消息
+type FormatICUMessageResponse struct {
+	Message string `json:"message"`
 }
 
 // BatchTranslationRequest 批量翻译请求（前端格式）
@@ -16,3 +61,9 @@ type BatchTranslationRequest struct {
 	Context      string            `json:"context"`
 	Translations map[string]string `json:"translations" binding:"required"`
 }
+
+// TranslationMatrixRow 翻译矩阵的一行（API v2）
+type TranslationMatrixRow struct {
+	KeyName string                            `json:"key_name"`
+	Cells   map[string]domain.TranslationCell `json:"cells"` // language_code -> cell
+}
@@ -0,0 +1,12 @@
+package dto
+
+// TMSuggestRequest 翻译记忆库查询参数
+type TMSuggestRequest struct {
+	Text      string  `form:"text" binding:"required,max=500"`
+	Source    string  `form:"source"` // 源语言代码，为空时使用默认语言
+	Target    string  `form:"target" binding:"required"`
+	MinScore  float64 `form:"min_score" binding:"omitempty,gt=0,lte=1"`
+	Limit     int     `form:"limit" binding:"omitempty,min=1,max=50"`
+	ProjectID uint64  `form:"project_id"` // 与 key_name 一起排除正在编辑的键
+	KeyName   string  `form:"key_name"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// TrashedTranslationResponse 回收站中的翻译
+type TrashedTranslationResponse struct {
+	ID           uint64 `json:"id"`
+	KeyName      string `json:"key_name"`
+	LanguageID   uint64 `json:"language_id"`
+	LanguageCode string `json:"language_code"`
+	Value        string `json:"value"`
+	Status       string `json:"status"`
+	DeletedBy    uint64 `json:"deleted_by"`
+	DeletedAt    string `json:"deleted_at"`
+	PurgeAt      string `json:"purge_at,omitempty"` // 永久删除的时间，不自动清除时为空
+}
+
+// TranslationTrashResponse 回收站列表响应
+type TranslationTrashResponse struct {
+	Translations  []*TrashedTranslationResponse `json:"translations"`
+	Total         int64                         `json:"total"`
+	RetentionDays int                           `json:"retention_days"` // 删除后保留的天数，0 表示不自动清除
+}
@@ -26,3 +26,38 @@ type ChangePasswordRequest struct {
 type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" binding:"required,min=6"`
 }
+
+// OffboardUserRequest 用户离职处理请求
+type OffboardUserRequest struct {
+	ReassignTo uint64 `json:"reassign_to"` // 接收内容引用和项目所有权的用户ID，为空时引用置为 0
+	Anonymize  bool   `json:"anonymize"`   // 同时匿名化翻译历史和审计日志中的操作人
+	Reason     string `json:"reason" binding:"max=500"`
+}
+
+// UpdateUserProfileRequest 更新个人资料请求，整体替换，空值表示清除
+type UpdateUserProfileRequest struct {
+	DisplayName string `json:"display_name" binding:"max=100"`
+	Language    string `json:"language" example:"zh-CN"`         // 界面语言，BCP 47 语言标签，为空时跟随浏览器
+	Timezone    string `json:"timezone" example:"Asia/Shanghai"` // IANA 时区，为空时跟随浏览器
+}
+
+// UserProfileResponse 个人资料响应
+type UserProfileResponse struct {
+	ID          uint64 `json:"id"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"` // 没有头像时为空
+	Language    string `json:"language"`
+	Timezone    string `json:"timezone"`
+}
+
+// DeactivateUserRequest 停用用户请求
+type DeactivateUserRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// TransferOwnershipRequest 转移用户所有权请求
+type TransferOwnershipRequest struct {
+	ToUserID uint64 `json:"to_user_id" binding:"required"` // 接收所有权的用户ID
+}
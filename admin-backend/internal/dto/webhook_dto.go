@@ -0,0 +1,48 @@
+package dto
+
+// WebhookRequest 创建/更新出站 Webhook 请求
+type WebhookRequest struct {
+	Name   string   `json:"name" binding:"omitempty,max=100"`
+	URL    string   `json:"url" binding:"omitempty,max=500"`
+	Events []string `json:"events"` // 订阅的事件，创建时为空表示全部事件，更新时为空表示不修改
+	Status string   `json:"status" binding:"omitempty,oneof=active disabled"`
+	// 消息模板（Go 模板），为空字符串表示使用内置 generic 模板，不传表示不修改
+	Template *string `json:"template" binding:"omitempty,max=16384"`
+	Preset   string  `json:"preset" binding:"omitempty,oneof=generic slack teams"` // 使用内置模板，不能与 template 同时指定
+}
+
+// WebhookResponse 出站 Webhook 响应
+type WebhookResponse struct {
+	ID        uint64   `json:"id"`
+	ProjectID uint64   `json:"project_id"`
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Status    string   `json:"status"`
+	Template  string   `json:"template"`         // 消息模板，为空表示使用内置 generic 模板
+	Secret    string   `json:"secret,omitempty"` // 仅在创建和重置密钥时返回
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse 出站 Webhook 推送记录
+type WebhookDeliveryResponse struct {
+	ID             uint64 `json:"id"`
+	WebhookID      uint64 `json:"webhook_id"`
+	EventID        string `json:"event_id"`
+	Event          string `json:"event"`
+	Status         string `json:"status"` // pending, delivered, failed
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"response_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"` // 待重试时的下次推送时间
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	Payload        string `json:"payload"` // 推送的请求体
+}
+
+// WebhookDeliveryListResponse 出站 Webhook 推送记录列表响应
+type WebhookDeliveryListResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+	Total      int64                      `json:"total"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "encoding/json"
+
+// WebhookTemplatePreviewRequest 预览 Webhook 消息模板请求
+type WebhookTemplatePreviewRequest struct {
+	Template  string `json:"template"`                                             // Go 模板，为空时使用内置模板
+	Preset    string `json:"preset" binding:"omitempty,oneof=generic slack teams"` // 内置模板
+	WebhookID uint64 `json:"webhook_id"`                                           // 未指定 template 和 preset 时使用该 Webhook 保存的模板
+	// 示例事件类型，默认 translation.updated
+	EventType string          `json:"event_type" binding:"omitempty,oneof=translation.updated translation.created translation.deleted project.created import.completed release.published"`
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"` // 自定义示例事件内容，为空时使用内置示例
+}
+
+// WebhookTemplatePreviewResponse Webhook 消息模板预览结果
+type WebhookTemplatePreviewResponse struct {
+	EventType string          `json:"event_type"`
+	Body      json.RawMessage `json:"body" swaggertype:"object"` // 将要发送的消息体
+}
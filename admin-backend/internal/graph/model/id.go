@@ -0,0 +1,20 @@
+package model
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// MarshalUint64ID 将领域模型的 uint64 ID 序列化为 GraphQL ID（字符串）
+func MarshalUint64ID(id uint64) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strconv.Quote(strconv.FormatUint(id, 10)))
+	})
+}
+
+// UnmarshalUint64ID 解析字符串或整数形式的 GraphQL ID
+func UnmarshalUint64ID(v interface{}) (uint64, error) {
+	return graphql.UnmarshalUint64(v)
+}
@@ -0,0 +1,32 @@
+// Package model GraphQL 模式中没有对应领域模型的类型
+package model
+
+import "yflow/internal/domain"
+
+// ProjectPage 分页的项目列表
+type ProjectPage struct {
+	Items    []*domain.Project
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// TranslationMatrix 分页的翻译矩阵，行按键名排序
+type TranslationMatrix struct {
+	Rows     []*MatrixRow
+	Total    int64
+	Page     int
+	PageSize int
+}
+
+// MatrixRow 翻译矩阵中的一个键
+type MatrixRow struct {
+	KeyName string
+	Cells   []*MatrixCell
+}
+
+// MatrixCell 翻译矩阵单元格，包含语言代码
+type MatrixCell struct {
+	LanguageCode string
+	domain.TranslationCell
+}
@@ -0,0 +1,81 @@
+// Package graph 提供 GraphQL 接口，解析器直接调用服务层，权限规则与 REST 接口一致
+package graph
+
+//go:generate go run github.com/99designs/gqlgen generate --config ../../gqlgen.yml
+
+import (
+	"context"
+
+	"yflow/internal/domain"
+)
+
+// Resolver GraphQL 根解析器
+type Resolver struct {
+	projectService       domain.ProjectService
+	projectMemberService domain.ProjectMemberService
+	languageService      domain.LanguageService
+	translationService   domain.TranslationService
+}
+
+// NewResolver 创建 GraphQL 根解析器
+func NewResolver(
+	projectService domain.ProjectService,
+	projectMemberService domain.ProjectMemberService,
+	languageService domain.LanguageService,
+	translationService domain.TranslationService,
+) *Resolver {
+	return &Resolver{
+		projectService:       projectService,
+		projectMemberService: projectMemberService,
+		languageService:      languageService,
+		translationService:   translationService,
+	}
+}
+
+// userContextKey 上下文中保存当前用户的键
+type userContextKey struct{}
+
+// user 发起请求的登录用户
+type user struct {
+	id   uint64
+	role string
+}
+
+// WithUser 在上下文中设置发起请求的用户，role 为全局角色
+func WithUser(ctx context.Context, userID uint64, role string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user{id: userID, role: role})
+}
+
+// userFromContext 获取发起请求的用户，未登录时返回 ErrUnauthorized
+func userFromContext(ctx context.Context) (user, error) {
+	u, ok := ctx.Value(userContextKey{}).(user)
+	if !ok || u.id == 0 {
+		return user{}, domain.ErrUnauthorized
+	}
+	return u, nil
+}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// pagination 规范化分页参数，与 REST 接口一致：页码小于 1 时为 1，每页数量超出范围时为默认值
+func pagination(page, pageSize *int) (int, int) {
+	p, size := 1, defaultPageSize
+	if page != nil && *page > 1 {
+		p = *page
+	}
+	if pageSize != nil && *pageSize >= 1 && *pageSize <= maxPageSize {
+		size = *pageSize
+	}
+	return p, size
+}
+
+// deref 返回字符串参数的值，未提供时为空字符串
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
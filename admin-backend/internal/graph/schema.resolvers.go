@@ -0,0 +1,101 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.49
+
+import (
+	"context"
+	"sort"
+	"yflow/internal/domain"
+	"yflow/internal/graph/model"
+)
+
+// Languages is the resolver for the languages field.
+func (r *projectResolver) Languages(ctx context.Context, obj *domain.Project) ([]*domain.Language, error) {
+	return r.languageService.GetAll(domain.WithOrganization(ctx, obj.OrganizationID))
+}
+
+// Matrix is the resolver for the matrix field.
+func (r *projectResolver) Matrix(ctx context.Context, obj *domain.Project, page *int, pageSize *int, keyword *string, reviewStatus *string, namespace *string, tags []string) (*model.TranslationMatrix, error) {
+	p, size := pagination(page, pageSize)
+	result, err := r.translationService.GetMatrixPage(domain.WithOrganization(ctx, obj.OrganizationID), domain.TranslationMatrixQuery{
+		ProjectID:    obj.ID,
+		Limit:        size,
+		Offset:       (p - 1) * size,
+		Keyword:      deref(keyword),
+		ReviewStatus: deref(reviewStatus),
+		Namespace:    deref(namespace),
+		Tags:         tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &model.TranslationMatrix{
+		Rows:     make([]*model.MatrixRow, 0, len(result.Keys)),
+		Total:    result.Total,
+		Page:     p,
+		PageSize: size,
+	}
+	for _, keyName := range result.Keys {
+		// 与 REST 接口一致，只有停用语言翻译的键不出现在结果中
+		cells, ok := result.Matrix[keyName]
+		if !ok {
+			continue
+		}
+		row := &model.MatrixRow{KeyName: keyName, Cells: make([]*model.MatrixCell, 0, len(cells))}
+		for languageCode, cell := range cells {
+			row.Cells = append(row.Cells, &model.MatrixCell{LanguageCode: languageCode, TranslationCell: cell})
+		}
+		sort.Slice(row.Cells, func(i, j int) bool { return row.Cells[i].LanguageCode < row.Cells[j].LanguageCode })
+		matrix.Rows = append(matrix.Rows, row)
+	}
+	return matrix, nil
+}
+
+// Projects is the resolver for the projects field.
+func (r *queryResolver) Projects(ctx context.Context, keyword *string, page *int, pageSize *int) (*model.ProjectPage, error) {
+	u, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, size := pagination(page, pageSize)
+	projects, total, err := r.projectService.GetAccessibleProjects(ctx, u.id, size, (p-1)*size, deref(keyword))
+	if err != nil {
+		return nil, err
+	}
+	return &model.ProjectPage{Items: projects, Total: total, Page: p, PageSize: size}, nil
+}
+
+// Project is the resolver for the project field.
+func (r *queryResolver) Project(ctx context.Context, id string) (*domain.Project, error) {
+	u, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	project, err := r.projectService.GetByIdentifier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 管理员可以访问所有项目，其他用户需要至少是项目的查看者
+	if u.role != "admin" {
+		hasPermission, err := r.projectMemberService.CheckPermission(ctx, u.id, project.ID, "viewer")
+		if err != nil {
+			return nil, err
+		}
+		if !hasPermission {
+			return nil, domain.ErrInsufficientPerm
+		}
+	}
+	return project, nil
+}
+
+// Project returns ProjectResolver implementation.
+func (r *Resolver) Project() ProjectResolver { return &projectResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type projectResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
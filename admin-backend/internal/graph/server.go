@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// complexityLimit 单次查询允许的最大复杂度，一页 100 个键、每个键 20 个字段的矩阵约为 2000
+const complexityLimit = 5000
+
+// NewServer 创建 GraphQL 服务，只接受 POST 请求
+func NewServer(resolver *Resolver, logger *zap.Logger) *handler.Server {
+	cfg := Config{Resolvers: resolver}
+	// 列表字段的复杂度按每页数量计算，避免一次查询拉取大量项目的完整矩阵
+	cfg.Complexity.Query.Projects = func(childComplexity int, keyword *string, page *int, pageSize *int) int {
+		_, size := pagination(page, pageSize)
+		return 1 + childComplexity*size
+	}
+	cfg.Complexity.Project.Matrix = func(childComplexity int, page *int, pageSize *int, keyword *string, reviewStatus *string, namespace *string, tags []string) int {
+		_, size := pagination(page, pageSize)
+		return 1 + childComplexity*size
+	}
+
+	srv := handler.New(NewExecutableSchema(cfg))
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(complexityLimit))
+	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+		return presentError(ctx, err, logger)
+	})
+	srv.SetRecoverFunc(func(ctx context.Context, v interface{}) error {
+		logger.Error("GraphQL resolver panic", zap.Any("panic", v), zap.String("path", graphql.GetPath(ctx).String()))
+		return domain.ErrInternalError
+	})
+	return srv
+}
+
+// presentError 将业务错误转换为 GraphQL 错误，extensions.code 与 REST 接口的错误码一致；
+// 非业务错误只记录日志，不向客户端暴露细节
+func presentError(ctx context.Context, err error, logger *zap.Logger) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	if appErr, ok := domain.IsAppError(err); ok {
+		gqlErr.Message = appErr.Message
+		gqlErr.Extensions = map[string]interface{}{"code": appErr.Code}
+		if appErr.Type != domain.ErrorTypeInternal {
+			return gqlErr
+		}
+	} else {
+		// 查询语法和校验错误没有包装业务错误，原样返回
+		var parseErr *gqlerror.Error
+		if errors.As(err, &parseErr) && parseErr.Err == nil {
+			return gqlErr
+		}
+		gqlErr.Extensions = map[string]interface{}{"code": "INTERNAL_ERROR"}
+	}
+
+	logger.Error("GraphQL query failed", zap.Error(err), zap.String("path", gqlErr.Path.String()))
+	gqlErr.Message = "服务器内部错误"
+	return gqlErr
+}
@@ -0,0 +1,122 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// apiKeyMetadata metadata 中的 API Key，对应 HTTP 接口的 X-API-Key 请求头
+	apiKeyMetadata = "x-api-key"
+	// clientMetadata metadata 中的客户端名称，对应 HTTP 接口的 X-YFlow-Client 请求头，记录在拉取用量的调用方中
+	clientMetadata = "x-yflow-client"
+)
+
+// callerContextKey 上下文中保存通过认证的调用方的键
+type callerContextKey struct{}
+
+// caller 通过认证的调用方
+type caller struct {
+	apiKey   *domain.APIKey // 使用 CLI_API_KEY 认证时为 nil
+	consumer string         // 拉取用量中记录的调用方
+}
+
+// APIKeyAuthInterceptor 返回 API Key 认证拦截器
+// 与 HTTP 接口的 API Key 认证一致：接受 CLI_API_KEY 和管理员创建的 API Key（yfk_ 开头），项目范围和权限范围由各方法检查
+func APIKeyAuthInterceptor(apiKeyService domain.APIKeyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		apiKey := firstMetadata(md, apiKeyMetadata)
+		if apiKey == "" {
+			return nil, status.Error(codes.Unauthenticated, "API Key is required")
+		}
+
+		var key *domain.APIKey
+		if apiKeyService != nil && strings.HasPrefix(apiKey, domain.APIKeyPrefix) {
+			// 管理员创建的 API Key，权限范围由 API Key 决定
+			authenticated, err := apiKeyService.Authenticate(ctx, apiKey, clientIP(ctx))
+			if err != nil {
+				if _, ok := domain.IsAppError(err); !ok {
+					return nil, status.Error(codes.Internal, "API Key 校验失败")
+				}
+				return nil, status.Error(codes.Unauthenticated, "Invalid API Key")
+			}
+			key = authenticated
+		} else if apiKey != expectedCLIAPIKey() {
+			return nil, status.Error(codes.Unauthenticated, "Invalid API Key")
+		}
+
+		sum := sha256.Sum256([]byte(apiKey))
+		consumer := "api_key:" + hex.EncodeToString(sum[:])[:12]
+		if client := strings.TrimSpace(firstMetadata(md, clientMetadata)); client != "" {
+			consumer += "/" + client
+		}
+		return handler(context.WithValue(ctx, callerContextKey{}, &caller{apiKey: key, consumer: consumer}), req)
+	}
+}
+
+// authorize 检查调用方的 API Key 是否可以对项目执行 scope 范围的操作
+// 组织的 API Key 只能访问该组织的项目，ctx 中应已设置项目所属的组织
+func authorize(ctx context.Context, projectID uint64, scope string) error {
+	c, _ := ctx.Value(callerContextKey{}).(*caller)
+	if c == nil || c.apiKey == nil {
+		return nil
+	}
+	key := c.apiKey
+	if !key.AllowsScope(scope) || !key.AllowsProject(projectID) {
+		return domain.ErrAPIKeyScopeDenied
+	}
+	if key.OrganizationID != 0 && domain.OrganizationFromContext(ctx) != key.OrganizationID {
+		return domain.ErrAPIKeyScopeDenied
+	}
+	return nil
+}
+
+// consumerFromContext 获取拉取用量中记录的调用方
+func consumerFromContext(ctx context.Context) string {
+	if c, _ := ctx.Value(callerContextKey{}).(*caller); c != nil {
+		return c.consumer
+	}
+	return "anonymous"
+}
+
+// expectedCLIAPIKey 获取 CLI_API_KEY，与 HTTP 接口相同，未设置时使用开发环境的默认值
+func expectedCLIAPIKey() string {
+	if key := os.Getenv("CLI_API_KEY"); key != "" {
+		return key
+	}
+	return "yflow-cli-default-key"
+}
+
+// firstMetadata 获取 metadata 中键的第一个值
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// clientIP 获取调用方的 IP 地址
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
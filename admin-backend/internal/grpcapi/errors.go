@@ -0,0 +1,45 @@
+package grpcapi
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"yflow/internal/domain"
+)
+
+// errorDomain 错误详情中的错误域
+const errorDomain = "yflow"
+
+// errorCodes 领域错误类型对应的 gRPC 状态码，与 HTTP 状态码的对应关系一致
+var errorCodes = map[domain.ErrorType]codes.Code{
+	domain.ErrorTypeValidation:   codes.InvalidArgument,
+	domain.ErrorTypeBadRequest:   codes.InvalidArgument,
+	domain.ErrorTypeNotFound:     codes.NotFound,
+	domain.ErrorTypeConflict:     codes.AlreadyExists,
+	domain.ErrorTypeUnauthorized: codes.Unauthenticated,
+	domain.ErrorTypeForbidden:    codes.PermissionDenied,
+}
+
+// toStatus 将领域错误转换为 gRPC 状态，错误码放在 ErrorInfo 的 reason 中
+// 第二个返回值表示是否为领域错误，内部错误不暴露原因，由调用方记录日志
+func toStatus(err error, fallback string) (error, bool) {
+	appErr, ok := domain.IsAppError(err)
+	if !ok {
+		return status.Error(codes.Internal, fallback), false
+	}
+	code, ok := errorCodes[appErr.Type]
+	if !ok {
+		return status.Error(codes.Internal, fallback), false
+	}
+	return errorStatus(code, appErr.Code, appErr.Message), true
+}
+
+// errorStatus 创建带错误码的 gRPC 状态
+func errorStatus(code codes.Code, reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -0,0 +1,249 @@
+// Package grpcapi 提供翻译拉取和推送的 gRPC 接口，与 CLI HTTP 接口共用服务层和 API Key 认证
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=yflow --go-grpc_out=../.. --go-grpc_opt=module=yflow yflow/v1/translation.proto
+
+import (
+	"context"
+	"encoding/base64"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"yflow/internal/domain"
+	"yflow/internal/grpcapi/yflowv1"
+)
+
+const (
+	// defaultPullPageSize 拉取翻译时的默认每页键数量，与 HTTP 接口一致
+	defaultPullPageSize = 1000
+	// maxPullPageSize 拉取翻译时的最大每页键数量
+	maxPullPageSize = 5000
+	// defaultMatrixPageSize 获取翻译矩阵时的默认每页键数量
+	defaultMatrixPageSize = 10
+	// maxMatrixPageSize 获取翻译矩阵时的最大每页键数量
+	maxMatrixPageSize = 100
+)
+
+// Server gRPC 翻译服务实现
+type Server struct {
+	yflowv1.UnimplementedTranslationServiceServer
+	projectService     domain.ProjectService
+	translationService domain.TranslationService
+	keyPushService     domain.KeyPushService
+	usageService       domain.UsageService
+	activityService    domain.ProjectActivityService
+	logger             *zap.Logger
+}
+
+// NewServer 创建 gRPC 翻译服务
+func NewServer(
+	projectService domain.ProjectService,
+	translationService domain.TranslationService,
+	keyPushService domain.KeyPushService,
+	usageService domain.UsageService,
+	activityService domain.ProjectActivityService,
+	logger *zap.Logger,
+) *Server {
+	return &Server{
+		projectService:     projectService,
+		translationService: translationService,
+		keyPushService:     keyPushService,
+		usageService:       usageService,
+		activityService:    activityService,
+		logger:             logger,
+	}
+}
+
+// NewGRPCServer 创建注册了翻译服务和 API Key 认证的 gRPC 服务器
+func NewGRPCServer(server *Server, apiKeyService domain.APIKeyService) *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(APIKeyAuthInterceptor(apiKeyService)))
+	yflowv1.RegisterTranslationServiceServer(grpcServer, server)
+	return grpcServer
+}
+
+// GetProject 按项目ID或项目标识获取项目
+func (s *Server) GetProject(ctx context.Context, req *yflowv1.GetProjectRequest) (*yflowv1.Project, error) {
+	project, _, err := s.resolveProject(ctx, req.GetProject(), domain.APIKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(project), nil
+}
+
+// PullTranslations 按键名分页拉取翻译，cursor 与 GET /api/cli/translations 的 next_cursor 通用
+func (s *Server) PullTranslations(ctx context.Context, req *yflowv1.PullTranslationsRequest) (*yflowv1.PullTranslationsResponse, error) {
+	project, ctx, err := s.resolveProject(ctx, req.GetProject(), domain.APIKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	query := domain.TranslationKeyPageQuery{
+		ProjectID: project.ID,
+		Locale:    req.GetLocale(),
+		Release:   req.GetRelease(),
+		Limit:     defaultPullPageSize,
+	}
+	// 命名空间为键名中第一个 "." 之前的部分
+	if namespace := strings.TrimSuffix(req.GetNamespace(), "."); namespace != "" {
+		query.KeyPrefix = namespace + "."
+	}
+	if limit := req.GetLimit(); limit < 0 {
+		return nil, errorStatus(codes.InvalidArgument, "BAD_REQUEST", "invalid limit")
+	} else if limit > 0 {
+		query.Limit = min(int(limit), maxPullPageSize)
+	}
+	if cursor := req.GetCursor(); cursor != "" {
+		afterKey, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || len(afterKey) == 0 {
+			return nil, errorStatus(codes.InvalidArgument, "BAD_REQUEST", "invalid cursor")
+		}
+		query.AfterKey = string(afterKey)
+	}
+
+	page, err := s.translationService.GetKeyPage(ctx, query)
+	if err != nil {
+		return nil, s.fail("PullTranslations", err, "获取翻译数据失败")
+	}
+
+	resp := &yflowv1.PullTranslationsResponse{
+		Translations: make([]*yflowv1.Translation, 0, len(page.Translations)),
+		HasMore:      page.HasMore,
+	}
+	for keyName, values := range page.Translations {
+		resp.Translations = append(resp.Translations, &yflowv1.Translation{KeyName: keyName, Values: values})
+	}
+	slices.SortFunc(resp.Translations, func(a, b *yflowv1.Translation) int {
+		return strings.Compare(a.KeyName, b.KeyName)
+	})
+	if page.HasMore {
+		resp.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(page.LastKey))
+	}
+
+	if s.usageService != nil {
+		s.usageService.Record(ctx, domain.UsageRecord{
+			ProjectID: project.ID,
+			Channel:   domain.UsageChannelGRPC,
+			Locale:    req.GetLocale(),
+			Consumer:  consumerFromContext(ctx),
+		})
+	}
+	return resp, nil
+}
+
+// GetMatrix 分页获取翻译矩阵，按键名排序
+func (s *Server) GetMatrix(ctx context.Context, req *yflowv1.GetMatrixRequest) (*yflowv1.Matrix, error) {
+	project, ctx, err := s.resolveProject(ctx, req.GetProject(), domain.APIKeyScopeRead)
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize := int(req.GetPage()), int(req.GetPageSize())
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxMatrixPageSize {
+		pageSize = defaultMatrixPageSize
+	}
+
+	result, err := s.translationService.GetMatrixPage(ctx, domain.TranslationMatrixQuery{
+		ProjectID: project.ID,
+		Limit:     pageSize,
+		Offset:    (page - 1) * pageSize,
+		Keyword:   req.GetKeyword(),
+	})
+	if err != nil {
+		return nil, s.fail("GetMatrix", err, "获取翻译矩阵失败")
+	}
+
+	matrix := &yflowv1.Matrix{
+		Rows:     make([]*yflowv1.MatrixRow, 0, len(result.Keys)),
+		Total:    result.Total,
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	}
+	for _, keyName := range result.Keys {
+		row := &yflowv1.MatrixRow{KeyName: keyName, Cells: make(map[string]*yflowv1.MatrixCell, len(result.Matrix[keyName]))}
+		for languageCode, cell := range result.Matrix[keyName] {
+			row.Cells[languageCode] = &yflowv1.MatrixCell{
+				Id:           cell.ID,
+				Value:        cell.Value,
+				ValueType:    cell.ValueType,
+				ReviewStatus: cell.ReviewStatus,
+				Version:      cell.Version,
+				UpdatedBy:    cell.UpdatedBy,
+				UpdatedAt:    timestamppb.New(cell.UpdatedAt),
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+	return matrix, nil
+}
+
+// PushKeys 推送新键或批量导入翻译
+func (s *Server) PushKeys(ctx context.Context, req *yflowv1.PushKeysRequest) (*yflowv1.PushKeysResponse, error) {
+	project, ctx, err := s.resolveProject(ctx, req.GetProject(), domain.APIKeyScopeWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	params := domain.PushKeysParams{ProjectID: project.ID, Keys: req.GetKeys()}
+	if len(req.GetTranslations()) > 0 {
+		params.Translations = make(map[string]map[string]string, len(req.GetTranslations()))
+		for languageCode, values := range req.GetTranslations() {
+			params.Translations[languageCode] = values.GetValues()
+		}
+	}
+	result, err := s.keyPushService.Push(ctx, params)
+	if err != nil {
+		return nil, s.fail("PushKeys", err, "推送翻译键失败")
+	}
+	return &yflowv1.PushKeysResponse{Added: result.Added, Existed: result.Existed, Failed: result.Failed}, nil
+}
+
+// resolveProject 按项目ID或项目标识查找项目并检查 API Key，返回设置了项目所属组织的 ctx
+func (s *Server) resolveProject(ctx context.Context, identifier, scope string) (*domain.Project, context.Context, error) {
+	if identifier == "" {
+		return nil, ctx, errorStatus(codes.InvalidArgument, "BAD_REQUEST", "project is required")
+	}
+	project, err := s.projectService.GetByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, ctx, s.fail("resolveProject", err, "获取项目失败")
+	}
+	ctx = domain.WithOrganization(ctx, project.OrganizationID)
+	if err := authorize(ctx, project.ID, scope); err != nil {
+		st, _ := toStatus(err, "API Key 校验失败")
+		return nil, ctx, st
+	}
+	if s.activityService != nil {
+		s.activityService.RecordAccess(project.ID)
+	}
+	return project, ctx, nil
+}
+
+// fail 将服务层错误转换为 gRPC 状态，内部错误记录日志
+func (s *Server) fail(method string, err error, fallback string) error {
+	st, ok := toStatus(err, fallback)
+	if !ok {
+		s.logger.Error("gRPC request failed", zap.String("method", method), zap.Error(err))
+	}
+	return st
+}
+
+// toProto 转换项目
+func toProto(project *domain.Project) *yflowv1.Project {
+	return &yflowv1.Project{
+		Id:             project.ID,
+		Name:           project.Name,
+		Slug:           project.Slug,
+		Description:    project.Description,
+		Status:         project.Status,
+		SourceLanguage: project.SourceLanguage,
+		CreatedAt:      timestamppb.New(project.CreatedAt),
+		UpdatedAt:      timestamppb.New(project.UpdatedAt),
+	}
+}
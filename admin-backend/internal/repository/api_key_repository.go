@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository API Key 仓储实现
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository 创建 API Key 仓储实例
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create 创建 API Key
+func (r *APIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	return dbFromContext(ctx, r.db).Create(key).Error
+}
+
+// GetByID 根据ID获取 API Key
+func (r *APIKeyRepository) GetByID(ctx context.Context, id uint64) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := dbFromContext(ctx, r.db).First(&key, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetByHash 根据 API Key 哈希获取 API Key
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := dbFromContext(ctx, r.db).Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// GetAll 获取所有 API Key（最新的在前）
+func (r *APIKeyRepository) GetAll(ctx context.Context) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	if err := dbFromContext(ctx, r.db).Order("id DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetByOrganization 获取组织的 API Key（最新的在前）
+func (r *APIKeyRepository) GetByOrganization(ctx context.Context, organizationID uint64) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	if err := dbFromContext(ctx, r.db).Where("organization_id = ?", organizationID).Order("id DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// UpdateLastUsed 记录 API Key 最近的使用时间和来源 IP
+func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, id uint64, usedAt time.Time, ip string) error {
+	return dbFromContext(ctx, r.db).Model(&domain.APIKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ip,
+		}).Error
+}
+
+// Delete 删除 API Key
+func (r *APIKeyRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.APIKey{}, id).Error
+}
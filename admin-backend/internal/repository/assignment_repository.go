@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AssignmentRepository 任务分配仓储实现
+type AssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAssignmentRepository 创建任务分配仓储实例
+func NewAssignmentRepository(db *gorm.DB) *AssignmentRepository {
+	return &AssignmentRepository{db: db}
+}
+
+// GetByID 根据ID获取任务分配
+func (r *AssignmentRepository) GetByID(ctx context.Context, id uint64) (*domain.Assignment, error) {
+	var assignment domain.Assignment
+	if err := dbFromContext(ctx, r.db).First(&assignment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// GetByProjectID 获取项目的任务分配，包含对应的语言和用户
+func (r *AssignmentRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Assignment, error) {
+	var assignments []*domain.Assignment
+	if err := dbFromContext(ctx, r.db).
+		Preload("Language").
+		Preload("User").
+		Where("project_id = ?", projectID).
+		Order("language_id ASC, type ASC, key_name ASC").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// GetByUserID 获取分配给用户的任务，包含对应的项目和语言
+func (r *AssignmentRepository) GetByUserID(ctx context.Context, userID uint64) ([]*domain.Assignment, error) {
+	var assignments []*domain.Assignment
+	if err := dbFromContext(ctx, r.db).
+		Preload("Project").
+		Preload("Language").
+		Where("user_id = ?", userID).
+		Order("project_id ASC, language_id ASC, type ASC").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// Upsert 写入任务分配，同一语言、键和工作类型已有分配时改为新的用户
+func (r *AssignmentRepository) Upsert(ctx context.Context, assignments []*domain.Assignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		// 基于唯一索引 idx_assignment_scope (project_id, language_id, key_name, type)
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "updated_by", "updated_at"}),
+	}).CreateInBatches(assignments, 500).Error
+}
+
+// Delete 删除任务分配
+func (r *AssignmentRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.Assignment{}, id).Error
+}
+
+// RenameKey 将分配到键的任务改为新键名，返回修改的条数
+// 新键名上已有的任务分配（键不存在时遗留的分配）会被删除，以释放唯一索引
+func (r *AssignmentRepository) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("project_id = ? AND key_name = ?", projectID, newKeyName).Delete(&domain.Assignment{}).Error; err != nil {
+		return 0, err
+	}
+	result := db.Model(&domain.Assignment{}).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Update("key_name", newKeyName)
+	return result.RowsAffected, result.Error
+}
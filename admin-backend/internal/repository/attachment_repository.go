@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository 附件仓储实现
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository 创建附件仓储实例
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// GetByID 根据ID获取附件
+func (r *AttachmentRepository) GetByID(ctx context.Context, id uint64) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	if err := dbFromContext(ctx, r.db).First(&attachment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetByProjectID 获取项目的附件，keyName 不为空时只返回该键的附件
+func (r *AttachmentRepository) GetByProjectID(ctx context.Context, projectID uint64, keyName string) ([]*domain.Attachment, error) {
+	query := dbFromContext(ctx, r.db).Where("project_id = ?", projectID)
+	if keyName != "" {
+		query = query.Where("key_name = ?", keyName)
+	}
+	var attachments []*domain.Attachment
+	if err := query.Order("key_name ASC, id ASC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// CountByKey 统计键的附件数量
+func (r *AttachmentRepository) CountByKey(ctx context.Context, projectID uint64, keyName string) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&domain.Attachment{}).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Count(&count).Error
+	return count, err
+}
+
+// Create 创建附件记录
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	return dbFromContext(ctx, r.db).Create(attachment).Error
+}
+
+// Delete 删除附件记录
+func (r *AttachmentRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.Attachment{}, id).Error
+}
+
+// RenameKey 将键的附件改为新键名，返回修改的条数
+func (r *AttachmentRepository) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.Attachment{}).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Update("key_name", newKeyName)
+	return result.RowsAffected, result.Error
+}
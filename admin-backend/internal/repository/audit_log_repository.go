@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository 审计日志仓储实现
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository 创建审计日志仓储实例
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create 记录审计日志
+func (r *AuditLogRepository) Create(ctx context.Context, log *domain.AuditLog) error {
+	return dbFromContext(ctx, r.db).Create(log).Error
+}
+
+// GetByProjectID 分页获取项目的审计日志（最新的在前）
+func (r *AuditLogRepository) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.AuditLog, int64, error) {
+	var logs []*domain.AuditLog
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&domain.AuditLog{}).Where("project_id = ?", projectID)
+
+	// 获取总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取分页数据
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// GetPage 按时间倒序游标分页获取审计日志，排序与 GetByProjectID 相同
+func (r *AuditLogRepository) GetPage(ctx context.Context, query domain.AuditLogPageQuery) (*domain.AuditLogPage, error) {
+	base := dbFromContext(ctx, r.db).Model(&domain.AuditLog{}).Where("project_id = ?", query.ProjectID)
+
+	page := &domain.AuditLogPage{}
+	if err := base.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return nil, err
+	}
+
+	pageQuery := base
+	if query.After != nil {
+		pageQuery = pageQuery.Where("created_at < ? OR (created_at = ? AND id < ?)",
+			query.After.CreatedAt, query.After.CreatedAt, query.After.ID)
+	}
+	// 多取一条用于判断是否还有下一页
+	if err := pageQuery.Order("created_at DESC, id DESC").Limit(query.Limit + 1).Find(&page.Logs).Error; err != nil {
+		return nil, err
+	}
+	if len(page.Logs) > query.Limit {
+		page.Logs = page.Logs[:query.Limit]
+		page.HasMore = true
+	}
+	return page, nil
+}
+
+// Stream 按 ID 顺序分批读取时间范围内的审计日志，fn 返回错误时停止
+func (r *AuditLogRepository) Stream(ctx context.Context, query domain.HistoryQuery, batchSize int, fn func([]*domain.AuditLog) error) error {
+	var lastID uint64
+	for {
+		var batch []*domain.AuditLog
+		if err := historyRangeQuery(dbFromContext(ctx, r.db).Model(&domain.AuditLog{}), query).
+			Where("id > ?", lastID).
+			Order("id ASC").
+			Limit(batchSize).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// CountActivity 按项目、月份、操作人和操作类型统计时间范围内的审计日志
+func (r *AuditLogRepository) CountActivity(ctx context.Context, query domain.HistoryQuery) ([]*domain.ActivityCount, error) {
+	return countActivity(dbFromContext(ctx, r.db).Model(&domain.AuditLog{}), query, "action")
+}
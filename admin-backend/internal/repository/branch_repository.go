@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BranchRepository 翻译分支仓储实现
+type BranchRepository struct {
+	db *gorm.DB
+}
+
+// NewBranchRepository 创建翻译分支仓储实例
+func NewBranchRepository(db *gorm.DB) *BranchRepository {
+	return &BranchRepository{db: db}
+}
+
+// GetByID 根据ID获取分支
+func (r *BranchRepository) GetByID(ctx context.Context, id uint64) (*domain.Branch, error) {
+	var branch domain.Branch
+	if err := dbFromContext(ctx, r.db).First(&branch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// GetByName 根据名称获取项目中的分支
+func (r *BranchRepository) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Branch, error) {
+	var branch domain.Branch
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND name = ?", projectID, name).
+		First(&branch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrBranchNotFound
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// GetByProjectID 获取项目的分支，按名称排序
+func (r *BranchRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Branch, error) {
+	var branches []*domain.Branch
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("name ASC").
+		Find(&branches).Error; err != nil {
+		return nil, err
+	}
+	return branches, nil
+}
+
+// Create 创建分支
+func (r *BranchRepository) Create(ctx context.Context, branch *domain.Branch) error {
+	return dbFromContext(ctx, r.db).Create(branch).Error
+}
+
+// Update 更新分支
+func (r *BranchRepository) Update(ctx context.Context, branch *domain.Branch) error {
+	return dbFromContext(ctx, r.db).Save(branch).Error
+}
+
+// Delete 删除分支及其中修改过的翻译
+func (r *BranchRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("branch_id = ?", id).Delete(&domain.BranchTranslation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Branch{}, id).Error
+	})
+}
+
+// GetTranslations 获取分支中修改过的翻译，按键名和语言排序
+func (r *BranchRepository) GetTranslations(ctx context.Context, branchID uint64) ([]*domain.BranchTranslation, error) {
+	var translations []*domain.BranchTranslation
+	if err := dbFromContext(ctx, r.db).
+		Where("branch_id = ?", branchID).
+		Order("key_name ASC, language_id ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// UpsertTranslations 写入分支中的翻译，已有记录只更新值和删除标记，保留首次修改时记录的主线值
+func (r *BranchRepository) UpsertTranslations(ctx context.Context, translations []*domain.BranchTranslation) error {
+	if len(translations) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		// 基于唯一索引 idx_branch_translation (branch_id, key_name, language_id)
+		DoUpdates: clause.AssignmentColumns([]string{"value", "deleted", "updated_by", "updated_at"}),
+	}).CreateInBatches(translations, 500).Error
+}
+
+// RenameKey 将项目未合并分支中该键的翻译改为新键名，返回修改的条数
+// 已合并分支的记录保留原样，作为合并时的历史
+func (r *BranchRepository) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	result := dbFromContext(ctx, r.db).Exec(
+		`UPDATE branch_translations bt
+		INNER JOIN branches b ON b.id = bt.branch_id
+		SET bt.key_name = ?
+		WHERE b.project_id = ? AND b.status = ? AND bt.key_name = ?`,
+		newKeyName, projectID, domain.BranchStatusOpen, keyName,
+	)
+	return result.RowsAffected, result.Error
+}
@@ -60,14 +60,51 @@ func InitDB(cfg *config.Config, zapLogger *zap.Logger, monitor *internal_utils.D
 	sqlDB.SetConnMaxLifetime(time.Hour)        // 连接最大生存时间
 	sqlDB.SetConnMaxIdleTime(10 * time.Minute) // 连接最大空闲时间
 
+	// 审核状态列加入前已有的翻译视为审核通过，迁移后补充
+	addingReviewStatus := db.Migrator().HasTable(&domain.Translation{}) && !db.Migrator().HasColumn(&domain.Translation{}, "ReviewStatus")
+
 	// 自动迁移表结构
 	err = db.AutoMigrate(
 		&domain.User{},
 		&domain.Project{},
+		&domain.ProjectSlugAlias{},
 		&domain.Language{},
 		&domain.Translation{},
 		&domain.ProjectMember{},
+		&domain.Organization{},
+		&domain.OrganizationMember{},
+		&domain.RoleReview{},
 		&domain.Invitation{},
+		&domain.InboundWebhook{},
+		&domain.InboundWebhookLog{},
+		&domain.Webhook{},
+		&domain.WebhookDelivery{},
+		&domain.ImportProfile{},
+		&domain.ReleaseThreshold{},
+		&domain.Assignment{},
+		&domain.Attachment{},
+		&domain.Namespace{},
+		&domain.Tag{},
+		&domain.KeyTag{},
+		&domain.Branch{},
+		&domain.BranchTranslation{},
+		&domain.Release{},
+		&domain.ReleaseTranslation{},
+		&domain.FigmaFrame{},
+		&domain.FigmaLayer{},
+		&domain.GlossaryTerm{},
+		&domain.GlossaryTranslation{},
+		&domain.OutboxEvent{},
+		&domain.AuditLog{},
+		&domain.TranslationHistory{},
+		&domain.Promotion{},
+		&domain.ProjectDuplicateJob{},
+		&domain.KeyLink{},
+		&domain.PersonalAccessToken{},
+		&domain.PasswordResetToken{},
+		&domain.APIKey{},
+		&domain.UserIdentity{},
+		&domain.UsageStat{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("自动迁移表结构失败: %w", err)
@@ -78,6 +115,24 @@ func InitDB(cfg *config.Config, zapLogger *zap.Logger, monitor *internal_utils.D
 		zapLogger.Warn("Warning during index creation", zap.Error(err))
 	}
 
+	// 使用全文索引搜索翻译时创建全文索引
+	if cfg.Search.Backend == "fulltext" {
+		if err := createFullTextIndex(db, zapLogger); err != nil {
+			zapLogger.Warn("Failed to create translation full-text index, search falls back to row scans", zap.Error(err))
+		}
+	}
+
+	// 为尚未记录活动的项目补充最近变更时间
+	if err := backfillProjectActivity(db); err != nil {
+		zapLogger.Warn("Failed to backfill project activity", zap.Error(err))
+	}
+
+	if addingReviewStatus {
+		if err := backfillReviewStatus(db); err != nil {
+			zapLogger.Warn("Failed to backfill translation review status", zap.Error(err))
+		}
+	}
+
 	// 初始化种子数据
 	if err := initSeedData(db, zapLogger); err != nil {
 		return nil, fmt.Errorf("初始化种子数据失败: %w", err)
@@ -86,6 +141,22 @@ func InitDB(cfg *config.Config, zapLogger *zap.Logger, monitor *internal_utils.D
 	return db, nil
 }
 
+// backfillProjectActivity 用翻译的最后修改时间补充项目的最近变更时间
+// 只处理尚未记录的项目，没有翻译的项目保持为空，闲置判断时按创建时间计算
+func backfillProjectActivity(db *gorm.DB) error {
+	return db.Exec(`UPDATE projects SET last_changed_at = (
+		SELECT MAX(t.updated_at) FROM translations t WHERE t.project_id = projects.id
+	) WHERE last_changed_at IS NULL`).Error
+}
+
+// backfillReviewStatus 将引入审核流程前已有的有效翻译标记为审核通过
+// 这些翻译此前按“有效即已审核”导出和发布，迁移后行为保持不变
+func backfillReviewStatus(db *gorm.DB) error {
+	return db.Model(&domain.Translation{}).
+		Where("status = ?", "active").
+		UpdateColumn("review_status", domain.ReviewStatusApproved).Error
+}
+
 // initSeedData 初始化种子数据
 func initSeedData(db *gorm.DB, zapLogger *zap.Logger) error {
 	// 创建管理员用户
@@ -284,6 +355,13 @@ func createOptimizationIndexes(db *gorm.DB, zapLogger *zap.Logger) error {
 			Columns:   []string{"project_id", "role"},
 			Unique:    false,
 		},
+		// 有效邀请码查询和过期邀请码清理
+		{
+			Name:      "idx_invitations_status_expires",
+			TableName: "invitations",
+			Columns:   []string{"status", "expires_at"},
+			Unique:    false,
+		},
 	}
 
 	for _, idx := range indexes {
@@ -330,6 +408,35 @@ func createIndexIfNotExists(db *gorm.DB, idx IndexDefinition, zapLogger *zap.Log
 	return nil
 }
 
+// fullTextIndexName 翻译键名和译文的全文索引
+const fullTextIndexName = "idx_translations_fulltext"
+
+// createFullTextIndex 在翻译的键名和译文上创建使用 ngram 分词的全文索引（索引不存在时）
+// ngram 分词支持中日韩文本，也使短语查询相当于包含查询；创建时关闭停用词，
+// 否则包含停用词（如 "at"、"on"）的 ngram 不进入索引。首次在大表上创建需要重建表，期间写入会被阻塞
+func createFullTextIndex(db *gorm.DB, zapLogger *zap.Logger) error {
+	exists, err := indexExists(db, "translations", fullTextIndexName)
+	if err != nil {
+		return fmt.Errorf("检查索引是否存在时出错: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	err = db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SET SESSION innodb_ft_enable_stopword = OFF").Error; err != nil {
+			return err
+		}
+		return conn.Exec("CREATE FULLTEXT INDEX " + fullTextIndexName + " ON translations (key_name, value) WITH PARSER ngram").Error
+	})
+	if err != nil {
+		return fmt.Errorf("创建全文索引失败: %w", err)
+	}
+
+	zapLogger.Info("Index created successfully", zap.String("index", fullTextIndexName))
+	return nil
+}
+
 // indexExists 检查索引是否存在
 func indexExists(db *gorm.DB, tableName, indexName string) (bool, error) {
 	var count int64
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// FigmaRepository Figma 设计稿画框和图层仓储实现
+type FigmaRepository struct {
+	db *gorm.DB
+}
+
+// NewFigmaRepository 创建 Figma 仓储实例
+func NewFigmaRepository(db *gorm.DB) *FigmaRepository {
+	return &FigmaRepository{db: db}
+}
+
+// GetFrameByID 根据ID获取画框，包含图层
+func (r *FigmaRepository) GetFrameByID(ctx context.Context, id uint64) (*domain.FigmaFrame, error) {
+	var frame domain.FigmaFrame
+	if err := dbFromContext(ctx, r.db).
+		Omit("Screenshot").
+		Preload("Layers", orderFigmaLayers).
+		First(&frame, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrFigmaFrameNotFound
+		}
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// GetFrameByNode 根据 Figma 文件和节点获取画框，不包含图层
+func (r *FigmaRepository) GetFrameByNode(ctx context.Context, projectID uint64, fileKey, nodeID string) (*domain.FigmaFrame, error) {
+	var frame domain.FigmaFrame
+	if err := dbFromContext(ctx, r.db).
+		Omit("Screenshot").
+		Where("project_id = ? AND file_key = ? AND node_id = ?", projectID, fileKey, nodeID).
+		First(&frame).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrFigmaFrameNotFound
+		}
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// FindFrames 查询项目中的画框及其图层
+func (r *FigmaRepository) FindFrames(ctx context.Context, projectID uint64, fileKey, keyName string) ([]*domain.FigmaFrame, error) {
+	db := dbFromContext(ctx, r.db)
+	query := db.Omit("Screenshot").Where("project_id = ?", projectID)
+	if fileKey != "" {
+		query = query.Where("file_key = ?", fileKey)
+	}
+	if keyName != "" {
+		query = query.Where("id IN (?)", db.Model(&domain.FigmaLayer{}).
+			Select("frame_id").
+			Where("project_id = ? AND key_name = ?", projectID, keyName))
+	}
+
+	var frames []*domain.FigmaFrame
+	if err := query.
+		Preload("Layers", orderFigmaLayers).
+		Order("file_key ASC, name ASC, id ASC").
+		Find(&frames).Error; err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// GetScreenshot 获取画框的截图内容和媒体类型
+func (r *FigmaRepository) GetScreenshot(ctx context.Context, id uint64) ([]byte, string, error) {
+	var frame domain.FigmaFrame
+	if err := dbFromContext(ctx, r.db).
+		Select("id", "screenshot", "screenshot_type").
+		First(&frame, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", domain.ErrFigmaFrameNotFound
+		}
+		return nil, "", err
+	}
+	return frame.Screenshot, frame.ScreenshotType, nil
+}
+
+// CreateFrame 创建画框
+func (r *FigmaRepository) CreateFrame(ctx context.Context, frame *domain.FigmaFrame) error {
+	return dbFromContext(ctx, r.db).Omit("Layers").Create(frame).Error
+}
+
+// UpdateFrame 更新画框的名称和更新人
+func (r *FigmaRepository) UpdateFrame(ctx context.Context, frame *domain.FigmaFrame) error {
+	return dbFromContext(ctx, r.db).
+		Model(frame).
+		Select("FileName", "Name", "UpdatedBy").
+		Updates(frame).Error
+}
+
+// UpdateScreenshot 替换画框的截图
+func (r *FigmaRepository) UpdateScreenshot(ctx context.Context, id uint64, data []byte, mediaType string, userID uint64) error {
+	return dbFromContext(ctx, r.db).
+		Model(&domain.FigmaFrame{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"screenshot":      data,
+			"screenshot_type": mediaType,
+			"updated_by":      userID,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+// ReplaceLayers 删除画框原有的图层后写入新的图层，调用方负责在事务中执行
+func (r *FigmaRepository) ReplaceLayers(ctx context.Context, frameID uint64, layers []*domain.FigmaLayer) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("frame_id = ?", frameID).Delete(&domain.FigmaLayer{}).Error; err != nil {
+		return err
+	}
+	if len(layers) == 0 {
+		return nil
+	}
+	return db.Create(&layers).Error
+}
+
+// DeleteFrame 删除画框及其图层
+func (r *FigmaRepository) DeleteFrame(ctx context.Context, id uint64) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("frame_id = ?", id).Delete(&domain.FigmaLayer{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(&domain.FigmaFrame{}, id).Error
+}
+
+// RenameKey 将引用该键的图层改为新键名，返回修改的条数
+func (r *FigmaRepository) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.FigmaLayer{}).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Update("key_name", newKeyName)
+	return result.RowsAffected, result.Error
+}
+
+// orderFigmaLayers 图层按键名排序
+func orderFigmaLayers(db *gorm.DB) *gorm.DB {
+	return db.Order("key_name ASC, node_id ASC")
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// GlossaryRepository 术语表仓储实现
+type GlossaryRepository struct {
+	db *gorm.DB
+}
+
+// NewGlossaryRepository 创建术语表仓储实例
+func NewGlossaryRepository(db *gorm.DB) *GlossaryRepository {
+	return &GlossaryRepository{db: db}
+}
+
+// GetByID 根据ID获取术语，包含各语言的译文
+func (r *GlossaryRepository) GetByID(ctx context.Context, id uint64) (*domain.GlossaryTerm, error) {
+	var term domain.GlossaryTerm
+	if err := preloadGlossaryTranslations(dbFromContext(ctx, r.db)).First(&term, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGlossaryTermNotFound
+		}
+		return nil, err
+	}
+	return &term, nil
+}
+
+// GetByProjectID 获取项目的所有术语，按术语排序
+func (r *GlossaryRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.GlossaryTerm, error) {
+	var terms []*domain.GlossaryTerm
+	if err := preloadGlossaryTranslations(dbFromContext(ctx, r.db)).
+		Where("project_id = ?", projectID).
+		Order("term ASC, id ASC").
+		Find(&terms).Error; err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// GetByTerm 获取项目中的术语
+func (r *GlossaryRepository) GetByTerm(ctx context.Context, projectID uint64, term string) (*domain.GlossaryTerm, error) {
+	var result domain.GlossaryTerm
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND term = ?", projectID, term).
+		First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrGlossaryTermNotFound
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Create 创建术语，不包括译文
+func (r *GlossaryRepository) Create(ctx context.Context, term *domain.GlossaryTerm) error {
+	return dbFromContext(ctx, r.db).Omit("Translations").Create(term).Error
+}
+
+// Update 更新术语的文本、说明、大小写设置和更新人
+func (r *GlossaryRepository) Update(ctx context.Context, term *domain.GlossaryTerm) error {
+	return dbFromContext(ctx, r.db).
+		Model(term).
+		Select("Term", "Definition", "CaseSensitive", "UpdatedBy").
+		Updates(term).Error
+}
+
+// ReplaceTranslations 删除术语原有的译文后写入新的译文，调用方负责在事务中执行
+func (r *GlossaryRepository) ReplaceTranslations(ctx context.Context, termID uint64, translations []domain.GlossaryTranslation) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("term_id = ?", termID).Delete(&domain.GlossaryTranslation{}).Error; err != nil {
+		return err
+	}
+	if len(translations) == 0 {
+		return nil
+	}
+	for i := range translations {
+		translations[i].ID = 0
+		translations[i].TermID = termID
+	}
+	return db.Omit("Language").Create(&translations).Error
+}
+
+// Delete 删除术语及其译文
+func (r *GlossaryRepository) Delete(ctx context.Context, id uint64) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("term_id = ?", id).Delete(&domain.GlossaryTranslation{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(&domain.GlossaryTerm{}, id).Error
+}
+
+// preloadGlossaryTranslations 预加载术语的译文及其语言，译文按语言排序
+func preloadGlossaryTranslations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Translations", func(db *gorm.DB) *gorm.DB { return db.Order("language_id ASC") }).
+		Preload("Translations.Language")
+}
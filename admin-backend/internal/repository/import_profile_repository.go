@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ImportProfileRepository 表格导入映射配置仓储实现
+type ImportProfileRepository struct {
+	db *gorm.DB
+}
+
+// NewImportProfileRepository 创建表格导入映射配置仓储实例
+func NewImportProfileRepository(db *gorm.DB) *ImportProfileRepository {
+	return &ImportProfileRepository{db: db}
+}
+
+// GetByID 根据ID获取映射配置
+func (r *ImportProfileRepository) GetByID(ctx context.Context, id uint64) (*domain.ImportProfile, error) {
+	var profile domain.ImportProfile
+	if err := dbFromContext(ctx, r.db).First(&profile, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrImportProfileNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetByProjectID 获取项目下的所有映射配置
+func (r *ImportProfileRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ImportProfile, error) {
+	var profiles []*domain.ImportProfile
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("id ASC").
+		Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Create 创建映射配置
+func (r *ImportProfileRepository) Create(ctx context.Context, profile *domain.ImportProfile) error {
+	return dbFromContext(ctx, r.db).Create(profile).Error
+}
+
+// Update 更新映射配置
+func (r *ImportProfileRepository) Update(ctx context.Context, profile *domain.ImportProfile) error {
+	return dbFromContext(ctx, r.db).Save(profile).Error
+}
+
+// Delete 删除映射配置
+func (r *ImportProfileRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.ImportProfile{}, id).Error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// InboundWebhookRepository 入站 Webhook 仓储实现
+type InboundWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewInboundWebhookRepository 创建入站 Webhook 仓储实例
+func NewInboundWebhookRepository(db *gorm.DB) *InboundWebhookRepository {
+	return &InboundWebhookRepository{db: db}
+}
+
+// GetByID 根据ID获取入站 Webhook
+func (r *InboundWebhookRepository) GetByID(ctx context.Context, id uint64) (*domain.InboundWebhook, error) {
+	var webhook domain.InboundWebhook
+	if err := dbFromContext(ctx, r.db).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetByProjectID 获取项目下的所有入站 Webhook
+func (r *InboundWebhookRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.InboundWebhook, error) {
+	var webhooks []*domain.InboundWebhook
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("id ASC").
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Create 创建入站 Webhook
+func (r *InboundWebhookRepository) Create(ctx context.Context, webhook *domain.InboundWebhook) error {
+	return dbFromContext(ctx, r.db).Create(webhook).Error
+}
+
+// Update 更新入站 Webhook
+func (r *InboundWebhookRepository) Update(ctx context.Context, webhook *domain.InboundWebhook) error {
+	return dbFromContext(ctx, r.db).Save(webhook).Error
+}
+
+// Delete 删除入站 Webhook
+func (r *InboundWebhookRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.InboundWebhook{}, id).Error
+}
+
+// CreateLog 记录一次入站导入
+func (r *InboundWebhookRepository) CreateLog(ctx context.Context, log *domain.InboundWebhookLog) error {
+	return dbFromContext(ctx, r.db).Create(log).Error
+}
+
+// GetLogs 分页获取入站导入日志
+func (r *InboundWebhookRepository) GetLogs(ctx context.Context, webhookID uint64, limit, offset int) ([]*domain.InboundWebhookLog, int64, error) {
+	var logs []*domain.InboundWebhookLog
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&domain.InboundWebhookLog{}).Where("webhook_id = ?", webhookID)
+
+	// 获取总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取分页数据
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
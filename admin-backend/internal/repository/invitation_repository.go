@@ -7,6 +7,7 @@ import (
 	"yflow/internal/domain"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // InvitationRepository 邀请码仓储实现
@@ -22,7 +23,7 @@ func NewInvitationRepository(db *gorm.DB) *InvitationRepository {
 // GetByID 根据ID获取邀请码
 func (r *InvitationRepository) GetByID(ctx context.Context, id uint64) (*domain.Invitation, error) {
 	var invitation domain.Invitation
-	if err := r.db.WithContext(ctx).Preload("Inviter").First(&invitation, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Inviter").First(&invitation, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrInvitationNotFound
 		}
@@ -34,7 +35,7 @@ func (r *InvitationRepository) GetByID(ctx context.Context, id uint64) (*domain.
 // GetByCode 根据邀请码获取邀请
 func (r *InvitationRepository) GetByCode(ctx context.Context, code string) (*domain.Invitation, error) {
 	var invitation domain.Invitation
-	if err := r.db.WithContext(ctx).Preload("Inviter").Where("code = ?", code).First(&invitation).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Inviter").Where("code = ?", code).First(&invitation).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrInvitationNotFound
 		}
@@ -48,7 +49,7 @@ func (r *InvitationRepository) GetByInviter(ctx context.Context, inviterID uint6
 	var invitations []*domain.Invitation
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Invitation{}).Where("inviter_id = ?", inviterID)
+	query := dbFromContext(ctx, r.db).Model(&domain.Invitation{}).Where("inviter_id = ?", inviterID)
 
 	// 获取总数
 	if err := query.Count(&total).Error; err != nil {
@@ -63,10 +64,10 @@ func (r *InvitationRepository) GetByInviter(ctx context.Context, inviterID uint6
 	return invitations, total, nil
 }
 
-// GetActiveInvitations 获取所有有效的邀请
+// GetActiveInvitations 获取所有有效的邀请，使用 (status, expires_at) 索引
 func (r *InvitationRepository) GetActiveInvitations(ctx context.Context) ([]*domain.Invitation, error) {
 	var invitations []*domain.Invitation
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("status = ?", domain.InvitationStatusActive).
 		Where("expires_at > ?", time.Now()).
 		Find(&invitations).Error; err != nil {
@@ -77,39 +78,104 @@ func (r *InvitationRepository) GetActiveInvitations(ctx context.Context) ([]*dom
 
 // Create 创建邀请码
 func (r *InvitationRepository) Create(ctx context.Context, invitation *domain.Invitation) error {
-	return r.db.WithContext(ctx).Create(invitation).Error
+	return dbFromContext(ctx, r.db).Create(invitation).Error
 }
 
 // Update 更新邀请码
 func (r *InvitationRepository) Update(ctx context.Context, invitation *domain.Invitation) error {
-	return r.db.WithContext(ctx).Save(invitation).Error
+	return dbFromContext(ctx, r.db).Save(invitation).Error
 }
 
-// MarkAsUsed 标记邀请码已使用
+// MarkAsUsed 原子地增加邀请码的使用次数，达到最多使用次数时标记为已使用
+// 邀请码不是有效状态或次数已用完时返回 ErrInvitationUsed
 func (r *InvitationRepository) MarkAsUsed(ctx context.Context, code string, userID uint64) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).Model(&domain.Invitation{}).
-		Where("code = ?", code).
+	// GORM 按列名顺序生成 SET，MySQL 从左到右求值：status 先于 use_count 计算，使用的是加一前的次数
+	result := dbFromContext(ctx, r.db).Model(&domain.Invitation{}).
+		Where("code = ? AND status = ? AND use_count < max_uses", code, domain.InvitationStatusActive).
 		Updates(map[string]interface{}{
-			"status":   domain.InvitationStatusUsed,
-			"used_at":  now,
-			"used_by":  userID,
-		}).Error
+			"status":    gorm.Expr("CASE WHEN use_count + 1 >= max_uses THEN ? ELSE status END", domain.InvitationStatusUsed),
+			"use_count": gorm.Expr("use_count + 1"),
+			"used_at":   now,
+			"used_by":   userID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvitationUsed
+	}
+	return nil
 }
 
 // Revoke 撤销邀请码
 func (r *InvitationRepository) Revoke(ctx context.Context, code string) error {
-	return r.db.WithContext(ctx).Model(&domain.Invitation{}).
+	return dbFromContext(ctx, r.db).Model(&domain.Invitation{}).
 		Where("code = ?", code).
 		Update("status", domain.InvitationStatusRevoked).Error
 }
 
 // Delete 根据邀请码删除邀请
 func (r *InvitationRepository) Delete(ctx context.Context, code string) error {
-	return r.db.WithContext(ctx).Where("code = ?", code).Delete(&domain.Invitation{}).Error
+	return dbFromContext(ctx, r.db).Where("code = ?", code).Delete(&domain.Invitation{}).Error
 }
 
 // DeleteByID 根据ID删除邀请
 func (r *InvitationRepository) DeleteByID(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&domain.Invitation{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&domain.Invitation{}, id).Error
+}
+
+// CreateBatch 在一个事务中批量创建邀请码
+func (r *InvitationRepository) CreateBatch(ctx context.Context, invitations []*domain.Invitation) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(invitations, 100).Error
+	})
+}
+
+// RevokeByBatch 撤销批次中仍然有效的邀请码，返回撤销的数量
+func (r *InvitationRepository) RevokeByBatch(ctx context.Context, batchLabel string) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&domain.Invitation{}).
+		Where("batch_label = ? AND status = ?", batchLabel, domain.InvitationStatusActive).
+		Update("status", domain.InvitationStatusRevoked)
+	return result.RowsAffected, result.Error
+}
+
+// ExpireActive 将已过期但状态仍为 active 的邀请码标记为 expired，每次最多 limit 条，最早过期的在前
+// 先锁定再更新，同时被使用或撤销的邀请码不会被标记
+func (r *InvitationRepository) ExpireActive(ctx context.Context, now time.Time, limit int) ([]*domain.Invitation, error) {
+	var invitations []*domain.Invitation
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND expires_at <= ?", domain.InvitationStatusActive, now).
+			Order("expires_at, id").
+			Limit(limit).
+			Find(&invitations).Error; err != nil {
+			return err
+		}
+		if len(invitations) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(invitations))
+		for i, invitation := range invitations {
+			ids[i] = invitation.ID
+			invitation.Status = domain.InvitationStatusExpired
+		}
+		return tx.Model(&domain.Invitation{}).
+			Where("id IN ?", ids).
+			Update("status", domain.InvitationStatusExpired).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// CountByBatch 统计批次中的邀请码数量
+func (r *InvitationRepository) CountByBatch(ctx context.Context, batchLabel string) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&domain.Invitation{}).
+		Where("batch_label = ?", batchLabel).
+		Count(&count).Error
+	return count, err
 }
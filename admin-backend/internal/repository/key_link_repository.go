@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KeyLinkRepository 跨项目键链接仓储实现
+type KeyLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewKeyLinkRepository 创建跨项目键链接仓储实例
+func NewKeyLinkRepository(db *gorm.DB) *KeyLinkRepository {
+	return &KeyLinkRepository{db: db}
+}
+
+// GetBySource 获取从源项目链接出去的键，keyNames 为空时返回项目的全部链接
+func (r *KeyLinkRepository) GetBySource(ctx context.Context, sourceProjectID uint64, keyNames []string) ([]*domain.KeyLink, error) {
+	query := dbFromContext(ctx, r.db).Where("source_project_id = ?", sourceProjectID)
+	if len(keyNames) > 0 {
+		query = query.Where("key_name IN ?", keyNames)
+	}
+	var links []*domain.KeyLink
+	if err := query.Order("id ASC").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetByProject 获取项目作为源项目或目标项目的链接，按键名排序
+func (r *KeyLinkRepository) GetByProject(ctx context.Context, projectID uint64) ([]*domain.KeyLink, error) {
+	var links []*domain.KeyLink
+	if err := dbFromContext(ctx, r.db).
+		Where("source_project_id = ? OR target_project_id = ?", projectID, projectID).
+		Order("key_name ASC, id ASC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// Upsert 写入链接，目标项目中的键已有链接时改为新的源项目
+func (r *KeyLinkRepository) Upsert(ctx context.Context, links []*domain.KeyLink) error {
+	if len(links) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		// 基于唯一索引 idx_key_link_target (target_project_id, key_name)
+		DoUpdates: clause.AssignmentColumns([]string{"source_project_id", "created_by", "created_at", "last_synced_at"}),
+	}).CreateInBatches(links, 500).Error
+}
+
+// MarkSynced 记录链接的同步时间
+func (r *KeyLinkRepository) MarkSynced(ctx context.Context, ids []uint64, syncedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).Model(&domain.KeyLink{}).
+		Where("id IN ?", ids).
+		Update("last_synced_at", syncedAt).Error
+}
+
+// Delete 删除目标项目中键的链接，不存在时返回 ErrKeyLinkNotFound
+func (r *KeyLinkRepository) Delete(ctx context.Context, targetProjectID uint64, keyName string) error {
+	result := dbFromContext(ctx, r.db).
+		Where("target_project_id = ? AND key_name = ?", targetProjectID, keyName).
+		Delete(&domain.KeyLink{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrKeyLinkNotFound
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 	"yflow/internal/domain"
 
 	"gorm.io/gorm"
@@ -20,8 +21,12 @@ func NewLanguageRepository(db *gorm.DB) *LanguageRepository {
 
 // GetByID 根据ID获取语言
 func (r *LanguageRepository) GetByID(ctx context.Context, id uint64) (*domain.Language, error) {
+	if language, ok := findCachedLanguage(ctx, func(l *domain.Language) bool { return l.ID == id }); ok {
+		return language, nil
+	}
+
 	var language domain.Language
-	if err := r.db.WithContext(ctx).First(&language, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&language, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrLanguageNotFound
 		}
@@ -36,17 +41,39 @@ func (r *LanguageRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*dom
 		return []*domain.Language{}, nil
 	}
 
+	if cached, ok := domain.RequestCacheFromContext(ctx).Languages(domain.OrganizationFromContext(ctx)); ok {
+		wanted := make(map[uint64]bool, len(ids))
+		for _, id := range ids {
+			wanted[id] = true
+		}
+		languages := make([]*domain.Language, 0, len(ids))
+		for _, language := range cached {
+			if wanted[language.ID] {
+				languages = append(languages, language)
+				delete(wanted, language.ID)
+			}
+		}
+		// 缓存只包含当前组织的语言，有未命中的 ID 时回退到数据库查询
+		if len(wanted) == 0 {
+			return languages, nil
+		}
+	}
+
 	var languages []*domain.Language
-	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&languages).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", ids).Find(&languages).Error; err != nil {
 		return nil, err
 	}
 	return languages, nil
 }
 
-// GetByCode 根据代码获取语言
+// GetByCode 根据代码获取当前组织的语言
 func (r *LanguageRepository) GetByCode(ctx context.Context, code string) (*domain.Language, error) {
+	if language, ok := findCachedLanguage(ctx, func(l *domain.Language) bool { return strings.EqualFold(l.Code, code) }); ok {
+		return language, nil
+	}
+
 	var language domain.Language
-	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&language).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("organization_id = ? AND code = ?", domain.OrganizationFromContext(ctx), code).First(&language).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrLanguageNotFound
 		}
@@ -55,34 +82,50 @@ func (r *LanguageRepository) GetByCode(ctx context.Context, code string) (*domai
 	return &language, nil
 }
 
-// GetAll 获取所有语言
+// GetAll 获取当前组织的所有语言，同一请求内只查询一次数据库
 func (r *LanguageRepository) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	organizationID := domain.OrganizationFromContext(ctx)
+	cache, populate := cacheForRead(ctx)
+	if languages, ok := cache.Languages(organizationID); ok {
+		return languages, nil
+	}
+
 	var languages []*domain.Language
-	if err := r.db.WithContext(ctx).Find(&languages).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("organization_id = ?", organizationID).Find(&languages).Error; err != nil {
 		return nil, err
 	}
+	if populate {
+		cache.SetLanguages(organizationID, languages)
+	}
 	return languages, nil
 }
 
 // Create 创建语言
 func (r *LanguageRepository) Create(ctx context.Context, language *domain.Language) error {
-	return r.db.WithContext(ctx).Create(language).Error
+	domain.RequestCacheFromContext(ctx).InvalidateLanguages()
+	return dbFromContext(ctx, r.db).Create(language).Error
 }
 
 // Update 更新语言
 func (r *LanguageRepository) Update(ctx context.Context, language *domain.Language) error {
-	return r.db.WithContext(ctx).Save(language).Error
+	domain.RequestCacheFromContext(ctx).InvalidateLanguages()
+	return dbFromContext(ctx, r.db).Save(language).Error
 }
 
 // Delete 删除语言
 func (r *LanguageRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&domain.Language{}, id).Error
+	domain.RequestCacheFromContext(ctx).InvalidateLanguages()
+	return dbFromContext(ctx, r.db).Delete(&domain.Language{}, id).Error
 }
 
-// GetDefault 获取默认语言
+// GetDefault 获取当前组织的默认语言
 func (r *LanguageRepository) GetDefault(ctx context.Context) (*domain.Language, error) {
+	if language, ok := findCachedLanguage(ctx, func(l *domain.Language) bool { return l.IsDefault }); ok {
+		return language, nil
+	}
+
 	var language domain.Language
-	if err := r.db.WithContext(ctx).Where("is_default = ?", true).First(&language).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("organization_id = ? AND is_default = ?", domain.OrganizationFromContext(ctx), true).First(&language).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrLanguageNotFound
 		}
@@ -90,3 +133,17 @@ func (r *LanguageRepository) GetDefault(ctx context.Context) (*domain.Language,
 	}
 	return &language, nil
 }
+
+// findCachedLanguage 在请求级缓存的当前组织语言列表中查找语言
+func findCachedLanguage(ctx context.Context, match func(*domain.Language) bool) (*domain.Language, bool) {
+	languages, ok := domain.RequestCacheFromContext(ctx).Languages(domain.OrganizationFromContext(ctx))
+	if !ok {
+		return nil, false
+	}
+	for _, language := range languages {
+		if match(language) {
+			return language, true
+		}
+	}
+	return nil, false
+}
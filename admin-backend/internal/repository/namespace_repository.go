@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// NamespaceRepository 命名空间仓储实现
+type NamespaceRepository struct {
+	db *gorm.DB
+}
+
+// NewNamespaceRepository 创建命名空间仓储实例
+func NewNamespaceRepository(db *gorm.DB) *NamespaceRepository {
+	return &NamespaceRepository{db: db}
+}
+
+// GetByID 根据ID获取命名空间
+func (r *NamespaceRepository) GetByID(ctx context.Context, id uint64) (*domain.Namespace, error) {
+	var namespace domain.Namespace
+	if err := dbFromContext(ctx, r.db).First(&namespace, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNamespaceNotFound
+		}
+		return nil, err
+	}
+	return &namespace, nil
+}
+
+// GetByName 根据名称获取项目中的命名空间
+func (r *NamespaceRepository) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Namespace, error) {
+	var namespace domain.Namespace
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND name = ?", projectID, name).
+		First(&namespace).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNamespaceNotFound
+		}
+		return nil, err
+	}
+	return &namespace, nil
+}
+
+// GetByProjectID 获取项目的命名空间（按名称排序），包含每个命名空间中有效翻译的键数量
+func (r *NamespaceRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Namespace, error) {
+	db := dbFromContext(ctx, r.db)
+	var namespaces []*domain.Namespace
+	if err := db.Where("project_id = ?", projectID).Order("name ASC").Find(&namespaces).Error; err != nil {
+		return nil, err
+	}
+	if len(namespaces) == 0 {
+		return namespaces, nil
+	}
+
+	var counts []struct {
+		NamespaceID uint64 `gorm:"column:namespace_id"`
+		Keys        int64  `gorm:"column:keys"`
+	}
+	if err := db.Model(&domain.Translation{}).
+		Select("namespace_id, COUNT(DISTINCT key_name) AS `keys`").
+		Where("project_id = ? AND namespace_id <> 0", projectID).
+		Group("namespace_id").
+		Find(&counts).Error; err != nil {
+		return nil, err
+	}
+	keys := make(map[uint64]int64, len(counts))
+	for _, count := range counts {
+		keys[count.NamespaceID] = count.Keys
+	}
+	for _, namespace := range namespaces {
+		namespace.Keys = keys[namespace.ID]
+	}
+	return namespaces, nil
+}
+
+// Create 创建命名空间
+func (r *NamespaceRepository) Create(ctx context.Context, namespace *domain.Namespace) error {
+	return dbFromContext(ctx, r.db).Create(namespace).Error
+}
+
+// Update 更新命名空间
+func (r *NamespaceRepository) Update(ctx context.Context, namespace *domain.Namespace) error {
+	return dbFromContext(ctx, r.db).Save(namespace).Error
+}
+
+// Delete 删除命名空间，其中的键（包括已软删除的翻译）回到未划分状态
+func (r *NamespaceRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().
+			Model(&domain.Translation{}).
+			Where("namespace_id = ?", id).
+			Update("namespace_id", 0).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Namespace{}, id).Error
+	})
+}
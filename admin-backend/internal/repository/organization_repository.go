@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository 组织仓储实现
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository 创建组织仓储实例
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// Create 创建组织
+func (r *OrganizationRepository) Create(ctx context.Context, organization *domain.Organization) error {
+	return dbFromContext(ctx, r.db).Create(organization).Error
+}
+
+// GetByID 根据ID获取组织
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uint64) (*domain.Organization, error) {
+	var organization domain.Organization
+	if err := dbFromContext(ctx, r.db).First(&organization, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetByName 根据名称获取组织
+func (r *OrganizationRepository) GetByName(ctx context.Context, name string) (*domain.Organization, error) {
+	var organization domain.Organization
+	if err := dbFromContext(ctx, r.db).Where("name = ?", name).First(&organization).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetAll 获取所有组织，按名称排序
+func (r *OrganizationRepository) GetAll(ctx context.Context) ([]*domain.Organization, error) {
+	var organizations []*domain.Organization
+	if err := dbFromContext(ctx, r.db).Order("name").Find(&organizations).Error; err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// GetByUser 获取用户所在的组织，按名称排序
+func (r *OrganizationRepository) GetByUser(ctx context.Context, userID uint64) ([]*domain.Organization, error) {
+	var organizations []*domain.Organization
+	err := dbFromContext(ctx, r.db).
+		Joins("JOIN organization_members ON organization_members.organization_id = organizations.id").
+		Where("organization_members.user_id = ?", userID).
+		Order("organizations.name").
+		Find(&organizations).Error
+	if err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// Update 更新组织
+func (r *OrganizationRepository) Update(ctx context.Context, organization *domain.Organization) error {
+	return dbFromContext(ctx, r.db).Save(organization).Error
+}
+
+// Delete 删除组织及其成员、语言和 API Key
+func (r *OrganizationRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", id).Delete(&domain.OrganizationMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("organization_id = ?", id).Delete(&domain.Language{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("organization_id = ?", id).Delete(&domain.APIKey{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Organization{}, id).Error
+	})
+}
+
+// OrganizationMemberRepository 组织成员仓储实现
+type OrganizationMemberRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationMemberRepository 创建组织成员仓储实例
+func NewOrganizationMemberRepository(db *gorm.DB) *OrganizationMemberRepository {
+	return &OrganizationMemberRepository{db: db}
+}
+
+// Create 创建组织成员
+func (r *OrganizationMemberRepository) Create(ctx context.Context, member *domain.OrganizationMember) error {
+	return dbFromContext(ctx, r.db).Create(member).Error
+}
+
+// Get 获取用户在组织中的成员关系
+func (r *OrganizationMemberRepository) Get(ctx context.Context, organizationID, userID uint64) (*domain.OrganizationMember, error) {
+	var member domain.OrganizationMember
+	err := dbFromContext(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrganizationMemberNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetByOrganization 获取组织的所有成员
+func (r *OrganizationMemberRepository) GetByOrganization(ctx context.Context, organizationID uint64) ([]*domain.OrganizationMember, error) {
+	var members []*domain.OrganizationMember
+	if err := dbFromContext(ctx, r.db).Where("organization_id = ?", organizationID).Order("id").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// Update 更新组织成员
+func (r *OrganizationMemberRepository) Update(ctx context.Context, member *domain.OrganizationMember) error {
+	return dbFromContext(ctx, r.db).Save(member).Error
+}
+
+// Delete 删除组织成员
+func (r *OrganizationMemberRepository) Delete(ctx context.Context, organizationID, userID uint64) error {
+	return dbFromContext(ctx, r.db).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(&domain.OrganizationMember{}).Error
+}
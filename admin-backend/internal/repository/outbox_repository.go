@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository 事务发件箱仓储实现
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository 创建事务发件箱仓储实例
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Create 写入事件（在事务中调用时与业务数据一同提交）
+func (r *OutboxRepository) Create(ctx context.Context, event *domain.OutboxEvent) error {
+	return dbFromContext(ctx, r.db).Create(event).Error
+}
+
+// GetDue 获取到期待投递的事件，按写入顺序返回
+func (r *OutboxRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEvent, error) {
+	var events []*domain.OutboxEvent
+	if err := dbFromContext(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", domain.OutboxStatusPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Claim 领取事件：尝试次数加一并推迟下次投递时间，尝试次数不匹配说明已被其他实例领取
+func (r *OutboxRepository) Claim(ctx context.Context, id uint64, attempts int, leaseUntil time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.OutboxEvent{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, domain.OutboxStatusPending, attempts).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": leaseUntil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkDelivered 标记事件已投递
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id uint64) error {
+	now := time.Now()
+	return dbFromContext(ctx, r.db).
+		Model(&domain.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":       domain.OutboxStatusDelivered,
+			"delivered_at": &now,
+			"last_error":   "",
+		}).Error
+}
+
+// MarkFailed 记录投递失败，status 为 pending 时在 nextAttemptAt 后重试
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uint64, status string, nextAttemptAt time.Time, lastError string) error {
+	return dbFromContext(ctx, r.db).
+		Model(&domain.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"next_attempt_at": nextAttemptAt,
+			"last_error":      lastError,
+		}).Error
+}
+
+// DeleteDeliveredBefore 删除指定时间之前已投递的事件
+func (r *OutboxRepository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("status = ? AND delivered_at < ?", domain.OutboxStatusDelivered, before).
+		Delete(&domain.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
+
+// GetFailed 获取死信事件，最近写入的在前
+func (r *OutboxRepository) GetFailed(ctx context.Context, eventType string, limit, offset int) ([]*domain.OutboxEvent, int64, error) {
+	query := dbFromContext(ctx, r.db).Model(&domain.OutboxEvent{}).Where("status = ?", domain.OutboxStatusFailed)
+	if eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []*domain.OutboxEvent
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// Requeue 将死信事件重置为待投递，保留最后一次错误便于排查
+func (r *OutboxRepository) Requeue(ctx context.Context, ids []uint64, nextAttemptAt time.Time) (int64, error) {
+	query := dbFromContext(ctx, r.db).Model(&domain.OutboxEvent{}).Where("status = ?", domain.OutboxStatusFailed)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":          domain.OutboxStatusPending,
+		"attempts":        0,
+		"next_attempt_at": nextAttemptAt,
+	})
+	return result.RowsAffected, result.Error
+}
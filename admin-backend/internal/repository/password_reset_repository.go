@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetRepository 找回密码令牌仓储实现
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository 创建找回密码令牌仓储实例
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// Create 创建令牌
+func (r *PasswordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	return dbFromContext(ctx, r.db).Create(token).Error
+}
+
+// GetByHash 根据令牌哈希获取令牌
+func (r *PasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	if err := dbFromContext(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed 将未使用的令牌标记为已使用，并发使用同一令牌时只有一个成功
+func (r *PasswordResetRepository) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteExpiredBefore 删除在 before 之前过期的令牌
+func (r *PasswordResetRepository) DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).Where("expires_at < ?", before).Delete(&domain.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenRepository 个人访问令牌仓储实现
+type PersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository 创建个人访问令牌仓储实例
+func NewPersonalAccessTokenRepository(db *gorm.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{db: db}
+}
+
+// Create 创建令牌
+func (r *PersonalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	return dbFromContext(ctx, r.db).Create(token).Error
+}
+
+// GetByID 根据ID获取令牌
+func (r *PersonalAccessTokenRepository) GetByID(ctx context.Context, id uint64) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	if err := dbFromContext(ctx, r.db).First(&token, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAccessTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByHash 根据令牌哈希获取令牌
+func (r *PersonalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	if err := dbFromContext(ctx, r.db).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAccessTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByUserID 获取用户的所有令牌（最新的在前）
+func (r *PersonalAccessTokenRepository) GetByUserID(ctx context.Context, userID uint64) ([]*domain.PersonalAccessToken, error) {
+	var tokens []*domain.PersonalAccessToken
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("id DESC").
+		Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// CountByUserID 统计用户的令牌数量
+func (r *PersonalAccessTokenRepository) CountByUserID(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&domain.PersonalAccessToken{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// UpdateLastUsed 记录令牌最近的使用时间和来源 IP
+func (r *PersonalAccessTokenRepository) UpdateLastUsed(ctx context.Context, id uint64, usedAt time.Time, ip string) error {
+	return dbFromContext(ctx, r.db).Model(&domain.PersonalAccessToken{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": usedAt,
+			"last_used_ip": ip,
+		}).Error
+}
+
+// Delete 删除令牌
+func (r *PersonalAccessTokenRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.PersonalAccessToken{}, id).Error
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ProjectDuplicateJobRepository 项目复制任务仓储实现
+type ProjectDuplicateJobRepository struct {
+	db *gorm.DB
+}
+
+// NewProjectDuplicateJobRepository 创建项目复制任务仓储实例
+func NewProjectDuplicateJobRepository(db *gorm.DB) *ProjectDuplicateJobRepository {
+	return &ProjectDuplicateJobRepository{db: db}
+}
+
+// Create 创建复制任务
+func (r *ProjectDuplicateJobRepository) Create(ctx context.Context, job *domain.ProjectDuplicateJob) error {
+	return dbFromContext(ctx, r.db).Create(job).Error
+}
+
+// GetByID 根据ID获取复制任务
+func (r *ProjectDuplicateJobRepository) GetByID(ctx context.Context, id uint64) (*domain.ProjectDuplicateJob, error) {
+	var job domain.ProjectDuplicateJob
+	if err := dbFromContext(ctx, r.db).First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrDuplicateJobNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Update 更新复制任务的进度和状态
+func (r *ProjectDuplicateJobRepository) Update(ctx context.Context, job *domain.ProjectDuplicateJob) error {
+	return dbFromContext(ctx, r.db).Save(job).Error
+}
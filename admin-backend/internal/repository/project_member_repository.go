@@ -6,6 +6,7 @@ import (
 	"yflow/internal/domain"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProjectMemberRepository 项目成员仓储实现
@@ -21,7 +22,7 @@ func NewProjectMemberRepository(db *gorm.DB) *ProjectMemberRepository {
 // GetByProjectAndUser 根据项目ID和用户ID获取成员关系
 func (r *ProjectMemberRepository) GetByProjectAndUser(ctx context.Context, projectID, userID uint64) (*domain.ProjectMember, error) {
 	var member domain.ProjectMember
-	if err := r.db.WithContext(ctx).Where("project_id = ? AND user_id = ?", projectID, userID).First(&member).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("project_id = ? AND user_id = ?", projectID, userID).First(&member).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrMemberNotFound
 		}
@@ -33,7 +34,7 @@ func (r *ProjectMemberRepository) GetByProjectAndUser(ctx context.Context, proje
 // GetByProjectID 根据项目ID获取所有成员
 func (r *ProjectMemberRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ProjectMember, error) {
 	var members []*domain.ProjectMember
-	if err := r.db.WithContext(ctx).Where("project_id = ?", projectID).Preload("User").Find(&members).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("project_id = ?", projectID).Preload("User").Find(&members).Error; err != nil {
 		return nil, err
 	}
 	return members, nil
@@ -42,7 +43,16 @@ func (r *ProjectMemberRepository) GetByProjectID(ctx context.Context, projectID
 // GetByUserID 根据用户ID获取所有项目成员关系
 func (r *ProjectMemberRepository) GetByUserID(ctx context.Context, userID uint64) ([]*domain.ProjectMember, error) {
 	var members []*domain.ProjectMember
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("Project").Find(&members).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Preload("Project").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetBySource 获取指定来源和角色的全部成员关系
+func (r *ProjectMemberRepository) GetBySource(ctx context.Context, source, role string) ([]*domain.ProjectMember, error) {
+	var members []*domain.ProjectMember
+	if err := dbFromContext(ctx, r.db).Where("source = ? AND role = ?", source, role).Find(&members).Error; err != nil {
 		return nil, err
 	}
 	return members, nil
@@ -50,15 +60,22 @@ func (r *ProjectMemberRepository) GetByUserID(ctx context.Context, userID uint64
 
 // Create 创建项目成员关系
 func (r *ProjectMemberRepository) Create(ctx context.Context, member *domain.ProjectMember) error {
-	return r.db.WithContext(ctx).Create(member).Error
+	return dbFromContext(ctx, r.db).Create(member).Error
+}
+
+// CreateOrRestore 创建成员关系，唯一索引冲突（已被软删除的成员关系）时恢复并更新角色和来源
+func (r *ProjectMemberRepository) CreateOrRestore(ctx context.Context, member *domain.ProjectMember) error {
+	return dbFromContext(ctx, r.db).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"role", "source", "updated_by", "updated_at", "deleted_at"}),
+	}).Create(member).Error
 }
 
 // Update 更新项目成员关系
 func (r *ProjectMemberRepository) Update(ctx context.Context, member *domain.ProjectMember) error {
-	return r.db.WithContext(ctx).Save(member).Error
+	return dbFromContext(ctx, r.db).Save(member).Error
 }
 
 // Delete 删除项目成员关系
 func (r *ProjectMemberRepository) Delete(ctx context.Context, projectID, userID uint64) error {
-	return r.db.WithContext(ctx).Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&domain.ProjectMember{}).Error
+	return dbFromContext(ctx, r.db).Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&domain.ProjectMember{}).Error
 }
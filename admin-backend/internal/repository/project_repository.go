@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 	"yflow/internal/domain"
 
 	"gorm.io/gorm"
@@ -18,15 +19,23 @@ func NewProjectRepository(db *gorm.DB) *ProjectRepository {
 	return &ProjectRepository{db: db}
 }
 
-// GetByID 根据ID获取项目
+// GetByID 根据ID获取项目，同一请求内只查询一次数据库
 func (r *ProjectRepository) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	cache, populate := cacheForRead(ctx)
+	if project, ok := cache.Project(id); ok {
+		return project, nil
+	}
+
 	var project domain.Project
-	if err := r.db.WithContext(ctx).First(&project, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&project, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrProjectNotFound
 		}
 		return nil, err
 	}
+	if populate {
+		cache.SetProject(&project)
+	}
 	return &project, nil
 }
 
@@ -37,7 +46,7 @@ func (r *ProjectRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*doma
 	}
 
 	var projects []*domain.Project
-	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&projects).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", ids).Find(&projects).Error; err != nil {
 		return nil, err
 	}
 	return projects, nil
@@ -46,7 +55,22 @@ func (r *ProjectRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*doma
 // GetBySlug 根据Slug获取项目
 func (r *ProjectRepository) GetBySlug(ctx context.Context, slug string) (*domain.Project, error) {
 	var project domain.Project
-	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&project).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("slug = ?", slug).First(&project).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetBySlugAlias 根据旧项目标识（别名）获取项目
+func (r *ProjectRepository) GetBySlugAlias(ctx context.Context, slug string) (*domain.Project, error) {
+	var project domain.Project
+	if err := dbFromContext(ctx, r.db).
+		Joins("JOIN project_slug_aliases ON project_slug_aliases.project_id = projects.id").
+		Where("project_slug_aliases.slug = ?", slug).
+		First(&project).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrProjectNotFound
 		}
@@ -55,13 +79,48 @@ func (r *ProjectRepository) GetBySlug(ctx context.Context, slug string) (*domain
 	return &project, nil
 }
 
+// SlugTaken 检查项目标识是否已被占用
+// 已删除项目的标识和其他项目的别名同样视为占用；excludeProjectID 自身的别名不算占用
+func (r *ProjectRepository) SlugTaken(ctx context.Context, slug string, excludeProjectID uint64) (bool, error) {
+	var count int64
+	if err := dbFromContext(ctx, r.db).Unscoped().
+		Model(&domain.Project{}).
+		Where("slug = ? AND id <> ?", slug, excludeProjectID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := dbFromContext(ctx, r.db).
+		Model(&domain.ProjectSlugAlias{}).
+		Where("slug = ? AND project_id <> ?", slug, excludeProjectID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateSlugAlias 创建项目标识别名
+func (r *ProjectRepository) CreateSlugAlias(ctx context.Context, alias *domain.ProjectSlugAlias) error {
+	return dbFromContext(ctx, r.db).Create(alias).Error
+}
+
+// DeleteSlugAlias 删除项目的指定标识别名
+func (r *ProjectRepository) DeleteSlugAlias(ctx context.Context, projectID uint64, slug string) error {
+	return dbFromContext(ctx, r.db).
+		Where("project_id = ? AND slug = ?", projectID, slug).
+		Delete(&domain.ProjectSlugAlias{}).Error
+}
+
 // GetAll 获取所有项目（分页）
-func (r *ProjectRepository) GetAll(ctx context.Context, limit, offset int, keyword string) ([]*domain.Project, int64, error) {
+func (r *ProjectRepository) GetAll(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.Project, int64, error) {
 	var projects []*domain.Project
 	var total int64
 
 	// 构建基础查询条件，GORM会自动处理软删除
-	baseQuery := r.db.WithContext(ctx).Model(&domain.Project{})
+	baseQuery := dbFromContext(ctx, r.db).Model(&domain.Project{})
 
 	// 构建搜索条件
 	var query *gorm.DB
@@ -84,25 +143,79 @@ func (r *ProjectRepository) GetAll(ctx context.Context, limit, offset int, keywo
 		return []*domain.Project{}, 0, nil
 	}
 
-	// 获取分页数据，添加排序优化查询性能
-	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&projects).Error; err != nil {
+	// 获取分页数据，未指定排序时按 ID 倒序
+	if err := orderBy(query, sort, "id DESC").Limit(limit).Offset(offset).Find(&projects).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return projects, total, nil
 }
 
+// GetByOrganization 获取组织下的所有项目
+func (r *ProjectRepository) GetByOrganization(ctx context.Context, organizationID uint64) ([]*domain.Project, error) {
+	var projects []*domain.Project
+	if err := dbFromContext(ctx, r.db).Where("organization_id = ?", organizationID).Order("id DESC").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
 // Create 创建项目
 func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project) error {
-	return r.db.WithContext(ctx).Create(project).Error
+	return dbFromContext(ctx, r.db).Create(project).Error
 }
 
 // Update 更新项目
 func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
-	return r.db.WithContext(ctx).Save(project).Error
+	domain.RequestCacheFromContext(ctx).InvalidateProject(project.ID)
+	return dbFromContext(ctx, r.db).Save(project).Error
 }
 
 // Delete 删除项目
 func (r *ProjectRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&domain.Project{}, id).Error
+	domain.RequestCacheFromContext(ctx).InvalidateProject(id)
+	return dbFromContext(ctx, r.db).Delete(&domain.Project{}, id).Error
+}
+
+// RecordActivity 写入项目最近的变更和访问时间
+// 多个实例会各自写入，只在新时间晚于已记录的时间时更新
+func (r *ProjectRepository) RecordActivity(ctx context.Context, activities []*domain.ProjectActivity) error {
+	db := dbFromContext(ctx, r.db)
+	for _, activity := range activities {
+		if !activity.LastChangedAt.IsZero() {
+			if err := db.Exec("UPDATE projects SET last_changed_at = ? WHERE id = ? AND (last_changed_at IS NULL OR last_changed_at < ?)",
+				activity.LastChangedAt, activity.ProjectID, activity.LastChangedAt).Error; err != nil {
+				return err
+			}
+		}
+		if !activity.LastAccessedAt.IsZero() {
+			if err := db.Exec("UPDATE projects SET last_accessed_at = ? WHERE id = ? AND (last_accessed_at IS NULL OR last_accessed_at < ?)",
+				activity.LastAccessedAt, activity.ProjectID, activity.LastAccessedAt).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetStale 获取最近变更早于 before 的活跃项目，从未变更的项目按创建时间计算
+func (r *ProjectRepository) GetStale(ctx context.Context, before time.Time, limit, offset int) ([]*domain.Project, int64, error) {
+	query := dbFromContext(ctx, r.db).Model(&domain.Project{}).
+		Where("status = ?", "active").
+		Where("COALESCE(last_changed_at, created_at) < ?", before)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.Project{}, 0, nil
+	}
+
+	var projects []*domain.Project
+	if err := query.Order("COALESCE(last_changed_at, created_at) ASC, id ASC").
+		Limit(limit).Offset(offset).Find(&projects).Error; err != nil {
+		return nil, 0, err
+	}
+	return projects, total, nil
 }
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// PromotionRepository 环境推送记录仓储实现
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository 创建环境推送记录仓储实例
+func NewPromotionRepository(db *gorm.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// Create 创建推送记录
+func (r *PromotionRepository) Create(ctx context.Context, promotion *domain.Promotion) error {
+	return dbFromContext(ctx, r.db).Create(promotion).Error
+}
+
+// GetByID 根据ID获取推送记录
+func (r *PromotionRepository) GetByID(ctx context.Context, id uint64) (*domain.Promotion, error) {
+	var promotion domain.Promotion
+	if err := dbFromContext(ctx, r.db).First(&promotion, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// GetByProjectID 分页获取项目的推送记录（最新的在前），不加载差异内容
+func (r *PromotionRepository) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Promotion, int64, error) {
+	var promotions []*domain.Promotion
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&domain.Promotion{}).Where("project_id = ?", projectID)
+
+	// 获取总数
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// 获取分页数据
+	if err := query.Omit("changes", "applied_changes").
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&promotions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return promotions, total, nil
+}
+
+// Update 更新推送记录
+func (r *PromotionRepository) Update(ctx context.Context, promotion *domain.Promotion) error {
+	return dbFromContext(ctx, r.db).Save(promotion).Error
+}
@@ -136,3 +136,13 @@ func (r *RedisClient) HGetAll(ctx context.Context, key string) (map[string]strin
 func (r *RedisClient) HDel(ctx context.Context, key string, fields ...string) error {
 	return r.client.HDel(ctx, r.GetKey(key), fields...).Err()
 }
+
+// HIncrBy 原子地增加哈希表字段的值，返回增加后的值
+func (r *RedisClient) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	return r.client.HIncrBy(ctx, r.GetKey(key), field, incr).Result()
+}
+
+// Expire 设置键的过期时间
+func (r *RedisClient) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return r.client.Expire(ctx, r.GetKey(key), expiration).Err()
+}
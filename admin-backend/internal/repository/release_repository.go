@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ReleaseRepository 发布版本仓储实现
+type ReleaseRepository struct {
+	db *gorm.DB
+}
+
+// NewReleaseRepository 创建发布版本仓储实例
+func NewReleaseRepository(db *gorm.DB) *ReleaseRepository {
+	return &ReleaseRepository{db: db}
+}
+
+// Create 创建发布版本，用 INSERT ... SELECT 冻结项目中有效语言的有效翻译
+func (r *ReleaseRepository) Create(ctx context.Context, release *domain.Release) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(release).Error; err != nil {
+			return err
+		}
+
+		snapshot := tx.Table("translations t").
+			Select("?, t.key_name, l.code, t.value, t.value_type, t.value_schema", release.ID).
+			Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
+			Where("t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL", release.ProjectID, "active")
+		if err := tx.Exec("INSERT INTO release_translations (release_id, key_name, language_code, value, value_type, value_schema) ?", snapshot).Error; err != nil {
+			return err
+		}
+
+		var counts struct {
+			Keys         int
+			Translations int
+		}
+		if err := tx.Model(&domain.ReleaseTranslation{}).
+			Select("COUNT(DISTINCT key_name) AS `keys`, COUNT(*) AS translations").
+			Where("release_id = ?", release.ID).
+			Scan(&counts).Error; err != nil {
+			return err
+		}
+		release.KeyCount, release.TranslationCount = counts.Keys, counts.Translations
+		return tx.Model(release).Updates(map[string]interface{}{
+			"key_count":         release.KeyCount,
+			"translation_count": release.TranslationCount,
+		}).Error
+	})
+}
+
+// GetByID 根据ID获取发布版本
+func (r *ReleaseRepository) GetByID(ctx context.Context, id uint64) (*domain.Release, error) {
+	var release domain.Release
+	if err := dbFromContext(ctx, r.db).First(&release, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReleaseNotFound
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+// GetByVersion 根据版本标签获取项目的发布版本
+func (r *ReleaseRepository) GetByVersion(ctx context.Context, projectID uint64, version string) (*domain.Release, error) {
+	var release domain.Release
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND version = ?", projectID, version).
+		First(&release).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReleaseNotFound
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+// GetLatest 获取项目最新的全量发布版本，跳过灰度中和已中止灰度的版本
+func (r *ReleaseRepository) GetLatest(ctx context.Context, projectID uint64) (*domain.Release, error) {
+	return r.first(ctx, "project_id = ? AND rollout_status IN ?", projectID, []string{"", domain.RolloutStatusCompleted})
+}
+
+// GetActiveRollout 获取项目进行中的灰度发布版本
+func (r *ReleaseRepository) GetActiveRollout(ctx context.Context, projectID uint64) (*domain.Release, error) {
+	return r.first(ctx, "project_id = ? AND rollout_status = ?", projectID, domain.RolloutStatusActive)
+}
+
+// UpdateRollout 保存发布版本的灰度发布状态和百分比
+func (r *ReleaseRepository) UpdateRollout(ctx context.Context, release *domain.Release) error {
+	return dbFromContext(ctx, r.db).Model(release).Updates(map[string]interface{}{
+		"rollout_status":     release.RolloutStatus,
+		"rollout_percentage": release.RolloutPercentage,
+	}).Error
+}
+
+// first 按条件获取 ID 最大的发布版本
+func (r *ReleaseRepository) first(ctx context.Context, query string, args ...interface{}) (*domain.Release, error) {
+	var release domain.Release
+	if err := dbFromContext(ctx, r.db).
+		Where(query, args...).
+		Order("id DESC").
+		First(&release).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReleaseNotFound
+		}
+		return nil, err
+	}
+	return &release, nil
+}
+
+// GetByProjectID 分页获取项目的发布版本，最新的在前
+func (r *ReleaseRepository) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Release, int64, error) {
+	var releases []*domain.Release
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&domain.Release{}).Where("project_id = ?", projectID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&releases).Error; err != nil {
+		return nil, 0, err
+	}
+	return releases, total, nil
+}
+
+// GetValues 获取发布版本的翻译：键名 -> 语言代码 -> 翻译值
+func (r *ReleaseRepository) GetValues(ctx context.Context, releaseID uint64) (map[string]map[string]string, error) {
+	var translations []*domain.ReleaseTranslation
+	if err := dbFromContext(ctx, r.db).
+		Select("key_name", "language_code", "value").
+		Where("release_id = ?", releaseID).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return releaseValues(translations), nil
+}
+
+// GetLocaleTranslations 获取发布版本中一种语言的翻译，按键名排序
+func (r *ReleaseRepository) GetLocaleTranslations(ctx context.Context, releaseID uint64, languageCode string) ([]*domain.ReleaseTranslation, error) {
+	var translations []*domain.ReleaseTranslation
+	if err := dbFromContext(ctx, r.db).
+		Select("key_name", "value", "value_type").
+		Where("release_id = ? AND language_code = ?", releaseID, languageCode).
+		Order("key_name ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetValueTypes 获取发布版本中非 string 类型的键的值类型
+func (r *ReleaseRepository) GetValueTypes(ctx context.Context, releaseID uint64) (map[string]domain.KeyValueType, error) {
+	var translations []*domain.ReleaseTranslation
+	if err := dbFromContext(ctx, r.db).
+		Select("key_name", "value_type", "value_schema").
+		Where("release_id = ? AND value_type <> ?", releaseID, domain.ValueTypeString).
+		Order("id ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	types := make(map[string]domain.KeyValueType)
+	for _, translation := range translations {
+		if _, exists := types[translation.KeyName]; !exists {
+			types[translation.KeyName] = domain.KeyValueType{ValueType: translation.ValueType, ValueSchema: translation.ValueSchema}
+		}
+	}
+	return types, nil
+}
+
+// GetKeyPage 按键名分页获取发布版本的翻译，与 TranslationRepository.GetKeyPage 的分页方式一致
+func (r *ReleaseRepository) GetKeyPage(ctx context.Context, releaseID uint64, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	scoped := func() *gorm.DB {
+		db := dbFromContext(ctx, r.db).Model(&domain.ReleaseTranslation{}).Where("release_id = ?", releaseID)
+		if query.Locale != "" {
+			db = db.Where("language_code = ?", query.Locale)
+		}
+		return db
+	}
+
+	keyQuery := scoped()
+	if query.KeyPrefix != "" {
+		keyQuery = keyQuery.Where("key_name LIKE ?", escapeLike(query.KeyPrefix)+"%")
+	}
+	if query.AfterKey != "" {
+		keyQuery = keyQuery.Where("key_name > ?", query.AfterKey)
+	}
+	if len(query.KeyNames) > 0 {
+		keyQuery = keyQuery.Where("key_name IN ?", query.KeyNames)
+	}
+
+	// 多取一个键用于判断是否还有下一页
+	var keyNames []string
+	if err := keyQuery.
+		Distinct("key_name").
+		Order("key_name ASC").
+		Limit(query.Limit+1).
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+
+	page := &domain.TranslationKeyPage{Translations: make(map[string]map[string]string)}
+	if len(keyNames) > query.Limit {
+		keyNames = keyNames[:query.Limit]
+		page.HasMore = true
+	}
+	if len(keyNames) == 0 {
+		return page, nil
+	}
+	page.LastKey = keyNames[len(keyNames)-1]
+
+	var translations []*domain.ReleaseTranslation
+	if err := scoped().
+		Select("key_name", "language_code", "value").
+		Where("key_name IN ?", keyNames).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	page.Translations = releaseValues(translations)
+	return page, nil
+}
+
+// releaseValues 转换为 键名 -> 语言代码 -> 翻译值
+func releaseValues(translations []*domain.ReleaseTranslation) map[string]map[string]string {
+	values := make(map[string]map[string]string)
+	for _, translation := range translations {
+		if values[translation.KeyName] == nil {
+			values[translation.KeyName] = make(map[string]string)
+		}
+		values[translation.KeyName][translation.LanguageCode] = translation.Value
+	}
+	return values
+}
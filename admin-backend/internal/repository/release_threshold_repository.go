@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// ReleaseThresholdRepository 发布门槛仓储实现
+type ReleaseThresholdRepository struct {
+	db *gorm.DB
+}
+
+// NewReleaseThresholdRepository 创建发布门槛仓储实例
+func NewReleaseThresholdRepository(db *gorm.DB) *ReleaseThresholdRepository {
+	return &ReleaseThresholdRepository{db: db}
+}
+
+// GetByProjectID 获取项目的发布门槛，包含对应的语言
+func (r *ReleaseThresholdRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ReleaseThreshold, error) {
+	var thresholds []*domain.ReleaseThreshold
+	if err := dbFromContext(ctx, r.db).
+		Preload("Language").
+		Where("project_id = ?", projectID).
+		Order("id ASC").
+		Find(&thresholds).Error; err != nil {
+		return nil, err
+	}
+	return thresholds, nil
+}
+
+// ReplaceByProjectID 删除项目原有的发布门槛后写入新的门槛，调用方负责在事务中执行
+func (r *ReleaseThresholdRepository) ReplaceByProjectID(ctx context.Context, projectID uint64, thresholds []*domain.ReleaseThreshold) error {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("project_id = ?", projectID).Delete(&domain.ReleaseThreshold{}).Error; err != nil {
+		return err
+	}
+	if len(thresholds) == 0 {
+		return nil
+	}
+	return db.Create(&thresholds).Error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"yflow/internal/domain"
+)
+
+// cacheForRead 返回可用于读取的请求级缓存
+// 缓存只保存已提交的数据：事务中读取时使用已有缓存，但不写入缓存，避免事务回滚后缓存中留下未提交的数据
+func cacheForRead(ctx context.Context) (cache *domain.RequestCache, populate bool) {
+	cache = domain.RequestCacheFromContext(ctx)
+	_, inTx := ctx.Value(txContextKey{}).(*txState)
+	return cache, cache != nil && !inTx
+}
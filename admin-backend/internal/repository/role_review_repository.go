@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// RoleReviewRepository 项目所有者角色复核仓储实现
+type RoleReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleReviewRepository 创建角色复核仓储实例
+func NewRoleReviewRepository(db *gorm.DB) *RoleReviewRepository {
+	return &RoleReviewRepository{db: db}
+}
+
+// GetByID 根据ID获取复核记录
+func (r *RoleReviewRepository) GetByID(ctx context.Context, id uint64) (*domain.RoleReview, error) {
+	var review domain.RoleReview
+	if err := dbFromContext(ctx, r.db).First(&review, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrRoleReviewNotFound
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetPendingProjectIDs 获取用户待复核的项目ID
+func (r *RoleReviewRepository) GetPendingProjectIDs(ctx context.Context, userID uint64) ([]uint64, error) {
+	var projectIDs []uint64
+	if err := dbFromContext(ctx, r.db).
+		Model(&domain.RoleReview{}).
+		Where("user_id = ? AND status = ?", userID, domain.RoleReviewStatusPending).
+		Pluck("project_id", &projectIDs).Error; err != nil {
+		return nil, err
+	}
+	return projectIDs, nil
+}
+
+// List 按创建时间倒序获取复核记录
+func (r *RoleReviewRepository) List(ctx context.Context, status string, limit, offset int) ([]*domain.RoleReview, int64, error) {
+	query := dbFromContext(ctx, r.db).Model(&domain.RoleReview{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []*domain.RoleReview
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// CreateBatch 批量创建复核记录
+func (r *RoleReviewRepository) CreateBatch(ctx context.Context, reviews []*domain.RoleReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).Create(&reviews).Error
+}
+
+// Update 更新复核记录
+func (r *RoleReviewRepository) Update(ctx context.Context, review *domain.RoleReview) error {
+	return dbFromContext(ctx, r.db).Save(review).Error
+}
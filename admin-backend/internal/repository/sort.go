@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// orderBy 按 sort 排序，sort 为空时使用 defaultOrder（为空时不排序）
+// 字段名须已按白名单校验；排序字段中没有 id 时追加 id 升序，保证分页结果稳定
+func orderBy(query *gorm.DB, sort []domain.SortField, defaultOrder string) *gorm.DB {
+	if len(sort) == 0 {
+		if defaultOrder == "" {
+			return query
+		}
+		return query.Order(defaultOrder)
+	}
+
+	hasID := false
+	for _, field := range sort {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Field}, Desc: field.Desc})
+		hasID = hasID || field.Field == "id"
+	}
+	if !hasID {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+	}
+	return query
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// StorageStatsRepository 数据表统计信息仓储实现
+type StorageStatsRepository struct {
+	db *gorm.DB
+}
+
+// NewStorageStatsRepository 创建数据表统计信息仓储实例
+func NewStorageStatsRepository(db *gorm.DB) *StorageStatsRepository {
+	return &StorageStatsRepository{db: db}
+}
+
+// GetTableStats 从 information_schema 获取指定表的统计信息
+// MySQL 8.0 默认缓存表统计信息 24 小时，查询前在当前连接上关闭缓存以获取最新的估算值
+func (r *StorageStatsRepository) GetTableStats(ctx context.Context, tables []string) ([]*domain.TableStats, error) {
+	stats := make([]*domain.TableStats, 0, len(tables))
+	if len(tables) == 0 {
+		return stats, nil
+	}
+
+	var results []struct {
+		TableName  string `gorm:"column:table_name"`
+		TableRows  int64  `gorm:"column:table_rows"`
+		DataBytes  int64  `gorm:"column:data_bytes"`
+		IndexBytes int64  `gorm:"column:index_bytes"`
+	}
+	err := r.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		// 旧版本 MySQL 没有该变量，设置失败时使用默认统计信息
+		conn.Exec("SET SESSION information_schema_stats_expiry = 0")
+		defer conn.Exec("SET SESSION information_schema_stats_expiry = DEFAULT")
+
+		return conn.Raw(`SELECT TABLE_NAME AS table_name, COALESCE(TABLE_ROWS, 0) AS table_rows,
+				COALESCE(DATA_LENGTH, 0) AS data_bytes, COALESCE(INDEX_LENGTH, 0) AS index_bytes
+			FROM information_schema.TABLES
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME IN ?
+			ORDER BY TABLE_NAME`, tables).
+			Scan(&results).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		stats = append(stats, &domain.TableStats{
+			Table:      result.TableName,
+			Rows:       result.TableRows,
+			DataBytes:  result.DataBytes,
+			IndexBytes: result.IndexBytes,
+		})
+	}
+	return stats, nil
+}
@@ -0,0 +1,396 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// keyTagCondition 筛选带有任一标签的键的翻译，翻译表别名须为 t，参数为标签名称列表
+const keyTagCondition = `EXISTS (SELECT 1 FROM key_tags kt INNER JOIN tags tg ON tg.id = kt.tag_id
+	WHERE kt.project_id = t.project_id AND kt.key_name = t.key_name AND tg.name IN ?)`
+
+// TagRepository 标签仓储实现
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository 创建标签仓储实例
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// GetByID 根据ID获取标签
+func (r *TagRepository) GetByID(ctx context.Context, id uint64) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := dbFromContext(ctx, r.db).First(&tag, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByName 根据名称获取项目中的标签
+func (r *TagRepository) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Tag, error) {
+	var tag domain.Tag
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND name = ?", projectID, name).
+		First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetByProjectID 获取项目的标签（按名称排序），包含每个标签中有有效翻译的键数量
+func (r *TagRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Tag, error) {
+	db := dbFromContext(ctx, r.db)
+	var tags []*domain.Tag
+	if err := db.Where("project_id = ?", projectID).Order("name ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	var counts []struct {
+		TagID uint64 `gorm:"column:tag_id"`
+		Keys  int64  `gorm:"column:keys"`
+	}
+	if err := db.Table("key_tags kt").
+		Select("kt.tag_id, COUNT(*) AS `keys`").
+		Where("kt.project_id = ?", projectID).
+		Where(`EXISTS (SELECT 1 FROM translations t
+			WHERE t.project_id = kt.project_id AND t.key_name = kt.key_name AND t.deleted_at IS NULL)`).
+		Group("kt.tag_id").
+		Find(&counts).Error; err != nil {
+		return nil, err
+	}
+	keys := make(map[uint64]int64, len(counts))
+	for _, count := range counts {
+		keys[count.TagID] = count.Keys
+	}
+	for _, tag := range tags {
+		tag.Keys = keys[tag.ID]
+	}
+	return tags, nil
+}
+
+// Create 创建标签
+func (r *TagRepository) Create(ctx context.Context, tag *domain.Tag) error {
+	return dbFromContext(ctx, r.db).Create(tag).Error
+}
+
+// Update 更新标签，名称变化时同步带有该标签的键的 tags 列
+func (r *TagRepository) Update(ctx context.Context, tag *domain.Tag) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var oldName string
+		if err := tx.Model(&domain.Tag{}).Where("id = ?", tag.ID).Pluck("name", &oldName).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(tag).Error; err != nil {
+			return err
+		}
+		if oldName == tag.Name {
+			return nil
+		}
+		keyNames, err := tagKeyNames(tx, tag.ID)
+		if err != nil {
+			return err
+		}
+		return refreshKeyTagColumn(tx, tag.ProjectID, keyNames)
+	})
+}
+
+// Delete 删除标签并移除键上的该标签，同步这些键的 tags 列
+func (r *TagRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var tag domain.Tag
+		if err := tx.First(&tag, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.ErrTagNotFound
+			}
+			return err
+		}
+		keyNames, err := tagKeyNames(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", id).Delete(&domain.KeyTag{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Tag{}, id).Error; err != nil {
+			return err
+		}
+		return refreshKeyTagColumn(tx, tag.ProjectID, keyNames)
+	})
+}
+
+// GetKeyNames 获取带有标签的键名（按键名排序）
+func (r *TagRepository) GetKeyNames(ctx context.Context, tagID uint64) ([]string, error) {
+	return tagKeyNames(dbFromContext(ctx, r.db), tagID)
+}
+
+// CountKeyTags 统计键的标签数量，没有标签的键不在结果中
+func (r *TagRepository) CountKeyTags(ctx context.Context, projectID uint64, keyNames []string) (map[string]int, error) {
+	const chunkSize = 500
+
+	counts := make(map[string]int)
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		var results []struct {
+			KeyName string `gorm:"column:key_name"`
+			Tags    int    `gorm:"column:tags"`
+		}
+		if err := dbFromContext(ctx, r.db).
+			Model(&domain.KeyTag{}).
+			Select("key_name, COUNT(*) AS tags").
+			Where("project_id = ? AND key_name IN ?", projectID, keyNames[start:end]).
+			Group("key_name").
+			Find(&results).Error; err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			counts[result.KeyName] = result.Tags
+		}
+	}
+	return counts, nil
+}
+
+// AddKeys 为键添加标签并同步 tags 列，返回新增了标签的键数量
+func (r *TagRepository) AddKeys(ctx context.Context, tag *domain.Tag, keyNames []string) (int64, error) {
+	var added int64
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		rows := make([]*domain.KeyTag, 0, len(keyNames))
+		for _, keyName := range keyNames {
+			rows = append(rows, &domain.KeyTag{ProjectID: tag.ProjectID, KeyName: keyName, TagID: tag.ID, CreatedAt: now})
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 500)
+		if result.Error != nil {
+			return result.Error
+		}
+		added = result.RowsAffected
+		return refreshKeyTagColumn(tx, tag.ProjectID, keyNames)
+	})
+	return added, err
+}
+
+// RemoveKeys 移除键上的标签并同步 tags 列，返回移除了标签的键数量
+func (r *TagRepository) RemoveKeys(ctx context.Context, tag *domain.Tag, keyNames []string) (int64, error) {
+	var removed int64
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("tag_id = ? AND key_name IN ?", tag.ID, keyNames).Delete(&domain.KeyTag{})
+		if result.Error != nil {
+			return result.Error
+		}
+		removed = result.RowsAffected
+		return refreshKeyTagColumn(tx, tag.ProjectID, keyNames)
+	})
+	return removed, err
+}
+
+// tagKeyNames 获取带有标签的键名（按键名排序）
+func tagKeyNames(db *gorm.DB, tagID uint64) ([]string, error) {
+	var keyNames []string
+	if err := db.Model(&domain.KeyTag{}).
+		Where("tag_id = ?", tagID).
+		Order("key_name ASC").
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// refreshKeyTagColumn 按键与标签的关联重写键在所有翻译（包括已软删除的翻译）中的 tags 列，标签按名称排序
+func refreshKeyTagColumn(db *gorm.DB, projectID uint64, keyNames []string) error {
+	const chunkSize = 500
+
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		if err := db.Exec(
+			`UPDATE translations t SET t.tags = COALESCE((
+				SELECT GROUP_CONCAT(tg.name ORDER BY tg.name SEPARATOR ',') FROM key_tags kt
+				INNER JOIN tags tg ON tg.id = kt.tag_id
+				WHERE kt.project_id = t.project_id AND kt.key_name = t.key_name
+			), '')
+			WHERE t.project_id = ? AND t.key_name IN ?`,
+			projectID, keyNames[start:end],
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncKeyTagsFromTranslations 按写入的翻译的 tags 列同步键与标签的关联，同一键以批次中最后一条翻译为准
+// 写入翻译时键的标签由 tags 列给出（见 TranslationService.applyKeyMetadata），项目中没有的标签自动创建
+func syncKeyTagsFromTranslations(db *gorm.DB, translations []*domain.Translation) error {
+	type projectTags struct {
+		tagsByKey map[string][]string
+		userID    uint64
+	}
+	projects := make(map[uint64]*projectTags)
+	var projectIDs []uint64
+	for _, translation := range translations {
+		project := projects[translation.ProjectID]
+		if project == nil {
+			project = &projectTags{tagsByKey: make(map[string][]string)}
+			projects[translation.ProjectID] = project
+			projectIDs = append(projectIDs, translation.ProjectID)
+		}
+		project.tagsByKey[translation.KeyName] = splitKeyTags(translation.Tags)
+		project.userID = translation.UpdatedBy
+	}
+	for _, projectID := range projectIDs {
+		if err := syncKeyTags(db, projectID, projects[projectID].tagsByKey, projects[projectID].userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncKeyTags 将键的标签改为 tagsByKey 中给出的标签（按名称），只增删有变化的关联，项目中没有的标签自动创建
+func syncKeyTags(db *gorm.DB, projectID uint64, tagsByKey map[string][]string, userID uint64) error {
+	const chunkSize = 500
+
+	tagIDs, err := ensureTags(db, projectID, tagsByKey, userID)
+	if err != nil {
+		return err
+	}
+	keyNames := make([]string, 0, len(tagsByKey))
+	for keyName := range tagsByKey {
+		keyNames = append(keyNames, keyName)
+	}
+
+	now := time.Now()
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		var current []*domain.KeyTag
+		if err := db.Where("project_id = ? AND key_name IN ?", projectID, keyNames[start:end]).
+			Find(&current).Error; err != nil {
+			return err
+		}
+		existing := make(map[domain.KeyTag]bool, len(current))
+		for _, row := range current {
+			existing[domain.KeyTag{KeyName: row.KeyName, TagID: row.TagID}] = true
+		}
+
+		wanted := make(map[domain.KeyTag]bool)
+		var added []*domain.KeyTag
+		for _, keyName := range keyNames[start:end] {
+			for _, name := range tagsByKey[keyName] {
+				id, ok := tagIDs[strings.ToLower(name)]
+				if !ok {
+					continue
+				}
+				key := domain.KeyTag{KeyName: keyName, TagID: id}
+				if wanted[key] {
+					continue
+				}
+				wanted[key] = true
+				if !existing[key] {
+					added = append(added, &domain.KeyTag{ProjectID: projectID, KeyName: keyName, TagID: id, CreatedAt: now})
+				}
+			}
+		}
+		var removed [][]interface{}
+		for key := range existing {
+			if !wanted[key] {
+				removed = append(removed, []interface{}{key.KeyName, key.TagID})
+			}
+		}
+		if len(removed) > 0 {
+			if err := db.Where("project_id = ? AND (key_name, tag_id) IN ?", projectID, removed).
+				Delete(&domain.KeyTag{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(added) > 0 {
+			if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&added).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ensureTags 获取 tagsByKey 中用到的标签的ID（按小写名称索引），项目中没有的标签自动创建
+func ensureTags(db *gorm.DB, projectID uint64, tagsByKey map[string][]string, userID uint64) (map[string]uint64, error) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, tags := range tagsByKey {
+		for _, name := range tags {
+			if !seen[strings.ToLower(name)] {
+				seen[strings.ToLower(name)] = true
+				names = append(names, name)
+			}
+		}
+	}
+	ids := make(map[string]uint64, len(names))
+	if len(names) == 0 {
+		return ids, nil
+	}
+
+	load := func() error {
+		var tags []*domain.Tag
+		if err := db.Select("id", "name").Where("project_id = ? AND name IN ?", projectID, names).Find(&tags).Error; err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			ids[strings.ToLower(tag.Name)] = tag.ID
+		}
+		return nil
+	}
+	if err := load(); err != nil {
+		return nil, err
+	}
+	var missing []*domain.Tag
+	for _, name := range names {
+		if _, ok := ids[strings.ToLower(name)]; !ok {
+			missing = append(missing, &domain.Tag{ProjectID: projectID, Name: name, CreatedBy: userID, UpdatedBy: userID})
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&missing).Error; err != nil {
+		return nil, err
+	}
+	return ids, load()
+}
+
+// splitKeyTags 解析逗号分隔的 tags 列，忽略空白、重复和超长的标签
+func splitKeyTags(tags string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(tags, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] || utf8.RuneCountInString(name) > domain.MaxKeyTagLength {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
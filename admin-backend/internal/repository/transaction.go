@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txContextKey 事务在 context 中的键
+type txContextKey struct{}
+
+// txState 当前事务及提交后回调
+type txState struct {
+	tx          *gorm.DB
+	afterCommit []func(ctx context.Context)
+}
+
+// Transactor 基于 GORM 的事务管理器
+// 事务通过 context 传递，仓储方法使用 dbFromContext 自动加入当前事务
+type Transactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor 创建事务管理器
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction 在事务中执行 fn，fn 返回错误时回滚
+// 已处于事务中时直接加入外层事务
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+
+	state := &txState{}
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state.tx = tx
+		return fn(context.WithValue(ctx, txContextKey{}, state))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, callback := range state.afterCommit {
+		callback(ctx)
+	}
+	return nil
+}
+
+// AfterCommit 注册事务提交后执行的回调；不在事务中时立即执行
+func (t *Transactor) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	if state, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
+
+// dbFromContext 返回 context 中的事务，不在事务中时返回 db
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txContextKey{}).(*txState); ok {
+		return state.tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
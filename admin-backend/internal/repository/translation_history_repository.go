@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"yflow/internal/domain"
+)
+
+// TranslationHistoryRepository 翻译变更历史仓储实现
+type TranslationHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationHistoryRepository 创建翻译变更历史仓储实例
+func NewTranslationHistoryRepository(db *gorm.DB) *TranslationHistoryRepository {
+	return &TranslationHistoryRepository{db: db}
+}
+
+// Stream 按 ID 顺序分批读取时间范围内的变更历史，fn 返回错误时停止
+func (r *TranslationHistoryRepository) Stream(ctx context.Context, query domain.HistoryQuery, batchSize int, fn func([]*domain.TranslationHistory) error) error {
+	var lastID uint64
+	for {
+		var batch []*domain.TranslationHistory
+		if err := historyRangeQuery(dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}), query).
+			Where("id > ?", lastID).
+			Order("id ASC").
+			Limit(batchSize).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// CountActivity 按项目、月份、操作人和操作类型统计时间范围内的变更历史
+func (r *TranslationHistoryRepository) CountActivity(ctx context.Context, query domain.HistoryQuery) ([]*domain.ActivityCount, error) {
+	return countActivity(dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}), query, "operation")
+}
+
+// CountDailyActivity 按日期、项目、操作人和操作类型统计时间范围内的变更历史
+func (r *TranslationHistoryRepository) CountDailyActivity(ctx context.Context, query domain.HistoryQuery, operations []string) ([]*domain.DailyActivityCount, error) {
+	var counts []*domain.DailyActivityCount
+	if err := historyRangeQuery(dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}), query).
+		Where("operation IN ?", operations).
+		Select("DATE_FORMAT(created_at, '%Y-%m-%d') AS date, project_id, user_id, operation AS action, COUNT(*) AS count").
+		Group("date, project_id, user_id, operation").
+		Order("date, project_id, user_id, operation").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// GetByID 根据ID获取变更历史
+func (r *TranslationHistoryRepository) GetByID(ctx context.Context, id uint64) (*domain.TranslationHistory, error) {
+	var entry domain.TranslationHistory
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).Take(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrHistoryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetVersionsAt 获取项目中 at 之后有变更的翻译在 at 时刻的版本，按翻译ID索引，没有当时版本的翻译值为 nil
+func (r *TranslationHistoryRepository) GetVersionsAt(ctx context.Context, projectID uint64, at time.Time) (map[uint64]*domain.TranslationHistory, error) {
+	var translationIDs []uint64
+	if err := dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}).
+		Distinct("translation_id").
+		Where("project_id = ? AND created_at > ?", projectID, at).
+		Pluck("translation_id", &translationIDs).Error; err != nil {
+		return nil, err
+	}
+
+	versions := make(map[uint64]*domain.TranslationHistory, len(translationIDs))
+	if len(translationIDs) == 0 {
+		return versions, nil
+	}
+	for _, id := range translationIDs {
+		versions[id] = nil
+	}
+
+	// 每条翻译在 at 及之前的最后一条变更历史
+	latest := dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}).
+		Select("MAX(id)").
+		Where("translation_id IN ? AND created_at <= ?", translationIDs, at).
+		Group("translation_id")
+	var entries []*domain.TranslationHistory
+	if err := dbFromContext(ctx, r.db).Where("id IN (?)", latest).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		versions[entry.TranslationID] = entry
+	}
+	return versions, nil
+}
+
+// GetChangedKeys 获取时间范围内有变更的键名，包括重命名前的键名，按键名排序
+func (r *TranslationHistoryRepository) GetChangedKeys(ctx context.Context, query domain.HistoryQuery) ([]string, error) {
+	var keyNames, previousKeys []string
+	if err := historyRangeQuery(dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}), query).
+		Distinct("key_name").
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	if err := historyRangeQuery(dbFromContext(ctx, r.db).Model(&domain.TranslationHistory{}), query).
+		Where("previous_key <> ''").
+		Distinct("previous_key").
+		Pluck("previous_key", &previousKeys).Error; err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(keyNames)+len(previousKeys))
+	changed := make([]string, 0, len(keyNames)+len(previousKeys))
+	for _, keyName := range append(keyNames, previousKeys...) {
+		if !seen[keyName] {
+			seen[keyName] = true
+			changed = append(changed, keyName)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// historyRangeQuery 添加项目和时间范围条件
+func historyRangeQuery(db *gorm.DB, query domain.HistoryQuery) *gorm.DB {
+	db = db.Where("created_at >= ? AND created_at < ?", query.From, query.To)
+	if query.ProjectID != 0 {
+		db = db.Where("project_id = ?", query.ProjectID)
+	}
+	return db
+}
+
+// countActivity 按项目、月份、操作人和 actionColumn 分组计数
+func countActivity(db *gorm.DB, query domain.HistoryQuery, actionColumn string) ([]*domain.ActivityCount, error) {
+	var counts []*domain.ActivityCount
+	if err := historyRangeQuery(db, query).
+		Select("project_id, DATE_FORMAT(created_at, '%Y-%m') AS month, user_id, " + actionColumn + " AS action, COUNT(*) AS count").
+		Group("project_id, month, user_id, " + actionColumn).
+		Order("project_id, month, user_id, " + actionColumn).
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// historyColumns INSERT ... SELECT 写入变更历史时的列顺序
+const historyColumns = "translation_id, project_id, key_name, previous_key, language_id, operation, old_value, new_value, old_status, new_status, user_id, created_at"
+
+// historyOperator 变更历史的操作人：优先使用显式传入的用户，否则取 context 中的当前用户
+func historyOperator(ctx context.Context, userID uint64) uint64 {
+	if userID != 0 {
+		return userID
+	}
+	return domain.ActorFromContext(ctx)
+}
+
+// recordHistory 写入变更历史，context 中指定了操作类型时替代写入翻译值的 create、update 和 restore
+func recordHistory(ctx context.Context, db *gorm.DB, entries []*domain.TranslationHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if operation := domain.HistoryOperationFromContext(ctx); operation != "" {
+		for _, entry := range entries {
+			switch entry.Operation {
+			case domain.HistoryOperationCreate, domain.HistoryOperationUpdate, domain.HistoryOperationRestore:
+				entry.Operation = operation
+			}
+		}
+	}
+	return dbFromContext(ctx, db).CreateInBatches(entries, 500).Error
+}
+
+// recordHistoryFrom 为 source 选中的翻译写入变更历史，须在修改翻译之前调用
+// columns 按 historyColumns 的顺序给出各列取值的表达式
+func recordHistoryFrom(ctx context.Context, db *gorm.DB, source *gorm.DB, columns string, args ...interface{}) error {
+	return dbFromContext(ctx, db).
+		Exec("INSERT INTO translation_histories ("+historyColumns+") ?", source.Select(columns, args...)).Error
+}
+
+// newTranslationHistory 根据写入后的翻译创建变更历史
+func newTranslationHistory(translation *domain.Translation, operation string, operator uint64, now time.Time) *domain.TranslationHistory {
+	return &domain.TranslationHistory{
+		TranslationID: translation.ID,
+		ProjectID:     translation.ProjectID,
+		KeyName:       translation.KeyName,
+		LanguageID:    translation.LanguageID,
+		Operation:     operation,
+		NewValue:      translation.Value,
+		NewStatus:     translation.Status,
+		UserID:        operator,
+		CreatedAt:     now,
+	}
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TranslationMemoryRepository 翻译记忆库仓储实现
+// 翻译记忆直接来自各项目的翻译：同一项目中同一键的源语言翻译和目标语言翻译构成一对原文和译文
+type TranslationMemoryRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationMemoryRepository 创建翻译记忆库仓储实例
+func NewTranslationMemoryRepository(db *gorm.DB) *TranslationMemoryRepository {
+	return &TranslationMemoryRepository{db: db}
+}
+
+// FindCandidates 获取原文长度和内容符合条件的原文-译文对，原文与查询文本完全相同的在前，其余按译文更新时间倒序
+// 不包括已删除项目中的翻译和空译文
+func (r *TranslationMemoryRepository) FindCandidates(ctx context.Context, query domain.TMCandidateQuery) ([]*domain.TMCandidate, error) {
+	db := dbFromContext(ctx, r.db).
+		Table("translations s").
+		Select("s.project_id, s.key_name, s.value AS source_text, t.value AS target_text, t.updated_at").
+		Joins("INNER JOIN translations t ON t.project_id = s.project_id AND t.key_name = s.key_name AND t.language_id = ? AND t.status IN ? AND t.deleted_at IS NULL AND t.value <> ''",
+			query.TargetLanguageID, query.Statuses).
+		Joins("INNER JOIN projects p ON p.id = s.project_id AND p.deleted_at IS NULL").
+		Where("s.language_id = ? AND s.status IN ? AND s.deleted_at IS NULL", query.SourceLanguageID, query.Statuses).
+		Where("CHAR_LENGTH(s.value) BETWEEN ? AND ?", query.MinLength, query.MaxLength)
+	if query.ProjectIDs != nil {
+		db = db.Where("s.project_id IN ?", query.ProjectIDs)
+	}
+	if len(query.Terms) > 0 {
+		conditions := make([]string, len(query.Terms))
+		args := make([]interface{}, len(query.Terms))
+		for i, term := range query.Terms {
+			conditions[i] = "s.value LIKE ?"
+			args[i] = "%" + escapeLike(term) + "%"
+		}
+		db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	if query.ExcludeKey != "" {
+		db = db.Where("NOT (s.project_id = ? AND s.key_name = ?)", query.ExcludeProjectID, query.ExcludeKey)
+	}
+
+	var candidates []*domain.TMCandidate
+	err := db.Order(clause.OrderBy{Expression: gorm.Expr("s.value = ? DESC, t.updated_at DESC", query.Text)}).
+		Limit(query.Limit).Scan(&candidates).Error
+	return candidates, err
+}
@@ -12,19 +12,21 @@ import (
 )
 
 // TranslationRepository 翻译仓储实现
+// 写入翻译时在同一事务中记录变更历史（translation_histories）
 type TranslationRepository struct {
-	db *gorm.DB
+	db         *gorm.DB
+	transactor *Transactor
 }
 
 // NewTranslationRepository 创建翻译仓储实例
 func NewTranslationRepository(db *gorm.DB) *TranslationRepository {
-	return &TranslationRepository{db: db}
+	return &TranslationRepository{db: db, transactor: NewTransactor(db)}
 }
 
 // GetByID 根据ID获取翻译
 func (r *TranslationRepository) GetByID(ctx context.Context, id uint64) (*domain.Translation, error) {
 	var translation domain.Translation
-	if err := r.db.WithContext(ctx).Preload("Project").Preload("Language").First(&translation, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Preload("Project").Preload("Language").First(&translation, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrTranslationNotFound
 		}
@@ -33,12 +35,27 @@ func (r *TranslationRepository) GetByID(ctx context.Context, id uint64) (*domain
 	return &translation, nil
 }
 
-// GetByProjectID 根据项目ID获取翻译（分页）
-func (r *TranslationRepository) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Translation, int64, error) {
+// GetByIDs 根据ID批量获取翻译，不存在的ID忽略
+func (r *TranslationRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	if len(ids) == 0 {
+		return translations, nil
+	}
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", ids).Order("id ASC").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetByProjectID 根据项目ID获取翻译（分页），reviewStatus 不为空时只返回该审核状态的翻译
+func (r *TranslationRepository) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int, reviewStatus string, sort []domain.SortField) ([]*domain.Translation, int64, error) {
 	var translations []*domain.Translation
 	var total int64
 
-	query := r.db.WithContext(ctx).Where("project_id = ?", projectID)
+	query := dbFromContext(ctx, r.db).Where("project_id = ?", projectID)
+	if reviewStatus != "" {
+		query = query.Where("review_status = ?", reviewStatus)
+	}
 
 	// 计算总数
 	if err := query.Model(&domain.Translation{}).Count(&total).Error; err != nil {
@@ -46,17 +63,69 @@ func (r *TranslationRepository) GetByProjectID(ctx context.Context, projectID ui
 	}
 
 	// 获取分页数据
-	if err := query.Preload("Language").Limit(limit).Offset(offset).Find(&translations).Error; err != nil {
+	if err := orderBy(query.Preload("Language"), sort, "").Limit(limit).Offset(offset).Find(&translations).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return translations, total, nil
 }
 
+// GetPageByProjectID 按 ID 顺序游标分页获取项目的翻译
+func (r *TranslationRepository) GetPageByProjectID(ctx context.Context, query domain.TranslationPageQuery) (*domain.TranslationPage, error) {
+	base := dbFromContext(ctx, r.db).Model(&domain.Translation{}).Where("project_id = ?", query.ProjectID)
+	if query.ReviewStatus != "" {
+		base = base.Where("review_status = ?", query.ReviewStatus)
+	}
+
+	page := &domain.TranslationPage{}
+	if err := base.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return nil, err
+	}
+
+	// 多取一条用于判断是否还有下一页
+	if err := base.Preload("Language").
+		Where("id > ?", query.AfterID).
+		Order("id ASC").
+		Limit(query.Limit + 1).
+		Find(&page.Translations).Error; err != nil {
+		return nil, err
+	}
+	if len(page.Translations) > query.Limit {
+		page.Translations = page.Translations[:query.Limit]
+		page.HasMore = true
+	}
+	return page, nil
+}
+
+// StreamByProject 按 ID 顺序分批读取项目中未删除的翻译，fn 返回错误时停止
+func (r *TranslationRepository) StreamByProject(ctx context.Context, projectID uint64, batchSize int, fn func([]*domain.Translation) error) error {
+	var lastID uint64
+	for {
+		var batch []*domain.Translation
+		if err := dbFromContext(ctx, r.db).
+			Where("project_id = ? AND id > ?", projectID, lastID).
+			Order("id ASC").
+			Limit(batchSize).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
 // GetByProjectAndLanguage 根据项目和语言获取翻译
 func (r *TranslationRepository) GetByProjectAndLanguage(ctx context.Context, projectID, languageID uint64) ([]*domain.Translation, error) {
 	var translations []*domain.Translation
-	if err := r.db.WithContext(ctx).Where("project_id = ? AND language_id = ?", projectID, languageID).Find(&translations).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("project_id = ? AND language_id = ?", projectID, languageID).Find(&translations).Error; err != nil {
 		return nil, err
 	}
 	return translations, nil
@@ -65,7 +134,7 @@ func (r *TranslationRepository) GetByProjectAndLanguage(ctx context.Context, pro
 // GetByProjectKeyLanguage 根据项目ID、键名和语言ID获取翻译
 func (r *TranslationRepository) GetByProjectKeyLanguage(ctx context.Context, projectID uint64, keyName string, languageID uint64) (*domain.Translation, error) {
 	var translation domain.Translation
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where("project_id = ? AND key_name = ? AND language_id = ?", projectID, keyName, languageID).
 		First(&translation).Error
 
@@ -95,7 +164,7 @@ func (r *TranslationRepository) GetByProjectKeyLanguages(ctx context.Context, ke
 	}
 
 	var translations []*domain.Translation
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Where(strings.Join(conditions, " OR "), args...).
 		Find(&translations).Error
 
@@ -111,13 +180,13 @@ func (r *TranslationRepository) GetByProjectKeyLanguages(ctx context.Context, ke
 func (r *TranslationRepository) GetStats(ctx context.Context) (totalTranslations int, totalKeys int, err error) {
 	// 获取总翻译数
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&domain.Translation{}).Count(&count).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&domain.Translation{}).Count(&count).Error; err != nil {
 		return 0, 0, err
 	}
 	totalTranslations = int(count)
 
 	// 获取唯一键数
-	if err := r.db.WithContext(ctx).Model(&domain.Translation{}).Distinct("key_name").Count(&count).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Model(&domain.Translation{}).Distinct("key_name").Count(&count).Error; err != nil {
 		return 0, 0, err
 	}
 	totalKeys = int(count)
@@ -127,36 +196,11 @@ func (r *TranslationRepository) GetStats(ctx context.Context) (totalTranslations
 
 // GetMatrix 获取翻译矩阵（key-language映射），支持分页和搜索
 func (r *TranslationRepository) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
-	// 优化：使用单个查询获取总数和键名
-	var totalCount int64
-	var keyNames []string
-
-	// 构建基础查询条件，添加状态过滤提高性能
-	baseWhere := "project_id = ? AND status = ?"
-	baseArgs := []interface{}{projectID, "active"}
-
-	// 优化关键词搜索查询
-	var countQuery *gorm.DB
-	if keyword != "" {
-		// 优化搜索策略：先尝试精确匹配，再尝试模糊匹配
-		// 这样可以更好地利用索引
-		searchWhere := baseWhere + " AND (key_name LIKE ? OR value LIKE ?)"
-		searchArgs := append(baseArgs, "%"+keyword+"%", "%"+keyword+"%")
-		countQuery = r.db.WithContext(ctx).Model(&domain.Translation{}).
-			Select("DISTINCT key_name").
-			Where(searchWhere, searchArgs...)
-	} else {
-		countQuery = r.db.WithContext(ctx).Model(&domain.Translation{}).
-			Select("DISTINCT key_name").
-			Where(baseWhere, baseArgs...)
-	}
-
-	// 使用子查询优化计数性能
-	var uniqueKeys []string
-	if err := countQuery.Pluck("key_name", &uniqueKeys).Error; err != nil {
+	uniqueKeys, err := r.SearchKeyNames(ctx, projectID, keyword, "", "")
+	if err != nil {
 		return nil, 0, err
 	}
-	totalCount = int64(len(uniqueKeys))
+	totalCount := int64(len(uniqueKeys))
 
 	// 如果没有数据，直接返回
 	if totalCount == 0 {
@@ -164,6 +208,7 @@ func (r *TranslationRepository) GetMatrix(ctx context.Context, projectID uint64,
 	}
 
 	// 应用分页获取实际需要的键名
+	var keyNames []string
 	if limit > 0 && offset >= 0 {
 		end := offset + limit
 		if end > len(uniqueKeys) {
@@ -176,68 +221,1132 @@ func (r *TranslationRepository) GetMatrix(ctx context.Context, projectID uint64,
 		keyNames = uniqueKeys
 	}
 
+	matrix, err := r.GetMatrixCells(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, 0, err
+	}
+	return matrix, totalCount, nil
+}
+
+// searchCollations 搜索比较规则对应的 MySQL 排序规则
+// 只能使用此白名单中的值拼接 SQL
+var searchCollations = map[string]string{
+	domain.SearchCollationAccentInsensitive: "utf8mb4_0900_ai_ci",
+	domain.SearchCollationCaseInsensitive:   "utf8mb4_0900_as_ci",
+	domain.SearchCollationExact:             "utf8mb4_0900_as_cs",
+}
+
+// SearchKeyNames 获取项目中键名或翻译值包含 keyword 的有效键名（按键名排序）
+// searchCollation 为空时使用列的默认排序规则，否则在查询中显式指定排序规则；
+// reviewStatus 不为空时只返回有该审核状态的翻译的键
+func (r *TranslationRepository) SearchKeyNames(ctx context.Context, projectID uint64, keyword, searchCollation, reviewStatus string) ([]string, error) {
+	// 构建基础查询条件，添加状态过滤提高性能
+	query := dbFromContext(ctx, r.db).Model(&domain.Translation{}).
+		Where("project_id = ? AND status = ?", projectID, "active")
+	if reviewStatus != "" {
+		query = query.Where("review_status = ?", reviewStatus)
+	}
+
+	if keyword != "" {
+		collateClause := ""
+		if searchCollation != "" {
+			collation, ok := searchCollations[searchCollation]
+			if !ok {
+				return nil, domain.ErrInvalidInput
+			}
+			collateClause = " COLLATE " + collation
+		}
+		pattern := "%" + escapeLike(keyword) + "%"
+		query = query.Where("(key_name"+collateClause+" LIKE ? OR value"+collateClause+" LIKE ?)", pattern, pattern)
+	}
+
+	var keyNames []string
+	if err := query.Distinct("key_name").Order("key_name ASC").Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// GetKeyValues 获取项目中某种语言的所有有效翻译：键名 -> 翻译值
+func (r *TranslationRepository) GetKeyValues(ctx context.Context, projectID uint64, languageCode string) (map[string]string, error) {
+	var results []struct {
+		KeyName string `gorm:"column:key_name"`
+		Value   string `gorm:"column:value"`
+	}
+	if err := dbFromContext(ctx, r.db).
+		Table("translations t").
+		Select("t.key_name, t.value").
+		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ? AND l.code = ?", "active", languageCode).
+		Where("t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL", projectID, "active").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(results))
+	for _, result := range results {
+		values[result.KeyName] = result.Value
+	}
+	return values, nil
+}
+
+// GetValuesByStatus 获取项目中指定状态（和审核状态）的所有翻译：键名 -> 语言代码 -> 翻译值
+// 只包含启用的语言
+func (r *TranslationRepository) GetValuesByStatus(ctx context.Context, projectID uint64, filter domain.TranslationStatusFilter) (map[string]map[string]string, error) {
+	var results []struct {
+		KeyName      string `gorm:"column:key_name"`
+		LanguageCode string `gorm:"column:language_code"`
+		Value        string `gorm:"column:value"`
+	}
+	query := dbFromContext(ctx, r.db).
+		Table("translations t").
+		Select("t.key_name, l.code as language_code, t.value").
+		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
+		Where("t.project_id = ? AND t.status IN ? AND t.deleted_at IS NULL", projectID, filter.Statuses)
+	if len(filter.ReviewStatuses) > 0 {
+		query = query.Where("t.review_status IN ?", filter.ReviewStatuses)
+	}
+	if filter.NamespaceID != 0 {
+		query = query.Where("t.namespace_id = ?", filter.NamespaceID)
+	}
+	if len(filter.Tags) > 0 {
+		query = query.Where(keyTagCondition, filter.Tags)
+	}
+	if err := query.Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]map[string]string)
+	for _, result := range results {
+		if values[result.KeyName] == nil {
+			values[result.KeyName] = make(map[string]string)
+		}
+		values[result.KeyName][result.LanguageCode] = result.Value
+	}
+	return values, nil
+}
+
+// GetKeyContexts 获取每个键的上下文说明
+// 上下文按翻译存储，同一键的不同语言可能不同，取 ID 最小的非空上下文
+func (r *TranslationRepository) GetKeyContexts(ctx context.Context, projectID uint64) (map[string]string, error) {
+	var results []struct {
+		KeyName string `gorm:"column:key_name"`
+		Context string `gorm:"column:context"`
+	}
+	if err := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Select("key_name, context").
+		Where("project_id = ? AND context <> ''", projectID).
+		Order("id ASC").
+		Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	contexts := make(map[string]string, len(results))
+	for _, result := range results {
+		if _, exists := contexts[result.KeyName]; !exists {
+			contexts[result.KeyName] = result.Context
+		}
+	}
+	return contexts, nil
+}
+
+// GetMatrixCells 获取指定键的翻译矩阵单元格
+func (r *TranslationRepository) GetMatrixCells(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]domain.TranslationCell, error) {
+	matrix := make(map[string]map[string]domain.TranslationCell)
 	// 如果分页后没有数据，返回空矩阵
 	if len(keyNames) == 0 {
-		return make(map[string]map[string]domain.TranslationCell), totalCount, nil
+		return matrix, nil
 	}
 
-	// 优化：使用JOIN查询避免N+1问题，只查询必要字段
+	// 优化：使用JOIN查询避免N+1问题，只查询必要字段；最后修改人的用户名在同一查询中获取
 	var results []struct {
-		ID           uint64    `gorm:"column:id"`
-		KeyName      string    `gorm:"column:key_name"`
-		LanguageCode string    `gorm:"column:language_code"`
-		Value        string    `gorm:"column:value"`
-		UpdatedAt    time.Time `gorm:"column:updated_at"`
+		ID                uint64    `gorm:"column:id"`
+		KeyName           string    `gorm:"column:key_name"`
+		LanguageCode      string    `gorm:"column:language_code"`
+		Value             string    `gorm:"column:value"`
+		ValueType         string    `gorm:"column:value_type"`
+		Status            string    `gorm:"column:status"`
+		UpdatedBy         uint64    `gorm:"column:updated_by"`
+		UpdatedByUsername string    `gorm:"column:updated_by_username"`
+		UpdatedAt         time.Time `gorm:"column:updated_at"`
+		MaxLength         int       `gorm:"column:max_length"`
+		ReviewStatus      string    `gorm:"column:review_status"`
+		Version           uint64    `gorm:"column:version"`
 	}
 
-	err := r.db.WithContext(ctx).
+	err := dbFromContext(ctx, r.db).
 		Table("translations t").
-		Select("t.id, t.key_name, l.code as language_code, t.value, t.updated_at").
+		Select("t.id, t.key_name, l.code as language_code, t.value, t.value_type, t.status, t.updated_by, COALESCE(u.username, '') as updated_by_username, t.updated_at, t.max_length, t.review_status, t.version").
 		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
-		Where("t.project_id = ? AND t.key_name IN ? AND t.status = ?", projectID, keyNames, "active").
+		Joins("LEFT JOIN users u ON u.id = t.updated_by").
+		Where("t.project_id = ? AND t.key_name IN ? AND t.status = ? AND t.deleted_at IS NULL", projectID, keyNames, "active").
 		Find(&results).Error
 
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	// 构建矩阵
-	matrix := make(map[string]map[string]domain.TranslationCell)
 	for _, result := range results {
 		if matrix[result.KeyName] == nil {
 			matrix[result.KeyName] = make(map[string]domain.TranslationCell)
 		}
 		matrix[result.KeyName][result.LanguageCode] = domain.TranslationCell{
-			ID:        result.ID,
-			Value:     result.Value,
-			UpdatedAt: result.UpdatedAt,
+			ID:                result.ID,
+			Value:             result.Value,
+			ValueType:         result.ValueType,
+			Status:            result.Status,
+			UpdatedBy:         result.UpdatedBy,
+			UpdatedByUsername: result.UpdatedByUsername,
+			UpdatedAt:         result.UpdatedAt,
+			MaxLength:         result.MaxLength,
+			ReviewStatus:      result.ReviewStatus,
+			Version:           result.Version,
 		}
 	}
 
-	return matrix, totalCount, nil
+	// 键的附件地址写入该键在各语言的单元格
+	var attachments []struct {
+		ID      uint64 `gorm:"column:id"`
+		KeyName string `gorm:"column:key_name"`
+	}
+	if err := dbFromContext(ctx, r.db).
+		Model(&domain.Attachment{}).
+		Select("id, key_name").
+		Where("project_id = ? AND key_name IN ?", projectID, keyNames).
+		Order("id ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	urls := make(map[string][]string)
+	for _, attachment := range attachments {
+		urls[attachment.KeyName] = append(urls[attachment.KeyName], domain.AttachmentContentURL(projectID, attachment.ID))
+	}
+	for keyName, row := range matrix {
+		if len(urls[keyName]) == 0 {
+			continue
+		}
+		for code, cell := range row {
+			cell.AttachmentURLs = urls[keyName]
+			row[code] = cell
+		}
+	}
+
+	return matrix, nil
+}
+
+// GetKeyPage 按键名顺序分页获取翻译（键集分页）
+// 只查询本页的键，内存占用与页大小成正比；指定语言时只返回有该语言翻译的键
+func (r *TranslationRepository) GetKeyPage(ctx context.Context, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	keyQuery := dbFromContext(ctx, r.db).
+		Table("translations t").
+		Where("t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL", query.ProjectID, "active")
+	if query.Locale != "" {
+		keyQuery = keyQuery.Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ? AND l.code = ?", "active", query.Locale)
+	}
+	if query.KeyPrefix != "" {
+		keyQuery = keyQuery.Where("t.key_name LIKE ?", escapeLike(query.KeyPrefix)+"%")
+	}
+	if query.AfterKey != "" {
+		keyQuery = keyQuery.Where("t.key_name > ?", query.AfterKey)
+	}
+	if len(query.KeyNames) > 0 {
+		keyQuery = keyQuery.Where("t.key_name IN ?", query.KeyNames)
+	}
+
+	// 多取一个键用于判断是否还有下一页
+	var keyNames []string
+	if err := keyQuery.
+		Distinct("t.key_name").
+		Order("t.key_name ASC").
+		Limit(query.Limit+1).
+		Pluck("t.key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+
+	page := &domain.TranslationKeyPage{Translations: make(map[string]map[string]string)}
+	if len(keyNames) > query.Limit {
+		keyNames = keyNames[:query.Limit]
+		page.HasMore = true
+	}
+	if len(keyNames) == 0 {
+		return page, nil
+	}
+	page.LastKey = keyNames[len(keyNames)-1]
+
+	var results []struct {
+		KeyName      string `gorm:"column:key_name"`
+		LanguageCode string `gorm:"column:language_code"`
+		Value        string `gorm:"column:value"`
+	}
+	valueQuery := dbFromContext(ctx, r.db).
+		Table("translations t").
+		Select("t.key_name, l.code as language_code, t.value").
+		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
+		Where("t.project_id = ? AND t.key_name IN ? AND t.status = ? AND t.deleted_at IS NULL", query.ProjectID, keyNames, "active")
+	if query.Locale != "" {
+		valueQuery = valueQuery.Where("l.code = ?", query.Locale)
+	}
+	if err := valueQuery.Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if page.Translations[result.KeyName] == nil {
+			page.Translations[result.KeyName] = make(map[string]string)
+		}
+		page.Translations[result.KeyName][result.LanguageCode] = result.Value
+	}
+
+	return page, nil
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// prefixQuery 构造项目下键名以 prefix 开头的翻译查询
+func (r *TranslationRepository) prefixQuery(ctx context.Context, projectID uint64, prefix string) *gorm.DB {
+	return dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name LIKE ?", projectID, escapeLike(prefix)+"%")
+}
+
+// GetKeyNamesByPrefix 获取项目下以 prefix 开头的所有键名（按键名排序）
+func (r *TranslationRepository) GetKeyNamesByPrefix(ctx context.Context, projectID uint64, prefix string) ([]string, error) {
+	var keyNames []string
+	if err := r.prefixQuery(ctx, projectID, prefix).
+		Distinct("key_name").
+		Order("key_name ASC").
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// CountByPrefix 统计项目下以 prefix 开头的翻译条数
+func (r *TranslationRepository) CountByPrefix(ctx context.Context, projectID uint64, prefix string) (int64, error) {
+	var count int64
+	if err := r.prefixQuery(ctx, projectID, prefix).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindExistingKeyNames 返回 keyNames 中在项目下已存在的键名
+// 已软删除的翻译不算作已存在，重命名时会被清除
+func (r *TranslationRepository) FindExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error) {
+	const chunkSize = 500
+
+	var existing []string
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+
+		var chunk []string
+		if err := dbFromContext(ctx, r.db).
+			Model(&domain.Translation{}).
+			Where("project_id = ? AND key_name IN ?", projectID, keyNames[start:end]).
+			Distinct("key_name").
+			Pluck("key_name", &chunk).Error; err != nil {
+			return nil, err
+		}
+		existing = append(existing, chunk...)
+	}
+	return existing, nil
+}
+
+// GetKeyValueTypes 获取非 string 类型的键的值类型和 JSON Schema，keyNames 为空时返回项目中的所有键
+// 值类型按翻译存储，同一键的所有语言保持一致，取 ID 最小的翻译
+func (r *TranslationRepository) GetKeyValueTypes(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyValueType, error) {
+	const chunkSize = 500
+
+	types := make(map[string]domain.KeyValueType)
+	load := func(names []string) error {
+		var results []struct {
+			KeyName     string `gorm:"column:key_name"`
+			ValueType   string `gorm:"column:value_type"`
+			ValueSchema string `gorm:"column:value_schema"`
+		}
+		query := dbFromContext(ctx, r.db).
+			Model(&domain.Translation{}).
+			Select("key_name, value_type, value_schema").
+			Where("project_id = ? AND value_type <> ?", projectID, domain.ValueTypeString)
+		if names != nil {
+			query = query.Where("key_name IN ?", names)
+		}
+		if err := query.Order("id ASC").Find(&results).Error; err != nil {
+			return err
+		}
+		for _, result := range results {
+			if _, exists := types[result.KeyName]; !exists {
+				types[result.KeyName] = domain.KeyValueType{ValueType: result.ValueType, ValueSchema: result.ValueSchema}
+			}
+		}
+		return nil
+	}
+
+	if len(keyNames) == 0 {
+		return types, load(nil)
+	}
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		if err := load(keyNames[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+// GetByProjectKey 获取键在各语言的翻译
+func (r *TranslationRepository) GetByProjectKey(ctx context.Context, projectID uint64, keyName string) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Order("id ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetActiveByValueTypes 获取项目中指定值类型的有效翻译，按键名和语言排序
+func (r *TranslationRepository) GetActiveByValueTypes(ctx context.Context, projectID uint64, valueTypes []string) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND value_type IN ? AND status = ?", projectID, valueTypes, "active").
+		Order("key_name ASC, language_id ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// UpdateKeyValueType 修改键在所有语言的值类型和 JSON Schema，返回修改的条数
+// 翻译值不变，不记录变更历史
+func (r *TranslationRepository) UpdateKeyValueType(ctx context.Context, projectID uint64, keyName string, valueType domain.KeyValueType, userID uint64) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ?", projectID, keyName).
+		Updates(map[string]interface{}{
+			"value_type":   valueType.ValueType,
+			"value_schema": valueType.ValueSchema,
+			"updated_by":   userID,
+			"updated_at":   time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// UpdateReviewStatus 修改翻译的审核状态，记录操作人、操作时间和驳回原因
+// 翻译值不变，不修改 updated_at
+func (r *TranslationRepository) UpdateReviewStatus(ctx context.Context, ids []uint64, reviewStatus string, userID uint64, comment string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// 每条翻译的审核状态变化记录为 review 变更历史，新值为被审核的译文，审核人和驳回原因不会被之后的审核覆盖
+	var translations []*domain.Translation
+	if err := dbFromContext(ctx, r.db).
+		Select("id, project_id, key_name, language_id, value, review_status").
+		Where("id IN ?", ids).
+		Find(&translations).Error; err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	operator := historyOperator(ctx, userID)
+	entries := make([]*domain.TranslationHistory, 0, len(translations))
+	for _, translation := range translations {
+		entries = append(entries, &domain.TranslationHistory{
+			TranslationID: translation.ID,
+			ProjectID:     translation.ProjectID,
+			KeyName:       translation.KeyName,
+			LanguageID:    translation.LanguageID,
+			Operation:     domain.HistoryOperationReview,
+			NewValue:      translation.Value,
+			OldStatus:     translation.ReviewStatus,
+			NewStatus:     reviewStatus,
+			Comment:       comment,
+			UserID:        operator,
+			CreatedAt:     now,
+		})
+	}
+	if err := recordHistory(ctx, r.db, entries); err != nil {
+		return 0, err
+	}
+
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("id IN ?", ids).
+		UpdateColumns(map[string]interface{}{
+			"review_status":  reviewStatus,
+			"reviewed_by":    userID,
+			"reviewed_at":    now,
+			"review_comment": comment,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// MarkOutdated 将键在源语言以外的非空译文标记为 outdated
+// 审核状态由系统根据源语言的修改设置，不修改 updated_at，也不记录审核历史
+func (r *TranslationRepository) MarkOutdated(ctx context.Context, projectID, sourceLanguageID uint64, keyNames []string) (int64, error) {
+	if len(keyNames) == 0 {
+		return 0, nil
+	}
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name IN ? AND language_id <> ?", projectID, keyNames, sourceLanguageID).
+		Where("value <> '' AND review_status <> ?", domain.ReviewStatusOutdated).
+		UpdateColumn("review_status", domain.ReviewStatusOutdated)
+	return result.RowsAffected, result.Error
+}
+
+// CountByReviewStatus 统计所有项目中该审核状态的有效翻译数
+func (r *TranslationRepository) CountByReviewStatus(ctx context.Context, reviewStatus string) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("review_status = ? AND status = ?", reviewStatus, "active").
+		Count(&count).Error
+	return count, err
+}
+
+// CountByLanguage 按语言聚合统计项目的有效翻译，一次查询得到所有语言的计数
+func (r *TranslationRepository) CountByLanguage(ctx context.Context, projectID uint64) (int64, []*domain.LanguageTranslationCount, error) {
+	db := dbFromContext(ctx, r.db)
+	var totalKeys int64
+	if err := db.Model(&domain.Translation{}).
+		Where("project_id = ? AND status = ?", projectID, "active").
+		Distinct("key_name").
+		Count(&totalKeys).Error; err != nil {
+		return 0, nil, err
+	}
+
+	var counts []*domain.LanguageTranslationCount
+	err := db.Model(&domain.Translation{}).
+		Select("language_id, "+
+			"SUM(CASE WHEN value <> '' AND review_status <> ? THEN 1 ELSE 0 END) AS translated, "+
+			"SUM(CASE WHEN value <> '' AND review_status = ? THEN 1 ELSE 0 END) AS outdated",
+			domain.ReviewStatusOutdated, domain.ReviewStatusOutdated).
+		Where("project_id = ? AND status = ?", projectID, "active").
+		Group("language_id").
+		Scan(&counts).Error
+	return totalKeys, counts, err
+}
+
+// GetPendingKeys 统计某语言待翻译或待审核的键，返回总数和按键名排序的前 Limit 个键
+// 按键分组在数据库中计算，不加载翻译值
+func (r *TranslationRepository) GetPendingKeys(ctx context.Context, query domain.PendingKeyQuery) (int64, []string, error) {
+	db := dbFromContext(ctx, r.db)
+	pending := db.Table("translations t").
+		Select("t.key_name").
+		Joins("INNER JOIN languages l ON t.language_id = l.id AND l.status = ?", "active").
+		Where("t.project_id = ? AND t.status = ? AND t.deleted_at IS NULL", query.ProjectID, "active")
+	if len(query.KeyNames) > 0 {
+		pending = pending.Where("t.key_name IN ?", query.KeyNames)
+	}
+	if len(query.ExcludeKeys) > 0 {
+		pending = pending.Where("t.key_name NOT IN ?", query.ExcludeKeys)
+	}
+	if query.Review {
+		pending = pending.Where("t.language_id = ? AND t.review_status = ?", query.LanguageID, domain.ReviewStatusInReview)
+	} else {
+		// 该语言没有非空且已提交审核或审核通过的有效译文，源语言修改后过期的译文需要重新翻译
+		pending = pending.Group("t.key_name").
+			Having("SUM(t.language_id = ? AND t.value <> '' AND t.review_status NOT IN ?) = 0", query.LanguageID, []string{domain.ReviewStatusDraft, domain.ReviewStatusOutdated})
+	}
+
+	var total int64
+	if err := db.Table("(?) AS pending", pending).Count(&total).Error; err != nil {
+		return 0, nil, err
+	}
+	if total == 0 || query.Limit <= 0 {
+		return total, []string{}, nil
+	}
+	var keys []string
+	if err := db.Table("(?) AS pending", pending).
+		Order("key_name ASC").
+		Limit(query.Limit).
+		Pluck("key_name", &keys).Error; err != nil {
+		return 0, nil, err
+	}
+	return total, keys, nil
+}
+
+// GetKeyMetadata 获取设置了最大长度、标签、平台或命名空间的键的元数据，keyNames 为空时返回项目中的所有键
+// 元数据按翻译存储，同一键的所有语言保持一致，取 ID 最小的翻译
+func (r *TranslationRepository) GetKeyMetadata(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyMetadata, error) {
+	const chunkSize = 500
+
+	metadata := make(map[string]domain.KeyMetadata)
+	load := func(names []string) error {
+		var results []struct {
+			KeyName     string `gorm:"column:key_name"`
+			MaxLength   int    `gorm:"column:max_length"`
+			Tags        string `gorm:"column:tags"`
+			Platform    string `gorm:"column:platform"`
+			NamespaceID uint64 `gorm:"column:namespace_id"`
+		}
+		query := dbFromContext(ctx, r.db).
+			Model(&domain.Translation{}).
+			Select("key_name, max_length, tags, platform, namespace_id").
+			Where("project_id = ? AND (max_length > 0 OR tags <> '' OR platform <> '' OR namespace_id <> 0)", projectID)
+		if names != nil {
+			query = query.Where("key_name IN ?", names)
+		}
+		if err := query.Order("id ASC").Find(&results).Error; err != nil {
+			return err
+		}
+		for _, result := range results {
+			if _, exists := metadata[result.KeyName]; !exists {
+				metadata[result.KeyName] = domain.KeyMetadata{
+					MaxLength:   result.MaxLength,
+					Tags:        result.Tags,
+					Platform:    result.Platform,
+					NamespaceID: result.NamespaceID,
+				}
+			}
+		}
+		return nil
+	}
+
+	if len(keyNames) == 0 {
+		return metadata, load(nil)
+	}
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		if err := load(keyNames[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	return metadata, nil
+}
+
+// GetKeyNamesByNamespace 获取命名空间中的键名（按键名排序），包括所有状态的翻译
+func (r *TranslationRepository) GetKeyNamesByNamespace(ctx context.Context, projectID, namespaceID uint64) ([]string, error) {
+	var keyNames []string
+	if err := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND namespace_id = ?", projectID, namespaceID).
+		Distinct("key_name").
+		Order("key_name ASC").
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// GetKeyNamesByTags 获取带有任一标签的键名（按键名排序），包括只有已软删除翻译的键
+func (r *TranslationRepository) GetKeyNamesByTags(ctx context.Context, projectID uint64, tags []string) ([]string, error) {
+	var keyNames []string
+	if err := dbFromContext(ctx, r.db).
+		Table("key_tags kt").
+		Joins("INNER JOIN tags tg ON tg.id = kt.tag_id").
+		Where("kt.project_id = ? AND tg.name IN ?", projectID, tags).
+		Distinct("kt.key_name").
+		Order("kt.key_name ASC").
+		Pluck("kt.key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// UpdateKeyNamespace 将键在所有语言的翻译归入命名空间，返回修改的条数
+// 已软删除的翻译一并修改，恢复后仍属于同一命名空间；翻译值不变，不记录变更历史
+func (r *TranslationRepository) UpdateKeyNamespace(ctx context.Context, projectID uint64, keyNames []string, namespaceID, userID uint64) (int64, error) {
+	const chunkSize = 500
+
+	var affected int64
+	for start := 0; start < len(keyNames); start += chunkSize {
+		end := start + chunkSize
+		if end > len(keyNames) {
+			end = len(keyNames)
+		}
+		result := dbFromContext(ctx, r.db).
+			Unscoped().
+			Model(&domain.Translation{}).
+			Where("project_id = ? AND key_name IN ? AND namespace_id <> ?", projectID, keyNames[start:end], namespaceID).
+			Updates(map[string]interface{}{
+				"namespace_id": namespaceID,
+				"updated_by":   historyOperator(ctx, userID),
+				"updated_at":   time.Now(),
+			})
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		affected += result.RowsAffected
+	}
+	return affected, nil
+}
+
+// UpdateKeyMetadata 修改键在所有语言的最大长度、标签和平台，返回修改的条数
+// 同时将键与标签的关联改为 metadata.Tags 中的标签，项目中没有的标签自动创建；
+// 命名空间通过 UpdateKeyNamespace 修改；翻译值不变，不记录变更历史
+func (r *TranslationRepository) UpdateKeyMetadata(ctx context.Context, projectID uint64, keyName string, metadata domain.KeyMetadata, userID uint64) (int64, error) {
+	var affected int64
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		result := dbFromContext(ctx, r.db).
+			Model(&domain.Translation{}).
+			Where("project_id = ? AND key_name = ?", projectID, keyName).
+			Updates(map[string]interface{}{
+				"max_length": metadata.MaxLength,
+				"tags":       metadata.Tags,
+				"platform":   metadata.Platform,
+				"updated_by": userID,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return syncKeyTags(dbFromContext(ctx, r.db), projectID, map[string][]string{keyName: splitKeyTags(metadata.Tags)}, userID)
+	})
+	return affected, err
+}
+
+// DeleteByPrefix 软删除项目下以 prefix 开头的翻译，返回删除的条数
+func (r *TranslationRepository) DeleteByPrefix(ctx context.Context, projectID uint64, prefix string, userID uint64) (int64, error) {
+	var affected int64
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if err := recordHistoryFrom(ctx, r.db, r.prefixQuery(ctx, projectID, prefix),
+			"id, project_id, key_name, '', language_id, ?, value, '', status, '', ?, ?",
+			domain.HistoryOperationDelete, historyOperator(ctx, userID), now); err != nil {
+			return err
+		}
+		result := r.prefixQuery(ctx, projectID, prefix).Updates(map[string]interface{}{
+			"updated_by": userID,
+			"deleted_at": now,
+		})
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
+}
+
+// filterQuery 构造按条件筛选翻译的查询
+func (r *TranslationRepository) filterQuery(ctx context.Context, filter domain.TranslationFilter) *gorm.DB {
+	query := dbFromContext(ctx, r.db).
+		Model(&domain.Translation{}).
+		Where("project_id = ?", filter.ProjectID)
+	if filter.KeyPrefix != "" {
+		query = query.Where("key_name LIKE ?", escapeLike(filter.KeyPrefix)+"%")
+	}
+	if filter.LanguageID != 0 {
+		query = query.Where("language_id = ?", filter.LanguageID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.OlderThan != nil {
+		query = query.Where("updated_at < ?", *filter.OlderThan)
+	}
+	return query
+}
+
+// CountByFilter 统计符合条件的翻译条数和键数量
+func (r *TranslationRepository) CountByFilter(ctx context.Context, filter domain.TranslationFilter) (int64, int64, error) {
+	var counts struct {
+		TranslationCount int64
+		KeyCount         int64
+	}
+	if err := r.filterQuery(ctx, filter).
+		Select("COUNT(*) AS translation_count, COUNT(DISTINCT key_name) AS key_count").
+		Scan(&counts).Error; err != nil {
+		return 0, 0, err
+	}
+	return counts.TranslationCount, counts.KeyCount, nil
+}
+
+// GetKeyNamesByFilter 获取符合条件的键名（按键名排序），最多 limit 个
+func (r *TranslationRepository) GetKeyNamesByFilter(ctx context.Context, filter domain.TranslationFilter, limit int) ([]string, error) {
+	var keyNames []string
+	if err := r.filterQuery(ctx, filter).
+		Distinct("key_name").
+		Order("key_name ASC").
+		Limit(limit).
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return nil, err
+	}
+	return keyNames, nil
+}
+
+// FindByFilter 按 ID 顺序获取符合条件的翻译，最多 limit 条，只包含 ID、键名和语言
+func (r *TranslationRepository) FindByFilter(ctx context.Context, filter domain.TranslationFilter, limit int) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	if err := r.filterQuery(ctx, filter).
+		Select("id", "key_name", "language_id").
+		Order("id ASC").
+		Limit(limit).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// DeleteByIDs 软删除指定的翻译并记录操作人，返回删除的条数
+func (r *TranslationRepository) DeleteByIDs(ctx context.Context, ids []uint64, userID uint64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	var affected int64
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if err := r.recordDeleteHistory(ctx, ids, historyOperator(ctx, userID), now); err != nil {
+			return err
+		}
+		result := dbFromContext(ctx, r.db).
+			Model(&domain.Translation{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"updated_by": userID,
+				"deleted_at": now,
+			})
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
+}
+
+// recordDeleteHistory 为即将删除的翻译记录变更历史，已删除的翻译不记录
+func (r *TranslationRepository) recordDeleteHistory(ctx context.Context, ids []uint64, operator uint64, now time.Time) error {
+	return recordHistoryFrom(ctx, r.db,
+		dbFromContext(ctx, r.db).Model(&domain.Translation{}).Where("id IN ?", ids),
+		"id, project_id, key_name, '', language_id, ?, value, '', status, '', ?, ?",
+		domain.HistoryOperationDelete, operator, now)
+}
+
+// UpdateStatusByPrefix 修改项目下以 prefix 开头的翻译状态，返回修改的条数
+func (r *TranslationRepository) UpdateStatusByPrefix(ctx context.Context, projectID uint64, prefix, status string, userID uint64) (int64, error) {
+	var affected int64
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := recordHistoryFrom(ctx, r.db, r.prefixQuery(ctx, projectID, prefix).Where("status <> ?", status),
+			"id, project_id, key_name, '', language_id, ?, value, value, status, ?, ?, ?",
+			domain.HistoryOperationStatus, status, historyOperator(ctx, userID), time.Now()); err != nil {
+			return err
+		}
+		result := r.prefixQuery(ctx, projectID, prefix).
+			Where("status <> ?", status).
+			Updates(map[string]interface{}{
+				"status":     status,
+				"updated_by": userID,
+			})
+		affected = result.RowsAffected
+		return result.Error
+	})
+	return affected, err
+}
+
+// RenamePrefix 将项目下以 prefix 开头的键名替换为以 newPrefix 开头，返回修改的条数
+// 与重命名结果冲突的已软删除翻译会被永久删除，以释放唯一索引
+func (r *TranslationRepository) RenamePrefix(ctx context.Context, projectID uint64, prefix, newPrefix string, userID uint64) (int64, error) {
+	var affected int64
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := dbFromContext(ctx, r.db).Exec(
+			`DELETE d FROM translations d
+			INNER JOIN translations s ON s.project_id = d.project_id AND s.language_id = d.language_id
+				AND d.key_name = CONCAT(?, SUBSTRING(s.key_name, ?))
+			WHERE d.project_id = ? AND d.deleted_at IS NOT NULL AND s.deleted_at IS NULL AND s.key_name LIKE ?`,
+			newPrefix, len([]rune(prefix))+1, projectID, escapeLike(prefix)+"%",
+		).Error; err != nil {
+			return err
+		}
+
+		if err := recordHistoryFrom(ctx, r.db, r.prefixQuery(ctx, projectID, prefix),
+			"id, project_id, CONCAT(?, SUBSTRING(key_name, ?)), key_name, language_id, ?, value, value, status, status, ?, ?",
+			newPrefix, len([]rune(prefix))+1, domain.HistoryOperationRename, historyOperator(ctx, userID), time.Now()); err != nil {
+			return err
+		}
+
+		result := r.prefixQuery(ctx, projectID, prefix).Updates(map[string]interface{}{
+			"key_name":   gorm.Expr("CONCAT(?, SUBSTRING(key_name, ?))", newPrefix, len([]rune(prefix))+1),
+			"updated_by": userID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+		return r.renameKeyTagPrefix(ctx, projectID, prefix, newPrefix)
+	})
+	return affected, err
+}
+
+// renameKeyTagPrefix 将以 prefix 开头的键的标签关联改为以 newPrefix 开头的键名
+// 重命名结果上遗留的关联（如已永久删除的键）先删除，以释放主键
+func (r *TranslationRepository) renameKeyTagPrefix(ctx context.Context, projectID uint64, prefix, newPrefix string) error {
+	db := dbFromContext(ctx, r.db)
+	var keyNames []string
+	if err := db.Model(&domain.KeyTag{}).
+		Where("project_id = ? AND key_name LIKE ?", projectID, escapeLike(prefix)+"%").
+		Distinct("key_name").
+		Pluck("key_name", &keyNames).Error; err != nil {
+		return err
+	}
+	if len(keyNames) == 0 {
+		return nil
+	}
+	renamed := make(map[string]bool, len(keyNames))
+	for _, keyName := range keyNames {
+		renamed[keyName] = true
+	}
+	var targets []string
+	for _, keyName := range keyNames {
+		target := newPrefix + string([]rune(keyName)[len([]rune(prefix)):])
+		if !renamed[target] {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) > 0 {
+		if err := db.Where("project_id = ? AND key_name IN ?", projectID, targets).Delete(&domain.KeyTag{}).Error; err != nil {
+			return err
+		}
+	}
+	return db.Model(&domain.KeyTag{}).
+		Where("project_id = ? AND key_name IN ?", projectID, keyNames).
+		Update("key_name", gorm.Expr("CONCAT(?, SUBSTRING(key_name, ?))", newPrefix, len([]rune(prefix))+1)).Error
+}
+
+// RenameKey 将项目中键在所有语言的翻译改为新键名，返回修改的条数
+// 与重命名结果冲突的已软删除翻译会被永久删除，以释放唯一索引
+func (r *TranslationRepository) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string, userID uint64) (int64, error) {
+	var affected int64
+	err := r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := dbFromContext(ctx, r.db).Exec(
+			`DELETE d FROM translations d
+			INNER JOIN translations s ON s.project_id = d.project_id AND s.language_id = d.language_id AND s.key_name = ?
+			WHERE d.project_id = ? AND d.key_name = ? AND d.deleted_at IS NOT NULL AND s.deleted_at IS NULL`,
+			keyName, projectID, newKeyName,
+		).Error; err != nil {
+			return err
+		}
+
+		keyQuery := func() *gorm.DB {
+			return dbFromContext(ctx, r.db).Model(&domain.Translation{}).
+				Where("project_id = ? AND key_name = ?", projectID, keyName)
+		}
+		if err := recordHistoryFrom(ctx, r.db, keyQuery(),
+			"id, project_id, ?, key_name, language_id, ?, value, value, status, status, ?, ?",
+			newKeyName, domain.HistoryOperationRename, historyOperator(ctx, userID), time.Now()); err != nil {
+			return err
+		}
+
+		result := keyQuery().Updates(map[string]interface{}{
+			"key_name":   newKeyName,
+			"updated_by": userID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected = result.RowsAffected
+
+		// 标签关联随键改名，新键名上遗留的关联先删除
+		db := dbFromContext(ctx, r.db)
+		if err := db.Where("project_id = ? AND key_name = ?", projectID, newKeyName).Delete(&domain.KeyTag{}).Error; err != nil {
+			return err
+		}
+		return db.Model(&domain.KeyTag{}).
+			Where("project_id = ? AND key_name = ?", projectID, keyName).
+			Update("key_name", newKeyName).Error
+	})
+	return affected, err
 }
 
 // Create 创建翻译
+// 唯一索引包含已软删除的翻译，存在相同键名和语言的已删除翻译时恢复该记录并覆盖其内容
 func (r *TranslationRepository) Create(ctx context.Context, translation *domain.Translation) error {
-	return r.db.WithContext(ctx).Create(translation).Error
+	return r.CreateBatch(ctx, []*domain.Translation{translation})
 }
 
-// CreateBatch 批量创建翻译
+// CreateBatch 批量创建翻译，已软删除的同键翻译会被恢复（见 Create）
 func (r *TranslationRepository) CreateBatch(ctx context.Context, translations []*domain.Translation) error {
 	if len(translations) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).CreateInBatches(translations, 100).Error
+
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		restored, err := r.restoreDeleted(ctx, translations)
+		if err != nil {
+			return err
+		}
+		remaining := translations
+		if len(restored) > 0 {
+			remaining = make([]*domain.Translation, 0, len(translations)-len(restored))
+			for _, translation := range translations {
+				if restored[translation] == nil {
+					remaining = append(remaining, translation)
+				}
+			}
+		}
+		if len(remaining) > 0 {
+			if err := dbFromContext(ctx, r.db).CreateInBatches(remaining, 100).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := syncKeyTagsFromTranslations(dbFromContext(ctx, r.db), translations); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		entries := make([]*domain.TranslationHistory, 0, len(translations))
+		for _, translation := range translations {
+			operator := historyOperator(ctx, translation.CreatedBy)
+			if previous := restored[translation]; previous != nil {
+				entry := newTranslationHistory(translation, domain.HistoryOperationRestore, operator, now)
+				entry.OldValue = previous.Value
+				entry.OldStatus = previous.Status
+				entries = append(entries, entry)
+				continue
+			}
+			entries = append(entries, newTranslationHistory(translation, domain.HistoryOperationCreate, operator, now))
+		}
+		return recordHistory(ctx, r.db, entries)
+	})
+}
+
+// restoreDeleted 用 translations 的内容恢复相同键名和语言的已软删除翻译，返回被恢复的翻译及其恢复前的内容
+// 被恢复的翻译沿用原记录的 ID，创建时间和创建人按本次创建重置
+func (r *TranslationRepository) restoreDeleted(ctx context.Context, translations []*domain.Translation) (map[*domain.Translation]*domain.Translation, error) {
+	const chunkSize = 500
+
+	restored := make(map[*domain.Translation]*domain.Translation)
+	for start := 0; start < len(translations); start += chunkSize {
+		end := start + chunkSize
+		if end > len(translations) {
+			end = len(translations)
+		}
+		chunk := translations[start:end]
+
+		conditions := make([][]interface{}, 0, len(chunk))
+		for _, translation := range chunk {
+			conditions = append(conditions, []interface{}{translation.ProjectID, translation.KeyName, translation.LanguageID})
+		}
+		var deleted []*domain.Translation
+		if err := dbFromContext(ctx, r.db).
+			Unscoped().
+			Select("id", "project_id", "key_name", "language_id", "value", "status", "version").
+			Where("(project_id, key_name, language_id) IN ? AND deleted_at IS NOT NULL", conditions).
+			Find(&deleted).Error; err != nil {
+			return nil, err
+		}
+		if len(deleted) == 0 {
+			continue
+		}
+
+		deletedByKey := make(map[domain.TranslationKey]*domain.Translation, len(deleted))
+		for _, d := range deleted {
+			deletedByKey[domain.TranslationKey{ProjectID: d.ProjectID, KeyName: d.KeyName, LanguageID: d.LanguageID}] = d
+		}
+		now := time.Now()
+		for _, translation := range chunk {
+			previous, ok := deletedByKey[domain.TranslationKey{ProjectID: translation.ProjectID, KeyName: translation.KeyName, LanguageID: translation.LanguageID}]
+			if !ok {
+				continue
+			}
+			id := previous.ID
+			if translation.Status == "" {
+				translation.Status = "active"
+			}
+			if err := dbFromContext(ctx, r.db).
+				Unscoped().
+				Model(&domain.Translation{}).
+				Where("id = ?", id).
+				Updates(map[string]interface{}{
+					"context":       translation.Context,
+					"value":         translation.Value,
+					"value_type":    translation.ValueType,
+					"value_schema":  translation.ValueSchema,
+					"max_length":    translation.MaxLength,
+					"tags":          translation.Tags,
+					"platform":      translation.Platform,
+					"namespace_id":  translation.NamespaceID,
+					"status":        translation.Status,
+					"review_status": domain.ReviewStatusDraft,
+					"version":       previous.Version + 1,
+					"created_by":    translation.CreatedBy,
+					"updated_by":    translation.UpdatedBy,
+					"created_at":    now,
+					"updated_at":    now,
+					"deleted_at":    nil,
+				}).Error; err != nil {
+				return nil, err
+			}
+			translation.ID = id
+			translation.Version = previous.Version + 1
+			translation.CreatedAt = now
+			translation.UpdatedAt = now
+			translation.DeletedAt = gorm.DeletedAt{}
+			restored[translation] = previous
+		}
+	}
+	return restored, nil
 }
 
 // Update 更新翻译
+// 键名或语言改为与已软删除的翻译相同时，永久删除该已删除翻译以释放唯一索引；
+// translation 的版本号须与数据库中的一致，否则说明读取之后翻译已被修改，返回 ErrTranslationConflict
 func (r *TranslationRepository) Update(ctx context.Context, translation *domain.Translation) error {
-	return r.db.WithContext(ctx).Save(translation).Error
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var previous domain.Translation
+		found := true
+		if err := dbFromContext(ctx, r.db).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("key_name", "value", "status", "version").
+			Where("id = ?", translation.ID).
+			Take(&previous).Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			found = false
+		}
+		if found {
+			if previous.Version != translation.Version {
+				return domain.ErrTranslationConflict
+			}
+			translation.Version++
+		}
+
+		if err := dbFromContext(ctx, r.db).
+			Unscoped().
+			Where("project_id = ? AND key_name = ? AND language_id = ? AND id <> ? AND deleted_at IS NOT NULL",
+				translation.ProjectID, translation.KeyName, translation.LanguageID, translation.ID).
+			Delete(&domain.Translation{}).Error; err != nil {
+			return err
+		}
+		if err := dbFromContext(ctx, r.db).Save(translation).Error; err != nil {
+			return err
+		}
+
+		operator := historyOperator(ctx, translation.UpdatedBy)
+		if !found {
+			return recordHistory(ctx, r.db, []*domain.TranslationHistory{
+				newTranslationHistory(translation, domain.HistoryOperationCreate, operator, time.Now()),
+			})
+		}
+		if previous.KeyName == translation.KeyName && previous.Value == translation.Value && previous.Status == translation.Status {
+			return nil
+		}
+		entry := newTranslationHistory(translation, domain.HistoryOperationUpdate, operator, time.Now())
+		entry.OldValue = previous.Value
+		entry.OldStatus = previous.Status
+		if previous.KeyName != translation.KeyName {
+			entry.PreviousKey = previous.KeyName
+		}
+		return recordHistory(ctx, r.db, []*domain.TranslationHistory{entry})
+	})
 }
 
 // Delete 删除翻译
 func (r *TranslationRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&domain.Translation{}, id).Error
+	return r.DeleteBatch(ctx, []uint64{id})
 }
 
 // DeleteBatch 批量删除翻译
@@ -245,33 +1354,217 @@ func (r *TranslationRepository) DeleteBatch(ctx context.Context, ids []uint64) e
 	if len(ids) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Delete(&domain.Translation{}, ids).Error
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.recordDeleteHistory(ctx, ids, historyOperator(ctx, 0), time.Now()); err != nil {
+			return err
+		}
+		return dbFromContext(ctx, r.db).Delete(&domain.Translation{}, ids).Error
+	})
 }
 
 // UpsertBatch 批量创建或更新翻译
 // 如果翻译已存在（基于唯一索引：project_id + key_name + language_id），则更新
-// 如果不存在，则创建
+// 如果不存在，则创建；与已软删除的翻译冲突时恢复该翻译并重置其状态
 // 使用数据库原生的 UPSERT 能力（MySQL: ON DUPLICATE KEY UPDATE, PostgreSQL: ON CONFLICT DO UPDATE）
 func (r *TranslationRepository) UpsertBatch(ctx context.Context, translations []*domain.Translation) error {
 	if len(translations) == 0 {
 		return nil
 	}
 
-	// 使用 GORM 的 OnConflict 子句实现 Upsert
-	// 这会根据不同数据库自动生成对应的 SQL：
-	// - MySQL: INSERT ... ON DUPLICATE KEY UPDATE
-	// - PostgreSQL: INSERT ... ON CONFLICT ... DO UPDATE
-	// - SQLite: INSERT ... ON CONFLICT ... DO UPDATE
-	return r.db.WithContext(ctx).
-		Clauses(clause.OnConflict{
-			// 基于唯一索引 idx_translation_unique (project_id, key_name, language_id)
-			Columns: []clause.Column{
-				{Name: "project_id"},
-				{Name: "key_name"},
-				{Name: "language_id"},
-			},
-			// 冲突时更新这些字段
-			DoUpdates: clause.AssignmentColumns([]string{"value", "context", "updated_at"}),
-		}).
-		Create(&translations).Error
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		previous, err := r.findByKeysUnscoped(ctx, translations)
+		if err != nil {
+			return err
+		}
+
+		// 使用 GORM 的 OnConflict 子句实现 Upsert
+		// 这会根据不同数据库自动生成对应的 SQL：
+		// - MySQL: INSERT ... ON DUPLICATE KEY UPDATE
+		// - PostgreSQL: INSERT ... ON CONFLICT ... DO UPDATE
+		// - SQLite: INSERT ... ON CONFLICT ... DO UPDATE
+		if err := dbFromContext(ctx, r.db).
+			Clauses(clause.OnConflict{
+				// 基于唯一索引 idx_translation_unique (project_id, key_name, language_id)
+				Columns: []clause.Column{
+					{Name: "project_id"},
+					{Name: "key_name"},
+					{Name: "language_id"},
+				},
+				// 冲突时更新这些字段；status 须在 deleted_at 之前赋值，以便根据原记录是否已删除决定是否重置；
+				// review_status 和 version 须在 value 之前赋值，译文变化或恢复已删除的翻译时回到草稿并增加版本号
+				DoUpdates: append(append([]clause.Assignment{
+					{Column: clause.Column{Name: "review_status"}, Value: gorm.Expr("IF(deleted_at IS NULL AND value = VALUES(value), review_status, ?)", domain.ReviewStatusDraft)},
+					{Column: clause.Column{Name: "version"}, Value: gorm.Expr("IF(deleted_at IS NULL AND value = VALUES(value), version, version + 1)")},
+				}, clause.AssignmentColumns([]string{"value", "value_type", "value_schema", "max_length", "tags", "platform", "namespace_id", "context", "updated_at"})...),
+					clause.Assignment{Column: clause.Column{Name: "status"}, Value: gorm.Expr("IF(deleted_at IS NULL, status, VALUES(status))")},
+					clause.Assignment{Column: clause.Column{Name: "deleted_at"}, Value: nil},
+				),
+			}).
+			Create(&translations).Error; err != nil {
+			return err
+		}
+
+		if err := syncKeyTagsFromTranslations(dbFromContext(ctx, r.db), translations); err != nil {
+			return err
+		}
+
+		// 批量 UPSERT 回填的 ID 不可靠，重新查询写入后的翻译
+		current, err := r.findByKeysUnscoped(ctx, translations)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		entries := make([]*domain.TranslationHistory, 0, len(translations))
+		for _, translation := range translations {
+			key := domain.TranslationKey{ProjectID: translation.ProjectID, KeyName: translation.KeyName, LanguageID: translation.LanguageID}
+			after, ok := current[key]
+			if !ok {
+				continue
+			}
+			delete(current, key) // 同一批次中重复的键只记录一次
+			operator := historyOperator(ctx, translation.UpdatedBy)
+			before, ok := previous[key]
+			switch {
+			case !ok:
+				entries = append(entries, newTranslationHistory(after, domain.HistoryOperationCreate, operator, now))
+			case before.DeletedAt.Valid:
+				entry := newTranslationHistory(after, domain.HistoryOperationRestore, operator, now)
+				entry.OldValue = before.Value
+				entry.OldStatus = before.Status
+				entries = append(entries, entry)
+			case before.Value != after.Value:
+				entry := newTranslationHistory(after, domain.HistoryOperationUpdate, operator, now)
+				entry.OldValue = before.Value
+				entry.OldStatus = before.Status
+				entries = append(entries, entry)
+			}
+		}
+		return recordHistory(ctx, r.db, entries)
+	})
+}
+
+// findByKeysUnscoped 按键名和语言获取翻译（包括已软删除的），不包含上下文
+func (r *TranslationRepository) findByKeysUnscoped(ctx context.Context, translations []*domain.Translation) (map[domain.TranslationKey]*domain.Translation, error) {
+	const chunkSize = 500
+
+	found := make(map[domain.TranslationKey]*domain.Translation, len(translations))
+	for start := 0; start < len(translations); start += chunkSize {
+		end := start + chunkSize
+		if end > len(translations) {
+			end = len(translations)
+		}
+
+		conditions := make([][]interface{}, 0, end-start)
+		for _, translation := range translations[start:end] {
+			conditions = append(conditions, []interface{}{translation.ProjectID, translation.KeyName, translation.LanguageID})
+		}
+		var chunk []*domain.Translation
+		if err := dbFromContext(ctx, r.db).
+			Unscoped().
+			Select("id", "project_id", "key_name", "language_id", "value", "status", "updated_by", "deleted_at").
+			Where("(project_id, key_name, language_id) IN ?", conditions).
+			Find(&chunk).Error; err != nil {
+			return nil, err
+		}
+		for _, translation := range chunk {
+			found[domain.TranslationKey{ProjectID: translation.ProjectID, KeyName: translation.KeyName, LanguageID: translation.LanguageID}] = translation
+		}
+	}
+	return found, nil
+}
+
+// GetDeleted 分页获取项目中已删除的翻译，最近删除的在前
+func (r *TranslationRepository) GetDeleted(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Translation, int64, error) {
+	var translations []*domain.Translation
+	var total int64
+
+	query := dbFromContext(ctx, r.db).
+		Unscoped().
+		Model(&domain.Translation{}).
+		Where("project_id = ? AND deleted_at IS NOT NULL", projectID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Preload("Language").
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&translations).Error; err != nil {
+		return nil, 0, err
+	}
+	return translations, total, nil
+}
+
+// GetDeletedByID 获取已删除的翻译，不存在或未删除时返回 ErrTranslationNotFound
+func (r *TranslationRepository) GetDeletedByID(ctx context.Context, id uint64) (*domain.Translation, error) {
+	var translation domain.Translation
+	if err := dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&translation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrTranslationNotFound
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// Restore 恢复已删除的翻译，翻译值和状态保持删除时的内容
+// 键级别的元数据使用 translation 中的值，以便与该键在其他语言的翻译保持一致
+func (r *TranslationRepository) Restore(ctx context.Context, translation *domain.Translation, userID uint64) error {
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		result := dbFromContext(ctx, r.db).
+			Unscoped().
+			Model(&domain.Translation{}).
+			Where("id = ? AND deleted_at IS NOT NULL", translation.ID).
+			Updates(map[string]interface{}{
+				"value_type":   translation.ValueType,
+				"value_schema": translation.ValueSchema,
+				"max_length":   translation.MaxLength,
+				"tags":         translation.Tags,
+				"platform":     translation.Platform,
+				"namespace_id": translation.NamespaceID,
+				"updated_by":   userID,
+				"updated_at":   now,
+				"deleted_at":   nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrTranslationNotFound
+		}
+		translation.UpdatedBy = userID
+		translation.UpdatedAt = now
+		translation.DeletedAt = gorm.DeletedAt{}
+
+		return recordHistory(ctx, r.db, []*domain.TranslationHistory{
+			newTranslationHistory(translation, domain.HistoryOperationRestore, historyOperator(ctx, userID), now),
+		})
+	})
+}
+
+// PurgeDeleted 永久删除删除时间早于 before 的翻译，最多 limit 条，返回删除的条数
+// 变更历史保留，仍可查看已清除翻译的修改记录
+func (r *TranslationRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error) {
+	var ids []uint64
+	if err := dbFromContext(ctx, r.db).
+		Unscoped().
+		Model(&domain.Translation{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
+		Where("id IN ? AND deleted_at IS NOT NULL", ids).
+		Delete(&domain.Translation{})
+	return result.RowsAffected, result.Error
 }
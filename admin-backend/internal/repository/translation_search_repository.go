@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TranslationSearchRepository 翻译搜索仓储实现，使用 LIKE 和 REGEXP_LIKE 逐行匹配
+type TranslationSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationSearchRepository 创建翻译搜索仓储实例
+func NewTranslationSearchRepository(db *gorm.DB) *TranslationSearchRepository {
+	return &TranslationSearchRepository{db: db}
+}
+
+// FindCandidates 获取键名或译文符合搜索条件的翻译，按更新时间倒序
+// exact 模式区分大小写和重音，regex 模式使用 MySQL 的 REGEXP_LIKE 并忽略大小写，
+// fuzzy 模式忽略大小写和重音并匹配任一片段。不包括已删除项目中的翻译
+func (r *TranslationSearchRepository) FindCandidates(ctx context.Context, query domain.TranslationSearchQuery) ([]*domain.TranslationSearchCandidate, error) {
+	db, err := searchQuery(dbFromContext(ctx, r.db), query)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*domain.TranslationSearchCandidate
+	err = db.Order("t.updated_at DESC, t.id DESC").Limit(query.Limit).Scan(&candidates).Error
+	return candidates, err
+}
+
+// FullTextSearchRepository 使用全文索引的翻译搜索仓储实现
+// translations 表的 key_name 和 value 上有使用 ngram 分词的全文索引（idx_translations_fulltext）时，
+// exact 和 fuzzy 模式先用 MATCH ... AGAINST 通过索引缩小范围，再用与 LIKE 实现相同的条件确认，
+// 结果与 LIKE 实现一致；fuzzy 模式的候选翻译按全文相关度排序。
+// regex 模式、片段短于分词长度或包含标点的查询无法使用索引，与 LIKE 实现相同；全文索引不存在时也与 LIKE 实现相同
+type FullTextSearchRepository struct {
+	db        *gorm.DB
+	once      sync.Once
+	indexed   bool // 全文索引是否存在
+	tokenSize int  // ngram 分词长度
+}
+
+// NewFullTextSearchRepository 创建使用全文索引的翻译搜索仓储实例
+func NewFullTextSearchRepository(db *gorm.DB) *FullTextSearchRepository {
+	return &FullTextSearchRepository{db: db}
+}
+
+// FindCandidates 获取键名或译文符合搜索条件的翻译
+// fuzzy 模式按全文相关度和更新时间倒序，其他模式按更新时间倒序
+func (r *FullTextSearchRepository) FindCandidates(ctx context.Context, query domain.TranslationSearchQuery) ([]*domain.TranslationSearchCandidate, error) {
+	db, err := searchQuery(dbFromContext(ctx, r.db), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var phrases []string
+	switch query.Mode {
+	case domain.SearchModeExact:
+		phrases = []string{query.Text}
+	case domain.SearchModeFuzzy:
+		phrases = query.Terms
+	}
+	if against, ok := r.against(ctx, phrases); ok {
+		db = db.Where("MATCH(t.key_name, t.value) AGAINST (? IN BOOLEAN MODE)", against)
+		if query.Mode == domain.SearchModeFuzzy {
+			db = db.Order(clause.OrderBy{Expression: gorm.Expr("MATCH(t.key_name, t.value) AGAINST (? IN BOOLEAN MODE) DESC", against)})
+		}
+	}
+
+	var candidates []*domain.TranslationSearchCandidate
+	err = db.Order("t.updated_at DESC, t.id DESC").Limit(query.Limit).Scan(&candidates).Error
+	return candidates, err
+}
+
+// against 将片段转为布尔模式的全文查询，匹配任一片段
+// 每个片段作为短语查询，ngram 分词下相当于包含该片段。片段为空、短于分词长度或包含字母、数字和空白以外的字符时
+// 返回 false，不使用全文索引
+func (r *FullTextSearchRepository) against(ctx context.Context, phrases []string) (string, bool) {
+	r.once.Do(func() { r.inspect(ctx) })
+	if len(phrases) == 0 || !r.indexed {
+		return "", false
+	}
+	quoted := make([]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		words := strings.Fields(phrase)
+		if len(words) == 0 {
+			return "", false
+		}
+		phrase = strings.Join(words, " ")
+		for _, word := range words {
+			if len([]rune(word)) < r.tokenSize {
+				return "", false
+			}
+		}
+		if strings.IndexFunc(phrase, func(c rune) bool {
+			return c != ' ' && !unicode.IsLetter(c) && !unicode.IsNumber(c)
+		}) >= 0 {
+			return "", false
+		}
+		quoted = append(quoted, `"`+phrase+`"`)
+	}
+	return strings.Join(quoted, " "), true
+}
+
+// inspect 检查全文索引是否存在，并查询 ngram 分词长度（只能在 MySQL 启动时设置），查询失败时使用默认值 2
+func (r *FullTextSearchRepository) inspect(ctx context.Context) {
+	db := r.db.WithContext(ctx)
+	if exists, err := indexExists(db, "translations", fullTextIndexName); err == nil {
+		r.indexed = exists
+	}
+	r.tokenSize = 2
+	var size int
+	if err := db.Raw("SELECT @@ngram_token_size").Scan(&size).Error; err == nil && size > 0 {
+		r.tokenSize = size
+	}
+}
+
+// searchQuery 构造符合搜索模式和过滤条件的翻译查询，不包括已删除项目中的翻译
+func searchQuery(db *gorm.DB, query domain.TranslationSearchQuery) (*gorm.DB, error) {
+	db = db.Table("translations t").
+		Select("t.id AS translation_id, t.project_id, p.name AS project_name, t.key_name, l.code AS language_code, " +
+			"t.value, t.status, t.review_status, t.updated_by, t.updated_at").
+		Joins("INNER JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL").
+		Joins("INNER JOIN languages l ON l.id = t.language_id").
+		Where("t.deleted_at IS NULL")
+
+	switch query.Mode {
+	case domain.SearchModeExact:
+		collation := " COLLATE " + searchCollations[domain.SearchCollationExact]
+		pattern := "%" + escapeLike(query.Text) + "%"
+		db = db.Where("(t.key_name"+collation+" LIKE ? OR t.value"+collation+" LIKE ?)", pattern, pattern)
+	case domain.SearchModeRegex:
+		db = db.Where("(REGEXP_LIKE(t.key_name, ?, 'i') OR REGEXP_LIKE(t.value, ?, 'i'))", query.Text, query.Text)
+	case domain.SearchModeFuzzy:
+		collation := " COLLATE " + searchCollations[domain.SearchCollationAccentInsensitive]
+		conditions := make([]string, 0, len(query.Terms))
+		args := make([]interface{}, 0, 2*len(query.Terms))
+		for _, term := range query.Terms {
+			pattern := "%" + escapeLike(term) + "%"
+			conditions = append(conditions, "t.key_name"+collation+" LIKE ? OR t.value"+collation+" LIKE ?")
+			args = append(args, pattern, pattern)
+		}
+		if len(conditions) > 0 {
+			db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+		}
+	default:
+		return nil, domain.ErrInvalidSearchMode
+	}
+
+	if query.ProjectIDs != nil {
+		db = db.Where("t.project_id IN ?", query.ProjectIDs)
+	}
+	if query.LanguageCode != "" {
+		db = db.Where("l.code = ?", query.LanguageCode)
+	}
+	if query.Status != "" {
+		db = db.Where("t.status = ?", query.Status)
+	}
+	if query.ReviewStatus != "" {
+		db = db.Where("t.review_status = ?", query.ReviewStatus)
+	}
+	if query.UpdatedBy != 0 {
+		db = db.Where("t.updated_by = ?", query.UpdatedBy)
+	}
+	if len(query.Tags) > 0 {
+		db = db.Where(keyTagCondition, query.Tags)
+	}
+	if query.From != nil {
+		db = db.Where("t.updated_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("t.updated_at < ?", *query.To)
+	}
+	return db, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"yflow/internal/domain"
+)
+
+// UsageStatRepository 翻译拉取用量仓储实现
+type UsageStatRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageStatRepository 创建翻译拉取用量仓储实例
+func NewUsageStatRepository(db *gorm.DB) *UsageStatRepository {
+	return &UsageStatRepository{db: db}
+}
+
+// Increment 将计数累加到对应的日汇总行，行不存在时创建
+func (r *UsageStatRepository) Increment(ctx context.Context, stats []*domain.UsageStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{
+			// 基于唯一索引 idx_usage_stat (project_id, date, channel, locale, consumer)
+			Columns: []clause.Column{
+				{Name: "project_id"},
+				{Name: "date"},
+				{Name: "channel"},
+				{Name: "locale"},
+				{Name: "consumer"},
+			},
+			DoUpdates: []clause.Assignment{
+				{Column: clause.Column{Name: "requests"}, Value: gorm.Expr("requests + VALUES(requests)")},
+				{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("VALUES(updated_at)")},
+			},
+		}).
+		Create(&stats).Error
+}
+
+// GetByProjectSince 获取项目自 since（含）以来的日汇总
+func (r *UsageStatRepository) GetByProjectSince(ctx context.Context, projectID uint64, since time.Time) ([]*domain.UsageStat, error) {
+	var stats []*domain.UsageStat
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ? AND date >= ?", projectID, since).
+		Order("date ASC").
+		Find(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
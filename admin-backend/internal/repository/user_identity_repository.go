@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// UserIdentityRepository 单点登录账号关联仓储实现
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewUserIdentityRepository 创建单点登录账号关联仓储实例
+func NewUserIdentityRepository(db *gorm.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{db: db}
+}
+
+// GetByProviderSubject 根据身份提供方和提供方账号ID获取关联
+func (r *UserIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	if err := dbFromContext(ctx, r.db).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSSOIdentityNotFound
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Create 创建关联
+func (r *UserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	return dbFromContext(ctx, r.db).Create(identity).Error
+}
+
+// Update 更新关联
+func (r *UserIdentityRepository) Update(ctx context.Context, identity *domain.UserIdentity) error {
+	return dbFromContext(ctx, r.db).Save(identity).Error
+}
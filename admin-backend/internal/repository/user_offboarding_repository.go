@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// userReference 记录用户ID的表字段
+type userReference struct {
+	name   string // 结果中使用的名称：表.字段
+	model  interface{}
+	column string
+}
+
+// userReferences 删除用户时需要改写的内容引用（创建人、修改人等）
+// 翻译变更历史和审计日志的操作人是历史事实，只在匿名化时处理；邀请码的邀请人有外键约束，单独处理
+var userReferences = []userReference{
+	{"users.created_by", &domain.User{}, "created_by"},
+	{"users.updated_by", &domain.User{}, "updated_by"},
+	{"projects.created_by", &domain.Project{}, "created_by"},
+	{"projects.updated_by", &domain.Project{}, "updated_by"},
+	{"languages.created_by", &domain.Language{}, "created_by"},
+	{"languages.updated_by", &domain.Language{}, "updated_by"},
+	{"translations.created_by", &domain.Translation{}, "created_by"},
+	{"translations.updated_by", &domain.Translation{}, "updated_by"},
+	{"translations.reviewed_by", &domain.Translation{}, "reviewed_by"},
+	{"project_members.created_by", &domain.ProjectMember{}, "created_by"},
+	{"project_members.updated_by", &domain.ProjectMember{}, "updated_by"},
+	{"role_reviews.resolved_by", &domain.RoleReview{}, "resolved_by"},
+	{"inbound_webhooks.created_by", &domain.InboundWebhook{}, "created_by"},
+	{"inbound_webhooks.updated_by", &domain.InboundWebhook{}, "updated_by"},
+	{"import_profiles.created_by", &domain.ImportProfile{}, "created_by"},
+	{"import_profiles.updated_by", &domain.ImportProfile{}, "updated_by"},
+	{"release_thresholds.created_by", &domain.ReleaseThreshold{}, "created_by"},
+	{"release_thresholds.updated_by", &domain.ReleaseThreshold{}, "updated_by"},
+	{"figma_frames.created_by", &domain.FigmaFrame{}, "created_by"},
+	{"figma_frames.updated_by", &domain.FigmaFrame{}, "updated_by"},
+	{"promotions.created_by", &domain.Promotion{}, "created_by"},
+	{"promotions.applied_by", &domain.Promotion{}, "applied_by"},
+}
+
+// UserOffboardingRepository 用户离职处理仓储实现
+type UserOffboardingRepository struct {
+	db *gorm.DB
+}
+
+// NewUserOffboardingRepository 创建用户离职处理仓储实例
+func NewUserOffboardingRepository(db *gorm.DB) *UserOffboardingRepository {
+	return &UserOffboardingRepository{db: db}
+}
+
+// ReassignReferences 将内容引用从 fromID 改为 toID（0 表示匿名），邀请码的邀请人改为 inviterID
+// 包括已软删除的记录，不修改 updated_at；inviterID 为 0 时不改写邀请码，删除用户时级联删除。
+// 返回各字段更新的行数，只包含有更新的字段
+func (r *UserOffboardingRepository) ReassignReferences(ctx context.Context, fromID, toID, inviterID uint64) (map[string]int64, error) {
+	db := dbFromContext(ctx, r.db)
+	updated := make(map[string]int64)
+	for _, ref := range userReferences {
+		result := db.Model(ref.model).Unscoped().Where(ref.column+" = ?", fromID).UpdateColumn(ref.column, toID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			updated[ref.name] = result.RowsAffected
+		}
+	}
+
+	if inviterID == 0 {
+		return updated, nil
+	}
+	result := db.Model(&domain.Invitation{}).Where("inviter_id = ?", fromID).UpdateColumn("inviter_id", inviterID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected > 0 {
+		updated["invitations.inviter_id"] = result.RowsAffected
+	}
+	return updated, nil
+}
+
+// AnonymizeActivity 将翻译变更历史和审计日志中的操作人置为 0，并清除邀请码中的被邀请人和邮箱
+// 返回匿名化的历史和审计记录数
+func (r *UserOffboardingRepository) AnonymizeActivity(ctx context.Context, userID uint64, email string) (int64, error) {
+	db := dbFromContext(ctx, r.db)
+	var total int64
+	for _, model := range []interface{}{&domain.TranslationHistory{}, &domain.AuditLog{}} {
+		result := db.Model(model).Where("user_id = ?", userID).UpdateColumn("user_id", 0)
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		total += result.RowsAffected
+	}
+
+	if err := db.Model(&domain.Invitation{}).Where("used_by = ?", userID).UpdateColumn("used_by", nil).Error; err != nil {
+		return 0, err
+	}
+	if email != "" {
+		if err := db.Model(&domain.Invitation{}).Where("email = ?", email).UpdateColumn("email", "").Error; err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// RemoveMemberships 彻底删除用户的项目成员关系（包括已移除的）、角色复核记录和任务分配，返回删除的成员关系数量
+func (r *UserOffboardingRepository) RemoveMemberships(ctx context.Context, userID uint64) (int64, error) {
+	db := dbFromContext(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&domain.RoleReview{}).Error; err != nil {
+		return 0, err
+	}
+	if err := db.Where("user_id = ?", userID).Delete(&domain.Assignment{}).Error; err != nil {
+		return 0, err
+	}
+	result := db.Unscoped().Where("user_id = ? AND deleted_at IS NULL", userID).Delete(&domain.ProjectMember{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if err := db.Unscoped().Where("user_id = ?", userID).Delete(&domain.ProjectMember{}).Error; err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteAccessTokens 删除用户的全部个人访问令牌，返回删除的数量
+func (r *UserOffboardingRepository) DeleteAccessTokens(ctx context.Context, userID uint64) (int64, error) {
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.PersonalAccessToken{})
+	return result.RowsAffected, result.Error
+}
+
+// ReassignInvitations 将用户作为邀请人的全部邀请码（包括已使用和已过期的）改为 toID，返回更新的数量
+func (r *UserOffboardingRepository) ReassignInvitations(ctx context.Context, fromID, toID uint64) (int64, error) {
+	result := dbFromContext(ctx, r.db).Model(&domain.Invitation{}).Where("inviter_id = ?", fromID).UpdateColumn("inviter_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+// CountActiveInvitations 统计用户作为邀请人的有效邀请码
+func (r *UserOffboardingRepository) CountActiveInvitations(ctx context.Context, inviterID uint64) (int64, error) {
+	var count int64
+	err := dbFromContext(ctx, r.db).Model(&domain.Invitation{}).
+		Where("inviter_id = ? AND status = ?", inviterID, domain.InvitationStatusActive).
+		Count(&count).Error
+	return count, err
+}
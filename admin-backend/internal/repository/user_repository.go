@@ -21,7 +21,7 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 // GetByID 根据ID获取用户
 func (r *UserRepository) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&user, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -37,7 +37,7 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.
 	}
 
 	var users []*domain.User
-	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id IN ?", ids).Find(&users).Error; err != nil {
 		return nil, err
 	}
 	return users, nil
@@ -46,7 +46,7 @@ func (r *UserRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.
 // GetByUsername 根据用户名获取用户
 func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("username = ?", username).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -57,18 +57,25 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*d
 
 // Create 创建用户
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return dbFromContext(ctx, r.db).Create(user).Error
 }
 
 // Update 更新用户
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return dbFromContext(ctx, r.db).Save(user).Error
+}
+
+// UpdateProfile 只更新个人资料字段，不覆盖同时进行的其他修改
+func (r *UserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	return dbFromContext(ctx, r.db).Model(user).
+		Select("display_name", "avatar_key", "language", "timezone", "updated_at").
+		Updates(user).Error
 }
 
 // GetByEmail 根据邮箱获取用户
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
 		}
@@ -78,11 +85,11 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 }
 
 // GetAll 获取用户列表
-func (r *UserRepository) GetAll(ctx context.Context, limit, offset int, keyword string) ([]*domain.User, int64, error) {
+func (r *UserRepository) GetAll(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.User, int64, error) {
 	var users []*domain.User
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.User{})
+	query := dbFromContext(ctx, r.db).Model(&domain.User{})
 
 	// 关键词搜索
 	if keyword != "" {
@@ -94,15 +101,24 @@ func (r *UserRepository) GetAll(ctx context.Context, limit, offset int, keyword
 		return nil, 0, err
 	}
 
-	// 获取分页数据
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
+	// 获取分页数据，未指定排序时最新创建的在前
+	if err := orderBy(query, sort, "created_at DESC").Limit(limit).Offset(offset).Find(&users).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return users, total, nil
 }
 
+// GetByRole 获取指定全局角色的全部用户
+func (r *UserRepository) GetByRole(ctx context.Context, role string) ([]*domain.User, error) {
+	var users []*domain.User
+	if err := dbFromContext(ctx, r.db).Where("role = ?", role).Order("id ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 // Delete 删除用户
 func (r *UserRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Delete(&domain.User{}, id).Error
+	return dbFromContext(ctx, r.db).Delete(&domain.User{}, id).Error
 }
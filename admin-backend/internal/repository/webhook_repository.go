@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"yflow/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository 出站 Webhook 仓储实现
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository 创建出站 Webhook 仓储实例
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// GetByID 根据ID获取 Webhook
+func (r *WebhookRepository) GetByID(ctx context.Context, id uint64) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	if err := dbFromContext(ctx, r.db).First(&webhook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetByProjectID 获取项目下的所有 Webhook
+func (r *WebhookRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Webhook, error) {
+	var webhooks []*domain.Webhook
+	if err := dbFromContext(ctx, r.db).
+		Where("project_id = ?", projectID).
+		Order("id ASC").
+		Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Create 创建 Webhook
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	return dbFromContext(ctx, r.db).Create(webhook).Error
+}
+
+// Update 更新 Webhook
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	return dbFromContext(ctx, r.db).Save(webhook).Error
+}
+
+// Delete 删除 Webhook，推送记录由外键级联删除
+func (r *WebhookRepository) Delete(ctx context.Context, id uint64) error {
+	return dbFromContext(ctx, r.db).Delete(&domain.Webhook{}, id).Error
+}
+
+// CreateDeliveries 写入待推送的记录，(webhook_id, event_id) 已存在时跳过
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return dbFromContext(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error
+}
+
+// GetDueDeliveries 获取到期待推送的记录，按写入顺序返回
+func (r *WebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	if err := dbFromContext(ctx, r.db).
+		Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery 领取推送：尝试次数加一并推迟下次推送时间，尝试次数不匹配说明已被其他实例领取
+func (r *WebhookRepository) ClaimDelivery(ctx context.Context, id uint64, attempts int, leaseUntil time.Time) (bool, error) {
+	result := dbFromContext(ctx, r.db).
+		Model(&domain.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ?", id, domain.WebhookDeliveryPending, attempts).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": leaseUntil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkDelivered 标记推送成功
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id uint64, responseStatus int) error {
+	now := time.Now()
+	return dbFromContext(ctx, r.db).
+		Model(&domain.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          domain.WebhookDeliveryDelivered,
+			"response_status": responseStatus,
+			"delivered_at":    &now,
+			"last_error":      "",
+		}).Error
+}
+
+// MarkFailed 记录推送失败，status 为 pending 时在 nextAttemptAt 后重试
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id uint64, status string, nextAttemptAt time.Time, responseStatus int, lastError string) error {
+	return dbFromContext(ctx, r.db).
+		Model(&domain.WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"next_attempt_at": nextAttemptAt,
+			"response_status": responseStatus,
+			"last_error":      lastError,
+		}).Error
+}
+
+// GetDeliveries 分页获取 Webhook 的推送记录，最新的在前
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, webhookID uint64, limit, offset int) ([]*domain.WebhookDelivery, int64, error) {
+	var deliveries []*domain.WebhookDelivery
+	var total int64
+
+	query := dbFromContext(ctx, r.db).Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// DeleteDeliveredBefore 删除指定时间之前推送成功的记录
+func (r *WebhookRepository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("status = ? AND delivered_at < ?", domain.WebhookDeliveryDelivered, before).
+		Delete(&domain.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
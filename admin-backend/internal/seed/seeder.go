@@ -0,0 +1,296 @@
+// Package seed 生成演示和压测用的假数据
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"yflow/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// Options 生成数据的参数
+type Options struct {
+	Projects      int
+	Keys          int
+	Languages     int
+	FillRate      float64
+	ProjectPrefix string
+	BatchSize     int
+	RandomSeed    int64
+	Username      string
+}
+
+// Validate 校验参数
+func (o Options) Validate() error {
+	switch {
+	case o.Projects <= 0:
+		return errors.New("--projects 必须大于 0")
+	case o.Keys <= 0:
+		return errors.New("--keys 必须大于 0")
+	case o.Languages <= 0:
+		return errors.New("--languages 必须大于 0")
+	case o.FillRate < 0 || o.FillRate > 1:
+		return errors.New("--fill 必须在 0 到 1 之间")
+	case o.BatchSize <= 0:
+		return errors.New("--batch 必须大于 0")
+	case strings.TrimSpace(o.ProjectPrefix) == "":
+		return errors.New("--prefix 不能为空")
+	}
+	return nil
+}
+
+// seedLanguages 常用语言，超出数量时生成合成语言代码
+var seedLanguages = []struct {
+	Code string
+	Name string
+}{
+	{"en", "English"},
+	{"zh-CN", "简体中文"},
+	{"zh-TW", "繁體中文"},
+	{"ja", "日本語"},
+	{"ko", "한국어"},
+	{"de", "Deutsch"},
+	{"fr", "Français"},
+	{"es", "Español"},
+	{"pt-BR", "Português (Brasil)"},
+	{"it", "Italiano"},
+	{"ru", "Русский"},
+	{"ar", "العربية"},
+	{"tr", "Türkçe"},
+	{"nl", "Nederlands"},
+	{"pl", "Polski"},
+	{"sv", "Svenska"},
+	{"vi", "Tiếng Việt"},
+	{"th", "ไทย"},
+	{"id", "Bahasa Indonesia"},
+	{"uk", "Українська"},
+}
+
+// 生成键名和文案用的词表
+var (
+	seedModules  = []string{"common", "auth", "dashboard", "settings", "billing", "profile", "orders", "products", "reports", "notifications", "search", "onboarding"}
+	seedScreens  = []string{"header", "footer", "form", "table", "dialog", "list", "detail", "sidebar", "toolbar", "empty", "toast", "wizard"}
+	seedElements = []string{"title", "subtitle", "description", "label", "placeholder", "button", "tooltip", "error", "success", "hint", "confirm", "cancel"}
+	seedWords    = []string{"account", "save", "changes", "project", "team", "invoice", "report", "please", "enter", "your", "email", "password", "new", "update", "delete", "item", "settings", "welcome", "back", "search", "results", "order", "status", "payment", "continue", "review", "upload", "file", "member", "invite"}
+	seedContexts = []string{"", "", "", "按钮文字，尽量简短", "显示在页面顶部", "表单校验失败时显示", "包含占位符 {name}"}
+)
+
+// Seeder 通过服务层生成假数据
+type Seeder struct {
+	projectService     domain.ProjectService
+	languageService    domain.LanguageService
+	translationService domain.TranslationService
+	userRepo           domain.UserRepository
+	logger             *zap.Logger
+}
+
+// NewSeeder 创建数据生成器
+func NewSeeder(
+	projectService domain.ProjectService,
+	languageService domain.LanguageService,
+	translationService domain.TranslationService,
+	userRepo domain.UserRepository,
+	logger *zap.Logger,
+) *Seeder {
+	return &Seeder{
+		projectService:     projectService,
+		languageService:    languageService,
+		translationService: translationService,
+		userRepo:           userRepo,
+		logger:             logger,
+	}
+}
+
+// Run 生成 Projects 个项目，每个项目 Keys 个键，覆盖 Languages 种语言
+func (s *Seeder) Run(ctx context.Context, opts Options) error {
+	start := time.Now()
+	rng := rand.New(rand.NewSource(opts.RandomSeed))
+
+	user, err := s.userRepo.GetByUsername(ctx, opts.Username)
+	if err != nil {
+		return fmt.Errorf("用户 %s 不存在: %w", opts.Username, err)
+	}
+
+	languages, err := s.ensureLanguages(ctx, opts.Languages, user.ID)
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for i := 0; i < opts.Projects; i++ {
+		project, err := s.ensureProject(ctx, fmt.Sprintf("%s %02d", opts.ProjectPrefix, i+1),
+			fmt.Sprintf("由 seed 命令生成：%d 个键 × %d 种语言", opts.Keys, len(languages)), user.ID)
+		if err != nil {
+			return err
+		}
+
+		count, err := s.seedTranslations(ctx, rng, project.ID, languages, opts)
+		if err != nil {
+			return fmt.Errorf("项目 %s 写入翻译失败: %w", project.Name, err)
+		}
+		total += count
+
+		s.logger.Info("项目数据已生成",
+			zap.Uint64("project_id", project.ID),
+			zap.String("project", project.Name),
+			zap.Int("translations", count),
+		)
+	}
+
+	s.logger.Info("数据生成完成",
+		zap.Int("projects", opts.Projects),
+		zap.Int("keys_per_project", opts.Keys),
+		zap.Int("languages", len(languages)),
+		zap.Int("translations", total),
+		zap.Duration("elapsed", time.Since(start)),
+	)
+	return nil
+}
+
+// ensureProject 返回指定名称的项目，已存在时直接复用
+// 复用的项目按相同的随机数种子重新写入，翻译以 upsert 方式写入，重复运行不会产生重复数据
+func (s *Seeder) ensureProject(ctx context.Context, name, description string, userID uint64) (*domain.Project, error) {
+	project, err := s.projectService.Create(ctx, domain.CreateProjectParams{
+		Name:        name,
+		Description: description,
+	}, userID)
+	if err == nil {
+		return project, nil
+	}
+	if !errors.Is(err, domain.ErrProjectExists) && !errors.Is(err, domain.ErrSlugExists) {
+		return nil, fmt.Errorf("创建项目失败: %w", err)
+	}
+
+	projects, _, err := s.projectService.GetAll(ctx, 100, 0, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("查找项目 %q 失败: %w", name, err)
+	}
+	for _, project := range projects {
+		if project.Name == name {
+			return project, nil
+		}
+	}
+	// 名称生成的 slug 被其他项目占用
+	return nil, fmt.Errorf("项目 %q 的标识已被其他项目占用，请使用 --prefix 指定其他名称前缀", name)
+}
+
+// ensureLanguages 返回 count 种语言，已存在的语言直接复用
+func (s *Seeder) ensureLanguages(ctx context.Context, count int, userID uint64) ([]*domain.Language, error) {
+	existing, err := s.languageService.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取语言列表失败: %w", err)
+	}
+	byCode := make(map[string]*domain.Language, len(existing))
+	hasDefault := false
+	for _, language := range existing {
+		byCode[language.Code] = language
+		hasDefault = hasDefault || language.IsDefault
+	}
+
+	languages := make([]*domain.Language, 0, count)
+	for i := 0; i < count; i++ {
+		code, name := seedLanguageAt(i)
+		if language, ok := byCode[code]; ok {
+			languages = append(languages, language)
+			continue
+		}
+
+		language, err := s.languageService.Create(ctx, domain.CreateLanguageParams{
+			Code:      code,
+			Name:      name,
+			IsDefault: !hasDefault && i == 0,
+		}, userID)
+		if err != nil {
+			return nil, fmt.Errorf("创建语言 %s 失败: %w", code, err)
+		}
+		languages = append(languages, language)
+	}
+	return languages, nil
+}
+
+// seedLanguageAt 返回第 i 种语言的代码和名称
+func seedLanguageAt(i int) (string, string) {
+	if i < len(seedLanguages) {
+		return seedLanguages[i].Code, seedLanguages[i].Name
+	}
+	n := i - len(seedLanguages)
+	return fmt.Sprintf("x-%c%c", 'a'+n/26%26, 'a'+n%26), fmt.Sprintf("Synthetic %d", n+1)
+}
+
+// seedTranslations 分批写入一个项目的翻译，第一种语言始终完整，其余语言按完成率随机缺失
+func (s *Seeder) seedTranslations(ctx context.Context, rng *rand.Rand, projectID uint64, languages []*domain.Language, opts Options) (int, error) {
+	batch := make([]domain.TranslationInput, 0, opts.BatchSize)
+	total := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := s.translationService.UpsertBatch(ctx, batch); err != nil {
+			return err
+		}
+		total += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for k := 0; k < opts.Keys; k++ {
+		keyName := seedKeyName(rng, k)
+		keyContext := seedContexts[rng.Intn(len(seedContexts))]
+		source := seedSentence(rng)
+
+		for i, language := range languages {
+			if i > 0 && rng.Float64() >= opts.FillRate {
+				continue
+			}
+			value := source
+			if i > 0 {
+				value = fmt.Sprintf("[%s] %s", language.Code, source)
+			}
+			batch = append(batch, domain.TranslationInput{
+				ProjectID:  projectID,
+				LanguageID: language.ID,
+				KeyName:    keyName,
+				Context:    keyContext,
+				Value:      value,
+			})
+			if len(batch) >= opts.BatchSize {
+				if err := flush(); err != nil {
+					return total, err
+				}
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// seedKeyName 生成形如 settings.form.placeholder_42 的键名，序号保证唯一
+func seedKeyName(rng *rand.Rand, index int) string {
+	return fmt.Sprintf("%s.%s.%s_%d",
+		seedModules[rng.Intn(len(seedModules))],
+		seedScreens[rng.Intn(len(seedScreens))],
+		seedElements[rng.Intn(len(seedElements))],
+		index,
+	)
+}
+
+// seedSentence 生成 2-10 个单词的英文文案
+func seedSentence(rng *rand.Rand) string {
+	n := 2 + rng.Intn(9)
+	words := make([]string, n)
+	for i := range words {
+		words[i] = seedWords[rng.Intn(len(seedWords))]
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+)
+
+const (
+	// maxAPIKeyProjects 一个 API Key 最多限定的项目数量
+	maxAPIKeyProjects = 50
+	// apiKeyPrefixLength 保存用于识别 API Key 的前缀长度（含 yfk_）
+	apiKeyPrefixLength = 12
+)
+
+// APIKeyService CLI API Key 服务实现
+type APIKeyService struct {
+	keyRepo       domain.APIKeyRepository
+	projectRepo   domain.ProjectRepository
+	securityUtils *utils.SecurityUtils
+}
+
+// NewAPIKeyService 创建 API Key 服务实例
+func NewAPIKeyService(keyRepo domain.APIKeyRepository, projectRepo domain.ProjectRepository) *APIKeyService {
+	return &APIKeyService{
+		keyRepo:       keyRepo,
+		projectRepo:   projectRepo,
+		securityUtils: utils.NewSecurityUtils(),
+	}
+}
+
+// Create 创建 API Key，返回的明文 API Key 只在创建时可见
+// context 中指定了组织时创建组织的 API Key，只能访问该组织的项目
+func (s *APIKeyService) Create(ctx context.Context, params domain.APIKeyParams, userID uint64) (*domain.APIKey, string, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, "", domain.ErrInvalidInput
+	}
+
+	scope := params.Scope
+	if scope == "" {
+		scope = domain.APIKeyScopeRead
+	}
+	if scope != domain.APIKeyScopeRead && scope != domain.APIKeyScopeWrite {
+		return nil, "", domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidInput.Code, domain.ErrInvalidInput.Message,
+			"scope 只能是 read 或 write")
+	}
+
+	projectIDs, err := s.normalizeProjects(ctx, params.ProjectIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	plain := domain.APIKeyPrefix + secret
+
+	key := &domain.APIKey{
+		Name:           name,
+		KeyHash:        hashAccessToken(plain),
+		KeyPrefix:      plain[:apiKeyPrefixLength],
+		Scope:          scope,
+		ProjectIDs:     joinProjectIDs(projectIDs),
+		OrganizationID: domain.OrganizationFromContext(ctx),
+		CreatedBy:      userID,
+	}
+	if err := s.keyRepo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	return key, plain, nil
+}
+
+// normalizeProjects 去重、排序并校验项目存在，指定了组织时项目需要属于该组织
+func (s *APIKeyService) normalizeProjects(ctx context.Context, ids []uint64) ([]uint64, error) {
+	seen := make(map[uint64]bool, len(ids))
+	unique := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return nil, domain.ErrInvalidInput
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, nil
+	}
+	if len(unique) > maxAPIKeyProjects {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidInput.Code, domain.ErrInvalidInput.Message,
+			"project_ids 最多 "+strconv.Itoa(maxAPIKeyProjects)+" 个")
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+
+	projects, err := s.projectRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) != len(unique) {
+		return nil, domain.ErrProjectNotFound
+	}
+	if organizationID := domain.OrganizationFromContext(ctx); organizationID != 0 {
+		for _, project := range projects {
+			if project.OrganizationID != organizationID {
+				return nil, domain.ErrProjectNotFound
+			}
+		}
+	}
+	return unique, nil
+}
+
+// GetAll 获取所有 API Key，context 中指定了组织时只获取该组织的 API Key
+func (s *APIKeyService) GetAll(ctx context.Context) ([]*domain.APIKey, error) {
+	if organizationID := domain.OrganizationFromContext(ctx); organizationID != 0 {
+		return s.keyRepo.GetByOrganization(ctx, organizationID)
+	}
+	return s.keyRepo.GetAll(ctx)
+}
+
+// Revoke 撤销 API Key，context 中指定了组织时只能撤销该组织的 API Key
+func (s *APIKeyService) Revoke(ctx context.Context, id uint64) error {
+	key, err := s.keyRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if organizationID := domain.OrganizationFromContext(ctx); organizationID != 0 && key.OrganizationID != organizationID {
+		return domain.ErrAPIKeyNotFound
+	}
+	return s.keyRepo.Delete(ctx, id)
+}
+
+// Authenticate 校验明文 API Key 并记录最近使用时间和来源 IP
+func (s *APIKeyService) Authenticate(ctx context.Context, plain, clientIP string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(plain, domain.APIKeyPrefix) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	key, err := s.keyRepo.GetByHash(ctx, hashAccessToken(plain))
+	if err != nil {
+		if err == domain.ErrAPIKeyNotFound {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= accessTokenTouchInterval || key.LastUsedIP != clientIP {
+		if err := s.keyRepo.UpdateLastUsed(ctx, key.ID, now, clientIP); err == nil {
+			key.LastUsedAt = &now
+			key.LastUsedIP = clientIP
+		}
+		// 记录使用时间失败不影响认证
+	}
+
+	return key, nil
+}
+
+// joinProjectIDs 将项目ID列表保存为逗号分隔的字符串
+func joinProjectIDs(ids []uint64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
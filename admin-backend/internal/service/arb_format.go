@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// Flutter ARB 格式
+const (
+	FormatARB = "arb"
+	arbExt    = "arb"
+)
+
+// ARBPlaceholder ARB 元数据中的占位符定义
+type ARBPlaceholder struct {
+	Type   string `json:"type"`
+	Format string `json:"format,omitempty"`
+}
+
+// arbMetadata 键的元数据块（@key）
+type arbMetadata struct {
+	Description  string                    `json:"description,omitempty"`
+	Placeholders map[string]ARBPlaceholder `json:"placeholders,omitempty"`
+}
+
+// arbLocale 将语言代码转换为 Flutter 使用的 ll_CC 格式
+func arbLocale(code string) string {
+	return strings.ReplaceAll(code, "-", "_")
+}
+
+// MarshalARB 生成一种语言的 ARB 文件
+// 键按名称排序，有上下文说明或占位符的键在其后输出 @key 元数据块：上下文说明写入 description，
+// 占位符从 ICU 消息中识别，plural、selectordinal 和 number 参数的类型为 num，date 和 time 为 DateTime（默认格式 yMd 和 jm），其余为 String
+func MarshalARB(locale string, values, contexts map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("{\n  \"@@locale\": ")
+	if err := writeARBValue(&buf, arbLocale(locale)); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		buf.WriteString(",\n  ")
+		if err := writeARBValue(&buf, key); err != nil {
+			return nil, err
+		}
+		buf.WriteString(": ")
+		if err := writeARBValue(&buf, values[key]); err != nil {
+			return nil, err
+		}
+
+		metadata := arbMetadata{Description: contexts[key], Placeholders: ARBPlaceholders(values[key])}
+		if metadata.Description == "" && len(metadata.Placeholders) == 0 {
+			continue
+		}
+		buf.WriteString(",\n  ")
+		if err := writeARBValue(&buf, "@"+key); err != nil {
+			return nil, err
+		}
+		buf.WriteString(": ")
+		if err := writeARBValue(&buf, metadata); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteString("\n}\n")
+	return buf.Bytes(), nil
+}
+
+// writeARBValue 以两个空格缩进写入 JSON 值，不转义 HTML 字符
+func writeARBValue(buf *bytes.Buffer, value interface{}) error {
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("  ", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(encoded.Bytes(), []byte("\n")))
+	return nil
+}
+
+// ParseARB 解析 ARB 文件，返回语言代码、键名 -> 值和键名 -> 描述
+// language 不为空时优先于文件中的 @@locale；以 @@ 开头的全局元数据忽略，@key 元数据块只读取 description
+func ParseARB(data []byte, language string) (string, map[string]string, map[string]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return "", nil, nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+			domain.ErrInvalidARB.Code, domain.ErrInvalidARB.Message, err.Error())
+	}
+
+	locale := language
+	if locale == "" {
+		if value, ok := raw["@@locale"]; ok {
+			if err := json.Unmarshal(value, &locale); err != nil {
+				return "", nil, nil, invalidARB("@@locale 必须是字符串")
+			}
+		}
+	}
+	if locale == "" {
+		return "", nil, nil, domain.ErrARBLocaleMissing
+	}
+
+	values := make(map[string]string)
+	contexts := make(map[string]string)
+	for key, value := range raw {
+		switch {
+		case strings.HasPrefix(key, "@@"):
+		case strings.HasPrefix(key, "@"):
+			var metadata arbMetadata
+			if err := json.Unmarshal(value, &metadata); err != nil {
+				return "", nil, nil, invalidARB(key + " 必须是对象")
+			}
+			if metadata.Description != "" {
+				contexts[key[1:]] = metadata.Description
+			}
+		default:
+			var text string
+			if err := json.Unmarshal(value, &text); err != nil {
+				return "", nil, nil, invalidARB(key + " 的值必须是字符串")
+			}
+			values[key] = text
+		}
+	}
+	return locale, values, contexts, nil
+}
+
+// invalidARB 带错误详情的 ARB 文件无效错误
+func invalidARB(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidARB.Code, domain.ErrInvalidARB.Message, details)
+}
+
+// ARBPlaceholders 从 ICU 消息中识别占位符，没有占位符时返回 nil
+// 同一占位符出现多次时以带类型的写法为准（如 {count, plural, ...} 中的 count 为 num）
+func ARBPlaceholders(message string) map[string]ARBPlaceholder {
+	placeholders := make(map[string]ARBPlaceholder)
+	// 多余的 } 按普通文本处理
+	for i := 0; i < len(message); i++ {
+		i = scanICUMessage(message, i, placeholders)
+	}
+	if len(placeholders) == 0 {
+		return nil
+	}
+	return placeholders
+}
+
+// scanICUMessage 扫描 ICU 消息文本，遇到未匹配的 } 或文本结束时返回其位置
+// 与 Flutter gen-l10n 的默认设置一致，撇号不作为转义字符
+func scanICUMessage(message string, i int, placeholders map[string]ARBPlaceholder) int {
+	for i < len(message) {
+		switch message[i] {
+		case '{':
+			i = scanICUArgument(message, i+1, placeholders)
+		case '}':
+			return i
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// scanICUArgument 解析 { 之后的参数，返回参数结束的 } 之后的位置
+func scanICUArgument(message string, i int, placeholders map[string]ARBPlaceholder) int {
+	start := skipICUSpaces(message, i)
+	end := start
+	for end < len(message) && isICUNameChar(message[end]) {
+		end++
+	}
+	name := message[start:end]
+	i = skipICUSpaces(message, end)
+	if name == "" || i >= len(message) || (message[i] != '}' && message[i] != ',') {
+		// 不是参数，按普通文本跳过匹配的花括号
+		return skipICUBlock(message, i)
+	}
+	if message[i] == '}' {
+		if _, exists := placeholders[name]; !exists {
+			placeholders[name] = ARBPlaceholder{Type: "String"}
+		}
+		return i + 1
+	}
+
+	// 带类型的参数：{name, type} 或 {name, type, style}
+	start = skipICUSpaces(message, i+1)
+	end = start
+	for end < len(message) && isICUNameChar(message[end]) {
+		end++
+	}
+	argType := message[start:end]
+	i = skipICUSpaces(message, end)
+
+	switch argType {
+	case "plural", "selectordinal", "select":
+		placeholder := ARBPlaceholder{Type: "num"}
+		if argType == "select" {
+			placeholder.Type = "String"
+		}
+		placeholders[name] = placeholder
+		if i < len(message) && message[i] == ',' {
+			i++
+		}
+		// 分支：选择器 { 消息 }
+		for i < len(message) && message[i] != '}' {
+			if message[i] == '{' {
+				i = scanICUMessage(message, i+1, placeholders) + 1
+				continue
+			}
+			i++
+		}
+		return i + 1
+	case "number":
+		placeholders[name] = ARBPlaceholder{Type: "num"}
+	case "date", "time":
+		placeholder := ARBPlaceholder{Type: "DateTime", Format: "yMd"}
+		if argType == "time" {
+			placeholder.Format = "jm"
+		}
+		if i < len(message) && message[i] == ',' {
+			styleEnd := strings.IndexByte(message[i:], '}')
+			if styleEnd > 0 {
+				if style := strings.TrimSpace(message[i+1 : i+styleEnd]); style != "" {
+					placeholder.Format = style
+				}
+			}
+		}
+		placeholders[name] = placeholder
+	default:
+		placeholders[name] = ARBPlaceholder{Type: "String"}
+	}
+	return skipICUBlock(message, i)
+}
+
+// skipICUBlock 跳到匹配当前层级的 } 之后
+func skipICUBlock(message string, i int) int {
+	depth := 0
+	for ; i < len(message); i++ {
+		switch message[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i + 1
+			}
+			depth--
+		}
+	}
+	return i
+}
+
+func skipICUSpaces(message string, i int) int {
+	for i < len(message) && (message[i] == ' ' || message[i] == '\t' || message[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+func isICUNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
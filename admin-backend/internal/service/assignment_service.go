@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// maxUserTaskKeys 每项任务最多列出的待处理键数量
+const maxUserTaskKeys = 100
+
+// AssignmentService 任务分配服务实现
+// 翻译任务待处理的键为该语言缺少译文或译文为草稿的键，审核任务待处理的键为该语言待审核的键，已废弃的翻译不计入
+type AssignmentService struct {
+	assignmentRepo  domain.AssignmentRepository
+	projectRepo     domain.ProjectRepository
+	memberRepo      domain.ProjectMemberRepository
+	languageRepo    domain.LanguageRepository
+	translationRepo domain.TranslationRepository
+	transactor      domain.Transactor
+}
+
+// NewAssignmentService 创建任务分配服务实例
+func NewAssignmentService(
+	assignmentRepo domain.AssignmentRepository,
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	transactor domain.Transactor,
+) *AssignmentService {
+	return &AssignmentService{
+		assignmentRepo:  assignmentRepo,
+		projectRepo:     projectRepo,
+		memberRepo:      memberRepo,
+		languageRepo:    languageRepo,
+		translationRepo: translationRepo,
+		transactor:      transactor,
+	}
+}
+
+// GetByProjectID 获取项目的任务分配
+func (s *AssignmentService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Assignment, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.assignmentRepo.GetByProjectID(ctx, projectID)
+}
+
+// Assign 将语言或其中的键分配给项目的编辑者或所有者
+func (s *AssignmentService) Assign(ctx context.Context, projectID uint64, params domain.AssignParams, operatorID uint64) ([]*domain.Assignment, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if params.Type != domain.AssignmentTypeTranslate && params.Type != domain.AssignmentTypeReview {
+		return nil, invalidAssignment("工作类型必须为 translate 或 review")
+	}
+
+	member, err := s.memberRepo.GetByProjectAndUser(ctx, projectID, params.UserID)
+	if err != nil || member.Role != "editor" && member.Role != "owner" {
+		return nil, invalidAssignment(fmt.Sprintf("用户 %d 不是项目的编辑者或所有者", params.UserID))
+	}
+
+	language, err := s.languageRepo.GetByCode(ctx, strings.TrimSpace(params.LanguageCode))
+	if err != nil {
+		return nil, invalidAssignment("语言不存在：" + params.LanguageCode)
+	}
+
+	keyNames := make([]string, 0, len(params.KeyNames))
+	seen := make(map[string]bool, len(params.KeyNames))
+	for _, keyName := range params.KeyNames {
+		keyName = strings.TrimSpace(keyName)
+		if keyName == "" || seen[keyName] {
+			continue
+		}
+		seen[keyName] = true
+		keyNames = append(keyNames, keyName)
+	}
+	if len(keyNames) > domain.MaxAssignmentKeys {
+		return nil, invalidAssignment(fmt.Sprintf("一次最多分配 %d 个键", domain.MaxAssignmentKeys))
+	}
+	if len(keyNames) > 0 {
+		existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[string]bool, len(existing))
+		for _, keyName := range existing {
+			found[keyName] = true
+		}
+		var missing []string
+		for _, keyName := range keyNames {
+			if !found[keyName] {
+				missing = append(missing, keyName)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, invalidAssignment("键不存在：" + strings.Join(missing, ", "))
+		}
+	} else {
+		// 不指定键时分配该语言的所有键
+		keyNames = []string{""}
+	}
+
+	assignments := make([]*domain.Assignment, 0, len(keyNames))
+	for _, keyName := range keyNames {
+		assignments = append(assignments, &domain.Assignment{
+			ProjectID:  projectID,
+			LanguageID: language.ID,
+			KeyName:    keyName,
+			Type:       params.Type,
+			UserID:     params.UserID,
+			CreatedBy:  operatorID,
+			UpdatedBy:  operatorID,
+		})
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		return s.assignmentRepo.Upsert(ctx, assignments)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.assignmentRepo.GetByProjectID(ctx, projectID)
+}
+
+// Delete 删除项目中的任务分配
+func (s *AssignmentService) Delete(ctx context.Context, projectID, assignmentID uint64) error {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return err
+	}
+	if assignment.ProjectID != projectID {
+		return domain.ErrAssignmentNotFound
+	}
+	return s.assignmentRepo.Delete(ctx, assignmentID)
+}
+
+// GetUserTasks 按用户的任务分配统计待处理的工作
+// 分配到具体键的优先于分配整个语言的；用户已不是项目的编辑者或所有者、语言已停用时对应的分配不计入
+func (s *AssignmentService) GetUserTasks(ctx context.Context, userID uint64) (*domain.UserTaskList, error) {
+	assignments, err := s.assignmentRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := s.memberRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	canEdit := make(map[uint64]bool, len(memberships))
+	for _, member := range memberships {
+		canEdit[member.ProjectID] = member.Role == "editor" || member.Role == "owner"
+	}
+
+	byProject := make(map[uint64][]*domain.Assignment)
+	var projectIDs []uint64
+	for _, assignment := range assignments {
+		if !canEdit[assignment.ProjectID] || assignment.Language.Status != "active" {
+			continue
+		}
+		if byProject[assignment.ProjectID] == nil {
+			projectIDs = append(projectIDs, assignment.ProjectID)
+		}
+		byProject[assignment.ProjectID] = append(byProject[assignment.ProjectID], assignment)
+	}
+
+	list := &domain.UserTaskList{Tasks: []domain.UserTask{}}
+	for _, projectID := range projectIDs {
+		tasks, err := s.projectTasks(ctx, userID, byProject[projectID])
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			if task.Type == domain.AssignmentTypeReview {
+				list.Review += task.Pending
+			} else {
+				list.Translate += task.Pending
+			}
+			list.Tasks = append(list.Tasks, task)
+		}
+	}
+	return list, nil
+}
+
+// projectTasks 统计用户在一个项目中按语言和工作类型划分的待处理工作
+// 每种语言和工作类型查询一次待处理键的数量和前 maxUserTaskKeys 个键，不加载项目的翻译值
+func (s *AssignmentService) projectTasks(ctx context.Context, userID uint64, assignments []*domain.Assignment) ([]domain.UserTask, error) {
+	project := assignments[0].Project
+	all, err := s.assignmentRepo.GetByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	type scope struct {
+		languageID uint64
+		taskType   string
+	}
+	// 用户在每种语言和工作类型中负责的范围：整个语言时排除分配给其他人的键，否则只包含分配给用户的键
+	var scopes []scope
+	languages := make(map[scope]string)
+	wholeLanguage := make(map[scope]bool)
+	ownKeys := make(map[scope][]string)
+	for _, assignment := range assignments {
+		key := scope{assignment.LanguageID, assignment.Type}
+		if _, ok := languages[key]; !ok {
+			scopes = append(scopes, key)
+			languages[key] = assignment.Language.Code
+		}
+		if assignment.KeyName == "" {
+			wholeLanguage[key] = true
+		} else {
+			ownKeys[key] = append(ownKeys[key], assignment.KeyName)
+		}
+	}
+	othersKeys := make(map[scope][]string)
+	for _, assignment := range all {
+		if assignment.KeyName != "" && assignment.UserID != userID {
+			key := scope{assignment.LanguageID, assignment.Type}
+			othersKeys[key] = append(othersKeys[key], assignment.KeyName)
+		}
+	}
+
+	tasks := make([]domain.UserTask, 0, len(scopes))
+	for _, key := range scopes {
+		query := domain.PendingKeyQuery{
+			ProjectID:  project.ID,
+			LanguageID: key.languageID,
+			Review:     key.taskType == domain.AssignmentTypeReview,
+			Limit:      maxUserTaskKeys,
+		}
+		if wholeLanguage[key] {
+			query.ExcludeKeys = othersKeys[key]
+		} else {
+			query.KeyNames = ownKeys[key]
+		}
+		pending, keys, err := s.translationRepo.GetPendingKeys(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		if pending == 0 {
+			continue
+		}
+		tasks = append(tasks, domain.UserTask{
+			ProjectID:    project.ID,
+			ProjectName:  project.Name,
+			LanguageCode: languages[key],
+			Type:         key.taskType,
+			Pending:      int(pending),
+			PendingKeys:  keys,
+		})
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].LanguageCode != tasks[j].LanguageCode {
+			return tasks[i].LanguageCode < tasks[j].LanguageCode
+		}
+		return tasks[i].Type > tasks[j].Type
+	})
+	return tasks, nil
+}
+
+func invalidAssignment(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidAssignment.Code, domain.ErrInvalidAssignment.Message, details)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+	"yflow/internal/domain"
+)
+
+const (
+	// MaxAttachmentSize 单个附件的最大字节数
+	MaxAttachmentSize = 5 << 20
+	// maxAttachmentsPerKey 每个键最多的附件数量
+	maxAttachmentsPerKey = 20
+	// maxAttachmentFileNameLength 附件文件名的最大长度
+	maxAttachmentFileNameLength = 255
+)
+
+// attachmentExtensions 允许的附件格式及其扩展名
+var attachmentExtensions = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpg",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// AttachmentService 翻译键附件服务实现
+// 附件为键的界面截图等视觉上下文，同一键的所有语言共用；文件保存在附件存储中，数据库只记录元数据。
+// 附件按键名关联，键重命名或删除后附件不随之变化
+type AttachmentService struct {
+	attachmentRepo  domain.AttachmentRepository
+	projectRepo     domain.ProjectRepository
+	translationRepo domain.TranslationRepository
+	storage         domain.AttachmentStorage
+	cacheService    domain.CacheService
+}
+
+// NewAttachmentService 创建附件服务实例
+func NewAttachmentService(
+	attachmentRepo domain.AttachmentRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	storage domain.AttachmentStorage,
+	cacheService domain.CacheService,
+) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo:  attachmentRepo,
+		projectRepo:     projectRepo,
+		translationRepo: translationRepo,
+		storage:         storage,
+		cacheService:    cacheService,
+	}
+}
+
+// GetByProjectID 获取项目的附件，keyName 不为空时只返回该键的附件
+func (s *AttachmentService) GetByProjectID(ctx context.Context, projectID uint64, keyName string) ([]*domain.Attachment, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.attachmentRepo.GetByProjectID(ctx, projectID, strings.TrimSpace(keyName))
+}
+
+// Upload 为键上传附件，只支持 PNG、JPEG、GIF 和 WebP 格式的图片
+func (s *AttachmentService) Upload(ctx context.Context, projectID uint64, params domain.AttachmentUploadParams, userID uint64) (*domain.Attachment, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	keyName := strings.TrimSpace(params.KeyName)
+	if keyName == "" {
+		return nil, invalidAttachment("键名不能为空")
+	}
+	existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, []string{keyName})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		return nil, invalidAttachment("键不存在：" + keyName)
+	}
+	count, err := s.attachmentRepo.CountByKey(ctx, projectID, keyName)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAttachmentsPerKey {
+		return nil, invalidAttachment(fmt.Sprintf("每个键最多 %d 个附件", maxAttachmentsPerKey))
+	}
+
+	if len(params.Data) == 0 {
+		return nil, invalidAttachment("附件内容为空")
+	}
+	if len(params.Data) > MaxAttachmentSize {
+		return nil, invalidAttachment(fmt.Sprintf("附件不能超过 %d MB", MaxAttachmentSize>>20))
+	}
+	contentType := http.DetectContentType(params.Data)
+	extension, ok := attachmentExtensions[contentType]
+	if !ok {
+		return nil, invalidAttachment("只支持 PNG、JPEG、GIF 和 WebP 格式的图片")
+	}
+
+	// 文件名只用于展示，去掉客户端提供的目录部分
+	fileName := strings.TrimSpace(path.Base(strings.ReplaceAll(params.FileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		fileName = "screenshot." + extension
+	}
+	if utf8.RuneCountInString(fileName) > maxAttachmentFileNameLength {
+		return nil, invalidAttachment(fmt.Sprintf("文件名不能超过 %d 个字符", maxAttachmentFileNameLength))
+	}
+
+	storageKey, err := attachmentStorageKey(projectID, extension)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, storageKey, params.Data, contentType); err != nil {
+		return nil, fmt.Errorf("保存附件文件失败: %w", err)
+	}
+
+	attachment := &domain.Attachment{
+		ProjectID:   projectID,
+		KeyName:     keyName,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(params.Data)),
+		StorageKey:  storageKey,
+		CreatedBy:   userID,
+	}
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		s.storage.Delete(ctx, storageKey)
+		return nil, err
+	}
+	s.invalidateMatrixCache(ctx, projectID)
+	return attachment, nil
+}
+
+// GetContent 获取附件的文件内容和格式
+func (s *AttachmentService) GetContent(ctx context.Context, projectID, attachmentID uint64) ([]byte, string, error) {
+	attachment, err := s.getAttachment(ctx, projectID, attachmentID)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := s.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, attachment.ContentType, nil
+}
+
+// Delete 删除附件，文件删除失败时只保留存储中的孤立文件，不影响附件记录的删除
+func (s *AttachmentService) Delete(ctx context.Context, projectID, attachmentID uint64) error {
+	attachment, err := s.getAttachment(ctx, projectID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.attachmentRepo.Delete(ctx, attachmentID); err != nil {
+		return err
+	}
+	s.storage.Delete(ctx, attachment.StorageKey)
+	s.invalidateMatrixCache(ctx, projectID)
+	return nil
+}
+
+// getAttachment 获取属于项目的附件
+func (s *AttachmentService) getAttachment(ctx context.Context, projectID, attachmentID uint64) (*domain.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.ProjectID != projectID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// invalidateMatrixCache 清除项目的翻译矩阵缓存，矩阵单元格中包含附件地址
+func (s *AttachmentService) invalidateMatrixCache(ctx context.Context, projectID uint64) {
+	if s.cacheService == nil {
+		return
+	}
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(projectID).MatrixPattern())
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ResponsePattern(domain.ProjectResponseScope(projectID)))
+}
+
+// attachmentStorageKey 生成附件在存储中的随机路径
+func attachmentStorageKey(projectID uint64, extension string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("projects/%d/%s.%s", projectID, hex.EncodeToString(b), extension), nil
+}
+
+func invalidAttachment(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidAttachment.Code, domain.ErrInvalidAttachment.Message, details)
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+)
+
+// attachmentStorageTimeout 访问 S3 的超时时间
+const attachmentStorageTimeout = 30 * time.Second
+
+// LocalAttachmentStorage 将附件保存在本地目录中
+// 多实例部署时目录需要共享（如挂载同一网络存储），否则应使用 S3
+type LocalAttachmentStorage struct {
+	dir string
+}
+
+// NewLocalAttachmentStorage 创建本地附件存储，目录在首次写入时创建
+func NewLocalAttachmentStorage(dir string) *LocalAttachmentStorage {
+	return &LocalAttachmentStorage{dir: dir}
+}
+
+// Put 保存文件，先写入临时文件再重命名，读取时不会得到写了一半的文件
+func (s *LocalAttachmentStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get 读取文件
+func (s *LocalAttachmentStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, domain.ErrAttachmentFileMissing
+	}
+	return data, err
+}
+
+// Delete 删除文件
+func (s *LocalAttachmentStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path 返回 key 对应的本地路径，拒绝指向存储目录之外的 key
+func (s *LocalAttachmentStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || cleaned != "/"+key {
+		return "", fmt.Errorf("invalid attachment key: %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
+
+// S3AttachmentStorage 将附件保存在 S3 或兼容 S3 的对象存储（如 MinIO）中
+// 使用路径形式的地址（endpoint/bucket/key）和 AWS Signature Version 4 签名
+type S3AttachmentStorage struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+// NewS3AttachmentStorage 创建 S3 附件存储，endpoint 为空时使用 AWS 区域地址
+func NewS3AttachmentStorage(cfg *config.AttachmentConfig) *S3AttachmentStorage {
+	endpoint := cfg.S3Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + cfg.S3Region + ".amazonaws.com"
+	}
+	return &S3AttachmentStorage{
+		endpoint:  strings.TrimRight(endpoint, "/"),
+		region:    cfg.S3Region,
+		bucket:    cfg.S3Bucket,
+		accessKey: cfg.S3AccessKey,
+		secretKey: cfg.S3SecretKey,
+		client:    &http.Client{Timeout: attachmentStorageTimeout},
+	}
+}
+
+// Put 上传对象
+func (s *S3AttachmentStorage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(http.MethodPut, key, resp)
+	}
+	return nil
+}
+
+// Get 下载对象
+func (s *S3AttachmentStorage) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, domain.ErrAttachmentFileMissing
+	default:
+		return nil, s3Error(http.MethodGet, key, resp)
+	}
+}
+
+// Delete 删除对象，S3 删除不存在的对象同样返回成功
+func (s *S3AttachmentStorage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error(http.MethodDelete, key, resp)
+	}
+	return nil
+}
+
+// do 发送签名后的对象请求
+func (s *S3AttachmentStorage) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	segments := strings.Split(s.bucket+"/"+key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	path := "/" + strings.Join(segments, "/")
+
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, path, body)
+	return s.client.Do(req)
+}
+
+// sign 按 AWS Signature Version 4 为请求添加 Authorization 头，签名 host、x-amz-content-sha256 和 x-amz-date
+func (s *S3AttachmentStorage) sign(req *http.Request, path string, body []byte) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		"host:" + req.URL.Host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	for _, part := range []string{s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Error 读取 S3 返回的错误信息
+func s3Error(method, key string, resp *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("S3 %s %s failed with status %d: %s", method, key, resp.StatusCode, strings.TrimSpace(string(message)))
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"yflow/internal/domain"
+)
+
+// AuditLogService 审计日志服务实现
+type AuditLogService struct {
+	auditLogRepo domain.AuditLogRepository
+	projectRepo  domain.ProjectRepository
+}
+
+// NewAuditLogService 创建审计日志服务实例
+func NewAuditLogService(auditLogRepo domain.AuditLogRepository, projectRepo domain.ProjectRepository) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo: auditLogRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+// GetByProjectID 分页获取项目的审计日志
+func (s *AuditLogService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.AuditLog, int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+	return s.auditLogRepo.GetByProjectID(ctx, projectID, limit, offset)
+}
+
+// GetSystemLogs 分页获取系统级审计日志（project_id 为 0）
+func (s *AuditLogService) GetSystemLogs(ctx context.Context, limit, offset int) ([]*domain.AuditLog, int64, error) {
+	return s.auditLogRepo.GetByProjectID(ctx, 0, limit, offset)
+}
+
+// GetPage 按时间倒序游标分页获取审计日志，ProjectID 不为 0 时验证项目是否存在
+func (s *AuditLogService) GetPage(ctx context.Context, query domain.AuditLogPageQuery) (*domain.AuditLogPage, error) {
+	if query.ProjectID != 0 {
+		if _, err := s.projectRepo.GetByID(ctx, query.ProjectID); err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+	}
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 10
+	}
+	return s.auditLogRepo.GetPage(ctx, query)
+}
+
+// recordAudit 记录一条审计日志，details 序列化为 JSON
+// 应在写操作的事务中调用，审计日志与业务数据一起提交或回滚
+func recordAudit(ctx context.Context, repo domain.AuditLogRepository, projectID, userID uint64, action string, details interface{}) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	return repo.Create(ctx, &domain.AuditLog{
+		ProjectID: projectID,
+		UserID:    userID,
+		Action:    action,
+		Details:   data,
+	})
+}
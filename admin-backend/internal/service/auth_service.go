@@ -39,6 +39,7 @@ func (s *AuthService) GenerateToken(ctx context.Context, user *domain.User) (str
 		UserID:   user.ID,
 		Username: user.Username,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        domain.SessionIDFromContext(ctx),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -69,6 +70,7 @@ func (s *AuthService) GenerateRefreshToken(ctx context.Context, user *domain.Use
 		UserID:   user.ID,
 		Username: user.Username,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        domain.SessionIDFromContext(ctx),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -117,6 +119,24 @@ func (s *AuthService) ValidateRefreshToken(ctx context.Context, tokenString stri
 	}, nil
 }
 
+// SessionID 返回访问令牌所属的登录会话ID
+func (s *AuthService) SessionID(ctx context.Context, tokenString string) (string, error) {
+	claims, err := s.parseToken(tokenString, s.jwtConfig.Secret)
+	if err != nil {
+		return "", err
+	}
+	return claims.ID, nil
+}
+
+// RefreshSessionID 返回刷新令牌所属的登录会话ID
+func (s *AuthService) RefreshSessionID(ctx context.Context, tokenString string) (string, error) {
+	claims, err := s.parseToken(tokenString, s.jwtConfig.RefreshSecret)
+	if err != nil {
+		return "", err
+	}
+	return claims.ID, nil
+}
+
 // parseToken 解析token的通用方法
 func (s *AuthService) parseToken(tokenString, secret string) (*JWTClaim, error) {
 	// 解析token
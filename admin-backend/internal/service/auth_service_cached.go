@@ -60,7 +60,7 @@ func (s *CachedAuthService) GenerateRefreshToken(ctx context.Context, user *doma
 
 // ValidateToken 验证JWT token（使用缓存）
 func (s *CachedAuthService) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
-	cacheKey := "token:" + token
+	cacheKey := domain.CacheKeys.AccessToken(token)
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.getMutex(cacheKey)
@@ -94,7 +94,7 @@ func (s *CachedAuthService) ValidateToken(ctx context.Context, token string) (*d
 
 // ValidateRefreshToken 验证刷新token（使用缓存）
 func (s *CachedAuthService) ValidateRefreshToken(ctx context.Context, token string) (*domain.User, error) {
-	cacheKey := "refresh_token:" + token
+	cacheKey := domain.CacheKeys.RefreshToken(token)
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.getMutex(cacheKey)
@@ -124,4 +124,14 @@ func (s *CachedAuthService) ValidateRefreshToken(ctx context.Context, token stri
 	}
 
 	return user, nil
-}
\ No newline at end of file
+}
+
+// SessionID 返回访问令牌所属的登录会话ID（只解析令牌，不使用缓存）
+func (s *CachedAuthService) SessionID(ctx context.Context, token string) (string, error) {
+	return s.authService.SessionID(ctx, token)
+}
+
+// RefreshSessionID 返回刷新令牌所属的登录会话ID
+func (s *CachedAuthService) RefreshSessionID(ctx context.Context, token string) (string, error) {
+	return s.authService.RefreshSessionID(ctx, token)
+}
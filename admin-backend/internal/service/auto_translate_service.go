@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"yflow/internal/domain"
+)
+
+// maxAutoTranslateTexts 一次填充最多机器翻译的文本数量（所有目标语言合计）
+const maxAutoTranslateTexts = 2000
+
+// maxPreTranslateTexts 一次预翻译最多机器翻译的文本数量（所有目标语言合计）
+const maxPreTranslateTexts = 20000
+
+// AutoTranslateService 机器翻译填充服务实现
+// 只填充目标语言中没有翻译（包括已废弃的翻译）的键，已有的翻译不会被覆盖
+type AutoTranslateService struct {
+	machineTranslation domain.MachineTranslationService
+	translationService domain.TranslationService
+	translationRepo    domain.TranslationRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	batchSize          int           // 预翻译时每次调用服务商翻译的文本数量
+	requestInterval    time.Duration // 预翻译时两次调用服务商之间的间隔
+}
+
+// NewAutoTranslateService 创建机器翻译填充服务实例
+// batchSize 和 requestInterval 用于预翻译时控制调用服务商的频率，batchSize 不大于 0 时为 50
+func NewAutoTranslateService(
+	machineTranslation domain.MachineTranslationService,
+	translationService domain.TranslationService,
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	batchSize int,
+	requestInterval time.Duration,
+) *AutoTranslateService {
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	return &AutoTranslateService{
+		machineTranslation: machineTranslation,
+		translationService: translationService,
+		translationRepo:    translationRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		batchSize:          batchSize,
+		requestInterval:    requestInterval,
+	}
+}
+
+// autoTranslateJob 一个目标语言需要机器翻译的键
+type autoTranslateJob struct {
+	language *domain.Language
+	keys     []string
+	texts    []string
+	result   domain.AutoTranslateLanguageResult
+}
+
+// FillMissing 使用机器翻译填充选定键在目标语言中缺失的翻译
+// 源语言的文本取有效的翻译，json 类型的键不做机器翻译。所有目标语言都翻译完成后一次写入，
+// 机器翻译服务调用失败时不写入任何翻译；写入的翻译在变更历史中记为 machine_translate
+func (s *AutoTranslateService) FillMissing(ctx context.Context, projectID uint64, params domain.AutoTranslateParams) (*domain.AutoTranslateResult, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	source, targets, err := s.resolveLanguages(ctx, project, params)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.translationRepo.GetValuesByStatus(ctx, projectID, exportStatuses[""])
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.translationRepo.GetValuesByStatus(ctx, projectID, domain.TranslationStatusFilter{Statuses: []string{"active", "deprecated"}})
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.translationRepo.GetKeyValueTypes(ctx, projectID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	keyNames := normalizeKeyNames(params.KeyNames)
+	if keyNames == nil {
+		for key := range active {
+			keyNames = append(keyNames, key)
+		}
+		sort.Strings(keyNames)
+	}
+
+	jobs := make([]*autoTranslateJob, 0, len(targets))
+	total := 0
+	for _, target := range targets {
+		job := &autoTranslateJob{language: target, result: domain.AutoTranslateLanguageResult{LanguageCode: target.Code}}
+		for _, key := range keyNames {
+			if existing[key][target.Code] != "" {
+				continue
+			}
+			text := active[key][source.Code]
+			if text == "" || types[key].ValueType == domain.ValueTypeJSON {
+				job.result.Skipped++
+				continue
+			}
+			job.keys = append(job.keys, key)
+			job.texts = append(job.texts, text)
+		}
+		total += len(job.texts)
+		jobs = append(jobs, job)
+	}
+	if total > maxAutoTranslateTexts {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrTooManyAutoTranslations.Code,
+			domain.ErrTooManyAutoTranslations.Message, fmt.Sprintf("共 %d 条，每次最多 %d 条", total, maxAutoTranslateTexts))
+	}
+
+	result := &domain.AutoTranslateResult{SourceLanguage: source.Code, Languages: []domain.AutoTranslateLanguageResult{}}
+	var inputs []domain.TranslationInput
+	for _, job := range jobs {
+		if len(job.texts) > 0 {
+			translated, err := s.machineTranslation.TranslateBatch(ctx, job.texts, source.Code, job.language.Code)
+			if err != nil {
+				return nil, domain.NewAppErrorWithCause(domain.ErrorTypeInternal, domain.ErrMachineTranslationFailed.Code,
+					domain.ErrMachineTranslationFailed.Message, err)
+			}
+			for i, key := range job.keys {
+				if i >= len(translated) || translated[i] == nil || translated[i].TranslatedText == "" {
+					job.result.Failed++
+					continue
+				}
+				inputs = append(inputs, domain.TranslationInput{
+					ProjectID:  projectID,
+					LanguageID: job.language.ID,
+					KeyName:    key,
+					Value:      translated[i].TranslatedText,
+				})
+				job.result.Translated++
+			}
+		}
+		result.Translated += job.result.Translated
+		result.Failed += job.result.Failed
+		result.Languages = append(result.Languages, job.result)
+	}
+
+	if len(inputs) > 0 {
+		ctx = domain.WithHistoryOperation(ctx, domain.HistoryOperationMachineTranslate)
+		if err := s.translationService.UpsertBatch(ctx, inputs); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// PreTranslate 使用机器翻译分批填充整个项目在选定语言中的空白翻译
+// 与 FillMissing 相同，只填充没有翻译（包括已废弃的翻译）的键。每批最多 batchSize 条，
+// 两批之间等待 requestInterval；每批翻译完成后立即写入，某一批调用服务商失败时记录错误并继续下一批
+func (s *AutoTranslateService) PreTranslate(ctx context.Context, projectID uint64, params domain.PreTranslateParams) (*domain.PreTranslateResult, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	source, targets, err := s.resolveLanguages(ctx, project, domain.AutoTranslateParams{
+		SourceLanguage:  params.SourceLanguage,
+		TargetLanguages: params.TargetLanguages,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.translationRepo.GetValuesByStatus(ctx, projectID, exportStatuses[""])
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.translationRepo.GetValuesByStatus(ctx, projectID, domain.TranslationStatusFilter{Statuses: []string{"active", "deprecated"}})
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.translationRepo.GetKeyValueTypes(ctx, projectID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 项目矩阵的行：所有有翻译的键
+	keyNames := make([]string, 0, len(existing))
+	for key := range existing {
+		keyNames = append(keyNames, key)
+	}
+	sort.Strings(keyNames)
+
+	jobs := make([]*autoTranslateJob, 0, len(targets))
+	languageResults := make([]domain.PreTranslateLanguageResult, len(targets))
+	total := 0
+	for i, target := range targets {
+		job := &autoTranslateJob{language: target}
+		languageResult := domain.PreTranslateLanguageResult{LanguageCode: target.Code, FilledKeys: []string{}, FailedKeys: []string{}}
+		for _, key := range keyNames {
+			if existing[key][target.Code] != "" {
+				continue
+			}
+			languageResult.Missing++
+			text := active[key][source.Code]
+			if text == "" || types[key].ValueType == domain.ValueTypeJSON {
+				languageResult.Skipped++
+				continue
+			}
+			job.keys = append(job.keys, key)
+			job.texts = append(job.texts, text)
+		}
+		total += len(job.texts)
+		jobs = append(jobs, job)
+		languageResults[i] = languageResult
+	}
+	if total > maxPreTranslateTexts {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrTooManyAutoTranslations.Code,
+			domain.ErrTooManyAutoTranslations.Message, fmt.Sprintf("共 %d 条，每次最多 %d 条", total, maxPreTranslateTexts))
+	}
+
+	ctx = domain.WithHistoryOperation(ctx, domain.HistoryOperationMachineTranslate)
+	result := &domain.PreTranslateResult{SourceLanguage: source.Code, Languages: languageResults}
+	requested := false
+	for i, job := range jobs {
+		languageResult := &result.Languages[i]
+		for start := 0; start < len(job.texts); start += s.batchSize {
+			end := start + s.batchSize
+			if end > len(job.texts) {
+				end = len(job.texts)
+			}
+			if requested {
+				if err := s.wait(ctx); err != nil {
+					return nil, err
+				}
+			}
+			requested = true
+			if err := s.preTranslateBatch(ctx, projectID, source.Code, job.language, job.keys[start:end], job.texts[start:end], languageResult); err != nil {
+				return nil, err
+			}
+		}
+		result.Filled += languageResult.Filled
+		result.Failed += languageResult.Failed
+		result.Skipped += languageResult.Skipped
+	}
+	return result, nil
+}
+
+// preTranslateBatch 翻译并写入一批键，服务商调用失败时这批键全部记为失败
+// 只在写入翻译失败时返回错误
+func (s *AutoTranslateService) preTranslateBatch(ctx context.Context, projectID uint64, sourceCode string, target *domain.Language, keys, texts []string, languageResult *domain.PreTranslateLanguageResult) error {
+	translated, err := s.machineTranslation.TranslateBatch(ctx, texts, sourceCode, target.Code)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		languageResult.Failed += len(keys)
+		languageResult.FailedKeys = append(languageResult.FailedKeys, keys...)
+		message := err.Error()
+		for _, existing := range languageResult.Errors {
+			if existing == message {
+				return nil
+			}
+		}
+		languageResult.Errors = append(languageResult.Errors, message)
+		return nil
+	}
+
+	inputs := make([]domain.TranslationInput, 0, len(keys))
+	var filled []string
+	for i, key := range keys {
+		if i >= len(translated) || translated[i] == nil || translated[i].TranslatedText == "" {
+			languageResult.Failed++
+			languageResult.FailedKeys = append(languageResult.FailedKeys, key)
+			continue
+		}
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  projectID,
+			LanguageID: target.ID,
+			KeyName:    key,
+			Value:      translated[i].TranslatedText,
+		})
+		filled = append(filled, key)
+	}
+	if len(inputs) == 0 {
+		return nil
+	}
+	if err := s.translationService.UpsertBatch(ctx, inputs); err != nil {
+		return err
+	}
+	languageResult.Filled += len(filled)
+	languageResult.FilledKeys = append(languageResult.FilledKeys, filled...)
+	return nil
+}
+
+// wait 等待两次调用服务商之间的间隔，请求取消时返回
+func (s *AutoTranslateService) wait(ctx context.Context) error {
+	if s.requestInterval <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(s.requestInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// resolveLanguages 确定源语言和目标语言，未指定源语言时使用项目的源语言，目标语言去重并保持请求中的顺序
+func (s *AutoTranslateService) resolveLanguages(ctx context.Context, project *domain.Project, params domain.AutoTranslateParams) (*domain.Language, []*domain.Language, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	byCode := make(map[string]*domain.Language, len(languages))
+	var source *domain.Language
+	for _, language := range languages {
+		if language.Status != "active" {
+			continue
+		}
+		byCode[language.Code] = language
+		if project.SourceLanguage == "" && language.IsDefault {
+			source = language
+		}
+	}
+	if project.SourceLanguage != "" {
+		source = byCode[project.SourceLanguage]
+	}
+	if code := strings.TrimSpace(params.SourceLanguage); code != "" {
+		if source = byCode[code]; source == nil {
+			return nil, nil, domain.ErrLanguageNotFound
+		}
+	}
+	if source == nil {
+		return nil, nil, domain.ErrNoSourceLanguage
+	}
+
+	var targets []*domain.Language
+	seen := make(map[string]bool)
+	for _, code := range params.TargetLanguages {
+		code = strings.TrimSpace(code)
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		target := byCode[code]
+		switch {
+		case target == nil:
+			return nil, nil, domain.ErrLanguageNotFound
+		case target.ID == source.ID:
+			return nil, nil, domain.ErrIsSourceLanguage
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return nil, nil, domain.ErrInvalidInput
+	}
+	return source, targets, nil
+}
+
+// normalizeKeyNames 去除键名首尾空白、空键名和重复的键名，全部为空时返回 nil
+func normalizeKeyNames(keyNames []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(keyNames))
+	for _, key := range keyNames {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, key)
+	}
+	return normalized
+}
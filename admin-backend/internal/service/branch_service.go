@@ -0,0 +1,454 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// maxBranchNameLength 分支名称最大字符数，与 branches.name 列长度一致
+	maxBranchNameLength = 100
+	// maxBranchDescriptionLength 分支说明最大字符数
+	maxBranchDescriptionLength = 500
+	// maxBranchTranslations 每次在分支中修改的翻译条数上限
+	maxBranchTranslations = 1000
+	// maxBranchConflictDetails 合并冲突错误详情中列出的翻译数量
+	maxBranchConflictDetails = 20
+)
+
+// branchNamePattern 分支名称，如 release-2.0、feature/checkout
+var branchNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// BranchService 翻译分支服务实现
+// 分支只保存修改过的翻译，读取时叠加在主线的当前翻译之上；合并时写入主线通过 TranslationService 完成
+type BranchService struct {
+	branchRepo         domain.BranchRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+	auditLogRepo       domain.AuditLogRepository
+	transactor         domain.Transactor
+}
+
+// NewBranchService 创建翻译分支服务实例
+func NewBranchService(
+	branchRepo domain.BranchRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	auditLogRepo domain.AuditLogRepository,
+	transactor domain.Transactor,
+) *BranchService {
+	return &BranchService{
+		branchRepo:         branchRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		translationService: translationService,
+		auditLogRepo:       auditLogRepo,
+		transactor:         transactor,
+	}
+}
+
+// GetByProjectID 获取项目的分支
+func (s *BranchService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Branch, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.branchRepo.GetByProjectID(ctx, projectID)
+}
+
+// Create 创建分支，不复制翻译，创建后分支的翻译与主线相同
+func (s *BranchService) Create(ctx context.Context, projectID uint64, params domain.BranchParams, userID uint64) (*domain.Branch, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxBranchNameLength || !branchNamePattern.MatchString(name) {
+		return nil, invalidBranch("名称只能包含字母、数字、点、下划线、斜杠和连字符，以字母或数字开头，最多 100 个字符")
+	}
+	if strings.EqualFold(name, domain.BranchMain) {
+		return nil, invalidBranch("main 为主线，不能用作分支名称")
+	}
+	description := strings.TrimSpace(params.Description)
+	if utf8.RuneCountInString(description) > maxBranchDescriptionLength {
+		return nil, invalidBranch("说明最多 500 个字符")
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	_, err := s.branchRepo.GetByName(ctx, projectID, name)
+	switch {
+	case err == nil:
+		return nil, domain.ErrBranchExists
+	case !errors.Is(err, domain.ErrBranchNotFound):
+		return nil, err
+	}
+
+	branch := &domain.Branch{
+		ProjectID:   projectID,
+		Name:        name,
+		Description: description,
+		Status:      domain.BranchStatusOpen,
+		CreatedBy:   userID,
+	}
+	if err := s.branchRepo.Create(ctx, branch); err != nil {
+		return nil, err
+	}
+	return branch, nil
+}
+
+// Delete 删除分支，主线的翻译不受影响
+func (s *BranchService) Delete(ctx context.Context, projectID, branchID uint64) error {
+	branch, err := s.getProjectBranch(ctx, projectID, branchID)
+	if err != nil {
+		return err
+	}
+	return s.branchRepo.Delete(ctx, branch.ID)
+}
+
+// GetValues 获取分支的翻译：主线的当前翻译叠加分支中修改过的翻译
+func (s *BranchService) GetValues(ctx context.Context, projectID, branchID uint64) (map[string]map[string]string, error) {
+	branch, err := s.getProjectBranch(ctx, projectID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	main, err := loadProjectValues(ctx, s.translationRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	languageIDs, err := activeLanguageIDs(ctx, s.languageRepo)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := s.branchRepo.GetTranslations(ctx, branch.ID)
+	if err != nil {
+		return nil, err
+	}
+	return overlayBranch(main, modified, languageCodesByID(languageIDs)), nil
+}
+
+// SetTranslations 在分支中修改或删除翻译，首次修改时记录主线的值用于合并时检测冲突
+// 同一键和语言出现多次时以最后一条为准
+func (s *BranchService) SetTranslations(ctx context.Context, projectID, branchID uint64, params []domain.BranchTranslationParams, userID uint64) (int, error) {
+	if len(params) == 0 {
+		return 0, domain.ErrInvalidInput
+	}
+	if len(params) > maxBranchTranslations {
+		return 0, invalidBranch(fmt.Sprintf("每次最多修改 %d 条翻译", maxBranchTranslations))
+	}
+
+	branch, err := s.getProjectBranch(ctx, projectID, branchID)
+	if err != nil {
+		return 0, err
+	}
+	if branch.Status == domain.BranchStatusMerged {
+		return 0, domain.ErrBranchMerged
+	}
+
+	languageIDs, err := activeLanguageIDs(ctx, s.languageRepo)
+	if err != nil {
+		return 0, err
+	}
+	main, err := loadProjectValues(ctx, s.translationRepo, projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	translations := make([]*domain.BranchTranslation, 0, len(params))
+	index := make(map[domain.PromotionChangeRef]int, len(params))
+	for _, param := range params {
+		keyName := strings.TrimSpace(param.KeyName)
+		if keyName == "" || utf8.RuneCountInString(keyName) > 255 {
+			return 0, domain.ErrInvalidKey
+		}
+		languageID, ok := languageIDs[param.LanguageCode]
+		if !ok {
+			return 0, domain.NewAppErrorWithDetails(domain.ErrorTypeNotFound, domain.ErrLanguageNotFound.Code,
+				domain.ErrLanguageNotFound.Message, "语言不存在或未启用："+param.LanguageCode)
+		}
+
+		baseValue, baseExists := main[keyName][param.LanguageCode]
+		translation := &domain.BranchTranslation{
+			BranchID:   branch.ID,
+			KeyName:    keyName,
+			LanguageID: languageID,
+			Deleted:    param.Deleted,
+			BaseValue:  baseValue,
+			BaseExists: baseExists,
+			UpdatedBy:  userID,
+		}
+		if !param.Deleted {
+			translation.Value = param.Value
+		}
+
+		ref := domain.PromotionChangeRef{Key: keyName, Language: param.LanguageCode}
+		if i, ok := index[ref]; ok {
+			translations[i] = translation
+			continue
+		}
+		index[ref] = len(translations)
+		translations = append(translations, translation)
+	}
+
+	if err := s.branchRepo.UpsertTranslations(ctx, translations); err != nil {
+		return 0, err
+	}
+	return len(translations), nil
+}
+
+// Diff 计算将分支合并到目标分支时的差异，target 为空或 main 时与主线比较并标记冲突
+func (s *BranchService) Diff(ctx context.Context, projectID, branchID uint64, target string) (*domain.BranchDiff, error) {
+	branch, err := s.getProjectBranch(ctx, projectID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	diff, _, err := s.diff(ctx, branch, target)
+	return diff, err
+}
+
+// Merge 将分支合并到主线
+// 分支修改翻译后主线也修改了它时为冲突，冲突必须全部指定处理方式，否则不做任何修改
+func (s *BranchService) Merge(ctx context.Context, projectID, branchID uint64, params domain.BranchMergeParams, userID uint64) (*domain.BranchMergeResult, error) {
+	branch, err := s.getProjectBranch(ctx, projectID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if branch.Status == domain.BranchStatusMerged {
+		return nil, domain.ErrBranchMerged
+	}
+
+	diff, languageIDs, err := s.diff(ctx, branch, domain.BranchMain)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[domain.PromotionChangeRef]bool, len(diff.Changes))
+	for _, change := range diff.Changes {
+		changes[domain.PromotionChangeRef{Key: change.Key, Language: change.Language}] = true
+	}
+	resolutions := make(map[domain.PromotionChangeRef]domain.BranchConflictResolution, len(params.Resolutions))
+	for _, resolution := range params.Resolutions {
+		ref := domain.PromotionChangeRef{Key: resolution.Key, Language: resolution.Language}
+		if !changes[ref] {
+			return nil, invalidBranch(fmt.Sprintf("没有 %s (%s) 的差异", resolution.Key, resolution.Language))
+		}
+		switch resolution.Resolution {
+		case domain.BranchResolutionBranch, domain.BranchResolutionMain, domain.BranchResolutionCustom:
+		default:
+			return nil, invalidBranch("处理方式只能是 branch、main 或 custom")
+		}
+		resolutions[ref] = resolution
+	}
+
+	var unresolved []string
+	for _, change := range diff.Changes {
+		if _, ok := resolutions[domain.PromotionChangeRef{Key: change.Key, Language: change.Language}]; change.Conflict && !ok {
+			unresolved = append(unresolved, fmt.Sprintf("%s (%s)", change.Key, change.Language))
+		}
+	}
+	if len(unresolved) > 0 {
+		details := "冲突的翻译：" + strings.Join(unresolved[:min(len(unresolved), maxBranchConflictDetails)], "、")
+		if len(unresolved) > maxBranchConflictDetails {
+			details += fmt.Sprintf(" 等 %d 条", len(unresolved))
+		}
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeConflict, domain.ErrBranchMergeConflict.Code,
+			domain.ErrBranchMergeConflict.Message, details)
+	}
+
+	result := &domain.BranchMergeResult{Branch: branch, Applied: []domain.PromotionChange{}}
+	for _, change := range diff.Changes {
+		applied := domain.PromotionChange{
+			Key:      change.Key,
+			Language: change.Language,
+			Type:     change.Type,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+		if resolution, ok := resolutions[domain.PromotionChangeRef{Key: change.Key, Language: change.Language}]; ok {
+			switch resolution.Resolution {
+			case domain.BranchResolutionMain:
+				result.Skipped++
+				continue
+			case domain.BranchResolutionCustom:
+				if change.Type != domain.PromotionChangeAdded {
+					applied.Type = domain.PromotionChangeModified
+				}
+				applied.NewValue = resolution.Value
+				if applied.Type == domain.PromotionChangeModified && applied.NewValue == applied.OldValue {
+					result.Skipped++
+					continue
+				}
+			}
+		}
+		result.Applied = append(result.Applied, applied)
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := applyTranslationChanges(ctx, s.translationService, s.translationRepo, projectID, result.Applied, languageIDs); err != nil {
+			return err
+		}
+
+		mergedAt := time.Now()
+		branch.Status = domain.BranchStatusMerged
+		branch.MergedBy = userID
+		branch.MergedAt = &mergedAt
+		if err := s.branchRepo.Update(ctx, branch); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionBranchMerge, map[string]interface{}{
+			"branch_id": branch.ID,
+			"branch":    branch.Name,
+			"applied":   len(result.Applied),
+			"skipped":   result.Skipped,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// diff 计算分支相对目标分支的差异，返回启用的语言供合并使用
+func (s *BranchService) diff(ctx context.Context, branch *domain.Branch, target string) (*domain.BranchDiff, map[string]uint64, error) {
+	if target = strings.TrimSpace(target); target == "" {
+		target = domain.BranchMain
+	}
+
+	main, err := loadProjectValues(ctx, s.translationRepo, branch.ProjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	languageIDs, err := activeLanguageIDs(ctx, s.languageRepo)
+	if err != nil {
+		return nil, nil, err
+	}
+	languageCodes := languageCodesByID(languageIDs)
+	modified, err := s.branchRepo.GetTranslations(ctx, branch.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	source := overlayBranch(main, modified, languageCodes)
+
+	targetValues := main
+	if target != domain.BranchMain {
+		targetBranch, err := s.branchRepo.GetByName(ctx, branch.ProjectID, target)
+		if err != nil {
+			return nil, nil, err
+		}
+		targetModified, err := s.branchRepo.GetTranslations(ctx, targetBranch.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		targetValues = overlayBranch(main, targetModified, languageCodes)
+	}
+
+	// 分支只包含启用的语言，不会出现目标中不存在的语言
+	promotionChanges, _ := diffTranslations(source, targetValues, languageIDs, true)
+	diff := &domain.BranchDiff{
+		Source:  branch.Name,
+		Target:  target,
+		Changes: make([]domain.BranchChange, 0, len(promotionChanges)),
+	}
+
+	bases := make(map[domain.PromotionChangeRef]*domain.BranchTranslation, len(modified))
+	if target == domain.BranchMain {
+		for _, translation := range modified {
+			if code, ok := languageCodes[translation.LanguageID]; ok {
+				bases[domain.PromotionChangeRef{Key: translation.KeyName, Language: code}] = translation
+			}
+		}
+	}
+	for _, change := range promotionChanges {
+		branchChange := domain.BranchChange{
+			Key:      change.Key,
+			Language: change.Language,
+			Type:     change.Type,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		}
+		// 主线的当前值与分支首次修改时记录的值不同，说明双方都修改了该翻译
+		if base, ok := bases[domain.PromotionChangeRef{Key: change.Key, Language: change.Language}]; ok {
+			current, exists := main[change.Key][change.Language]
+			if exists != base.BaseExists || current != base.BaseValue {
+				branchChange.Conflict = true
+				branchChange.BaseValue = base.BaseValue
+				diff.Conflicts++
+			}
+		}
+		diff.Changes = append(diff.Changes, branchChange)
+	}
+	return diff, languageIDs, nil
+}
+
+// getProjectBranch 获取分支并确认其属于该项目
+func (s *BranchService) getProjectBranch(ctx context.Context, projectID, branchID uint64) (*domain.Branch, error) {
+	branch, err := s.branchRepo.GetByID(ctx, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if branch.ProjectID != projectID {
+		return nil, domain.ErrBranchNotFound
+	}
+	return branch, nil
+}
+
+// overlayBranch 将分支中修改过的翻译叠加在主线翻译之上，不修改主线的 map
+// 已停用语言的翻译不参与叠加
+func overlayBranch(main map[string]map[string]string, modified []*domain.BranchTranslation, languageCodes map[uint64]string) map[string]map[string]string {
+	values := make(map[string]map[string]string, len(main))
+	for keyName, languages := range main {
+		values[keyName] = languages
+	}
+
+	copied := make(map[string]bool)
+	for _, translation := range modified {
+		code, ok := languageCodes[translation.LanguageID]
+		if !ok {
+			continue
+		}
+		if !copied[translation.KeyName] {
+			copied[translation.KeyName] = true
+			languages := make(map[string]string, len(values[translation.KeyName])+1)
+			for c, value := range values[translation.KeyName] {
+				languages[c] = value
+			}
+			values[translation.KeyName] = languages
+		}
+
+		if translation.Deleted {
+			delete(values[translation.KeyName], code)
+			if len(values[translation.KeyName]) == 0 {
+				delete(values, translation.KeyName)
+			}
+			continue
+		}
+		if values[translation.KeyName] == nil {
+			values[translation.KeyName] = make(map[string]string)
+		}
+		values[translation.KeyName][code] = translation.Value
+	}
+	return values
+}
+
+// languageCodesByID 语言代码 -> 语言ID 转换为 语言ID -> 语言代码
+func languageCodesByID(languageIDs map[string]uint64) map[uint64]string {
+	codes := make(map[uint64]string, len(languageIDs))
+	for code, id := range languageIDs {
+		codes[id] = code
+	}
+	return codes
+}
+
+// invalidBranch 带错误详情的分支无效错误
+func invalidBranch(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidBranch.Code, domain.ErrInvalidBranch.Message, details)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"yflow/internal/domain"
+)
+
+const (
+	// bulkDeleteBatchSize 每个事务删除的翻译条数
+	bulkDeleteBatchSize = 1000
+	// bulkDeleteTokenTTL 确认令牌的有效期
+	bulkDeleteTokenTTL = 10 * time.Minute
+)
+
+// BulkDeleteService 按条件批量删除翻译服务实现
+type BulkDeleteService struct {
+	translationRepo domain.TranslationRepository
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	auditLogRepo    domain.AuditLogRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+	signingKey      []byte
+}
+
+// NewBulkDeleteService 创建按条件批量删除翻译服务实例
+// signingKey 用于签名确认令牌；翻译缓存由 translation.updated 事件的订阅方清除
+func NewBulkDeleteService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	signingKey string,
+) *BulkDeleteService {
+	return &BulkDeleteService{
+		translationRepo: translationRepo,
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		auditLogRepo:    auditLogRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+		signingKey:      []byte(signingKey),
+	}
+}
+
+// Preview 统计符合条件的翻译并签发确认令牌
+// 令牌绑定操作人、筛选条件和匹配的翻译条数，有效期内匹配结果不变时才能用于执行删除
+func (s *BulkDeleteService) Preview(ctx context.Context, projectID uint64, params domain.BulkDeleteParams, userID uint64) (*domain.BulkDeleteResult, error) {
+	filter, result, err := s.match(ctx, projectID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	samples, err := s.translationRepo.GetKeyNamesByFilter(ctx, filter, maxKeyPrefixSamples)
+	if err != nil {
+		return nil, err
+	}
+	result.SampleKeys = samples
+
+	expiresAt := time.Now().Add(bulkDeleteTokenTTL).Truncate(time.Second)
+	result.ExpiresAt = &expiresAt
+	result.ConfirmationToken = strconv.FormatInt(expiresAt.Unix(), 10) + "." + s.sign(filter, result.Translations, userID, expiresAt)
+	return result, nil
+}
+
+// Delete 校验确认令牌后分批删除符合条件的翻译
+// 每批在独立的事务中删除并发布 translation.updated 事件；中途失败时已删除的批次不会回滚，审计日志记录实际删除的条数
+func (s *BulkDeleteService) Delete(ctx context.Context, projectID uint64, params domain.BulkDeleteParams, userID uint64) (*domain.BulkDeleteResult, error) {
+	if params.ConfirmationToken == "" {
+		return nil, domain.ErrBulkDeleteTokenRequired
+	}
+	if err := ensureProjectsUnprotected(ctx, s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+	filter, result, err := s.match(ctx, projectID, params)
+	if err != nil {
+		return nil, err
+	}
+	if !s.verify(params.ConfirmationToken, filter, result.Translations, userID) {
+		return nil, domain.ErrBulkDeleteTokenInvalid
+	}
+
+	// 最多删除预览时匹配的条数，避免执行期间新增的翻译被删除
+	var deleteErr error
+	for result.Deleted < result.Translations {
+		limit := bulkDeleteBatchSize
+		if remaining := result.Translations - result.Deleted; remaining < int64(limit) {
+			limit = int(remaining)
+		}
+		batch, err := s.translationRepo.FindByFilter(ctx, filter, limit)
+		if err != nil {
+			deleteErr = err
+			break
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		ids := make([]uint64, 0, len(batch))
+		seen := make(map[string]bool, len(batch))
+		var keyNames []string
+		for _, translation := range batch {
+			ids = append(ids, translation.ID)
+			if !seen[translation.KeyName] {
+				seen[translation.KeyName] = true
+				keyNames = append(keyNames, translation.KeyName)
+			}
+		}
+
+		err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+			affected, err := s.translationRepo.DeleteByIDs(ctx, ids, userID)
+			if err != nil {
+				return err
+			}
+			result.Deleted += affected
+			return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+				Action:   domain.TranslationActionDeleted,
+				KeyNames: keyNames,
+				Count:    int(affected),
+			})
+		})
+		if err != nil {
+			deleteErr = err
+			break
+		}
+		result.Batches++
+	}
+
+	if result.Batches > 0 {
+		summary := *result
+		summary.ExpiresAt = nil
+		if err := recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionBulkDelete, summary); err != nil && deleteErr == nil {
+			deleteErr = err
+		}
+	}
+	if deleteErr != nil {
+		return nil, deleteErr
+	}
+	return result, nil
+}
+
+// match 校验筛选条件并统计符合条件的翻译
+func (s *BulkDeleteService) match(ctx context.Context, projectID uint64, params domain.BulkDeleteParams) (domain.TranslationFilter, *domain.BulkDeleteResult, error) {
+	filter := domain.TranslationFilter{
+		ProjectID: projectID,
+		KeyPrefix: normalizeKeyPrefix(params.KeyPrefix),
+		Status:    strings.TrimSpace(params.Status),
+		OlderThan: params.OlderThan,
+	}
+	language := strings.TrimSpace(params.Language)
+	if filter.KeyPrefix == "" && language == "" && filter.Status == "" && filter.OlderThan == nil {
+		return filter, nil, domain.ErrInvalidBulkDeleteFilter
+	}
+	if filter.Status != "" && filter.Status != "active" && filter.Status != "deprecated" {
+		return filter, nil, domain.ErrInvalidBulkDeleteFilter
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return filter, nil, domain.ErrProjectNotFound
+	}
+	if language != "" {
+		lang, err := s.languageRepo.GetByCode(ctx, language)
+		if err != nil {
+			return filter, nil, err
+		}
+		filter.LanguageID = lang.ID
+		language = lang.Code
+	}
+
+	translations, keys, err := s.translationRepo.CountByFilter(ctx, filter)
+	if err != nil {
+		return filter, nil, err
+	}
+	return filter, &domain.BulkDeleteResult{
+		KeyPrefix:    filter.KeyPrefix,
+		Language:     language,
+		Status:       filter.Status,
+		OlderThan:    filter.OlderThan,
+		Keys:         keys,
+		Translations: translations,
+	}, nil
+}
+
+// sign 计算确认令牌的签名，覆盖操作人、筛选条件、匹配条数和过期时间
+func (s *BulkDeleteService) sign(filter domain.TranslationFilter, translations int64, userID uint64, expiresAt time.Time) string {
+	olderThan := ""
+	if filter.OlderThan != nil {
+		olderThan = filter.OlderThan.UTC().Format(time.RFC3339Nano)
+	}
+	payload, _ := json.Marshal([]interface{}{
+		filter.ProjectID,
+		filter.KeyPrefix,
+		filter.LanguageID,
+		filter.Status,
+		olderThan,
+		translations,
+		userID,
+		expiresAt.Unix(),
+	})
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify 校验确认令牌未过期且与当前的筛选条件和匹配条数一致
+func (s *BulkDeleteService) verify(token string, filter domain.TranslationFilter, translations int64, userID uint64) bool {
+	expires, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return false
+	}
+	expiresAt := time.Unix(unix, 0)
+	if time.Now().After(expiresAt) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(filter, translations, userID, expiresAt)))
+}
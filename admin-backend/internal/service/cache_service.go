@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"yflow/internal/domain"
 	"yflow/internal/repository"
 	"math/rand"
@@ -94,6 +93,16 @@ func (s *CacheService) HDel(ctx context.Context, key string, fields ...string) e
 	return s.redisClient.HDel(ctx, key, fields...)
 }
 
+// HIncrBy 原子地增加哈希表字段的值，返回增加后的值
+func (s *CacheService) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	return s.redisClient.HIncrBy(ctx, key, field, incr)
+}
+
+// Expire 设置键的过期时间
+func (s *CacheService) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return s.redisClient.Expire(ctx, key, expiration)
+}
+
 // SetWithEmptyCache 设置缓存，对于空结果也缓存一小段时间防止缓存穿透
 func (s *CacheService) SetWithEmptyCache(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	// 如果值为空，设置较短的过期时间防止缓存穿透
@@ -154,39 +163,6 @@ func (s *CacheService) AddRandomExpiration(baseExpiration time.Duration) time.Du
 	return baseExpiration + randomMinutes
 }
 
-// GetTranslationKey 获取翻译缓存键
-func (s *CacheService) GetTranslationKey(projectID uint64) string {
-	return fmt.Sprintf("%s%d", domain.TranslationKeyPrefix, projectID)
-}
-
-// GetTranslationMatrixKey 获取翻译矩阵缓存键
-func (s *CacheService) GetTranslationMatrixKey(projectID uint64, keyword string) string {
-	if keyword == "" {
-		return fmt.Sprintf("%s%d", domain.TranslationMatrixPrefix, projectID)
-	}
-	return fmt.Sprintf("%s%d:%s", domain.TranslationMatrixPrefix, projectID, keyword)
-}
-
-// GetDashboardStatsKey 获取仪表板统计缓存键
-func (s *CacheService) GetDashboardStatsKey() string {
-	return domain.DashboardStatsKey
-}
-
-// GetLanguagesKey 获取语言列表缓存键
-func (s *CacheService) GetLanguagesKey() string {
-	return domain.LanguagesKey
-}
-
-// GetProjectKey 获取项目缓存键
-func (s *CacheService) GetProjectKey(projectID uint64) string {
-	return fmt.Sprintf("%s%d", domain.ProjectKeyPrefix, projectID)
-}
-
-// GetProjectsKey 获取项目列表缓存键
-func (s *CacheService) GetProjectsKey() string {
-	return domain.ProjectsKey
-}
-
 // isEmptyValue 检查值是否为空
 func isEmptyValue(value interface{}) bool {
 	switch v := value.(type) {
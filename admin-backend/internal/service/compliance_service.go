@@ -0,0 +1,423 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// historyExportBatchSize 导出时每批读取的记录数，每批写入后刷新输出
+	historyExportBatchSize = 1000
+	// maxComplianceReportMonths 合规报告最多包含的月份数
+	maxComplianceReportMonths = 24
+	// complianceMonthLayout 合规报告的月份格式
+	complianceMonthLayout = "2006-01"
+)
+
+// historyExportColumns 翻译变更历史导出的列（CSV 表头与 JSONL 字段名一致）
+var historyExportColumns = []string{
+	"id", "created_at", "project_id", "translation_id", "key_name", "previous_key", "language",
+	"operation", "old_value", "new_value", "old_status", "new_status", "user_id", "username", "comment",
+}
+
+// auditExportColumns 审计日志导出的列
+var auditExportColumns = []string{"id", "created_at", "project_id", "action", "user_id", "username", "details"}
+
+// historyExportRow 翻译变更历史的导出行
+type historyExportRow struct {
+	ID            uint64 `json:"id"`
+	CreatedAt     string `json:"created_at"`
+	ProjectID     uint64 `json:"project_id"`
+	TranslationID uint64 `json:"translation_id"`
+	KeyName       string `json:"key_name"`
+	PreviousKey   string `json:"previous_key"`
+	Language      string `json:"language"`
+	Operation     string `json:"operation"`
+	OldValue      string `json:"old_value"`
+	NewValue      string `json:"new_value"`
+	OldStatus     string `json:"old_status"`
+	NewStatus     string `json:"new_status"`
+	UserID        uint64 `json:"user_id"`
+	Username      string `json:"username"`
+	Comment       string `json:"comment"`
+}
+
+// values 按 historyExportColumns 的顺序返回 CSV 字段
+func (r *historyExportRow) values() []string {
+	return []string{
+		strconv.FormatUint(r.ID, 10), r.CreatedAt, strconv.FormatUint(r.ProjectID, 10), strconv.FormatUint(r.TranslationID, 10),
+		r.KeyName, r.PreviousKey, r.Language, r.Operation, r.OldValue, r.NewValue, r.OldStatus, r.NewStatus,
+		strconv.FormatUint(r.UserID, 10), r.Username, r.Comment,
+	}
+}
+
+// auditExportRow 审计日志的导出行
+type auditExportRow struct {
+	ID        uint64          `json:"id"`
+	CreatedAt string          `json:"created_at"`
+	ProjectID uint64          `json:"project_id"`
+	Action    string          `json:"action"`
+	UserID    uint64          `json:"user_id"`
+	Username  string          `json:"username"`
+	Details   json.RawMessage `json:"details"`
+}
+
+// values 按 auditExportColumns 的顺序返回 CSV 字段
+func (r *auditExportRow) values() []string {
+	return []string{
+		strconv.FormatUint(r.ID, 10), r.CreatedAt, strconv.FormatUint(r.ProjectID, 10), r.Action,
+		strconv.FormatUint(r.UserID, 10), r.Username, string(r.Details),
+	}
+}
+
+// ComplianceService 历史导出和合规报告服务实现
+// 翻译变更历史记录谁修改了什么，审计日志记录项目级操作和数据导出，合规报告按月汇总两者
+type ComplianceService struct {
+	historyRepo  domain.TranslationHistoryRepository
+	auditLogRepo domain.AuditLogRepository
+	projectRepo  domain.ProjectRepository
+	languageRepo domain.LanguageRepository
+	userRepo     domain.UserRepository
+}
+
+// NewComplianceService 创建历史导出和合规报告服务实例
+func NewComplianceService(
+	historyRepo domain.TranslationHistoryRepository,
+	auditLogRepo domain.AuditLogRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+) *ComplianceService {
+	return &ComplianceService{
+		historyRepo:  historyRepo,
+		auditLogRepo: auditLogRepo,
+		projectRepo:  projectRepo,
+		languageRepo: languageRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// ExportHistory 校验参数并记录审计日志后，将时间范围内的记录流式写入 w
+// 每批记录写入后刷新输出（w 实现 Flush 时），导出大量记录时不会占用过多内存
+func (s *ComplianceService) ExportHistory(ctx context.Context, params domain.HistoryExportParams, userID uint64, w io.Writer) error {
+	if params.From.IsZero() || params.To.IsZero() || !params.From.Before(params.To) {
+		return domain.ErrInvalidHistoryRange
+	}
+	if params.Source != domain.HistorySourceTranslations && params.Source != domain.HistorySourceAudit {
+		return domain.ErrInvalidHistorySource
+	}
+	if params.Format != domain.HistoryFormatCSV && params.Format != domain.HistoryFormatJSONL {
+		return domain.ErrInvalidHistoryFormat
+	}
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return domain.ErrProjectNotFound
+	}
+
+	// 导出本身也是对数据的访问，计入合规报告
+	if err := recordAudit(ctx, s.auditLogRepo, params.ProjectID, userID, domain.AuditActionHistoryExport, map[string]interface{}{
+		"source": params.Source,
+		"format": params.Format,
+		"from":   params.From.Format(time.RFC3339),
+		"to":     params.To.Format(time.RFC3339),
+	}); err != nil {
+		return err
+	}
+
+	out := newExportWriter(w, params.Format)
+	users := newUsernameResolver(s.userRepo)
+	query := domain.HistoryQuery{ProjectID: params.ProjectID, From: params.From, To: params.To}
+
+	if params.Source == domain.HistorySourceAudit {
+		if err := out.header(auditExportColumns); err != nil {
+			return err
+		}
+		err := s.auditLogRepo.Stream(ctx, query, historyExportBatchSize, func(logs []*domain.AuditLog) error {
+			userIDs := make([]uint64, 0, len(logs))
+			for _, log := range logs {
+				userIDs = append(userIDs, log.UserID)
+			}
+			if err := users.load(ctx, userIDs); err != nil {
+				return err
+			}
+			for _, log := range logs {
+				row := &auditExportRow{
+					ID:        log.ID,
+					CreatedAt: log.CreatedAt.Format(time.RFC3339),
+					ProjectID: log.ProjectID,
+					Action:    log.Action,
+					UserID:    log.UserID,
+					Username:  users.name(log.UserID),
+					Details:   log.Details,
+				}
+				if len(row.Details) == 0 {
+					row.Details = json.RawMessage("null")
+				}
+				if err := out.row(row, row.values()); err != nil {
+					return err
+				}
+			}
+			return out.flush()
+		})
+		if err != nil {
+			return err
+		}
+		return out.flush()
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	languageCodes := make(map[uint64]string, len(languages))
+	for _, language := range languages {
+		languageCodes[language.ID] = language.Code
+	}
+
+	if err := out.header(historyExportColumns); err != nil {
+		return err
+	}
+	err = s.historyRepo.Stream(ctx, query, historyExportBatchSize, func(entries []*domain.TranslationHistory) error {
+		userIDs := make([]uint64, 0, len(entries))
+		for _, entry := range entries {
+			userIDs = append(userIDs, entry.UserID)
+		}
+		if err := users.load(ctx, userIDs); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			row := &historyExportRow{
+				ID:            entry.ID,
+				CreatedAt:     entry.CreatedAt.Format(time.RFC3339),
+				ProjectID:     entry.ProjectID,
+				TranslationID: entry.TranslationID,
+				KeyName:       entry.KeyName,
+				PreviousKey:   entry.PreviousKey,
+				Language:      languageCodes[entry.LanguageID],
+				Operation:     entry.Operation,
+				OldValue:      entry.OldValue,
+				NewValue:      entry.NewValue,
+				OldStatus:     entry.OldStatus,
+				NewStatus:     entry.NewStatus,
+				UserID:        entry.UserID,
+				Username:      users.name(entry.UserID),
+				Comment:       entry.Comment,
+			}
+			if err := out.row(row, row.values()); err != nil {
+				return err
+			}
+		}
+		return out.flush()
+	})
+	if err != nil {
+		return err
+	}
+	return out.flush()
+}
+
+// GetReport 按项目和月份汇总每个用户的翻译变更、审计操作和数据导出次数
+func (s *ComplianceService) GetReport(ctx context.Context, params domain.ComplianceReportParams) (*domain.ComplianceReport, error) {
+	from, err := time.ParseInLocation(complianceMonthLayout, params.From, time.Local)
+	if err != nil {
+		return nil, domain.ErrInvalidHistoryRange
+	}
+	to, err := time.ParseInLocation(complianceMonthLayout, params.To, time.Local)
+	if err != nil || to.Before(from) || !to.Before(from.AddDate(0, maxComplianceReportMonths, 0)) {
+		return nil, domain.ErrInvalidHistoryRange
+	}
+	if params.ProjectID != 0 {
+		if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+	}
+
+	query := domain.HistoryQuery{ProjectID: params.ProjectID, From: from, To: to.AddDate(0, 1, 0)}
+	changes, err := s.historyRepo.CountActivity(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := s.auditLogRepo.CountActivity(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按项目 -> 月份 -> 用户归并两类统计
+	type activityKey struct {
+		projectID uint64
+		month     string
+		userID    uint64
+	}
+	activities := make(map[activityKey]*domain.ComplianceUserActivity)
+	activity := func(count *domain.ActivityCount) *domain.ComplianceUserActivity {
+		key := activityKey{projectID: count.ProjectID, month: count.Month, userID: count.UserID}
+		if a, ok := activities[key]; ok {
+			return a
+		}
+		a := &domain.ComplianceUserActivity{
+			UserID:  count.UserID,
+			Changes: make(map[string]int64),
+			Actions: make(map[string]int64),
+		}
+		activities[key] = a
+		return a
+	}
+	for _, count := range changes {
+		activity(count).Changes[count.Action] += count.Count
+	}
+	for _, count := range actions {
+		a := activity(count)
+		a.Actions[count.Action] += count.Count
+		if strings.HasSuffix(count.Action, ".export") {
+			a.Exports += count.Count
+		}
+	}
+
+	projectIDs := make([]uint64, 0)
+	userIDs := make([]uint64, 0)
+	seenProjects := make(map[uint64]bool)
+	for key := range activities {
+		if !seenProjects[key.projectID] {
+			seenProjects[key.projectID] = true
+			projectIDs = append(projectIDs, key.projectID)
+		}
+		userIDs = append(userIDs, key.userID)
+	}
+	projectNames := make(map[uint64]string, len(projectIDs))
+	if len(projectIDs) > 0 {
+		projects, err := s.projectRepo.GetByIDs(ctx, projectIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			projectNames[project.ID] = project.Name
+		}
+	}
+	users := newUsernameResolver(s.userRepo)
+	if err := users.load(ctx, userIDs); err != nil {
+		return nil, err
+	}
+
+	projectReports := make(map[uint64]*domain.ComplianceProjectReport)
+	monthReports := make(map[activityKey]*domain.ComplianceMonthReport)
+	for key, a := range activities {
+		a.Username = users.name(a.UserID)
+		projectReport, ok := projectReports[key.projectID]
+		if !ok {
+			projectReport = &domain.ComplianceProjectReport{ProjectID: key.projectID, ProjectName: projectNames[key.projectID]}
+			projectReports[key.projectID] = projectReport
+		}
+		monthKey := activityKey{projectID: key.projectID, month: key.month}
+		monthReport, ok := monthReports[monthKey]
+		if !ok {
+			monthReport = &domain.ComplianceMonthReport{Month: key.month}
+			monthReports[monthKey] = monthReport
+			projectReport.Months = append(projectReport.Months, monthReport)
+		}
+		monthReport.Users = append(monthReport.Users, a)
+	}
+
+	report := &domain.ComplianceReport{
+		From:     from.Format(complianceMonthLayout),
+		To:       to.Format(complianceMonthLayout),
+		Projects: make([]*domain.ComplianceProjectReport, 0, len(projectReports)),
+	}
+	for _, projectReport := range projectReports {
+		sort.Slice(projectReport.Months, func(i, j int) bool { return projectReport.Months[i].Month < projectReport.Months[j].Month })
+		for _, monthReport := range projectReport.Months {
+			sort.Slice(monthReport.Users, func(i, j int) bool { return monthReport.Users[i].UserID < monthReport.Users[j].UserID })
+		}
+		report.Projects = append(report.Projects, projectReport)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool { return report.Projects[i].ProjectID < report.Projects[j].ProjectID })
+	return report, nil
+}
+
+// exportWriter 按 CSV 或 JSONL 格式写入导出行
+type exportWriter struct {
+	w       io.Writer
+	csv     *csv.Writer
+	encoder *json.Encoder
+}
+
+// newExportWriter 创建导出写入器
+func newExportWriter(w io.Writer, format string) *exportWriter {
+	if format == domain.HistoryFormatCSV {
+		return &exportWriter{w: w, csv: csv.NewWriter(w)}
+	}
+	return &exportWriter{w: w, encoder: json.NewEncoder(w)}
+}
+
+// header 写入 CSV 表头，JSONL 没有表头
+func (e *exportWriter) header(columns []string) error {
+	if e.csv == nil {
+		return nil
+	}
+	return e.csv.Write(columns)
+}
+
+// row 写入一行：CSV 写入 values，JSONL 写入 record 的 JSON
+func (e *exportWriter) row(record interface{}, values []string) error {
+	if e.csv != nil {
+		return e.csv.Write(values)
+	}
+	return e.encoder.Encode(record)
+}
+
+// flush 将缓冲的内容写出，w 支持 Flush 时（如 HTTP 响应）一并刷新
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if flusher, ok := e.w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// usernameResolver 按需批量加载并缓存用户名
+type usernameResolver struct {
+	userRepo domain.UserRepository
+	names    map[uint64]string
+}
+
+// newUsernameResolver 创建用户名解析器
+func newUsernameResolver(userRepo domain.UserRepository) *usernameResolver {
+	return &usernameResolver{userRepo: userRepo, names: map[uint64]string{0: ""}}
+}
+
+// load 加载尚未缓存的用户名，不存在的用户记为空
+func (r *usernameResolver) load(ctx context.Context, userIDs []uint64) error {
+	missing := make([]uint64, 0)
+	for _, id := range userIDs {
+		if _, ok := r.names[id]; !ok {
+			r.names[id] = ""
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	users, err := r.userRepo.GetByIDs(ctx, missing)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		r.names[user.ID] = user.Username
+	}
+	return nil
+}
+
+// name 获取已加载的用户名
+func (r *usernameResolver) name(userID uint64) string {
+	return r.names[userID]
+}
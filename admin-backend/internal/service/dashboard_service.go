@@ -2,14 +2,34 @@ package service
 
 import (
 	"context"
+	"sort"
+	"time"
 	"yflow/internal/domain"
 )
 
+// activityDateLayout 活动趋势的日期格式
+const activityDateLayout = "2006-01-02"
+
+// activityAddedOperations 计为新增翻译的变更历史操作类型
+var activityAddedOperations = map[string]bool{
+	domain.HistoryOperationCreate: true,
+}
+
+// activityOperations 活动趋势统计的变更历史操作类型，除新增外均计为修改
+var activityOperations = []string{
+	domain.HistoryOperationCreate,
+	domain.HistoryOperationUpdate,
+	domain.HistoryOperationMachineTranslate,
+	domain.HistoryOperationRollback,
+}
+
 // DashboardService 仪表板服务实现
 type DashboardService struct {
 	projectRepo     domain.ProjectRepository
 	languageRepo    domain.LanguageRepository
 	translationRepo domain.TranslationRepository
+	historyRepo     domain.TranslationHistoryRepository
+	userRepo        domain.UserRepository
 }
 
 // NewDashboardService 创建仪表板服务实例
@@ -17,11 +37,15 @@ func NewDashboardService(
 	projectRepo domain.ProjectRepository,
 	languageRepo domain.LanguageRepository,
 	translationRepo domain.TranslationRepository,
+	historyRepo domain.TranslationHistoryRepository,
+	userRepo domain.UserRepository,
 ) *DashboardService {
 	return &DashboardService{
 		projectRepo:     projectRepo,
 		languageRepo:    languageRepo,
 		translationRepo: translationRepo,
+		historyRepo:     historyRepo,
+		userRepo:        userRepo,
 	}
 }
 
@@ -30,7 +54,7 @@ func (s *DashboardService) GetStats(ctx context.Context) (*domain.DashboardStats
 	stats := &domain.DashboardStats{}
 
 	// 获取项目总数
-	_, totalProjects, err := s.projectRepo.GetAll(ctx, 1000000, 0, "") // 大数获取全部，无关键词过滤
+	_, totalProjects, err := s.projectRepo.GetAll(ctx, 1000000, 0, "", nil) // 大数获取全部，无关键词过滤
 	if err != nil {
 		return nil, err
 	}
@@ -52,5 +76,121 @@ func (s *DashboardService) GetStats(ctx context.Context) (*domain.DashboardStats
 	stats.TotalTranslations = totalTranslations
 	stats.TotalKeys = totalKeys
 
+	// 源语言译文修改后等待重新翻译的译文
+	outdated, err := s.translationRepo.CountByReviewStatus(ctx, domain.ReviewStatusOutdated)
+	if err != nil {
+		return nil, err
+	}
+	stats.OutdatedTranslations = int(outdated)
+
 	return stats, nil
 }
+
+// GetActivity 按变更历史统计最近 days 天（包括今天）新增和修改的翻译数
+// 计数在数据库中按日期、项目、操作人和操作类型聚合，再在内存中汇总为三个维度
+func (s *DashboardService) GetActivity(ctx context.Context, days int) (*domain.DashboardActivity, error) {
+	if days != 30 && days != 90 {
+		return nil, domain.ErrInvalidActivityDays
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := today.AddDate(0, 0, -(days - 1))
+
+	counts, err := s.historyRepo.CountDailyActivity(ctx, domain.HistoryQuery{From: from, To: today.AddDate(0, 0, 1)}, activityOperations)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := &domain.DashboardActivity{
+		Days:      days,
+		From:      from.Format(activityDateLayout),
+		To:        today.Format(activityDateLayout),
+		Daily:     make([]domain.DailyActivityTrend, days),
+		Operators: []domain.OperatorActivityTrend{},
+		Projects:  []domain.ProjectActivityTrend{},
+	}
+	dayIndex := make(map[string]int, days)
+	for i := range activity.Daily {
+		date := from.AddDate(0, 0, i).Format(activityDateLayout)
+		activity.Daily[i].Date = date
+		dayIndex[date] = i
+	}
+
+	operators := make(map[uint64]*domain.ActivityTrendCount)
+	projects := make(map[uint64]*domain.ActivityTrendCount)
+	add := func(totals map[uint64]*domain.ActivityTrendCount, id uint64, count *domain.DailyActivityCount) {
+		if totals[id] == nil {
+			totals[id] = &domain.ActivityTrendCount{}
+		}
+		addActivity(totals[id], count)
+	}
+	for _, count := range counts {
+		i, ok := dayIndex[count.Date]
+		if !ok {
+			continue
+		}
+		addActivity(&activity.Daily[i].ActivityTrendCount, count)
+		addActivity(&activity.Total, count)
+		add(operators, count.UserID, count)
+		add(projects, count.ProjectID, count)
+	}
+
+	userIDs := make([]uint64, 0, len(operators))
+	for userID := range operators {
+		userIDs = append(userIDs, userID)
+	}
+	users := newUsernameResolver(s.userRepo)
+	if err := users.load(ctx, userIDs); err != nil {
+		return nil, err
+	}
+	for userID, totals := range operators {
+		activity.Operators = append(activity.Operators, domain.OperatorActivityTrend{
+			UserID: userID, Username: users.name(userID), ActivityTrendCount: *totals,
+		})
+	}
+	sort.Slice(activity.Operators, func(i, j int) bool {
+		a, b := activity.Operators[i], activity.Operators[j]
+		if a.Added+a.Updated != b.Added+b.Updated {
+			return a.Added+a.Updated > b.Added+b.Updated
+		}
+		return a.UserID < b.UserID
+	})
+
+	projectIDs := make([]uint64, 0, len(projects))
+	for projectID := range projects {
+		projectIDs = append(projectIDs, projectID)
+	}
+	projectNames := make(map[uint64]string, len(projectIDs))
+	if len(projectIDs) > 0 {
+		found, err := s.projectRepo.GetByIDs(ctx, projectIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range found {
+			projectNames[project.ID] = project.Name
+		}
+	}
+	for projectID, totals := range projects {
+		activity.Projects = append(activity.Projects, domain.ProjectActivityTrend{
+			ProjectID: projectID, ProjectName: projectNames[projectID], ActivityTrendCount: *totals,
+		})
+	}
+	sort.Slice(activity.Projects, func(i, j int) bool {
+		a, b := activity.Projects[i], activity.Projects[j]
+		if a.Added+a.Updated != b.Added+b.Updated {
+			return a.Added+a.Updated > b.Added+b.Updated
+		}
+		return a.ProjectID < b.ProjectID
+	})
+
+	return activity, nil
+}
+
+// addActivity 按操作类型将计数累加到新增或修改
+func addActivity(totals *domain.ActivityTrendCount, count *domain.DailyActivityCount) {
+	if activityAddedOperations[count.Action] {
+		totals.Added += count.Count
+	} else {
+		totals.Updated += count.Count
+	}
+}
@@ -26,7 +26,7 @@ func NewCachedDashboardService(
 
 // GetStats 获取仪表板统计信息（使用缓存）
 func (s *CachedDashboardService) GetStats(ctx context.Context) (*domain.DashboardStats, error) {
-	cacheKey := s.cacheService.GetDashboardStatsKey()
+	cacheKey := domain.CacheKeys.DashboardStats()
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.mutexManager.GetMutex(cacheKey)
@@ -57,3 +57,38 @@ func (s *CachedDashboardService) GetStats(ctx context.Context) (*domain.Dashboar
 
 	return stats, nil
 }
+
+// GetActivity 获取仪表板活动趋势（使用缓存）
+// 缓存由 translation.updated 事件清除，过期时间较短，日期变化后及时按新的日期范围统计
+func (s *CachedDashboardService) GetActivity(ctx context.Context, days int) (*domain.DashboardActivity, error) {
+	cacheKey := domain.CacheKeys.DashboardActivity(days)
+
+	// 使用互斥锁防止缓存击穿
+	mutex := s.mutexManager.GetMutex(cacheKey)
+	mutex.Lock()
+	defer func() {
+		mutex.Unlock()
+		s.mutexManager.RemoveMutex(cacheKey) // 请求完成后移除锁
+	}()
+
+	// 尝试从缓存获取
+	var activity *domain.DashboardActivity
+	err := s.cacheService.GetJSONWithEmptyCheck(ctx, cacheKey, &activity)
+	if err == nil {
+		return activity, nil
+	}
+
+	// 缓存未命中，从数据库获取
+	activity, err = s.dashboardService.GetActivity(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新缓存，添加随机过期时间防止雪崩
+	expiration := s.cacheService.AddRandomExpiration(domain.ShortExpiration)
+	if err := s.cacheService.SetJSONWithEmptyCache(ctx, cacheKey, activity, expiration); err != nil {
+		// 缓存更新失败，但不影响返回结果
+	}
+
+	return activity, nil
+}
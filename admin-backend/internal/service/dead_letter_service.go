@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// DeadLetterService 发件箱死信管理服务
+// 超过最大投递次数的事件保留在发件箱中（状态为 failed），修复订阅方或事件后端的问题后由管理员重新投递
+type DeadLetterService struct {
+	repo   domain.OutboxRepository
+	logger *zap.Logger
+}
+
+// NewDeadLetterService 创建死信管理服务
+func NewDeadLetterService(repo domain.OutboxRepository, logger *zap.Logger) *DeadLetterService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &DeadLetterService{repo: repo, logger: logger}
+}
+
+// List 获取死信事件
+func (s *DeadLetterService) List(ctx context.Context, params domain.DeadLetterListParams) ([]*domain.OutboxEvent, int64, error) {
+	return s.repo.GetFailed(ctx, params.EventType, params.Limit, params.Offset)
+}
+
+// Requeue 将死信事件重置为待投递，投递器在下一次轮询时投递，并重新获得完整的重试次数
+func (s *DeadLetterService) Requeue(ctx context.Context, params domain.RequeueDeadLettersParams, userID uint64) (int64, error) {
+	ids := params.IDs
+	if params.All {
+		ids = nil
+	} else if len(ids) == 0 {
+		return 0, domain.ErrNoDeadLettersSelected
+	}
+
+	requeued, err := s.repo.Requeue(ctx, ids, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("Dead letter events requeued",
+		zap.Uint64("user_id", userID),
+		zap.Uint64s("ids", ids),
+		zap.Bool("all", params.All),
+		zap.Int64("requeued", requeued),
+	)
+	return requeued, nil
+}
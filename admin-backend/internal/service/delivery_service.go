@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+
+	"yflow/internal/domain"
+)
+
+// deliveryLocalePattern 分发接口的语言代码，长度与 release_translations.language_code 列一致
+var deliveryLocalePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,9}$`)
+
+// DeliveryService 公开分发服务实现
+// 只分发已发布的版本，不读取项目当前的翻译；发布版本不可修改，语言包按发布版本缓存
+type DeliveryService struct {
+	projectRepo  domain.ProjectRepository
+	releaseRepo  domain.ReleaseRepository
+	cacheService domain.CacheService
+}
+
+// NewDeliveryService 创建公开分发服务实例，cacheService 可以为 nil
+func NewDeliveryService(
+	projectRepo domain.ProjectRepository,
+	releaseRepo domain.ReleaseRepository,
+	cacheService domain.CacheService,
+) *DeliveryService {
+	return &DeliveryService{
+		projectRepo:  projectRepo,
+		releaseRepo:  releaseRepo,
+		cacheService: cacheService,
+	}
+}
+
+// GetBundle 获取开启公开分发的项目中一种语言的语言包
+// 项目按当前标识或旧标识查找，修改标识后已发布的应用仍能获取；json 类型的键输出解析后的值
+func (s *DeliveryService) GetBundle(ctx context.Context, projectSlug, locale, clientID string) (*domain.DeliveryBundle, error) {
+	if projectSlug == "" || !deliveryLocalePattern.MatchString(locale) {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	project, err := s.getProject(ctx, projectSlug)
+	if err != nil {
+		return nil, err
+	}
+	release, err := s.deliveredRelease(ctx, project.ID, clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrReleaseNotFound) {
+			return nil, domain.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	cacheKey := domain.CacheKeys.Project(project.ID).Delivery(release.ID, locale)
+	if s.cacheService != nil {
+		var cached domain.DeliveryBundle
+		if err := s.cacheService.GetJSON(ctx, cacheKey, &cached); err == nil && cached.ETag != "" {
+			return &cached, nil
+		}
+	}
+
+	translations, err := s.releaseRepo.GetLocaleTranslations(ctx, release.ID, locale)
+	if err != nil {
+		return nil, err
+	}
+	if len(translations) == 0 {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	document := make(map[string]interface{}, len(translations))
+	for _, translation := range translations {
+		document[translation.KeyName] = jsonExportValue(translation.Value, domain.KeyValueType{ValueType: translation.ValueType})
+	}
+	body, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(body)
+	bundle := &domain.DeliveryBundle{
+		ProjectID: project.ID,
+		Release:   release.Version,
+		Locale:    locale,
+		Body:      body,
+		ETag:      `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+
+	// 缓存失败不影响本次请求
+	if s.cacheService != nil {
+		_ = s.cacheService.SetJSON(ctx, cacheKey, bundle, domain.LongExpiration)
+	}
+	return bundle, nil
+}
+
+// deliveredRelease 选择分发给客户端的发布版本
+// 有进行中的灰度发布且客户端ID所在的桶小于灰度百分比时返回灰度版本，否则返回最新的全量版本
+func (s *DeliveryService) deliveredRelease(ctx context.Context, projectID uint64, clientID string) (*domain.Release, error) {
+	if clientID != "" {
+		canary, err := s.releaseRepo.GetActiveRollout(ctx, projectID)
+		switch {
+		case err == nil:
+			if rolloutBucket(canary.ID, clientID) < canary.RolloutPercentage {
+				return canary, nil
+			}
+		case !errors.Is(err, domain.ErrReleaseNotFound):
+			return nil, err
+		}
+	}
+	return s.releaseRepo.GetLatest(ctx, projectID)
+}
+
+// rolloutBucket 客户端在发布版本灰度中所在的桶（0-99）
+// 同一客户端在同一次灰度中的桶不变，提高百分比时已获取灰度版本的客户端保持不变；桶按发布版本打散，每次灰度的客户端不同
+func rolloutBucket(releaseID uint64, clientID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strconv.FormatUint(releaseID, 10) + ":" + clientID))
+	return int(h.Sum32() % 100)
+}
+
+// getProject 按当前标识或旧标识获取开启公开分发的项目
+func (s *DeliveryService) getProject(ctx context.Context, slug string) (*domain.Project, error) {
+	project, err := s.projectRepo.GetBySlug(ctx, slug)
+	if errors.Is(err, domain.ErrProjectNotFound) {
+		project, err = s.projectRepo.GetBySlugAlias(ctx, slug)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			return nil, domain.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	if !project.DeliveryEnabled {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	return project, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"yflow/internal/domain"
+)
+
+// 邮件模板中定义主题和正文的模板名称
+const (
+	emailSubjectTemplate = "subject"
+	emailBodyTemplate    = "body"
+)
+
+// defaultEmailTemplates 内置邮件模板，每个模板用 define 分别定义主题（subject）和正文（body）
+var defaultEmailTemplates = map[domain.EmailEvent]string{
+	domain.EmailEventInvitation: `{{define "subject"}}YFlow 邀请{{end}}
+{{define "body"}}您好，
+
+您被邀请加入 YFlow 翻译管理平台（角色：{{.Role}}）。
+{{with .Description}}
+{{.}}
+{{end}}
+请通过以下链接完成注册：
+{{.InvitationURL}}
+
+邀请码：{{.Code}}
+有效期至：{{formatTime "2006-01-02 15:04 MST" .ExpiresAt}}
+{{end}}`,
+	domain.EmailEventMemberAdded: `{{define "subject"}}YFlow 项目成员通知{{end}}
+{{define "body"}}您好，
+
+您已被添加为 YFlow 项目“{{.ProjectName}}”的成员（角色：{{.Role}}）。
+
+登录 YFlow 后即可在项目列表中看到该项目。
+{{end}}`,
+	domain.EmailEventPasswordReset: `{{define "subject"}}YFlow 重置密码{{end}}
+{{define "body"}}{{.Username}}，您好：
+
+我们收到了重置您 YFlow 账号密码的请求。请通过以下链接设置新密码：
+{{.ResetURL}}
+
+链接在 {{formatTime "2006-01-02 15:04 MST" .ExpiresAt}} 前有效，且只能使用一次。如果这不是您本人的操作，请忽略本邮件，您的密码不会改变。
+{{end}}`,
+	domain.EmailEventUntranslatedDigest: `{{define "subject"}}YFlow 待翻译摘要：{{.ProjectName}}（{{.Total}} 个键）{{end}}
+{{define "body"}}{{.Username}}，您好：
+
+项目“{{.ProjectName}}”目前有以下待翻译的键：
+{{range .Languages}}
+{{.Name}}（{{.Code}}）：{{.Pending}} 个
+{{- range .Keys}}
+  - {{.}}
+{{- end}}
+{{- if gt .Pending (len .Keys)}}
+  …
+{{- end}}
+{{end}}
+前往翻译：{{.ProjectURL}}
+{{end}}`,
+}
+
+// EmailTemplates 通知邮件模板
+type EmailTemplates struct {
+	templates map[domain.EmailEvent]*template.Template
+}
+
+// NewEmailTemplates 加载通知邮件模板
+// dir 不为空时从目录中读取 <事件类型>.tmpl 覆盖对应的内置模板，文件不存在的事件使用内置模板
+func NewEmailTemplates(dir string) (*EmailTemplates, error) {
+	templates := &EmailTemplates{templates: make(map[domain.EmailEvent]*template.Template, len(domain.EmailEvents))}
+	for _, event := range domain.EmailEvents {
+		text := defaultEmailTemplates[event]
+		if dir != "" {
+			content, err := os.ReadFile(filepath.Join(dir, string(event)+".tmpl"))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+			if err == nil {
+				text = string(content)
+			}
+		}
+
+		tmpl, err := parseEmailTemplate(event, text)
+		if err != nil {
+			return nil, err
+		}
+		templates.templates[event] = tmpl
+	}
+	return templates, nil
+}
+
+// parseEmailTemplate 解析邮件模板，要求同时定义 subject 和 body
+func parseEmailTemplate(event domain.EmailEvent, text string) (*template.Template, error) {
+	tmpl, err := template.New(string(event)).
+		Funcs(template.FuncMap{"formatTime": templateFormatTime, "join": templateJoin}).
+		Option("missingkey=error").
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s email template: %w", event, err)
+	}
+	for _, name := range []string{emailSubjectTemplate, emailBodyTemplate} {
+		if tmpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("%s email template must define %q", event, name)
+		}
+	}
+	return tmpl, nil
+}
+
+// Render 渲染事件的邮件主题和正文，主题中的换行替换为空格
+func (t *EmailTemplates) Render(event domain.EmailEvent, data interface{}) (string, string, error) {
+	tmpl, ok := t.templates[event]
+	if !ok {
+		return "", "", fmt.Errorf("unknown email event: %s", event)
+	}
+
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, emailSubjectTemplate, data); err != nil {
+		return "", "", fmt.Errorf("render %s email subject: %w", event, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, emailBodyTemplate, data); err != nil {
+		return "", "", fmt.Errorf("render %s email body: %w", event, err)
+	}
+	return strings.Join(strings.Fields(subject.String()), " "), strings.TrimLeft(body.String(), "\n"), nil
+}
+
+// EmailNotifier 按事件模板发送通知邮件
+type EmailNotifier struct {
+	mailer    domain.Mailer
+	templates *EmailTemplates
+}
+
+// NewEmailNotifier 创建通知邮件发送实例，templates 为 nil 时使用内置模板
+func NewEmailNotifier(mailer domain.Mailer, templates *EmailTemplates) *EmailNotifier {
+	if templates == nil {
+		// 内置模板在测试中保证可以解析
+		templates, _ = NewEmailTemplates("")
+	}
+	return &EmailNotifier{mailer: mailer, templates: templates}
+}
+
+// Enabled 是否已配置邮件服务
+func (n *EmailNotifier) Enabled() bool {
+	return n.mailer != nil && n.mailer.Enabled()
+}
+
+// Notify 按事件模板渲染邮件并发送
+func (n *EmailNotifier) Notify(ctx context.Context, to string, event domain.EmailEvent, data interface{}) error {
+	if !n.Enabled() {
+		return domain.ErrMailNotConfigured
+	}
+	subject, body, err := n.templates.Render(event, data)
+	if err != nil {
+		return err
+	}
+	return n.mailer.Send(ctx, to, subject, body)
+}
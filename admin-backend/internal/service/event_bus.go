@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// eventSubscription 事件订阅
+type eventSubscription struct {
+	name    string
+	handler domain.EventHandler
+}
+
+// InMemoryEventBus 进程内事件总线
+// 发布时同步调用订阅方，处理函数的错误和 panic 只记录日志，不影响发布方的写操作
+type InMemoryEventBus struct {
+	mu       sync.RWMutex
+	handlers map[domain.EventType][]eventSubscription
+	logger   *zap.Logger
+}
+
+// NewInMemoryEventBus 创建进程内事件总线
+func NewInMemoryEventBus(logger *zap.Logger) *InMemoryEventBus {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &InMemoryEventBus{
+		handlers: make(map[domain.EventType][]eventSubscription),
+		logger:   logger,
+	}
+}
+
+// Subscribe 订阅事件
+func (b *InMemoryEventBus) Subscribe(eventType domain.EventType, name string, handler domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], eventSubscription{name: name, handler: handler})
+}
+
+// Publish 发布事件并同步分发给订阅方
+func (b *InMemoryEventBus) Publish(ctx context.Context, event domain.Event) error {
+	b.dispatch(ctx, event)
+	return nil
+}
+
+// dispatch 依次调用事件的订阅方
+func (b *InMemoryEventBus) dispatch(ctx context.Context, event domain.Event) {
+	b.mu.RLock()
+	subscriptions := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, subscription := range subscriptions {
+		if err := b.invoke(ctx, subscription, event); err != nil {
+			b.logger.Error("Event handler failed",
+				zap.String("event_id", event.ID),
+				zap.String("event_type", string(event.Type)),
+				zap.String("subscriber", subscription.name),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// invoke 调用单个订阅方，panic 转换为错误
+func (b *InMemoryEventBus) invoke(ctx context.Context, subscription eventSubscription, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return subscription.handler(ctx, event)
+}
+
+// publishEvent 构造并发布事件，记录 context 中的当前用户为触发者；bus 为空时不发布
+// 应在写操作的事务中调用：使用事务发件箱时返回错误表示事件未能写入，调用方应回滚
+func publishEvent(ctx context.Context, bus domain.EventBus, eventType domain.EventType, projectID uint64, payload interface{}) error {
+	if bus == nil {
+		return nil
+	}
+	event, err := domain.NewEvent(eventType, projectID, payload)
+	if err != nil {
+		return err
+	}
+	event.ActorID = domain.ActorFromContext(ctx)
+	return bus.Publish(ctx, event)
+}
+
+// withinTransaction 在事务中执行 fn；transactor 为空时直接执行
+func withinTransaction(ctx context.Context, transactor domain.Transactor, fn func(ctx context.Context) error) error {
+	if transactor == nil {
+		return fn(ctx)
+	}
+	return transactor.WithinTransaction(ctx, fn)
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+const (
+	// redisEventField 消息中保存事件 JSON 的字段
+	redisEventField = "event"
+	// redisEventMaxLen 事件流保留的最大消息数（近似裁剪）
+	redisEventMaxLen = 10000
+	// redisEventBatch 每次读取的最大消息数
+	redisEventBatch = 50
+	// redisEventBlock 读取阻塞时间
+	redisEventBlock = 5 * time.Second
+)
+
+// RedisStreamEventBus 基于 Redis Streams 的事件总线
+// 事件写入 Redis 流，各实例以同一消费组读取：每个事件只由一个实例处理，
+// 实例重启后会先处理自己未确认的事件。订阅方异步执行，适合多实例部署。
+type RedisStreamEventBus struct {
+	client   *redis.Client
+	stream   string
+	group    string
+	consumer string
+	local    *InMemoryEventBus
+	logger   *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRedisStreamEventBus 创建 Redis Streams 事件总线，流名称带 Redis 键前缀
+func NewRedisStreamEventBus(client *repository.RedisClient, stream, group, consumer string, logger *zap.Logger) *RedisStreamEventBus {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &RedisStreamEventBus{
+		client:   client.GetClient(),
+		stream:   client.GetKey(stream),
+		group:    group,
+		consumer: consumer,
+		local:    NewInMemoryEventBus(logger),
+		logger:   logger,
+	}
+}
+
+// Subscribe 订阅事件
+func (b *RedisStreamEventBus) Subscribe(eventType domain.EventType, name string, handler domain.EventHandler) {
+	b.local.Subscribe(eventType, name, handler)
+}
+
+// Publish 将事件写入 Redis 流
+func (b *RedisStreamEventBus) Publish(ctx context.Context, event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	err = b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: b.stream,
+		MaxLen: redisEventMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{redisEventField: data},
+	}).Err()
+	if err != nil {
+		b.logger.Error("Failed to publish event",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", string(event.Type)),
+			zap.Error(err),
+		)
+	}
+	return err
+}
+
+// Start 创建消费组并开始消费事件
+func (b *RedisStreamEventBus) Start(ctx context.Context) error {
+	err := b.client.XGroupCreateMkStream(ctx, b.stream, b.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	consumeCtx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.consume(consumeCtx)
+	}()
+	return nil
+}
+
+// Stop 停止消费并等待正在处理的事件完成
+func (b *RedisStreamEventBus) Stop(ctx context.Context) error {
+	if b.cancel == nil {
+		return nil
+	}
+	b.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// consume 消费循环：先处理本实例未确认的事件（ID 为 0），再读取新事件（ID 为 >）
+func (b *RedisStreamEventBus) consume(ctx context.Context) {
+	startID := "0"
+	for ctx.Err() == nil {
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    b.group,
+			Consumer: b.consumer,
+			Streams:  []string{b.stream, startID},
+			Count:    redisEventBatch,
+			Block:    redisEventBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			b.logger.Error("Failed to read events", zap.Error(err))
+			b.sleep(ctx, time.Second)
+			continue
+		}
+
+		// 停止时已读取的事件仍处理完并确认
+		handleCtx := context.WithoutCancel(ctx)
+		received := 0
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				received++
+				b.handle(handleCtx, message)
+			}
+		}
+		if startID == "0" && received == 0 {
+			startID = ">"
+		}
+	}
+}
+
+// handle 分发单条消息并确认；处理函数的错误由本地总线记录，消息仍会确认
+func (b *RedisStreamEventBus) handle(ctx context.Context, message redis.XMessage) {
+	raw, _ := message.Values[redisEventField].(string)
+
+	var event domain.Event
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		b.logger.Error("Discarding malformed event", zap.String("message_id", message.ID), zap.Error(err))
+	} else {
+		b.local.dispatch(ctx, event)
+	}
+
+	if err := b.client.XAck(ctx, b.stream, b.group, message.ID).Err(); err != nil {
+		b.logger.Error("Failed to ack event", zap.String("message_id", message.ID), zap.Error(err))
+	}
+}
+
+// sleep 等待 d 或直到 ctx 取消
+func (b *RedisStreamEventBus) sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
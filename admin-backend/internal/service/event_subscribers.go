@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"yflow/internal/domain"
+)
+
+// RegisterCacheInvalidationSubscribers 注册跨服务的缓存失效订阅
+// 各带缓存服务只负责清除自己的缓存，仪表板统计和 HTTP 响应缓存等由事件驱动失效；
+// 不经过翻译服务的批量写操作（如按键名前缀批量操作）同样依赖此订阅清除翻译缓存
+func RegisterCacheInvalidationSubscribers(bus domain.EventBus, cacheService domain.CacheService) {
+	bus.Subscribe(domain.EventTranslationUpdated, "cache-invalidation", func(ctx context.Context, event domain.Event) error {
+		if err := cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(event.ProjectID).TranslationsPattern()); err != nil {
+			return err
+		}
+		if err := cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(event.ProjectID).MatrixPattern()); err != nil {
+			return err
+		}
+		if err := cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(event.ProjectID).StatsPattern()); err != nil {
+			return err
+		}
+		if err := cacheService.DeleteByPattern(ctx, domain.CacheKeys.ResponsePattern(domain.ProjectResponseScope(event.ProjectID))); err != nil {
+			return err
+		}
+		if err := cacheService.DeleteByPattern(ctx, domain.CacheKeys.DashboardActivityPattern()); err != nil {
+			return err
+		}
+		return cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
+	})
+
+	bus.Subscribe(domain.EventProjectCreated, "cache-invalidation", func(ctx context.Context, event domain.Event) error {
+		return cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
+	})
+}
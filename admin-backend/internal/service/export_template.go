@@ -0,0 +1,88 @@
+package service
+
+import (
+	"path"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// 导出文件命名模板支持的占位符
+const (
+	ExportPlaceholderLocale           = "{locale}"
+	ExportPlaceholderLocaleUnderscore = "{locale_underscore}" // 以下划线连接的语言代码，如 zh_CN
+	ExportPlaceholderNamespace        = "{namespace}"
+	ExportPlaceholderProject          = "{project}"
+	ExportPlaceholderExt              = "{ext}"
+)
+
+// DefaultExportFileTemplate 项目未配置模板时使用的默认文件命名模板
+const DefaultExportFileTemplate = "{locale}.{ext}"
+
+// DefaultExportNamespace 未划分命名空间的键所属的默认命名空间
+const DefaultExportNamespace = "messages"
+
+// ExportFileVars 渲染导出文件名所需的变量
+type ExportFileVars struct {
+	Locale    string
+	Namespace string
+	Project   string
+	Ext       string
+}
+
+// ValidateExportFileTemplate 校验导出文件命名模板
+// 模板必须包含 {locale} 或 {locale_underscore}，否则多语言文件会相互覆盖；同时禁止绝对路径和目录穿越
+func ValidateExportFileTemplate(template string) error {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return nil
+	}
+	if len(template) > 255 {
+		return domain.ErrInvalidExportTemplate
+	}
+	if !strings.Contains(template, ExportPlaceholderLocale) && !strings.Contains(template, ExportPlaceholderLocaleUnderscore) {
+		return domain.ErrInvalidExportTemplate
+	}
+	if strings.HasPrefix(template, "/") || strings.Contains(template, "\\") {
+		return domain.ErrInvalidExportTemplate
+	}
+	for _, segment := range strings.Split(template, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return domain.ErrInvalidExportTemplate
+		}
+	}
+	return nil
+}
+
+// RenderExportFileName 按模板渲染导出文件名
+// 空模板回退到 DefaultExportFileTemplate
+func RenderExportFileName(template string, vars ExportFileVars) string {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		template = DefaultExportFileTemplate
+	}
+
+	namespace := vars.Namespace
+	if namespace == "" {
+		namespace = DefaultExportNamespace
+	}
+
+	replacer := strings.NewReplacer(
+		ExportPlaceholderLocale, sanitizeExportPathSegment(vars.Locale),
+		ExportPlaceholderLocaleUnderscore, sanitizeExportPathSegment(strings.ReplaceAll(vars.Locale, "-", "_")),
+		ExportPlaceholderNamespace, sanitizeExportPathSegment(namespace),
+		ExportPlaceholderProject, sanitizeExportPathSegment(vars.Project),
+		ExportPlaceholderExt, sanitizeExportPathSegment(vars.Ext),
+	)
+
+	return path.Clean(replacer.Replace(template))
+}
+
+// sanitizeExportPathSegment 清理占位符的值，避免其引入额外的目录层级
+func sanitizeExportPathSegment(value string) string {
+	value = strings.ReplaceAll(value, "/", "_")
+	value = strings.ReplaceAll(value, "\\", "_")
+	if value == "." || value == ".." {
+		return "_"
+	}
+	return value
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"yflow/internal/domain"
+)
+
+const (
+	// maxFigmaLayers 一次同步的画框中最多包含的文本图层数量
+	maxFigmaLayers = 500
+	// maxFigmaValueKeys 一次最多查询的键数量
+	maxFigmaValueKeys = 500
+	// MaxFigmaScreenshotSize 画框截图的最大字节数
+	MaxFigmaScreenshotSize = 5 << 20
+)
+
+// figmaScreenshotTypes 允许的截图格式，与 Figma 的 exportAsync 支持的位图格式一致
+var figmaScreenshotTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+}
+
+// FigmaService Figma 插件服务实现
+// 插件把选中的文本图层同步为翻译键，画框截图作为翻译的视觉上下文，图层与键的对应关系用于查询设计稿引用了哪些键
+type FigmaService struct {
+	figmaRepo          domain.FigmaRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+	transactor         domain.Transactor
+}
+
+// NewFigmaService 创建 Figma 插件服务实例
+func NewFigmaService(
+	figmaRepo domain.FigmaRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	transactor domain.Transactor,
+) *FigmaService {
+	return &FigmaService{
+		figmaRepo:          figmaRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		translationService: translationService,
+		transactor:         transactor,
+	}
+}
+
+// SyncFrame 同步画框及其中引用翻译键的文本图层
+// 画框按 Figma 文件和节点ID识别，重复同步时替换全部图层；不存在的键以图层文本作为指定语言的翻译创建，
+// 已存在的键不修改翻译，图层文本与当前翻译不同的键在结果中列出
+func (s *FigmaService) SyncFrame(ctx context.Context, projectID uint64, params domain.FigmaFrameParams, userID uint64) (*domain.FigmaSyncResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	fileKey, nodeID := strings.TrimSpace(params.FileKey), strings.TrimSpace(params.NodeID)
+	if fileKey == "" || nodeID == "" {
+		return nil, invalidFigmaFrame("文件标识和画框节点ID不能为空")
+	}
+	if len(params.Layers) > maxFigmaLayers {
+		return nil, invalidFigmaFrame(fmt.Sprintf("一个画框最多同步 %d 个文本图层", maxFigmaLayers))
+	}
+
+	language, err := s.resolveLanguage(ctx, params.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同一个键可以出现在多个图层中，新建时使用第一个图层的文本
+	layers := make([]*domain.FigmaLayer, 0, len(params.Layers))
+	layerNodes := make(map[string]bool, len(params.Layers))
+	texts := make(map[string]string)
+	contexts := make(map[string]string)
+	var keyNames []string
+	for _, layer := range params.Layers {
+		layerNode, keyName := strings.TrimSpace(layer.NodeID), strings.TrimSpace(layer.KeyName)
+		if layerNode == "" || keyName == "" {
+			return nil, invalidFigmaFrame("图层的节点ID和键名不能为空")
+		}
+		if layerNodes[layerNode] {
+			return nil, invalidFigmaFrame("图层重复：" + layerNode)
+		}
+		layerNodes[layerNode] = true
+
+		layers = append(layers, &domain.FigmaLayer{
+			NodeID:    layerNode,
+			ProjectID: projectID,
+			KeyName:   keyName,
+			Name:      truncateRunes(strings.TrimSpace(layer.Name), 255),
+			Text:      layer.Text,
+		})
+		if _, ok := texts[keyName]; !ok {
+			texts[keyName] = layer.Text
+			contexts[keyName] = truncateRunes(strings.TrimSpace(layer.Context), 500)
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	sort.Strings(keyNames)
+
+	existingNames, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(existingNames))
+	for _, name := range existingNames {
+		existing[name] = true
+	}
+	currentValues, err := s.translationRepo.GetKeyValues(ctx, projectID, language.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.FigmaSyncResult{
+		CreatedKeys:  []string{},
+		ExistingKeys: []string{},
+		ChangedKeys:  []string{},
+	}
+	var inputs []domain.TranslationInput
+	for _, keyName := range keyNames {
+		if existing[keyName] {
+			result.ExistingKeys = append(result.ExistingKeys, keyName)
+			if currentValues[keyName] != strings.TrimSpace(texts[keyName]) {
+				result.ChangedKeys = append(result.ChangedKeys, keyName)
+			}
+			continue
+		}
+		if strings.TrimSpace(texts[keyName]) == "" {
+			return nil, invalidFigmaFrame("新建的键没有图层文本：" + keyName)
+		}
+		result.CreatedKeys = append(result.CreatedKeys, keyName)
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  projectID,
+			LanguageID: language.ID,
+			KeyName:    keyName,
+			Context:    contexts[keyName],
+			Value:      texts[keyName],
+		})
+	}
+
+	var frameID uint64
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		frame, err := s.figmaRepo.GetFrameByNode(ctx, projectID, fileKey, nodeID)
+		switch {
+		case err == domain.ErrFigmaFrameNotFound:
+			frame = &domain.FigmaFrame{
+				ProjectID: projectID,
+				FileKey:   fileKey,
+				NodeID:    nodeID,
+				FileName:  truncateRunes(strings.TrimSpace(params.FileName), 255),
+				Name:      truncateRunes(strings.TrimSpace(params.Name), 255),
+				CreatedBy: userID,
+				UpdatedBy: userID,
+			}
+			if err := s.figmaRepo.CreateFrame(ctx, frame); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			frame.FileName = truncateRunes(strings.TrimSpace(params.FileName), 255)
+			frame.Name = truncateRunes(strings.TrimSpace(params.Name), 255)
+			frame.UpdatedBy = userID
+			if err := s.figmaRepo.UpdateFrame(ctx, frame); err != nil {
+				return err
+			}
+		}
+		frameID = frame.ID
+
+		for _, layer := range layers {
+			layer.FrameID = frame.ID
+		}
+		if err := s.figmaRepo.ReplaceLayers(ctx, frame.ID, layers); err != nil {
+			return err
+		}
+		return s.translationService.UpsertBatch(ctx, inputs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Frame, err = s.figmaRepo.GetFrameByID(ctx, frameID)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UploadScreenshot 上传画框截图，替换原有的截图
+func (s *FigmaService) UploadScreenshot(ctx context.Context, projectID, frameID uint64, data []byte, userID uint64) (*domain.FigmaFrame, error) {
+	if _, err := s.getFrame(ctx, projectID, frameID); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, invalidFigmaScreenshot("截图内容为空")
+	}
+	if len(data) > MaxFigmaScreenshotSize {
+		return nil, invalidFigmaScreenshot(fmt.Sprintf("截图不能超过 %d MB", MaxFigmaScreenshotSize>>20))
+	}
+	mediaType := http.DetectContentType(data)
+	if !figmaScreenshotTypes[mediaType] {
+		return nil, invalidFigmaScreenshot("只支持 PNG 和 JPEG 格式的截图")
+	}
+
+	if err := s.figmaRepo.UpdateScreenshot(ctx, frameID, data, mediaType, userID); err != nil {
+		return nil, err
+	}
+	return s.figmaRepo.GetFrameByID(ctx, frameID)
+}
+
+// GetScreenshot 获取画框截图
+func (s *FigmaService) GetScreenshot(ctx context.Context, projectID, frameID uint64) ([]byte, string, error) {
+	if _, err := s.getFrame(ctx, projectID, frameID); err != nil {
+		return nil, "", err
+	}
+	data, mediaType, err := s.figmaRepo.GetScreenshot(ctx, frameID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) == 0 {
+		return nil, "", domain.ErrFigmaScreenshotMissing
+	}
+	return data, mediaType, nil
+}
+
+// DeleteFrame 删除画框及其图层，已创建的翻译键保留
+func (s *FigmaService) DeleteFrame(ctx context.Context, projectID, frameID uint64) error {
+	if _, err := s.getFrame(ctx, projectID, frameID); err != nil {
+		return err
+	}
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		return s.figmaRepo.DeleteFrame(ctx, frameID)
+	})
+}
+
+// GetValues 获取指定键在各启用语言中的当前翻译，不存在的键不返回
+func (s *FigmaService) GetValues(ctx context.Context, projectID uint64, keyNames []string) (map[string]map[string]string, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if len(keyNames) > maxFigmaValueKeys {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidInput.Code,
+			domain.ErrInvalidInput.Message, fmt.Sprintf("一次最多查询 %d 个键", maxFigmaValueKeys))
+	}
+
+	values, err := s.translationRepo.GetValuesByStatus(ctx, projectID, exportStatuses[""])
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]string, len(keyNames))
+	for _, keyName := range keyNames {
+		if langs, ok := values[strings.TrimSpace(keyName)]; ok {
+			result[strings.TrimSpace(keyName)] = langs
+		}
+	}
+	return result, nil
+}
+
+// GetReferences 查询引用翻译键的画框及其图层
+func (s *FigmaService) GetReferences(ctx context.Context, projectID uint64, fileKey, keyName string) ([]*domain.FigmaFrame, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.figmaRepo.FindFrames(ctx, projectID, strings.TrimSpace(fileKey), strings.TrimSpace(keyName))
+}
+
+// getFrame 获取画框并检查是否属于项目
+func (s *FigmaService) getFrame(ctx context.Context, projectID, frameID uint64) (*domain.FigmaFrame, error) {
+	frame, err := s.figmaRepo.GetFrameByID(ctx, frameID)
+	if err != nil {
+		return nil, err
+	}
+	if frame.ProjectID != projectID {
+		return nil, domain.ErrFigmaFrameNotFound
+	}
+	return frame, nil
+}
+
+// resolveLanguage 查找图层文本所属的语言，未指定时使用默认语言
+func (s *FigmaService) resolveLanguage(ctx context.Context, code string) (*domain.Language, error) {
+	if code = strings.TrimSpace(code); code != "" {
+		language, err := s.languageRepo.GetByCode(ctx, code)
+		if err != nil {
+			return nil, invalidFigmaFrame("语言不存在：" + code)
+		}
+		return language, nil
+	}
+	language, err := s.languageRepo.GetDefault(ctx)
+	if err != nil {
+		if err == domain.ErrLanguageNotFound {
+			return nil, domain.ErrFigmaSourceLanguage
+		}
+		return nil, err
+	}
+	return language, nil
+}
+
+// invalidFigmaFrame 带错误详情的无效画框错误
+func invalidFigmaFrame(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidFigmaFrame.Code, domain.ErrInvalidFigmaFrame.Message, details)
+}
+
+// invalidFigmaScreenshot 带错误详情的无效截图错误
+func invalidFigmaScreenshot(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidFigmaScreenshot.Code, domain.ErrInvalidFigmaScreenshot.Message, details)
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// gettextPluralRule 一种语言的 gettext 复数规则
+// categories 按 msgstr 的下标顺序列出对应的 CLDR 复数类别
+type gettextPluralRule struct {
+	forms      string
+	categories []string
+}
+
+var (
+	pluralRuleSingle  = gettextPluralRule{"nplurals=1; plural=0;", []string{"other"}}
+	pluralRuleEnglish = gettextPluralRule{"nplurals=2; plural=(n != 1);", []string{"one", "other"}}
+	pluralRuleFrench  = gettextPluralRule{"nplurals=2; plural=(n > 1);", []string{"one", "other"}}
+	pluralRuleRussian = gettextPluralRule{
+		"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		[]string{"one", "few", "many"},
+	}
+	pluralRuleSerbian = gettextPluralRule{
+		"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		[]string{"one", "few", "other"},
+	}
+	pluralRulePolish = gettextPluralRule{
+		"nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);",
+		[]string{"one", "few", "many"},
+	}
+	pluralRuleCzech  = gettextPluralRule{"nplurals=3; plural=(n==1 ? 0 : n>=2 && n<=4 ? 1 : 2);", []string{"one", "few", "other"}}
+	pluralRuleArabic = gettextPluralRule{
+		"nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);",
+		[]string{"zero", "one", "two", "few", "many", "other"},
+	}
+)
+
+// gettextPluralRules 按语言（不含地区）查找复数规则，未列出的语言使用 pluralRuleEnglish
+var gettextPluralRules = map[string]gettextPluralRule{
+	"ja": pluralRuleSingle, "zh": pluralRuleSingle, "ko": pluralRuleSingle, "vi": pluralRuleSingle,
+	"th": pluralRuleSingle, "id": pluralRuleSingle, "ms": pluralRuleSingle,
+	"fr": pluralRuleFrench, "tr": pluralRuleFrench,
+	"ru": pluralRuleRussian, "uk": pluralRuleRussian, "be": pluralRuleRussian,
+	"sr": pluralRuleSerbian, "hr": pluralRuleSerbian, "bs": pluralRuleSerbian,
+	"pl": pluralRulePolish,
+	"cs": pluralRuleCzech, "sk": pluralRuleCzech,
+	"ar": pluralRuleArabic,
+}
+
+// gettextPluralCategories 键名最后一段为这些 CLDR 复数类别时（如 items.one、items_other）视为同一复数词条的不同形式
+var gettextPluralCategories = map[string]bool{
+	"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true,
+}
+
+// POEntry PO 文件中的一个词条
+type POEntry struct {
+	Comments []string // 提取注释（#.），导出时为对应的键名
+	Context  string   // msgctxt
+	ID       string   // msgid
+	IDPlural string   // msgid_plural，不为空时 Str 按复数规则的顺序列出各形式
+	Str      []string // msgstr，POT 模板中为空字符串
+}
+
+// POFile PO 或 POT 文件，Language 为空时为 POT 模板
+type POFile struct {
+	Project  string
+	Language string
+	Entries  []POEntry
+}
+
+// BuildPOFile 以源语言的翻译为 msgid、键的上下文说明为 msgctxt 生成 PO 文件，target 为空时生成 POT 模板
+// 源语言没有翻译的键不导出；源语言同时有 one 和 other 形式的一组键（如 items.one、items.other）导出为一个复数词条，
+// 源语言的值为只有一个 plural 参数的 ICU 消息的键也导出为复数词条；
+// msgctxt 和 msgid 都相同的键在 gettext 中无法区分，只保留键名排序在前的一个的译文，其余键名写入注释
+func BuildPOFile(project string, values map[string]map[string]string, contexts map[string]string, source, target string) *POFile {
+	type pluralGroup struct {
+		keys    map[string]string // 复数类别 -> 键名
+		emitted bool
+	}
+	groups := make(map[string]*pluralGroup)
+	keys := make([]string, 0, len(values))
+	for key, langs := range values {
+		// 目标语言特有的复数形式（如俄语的 few）源语言中没有，也归入复数词条
+		if prefix, category, ok := splitPluralKey(key); ok {
+			if groups[prefix] == nil {
+				groups[prefix] = &pluralGroup{keys: make(map[string]string)}
+			}
+			groups[prefix].keys[category] = key
+		}
+		if langs[source] != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	rule := gettextPluralRuleFor(target)
+	file := &POFile{Project: project, Language: target}
+	seen := make(map[[2]string]int)
+	for _, key := range keys {
+		entry := POEntry{Comments: []string{key}, Context: contexts[key], ID: values[key][source]}
+
+		if prefix, _, ok := splitPluralKey(key); ok {
+			group := groups[prefix]
+			if values[group.keys["one"]][source] != "" && values[group.keys["other"]][source] != "" {
+				if group.emitted {
+					continue
+				}
+				group.emitted = true
+				entry = buildPluralPOEntry(group.keys, values, contexts, source, target, rule)
+			}
+		} else if forms, ok := icuPluralForms(values[key][source]); ok && forms["other"] != "" {
+			entry = buildICUPluralPOEntry(key, contexts[key], forms, values[key][target], target, rule)
+		}
+		if entry.IDPlural == "" {
+			entry.Str = []string{""}
+			if target != "" {
+				entry.Str[0] = values[key][target]
+			}
+		}
+
+		id := [2]string{entry.Context, entry.ID}
+		if index, ok := seen[id]; ok {
+			file.Entries[index].Comments = append(file.Entries[index].Comments, entry.Comments...)
+			continue
+		}
+		seen[id] = len(file.Entries)
+		file.Entries = append(file.Entries, entry)
+	}
+	return file
+}
+
+// buildPluralPOEntry 生成复数词条，目标语言缺少的复数形式使用 other 形式的译文
+func buildPluralPOEntry(keys map[string]string, values map[string]map[string]string, contexts map[string]string, source, target string, rule gettextPluralRule) POEntry {
+	comments := make([]string, 0, len(keys))
+	for _, key := range keys {
+		comments = append(comments, key)
+	}
+	sort.Strings(comments)
+
+	context := contexts[keys["other"]]
+	if context == "" {
+		context = contexts[keys["one"]]
+	}
+	entry := POEntry{
+		Comments: []string{strings.Join(comments, ", ")},
+		Context:  context,
+		ID:       values[keys["one"]][source],
+		IDPlural: values[keys["other"]][source],
+	}
+
+	if target == "" {
+		// POT 模板不知道目标语言的复数规则，按 gettext 的惯例输出两个空的形式
+		entry.Str = []string{"", ""}
+		return entry
+	}
+	fallback := values[keys["other"]][target]
+	for _, category := range rule.categories {
+		value := fallback
+		if key, ok := keys[category]; ok && values[key][target] != "" {
+			value = values[key][target]
+		}
+		entry.Str = append(entry.Str, value)
+	}
+	return entry
+}
+
+// buildICUPluralPOEntry 将源语言中只有一个 plural 参数的 ICU 消息导出为复数词条
+// msgid 和 msgid_plural 为源语言的 one（没有时为 other）和 other 形式，译文按目标语言的复数规则展开，
+// 目标语言缺少的形式使用 other 形式；译文不是这种 ICU 消息时各形式为空
+func buildICUPluralPOEntry(key, context string, source map[string]string, value, target string, rule gettextPluralRule) POEntry {
+	entry := POEntry{Comments: []string{key}, Context: context, ID: source["one"], IDPlural: source["other"]}
+	if entry.ID == "" {
+		entry.ID = source["other"]
+	}
+	if target == "" {
+		entry.Str = []string{"", ""}
+		return entry
+	}
+
+	forms, _ := icuPluralForms(value)
+	for _, category := range rule.categories {
+		form := forms[category]
+		if form == "" {
+			form = forms["other"]
+		}
+		entry.Str = append(entry.Str, form)
+	}
+	return entry
+}
+
+// splitPluralKey 拆分以复数类别结尾的键名，返回包含分隔符的前缀和复数类别
+func splitPluralKey(key string) (string, string, bool) {
+	index := strings.LastIndexAny(key, "._")
+	if index <= 0 || !gettextPluralCategories[key[index+1:]] {
+		return "", "", false
+	}
+	return key[:index+1], key[index+1:], true
+}
+
+// gettextPluralRuleFor 查找语言的复数规则，巴西葡萄牙语与法语相同
+func gettextPluralRuleFor(language string) gettextPluralRule {
+	code := strings.ToLower(gettextLanguageCode(language))
+	if code == "pt_br" {
+		return pluralRuleFrench
+	}
+	if index := strings.Index(code, "_"); index > 0 {
+		code = code[:index]
+	}
+	if rule, ok := gettextPluralRules[code]; ok {
+		return rule
+	}
+	return pluralRuleEnglish
+}
+
+// gettextLanguageCode 将语言代码转换为 gettext 使用的 ll_CC 格式
+func gettextLanguageCode(code string) string {
+	return strings.ReplaceAll(code, "-", "_")
+}
+
+// MarshalPO 序列化 PO 或 POT 文件
+func MarshalPO(file *POFile) []byte {
+	var buf bytes.Buffer
+
+	header := "Project-Id-Version: " + file.Project + "\n"
+	pluralForms := "nplurals=INTEGER; plural=EXPRESSION;"
+	if file.Language != "" {
+		header += "Language: " + gettextLanguageCode(file.Language) + "\n"
+		pluralForms = gettextPluralRuleFor(file.Language).forms
+	}
+	header += "MIME-Version: 1.0\n" +
+		"Content-Type: text/plain; charset=UTF-8\n" +
+		"Content-Transfer-Encoding: 8bit\n" +
+		"Plural-Forms: " + pluralForms + "\n"
+	writePOString(&buf, "msgid", "")
+	writePOString(&buf, "msgstr", header)
+
+	for _, entry := range file.Entries {
+		buf.WriteString("\n")
+		for _, comment := range entry.Comments {
+			buf.WriteString("#. " + strings.ReplaceAll(comment, "\n", " ") + "\n")
+		}
+		if entry.Context != "" {
+			writePOString(&buf, "msgctxt", entry.Context)
+		}
+		writePOString(&buf, "msgid", entry.ID)
+		if entry.IDPlural == "" {
+			writePOString(&buf, "msgstr", entry.Str[0])
+			continue
+		}
+		writePOString(&buf, "msgid_plural", entry.IDPlural)
+		for i, value := range entry.Str {
+			writePOString(&buf, "msgstr["+strconv.Itoa(i)+"]", value)
+		}
+	}
+	return buf.Bytes()
+}
+
+// writePOString 写入一个关键字及其字符串，多行文本按 gettext 的惯例从空字符串开始逐行书写
+func writePOString(buf *bytes.Buffer, keyword, value string) {
+	buf.WriteString(keyword + " ")
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 1 {
+		buf.WriteString("\"\"\n")
+	}
+	for _, line := range lines {
+		buf.WriteString("\"" + escapePOString(line) + "\"\n")
+	}
+}
+
+var poEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func escapePOString(value string) string {
+	return poEscaper.Replace(value)
+}
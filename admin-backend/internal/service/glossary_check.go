@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"unicode"
+
+	"yflow/internal/domain"
+)
+
+// CheckGlossary 按术语表检查一条译文
+// source 为默认语言中同一键的文本，为空时（如检查默认语言自身）只检查禁用译文。
+// 原文包含术语而译文没有使用规定译文时报告 glossary_mismatch；译文包含该语言的禁用译文时报告 forbidden_term，
+// 术语有规定译文时 suggestion 为将禁用译文替换为规定译文后的译文。
+// 字母和数字开头或结尾的术语按整个单词匹配，中文、日文、韩文按字符匹配；术语不区分大小写时按字符忽略大小写
+func CheckGlossary(terms []*domain.GlossaryTerm, languageID uint64, value, source string) []domain.QAIssue {
+	if value == "" {
+		return nil
+	}
+
+	var issues []domain.QAIssue
+	for _, term := range terms {
+		var rule *domain.GlossaryTranslation
+		for i := range term.Translations {
+			if term.Translations[i].LanguageID == languageID {
+				rule = &term.Translations[i]
+				break
+			}
+		}
+		if rule == nil {
+			continue
+		}
+
+		if source != "" && rule.Translation != "" &&
+			len(findGlossaryTerm(source, term.Term, term.CaseSensitive)) > 0 &&
+			len(findGlossaryTerm(value, rule.Translation, term.CaseSensitive)) == 0 {
+			issues = append(issues, domain.QAIssue{
+				Type:    domain.QAIssueGlossaryMismatch,
+				Message: fmt.Sprintf("原文包含术语“%s”，译文应使用“%s”", term.Term, rule.Translation),
+			})
+		}
+
+		for _, forbidden := range ParseGlossaryForbidden(rule.Forbidden) {
+			matches := findGlossaryTerm(value, forbidden, term.CaseSensitive)
+			if len(matches) == 0 {
+				continue
+			}
+			issue := domain.QAIssue{
+				Type:    domain.QAIssueForbiddenTerm,
+				Message: fmt.Sprintf("译文使用了术语“%s”禁用的译文“%s”", term.Term, forbidden),
+			}
+			if rule.Translation != "" {
+				issue.Suggestion = replaceGlossaryMatches(value, matches, rule.Translation)
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// findGlossaryTerm 返回术语在文本中不重叠的出现位置（字符下标，左闭右开）
+func findGlossaryTerm(text, term string, caseSensitive bool) [][2]int {
+	textRunes, termRunes := []rune(text), []rune(term)
+	if len(termRunes) == 0 || len(termRunes) > len(textRunes) {
+		return nil
+	}
+	equal := func(a, b rune) bool {
+		return a == b || (!caseSensitive && unicode.ToLower(a) == unicode.ToLower(b))
+	}
+	checkStart := isGlossaryWordRune(termRunes[0])
+	checkEnd := isGlossaryWordRune(termRunes[len(termRunes)-1])
+
+	var matches [][2]int
+	for i := 0; i+len(termRunes) <= len(textRunes); {
+		end := i + len(termRunes)
+		matched := true
+		for j, r := range termRunes {
+			if !equal(textRunes[i+j], r) {
+				matched = false
+				break
+			}
+		}
+		if matched && checkStart && i > 0 && isGlossaryWordRune(textRunes[i-1]) {
+			matched = false
+		}
+		if matched && checkEnd && end < len(textRunes) && isGlossaryWordRune(textRunes[end]) {
+			matched = false
+		}
+		if matched {
+			matches = append(matches, [2]int{i, end})
+			i = end
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+// replaceGlossaryMatches 将文本中匹配的位置替换为 replacement
+func replaceGlossaryMatches(text string, matches [][2]int, replacement string) string {
+	runes := []rune(text)
+	result := make([]rune, 0, len(runes))
+	last := 0
+	for _, match := range matches {
+		result = append(result, runes[last:match[0]]...)
+		result = append(result, []rune(replacement)...)
+		last = match[1]
+	}
+	return string(append(result, runes[last:]...))
+}
+
+// isGlossaryWordRune 是否为需要按单词边界匹配的字符：字母和数字，中文、日文、韩文除外
+func isGlossaryWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsNumber(r)) && !containsCJK([]rune{r})
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// maxGlossaryTextLength 术语、规定译文和禁用译文的最大字符数
+	maxGlossaryTextLength = 255
+	// maxGlossaryDefinitionLength 术语说明的最大字符数
+	maxGlossaryDefinitionLength = 2000
+	// maxGlossaryForbidden 每种语言最多设置的禁用译文数量
+	maxGlossaryForbidden = 20
+)
+
+// GlossaryService 术语表服务实现
+type GlossaryService struct {
+	glossaryRepo domain.GlossaryRepository
+	projectRepo  domain.ProjectRepository
+	languageRepo domain.LanguageRepository
+	transactor   domain.Transactor
+}
+
+// NewGlossaryService 创建术语表服务实例
+func NewGlossaryService(
+	glossaryRepo domain.GlossaryRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	transactor domain.Transactor,
+) *GlossaryService {
+	return &GlossaryService{
+		glossaryRepo: glossaryRepo,
+		projectRepo:  projectRepo,
+		languageRepo: languageRepo,
+		transactor:   transactor,
+	}
+}
+
+// Create 创建术语，同一项目中的术语不区分大小写不能重复
+func (s *GlossaryService) Create(ctx context.Context, projectID uint64, params domain.GlossaryTermParams, userID uint64) (*domain.GlossaryTerm, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	term := &domain.GlossaryTerm{
+		ProjectID: projectID,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	}
+	translations, err := s.applyParams(ctx, term, params)
+	if err != nil {
+		return nil, err
+	}
+	if translations == nil {
+		translations = []domain.GlossaryTranslation{}
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.glossaryRepo.Create(ctx, term); err != nil {
+			return err
+		}
+		return s.glossaryRepo.ReplaceTranslations(ctx, term.ID, translations)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.glossaryRepo.GetByID(ctx, term.ID)
+}
+
+// GetByID 获取术语
+func (s *GlossaryService) GetByID(ctx context.Context, id uint64) (*domain.GlossaryTerm, error) {
+	return s.glossaryRepo.GetByID(ctx, id)
+}
+
+// GetByProjectID 获取项目的术语表
+func (s *GlossaryService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.GlossaryTerm, error) {
+	return s.glossaryRepo.GetByProjectID(ctx, projectID)
+}
+
+// Update 更新术语，空字段保持不变；提供译文时替换全部语言的译文
+func (s *GlossaryService) Update(ctx context.Context, id uint64, params domain.GlossaryTermParams, userID uint64) (*domain.GlossaryTerm, error) {
+	term, err := s.glossaryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.applyParams(ctx, term, params)
+	if err != nil {
+		return nil, err
+	}
+	term.UpdatedBy = userID
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.glossaryRepo.Update(ctx, term); err != nil {
+			return err
+		}
+		if translations == nil {
+			return nil
+		}
+		return s.glossaryRepo.ReplaceTranslations(ctx, term.ID, translations)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.glossaryRepo.GetByID(ctx, term.ID)
+}
+
+// Delete 删除术语
+func (s *GlossaryService) Delete(ctx context.Context, id uint64) error {
+	if _, err := s.glossaryRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.glossaryRepo.Delete(ctx, id)
+}
+
+// applyParams 将参数合并到术语并校验结果，返回需要写入的译文；未提供译文时返回 nil
+func (s *GlossaryService) applyParams(ctx context.Context, term *domain.GlossaryTerm, params domain.GlossaryTermParams) ([]domain.GlossaryTranslation, error) {
+	if text := strings.TrimSpace(params.Term); text != "" && text != term.Term {
+		if utf8.RuneCountInString(text) > maxGlossaryTextLength {
+			return nil, invalidGlossaryTerm(fmt.Sprintf("术语不能超过 %d 个字符", maxGlossaryTextLength))
+		}
+		existing, err := s.glossaryRepo.GetByTerm(ctx, term.ProjectID, text)
+		if err != nil && !errors.Is(err, domain.ErrGlossaryTermNotFound) {
+			return nil, err
+		}
+		if existing != nil && existing.ID != term.ID {
+			return nil, domain.ErrGlossaryTermExists
+		}
+		term.Term = text
+	}
+	if term.Term == "" {
+		return nil, invalidGlossaryTerm("术语不能为空")
+	}
+	if params.Definition != nil {
+		definition := strings.TrimSpace(*params.Definition)
+		if utf8.RuneCountInString(definition) > maxGlossaryDefinitionLength {
+			return nil, invalidGlossaryTerm(fmt.Sprintf("说明不能超过 %d 个字符", maxGlossaryDefinitionLength))
+		}
+		term.Definition = definition
+	}
+	if params.CaseSensitive != nil {
+		term.CaseSensitive = *params.CaseSensitive
+	}
+	if params.Translations == nil {
+		return nil, nil
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageIDs := make(map[string]uint64, len(languages))
+	for _, language := range languages {
+		languageIDs[language.Code] = language.ID
+	}
+
+	translations := make([]domain.GlossaryTranslation, 0, len(params.Translations))
+	used := make(map[uint64]bool, len(params.Translations))
+	for _, param := range params.Translations {
+		code := strings.TrimSpace(param.LanguageCode)
+		languageID, ok := languageIDs[code]
+		if !ok {
+			return nil, invalidGlossaryTerm("语言不存在：" + param.LanguageCode)
+		}
+		if used[languageID] {
+			return nil, invalidGlossaryTerm("语言重复：" + code)
+		}
+		used[languageID] = true
+
+		translation := strings.TrimSpace(param.Translation)
+		if utf8.RuneCountInString(translation) > maxGlossaryTextLength {
+			return nil, invalidGlossaryTerm(fmt.Sprintf("%s 的规定译文不能超过 %d 个字符", code, maxGlossaryTextLength))
+		}
+		forbidden, err := normalizeGlossaryForbidden(code, translation, param.Forbidden, term.CaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		if translation == "" && len(forbidden) == 0 {
+			return nil, invalidGlossaryTerm(code + " 的规定译文和禁用译文不能都为空")
+		}
+
+		data, err := json.Marshal(forbidden)
+		if err != nil {
+			return nil, domain.ErrInvalidInput
+		}
+		translations = append(translations, domain.GlossaryTranslation{
+			LanguageID:  languageID,
+			Translation: translation,
+			Forbidden:   string(data),
+		})
+	}
+	return translations, nil
+}
+
+// normalizeGlossaryForbidden 去掉禁用译文的首尾空白和重复项，禁用译文不能与规定译文相同
+func normalizeGlossaryForbidden(code, translation string, values []string, caseSensitive bool) ([]string, error) {
+	fold := func(value string) string {
+		if caseSensitive {
+			return value
+		}
+		return strings.ToLower(value)
+	}
+
+	forbidden := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[fold(value)] {
+			continue
+		}
+		if utf8.RuneCountInString(value) > maxGlossaryTextLength {
+			return nil, invalidGlossaryTerm(fmt.Sprintf("%s 的禁用译文不能超过 %d 个字符", code, maxGlossaryTextLength))
+		}
+		if translation != "" && fold(value) == fold(translation) {
+			return nil, invalidGlossaryTerm(code + " 的禁用译文不能与规定译文相同：" + value)
+		}
+		seen[fold(value)] = true
+		forbidden = append(forbidden, value)
+	}
+	if len(forbidden) > maxGlossaryForbidden {
+		return nil, invalidGlossaryTerm(fmt.Sprintf("%s 的禁用译文不能超过 %d 个", code, maxGlossaryForbidden))
+	}
+	return forbidden, nil
+}
+
+// ParseGlossaryForbidden 解析存储的禁用译文，格式错误时视为未设置
+func ParseGlossaryForbidden(raw string) []string {
+	forbidden := []string{}
+	if raw == "" {
+		return forbidden
+	}
+	if err := json.Unmarshal([]byte(raw), &forbidden); err != nil {
+		return []string{}
+	}
+	return forbidden
+}
+
+// invalidGlossaryTerm 返回带有具体原因的术语校验错误
+func invalidGlossaryTerm(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidGlossaryTerm.Code, domain.ErrInvalidGlossaryTerm.Message, details)
+}
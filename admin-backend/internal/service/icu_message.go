@@ -0,0 +1,673 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+// icuComplexArgPattern 包含 plural、selectordinal 或 select 参数的文本才按 ICU 消息校验，
+// 其余文本中的花括号可能只是普通字符
+var icuComplexArgPattern = regexp.MustCompile(`\{\s*[A-Za-z0-9_]+\s*,\s*(?:plural|selectordinal|select)\s*,`)
+
+// icuSimpleArgTypes 支持的简单参数类型
+var icuSimpleArgTypes = map[string]bool{
+	"number": true, "date": true, "time": true, "spellout": true, "ordinal": true, "duration": true,
+}
+
+// cldrPluralCategorySet CLDR 复数类别
+var cldrPluralCategorySet = map[string]bool{
+	"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true,
+}
+
+// cldrCardinalCategories 按语言（不含地区）列出 CLDR 基数复数类别，未列出的语言为 one、other
+var cldrCardinalCategories = map[string][]string{
+	"ja": {"other"}, "zh": {"other"}, "ko": {"other"}, "vi": {"other"}, "th": {"other"},
+	"id": {"other"}, "ms": {"other"}, "lo": {"other"}, "my": {"other"}, "km": {"other"}, "yue": {"other"},
+	"fr": {"one", "many", "other"}, "es": {"one", "many", "other"}, "it": {"one", "many", "other"},
+	"pt": {"one", "many", "other"}, "ca": {"one", "many", "other"},
+	"ru": {"one", "few", "many", "other"}, "uk": {"one", "few", "many", "other"}, "be": {"one", "few", "many", "other"},
+	"pl": {"one", "few", "many", "other"}, "cs": {"one", "few", "many", "other"}, "sk": {"one", "few", "many", "other"},
+	"lt": {"one", "few", "many", "other"},
+	"hr": {"one", "few", "other"}, "sr": {"one", "few", "other"}, "bs": {"one", "few", "other"}, "ro": {"one", "few", "other"},
+	"lv": {"zero", "one", "other"},
+	"sl": {"one", "two", "few", "other"},
+	"he": {"one", "two", "other"},
+	"ga": {"one", "two", "few", "many", "other"}, "mt": {"one", "two", "few", "many", "other"},
+	"ar": {"zero", "one", "two", "few", "many", "other"}, "cy": {"zero", "one", "two", "few", "many", "other"},
+}
+
+// cldrOrdinalCategories 按语言（不含地区）列出 CLDR 序数复数类别，未列出的语言只有 other
+var cldrOrdinalCategories = map[string][]string{
+	"en": {"one", "two", "few", "other"}, "ca": {"one", "two", "few", "other"}, "mr": {"one", "two", "few", "other"},
+	"fr": {"one", "other"}, "hu": {"one", "other"}, "sv": {"one", "other"}, "ro": {"one", "other"},
+	"vi": {"one", "other"}, "ms": {"one", "other"}, "fil": {"one", "other"}, "tl": {"one", "other"}, "ne": {"one", "other"},
+	"it": {"many", "other"}, "kk": {"many", "other"},
+	"ka": {"one", "many", "other"}, "sq": {"one", "many", "other"},
+	"az": {"one", "few", "many", "other"}, "mk": {"one", "two", "many", "other"},
+	"hi": {"one", "two", "few", "many", "other"}, "gu": {"one", "two", "few", "many", "other"}, "bn": {"one", "two", "few", "many", "other"},
+	"cy": {"zero", "one", "two", "few", "many", "other"},
+}
+
+// PluralCategories 返回语言的 CLDR 基数复数类别（plural 参数使用），按 zero、one、two、few、many、other 排列
+func PluralCategories(language string) []string {
+	if categories, ok := cldrCardinalCategories[pluralLanguage(language)]; ok {
+		return categories
+	}
+	return []string{"one", "other"}
+}
+
+// OrdinalCategories 返回语言的 CLDR 序数复数类别（selectordinal 参数使用）
+func OrdinalCategories(language string) []string {
+	if categories, ok := cldrOrdinalCategories[pluralLanguage(language)]; ok {
+		return categories
+	}
+	return []string{"other"}
+}
+
+// pluralLanguage 语言代码中的语言部分，如 pt-BR、zh_Hant 分别为 pt、zh
+func pluralLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if index := strings.IndexAny(language, "-_"); index > 0 {
+		language = language[:index]
+	}
+	return language
+}
+
+// IsICUMessage 判断文本是否包含 plural、selectordinal 或 select 参数
+func IsICUMessage(value string) bool {
+	return icuComplexArgPattern.MatchString(value)
+}
+
+// ICUSyntaxError ICU 消息的语法错误
+type ICUSyntaxError struct {
+	Offset  int // 出错位置（字符序号，从 0 开始）
+	Message string
+}
+
+func (e *ICUSyntaxError) Error() string {
+	return fmt.Sprintf("第 %d 个字符处%s", e.Offset+1, e.Message)
+}
+
+// icuParser ICU MessageFormat 解析器
+// 撇号按 ICU 默认的 DOUBLE_OPTIONAL 规则处理：'' 表示一个撇号，撇号后紧跟 {、}、|（复数分支中还有 #）时开始引用文本，
+// 其余撇号为普通字符
+type icuParser struct {
+	message string
+	pos     int
+}
+
+// ParseICUMessage 将 ICU 消息解析为结构化的元素，语法错误时返回 *ICUSyntaxError
+// plural 和 selectordinal 的选项只能是 CLDR 复数类别或 =N，所有复杂参数都必须有 other 选项
+func ParseICUMessage(message string) ([]domain.ICUElement, error) {
+	p := &icuParser{message: message}
+	elements, err := p.parseMessage(false, false)
+	if err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+func (p *icuParser) errorAt(pos int, format string, args ...interface{}) error {
+	return &ICUSyntaxError{Offset: utf8.RuneCountInString(p.message[:pos]), Message: fmt.Sprintf(format, args...)}
+}
+
+// parseMessage 解析消息文本，nested 时遇到未匹配的 } 返回（不消费）
+func (p *icuParser) parseMessage(inPlural, nested bool) ([]domain.ICUElement, error) {
+	elements := []domain.ICUElement{}
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			elements = append(elements, domain.ICUElement{Type: domain.ICUElementText, Value: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.message) {
+		c := p.message[p.pos]
+		switch {
+		case c == '{':
+			flush()
+			element, err := p.parseArgument(inPlural)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		case c == '}':
+			if !nested {
+				return nil, p.errorAt(p.pos, "有多余的 }")
+			}
+			flush()
+			return elements, nil
+		case c == '#' && inPlural:
+			flush()
+			elements = append(elements, domain.ICUElement{Type: domain.ICUElementPound})
+			p.pos++
+		case c == '\'':
+			p.parseApostrophe(&text, inPlural)
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	if nested {
+		return nil, p.errorAt(p.pos, "缺少 }")
+	}
+	flush()
+	return elements, nil
+}
+
+// parseApostrophe 处理撇号及其引用的文本
+func (p *icuParser) parseApostrophe(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos < len(p.message) && p.message[p.pos] == '\'' {
+		text.WriteByte('\'')
+		p.pos++
+		return
+	}
+	if p.pos >= len(p.message) || !isICUSyntaxChar(p.message[p.pos], inPlural) {
+		text.WriteByte('\'')
+		return
+	}
+	// 引用文本到下一个单独的撇号为止，没有时到消息结束
+	for p.pos < len(p.message) {
+		c := p.message[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteByte(c)
+			continue
+		}
+		if p.pos < len(p.message) && p.message[p.pos] == '\'' {
+			text.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+// parseArgument 解析 { 开始的参数
+func (p *icuParser) parseArgument(inPlural bool) (domain.ICUElement, error) {
+	start := p.pos
+	p.pos++
+	p.skipSpaces()
+	name := p.readWhile(isICUNameChar)
+	if name == "" {
+		return domain.ICUElement{}, p.errorAt(p.pos, "缺少参数名")
+	}
+	p.skipSpaces()
+	if p.pos >= len(p.message) {
+		return domain.ICUElement{}, p.errorAt(start, "参数 %s 缺少 }", name)
+	}
+	if p.message[p.pos] == '}' {
+		p.pos++
+		return domain.ICUElement{Type: domain.ICUElementArgument, Name: name}, nil
+	}
+	if p.message[p.pos] != ',' {
+		return domain.ICUElement{}, p.errorAt(p.pos, "参数 %s 之后应为 , 或 }", name)
+	}
+	p.pos++
+	p.skipSpaces()
+	typePos := p.pos
+	argType := p.readWhile(isICUNameChar)
+	p.skipSpaces()
+
+	switch {
+	case argType == domain.ICUElementPlural || argType == domain.ICUElementSelectOrdinal || argType == domain.ICUElementSelect:
+		return p.parseOptions(start, name, argType, inPlural)
+	case icuSimpleArgTypes[argType]:
+		element := domain.ICUElement{Type: domain.ICUElementArgument, Name: name, Format: argType}
+		if p.pos < len(p.message) && p.message[p.pos] == ',' {
+			end := strings.IndexByte(p.message[p.pos:], '}')
+			if end < 0 {
+				return domain.ICUElement{}, p.errorAt(start, "参数 %s 缺少 }", name)
+			}
+			element.Style = strings.TrimSpace(p.message[p.pos+1 : p.pos+end])
+			p.pos += end
+		}
+		if p.pos >= len(p.message) || p.message[p.pos] != '}' {
+			return domain.ICUElement{}, p.errorAt(p.pos, "参数 %s 缺少 }", name)
+		}
+		p.pos++
+		return element, nil
+	case argType == "":
+		return domain.ICUElement{}, p.errorAt(p.pos, "参数 %s 缺少类型", name)
+	default:
+		return domain.ICUElement{}, p.errorAt(typePos, "不支持的参数类型 %s", argType)
+	}
+}
+
+// parseOptions 解析 plural、selectordinal 和 select 参数的选项
+func (p *icuParser) parseOptions(start int, name, argType string, inPlural bool) (domain.ICUElement, error) {
+	element := domain.ICUElement{Type: argType, Name: name}
+	if p.pos >= len(p.message) || p.message[p.pos] != ',' {
+		return domain.ICUElement{}, p.errorAt(p.pos, "%s 参数 %s 缺少选项", argType, name)
+	}
+	p.pos++
+	p.skipSpaces()
+
+	plural := argType != domain.ICUElementSelect
+	if plural && strings.HasPrefix(p.message[p.pos:], "offset:") {
+		p.pos += len("offset:")
+		offset, err := strconv.Atoi(p.readWhile(func(c byte) bool { return c >= '0' && c <= '9' }))
+		if err != nil {
+			return domain.ICUElement{}, p.errorAt(p.pos, "offset 必须是非负整数")
+		}
+		element.Offset = offset
+	}
+
+	seen := make(map[string]bool)
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.message) {
+			return domain.ICUElement{}, p.errorAt(start, "%s 参数 %s 缺少 }", argType, name)
+		}
+		if p.message[p.pos] == '}' {
+			p.pos++
+			break
+		}
+
+		selectorPos := p.pos
+		selector := p.readWhile(func(c byte) bool { return isICUNameChar(c) || c == '=' || c == '-' })
+		switch {
+		case selector == "":
+			return domain.ICUElement{}, p.errorAt(p.pos, "%s 参数 %s 的选项名无效", argType, name)
+		case plural && strings.HasPrefix(selector, "="):
+			if _, err := strconv.Atoi(selector[1:]); err != nil {
+				return domain.ICUElement{}, p.errorAt(selectorPos, "无效的选项 %s", selector)
+			}
+		case plural && !cldrPluralCategorySet[selector]:
+			return domain.ICUElement{}, p.errorAt(selectorPos, "%s 不是复数类别（可选 zero、one、two、few、many、other 或 =N）", selector)
+		case !plural && strings.Contains(selector, "="):
+			return domain.ICUElement{}, p.errorAt(selectorPos, "无效的选项 %s", selector)
+		}
+		if seen[selector] {
+			return domain.ICUElement{}, p.errorAt(selectorPos, "选项 %s 重复", selector)
+		}
+		seen[selector] = true
+
+		p.skipSpaces()
+		if p.pos >= len(p.message) || p.message[p.pos] != '{' {
+			return domain.ICUElement{}, p.errorAt(p.pos, "选项 %s 之后应为 {", selector)
+		}
+		p.pos++
+		message, err := p.parseMessage(plural || inPlural, true)
+		if err != nil {
+			return domain.ICUElement{}, err
+		}
+		p.pos++
+		element.Options = append(element.Options, domain.ICUOption{Selector: selector, Message: message})
+	}
+
+	if !seen["other"] {
+		return domain.ICUElement{}, p.errorAt(start, "%s 参数 %s 缺少 other 选项", argType, name)
+	}
+	return element, nil
+}
+
+func (p *icuParser) skipSpaces() {
+	p.pos = skipICUSpaces(p.message, p.pos)
+	for p.pos < len(p.message) && p.message[p.pos] == '\r' {
+		p.pos = skipICUSpaces(p.message, p.pos+1)
+	}
+}
+
+func (p *icuParser) readWhile(accept func(byte) bool) string {
+	start := p.pos
+	for p.pos < len(p.message) && accept(p.message[p.pos]) {
+		p.pos++
+	}
+	return p.message[start:p.pos]
+}
+
+// isICUSyntaxChar 撇号之后需要引用的字符
+func isICUSyntaxChar(c byte, inPlural bool) bool {
+	return c == '{' || c == '}' || c == '|' || (c == '#' && inPlural)
+}
+
+// FormatICUMessage 将结构化的元素序列化为 ICU 消息，文本中的语法字符按需引用
+// 元素不完整（如参数名为空、复杂参数没有 other 选项）时返回 *ICUSyntaxError，Offset 为 0
+func FormatICUMessage(elements []domain.ICUElement) (string, error) {
+	var buf strings.Builder
+	if err := formatICUElements(&buf, elements, false); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func formatICUElements(buf *strings.Builder, elements []domain.ICUElement, inPlural bool) error {
+	for _, element := range elements {
+		switch element.Type {
+		case domain.ICUElementText:
+			writeICUText(buf, element.Value, inPlural)
+		case domain.ICUElementPound:
+			if !inPlural {
+				return &ICUSyntaxError{Message: "# 只能出现在 plural 或 selectordinal 的选项中"}
+			}
+			buf.WriteByte('#')
+		case domain.ICUElementArgument:
+			if !isICUName(element.Name) {
+				return &ICUSyntaxError{Message: fmt.Sprintf("无效的参数名 %q", element.Name)}
+			}
+			buf.WriteString("{" + element.Name)
+			if element.Format != "" {
+				if !icuSimpleArgTypes[element.Format] {
+					return &ICUSyntaxError{Message: "不支持的参数类型 " + element.Format}
+				}
+				buf.WriteString(", " + element.Format)
+				if element.Style != "" {
+					buf.WriteString(", " + element.Style)
+				}
+			}
+			buf.WriteByte('}')
+		case domain.ICUElementPlural, domain.ICUElementSelectOrdinal, domain.ICUElementSelect:
+			if err := formatICUOptions(buf, element, inPlural); err != nil {
+				return err
+			}
+		default:
+			return &ICUSyntaxError{Message: "不支持的元素类型 " + element.Type}
+		}
+	}
+	return nil
+}
+
+func formatICUOptions(buf *strings.Builder, element domain.ICUElement, inPlural bool) error {
+	if !isICUName(element.Name) {
+		return &ICUSyntaxError{Message: fmt.Sprintf("无效的参数名 %q", element.Name)}
+	}
+	plural := element.Type != domain.ICUElementSelect
+	buf.WriteString("{" + element.Name + ", " + element.Type + ",")
+	if plural && element.Offset > 0 {
+		buf.WriteString(" offset:" + strconv.Itoa(element.Offset))
+	}
+
+	seen := make(map[string]bool)
+	for _, option := range element.Options {
+		valid := isICUName(option.Selector)
+		if plural {
+			_, err := strconv.Atoi(strings.TrimPrefix(option.Selector, "="))
+			valid = cldrPluralCategorySet[option.Selector] || strings.HasPrefix(option.Selector, "=") && err == nil
+		}
+		if !valid || seen[option.Selector] {
+			return &ICUSyntaxError{Message: fmt.Sprintf("%s 参数 %s 的选项 %q 无效或重复", element.Type, element.Name, option.Selector)}
+		}
+		seen[option.Selector] = true
+		buf.WriteString(" " + option.Selector + " {")
+		if err := formatICUElements(buf, option.Message, plural || inPlural); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+	}
+	if !seen["other"] {
+		return &ICUSyntaxError{Message: fmt.Sprintf("%s 参数 %s 缺少 other 选项", element.Type, element.Name)}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// writeICUText 写入文本，连续的语法字符用一对撇号引用；撇号在可能与后续内容组成引用时写为 ''
+func writeICUText(buf *strings.Builder, text string, inPlural bool) {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\'':
+			if i == len(text)-1 || text[i+1] == '\'' || isICUSyntaxChar(text[i+1], inPlural) {
+				buf.WriteString("''")
+			} else {
+				buf.WriteByte('\'')
+			}
+		case isICUSyntaxChar(c, inPlural):
+			end := i + 1
+			for end < len(text) && isICUSyntaxChar(text[end], inPlural) {
+				end++
+			}
+			buf.WriteString("'" + text[i:end] + "'")
+			i = end - 1
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
+
+func isICUName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isICUNameChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnalyzeICUMessage 解析 ICU 消息供结构化编辑，并按语言的 CLDR 复数规则检查 plural 和 selectordinal 的选项
+func AnalyzeICUMessage(message, language string) *domain.ICUMessageInfo {
+	info := &domain.ICUMessageInfo{
+		Language:          language,
+		PluralCategories:  PluralCategories(language),
+		OrdinalCategories: OrdinalCategories(language),
+		Elements:          []domain.ICUElement{},
+		Issues:            []domain.QAIssue{},
+	}
+	elements, err := ParseICUMessage(message)
+	if err != nil {
+		syntaxErr := err.(*ICUSyntaxError)
+		info.Error = syntaxErr.Error()
+		info.ErrorOffset = &syntaxErr.Offset
+		return info
+	}
+	info.Valid = true
+	info.Elements = elements
+	if language != "" {
+		info.Issues = append(info.Issues, checkPluralCategories(elements, language)...)
+	}
+	return info
+}
+
+// CheckICUMessage 检查包含 plural、selectordinal 或 select 参数的文本：语法错误（icu_invalid）、
+// 复数选项与语言的 CLDR 复数类别不符（plural_category），以及 select 的选项与源语言不一致（select_mismatch）
+// 译文和源语言都不包含这些参数时不检查
+func CheckICUMessage(value, source, language string) []domain.QAIssue {
+	if !IsICUMessage(value) && !IsICUMessage(source) {
+		return nil
+	}
+	elements, err := ParseICUMessage(value)
+	if err != nil {
+		return []domain.QAIssue{{Type: domain.QAIssueICUInvalid, Message: "ICU 消息语法错误：" + err.Error()}}
+	}
+
+	var issues []domain.QAIssue
+	if language != "" {
+		issues = append(issues, checkPluralCategories(elements, language)...)
+	}
+	if source == "" || source == value {
+		return issues
+	}
+	sourceElements, err := ParseICUMessage(source)
+	if err != nil {
+		return issues
+	}
+	sourceOptions, options := icuSelectOptions(sourceElements), icuSelectOptions(elements)
+	names := make([]string, 0, len(sourceOptions))
+	for name := range sourceOptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if message := compareMarkupItems("选项", sourceOptions[name], options[name]); message != "" && options[name] != nil {
+			issues = append(issues, domain.QAIssue{Type: domain.QAIssueSelectMismatch, Message: "select 参数 " + name + " " + message})
+		}
+	}
+	return issues
+}
+
+// checkPluralCategories 检查 plural 和 selectordinal 参数的选项：不能使用语言没有的复数类别，
+// 语言的每个复数类别都要有选项（=0、=1、=2 分别可以代替 zero、one、two）
+func checkPluralCategories(elements []domain.ICUElement, language string) []domain.QAIssue {
+	var issues []domain.QAIssue
+	walkICUElements(elements, func(element domain.ICUElement) {
+		var categories []string
+		switch element.Type {
+		case domain.ICUElementPlural:
+			categories = PluralCategories(language)
+		case domain.ICUElementSelectOrdinal:
+			categories = OrdinalCategories(language)
+		default:
+			return
+		}
+
+		allowed := make(map[string]bool, len(categories))
+		for _, category := range categories {
+			allowed[category] = true
+		}
+		present := make(map[string]bool)
+		var unsupported []string
+		for _, option := range element.Options {
+			switch {
+			case strings.HasPrefix(option.Selector, "="):
+				if category, ok := map[string]string{"=0": "zero", "=1": "one", "=2": "two"}[option.Selector]; ok {
+					present[category] = true
+				}
+			case allowed[option.Selector]:
+				present[option.Selector] = true
+			default:
+				unsupported = append(unsupported, option.Selector)
+			}
+		}
+		var missing []string
+		for _, category := range categories {
+			if !present[category] {
+				missing = append(missing, category)
+			}
+		}
+
+		var parts []string
+		if len(missing) > 0 {
+			parts = append(parts, "缺少复数类别 "+strings.Join(missing, "、"))
+		}
+		if len(unsupported) > 0 {
+			parts = append(parts, language+" 没有复数类别 "+strings.Join(unsupported, "、"))
+		}
+		if len(parts) > 0 {
+			issues = append(issues, domain.QAIssue{
+				Type:    domain.QAIssuePluralCategory,
+				Message: fmt.Sprintf("%s 参数 %s %s（%s 的复数类别为 %s）", element.Type, element.Name, strings.Join(parts, "；"), language, strings.Join(categories, "、")),
+			})
+		}
+	})
+	return issues
+}
+
+// icuSelectOptions 按参数名收集 select 参数的选项（嵌套的参数同名时合并）
+func icuSelectOptions(elements []domain.ICUElement) map[string][]string {
+	options := make(map[string][]string)
+	walkICUElements(elements, func(element domain.ICUElement) {
+		if element.Type != domain.ICUElementSelect {
+			return
+		}
+		for _, option := range element.Options {
+			if !containsString(options[element.Name], option.Selector) {
+				options[element.Name] = append(options[element.Name], option.Selector)
+			}
+		}
+	})
+	for name := range options {
+		sort.Strings(options[name])
+	}
+	return options
+}
+
+// walkICUElements 深度优先遍历元素及其选项中的元素
+func walkICUElements(elements []domain.ICUElement, visit func(domain.ICUElement)) {
+	for _, element := range elements {
+		visit(element)
+		for _, option := range element.Options {
+			walkICUElements(option.Message, visit)
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// icuPluralForms 将只有一个 plural 参数的 ICU 消息展开为各复数类别的文本，供不支持 ICU 的复数格式导出
+// 参数前后的文本并入每个形式，# 替换为 %d，其余参数保持 ICU 写法；=N 选项没有对应的复数类别，不导出。
+// 消息不是这种形式（没有或有多个复数参数、包含 select 等）时返回 false
+func icuPluralForms(message string) (map[string]string, bool) {
+	if !IsICUMessage(message) {
+		return nil, false
+	}
+	elements, err := ParseICUMessage(message)
+	if err != nil {
+		return nil, false
+	}
+
+	index := -1
+	for i, element := range elements {
+		switch element.Type {
+		case domain.ICUElementPlural:
+			if index >= 0 {
+				return nil, false
+			}
+			index = i
+		case domain.ICUElementSelectOrdinal, domain.ICUElementSelect:
+			return nil, false
+		}
+	}
+	if index < 0 {
+		return nil, false
+	}
+
+	prefix, ok := renderPluralText(elements[:index])
+	if !ok {
+		return nil, false
+	}
+	suffix, ok := renderPluralText(elements[index+1:])
+	if !ok {
+		return nil, false
+	}
+	forms := make(map[string]string)
+	for _, option := range elements[index].Options {
+		if !cldrPluralCategorySet[option.Selector] {
+			continue
+		}
+		text, ok := renderPluralText(option.Message)
+		if !ok {
+			return nil, false
+		}
+		forms[option.Selector] = prefix + text + suffix
+	}
+	return forms, true
+}
+
+// renderPluralText 将不含复杂参数的元素写为文本，嵌套复杂参数时返回 false
+func renderPluralText(elements []domain.ICUElement) (string, bool) {
+	var buf strings.Builder
+	for _, element := range elements {
+		switch element.Type {
+		case domain.ICUElementText:
+			buf.WriteString(element.Value)
+		case domain.ICUElementPound:
+			buf.WriteString("%d")
+		case domain.ICUElementArgument:
+			if err := formatICUElements(&buf, []domain.ICUElement{element}, false); err != nil {
+				return "", false
+			}
+		default:
+			return "", false
+		}
+	}
+	return buf.String(), true
+}
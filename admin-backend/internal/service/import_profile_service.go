@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// maxImportProfileHeaderRow 表头所在行的上限，表头之前通常只有标题或说明
+const maxImportProfileHeaderRow = 100
+
+// ImportProfileService 表格导入映射配置服务实现
+type ImportProfileService struct {
+	profileRepo        domain.ImportProfileRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationService domain.TranslationService
+	eventBus           domain.EventBus
+	transactor         domain.Transactor
+}
+
+// NewImportProfileService 创建表格导入映射配置服务实例
+// 写入翻译通过 TranslationService 完成，以复用其校验和缓存失效逻辑
+func NewImportProfileService(
+	profileRepo domain.ImportProfileRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationService domain.TranslationService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *ImportProfileService {
+	return &ImportProfileService{
+		profileRepo:        profileRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationService: translationService,
+		eventBus:           eventBus,
+		transactor:         transactor,
+	}
+}
+
+// Create 创建映射配置
+func (s *ImportProfileService) Create(ctx context.Context, projectID uint64, params domain.ImportProfileParams, userID uint64) (*domain.ImportProfile, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	profile := &domain.ImportProfile{
+		ProjectID: projectID,
+		HeaderRow: 1,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	}
+	if err := s.applyParams(ctx, profile, params); err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.Create(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// GetByID 获取映射配置
+func (s *ImportProfileService) GetByID(ctx context.Context, id uint64) (*domain.ImportProfile, error) {
+	return s.profileRepo.GetByID(ctx, id)
+}
+
+// GetByProjectID 获取项目下的映射配置列表
+func (s *ImportProfileService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ImportProfile, error) {
+	return s.profileRepo.GetByProjectID(ctx, projectID)
+}
+
+// Update 更新映射配置，空字段保持不变
+func (s *ImportProfileService) Update(ctx context.Context, id uint64, params domain.ImportProfileParams, userID uint64) (*domain.ImportProfile, error) {
+	profile, err := s.profileRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyParams(ctx, profile, params); err != nil {
+		return nil, err
+	}
+	profile.UpdatedBy = userID
+
+	if err := s.profileRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Delete 删除映射配置
+func (s *ImportProfileService) Delete(ctx context.Context, id uint64) error {
+	if _, err := s.profileRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.profileRepo.Delete(ctx, id)
+}
+
+// Suggest 读取上传文件的表头并推测列映射
+func (s *ImportProfileService) Suggest(ctx context.Context, projectID uint64, params domain.ImportMappingSuggestParams) (*domain.ImportMappingSuggestion, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	headerRow := params.HeaderRow
+	if headerRow == 0 {
+		headerRow = 1
+	}
+	if headerRow < 0 || headerRow > maxImportProfileHeaderRow {
+		return nil, invalidImportProfile(fmt.Sprintf("表头行必须在 1 到 %d 之间", maxImportProfileHeaderRow))
+	}
+
+	format, rows, err := ParseSpreadsheet(params.Data, params.Format)
+	if err != nil {
+		return nil, err
+	}
+	header, err := spreadsheetHeader(rows, headerRow)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion := SuggestImportMapping(header, languages)
+	suggestion.Format = format
+	suggestion.HeaderRow = headerRow
+	return suggestion, nil
+}
+
+// Import 按映射配置导入表格
+// 表头按列名匹配（忽略首尾空白和大小写），配置中的列缺失时整个文件不导入；
+// 映射的语言已被删除时跳过该列。空行和空单元格跳过，同一键和语言出现多次时以最后一次为准
+func (s *ImportProfileService) Import(ctx context.Context, profileID uint64, params domain.SpreadsheetImportParams) (*domain.SpreadsheetImportResult, error) {
+	profile, err := s.profileRepo.GetByID(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	format, rows, err := ParseSpreadsheet(params.Data, params.Format)
+	if err != nil {
+		return nil, err
+	}
+	header, err := spreadsheetHeader(rows, profile.HeaderRow)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按表头定位配置中的列
+	columnIndex := spreadsheetColumnIndex(header)
+	var missing []string
+	findColumn := func(name string) int {
+		i, ok := columnIndex[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			missing = append(missing, name)
+			return -1
+		}
+		return i
+	}
+	keyColumn := findColumn(profile.KeyColumn)
+	contextColumn := -1
+	if profile.ContextColumn != "" {
+		contextColumn = findColumn(profile.ContextColumn)
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64, len(languages))
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	result := &domain.SpreadsheetImportResult{UnmappedLanguages: []string{}}
+	type languageColumn struct {
+		index      int
+		languageID uint64
+	}
+	mapping := ParseLanguageMapping(profile.LanguageColumns)
+	columns := make([]string, 0, len(mapping))
+	for column := range mapping {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	var languageColumns []languageColumn
+	for _, column := range columns {
+		languageID, ok := languageCodeToID[mapping[column]]
+		if !ok {
+			result.UnmappedLanguages = append(result.UnmappedLanguages, mapping[column])
+			continue
+		}
+		languageColumns = append(languageColumns, languageColumn{index: findColumn(column), languageID: languageID})
+	}
+
+	if len(missing) > 0 {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrImportColumnNotFound.Code,
+			domain.ErrImportColumnNotFound.Message, strings.Join(missing, "、"))
+	}
+
+	keys := make(map[string]bool)
+	inputsByCell := make(map[string]int)
+	var inputs []domain.TranslationInput
+	for _, row := range rows[profile.HeaderRow:] {
+		if isBlankSpreadsheetRow(row) {
+			continue
+		}
+		result.Rows++
+
+		keyName := strings.TrimSpace(spreadsheetCell(row, keyColumn))
+		if keyName == "" {
+			result.SkippedRows++
+			continue
+		}
+		keyContext := truncateRunes(strings.TrimSpace(spreadsheetCell(row, contextColumn)), 500)
+
+		for _, column := range languageColumns {
+			value := spreadsheetCell(row, column.index)
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			input := domain.TranslationInput{
+				ProjectID:  profile.ProjectID,
+				KeyName:    keyName,
+				LanguageID: column.languageID,
+				Context:    keyContext,
+				Value:      value,
+			}
+			cellKey := fmt.Sprintf("%s:%d", keyName, column.languageID)
+			if idx, exists := inputsByCell[cellKey]; exists {
+				inputs[idx] = input
+				continue
+			}
+			inputsByCell[cellKey] = len(inputs)
+			inputs = append(inputs, input)
+			keys[keyName] = true
+		}
+	}
+
+	if len(inputs) == 0 {
+		return result, nil
+	}
+
+	// 导入的翻译与导入完成事件在同一事务中提交
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.translationService.UpsertBatch(ctx, inputs); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventImportCompleted, profile.ProjectID, domain.ImportCompletedPayload{
+			Source:       domain.ImportSourceFile,
+			Format:       format,
+			Keys:         len(keys),
+			Translations: len(inputs),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Keys = len(keys)
+	result.Translations = len(inputs)
+	return result, nil
+}
+
+// applyParams 将参数合并到映射配置并校验结果
+func (s *ImportProfileService) applyParams(ctx context.Context, profile *domain.ImportProfile, params domain.ImportProfileParams) error {
+	if params.Name != "" {
+		profile.Name = strings.TrimSpace(params.Name)
+	}
+	if params.HeaderRow != 0 {
+		if params.HeaderRow < 1 || params.HeaderRow > maxImportProfileHeaderRow {
+			return invalidImportProfile(fmt.Sprintf("表头行必须在 1 到 %d 之间", maxImportProfileHeaderRow))
+		}
+		profile.HeaderRow = params.HeaderRow
+	}
+	if params.KeyColumn != "" {
+		profile.KeyColumn = strings.TrimSpace(params.KeyColumn)
+	}
+	if params.ContextColumn != nil {
+		profile.ContextColumn = strings.TrimSpace(*params.ContextColumn)
+	}
+
+	mapping := ParseLanguageMapping(profile.LanguageColumns)
+	if params.LanguageColumns != nil {
+		languages, err := s.languageRepo.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+		known := make(map[string]bool, len(languages))
+		for _, lang := range languages {
+			known[lang.Code] = true
+		}
+
+		mapping = make(map[string]string, len(params.LanguageColumns))
+		usedLanguages := make(map[string]bool, len(params.LanguageColumns))
+		for column, code := range params.LanguageColumns {
+			column = strings.TrimSpace(column)
+			if column == "" {
+				return invalidImportProfile("语言列的表头不能为空")
+			}
+			if !known[code] {
+				return invalidImportProfile("语言不存在：" + code)
+			}
+			if usedLanguages[code] {
+				return invalidImportProfile("多个列映射到同一语言：" + code)
+			}
+			usedLanguages[code] = true
+			mapping[column] = code
+		}
+		data, err := json.Marshal(mapping)
+		if err != nil {
+			return domain.ErrInvalidInput
+		}
+		profile.LanguageColumns = string(data)
+	}
+
+	switch {
+	case profile.Name == "":
+		return invalidImportProfile("名称不能为空")
+	case profile.KeyColumn == "":
+		return invalidImportProfile("键名列不能为空")
+	case len(mapping) == 0:
+		return invalidImportProfile("至少需要映射一个语言列")
+	}
+
+	// 同一列只能有一种用途
+	used := map[string]bool{strings.ToLower(profile.KeyColumn): true}
+	if profile.ContextColumn != "" {
+		if used[strings.ToLower(profile.ContextColumn)] {
+			return invalidImportProfile("上下文列不能与键名列相同")
+		}
+		used[strings.ToLower(profile.ContextColumn)] = true
+	}
+	for column := range mapping {
+		if used[strings.ToLower(column)] {
+			return invalidImportProfile("列重复或与键名列、上下文列相同：" + column)
+		}
+		used[strings.ToLower(column)] = true
+	}
+	return nil
+}
+
+// spreadsheetHeader 获取表头行
+func spreadsheetHeader(rows [][]string, headerRow int) ([]string, error) {
+	if headerRow < 1 || headerRow > len(rows) || isBlankSpreadsheetRow(rows[headerRow-1]) {
+		return nil, invalidSpreadsheet(fmt.Sprintf("第 %d 行没有表头", headerRow))
+	}
+	return rows[headerRow-1], nil
+}
+
+// isBlankSpreadsheetRow 检查是否为空行
+func isBlankSpreadsheetRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidImportProfile 带错误详情的映射配置无效错误
+func invalidImportProfile(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidImportProfile.Code, domain.ErrInvalidImportProfile.Message, details)
+}
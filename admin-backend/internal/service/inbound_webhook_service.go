@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+)
+
+// InboundWebhookSignaturePrefix 签名头的算法前缀，如 sha256=<hex>
+const InboundWebhookSignaturePrefix = "sha256="
+
+// 支持的来源系统
+var inboundWebhookProviders = map[string]bool{
+	"generic":  true,
+	"crowdin":  true,
+	"lokalise": true,
+}
+
+// InboundWebhookService 入站 Webhook 服务实现
+type InboundWebhookService struct {
+	webhookRepo        domain.InboundWebhookRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+	eventBus           domain.EventBus
+	transactor         domain.Transactor
+	securityUtils      *utils.SecurityUtils
+}
+
+// NewInboundWebhookService 创建入站 Webhook 服务实例
+// 写入翻译通过 TranslationService 完成，以复用其校验和缓存失效逻辑
+func NewInboundWebhookService(
+	webhookRepo domain.InboundWebhookRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *InboundWebhookService {
+	return &InboundWebhookService{
+		webhookRepo:        webhookRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		translationService: translationService,
+		eventBus:           eventBus,
+		transactor:         transactor,
+		securityUtils:      utils.NewSecurityUtils(),
+	}
+}
+
+// Create 创建入站 Webhook，并生成签名密钥
+func (s *InboundWebhookService) Create(ctx context.Context, projectID uint64, params domain.InboundWebhookParams, userID uint64) (*domain.InboundWebhook, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	webhook := &domain.InboundWebhook{
+		ProjectID:        projectID,
+		Provider:         "generic",
+		ConflictStrategy: domain.ConflictStrategyOverwrite,
+		Status:           domain.InboundWebhookStatusActive,
+		CreatedBy:        userID,
+		UpdatedBy:        userID,
+	}
+	if err := s.applyParams(webhook, params); err != nil {
+		return nil, err
+	}
+	if webhook.Name == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetByID 获取入站 Webhook
+func (s *InboundWebhookService) GetByID(ctx context.Context, id uint64) (*domain.InboundWebhook, error) {
+	return s.webhookRepo.GetByID(ctx, id)
+}
+
+// GetByProjectID 获取项目下的入站 Webhook 列表
+func (s *InboundWebhookService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.InboundWebhook, error) {
+	return s.webhookRepo.GetByProjectID(ctx, projectID)
+}
+
+// Update 更新入站 Webhook 配置，空字段保持不变
+func (s *InboundWebhookService) Update(ctx context.Context, id uint64, params domain.InboundWebhookParams, userID uint64) (*domain.InboundWebhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyParams(webhook, params); err != nil {
+		return nil, err
+	}
+	webhook.UpdatedBy = userID
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Delete 删除入站 Webhook
+func (s *InboundWebhookService) Delete(ctx context.Context, id uint64) error {
+	if _, err := s.webhookRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+// RotateSecret 重新生成签名密钥，旧密钥立即失效
+func (s *InboundWebhookService) RotateSecret(ctx context.Context, id uint64, userID uint64) (*domain.InboundWebhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+	webhook.UpdatedBy = userID
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetLogs 获取入站导入日志
+func (s *InboundWebhookService) GetLogs(ctx context.Context, webhookID uint64, limit, offset int) ([]*domain.InboundWebhookLog, int64, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	return s.webhookRepo.GetLogs(ctx, webhookID, limit, offset)
+}
+
+// Ingest 校验签名并导入外部系统推送的翻译
+// 签名或数据校验失败时同样记录日志，便于排查对接问题
+func (s *InboundWebhookService) Ingest(ctx context.Context, webhookID uint64, body []byte, signature string) (*domain.InboundWebhookLog, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	importLog := &domain.InboundWebhookLog{
+		WebhookID: webhook.ID,
+		ProjectID: webhook.ProjectID,
+	}
+
+	if webhook.Status != domain.InboundWebhookStatusActive {
+		return nil, s.reject(ctx, importLog, domain.ErrWebhookDisabled, "webhook disabled")
+	}
+
+	if !VerifyInboundWebhookSignature(webhook.Secret, body, signature) {
+		return nil, s.reject(ctx, importLog, domain.ErrInvalidWebhookSignature, "signature mismatch")
+	}
+
+	var payload domain.InboundWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, s.reject(ctx, importLog, domain.ErrInvalidWebhookPayload, "invalid json")
+	}
+	if webhook.ExternalProjectID != "" && payload.ExternalProjectID != webhook.ExternalProjectID {
+		return nil, s.reject(ctx, importLog, domain.ErrInvalidWebhookPayload, "external project mismatch")
+	}
+	importLog.Received = len(payload.Translations)
+
+	inputs, err := s.buildInputs(ctx, webhook, payload.Translations, importLog)
+	if err != nil {
+		importLog.Status = domain.InboundWebhookLogFailed
+		importLog.Message = truncateRunes(err.Error(), 500)
+		_ = s.webhookRepo.CreateLog(ctx, importLog)
+		return nil, err
+	}
+
+	if err := s.translationService.UpsertBatch(ctx, inputs); err != nil {
+		importLog.Status = domain.InboundWebhookLogFailed
+		importLog.Message = truncateRunes(err.Error(), 500)
+		_ = s.webhookRepo.CreateLog(ctx, importLog)
+		return nil, err
+	}
+
+	keys := make(map[string]bool)
+	for _, input := range inputs {
+		keys[input.KeyName] = true
+	}
+
+	// 成功日志与导入完成事件在同一事务中提交
+	importLog.Status = domain.InboundWebhookLogSuccess
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.webhookRepo.CreateLog(ctx, importLog); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventImportCompleted, webhook.ProjectID, domain.ImportCompletedPayload{
+			Source:       domain.ImportSourceWebhook,
+			Format:       webhook.Provider,
+			Keys:         len(keys),
+			Translations: len(inputs),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return importLog, nil
+}
+
+// buildInputs 按语言映射和冲突策略将推送内容转换为翻译输入，并统计结果
+func (s *InboundWebhookService) buildInputs(
+	ctx context.Context,
+	webhook *domain.InboundWebhook,
+	items []domain.InboundWebhookTranslation,
+	importLog *domain.InboundWebhookLog,
+) ([]domain.TranslationInput, error) {
+	// 公开推送没有项目路由，按 Webhook 所属项目的组织获取语言
+	ctx, err := projectOrganizationContext(ctx, s.projectRepo, webhook.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64, len(languages))
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	mapping := ParseLanguageMapping(webhook.LanguageMapping)
+
+	var inputs []domain.TranslationInput
+	var keys []domain.TranslationKey
+	for _, item := range items {
+		keyName := strings.TrimSpace(item.Key)
+		if keyName == "" {
+			importLog.Skipped++
+			continue
+		}
+
+		// 未配置映射的语言按同名代码匹配
+		code := item.Language
+		if mapped, ok := mapping[code]; ok {
+			code = mapped
+		}
+		languageID, ok := languageCodeToID[code]
+		if !ok {
+			importLog.Skipped++
+			continue
+		}
+
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  webhook.ProjectID,
+			KeyName:    keyName,
+			LanguageID: languageID,
+			Context:    item.Context,
+			Value:      item.Value,
+		})
+		keys = append(keys, domain.TranslationKey{
+			ProjectID:  webhook.ProjectID,
+			KeyName:    keyName,
+			LanguageID: languageID,
+		})
+	}
+
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	existing, err := s.translationRepo.GetByProjectKeyLanguages(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	existingMap := make(map[string]*domain.Translation, len(existing))
+	for _, t := range existing {
+		existingMap[fmt.Sprintf("%s:%d", t.KeyName, t.LanguageID)] = t
+	}
+
+	filtered := make([]domain.TranslationInput, 0, len(inputs))
+	for _, input := range inputs {
+		current, exists := existingMap[fmt.Sprintf("%s:%d", input.KeyName, input.LanguageID)]
+		if !exists {
+			importLog.Created++
+			filtered = append(filtered, input)
+			continue
+		}
+
+		if !ShouldApplyConflictStrategy(webhook.ConflictStrategy, current.Value) {
+			importLog.Skipped++
+			continue
+		}
+		importLog.Updated++
+		filtered = append(filtered, input)
+	}
+
+	return filtered, nil
+}
+
+// applyParams 将参数合并到 Webhook 配置
+func (s *InboundWebhookService) applyParams(webhook *domain.InboundWebhook, params domain.InboundWebhookParams) error {
+	if params.Name != "" {
+		webhook.Name = strings.TrimSpace(params.Name)
+	}
+	if params.Provider != "" {
+		if !inboundWebhookProviders[params.Provider] {
+			return domain.ErrInvalidInput
+		}
+		webhook.Provider = params.Provider
+	}
+	if params.ExternalProjectID != "" {
+		webhook.ExternalProjectID = strings.TrimSpace(params.ExternalProjectID)
+	}
+	if params.LanguageMapping != nil {
+		data, err := json.Marshal(params.LanguageMapping)
+		if err != nil {
+			return domain.ErrInvalidInput
+		}
+		webhook.LanguageMapping = string(data)
+	}
+	if params.ConflictStrategy != "" {
+		if !IsValidConflictStrategy(params.ConflictStrategy) {
+			return domain.ErrInvalidConflictStrategy
+		}
+		webhook.ConflictStrategy = params.ConflictStrategy
+	}
+	if params.Status != "" {
+		if params.Status != domain.InboundWebhookStatusActive && params.Status != domain.InboundWebhookStatusDisabled {
+			return domain.ErrInvalidInput
+		}
+		webhook.Status = params.Status
+	}
+	return nil
+}
+
+// reject 记录被拒绝的请求并返回对应错误
+func (s *InboundWebhookService) reject(ctx context.Context, importLog *domain.InboundWebhookLog, err error, message string) error {
+	importLog.Status = domain.InboundWebhookLogRejected
+	importLog.Message = message
+	_ = s.webhookRepo.CreateLog(ctx, importLog)
+	return err
+}
+
+// VerifyInboundWebhookSignature 校验请求体的 HMAC-SHA256 签名
+// 签名格式为 sha256=<hex>，也兼容不带前缀的十六进制字符串
+func VerifyInboundWebhookSignature(secret string, body []byte, signature string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), InboundWebhookSignaturePrefix)
+	if secret == "" || signature == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// SignInboundWebhookPayload 计算请求体签名，供对接方和测试使用
+func SignInboundWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return InboundWebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseLanguageMapping 解析存储的语言映射，格式错误时视为未配置
+func ParseLanguageMapping(raw string) map[string]string {
+	mapping := make(map[string]string)
+	if raw == "" {
+		return mapping
+	}
+	if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+		return make(map[string]string)
+	}
+	return mapping
+}
+
+// IsValidConflictStrategy 检查冲突策略是否受支持
+func IsValidConflictStrategy(strategy string) bool {
+	switch strategy {
+	case domain.ConflictStrategyOverwrite, domain.ConflictStrategySkip, domain.ConflictStrategyFillEmpty:
+		return true
+	}
+	return false
+}
+
+// ShouldApplyConflictStrategy 判断在已有翻译的情况下是否写入新值
+func ShouldApplyConflictStrategy(strategy, currentValue string) bool {
+	switch strategy {
+	case domain.ConflictStrategySkip:
+		return false
+	case domain.ConflictStrategyFillEmpty:
+		return strings.TrimSpace(currentValue) == ""
+	default:
+		return true
+	}
+}
+
+// truncateRunes 按字符截断字符串以适配字段长度
+func truncateRunes(value string, limit int) string {
+	runes := []rune(value)
+	if len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return value
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// invitationExpireBatchSize 每个事务标记的过期邀请码数量
+	invitationExpireBatchSize = 500
+	// invitationExpireTimeout 定期标记的超时时间
+	invitationExpireTimeout = 5 * time.Minute
+)
+
+// InvitationExpiry 过期邀请码状态更新
+// 定期将已过期但状态仍为 active 的邀请码标记为 expired。每批在一个事务中记录系统级审计日志（project_id 为 0）
+// 并发布 invitation.expired 事件，订阅方可据此通知邀请人
+type InvitationExpiry struct {
+	invitationRepo domain.InvitationRepository
+	auditRepo      domain.AuditLogRepository
+	eventBus       domain.EventBus
+	transactor     domain.Transactor
+	interval       time.Duration
+	logger         *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewInvitationExpiry 创建过期邀请码状态更新，interval 为 0 时不定期更新
+func NewInvitationExpiry(
+	invitationRepo domain.InvitationRepository,
+	auditRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	interval time.Duration,
+	logger *zap.Logger,
+) *InvitationExpiry {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &InvitationExpiry{
+		invitationRepo: invitationRepo,
+		auditRepo:      auditRepo,
+		eventBus:       eventBus,
+		transactor:     transactor,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+// Expire 标记所有已过期的有效邀请码，分批处理，返回标记的数量
+func (e *InvitationExpiry) Expire(ctx context.Context) (int, error) {
+	now := time.Now()
+	total := 0
+	for {
+		var expired []*domain.Invitation
+		err := withinTransaction(ctx, e.transactor, func(ctx context.Context) error {
+			var err error
+			expired, err = e.invitationRepo.ExpireActive(ctx, now, invitationExpireBatchSize)
+			if err != nil || len(expired) == 0 {
+				return err
+			}
+
+			payload := domain.InvitationsExpiredPayload{
+				Invitations: make([]domain.ExpiredInvitation, len(expired)),
+				Count:       len(expired),
+			}
+			ids := make([]uint64, len(expired))
+			for i, invitation := range expired {
+				ids[i] = invitation.ID
+				payload.Invitations[i] = domain.ExpiredInvitation{
+					ID:         invitation.ID,
+					InviterID:  invitation.InviterID,
+					Email:      invitation.Email,
+					BatchLabel: invitation.BatchLabel,
+					ExpiresAt:  invitation.ExpiresAt,
+				}
+			}
+			if err := recordAudit(ctx, e.auditRepo, 0, 0, domain.AuditActionInvitationExpire, map[string]interface{}{
+				"invitation_ids": ids,
+				"count":          len(ids),
+			}); err != nil {
+				return err
+			}
+			return publishEvent(ctx, e.eventBus, domain.EventInvitationsExpired, 0, payload)
+		})
+		if err != nil {
+			return total, err
+		}
+		total += len(expired)
+		if len(expired) < invitationExpireBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Start 启动定期更新
+func (e *InvitationExpiry) Start(ctx context.Context) error {
+	if e.interval <= 0 {
+		return nil
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期更新并等待当前更新完成
+func (e *InvitationExpiry) Stop(ctx context.Context) error {
+	if e.cancel == nil {
+		return nil
+	}
+	e.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 更新循环，启动后立即更新一次
+func (e *InvitationExpiry) run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		expireCtx, cancel := context.WithTimeout(ctx, invitationExpireTimeout)
+		expired, err := e.Expire(expireCtx)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			e.logger.Error("Scheduled invitation expiry failed", zap.Int("expired", expired), zap.Error(err))
+		} else if expired > 0 {
+			e.logger.Info("Scheduled invitation expiry completed", zap.Int("expired", expired))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,16 +2,33 @@ package service
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
+	"regexp"
+	"strings"
 	"time"
 	"yflow/internal/domain"
 	"yflow/internal/utils"
 )
 
+const (
+	// maxInvitationBatchSize 一次批量创建的最大邀请码数量
+	maxInvitationBatchSize = 200
+	// maxInvitationUses 单个邀请码最多可使用的次数上限
+	maxInvitationUses = 1000
+)
+
+// batchLabelPattern 批次标签允许的字符，标签会出现在撤销接口的路径中
+var batchLabelPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+
 // InvitationService 邀请码服务实现
 type InvitationService struct {
 	invitationRepo domain.InvitationRepository
 	userRepo       domain.UserRepository
+	userService    domain.UserService
+	notifier       domain.EmailNotifier
+	transactor     domain.Transactor
 	securityUtils  *utils.SecurityUtils
 	frontendURL    string
 }
@@ -20,34 +37,39 @@ type InvitationService struct {
 func NewInvitationService(
 	invitationRepo domain.InvitationRepository,
 	userRepo domain.UserRepository,
+	userService domain.UserService,
+	notifier domain.EmailNotifier,
+	transactor domain.Transactor,
 	frontendURL string,
 ) *InvitationService {
 	return &InvitationService{
 		invitationRepo: invitationRepo,
 		userRepo:       userRepo,
+		userService:    userService,
+		notifier:       notifier,
+		transactor:     transactor,
 		securityUtils:  utils.NewSecurityUtils(),
 		frontendURL:    frontendURL,
 	}
 }
 
 // CreateInvitation 创建邀请码
+// 指定邮箱时创建后发送邀请邮件，发送失败不影响已创建的邀请码，EmailStatus 标记为 failed
 func (s *InvitationService) CreateInvitation(ctx context.Context, inviterID uint64, params domain.CreateInvitationParams) (*domain.Invitation, string, error) {
-	// 验证角色
-	role := params.Role
-	if role == "" {
-		role = "member"
+	role, expiresInDays, err := normalizeInvitationOptions(params.Role, params.ExpiresInDays)
+	if err != nil {
+		return nil, "", err
 	}
-	if role != "admin" && role != "member" && role != "viewer" {
-		return nil, "", domain.ErrInvalidRole
+	maxUses := params.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
 	}
-
-	// 验证过期天数
-	expiresInDays := params.ExpiresInDays
-	if expiresInDays <= 0 {
-		expiresInDays = 7 // 默认7天
+	if maxUses < 1 || maxUses > maxInvitationUses {
+		return nil, "", domain.ErrInvalidInvitationUses
 	}
-	if expiresInDays > 365 {
-		expiresInDays = 365 // 最多365天
+	email := strings.TrimSpace(params.Email)
+	if email != "" && !s.notifier.Enabled() {
+		return nil, "", domain.ErrMailNotConfigured
 	}
 
 	// 生成邀请码
@@ -63,7 +85,9 @@ func (s *InvitationService) CreateInvitation(ctx context.Context, inviterID uint
 		Role:        role,
 		Status:      domain.InvitationStatusActive,
 		ExpiresAt:   time.Now().AddDate(0, 0, expiresInDays),
+		MaxUses:     maxUses,
 		Description: params.Description,
+		Email:       email,
 	}
 
 	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
@@ -73,6 +97,14 @@ func (s *InvitationService) CreateInvitation(ctx context.Context, inviterID uint
 	// 生成邀请链接
 	invitationURL := s.generateInvitationURL(code)
 
+	if email != "" {
+		err := s.notifier.Notify(ctx, email, domain.EmailEventInvitation, invitationEmailData(invitation, invitationURL))
+		invitation.EmailStatus = domain.InvitationEmailSent
+		if err != nil {
+			invitation.EmailStatus = domain.InvitationEmailFailed
+		}
+	}
+
 	return invitation, invitationURL, nil
 }
 
@@ -131,7 +163,8 @@ func (s *InvitationService) ValidateInvitation(ctx context.Context, code string)
 	return invitation, nil
 }
 
-// UseInvitation 使用邀请码（创建用户并标记邀请为已使用）
+// UseInvitation 使用邀请码（创建用户后调用），使用次数加一，用完时标记为已使用
+// 并发使用时由仓储原子地检查剩余次数，已用完时返回 ErrInvitationUsed
 func (s *InvitationService) UseInvitation(ctx context.Context, code string, userID uint64) error {
 	invitation, err := s.invitationRepo.GetByCode(ctx, code)
 	if err != nil {
@@ -155,6 +188,28 @@ func (s *InvitationService) UseInvitation(ctx context.Context, code string, user
 	return s.invitationRepo.MarkAsUsed(ctx, code, userID)
 }
 
+// RegisterWithInvitation 使用邀请码注册用户，用户使用邀请码指定的角色
+// 创建用户和占用邀请码在同一个事务中完成：并发注册时邀请码已用完的一方返回 ErrInvitationUsed，新建的用户随事务回滚
+func (s *InvitationService) RegisterWithInvitation(ctx context.Context, code string, params domain.CreateUserParams) (*domain.User, error) {
+	var user *domain.User
+	err := withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		invitation, err := s.ValidateInvitation(ctx, code)
+		if err != nil {
+			return err
+		}
+		params.Role = invitation.Role
+		user, err = s.userService.CreateUser(ctx, params)
+		if err != nil {
+			return err
+		}
+		return s.UseInvitation(ctx, code, user.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 // RevokeInvitation 撤销邀请码
 func (s *InvitationService) RevokeInvitation(ctx context.Context, code string) error {
 	invitation, err := s.invitationRepo.GetByCode(ctx, code)
@@ -175,6 +230,202 @@ func (s *InvitationService) DeleteInvitation(ctx context.Context, code string) e
 	return s.invitationRepo.Delete(ctx, code)
 }
 
+// CreateInvitationBatch 批量创建邀请码
+// 指定邮箱或收件人时为每个邮箱创建一个邀请码，收件人可以分别指定角色，创建完成后逐个发送邀请邮件（SkipEmail 时不发送）；
+// 邮件发送失败不影响已创建的邀请码，结果中标记为 failed，可从 CSV 中取得链接另行发送
+func (s *InvitationService) CreateInvitationBatch(ctx context.Context, inviterID uint64, params domain.CreateInvitationBatchParams) (*domain.InvitationBatchResult, error) {
+	role, expiresInDays, err := normalizeInvitationOptions(params.Role, params.ExpiresInDays)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := normalizeInvitationRecipients(params.Emails, params.Recipients, role)
+	if err != nil {
+		return nil, err
+	}
+	count := params.Count
+	if len(recipients) > 0 {
+		if count != 0 && count != len(recipients) {
+			return nil, domain.ErrInvalidInvitationCount
+		}
+		count = len(recipients)
+		if !params.SkipEmail && !s.notifier.Enabled() {
+			return nil, domain.ErrMailNotConfigured
+		}
+	}
+	if count < 1 || count > maxInvitationBatchSize {
+		return nil, domain.ErrInvalidInvitationCount
+	}
+
+	label := strings.TrimSpace(params.BatchLabel)
+	if label == "" {
+		label = "batch-" + time.Now().Format("20060102-150405")
+	}
+	if !batchLabelPattern.MatchString(label) {
+		return nil, domain.ErrInvalidBatchLabel
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, expiresInDays)
+	invitations := make([]*domain.Invitation, count)
+	for i := range invitations {
+		code, err := s.generateInvitationCode()
+		if err != nil {
+			return nil, err
+		}
+		invitations[i] = &domain.Invitation{
+			Code:        code,
+			InviterID:   inviterID,
+			Role:        role,
+			Status:      domain.InvitationStatusActive,
+			ExpiresAt:   expiresAt,
+			MaxUses:     1,
+			Description: params.Description,
+			BatchLabel:  label,
+		}
+		if len(recipients) > 0 {
+			invitations[i].Email = recipients[i].Email
+			invitations[i].Role = recipients[i].Role
+		}
+	}
+	if err := s.invitationRepo.CreateBatch(ctx, invitations); err != nil {
+		return nil, err
+	}
+
+	result := &domain.InvitationBatchResult{
+		BatchLabel:  label,
+		Invitations: make([]domain.InvitationBatchItem, 0, count),
+	}
+	for _, invitation := range invitations {
+		item := domain.InvitationBatchItem{
+			Code:          invitation.Code,
+			InvitationURL: s.generateInvitationURL(invitation.Code),
+			Email:         invitation.Email,
+			Role:          invitation.Role,
+			ExpiresAt:     invitation.ExpiresAt,
+		}
+		if item.Email != "" && !params.SkipEmail {
+			if err := s.notifier.Notify(ctx, item.Email, domain.EmailEventInvitation, invitationEmailData(invitation, item.InvitationURL)); err != nil {
+				item.EmailStatus = domain.InvitationEmailFailed
+				result.EmailFailed++
+			} else {
+				item.EmailStatus = domain.InvitationEmailSent
+				result.Emailed++
+			}
+		}
+		result.Invitations = append(result.Invitations, item)
+	}
+	return result, nil
+}
+
+// RevokeInvitationBatch 撤销批次中所有仍然有效的邀请码，返回撤销的数量
+// 已使用、已撤销的邀请码保持不变
+func (s *InvitationService) RevokeInvitationBatch(ctx context.Context, batchLabel string) (int64, error) {
+	batchLabel = strings.TrimSpace(batchLabel)
+	if !batchLabelPattern.MatchString(batchLabel) {
+		return 0, domain.ErrInvalidBatchLabel
+	}
+	revoked, err := s.invitationRepo.RevokeByBatch(ctx, batchLabel)
+	if err != nil {
+		return 0, err
+	}
+	if revoked == 0 {
+		total, err := s.invitationRepo.CountByBatch(ctx, batchLabel)
+		if err != nil {
+			return 0, err
+		}
+		if total == 0 {
+			return 0, domain.ErrInvitationBatchNotFound
+		}
+	}
+	return revoked, nil
+}
+
+// WriteInvitationBatchCSV 将批量创建的邀请码写为 CSV，第一行为表头
+func WriteInvitationBatchCSV(w io.Writer, result *domain.InvitationBatchResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"code", "invitation_url", "email", "role", "expires_at", "batch_label", "email_status"}); err != nil {
+		return err
+	}
+	for _, item := range result.Invitations {
+		record := []string{
+			item.Code,
+			item.InvitationURL,
+			item.Email,
+			item.Role,
+			item.ExpiresAt.Format(time.RFC3339),
+			result.BatchLabel,
+			item.EmailStatus,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// normalizeInvitationOptions 验证角色并限制过期天数，角色默认为 member，过期天数默认 7 天、最多 365 天
+func normalizeInvitationOptions(role string, expiresInDays int) (string, int, error) {
+	if role == "" {
+		role = "member"
+	}
+	if !isInvitationRole(role) {
+		return "", 0, domain.ErrInvalidRole
+	}
+	if expiresInDays <= 0 {
+		expiresInDays = 7
+	}
+	if expiresInDays > 365 {
+		expiresInDays = 365
+	}
+	return role, expiresInDays, nil
+}
+
+// isInvitationRole 检查是否为邀请可以赋予的角色
+func isInvitationRole(role string) bool {
+	return role == "admin" || role == "member" || role == "viewer"
+}
+
+// normalizeInvitationRecipients 合并邮箱和收件人，邮箱使用默认角色，收件人未指定角色时同样使用默认角色；
+// 去除邮箱首尾空白、空邮箱和重复的邮箱（不区分大小写，保留先出现的），保持原有顺序
+func normalizeInvitationRecipients(emails []string, recipients []domain.InvitationRecipient, defaultRole string) ([]domain.InvitationRecipient, error) {
+	all := make([]domain.InvitationRecipient, 0, len(emails)+len(recipients))
+	for _, email := range emails {
+		all = append(all, domain.InvitationRecipient{Email: email})
+	}
+	all = append(all, recipients...)
+
+	var normalized []domain.InvitationRecipient
+	seen := make(map[string]bool, len(all))
+	for _, recipient := range all {
+		email := strings.TrimSpace(recipient.Email)
+		if email == "" || seen[strings.ToLower(email)] {
+			continue
+		}
+		role := recipient.Role
+		if role == "" {
+			role = defaultRole
+		}
+		if !isInvitationRole(role) {
+			return nil, domain.ErrInvalidRole
+		}
+		seen[strings.ToLower(email)] = true
+		normalized = append(normalized, domain.InvitationRecipient{Email: email, Role: role})
+	}
+	return normalized, nil
+}
+
+// invitationEmailData 邀请邮件模板数据
+func invitationEmailData(invitation *domain.Invitation, invitationURL string) domain.InvitationEmailData {
+	return domain.InvitationEmailData{
+		InvitationURL: invitationURL,
+		Code:          invitation.Code,
+		Role:          invitation.Role,
+		ExpiresAt:     invitation.ExpiresAt,
+		Description:   invitation.Description,
+	}
+}
+
 // generateInvitationCode 生成邀请码
 func (s *InvitationService) generateInvitationCode() (string, error) {
 	return s.securityUtils.GenerateSecureToken(32)
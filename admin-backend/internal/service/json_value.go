@@ -0,0 +1,365 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// json 类型的值使用 JSON Schema（draft 2020-12）的以下关键字校验，其余关键字忽略：
+// type、enum、const、properties、required、additionalProperties、items、
+// minItems、maxItems、minLength、maxLength、minimum、maximum
+var jsonSchemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// ValidateValueSchema 检查 JSON Schema 本身是否有效：必须是 JSON 对象，支持的关键字取值类型正确
+func ValidateValueSchema(schema string) error {
+	node, err := decodeJSONValue(schema)
+	if err != nil {
+		return invalidValueSchema(err.Error())
+	}
+	if _, ok := node.(map[string]interface{}); !ok {
+		return invalidValueSchema("JSON Schema 必须是对象")
+	}
+	if problem := checkJSONSchema(node, "#"); problem != "" {
+		return invalidValueSchema(problem)
+	}
+	return nil
+}
+
+// ValidateJSONValue 检查值是否为合法的 JSON，schema 不为空时还需要符合该 JSON Schema
+func ValidateJSONValue(value, schema string) error {
+	decoded, err := decodeJSONValue(value)
+	if err != nil {
+		return invalidJSONValue(err.Error())
+	}
+	if schema == "" {
+		return nil
+	}
+	node, err := decodeJSONValue(schema)
+	if err != nil {
+		return invalidValueSchema(err.Error())
+	}
+	if problem := matchJSONSchema(node, decoded, "$"); problem != "" {
+		return invalidJSONValue(problem)
+	}
+	return nil
+}
+
+// decodeJSONValue 解析一个完整的 JSON 值，末尾不能有多余内容
+func decodeJSONValue(text string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("JSON 值之后有多余的内容")
+	}
+	return value, nil
+}
+
+// marshalJSONValue 将导入文件中的结构化值序列化为紧凑的 JSON，不转义 HTML 字符
+func marshalJSONValue(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// checkJSONSchema 检查 Schema 节点，返回第一个问题的描述，没有问题时返回空字符串
+func checkJSONSchema(node interface{}, path string) string {
+	if _, ok := node.(bool); ok {
+		return ""
+	}
+	schema, ok := node.(map[string]interface{})
+	if !ok {
+		return path + " 必须是对象或布尔值"
+	}
+
+	if t, exists := schema["type"]; exists {
+		names, ok := schemaTypeNames(t)
+		if !ok {
+			return path + "/type 必须是类型名称或类型名称数组"
+		}
+		for _, name := range names {
+			if !jsonSchemaTypes[name] {
+				return fmt.Sprintf("%s/type 不支持的类型: %s", path, name)
+			}
+		}
+	}
+	if enum, exists := schema["enum"]; exists {
+		if _, ok := enum.([]interface{}); !ok {
+			return path + "/enum 必须是数组"
+		}
+	}
+	if properties, exists := schema["properties"]; exists {
+		properties, ok := properties.(map[string]interface{})
+		if !ok {
+			return path + "/properties 必须是对象"
+		}
+		for _, name := range sortedSchemaKeys(properties) {
+			if problem := checkJSONSchema(properties[name], path+"/properties/"+name); problem != "" {
+				return problem
+			}
+		}
+	}
+	if required, exists := schema["required"]; exists {
+		names, ok := required.([]interface{})
+		if !ok {
+			return path + "/required 必须是字符串数组"
+		}
+		for _, name := range names {
+			if _, ok := name.(string); !ok {
+				return path + "/required 必须是字符串数组"
+			}
+		}
+	}
+	for _, keyword := range []string{"additionalProperties", "items"} {
+		if sub, exists := schema[keyword]; exists {
+			if problem := checkJSONSchema(sub, path+"/"+keyword); problem != "" {
+				return problem
+			}
+		}
+	}
+	for _, keyword := range []string{"minItems", "maxItems", "minLength", "maxLength"} {
+		if limit, exists := schema[keyword]; exists {
+			if n, ok := schemaNumber(limit); !ok || n < 0 || n != math.Trunc(n) {
+				return fmt.Sprintf("%s/%s 必须是非负整数", path, keyword)
+			}
+		}
+	}
+	for _, keyword := range []string{"minimum", "maximum"} {
+		if limit, exists := schema[keyword]; exists {
+			if _, ok := schemaNumber(limit); !ok {
+				return fmt.Sprintf("%s/%s 必须是数字", path, keyword)
+			}
+		}
+	}
+	return ""
+}
+
+// matchJSONSchema 按 Schema 校验值，返回第一个不符合之处的描述，符合时返回空字符串
+func matchJSONSchema(node, value interface{}, path string) string {
+	switch schema := node.(type) {
+	case bool:
+		if !schema {
+			return path + " 不允许出现"
+		}
+		return ""
+	case map[string]interface{}:
+		if t, exists := schema["type"]; exists {
+			names, _ := schemaTypeNames(t)
+			matched := false
+			for _, name := range names {
+				if jsonValueHasType(value, name) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return fmt.Sprintf("%s 的类型应为 %s", path, strings.Join(names, " 或 "))
+			}
+		}
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			matched := false
+			for _, candidate := range enum {
+				if jsonValuesEqual(candidate, value) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return path + " 不是允许的取值之一"
+			}
+		}
+		if constant, exists := schema["const"]; exists && !jsonValuesEqual(constant, value) {
+			return path + " 与 const 不一致"
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if required, ok := schema["required"].([]interface{}); ok {
+				for _, name := range required {
+					if name, ok := name.(string); ok {
+						if _, exists := v[name]; !exists {
+							return fmt.Sprintf("%s 缺少必需的属性 %s", path, name)
+						}
+					}
+				}
+			}
+			properties, _ := schema["properties"].(map[string]interface{})
+			additional, hasAdditional := schema["additionalProperties"]
+			for _, name := range sortedSchemaKeys(v) {
+				if sub, exists := properties[name]; exists {
+					if problem := matchJSONSchema(sub, v[name], path+"."+name); problem != "" {
+						return problem
+					}
+				} else if hasAdditional {
+					if problem := matchJSONSchema(additional, v[name], path+"."+name); problem != "" {
+						return problem
+					}
+				}
+			}
+		case []interface{}:
+			if problem := checkSchemaLimit(schema, "minItems", "maxItems", len(v), path, "元素"); problem != "" {
+				return problem
+			}
+			if items, exists := schema["items"]; exists {
+				for i, item := range v {
+					if problem := matchJSONSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); problem != "" {
+						return problem
+					}
+				}
+			}
+		case string:
+			if problem := checkSchemaLimit(schema, "minLength", "maxLength", len([]rune(v)), path, "字符"); problem != "" {
+				return problem
+			}
+		case json.Number:
+			n, _ := v.Float64()
+			if minimum, ok := schemaNumber(schema["minimum"]); ok && n < minimum {
+				return fmt.Sprintf("%s 不能小于 %v", path, minimum)
+			}
+			if maximum, ok := schemaNumber(schema["maximum"]); ok && n > maximum {
+				return fmt.Sprintf("%s 不能大于 %v", path, maximum)
+			}
+		}
+		return ""
+	default:
+		return path + " 的 Schema 无效"
+	}
+}
+
+// checkSchemaLimit 检查数组元素个数或字符串长度的上下限
+func checkSchemaLimit(schema map[string]interface{}, minKeyword, maxKeyword string, size int, path, unit string) string {
+	if minimum, ok := schemaNumber(schema[minKeyword]); ok && float64(size) < minimum {
+		return fmt.Sprintf("%s 至少需要 %v 个%s", path, minimum, unit)
+	}
+	if maximum, ok := schemaNumber(schema[maxKeyword]); ok && float64(size) > maximum {
+		return fmt.Sprintf("%s 最多只能有 %v 个%s", path, maximum, unit)
+	}
+	return ""
+}
+
+// schemaTypeNames 读取 type 关键字，可以是类型名称或类型名称数组
+func schemaTypeNames(t interface{}) ([]string, bool) {
+	switch t := t.(type) {
+	case string:
+		return []string{t}, true
+	case []interface{}:
+		names := make([]string, 0, len(t))
+		for _, name := range t {
+			name, ok := name.(string)
+			if !ok {
+				return nil, false
+			}
+			names = append(names, name)
+		}
+		return names, len(names) > 0
+	}
+	return nil, false
+}
+
+// jsonValueHasType 判断值是否属于 JSON Schema 类型，整数值同时属于 number 和 integer
+func jsonValueHasType(value interface{}, name string) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return name == "object"
+	case []interface{}:
+		return name == "array"
+	case string:
+		return name == "string"
+	case bool:
+		return name == "boolean"
+	case nil:
+		return name == "null"
+	case json.Number:
+		if name == "number" {
+			return true
+		}
+		n, err := v.Float64()
+		return name == "integer" && err == nil && n == math.Trunc(n)
+	}
+	return false
+}
+
+// jsonValuesEqual 比较两个 JSON 值，数字按数值比较
+func jsonValuesEqual(a, b interface{}) bool {
+	if x, ok := a.(json.Number); ok {
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	}
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !jsonValuesEqual(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, exists := y[key]
+			if !exists || !jsonValuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// schemaNumber 读取数字关键字
+func schemaNumber(value interface{}) (float64, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	n, err := number.Float64()
+	return n, err == nil
+}
+
+// sortedSchemaKeys 按名称排序对象的属性，使错误描述稳定
+func sortedSchemaKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// invalidValueSchema 带错误详情的 JSON Schema 无效错误
+func invalidValueSchema(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidValueSchema.Code, domain.ErrInvalidValueSchema.Message, details)
+}
+
+// invalidJSONValue 带错误详情的 JSON 值无效错误
+func invalidJSONValue(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidJSONValue.Code, domain.ErrInvalidJSONValue.Message, details)
+}
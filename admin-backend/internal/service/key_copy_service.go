@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"yflow/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// maxKeyCopyKeys 一次最多复制的键数量
+const maxKeyCopyKeys = 1000
+
+// KeyCopyService 跨项目复制键服务实现
+// 复制时按键读取源项目各语言的翻译写入目标项目，保留键在目标项目中所属的命名空间；
+// 链接的键通过订阅 translation.updated 事件同步，只写入值有变化的翻译，互相链接的键同步一次后即停止。
+// 语言属于组织，翻译按语言 ID 原样复制，因此只能在同一组织的项目之间复制和同步
+type KeyCopyService struct {
+	translationRepo domain.TranslationRepository
+	linkRepo        domain.KeyLinkRepository
+	projectRepo     domain.ProjectRepository
+	memberService   domain.ProjectMemberService
+	auditLogRepo    domain.AuditLogRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+	logger          *zap.Logger
+}
+
+// NewKeyCopyService 创建跨项目复制键服务实例
+func NewKeyCopyService(
+	translationRepo domain.TranslationRepository,
+	linkRepo domain.KeyLinkRepository,
+	projectRepo domain.ProjectRepository,
+	memberService domain.ProjectMemberService,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	logger *zap.Logger,
+) *KeyCopyService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &KeyCopyService{
+		translationRepo: translationRepo,
+		linkRepo:        linkRepo,
+		projectRepo:     projectRepo,
+		memberService:   memberService,
+		auditLogRepo:    auditLogRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+		logger:          logger,
+	}
+}
+
+// Copy 将源项目中的键复制到项目
+// 需要能查看源项目；源项目属于其他组织时返回 ErrCrossOrganizationCopy；
+// 任一键在源项目中不存在时不复制并返回 ErrKeyNotFound。
+// 项目中已有的键在 Overwrite 为 false 时跳过，也不建立链接
+func (s *KeyCopyService) Copy(ctx context.Context, projectID uint64, params domain.KeyCopyParams, userID uint64) (*domain.KeyCopyResult, error) {
+	keyNames := normalizeKeyNames(params.KeyNames)
+	if len(keyNames) == 0 || len(keyNames) > maxKeyCopyKeys {
+		return nil, domain.ErrInvalidKey
+	}
+	if params.SourceProjectID == projectID {
+		return nil, domain.ErrInvalidCopySource
+	}
+	target, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	source, err := s.projectRepo.GetByID(ctx, params.SourceProjectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	allowed, err := s.memberService.CheckPermission(ctx, userID, params.SourceProjectID, "viewer")
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domain.ErrForbidden
+	}
+	if source.OrganizationID != target.OrganizationID {
+		return nil, domain.ErrCrossOrganizationCopy
+	}
+
+	found, err := s.translationRepo.FindExistingKeyNames(ctx, params.SourceProjectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) != len(keyNames) {
+		return nil, domain.ErrKeyNotFound
+	}
+	existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	existingKeys := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		existingKeys[name] = true
+	}
+
+	result := &domain.KeyCopyResult{SourceProjectID: params.SourceProjectID, Copied: []string{}}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		now := time.Now()
+		var links []*domain.KeyLink
+		for _, keyName := range keyNames {
+			if existingKeys[keyName] && !params.Overwrite {
+				result.Skipped = append(result.Skipped, keyName)
+				continue
+			}
+			count, err := s.copyKey(ctx, params.SourceProjectID, projectID, keyName, userID)
+			if err != nil {
+				return err
+			}
+			result.Translations += count
+			result.Copied = append(result.Copied, keyName)
+			if params.Link {
+				links = append(links, &domain.KeyLink{
+					SourceProjectID: params.SourceProjectID,
+					TargetProjectID: projectID,
+					KeyName:         keyName,
+					CreatedBy:       userID,
+					CreatedAt:       now,
+					LastSyncedAt:    &now,
+				})
+			}
+		}
+		if err := s.linkRepo.Upsert(ctx, links); err != nil {
+			return err
+		}
+		result.Linked = len(links)
+
+		if err := recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionKeyCopy, result); err != nil {
+			return err
+		}
+		if result.Translations == 0 {
+			return nil
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+			Action:   domain.TranslationActionUpserted,
+			KeyNames: result.Copied,
+			Count:    int(result.Translations),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListLinks 获取项目作为源项目或目标项目的链接
+func (s *KeyCopyService) ListLinks(ctx context.Context, projectID uint64) ([]*domain.KeyLink, error) {
+	return s.linkRepo.GetByProject(ctx, projectID)
+}
+
+// Unlink 取消项目中键的链接，已复制的翻译保留
+func (s *KeyCopyService) Unlink(ctx context.Context, projectID uint64, keyName string, userID uint64) error {
+	keyName = strings.TrimSpace(keyName)
+	if keyName == "" {
+		return domain.ErrInvalidKey
+	}
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.linkRepo.Delete(ctx, projectID, keyName); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionKeyUnlink, map[string]interface{}{
+			"key_name": keyName,
+		})
+	})
+}
+
+// HandleEvent 处理翻译变更事件，将源项目中链接的键的修改同步到各目标项目
+// 删除不同步，审核状态变化不改变译文；目标项目已删除时跳过
+func (s *KeyCopyService) HandleEvent(ctx context.Context, event domain.Event) error {
+	var payload domain.TranslationUpdatedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		return err
+	}
+	if payload.Action == domain.TranslationActionDeleted || payload.Action == domain.TranslationActionReviewed {
+		return nil
+	}
+
+	// 事件没有键名时（如导入）同步项目的全部链接
+	links, err := s.linkRepo.GetBySource(ctx, event.ProjectID, payload.KeyNames)
+	if err != nil {
+		return err
+	}
+	targets := make(map[uint64][]*domain.KeyLink)
+	for _, link := range links {
+		targets[link.TargetProjectID] = append(targets[link.TargetProjectID], link)
+	}
+	targetIDs := make([]uint64, 0, len(targets))
+	for id := range targets {
+		targetIDs = append(targetIDs, id)
+	}
+	sort.Slice(targetIDs, func(i, j int) bool { return targetIDs[i] < targetIDs[j] })
+
+	var errs []error
+	for _, targetID := range targetIDs {
+		if err := s.syncLinks(ctx, event.ProjectID, targetID, targets[targetID]); err != nil {
+			s.logger.Warn("Failed to sync linked keys",
+				zap.Uint64("source_project_id", event.ProjectID),
+				zap.Uint64("target_project_id", targetID),
+				zap.Error(err),
+			)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncLinks 在一个事务中将链接的键写入目标项目，有翻译变化时发布目标项目的 translation.updated 事件
+// 源项目和目标项目不在同一组织时（如项目移动了组织）不同步
+func (s *KeyCopyService) syncLinks(ctx context.Context, sourceID, targetID uint64, links []*domain.KeyLink) error {
+	target, err := s.projectRepo.GetByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			return nil
+		}
+		return err
+	}
+	source, err := s.projectRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			return nil
+		}
+		return err
+	}
+	if source.OrganizationID != target.OrganizationID {
+		s.logger.Warn("Skipped syncing linked keys across organizations",
+			zap.Uint64("source_project_id", sourceID),
+			zap.Uint64("target_project_id", targetID),
+		)
+		return nil
+	}
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		var keyNames []string
+		var ids []uint64
+		var count int64
+		for _, link := range links {
+			copied, err := s.copyKey(ctx, sourceID, targetID, link.KeyName, link.CreatedBy)
+			if err != nil {
+				return err
+			}
+			if copied == 0 {
+				continue
+			}
+			keyNames = append(keyNames, link.KeyName)
+			ids = append(ids, link.ID)
+			count += copied
+		}
+		if count == 0 {
+			return nil
+		}
+		if err := s.linkRepo.MarkSynced(ctx, ids, time.Now()); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, targetID, domain.TranslationUpdatedPayload{
+			Action:   domain.TranslationActionUpserted,
+			KeyNames: keyNames,
+			Count:    int(count),
+		})
+	})
+}
+
+// copyKey 将源项目中键在各语言的翻译写入目标项目，返回新增或修改的条数
+// 值和值类型都相同的翻译不写入；键在目标项目中已有翻译时保留其命名空间，否则不属于任何命名空间
+func (s *KeyCopyService) copyKey(ctx context.Context, sourceID, targetID uint64, keyName string, userID uint64) (int64, error) {
+	sources, err := s.translationRepo.GetByProjectKey(ctx, sourceID, keyName)
+	if err != nil {
+		return 0, err
+	}
+	targets, err := s.translationRepo.GetByProjectKey(ctx, targetID, keyName)
+	if err != nil {
+		return 0, err
+	}
+	current := make(map[uint64]*domain.Translation, len(targets))
+	var namespaceID uint64
+	for _, translation := range targets {
+		current[translation.LanguageID] = translation
+		namespaceID = translation.NamespaceID
+	}
+
+	copies := make([]*domain.Translation, 0, len(sources))
+	for _, translation := range sources {
+		if existing, ok := current[translation.LanguageID]; ok &&
+			existing.Value == translation.Value && existing.ValueType == translation.ValueType {
+			continue
+		}
+		copies = append(copies, &domain.Translation{
+			ProjectID:    targetID,
+			KeyName:      keyName,
+			Context:      translation.Context,
+			LanguageID:   translation.LanguageID,
+			Value:        translation.Value,
+			ValueType:    translation.ValueType,
+			ValueSchema:  translation.ValueSchema,
+			MaxLength:    translation.MaxLength,
+			Tags:         translation.Tags,
+			Platform:     translation.Platform,
+			NamespaceID:  namespaceID,
+			Status:       translation.Status,
+			ReviewStatus: translation.ReviewStatus,
+			CreatedBy:    userID,
+			UpdatedBy:    userID,
+		})
+	}
+	if err := s.translationRepo.UpsertBatch(ctx, copies); err != nil {
+		return 0, err
+	}
+	return int64(len(copies)), nil
+}
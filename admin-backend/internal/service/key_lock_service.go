@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+// keyLockTTL 编辑锁的有效期，编辑器应在到期前续期（建议每 20 秒一次）
+const keyLockTTL = time.Minute
+
+// KeyLockService 键编辑锁服务实现
+// 每个项目的编辑锁保存在 Redis 中一个哈希表里，哈希表随最近写入的锁续期，其中过期的锁在读取时清理。
+// 获取锁不是原子操作，两人同时获取时可能都成功；编辑锁只用于提示，并发修改由翻译的版本号拒绝
+type KeyLockService struct {
+	cacheService domain.CacheService
+}
+
+// NewKeyLockService 创建键编辑锁服务实例
+func NewKeyLockService(cacheService domain.CacheService) *KeyLockService {
+	return &KeyLockService{cacheService: cacheService}
+}
+
+// Acquire 获取键的编辑锁
+func (s *KeyLockService) Acquire(ctx context.Context, projectID uint64, keyName string, userID uint64, username string) (*domain.KeyLock, error) {
+	keyName, err := validLockKeyName(keyName)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	current, err := s.get(ctx, projectID, keyName)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.UserID != userID && now.Before(current.ExpiresAt) {
+		return current, domain.ErrKeyLocked
+	}
+
+	lock := &domain.KeyLock{
+		ProjectID:  projectID,
+		KeyName:    keyName,
+		UserID:     userID,
+		Username:   username,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(keyLockTTL),
+	}
+	// 持有者重新获取时保留获取时间
+	if current != nil && current.UserID == userID && now.Before(current.ExpiresAt) {
+		lock.AcquiredAt = current.AcquiredAt
+	}
+	if err := s.save(ctx, lock); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// Heartbeat 续期用户持有的编辑锁
+func (s *KeyLockService) Heartbeat(ctx context.Context, projectID uint64, keyName string, userID uint64) (*domain.KeyLock, error) {
+	keyName, err := validLockKeyName(keyName)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	lock, err := s.get(ctx, projectID, keyName)
+	if err != nil {
+		return nil, err
+	}
+	// 锁过期后可能有人修改了译文，编辑器应重新获取锁并加载最新的译文
+	if lock == nil || lock.UserID != userID || !now.Before(lock.ExpiresAt) {
+		return nil, domain.ErrKeyLockNotHeld
+	}
+	lock.ExpiresAt = now.Add(keyLockTTL)
+	if err := s.save(ctx, lock); err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// Release 释放用户持有的编辑锁
+func (s *KeyLockService) Release(ctx context.Context, projectID uint64, keyName string, userID uint64) error {
+	keyName, err := validLockKeyName(keyName)
+	if err != nil {
+		return err
+	}
+	lock, err := s.get(ctx, projectID, keyName)
+	if err != nil {
+		return err
+	}
+	// 没有锁或锁由其他用户持有时不做处理
+	if lock == nil || lock.UserID != userID {
+		return nil
+	}
+	return s.cacheService.HDel(ctx, domain.CacheKeys.KeyLocks(projectID), keyName)
+}
+
+// List 获取项目中有效的编辑锁
+func (s *KeyLockService) List(ctx context.Context, projectID uint64) ([]*domain.KeyLock, error) {
+	fields, err := s.cacheService.HGetAll(ctx, domain.CacheKeys.KeyLocks(projectID))
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return []*domain.KeyLock{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	locks := make([]*domain.KeyLock, 0, len(fields))
+	var expired []string
+	for keyName, value := range fields {
+		var lock domain.KeyLock
+		if err := json.Unmarshal([]byte(value), &lock); err != nil || !now.Before(lock.ExpiresAt) {
+			expired = append(expired, keyName)
+			continue
+		}
+		locks = append(locks, &lock)
+	}
+	if len(expired) > 0 {
+		_ = s.cacheService.HDel(ctx, domain.CacheKeys.KeyLocks(projectID), expired...)
+	}
+
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].KeyName < locks[j].KeyName
+	})
+	return locks, nil
+}
+
+// get 读取键的编辑锁，没有锁时返回 nil；返回的锁可能已过期
+func (s *KeyLockService) get(ctx context.Context, projectID uint64, keyName string) (*domain.KeyLock, error) {
+	value, err := s.cacheService.HGet(ctx, domain.CacheKeys.KeyLocks(projectID), keyName)
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lock domain.KeyLock
+	if err := json.Unmarshal([]byte(value), &lock); err != nil {
+		return nil, nil
+	}
+	return &lock, nil
+}
+
+// save 写入编辑锁，项目的哈希表随之续期
+func (s *KeyLockService) save(ctx context.Context, lock *domain.KeyLock) error {
+	value, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	key := domain.CacheKeys.KeyLocks(lock.ProjectID)
+	if err := s.cacheService.HSet(ctx, key, lock.KeyName, string(value)); err != nil {
+		return err
+	}
+	return s.cacheService.Expire(ctx, key, keyLockTTL)
+}
+
+// validLockKeyName 校验并规范化要锁定的键名
+func validLockKeyName(keyName string) (string, error) {
+	keyName = strings.TrimSpace(keyName)
+	if keyName == "" || utf8.RuneCountInString(keyName) > maxKeyNameLength {
+		return "", domain.ErrInvalidKey
+	}
+	return keyName, nil
+}
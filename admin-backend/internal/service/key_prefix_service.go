@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+	"yflow/internal/domain"
+)
+
+const (
+	// maxKeyPrefixSamples 结果中返回的示例键数量
+	maxKeyPrefixSamples = 20
+	// maxKeyNameLength 键名最大长度，与 translations.key_name 列长度一致
+	maxKeyNameLength = 255
+	// keyPrefixExportPageSize 导出时每次读取的键数量
+	keyPrefixExportPageSize = 1000
+)
+
+// KeyPrefixService 按键名前缀批量操作服务实现
+type KeyPrefixService struct {
+	translationRepo domain.TranslationRepository
+	projectRepo     domain.ProjectRepository
+	auditLogRepo    domain.AuditLogRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+}
+
+// NewKeyPrefixService 创建按键名前缀批量操作服务实例
+// 翻译缓存由 translation.updated 事件的订阅方清除
+func NewKeyPrefixService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *KeyPrefixService {
+	return &KeyPrefixService{
+		translationRepo: translationRepo,
+		projectRepo:     projectRepo,
+		auditLogRepo:    auditLogRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+	}
+}
+
+// Delete 删除以前缀开头的所有键
+func (s *KeyPrefixService) Delete(ctx context.Context, projectID uint64, params domain.KeyPrefixParams, userID uint64) (*domain.KeyPrefixResult, error) {
+	result, keyNames, err := s.preview(ctx, projectID, params.Prefix, params.Preview)
+	if err != nil || result.Preview || len(keyNames) == 0 {
+		return result, err
+	}
+	if err := ensureProjectsUnprotected(ctx, s.projectRepo, projectID); err != nil {
+		return nil, err
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		affected, err := s.translationRepo.DeleteByPrefix(ctx, projectID, result.Prefix, userID)
+		if err != nil {
+			return err
+		}
+		result.Translations = affected
+		return s.commit(ctx, projectID, userID, domain.AuditActionKeyPrefixDelete, result, domain.TranslationActionDeleted, keyNames)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ChangeStatus 修改以前缀开头的所有键的状态
+func (s *KeyPrefixService) ChangeStatus(ctx context.Context, projectID uint64, params domain.KeyPrefixParams, userID uint64) (*domain.KeyPrefixResult, error) {
+	if params.Status != "active" && params.Status != "deprecated" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	result, keyNames, err := s.preview(ctx, projectID, params.Prefix, params.Preview)
+	if err != nil {
+		return nil, err
+	}
+	result.Status = params.Status
+	if result.Preview || len(keyNames) == 0 {
+		return result, nil
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		affected, err := s.translationRepo.UpdateStatusByPrefix(ctx, projectID, result.Prefix, params.Status, userID)
+		if err != nil {
+			return err
+		}
+		result.Translations = affected
+		return s.commit(ctx, projectID, userID, domain.AuditActionKeyPrefixStatus, result, domain.TranslationActionUpdated, keyNames)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Rename 将以前缀开头的所有键改为以新前缀开头，如 checkout.* → payment.*
+// 重命名后的键名与未删除的键冲突时拒绝执行，预览时在结果中列出冲突的键；与已删除的键重名时清除已删除的记录
+func (s *KeyPrefixService) Rename(ctx context.Context, projectID uint64, params domain.KeyPrefixParams, userID uint64) (*domain.KeyPrefixResult, error) {
+	newPrefix := normalizeKeyPrefix(params.NewPrefix)
+	if newPrefix == "" {
+		return nil, domain.ErrInvalidKeyPrefix
+	}
+	prefix := normalizeKeyPrefix(params.Prefix)
+	// 一个前缀包含另一个时，重命名结果会与原键重叠
+	if strings.HasPrefix(newPrefix, prefix) || strings.HasPrefix(prefix, newPrefix) {
+		return nil, domain.ErrInvalidKeyPrefix
+	}
+
+	result, keyNames, err := s.preview(ctx, projectID, params.Prefix, params.Preview)
+	if err != nil {
+		return nil, err
+	}
+	result.NewPrefix = newPrefix
+	if len(keyNames) == 0 {
+		return result, nil
+	}
+
+	newKeyNames := make([]string, len(keyNames))
+	for i, keyName := range keyNames {
+		newKeyNames[i] = newPrefix + strings.TrimPrefix(keyName, prefix)
+		if utf8.RuneCountInString(newKeyNames[i]) > maxKeyNameLength {
+			return nil, domain.ErrInvalidKey
+		}
+	}
+	conflicts, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, newKeyNames)
+	if err != nil {
+		return nil, err
+	}
+	result.Conflicts = conflicts
+	if result.Preview {
+		return result, nil
+	}
+	if len(conflicts) > 0 {
+		return nil, domain.ErrKeyPrefixConflict
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		affected, err := s.translationRepo.RenamePrefix(ctx, projectID, prefix, newPrefix, userID)
+		if err != nil {
+			if isDuplicateKeyError(err) {
+				return domain.ErrKeyPrefixConflict
+			}
+			return err
+		}
+		result.Translations = affected
+		// 订阅方需要同时知道旧键和新键
+		return s.commit(ctx, projectID, userID, domain.AuditActionKeyPrefixRename, result, domain.TranslationActionRenamed, append(keyNames, newKeyNames...))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Export 导出以前缀开头的所有有效翻译
+func (s *KeyPrefixService) Export(ctx context.Context, projectID uint64, params domain.KeyPrefixParams, userID uint64) (*domain.KeyPrefixResult, error) {
+	result, keyNames, err := s.preview(ctx, projectID, params.Prefix, params.Preview)
+	if err != nil || result.Preview || len(keyNames) == 0 {
+		return result, err
+	}
+
+	result.Data = make(map[string]map[string]string)
+	query := domain.TranslationKeyPageQuery{
+		ProjectID: projectID,
+		KeyPrefix: result.Prefix,
+		Limit:     keyPrefixExportPageSize,
+	}
+	for {
+		page, err := s.translationRepo.GetKeyPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for keyName, values := range page.Translations {
+			result.Data[keyName] = values
+		}
+		if !page.HasMore {
+			break
+		}
+		query.AfterKey = page.LastKey
+	}
+
+	// 审计日志只记录导出范围，不记录导出内容
+	summary := *result
+	summary.Data = nil
+	if err := recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionKeyPrefixExport, summary); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// preview 校验前缀并统计受影响的键和翻译
+func (s *KeyPrefixService) preview(ctx context.Context, projectID uint64, rawPrefix string, preview bool) (*domain.KeyPrefixResult, []string, error) {
+	prefix := normalizeKeyPrefix(rawPrefix)
+	if prefix == "" {
+		return nil, nil, domain.ErrInvalidKeyPrefix
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, nil, domain.ErrProjectNotFound
+	}
+
+	keyNames, err := s.translationRepo.GetKeyNamesByPrefix(ctx, projectID, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	count, err := s.translationRepo.CountByPrefix(ctx, projectID, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	samples := keyNames
+	if len(samples) > maxKeyPrefixSamples {
+		samples = samples[:maxKeyPrefixSamples]
+	}
+	return &domain.KeyPrefixResult{
+		Prefix:       prefix,
+		Preview:      preview,
+		Keys:         len(keyNames),
+		Translations: count,
+		SampleKeys:   append([]string{}, samples...),
+	}, keyNames, nil
+}
+
+// commit 写入审计日志并发布翻译变更事件，应在操作的事务中调用
+func (s *KeyPrefixService) commit(ctx context.Context, projectID, userID uint64, auditAction string, result *domain.KeyPrefixResult, eventAction string, keyNames []string) error {
+	if err := recordAudit(ctx, s.auditLogRepo, projectID, userID, auditAction, result); err != nil {
+		return err
+	}
+	return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+		Action:   eventAction,
+		KeyNames: keyNames,
+		Count:    int(result.Translations),
+	})
+}
+
+// normalizeKeyPrefix 去掉前缀两端的空白和末尾的通配符 *
+func normalizeKeyPrefix(prefix string) string {
+	return strings.TrimSuffix(strings.TrimSpace(prefix), "*")
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"yflow/internal/domain"
+)
+
+// KeyPushService CLI 推送键服务实现
+type KeyPushService struct {
+	translationService domain.TranslationService
+	languageService    domain.LanguageService
+}
+
+// NewKeyPushService 创建推送键服务实例
+func NewKeyPushService(translationService domain.TranslationService, languageService domain.LanguageService) *KeyPushService {
+	return &KeyPushService{
+		translationService: translationService,
+		languageService:    languageService,
+	}
+}
+
+// Push 推送新键或批量导入翻译
+// 语言按 ctx 中的组织读取，调用方应先设置项目所属的组织
+func (s *KeyPushService) Push(ctx context.Context, params domain.PushKeysParams) (*domain.PushKeysResult, error) {
+	// 获取所有语言
+	languages, err := s.languageService.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取语言列表失败: %w", err)
+	}
+
+	// 获取现有的翻译键，用于判断新增或已存在
+	matrix, _, err := s.translationService.GetMatrix(ctx, params.ProjectID, -1, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("获取现有翻译失败: %w", err)
+	}
+
+	// 判断操作类型：批量导入或推送键
+	if len(params.Keys) == 0 && len(params.Translations) > 0 {
+		return s.bulkImport(ctx, params, languages, matrix), nil
+	}
+	return s.pushKeys(ctx, params, languages, matrix), nil
+}
+
+// bulkImport 批量导入翻译，已存在的键更新翻译值
+func (s *KeyPushService) bulkImport(
+	ctx context.Context,
+	params domain.PushKeysParams,
+	languages []*domain.Language,
+	matrix map[string]map[string]domain.TranslationCell,
+) *domain.PushKeysResult {
+	// 创建语言代码到ID的映射
+	languageCodeToID := make(map[string]uint64)
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	var added []string
+	var existed []string
+	var failed []string
+
+	// 收集所有要导入的翻译
+	var inputs []domain.TranslationInput
+
+	for langCode, langTranslations := range params.Translations {
+		langID, exists := languageCodeToID[langCode]
+		if !exists {
+			// 忽略未知语言
+			continue
+		}
+
+		for key, value := range langTranslations {
+			// 跳过空值
+			if value == "" {
+				continue
+			}
+
+			// 判断是新增还是更新
+			if _, keyExists := matrix[key]; keyExists {
+				if !containsString(existed, key) {
+					existed = append(existed, key)
+				}
+			} else {
+				if !containsString(added, key) && !containsString(existed, key) {
+					added = append(added, key)
+				}
+			}
+
+			inputs = append(inputs, domain.TranslationInput{
+				ProjectID:  params.ProjectID,
+				KeyName:    key,
+				LanguageID: langID,
+				Value:      value,
+			})
+		}
+	}
+
+	if len(inputs) == 0 {
+		return &domain.PushKeysResult{
+			Added:   []string{},
+			Existed: existed,
+			Failed:  []string{},
+		}
+	}
+
+	// 使用 UpsertBatch 进行批量导入/更新
+	if err := s.translationService.UpsertBatch(ctx, inputs); err != nil {
+		// 如果失败，标记所有新增的键为失败
+		failed = append(failed, added...)
+		added = []string{}
+	}
+
+	return &domain.PushKeysResult{
+		Added:   added,
+		Existed: existed,
+		Failed:  failed,
+	}
+}
+
+// pushKeys 为每个新键在所有语言中创建翻译，已存在的键不做修改
+func (s *KeyPushService) pushKeys(
+	ctx context.Context,
+	params domain.PushKeysParams,
+	languages []*domain.Language,
+	matrix map[string]map[string]domain.TranslationCell,
+) *domain.PushKeysResult {
+	// 找到默认语言
+	var defaultLanguage *domain.Language
+	for _, lang := range languages {
+		if lang.IsDefault {
+			defaultLanguage = lang
+			break
+		}
+	}
+	if defaultLanguage == nil && len(languages) > 0 {
+		defaultLanguage = languages[0]
+	}
+
+	var added []string
+	var existed []string
+	var failed []string
+
+	// 处理每个键
+	for _, key := range params.Keys {
+		if _, exists := matrix[key]; exists {
+			existed = append(existed, key)
+			continue
+		}
+
+		// 为所有语言创建新的翻译记录
+		keyAdded := false
+		keyFailed := false
+
+		for _, language := range languages {
+			// 确定翻译值
+			var value string
+
+			// 优先使用新的多语言数据结构
+			if params.Translations != nil {
+				if langTranslations, exists := params.Translations[language.Code]; exists {
+					value = langTranslations[key]
+				}
+			} else {
+				// 向后兼容：使用旧的 Defaults 字段
+				if language.Code == defaultLanguage.Code {
+					value = params.Defaults[key]
+				}
+			}
+
+			input := domain.TranslationInput{
+				ProjectID:  params.ProjectID,
+				KeyName:    key,
+				LanguageID: language.ID,
+				Value:      value,
+			}
+
+			if _, err := s.translationService.Create(ctx, input, 1); err != nil {
+				keyFailed = true
+			} else {
+				keyAdded = true
+			}
+		}
+
+		if keyFailed && !keyAdded {
+			failed = append(failed, key)
+		} else if keyAdded {
+			added = append(added, key)
+		}
+	}
+
+	return &domain.PushKeysResult{
+		Added:   added,
+		Existed: existed,
+		Failed:  failed,
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+// KeyRenameService 翻译键重命名服务实现
+// 在一个事务中修改键在所有语言的翻译、未合并分支中的翻译以及引用该键的附件、任务分配和设计稿图层，
+// 避免逐条修改翻译时键被拆分成新旧两个
+type KeyRenameService struct {
+	translationRepo domain.TranslationRepository
+	branchRepo      domain.BranchRepository
+	attachmentRepo  domain.AttachmentRepository
+	assignmentRepo  domain.AssignmentRepository
+	figmaRepo       domain.FigmaRepository
+	projectRepo     domain.ProjectRepository
+	auditLogRepo    domain.AuditLogRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+}
+
+// NewKeyRenameService 创建翻译键重命名服务实例
+// 翻译缓存由 translation.updated 事件的订阅方清除
+func NewKeyRenameService(
+	translationRepo domain.TranslationRepository,
+	branchRepo domain.BranchRepository,
+	attachmentRepo domain.AttachmentRepository,
+	assignmentRepo domain.AssignmentRepository,
+	figmaRepo domain.FigmaRepository,
+	projectRepo domain.ProjectRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *KeyRenameService {
+	return &KeyRenameService{
+		translationRepo: translationRepo,
+		branchRepo:      branchRepo,
+		attachmentRepo:  attachmentRepo,
+		assignmentRepo:  assignmentRepo,
+		figmaRepo:       figmaRepo,
+		projectRepo:     projectRepo,
+		auditLogRepo:    auditLogRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+	}
+}
+
+// Rename 将键改为新键名
+// 新键名与未删除的键相同，或与未合并分支中同一语言的翻译冲突时返回 ErrKeyExists；与已删除的键重名时清除已删除的记录。
+// 每条翻译记录一条 rename 变更历史，并写入审计日志
+func (s *KeyRenameService) Rename(ctx context.Context, projectID uint64, params domain.KeyRenameParams, userID uint64) (*domain.KeyRenameResult, error) {
+	keyName := strings.TrimSpace(params.KeyName)
+	newKeyName := strings.TrimSpace(params.NewKeyName)
+	if keyName == "" || newKeyName == "" || keyName == newKeyName || utf8.RuneCountInString(newKeyName) > maxKeyNameLength {
+		return nil, domain.ErrInvalidKey
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, []string{keyName, newKeyName})
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, name := range existing {
+		if name == newKeyName {
+			return nil, domain.ErrKeyExists
+		}
+		found = found || name == keyName
+	}
+	if !found {
+		return nil, domain.ErrKeyNotFound
+	}
+
+	result := &domain.KeyRenameResult{KeyName: keyName, NewKeyName: newKeyName}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		steps := []struct {
+			count  *int64
+			rename func(ctx context.Context) (int64, error)
+		}{
+			{&result.Translations, func(ctx context.Context) (int64, error) {
+				return s.translationRepo.RenameKey(ctx, projectID, keyName, newKeyName, userID)
+			}},
+			{&result.BranchTranslations, func(ctx context.Context) (int64, error) {
+				return s.branchRepo.RenameKey(ctx, projectID, keyName, newKeyName)
+			}},
+			{&result.Attachments, func(ctx context.Context) (int64, error) {
+				return s.attachmentRepo.RenameKey(ctx, projectID, keyName, newKeyName)
+			}},
+			{&result.Assignments, func(ctx context.Context) (int64, error) {
+				return s.assignmentRepo.RenameKey(ctx, projectID, keyName, newKeyName)
+			}},
+			{&result.FigmaLayers, func(ctx context.Context) (int64, error) {
+				return s.figmaRepo.RenameKey(ctx, projectID, keyName, newKeyName)
+			}},
+		}
+		for _, step := range steps {
+			count, err := step.rename(ctx)
+			if err != nil {
+				if isDuplicateKeyError(err) {
+					return domain.ErrKeyExists
+				}
+				return err
+			}
+			*step.count = count
+		}
+
+		if err := recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionKeyRename, result); err != nil {
+			return err
+		}
+		// 订阅方需要同时知道旧键和新键
+		return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+			Action:   domain.TranslationActionRenamed,
+			KeyNames: []string{keyName, newKeyName},
+			Count:    int(result.Translations),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -18,7 +18,7 @@ func NewLanguageService(languageRepo domain.LanguageRepository) *LanguageService
 	}
 }
 
-// Create 创建语言
+// Create 在当前组织中创建语言
 func (s *LanguageService) Create(ctx context.Context, params domain.CreateLanguageParams, userID uint64) (*domain.Language, error) {
 	// 验证语言代码格式
 	code := strings.TrimSpace(params.Code)
@@ -41,12 +41,13 @@ func (s *LanguageService) Create(ctx context.Context, params domain.CreateLangua
 
 	// 创建语言
 	language := &domain.Language{
-		Code:      code,
-		Name:      strings.TrimSpace(params.Name),
-		IsDefault: params.IsDefault,
-		Status:    "active",
-		CreatedBy: userID,
-		UpdatedBy: userID,
+		OrganizationID: domain.OrganizationFromContext(ctx),
+		Code:           code,
+		Name:           strings.TrimSpace(params.Name),
+		IsDefault:      params.IsDefault,
+		Status:         "active",
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
 	}
 
 	if err := s.languageRepo.Create(ctx, language); err != nil {
@@ -69,7 +70,7 @@ func (s *LanguageService) GetAll(ctx context.Context) ([]*domain.Language, error
 // Update 更新语言
 func (s *LanguageService) Update(ctx context.Context, id uint64, params domain.CreateLanguageParams, userID uint64) (*domain.Language, error) {
 	// 获取现有语言
-	language, err := s.languageRepo.GetByID(ctx, id)
+	language, err := s.getInOrganization(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +118,7 @@ func (s *LanguageService) Update(ctx context.Context, id uint64, params domain.C
 // Delete 删除语言
 func (s *LanguageService) Delete(ctx context.Context, id uint64) error {
 	// 检查语言是否存在
-	language, err := s.languageRepo.GetByID(ctx, id)
+	language, err := s.getInOrganization(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -131,6 +132,18 @@ func (s *LanguageService) Delete(ctx context.Context, id uint64) error {
 	return s.languageRepo.Delete(ctx, id)
 }
 
+// getInOrganization 获取当前组织的语言，其他组织的语言视为不存在
+func (s *LanguageService) getInOrganization(ctx context.Context, id uint64) (*domain.Language, error) {
+	language, err := s.languageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if language.OrganizationID != domain.OrganizationFromContext(ctx) {
+		return nil, domain.ErrLanguageNotFound
+	}
+	return language, nil
+}
+
 // clearDefaultLanguage 清除其他语言的默认设置
 func (s *LanguageService) clearDefaultLanguage(ctx context.Context) error {
 	defaultLanguage, err := s.languageRepo.GetDefault(ctx)
@@ -31,14 +31,18 @@ func (s *CachedLanguageService) Create(ctx context.Context, params domain.Create
 		return nil, err
 	}
 
-	// 清除语言列表缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetLanguagesKey())
+	// 清除当前组织的语言列表缓存
+	s.cacheService.Delete(ctx, domain.CacheKeys.OrganizationLanguages(domain.OrganizationFromContext(ctx)))
 
-	// 清除所有项目的翻译矩阵缓存，因为新增语言可能影响所有项目
-	s.cacheService.DeleteByPattern(ctx, domain.TranslationMatrixPrefix+"*")
+	// 清除所有项目的翻译矩阵和统计缓存，因为新增语言可能影响所有项目
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllProjectsPattern(domain.CacheSectionMatrix))
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllProjectsPattern(domain.CacheSectionStats))
+
+	// 清除 HTTP 响应缓存，语言变化会影响语言列表和所有项目的翻译数据
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllResponsesPattern())
 
 	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	s.cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
 
 	return language, nil
 }
@@ -49,9 +53,9 @@ func (s *CachedLanguageService) GetByID(ctx context.Context, id uint64) (*domain
 	return s.languageService.GetByID(ctx, id)
 }
 
-// GetAll 获取所有语言（使用缓存）
+// GetAll 获取当前组织的所有语言（使用缓存）
 func (s *CachedLanguageService) GetAll(ctx context.Context) ([]*domain.Language, error) {
-	cacheKey := s.cacheService.GetLanguagesKey()
+	cacheKey := domain.CacheKeys.OrganizationLanguages(domain.OrganizationFromContext(ctx))
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.mutexManager.GetMutex(cacheKey)
@@ -90,14 +94,18 @@ func (s *CachedLanguageService) Update(ctx context.Context, id uint64, params do
 		return nil, err
 	}
 
-	// 清除语言列表缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetLanguagesKey())
+	// 清除当前组织的语言列表缓存
+	s.cacheService.Delete(ctx, domain.CacheKeys.OrganizationLanguages(domain.OrganizationFromContext(ctx)))
+
+	// 清除所有项目的翻译矩阵和统计缓存，因为语言变更可能影响所有项目
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllProjectsPattern(domain.CacheSectionMatrix))
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllProjectsPattern(domain.CacheSectionStats))
 
-	// 清除所有项目的翻译矩阵缓存，因为语言变更可能影响所有项目
-	s.cacheService.DeleteByPattern(ctx, domain.TranslationMatrixPrefix+"*")
+	// 清除 HTTP 响应缓存，语言变化会影响语言列表和所有项目的翻译数据
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllResponsesPattern())
 
 	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	s.cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
 
 	return language, nil
 }
@@ -109,14 +117,18 @@ func (s *CachedLanguageService) Delete(ctx context.Context, id uint64) error {
 		return err
 	}
 
-	// 清除语言列表缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetLanguagesKey())
+	// 清除当前组织的语言列表缓存
+	s.cacheService.Delete(ctx, domain.CacheKeys.OrganizationLanguages(domain.OrganizationFromContext(ctx)))
+
+	// 清除所有项目的翻译矩阵和统计缓存，因为删除语言可能影响所有项目
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllProjectsPattern(domain.CacheSectionMatrix))
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllProjectsPattern(domain.CacheSectionStats))
 
-	// 清除所有项目的翻译矩阵缓存，因为删除语言可能影响所有项目
-	s.cacheService.DeleteByPattern(ctx, domain.TranslationMatrixPrefix+"*")
+	// 清除 HTTP 响应缓存，语言变化会影响语言列表和所有项目的翻译数据
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.AllResponsesPattern())
 
 	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	s.cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
 
 	return nil
 }
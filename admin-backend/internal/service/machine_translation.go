@@ -35,6 +35,8 @@ func (s *LibreTranslateService) Translate(ctx context.Context, text, sourceLang,
 	if sourceLang == "auto" {
 		sourceLang = "auto"
 	}
+	// 同时接受 YFlow 语言代码
+	sourceLang, targetLang = ToLibreTranslateCode(sourceLang), ToLibreTranslateCode(targetLang)
 
 	url := fmt.Sprintf("%s/translate", s.cfg.URL)
 
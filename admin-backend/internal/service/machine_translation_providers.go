@@ -0,0 +1,364 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+)
+
+const (
+	// deepLBatchSize DeepL 单次请求最多翻译的文本数量
+	deepLBatchSize = 50
+	// googleBatchSize Google Cloud Translation 单次请求最多翻译的文本数量
+	googleBatchSize = 128
+	// machineTranslationTimeout 调用机器翻译接口的超时时间
+	machineTranslationTimeout = 30 * time.Second
+)
+
+// DeepLService DeepL 机器翻译服务实现
+// 语言代码可以使用 YFlow 代码（zh_CN、en_US）或 BCP 47 代码（zh-Hans、en-US），调用时转换为 DeepL 代码
+type DeepLService struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewDeepLService 创建 DeepL 服务实例，未配置地址时按 API Key 选择免费版或专业版的地址
+func NewDeepLService(cfg *config.MachineTranslationConfig) *DeepLService {
+	baseURL := cfg.DeepLURL
+	if baseURL == "" {
+		baseURL = "https://api.deepl.com"
+		if strings.HasSuffix(cfg.DeepLAPIKey, ":fx") {
+			baseURL = "https://api-free.deepl.com"
+		}
+	}
+	return &DeepLService{
+		apiKey:  cfg.DeepLAPIKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: machineTranslationTimeout},
+	}
+}
+
+// Translate 单条翻译
+func (s *DeepLService) Translate(ctx context.Context, text, sourceLang, targetLang string) (*domain.MachineTranslationResult, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+	results, err := s.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// TranslateBatch 批量翻译，每次请求最多 50 条，任一请求失败时返回错误
+func (s *DeepLService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]*domain.MachineTranslationResult, error) {
+	results := make([]*domain.MachineTranslationResult, 0, len(texts))
+	for start := 0; start < len(texts); start += deepLBatchSize {
+		end := start + deepLBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		payload := map[string]interface{}{
+			"text":        texts[start:end],
+			"target_lang": toDeepLTargetCode(targetLang),
+		}
+		if source := toDeepLSourceCode(sourceLang); source != "" {
+			payload["source_lang"] = source
+		}
+		var result struct {
+			Translations []struct {
+				DetectedSourceLanguage string `json:"detected_source_language"`
+				Text                   string `json:"text"`
+			} `json:"translations"`
+		}
+		if err := s.call(ctx, http.MethodPost, "/v2/translate", payload, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Translations) != end-start {
+			return nil, fmt.Errorf("DeepL returned %d translations for %d texts", len(result.Translations), end-start)
+		}
+		for _, translation := range result.Translations {
+			results = append(results, &domain.MachineTranslationResult{
+				TranslatedText:     translation.Text,
+				DetectedSourceLang: fromProviderCode(translation.DetectedSourceLanguage),
+			})
+		}
+	}
+	return results, nil
+}
+
+// GetSupportedLanguages 获取支持的目标语言，代码转换为 BCP 47 形式（如 de、en-US、zh-Hans）
+func (s *DeepLService) GetSupportedLanguages(ctx context.Context) ([]domain.MachineTranslationLanguage, error) {
+	var result []struct {
+		Language string `json:"language"`
+		Name     string `json:"name"`
+	}
+	if err := s.call(ctx, http.MethodGet, "/v2/languages?type=target", nil, &result); err != nil {
+		return nil, err
+	}
+	languages := make([]domain.MachineTranslationLanguage, 0, len(result))
+	for _, language := range result {
+		languages = append(languages, domain.MachineTranslationLanguage{Code: fromProviderCode(language.Language), Name: language.Name})
+	}
+	return languages, nil
+}
+
+// IsAvailable 检查服务是否可用（API Key 有效）
+func (s *DeepLService) IsAvailable(ctx context.Context) bool {
+	var usage map[string]interface{}
+	return s.call(ctx, http.MethodGet, "/v2/usage", nil, &usage) == nil
+}
+
+// call 调用 DeepL 接口，payload 不为空时以 JSON 发送
+func (s *DeepLService) call(ctx context.Context, method, path string, payload, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+s.apiKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doMachineTranslationRequest(s.client, req, "DeepL", result)
+}
+
+// GoogleTranslateService Google Cloud Translation（v2）机器翻译服务实现
+// 语言代码的处理方式与 DeepLService 相同
+type GoogleTranslateService struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewGoogleTranslateService 创建 Google Cloud Translation 服务实例
+func NewGoogleTranslateService(cfg *config.MachineTranslationConfig) *GoogleTranslateService {
+	return &GoogleTranslateService{
+		apiKey:  cfg.GoogleAPIKey,
+		baseURL: strings.TrimSuffix(cfg.GoogleURL, "/"),
+		client:  &http.Client{Timeout: machineTranslationTimeout},
+	}
+}
+
+// Translate 单条翻译
+func (s *GoogleTranslateService) Translate(ctx context.Context, text, sourceLang, targetLang string) (*domain.MachineTranslationResult, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+	results, err := s.TranslateBatch(ctx, []string{text}, sourceLang, targetLang)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// TranslateBatch 批量翻译，每次请求最多 128 条，任一请求失败时返回错误
+func (s *GoogleTranslateService) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]*domain.MachineTranslationResult, error) {
+	results := make([]*domain.MachineTranslationResult, 0, len(texts))
+	for start := 0; start < len(texts); start += googleBatchSize {
+		end := start + googleBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		payload := map[string]interface{}{
+			"q":      texts[start:end],
+			"target": toGoogleCode(targetLang),
+			"format": "text",
+		}
+		if source := toGoogleCode(sourceLang); source != "" {
+			payload["source"] = source
+		}
+		var result struct {
+			Data struct {
+				Translations []struct {
+					TranslatedText         string `json:"translatedText"`
+					DetectedSourceLanguage string `json:"detectedSourceLanguage"`
+				} `json:"translations"`
+			} `json:"data"`
+		}
+		if err := s.call(ctx, http.MethodPost, "/language/translate/v2", nil, payload, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Data.Translations) != end-start {
+			return nil, fmt.Errorf("Google Translate returned %d translations for %d texts", len(result.Data.Translations), end-start)
+		}
+		for _, translation := range result.Data.Translations {
+			results = append(results, &domain.MachineTranslationResult{
+				TranslatedText:     translation.TranslatedText,
+				DetectedSourceLang: translation.DetectedSourceLanguage,
+			})
+		}
+	}
+	return results, nil
+}
+
+// GetSupportedLanguages 获取支持的语言，名称为英文
+func (s *GoogleTranslateService) GetSupportedLanguages(ctx context.Context) ([]domain.MachineTranslationLanguage, error) {
+	var result struct {
+		Data struct {
+			Languages []struct {
+				Language string `json:"language"`
+				Name     string `json:"name"`
+			} `json:"languages"`
+		} `json:"data"`
+	}
+	if err := s.call(ctx, http.MethodGet, "/language/translate/v2/languages", url.Values{"target": {"en"}}, nil, &result); err != nil {
+		return nil, err
+	}
+	languages := make([]domain.MachineTranslationLanguage, 0, len(result.Data.Languages))
+	for _, language := range result.Data.Languages {
+		languages = append(languages, domain.MachineTranslationLanguage{Code: language.Language, Name: language.Name})
+	}
+	return languages, nil
+}
+
+// IsAvailable 检查服务是否可用（API Key 有效）
+func (s *GoogleTranslateService) IsAvailable(ctx context.Context) bool {
+	_, err := s.GetSupportedLanguages(ctx)
+	return err == nil
+}
+
+// call 调用 Google Cloud Translation 接口，API Key 通过查询参数传递
+func (s *GoogleTranslateService) call(ctx context.Context, method, path string, query url.Values, payload, result interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("key", s.apiKey)
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path+"?"+query.Encode(), body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doMachineTranslationRequest(s.client, req, "Google Translate", result)
+}
+
+// doMachineTranslationRequest 发送请求并解析 JSON 响应，非 200 响应返回包含响应内容的错误
+func doMachineTranslationRequest(client *http.Client, req *http.Request, provider string, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s API: %w", provider, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s API returned status %d: %s", provider, resp.StatusCode, truncateRunes(string(body), 500))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// normalizeMachineTranslationCode 将 YFlow 或 BCP 47 语言代码统一为小写、以连字符分隔的形式，auto 视为未指定
+func normalizeMachineTranslationCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "_", "-"))
+	if code == "auto" {
+		return ""
+	}
+	return code
+}
+
+// isTraditionalChinese 判断规范化后的中文代码是否为繁体
+func isTraditionalChinese(code string) bool {
+	switch code {
+	case "zh-tw", "zh-hk", "zh-mo", "zh-hant", "zh-hant-tw", "zh-hant-hk":
+		return true
+	}
+	return false
+}
+
+// baseLanguage 规范化代码中的语言部分
+func baseLanguage(code string) string {
+	if i := strings.IndexByte(code, '-'); i >= 0 {
+		return code[:i]
+	}
+	return code
+}
+
+// toDeepLTargetCode 转换为 DeepL 的目标语言代码：英语、葡萄牙语和中文需要指定变体
+func toDeepLTargetCode(code string) string {
+	code = normalizeMachineTranslationCode(code)
+	switch base := baseLanguage(code); {
+	case base == "zh" && isTraditionalChinese(code):
+		return "ZH-HANT"
+	case base == "zh":
+		return "ZH-HANS"
+	case base == "en" && code == "en-gb":
+		return "EN-GB"
+	case base == "en":
+		return "EN-US"
+	case base == "pt" && code == "pt-br":
+		return "PT-BR"
+	case base == "pt":
+		return "PT-PT"
+	default:
+		return strings.ToUpper(base)
+	}
+}
+
+// toDeepLSourceCode 转换为 DeepL 的源语言代码，源语言不区分变体，未指定时返回空字符串（自动检测）
+func toDeepLSourceCode(code string) string {
+	return strings.ToUpper(baseLanguage(normalizeMachineTranslationCode(code)))
+}
+
+// toGoogleCode 转换为 Google Cloud Translation 的语言代码：中文区分简繁，其余只使用语言部分
+func toGoogleCode(code string) string {
+	code = normalizeMachineTranslationCode(code)
+	switch base := baseLanguage(code); {
+	case base == "zh" && isTraditionalChinese(code):
+		return "zh-TW"
+	case base == "zh":
+		return "zh-CN"
+	default:
+		return base
+	}
+}
+
+// fromProviderCode 将服务商返回的大写语言代码（如 DE、EN-US、ZH-HANS）转换为 BCP 47 形式（de、en-US、zh-Hans）
+func fromProviderCode(code string) string {
+	parts := strings.Split(code, "-")
+	for i, part := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(part)
+		case len(part) == 4:
+			parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+		default:
+			parts[i] = strings.ToUpper(part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"yflow/internal/domain"
+)
+
+// SMTPMailer 通过 SMTP 发送邮件
+// 未配置服务器地址时 Enabled 返回 false，Send 返回 ErrMailNotConfigured
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer 创建 SMTP 邮件发送实例，username 为空时不进行认证
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Enabled 是否已配置 SMTP 服务器
+func (m *SMTPMailer) Enabled() bool {
+	return m.host != ""
+}
+
+// Send 发送 UTF-8 纯文本邮件
+// 服务器支持 STARTTLS 时自动加密，认证使用 PLAIN（要求加密连接或本机服务器）
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if !m.Enabled() {
+		return domain.ErrMailNotConfigured
+	}
+	if strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("invalid recipient address: %q", to)
+	}
+
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	return smtp.SendMail(addr, auth, m.from, []string{to}, buildMailMessage(m.from, to, subject, body))
+}
+
+// buildMailMessage 生成邮件内容，主题按 RFC 2047 编码，正文使用 base64 编码
+func buildMailMessage(from, to, subject, body string) []byte {
+	var msg strings.Builder
+	msg.WriteString("From: " + from + "\r\n")
+	msg.WriteString("To: " + to + "\r\n")
+	msg.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		msg.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	msg.WriteString(encoded + "\r\n")
+	return []byte(msg.String())
+}
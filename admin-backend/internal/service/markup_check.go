@@ -0,0 +1,345 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"yflow/internal/domain"
+)
+
+// allowedMarkupTags 译文中允许的 HTML 标签及各标签允许的属性（另见 allowedMarkupAttrs）
+var allowedMarkupTags = map[string][]string{
+	"a": {"href", "target", "rel", "name"}, "img": {"src", "alt", "width", "height"},
+	"abbr": nil, "b": nil, "blockquote": nil, "br": nil, "code": nil, "del": nil, "em": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil, "hr": nil, "i": nil, "ins": nil,
+	"li": {"value"}, "mark": nil, "ol": {"start"}, "p": nil, "pre": nil, "s": nil, "small": nil, "span": nil,
+	"strong": nil, "sub": nil, "sup": nil, "u": nil, "ul": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+}
+
+// allowedMarkupAttrs 所有允许的标签都可以使用的属性
+var allowedMarkupAttrs = map[string]bool{"class": true, "title": true, "lang": true, "dir": true}
+
+// removedMarkupTags 连同内容一起移除的标签
+var removedMarkupTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true, "template": true,
+}
+
+// voidMarkupTags 没有结束标签的元素
+var voidMarkupTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true,
+	"link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+}
+
+// allowedURLSchemes 链接允许的协议，相对地址和锚点不受限制
+var allowedURLSchemes = map[string]bool{"http": true, "https": true, "mailto": true, "tel": true}
+
+var (
+	markdownLinkPattern     = regexp.MustCompile(`(!?)\[([^\]]*)\]\(\s*<?((?:[^()\s<>]|\([^()\s]*\))*)>?(?:\s+"[^"]*")?\s*\)`)
+	markdownAutolinkPattern = regexp.MustCompile(`<((?:https?|mailto|tel):[^<>\s]+)>`)
+	urlSchemePattern        = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*):`)
+)
+
+// CheckMarkup 检查 markdown 或 html 类型的译文，返回发现的问题（只填写 Type、Message 和 Suggestion）
+// 检查标记是否能正确解析（标签配对、代码块闭合、链接语法完整）以及是否包含不允许的标签、属性或链接协议，
+// 有不允许的内容时 Suggestion 为移除这些内容后的译文；source 不为空时还要求链接和占位符与源语言一致。
+// 只报告问题，不修改译文
+func CheckMarkup(valueType, value, source string) []domain.QAIssue {
+	if value == "" {
+		return nil
+	}
+	analysis := analyzeMarkup(valueType, value)
+
+	var issues []domain.QAIssue
+	if len(analysis.structure) > 0 {
+		issues = append(issues, domain.QAIssue{Type: domain.QAIssueMarkupInvalid, Message: strings.Join(analysis.structure, "；")})
+	}
+	if len(analysis.disallowed) > 0 {
+		issues = append(issues, domain.QAIssue{
+			Type:       domain.QAIssueDisallowedMarkup,
+			Message:    "不允许的内容：" + strings.Join(analysis.disallowed, "、"),
+			Suggestion: analysis.sanitized.String(),
+		})
+	}
+	if source == "" || source == value {
+		return issues
+	}
+
+	sourceAnalysis := analyzeMarkup(valueType, source)
+	if message := compareMarkupItems("链接", sourceAnalysis.links, analysis.links); message != "" {
+		issues = append(issues, domain.QAIssue{Type: domain.QAIssueLinkMismatch, Message: message})
+	}
+	if message := compareMarkupItems("占位符", placeholderNames(source), placeholderNames(value)); message != "" {
+		issues = append(issues, domain.QAIssue{Type: domain.QAIssuePlaceholderMismatch, Message: message})
+	}
+	return issues
+}
+
+// markupAnalysis 标记的分析结果
+type markupAnalysis struct {
+	links      []string
+	structure  []string // 无法正确解析之处
+	disallowed []string
+	sanitized  strings.Builder
+
+	stack    []string // 未关闭的 HTML 标签
+	skipping string   // 正在移除内容的标签
+}
+
+// analyzeMarkup 分析 markdown 或 html 译文；markdown 中的代码块和行内代码按原文保留，不检查其中的 HTML
+func analyzeMarkup(valueType, value string) *markupAnalysis {
+	a := &markupAnalysis{}
+	if valueType == domain.ValueTypeMarkdown {
+		a.analyzeMarkdown(value)
+	} else {
+		a.analyzeHTML(value)
+	}
+	for i := len(a.stack) - 1; i >= 0; i-- {
+		a.structure = append(a.structure, fmt.Sprintf("<%s> 没有关闭", a.stack[i]))
+	}
+	return a
+}
+
+// analyzeMarkdown 拆分代码块和行内代码，其余文本检查链接和内嵌的 HTML
+func (a *markupAnalysis) analyzeMarkdown(value string) {
+	lines := strings.SplitAfter(value, "\n")
+	var text strings.Builder
+	fence := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			a.sanitized.WriteString(line)
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			a.analyzeMarkdownText(text.String())
+			text.Reset()
+			fence = trimmed[:3]
+			a.sanitized.WriteString(line)
+		default:
+			text.WriteString(line)
+		}
+	}
+	if fence != "" {
+		a.structure = append(a.structure, "代码块没有闭合")
+	}
+	a.analyzeMarkdownText(text.String())
+}
+
+// analyzeMarkdownText 处理代码块之外的文本，行内代码按原文保留
+func (a *markupAnalysis) analyzeMarkdownText(text string) {
+	for text != "" {
+		start := strings.IndexByte(text, '`')
+		if start < 0 {
+			a.analyzeMarkdownInline(text)
+			return
+		}
+		a.analyzeMarkdownInline(text[:start])
+		run := len(text[start:]) - len(strings.TrimLeft(text[start:], "`"))
+		delimiter := text[start : start+run]
+		end := strings.Index(text[start+run:], delimiter)
+		if end < 0 {
+			a.structure = append(a.structure, "行内代码没有闭合")
+			a.sanitized.WriteString(text[start:])
+			return
+		}
+		end += start + run + run
+		a.sanitized.WriteString(text[start:end])
+		text = text[end:]
+	}
+}
+
+// analyzeMarkdownInline 检查 markdown 链接和自动链接，其余部分按 HTML 片段处理
+func (a *markupAnalysis) analyzeMarkdownInline(text string) {
+	if strings.Count(text, "](") > len(markdownLinkPattern.FindAllString(text, -1)) {
+		a.structure = append(a.structure, "链接语法不完整")
+	}
+	text = markdownLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownLinkPattern.FindStringSubmatch(link)
+		if !isAllowedURL(match[3]) {
+			// 图片和链接都只保留文字部分
+			a.disallowed = append(a.disallowed, fmt.Sprintf("链接 %s", match[3]))
+			return match[2]
+		}
+		a.links = append(a.links, match[3])
+		return link
+	})
+
+	for text != "" {
+		loc := markdownAutolinkPattern.FindStringIndex(text)
+		if loc == nil {
+			a.analyzeHTML(text)
+			return
+		}
+		a.analyzeHTML(text[:loc[0]])
+		a.links = append(a.links, text[loc[0]+1:loc[1]-1])
+		a.sanitized.WriteString(text[loc[0]:loc[1]])
+		text = text[loc[1]:]
+	}
+}
+
+// analyzeHTML 检查 HTML 片段的标签配对、标签和属性是否允许，并生成移除不允许内容后的文本
+// 文本和允许的标签按原文输出，未知标签只移除标签本身，removedMarkupTags 中的标签连同内容一起移除
+func (a *markupAnalysis) analyzeHTML(fragment string) {
+	z := html.NewTokenizer(strings.NewReader(fragment))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if z.Err() != io.EOF {
+				a.structure = append(a.structure, z.Err().Error())
+			}
+			return
+		}
+		raw := string(z.Raw())
+		token := z.Token()
+		name := token.Data
+
+		if a.skipping != "" {
+			if tt == html.EndTagToken && name == a.skipping {
+				a.skipping = ""
+			}
+			continue
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if removedMarkupTags[name] {
+				a.disallowed = append(a.disallowed, "<"+name+">")
+				if tt == html.StartTagToken && !voidMarkupTags[name] {
+					a.skipping = name
+				}
+				continue
+			}
+			if tt == html.StartTagToken && !voidMarkupTags[name] {
+				a.stack = append(a.stack, name)
+			}
+			allowedAttrs, allowed := allowedMarkupTags[name]
+			if !allowed {
+				a.disallowed = append(a.disallowed, "<"+name+">")
+				continue
+			}
+			a.sanitized.WriteString(a.sanitizeTag(token, raw, allowedAttrs))
+		case html.EndTagToken:
+			if removedMarkupTags[name] || voidMarkupTags[name] {
+				continue
+			}
+			a.closeTag(name)
+			if _, allowed := allowedMarkupTags[name]; allowed {
+				a.sanitized.WriteString(raw)
+			}
+		default:
+			a.sanitized.WriteString(raw)
+		}
+	}
+}
+
+// sanitizeTag 检查允许的标签的属性，有不允许的属性时重新生成标签
+func (a *markupAnalysis) sanitizeTag(token html.Token, raw string, allowedAttrs []string) string {
+	kept := make([]html.Attribute, 0, len(token.Attr))
+	for _, attr := range token.Attr {
+		allowed := allowedMarkupAttrs[attr.Key]
+		for _, name := range allowedAttrs {
+			allowed = allowed || attr.Key == name
+		}
+		switch {
+		case !allowed || strings.HasPrefix(attr.Key, "on"):
+			a.disallowed = append(a.disallowed, fmt.Sprintf("<%s %s>", token.Data, attr.Key))
+		case (attr.Key == "href" || attr.Key == "src") && !isAllowedURL(attr.Val):
+			a.disallowed = append(a.disallowed, fmt.Sprintf("链接 %s", attr.Val))
+		default:
+			if attr.Key == "href" || attr.Key == "src" {
+				a.links = append(a.links, attr.Val)
+			}
+			kept = append(kept, attr)
+		}
+	}
+	if len(kept) == len(token.Attr) {
+		return raw
+	}
+	token.Attr = kept
+	return token.String()
+}
+
+// closeTag 关闭最近的同名标签，其间未关闭的标签和没有对应开始标签的结束标签记为解析问题
+func (a *markupAnalysis) closeTag(name string) {
+	for i := len(a.stack) - 1; i >= 0; i-- {
+		if a.stack[i] != name {
+			continue
+		}
+		for j := len(a.stack) - 1; j > i; j-- {
+			a.structure = append(a.structure, fmt.Sprintf("<%s> 没有关闭", a.stack[j]))
+		}
+		a.stack = a.stack[:i]
+		return
+	}
+	a.structure = append(a.structure, fmt.Sprintf("</%s> 没有对应的开始标签", name))
+}
+
+// isAllowedURL 判断链接的协议是否允许，去除空白和控制字符后判断，防止 java\tscript: 之类的写法
+func isAllowedURL(url string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, url)
+	match := urlSchemePattern.FindStringSubmatch(cleaned)
+	return match == nil || allowedURLSchemes[strings.ToLower(match[1])]
+}
+
+// placeholderNames 译文中的占位符：{{name}} 形式的插值、printf 格式（如 %s、%1$d，%% 不是占位符）
+// 以及 ICU 参数名称；前两种按出现次数列出，ICU 参数按名称去重
+func placeholderNames(value string) []string {
+	var names []string
+	value = mustachePlaceholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		names = append(names, "{{"+mustachePlaceholderPattern.FindStringSubmatch(match)[1]+"}}")
+		return " "
+	})
+	value = strings.ReplaceAll(value, "%%", "")
+	names = append(names, printfPlaceholderPattern.FindAllString(value, -1)...)
+	for name := range ARBPlaceholders(value) {
+		names = append(names, name)
+	}
+	return names
+}
+
+// compareMarkupItems 比较源语言和译文中的链接或占位符（按出现次数），一致时返回空字符串
+func compareMarkupItems(kind string, source, target []string) string {
+	counts := make(map[string]int)
+	for _, item := range source {
+		counts[item]++
+	}
+	for _, item := range target {
+		counts[item]--
+	}
+	var missing, extra []string
+	for item, count := range counts {
+		for ; count > 0; count-- {
+			missing = append(missing, item)
+		}
+		for ; count < 0; count++ {
+			extra = append(extra, item)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return ""
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("缺少%s %s", kind, strings.Join(missing, "、")))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, fmt.Sprintf("多出%s %s", kind, strings.Join(extra, "、")))
+	}
+	return strings.Join(parts, "；") + "（与源语言不一致）"
+}
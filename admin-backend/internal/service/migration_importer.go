@@ -0,0 +1,329 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// 支持迁移的外部 TMS
+const (
+	MigrationSourceLokalise = "lokalise"
+	MigrationSourceCrowdin  = "crowdin"
+	MigrationSourcePOEditor = "poeditor"
+)
+
+// bundleLocalePattern 导出包路径中的语言代码，如 en、zh-CN、zh_Hans、es-419
+var bundleLocalePattern = regexp.MustCompile(`^[a-z]{2}([-_]([A-Za-z]{2,4}|[0-9]{3}))?$`)
+
+// maxMigrationBundleFileSize 迁移包中单个文件的大小上限 (20MB)
+const maxMigrationBundleFileSize = 20 << 20
+
+// MigrationEntry 从外部导出包中解析出的单条翻译
+type MigrationEntry struct {
+	Key         string
+	Language    string // 外部系统的语言代码，尚未映射
+	Value       string
+	Context     string
+	Tags        []string
+	Screenshots int // 键附带的截图数量，截图不导入
+}
+
+// ParseMigrationBundle 解析外部 TMS 的原生导出内容
+// data 可以是 zip 导出包或单个 JSON 文件；单文件不含语言信息时使用 language 参数
+func ParseMigrationBundle(source string, data []byte, language string) ([]MigrationEntry, error) {
+	switch source {
+	case MigrationSourceLokalise, MigrationSourceCrowdin, MigrationSourcePOEditor:
+	default:
+		return nil, domain.ErrUnsupportedMigrationSource
+	}
+
+	if isZipArchive(data) {
+		return parseMigrationZip(source, data)
+	}
+
+	entries, err := parseMigrationFile(source, data, language)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseMigrationZip 解析 zip 导出包，语言从文件路径推断
+// Lokalise 和 Crowdin 的导出包通常为 <locale>/<file>.json 或 <locale>.json
+func parseMigrationZip(source string, data []byte) ([]MigrationEntry, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, domain.ErrInvalidMigrationBundle
+	}
+
+	var entries []MigrationEntry
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".json") {
+			continue
+		}
+		if strings.HasPrefix(path.Base(file.Name), ".") || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+
+		language := localeFromBundlePath(file.Name)
+		if language == "" {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, domain.ErrInvalidMigrationBundle
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxMigrationBundleFileSize+1))
+		rc.Close()
+		if err != nil || len(content) > maxMigrationBundleFileSize {
+			return nil, domain.ErrInvalidMigrationBundle
+		}
+
+		fileEntries, err := parseMigrationFile(source, content, language)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+
+	if len(entries) == 0 {
+		return nil, domain.ErrInvalidMigrationBundle
+	}
+	return entries, nil
+}
+
+// parseMigrationFile 解析单个 JSON 文件
+func parseMigrationFile(source string, data []byte, language string) ([]MigrationEntry, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, domain.ErrInvalidMigrationBundle
+	}
+
+	switch v := raw.(type) {
+	case []interface{}:
+		// POEditor 的 JSON 导出为术语数组
+		if source != MigrationSourcePOEditor {
+			return nil, domain.ErrInvalidMigrationBundle
+		}
+		return parsePOEditorTerms(v, language)
+	case map[string]interface{}:
+		// Lokalise 的 JSON (all keys) 导出包含 keys 数组，自带语言信息
+		if keys, ok := v["keys"].([]interface{}); ok && source == MigrationSourceLokalise {
+			return parseLokaliseKeys(keys), nil
+		}
+		if language == "" {
+			return nil, domain.ErrInvalidMigrationBundle
+		}
+		flat := make(map[string]string)
+		flattenMigrationValue("", v, flat)
+		entries := make([]MigrationEntry, 0, len(flat))
+		for key, value := range flat {
+			entries = append(entries, MigrationEntry{Key: key, Language: language, Value: value})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+		return entries, nil
+	default:
+		return nil, domain.ErrInvalidMigrationBundle
+	}
+}
+
+// parseLokaliseKeys 解析 Lokalise keys 数组
+func parseLokaliseKeys(keys []interface{}) []MigrationEntry {
+	var entries []MigrationEntry
+	for _, item := range keys {
+		keyObj, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		keyName := lokaliseKeyName(keyObj["key_name"])
+		if keyName == "" {
+			continue
+		}
+		description, _ := keyObj["description"].(string)
+		tags := toStringSlice(keyObj["tags"])
+		screenshots, _ := keyObj["screenshots"].([]interface{})
+
+		translations, _ := keyObj["translations"].([]interface{})
+		for _, t := range translations {
+			tObj, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			language, _ := tObj["language_iso"].(string)
+			if language == "" {
+				continue
+			}
+			entries = append(entries, MigrationEntry{
+				Key:         keyName,
+				Language:    language,
+				Value:       migrationValueToString(tObj["translation"]),
+				Context:     description,
+				Tags:        tags,
+				Screenshots: len(screenshots),
+			})
+		}
+	}
+	return entries
+}
+
+// lokaliseKeyName 取 Lokalise 键名，多平台键名优先使用 web
+func lokaliseKeyName(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		for _, platform := range []string{"web", "other", "ios", "android"} {
+			if name, ok := v[platform].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// parsePOEditorTerms 解析 POEditor 术语数组，一次导出只包含一种语言
+func parsePOEditorTerms(terms []interface{}, language string) ([]MigrationEntry, error) {
+	if language == "" {
+		return nil, domain.ErrInvalidMigrationBundle
+	}
+
+	var entries []MigrationEntry
+	for _, item := range terms {
+		term, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := term["term"].(string)
+		if key == "" {
+			continue
+		}
+
+		// POEditor 的 context 是上下文说明，comment 是给译者的备注
+		context, _ := term["context"].(string)
+		if comment, _ := term["comment"].(string); comment != "" {
+			if context != "" {
+				context += "\n"
+			}
+			context += comment
+		}
+
+		entries = append(entries, MigrationEntry{
+			Key:      key,
+			Language: language,
+			Value:    migrationValueToString(term["definition"]),
+			Context:  context,
+			Tags:     toStringSlice(term["tags"]),
+		})
+	}
+	return entries, nil
+}
+
+// flattenMigrationValue 将嵌套 JSON 展平为点分隔的键
+func flattenMigrationValue(prefix string, value interface{}, out map[string]string) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		if prefix != "" {
+			out[prefix] = migrationValueToString(value)
+		}
+		return
+	}
+	for key, child := range obj {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		flattenMigrationValue(fullKey, child, out)
+	}
+}
+
+// migrationValueToString 将翻译值转换为字符串，复数等结构化值保留为 JSON
+func migrationValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// toStringSlice 将 JSON 数组转换为字符串切片
+func toStringSlice(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// localeFromBundlePath 从导出包内的文件路径推断语言代码
+// 优先取第一个像语言代码的目录名，否则使用文件名
+func localeFromBundlePath(name string) string {
+	segments := strings.Split(strings.Trim(name, "/"), "/")
+	for _, segment := range segments[:len(segments)-1] {
+		if bundleLocalePattern.MatchString(segment) {
+			return segment
+		}
+	}
+	base := strings.TrimSuffix(segments[len(segments)-1], path.Ext(name))
+	if bundleLocalePattern.MatchString(base) {
+		return base
+	}
+	return ""
+}
+
+// isZipArchive 检查数据是否为 zip 文件
+func isZipArchive(data []byte) bool {
+	return len(data) >= 4 && bytes.Equal(data[:4], []byte("PK\x03\x04"))
+}
+
+// ResolveLanguageCode 将外部语言代码匹配到本系统的语言
+// 依次尝试：完全匹配、忽略大小写和 -/_ 差异、仅匹配主语言
+func ResolveLanguageCode(code string, languageCodeToID map[string]uint64) (uint64, bool) {
+	if id, ok := languageCodeToID[code]; ok {
+		return id, true
+	}
+
+	normalize := func(c string) string {
+		return strings.ToLower(strings.ReplaceAll(c, "-", "_"))
+	}
+	target := normalize(code)
+	base := strings.SplitN(target, "_", 2)[0]
+
+	var baseMatch uint64
+	baseFound := false
+	for ourCode, id := range languageCodeToID {
+		normalized := normalize(ourCode)
+		if normalized == target {
+			return id, true
+		}
+		if normalized == base {
+			baseMatch = id
+			baseFound = true
+		}
+	}
+	return baseMatch, baseFound
+}
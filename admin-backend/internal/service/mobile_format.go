@@ -0,0 +1,275 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 移动端导出格式
+const (
+	FormatAndroidXML        = "android-xml"
+	FormatAppleStrings      = "apple-strings"
+	FormatAppleStringsDict  = "apple-stringsdict"
+	androidXMLExt           = "xml"
+	appleStringsExt         = "strings"
+	appleStringsDictExt     = "stringsdict"
+	stringsDictValueVarName = "value"
+)
+
+// mobileExportExts 移动端导出格式的文件扩展名
+var mobileExportExts = map[string]string{
+	FormatAndroidXML:       androidXMLExt,
+	FormatAppleStrings:     appleStringsExt,
+	FormatAppleStringsDict: appleStringsDictExt,
+}
+
+// cldrPluralOrder CLDR 复数类别的输出顺序
+var cldrPluralOrder = []string{"zero", "one", "two", "few", "many", "other"}
+
+// androidNameInvalidChars Android 资源名只能包含字母、数字和下划线
+var androidNameInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// stringsDictValueType 复数文本中第一个数值格式说明符的类型（如 %d、%ld、%1$lu）
+var stringsDictValueType = regexp.MustCompile(`%(?:\d+\$)?(l{0,2}[diuxXo]|[feEgG])`)
+
+// MarshalMobileStrings 将一种语言的翻译序列化为移动端格式
+// values 为 键名 -> 值，contexts 为 键名 -> 上下文说明，上下文说明作为注释输出
+func MarshalMobileStrings(format string, values, contexts map[string]string) []byte {
+	switch format {
+	case FormatAndroidXML:
+		return marshalAndroidStrings(values, contexts)
+	case FormatAppleStrings:
+		return marshalAppleStrings(values, contexts)
+	default:
+		return marshalAppleStringsDict(values)
+	}
+}
+
+// groupPluralValues 将键名以复数类别结尾（如 items.one、items_other）且有 other 形式的一组键合并为复数条目，
+// 值为只有一个 plural 参数的 ICU 消息的键按 icuPluralForms 展开为以键名命名的复数条目
+// 返回 复数条目名 -> 复数类别 -> 值，以及其余的普通键
+func groupPluralValues(values map[string]string) (map[string]map[string]string, map[string]string) {
+	groups := make(map[string]map[string]string)
+	for key, value := range values {
+		if prefix, category, ok := splitPluralKey(key); ok {
+			name := prefix[:len(prefix)-1]
+			if groups[name] == nil {
+				groups[name] = make(map[string]string)
+			}
+			groups[name][category] = value
+		}
+	}
+
+	plurals := make(map[string]map[string]string)
+	for name, forms := range groups {
+		if forms["other"] != "" {
+			plurals[name] = forms
+		}
+	}
+	icuKeys := make(map[string]bool)
+	for key, value := range values {
+		if forms, ok := icuPluralForms(value); ok && forms["other"] != "" && plurals[key] == nil {
+			plurals[key] = forms
+			icuKeys[key] = true
+		}
+	}
+	singles := make(map[string]string, len(values))
+	for key, value := range values {
+		if prefix, _, ok := splitPluralKey(key); ok && plurals[prefix[:len(prefix)-1]] != nil {
+			continue
+		}
+		if icuKeys[key] {
+			continue
+		}
+		singles[key] = value
+	}
+	return plurals, singles
+}
+
+// marshalAndroidStrings 生成 Android 的 res/values/strings.xml
+// 键名中的非法字符替换为下划线，替换后重名的键只保留排序在前的一个
+func marshalAndroidStrings(values, contexts map[string]string) []byte {
+	plurals, singles := groupPluralValues(values)
+
+	type resource struct {
+		key     string
+		name    string
+		value   string
+		plurals map[string]string
+	}
+	var resources []resource
+	for key, value := range singles {
+		resources = append(resources, resource{key: key, name: androidResourceName(key), value: value})
+	}
+	for name, forms := range plurals {
+		resources = append(resources, resource{key: name, name: androidResourceName(name), plurals: forms})
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].key < resources[j].key })
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<resources>\n")
+	used := make(map[string]string, len(resources))
+	for _, res := range resources {
+		if original, ok := used[res.name]; ok {
+			buf.WriteString("    <!-- " + escapeXMLComment(res.key+" 与 "+original+" 的资源名相同，已跳过") + " -->\n")
+			continue
+		}
+		used[res.name] = res.key
+
+		if context := contexts[res.key]; context != "" && res.plurals == nil {
+			buf.WriteString("    <!-- " + escapeXMLComment(context) + " -->\n")
+		}
+		if res.plurals == nil {
+			buf.WriteString(`    <string name="` + res.name + `">` + escapeAndroidString(res.value) + "</string>\n")
+			continue
+		}
+		buf.WriteString(`    <plurals name="` + res.name + "\">\n")
+		for _, category := range cldrPluralOrder {
+			if value, ok := res.plurals[category]; ok {
+				buf.WriteString(`        <item quantity="` + category + `">` + escapeAndroidString(value) + "</item>\n")
+			}
+		}
+		buf.WriteString("    </plurals>\n")
+	}
+	buf.WriteString("</resources>\n")
+	return buf.Bytes()
+}
+
+// androidResourceName 将键名转换为合法的 Android 资源名
+func androidResourceName(key string) string {
+	name := androidNameInvalidChars.ReplaceAllString(key, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// escapeAndroidString 按 Android 字符串资源的规则转义
+// 反斜杠、引号和撇号需要转义，开头的 @ 和 ? 会被当作资源引用，换行和制表符写为转义序列
+func escapeAndroidString(value string) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '@', '?':
+			if i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// marshalAppleStrings 生成 iOS/macOS 的 Localizable.strings，复数条目不输出（在 .stringsdict 中）
+func marshalAppleStrings(values, contexts map[string]string) []byte {
+	_, singles := groupPluralValues(values)
+	keys := make([]string, 0, len(singles))
+	for key := range singles {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if context := contexts[key]; context != "" {
+			buf.WriteString("/* " + strings.ReplaceAll(context, "*/", "* /") + " */\n")
+		}
+		buf.WriteString(`"` + escapeAppleString(key) + `" = "` + escapeAppleString(singles[key]) + "\";\n")
+	}
+	return buf.Bytes()
+}
+
+var appleStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// escapeAppleString 按 .strings 文件的规则转义
+func escapeAppleString(value string) string {
+	return appleStringEscaper.Replace(value)
+}
+
+// marshalAppleStringsDict 生成 iOS/macOS 的 Localizable.stringsdict，只包含复数条目
+// 每个条目使用一个名为 value 的变量，数值类型取自 other 形式中的第一个格式说明符，没有时为 d
+func marshalAppleStringsDict(values map[string]string) []byte {
+	plurals, _ := groupPluralValues(values)
+	names := make([]string, 0, len(plurals))
+	for name := range plurals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	buf.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	for _, name := range names {
+		forms := plurals[name]
+		valueType := "d"
+		if match := stringsDictValueType.FindStringSubmatch(forms["other"]); match != nil {
+			valueType = match[1]
+		}
+
+		writePlistKeyValue(&buf, 1, name, "")
+		buf.WriteString("\t<dict>\n")
+		writePlistKeyValue(&buf, 2, "NSStringLocalizedFormatKey", "%#@"+stringsDictValueVarName+"@")
+		writePlistKeyValue(&buf, 2, stringsDictValueVarName, "")
+		buf.WriteString("\t\t<dict>\n")
+		writePlistKeyValue(&buf, 3, "NSStringFormatSpecTypeKey", "NSStringPluralRuleType")
+		writePlistKeyValue(&buf, 3, "NSStringFormatValueTypeKey", valueType)
+		for _, category := range cldrPluralOrder {
+			if value, ok := forms[category]; ok {
+				writePlistKeyValue(&buf, 3, category, value)
+			}
+		}
+		buf.WriteString("\t\t</dict>\n\t</dict>\n")
+	}
+	buf.WriteString("</dict>\n</plist>\n")
+	return buf.Bytes()
+}
+
+// writePlistKeyValue 写入 plist 的 key，value 不为空时紧跟一个 string
+func writePlistKeyValue(buf *bytes.Buffer, depth int, key, value string) {
+	indent := strings.Repeat("\t", depth)
+	buf.WriteString(indent + "<key>" + escapeXMLText(key) + "</key>\n")
+	if value != "" {
+		buf.WriteString(indent + "<string>" + escapeXMLText(value) + "</string>\n")
+	}
+}
+
+func escapeXMLText(value string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// escapeXMLComment XML 注释中不能出现 --
+func escapeXMLComment(value string) string {
+	value = strings.ReplaceAll(value, "\n", " ")
+	for strings.Contains(value, "--") {
+		value = strings.ReplaceAll(value, "--", "- -")
+	}
+	return value
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// maxNamespaceNameLength 命名空间名称最大字符数，与 namespaces.name 列长度一致
+	maxNamespaceNameLength = 100
+	// maxNamespaceDescriptionLength 命名空间说明最大字符数
+	maxNamespaceDescriptionLength = 500
+)
+
+// namespaceNamePattern 命名空间名称用于导出文件路径，只允许字母、数字、点、下划线和连字符
+var namespaceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// NamespaceService 键命名空间服务实现
+type NamespaceService struct {
+	namespaceRepo   domain.NamespaceRepository
+	projectRepo     domain.ProjectRepository
+	translationRepo domain.TranslationRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+}
+
+// NewNamespaceService 创建键命名空间服务实例
+// 键的命名空间变化后通过 translation.updated 事件清除翻译缓存
+func NewNamespaceService(
+	namespaceRepo domain.NamespaceRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *NamespaceService {
+	return &NamespaceService{
+		namespaceRepo:   namespaceRepo,
+		projectRepo:     projectRepo,
+		translationRepo: translationRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+	}
+}
+
+// GetByProjectID 获取项目的命名空间及其中的键数量
+func (s *NamespaceService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Namespace, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.namespaceRepo.GetByProjectID(ctx, projectID)
+}
+
+// Create 创建命名空间，同一项目中的名称不能重复
+func (s *NamespaceService) Create(ctx context.Context, projectID uint64, params domain.NamespaceParams, userID uint64) (*domain.Namespace, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	namespace := &domain.Namespace{
+		ProjectID: projectID,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, invalidNamespace("名称不能为空")
+	}
+	if err := s.applyParams(ctx, namespace, params); err != nil {
+		return nil, err
+	}
+
+	if err := s.namespaceRepo.Create(ctx, namespace); err != nil {
+		return nil, err
+	}
+	return namespace, nil
+}
+
+// Update 修改命名空间的名称或说明，键的归属不变
+// 改名后按旧名称筛选的矩阵缓存失效，因此同样发布翻译更新事件
+func (s *NamespaceService) Update(ctx context.Context, projectID, namespaceID uint64, params domain.NamespaceParams, userID uint64) (*domain.Namespace, error) {
+	namespace, err := s.getProjectNamespace(ctx, projectID, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := namespace.Name
+	if err := s.applyParams(ctx, namespace, params); err != nil {
+		return nil, err
+	}
+	namespace.UpdatedBy = userID
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.namespaceRepo.Update(ctx, namespace); err != nil {
+			return err
+		}
+		if namespace.Name == oldName {
+			return nil
+		}
+		keyNames, err := s.translationRepo.GetKeyNamesByNamespace(ctx, projectID, namespace.ID)
+		if err != nil {
+			return err
+		}
+		return s.publishKeysMoved(ctx, projectID, keyNames, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return namespace, nil
+}
+
+// Delete 删除命名空间，其中的键回到未划分状态
+func (s *NamespaceService) Delete(ctx context.Context, projectID, namespaceID uint64) error {
+	namespace, err := s.getProjectNamespace(ctx, projectID, namespaceID)
+	if err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		keyNames, err := s.translationRepo.GetKeyNamesByNamespace(ctx, projectID, namespace.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.namespaceRepo.Delete(ctx, namespace.ID); err != nil {
+			return err
+		}
+		return s.publishKeysMoved(ctx, projectID, keyNames, 0)
+	})
+}
+
+// AssignKeys 将键在所有语言的翻译归入命名空间，Namespace 为空时移出命名空间
+// 任一键在项目中不存在时不做修改，错误详情列出这些键
+func (s *NamespaceService) AssignKeys(ctx context.Context, projectID uint64, params domain.AssignNamespaceParams, userID uint64) (*domain.AssignNamespaceResult, error) {
+	keyNames := make([]string, 0, len(params.KeyNames))
+	seen := make(map[string]bool, len(params.KeyNames))
+	for _, keyName := range params.KeyNames {
+		keyName = strings.TrimSpace(keyName)
+		if keyName != "" && !seen[keyName] {
+			seen[keyName] = true
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	if len(keyNames) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	sort.Strings(keyNames)
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	result := &domain.AssignNamespaceResult{Namespace: strings.TrimSpace(params.Namespace), KeyNames: keyNames}
+	var namespaceID uint64
+	if result.Namespace != "" {
+		namespace, err := s.namespaceRepo.GetByName(ctx, projectID, result.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		namespaceID = namespace.ID
+	}
+
+	err := withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+		if err != nil {
+			return err
+		}
+		if len(existing) < len(keyNames) {
+			found := make(map[string]bool, len(existing))
+			for _, keyName := range existing {
+				found[keyName] = true
+			}
+			var missing []string
+			for _, keyName := range keyNames {
+				if !found[keyName] {
+					missing = append(missing, keyName)
+				}
+			}
+			return domain.NewAppErrorWithDetails(domain.ErrorTypeNotFound, domain.ErrTranslationNotFound.Code, domain.ErrTranslationNotFound.Message,
+				"项目中没有键 "+strings.Join(missing, ", "))
+		}
+
+		result.Translations, err = s.translationRepo.UpdateKeyNamespace(ctx, projectID, keyNames, namespaceID, userID)
+		if err != nil {
+			return err
+		}
+		return s.publishKeysMoved(ctx, projectID, keyNames, int(result.Translations))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// getProjectNamespace 获取命名空间并确认其属于该项目
+func (s *NamespaceService) getProjectNamespace(ctx context.Context, projectID, namespaceID uint64) (*domain.Namespace, error) {
+	namespace, err := s.namespaceRepo.GetByID(ctx, namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	if namespace.ProjectID != projectID {
+		return nil, domain.ErrNamespaceNotFound
+	}
+	return namespace, nil
+}
+
+// applyParams 校验并写入名称和说明，空名称和 nil 说明保持不变
+func (s *NamespaceService) applyParams(ctx context.Context, namespace *domain.Namespace, params domain.NamespaceParams) error {
+	if name := strings.TrimSpace(params.Name); name != "" && name != namespace.Name {
+		if utf8.RuneCountInString(name) > maxNamespaceNameLength || !namespaceNamePattern.MatchString(name) {
+			return invalidNamespace("名称只能包含字母、数字、点、下划线和连字符，以字母或数字开头，最多 100 个字符")
+		}
+		_, err := s.namespaceRepo.GetByName(ctx, namespace.ProjectID, name)
+		switch {
+		case err == nil:
+			return domain.ErrNamespaceExists
+		case !errors.Is(err, domain.ErrNamespaceNotFound):
+			return err
+		}
+		namespace.Name = name
+	}
+	if params.Description != nil {
+		description := strings.TrimSpace(*params.Description)
+		if utf8.RuneCountInString(description) > maxNamespaceDescriptionLength {
+			return invalidNamespace("说明最多 500 个字符")
+		}
+		namespace.Description = description
+	}
+	return nil
+}
+
+// publishKeysMoved 键的命名空间变化影响按命名空间筛选的矩阵和导出，按翻译更新通知
+func (s *NamespaceService) publishKeysMoved(ctx context.Context, projectID uint64, keyNames []string, count int) error {
+	if len(keyNames) == 0 {
+		return nil
+	}
+	return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: keyNames,
+		Count:    count,
+	})
+}
+
+// invalidNamespace 带错误详情的命名空间无效错误
+func invalidNamespace(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidNamespace.Code, domain.ErrInvalidNamespace.Message, details)
+}
@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// OrganizationService 组织服务实现
+type OrganizationService struct {
+	orgRepo           domain.OrganizationRepository
+	orgMemberRepo     domain.OrganizationMemberRepository
+	userRepo          domain.UserRepository
+	projectRepo       domain.ProjectRepository
+	projectMemberRepo domain.ProjectMemberRepository
+	languageRepo      domain.LanguageRepository
+	transactor        domain.Transactor
+}
+
+// NewOrganizationService 创建组织服务实例
+func NewOrganizationService(
+	orgRepo domain.OrganizationRepository,
+	orgMemberRepo domain.OrganizationMemberRepository,
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	projectMemberRepo domain.ProjectMemberRepository,
+	languageRepo domain.LanguageRepository,
+	transactor domain.Transactor,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:           orgRepo,
+		orgMemberRepo:     orgMemberRepo,
+		userRepo:          userRepo,
+		projectRepo:       projectRepo,
+		projectMemberRepo: projectMemberRepo,
+		languageRepo:      languageRepo,
+		transactor:        transactor,
+	}
+}
+
+// Create 创建组织，创建者成为 owner，并复制共享语言列表中启用的语言作为组织的语言
+func (s *OrganizationService) Create(ctx context.Context, params domain.OrganizationParams, userID uint64) (*domain.Organization, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.orgRepo.GetByName(ctx, name); err == nil {
+		return nil, domain.ErrOrganizationExists
+	} else if err != domain.ErrOrganizationNotFound {
+		return nil, err
+	}
+
+	sharedLanguages, err := s.languageRepo.GetAll(domain.WithOrganization(ctx, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	organization := &domain.Organization{
+		Name:        name,
+		Description: strings.TrimSpace(params.Description),
+		CreatedBy:   userID,
+		UpdatedBy:   userID,
+	}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.orgRepo.Create(ctx, organization); err != nil {
+			return err
+		}
+		if err := s.orgMemberRepo.Create(ctx, &domain.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+			Role:           domain.OrganizationRoleOwner,
+			CreatedBy:      userID,
+		}); err != nil {
+			return err
+		}
+
+		orgCtx := domain.WithOrganization(ctx, organization.ID)
+		for _, shared := range sharedLanguages {
+			if shared.Status != "active" {
+				continue
+			}
+			language := &domain.Language{
+				OrganizationID: organization.ID,
+				Code:           shared.Code,
+				Name:           shared.Name,
+				IsDefault:      shared.IsDefault,
+				Status:         "active",
+				CreatedBy:      userID,
+				UpdatedBy:      userID,
+			}
+			if err := s.languageRepo.Create(orgCtx, language); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// 并发创建同名组织时由唯一索引拦截
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrOrganizationExists
+		}
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+// GetByID 根据ID获取组织
+func (s *OrganizationService) GetByID(ctx context.Context, id uint64) (*domain.Organization, error) {
+	return s.orgRepo.GetByID(ctx, id)
+}
+
+// GetAll 获取所有组织
+func (s *OrganizationService) GetAll(ctx context.Context) ([]*domain.Organization, error) {
+	return s.orgRepo.GetAll(ctx)
+}
+
+// GetUserOrganizations 获取用户所在的组织
+func (s *OrganizationService) GetUserOrganizations(ctx context.Context, userID uint64) ([]*domain.Organization, error) {
+	return s.orgRepo.GetByUser(ctx, userID)
+}
+
+// Update 更新组织名称和描述
+func (s *OrganizationService) Update(ctx context.Context, id uint64, params domain.OrganizationParams, userID uint64) (*domain.Organization, error) {
+	organization, err := s.orgRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(params.Name); name != "" && name != organization.Name {
+		if existing, err := s.orgRepo.GetByName(ctx, name); err == nil && existing.ID != id {
+			return nil, domain.ErrOrganizationExists
+		} else if err != nil && err != domain.ErrOrganizationNotFound {
+			return nil, err
+		}
+		organization.Name = name
+	}
+	organization.Description = strings.TrimSpace(params.Description)
+	organization.UpdatedBy = userID
+
+	if err := s.orgRepo.Update(ctx, organization); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrOrganizationExists
+		}
+		return nil, err
+	}
+	return organization, nil
+}
+
+// Delete 删除没有项目的组织，同时删除组织的成员、语言和 API Key
+func (s *OrganizationService) Delete(ctx context.Context, id uint64) error {
+	if _, err := s.orgRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	projects, err := s.projectRepo.GetByOrganization(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(projects) > 0 {
+		return domain.ErrOrganizationNotEmpty
+	}
+
+	return s.orgRepo.Delete(ctx, id)
+}
+
+// GetMembers 获取组织成员
+func (s *OrganizationService) GetMembers(ctx context.Context, id uint64) ([]*domain.OrganizationMemberInfo, error) {
+	if _, err := s.orgRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgMemberRepo.GetByOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return []*domain.OrganizationMemberInfo{}, nil
+	}
+
+	userIDs := make([]uint64, len(members))
+	for i, member := range members {
+		userIDs[i] = member.UserID
+	}
+	users, err := s.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[uint64]*domain.User, len(users))
+	for _, user := range users {
+		userMap[user.ID] = user
+	}
+
+	infos := make([]*domain.OrganizationMemberInfo, 0, len(members))
+	for _, member := range members {
+		user, exists := userMap[member.UserID]
+		if !exists {
+			continue
+		}
+		infos = append(infos, &domain.OrganizationMemberInfo{
+			UserID:    member.UserID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Role:      member.Role,
+			CreatedAt: member.CreatedAt,
+		})
+	}
+	return infos, nil
+}
+
+// AddMember 按用户ID或邮箱添加组织成员
+func (s *OrganizationService) AddMember(ctx context.Context, id uint64, params domain.AddOrganizationMemberParams, createdBy uint64) (*domain.OrganizationMember, error) {
+	role := params.Role
+	if role == "" {
+		role = domain.OrganizationRoleMember
+	}
+	if domain.OrganizationRoleLevel(role) == 0 {
+		return nil, domain.ErrInvalidOrganizationRole
+	}
+
+	if _, err := s.orgRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	var err error
+	switch {
+	case params.UserID != 0:
+		user, err = s.userRepo.GetByID(ctx, params.UserID)
+	case strings.TrimSpace(params.Email) != "":
+		user, err = s.userRepo.GetByEmail(ctx, strings.TrimSpace(params.Email))
+	default:
+		return nil, domain.ErrInvalidInput
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.orgMemberRepo.Get(ctx, id, user.ID); err == nil {
+		return nil, domain.ErrOrganizationMemberExists
+	} else if err != domain.ErrOrganizationMemberNotFound {
+		return nil, err
+	}
+
+	member := &domain.OrganizationMember{
+		OrganizationID: id,
+		UserID:         user.ID,
+		Role:           role,
+		CreatedBy:      createdBy,
+	}
+	if err := s.orgMemberRepo.Create(ctx, member); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrOrganizationMemberExists
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// UpdateMemberRole 更新组织成员角色，组织至少保留一个 owner
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, id, userID uint64, role string) (*domain.OrganizationMember, error) {
+	if domain.OrganizationRoleLevel(role) == 0 {
+		return nil, domain.ErrInvalidOrganizationRole
+	}
+
+	member, err := s.orgMemberRepo.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Role == domain.OrganizationRoleOwner && role != domain.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, id, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	member.Role = role
+	if err := s.orgMemberRepo.Update(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember 移除组织成员，同时移除该用户在组织下所有项目的成员关系
+func (s *OrganizationService) RemoveMember(ctx context.Context, id, userID uint64) error {
+	member, err := s.orgMemberRepo.Get(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role == domain.OrganizationRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, id, userID); err != nil {
+			return err
+		}
+	}
+
+	projects, err := s.projectRepo.GetByOrganization(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		for _, project := range projects {
+			if err := s.projectMemberRepo.Delete(ctx, project.ID, userID); err != nil {
+				return err
+			}
+		}
+		return s.orgMemberRepo.Delete(ctx, id, userID)
+	})
+}
+
+// ensureAnotherOwner 确认除 userID 外组织还有其他 owner
+func (s *OrganizationService) ensureAnotherOwner(ctx context.Context, id, userID uint64) error {
+	members, err := s.orgMemberRepo.GetByOrganization(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if member.UserID != userID && member.Role == domain.OrganizationRoleOwner {
+			return nil
+		}
+	}
+	return domain.ErrLastOrganizationOwner
+}
+
+// GetProjects 获取组织下的项目
+func (s *OrganizationService) GetProjects(ctx context.Context, id uint64) ([]*domain.Project, error) {
+	if _, err := s.orgRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.projectRepo.GetByOrganization(ctx, id)
+}
+
+// CheckRole 检查用户在组织中的角色是否不低于 requiredRole，系统管理员拥有所有组织的权限
+// 组织不存在时返回 ErrOrganizationNotFound
+func (s *OrganizationService) CheckRole(ctx context.Context, id, userID uint64, requiredRole string) (bool, error) {
+	if _, err := s.orgRepo.GetByID(ctx, id); err != nil {
+		return false, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if user.Role == "admin" {
+		return true, nil
+	}
+
+	member, err := s.orgMemberRepo.Get(ctx, id, userID)
+	if err != nil {
+		if err == domain.ErrOrganizationMemberNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return domain.OrganizationRoleLevel(member.Role) >= domain.OrganizationRoleLevel(requiredRole), nil
+}
+
+// projectOrganizationContext 将 ctx 的当前组织设置为项目所属的组织
+// 用于项目由请求体或后台任务指定、无法由路由中间件确定组织的场景
+func projectOrganizationContext(ctx context.Context, projectRepo domain.ProjectRepository, projectID uint64) (context.Context, error) {
+	project, err := projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return ctx, err
+	}
+	return domain.WithOrganization(ctx, project.OrganizationID), nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// newOutboundHTTPClient 创建由服务端向外部地址发起请求的 HTTP 客户端
+// 在建立连接时检查解析后的地址，避免 DNS 指向内网地址绕过主机名检查；不使用代理，也不跟随重定向。
+// 地址不允许连接时返回 errNotAllowed
+func newOutboundHTTPClient(timeout time.Duration, allowPrivateNetworks bool, errNotAllowed error) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || (!allowPrivateNetworks && !IsPublicIP(ip)) {
+				return errNotAllowed
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// nonPublicNetworks 标准库未识别的非公网地址段
+var nonPublicNetworks = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},     // 本网络 (RFC 1122)
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}, // 运营商级 NAT (RFC 6598)
+	{IP: net.IPv4(192, 0, 0, 0), Mask: net.CIDRMask(24, 32)},  // IETF 协议分配 (RFC 6890)
+	{IP: net.IPv4(198, 18, 0, 0), Mask: net.CIDRMask(15, 32)}, // 网络设备基准测试 (RFC 2544)
+	// NAT64 (RFC 6052)，经转换可到达任意 IPv4 地址，包括内网地址
+	{IP: net.ParseIP("64:ff9b::"), Mask: net.CIDRMask(96, 128)},
+}
+
+// IsPublicIP 判断地址是否为公网单播地址
+// IPv4 映射的 IPv6 地址（如 ::ffff:127.0.0.1）按对应的 IPv4 地址判断
+func IsPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
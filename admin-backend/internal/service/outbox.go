@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// outboxInlineGrace 写入后等待提交时同步投递的时间，超时未投递（如进程崩溃）由投递器接管
+	outboxInlineGrace = 30 * time.Second
+	// outboxLease 投递器领取事件后的租约时间，超时未完成时允许重新领取
+	outboxLease = time.Minute
+	// outboxMaxAttempts 最大投递次数，超过后标记为 failed
+	outboxMaxAttempts = 10
+	// outboxMaxBackoff 最大重试间隔
+	outboxMaxBackoff = time.Hour
+	// outboxRetention 已投递事件的保留时间
+	outboxRetention = 7 * 24 * time.Hour
+	// outboxPollInterval 投递器轮询间隔
+	outboxPollInterval = 5 * time.Second
+	// outboxBatchSize 每次轮询处理的最大事件数
+	outboxBatchSize = 100
+	// outboxCleanupInterval 清理已投递事件的间隔
+	outboxCleanupInterval = time.Hour
+)
+
+// OutboxEventBus 事务发件箱事件总线
+// Publish 将事件写入发件箱表（在事务中调用时与业务数据一同提交），提交后立即同步投递到底层总线；
+// 同步投递失败或提交后进程崩溃的事件由 OutboxDispatcher 重试，保证至少一次投递。
+// 重复投递时事件 ID 不变，订阅方可据此去重。
+type OutboxEventBus struct {
+	repo       domain.OutboxRepository
+	transactor domain.Transactor
+	delivery   domain.EventBus
+	logger     *zap.Logger
+}
+
+// NewOutboxEventBus 创建事务发件箱事件总线，delivery 为实际投递的事件总线
+func NewOutboxEventBus(repo domain.OutboxRepository, transactor domain.Transactor, delivery domain.EventBus, logger *zap.Logger) *OutboxEventBus {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &OutboxEventBus{
+		repo:       repo,
+		transactor: transactor,
+		delivery:   delivery,
+		logger:     logger,
+	}
+}
+
+// Subscribe 订阅事件（订阅底层总线）
+func (b *OutboxEventBus) Subscribe(eventType domain.EventType, name string, handler domain.EventHandler) {
+	b.delivery.Subscribe(eventType, name, handler)
+}
+
+// Publish 写入发件箱并在事务提交后投递；写入失败时返回错误，调用方应回滚事务
+func (b *OutboxEventBus) Publish(ctx context.Context, event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	record := &domain.OutboxEvent{
+		EventID:       event.ID,
+		EventType:     string(event.Type),
+		ProjectID:     event.ProjectID,
+		Payload:       string(data),
+		Status:        domain.OutboxStatusPending,
+		NextAttemptAt: time.Now().Add(outboxInlineGrace),
+	}
+	if err := b.repo.Create(ctx, record); err != nil {
+		return err
+	}
+
+	b.transactor.AfterCommit(ctx, func(ctx context.Context) {
+		if err := b.delivery.Publish(ctx, event); err != nil {
+			// 保持 pending，由投递器在宽限期后重试
+			b.logger.Warn("Inline event delivery failed, deferring to outbox dispatcher",
+				zap.String("event_id", event.ID),
+				zap.Error(err),
+			)
+			return
+		}
+		if err := b.repo.MarkDelivered(ctx, record.ID); err != nil {
+			b.logger.Warn("Failed to mark outbox event delivered", zap.String("event_id", event.ID), zap.Error(err))
+		}
+	})
+	return nil
+}
+
+// OutboxDispatcher 发件箱投递器，定期重试未投递的事件并清理历史事件
+type OutboxDispatcher struct {
+	repo     domain.OutboxRepository
+	delivery domain.EventBus
+	logger   *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewOutboxDispatcher 创建发件箱投递器
+func NewOutboxDispatcher(repo domain.OutboxRepository, delivery domain.EventBus, logger *zap.Logger) *OutboxDispatcher {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &OutboxDispatcher{
+		repo:     repo,
+		delivery: delivery,
+		logger:   logger,
+	}
+}
+
+// Start 启动后台投递
+func (d *OutboxDispatcher) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止后台投递并等待当前批次完成
+func (d *OutboxDispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 轮询循环
+func (d *OutboxDispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	lastCleanup := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := d.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Error("Outbox dispatch failed", zap.Error(err))
+		}
+
+		if time.Since(lastCleanup) >= outboxCleanupInterval {
+			lastCleanup = time.Now()
+			if _, err := d.repo.DeleteDeliveredBefore(ctx, time.Now().Add(-outboxRetention)); err != nil && ctx.Err() == nil {
+				d.logger.Error("Outbox cleanup failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// DispatchDue 投递一批到期的事件，返回成功投递的数量
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	records, err := d.repo.GetDue(ctx, now, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, record := range records {
+		claimed, err := d.repo.Claim(ctx, record.ID, record.Attempts, now.Add(outboxLease))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if d.dispatch(ctx, record, record.Attempts+1) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// dispatch 投递单个事件并记录结果，attempts 为包括本次在内的投递次数
+func (d *OutboxDispatcher) dispatch(ctx context.Context, record *domain.OutboxEvent, attempts int) bool {
+	var event domain.Event
+	err := json.Unmarshal([]byte(record.Payload), &event)
+	if err == nil {
+		err = d.delivery.Publish(ctx, event)
+	}
+
+	if err == nil {
+		if markErr := d.repo.MarkDelivered(ctx, record.ID); markErr != nil {
+			d.logger.Warn("Failed to mark outbox event delivered", zap.String("event_id", record.EventID), zap.Error(markErr))
+		}
+		return true
+	}
+
+	status := domain.OutboxStatusPending
+	if attempts >= outboxMaxAttempts {
+		status = domain.OutboxStatusFailed
+	}
+	d.logger.Warn("Outbox event delivery failed",
+		zap.String("event_id", record.EventID),
+		zap.Int("attempts", attempts),
+		zap.String("status", status),
+		zap.Error(err),
+	)
+	lastError := truncateRunes(err.Error(), 500)
+	if markErr := d.repo.MarkFailed(ctx, record.ID, status, time.Now().Add(outboxBackoff(attempts)), lastError); markErr != nil {
+		d.logger.Error("Failed to record outbox delivery failure", zap.String("event_id", record.EventID), zap.Error(markErr))
+		return false
+	}
+	if status == domain.OutboxStatusFailed {
+		d.alertDeadLetter(ctx, record, attempts, lastError)
+	}
+	return false
+}
+
+// alertDeadLetter 事件进入死信状态时记录错误日志并发布告警事件
+// 告警直接投递到底层总线而不写入发件箱，避免告警本身再次进入死信
+func (d *OutboxDispatcher) alertDeadLetter(ctx context.Context, record *domain.OutboxEvent, attempts int, lastError string) {
+	d.logger.Error("Outbox event moved to dead letter",
+		zap.Uint64("outbox_id", record.ID),
+		zap.String("event_id", record.EventID),
+		zap.String("event_type", record.EventType),
+		zap.Int("attempts", attempts),
+		zap.String("last_error", lastError),
+	)
+
+	err := publishEvent(ctx, d.delivery, domain.EventOutboxDeadLettered, record.ProjectID, domain.OutboxDeadLetteredPayload{
+		OutboxID:  record.ID,
+		EventID:   record.EventID,
+		EventType: record.EventType,
+		Attempts:  attempts,
+		LastError: lastError,
+	})
+	if err != nil {
+		d.logger.Error("Failed to publish dead letter alert event", zap.String("event_id", record.EventID), zap.Error(err))
+	}
+}
+
+// outboxBackoff 第 attempts 次失败后的重试间隔：10s、40s、90s……，最长一小时
+func outboxBackoff(attempts int) time.Duration {
+	backoff := time.Duration(attempts*attempts) * 10 * time.Second
+	if backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return backoff
+}
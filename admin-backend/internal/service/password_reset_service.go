@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// defaultPasswordResetTTL 重置链接的默认有效期
+const defaultPasswordResetTTL = time.Hour
+
+// PasswordResetService 找回密码服务实现
+// 重置链接中的令牌只保存哈希值，使用一次或过期后失效
+type PasswordResetService struct {
+	userRepo      domain.UserRepository
+	resetRepo     domain.PasswordResetRepository
+	notifier      domain.EmailNotifier
+	securityUtils *utils.SecurityUtils
+	frontendURL   string
+	ttl           time.Duration
+}
+
+// NewPasswordResetService 创建找回密码服务实例，ttl 为 0 时链接有效期为 1 小时
+func NewPasswordResetService(
+	userRepo domain.UserRepository,
+	resetRepo domain.PasswordResetRepository,
+	notifier domain.EmailNotifier,
+	frontendURL string,
+	ttl time.Duration,
+) *PasswordResetService {
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+	return &PasswordResetService{
+		userRepo:      userRepo,
+		resetRepo:     resetRepo,
+		notifier:      notifier,
+		securityUtils: utils.NewSecurityUtils(),
+		frontendURL:   frontendURL,
+		ttl:           ttl,
+	}
+}
+
+// RequestReset 向邮箱对应的用户发送重置密码链接
+// 邮箱不存在或用户未启用时同样返回成功，避免通过该接口探测注册邮箱
+func (s *PasswordResetService) RequestReset(ctx context.Context, email string) error {
+	if !s.notifier.Enabled() {
+		return domain.ErrMailNotConfigured
+	}
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return domain.ErrInvalidInput
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || user.Status != "active" {
+		return nil
+	}
+
+	plain, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	token := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashAccessToken(plain),
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if err := s.resetRepo.Create(ctx, token); err != nil {
+		return err
+	}
+	// 顺带清理早已过期的令牌，清理失败不影响本次请求
+	_, _ = s.resetRepo.DeleteExpiredBefore(ctx, now.Add(-24*time.Hour))
+
+	return s.notifier.Notify(ctx, user.Email, domain.EmailEventPasswordReset, domain.PasswordResetEmailData{
+		Username:  user.Username,
+		ResetURL:  s.resetURL(plain),
+		ExpiresAt: token.ExpiresAt,
+	})
+}
+
+// ConfirmReset 校验令牌并设置新密码，令牌使用后立即失效
+func (s *PasswordResetService) ConfirmReset(ctx context.Context, plain, newPassword string) error {
+	token, err := s.resetRepo.GetByHash(ctx, hashAccessToken(strings.TrimSpace(plain)))
+	if err != nil {
+		return err
+	}
+	if token.UsedAt != nil || time.Now().After(token.ExpiresAt) {
+		return domain.ErrInvalidResetToken
+	}
+
+	// 令牌发出后被禁用的用户不能再重置密码
+	user, err := s.userRepo.GetByID(ctx, token.UserID)
+	if err != nil || user.Status != "active" {
+		return domain.ErrInvalidResetToken
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	// 先标记令牌，同一令牌并发使用时只有一个请求能修改密码
+	claimed, err := s.resetRepo.MarkUsed(ctx, token.ID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return domain.ErrInvalidResetToken
+	}
+
+	user.Password = string(hashedPassword)
+	return s.userRepo.Update(ctx, user)
+}
+
+// resetURL 生成重置密码链接
+func (s *PasswordResetService) resetURL(token string) string {
+	frontendURL := s.frontendURL
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+	return fmt.Sprintf("%s/reset-password?token=%s", frontendURL, url.QueryEscape(token))
+}
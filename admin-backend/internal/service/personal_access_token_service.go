@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+)
+
+const (
+	// defaultAccessTokenDays 未指定有效期时令牌的有效天数
+	defaultAccessTokenDays = 90
+	// maxAccessTokenDays 令牌的最长有效天数
+	maxAccessTokenDays = 365
+	// maxAccessTokensPerUser 每个用户最多持有的令牌数量
+	maxAccessTokensPerUser = 20
+	// accessTokenTouchInterval 同一令牌两次记录使用时间的最小间隔，避免每个请求都写库
+	accessTokenTouchInterval = time.Minute
+	// accessTokenPrefixLength 保存用于识别令牌的前缀长度（含 yfp_）
+	accessTokenPrefixLength = 12
+)
+
+// PersonalAccessTokenService 个人访问令牌服务实现
+type PersonalAccessTokenService struct {
+	tokenRepo     domain.PersonalAccessTokenRepository
+	securityUtils *utils.SecurityUtils
+}
+
+// NewPersonalAccessTokenService 创建个人访问令牌服务实例
+func NewPersonalAccessTokenService(tokenRepo domain.PersonalAccessTokenRepository) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		tokenRepo:     tokenRepo,
+		securityUtils: utils.NewSecurityUtils(),
+	}
+}
+
+// Create 创建令牌，返回的明文令牌只在创建时可见
+func (s *PersonalAccessTokenService) Create(ctx context.Context, userID uint64, params domain.PersonalAccessTokenParams) (*domain.PersonalAccessToken, string, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, "", domain.ErrInvalidInput
+	}
+
+	expiresInDays := params.ExpiresInDays
+	if expiresInDays <= 0 {
+		expiresInDays = defaultAccessTokenDays
+	}
+	if expiresInDays > maxAccessTokenDays {
+		expiresInDays = maxAccessTokenDays
+	}
+
+	count, err := s.tokenRepo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if count >= maxAccessTokensPerUser {
+		return nil, "", domain.ErrAccessTokenLimit
+	}
+
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	plain := domain.PersonalAccessTokenPrefix + secret
+
+	token := &domain.PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   hashAccessToken(plain),
+		TokenPrefix: plain[:accessTokenPrefixLength],
+		ExpiresAt:   time.Now().AddDate(0, 0, expiresInDays),
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+
+	return token, plain, nil
+}
+
+// GetByUserID 获取用户的所有令牌
+func (s *PersonalAccessTokenService) GetByUserID(ctx context.Context, userID uint64) ([]*domain.PersonalAccessToken, error) {
+	return s.tokenRepo.GetByUserID(ctx, userID)
+}
+
+// Revoke 撤销令牌，只能撤销自己的令牌
+func (s *PersonalAccessTokenService) Revoke(ctx context.Context, userID, id uint64) error {
+	token, err := s.tokenRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if token.UserID != userID {
+		return domain.ErrAccessTokenNotFound
+	}
+	return s.tokenRepo.Delete(ctx, id)
+}
+
+// Authenticate 校验明文令牌并记录最近使用时间和来源 IP
+func (s *PersonalAccessTokenService) Authenticate(ctx context.Context, plain, clientIP string) (*domain.PersonalAccessToken, error) {
+	if !strings.HasPrefix(plain, domain.PersonalAccessTokenPrefix) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	token, err := s.tokenRepo.GetByHash(ctx, hashAccessToken(plain))
+	if err != nil {
+		if err == domain.ErrAccessTokenNotFound {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if token.IsExpired() {
+		return nil, domain.ErrAccessTokenExpired
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= accessTokenTouchInterval || token.LastUsedIP != clientIP {
+		if err := s.tokenRepo.UpdateLastUsed(ctx, token.ID, now, clientIP); err == nil {
+			token.LastUsedAt = &now
+			token.LastUsedIP = clientIP
+		}
+		// 记录使用时间失败不影响认证
+	}
+
+	return token, nil
+}
+
+// hashAccessToken 计算令牌的 SHA-256 哈希
+// 令牌本身是高熵随机值，不需要加盐的慢哈希
+func hashAccessToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
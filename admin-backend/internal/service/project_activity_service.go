@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// projectActivityFlushTimeout 单次写入活动时间的超时时间
+const projectActivityFlushTimeout = 30 * time.Second
+
+// ProjectActivityService 项目活动跟踪和闲置项目服务
+// 访问和内容变更时间先在内存中按项目合并，定期批量写入数据库，避免每个请求都更新项目表。
+// 内容变更通过订阅 translation.updated 和 import.completed 事件记录。
+type ProjectActivityService struct {
+	projectRepo    domain.ProjectRepository
+	projectService domain.ProjectService
+	auditRepo      domain.AuditLogRepository
+	staleDays      int
+	interval       time.Duration
+	logger         *zap.Logger
+
+	mu      sync.Mutex
+	pending map[uint64]*domain.ProjectActivity
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewProjectActivityService 创建项目活动服务
+// staleDays 为默认的闲置天数，interval 为活动时间写入数据库的间隔
+func NewProjectActivityService(
+	projectRepo domain.ProjectRepository,
+	projectService domain.ProjectService,
+	auditRepo domain.AuditLogRepository,
+	staleDays int,
+	interval time.Duration,
+	logger *zap.Logger,
+) *ProjectActivityService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &ProjectActivityService{
+		projectRepo:    projectRepo,
+		projectService: projectService,
+		auditRepo:      auditRepo,
+		staleDays:      staleDays,
+		interval:       interval,
+		logger:         logger,
+		pending:        make(map[uint64]*domain.ProjectActivity),
+	}
+}
+
+// RecordAccess 记录项目被访问
+func (s *ProjectActivityService) RecordAccess(projectID uint64) {
+	s.record(projectID, time.Time{}, time.Now())
+}
+
+// HandleEvent 处理翻译变更和导入完成事件，记录项目的内容变更时间
+func (s *ProjectActivityService) HandleEvent(ctx context.Context, event domain.Event) error {
+	changedAt := event.OccurredAt
+	if changedAt.IsZero() {
+		changedAt = time.Now()
+	}
+	s.record(event.ProjectID, changedAt, time.Time{})
+	return nil
+}
+
+// record 合并项目的活动时间，只保留最新的时间
+func (s *ProjectActivityService) record(projectID uint64, changedAt, accessedAt time.Time) {
+	if projectID == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	activity, ok := s.pending[projectID]
+	if !ok {
+		activity = &domain.ProjectActivity{ProjectID: projectID}
+		s.pending[projectID] = activity
+	}
+	if changedAt.After(activity.LastChangedAt) {
+		activity.LastChangedAt = changedAt
+	}
+	if accessedAt.After(activity.LastAccessedAt) {
+		activity.LastAccessedAt = accessedAt
+	}
+}
+
+// Flush 将内存中的活动时间写入数据库，写入失败时保留到下次写入
+func (s *ProjectActivityService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[uint64]*domain.ProjectActivity)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	activities := make([]*domain.ProjectActivity, 0, len(pending))
+	for _, activity := range pending {
+		activities = append(activities, activity)
+	}
+	if err := s.projectRepo.RecordActivity(ctx, activities); err != nil {
+		for _, activity := range activities {
+			s.record(activity.ProjectID, activity.LastChangedAt, activity.LastAccessedAt)
+		}
+		return err
+	}
+	return nil
+}
+
+// Start 启动定期写入
+func (s *ProjectActivityService) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期写入，并写入尚未保存的活动时间
+func (s *ProjectActivityService) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.Flush(ctx)
+}
+
+// run 写入循环
+func (s *ProjectActivityService) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flushCtx, cancel := context.WithTimeout(ctx, projectActivityFlushTimeout)
+			if err := s.Flush(flushCtx); err != nil && ctx.Err() == nil {
+				s.logger.Error("Failed to flush project activity", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// GetStaleProjects 获取超过指定天数没有内容变更的活跃项目
+func (s *ProjectActivityService) GetStaleProjects(ctx context.Context, params domain.StaleProjectParams) (*domain.StaleProjectReport, error) {
+	days, err := s.resolveStaleDays(params.Days)
+	if err != nil {
+		return nil, err
+	}
+	s.flushBeforeRead(ctx)
+
+	before := time.Now().AddDate(0, 0, -days)
+	projects, total, err := s.projectRepo.GetStale(ctx, before, params.Limit, params.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.StaleProjectReport{
+		Days:     days,
+		Before:   before,
+		Projects: projects,
+		Total:    total,
+	}, nil
+}
+
+// ArchiveStaleProjects 归档指定的闲置项目
+// 从报告生成到执行归档期间可能有新的变更，归档前按最新的活动时间重新检查
+func (s *ProjectActivityService) ArchiveStaleProjects(ctx context.Context, params domain.ArchiveStaleProjectsParams, userID uint64) (*domain.ArchiveStaleProjectsResult, error) {
+	days, err := s.resolveStaleDays(params.Days)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.ProjectIDs) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	s.flushBeforeRead(ctx)
+
+	projects, err := s.projectRepo.GetByIDs(ctx, params.ProjectIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint64]*domain.Project, len(projects))
+	for _, project := range projects {
+		byID[project.ID] = project
+	}
+
+	before := time.Now().AddDate(0, 0, -days)
+	result := &domain.ArchiveStaleProjectsResult{
+		Archived: []uint64{},
+		Skipped:  []uint64{},
+	}
+	for _, id := range params.ProjectIDs {
+		project, ok := byID[id]
+		if !ok || project.Status != "active" || !projectStaleBefore(project, before) {
+			result.Skipped = append(result.Skipped, id)
+			continue
+		}
+
+		if _, err := s.projectService.Update(ctx, id, domain.UpdateProjectParams{Status: "archived"}, userID); err != nil {
+			return nil, err
+		}
+		if err := recordAudit(ctx, s.auditRepo, id, userID, domain.AuditActionProjectArchive, map[string]interface{}{
+			"stale_days":      days,
+			"last_changed_at": project.LastChangedAt,
+		}); err != nil {
+			s.logger.Warn("Failed to record project archive audit log", zap.Uint64("project_id", id), zap.Error(err))
+		}
+		delete(byID, id) // 请求中重复的项目ID只归档一次
+		result.Archived = append(result.Archived, id)
+	}
+	return result, nil
+}
+
+// resolveStaleDays 未指定闲置天数时使用默认值
+func (s *ProjectActivityService) resolveStaleDays(days int) (int, error) {
+	if days == 0 {
+		days = s.staleDays
+	}
+	if days <= 0 {
+		return 0, domain.ErrInvalidStaleDays
+	}
+	return days, nil
+}
+
+// flushBeforeRead 读取前写入本实例尚未保存的活动时间，失败时只记录日志
+func (s *ProjectActivityService) flushBeforeRead(ctx context.Context) {
+	if err := s.Flush(ctx); err != nil {
+		s.logger.Warn("Failed to flush project activity before reading", zap.Error(err))
+	}
+}
+
+// projectStaleBefore 项目最近的内容变更（从未变更时为创建时间）是否早于 before
+func projectStaleBefore(project *domain.Project, before time.Time) bool {
+	lastChanged := project.CreatedAt
+	if project.LastChangedAt != nil {
+		lastChanged = *project.LastChangedAt
+	}
+	return lastChanged.Before(before)
+}
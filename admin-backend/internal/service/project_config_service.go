@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// 项目成员角色
+var projectMemberRoles = map[string]bool{
+	"owner":  true,
+	"editor": true,
+	"viewer": true,
+}
+
+// ProjectConfigService 项目配置导出/导入服务实现
+// 写入通过各自的服务完成，以复用其校验和缓存失效逻辑
+type ProjectConfigService struct {
+	projectService domain.ProjectService
+	languageRepo   domain.LanguageRepository
+	userRepo       domain.UserRepository
+	memberService  domain.ProjectMemberService
+	webhookService domain.InboundWebhookService
+	auditRepo      domain.AuditLogRepository
+	transactor     domain.Transactor
+}
+
+// NewProjectConfigService 创建项目配置服务实例
+func NewProjectConfigService(
+	projectService domain.ProjectService,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+	memberService domain.ProjectMemberService,
+	webhookService domain.InboundWebhookService,
+	auditRepo domain.AuditLogRepository,
+	transactor domain.Transactor,
+) *ProjectConfigService {
+	return &ProjectConfigService{
+		projectService: projectService,
+		languageRepo:   languageRepo,
+		userRepo:       userRepo,
+		memberService:  memberService,
+		webhookService: webhookService,
+		auditRepo:      auditRepo,
+		transactor:     transactor,
+	}
+}
+
+// Export 导出项目配置，Webhook 和成员按名称排序，便于比较不同版本
+func (s *ProjectConfigService) Export(ctx context.Context, projectID uint64) (*domain.ProjectConfig, error) {
+	project, err := s.projectService.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &domain.ProjectConfig{
+		Version: domain.ProjectConfigVersion,
+		Source:  project.Slug,
+		Settings: domain.ProjectConfigSettings{
+			Description:        project.Description,
+			Status:             project.Status,
+			ExportFileTemplate: project.ExportFileTemplate,
+		},
+		Languages: []string{},
+		Webhooks:  []domain.ProjectConfigWebhook{},
+		Members:   []domain.ProjectConfigMember{},
+	}
+
+	// 语言在实例内全局启用，导出当前启用的语言
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, language := range languages {
+		if language.Status == "active" {
+			config.Languages = append(config.Languages, language.Code)
+		}
+	}
+	sort.Strings(config.Languages)
+
+	webhooks, err := s.webhookService.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		config.Webhooks = append(config.Webhooks, toProjectConfigWebhook(webhook))
+	}
+	sort.Slice(config.Webhooks, func(i, j int) bool { return config.Webhooks[i].Name < config.Webhooks[j].Name })
+
+	members, err := s.memberService.GetProjectMembers(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		config.Members = append(config.Members, domain.ProjectConfigMember{Username: member.Username, Role: member.Role})
+	}
+	sort.Slice(config.Members, func(i, j int) bool { return config.Members[i].Username < config.Members[j].Username })
+
+	return config, nil
+}
+
+// Import 将配置应用到项目
+// 先校验整个文档并计算变更，dry run 时直接返回；执行时所有变更在同一事务中完成并写入审计日志
+func (s *ProjectConfigService) Import(ctx context.Context, projectID uint64, params domain.ProjectConfigImportParams, userID uint64) (*domain.ProjectConfigImportResult, error) {
+	config := params.Config
+	if err := validateProjectConfig(config); err != nil {
+		return nil, err
+	}
+
+	project, err := s.projectService.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ProjectConfigImportResult{
+		DryRun:           params.DryRun,
+		Changes:          []domain.ProjectConfigChange{},
+		MissingLanguages: []string{},
+		UnknownUsers:     []string{},
+	}
+
+	if err := s.checkLanguages(ctx, config.Languages, result); err != nil {
+		return nil, err
+	}
+
+	// 设置
+	settings := domain.UpdateProjectParams{}
+	for _, field := range []struct {
+		name     string
+		current  string
+		imported string
+		apply    func(value string)
+	}{
+		{"description", project.Description, config.Settings.Description, func(v string) { settings.Description = v }},
+		{"status", project.Status, config.Settings.Status, func(v string) { settings.Status = v }},
+		{"export_file_template", project.ExportFileTemplate, config.Settings.ExportFileTemplate, func(v string) { settings.ExportFileTemplate = &v }},
+	} {
+		imported := strings.TrimSpace(field.imported)
+		if imported == "" || imported == field.current {
+			continue
+		}
+		field.apply(imported)
+		result.Changes = append(result.Changes, domain.ProjectConfigChange{
+			Section: "settings", Target: field.name, Action: domain.ProjectConfigActionUpdate, Old: field.current, New: imported,
+		})
+	}
+
+	// 入站 Webhook
+	webhooks, err := s.webhookService.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	existingWebhooks := make(map[string]*domain.InboundWebhook, len(webhooks))
+	for _, webhook := range webhooks {
+		existingWebhooks[webhook.Name] = webhook
+	}
+	var createWebhooks []domain.ProjectConfigWebhook
+	updateWebhooks := make(map[uint64]domain.ProjectConfigWebhook)
+	for _, imported := range config.Webhooks {
+		existing, ok := existingWebhooks[imported.Name]
+		if !ok {
+			createWebhooks = append(createWebhooks, imported)
+			result.Changes = append(result.Changes, domain.ProjectConfigChange{
+				Section: "webhooks", Target: imported.Name, Action: domain.ProjectConfigActionCreate,
+			})
+			continue
+		}
+		if !webhookConfigDiffers(toProjectConfigWebhook(existing), imported) {
+			continue
+		}
+		updateWebhooks[existing.ID] = imported
+		result.Changes = append(result.Changes, domain.ProjectConfigChange{
+			Section: "webhooks", Target: imported.Name, Action: domain.ProjectConfigActionUpdate,
+		})
+	}
+
+	// 成员
+	members, err := s.memberService.GetProjectMembers(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	existingRoles := make(map[uint64]string, len(members))
+	for _, member := range members {
+		existingRoles[member.UserID] = member.Role
+	}
+	type memberChange struct {
+		userID uint64
+		role   string
+		create bool
+	}
+	var memberChanges []memberChange
+	for _, imported := range config.Members {
+		user, err := s.userRepo.GetByUsername(ctx, imported.Username)
+		if err != nil {
+			if err == domain.ErrUserNotFound {
+				result.UnknownUsers = append(result.UnknownUsers, imported.Username)
+				continue
+			}
+			return nil, err
+		}
+		current, isMember := existingRoles[user.ID]
+		if isMember && current == imported.Role {
+			continue
+		}
+		change := domain.ProjectConfigChange{Section: "members", Target: imported.Username, Action: domain.ProjectConfigActionCreate, New: imported.Role}
+		if isMember {
+			change.Action = domain.ProjectConfigActionUpdate
+			change.Old = current
+		}
+		result.Changes = append(result.Changes, change)
+		memberChanges = append(memberChanges, memberChange{userID: user.ID, role: imported.Role, create: !isMember})
+	}
+
+	if params.DryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if settings != (domain.UpdateProjectParams{}) {
+			if _, err := s.projectService.Update(ctx, projectID, settings, userID); err != nil {
+				return err
+			}
+		}
+		for _, imported := range createWebhooks {
+			if _, err := s.webhookService.Create(ctx, projectID, toInboundWebhookParams(imported), userID); err != nil {
+				return err
+			}
+		}
+		for id, imported := range updateWebhooks {
+			if _, err := s.webhookService.Update(ctx, id, toInboundWebhookParams(imported), userID); err != nil {
+				return err
+			}
+		}
+		for _, change := range memberChanges {
+			if change.create {
+				_, err = s.memberService.AddMember(ctx, projectID, domain.AddMemberParams{MemberUserID: change.userID, Role: change.role}, userID)
+			} else {
+				_, err = s.memberService.UpdateMemberRole(ctx, projectID, change.userID, domain.UpdateMemberRoleParams{Role: change.role})
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return recordAudit(ctx, s.auditRepo, projectID, userID, domain.AuditActionConfigImport, map[string]interface{}{
+			"source":  config.Source,
+			"changes": result.Changes,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// checkLanguages 记录本实例不存在或未启用的语言
+func (s *ProjectConfigService) checkLanguages(ctx context.Context, codes []string, result *domain.ProjectConfigImportResult) error {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	active := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		active[language.Code] = language.Status == "active"
+	}
+	for _, code := range codes {
+		if !active[code] {
+			result.MissingLanguages = append(result.MissingLanguages, code)
+		}
+	}
+	return nil
+}
+
+// validateProjectConfig 在执行任何变更前校验整个配置文档
+func validateProjectConfig(config domain.ProjectConfig) error {
+	invalid := func(details string) error {
+		return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidProjectConfig.Code, domain.ErrInvalidProjectConfig.Message, details)
+	}
+
+	if config.Version != domain.ProjectConfigVersion {
+		return invalid("不支持的配置版本")
+	}
+	if status := config.Settings.Status; status != "" && status != "active" && status != "archived" {
+		return invalid("无效的项目状态: " + status)
+	}
+	if template := strings.TrimSpace(config.Settings.ExportFileTemplate); template != "" {
+		if err := ValidateExportFileTemplate(template); err != nil {
+			return invalid("无效的导出文件命名模板: " + template)
+		}
+	}
+
+	names := make(map[string]bool, len(config.Webhooks))
+	for _, webhook := range config.Webhooks {
+		if webhook.Name == "" || strings.TrimSpace(webhook.Name) != webhook.Name {
+			return invalid("Webhook 名称不能为空或包含首尾空格")
+		}
+		if names[webhook.Name] {
+			return invalid("重复的 Webhook 名称: " + webhook.Name)
+		}
+		names[webhook.Name] = true
+		if webhook.Provider != "" && !inboundWebhookProviders[webhook.Provider] {
+			return invalid("无效的 Webhook 来源系统: " + webhook.Provider)
+		}
+		if webhook.ConflictStrategy != "" && !IsValidConflictStrategy(webhook.ConflictStrategy) {
+			return invalid("无效的 Webhook 冲突策略: " + webhook.ConflictStrategy)
+		}
+		if webhook.Status != "" && webhook.Status != domain.InboundWebhookStatusActive && webhook.Status != domain.InboundWebhookStatusDisabled {
+			return invalid("无效的 Webhook 状态: " + webhook.Status)
+		}
+	}
+
+	usernames := make(map[string]bool, len(config.Members))
+	for _, member := range config.Members {
+		if member.Username == "" {
+			return invalid("成员用户名不能为空")
+		}
+		if usernames[member.Username] {
+			return invalid("重复的成员: " + member.Username)
+		}
+		usernames[member.Username] = true
+		if !projectMemberRoles[member.Role] {
+			return invalid("无效的成员角色: " + member.Role)
+		}
+	}
+	return nil
+}
+
+// webhookConfigDiffers 比较配置中给出的字段，未给出的字段视为不修改
+func webhookConfigDiffers(existing, imported domain.ProjectConfigWebhook) bool {
+	if imported.Provider != "" && imported.Provider != existing.Provider {
+		return true
+	}
+	if imported.ExternalProjectID != "" && imported.ExternalProjectID != existing.ExternalProjectID {
+		return true
+	}
+	if imported.LanguageMapping != nil && !reflect.DeepEqual(imported.LanguageMapping, existing.LanguageMapping) {
+		return true
+	}
+	if imported.ConflictStrategy != "" && imported.ConflictStrategy != existing.ConflictStrategy {
+		return true
+	}
+	return imported.Status != "" && imported.Status != existing.Status
+}
+
+// toProjectConfigWebhook 将入站 Webhook 转换为配置项
+func toProjectConfigWebhook(webhook *domain.InboundWebhook) domain.ProjectConfigWebhook {
+	mapping := map[string]string{}
+	if webhook.LanguageMapping != "" {
+		_ = json.Unmarshal([]byte(webhook.LanguageMapping), &mapping)
+	}
+	return domain.ProjectConfigWebhook{
+		Name:              webhook.Name,
+		Provider:          webhook.Provider,
+		ExternalProjectID: webhook.ExternalProjectID,
+		LanguageMapping:   mapping,
+		ConflictStrategy:  webhook.ConflictStrategy,
+		Status:            webhook.Status,
+	}
+}
+
+// toInboundWebhookParams 将配置项转换为入站 Webhook 参数
+func toInboundWebhookParams(webhook domain.ProjectConfigWebhook) domain.InboundWebhookParams {
+	return domain.InboundWebhookParams{
+		Name:              webhook.Name,
+		Provider:          webhook.Provider,
+		ExternalProjectID: webhook.ExternalProjectID,
+		LanguageMapping:   webhook.LanguageMapping,
+		ConflictStrategy:  webhook.ConflictStrategy,
+		Status:            webhook.Status,
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"yflow/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// duplicateBatchSize 每批复制的翻译条数
+	duplicateBatchSize = 500
+	// duplicateFinishTimeout 后台复制结束后写入任务状态的超时时间，服务停止时同样适用
+	duplicateFinishTimeout = 10 * time.Second
+)
+
+// ProjectDuplicateService 项目复制服务实现
+// 新项目、设置、命名空间、发布门槛和成员在一个事务中创建；翻译不超过 syncLimit 条时也在该事务中复制，
+// 否则事务提交后在后台按批复制，每批一个事务并更新任务进度
+type ProjectDuplicateService struct {
+	projectService  domain.ProjectService
+	projectRepo     domain.ProjectRepository
+	translationRepo domain.TranslationRepository
+	namespaceRepo   domain.NamespaceRepository
+	thresholdRepo   domain.ReleaseThresholdRepository
+	memberRepo      domain.ProjectMemberRepository
+	jobRepo         domain.ProjectDuplicateJobRepository
+	auditLogRepo    domain.AuditLogRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+	syncLimit       int
+	logger          *zap.Logger
+
+	ctx    context.Context // 后台复制使用，服务停止时取消
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewProjectDuplicateService 创建项目复制服务实例
+func NewProjectDuplicateService(
+	projectService domain.ProjectService,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	namespaceRepo domain.NamespaceRepository,
+	thresholdRepo domain.ReleaseThresholdRepository,
+	memberRepo domain.ProjectMemberRepository,
+	jobRepo domain.ProjectDuplicateJobRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	syncLimit int,
+	logger *zap.Logger,
+) *ProjectDuplicateService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ProjectDuplicateService{
+		projectService:  projectService,
+		projectRepo:     projectRepo,
+		translationRepo: translationRepo,
+		namespaceRepo:   namespaceRepo,
+		thresholdRepo:   thresholdRepo,
+		memberRepo:      memberRepo,
+		jobRepo:         jobRepo,
+		auditLogRepo:    auditLogRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+		syncLimit:       syncLimit,
+		logger:          logger,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+// Duplicate 复制项目到新项目
+// 新项目属于原项目的组织，使用原项目的源语言和导出文件命名模板，发起人成为新项目的 owner。
+// 复制的翻译保留审核状态，每条记录一条 create 变更历史
+func (s *ProjectDuplicateService) Duplicate(ctx context.Context, projectID uint64, params domain.ProjectDuplicateParams, userID uint64) (*domain.ProjectDuplicateJob, error) {
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	source, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	description := strings.TrimSpace(params.Description)
+	if description == "" {
+		description = source.Description
+	}
+	total, err := s.translationRepo.CountByPrefix(ctx, projectID, "")
+	if err != nil {
+		return nil, err
+	}
+	inline := s.syncLimit > 0 && total <= int64(s.syncLimit)
+
+	var job *domain.ProjectDuplicateJob
+	var namespaces map[uint64]uint64
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		project, err := s.projectService.Create(ctx, domain.CreateProjectParams{
+			Name:           params.Name,
+			Description:    description,
+			Slug:           params.Slug,
+			AutoSuffix:     params.AutoSuffix,
+			OrganizationID: source.OrganizationID,
+			SourceLanguage: source.SourceLanguage,
+		}, userID)
+		if err != nil {
+			return err
+		}
+		if source.ExportFileTemplate != "" {
+			project.ExportFileTemplate = source.ExportFileTemplate
+			if err := s.projectRepo.Update(ctx, project); err != nil {
+				return err
+			}
+		}
+
+		if namespaces, err = s.copyNamespaces(ctx, source.ID, project.ID, userID); err != nil {
+			return err
+		}
+		if err := s.copyThresholds(ctx, source.ID, project.ID, userID); err != nil {
+			return err
+		}
+		if err := s.copyMembers(ctx, source.ID, project.ID, params.IncludeMembers, userID); err != nil {
+			return err
+		}
+
+		job = &domain.ProjectDuplicateJob{
+			SourceProjectID: source.ID,
+			ProjectID:       project.ID,
+			Status:          domain.ProjectDuplicateStatusRunning,
+			IncludeMembers:  params.IncludeMembers,
+			Total:           total,
+			CreatedBy:       userID,
+		}
+		if err := s.jobRepo.Create(ctx, job); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.auditLogRepo, source.ID, userID, domain.AuditActionProjectDuplicate, map[string]interface{}{
+			"job_id":          job.ID,
+			"project_id":      project.ID,
+			"slug":            project.Slug,
+			"include_members": params.IncludeMembers,
+			"translations":    total,
+		}); err != nil {
+			return err
+		}
+		if !inline {
+			return nil
+		}
+
+		// 翻译较少时在同一事务中复制，失败时不留下新项目
+		if err := s.copyTranslations(ctx, job, namespaces, userID); err != nil {
+			return err
+		}
+		now := time.Now()
+		job.Status = domain.ProjectDuplicateStatusCompleted
+		job.FinishedAt = &now
+		if err := s.jobRepo.Update(ctx, job); err != nil {
+			return err
+		}
+		return s.publishCopied(ctx, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !inline {
+		s.wg.Add(1)
+		go s.run(*job, namespaces, userID)
+	}
+	return job, nil
+}
+
+// GetJob 获取从项目发起的复制任务
+func (s *ProjectDuplicateService) GetJob(ctx context.Context, projectID, jobID uint64) (*domain.ProjectDuplicateJob, error) {
+	job, err := s.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.SourceProjectID != projectID {
+		return nil, domain.ErrDuplicateJobNotFound
+	}
+	return job, nil
+}
+
+// Stop 停止后台复制，未完成的任务标记为失败
+func (s *ProjectDuplicateService) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 在后台复制翻译并写入任务结果
+func (s *ProjectDuplicateService) run(job domain.ProjectDuplicateJob, namespaces map[uint64]uint64, userID uint64) {
+	defer s.wg.Done()
+
+	copyErr := s.copyTranslations(s.ctx, &job, namespaces, userID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), duplicateFinishTimeout)
+	defer cancel()
+	now := time.Now()
+	job.FinishedAt = &now
+	if copyErr != nil {
+		job.Status = domain.ProjectDuplicateStatusFailed
+		job.Error = copyErr.Error()
+		if errors.Is(copyErr, context.Canceled) {
+			job.Error = "服务停止，复制中断"
+		}
+		if message := []rune(job.Error); len(message) > 500 {
+			job.Error = string(message[:500])
+		}
+		s.logger.Error("Failed to duplicate project translations",
+			zap.Uint64("job_id", job.ID),
+			zap.Uint64("source_project_id", job.SourceProjectID),
+			zap.Uint64("project_id", job.ProjectID),
+			zap.Int64("copied", job.Copied),
+			zap.Error(copyErr))
+	} else {
+		job.Status = domain.ProjectDuplicateStatusCompleted
+		if err := s.publishCopied(ctx, &job); err != nil {
+			s.logger.Error("Failed to publish duplicated translations", zap.Uint64("job_id", job.ID), zap.Error(err))
+		}
+	}
+	if err := s.jobRepo.Update(ctx, &job); err != nil {
+		s.logger.Error("Failed to update project duplicate job", zap.Uint64("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// copyTranslations 按批复制原项目中未删除的翻译，每批完成后更新任务进度
+func (s *ProjectDuplicateService) copyTranslations(ctx context.Context, job *domain.ProjectDuplicateJob, namespaces map[uint64]uint64, userID uint64) error {
+	return s.translationRepo.StreamByProject(ctx, job.SourceProjectID, duplicateBatchSize, func(batch []*domain.Translation) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		copies := make([]*domain.Translation, 0, len(batch))
+		for _, translation := range batch {
+			copies = append(copies, &domain.Translation{
+				ProjectID:     job.ProjectID,
+				KeyName:       translation.KeyName,
+				Context:       translation.Context,
+				LanguageID:    translation.LanguageID,
+				Value:         translation.Value,
+				ValueType:     translation.ValueType,
+				ValueSchema:   translation.ValueSchema,
+				MaxLength:     translation.MaxLength,
+				Tags:          translation.Tags,
+				Platform:      translation.Platform,
+				NamespaceID:   namespaces[translation.NamespaceID],
+				Status:        translation.Status,
+				ReviewStatus:  translation.ReviewStatus,
+				ReviewedBy:    translation.ReviewedBy,
+				ReviewedAt:    translation.ReviewedAt,
+				ReviewComment: translation.ReviewComment,
+				CreatedBy:     userID,
+				UpdatedBy:     userID,
+			})
+		}
+		return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+			if err := s.translationRepo.CreateBatch(ctx, copies); err != nil {
+				return err
+			}
+			job.Copied += int64(len(copies))
+			return s.jobRepo.Update(ctx, job)
+		})
+	})
+}
+
+// copyNamespaces 复制命名空间，返回原命名空间ID到新命名空间ID的映射
+func (s *ProjectDuplicateService) copyNamespaces(ctx context.Context, sourceID, projectID, userID uint64) (map[uint64]uint64, error) {
+	namespaces, err := s.namespaceRepo.GetByProjectID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	mapping := make(map[uint64]uint64, len(namespaces))
+	for _, namespace := range namespaces {
+		copied := &domain.Namespace{
+			ProjectID:   projectID,
+			Name:        namespace.Name,
+			Description: namespace.Description,
+			CreatedBy:   userID,
+			UpdatedBy:   userID,
+		}
+		if err := s.namespaceRepo.Create(ctx, copied); err != nil {
+			return nil, err
+		}
+		mapping[namespace.ID] = copied.ID
+	}
+	return mapping, nil
+}
+
+// copyThresholds 复制发布门槛
+func (s *ProjectDuplicateService) copyThresholds(ctx context.Context, sourceID, projectID, userID uint64) error {
+	thresholds, err := s.thresholdRepo.GetByProjectID(ctx, sourceID)
+	if err != nil || len(thresholds) == 0 {
+		return err
+	}
+	copies := make([]*domain.ReleaseThreshold, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		copies = append(copies, &domain.ReleaseThreshold{
+			ProjectID:     projectID,
+			LanguageID:    threshold.LanguageID,
+			MinCompletion: threshold.MinCompletion,
+			MinApproval:   threshold.MinApproval,
+			CreatedBy:     userID,
+			UpdatedBy:     userID,
+		})
+	}
+	return s.thresholdRepo.ReplaceByProjectID(ctx, projectID, copies)
+}
+
+// copyMembers 复制项目成员（includeMembers 为 true 时）并将发起人设为新项目的 owner
+func (s *ProjectDuplicateService) copyMembers(ctx context.Context, sourceID, projectID uint64, includeMembers bool, userID uint64) error {
+	if includeMembers {
+		members, err := s.memberRepo.GetByProjectID(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if member.UserID == userID {
+				continue
+			}
+			if err := s.memberRepo.CreateOrRestore(ctx, &domain.ProjectMember{
+				ProjectID: projectID,
+				UserID:    member.UserID,
+				Role:      member.Role,
+				Source:    member.Source,
+				CreatedBy: userID,
+				UpdatedBy: userID,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return s.memberRepo.CreateOrRestore(ctx, &domain.ProjectMember{
+		ProjectID: projectID,
+		UserID:    userID,
+		Role:      "owner",
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	})
+}
+
+// publishCopied 发布新项目的翻译创建事件
+func (s *ProjectDuplicateService) publishCopied(ctx context.Context, job *domain.ProjectDuplicateJob) error {
+	if job.Copied == 0 {
+		return nil
+	}
+	return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, job.ProjectID, domain.TranslationUpdatedPayload{
+		Action: domain.TranslationActionCreated,
+		Count:  int(job.Copied),
+	})
+}
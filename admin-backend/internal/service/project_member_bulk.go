@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// maxBulkMembers 一次批量添加的最大成员数量
+const maxBulkMembers = 500
+
+// memberRoles 可以分配的项目角色
+var memberRoles = map[string]bool{"owner": true, "editor": true, "viewer": true}
+
+// BulkAddMembers 批量添加项目成员
+// 每一项按用户ID或邮箱查找用户，用户不存在、已禁用、角色无效或与前面的项重复时该项失败，
+// 已是项目成员的用户跳过且角色不变；被移除过的成员关系会恢复。
+// 要求通知时在全部添加完成后逐个发送邮件，发送失败不影响已添加的成员
+func (s *ProjectMemberService) BulkAddMembers(ctx context.Context, projectID uint64, params domain.BulkAddMembersParams, createdBy uint64) (*domain.BulkAddMembersResult, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.Members) == 0 || len(params.Members) > maxBulkMembers {
+		return nil, domain.ErrInvalidBulkMembers
+	}
+	if params.Notify && !s.notifier.Enabled() {
+		return nil, domain.ErrMailNotConfigured
+	}
+
+	users, err := s.resolveBulkMemberUsers(ctx, params.Members)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.memberRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	existingRoles := make(map[uint64]string, len(members))
+	for _, member := range members {
+		existingRoles[member.UserID] = member.Role
+	}
+
+	result := &domain.BulkAddMembersResult{Results: make([]domain.BulkMemberResult, 0, len(params.Members))}
+	seen := make(map[uint64]int, len(params.Members))
+	var added []int
+	for i, entry := range params.Members {
+		item := domain.BulkMemberResult{
+			Index:  entry.Index,
+			UserID: entry.UserID,
+			Email:  strings.TrimSpace(entry.Email),
+			Role:   strings.ToLower(strings.TrimSpace(entry.Role)),
+		}
+		if item.Index == 0 {
+			item.Index = i + 1
+		}
+
+		user := users[i]
+		switch {
+		case entry.UserID == 0 && item.Email == "":
+			item.Message = "未指定用户ID或邮箱"
+		case user == nil:
+			item.Message = "用户不存在"
+		case user.Status != "active":
+			item.Message = "用户已禁用"
+		case item.Role == "":
+			item.Message = "未指定角色"
+		case !memberRoles[item.Role]:
+			item.Message = "无效的角色：" + entry.Role
+		case project.OrganizationID != 0 && !s.isOrganizationMember(ctx, project.OrganizationID, user.ID):
+			item.Message = domain.ErrNotOrganizationMember.Message
+		}
+		if user != nil {
+			item.UserID, item.Username, item.Email = user.ID, user.Username, user.Email
+		}
+		if item.Message == "" {
+			if index, duplicate := seen[user.ID]; duplicate {
+				item.Message = fmt.Sprintf("与第 %d 项重复", index)
+			} else {
+				seen[user.ID] = item.Index
+			}
+		}
+
+		switch {
+		case item.Message != "":
+			item.Status = domain.BulkMemberFailed
+			result.Failed++
+		case existingRoles[user.ID] != "":
+			item.Status = domain.BulkMemberExisting
+			item.Message = "用户已是项目成员（角色：" + existingRoles[user.ID] + "）"
+			result.Existing++
+		default:
+			member := &domain.ProjectMember{
+				ProjectID: projectID,
+				UserID:    user.ID,
+				Role:      item.Role,
+				CreatedBy: createdBy,
+				UpdatedBy: createdBy,
+			}
+			if err := s.memberRepo.CreateOrRestore(ctx, member); err != nil {
+				item.Status = domain.BulkMemberFailed
+				item.Message = "添加成员失败"
+				result.Failed++
+			} else {
+				item.Status = domain.BulkMemberAdded
+				result.Added++
+				added = append(added, len(result.Results))
+			}
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	if params.Notify {
+		for _, i := range added {
+			item := &result.Results[i]
+			data := domain.MemberAddedEmailData{ProjectName: project.Name, Role: item.Role}
+			if item.Email == "" {
+				item.EmailStatus = domain.InvitationEmailFailed
+				result.EmailFailed++
+			} else if err := s.notifier.Notify(ctx, item.Email, domain.EmailEventMemberAdded, data); err != nil {
+				item.EmailStatus = domain.InvitationEmailFailed
+				result.EmailFailed++
+			} else {
+				item.EmailStatus = domain.InvitationEmailSent
+				result.Emailed++
+			}
+		}
+	}
+	return result, nil
+}
+
+// ImportMembers 从 CSV/XLSX 导入项目成员
+// 第一行为表头（忽略大小写）：user 列可以填写用户ID或邮箱，也可以分别使用 user_id 和 email 列；
+// role 列为空或没有 role 列时使用默认角色。空行跳过，结果中的序号为表格中的行号
+func (s *ProjectMemberService) ImportMembers(ctx context.Context, projectID uint64, params domain.MemberImportParams, createdBy uint64) (*domain.BulkAddMembersResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	_, rows, err := ParseSpreadsheet(params.Data, params.Format)
+	if err != nil {
+		return nil, err
+	}
+	header, err := spreadsheetHeader(rows, 1)
+	if err != nil {
+		return nil, err
+	}
+	columns := spreadsheetColumnIndex(header)
+	column := func(name string) int {
+		if index, ok := columns[name]; ok {
+			return index
+		}
+		return -1
+	}
+	userColumn, userIDColumn, emailColumn, roleColumn := column("user"), column("user_id"), column("email"), column("role")
+	if userColumn < 0 && userIDColumn < 0 && emailColumn < 0 {
+		return nil, invalidSpreadsheet("缺少 user、user_id 或 email 列")
+	}
+
+	entries := make([]domain.BulkMemberEntry, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		if isBlankSpreadsheetRow(row) {
+			continue
+		}
+		entry := domain.BulkMemberEntry{
+			Index: i + 2,
+			Email: strings.TrimSpace(spreadsheetCell(row, emailColumn)),
+			Role:  strings.TrimSpace(spreadsheetCell(row, roleColumn)),
+		}
+		if entry.Role == "" {
+			entry.Role = params.DefaultRole
+		}
+		userID := strings.TrimSpace(spreadsheetCell(row, userIDColumn))
+		if user := strings.TrimSpace(spreadsheetCell(row, userColumn)); user != "" {
+			if strings.Contains(user, "@") {
+				entry.Email = user
+			} else {
+				userID = user
+			}
+		}
+		if userID != "" {
+			id, err := strconv.ParseUint(userID, 10, 64)
+			if err != nil {
+				return nil, invalidSpreadsheet(fmt.Sprintf("第 %d 行的用户ID无效：%s", entry.Index, userID))
+			}
+			entry.UserID = id
+		}
+		entries = append(entries, entry)
+	}
+
+	return s.BulkAddMembers(ctx, projectID, domain.BulkAddMembersParams{Members: entries, Notify: params.Notify}, createdBy)
+}
+
+// resolveBulkMemberUsers 按用户ID或邮箱查找每一项对应的用户，找不到时为 nil
+func (s *ProjectMemberService) resolveBulkMemberUsers(ctx context.Context, entries []domain.BulkMemberEntry) ([]*domain.User, error) {
+	var userIDs []uint64
+	for _, entry := range entries {
+		if entry.UserID != 0 {
+			userIDs = append(userIDs, entry.UserID)
+		}
+	}
+	byID := make(map[uint64]*domain.User, len(userIDs))
+	if len(userIDs) > 0 {
+		users, err := s.userRepo.GetByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+	}
+
+	result := make([]*domain.User, len(entries))
+	byEmail := make(map[string]*domain.User)
+	for i, entry := range entries {
+		if entry.UserID != 0 {
+			result[i] = byID[entry.UserID]
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(entry.Email))
+		if email == "" {
+			continue
+		}
+		user, looked := byEmail[email]
+		if !looked {
+			var err error
+			user, err = s.userRepo.GetByEmail(ctx, email)
+			if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+				return nil, err
+			}
+			byEmail[email] = user
+		}
+		result[i] = user
+	}
+	return result, nil
+}
@@ -6,10 +6,13 @@ import (
 )
 
 // ProjectMemberService 项目成员服务实现
+// 组织的 owner 和 admin 拥有组织下所有项目的 owner 权限；组织的项目只能添加组织成员
 type ProjectMemberService struct {
-	memberRepo  domain.ProjectMemberRepository
-	userRepo    domain.UserRepository
-	projectRepo domain.ProjectRepository
+	memberRepo    domain.ProjectMemberRepository
+	userRepo      domain.UserRepository
+	projectRepo   domain.ProjectRepository
+	orgMemberRepo domain.OrganizationMemberRepository
+	notifier      domain.EmailNotifier
 }
 
 // NewProjectMemberService 创建项目成员服务实例
@@ -17,18 +20,23 @@ func NewProjectMemberService(
 	memberRepo domain.ProjectMemberRepository,
 	userRepo domain.UserRepository,
 	projectRepo domain.ProjectRepository,
+	orgMemberRepo domain.OrganizationMemberRepository,
+	notifier domain.EmailNotifier,
 ) *ProjectMemberService {
 	return &ProjectMemberService{
-		memberRepo:  memberRepo,
-		userRepo:    userRepo,
-		projectRepo: projectRepo,
+		memberRepo:    memberRepo,
+		userRepo:      userRepo,
+		projectRepo:   projectRepo,
+		orgMemberRepo: orgMemberRepo,
+		notifier:      notifier,
 	}
 }
 
 // AddMember 添加项目成员
 func (s *ProjectMemberService) AddMember(ctx context.Context, projectID uint64, params domain.AddMemberParams, createdBy uint64) (*domain.ProjectMember, error) {
 	// 检查项目是否存在
-	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -37,6 +45,16 @@ func (s *ProjectMemberService) AddMember(ctx context.Context, projectID uint64,
 		return nil, err
 	}
 
+	// 组织的项目只能添加组织成员
+	if project.OrganizationID != 0 {
+		if _, err := s.orgMemberRepo.Get(ctx, project.OrganizationID, params.MemberUserID); err != nil {
+			if err == domain.ErrOrganizationMemberNotFound {
+				return nil, domain.ErrNotOrganizationMember
+			}
+			return nil, err
+		}
+	}
+
 	// 检查用户是否已是项目成员
 	if _, err := s.memberRepo.GetByProjectAndUser(ctx, projectID, params.MemberUserID); err == nil {
 		return nil, domain.ErrMemberExists
@@ -98,11 +116,16 @@ func (s *ProjectMemberService) GetProjectMembers(ctx context.Context, projectID
 		}
 
 		memberInfo := &domain.ProjectMemberInfo{
-			ID:       member.ID,
-			UserID:   member.UserID,
-			Username: user.Username,
-			Email:    user.Email,
-			Role:     member.Role,
+			ID:          member.ID,
+			UserID:      member.UserID,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+			AvatarURL:   user.AvatarURL(),
+			Email:       user.Email,
+			Language:    user.Language,
+			Timezone:    user.Timezone,
+			Role:        member.Role,
+			Source:      member.Source,
 		}
 		memberInfos = append(memberInfos, memberInfo)
 	}
@@ -146,7 +169,9 @@ func (s *ProjectMemberService) UpdateMemberRole(ctx context.Context, projectID,
 		return nil, err
 	}
 
+	// 手动修改角色后不再视为管理员同步添加的成员
 	member.Role = params.Role
+	member.Source = ""
 	if err := s.memberRepo.Update(ctx, member); err != nil {
 		return nil, err
 	}
@@ -183,6 +208,15 @@ func (s *ProjectMemberService) CheckPermission(ctx context.Context, userID, proj
 		return true, nil
 	}
 
+	// 组织的 owner 和 admin 拥有组织下所有项目的权限
+	organizationAdmin, err := s.isOrganizationAdmin(ctx, userID, projectID)
+	if err != nil {
+		return false, err
+	}
+	if organizationAdmin {
+		return true, nil
+	}
+
 	// 获取用户在项目中的角色
 	member, err := s.memberRepo.GetByProjectAndUser(ctx, projectID, userID)
 	if err != nil {
@@ -222,6 +256,15 @@ func (s *ProjectMemberService) GetMemberRole(ctx context.Context, userID, projec
 		return "owner", nil
 	}
 
+	// 组织的 owner 和 admin 默认为owner权限
+	organizationAdmin, err := s.isOrganizationAdmin(ctx, userID, projectID)
+	if err != nil {
+		return "", err
+	}
+	if organizationAdmin {
+		return "owner", nil
+	}
+
 	// 获取用户在项目中的角色
 	member, err := s.memberRepo.GetByProjectAndUser(ctx, projectID, userID)
 	if err != nil {
@@ -230,3 +273,32 @@ func (s *ProjectMemberService) GetMemberRole(ctx context.Context, userID, projec
 
 	return member.Role, nil
 }
+
+// isOrganizationAdmin 用户是否为项目所属组织的 owner 或 admin，项目不属于组织时为 false
+func (s *ProjectMemberService) isOrganizationAdmin(ctx context.Context, userID, projectID uint64) (bool, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		if err == domain.ErrProjectNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if project.OrganizationID == 0 {
+		return false, nil
+	}
+
+	member, err := s.orgMemberRepo.Get(ctx, project.OrganizationID, userID)
+	if err != nil {
+		if err == domain.ErrOrganizationMemberNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return domain.OrganizationRoleLevel(member.Role) >= domain.OrganizationRoleLevel(domain.OrganizationRoleAdmin), nil
+}
+
+// isOrganizationMember 用户是否为组织成员，查询失败时视为不是
+func (s *ProjectMemberService) isOrganizationMember(ctx context.Context, organizationID, userID uint64) bool {
+	_, err := s.orgMemberRepo.Get(ctx, organizationID, userID)
+	return err == nil
+}
@@ -2,10 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"yflow/internal/domain"
+	"strconv"
 	"strings"
-
-	"github.com/gosimple/slug"
 )
 
 // ProjectService 项目服务实现
@@ -13,46 +13,99 @@ type ProjectService struct {
 	projectRepo       domain.ProjectRepository
 	userRepo          domain.UserRepository
 	projectMemberRepo domain.ProjectMemberRepository
+	organizations     domain.OrganizationService
+	languageRepo      domain.LanguageRepository
+	auditLogRepo      domain.AuditLogRepository
+	eventBus          domain.EventBus
+	transactor        domain.Transactor
 }
 
 // NewProjectService 创建项目服务实例
+// 创建项目与事件发布在同一事务中执行；eventBus 为空时不发布事件，transactor 为空时不开启事务
 func NewProjectService(
 	projectRepo domain.ProjectRepository,
 	userRepo domain.UserRepository,
 	projectMemberRepo domain.ProjectMemberRepository,
+	organizations domain.OrganizationService,
+	languageRepo domain.LanguageRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
 ) *ProjectService {
 	return &ProjectService{
 		projectRepo:       projectRepo,
 		userRepo:          userRepo,
 		projectMemberRepo: projectMemberRepo,
+		organizations:     organizations,
+		languageRepo:      languageRepo,
+		auditLogRepo:      auditLogRepo,
+		eventBus:          eventBus,
+		transactor:        transactor,
 	}
 }
 
 // Create 创建项目
+// 在组织中创建项目需要是系统管理员或组织的 owner、admin
 func (s *ProjectService) Create(ctx context.Context, params domain.CreateProjectParams, userID uint64) (*domain.Project, error) {
-	// 生成slug
-	projectSlug := slug.Make(params.Name)
-	if projectSlug == "" {
-		return nil, domain.ErrInvalidSlug
+	if params.OrganizationID != 0 {
+		allowed, err := s.organizations.CheckRole(ctx, params.OrganizationID, userID, domain.OrganizationRoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, domain.ErrForbidden
+		}
+	}
+
+	// 使用自定义slug，未指定时根据名称生成
+	var baseSlug string
+	if custom := strings.TrimSpace(params.Slug); custom != "" {
+		if err := ValidateProjectSlug(custom); err != nil {
+			return nil, err
+		}
+		baseSlug = custom
+	} else {
+		baseSlug = slugFromName(params.Name)
+		if baseSlug == "" {
+			return nil, domain.ErrInvalidSlug
+		}
 	}
 
-	// 检查slug是否已存在
-	existingProject, err := s.projectRepo.GetBySlug(ctx, projectSlug)
-	if err == nil && existingProject != nil {
-		return nil, domain.ErrProjectExists
+	// 检查slug是否已被占用，按需追加后缀
+	projectSlug, err := s.availableSlug(ctx, baseSlug, params.AutoSuffix)
+	if err != nil {
+		return nil, err
 	}
 
 	// 创建项目
 	project := &domain.Project{
-		Name:        strings.TrimSpace(params.Name),
-		Description: strings.TrimSpace(params.Description),
-		Slug:        projectSlug,
-		Status:      "active",
-		CreatedBy:   userID,
-		UpdatedBy:   userID,
+		Name:           strings.TrimSpace(params.Name),
+		Description:    strings.TrimSpace(params.Description),
+		Slug:           projectSlug,
+		Status:         "active",
+		OrganizationID: params.OrganizationID,
+		CreatedBy:      userID,
+		UpdatedBy:      userID,
+	}
+	if err := s.setSourceLanguage(ctx, project, params.SourceLanguage); err != nil {
+		return nil, err
 	}
 
-	if err := s.projectRepo.Create(ctx, project); err != nil {
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.projectRepo.Create(ctx, project); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventProjectCreated, project.ID, domain.ProjectCreatedPayload{
+			Name:      project.Name,
+			Slug:      project.Slug,
+			CreatedBy: userID,
+		})
+	})
+	if err != nil {
+		// 并发创建同名项目或相同slug时由唯一索引拦截
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrProjectExists
+		}
 		return nil, err
 	}
 
@@ -64,8 +117,29 @@ func (s *ProjectService) GetByID(ctx context.Context, id uint64) (*domain.Projec
 	return s.projectRepo.GetByID(ctx, id)
 }
 
+// GetByIdentifier 根据项目ID或slug获取项目
+// 纯数字按ID查找；否则按当前slug查找，找不到时再按旧slug（别名）查找
+func (s *ProjectService) GetByIdentifier(ctx context.Context, identifier string) (*domain.Project, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, domain.ErrProjectNotFound
+	}
+	if id, err := strconv.ParseUint(identifier, 10, 64); err == nil {
+		return s.projectRepo.GetByID(ctx, id)
+	}
+
+	project, err := s.projectRepo.GetBySlug(ctx, identifier)
+	if err == nil {
+		return project, nil
+	}
+	if !errors.Is(err, domain.ErrProjectNotFound) {
+		return nil, err
+	}
+	return s.projectRepo.GetBySlugAlias(ctx, identifier)
+}
+
 // GetAll 获取所有项目
-func (s *ProjectService) GetAll(ctx context.Context, limit, offset int, keyword string) ([]*domain.Project, int64, error) {
+func (s *ProjectService) GetAll(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.Project, int64, error) {
 	if limit <= 0 {
 		limit = 10
 	}
@@ -76,7 +150,7 @@ func (s *ProjectService) GetAll(ctx context.Context, limit, offset int, keyword
 		offset = 0
 	}
 
-	return s.projectRepo.GetAll(ctx, limit, offset, keyword)
+	return s.projectRepo.GetAll(ctx, limit, offset, keyword, sort)
 }
 
 // Update 更新项目
@@ -87,19 +161,9 @@ func (s *ProjectService) Update(ctx context.Context, id uint64, params domain.Up
 		return nil, err
 	}
 
-	// 更新字段
+	// 更新字段（修改名称不再改变slug，slug通过 RenameSlug 修改）
 	if params.Name != "" {
 		project.Name = strings.TrimSpace(params.Name)
-		// 如果名称改变，重新生成slug
-		newSlug := slug.Make(params.Name)
-		if newSlug != project.Slug {
-			// 检查新slug是否已存在
-			existingProject, err := s.projectRepo.GetBySlug(ctx, newSlug)
-			if err == nil && existingProject != nil && existingProject.ID != project.ID {
-				return nil, domain.ErrProjectExists
-			}
-			project.Slug = newSlug
-		}
 	}
 
 	if params.Description != "" {
@@ -113,24 +177,197 @@ func (s *ProjectService) Update(ctx context.Context, id uint64, params domain.Up
 		project.Status = params.Status
 	}
 
+	if params.ExportFileTemplate != nil {
+		template := strings.TrimSpace(*params.ExportFileTemplate)
+		if err := ValidateExportFileTemplate(template); err != nil {
+			return nil, err
+		}
+		project.ExportFileTemplate = template
+	}
+
+	if params.SourceLanguage != nil {
+		if err := s.setSourceLanguage(ctx, project, *params.SourceLanguage); err != nil {
+			return nil, err
+		}
+	}
+
 	// 更新UpdatedBy字段
 	project.UpdatedBy = userID
 
 	// 保存更新
 	if err := s.projectRepo.Update(ctx, project); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrProjectExists
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// setSourceLanguage 设置项目的源语言，源语言必须是项目所属组织中启用的语言；为空时使用默认语言
+// 修改源语言不影响已有译文的审核状态，之后修改新源语言的译文时其他语言的译文才会过期
+func (s *ProjectService) setSourceLanguage(ctx context.Context, project *domain.Project, code string) error {
+	code = strings.TrimSpace(code)
+	if code != "" {
+		language, err := s.languageRepo.GetByCode(domain.WithOrganization(ctx, project.OrganizationID), code)
+		if err == domain.ErrLanguageNotFound || (err == nil && language.Status != "active") {
+			return domain.ErrInvalidSourceLanguage
+		}
+		if err != nil {
+			return err
+		}
+		code = language.Code
+	}
+	project.SourceLanguage = code
+	return nil
+}
+
+// RenameSlug 修改项目slug，旧slug保留为别名以便继续访问
+// 新slug与当前slug相同时不做修改；改回该项目曾用过的slug时移除对应别名
+func (s *ProjectService) RenameSlug(ctx context.Context, id uint64, newSlug string, userID uint64) (*domain.Project, error) {
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	newSlug = strings.TrimSpace(newSlug)
+	if err := ValidateProjectSlug(newSlug); err != nil {
+		return nil, err
+	}
+	if newSlug == project.Slug {
+		return project, nil
+	}
+
+	taken, err := s.projectRepo.SlugTaken(ctx, newSlug, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, domain.ErrSlugExists
+	}
+
+	oldSlug := project.Slug
+	project.Slug = newSlug
+	project.UpdatedBy = userID
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.projectRepo.DeleteSlugAlias(ctx, project.ID, newSlug); err != nil {
+			return err
+		}
+		if err := s.projectRepo.Update(ctx, project); err != nil {
+			return err
+		}
+		return s.projectRepo.CreateSlugAlias(ctx, &domain.ProjectSlugAlias{
+			ProjectID: project.ID,
+			Slug:      oldSlug,
+		})
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrSlugExists
+		}
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// availableSlug 返回未被占用的slug
+// autoSuffix 为 true 时依次尝试 base-2、base-3……，否则冲突时返回 ErrSlugExists
+func (s *ProjectService) availableSlug(ctx context.Context, base string, autoSuffix bool) (string, error) {
+	candidate := base
+	for n := 2; ; n++ {
+		taken, err := s.projectRepo.SlugTaken(ctx, candidate, 0)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		if !autoSuffix || n > maxSlugSuffix {
+			return "", domain.ErrSlugExists
+		}
+
+		suffix := "-" + strconv.Itoa(n)
+		candidate = truncateSlug(base, maxProjectSlugLength-len(suffix)) + suffix
+	}
+}
+
+// SetProtection 开启或关闭项目的删除保护，变更记录审计日志
+// 状态没有变化时不做修改
+func (s *ProjectService) SetProtection(ctx context.Context, id uint64, protected bool, userID uint64) (*domain.Project, error) {
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.Protected == protected {
+		return project, nil
+	}
+
+	project.Protected = protected
+	project.UpdatedBy = userID
+	action := domain.AuditActionProjectProtect
+	if !protected {
+		action = domain.AuditActionProjectUnprotect
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.projectRepo.Update(ctx, project); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditLogRepo, project.ID, userID, action, map[string]interface{}{
+			"protected": protected,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// SetDelivery 开启或关闭项目的公开分发，变更记录审计日志
+// 开启后应用可以不经认证获取项目最新发布版本的语言包；状态没有变化时不做修改
+func (s *ProjectService) SetDelivery(ctx context.Context, id uint64, enabled bool, userID uint64) (*domain.Project, error) {
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
+	if project.DeliveryEnabled == enabled {
+		return project, nil
+	}
+
+	project.DeliveryEnabled = enabled
+	project.UpdatedBy = userID
+	action := domain.AuditActionDeliveryEnable
+	if !enabled {
+		action = domain.AuditActionDeliveryDisable
+	}
 
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.projectRepo.Update(ctx, project); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditLogRepo, project.ID, userID, action, map[string]interface{}{
+			"delivery_enabled": enabled,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
 	return project, nil
 }
 
-// Delete 删除项目
+// Delete 删除项目，开启删除保护的项目不能删除
 func (s *ProjectService) Delete(ctx context.Context, id uint64) error {
 	// 检查项目是否存在
-	_, err := s.projectRepo.GetByID(ctx, id)
+	project, err := s.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if project.Protected {
+		return domain.ErrProjectProtected
+	}
 
 	// 删除项目
 	return s.projectRepo.Delete(ctx, id)
@@ -146,7 +383,7 @@ func (s *ProjectService) GetAccessibleProjects(ctx context.Context, userID uint6
 
 	// 如果是管理员，返回所有项目
 	if user.Role == "admin" {
-		return s.GetAll(ctx, limit, offset, keyword)
+		return s.GetAll(ctx, limit, offset, keyword, nil)
 	}
 
 	// 普通用户：获取用户参与的项目
@@ -202,3 +439,20 @@ func (s *ProjectService) GetAccessibleProjects(ctx context.Context, userID uint6
 	paginatedProjects := filteredProjects[start:end]
 	return paginatedProjects, total, nil
 }
+
+// ensureProjectsUnprotected 任一项目开启删除保护时返回 ErrProjectProtected，供批量删除操作在执行前检查
+func ensureProjectsUnprotected(ctx context.Context, projectRepo domain.ProjectRepository, projectIDs ...uint64) error {
+	if len(projectIDs) == 0 {
+		return nil
+	}
+	projects, err := projectRepo.GetByIDs(ctx, projectIDs)
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if project.Protected {
+			return domain.ErrProjectProtected
+		}
+	}
+	return nil
+}
@@ -2,7 +2,8 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"strconv"
+	"strings"
 	"yflow/internal/domain"
 )
 
@@ -33,18 +34,15 @@ func (s *CachedProjectService) Create(ctx context.Context, params domain.CreateP
 	}
 
 	// 清除项目列表缓存（包括所有分页的缓存）
-	baseKey := s.cacheService.GetProjectsKey()
-	s.cacheService.DeleteByPattern(ctx, baseKey+"*") // 使用通配符删除所有相关缓存
-
-	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	// 仪表板缓存由 project.created 事件的订阅方清除
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ProjectListPattern())
 
 	return project, nil
 }
 
 // GetByID 根据ID获取项目（使用缓存）
 func (s *CachedProjectService) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
-	cacheKey := s.cacheService.GetProjectKey(id)
+	cacheKey := domain.CacheKeys.Project(id).Info()
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.mutexManager.GetMutex(cacheKey)
@@ -81,15 +79,26 @@ func (s *CachedProjectService) GetByID(ctx context.Context, id uint64) (*domain.
 	return project, nil
 }
 
+// GetByIdentifier 根据项目ID或slug获取项目（按ID查找时使用缓存）
+func (s *CachedProjectService) GetByIdentifier(ctx context.Context, identifier string) (*domain.Project, error) {
+	if id, err := strconv.ParseUint(strings.TrimSpace(identifier), 10, 64); err == nil {
+		return s.GetByID(ctx, id)
+	}
+	return s.projectService.GetByIdentifier(ctx, identifier)
+}
+
 // GetAll 获取所有项目（使用缓存）
-func (s *CachedProjectService) GetAll(ctx context.Context, limit, offset int, keyword string) ([]*domain.Project, int64, error) {
+func (s *CachedProjectService) GetAll(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.Project, int64, error) {
 	// 生成缓存键
-	cacheKey := s.cacheService.GetProjectsKey()
+	parts := []string{strconv.Itoa(limit), strconv.Itoa(offset)}
 	if keyword != "" {
 		// 如果有搜索关键词，添加到缓存键中
-		cacheKey += ":search:" + keyword
+		parts = append([]string{"search", keyword}, parts...)
+	}
+	for _, field := range sort {
+		parts = append(parts, field.String())
 	}
-	cacheKey += fmt.Sprintf(":%d:%d", limit, offset)
+	cacheKey := domain.CacheKeys.ProjectList(parts...)
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.mutexManager.GetMutex(cacheKey)
@@ -112,7 +121,7 @@ func (s *CachedProjectService) GetAll(ctx context.Context, limit, offset int, ke
 	}
 
 	// 缓存未命中，从数据库获取
-	projects, total, err := s.projectService.GetAll(ctx, limit, offset, keyword)
+	projects, total, err := s.projectService.GetAll(ctx, limit, offset, keyword, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -139,14 +148,66 @@ func (s *CachedProjectService) Update(ctx context.Context, id uint64, params dom
 	}
 
 	// 清除该项目的缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetProjectKey(id))
+	s.cacheService.Delete(ctx, domain.CacheKeys.Project(id).Info())
 
 	// 清除项目列表缓存（包括所有分页的缓存）
-	baseKey := s.cacheService.GetProjectsKey()
-	s.cacheService.DeleteByPattern(ctx, baseKey+"*")
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ProjectListPattern())
 
 	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	s.cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
+
+	// 源语言变化时矩阵的 QA 检查和过期译文统计随之变化
+	if params.SourceLanguage != nil {
+		s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(id).MatrixPattern())
+	}
+
+	return project, nil
+}
+
+// RenameSlug 修改项目slug（更新缓存）
+func (s *CachedProjectService) RenameSlug(ctx context.Context, id uint64, newSlug string, userID uint64) (*domain.Project, error) {
+	project, err := s.projectService.RenameSlug(ctx, id, newSlug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除该项目的缓存
+	s.cacheService.Delete(ctx, domain.CacheKeys.Project(id).Info())
+
+	// 清除项目列表缓存（包括所有分页的缓存）
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ProjectListPattern())
+
+	return project, nil
+}
+
+// SetProtection 开启或关闭项目的删除保护（更新缓存）
+func (s *CachedProjectService) SetProtection(ctx context.Context, id uint64, protected bool, userID uint64) (*domain.Project, error) {
+	project, err := s.projectService.SetProtection(ctx, id, protected, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除该项目的缓存
+	s.cacheService.Delete(ctx, domain.CacheKeys.Project(id).Info())
+
+	// 清除项目列表缓存（包括所有分页的缓存）
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ProjectListPattern())
+
+	return project, nil
+}
+
+// SetDelivery 开启或关闭项目的公开分发（更新缓存）
+func (s *CachedProjectService) SetDelivery(ctx context.Context, id uint64, enabled bool, userID uint64) (*domain.Project, error) {
+	project, err := s.projectService.SetDelivery(ctx, id, enabled, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除该项目的缓存
+	s.cacheService.Delete(ctx, domain.CacheKeys.Project(id).Info())
+
+	// 清除项目列表缓存（包括所有分页的缓存）
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ProjectListPattern())
 
 	return project, nil
 }
@@ -159,14 +220,16 @@ func (s *CachedProjectService) Delete(ctx context.Context, id uint64) error {
 	}
 
 	// 清除该项目的缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetProjectKey(id))
+	s.cacheService.Delete(ctx, domain.CacheKeys.Project(id).Info())
+
+	// 清除该项目的 HTTP 响应缓存
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ResponsePattern(domain.ProjectResponseScope(id)))
 
 	// 清除项目列表缓存（包括所有分页的缓存）
-	baseKey := s.cacheService.GetProjectsKey()
-	s.cacheService.DeleteByPattern(ctx, baseKey+"*")
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.ProjectListPattern())
 
 	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	s.cacheService.Delete(ctx, domain.CacheKeys.DashboardStats())
 
 	return nil
 }
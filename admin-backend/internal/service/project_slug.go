@@ -0,0 +1,57 @@
+package service
+
+import (
+	"strings"
+	"yflow/internal/domain"
+
+	"github.com/gosimple/slug"
+)
+
+const (
+	// maxProjectSlugLength 项目slug最大长度，与 projects.slug 列长度一致
+	maxProjectSlugLength = 100
+	// maxSlugSuffix 自动追加后缀时尝试的最大序号
+	maxSlugSuffix = 100
+	// numericSlugPrefix 名称生成的slug为纯数字时添加的前缀，避免与项目ID混淆
+	numericSlugPrefix = "project-"
+)
+
+// ValidateProjectSlug 校验自定义项目slug
+// 只允许小写字母、数字、连字符和下划线，首尾不能是连字符或下划线；
+// 纯数字的slug会与项目ID混淆，不允许使用
+func ValidateProjectSlug(s string) error {
+	if len(s) > maxProjectSlugLength || !slug.IsSlug(s) || isNumericSlug(s) {
+		return domain.ErrInvalidSlug
+	}
+	return nil
+}
+
+// slugFromName 根据项目名称生成slug，无法生成时返回空字符串
+func slugFromName(name string) string {
+	s := slug.Make(name)
+	if s == "" {
+		return ""
+	}
+	if isNumericSlug(s) {
+		s = numericSlugPrefix + s
+	}
+	return truncateSlug(s, maxProjectSlugLength)
+}
+
+// truncateSlug 将slug截断到指定长度，并去掉末尾的连字符和下划线
+func truncateSlug(s string, maxLen int) string {
+	if len(s) > maxLen {
+		s = s[:maxLen]
+	}
+	return strings.TrimRight(s, "-_")
+}
+
+// isNumericSlug 检查slug是否为纯数字
+func isNumericSlug(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
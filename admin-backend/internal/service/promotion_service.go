@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+	"yflow/internal/domain"
+)
+
+const (
+	// promotionRemotePageSize 从源实例拉取翻译时每页的键数量，不超过 CLI 接口的分页上限
+	promotionRemotePageSize = 5000
+	// maxPromotionRemoteResponseSize 源实例单页响应的大小上限 (50MB)
+	maxPromotionRemoteResponseSize = 50 << 20
+)
+
+// PromotionRemoteOptions 从远程源实例拉取翻译的限制
+// 远程拉取由服务端发起请求，只允许连接列表中的实例，防止被用来访问内网服务
+type PromotionRemoteOptions struct {
+	AllowedHosts         []string // 允许的源实例主机名，带端口时只匹配该端口；为空时不允许远程源
+	AllowPrivateNetworks bool     // 是否允许连接回环、内网和链路本地地址
+	Timeout              time.Duration
+}
+
+// errPromotionAddressNotAllowed 源实例解析到的地址不允许连接
+var errPromotionAddressNotAllowed = errors.New("promotion source address not allowed")
+
+// PromotionService 环境推送服务实现
+// 以本实例为目标环境：从源环境获取翻译并计算差异，审核通过的差异写入本实例，应用记录使用 HMAC 签名
+type PromotionService struct {
+	promotionRepo      domain.PromotionRepository
+	projectRepo        domain.ProjectRepository
+	languageRepo       domain.LanguageRepository
+	translationRepo    domain.TranslationRepository
+	translationService domain.TranslationService
+	auditLogRepo       domain.AuditLogRepository
+	transactor         domain.Transactor
+	signingKey         []byte
+	allowedHosts       map[string]bool
+	httpClient         *http.Client
+}
+
+// NewPromotionService 创建环境推送服务实例
+// 写入翻译通过 TranslationService 完成，以复用其校验、事件和缓存失效逻辑
+func NewPromotionService(
+	promotionRepo domain.PromotionRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	translationService domain.TranslationService,
+	auditLogRepo domain.AuditLogRepository,
+	transactor domain.Transactor,
+	signingKey string,
+	remote PromotionRemoteOptions,
+) *PromotionService {
+	allowedHosts := make(map[string]bool, len(remote.AllowedHosts))
+	for _, host := range remote.AllowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			allowedHosts[host] = true
+		}
+	}
+	return &PromotionService{
+		promotionRepo:      promotionRepo,
+		projectRepo:        projectRepo,
+		languageRepo:       languageRepo,
+		translationRepo:    translationRepo,
+		translationService: translationService,
+		auditLogRepo:       auditLogRepo,
+		transactor:         transactor,
+		signingKey:         []byte(signingKey),
+		allowedHosts:       allowedHosts,
+		httpClient:         newPromotionHTTPClient(remote),
+	}
+}
+
+// newPromotionHTTPClient 创建拉取源实例的 HTTP 客户端
+func newPromotionHTTPClient(remote PromotionRemoteOptions) *http.Client {
+	return newOutboundHTTPClient(remote.Timeout, remote.AllowPrivateNetworks, errPromotionAddressNotAllowed)
+}
+
+// hostAllowed 判断源实例地址的主机是否在允许列表中
+func (s *PromotionService) hostAllowed(baseURL string) bool {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	return s.allowedHosts[strings.ToLower(parsed.Host)] || s.allowedHosts[strings.ToLower(parsed.Hostname())]
+}
+
+// CreateDiff 计算源环境与本实例项目的差异，并保存为待应用的推送记录
+func (s *PromotionService) CreateDiff(ctx context.Context, projectID uint64, params domain.PromotionSourceParams, userID uint64) (*domain.PromotionDetail, error) {
+	// 远程源的地址在访问数据库和发起请求之前校验
+	var baseURL string
+	if params.RemoteURL != "" {
+		var err error
+		if baseURL, err = normalizeRemoteURL(params.RemoteURL); err != nil {
+			return nil, err
+		}
+		if params.SourceProject == "" {
+			return nil, domain.ErrInvalidInput
+		}
+		if !s.hostAllowed(baseURL) {
+			return nil, promotionSourceError("source instance is not in PROMOTION_ALLOWED_HOSTS")
+		}
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	promotion := &domain.Promotion{
+		ProjectID: projectID,
+		Status:    domain.PromotionStatusPending,
+		CreatedBy: userID,
+	}
+
+	var source map[string]map[string]string
+	switch {
+	case baseURL != "":
+		var err error
+		source, err = s.fetchRemote(ctx, baseURL, params.APIKey, params.SourceProject)
+		if err != nil {
+			return nil, err
+		}
+		promotion.Source = baseURL
+		promotion.SourceProject = params.SourceProject
+	case params.Snapshot != nil:
+		source = params.Snapshot
+		promotion.Source = domain.PromotionSourceSnapshot
+		promotion.SourceProject = params.SourceProject
+	default:
+		return nil, domain.ErrInvalidInput
+	}
+
+	target, err := loadProjectValues(ctx, s.translationRepo, projectID)
+	if err != nil {
+		return nil, err
+	}
+	languageIDs, err := activeLanguageIDs(ctx, s.languageRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, missing := diffTranslations(source, target, languageIDs, params.IncludeRemovals)
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	promotion.Changes = string(data)
+	promotion.MissingLanguages = strings.Join(missing, ",")
+
+	if err := s.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return &domain.PromotionDetail{Promotion: promotion, Changes: changes}, nil
+}
+
+// GetByID 获取推送记录及其差异，已应用的记录同时校验签名
+func (s *PromotionService) GetByID(ctx context.Context, id uint64) (*domain.PromotionDetail, error) {
+	promotion, err := s.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := decodePromotionChanges(promotion.Changes)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := decodePromotionChanges(promotion.AppliedChanges)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PromotionDetail{
+		Promotion:      promotion,
+		Changes:        changes,
+		Applied:        applied,
+		SignatureValid: s.verifySignature(promotion),
+	}, nil
+}
+
+// GetByProjectID 分页获取项目的推送记录
+func (s *PromotionService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Promotion, int64, error) {
+	return s.promotionRepo.GetByProjectID(ctx, projectID, limit, offset)
+}
+
+// Apply 将审核通过的差异应用到本实例，返回已应用的差异
+// 计算差异后目标环境中相关翻译被修改过时拒绝应用，需要重新计算差异
+func (s *PromotionService) Apply(ctx context.Context, id uint64, params domain.PromotionApplyParams, userID uint64) (*domain.PromotionDetail, error) {
+	promotion, err := s.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if promotion.Status == domain.PromotionStatusApplied {
+		return nil, domain.ErrPromotionApplied
+	}
+
+	changes, err := decodePromotionChanges(promotion.Changes)
+	if err != nil {
+		return nil, err
+	}
+	approved, err := selectApprovedChanges(changes, params)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := loadProjectValues(ctx, s.translationRepo, promotion.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	languageIDs, err := activeLanguageIDs(ctx, s.languageRepo)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range approved {
+		if _, ok := languageIDs[change.Language]; !ok {
+			return nil, domain.ErrPromotionStale
+		}
+		value, exists := current[change.Key][change.Language]
+		if change.Type == domain.PromotionChangeAdded && exists ||
+			change.Type != domain.PromotionChangeAdded && (!exists || value != change.OldValue) {
+			return nil, domain.ErrPromotionStale
+		}
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := applyTranslationChanges(ctx, s.translationService, s.translationRepo, promotion.ProjectID, approved, languageIDs); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(approved)
+		if err != nil {
+			return err
+		}
+		appliedAt := time.Now().UTC().Truncate(time.Second)
+		promotion.Status = domain.PromotionStatusApplied
+		promotion.AppliedChanges = string(data)
+		promotion.AppliedBy = userID
+		promotion.AppliedAt = &appliedAt
+		promotion.Signature = s.sign(promotion)
+		if err := s.promotionRepo.Update(ctx, promotion); err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, s.auditLogRepo, promotion.ProjectID, userID, domain.AuditActionPromotionApply, map[string]interface{}{
+			"promotion_id":   promotion.ID,
+			"source":         promotion.Source,
+			"source_project": promotion.SourceProject,
+			"applied":        len(approved),
+			"signature":      promotion.Signature,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.PromotionDetail{
+		Promotion:      promotion,
+		Changes:        changes,
+		Applied:        approved,
+		SignatureValid: true,
+	}, nil
+}
+
+// verifySignature 校验已应用推送记录的签名，记录被篡改时返回 false
+func (s *PromotionService) verifySignature(promotion *domain.Promotion) bool {
+	if promotion.Status != domain.PromotionStatusApplied || promotion.Signature == "" {
+		return false
+	}
+	return hmac.Equal([]byte(promotion.Signature), []byte(s.sign(promotion)))
+}
+
+// sign 计算推送记录的签名，覆盖来源、应用人、应用时间和已应用的差异
+func (s *PromotionService) sign(promotion *domain.Promotion) string {
+	appliedAt := ""
+	if promotion.AppliedAt != nil {
+		appliedAt = promotion.AppliedAt.UTC().Format(time.RFC3339)
+	}
+	payload, _ := json.Marshal([]interface{}{
+		promotion.ID,
+		promotion.ProjectID,
+		promotion.Source,
+		promotion.SourceProject,
+		promotion.AppliedBy,
+		appliedAt,
+		promotion.AppliedChanges,
+	})
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// fetchRemote 通过源实例的 CLI 接口分页拉取项目的所有翻译
+func (s *PromotionService) fetchRemote(ctx context.Context, baseURL, apiKey, sourceProject string) (map[string]map[string]string, error) {
+	translations := make(map[string]map[string]string)
+	cursor := ""
+	for {
+		query := url.Values{}
+		query.Set("project_id", sourceProject)
+		query.Set("limit", fmt.Sprint(promotionRemotePageSize))
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		// 连接失败的原因和源实例的状态码不返回给调用方，避免被用来探测网络
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/cli/translations?"+query.Encode(), nil)
+		if err != nil {
+			return nil, promotionSourceError(promotionSourceUnavailable)
+		}
+		req.Header.Set("X-API-Key", apiKey)
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, promotionSourceError(promotionSourceUnavailable)
+		}
+		var page struct {
+			Data struct {
+				Translations map[string]map[string]string `json:"translations"`
+				NextCursor   string                       `json:"next_cursor"`
+				HasMore      bool                         `json:"has_more"`
+			} `json:"data"`
+		}
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxPromotionRemoteResponseSize)).Decode(&page)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, promotionSourceError(promotionSourceUnavailable)
+		}
+		if err != nil {
+			return nil, promotionSourceError("invalid response from source instance")
+		}
+
+		for keyName, values := range page.Data.Translations {
+			translations[keyName] = values
+		}
+		if !page.Data.HasMore {
+			return translations, nil
+		}
+		if page.Data.NextCursor == "" || page.Data.NextCursor == cursor {
+			return nil, promotionSourceError("source instance returned an invalid cursor")
+		}
+		cursor = page.Data.NextCursor
+	}
+}
+
+// promotionSourceUnavailable 无法从源实例获取翻译时返回的说明
+const promotionSourceUnavailable = "could not fetch translations from source instance, check the address, API key and project"
+
+// promotionSourceError 构造带原因说明的源环境错误
+func promotionSourceError(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeBadRequest, domain.ErrPromotionSourceFailed.Code, domain.ErrPromotionSourceFailed.Message, details)
+}
+
+// normalizeRemoteURL 校验源实例地址，只允许 http 和 https，去掉末尾的 /
+func normalizeRemoteURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", domain.ErrInvalidInput
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return strings.TrimRight(parsed.String(), "/"), nil
+}
+
+// diffTranslations 计算源环境相对目标环境的差异，按键名和语言代码排序
+// 目标环境不存在的语言无法应用，不计入差异，单独返回
+func diffTranslations(source, target map[string]map[string]string, languageIDs map[string]uint64, includeRemovals bool) ([]domain.PromotionChange, []string) {
+	changes := []domain.PromotionChange{}
+	missing := make(map[string]bool)
+
+	for keyName, values := range source {
+		for code, value := range values {
+			if _, ok := languageIDs[code]; !ok {
+				missing[code] = true
+				continue
+			}
+			old, exists := target[keyName][code]
+			switch {
+			case !exists:
+				changes = append(changes, domain.PromotionChange{Key: keyName, Language: code, Type: domain.PromotionChangeAdded, NewValue: value})
+			case old != value:
+				changes = append(changes, domain.PromotionChange{Key: keyName, Language: code, Type: domain.PromotionChangeModified, OldValue: old, NewValue: value})
+			}
+		}
+	}
+
+	if includeRemovals {
+		for keyName, values := range target {
+			for code, value := range values {
+				if _, exists := source[keyName][code]; !exists {
+					changes = append(changes, domain.PromotionChange{Key: keyName, Language: code, Type: domain.PromotionChangeRemoved, OldValue: value})
+				}
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Key != changes[j].Key {
+			return changes[i].Key < changes[j].Key
+		}
+		return changes[i].Language < changes[j].Language
+	})
+
+	missingCodes := make([]string, 0, len(missing))
+	for code := range missing {
+		missingCodes = append(missingCodes, code)
+	}
+	sort.Strings(missingCodes)
+	return changes, missingCodes
+}
+
+// selectApprovedChanges 根据审核结果选出要应用的差异
+func selectApprovedChanges(changes []domain.PromotionChange, params domain.PromotionApplyParams) ([]domain.PromotionChange, error) {
+	if params.All {
+		if len(changes) == 0 {
+			return nil, domain.ErrInvalidInput
+		}
+		return changes, nil
+	}
+	if len(params.Approved) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	index := make(map[domain.PromotionChangeRef]domain.PromotionChange, len(changes))
+	for _, change := range changes {
+		index[domain.PromotionChangeRef{Key: change.Key, Language: change.Language}] = change
+	}
+
+	approved := make([]domain.PromotionChange, 0, len(params.Approved))
+	seen := make(map[domain.PromotionChangeRef]bool, len(params.Approved))
+	for _, ref := range params.Approved {
+		change, ok := index[ref]
+		if !ok {
+			return nil, domain.ErrInvalidInput
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			approved = append(approved, change)
+		}
+	}
+	return approved, nil
+}
+
+// decodePromotionChanges 解析保存的差异
+func decodePromotionChanges(data string) ([]domain.PromotionChange, error) {
+	changes := []domain.PromotionChange{}
+	if data == "" {
+		return changes, nil
+	}
+	if err := json.Unmarshal([]byte(data), &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// DefaultQAMaxLengthRatio 译文长度默认最多为原文的倍数
+	DefaultQAMaxLengthRatio = 2.0
+	// qaLengthAllowance 原文较短时译文至少允许比原文多出的字符数
+	qaLengthAllowance = 10
+)
+
+var (
+	// mustachePlaceholderPattern {{name}} 或 {{{name}}} 形式的插值
+	mustachePlaceholderPattern = regexp.MustCompile(`\{\{\{?\s*([^{}]*?)\s*\}?\}\}`)
+	// printfPlaceholderPattern printf 格式的占位符，如 %s、%d、%1$s、%.2f、%@
+	printfPlaceholderPattern = regexp.MustCompile(`%(?:\d+\$)?[-+0#']*(?:\d+|\*)?(?:\.(?:\d+|\*))?(?:hh|h|ll|l|L|q|j|z|t)?[diouxXeEfFgGaAcspn@]`)
+	// inlineTagPattern 文本中的 HTML 标签
+	inlineTagPattern = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9-]*)(?:\s[^<>]*|/)?>`)
+)
+
+// CheckTranslation 按值类型检查一条译文，返回发现的问题（只填写 Type、Message 和 Suggestion）
+// markdown 和 html 按 CheckMarkup 检查；string 检查文本中的 HTML 标签是否配对。
+// source 为默认语言中同一键的文本，不为空时还检查占位符（{{name}}、printf 格式和 ICU 参数）、HTML 标签、
+// 末尾空白是否与源语言一致，以及译文是否超过原文长度的 maxLengthRatio 倍（不大于 0 时不检查长度）。
+// string 类型包含 plural、select 等参数时还按 CheckICUMessage 检查，复数选项按 language 的 CLDR 复数类别检查。
+// json 类型不检查
+func CheckTranslation(valueType, value, source, language string, maxLengthRatio float64) []domain.QAIssue {
+	if value == "" || valueType == domain.ValueTypeJSON {
+		return nil
+	}
+
+	var issues []domain.QAIssue
+	if valueType == domain.ValueTypeMarkdown || valueType == domain.ValueTypeHTML {
+		issues = CheckMarkup(valueType, value, source)
+	} else {
+		issues = checkInlineTags(value, source)
+		issues = append(issues, CheckICUMessage(value, source, language)...)
+		if source != "" && source != value {
+			if message := compareMarkupItems("占位符", placeholderNames(source), placeholderNames(value)); message != "" {
+				issues = append(issues, domain.QAIssue{Type: domain.QAIssuePlaceholderMismatch, Message: message})
+			}
+		}
+	}
+	if source == "" {
+		return issues
+	}
+
+	if issue, ok := checkTrailingWhitespace(value, source); ok {
+		issues = append(issues, issue)
+	}
+	if maxLengthRatio > 0 {
+		valueLength, sourceLength := utf8.RuneCountInString(value), utf8.RuneCountInString(source)
+		limit := int(math.Ceil(float64(sourceLength) * maxLengthRatio))
+		if limit < sourceLength+qaLengthAllowance {
+			limit = sourceLength + qaLengthAllowance
+		}
+		if valueLength > limit {
+			issues = append(issues, domain.QAIssue{
+				Type:    domain.QAIssueLengthExceeded,
+				Message: fmt.Sprintf("译文 %d 个字符，超过原文（%d 个字符）的 %g 倍", valueLength, sourceLength, maxLengthRatio),
+			})
+		}
+	}
+	return issues
+}
+
+// checkInlineTags 检查文本中的 HTML 标签是否配对，source 不为空时还要求开始标签与源语言一致
+func checkInlineTags(value, source string) []domain.QAIssue {
+	tags, structure := inlineTags(value)
+	var issues []domain.QAIssue
+	if len(structure) > 0 {
+		issues = append(issues, domain.QAIssue{Type: domain.QAIssueMarkupInvalid, Message: strings.Join(structure, "；")})
+	}
+	if source != "" && source != value {
+		sourceTags, _ := inlineTags(source)
+		if message := compareMarkupItems("标签", sourceTags, tags); message != "" {
+			issues = append(issues, domain.QAIssue{Type: domain.QAIssueTagMismatch, Message: message})
+		}
+	}
+	return issues
+}
+
+// inlineTags 返回文本中的开始标签（如 <b>）和无法配对之处，没有结束标签的元素不需要关闭
+func inlineTags(value string) ([]string, []string) {
+	if !strings.Contains(value, "<") {
+		return nil, nil
+	}
+
+	var tags, structure, stack []string
+	for _, match := range inlineTagPattern.FindAllStringSubmatch(value, -1) {
+		name := strings.ToLower(match[2])
+		selfClosing := strings.HasSuffix(match[0], "/>")
+		switch {
+		case match[1] == "":
+			tags = append(tags, "<"+name+">")
+			if !selfClosing && !voidMarkupTags[name] {
+				stack = append(stack, name)
+			}
+		case voidMarkupTags[name]:
+		case len(stack) > 0 && stack[len(stack)-1] == name:
+			stack = stack[:len(stack)-1]
+		default:
+			structure = append(structure, fmt.Sprintf("</%s> 没有对应的开始标签", name))
+		}
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		structure = append(structure, fmt.Sprintf("<%s> 没有关闭", stack[i]))
+	}
+	return tags, structure
+}
+
+// checkTrailingWhitespace 比较译文与源语言末尾的空白，不一致时 Suggestion 为改用源语言末尾空白后的译文
+func checkTrailingWhitespace(value, source string) (domain.QAIssue, bool) {
+	valueSpace := value[len(strings.TrimRightFunc(value, unicode.IsSpace)):]
+	sourceSpace := source[len(strings.TrimRightFunc(source, unicode.IsSpace)):]
+	if valueSpace == sourceSpace {
+		return domain.QAIssue{}, false
+	}
+
+	message := "译文末尾的空白与源语言不一致"
+	switch {
+	case valueSpace == "":
+		message = "源语言末尾有空白，译文没有"
+	case sourceSpace == "":
+		message = "译文末尾有多余的空白"
+	}
+	return domain.QAIssue{
+		Type:       domain.QAIssueTrailingWhitespace,
+		Message:    message,
+		Suggestion: value[:len(value)-len(valueSpace)] + sourceSpace,
+	}, true
+}
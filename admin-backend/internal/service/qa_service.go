@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// maxQAReportIssues QA 报告最多列出的问题数量
+const maxQAReportIssues = 1000
+
+// qaIssueTypes 支持的问题类型
+var qaIssueTypes = map[string]bool{
+	domain.QAIssueMarkupInvalid:       true,
+	domain.QAIssueDisallowedMarkup:    true,
+	domain.QAIssueLinkMismatch:        true,
+	domain.QAIssuePlaceholderMismatch: true,
+	domain.QAIssueGlossaryMismatch:    true,
+	domain.QAIssueForbiddenTerm:       true,
+	domain.QAIssueTagMismatch:         true,
+	domain.QAIssueTrailingWhitespace:  true,
+	domain.QAIssueLengthExceeded:      true,
+	domain.QAIssueICUInvalid:          true,
+	domain.QAIssuePluralCategory:      true,
+	domain.QAIssueSelectMismatch:      true,
+}
+
+// maxQALengthRatio 允许设置的最大长度倍数
+const maxQALengthRatio = 10
+
+// QAService 翻译质量检查服务实现
+// 按 CheckTranslation 检查 string、markdown 和 html 类型的键：标记能否正确解析、是否包含不允许的标签或链接，
+// 以及占位符、链接、标签、末尾空白和长度是否与默认语言的译文相符；项目有术语表时还检查译文是否符合术语表；
+// 并统计各语言的术语一致性。只报告问题，不修改翻译
+type QAService struct {
+	translationRepo domain.TranslationRepository
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	glossaryRepo    domain.GlossaryRepository
+}
+
+// NewQAService 创建翻译质量检查服务实例
+func NewQAService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	glossaryRepo domain.GlossaryRepository,
+) *QAService {
+	return &QAService{
+		translationRepo: translationRepo,
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		glossaryRepo:    glossaryRepo,
+	}
+}
+
+// GetReport 检查项目中 string、markdown 和 html 类型的有效翻译，项目有术语表时同时按术语表检查
+// 默认语言的译文作为源文本，自身只检查标记、不允许的内容和禁用译文；没有默认语言时不与源文本比较
+func (s *QAService) GetReport(ctx context.Context, projectID uint64, query domain.QAReportQuery) (*domain.QAReport, error) {
+	query.Language = strings.TrimSpace(query.Language)
+	query.Type = strings.TrimSpace(query.Type)
+	if query.Type != "" && !qaIssueTypes[query.Type] {
+		return nil, domain.ErrInvalidQAIssueType
+	}
+	if query.MaxLengthRatio == 0 {
+		query.MaxLengthRatio = DefaultQAMaxLengthRatio
+	}
+	if query.MaxLengthRatio < 1 || query.MaxLengthRatio > maxQALengthRatio {
+		return nil, domain.ErrInvalidQALengthRatio
+	}
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodes := make(map[uint64]string, len(languages))
+	var sourceLanguageID uint64
+	report := &domain.QAReport{Counts: make(map[string]int), Issues: []domain.QAIssue{}}
+	for _, lang := range languages {
+		languageCodes[lang.ID] = lang.Code
+		if source != nil && lang.ID == source.ID {
+			sourceLanguageID = lang.ID
+			report.SourceLanguage = lang.Code
+		}
+	}
+	if query.Language != "" {
+		found := false
+		for _, code := range languageCodes {
+			found = found || code == query.Language
+		}
+		if !found {
+			return nil, domain.ErrLanguageNotFound
+		}
+	}
+
+	terms, err := s.glossaryRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	valueTypes := []string{domain.ValueTypeString, domain.ValueTypeMarkdown, domain.ValueTypeHTML}
+	translations, err := s.translationRepo.GetActiveByValueTypes(ctx, projectID, valueTypes)
+	if err != nil {
+		return nil, err
+	}
+	sources := make(map[string]string)
+	keys := make(map[string]bool)
+	for _, translation := range translations {
+		keys[translation.KeyName] = true
+		if translation.LanguageID == sourceLanguageID {
+			sources[translation.KeyName] = translation.Value
+		}
+	}
+	report.Keys = len(keys)
+
+	for _, translation := range translations {
+		language := languageCodes[translation.LanguageID]
+		if query.Language != "" && language != query.Language {
+			continue
+		}
+		report.Translations++
+
+		source := ""
+		if translation.LanguageID != sourceLanguageID {
+			source = sources[translation.KeyName]
+		}
+		issues := CheckTranslation(translation.ValueType, translation.Value, source, language, query.MaxLengthRatio)
+		issues = append(issues, CheckGlossary(terms, translation.LanguageID, translation.Value, source)...)
+		for _, issue := range issues {
+			if query.Type != "" && issue.Type != query.Type {
+				continue
+			}
+			report.Counts[issue.Type]++
+			if len(report.Issues) >= maxQAReportIssues {
+				report.Truncated = true
+				continue
+			}
+			issue.KeyName = translation.KeyName
+			issue.Language = language
+			issue.TranslationID = translation.ID
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"yflow/internal/domain"
+)
+
+// maxReleaseReadinessKeys 每种未达标语言最多列出的缺少翻译或未审核通过的键数量
+const maxReleaseReadinessKeys = 500
+
+// ReleaseGateService 发布门槛服务实现
+// 完成率 = 有翻译的键 / 项目中有效的键，审核通过率 = 审核通过的键 / 项目中有效的键，已废弃的翻译不计入
+type ReleaseGateService struct {
+	thresholdRepo   domain.ReleaseThresholdRepository
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	translationRepo domain.TranslationRepository
+	transactor      domain.Transactor
+}
+
+// NewReleaseGateService 创建发布门槛服务实例
+func NewReleaseGateService(
+	thresholdRepo domain.ReleaseThresholdRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	transactor domain.Transactor,
+) *ReleaseGateService {
+	return &ReleaseGateService{
+		thresholdRepo:   thresholdRepo,
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		translationRepo: translationRepo,
+		transactor:      transactor,
+	}
+}
+
+// GetThresholds 获取项目的发布门槛
+func (s *ReleaseGateService) GetThresholds(ctx context.Context, projectID uint64) ([]*domain.ReleaseThreshold, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.thresholdRepo.GetByProjectID(ctx, projectID)
+}
+
+// SetThresholds 替换项目的全部发布门槛
+func (s *ReleaseGateService) SetThresholds(ctx context.Context, projectID uint64, params []domain.ReleaseThresholdParams, userID uint64) ([]*domain.ReleaseThreshold, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageIDs := make(map[string]uint64, len(languages))
+	for _, lang := range languages {
+		languageIDs[lang.Code] = lang.ID
+	}
+
+	thresholds := make([]*domain.ReleaseThreshold, 0, len(params))
+	used := make(map[uint64]bool, len(params))
+	for _, param := range params {
+		languageID, ok := languageIDs[strings.TrimSpace(param.LanguageCode)]
+		if !ok {
+			return nil, invalidReleaseThreshold("语言不存在：" + param.LanguageCode)
+		}
+		if used[languageID] {
+			return nil, invalidReleaseThreshold("语言重复：" + param.LanguageCode)
+		}
+		used[languageID] = true
+		if param.MinCompletion < 0 || param.MinCompletion > 100 || param.MinApproval < 0 || param.MinApproval > 100 {
+			return nil, invalidReleaseThreshold(fmt.Sprintf("%s 的门槛必须在 0 到 100 之间", param.LanguageCode))
+		}
+
+		thresholds = append(thresholds, &domain.ReleaseThreshold{
+			ProjectID:     projectID,
+			LanguageID:    languageID,
+			MinCompletion: param.MinCompletion,
+			MinApproval:   param.MinApproval,
+			CreatedBy:     userID,
+			UpdatedBy:     userID,
+		})
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		return s.thresholdRepo.ReplaceByProjectID(ctx, projectID, thresholds)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.thresholdRepo.GetByProjectID(ctx, projectID)
+}
+
+// Check 按发布门槛检查各启用语言的完成率和审核通过率
+// 没有设置门槛的语言只统计，不会阻止发布
+func (s *ReleaseGateService) Check(ctx context.Context, projectID uint64) (*domain.ReleaseReadiness, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	thresholds, err := s.thresholdRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	thresholdByLanguage := make(map[uint64]*domain.ReleaseThreshold, len(thresholds))
+	for _, threshold := range thresholds {
+		thresholdByLanguage[threshold.LanguageID] = threshold
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	translated, err := s.translationRepo.GetValuesByStatus(ctx, projectID, exportStatuses[""])
+	if err != nil {
+		return nil, err
+	}
+	approved, err := s.translationRepo.GetValuesByStatus(ctx, projectID, exportStatuses[domain.ExportOnlyStatusApproved])
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(translated))
+	for key := range translated {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	readiness := &domain.ReleaseReadiness{
+		TotalKeys: len(keys),
+		Blocking:  []string{},
+		Languages: []domain.LanguageReadiness{},
+	}
+	for _, language := range languages {
+		if language.Status != "active" {
+			continue
+		}
+
+		result := domain.LanguageReadiness{
+			LanguageCode:   language.Code,
+			MissingKeys:    []string{},
+			UnapprovedKeys: []string{},
+		}
+		var missingKeys, unapprovedKeys []string
+		for _, key := range keys {
+			switch {
+			case translated[key][language.Code] == "":
+				missingKeys = append(missingKeys, key)
+			case approved[key][language.Code] == "":
+				result.Translated++
+				unapprovedKeys = append(unapprovedKeys, key)
+			default:
+				result.Translated++
+				result.Approved++
+			}
+		}
+		completion, approval := readinessPercent(result.Translated, len(keys)), readinessPercent(result.Approved, len(keys))
+		result.Completion = math.Round(completion*100) / 100
+		result.Approval = math.Round(approval*100) / 100
+
+		if threshold, ok := thresholdByLanguage[language.ID]; ok {
+			result.MinCompletion = threshold.MinCompletion
+			result.MinApproval = threshold.MinApproval
+			result.Blocking = completion < float64(threshold.MinCompletion) || approval < float64(threshold.MinApproval)
+		}
+		if result.Blocking {
+			readiness.Blocking = append(readiness.Blocking, language.Code)
+			// 缺少翻译的键同时影响两种比率；只有审核通过率未达标时才列出未审核通过的键
+			result.MissingKeys, result.KeysTruncated = truncateReadinessKeys(missingKeys)
+			if approval < float64(result.MinApproval) {
+				var truncated bool
+				result.UnapprovedKeys, truncated = truncateReadinessKeys(unapprovedKeys)
+				result.KeysTruncated = result.KeysTruncated || truncated
+			}
+		}
+		readiness.Languages = append(readiness.Languages, result)
+	}
+
+	readiness.Ready = len(readiness.Blocking) == 0
+	return readiness, nil
+}
+
+// Ensure 发布前检查发布门槛
+// 未达标且没有覆盖时返回 ErrReleaseBlocked，同时返回检查结果以便列出阻止发布的语言和键
+func (s *ReleaseGateService) Ensure(ctx context.Context, projectID uint64, override bool) (*domain.ReleaseReadiness, error) {
+	readiness, err := s.Check(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if readiness.Ready {
+		return readiness, nil
+	}
+	if !override {
+		return readiness, domain.NewAppErrorWithDetails(domain.ErrorTypeConflict, domain.ErrReleaseBlocked.Code,
+			domain.ErrReleaseBlocked.Message, "未达标的语言："+strings.Join(readiness.Blocking, "、"))
+	}
+	readiness.Overridden = true
+	return readiness, nil
+}
+
+// readinessPercent 计算百分比，项目中没有键时视为 100%
+func readinessPercent(count, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(count) * 100 / float64(total)
+}
+
+// truncateReadinessKeys 截断键列表，返回是否被截断
+func truncateReadinessKeys(keys []string) ([]string, bool) {
+	if keys == nil {
+		return []string{}, false
+	}
+	if len(keys) > maxReleaseReadinessKeys {
+		return keys[:maxReleaseReadinessKeys], true
+	}
+	return keys, false
+}
+
+// invalidReleaseThreshold 带错误详情的发布门槛无效错误
+func invalidReleaseThreshold(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidReleaseThreshold.Code, domain.ErrInvalidReleaseThreshold.Message, details)
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// maxReleaseVersionLength 版本标签最大字符数，与 releases.version 列长度一致
+	maxReleaseVersionLength = 100
+	// maxReleaseDescriptionLength 发布说明最大字符数
+	maxReleaseDescriptionLength = 500
+)
+
+// releaseVersionPattern 版本标签用于 CLI 和导出的查询参数，只允许字母、数字、点、加号、下划线和连字符
+var releaseVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.+_-]*$`)
+
+// ReleaseService 发布版本服务实现
+type ReleaseService struct {
+	releaseRepo  domain.ReleaseRepository
+	projectRepo  domain.ProjectRepository
+	auditLogRepo domain.AuditLogRepository
+	gateService  domain.ReleaseGateService
+	eventBus     domain.EventBus
+	transactor   domain.Transactor
+}
+
+// NewReleaseService 创建发布版本服务实例
+func NewReleaseService(
+	releaseRepo domain.ReleaseRepository,
+	projectRepo domain.ProjectRepository,
+	auditLogRepo domain.AuditLogRepository,
+	gateService domain.ReleaseGateService,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *ReleaseService {
+	return &ReleaseService{
+		releaseRepo:  releaseRepo,
+		projectRepo:  projectRepo,
+		auditLogRepo: auditLogRepo,
+		gateService:  gateService,
+		eventBus:     eventBus,
+		transactor:   transactor,
+	}
+}
+
+// Create 发布版本：冻结项目中有效语言的有效翻译，同一项目中的版本标签不能重复
+// 有语言未达到发布门槛时返回 ErrReleaseBlocked 和检查结果，params.Override 为 true 时仍然发布并记录为已覆盖
+// params.RolloutPercentage 大于 0 时灰度发布，需要项目已有全量发布的版本；项目有进行中的灰度发布时不能再发布
+// 发布成功后发布 release.published 事件
+func (s *ReleaseService) Create(ctx context.Context, projectID uint64, params domain.ReleaseParams, userID uint64) (*domain.Release, *domain.ReleaseReadiness, error) {
+	version := strings.TrimSpace(params.Version)
+	if version == "" || utf8.RuneCountInString(version) > maxReleaseVersionLength || !releaseVersionPattern.MatchString(version) {
+		return nil, nil, invalidRelease("版本标签只能包含字母、数字、点、加号、下划线和连字符，以字母或数字开头，最多 100 个字符")
+	}
+	description := strings.TrimSpace(params.Description)
+	if utf8.RuneCountInString(description) > maxReleaseDescriptionLength {
+		return nil, nil, invalidRelease("说明最多 500 个字符")
+	}
+	if params.RolloutPercentage < 0 || params.RolloutPercentage >= 100 {
+		return nil, nil, invalidRelease("灰度百分比范围为 1 到 99，0 表示直接全量发布")
+	}
+
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, nil, domain.ErrProjectNotFound
+	}
+	_, err := s.releaseRepo.GetByVersion(ctx, projectID, version)
+	switch {
+	case err == nil:
+		return nil, nil, domain.ErrReleaseExists
+	case !errors.Is(err, domain.ErrReleaseNotFound):
+		return nil, nil, err
+	}
+	if err := s.checkRolloutAllowed(ctx, projectID, params.RolloutPercentage > 0); err != nil {
+		return nil, nil, err
+	}
+
+	readiness, err := s.gateService.Ensure(ctx, projectID, params.Override)
+	if err != nil {
+		return nil, readiness, err
+	}
+
+	release := &domain.Release{
+		ProjectID:      projectID,
+		Version:        version,
+		Description:    description,
+		GateOverridden: readiness.Overridden,
+		CreatedBy:      userID,
+	}
+	if params.RolloutPercentage > 0 {
+		release.RolloutStatus = domain.RolloutStatusActive
+		release.RolloutPercentage = params.RolloutPercentage
+	}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.releaseRepo.Create(ctx, release); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionReleaseCreate, map[string]interface{}{
+			"release_id":   release.ID,
+			"version":      release.Version,
+			"keys":         release.KeyCount,
+			"translations": release.TranslationCount,
+			"overridden":   release.GateOverridden,
+			"blocking":     readiness.Blocking,
+			"rollout":      release.RolloutPercentage,
+		}); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventReleasePublished, projectID, domain.ReleasePublishedPayload{
+			ReleaseID:        release.ID,
+			Version:          release.Version,
+			Description:      release.Description,
+			KeyCount:         release.KeyCount,
+			TranslationCount: release.TranslationCount,
+			GateOverridden:   release.GateOverridden,
+			CreatedBy:        userID,
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return release, readiness, nil
+}
+
+// GetByID 获取发布版本并确认其属于该项目
+func (s *ReleaseService) GetByID(ctx context.Context, projectID, releaseID uint64) (*domain.Release, error) {
+	release, err := s.releaseRepo.GetByID(ctx, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	if release.ProjectID != projectID {
+		return nil, domain.ErrReleaseNotFound
+	}
+	return release, nil
+}
+
+// GetByProjectID 分页获取项目的发布版本，最新的在前
+func (s *ReleaseService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Release, int64, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, 0, domain.ErrProjectNotFound
+	}
+	return s.releaseRepo.GetByProjectID(ctx, projectID, limit, offset)
+}
+
+// AdvanceRollout 提高灰度发布的百分比，百分比只能增加，已获取灰度版本的客户端保持不变；达到 100 时全量发布
+func (s *ReleaseService) AdvanceRollout(ctx context.Context, projectID, releaseID uint64, percentage int, userID uint64) (*domain.Release, error) {
+	release, err := s.getActiveRollout(ctx, projectID, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	if percentage <= release.RolloutPercentage || percentage > 100 {
+		return nil, invalidRelease(fmt.Sprintf("灰度百分比只能提高，范围为 %d 到 100", release.RolloutPercentage+1))
+	}
+
+	from := release.RolloutPercentage
+	release.RolloutPercentage = percentage
+	if percentage == 100 {
+		release.RolloutStatus = domain.RolloutStatusCompleted
+	}
+	err = s.saveRollout(ctx, release, userID, domain.AuditActionRolloutAdvance, map[string]interface{}{
+		"release_id": release.ID,
+		"version":    release.Version,
+		"from":       from,
+		"to":         percentage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// AbortRollout 中止灰度发布，之后不再分发该版本，所有请求获取最新的全量版本
+func (s *ReleaseService) AbortRollout(ctx context.Context, projectID, releaseID uint64, userID uint64) (*domain.Release, error) {
+	release, err := s.getActiveRollout(ctx, projectID, releaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	release.RolloutStatus = domain.RolloutStatusAborted
+	err = s.saveRollout(ctx, release, userID, domain.AuditActionRolloutAbort, map[string]interface{}{
+		"release_id": release.ID,
+		"version":    release.Version,
+		"percentage": release.RolloutPercentage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// checkRolloutAllowed 项目有进行中的灰度发布时不能再发布；灰度发布需要有全量版本分发给其余的请求
+func (s *ReleaseService) checkRolloutAllowed(ctx context.Context, projectID uint64, rollout bool) error {
+	_, err := s.releaseRepo.GetActiveRollout(ctx, projectID)
+	switch {
+	case err == nil:
+		return domain.ErrRolloutActive
+	case !errors.Is(err, domain.ErrReleaseNotFound):
+		return err
+	}
+	if !rollout {
+		return nil
+	}
+	_, err = s.releaseRepo.GetLatest(ctx, projectID)
+	if errors.Is(err, domain.ErrReleaseNotFound) {
+		return invalidRelease("项目还没有全量发布的版本，第一个版本不能灰度发布")
+	}
+	return err
+}
+
+// getActiveRollout 获取属于该项目且灰度中的发布版本
+func (s *ReleaseService) getActiveRollout(ctx context.Context, projectID, releaseID uint64) (*domain.Release, error) {
+	release, err := s.GetByID(ctx, projectID, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	if release.RolloutStatus != domain.RolloutStatusActive {
+		return nil, domain.ErrRolloutInactive
+	}
+	return release, nil
+}
+
+// saveRollout 保存灰度发布状态并记录审计日志
+func (s *ReleaseService) saveRollout(ctx context.Context, release *domain.Release, userID uint64, action string, details map[string]interface{}) error {
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.releaseRepo.UpdateRollout(ctx, release); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditLogRepo, release.ProjectID, userID, action, details)
+	})
+}
+
+// invalidRelease 带错误详情的发布版本无效错误
+func invalidRelease(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidRelease.Code, domain.ErrInvalidRelease.Message, details)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Java 和 .NET 资源文件格式
+const (
+	FormatProperties = "properties"
+	FormatResx       = "resx"
+	propertiesExt    = "properties"
+	resxExt          = "resx"
+)
+
+// propertiesLocale 将语言代码转换为 Java ResourceBundle 使用的 ll_CC 格式
+func propertiesLocale(code string) string {
+	return strings.ReplaceAll(code, "-", "_")
+}
+
+// MarshalProperties 生成一种语言的 Java .properties 文件
+// 键按名称排序，上下文说明作为 # 注释输出在键之前。文件只包含 ASCII 字符，其余字符转义为 \uXXXX（UTF-16），
+// Java 8 及以前按 ISO-8859-1 读取 ResourceBundle 时也不会乱码
+func MarshalProperties(values, contexts map[string]string) []byte {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		if context := contexts[key]; context != "" {
+			for _, line := range strings.Split(strings.ReplaceAll(context, "\r\n", "\n"), "\n") {
+				buf.WriteString("# " + escapePropertiesUnicode(strings.TrimRight(line, "\r")) + "\n")
+			}
+		}
+		buf.WriteString(escapeProperties(key, true) + "=" + escapeProperties(values[key], false) + "\n")
+	}
+	return buf.Bytes()
+}
+
+// escapeProperties 按 java.util.Properties 的规则转义键或值
+// 键中的空格、= 和 : 以及键首的 # 和 ! 需要转义；值只需转义开头的空格，其余空格原样保留
+func escapeProperties(value string, isKey bool) string {
+	var b strings.Builder
+	for i, r := range value {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\f':
+			b.WriteString(`\f`)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteString(`\ `)
+			} else {
+				b.WriteRune(r)
+			}
+		case '=', ':':
+			if isKey {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		case '#', '!':
+			if i == 0 {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		default:
+			writePropertiesRune(&b, r)
+		}
+	}
+	return b.String()
+}
+
+// escapePropertiesUnicode 只将非 ASCII 字符和控制字符转义为 \uXXXX，用于注释
+func escapePropertiesUnicode(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		writePropertiesRune(&b, r)
+	}
+	return b.String()
+}
+
+// writePropertiesRune 写入一个字符，非 ASCII 字符和控制字符转义为 \uXXXX，BMP 以外的字符写为代理对
+func writePropertiesRune(b *strings.Builder, r rune) {
+	switch {
+	case r >= 0x20 && r < 0x7f:
+		b.WriteRune(r)
+	case r > 0xffff:
+		r -= 0x10000
+		fmt.Fprintf(b, `\u%04X\u%04X`, 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+	default:
+		fmt.Fprintf(b, `\u%04X`, r)
+	}
+}
+
+// resxHeader .resx 文件的 resheader，与 Visual Studio 生成的文件相同，ResXResourceReader 依此识别格式
+const resxHeader = `  <resheader name="resmimetype">
+    <value>text/microsoft-resx</value>
+  </resheader>
+  <resheader name="version">
+    <value>2.0</value>
+  </resheader>
+  <resheader name="reader">
+    <value>System.Resources.ResXResourceReader, System.Windows.Forms, Version=4.0.0.0, Culture=neutral, PublicKeyToken=b77a5c561934e089</value>
+  </resheader>
+  <resheader name="writer">
+    <value>System.Resources.ResXResourceWriter, System.Windows.Forms, Version=4.0.0.0, Culture=neutral, PublicKeyToken=b77a5c561934e089</value>
+  </resheader>
+`
+
+// MarshalResx 生成一种语言的 .NET .resx 文件（UTF-8）
+// 键按名称排序，每个键为一个 data 元素并保留空白，上下文说明写入 comment。
+// 引号、换行和制表符转义为字符引用，在属性值中也不会被规范化；XML 1.0 不允许的控制字符替换为 U+FFFD
+func MarshalResx(values, contexts map[string]string) []byte {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	buf.WriteString("<root>\n")
+	buf.WriteString(resxHeader)
+	for _, key := range keys {
+		buf.WriteString(`  <data name="` + escapeXMLText(key) + `" xml:space="preserve">` + "\n")
+		buf.WriteString("    <value>" + escapeXMLText(values[key]) + "</value>\n")
+		if context := contexts[key]; context != "" {
+			buf.WriteString("    <comment>" + escapeXMLText(context) + "</comment>\n")
+		}
+		buf.WriteString("  </data>\n")
+	}
+	buf.WriteString("</root>\n")
+	return buf.Bytes()
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// roleSyncTimeout 定期同步的超时时间
+const roleSyncTimeout = 5 * time.Minute
+
+// globalRoleLevels 全局角色层级：admin > member > viewer
+var globalRoleLevels = map[string]int{
+	"admin":  3,
+	"member": 2,
+	"viewer": 1,
+}
+
+// isGlobalRoleDowngrade 判断全局角色是否降级
+func isGlobalRoleDowngrade(previousRole, newRole string) bool {
+	return globalRoleLevels[newRole] < globalRoleLevels[previousRole]
+}
+
+// RoleSyncService 全局角色与项目成员同步服务
+// 全局管理员在每个项目中以 owner 成员（来源 role_sync）出现在成员列表里；
+// 全局角色降级或不再是管理员时，其 owner 成员关系进入复核队列，由管理员决定保留、降级或移除，避免残留权限。
+// interval 大于 0 时随应用启动定期同步。
+type RoleSyncService struct {
+	userRepo    domain.UserRepository
+	projectRepo domain.ProjectRepository
+	memberRepo  domain.ProjectMemberRepository
+	reviewRepo  domain.RoleReviewRepository
+	transactor  domain.Transactor
+	interval    time.Duration
+	logger      *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRoleSyncService 创建角色同步服务，interval 为 0 时不定期同步
+func NewRoleSyncService(
+	userRepo domain.UserRepository,
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	reviewRepo domain.RoleReviewRepository,
+	transactor domain.Transactor,
+	interval time.Duration,
+	logger *zap.Logger,
+) *RoleSyncService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &RoleSyncService{
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+		memberRepo:  memberRepo,
+		reviewRepo:  reviewRepo,
+		transactor:  transactor,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+// Start 启动定期同步
+func (s *RoleSyncService) Start(ctx context.Context) error {
+	if s.interval <= 0 {
+		return nil
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期同步并等待当前同步完成
+func (s *RoleSyncService) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 同步循环，启动后立即同步一次
+func (s *RoleSyncService) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		syncCtx, cancel := context.WithTimeout(ctx, roleSyncTimeout)
+		result, err := s.Sync(syncCtx, 0)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("Scheduled role sync failed", zap.Error(err))
+		} else if err == nil && (result.MembersAdded > 0 || result.ReviewsQueued > 0) {
+			s.logger.Info("Scheduled role sync completed",
+				zap.Int("members_added", result.MembersAdded),
+				zap.Int("reviews_queued", result.ReviewsQueued),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync 为启用的全局管理员补充缺少的项目 owner 成员关系，并复核已不是管理员的用户由同步添加的 owner 成员关系
+// 管理员已有的成员关系（任何角色）保持不变；operatorID 为 0 表示定期同步
+func (s *RoleSyncService) Sync(ctx context.Context, operatorID uint64) (*domain.RoleSyncResult, error) {
+	admins, err := s.userRepo.GetByRole(ctx, "admin")
+	if err != nil {
+		return nil, err
+	}
+	projects, _, err := s.projectRepo.GetAll(ctx, -1, 0, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.RoleSyncResult{Projects: len(projects)}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		isAdmin := make(map[uint64]bool, len(admins))
+		for _, admin := range admins {
+			isAdmin[admin.ID] = true
+			if admin.Status != "active" {
+				continue
+			}
+			result.Admins++
+
+			members, err := s.memberRepo.GetByUserID(ctx, admin.ID)
+			if err != nil {
+				return err
+			}
+			joined := make(map[uint64]bool, len(members))
+			for _, member := range members {
+				joined[member.ProjectID] = true
+			}
+			for _, project := range projects {
+				if joined[project.ID] {
+					continue
+				}
+				if err := s.memberRepo.CreateOrRestore(ctx, &domain.ProjectMember{
+					ProjectID: project.ID,
+					UserID:    admin.ID,
+					Role:      "owner",
+					Source:    domain.ProjectMemberSourceRoleSync,
+					CreatedBy: operatorID,
+					UpdatedBy: operatorID,
+				}); err != nil {
+					return err
+				}
+				result.MembersAdded++
+			}
+		}
+
+		// 全局角色被直接修改（未经过用户管理接口）时，同步添加的 owner 成员关系在这里补充复核
+		synced, err := s.memberRepo.GetBySource(ctx, domain.ProjectMemberSourceRoleSync, "owner")
+		if err != nil {
+			return err
+		}
+		leftovers := make(map[uint64][]*domain.ProjectMember)
+		var userIDs []uint64
+		for _, member := range synced {
+			if isAdmin[member.UserID] {
+				continue
+			}
+			if leftovers[member.UserID] == nil {
+				userIDs = append(userIDs, member.UserID)
+			}
+			leftovers[member.UserID] = append(leftovers[member.UserID], member)
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+		users, err := s.userRepo.GetByIDs(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		for _, user := range users {
+			queued, err := s.queueReviews(ctx, user.ID, leftovers[user.ID], domain.RoleReviewReasonLeftover, user.Role)
+			if err != nil {
+				return err
+			}
+			result.ReviewsQueued += queued
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueueDowngradeReviews 全局角色降级时将用户全部的项目 owner 成员关系加入复核队列
+// 角色没有降级时不做处理；由用户管理接口在修改角色的同一事务中调用
+func (s *RoleSyncService) QueueDowngradeReviews(ctx context.Context, userID uint64, previousRole, newRole string) (int, error) {
+	if !isGlobalRoleDowngrade(previousRole, newRole) {
+		return 0, nil
+	}
+	members, err := s.memberRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return s.queueReviews(ctx, userID, members, domain.RoleReviewReasonDowngraded, newRole)
+}
+
+// queueReviews 将 owner 成员关系加入复核队列，已有待复核记录的项目跳过
+func (s *RoleSyncService) queueReviews(ctx context.Context, userID uint64, members []*domain.ProjectMember, reason, globalRole string) (int, error) {
+	pendingProjectIDs, err := s.reviewRepo.GetPendingProjectIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	pending := make(map[uint64]bool, len(pendingProjectIDs))
+	for _, projectID := range pendingProjectIDs {
+		pending[projectID] = true
+	}
+
+	var reviews []*domain.RoleReview
+	for _, member := range members {
+		if member.Role != "owner" || pending[member.ProjectID] {
+			continue
+		}
+		pending[member.ProjectID] = true
+		reviews = append(reviews, &domain.RoleReview{
+			ProjectID:  member.ProjectID,
+			UserID:     userID,
+			Role:       member.Role,
+			Reason:     reason,
+			GlobalRole: globalRole,
+			Status:     domain.RoleReviewStatusPending,
+		})
+	}
+	if err := s.reviewRepo.CreateBatch(ctx, reviews); err != nil {
+		return 0, err
+	}
+	return len(reviews), nil
+}
+
+// ListReviews 获取角色复核记录
+func (s *RoleSyncService) ListReviews(ctx context.Context, status string, limit, offset int) ([]*domain.RoleReview, int64, error) {
+	return s.reviewRepo.List(ctx, status, limit, offset)
+}
+
+// ResolveReview 处理角色复核
+// 保留或降级后成员关系不再视为同步添加；成员关系已不存在时记为已移除
+func (s *RoleSyncService) ResolveReview(ctx context.Context, id uint64, params domain.ResolveRoleReviewParams, userID uint64) (*domain.RoleReview, error) {
+	switch params.Action {
+	case domain.RoleReviewActionKeep, domain.RoleReviewActionRemove:
+	case domain.RoleReviewActionDowngrade:
+		if params.Role != "editor" && params.Role != "viewer" {
+			return nil, domain.ErrInvalidRoleReviewAction
+		}
+	default:
+		return nil, domain.ErrInvalidRoleReviewAction
+	}
+
+	var review *domain.RoleReview
+	err := withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		var err error
+		review, err = s.reviewRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if review.Status != domain.RoleReviewStatusPending {
+			return domain.ErrRoleReviewResolved
+		}
+
+		member, err := s.memberRepo.GetByProjectAndUser(ctx, review.ProjectID, review.UserID)
+		switch {
+		case err == domain.ErrMemberNotFound:
+			review.Status = domain.RoleReviewStatusRemoved
+		case err != nil:
+			return err
+		case params.Action == domain.RoleReviewActionRemove:
+			if err := s.memberRepo.Delete(ctx, review.ProjectID, review.UserID); err != nil {
+				return err
+			}
+			review.Status = domain.RoleReviewStatusRemoved
+		default:
+			review.Status = domain.RoleReviewStatusKept
+			if params.Action == domain.RoleReviewActionDowngrade {
+				member.Role = params.Role
+				review.Status = domain.RoleReviewStatusDowngraded
+			}
+			member.Source = ""
+			member.UpdatedBy = userID
+			if err := s.memberRepo.Update(ctx, member); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		review.ResolvedBy = userID
+		review.ResolvedAt = &now
+		return s.reviewRepo.Update(ctx, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// defaultSearchLimit 默认每页返回的搜索结果数量
+	defaultSearchLimit = 20
+	// maxSearchLimit 每页最多返回的搜索结果数量
+	maxSearchLimit = 100
+	// maxSearchCandidates 一次搜索最多计算相关度的翻译数量
+	maxSearchCandidates = 1000
+)
+
+// SearchService 翻译搜索服务实现
+// 在用户可访问的所有项目中按键名和译文搜索：先由搜索仓储（逐行匹配或全文索引）按搜索模式和过滤条件筛选候选翻译，
+// 再计算相关度排序并分页
+type SearchService struct {
+	searchRepo domain.TranslationSearchRepository
+	userRepo   domain.UserRepository
+	memberRepo domain.ProjectMemberRepository
+}
+
+// NewSearchService 创建翻译搜索服务实例
+func NewSearchService(
+	searchRepo domain.TranslationSearchRepository,
+	userRepo domain.UserRepository,
+	memberRepo domain.ProjectMemberRepository,
+) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+		userRepo:   userRepo,
+		memberRepo: memberRepo,
+	}
+}
+
+// Search 在用户可访问的项目中按键名和译文搜索翻译
+// 管理员可以搜索所有项目，其他用户只能搜索所参与的项目。相关度取键名和译文中较高的一个，
+// 按相关度、更新时间排序；匹配的翻译超过 maxSearchCandidates 条时只对搜索仓储返回的前 maxSearchCandidates 条排序
+func (s *SearchService) Search(ctx context.Context, userID uint64, params domain.SearchParams) (*domain.SearchResult, error) {
+	text := strings.TrimSpace(params.Query)
+	if text == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	mode := params.Mode
+	if mode == "" {
+		mode = domain.SearchModeFuzzy
+	}
+	if err := validateReviewStatus(params.ReviewStatus); err != nil {
+		return nil, err
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+	offset := max(params.Offset, 0)
+
+	scorer, err := newSearchScorer(mode, text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.SearchResult{Items: []domain.SearchHit{}}
+	projectIDs, err := s.accessibleProjectIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if params.ProjectID != 0 {
+		if projectIDs != nil && !slices.Contains(projectIDs, params.ProjectID) {
+			return nil, domain.ErrInsufficientPerm
+		}
+		projectIDs = []uint64{params.ProjectID}
+	}
+	if projectIDs != nil && len(projectIDs) == 0 {
+		return result, nil
+	}
+
+	candidates, err := s.searchRepo.FindCandidates(ctx, domain.TranslationSearchQuery{
+		Mode:         mode,
+		Text:         text,
+		Terms:        scorer.terms,
+		ProjectIDs:   projectIDs,
+		LanguageCode: params.Language,
+		Status:       params.Status,
+		ReviewStatus: params.ReviewStatus,
+		UpdatedBy:    params.UpdatedBy,
+		Tags:         normalizeTagFilter(params.Tags),
+		From:         params.From,
+		To:           params.To,
+		Limit:        maxSearchCandidates + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) > maxSearchCandidates {
+		candidates = candidates[:maxSearchCandidates]
+		result.Truncated = true
+	}
+
+	hits := make([]domain.SearchHit, 0, len(candidates))
+	for _, candidate := range candidates {
+		hit := domain.SearchHit{
+			TranslationID: candidate.TranslationID,
+			ProjectID:     candidate.ProjectID,
+			ProjectName:   candidate.ProjectName,
+			KeyName:       candidate.KeyName,
+			LanguageCode:  candidate.LanguageCode,
+			Value:         candidate.Value,
+			Status:        candidate.Status,
+			ReviewStatus:  candidate.ReviewStatus,
+			UpdatedBy:     candidate.UpdatedBy,
+			UpdatedAt:     candidate.UpdatedAt,
+		}
+		hit.MatchedField, hit.Score = scorer.match(candidate.KeyName, candidate.Value)
+		hits = append(hits, hit)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		a, b := hits[i], hits[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+		return a.TranslationID > b.TranslationID
+	})
+	result.Total = len(hits)
+	if offset < len(hits) {
+		result.Items = hits[offset:min(offset+limit, len(hits))]
+	}
+	return result, nil
+}
+
+// accessibleProjectIDs 返回用户可访问的项目ID，管理员返回 nil 表示不限项目
+func (s *SearchService) accessibleProjectIDs(ctx context.Context, userID uint64) ([]uint64, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == "admin" {
+		return nil, nil
+	}
+	members, err := s.memberRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]uint64, 0, len(members))
+	for _, member := range members {
+		projectIDs = append(projectIDs, member.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+// searchScorer 按搜索模式计算键名或译文与查询文本的相关度
+type searchScorer struct {
+	mode       string
+	text       string
+	pattern    *regexp.Regexp
+	normalized []rune
+	terms      []string // fuzzy 模式下用于筛选候选翻译的片段
+}
+
+// newSearchScorer 校验搜索模式和查询文本
+func newSearchScorer(mode, text string) (*searchScorer, error) {
+	scorer := &searchScorer{mode: mode, text: text}
+	switch mode {
+	case domain.SearchModeExact:
+	case domain.SearchModeRegex:
+		pattern, err := regexp.Compile("(?i)" + text)
+		if err != nil {
+			return nil, domain.ErrInvalidSearchRegex
+		}
+		scorer.pattern = pattern
+	case domain.SearchModeFuzzy:
+		scorer.normalized = normalizeTMText(text)
+		scorer.terms = tmSearchTerms(text)
+		if len(scorer.terms) == 0 {
+			scorer.terms = []string{text}
+		}
+	default:
+		return nil, domain.ErrInvalidSearchMode
+	}
+	return scorer, nil
+}
+
+// match 返回键名和译文中相关度较高的字段及其相关度
+func (s *searchScorer) match(keyName, value string) (string, float64) {
+	field, score := "value", s.score(value)
+	if keyScore := s.score(keyName); keyScore > score {
+		field, score = "key", keyScore
+	}
+	if score == 0 && s.mode == domain.SearchModeRegex {
+		// 数据库与 Go 的正则语法略有差异，数据库匹配而这里都不匹配的按最低的部分匹配计算
+		score = 0.5
+	}
+	return field, score
+}
+
+// score 计算相关度，保留两位小数
+// exact 和 regex 模式：完全相同为 1，部分匹配时在 0.5-1 之间按匹配部分所占的比例计算，不匹配为 0；
+// fuzzy 模式：编辑距离相似度与包含的片段比例的平均值
+func (s *searchScorer) score(field string) float64 {
+	if field == "" {
+		return 0
+	}
+	switch s.mode {
+	case domain.SearchModeExact:
+		if field == s.text {
+			return 1
+		}
+		if !strings.Contains(field, s.text) {
+			return 0
+		}
+		return partialMatchScore(s.text, field)
+	case domain.SearchModeRegex:
+		match := s.pattern.FindStringIndex(field)
+		if match == nil {
+			return 0
+		}
+		return partialMatchScore(field[match[0]:match[1]], field)
+	default:
+		lower := strings.ToLower(field)
+		contained := 0
+		for _, term := range s.terms {
+			if strings.Contains(lower, strings.ToLower(term)) {
+				contained++
+			}
+		}
+		coverage := float64(contained) / float64(len(s.terms))
+		similarity := tmSimilarity(s.normalized, normalizeTMText(field))
+		return math.Round((similarity+coverage)/2*100) / 100
+	}
+}
+
+// partialMatchScore 部分匹配的相关度：匹配部分越接近整个字段越高，完全覆盖时为 1
+func partialMatchScore(match, field string) float64 {
+	ratio := float64(len([]rune(match))) / float64(len([]rune(field)))
+	return math.Round((0.5+ratio/2)*100) / 100
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+)
+
+const (
+	// sessionTouchInterval 记录会话最近访问时间的最小间隔，避免每个请求都写 Redis
+	sessionTouchInterval = time.Minute
+	// maxUserAgentLength 保存的 User-Agent 最大长度
+	maxUserAgentLength = 255
+)
+
+// SessionService 登录会话服务实现
+// 会话保存在 Redis 中每个用户一个哈希表里，撤销时从哈希表删除并写入黑名单，
+// 黑名单保留到该会话签发的访问令牌全部过期，认证中间件据此拒绝已撤销会话的令牌
+type SessionService struct {
+	cacheService  domain.CacheService
+	accessTTL     time.Duration
+	sessionTTL    time.Duration
+	securityUtils *utils.SecurityUtils
+	logger        *zap.Logger
+}
+
+// NewSessionService 创建登录会话服务实例
+// accessTTL 为访问令牌有效期，sessionTTL 为刷新令牌有效期（会话最长存活时间）
+func NewSessionService(cacheService domain.CacheService, accessTTL, sessionTTL time.Duration, logger *zap.Logger) *SessionService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &SessionService{
+		cacheService:  cacheService,
+		accessTTL:     accessTTL,
+		sessionTTL:    sessionTTL,
+		securityUtils: utils.NewSecurityUtils(),
+		logger:        logger,
+	}
+}
+
+// startSession 登录成功后创建会话，返回的 context 用于签发带会话ID的令牌
+// sessions 为空时（未启用会话管理的测试）原样返回
+func startSession(ctx context.Context, sessions domain.SessionService, userID uint64) (context.Context, error) {
+	if sessions == nil {
+		return ctx, nil
+	}
+	session, err := sessions.Start(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.WithSessionID(ctx, session.ID), nil
+}
+
+// Start 为用户创建会话
+func (s *SessionService) Start(ctx context.Context, userID uint64) (*domain.Session, error) {
+	id, err := s.securityUtils.GenerateSecureToken(16)
+	if err != nil {
+		return nil, err
+	}
+	client := domain.ClientInfoFromContext(ctx)
+	now := time.Now()
+	session := &domain.Session{
+		ID:         id,
+		UserID:     userID,
+		UserAgent:  truncateRunes(client.UserAgent, maxUserAgentLength),
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.sessionTTL),
+	}
+	if err := s.save(ctx, session); err != nil {
+		return nil, err
+	}
+	// 哈希表随最近创建的会话续期，其中过期的会话在读取时清理
+	if err := s.cacheService.Expire(ctx, domain.CacheKeys.UserSessions(userID), s.sessionTTL); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Validate 检查会话未被撤销并记录最近访问时间
+func (s *SessionService) Validate(ctx context.Context, userID uint64, sessionID string) error {
+	if sessionID == "" {
+		return domain.ErrSessionRevoked
+	}
+	revoked, err := s.cacheService.Exists(ctx, domain.CacheKeys.RevokedSession(sessionID))
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return domain.ErrSessionRevoked
+	}
+
+	session, err := s.get(ctx, userID, sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.ErrSessionRevoked
+		}
+		return err
+	}
+	now := time.Now()
+	if now.After(session.ExpiresAt) {
+		_ = s.cacheService.HDel(ctx, domain.CacheKeys.UserSessions(userID), sessionID)
+		return domain.ErrSessionRevoked
+	}
+
+	client := domain.ClientInfoFromContext(ctx)
+	if now.Sub(session.LastSeenAt) < sessionTouchInterval && (client.IP == "" || client.IP == session.IP) {
+		return nil
+	}
+	session.LastSeenAt = now
+	if client.IP != "" {
+		session.IP = client.IP
+	}
+	if err := s.save(ctx, session); err != nil {
+		// 最近访问时间写入失败不影响请求
+		s.logger.Warn("Failed to touch session", zap.Uint64("user_id", userID), zap.Error(err))
+	}
+	return nil
+}
+
+// List 用户的有效会话，最近访问的在前
+func (s *SessionService) List(ctx context.Context, userID uint64) ([]*domain.Session, error) {
+	fields, err := s.cacheService.HGetAll(ctx, domain.CacheKeys.UserSessions(userID))
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return []*domain.Session{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sessions := make([]*domain.Session, 0, len(fields))
+	var expired []string
+	for id, value := range fields {
+		var session domain.Session
+		if err := json.Unmarshal([]byte(value), &session); err != nil || now.After(session.ExpiresAt) {
+			expired = append(expired, id)
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+	if len(expired) > 0 {
+		_ = s.cacheService.HDel(ctx, domain.CacheKeys.UserSessions(userID), expired...)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+	return sessions, nil
+}
+
+// Revoke 撤销用户的一个会话
+func (s *SessionService) Revoke(ctx context.Context, userID uint64, sessionID string) error {
+	if _, err := s.get(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	return s.revoke(ctx, userID, []string{sessionID})
+}
+
+// RevokeAll 撤销用户的全部会话
+func (s *SessionService) RevokeAll(ctx context.Context, userID uint64) (int, error) {
+	fields, err := s.cacheService.HGetAll(ctx, domain.CacheKeys.UserSessions(userID))
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	if err := s.revoke(ctx, userID, ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// revoke 先写入黑名单再从用户的会话中删除，黑名单写入失败时会话仍然可见，可以重试
+func (s *SessionService) revoke(ctx context.Context, userID uint64, sessionIDs []string) error {
+	for _, id := range sessionIDs {
+		if err := s.cacheService.Set(ctx, domain.CacheKeys.RevokedSession(id), "1", s.accessTTL); err != nil {
+			return err
+		}
+	}
+	return s.cacheService.HDel(ctx, domain.CacheKeys.UserSessions(userID), sessionIDs...)
+}
+
+// get 读取用户的会话
+func (s *SessionService) get(ctx context.Context, userID uint64, sessionID string) (*domain.Session, error) {
+	value, err := s.cacheService.HGet(ctx, domain.CacheKeys.UserSessions(userID), sessionID)
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return nil, domain.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session domain.Session
+	if err := json.Unmarshal([]byte(value), &session); err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+// save 写入会话
+func (s *SessionService) save(ctx context.Context, session *domain.Session) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return s.cacheService.HSet(ctx, domain.CacheKeys.UserSessions(session.UserID), session.ID, string(value))
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"yflow/internal/domain"
+)
+
+// projectSourceLanguage 获取项目的源语言：项目指定了源语言时按代码查找，否则使用项目所属组织的默认语言
+// 源语言不存在时返回 nil
+func projectSourceLanguage(ctx context.Context, languageRepo domain.LanguageRepository, project *domain.Project) (*domain.Language, error) {
+	ctx = domain.WithOrganization(ctx, project.OrganizationID)
+	var language *domain.Language
+	var err error
+	if project.SourceLanguage != "" {
+		language, err = languageRepo.GetByCode(ctx, project.SourceLanguage)
+	} else {
+		language, err = languageRepo.GetDefault(ctx)
+	}
+	if err == domain.ErrLanguageNotFound {
+		return nil, nil
+	}
+	return language, err
+}
+
+// sourceChange 一个项目中源语言译文被修改的键
+type sourceChange struct {
+	projectID        uint64
+	sourceLanguageID uint64
+	keyNames         []string
+}
+
+// sourceChanges 找出 translations 中会修改已有源语言译文值的键，按项目返回
+// 只比较已存在的译文，新增的源语言译文不影响其他语言
+func sourceChanges(
+	ctx context.Context,
+	translationRepo domain.TranslationRepository,
+	languageRepo domain.LanguageRepository,
+	projects []*domain.Project,
+	translations []*domain.Translation,
+) ([]sourceChange, error) {
+	var changes []sourceChange
+	for _, project := range projects {
+		source, err := projectSourceLanguage(ctx, languageRepo, project)
+		if err != nil {
+			return nil, err
+		}
+		if source == nil {
+			continue
+		}
+
+		values := make(map[string]string)
+		var keys []domain.TranslationKey
+		for _, t := range translations {
+			if t.ProjectID != project.ID || t.LanguageID != source.ID {
+				continue
+			}
+			if _, ok := values[t.KeyName]; !ok {
+				keys = append(keys, domain.TranslationKey{ProjectID: project.ID, KeyName: t.KeyName, LanguageID: source.ID})
+			}
+			values[t.KeyName] = t.Value
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		existing, err := translationRepo.GetByProjectKeyLanguages(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		change := sourceChange{projectID: project.ID, sourceLanguageID: source.ID}
+		for _, t := range existing {
+			if value, ok := values[t.KeyName]; ok && value != t.Value {
+				change.keyNames = append(change.keyNames, t.KeyName)
+			}
+		}
+		if len(change.keyNames) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// markOutdated 将源语言译文被修改的键在其他语言的译文标记为 outdated
+// 需要在写入新的译文之前执行：同时写入的其他语言译文如果值有变化会回到 draft
+func markOutdated(ctx context.Context, translationRepo domain.TranslationRepository, changes []sourceChange) error {
+	for _, change := range changes {
+		if _, err := translationRepo.MarkOutdated(ctx, change.projectID, change.sourceLanguageID, change.keyNames); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countOutdated 在矩阵中源语言的单元格上记录该键在其他语言中 outdated 的译文数，sourceCode 为空时不统计
+func countOutdated(matrix map[string]map[string]domain.TranslationCell, sourceCode string) {
+	if sourceCode == "" {
+		return
+	}
+	for _, row := range matrix {
+		cell, ok := row[sourceCode]
+		if !ok {
+			continue
+		}
+		cell.Outdated = 0
+		for code, other := range row {
+			if code != sourceCode && other.ReviewStatus == domain.ReviewStatusOutdated {
+				cell.Outdated++
+			}
+		}
+		row[sourceCode] = cell
+	}
+}
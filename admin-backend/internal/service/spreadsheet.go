@@ -0,0 +1,415 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"yflow/internal/domain"
+)
+
+// 支持导入的表格格式
+const (
+	SpreadsheetFormatCSV  = "csv"
+	SpreadsheetFormatXLSX = "xlsx"
+)
+
+// 表格大小限制，避免表头之外的异常单元格（如 XFD1048576）导致分配过多内存
+const (
+	maxSpreadsheetRows    = 100000
+	maxSpreadsheetColumns = 1000
+)
+
+// spreadsheetKeyHeaders 推测为键名列的表头（已标准化）
+var spreadsheetKeyHeaders = map[string]bool{
+	"key": true, "keys": true, "key name": true, "keyname": true, "string key": true,
+	"id": true, "string id": true, "identifier": true, "键": true, "键名": true, "标识": true,
+}
+
+// spreadsheetContextHeaders 推测为上下文列的表头（已标准化）
+var spreadsheetContextHeaders = map[string]bool{
+	"context": true, "description": true, "comment": true, "comments": true, "note": true, "notes": true,
+	"developer comment": true, "上下文": true, "描述": true, "说明": true, "备注": true,
+}
+
+// spreadsheetLocalePattern 形如语言代码的表头，如 en、zh-CN、pt_BR、zh-Hans
+var spreadsheetLocalePattern = regexp.MustCompile(`(?i)^[a-z]{2,3}([-_][a-z0-9]{2,4})?$`)
+
+// spreadsheetParenthesizedLocale 括号中的语言代码，如 English (en)、中文 [zh-CN]
+var spreadsheetParenthesizedLocale = regexp.MustCompile(`[(\[（]\s*([A-Za-z]{2,3}([-_][A-Za-z0-9]{2,4})?)\s*[)\]）]`)
+
+// ParseSpreadsheet 解析 CSV 或 XLSX 文件，返回所有行（XLSX 只读取第一个工作表）
+// format 为空时按文件内容识别：zip 文件视为 XLSX，其他视为 CSV
+func ParseSpreadsheet(data []byte, format string) (string, [][]string, error) {
+	if format == "" {
+		format = SpreadsheetFormatCSV
+		if isZipArchive(data) {
+			format = SpreadsheetFormatXLSX
+		}
+	}
+
+	var rows [][]string
+	var err error
+	switch format {
+	case SpreadsheetFormatCSV:
+		rows, err = parseCSV(data)
+	case SpreadsheetFormatXLSX:
+		rows, err = parseXLSX(data)
+	default:
+		return "", nil, domain.ErrUnsupportedSpreadsheetFormat
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	return format, rows, nil
+}
+
+// parseCSV 解析 CSV 文件，自动去除 UTF-8 BOM，并按第一行识别逗号、分号或制表符分隔
+// 与 encoding/csv 一致，完全空白的行被忽略，不计入行号
+func parseCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return nil, invalidSpreadsheet("CSV 文件必须使用 UTF-8 编码")
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectCSVDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, invalidSpreadsheet(err.Error())
+		}
+		if len(rows) >= maxSpreadsheetRows {
+			return nil, invalidSpreadsheet(fmt.Sprintf("表格不能超过 %d 行", maxSpreadsheetRows))
+		}
+		if len(record) > maxSpreadsheetColumns {
+			return nil, invalidSpreadsheet(fmt.Sprintf("表格不能超过 %d 列", maxSpreadsheetColumns))
+		}
+		rows = append(rows, record)
+	}
+	return rows, nil
+}
+
+// detectCSVDelimiter 按第一行中出现次数最多的分隔符识别，Excel 在部分地区导出的 CSV 使用分号
+func detectCSVDelimiter(data []byte) rune {
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+	delimiter, best := ',', bytes.Count(firstLine, []byte{','})
+	for _, candidate := range []rune{';', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(candidate))); n > best {
+			delimiter, best = candidate, n
+		}
+	}
+	return delimiter
+}
+
+// xlsxWorkbook 工作簿中的工作表列表
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships 工作簿的关系文件，记录工作表的路径
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxRichText 共享字符串或行内字符串，带格式的文本分为多段
+type xlsxRichText struct {
+	T string `xml:"t"`
+	R []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String 拼接所有文本段
+func (t xlsxRichText) String() string {
+	var sb strings.Builder
+	sb.WriteString(t.T)
+	for _, r := range t.R {
+		sb.WriteString(r.T)
+	}
+	return sb.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string       `xml:"r,attr"`
+			Type   string       `xml:"t,attr"`
+			Value  string       `xml:"v"`
+			Inline xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// parseXLSX 读取 XLSX 文件第一个工作表的单元格文本
+// 只解析共享字符串和单元格值，不计算公式（使用 Excel 保存的计算结果），数字按原样输出
+func parseXLSX(data []byte) ([][]string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, invalidSpreadsheet("不是有效的 XLSX 文件")
+	}
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+
+	var shared xlsxSharedStrings
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(file, &shared); err != nil {
+			return nil, err
+		}
+	}
+
+	file, ok := files[firstWorksheetPath(files)]
+	if !ok {
+		return nil, invalidSpreadsheet("XLSX 文件中没有工作表")
+	}
+	var sheet xlsxWorksheet
+	if err := decodeXLSXPart(file, &sheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		// 空行不会写入文件，按行号补齐，使表头行号与 Excel 中一致
+		index := row.Index
+		if index <= 0 {
+			index = len(rows) + 1
+		}
+		if index > maxSpreadsheetRows {
+			return nil, invalidSpreadsheet(fmt.Sprintf("表格不能超过 %d 行", maxSpreadsheetRows))
+		}
+		for len(rows) < index {
+			rows = append(rows, nil)
+		}
+
+		var values []string
+		for _, cell := range row.Cells {
+			column := len(values)
+			if cell.Ref != "" {
+				if column, ok = xlsxColumnIndex(cell.Ref); !ok {
+					return nil, invalidSpreadsheet("无效的单元格位置: " + cell.Ref)
+				}
+			}
+			if column >= maxSpreadsheetColumns {
+				return nil, invalidSpreadsheet(fmt.Sprintf("表格不能超过 %d 列", maxSpreadsheetColumns))
+			}
+			for len(values) <= column {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				i, err := strconv.Atoi(cell.Value)
+				if err != nil || i < 0 || i >= len(shared.Items) {
+					return nil, invalidSpreadsheet("无效的共享字符串: " + cell.Ref)
+				}
+				values[column] = shared.Items[i].String()
+			case "inlineStr":
+				values[column] = cell.Inline.String()
+			default:
+				values[column] = cell.Value
+			}
+		}
+		rows[index-1] = values
+	}
+	return rows, nil
+}
+
+// firstWorksheetPath 按工作簿中的顺序找到第一个工作表的路径，找不到时使用默认路径
+func firstWorksheetPath(files map[string]*zip.File) string {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	var workbook xlsxWorkbook
+	var rels xlsxRelationships
+	workbookFile, ok := files["xl/workbook.xml"]
+	relsFile, relsOK := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !relsOK || decodeXLSXPart(workbookFile, &workbook) != nil || decodeXLSXPart(relsFile, &rels) != nil {
+		return fallback
+	}
+	if len(workbook.Sheets) == 0 {
+		return fallback
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != workbook.Sheets[0].RelID {
+			continue
+		}
+		// 目标路径相对于 xl/ 目录，也可能是以 / 开头的绝对路径
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+	return fallback
+}
+
+// decodeXLSXPart 解析 XLSX 中的 XML 文件
+func decodeXLSXPart(file *zip.File, v interface{}) error {
+	rc, err := file.Open()
+	if err != nil {
+		return invalidSpreadsheet("不是有效的 XLSX 文件")
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxMigrationBundleFileSize+1))
+	if err != nil {
+		return invalidSpreadsheet("不是有效的 XLSX 文件")
+	}
+	if len(content) > maxMigrationBundleFileSize {
+		return invalidSpreadsheet(fmt.Sprintf("%s 解压后超过 %d 字节", file.Name, maxMigrationBundleFileSize))
+	}
+	if err := xml.Unmarshal(content, v); err != nil {
+		return invalidSpreadsheet(fmt.Sprintf("%s: %v", file.Name, err))
+	}
+	return nil
+}
+
+// xlsxColumnIndex 将单元格位置（如 AB12）转换为从 0 开始的列号
+func xlsxColumnIndex(ref string) (int, bool) {
+	column := 0
+	letters := 0
+	for _, c := range ref {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		column = column*26 + int(c-'A'+1)
+		letters++
+		if letters > 3 {
+			return 0, false
+		}
+	}
+	if letters == 0 {
+		return 0, false
+	}
+	return column - 1, true
+}
+
+// invalidSpreadsheet 带错误详情的表格无效错误
+func invalidSpreadsheet(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidSpreadsheet.Code, domain.ErrInvalidSpreadsheet.Message, details)
+}
+
+// spreadsheetCell 获取行中的单元格，列不存在时为空
+func spreadsheetCell(row []string, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	return row[index]
+}
+
+// normalizeSpreadsheetHeader 标准化表头：忽略首尾空白、大小写，- 和 _ 视为空格
+func normalizeSpreadsheetHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	header = strings.NewReplacer("_", " ", "-", " ").Replace(header)
+	return strings.Join(strings.Fields(header), " ")
+}
+
+// spreadsheetColumnIndex 按表头查找列，忽略首尾空白和大小写；表头重复时使用第一列
+func spreadsheetColumnIndex(header []string) map[string]int {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		if _, exists := index[key]; !exists {
+			index[key] = i
+		}
+	}
+	return index
+}
+
+// SuggestImportMapping 按表头推测列映射
+// 键名和上下文列按常见列名识别；语言列依次按语言代码、语言名称、括号中的语言代码匹配本系统的语言，
+// 同一语言只映射第一列。没有识别出键名列时，使用第一个未映射的列
+func SuggestImportMapping(header []string, languages []*domain.Language) *domain.ImportMappingSuggestion {
+	languageCodeToID := make(map[string]uint64, len(languages))
+	languageIDToCode := make(map[uint64]string, len(languages))
+	languageNameToID := make(map[string]uint64, len(languages))
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+		languageIDToCode[lang.ID] = lang.Code
+		if name := strings.ToLower(strings.TrimSpace(lang.Name)); name != "" {
+			languageNameToID[name] = lang.ID
+		}
+	}
+
+	suggestion := &domain.ImportMappingSuggestion{
+		Columns:         make([]string, 0, len(header)),
+		LanguageColumns: make(map[string]string),
+		UnmappedColumns: []string{},
+	}
+	mappedLanguages := make(map[uint64]bool)
+	var unmapped []string
+	for _, column := range header {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+		suggestion.Columns = append(suggestion.Columns, column)
+
+		normalized := normalizeSpreadsheetHeader(column)
+		if suggestion.KeyColumn == "" && spreadsheetKeyHeaders[normalized] {
+			suggestion.KeyColumn = column
+			continue
+		}
+		if suggestion.ContextColumn == "" && spreadsheetContextHeaders[normalized] {
+			suggestion.ContextColumn = column
+			continue
+		}
+
+		languageID, ok := languageNameToID[strings.ToLower(column)]
+		if !ok && spreadsheetLocalePattern.MatchString(column) {
+			languageID, ok = ResolveLanguageCode(column, languageCodeToID)
+		}
+		if !ok {
+			if match := spreadsheetParenthesizedLocale.FindStringSubmatch(column); match != nil {
+				languageID, ok = ResolveLanguageCode(match[1], languageCodeToID)
+			}
+		}
+		if ok && !mappedLanguages[languageID] {
+			mappedLanguages[languageID] = true
+			suggestion.LanguageColumns[column] = languageIDToCode[languageID]
+			continue
+		}
+		unmapped = append(unmapped, column)
+	}
+
+	if suggestion.KeyColumn == "" && len(unmapped) > 0 {
+		suggestion.KeyColumn = unmapped[0]
+		unmapped = unmapped[1:]
+	}
+	suggestion.UnmappedColumns = append(suggestion.UnmappedColumns, unmapped...)
+	return suggestion
+}
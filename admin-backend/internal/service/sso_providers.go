@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+)
+
+const (
+	// ssoHTTPTimeout 调用身份提供方接口的超时时间
+	ssoHTTPTimeout = 10 * time.Second
+	// oidcMetadataTTL OIDC 发现文档和签名公钥的缓存时间
+	oidcMetadataTTL = time.Hour
+	// ssoMaxResponseSize 身份提供方响应的最大读取长度
+	ssoMaxResponseSize = 1 << 20
+)
+
+// ssoProvider 身份提供方的授权码流程
+type ssoProvider interface {
+	// authURL 生成授权地址
+	authURL(ctx context.Context, redirectURI, state, nonce, challenge string) (string, error)
+	// exchange 用授权码换取令牌并返回用户信息
+	exchange(ctx context.Context, redirectURI, code, verifier, nonce string) (*domain.SSOIdentity, error)
+}
+
+// newSSOProvider 按配置的类型创建身份提供方
+func newSSOProvider(cfg config.SSOProviderConfig, client *http.Client) (ssoProvider, error) {
+	switch cfg.Type {
+	case "google", "oidc":
+		return &oidcProvider{cfg: cfg, client: client}, nil
+	case "github":
+		return &githubProvider{
+			cfg:          cfg,
+			client:       client,
+			authEndpoint: "https://github.com/login/oauth/authorize",
+			tokenURL:     "https://github.com/login/oauth/access_token",
+			apiBase:      "https://api.github.com",
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SSO provider type: %s", cfg.Type)
+	}
+}
+
+// oidcDiscovery OIDC 发现文档中使用的字段
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcProvider 通用 OIDC 身份提供方（Google 同样使用 OIDC）
+// 端点通过签发者的发现文档获取，ID Token 使用 JWKS 中的 RSA 公钥校验
+type oidcProvider struct {
+	cfg    config.SSOProviderConfig
+	client *http.Client
+
+	mu           sync.Mutex
+	discovery    *oidcDiscovery
+	discoveredAt time.Time
+	keys         map[string]*rsa.PublicKey
+	keysAt       time.Time
+}
+
+// metadata 获取发现文档，过期后重新获取
+func (p *oidcProvider) metadata(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil && time.Since(p.discoveredAt) < oidcMetadataTTL {
+		return p.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := ssoGetJSON(ctx, p.client, p.cfg.Issuer+"/.well-known/openid-configuration", "", &discovery); err != nil {
+		return nil, fmt.Errorf("discover OIDC provider %s: %w", p.cfg.Name, err)
+	}
+	if strings.TrimRight(discovery.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("OIDC provider %s issuer mismatch: %s", p.cfg.Name, discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC provider %s discovery document is incomplete", p.cfg.Name)
+	}
+	p.discovery = &discovery
+	p.discoveredAt = time.Now()
+	return p.discovery, nil
+}
+
+// publicKey 获取 ID Token 的签名公钥，找不到 kid 时重新获取 JWKS（提供方轮换了密钥）
+func (p *oidcProvider) publicKey(ctx context.Context, jwksURI, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok && time.Since(p.keysAt) < oidcMetadataTTL {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := ssoGetJSON(ctx, p.client, jwksURI, "", &jwks); err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	p.keys = keys
+	p.keysAt = time.Now()
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+	return key, nil
+}
+
+func (p *oidcProvider) authURL(ctx context.Context, redirectURI, state, nonce, challenge string) (string, error) {
+	discovery, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+	return appendQuery(discovery.AuthorizationEndpoint, query), nil
+}
+
+func (p *oidcProvider) exchange(ctx context.Context, redirectURI, code, verifier, nonce string) (*domain.SSOIdentity, error) {
+	discovery, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var token struct {
+		IDToken     string `json:"id_token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := ssoPostForm(ctx, p.client, discovery.TokenEndpoint, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {verifier},
+	}, &token); err != nil {
+		return nil, err
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token.IDToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.publicKey(ctx, discovery.JWKSURI, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token: %w", err)
+	}
+	if claimNonce, _ := claims["nonce"].(string); claimNonce != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	identity := oidcIdentity(claims)
+	if identity.Subject == "" {
+		return nil, errors.New("id_token has no subject")
+	}
+	// 部分提供方只在 UserInfo 中返回邮箱
+	if identity.Email == "" && discovery.UserinfoEndpoint != "" && token.AccessToken != "" {
+		userinfo := map[string]interface{}{}
+		if err := ssoGetJSON(ctx, p.client, discovery.UserinfoEndpoint, token.AccessToken, &userinfo); err == nil {
+			if info := oidcIdentity(userinfo); info.Subject == identity.Subject {
+				identity.Email, identity.EmailVerified = info.Email, info.EmailVerified
+			}
+		}
+	}
+	return identity, nil
+}
+
+// oidcIdentity 从 ID Token 或 UserInfo 的声明中读取用户信息
+func oidcIdentity(claims map[string]interface{}) *domain.SSOIdentity {
+	identity := &domain.SSOIdentity{}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.Username, _ = claims["preferred_username"].(string)
+	// email_verified 在部分提供方中是字符串
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+	return identity
+}
+
+// githubProvider GitHub OAuth App，GitHub 不支持 OIDC 登录，通过 API 获取用户和已验证的邮箱
+type githubProvider struct {
+	cfg          config.SSOProviderConfig
+	client       *http.Client
+	authEndpoint string
+	tokenURL     string
+	apiBase      string
+}
+
+func (p *githubProvider) authURL(ctx context.Context, redirectURI, state, nonce, challenge string) (string, error) {
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"read:user", "user:email"}
+	}
+	query := url.Values{
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+		"allow_signup":          {"false"},
+	}
+	return appendQuery(p.authEndpoint, query), nil
+}
+
+func (p *githubProvider) exchange(ctx context.Context, redirectURI, code, verifier, nonce string) (*domain.SSOIdentity, error) {
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := ssoPostForm(ctx, p.client, p.tokenURL, url.Values{
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+		"code_verifier": {verifier},
+	}, &token); err != nil {
+		return nil, err
+	}
+	// GitHub 在授权码无效时同样返回 200
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("exchange code: %s %s", token.Error, token.ErrorDescription)
+	}
+
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+	}
+	if err := ssoGetJSON(ctx, p.client, p.apiBase+"/user", token.AccessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, errors.New("GitHub user has no id")
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err := ssoGetJSON(ctx, p.client, p.apiBase+"/user/emails", token.AccessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &domain.SSOIdentity{
+		Subject:  fmt.Sprintf("%d", user.ID),
+		Name:     user.Name,
+		Username: user.Login,
+	}
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			identity.Email = email.Email
+			identity.EmailVerified = true
+			break
+		}
+	}
+	return identity, nil
+}
+
+// appendQuery 在地址后追加查询参数，地址中已有查询参数时保留
+func appendQuery(endpoint string, query url.Values) string {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return endpoint + separator + query.Encode()
+}
+
+// ssoGetJSON 发送 GET 请求并解析 JSON 响应，accessToken 不为空时以 Bearer 方式携带
+func ssoGetJSON(ctx context.Context, client *http.Client, endpoint, accessToken string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	return ssoDo(client, req, out)
+}
+
+// ssoPostForm 发送表单 POST 请求并解析 JSON 响应
+func ssoPostForm(ctx context.Context, client *http.Client, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return ssoDo(client, req, out)
+}
+
+// ssoDo 发送请求，非 2xx 响应返回包含响应内容的错误
+func ssoDo(client *http.Client, req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ssoMaxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, truncateRunes(string(body), 200))
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+)
+
+const (
+	// ssoSessionTTL 从跳转到身份提供方到回调的最长时间
+	ssoSessionTTL = 10 * time.Minute
+	// ssoSessionIssuer 登录会话的签发者，与访问令牌区分
+	ssoSessionIssuer = "yflow-sso"
+	// ssoCallbackPath 回调地址相对于后端地址的路径
+	ssoCallbackPath = "/api/auth/oidc/callback"
+	// ssoMaxUsernameAttempts 生成不重复用户名的最多尝试次数
+	ssoMaxUsernameAttempts = 100
+)
+
+// ssoUsernameInvalid 用户名中不允许的字符
+var ssoUsernameInvalid = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ssoSessionClaims 登录会话，保存在浏览器 Cookie 中，回调时校验 state 并取回 nonce 和 PKCE 校验码
+type ssoSessionClaims struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	jwt.RegisteredClaims
+}
+
+// SSOService 单点登录服务实现
+// 使用授权码流程（带 PKCE）登录，身份提供方账号第一次登录时按已验证的邮箱关联已有用户，没有对应用户时按配置自动创建
+type SSOService struct {
+	cfg           config.SSOConfig
+	sessionKey    []byte
+	providers     map[string]ssoProvider
+	userRepo      domain.UserRepository
+	identityRepo  domain.UserIdentityRepository
+	authService   domain.AuthService
+	sessions      domain.SessionService
+	securityUtils *utils.SecurityUtils
+	logger        *zap.Logger
+}
+
+// NewSSOService 创建单点登录服务实例，sessionSecret 用于签名登录会话
+func NewSSOService(
+	cfg config.SSOConfig,
+	sessionSecret string,
+	userRepo domain.UserRepository,
+	identityRepo domain.UserIdentityRepository,
+	authService domain.AuthService,
+	sessions domain.SessionService,
+	logger *zap.Logger,
+) (*SSOService, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	// 登录会话使用派生的密钥签名，不能被当作访问令牌使用
+	sessionKey := sha256.Sum256([]byte(ssoSessionIssuer + ":" + sessionSecret))
+	client := &http.Client{Timeout: ssoHTTPTimeout}
+
+	providers := make(map[string]ssoProvider, len(cfg.Providers))
+	for _, providerCfg := range cfg.Providers {
+		provider, err := newSSOProvider(providerCfg, client)
+		if err != nil {
+			return nil, err
+		}
+		providers[providerCfg.Name] = provider
+	}
+
+	return &SSOService{
+		cfg:           cfg,
+		sessionKey:    sessionKey[:],
+		providers:     providers,
+		userRepo:      userRepo,
+		identityRepo:  identityRepo,
+		authService:   authService,
+		sessions:      sessions,
+		securityUtils: utils.NewSecurityUtils(),
+		logger:        logger,
+	}, nil
+}
+
+// Providers 已配置的身份提供方，按配置顺序返回
+func (s *SSOService) Providers() []domain.SSOProvider {
+	providers := make([]domain.SSOProvider, 0, len(s.cfg.Providers))
+	for _, provider := range s.cfg.Providers {
+		providers = append(providers, domain.SSOProvider{
+			Name:        provider.Name,
+			Type:        provider.Type,
+			DisplayName: provider.DisplayName,
+		})
+	}
+	return providers
+}
+
+// Begin 开始登录，生成 state、nonce 和 PKCE 校验码
+func (s *SSOService) Begin(ctx context.Context, providerName string) (*domain.SSOAuthRequest, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, domain.ErrSSOProviderNotFound
+	}
+
+	claims := ssoSessionClaims{Provider: providerName}
+	for _, value := range []*string{&claims.State, &claims.Nonce, &claims.Verifier} {
+		token, err := s.securityUtils.GenerateSecureToken(32)
+		if err != nil {
+			return nil, err
+		}
+		*value = token
+	}
+	challenge := sha256.Sum256([]byte(claims.Verifier))
+
+	authURL, err := provider.authURL(ctx, s.redirectURI(), claims.State, claims.Nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    ssoSessionIssuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ssoSessionTTL)),
+	}
+	session, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SSOAuthRequest{AuthURL: authURL, Session: session}, nil
+}
+
+// Complete 校验回调参数，关联或创建用户并签发令牌
+func (s *SSOService) Complete(ctx context.Context, session, state, code string) (*domain.LoginResult, error) {
+	claims := &ssoSessionClaims{}
+	_, err := jwt.ParseWithClaims(session, claims, func(t *jwt.Token) (interface{}, error) {
+		return s.sessionKey, nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithIssuer(ssoSessionIssuer), jwt.WithExpirationRequired())
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(claims.State)) != 1 {
+		return nil, domain.ErrSSOStateMismatch
+	}
+	provider, ok := s.providers[claims.Provider]
+	if !ok {
+		return nil, domain.ErrSSOProviderNotFound
+	}
+
+	identity, err := provider.exchange(ctx, s.redirectURI(), code, claims.Verifier, claims.Nonce)
+	if err != nil {
+		return nil, domain.NewAppErrorWithCause(domain.ErrorTypeUnauthorized, domain.ErrSSOLoginFailed.Code, domain.ErrSSOLoginFailed.Message, err)
+	}
+
+	user, err := s.resolveUser(ctx, claims.Provider, identity)
+	if err != nil {
+		return nil, err
+	}
+	if ctx, err = startSession(ctx, s.sessions, user.ID); err != nil {
+		return nil, err
+	}
+
+	token, err := s.authService.GenerateToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := s.authService.GenerateRefreshToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponse := *user
+	userResponse.Password = ""
+	return &domain.LoginResult{
+		User:         &userResponse,
+		AccessToken:  token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// resolveUser 查找身份提供方账号关联的用户
+// 第一次登录时按已验证的邮箱关联已有用户，没有对应用户且允许自动创建时以默认角色创建用户
+func (s *SSOService) resolveUser(ctx context.Context, providerName string, identity *domain.SSOIdentity) (*domain.User, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, domain.ErrSSOEmailRequired
+	}
+	if !s.domainAllowed(identity.Email) {
+		return nil, domain.ErrSSODomainNotAllowed
+	}
+
+	var user *domain.User
+	link, err := s.identityRepo.GetByProviderSubject(ctx, providerName, identity.Subject)
+	switch {
+	case err == nil:
+		if user, err = s.userRepo.GetByID(ctx, link.UserID); err != nil {
+			return nil, err
+		}
+		if link.Email != identity.Email {
+			link.Email = identity.Email
+			if err := s.identityRepo.Update(ctx, link); err != nil {
+				return nil, err
+			}
+		}
+	case errors.Is(err, domain.ErrSSOIdentityNotFound):
+		if user, err = s.userForNewIdentity(ctx, providerName, identity); err != nil {
+			return nil, err
+		}
+		if err := s.identityRepo.Create(ctx, &domain.UserIdentity{
+			UserID:   user.ID,
+			Provider: providerName,
+			Subject:  identity.Subject,
+			Email:    identity.Email,
+		}); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if user.Status != "active" {
+		return nil, domain.ErrUserDisabled
+	}
+	return user, nil
+}
+
+// userForNewIdentity 为第一次登录的身份提供方账号查找或创建用户
+func (s *SSOService) userForNewIdentity(ctx context.Context, providerName string, identity *domain.SSOIdentity) (*domain.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, identity.Email)
+	if err == nil {
+		s.logger.Info("SSO identity linked to existing user",
+			zap.String("provider", providerName),
+			zap.Uint64("user_id", user.ID),
+		)
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	if !s.cfg.AutoProvision {
+		return nil, domain.ErrSSOUserNotProvisioned
+	}
+
+	username, err := s.availableUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	// 自动创建的用户只能通过单点登录或找回密码登录
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user = &domain.User{
+		Username: username,
+		Email:    identity.Email,
+		Password: string(hashedPassword),
+		Role:     s.cfg.DefaultRole,
+		Status:   "active",
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	s.logger.Info("SSO user provisioned",
+		zap.String("provider", providerName),
+		zap.Uint64("user_id", user.ID),
+		zap.String("username", user.Username),
+		zap.String("role", user.Role),
+	)
+	return user, nil
+}
+
+// availableUsername 按提供方用户名或邮箱生成未被占用的用户名
+func (s *SSOService) availableUsername(ctx context.Context, identity *domain.SSOIdentity) (string, error) {
+	base := identity.Username
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = strings.Trim(ssoUsernameInvalid.ReplaceAllString(base, "-"), ".-")
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	if len(base) < 3 {
+		base = "user-" + base
+	}
+
+	for i := 1; i <= ssoMaxUsernameAttempts; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		_, err := s.userRepo.GetByUsername(ctx, candidate)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", domain.ErrUserExists
+}
+
+// domainAllowed 检查邮箱域名是否允许登录
+func (s *SSOService) domainAllowed(email string) bool {
+	if len(s.cfg.AllowedDomains) == 0 {
+		return true
+	}
+	_, emailDomain, _ := strings.Cut(email, "@")
+	for _, allowed := range s.cfg.AllowedDomains {
+		if strings.EqualFold(emailDomain, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectURI 身份提供方回调地址
+func (s *SSOService) redirectURI() string {
+	return s.cfg.BaseURL + ssoCallbackPath
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// storageMonitorTimeout 单次检查的超时时间
+const storageMonitorTimeout = 30 * time.Second
+
+// StorageThresholds 数据增长软限制，值为 0 时不检查对应指标
+type StorageThresholds struct {
+	TranslationRows int64 // translations 表行数
+	HistoryRows     int64 // 历史记录表（审计日志、翻译变更历史、Webhook 日志、发件箱）行数
+	TableBytes      int64 // 单表数据和索引占用的空间
+}
+
+// storageTable 监控的数据表
+type storageTable struct {
+	name           string
+	history        bool
+	recommendation string // 行数超过软限制时的处理建议
+}
+
+// monitoredTables 监控的数据表及处理建议
+var monitoredTables = []storageTable{
+	{name: "translations", recommendation: "清理已废弃（deprecated）的翻译，并定期清除软删除的记录"},
+	{name: "audit_logs", history: true, recommendation: "启用审计日志归档，将早期记录导出后从数据库中删除"},
+	{name: "translation_histories", history: true, recommendation: "定期导出翻译变更历史归档，并删除超过保留期限的记录"},
+	{name: "inbound_webhook_logs", history: true, recommendation: "启用入站 Webhook 日志归档，只保留最近的接收记录"},
+	{name: "outbox_events", history: true, recommendation: "检查事件投递是否积压或大量失败，已投递的事件会定期自动清理"},
+}
+
+// StorageMonitor 数据库增长监控
+// 定期检查数据表的行数和占用空间，超过软限制时记录告警日志并发布 storage.threshold_exceeded 事件。
+// 同一告警持续存在时只通知一次，恢复后再次超过时重新通知。
+type StorageMonitor struct {
+	repo       domain.StorageStatsRepository
+	eventBus   domain.EventBus
+	thresholds StorageThresholds
+	interval   time.Duration
+	logger     *zap.Logger
+
+	mu     sync.Mutex
+	report *domain.StorageReport
+	active map[string]bool // 当前持续存在的告警
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewStorageMonitor 创建数据库增长监控，interval 为 0 时不启动定期检查
+func NewStorageMonitor(repo domain.StorageStatsRepository, eventBus domain.EventBus, thresholds StorageThresholds, interval time.Duration, logger *zap.Logger) *StorageMonitor {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &StorageMonitor{
+		repo:       repo,
+		eventBus:   eventBus,
+		thresholds: thresholds,
+		interval:   interval,
+		logger:     logger,
+		active:     make(map[string]bool),
+	}
+}
+
+// Start 启动定期检查
+func (m *StorageMonitor) Start(ctx context.Context) error {
+	if m.interval <= 0 {
+		return nil
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期检查并等待当前检查完成
+func (m *StorageMonitor) Stop(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 检查循环，启动后立即检查一次
+func (m *StorageMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, storageMonitorTimeout)
+		if _, err := m.Check(checkCtx); err != nil && ctx.Err() == nil {
+			m.logger.Error("Storage growth check failed", zap.Error(err))
+		}
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetReport 获取最近一次检查结果，refresh 为 true 或尚未检查时立即检查
+func (m *StorageMonitor) GetReport(ctx context.Context, refresh bool) (*domain.StorageReport, error) {
+	if !refresh {
+		m.mu.Lock()
+		report := m.report
+		m.mu.Unlock()
+		if report != nil {
+			return report, nil
+		}
+	}
+	return m.Check(ctx)
+}
+
+// Check 检查数据表增长情况，对新出现的告警发送通知
+func (m *StorageMonitor) Check(ctx context.Context) (*domain.StorageReport, error) {
+	names := make([]string, 0, len(monitoredTables))
+	for _, table := range monitoredTables {
+		names = append(names, table.name)
+	}
+	stats, err := m.repo.GetTableStats(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.StorageReport{
+		Status:    domain.StorageStatusOK,
+		CheckedAt: time.Now(),
+		Tables:    stats,
+		Alerts:    m.evaluate(stats),
+	}
+	if len(report.Alerts) > 0 {
+		report.Status = domain.StorageStatusWarning
+	}
+
+	m.mu.Lock()
+	m.report = report
+	current := make(map[string]bool, len(report.Alerts))
+	var raised []*domain.StorageAlert
+	for _, alert := range report.Alerts {
+		key := alert.Table + ":" + alert.Metric
+		current[key] = true
+		if !m.active[key] {
+			raised = append(raised, alert)
+		}
+	}
+	m.active = current
+	m.mu.Unlock()
+
+	for _, alert := range raised {
+		m.notify(ctx, alert)
+	}
+	return report, nil
+}
+
+// evaluate 根据软限制生成告警
+func (m *StorageMonitor) evaluate(stats []*domain.TableStats) []*domain.StorageAlert {
+	alerts := []*domain.StorageAlert{}
+	for _, stat := range stats {
+		table, ok := findStorageTable(stat.Table)
+		if !ok {
+			continue
+		}
+
+		rowLimit := m.thresholds.TranslationRows
+		if table.history {
+			rowLimit = m.thresholds.HistoryRows
+		}
+		if rowLimit > 0 && stat.Rows > rowLimit {
+			alerts = append(alerts, &domain.StorageAlert{
+				Table:          stat.Table,
+				Metric:         domain.StorageMetricRows,
+				Value:          stat.Rows,
+				Threshold:      rowLimit,
+				Recommendation: fmt.Sprintf("%s 超过 %s 行，%s", stat.Table, formatCount(rowLimit), table.recommendation),
+			})
+		}
+
+		size := stat.DataBytes + stat.IndexBytes
+		if m.thresholds.TableBytes > 0 && size > m.thresholds.TableBytes {
+			alerts = append(alerts, &domain.StorageAlert{
+				Table:          stat.Table,
+				Metric:         domain.StorageMetricBytes,
+				Value:          size,
+				Threshold:      m.thresholds.TableBytes,
+				Recommendation: fmt.Sprintf("%s 占用空间超过 %d MB，%s", stat.Table, m.thresholds.TableBytes/(1<<20), table.recommendation),
+			})
+		}
+	}
+	return alerts
+}
+
+// notify 记录告警日志并发布事件
+func (m *StorageMonitor) notify(ctx context.Context, alert *domain.StorageAlert) {
+	m.logger.Warn("Storage soft limit exceeded",
+		zap.String("table", alert.Table),
+		zap.String("metric", alert.Metric),
+		zap.Int64("value", alert.Value),
+		zap.Int64("threshold", alert.Threshold),
+		zap.String("recommendation", alert.Recommendation),
+	)
+
+	err := publishEvent(ctx, m.eventBus, domain.EventStorageThresholdExceeded, 0, domain.StorageThresholdExceededPayload{
+		Table:          alert.Table,
+		Metric:         alert.Metric,
+		Value:          alert.Value,
+		Threshold:      alert.Threshold,
+		Recommendation: alert.Recommendation,
+	})
+	if err != nil {
+		m.logger.Error("Failed to publish storage alert event", zap.String("table", alert.Table), zap.Error(err))
+	}
+}
+
+// findStorageTable 查找监控的数据表
+func findStorageTable(name string) (storageTable, bool) {
+	for _, table := range monitoredTables {
+		if table.name == name {
+			return table, true
+		}
+	}
+	return storageTable{}, false
+}
+
+// formatCount 将行数格式化为便于阅读的形式，如 10M、500K
+func formatCount(n int64) string {
+	switch {
+	case n >= 1_000_000 && n%1_000_000 == 0:
+		return fmt.Sprintf("%dM", n/1_000_000)
+	case n >= 1_000 && n%1_000 == 0:
+		return fmt.Sprintf("%dK", n/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"yflow/internal/domain"
+)
+
+// maxTagDescriptionLength 标签说明最大字符数
+const maxTagDescriptionLength = 500
+
+// tagColorPattern 标签颜色为 #RRGGBB 格式
+var tagColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// TagService 键标签服务实现
+// 键的标签也可以通过 SetKeyMetadata 整体设置，两种方式修改的是同一组关联
+type TagService struct {
+	tagRepo         domain.TagRepository
+	projectRepo     domain.ProjectRepository
+	translationRepo domain.TranslationRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+}
+
+// NewTagService 创建键标签服务实例
+// 键的标签变化后通过 translation.updated 事件清除翻译缓存
+func NewTagService(
+	tagRepo domain.TagRepository,
+	projectRepo domain.ProjectRepository,
+	translationRepo domain.TranslationRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *TagService {
+	return &TagService{
+		tagRepo:         tagRepo,
+		projectRepo:     projectRepo,
+		translationRepo: translationRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+	}
+}
+
+// GetByProjectID 获取项目的标签及带有每个标签的键数量
+func (s *TagService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Tag, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	return s.tagRepo.GetByProjectID(ctx, projectID)
+}
+
+// Create 创建标签，同一项目中的名称不能重复
+func (s *TagService) Create(ctx context.Context, projectID uint64, params domain.TagParams, userID uint64) (*domain.Tag, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	tag := &domain.Tag{
+		ProjectID: projectID,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	}
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, invalidTag("名称不能为空")
+	}
+	if err := s.applyParams(ctx, tag, params); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, domain.ErrTagExists
+		}
+		return nil, err
+	}
+	return tag, nil
+}
+
+// Update 修改标签的名称、颜色或说明
+// 改名后按旧名称筛选的矩阵和导出缓存失效，因此发布翻译更新事件
+func (s *TagService) Update(ctx context.Context, projectID, tagID uint64, params domain.TagParams, userID uint64) (*domain.Tag, error) {
+	tag, err := s.getProjectTag(ctx, projectID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := tag.Name
+	if err := s.applyParams(ctx, tag, params); err != nil {
+		return nil, err
+	}
+	tag.UpdatedBy = userID
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.tagRepo.Update(ctx, tag); err != nil {
+			if isDuplicateKeyError(err) {
+				return domain.ErrTagExists
+			}
+			return err
+		}
+		if tag.Name == oldName {
+			return nil
+		}
+		keyNames, err := s.tagRepo.GetKeyNames(ctx, tag.ID)
+		if err != nil {
+			return err
+		}
+		return s.publishKeysTagged(ctx, projectID, keyNames, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// Delete 删除标签并移除键上的该标签
+func (s *TagService) Delete(ctx context.Context, projectID, tagID uint64) error {
+	tag, err := s.getProjectTag(ctx, projectID, tagID)
+	if err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		keyNames, err := s.tagRepo.GetKeyNames(ctx, tag.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.tagRepo.Delete(ctx, tag.ID); err != nil {
+			return err
+		}
+		return s.publishKeysTagged(ctx, projectID, keyNames, 0)
+	})
+}
+
+// TagKeys 为键添加标签，已带有该标签的键不变
+// 任一键在项目中不存在或会超过 MaxKeyTags 个标签时不做修改，错误详情列出这些键
+func (s *TagService) TagKeys(ctx context.Context, projectID, tagID uint64, keyNames []string) (*domain.TagKeysResult, error) {
+	keyNames = normalizeKeyNames(keyNames)
+	if len(keyNames) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	sort.Strings(keyNames)
+	tag, err := s.getProjectTag(ctx, projectID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.TagKeysResult{Tag: tag.Name, KeyNames: keyNames}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.checkKeysExist(ctx, projectID, keyNames); err != nil {
+			return err
+		}
+		tagged, err := s.tagRepo.GetKeyNames(ctx, tag.ID)
+		if err != nil {
+			return err
+		}
+		hasTag := make(map[string]bool, len(tagged))
+		for _, keyName := range tagged {
+			hasTag[keyName] = true
+		}
+		counts, err := s.tagRepo.CountKeyTags(ctx, projectID, keyNames)
+		if err != nil {
+			return err
+		}
+		var full []string
+		for _, keyName := range keyNames {
+			if !hasTag[keyName] && counts[keyName] >= domain.MaxKeyTags {
+				full = append(full, keyName)
+			}
+		}
+		if len(full) > 0 {
+			return invalidKeyTags(fmt.Sprintf("键最多 %d 个标签：%s", domain.MaxKeyTags, strings.Join(full, ", ")))
+		}
+
+		result.Changed, err = s.tagRepo.AddKeys(ctx, tag, keyNames)
+		if err != nil {
+			return err
+		}
+		return s.publishKeysTagged(ctx, projectID, keyNames, int(result.Changed))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UntagKeys 移除键上的标签，没有该标签的键不变
+func (s *TagService) UntagKeys(ctx context.Context, projectID, tagID uint64, keyNames []string) (*domain.TagKeysResult, error) {
+	keyNames = normalizeKeyNames(keyNames)
+	if len(keyNames) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	sort.Strings(keyNames)
+	tag, err := s.getProjectTag(ctx, projectID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.TagKeysResult{Tag: tag.Name, KeyNames: keyNames}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		result.Changed, err = s.tagRepo.RemoveKeys(ctx, tag, keyNames)
+		if err != nil {
+			return err
+		}
+		if result.Changed == 0 {
+			return nil
+		}
+		return s.publishKeysTagged(ctx, projectID, keyNames, int(result.Changed))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkKeysExist 确认键在项目中都存在，错误详情列出不存在的键
+func (s *TagService) checkKeysExist(ctx context.Context, projectID uint64, keyNames []string) error {
+	existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return err
+	}
+	if len(existing) == len(keyNames) {
+		return nil
+	}
+	found := make(map[string]bool, len(existing))
+	for _, keyName := range existing {
+		found[keyName] = true
+	}
+	var missing []string
+	for _, keyName := range keyNames {
+		if !found[keyName] {
+			missing = append(missing, keyName)
+		}
+	}
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeNotFound, domain.ErrTranslationNotFound.Code, domain.ErrTranslationNotFound.Message,
+		"项目中没有键 "+strings.Join(missing, ", "))
+}
+
+// getProjectTag 获取标签并确认其属于该项目
+func (s *TagService) getProjectTag(ctx context.Context, projectID, tagID uint64) (*domain.Tag, error) {
+	tag, err := s.tagRepo.GetByID(ctx, tagID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.ProjectID != projectID {
+		return nil, domain.ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// applyParams 校验并写入名称、颜色和说明，空名称和 nil 字段保持不变
+// 标签名称与 SetKeyMetadata 的标签规则一致：不能包含逗号，最多 MaxKeyTagLength 个字符
+func (s *TagService) applyParams(ctx context.Context, tag *domain.Tag, params domain.TagParams) error {
+	if name := strings.TrimSpace(params.Name); name != "" && name != tag.Name {
+		if strings.Contains(name, ",") || utf8.RuneCountInString(name) > domain.MaxKeyTagLength {
+			return invalidTag(fmt.Sprintf("名称不能包含逗号，最多 %d 个字符", domain.MaxKeyTagLength))
+		}
+		_, err := s.tagRepo.GetByName(ctx, tag.ProjectID, name)
+		switch {
+		case err == nil:
+			return domain.ErrTagExists
+		case !errors.Is(err, domain.ErrTagNotFound):
+			return err
+		}
+		tag.Name = name
+	}
+	if params.Color != nil {
+		color := strings.TrimSpace(*params.Color)
+		if color != "" && !tagColorPattern.MatchString(color) {
+			return invalidTag("颜色格式应为 #RRGGBB")
+		}
+		tag.Color = strings.ToLower(color)
+	}
+	if params.Description != nil {
+		description := strings.TrimSpace(*params.Description)
+		if utf8.RuneCountInString(description) > maxTagDescriptionLength {
+			return invalidTag("说明最多 500 个字符")
+		}
+		tag.Description = description
+	}
+	return nil
+}
+
+// publishKeysTagged 键的标签变化影响按标签筛选的矩阵和导出，按翻译更新通知
+func (s *TagService) publishKeysTagged(ctx context.Context, projectID uint64, keyNames []string, count int) error {
+	if len(keyNames) == 0 {
+		return nil
+	}
+	return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: keyNames,
+		Count:    count,
+	})
+}
+
+// normalizeTagFilter 去掉标签筛选条件中的空白和重复名称
+func normalizeTagFilter(tags []string) []string {
+	return normalizeKeyNames(tags)
+}
+
+// invalidTag 带错误详情的标签无效错误
+func invalidTag(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidTag.Code, domain.ErrInvalidTag.Message, details)
+}
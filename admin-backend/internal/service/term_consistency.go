@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// maxInconsistentTerms 一致性明细最多列出的不一致术语数量
+const maxInconsistentTerms = 500
+
+// termGroup 默认语言中文本相同的一组键
+type termGroup struct {
+	source string
+	keys   []string
+}
+
+// termUsage 术语在某一语言中的译文及使用它们的键
+type termUsage struct {
+	translations []domain.TermTranslation // 按使用次数从多到少排序，次数相同时按译文排序
+	occurrences  int
+}
+
+// GetConsistency 统计各启用语言的术语一致性得分
+// 默认语言中文本相同（忽略首尾空白）的多个键视为同一术语，每个术语中与使用最多的译文相同的译文计为一致
+func (s *QAService) GetConsistency(ctx context.Context, projectID uint64) (*domain.ConsistencyReport, error) {
+	source, languages, groups, values, err := s.loadTermGroups(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ConsistencyReport{
+		SourceLanguage: source,
+		Terms:          len(groups),
+		Languages:      []domain.LanguageConsistency{},
+	}
+	for _, code := range languages {
+		result := domain.LanguageConsistency{LanguageCode: code}
+		for _, group := range groups {
+			usage := termUsageOf(group, values, code)
+			if usage.occurrences < 2 {
+				continue
+			}
+			result.Terms++
+			result.Occurrences += usage.occurrences
+			result.Consistent += len(usage.translations[0].Keys)
+			if len(usage.translations) > 1 {
+				result.InconsistentTerms++
+			}
+		}
+		result.Score = consistencyScore(result.Consistent, result.Occurrences)
+		report.Languages = append(report.Languages, result)
+	}
+	return report, nil
+}
+
+// GetInconsistencies 列出某一语言中译文不一致的术语，按默认语言的文本排序
+func (s *QAService) GetInconsistencies(ctx context.Context, projectID uint64, languageCode string) (*domain.ConsistencyDetail, error) {
+	source, languages, groups, values, err := s.loadTermGroups(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	languageCode = strings.TrimSpace(languageCode)
+	if languageCode == source {
+		return nil, domain.ErrIsSourceLanguage
+	}
+	found := false
+	for _, code := range languages {
+		found = found || code == languageCode
+	}
+	if !found {
+		return nil, domain.ErrLanguageNotFound
+	}
+
+	detail := &domain.ConsistencyDetail{
+		SourceLanguage: source,
+		LanguageCode:   languageCode,
+		Terms:          []domain.InconsistentTerm{},
+	}
+	var consistent, occurrences int
+	for _, group := range groups {
+		usage := termUsageOf(group, values, languageCode)
+		if usage.occurrences < 2 {
+			continue
+		}
+		occurrences += usage.occurrences
+		consistent += len(usage.translations[0].Keys)
+		if len(usage.translations) == 1 {
+			continue
+		}
+		if len(detail.Terms) >= maxInconsistentTerms {
+			detail.Truncated = true
+			continue
+		}
+		detail.Terms = append(detail.Terms, domain.InconsistentTerm{
+			Source:       group.source,
+			Suggestion:   usage.translations[0].Value,
+			Translations: usage.translations,
+		})
+	}
+	detail.Score = consistencyScore(consistent, occurrences)
+	return detail, nil
+}
+
+// loadTermGroups 读取项目的有效翻译，按默认语言的文本分组，只保留被多个键使用的文本
+// 返回默认语言代码和其余启用语言的代码
+func (s *QAService) loadTermGroups(ctx context.Context, projectID uint64) (string, []string, []termGroup, map[string]map[string]string, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return "", nil, nil, nil, domain.ErrProjectNotFound
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	var source string
+	var targets []string
+	for _, language := range languages {
+		switch {
+		case language.Status != "active":
+		case language.IsDefault:
+			source = language.Code
+		default:
+			targets = append(targets, language.Code)
+		}
+	}
+	if source == "" {
+		return "", nil, nil, nil, domain.ErrNoSourceLanguage
+	}
+
+	values, err := s.translationRepo.GetValuesByStatus(ctx, projectID, exportStatuses[""])
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	keysBySource := make(map[string][]string)
+	for key, byLanguage := range values {
+		if text := strings.TrimSpace(byLanguage[source]); text != "" {
+			keysBySource[text] = append(keysBySource[text], key)
+		}
+	}
+	groups := make([]termGroup, 0, len(keysBySource))
+	for text, keys := range keysBySource {
+		if len(keys) < 2 {
+			continue
+		}
+		sort.Strings(keys)
+		groups = append(groups, termGroup{source: text, keys: keys})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].source < groups[j].source })
+	return source, targets, groups, values, nil
+}
+
+// termUsageOf 统计术语在某一语言中的译文，未翻译的键不参与比较
+func termUsageOf(group termGroup, values map[string]map[string]string, languageCode string) termUsage {
+	keysByValue := make(map[string][]string)
+	var usage termUsage
+	for _, key := range group.keys {
+		value := strings.TrimSpace(values[key][languageCode])
+		if value == "" {
+			continue
+		}
+		keysByValue[value] = append(keysByValue[value], key)
+		usage.occurrences++
+	}
+	for value, keys := range keysByValue {
+		usage.translations = append(usage.translations, domain.TermTranslation{Value: value, Keys: keys})
+	}
+	sort.Slice(usage.translations, func(i, j int) bool {
+		a, b := usage.translations[i], usage.translations[j]
+		if len(a.Keys) != len(b.Keys) {
+			return len(a.Keys) > len(b.Keys)
+		}
+		return a.Value < b.Value
+	})
+	return usage
+}
+
+// consistencyScore 计算一致性得分（百分比，保留两位小数），没有可比较的译文时为 100
+func consistencyScore(consistent, occurrences int) float64 {
+	return math.Round(readinessPercent(consistent, occurrences)*100) / 100
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"unicode"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"yflow/internal/domain"
+)
+
+// wordDiff 计算 oldValue 到 newValue 的词级差异，相邻的同类片段合并
+// 英文等按单词、空白和标点切分，中日韩文字没有空格分词，按单个字符比较
+func wordDiff(oldValue, newValue string) []domain.DiffSegment {
+	a, b := diffTokens(oldValue), diffTokens(newValue)
+	matcher := difflib.NewMatcherWithJunk(a, b, false, nil)
+
+	var segments []domain.DiffSegment
+	add := func(op string, tokens []string) {
+		for _, token := range tokens {
+			if n := len(segments); n > 0 && segments[n-1].Op == op {
+				segments[n-1].Text += token
+				continue
+			}
+			segments = append(segments, domain.DiffSegment{Op: op, Text: token})
+		}
+	}
+	for _, code := range matcher.GetOpCodes() {
+		switch code.Tag {
+		case 'e':
+			add(domain.DiffOpEqual, a[code.I1:code.I2])
+		case 'd':
+			add(domain.DiffOpDelete, a[code.I1:code.I2])
+		case 'i':
+			add(domain.DiffOpInsert, b[code.J1:code.J2])
+		case 'r':
+			add(domain.DiffOpDelete, a[code.I1:code.I2])
+			add(domain.DiffOpInsert, b[code.J1:code.J2])
+		}
+	}
+	if segments == nil {
+		segments = []domain.DiffSegment{}
+	}
+	return segments
+}
+
+// diffTokens 将文本切分为单词、连续空白、单个标点和单个中日韩字符
+func diffTokens(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+// isWordRune 是否为组成单词的字符，中日韩字符单独成词
+func isWordRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+// unifiedDiff 生成按行的 unified diff，值相同时返回空字符串
+func unifiedDiff(oldValue, newValue, fromName, toName string) string {
+	if oldValue == newValue {
+		return ""
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(oldValue),
+		B:        splitDiffLines(newValue),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// splitDiffLines 按行切分，空字符串没有行
+func splitDiffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return difflib.SplitLines(s)
+}
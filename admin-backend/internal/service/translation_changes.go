@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"yflow/internal/domain"
+)
+
+const (
+	// projectValuesPageSize 读取项目翻译时每页的键数量
+	projectValuesPageSize = 1000
+	// translationDeleteChunkSize 删除翻译时每次查询的数量
+	translationDeleteChunkSize = 500
+)
+
+// loadProjectValues 读取项目的所有有效翻译：键名 -> 语言代码 -> 翻译值
+func loadProjectValues(ctx context.Context, translationRepo domain.TranslationRepository, projectID uint64) (map[string]map[string]string, error) {
+	translations := make(map[string]map[string]string)
+	query := domain.TranslationKeyPageQuery{ProjectID: projectID, Limit: projectValuesPageSize}
+	for {
+		page, err := translationRepo.GetKeyPage(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for keyName, values := range page.Translations {
+			translations[keyName] = values
+		}
+		if !page.HasMore {
+			return translations, nil
+		}
+		query.AfterKey = page.LastKey
+	}
+}
+
+// activeLanguageIDs 返回本实例启用的语言：语言代码 -> 语言ID
+func activeLanguageIDs(ctx context.Context, languageRepo domain.LanguageRepository) (map[string]uint64, error) {
+	languages, err := languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]uint64, len(languages))
+	for _, language := range languages {
+		if language.Status == "active" {
+			ids[language.Code] = language.ID
+		}
+	}
+	return ids, nil
+}
+
+// applyTranslationChanges 写入新增和修改的翻译，删除被移除的翻译
+// 写入通过 TranslationService 完成，以复用其校验、事件和缓存失效逻辑
+func applyTranslationChanges(
+	ctx context.Context,
+	translationService domain.TranslationService,
+	translationRepo domain.TranslationRepository,
+	projectID uint64,
+	changes []domain.PromotionChange,
+	languageIDs map[string]uint64,
+) error {
+	var inputs []domain.TranslationInput
+	var removed []domain.TranslationKey
+	for _, change := range changes {
+		if change.Type == domain.PromotionChangeRemoved {
+			removed = append(removed, domain.TranslationKey{
+				ProjectID:  projectID,
+				KeyName:    change.Key,
+				LanguageID: languageIDs[change.Language],
+			})
+			continue
+		}
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  projectID,
+			LanguageID: languageIDs[change.Language],
+			KeyName:    change.Key,
+			Value:      change.NewValue,
+		})
+	}
+
+	if err := translationService.UpsertBatch(ctx, inputs); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(removed); start += translationDeleteChunkSize {
+		end := start + translationDeleteChunkSize
+		if end > len(removed) {
+			end = len(removed)
+		}
+		translations, err := translationRepo.GetByProjectKeyLanguages(ctx, removed[start:end])
+		if err != nil {
+			return err
+		}
+		ids := make([]uint64, 0, len(translations))
+		for _, translation := range translations {
+			ids = append(ids, translation.ID)
+		}
+		if err := translationService.DeleteBatch(ctx, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"yflow/internal/domain"
+)
+
+// TranslationDeltaService 增量同步服务实现
+// 根据翻译变更历史找出同步起点之后变更的键，再读取这些键的当前翻译，客户端不需要每次全量拉取
+type TranslationDeltaService struct {
+	projectRepo     domain.ProjectRepository
+	releaseRepo     domain.ReleaseRepository
+	translationRepo domain.TranslationRepository
+	historyRepo     domain.TranslationHistoryRepository
+}
+
+// NewTranslationDeltaService 创建增量同步服务实例
+func NewTranslationDeltaService(
+	projectRepo domain.ProjectRepository,
+	releaseRepo domain.ReleaseRepository,
+	translationRepo domain.TranslationRepository,
+	historyRepo domain.TranslationHistoryRepository,
+) *TranslationDeltaService {
+	return &TranslationDeltaService{
+		projectRepo:     projectRepo,
+		releaseRepo:     releaseRepo,
+		translationRepo: translationRepo,
+		historyRepo:     historyRepo,
+	}
+}
+
+// GetDelta 获取同步起点之后变更的键
+// 变更范围为 [since, synced_at)，下次以 synced_at 为起点时不会遗漏变更；起点上的变更可能重复返回，按键整体替换不受影响
+func (s *TranslationDeltaService) GetDelta(ctx context.Context, query domain.TranslationDeltaQuery) (*domain.TranslationDelta, error) {
+	if query.MaxKeys <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.projectRepo.GetByID(ctx, query.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	since, err := s.resolveSince(ctx, query.ProjectID, query.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	delta := &domain.TranslationDelta{
+		Since:        since,
+		SyncedAt:     time.Now().UTC(),
+		Translations: make(map[string]map[string]string),
+		Deleted:      []string{},
+	}
+	changed, err := s.historyRepo.GetChangedKeys(ctx, domain.HistoryQuery{ProjectID: query.ProjectID, From: since, To: delta.SyncedAt})
+	if err != nil {
+		return nil, err
+	}
+	keyNames := changed[:0]
+	for _, keyName := range changed {
+		if strings.HasPrefix(keyName, query.KeyPrefix) {
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	if len(keyNames) > query.MaxKeys {
+		return nil, domain.ErrTooManyChanges
+	}
+
+	for start := 0; start < len(keyNames); start += projectValuesPageSize {
+		chunk := keyNames[start:min(start+projectValuesPageSize, len(keyNames))]
+		page, err := s.translationRepo.GetKeyPage(ctx, domain.TranslationKeyPageQuery{
+			ProjectID: query.ProjectID,
+			Locale:    query.Locale,
+			KeyNames:  chunk,
+			Limit:     len(chunk),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, keyName := range chunk {
+			if values, ok := page.Translations[keyName]; ok {
+				delta.Translations[keyName] = values
+			} else {
+				delta.Deleted = append(delta.Deleted, keyName)
+			}
+		}
+	}
+	sort.Strings(delta.Deleted)
+	return delta, nil
+}
+
+// resolveSince 解析同步起点：RFC 3339 时间，或发布版本标签对应的发布时间
+func (s *TranslationDeltaService) resolveSince(ctx context.Context, projectID uint64, since string) (time.Time, error) {
+	since = strings.TrimSpace(since)
+	if since == "" {
+		return time.Time{}, domain.ErrInvalidDeltaSince
+	}
+	if at, err := time.Parse(time.RFC3339, since); err == nil {
+		return at.UTC(), nil
+	}
+	if !releaseVersionPattern.MatchString(since) {
+		return time.Time{}, domain.ErrInvalidDeltaSince
+	}
+	release, err := s.releaseRepo.GetByVersion(ctx, projectID, since)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return release.CreatedAt.UTC(), nil
+}
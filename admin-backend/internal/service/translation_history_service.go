@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// translationChangesLimit 变化报告最多返回的变化条数
+	translationChangesLimit = 5000
+	// translationChangesBatchSize 汇总变化时每批读取的变更历史条数
+	translationChangesBatchSize = 1000
+)
+
+// TranslationHistoryService 翻译变更历史查询服务实现
+type TranslationHistoryService struct {
+	historyRepo  domain.TranslationHistoryRepository
+	projectRepo  domain.ProjectRepository
+	languageRepo domain.LanguageRepository
+	userRepo     domain.UserRepository
+}
+
+// NewTranslationHistoryService 创建翻译变更历史查询服务实例
+func NewTranslationHistoryService(
+	historyRepo domain.TranslationHistoryRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+) *TranslationHistoryService {
+	return &TranslationHistoryService{
+		historyRepo:  historyRepo,
+		projectRepo:  projectRepo,
+		languageRepo: languageRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GetDiff 获取一条变更历史中旧值和新值的词级差异和 unified diff
+func (s *TranslationHistoryService) GetDiff(ctx context.Context, historyID uint64) (*domain.TranslationHistoryDiff, error) {
+	entry, err := s.historyRepo.GetByID(ctx, historyID)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.languageCodes(ctx, []uint64{entry.LanguageID})
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue, newValue := historyValues(entry)
+	oldKey := entry.KeyName
+	if entry.PreviousKey != "" {
+		oldKey = entry.PreviousKey
+	}
+	operator, err := s.operator(ctx, entry.UserID)
+	if err != nil {
+		return nil, err
+	}
+	language := languages[entry.LanguageID]
+	return &domain.TranslationHistoryDiff{
+		HistoryID:     entry.ID,
+		TranslationID: entry.TranslationID,
+		KeyName:       entry.KeyName,
+		PreviousKey:   entry.PreviousKey,
+		Language:      language,
+		Operation:     entry.Operation,
+		OldValue:      oldValue,
+		NewValue:      newValue,
+		Segments:      wordDiff(oldValue, newValue),
+		Unified:       unifiedDiff(oldValue, newValue, "a/"+oldKey+"@"+language, "b/"+entry.KeyName+"@"+language),
+		UserID:        entry.UserID,
+		Operator:      operator,
+		CreatedAt:     entry.CreatedAt,
+	}, nil
+}
+
+// operator 获取变更历史操作人的展示信息，操作人为 0 或用户已删除时返回 nil
+func (s *TranslationHistoryService) operator(ctx context.Context, userID uint64) (*domain.UserSummary, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.UserSummary{
+		ID:          user.ID,
+		Username:    user.Username,
+		DisplayName: user.DisplayName,
+		AvatarURL:   user.AvatarURL(),
+	}, nil
+}
+
+// translationNetChange 汇总中的一条翻译在时间范围内的首尾状态
+type translationNetChange struct {
+	first *domain.TranslationHistory
+	last  *domain.TranslationHistory
+}
+
+// GetChanges 汇总时间范围内项目中每条翻译的净变化
+// 范围内创建后又删除、或修改后又改回原值的翻译不计入
+func (s *TranslationHistoryService) GetChanges(ctx context.Context, params domain.TranslationChangesParams) (*domain.TranslationChangeReport, error) {
+	if params.From.IsZero() || params.To.IsZero() || !params.From.Before(params.To) {
+		return nil, domain.ErrInvalidHistoryRange
+	}
+	if _, err := s.projectRepo.GetByID(ctx, params.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	// 变更历史按 ID 即时间顺序读取，每条翻译只保留范围内的第一条和最后一条
+	changes := make(map[uint64]*translationNetChange)
+	query := domain.HistoryQuery{ProjectID: params.ProjectID, From: params.From, To: params.To}
+	err := s.historyRepo.Stream(ctx, query, translationChangesBatchSize, func(entries []*domain.TranslationHistory) error {
+		for _, entry := range entries {
+			if change, ok := changes[entry.TranslationID]; ok {
+				change.last = entry
+				continue
+			}
+			changes[entry.TranslationID] = &translationNetChange{first: entry, last: entry}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	languageIDs := make([]uint64, 0)
+	seen := make(map[uint64]bool)
+	for _, change := range changes {
+		if !seen[change.last.LanguageID] {
+			seen[change.last.LanguageID] = true
+			languageIDs = append(languageIDs, change.last.LanguageID)
+		}
+	}
+	languages, err := s.languageCodes(ctx, languageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.TranslationChangeReport{
+		ProjectID: params.ProjectID,
+		From:      params.From,
+		To:        params.To,
+		Changes:   []domain.TranslationChange{},
+	}
+	for translationID, change := range changes {
+		item, ok := netChange(change)
+		if !ok {
+			continue
+		}
+		item.TranslationID = translationID
+		item.Language = languages[change.last.LanguageID]
+		switch item.Change {
+		case domain.TranslationChangeAdded:
+			report.Added++
+		case domain.TranslationChangeModified:
+			report.Modified++
+		case domain.TranslationChangeRemoved:
+			report.Removed++
+		case domain.TranslationChangeRenamed:
+			report.Renamed++
+		}
+		report.Changes = append(report.Changes, item)
+	}
+
+	sort.Slice(report.Changes, func(i, j int) bool {
+		a, b := report.Changes[i], report.Changes[j]
+		if a.KeyName != b.KeyName {
+			return a.KeyName < b.KeyName
+		}
+		return a.Language < b.Language
+	})
+	if len(report.Changes) > translationChangesLimit {
+		report.Changes = report.Changes[:translationChangesLimit]
+		report.Truncated = true
+	}
+	for i := range report.Changes {
+		report.Changes[i].Segments = wordDiff(report.Changes[i].OldValue, report.Changes[i].NewValue)
+	}
+	return report, nil
+}
+
+// netChange 比较翻译在范围开始和结束时的状态，没有净变化时返回 false
+func netChange(change *translationNetChange) (domain.TranslationChange, bool) {
+	oldValue, _ := historyValues(change.first)
+	_, newValue := historyValues(change.last)
+	existedBefore := change.first.Operation != domain.HistoryOperationCreate && change.first.Operation != domain.HistoryOperationRestore
+	existsAfter := change.last.Operation != domain.HistoryOperationDelete
+
+	oldKey := change.first.KeyName
+	if change.first.PreviousKey != "" {
+		oldKey = change.first.PreviousKey
+	}
+	item := domain.TranslationChange{
+		KeyName:  change.last.KeyName,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+	if oldKey != item.KeyName {
+		item.PreviousKey = oldKey
+	}
+
+	switch {
+	case !existedBefore && !existsAfter:
+		return item, false
+	case !existedBefore:
+		item.Change = domain.TranslationChangeAdded
+		item.OldValue = ""
+	case !existsAfter:
+		item.Change = domain.TranslationChangeRemoved
+		item.NewValue = ""
+	case oldValue != newValue:
+		item.Change = domain.TranslationChangeModified
+	case item.PreviousKey != "":
+		item.Change = domain.TranslationChangeRenamed
+	default:
+		return item, false
+	}
+	return item, true
+}
+
+// historyValues 返回变更历史记录的旧值和新值
+// 审核操作只记录了当时的翻译值（NewValue），值没有变化
+func historyValues(entry *domain.TranslationHistory) (string, string) {
+	if entry.Operation == domain.HistoryOperationReview {
+		return entry.NewValue, entry.NewValue
+	}
+	return entry.OldValue, entry.NewValue
+}
+
+// languageCodes 查询语言ID对应的语言代码
+func (s *TranslationHistoryService) languageCodes(ctx context.Context, ids []uint64) (map[uint64]string, error) {
+	codes := make(map[uint64]string, len(ids))
+	if len(ids) == 0 {
+		return codes, nil
+	}
+	languages, err := s.languageRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, language := range languages {
+		codes[language.ID] = language.Code
+	}
+	return codes, nil
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// defaultTMMinScore 默认的最低相似度
+	defaultTMMinScore = 0.5
+	// defaultTMSuggestions 默认返回的建议数量
+	defaultTMSuggestions = 10
+	// maxTMSuggestions 最多返回的建议数量
+	maxTMSuggestions = 50
+	// maxTMCandidates 一次查询最多计算相似度的候选翻译数量
+	maxTMCandidates = 300
+	// maxTMSearchTerms 预筛选候选翻译时使用的片段数量
+	maxTMSearchTerms = 6
+)
+
+// TranslationMemoryService 翻译记忆库服务实现
+// 在用户可访问的项目中查找原文与查询文本相似的翻译：先按原文长度和包含的片段在数据库中预筛选，
+// 再按编辑距离计算相似度
+type TranslationMemoryService struct {
+	memoryRepo   domain.TranslationMemoryRepository
+	languageRepo domain.LanguageRepository
+	userRepo     domain.UserRepository
+	memberRepo   domain.ProjectMemberRepository
+}
+
+// NewTranslationMemoryService 创建翻译记忆库服务实例
+func NewTranslationMemoryService(
+	memoryRepo domain.TranslationMemoryRepository,
+	languageRepo domain.LanguageRepository,
+	userRepo domain.UserRepository,
+	memberRepo domain.ProjectMemberRepository,
+) *TranslationMemoryService {
+	return &TranslationMemoryService{
+		memoryRepo:   memoryRepo,
+		languageRepo: languageRepo,
+		userRepo:     userRepo,
+		memberRepo:   memberRepo,
+	}
+}
+
+// Suggest 在用户可访问的项目中查找与文本相似的已有翻译
+// 管理员可以使用所有项目的翻译，其他用户只使用所参与项目的翻译。只使用有效的翻译，
+// 相似度为规范化（忽略大小写和多余空白）后的编辑距离相似度，按相似度、使用次数和更新时间排序
+func (s *TranslationMemoryService) Suggest(ctx context.Context, userID uint64, query domain.TMSuggestQuery) (*domain.TMSuggestResult, error) {
+	text := strings.TrimSpace(query.Text)
+	if text == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	minScore := query.MinScore
+	if minScore <= 0 {
+		minScore = defaultTMMinScore
+	}
+	if minScore > 1 {
+		minScore = 1
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTMSuggestions
+	}
+	limit = min(limit, maxTMSuggestions)
+
+	source, target, err := s.resolveTMLanguages(ctx, query.SourceLanguage, query.TargetLanguage)
+	if err != nil {
+		return nil, err
+	}
+	result := &domain.TMSuggestResult{
+		SourceLanguage: source.Code,
+		TargetLanguage: target.Code,
+		Suggestions:    []domain.TMSuggestion{},
+	}
+
+	projectIDs, err := s.accessibleProjectIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if projectIDs != nil && len(projectIDs) == 0 {
+		return result, nil
+	}
+
+	// 相似度不低于 minScore 的原文长度必然在 [n*minScore, n/minScore] 范围内
+	length := len([]rune(text))
+	candidates, err := s.memoryRepo.FindCandidates(ctx, domain.TMCandidateQuery{
+		SourceLanguageID: source.ID,
+		TargetLanguageID: target.ID,
+		Statuses:         exportStatuses[""].Statuses,
+		ProjectIDs:       projectIDs,
+		MinLength:        int(math.Floor(float64(length) * minScore)),
+		MaxLength:        int(math.Ceil(float64(length) / minScore)),
+		Terms:            tmSearchTerms(text),
+		Text:             text,
+		ExcludeProjectID: query.ExcludeProjectID,
+		ExcludeKey:       query.ExcludeKey,
+		Limit:            maxTMCandidates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := normalizeTMText(text)
+	type scoredSource struct {
+		normalized string
+		score      float64
+	}
+	sources := make(map[string]scoredSource)
+	index := make(map[string]int)
+	for _, candidate := range candidates {
+		source, ok := sources[candidate.SourceText]
+		if !ok {
+			candidateText := normalizeTMText(candidate.SourceText)
+			source = scoredSource{normalized: string(candidateText), score: tmSimilarity(normalized, candidateText)}
+			sources[candidate.SourceText] = source
+		}
+		score := source.score
+		if score < minScore {
+			continue
+		}
+		// 规范化后原文相同、译文也相同的合并为一条；相同原文的候选翻译按更新时间倒序，保留最近更新的键
+		pair := source.normalized + "\x00" + candidate.TargetText
+		if i, exists := index[pair]; exists {
+			result.Suggestions[i].Occurrences++
+			continue
+		}
+		index[pair] = len(result.Suggestions)
+		result.Suggestions = append(result.Suggestions, domain.TMSuggestion{
+			SourceText:  candidate.SourceText,
+			TargetText:  candidate.TargetText,
+			Score:       score,
+			Occurrences: 1,
+			ProjectID:   candidate.ProjectID,
+			KeyName:     candidate.KeyName,
+			UpdatedAt:   candidate.UpdatedAt,
+		})
+	}
+
+	sort.SliceStable(result.Suggestions, func(i, j int) bool {
+		a, b := result.Suggestions[i], result.Suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Occurrences != b.Occurrences {
+			return a.Occurrences > b.Occurrences
+		}
+		return a.UpdatedAt.After(b.UpdatedAt)
+	})
+	if len(result.Suggestions) > limit {
+		result.Suggestions = result.Suggestions[:limit]
+	}
+	return result, nil
+}
+
+// resolveTMLanguages 按语言代码获取启用的源语言和目标语言，源语言为空时使用默认语言
+func (s *TranslationMemoryService) resolveTMLanguages(ctx context.Context, sourceCode, targetCode string) (*domain.Language, *domain.Language, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	sourceCode, targetCode = strings.TrimSpace(sourceCode), strings.TrimSpace(targetCode)
+	var source, target *domain.Language
+	for _, language := range languages {
+		if language.Status != "active" {
+			continue
+		}
+		if language.Code == sourceCode || (sourceCode == "" && language.IsDefault) {
+			source = language
+		}
+		if language.Code == targetCode {
+			target = language
+		}
+	}
+	switch {
+	case source == nil && sourceCode == "":
+		return nil, nil, domain.ErrNoSourceLanguage
+	case source == nil || target == nil:
+		return nil, nil, domain.ErrLanguageNotFound
+	case source.ID == target.ID:
+		return nil, nil, domain.ErrIsSourceLanguage
+	}
+	return source, target, nil
+}
+
+// accessibleProjectIDs 返回用户可访问的项目ID，管理员返回 nil 表示不限项目
+func (s *TranslationMemoryService) accessibleProjectIDs(ctx context.Context, userID uint64) ([]uint64, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == "admin" {
+		return nil, nil
+	}
+	members, err := s.memberRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]uint64, 0, len(members))
+	for _, member := range members {
+		projectIDs = append(projectIDs, member.ProjectID)
+	}
+	return projectIDs, nil
+}
+
+// tmSearchTerms 选择用于预筛选候选翻译的片段
+// 使用最长的几个单词（至少 3 个字符）；中文、日文、韩文没有空格分词，使用均匀分布的几个双字片段
+func tmSearchTerms(text string) []string {
+	var words, bigrams []string
+	seen := make(map[string]bool)
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		runes := []rune(field)
+		if !containsCJK(runes) {
+			if len(runes) >= 3 && !seen[field] {
+				seen[field] = true
+				words = append(words, field)
+			}
+			continue
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			if bigram := string(runes[i : i+2]); !seen[bigram] {
+				seen[bigram] = true
+				bigrams = append(bigrams, bigram)
+			}
+		}
+	}
+
+	sort.SliceStable(words, func(i, j int) bool { return len([]rune(words[i])) > len([]rune(words[j])) })
+	terms := words
+	if len(terms) > maxTMSearchTerms {
+		terms = terms[:maxTMSearchTerms]
+	}
+	if remaining := maxTMSearchTerms - len(terms); remaining > 0 && len(bigrams) > 0 {
+		if len(bigrams) <= remaining {
+			terms = append(terms, bigrams...)
+		} else {
+			for i := 0; i < remaining; i++ {
+				terms = append(terms, bigrams[i*len(bigrams)/remaining])
+			}
+		}
+	}
+	return terms
+}
+
+// containsCJK 是否包含中文、日文假名或韩文字符
+func containsCJK(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTMText 转为小写并合并连续的空白，用于计算相似度
+func normalizeTMText(text string) []rune {
+	return []rune(strings.ToLower(strings.Join(strings.Fields(text), " ")))
+}
+
+// tmSimilarity 按编辑距离计算相似度：1 - 距离 / 较长文本的字符数，保留两位小数
+func tmSimilarity(a, b []rune) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 1
+	}
+	distance := levenshtein(a, b)
+	return math.Round((1-float64(distance)/float64(longest))*100) / 100
+}
+
+// levenshtein 计算两个字符序列的编辑距离
+func levenshtein(a, b []rune) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"yflow/internal/domain"
+)
+
+// TranslationRollbackService 翻译回滚服务实现
+type TranslationRollbackService struct {
+	translationRepo domain.TranslationRepository
+	historyRepo     domain.TranslationHistoryRepository
+	projectRepo     domain.ProjectRepository
+	languageRepo    domain.LanguageRepository
+	auditLogRepo    domain.AuditLogRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+}
+
+// NewTranslationRollbackService 创建翻译回滚服务实例
+// languageRepo 用于确定项目的源语言，回滚源语言译文时其他语言的译文标记为过期
+func NewTranslationRollbackService(
+	translationRepo domain.TranslationRepository,
+	historyRepo domain.TranslationHistoryRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	auditLogRepo domain.AuditLogRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+) *TranslationRollbackService {
+	return &TranslationRollbackService{
+		translationRepo: translationRepo,
+		historyRepo:     historyRepo,
+		projectRepo:     projectRepo,
+		languageRepo:    languageRepo,
+		auditLogRepo:    auditLogRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+	}
+}
+
+// RollbackTranslation 将翻译的值恢复为变更历史记录的版本（该次变更后的值），键名和状态保持不变
+// 值已相同时不做修改；不能回滚到删除操作
+func (s *TranslationRollbackService) RollbackTranslation(ctx context.Context, translationID, historyID, userID uint64) (*domain.Translation, error) {
+	translation, err := s.translationRepo.GetByID(ctx, translationID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.historyRepo.GetByID(ctx, historyID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.TranslationID != translation.ID {
+		return nil, domain.ErrHistoryNotFound
+	}
+	if entry.Operation == domain.HistoryOperationDelete {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidRollback.Code, domain.ErrInvalidRollback.Message,
+			"该版本为删除操作，没有翻译值")
+	}
+
+	if !rollbackTo(translation, entry, userID) {
+		return translation, nil
+	}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		return s.save(ctx, translation.ProjectID, []*domain.Translation{translation})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// RollbackProject 将项目中 To 之后有变更的翻译恢复为 To 时刻的值
+// 之后才创建的翻译不会删除，当时已删除或当前已删除的翻译不会恢复，这些翻译计入 Skipped
+func (s *TranslationRollbackService) RollbackProject(ctx context.Context, projectID uint64, params domain.ProjectRollbackParams, userID uint64) (*domain.ProjectRollbackResult, error) {
+	if params.To.IsZero() || params.To.After(time.Now()) {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidRollback.Code, domain.ErrInvalidRollback.Message,
+			"回滚时刻不能为空或晚于当前时间")
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	result := &domain.ProjectRollbackResult{To: params.To}
+	err := withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		versions, err := s.historyRepo.GetVersionsAt(ctx, projectID, params.To)
+		if err != nil {
+			return err
+		}
+		ids := make([]uint64, 0, len(versions))
+		for id := range versions {
+			ids = append(ids, id)
+		}
+		current, err := s.translationRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		// 当前已删除或移到其他项目的翻译不在 current 中
+		result.Skipped = len(ids)
+		var changed []*domain.Translation
+		for _, translation := range current {
+			version := versions[translation.ID]
+			if translation.ProjectID != projectID || version == nil || version.Operation == domain.HistoryOperationDelete {
+				continue
+			}
+			result.Skipped--
+			if rollbackTo(translation, version, userID) {
+				changed = append(changed, translation)
+			}
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+		if err := s.save(ctx, projectID, changed); err != nil {
+			return err
+		}
+		result.Translations = len(changed)
+		return recordAudit(ctx, s.auditLogRepo, projectID, userID, domain.AuditActionProjectRollback, map[string]interface{}{
+			"to":           params.To,
+			"translations": result.Translations,
+			"skipped":      result.Skipped,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// save 写入项目中回滚后的翻译，变更历史的操作类型为 rollback
+// 源语言译文的值发生了变化，其他语言的译文先标记为过期，同时回滚的译文随后回到 draft
+func (s *TranslationRollbackService) save(ctx context.Context, projectID uint64, translations []*domain.Translation) error {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		change := sourceChange{projectID: projectID, sourceLanguageID: source.ID}
+		for _, translation := range translations {
+			if translation.LanguageID == source.ID {
+				change.keyNames = append(change.keyNames, translation.KeyName)
+			}
+		}
+		if len(change.keyNames) > 0 {
+			if err := markOutdated(ctx, s.translationRepo, []sourceChange{change}); err != nil {
+				return err
+			}
+		}
+	}
+
+	historyCtx := domain.WithHistoryOperation(ctx, domain.HistoryOperationRollback)
+	for _, translation := range translations {
+		if err := s.translationRepo.Update(historyCtx, translation); err != nil {
+			return err
+		}
+	}
+	return publishTranslationsUpdated(ctx, s.eventBus, domain.TranslationActionUpdated, translations)
+}
+
+// rollbackTo 将翻译值改为版本中的值，值变化的译文需要重新审核；值相同时返回 false
+func rollbackTo(translation *domain.Translation, version *domain.TranslationHistory, userID uint64) bool {
+	if translation.Value == version.NewValue {
+		return false
+	}
+	translation.Value = version.NewValue
+	translation.ReviewStatus = domain.ReviewStatusDraft
+	translation.UpdatedBy = userID
+	return true
+}
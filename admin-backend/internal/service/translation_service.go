@@ -1,11 +1,19 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"yflow/internal/domain"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+	"yflow/internal/domain"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
 )
 
 // TranslationService 翻译服务实现
@@ -13,18 +21,32 @@ type TranslationService struct {
 	translationRepo domain.TranslationRepository
 	projectRepo     domain.ProjectRepository
 	languageRepo    domain.LanguageRepository
+	namespaceRepo   domain.NamespaceRepository
+	releaseRepo     domain.ReleaseRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
 }
 
 // NewTranslationService 创建翻译服务实例
+// 写操作与事件发布在同一事务中执行；eventBus 为空时不发布事件，transactor 为空时不开启事务
+// releaseRepo 用于按发布版本拉取和导出翻译
 func NewTranslationService(
 	translationRepo domain.TranslationRepository,
 	projectRepo domain.ProjectRepository,
 	languageRepo domain.LanguageRepository,
+	namespaceRepo domain.NamespaceRepository,
+	releaseRepo domain.ReleaseRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
 ) *TranslationService {
 	return &TranslationService{
 		translationRepo: translationRepo,
 		projectRepo:     projectRepo,
 		languageRepo:    languageRepo,
+		namespaceRepo:   namespaceRepo,
+		releaseRepo:     releaseRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
 	}
 }
 
@@ -56,17 +78,31 @@ func (s *TranslationService) Create(ctx context.Context, input domain.Translatio
 
 	// 创建翻译
 	translation := &domain.Translation{
-		ProjectID:  input.ProjectID,
-		KeyName:    keyName,
-		Context:    strings.TrimSpace(input.Context),
-		LanguageID: input.LanguageID,
-		Value:      strings.TrimSpace(input.Value),
-		Status:     "active",
-		CreatedBy:  userID,
-		UpdatedBy:  userID,
+		ProjectID:    input.ProjectID,
+		KeyName:      keyName,
+		Context:      strings.TrimSpace(input.Context),
+		LanguageID:   input.LanguageID,
+		Value:        strings.TrimSpace(input.Value),
+		ValueType:    input.ValueType,
+		Status:       "active",
+		ReviewStatus: domain.ReviewStatusDraft,
+		CreatedBy:    userID,
+		UpdatedBy:    userID,
+	}
+	if err := s.applyValueTypes(ctx, []*domain.Translation{translation}); err != nil {
+		return nil, err
+	}
+	if err := s.applyKeyMetadata(ctx, []*domain.Translation{translation}); err != nil {
+		return nil, err
 	}
 
-	if err := s.translationRepo.Create(ctx, translation); err != nil {
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.translationRepo.Create(ctx, translation); err != nil {
+			return err
+		}
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionCreated, []*domain.Translation{translation})
+	})
+	if err != nil {
 		// 检查是否是唯一约束冲突错误
 		if isDuplicateKeyError(err) {
 			return nil, domain.NewAppErrorWithDetails(
@@ -163,12 +199,14 @@ func (s *TranslationService) CreateBatch(ctx context.Context, inputs []domain.Tr
 		}
 
 		translations = append(translations, &domain.Translation{
-			ProjectID:  input.ProjectID,
-			KeyName:    keyName,
-			Context:    strings.TrimSpace(input.Context),
-			LanguageID: input.LanguageID,
-			Value:      strings.TrimSpace(input.Value),
-			Status:     "active",
+			ProjectID:    input.ProjectID,
+			KeyName:      keyName,
+			Context:      strings.TrimSpace(input.Context),
+			LanguageID:   input.LanguageID,
+			Value:        strings.TrimSpace(input.Value),
+			ValueType:    input.ValueType,
+			Status:       "active",
+			ReviewStatus: domain.ReviewStatusDraft,
 		})
 	}
 
@@ -186,8 +224,19 @@ func (s *TranslationService) CreateBatch(ctx context.Context, inputs []domain.Tr
 	if len(translations) == 0 {
 		return nil
 	}
+	if err := s.applyValueTypes(ctx, translations); err != nil {
+		return err
+	}
+	if err := s.applyKeyMetadata(ctx, translations); err != nil {
+		return err
+	}
 
-	return s.translationRepo.CreateBatch(ctx, translations)
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.translationRepo.CreateBatch(ctx, translations); err != nil {
+			return err
+		}
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionCreated, translations)
+	})
 }
 
 // UpsertBatch 批量创建或更新翻译
@@ -239,22 +288,49 @@ func (s *TranslationService) UpsertBatch(ctx context.Context, inputs []domain.Tr
 	translations := make([]*domain.Translation, 0, len(inputs))
 	for _, input := range inputs {
 		translations = append(translations, &domain.Translation{
-			ProjectID:  input.ProjectID,
-			KeyName:    strings.TrimSpace(input.KeyName),
-			Context:    strings.TrimSpace(input.Context),
-			LanguageID: input.LanguageID,
-			Value:      strings.TrimSpace(input.Value),
-			Status:     "active",
+			ProjectID:    input.ProjectID,
+			KeyName:      strings.TrimSpace(input.KeyName),
+			Context:      strings.TrimSpace(input.Context),
+			LanguageID:   input.LanguageID,
+			Value:        strings.TrimSpace(input.Value),
+			ValueType:    input.ValueType,
+			Status:       "active",
+			ReviewStatus: domain.ReviewStatusDraft,
 		})
 	}
+	if err := s.applyValueTypes(ctx, translations); err != nil {
+		return err
+	}
+	if err := s.applyKeyMetadata(ctx, translations); err != nil {
+		return err
+	}
+	changes, err := sourceChanges(ctx, s.translationRepo, s.languageRepo, projects, translations)
+	if err != nil {
+		return err
+	}
 
 	// 使用 UpsertBatch 而不是 CreateBatch
-	return s.translationRepo.UpsertBatch(ctx, translations)
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		// 源语言译文修改后其他语言的译文过期，先标记再写入，同时写入的新译文回到 draft
+		if err := markOutdated(ctx, s.translationRepo, changes); err != nil {
+			return err
+		}
+		if err := s.translationRepo.UpsertBatch(ctx, translations); err != nil {
+			return err
+		}
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionUpserted, translations)
+	})
 }
 
 // CreateBatchFromRequest 从批量翻译参数创建或更新翻译
 // 现在使用 UpsertBatch，支持创建和更新操作
 func (s *TranslationService) CreateBatchFromRequest(ctx context.Context, params domain.BatchTranslationParams) error {
+	// 项目由请求体指定，按项目所属的组织获取语言
+	ctx, err := projectOrganizationContext(ctx, s.projectRepo, params.ProjectID)
+	if err != nil {
+		return err
+	}
+
 	// 获取所有语言
 	languages, err := s.languageRepo.GetAll(ctx)
 	if err != nil {
@@ -299,8 +375,12 @@ func (s *TranslationService) GetByID(ctx context.Context, id uint64) (*domain.Tr
 	return s.translationRepo.GetByID(ctx, id)
 }
 
-// GetByProjectID 根据项目ID获取翻译
-func (s *TranslationService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Translation, int64, error) {
+// GetByProjectID 根据项目ID获取翻译，reviewStatus 不为空时只返回该审核状态的翻译
+func (s *TranslationService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int, reviewStatus string, sort []domain.SortField) ([]*domain.Translation, int64, error) {
+	if err := validateReviewStatus(reviewStatus); err != nil {
+		return nil, 0, err
+	}
+
 	// 验证项目是否存在
 	_, err := s.projectRepo.GetByID(ctx, projectID)
 	if err != nil {
@@ -317,18 +397,301 @@ func (s *TranslationService) GetByProjectID(ctx context.Context, projectID uint6
 		offset = 0
 	}
 
-	return s.translationRepo.GetByProjectID(ctx, projectID, limit, offset)
+	return s.translationRepo.GetByProjectID(ctx, projectID, limit, offset, reviewStatus, sort)
+}
+
+// GetPageByProjectID 按 ID 顺序游标分页获取项目的翻译
+func (s *TranslationService) GetPageByProjectID(ctx context.Context, query domain.TranslationPageQuery) (*domain.TranslationPage, error) {
+	if err := validateReviewStatus(query.ReviewStatus); err != nil {
+		return nil, err
+	}
+
+	// 验证项目是否存在
+	if _, err := s.projectRepo.GetByID(ctx, query.ProjectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	if query.Limit > 100 {
+		query.Limit = 100
+	}
+
+	return s.translationRepo.GetPageByProjectID(ctx, query)
 }
 
 // GetMatrix 获取翻译矩阵
 func (s *TranslationService) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
 	// 验证项目是否存在
-	_, err := s.projectRepo.GetByID(ctx, projectID)
+	project, err := s.projectRepo.GetByID(ctx, projectID)
 	if err != nil {
 		return nil, 0, domain.ErrProjectNotFound
 	}
 
-	return s.translationRepo.GetMatrix(ctx, projectID, limit, offset, keyword)
+	matrix, total, err := s.translationRepo.GetMatrix(ctx, projectID, limit, offset, keyword)
+	if err != nil {
+		return nil, 0, err
+	}
+	source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+	if err != nil {
+		return nil, 0, err
+	}
+	if source != nil {
+		countOutdated(matrix, source.Code)
+	}
+	return matrix, total, nil
+}
+
+// filterNamespaceKeys 只保留命名空间中的键，保持原有顺序
+func (s *TranslationService) filterNamespaceKeys(ctx context.Context, projectID uint64, name string, keyNames []string) ([]string, error) {
+	namespace, err := s.namespaceRepo.GetByName(ctx, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+	namespaceKeys, err := s.translationRepo.GetKeyNamesByNamespace(ctx, projectID, namespace.ID)
+	if err != nil {
+		return nil, err
+	}
+	inNamespace := make(map[string]bool, len(namespaceKeys))
+	for _, keyName := range namespaceKeys {
+		inNamespace[keyName] = true
+	}
+	filtered := make([]string, 0, len(namespaceKeys))
+	for _, keyName := range keyNames {
+		if inNamespace[keyName] {
+			filtered = append(filtered, keyName)
+		}
+	}
+	return filtered, nil
+}
+
+// filterTagKeys 只保留带有任一标签的键，保持原有顺序；项目中没有的标签不匹配任何键
+func (s *TranslationService) filterTagKeys(ctx context.Context, projectID uint64, tags []string, keyNames []string) ([]string, error) {
+	tagKeys, err := s.translationRepo.GetKeyNamesByTags(ctx, projectID, tags)
+	if err != nil {
+		return nil, err
+	}
+	tagged := make(map[string]bool, len(tagKeys))
+	for _, keyName := range tagKeys {
+		tagged[keyName] = true
+	}
+	filtered := make([]string, 0, len(tagKeys))
+	for _, keyName := range keyNames {
+		if tagged[keyName] {
+			filtered = append(filtered, keyName)
+		}
+	}
+	return filtered, nil
+}
+
+// GetMatrixPage 按指定排序规则分页获取翻译矩阵
+func (s *TranslationService) GetMatrixPage(ctx context.Context, query domain.TranslationMatrixQuery) (*domain.TranslationMatrixPage, error) {
+	switch query.SortBy {
+	case "", domain.MatrixSortByKey:
+	case domain.MatrixSortByValue:
+		if query.SortLanguage == "" {
+			return nil, domain.NewAppError(domain.ErrorTypeValidation, "INVALID_SORT", "按翻译值排序时必须指定语言")
+		}
+	default:
+		return nil, domain.NewAppError(domain.ErrorTypeValidation, "INVALID_SORT", "不支持的排序字段")
+	}
+
+	switch query.SearchCollation {
+	case "", domain.SearchCollationAccentInsensitive, domain.SearchCollationCaseInsensitive, domain.SearchCollationExact:
+	default:
+		return nil, domain.NewAppError(domain.ErrorTypeValidation, "INVALID_COLLATION", "不支持的搜索比较规则")
+	}
+	if err := validateReviewStatus(query.ReviewStatus); err != nil {
+		return nil, err
+	}
+
+	// 区域设置为空时按字节顺序排序
+	var collator *collate.Collator
+	if query.Collation != "" {
+		tag, err := language.Parse(query.Collation)
+		if err != nil {
+			return nil, domain.NewAppError(domain.ErrorTypeValidation, "INVALID_COLLATION", "无效的排序区域设置")
+		}
+		collator = collate.New(tag)
+	}
+
+	// 验证项目是否存在
+	project, err := s.projectRepo.GetByID(ctx, query.ProjectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	keyNames, err := s.translationRepo.SearchKeyNames(ctx, query.ProjectID, query.Keyword, query.SearchCollation, query.ReviewStatus)
+	if err != nil {
+		return nil, err
+	}
+	if query.Namespace != "" {
+		keyNames, err = s.filterNamespaceKeys(ctx, query.ProjectID, query.Namespace, keyNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tags := normalizeTagFilter(query.Tags); len(tags) > 0 {
+		keyNames, err = s.filterTagKeys(ctx, query.ProjectID, tags, keyNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var values map[string]string
+	if query.SortBy == domain.MatrixSortByValue {
+		values, err = s.translationRepo.GetKeyValues(ctx, query.ProjectID, query.SortLanguage)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortMatrixKeys(keyNames, values, collator, query.Descending)
+
+	page := &domain.TranslationMatrixPage{
+		Keys:  []string{},
+		Total: int64(len(keyNames)),
+	}
+	if query.Limit > 0 && query.Offset >= 0 {
+		if query.Offset < len(keyNames) {
+			end := query.Offset + query.Limit
+			if end > len(keyNames) {
+				end = len(keyNames)
+			}
+			page.Keys = keyNames[query.Offset:end]
+		}
+	} else {
+		page.Keys = keyNames
+	}
+
+	page.Matrix, err = s.translationRepo.GetMatrixCells(ctx, query.ProjectID, page.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// 与项目源语言的译文比较，没有源语言时不与源文本比较
+	sourceCode := ""
+	source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+	if err != nil {
+		return nil, err
+	}
+	if source != nil {
+		sourceCode = source.Code
+	}
+	annotateMatrixQA(page.Matrix, sourceCode)
+	countOutdated(page.Matrix, sourceCode)
+	return page, nil
+}
+
+// annotateMatrixQA 按 CheckTranslation（默认长度倍数）检查各单元格，将问题类型写入 QAIssues
+// 默认语言的单元格作为源文本，自身只检查标记
+func annotateMatrixQA(matrix map[string]map[string]domain.TranslationCell, sourceCode string) {
+	for _, row := range matrix {
+		for code, cell := range row {
+			source := ""
+			if code != sourceCode {
+				source = row[sourceCode].Value
+			}
+			seen := make(map[string]bool)
+			for _, issue := range CheckTranslation(cell.ValueType, cell.Value, source, code, DefaultQAMaxLengthRatio) {
+				if !seen[issue.Type] {
+					seen[issue.Type] = true
+					cell.QAIssues = append(cell.QAIssues, issue.Type)
+				}
+			}
+			row[code] = cell
+		}
+	}
+}
+
+// sortMatrixKeys 对键名排序：values 不为空时按翻译值排序（没有翻译的键排在最后），
+// 值相同时按键名排序；collator 为空时按字节顺序比较
+func sortMatrixKeys(keyNames []string, values map[string]string, collator *collate.Collator, descending bool) {
+	compare := strings.Compare
+	if collator != nil {
+		compare = collator.CompareString
+	}
+
+	sort.SliceStable(keyNames, func(i, j int) bool {
+		a, b := keyNames[i], keyNames[j]
+		if values != nil {
+			va, okA := values[a]
+			vb, okB := values[b]
+			if okA != okB {
+				return okA
+			}
+			if c := compare(va, vb); c != 0 {
+				return (c < 0) != descending
+			}
+		}
+		if c := compare(a, b); c != 0 {
+			return (c < 0) != descending
+		}
+		return a < b
+	})
+}
+
+// GetKeyPage 按键名分页获取翻译，指定发布版本时从该版本的快照中读取
+func (s *TranslationService) GetKeyPage(ctx context.Context, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	if query.Limit <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	// 验证项目是否存在
+	_, err := s.projectRepo.GetByID(ctx, query.ProjectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	if query.Release != "" {
+		release, err := s.releaseRepo.GetByVersion(ctx, query.ProjectID, query.Release)
+		if err != nil {
+			return nil, err
+		}
+		return s.releaseRepo.GetKeyPage(ctx, release.ID, query)
+	}
+	return s.translationRepo.GetKeyPage(ctx, query)
+}
+
+// GetProjectStats 统计项目各启用语言的翻译完成情况
+// 计数由数据库按语言聚合，没有任何翻译的启用语言计为全部未翻译；过期的译文不计入已翻译
+func (s *TranslationService) GetProjectStats(ctx context.Context, projectID uint64) (*domain.ProjectStats, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	languages, err := s.languageRepo.GetAll(domain.WithOrganization(ctx, project.OrganizationID))
+	if err != nil {
+		return nil, err
+	}
+	totalKeys, counts, err := s.translationRepo.CountByLanguage(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	countByLanguage := make(map[uint64]*domain.LanguageTranslationCount, len(counts))
+	for _, count := range counts {
+		countByLanguage[count.LanguageID] = count
+	}
+
+	stats := &domain.ProjectStats{
+		ProjectID: projectID,
+		TotalKeys: int(totalKeys),
+		Languages: []domain.LanguageStats{},
+	}
+	for _, language := range languages {
+		if language.Status != "active" {
+			continue
+		}
+		result := domain.LanguageStats{LanguageCode: language.Code, LanguageName: language.Name}
+		if count, ok := countByLanguage[language.ID]; ok {
+			result.Translated = int(count.Translated)
+			result.Outdated = int(count.Outdated)
+		}
+		result.Untranslated = stats.TotalKeys - result.Translated - result.Outdated
+		result.Percentage = math.Round(readinessPercent(result.Translated, stats.TotalKeys)*100) / 100
+		stats.Languages = append(stats.Languages, result)
+	}
+	return stats, nil
 }
 
 // Update 更新翻译
@@ -338,6 +701,14 @@ func (s *TranslationService) Update(ctx context.Context, id uint64, input domain
 	if err != nil {
 		return nil, err
 	}
+	// 编辑所基于的版本已过期时拒绝更新，避免覆盖他人的修改
+	if input.Version != 0 && input.Version != translation.Version {
+		return nil, domain.ErrTranslationConflict
+	}
+	oldProjectID := translation.ProjectID
+	oldKeyName := translation.KeyName
+	oldLanguageID := translation.LanguageID
+	oldValue := translation.Value
 
 	// 如果项目ID改变，验证新项目
 	if input.ProjectID != 0 && input.ProjectID != translation.ProjectID {
@@ -367,151 +738,1984 @@ func (s *TranslationService) Update(ctx context.Context, id uint64, input domain
 	}
 
 	if input.Value != "" {
-		translation.Value = strings.TrimSpace(input.Value)
+		value := strings.TrimSpace(input.Value)
+		// 修改后的译文需要重新审核
+		if value != translation.Value {
+			translation.ReviewStatus = domain.ReviewStatusDraft
+		}
+		translation.Value = value
+	}
+
+	// 值类型只能通过 SetValueType 修改，这里只校验是否与键的值类型一致
+	if input.ValueType != "" {
+		translation.ValueType = input.ValueType
+	}
+	if err := s.applyValueTypes(ctx, []*domain.Translation{translation}); err != nil {
+		return nil, err
+	}
+	if err := s.applyKeyMetadata(ctx, []*domain.Translation{translation}); err != nil {
+		return nil, err
 	}
 
 	// 更新UpdatedBy字段
 	translation.UpdatedBy = userID
 
+	// 只修改源语言译文的值时，其他语言的译文过期
+	var changes []sourceChange
+	if translation.ProjectID == oldProjectID && translation.KeyName == oldKeyName &&
+		translation.LanguageID == oldLanguageID && translation.Value != oldValue {
+		project, err := s.projectRepo.GetByID(ctx, translation.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+		if err != nil {
+			return nil, err
+		}
+		if source != nil && source.ID == translation.LanguageID {
+			changes = []sourceChange{{projectID: project.ID, sourceLanguageID: source.ID, keyNames: []string{translation.KeyName}}}
+		}
+	}
+
 	// 保存更新
-	if err := s.translationRepo.Update(ctx, translation); err != nil {
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := markOutdated(ctx, s.translationRepo, changes); err != nil {
+			return err
+		}
+		if err := s.translationRepo.Update(ctx, translation); err != nil {
+			return err
+		}
+		if err := s.publishTranslationsUpdated(ctx, domain.TranslationActionUpdated, []*domain.Translation{translation}); err != nil {
+			return err
+		}
+		if oldProjectID == translation.ProjectID {
+			return nil
+		}
+		// 翻译移出原项目，原项目同样发生了变更
+		return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, oldProjectID, domain.TranslationUpdatedPayload{
+			Action:      domain.TranslationActionDeleted,
+			KeyNames:    []string{translation.KeyName},
+			LanguageIDs: []uint64{translation.LanguageID},
+			Count:       1,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return translation, nil
 }
 
-// Delete 删除翻译
-func (s *TranslationService) Delete(ctx context.Context, id uint64) error {
-	// 检查翻译是否存在
-	_, err := s.translationRepo.GetByID(ctx, id)
-	if err != nil {
-		return err
+// SetValueType 修改键在所有语言的值类型
+// 改为 json 类型时，已有的非空翻译值必须是合法的 JSON 并符合 JSON Schema，否则不做修改；改为其他类型时清除 JSON Schema。
+// markdown 和 html 类型的值不在写入时校验，标记问题在 QA 报告中列出
+func (s *TranslationService) SetValueType(ctx context.Context, projectID uint64, params domain.SetValueTypeParams, userID uint64) (*domain.SetValueTypeResult, error) {
+	valueType := domain.KeyValueType{ValueType: params.ValueType}
+	switch params.ValueType {
+	case domain.ValueTypeString, domain.ValueTypeMarkdown, domain.ValueTypeHTML:
+	case domain.ValueTypeJSON:
+		valueType.ValueSchema = strings.TrimSpace(params.ValueSchema)
+		if valueType.ValueSchema != "" {
+			if err := ValidateValueSchema(valueType.ValueSchema); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, domain.ErrInvalidValueType
 	}
 
-	return s.translationRepo.Delete(ctx, id)
-}
-
-// DeleteBatch 批量删除翻译
-func (s *TranslationService) DeleteBatch(ctx context.Context, ids []uint64) error {
-	if len(ids) == 0 {
-		return nil
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
 	}
 
-	return s.translationRepo.DeleteBatch(ctx, ids)
-}
+	result := &domain.SetValueTypeResult{
+		KeyName:   strings.TrimSpace(params.KeyName),
+		ValueType: valueType.ValueType,
+	}
+	if valueType.ValueSchema != "" {
+		result.ValueSchema = json.RawMessage(valueType.ValueSchema)
+	}
+	err := withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		translations, err := s.translationRepo.GetByProjectKey(ctx, projectID, result.KeyName)
+		if err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return domain.ErrTranslationNotFound
+		}
+		if valueType.ValueType == domain.ValueTypeJSON {
+			for _, translation := range translations {
+				if err := validateTranslationValue(translation, valueType); err != nil {
+					return err
+				}
+			}
+		}
 
-// Export 导出翻译
-func (s *TranslationService) Export(ctx context.Context, projectID uint64, format string) ([]byte, error) {
-	// 验证项目是否存在
-	_, err := s.projectRepo.GetByID(ctx, projectID)
+		result.Translations, err = s.translationRepo.UpdateKeyValueType(ctx, projectID, result.KeyName, valueType, userID)
+		if err != nil {
+			return err
+		}
+		// 值类型决定 JSON 导出的内容，按翻译更新通知
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionUpdated, translations)
+	})
 	if err != nil {
-		return nil, domain.ErrProjectNotFound
+		return nil, err
 	}
+	return result, nil
+}
 
-	// 获取翻译矩阵（导出所有数据，不分页）
-	matrix, _, err := s.translationRepo.GetMatrix(ctx, projectID, -1, 0, "")
+// SetKeyMetadata 修改键在所有语言的最大长度、标签和平台
+// 设置最大长度时，已有的非空翻译值（json 类型除外）不能超过该长度，否则不做修改
+func (s *TranslationService) SetKeyMetadata(ctx context.Context, projectID uint64, params domain.SetKeyMetadataParams, userID uint64) (*domain.SetKeyMetadataResult, error) {
+	tags, err := normalizeKeyTags(params.Tags)
 	if err != nil {
 		return nil, err
 	}
+	platform := strings.ToLower(strings.TrimSpace(params.Platform))
+	switch platform {
+	case "", domain.KeyPlatformWeb, domain.KeyPlatformIOS, domain.KeyPlatformAndroid, domain.KeyPlatformDesktop:
+	default:
+		return nil, domain.ErrInvalidKeyPlatform
+	}
 
-	// 转换为简单格式 (key -> language -> value)
-	simpleMatrix := make(map[string]map[string]string)
-	for key, langs := range matrix {
-		simpleMatrix[key] = make(map[string]string)
-		for lang, cell := range langs {
-			simpleMatrix[key][lang] = cell.Value
-		}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
 	}
 
-	switch format {
-	case "json":
-		return json.MarshalIndent(simpleMatrix, "", "  ")
-	default:
-		return nil, fmt.Errorf("unsupported format: %s", format)
+	metadata := domain.KeyMetadata{MaxLength: params.MaxLength, Tags: strings.Join(tags, ","), Platform: platform}
+	result := &domain.SetKeyMetadataResult{
+		KeyName:   strings.TrimSpace(params.KeyName),
+		MaxLength: metadata.MaxLength,
+		Tags:      tags,
+		Platform:  platform,
 	}
-}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		translations, err := s.translationRepo.GetByProjectKey(ctx, projectID, result.KeyName)
+		if err != nil {
+			return err
+		}
+		if len(translations) == 0 {
+			return domain.ErrTranslationNotFound
+		}
+		for _, translation := range translations {
+			if err := validateValueLength(translation, metadata.MaxLength); err != nil {
+				return err
+			}
+		}
 
-// Import 导入翻译
-func (s *TranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string) error {
-	// 验证项目是否存在
-	_, err := s.projectRepo.GetByID(ctx, projectID)
+		result.Translations, err = s.translationRepo.UpdateKeyMetadata(ctx, projectID, result.KeyName, metadata, userID)
+		return err
+	})
 	if err != nil {
-		return domain.ErrProjectNotFound
+		return nil, err
 	}
+	return result, nil
+}
 
-	switch format {
-	case "json":
-		return s.importFromJSON(ctx, projectID, data)
-	default:
-		return fmt.Errorf("unsupported format: %s", format)
-	}
+// SubmitForReview 提交翻译审核：draft → in_review，译文不能为空
+// 源语言修改后过期（outdated）的译文确认无需修改时也可以直接提交
+func (s *TranslationService) SubmitForReview(ctx context.Context, projectID uint64, params domain.ReviewTransitionParams, userID uint64) (*domain.ReviewTransitionResult, error) {
+	from := []string{domain.ReviewStatusDraft, domain.ReviewStatusOutdated}
+	return s.transitionReview(ctx, projectID, params.TranslationIDs, from, domain.ReviewStatusInReview, userID, "")
 }
 
-// importFromJSON 从JSON导入翻译
-func (s *TranslationService) importFromJSON(ctx context.Context, projectID uint64, data []byte) error {
-	var rawData map[string]interface{}
-	if err := json.Unmarshal(data, &rawData); err != nil {
-		return fmt.Errorf("invalid JSON format: %w", err)
+// ApproveTranslations 审核通过：in_review → approved
+func (s *TranslationService) ApproveTranslations(ctx context.Context, projectID uint64, params domain.ReviewTransitionParams, userID uint64) (*domain.ReviewTransitionResult, error) {
+	return s.transitionReview(ctx, projectID, params.TranslationIDs, []string{domain.ReviewStatusInReview}, domain.ReviewStatusApproved, userID, "")
+}
+
+// RejectTranslations 驳回待审核或已通过的翻译：回到 draft，comment 为驳回原因
+func (s *TranslationService) RejectTranslations(ctx context.Context, projectID uint64, params domain.ReviewTransitionParams, userID uint64) (*domain.ReviewTransitionResult, error) {
+	from := []string{domain.ReviewStatusInReview, domain.ReviewStatusApproved}
+	return s.transitionReview(ctx, projectID, params.TranslationIDs, from, domain.ReviewStatusDraft, userID, strings.TrimSpace(params.Comment))
+}
+
+// transitionReview 将项目中的翻译从 from 中的审核状态改为 to
+// 任一翻译不属于该项目或当前状态不允许此操作时不做修改，错误详情列出这些翻译
+func (s *TranslationService) transitionReview(ctx context.Context, projectID uint64, ids []uint64, from []string, to string, userID uint64, comment string) (*domain.ReviewTransitionResult, error) {
+	unique := make([]uint64, 0, len(ids))
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, domain.ErrInvalidInput
 	}
 
-	// 获取所有语言
-	languages, err := s.languageRepo.GetAll(ctx)
-	if err != nil {
-		return err
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
 	}
 
-	// 创建语言代码到ID的映射
-	languageCodeToID := make(map[string]uint64)
-	for _, lang := range languages {
-		languageCodeToID[lang.Code] = lang.ID
+	allowed := make(map[string]bool, len(from))
+	for _, status := range from {
+		allowed[status] = true
 	}
 
-	// 转换为翻译请求
+	result := &domain.ReviewTransitionResult{ReviewStatus: to}
+	err := withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		translations, err := s.translationRepo.GetByIDs(ctx, unique)
+		if err != nil {
+			return err
+		}
+		found := make(map[uint64]bool, len(translations))
+		var invalid []string
+		for _, translation := range translations {
+			if translation.ProjectID != projectID {
+				continue
+			}
+			found[translation.ID] = true
+			switch {
+			case !allowed[translation.ReviewStatus]:
+				invalid = append(invalid, fmt.Sprintf("翻译 %d（键 %s）的审核状态为 %s", translation.ID, translation.KeyName, translation.ReviewStatus))
+			case to == domain.ReviewStatusInReview && translation.Value == "":
+				invalid = append(invalid, fmt.Sprintf("翻译 %d（键 %s）的译文为空", translation.ID, translation.KeyName))
+			}
+		}
+
+		var missing []string
+		for _, id := range unique {
+			if !found[id] {
+				missing = append(missing, strconv.FormatUint(id, 10))
+			}
+		}
+		if len(missing) > 0 {
+			return domain.NewAppErrorWithDetails(domain.ErrorTypeNotFound, domain.ErrTranslationNotFound.Code, domain.ErrTranslationNotFound.Message,
+				"项目中没有翻译 "+strings.Join(missing, ", "))
+		}
+		if len(invalid) > 0 {
+			return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidReviewTransition.Code, domain.ErrInvalidReviewTransition.Message,
+				strings.Join(invalid, "；"))
+		}
+
+		result.Translations, err = s.translationRepo.UpdateReviewStatus(ctx, unique, to, userID, comment)
+		if err != nil {
+			return err
+		}
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionReviewed, translations)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validateReviewStatus 检查审核状态筛选条件，为空时不筛选
+func validateReviewStatus(reviewStatus string) error {
+	switch reviewStatus {
+	case "", domain.ReviewStatusDraft, domain.ReviewStatusInReview, domain.ReviewStatusApproved, domain.ReviewStatusOutdated:
+		return nil
+	default:
+		return domain.ErrInvalidReviewStatus
+	}
+}
+
+// normalizeKeyTags 去除标签首尾空白、空标签和重复的标签，标签不能包含逗号
+func normalizeKeyTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if strings.Contains(tag, ",") {
+			return nil, invalidKeyTags(fmt.Sprintf("标签 %s 不能包含逗号", tag))
+		}
+		if utf8.RuneCountInString(tag) > domain.MaxKeyTagLength {
+			return nil, invalidKeyTags(fmt.Sprintf("标签 %s 超过 %d 个字符", tag, domain.MaxKeyTagLength))
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > domain.MaxKeyTags {
+		return nil, invalidKeyTags(fmt.Sprintf("最多 %d 个标签", domain.MaxKeyTags))
+	}
+	return normalized, nil
+}
+
+// invalidKeyTags 带错误详情的标签无效错误
+func invalidKeyTags(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidKeyTags.Code, domain.ErrInvalidKeyTags.Message, details)
+}
+
+// applyKeyMetadata 为要写入的翻译设置所属键的最大长度、标签、平台和命名空间，并检查翻译值是否超过最大长度
+// 新键没有元数据，须在创建后通过 SetKeyMetadata 设置，或通过命名空间服务归入命名空间
+func (s *TranslationService) applyKeyMetadata(ctx context.Context, translations []*domain.Translation) error {
+	keyNames := make(map[uint64][]string)
+	seen := make(map[domain.TranslationKey]bool)
+	for _, translation := range translations {
+		key := domain.TranslationKey{ProjectID: translation.ProjectID, KeyName: translation.KeyName}
+		if !seen[key] {
+			seen[key] = true
+			keyNames[translation.ProjectID] = append(keyNames[translation.ProjectID], translation.KeyName)
+		}
+	}
+
+	metadata := make(map[uint64]map[string]domain.KeyMetadata, len(keyNames))
+	for projectID, names := range keyNames {
+		projectMetadata, err := s.translationRepo.GetKeyMetadata(ctx, projectID, names)
+		if err != nil {
+			return err
+		}
+		metadata[projectID] = projectMetadata
+	}
+
+	for _, translation := range translations {
+		keyMetadata := metadata[translation.ProjectID][translation.KeyName]
+		translation.MaxLength = keyMetadata.MaxLength
+		translation.Tags = keyMetadata.Tags
+		translation.Platform = keyMetadata.Platform
+		translation.NamespaceID = keyMetadata.NamespaceID
+		if err := validateValueLength(translation, keyMetadata.MaxLength); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateValueLength 检查翻译值的字符数是否超过 maxLength（不大于 0 时不检查），json 类型的值不检查，错误详情注明键名和语言
+func validateValueLength(translation *domain.Translation, maxLength int) error {
+	if maxLength <= 0 || translation.ValueType == domain.ValueTypeJSON {
+		return nil
+	}
+	if length := utf8.RuneCountInString(translation.Value); length > maxLength {
+		return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrValueTooLong.Code, domain.ErrValueTooLong.Message,
+			fmt.Sprintf("键 %s，语言ID %d: %d 个字符，最多 %d 个字符", translation.KeyName, translation.LanguageID, length, maxLength))
+	}
+	return nil
+}
+
+// applyValueTypes 为要写入的翻译设置所属键的值类型和 JSON Schema，并校验 json 类型的值和 string 类型值中的 ICU 消息
+// 已有的键沿用其值类型，翻译指定的值类型与之不同时返回 ErrValueTypeMismatch；
+// 新键使用翻译指定的值类型（同一批次中同一键只能指定一种），未指定时为 string。空值视为未翻译，不做校验
+func (s *TranslationService) applyValueTypes(ctx context.Context, translations []*domain.Translation) error {
+	byProject := make(map[uint64][]*domain.Translation)
+	var projectIDs []uint64
+	for _, translation := range translations {
+		if byProject[translation.ProjectID] == nil {
+			projectIDs = append(projectIDs, translation.ProjectID)
+		}
+		byProject[translation.ProjectID] = append(byProject[translation.ProjectID], translation)
+	}
+
+	for _, projectID := range projectIDs {
+		group := byProject[projectID]
+		requested := make(map[string]string)
+		var keyNames []string
+		for _, translation := range group {
+			current, seen := requested[translation.KeyName]
+			if !seen {
+				keyNames = append(keyNames, translation.KeyName)
+			}
+			switch translation.ValueType {
+			case "":
+				requested[translation.KeyName] = current
+			case domain.ValueTypeString, domain.ValueTypeJSON, domain.ValueTypeMarkdown, domain.ValueTypeHTML:
+				if current != "" && current != translation.ValueType {
+					return valueTypeMismatch(fmt.Sprintf("键 %s 同时包含 %s 和 %s 类型的值", translation.KeyName, current, translation.ValueType))
+				}
+				requested[translation.KeyName] = translation.ValueType
+			default:
+				return domain.ErrInvalidValueType
+			}
+		}
+
+		types, err := s.translationRepo.GetKeyValueTypes(ctx, projectID, keyNames)
+		if err != nil {
+			return err
+		}
+		var newJSONKeys []string
+		for _, keyName := range keyNames {
+			keyType, exists := types[keyName]
+			switch {
+			case exists && requested[keyName] != "" && requested[keyName] != keyType.ValueType:
+				return valueTypeMismatch(fmt.Sprintf("键 %s 的值类型为 %s", keyName, keyType.ValueType))
+			case !exists && requested[keyName] == domain.ValueTypeJSON:
+				newJSONKeys = append(newJSONKeys, keyName)
+			}
+		}
+		// 没有查到值类型的已有键为 string 类型
+		if len(newJSONKeys) > 0 {
+			existing, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, newJSONKeys)
+			if err != nil {
+				return err
+			}
+			if len(existing) > 0 {
+				return valueTypeMismatch(fmt.Sprintf("键 %s 的值类型为 string", existing[0]))
+			}
+		}
+
+		for _, translation := range group {
+			keyType, exists := types[translation.KeyName]
+			if !exists {
+				keyType = domain.KeyValueType{ValueType: requested[translation.KeyName], ValueSchema: translation.ValueSchema}
+				if keyType.ValueType == "" {
+					keyType.ValueType = domain.ValueTypeString
+				}
+			}
+			translation.ValueType = keyType.ValueType
+			translation.ValueSchema = ""
+			if keyType.ValueType == domain.ValueTypeString {
+				if err := validateICUValue(translation); err != nil {
+					return err
+				}
+				continue
+			}
+			if keyType.ValueType != domain.ValueTypeJSON {
+				continue
+			}
+			translation.ValueSchema = keyType.ValueSchema
+			if err := validateTranslationValue(translation, keyType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateTranslationValue 校验 json 类型的翻译值，错误详情注明键名和语言
+func validateTranslationValue(translation *domain.Translation, valueType domain.KeyValueType) error {
+	if translation.Value == "" {
+		return nil
+	}
+	err := ValidateJSONValue(translation.Value, valueType.ValueSchema)
+	if appErr, ok := domain.IsAppError(err); ok {
+		return domain.NewAppErrorWithDetails(appErr.Type, appErr.Code, appErr.Message,
+			fmt.Sprintf("键 %s，语言ID %d: %s", translation.KeyName, translation.LanguageID, appErr.Details))
+	}
+	return err
+}
+
+// validateICUValue 校验包含 plural、selectordinal 或 select 参数的 string 类型翻译值的 ICU 语法，错误详情注明键名和语言
+func validateICUValue(translation *domain.Translation) error {
+	if !IsICUMessage(translation.Value) {
+		return nil
+	}
+	if _, err := ParseICUMessage(translation.Value); err != nil {
+		return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrInvalidICUMessage.Code, domain.ErrInvalidICUMessage.Message,
+			fmt.Sprintf("键 %s，语言ID %d: %s", translation.KeyName, translation.LanguageID, err.Error()))
+	}
+	return nil
+}
+
+// valueTypeMismatch 带错误详情的值类型不一致错误
+func valueTypeMismatch(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrValueTypeMismatch.Code, domain.ErrValueTypeMismatch.Message, details)
+}
+
+// Delete 删除翻译
+func (s *TranslationService) Delete(ctx context.Context, id uint64) error {
+	// 检查翻译是否存在
+	translation, err := s.translationRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.translationRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionDeleted, []*domain.Translation{translation})
+	})
+}
+
+// DeleteBatch 批量删除翻译，翻译所属的项目开启删除保护时不删除
+func (s *TranslationService) DeleteBatch(ctx context.Context, ids []uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// 先查询待删除的翻译，用于检查删除保护和发布事件
+	var deleted []*domain.Translation
+	projectIDSet := make(map[uint64]bool)
+	var projectIDs []uint64
+	for _, id := range ids {
+		if translation, err := s.translationRepo.GetByID(ctx, id); err == nil {
+			deleted = append(deleted, translation)
+			if !projectIDSet[translation.ProjectID] {
+				projectIDSet[translation.ProjectID] = true
+				projectIDs = append(projectIDs, translation.ProjectID)
+			}
+		}
+	}
+	if err := ensureProjectsUnprotected(ctx, s.projectRepo, projectIDs...); err != nil {
+		return err
+	}
+
+	return withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.translationRepo.DeleteBatch(ctx, ids); err != nil {
+			return err
+		}
+		return s.publishTranslationsUpdated(ctx, domain.TranslationActionDeleted, deleted)
+	})
+}
+
+// exportStatuses 导出选项对应的翻译状态，只导出审核通过的翻译时还按审核状态筛选
+var exportStatuses = map[string]domain.TranslationStatusFilter{
+	"":                              {Statuses: []string{"active"}},
+	domain.ExportOnlyStatusApproved: {Statuses: []string{"active"}, ReviewStatuses: []string{domain.ReviewStatusApproved}},
+}
+
+// GetExportValues 按导出选项获取项目翻译：键名 -> 语言代码 -> 翻译值
+// 所有导出格式都基于此结果，保证状态过滤和缺失翻译的处理方式一致
+func (s *TranslationService) GetExportValues(ctx context.Context, projectID uint64, options domain.ExportOptions) (map[string]map[string]string, error) {
+	filter, ok := exportStatuses[options.OnlyStatus]
+	if !ok {
+		return nil, domain.ErrInvalidExportStatus
+	}
+	missing := options.Missing
+	switch missing {
+	case "":
+		missing = domain.ExportMissingSkip
+	case domain.ExportMissingSkip, domain.ExportMissingEmpty, domain.ExportMissingSourceFallback:
+	default:
+		return nil, domain.ErrInvalidExportMissing
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	var values map[string]map[string]string
+	if options.Release != "" {
+		release, err := s.exportRelease(ctx, projectID, options)
+		if err != nil {
+			return nil, err
+		}
+		if values, err = s.releaseRepo.GetValues(ctx, release.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		if options.Namespace != "" {
+			namespace, err := s.namespaceRepo.GetByName(ctx, projectID, options.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			filter.NamespaceID = namespace.ID
+		}
+		filter.Tags = normalizeTagFilter(options.Tags)
+		var err error
+		if values, err = s.translationRepo.GetValuesByStatus(ctx, projectID, filter); err != nil {
+			return nil, err
+		}
+	}
+	if missing == domain.ExportMissingSkip {
+		return values, nil
+	}
+
+	// 源语言的翻译同样经过状态过滤，回退时不会引入未通过的翻译
+	sourceCode := ""
+	if missing == domain.ExportMissingSourceFallback {
+		source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+		if err != nil {
+			return nil, err
+		}
+		if source == nil {
+			return nil, domain.ErrExportSourceLanguage
+		}
+		sourceCode = source.Code
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, langs := range values {
+		for _, language := range languages {
+			if language.Status != "active" {
+				continue
+			}
+			if _, exists := langs[language.Code]; exists {
+				continue
+			}
+			if missing == domain.ExportMissingEmpty {
+				langs[language.Code] = ""
+			} else if value, exists := langs[sourceCode]; exists {
+				langs[language.Code] = value
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// exportRelease 获取导出指定的发布版本，发布版本的快照不能再按状态、命名空间或标签筛选
+func (s *TranslationService) exportRelease(ctx context.Context, projectID uint64, options domain.ExportOptions) (*domain.Release, error) {
+	if options.OnlyStatus != "" || options.Namespace != "" || len(normalizeTagFilter(options.Tags)) > 0 {
+		return nil, invalidRelease("按发布版本导出时不能指定 only_status、namespace 或 tags")
+	}
+	return s.releaseRepo.GetByVersion(ctx, projectID, options.Release)
+}
+
+// exportValueTypes 获取导出时键的值类型，按发布版本导出时使用发布时的值类型
+func (s *TranslationService) exportValueTypes(ctx context.Context, projectID uint64, options domain.ExportOptions) (map[string]domain.KeyValueType, error) {
+	if options.Release == "" {
+		return s.translationRepo.GetKeyValueTypes(ctx, projectID, nil)
+	}
+	release, err := s.releaseRepo.GetByVersion(ctx, projectID, options.Release)
+	if err != nil {
+		return nil, err
+	}
+	return s.releaseRepo.GetValueTypes(ctx, release.ID)
+}
+
+// Export 导出翻译
+// json 格式中 json 类型的键输出解析后的值（数组、对象等），其余格式按字符串输出；
+// xliff 格式的 1.2 版本将每种目标语言导出为文档中的一个 file 元素，2.0 版本只能包含一种目标语言；
+// yaml 格式导出为 Rails 风格的文档，每种语言一个根节点，键名按 . 重新嵌套；
+// 移动端格式（android-xml、apple-strings、apple-stringsdict）、ARB、properties 和 resx 每个文件只能包含一种语言
+func (s *TranslationService) Export(ctx context.Context, projectID uint64, format string, options domain.ExportOptions) ([]byte, error) {
+	switch format {
+	case "json":
+		values, err := s.GetExportValues(ctx, projectID, options)
+		if err != nil {
+			return nil, err
+		}
+		types, err := s.exportValueTypes(ctx, projectID, options)
+		if err != nil {
+			return nil, err
+		}
+		document := make(map[string]map[string]interface{}, len(values))
+		for key, langs := range values {
+			document[key] = make(map[string]interface{}, len(langs))
+			for lang, value := range langs {
+				document[key][lang] = jsonExportValue(value, types[key])
+			}
+		}
+		return json.MarshalIndent(document, "", "  ")
+	case "yaml":
+		values, err := s.GetExportValues(ctx, projectID, options)
+		if err != nil {
+			return nil, err
+		}
+		return MarshalLocaleYAML(transposeExportValues(values))
+	case "xliff":
+		project, err := s.projectRepo.GetByID(ctx, projectID)
+		if err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		files, err := s.buildXLIFFFiles(ctx, project, options)
+		if err != nil {
+			return nil, err
+		}
+		return MarshalXLIFF(options.XLIFFVersion, files)
+	case "po", "pot":
+		project, err := s.projectRepo.GetByID(ctx, projectID)
+		if err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		files, err := s.buildPOFiles(ctx, project, options, format == "pot")
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 1 {
+			return nil, domain.ErrGettextMultipleTargets
+		}
+		return MarshalPO(files[0]), nil
+	case FormatAndroidXML, FormatAppleStrings, FormatAppleStringsDict, FormatARB, FormatProperties, FormatResx:
+		project, err := s.projectRepo.GetByID(ctx, projectID)
+		if err != nil {
+			return nil, domain.ErrProjectNotFound
+		}
+		if options.TargetLanguage == "" {
+			language, err := s.languageRepo.GetDefault(ctx)
+			if err != nil {
+				if err == domain.ErrLanguageNotFound {
+					return nil, domain.ErrExportLanguageMissing
+				}
+				return nil, err
+			}
+			options.TargetLanguage = language.Code
+		}
+		files, err := s.buildLocaleExportFiles(ctx, project, format, options)
+		if err != nil {
+			return nil, err
+		}
+		return files[0].Content, nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+// ExportFiles 按语言拆分导出翻译文件
+// 文件路径由项目的导出文件命名模板决定，未配置时使用 DefaultExportFileTemplate
+func (s *TranslationService) ExportFiles(ctx context.Context, projectID uint64, format string, options domain.ExportOptions) ([]*domain.ExportFile, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	switch format {
+	case "xliff":
+		return s.buildXLIFFExportFiles(ctx, project, options)
+	case "po":
+		return s.buildPOExportFiles(ctx, project, options)
+	case FormatAndroidXML, FormatAppleStrings, FormatAppleStringsDict, FormatARB, FormatProperties, FormatResx:
+		return s.buildLocaleExportFiles(ctx, project, format, options)
+	}
+
+	values, err := s.GetExportValues(ctx, projectID, options)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.exportValueTypes(ctx, projectID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildExportFiles(project, values, types, format, options)
+}
+
+// buildXLIFFExportFiles 每种目标语言导出为一个 XLIFF 文件
+func (s *TranslationService) buildXLIFFExportFiles(ctx context.Context, project *domain.Project, options domain.ExportOptions) ([]*domain.ExportFile, error) {
+	xliffFiles, err := s.buildXLIFFFiles(ctx, project, options)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*domain.ExportFile, 0, len(xliffFiles))
+	for _, xliffFile := range xliffFiles {
+		content, err := MarshalXLIFF(options.XLIFFVersion, []*XLIFFFile{xliffFile})
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &domain.ExportFile{
+			Name: RenderExportFileName(project.ExportFileTemplate, ExportFileVars{
+				Locale:    xliffFile.TargetLanguage,
+				Namespace: options.Namespace,
+				Project:   project.Slug,
+				Ext:       "xlf",
+			}),
+			Locale:  xliffFile.TargetLanguage,
+			Content: content,
+		})
+	}
+	return files, nil
+}
+
+// buildXLIFFFiles 以项目的源语言为源语言，为每种目标语言生成一个 XLIFF 文件
+// 只包含源语言有翻译的键；目标语言缺失的翻译（按 missing 选项处理后仍为空）不输出 target，在 CAT 工具中显示为待翻译
+func (s *TranslationService) buildXLIFFFiles(ctx context.Context, project *domain.Project, options domain.ExportOptions) ([]*XLIFFFile, error) {
+	switch options.XLIFFVersion {
+	case "", XLIFFVersion12, XLIFFVersion20:
+	default:
+		return nil, domain.ErrUnsupportedXLIFFVersion
+	}
+
+	values, err := s.GetExportValues(ctx, project.ID, options)
+	if err != nil {
+		return nil, err
+	}
+	source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, domain.ErrXLIFFSourceLanguage
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := s.translationRepo.GetKeyContexts(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key, langs := range values {
+		if langs[source.Code] != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var files []*XLIFFFile
+	for _, language := range languages {
+		if language.Status != "active" || language.Code == source.Code {
+			continue
+		}
+		if options.TargetLanguage != "" && !strings.EqualFold(xliffLanguageCode(language.Code), xliffLanguageCode(options.TargetLanguage)) {
+			continue
+		}
+
+		file := &XLIFFFile{Original: project.Slug, SourceLanguage: source.Code, TargetLanguage: language.Code}
+		for _, key := range keys {
+			unit := XLIFFUnit{Key: key, Source: values[key][source.Code], Note: contexts[key]}
+			if value := values[key][language.Code]; value != "" {
+				unit.Target = value
+				unit.HasTarget = true
+			}
+			file.Units = append(file.Units, unit)
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, domain.ErrXLIFFNoTargetLanguage
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].TargetLanguage < files[j].TargetLanguage })
+	return files, nil
+}
+
+// buildPOExportFiles 每种目标语言导出为一个 PO 文件
+func (s *TranslationService) buildPOExportFiles(ctx context.Context, project *domain.Project, options domain.ExportOptions) ([]*domain.ExportFile, error) {
+	poFiles, err := s.buildPOFiles(ctx, project, options, false)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*domain.ExportFile, 0, len(poFiles))
+	for _, poFile := range poFiles {
+		files = append(files, &domain.ExportFile{
+			Name: RenderExportFileName(project.ExportFileTemplate, ExportFileVars{
+				Locale:    poFile.Language,
+				Namespace: options.Namespace,
+				Project:   project.Slug,
+				Ext:       "po",
+			}),
+			Locale:  poFile.Language,
+			Content: MarshalPO(poFile),
+		})
+	}
+	return files, nil
+}
+
+// buildPOFiles 以项目源语言的翻译为 msgid，为每种目标语言生成一个 PO 文件，template 为 true 时只生成一个 POT 模板
+func (s *TranslationService) buildPOFiles(ctx context.Context, project *domain.Project, options domain.ExportOptions, template bool) ([]*POFile, error) {
+	values, err := s.GetExportValues(ctx, project.ID, options)
+	if err != nil {
+		return nil, err
+	}
+	source, err := projectSourceLanguage(ctx, s.languageRepo, project)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, domain.ErrGettextSourceLanguage
+	}
+	contexts, err := s.translationRepo.GetKeyContexts(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if template {
+		return []*POFile{BuildPOFile(project.Slug, values, contexts, source.Code, "")}, nil
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var files []*POFile
+	for _, language := range languages {
+		if language.Status != "active" || language.Code == source.Code {
+			continue
+		}
+		if options.TargetLanguage != "" && !strings.EqualFold(gettextLanguageCode(language.Code), gettextLanguageCode(options.TargetLanguage)) {
+			continue
+		}
+		files = append(files, BuildPOFile(project.Slug, values, contexts, source.Code, language.Code))
+	}
+	if len(files) == 0 {
+		return nil, domain.ErrGettextNoTargetLanguage
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Language < files[j].Language })
+	return files, nil
+}
+
+// buildLocaleExportFiles 每种启用的语言导出为一个移动端格式、ARB、properties 或 resx 文件，指定 options.TargetLanguage 时只导出该语言
+// 键的上下文说明作为注释写入 strings.xml、.strings 和 .properties，在 ARB 中写入 @key 元数据的 description，在 resx 中写入 comment，
+// 供翻译和开发人员参考
+func (s *TranslationService) buildLocaleExportFiles(ctx context.Context, project *domain.Project, format string, options domain.ExportOptions) ([]*domain.ExportFile, error) {
+	values, err := s.GetExportValues(ctx, project.ID, options)
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := s.translationRepo.GetKeyContexts(ctx, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	localeMatrix := transposeExportValues(values)
+	var files []*domain.ExportFile
+	for _, language := range languages {
+		if language.Status != "active" {
+			continue
+		}
+		if options.TargetLanguage != "" && !strings.EqualFold(language.Code, options.TargetLanguage) {
+			continue
+		}
+		vars := ExportFileVars{Locale: language.Code, Namespace: options.Namespace, Project: project.Slug, Ext: mobileExportExts[format]}
+		var content []byte
+		switch format {
+		case FormatARB:
+			// Flutter 按 app_zh_CN.arb 的形式查找文件，与 @@locale 保持一致
+			vars.Locale, vars.Ext = arbLocale(language.Code), arbExt
+			content, err = MarshalARB(language.Code, localeMatrix[language.Code], contexts)
+			if err != nil {
+				return nil, err
+			}
+		case FormatProperties:
+			// Java ResourceBundle 按 messages_zh_CN.properties 的形式查找文件
+			vars.Locale, vars.Ext = propertiesLocale(language.Code), propertiesExt
+			content = MarshalProperties(localeMatrix[language.Code], contexts)
+		case FormatResx:
+			// .NET 的附属程序集使用 Resources.zh-CN.resx 形式的区域性名称，与语言代码相同
+			vars.Ext = resxExt
+			content = MarshalResx(localeMatrix[language.Code], contexts)
+		default:
+			content = MarshalMobileStrings(format, localeMatrix[language.Code], contexts)
+		}
+		files = append(files, &domain.ExportFile{
+			Name:    RenderExportFileName(project.ExportFileTemplate, vars),
+			Locale:  language.Code,
+			Content: content,
+		})
+	}
+	if len(files) == 0 {
+		return nil, domain.ErrLanguageNotFound
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Locale < files[j].Locale })
+	return files, nil
+}
+
+// buildExportFiles 将导出的翻译按语言拆分并序列化为文件
+// yaml 格式的 Rails 风格文件以语言代码为根节点，扩展名为 .yml；Symfony 风格文件没有根节点，扩展名为 .yaml；
+// json 格式中 json 类型的键输出解析后的值，其余格式按字符串输出
+func buildExportFiles(project *domain.Project, values map[string]map[string]string, types map[string]domain.KeyValueType, format string, options domain.ExportOptions) ([]*domain.ExportFile, error) {
+	var ext string
+	switch format {
+	case "json":
+		ext = "json"
+	case "yaml":
+		switch options.YAMLStyle {
+		case "", YAMLStyleRails:
+			ext = "yml"
+		case YAMLStyleSymfony:
+			ext = "yaml"
+		default:
+			return nil, domain.ErrUnsupportedYAMLStyle
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	localeMatrix := transposeExportValues(values)
+
+	locales := make([]string, 0, len(localeMatrix))
+	for locale := range localeMatrix {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	files := make([]*domain.ExportFile, 0, len(locales))
+	for _, locale := range locales {
+		var content []byte
+		var err error
+		switch {
+		case format == "json":
+			document := make(map[string]interface{}, len(localeMatrix[locale]))
+			for key, value := range localeMatrix[locale] {
+				document[key] = jsonExportValue(value, types[key])
+			}
+			content, err = json.MarshalIndent(document, "", "  ")
+		case options.YAMLStyle == YAMLStyleSymfony:
+			content, err = MarshalFlatLocaleYAML(localeMatrix[locale])
+		default:
+			content, err = MarshalLocaleYAML(map[string]map[string]string{locale: localeMatrix[locale]})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, &domain.ExportFile{
+			Name: RenderExportFileName(project.ExportFileTemplate, ExportFileVars{
+				Locale:    locale,
+				Namespace: options.Namespace,
+				Project:   project.Slug,
+				Ext:       ext,
+			}),
+			Locale:  locale,
+			Content: content,
+		})
+	}
+
+	return files, nil
+}
+
+// jsonExportValue json 类型的值在 JSON 导出中原样输出，空值（按 missing 选项填充的空译文）仍输出为字符串
+func jsonExportValue(value string, valueType domain.KeyValueType) interface{} {
+	if valueType.ValueType == domain.ValueTypeJSON && json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+	return value
+}
+
+// transposeExportValues 将 key -> language -> value 转换为 language -> key -> value
+func transposeExportValues(values map[string]map[string]string) map[string]map[string]string {
+	localeMatrix := make(map[string]map[string]string)
+	for key, langs := range values {
+		for lang, value := range langs {
+			if localeMatrix[lang] == nil {
+				localeMatrix[lang] = make(map[string]string)
+			}
+			localeMatrix[lang][key] = value
+		}
+	}
+	return localeMatrix
+}
+
+// Import 导入翻译
+// yaml 格式的文件没有语言根节点时（Symfony 风格）、arb 格式的文件没有 @@locale 时需要通过 options.Language 指定语言；
+// options.Strategy 决定与已有翻译冲突时的处理方式，为空时 json 格式为 fail，其余格式为 overwrite（与引入策略前的行为一致）。
+// 译文没有变化的翻译不会写入；fail 策略下有冲突时返回 ErrImportConflict，不写入任何翻译
+func (s *TranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string, options domain.ImportOptions) (*domain.ImportResult, error) {
+	// 验证项目是否存在
+	_, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	var namespace *domain.Namespace
+	if options.Namespace != "" {
+		namespace, err = s.namespaceRepo.GetByName(ctx, projectID, options.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	strategy := options.Strategy
+	switch strategy {
+	case "":
+		strategy = domain.ImportStrategyOverwrite
+		if format == "json" {
+			strategy = domain.ImportStrategyFail
+		}
+	case domain.ImportStrategySkip, domain.ImportStrategyOverwrite, domain.ImportStrategyMerge, domain.ImportStrategyFail:
+	default:
+		return nil, domain.ErrInvalidImportStrategy
+	}
+
+	importer := s.importFromJSON
+	switch format {
+	case "json":
+	case "xliff":
+		importer = s.importFromXLIFF
+	case "yaml":
+		importer = func(ctx context.Context, projectID uint64, data []byte) ([]domain.TranslationInput, error) {
+			return s.importFromYAML(ctx, projectID, data, options.Language)
+		}
+	case FormatARB:
+		importer = func(ctx context.Context, projectID uint64, data []byte) ([]domain.TranslationInput, error) {
+			return s.importFromARB(ctx, projectID, data, options.Language)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	var result *domain.ImportResult
+	// 冲突检查、导入的翻译与导入完成事件在同一事务中提交
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		inputs, err := importer(ctx, projectID, data)
+		if err != nil {
+			return err
+		}
+		var writes []domain.TranslationInput
+		result, writes, err = s.resolveImportConflicts(ctx, projectID, inputs, strategy)
+		if err != nil {
+			return err
+		}
+		result.Format = format
+		if result.Conflicts > 0 {
+			return importConflictError(result)
+		}
+		if len(writes) > 0 {
+			if err := s.UpsertBatch(ctx, writes); err != nil {
+				return err
+			}
+		}
+		if namespace != nil {
+			// 文件中的键（包括跳过的已有键）都归入命名空间
+			result.Namespace = namespace.Name
+			if err := s.assignImportNamespace(ctx, projectID, inputs, namespace.ID); err != nil {
+				return err
+			}
+		}
+		if len(writes) == 0 {
+			return nil
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventImportCompleted, projectID, domain.ImportCompletedPayload{
+			Source:       domain.ImportSourceFile,
+			Format:       format,
+			Keys:         result.Created + result.Updated,
+			Translations: result.Translations,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// assignImportNamespace 将导入文件中的键归入命名空间，有键的命名空间变化时发布翻译更新事件
+func (s *TranslationService) assignImportNamespace(ctx context.Context, projectID uint64, inputs []domain.TranslationInput, namespaceID uint64) error {
+	seen := make(map[string]bool)
+	var keyNames []string
+	for _, input := range inputs {
+		keyName := strings.TrimSpace(input.KeyName)
+		if keyName != "" && !seen[keyName] {
+			seen[keyName] = true
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	sort.Strings(keyNames)
+
+	affected, err := s.translationRepo.UpdateKeyNamespace(ctx, projectID, keyNames, namespaceID, 0)
+	if err != nil || affected == 0 {
+		return err
+	}
+	return publishEvent(ctx, s.eventBus, domain.EventTranslationUpdated, projectID, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: keyNames,
+		Count:    int(affected),
+	})
+}
+
+// maxImportKeyResults 导入结果中最多返回的键数量
+const maxImportKeyResults = 1000
+
+// maxImportConflictDetails 冲突错误详情中最多列出的键数量
+const maxImportConflictDetails = 20
+
+// resolveImportConflicts 按冲突策略决定要写入的翻译，并生成每个键的处理结果
+// 同一键和语言出现多次时以最后一次为准；没有上下文说明的翻译保留已有的上下文说明
+func (s *TranslationService) resolveImportConflicts(ctx context.Context, projectID uint64, inputs []domain.TranslationInput, strategy string) (*domain.ImportResult, []domain.TranslationInput, error) {
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	languageIDToCode := make(map[uint64]string, len(languages))
+	for _, language := range languages {
+		languageIDToCode[language.ID] = language.Code
+	}
+
+	cellIndex := make(map[string]int)
+	byKey := make(map[string][]domain.TranslationInput)
+	var keyNames []string
+	languageIDs := make(map[uint64]bool)
+	for _, input := range inputs {
+		input.KeyName = strings.TrimSpace(input.KeyName)
+		if input.KeyName == "" {
+			continue
+		}
+		cell := fmt.Sprintf("%s:%d", input.KeyName, input.LanguageID)
+		if idx, exists := cellIndex[cell]; exists {
+			byKey[input.KeyName][idx] = input
+			continue
+		}
+		if byKey[input.KeyName] == nil {
+			keyNames = append(keyNames, input.KeyName)
+		}
+		cellIndex[cell] = len(byKey[input.KeyName])
+		byKey[input.KeyName] = append(byKey[input.KeyName], input)
+		languageIDs[input.LanguageID] = true
+	}
+	sort.Strings(keyNames)
+
+	existingKeys := make(map[string]bool)
+	found, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, keyName := range found {
+		existingKeys[keyName] = true
+	}
+	// 按语言读取已有翻译，避免为每个单元格拼接查询条件
+	existingCells := make(map[string]*domain.Translation)
+	for languageID := range languageIDs {
+		translations, err := s.translationRepo.GetByProjectAndLanguage(ctx, projectID, languageID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range translations {
+			if byKey[t.KeyName] != nil {
+				existingCells[fmt.Sprintf("%s:%d", t.KeyName, t.LanguageID)] = t
+			}
+		}
+	}
+
+	result := &domain.ImportResult{Strategy: strategy, Keys: []domain.ImportKeyResult{}}
+	var writes []domain.TranslationInput
+	for _, keyName := range keyNames {
+		keyResult := domain.ImportKeyResult{Key: keyName, Action: domain.ImportKeySkip}
+		keyInputs := byKey[keyName]
+		sort.Slice(keyInputs, func(i, j int) bool {
+			return languageIDToCode[keyInputs[i].LanguageID] < languageIDToCode[keyInputs[j].LanguageID]
+		})
+
+		var conflicts []string
+		for _, input := range keyInputs {
+			code := languageIDToCode[input.LanguageID]
+			current := existingCells[fmt.Sprintf("%s:%d", keyName, input.LanguageID)]
+			write := false
+			switch {
+			case strategy == domain.ImportStrategySkip && existingKeys[keyName]:
+			case current == nil:
+				write = true
+			case strategy == domain.ImportStrategyFail:
+				conflicts = append(conflicts, code)
+			case strategy == domain.ImportStrategyMerge:
+				write = strings.TrimSpace(current.Value) == ""
+			default:
+				write = current.Value != strings.TrimSpace(input.Value) ||
+					(input.Context != "" && current.Context != strings.TrimSpace(input.Context))
+			}
+			if !write {
+				keyResult.SkippedLanguages = append(keyResult.SkippedLanguages, code)
+				continue
+			}
+			if current != nil && input.Context == "" {
+				input.Context = current.Context
+			}
+			writes = append(writes, input)
+			keyResult.Languages = append(keyResult.Languages, code)
+		}
+
+		switch {
+		case len(conflicts) > 0:
+			keyResult.Action = domain.ImportKeyConflict
+			keyResult.Reason = "已有翻译：" + strings.Join(conflicts, "、")
+			result.Conflicts++
+		case len(keyResult.Languages) > 0 && existingKeys[keyName]:
+			keyResult.Action = domain.ImportKeyUpdate
+			result.Updated++
+		case len(keyResult.Languages) > 0:
+			keyResult.Action = domain.ImportKeyCreate
+			result.Created++
+		default:
+			switch {
+			case strategy == domain.ImportStrategySkip && existingKeys[keyName]:
+				keyResult.Reason = "键已存在"
+			case strategy == domain.ImportStrategyMerge:
+				keyResult.Reason = "已有译文"
+			default:
+				keyResult.Reason = "译文没有变化"
+			}
+			result.Skipped++
+		}
+		if len(result.Keys) < maxImportKeyResults {
+			result.Keys = append(result.Keys, keyResult)
+		} else {
+			result.Truncated = true
+		}
+	}
+	if result.Conflicts > 0 {
+		return result, nil, nil
+	}
+	result.Translations = len(writes)
+	return result, writes, nil
+}
+
+// importConflictError fail 策略下的冲突错误，详情列出冲突的键
+func importConflictError(result *domain.ImportResult) error {
+	var keys []string
+	for _, keyResult := range result.Keys {
+		if keyResult.Action == domain.ImportKeyConflict && len(keys) < maxImportConflictDetails {
+			keys = append(keys, keyResult.Key)
+		}
+	}
+	details := "已有翻译的键：" + strings.Join(keys, "、")
+	if result.Conflicts > len(keys) {
+		details += fmt.Sprintf(" 等 %d 个键", result.Conflicts)
+	}
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeConflict, domain.ErrImportConflict.Code,
+		domain.ErrImportConflict.Message, details)
+}
+
+// ImportMigration 从 Lokalise、Crowdin、POEditor 的原生导出内容迁移翻译
+// 已存在的翻译会被覆盖；描述和备注写入上下文，标签追加到键已有的标签上；截图不导入，在结果中给出警告
+func (s *TranslationService) ImportMigration(ctx context.Context, projectID uint64, params domain.MigrationImportParams) (*domain.MigrationImportResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	entries, err := ParseMigrationBundle(params.Source, params.Data, params.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64)
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	result := &domain.MigrationImportResult{}
+	unmapped := make(map[string]bool)
+	keys := make(map[string]bool)
+	tagsByKey := make(map[string][]string)
+	screenshotsByKey := make(map[string]int)
+	// 同一键和语言出现多次时以最后一次为准
+	inputsByCell := make(map[string]int)
+	var inputs []domain.TranslationInput
+
+	for _, entry := range entries {
+		keyName := strings.TrimSpace(entry.Key)
+		if keyName == "" {
+			result.SkippedEntries++
+			continue
+		}
+		// 标签和截图属于键，每种语言的条目中重复出现
+		tagsByKey[keyName] = append(tagsByKey[keyName], entry.Tags...)
+		if entry.Screenshots > screenshotsByKey[keyName] {
+			screenshotsByKey[keyName] = entry.Screenshots
+		}
+		languageID, ok := ResolveLanguageCode(entry.Language, languageCodeToID)
+		if !ok {
+			unmapped[entry.Language] = true
+			result.SkippedEntries++
+			continue
+		}
+
+		input := domain.TranslationInput{
+			ProjectID:  projectID,
+			KeyName:    keyName,
+			LanguageID: languageID,
+			Context:    truncateRunes(entry.Context, 500),
+			Value:      entry.Value,
+		}
+		cell := fmt.Sprintf("%s:%d", keyName, languageID)
+		if idx, exists := inputsByCell[cell]; exists {
+			inputs[idx] = input
+			continue
+		}
+		inputsByCell[cell] = len(inputs)
+		inputs = append(inputs, input)
+		keys[keyName] = true
+	}
+
+	if len(inputs) == 0 {
+		return nil, domain.ErrInvalidMigrationBundle
+	}
+
+	result.Keys = len(keys)
+	result.Translations = len(inputs)
+	result.UnmappedLanguages = make([]string, 0, len(unmapped))
+	for code := range unmapped {
+		result.UnmappedLanguages = append(result.UnmappedLanguages, code)
+	}
+	sort.Strings(result.UnmappedLanguages)
+	result.Warnings = []string{}
+	for keyName, screenshots := range screenshotsByKey {
+		if keys[keyName] {
+			result.Screenshots += screenshots
+		}
+	}
+	if result.Screenshots > 0 {
+		result.Warnings = append(result.Warnings, domain.MigrationWarningSkippedScreenshots)
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.UpsertBatch(ctx, inputs); err != nil {
+			return err
+		}
+		if err := s.importKeyTags(ctx, projectID, keys, tagsByKey, params.UserID, result); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventImportCompleted, projectID, domain.ImportCompletedPayload{
+			Source:       params.Source,
+			Keys:         result.Keys,
+			Translations: result.Translations,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importKeyTags 将迁移内容中的标签追加到已导入的键已有的标签上
+// 包含逗号、超过 domain.MaxKeyTagLength 个字符或超出 domain.MaxKeyTags 上限的标签跳过并计入 SkippedTags
+func (s *TranslationService) importKeyTags(ctx context.Context, projectID uint64, keys map[string]bool, tagsByKey map[string][]string, userID uint64, result *domain.MigrationImportResult) error {
+	var keyNames []string
+	for keyName, tags := range tagsByKey {
+		if keys[keyName] && len(tags) > 0 {
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	if len(keyNames) == 0 {
+		return nil
+	}
+	sort.Strings(keyNames)
+
+	metadata, err := s.translationRepo.GetKeyMetadata(ctx, projectID, keyNames)
+	if err != nil {
+		return err
+	}
+	for _, keyName := range keyNames {
+		keyMetadata := metadata[keyName]
+		var tags []string
+		seen := make(map[string]bool)
+		for _, tag := range strings.Split(keyMetadata.Tags, ",") {
+			if tag != "" {
+				tags = append(tags, tag)
+				seen[tag] = true
+			}
+		}
+
+		// 同一键的标签在每种语言的条目中重复出现，跳过的标签只计一次
+		skipped := make(map[string]bool)
+		tagged, changed := false, false
+		for _, tag := range tagsByKey[keyName] {
+			tag = strings.TrimSpace(tag)
+			if tag == "" || skipped[tag] {
+				continue
+			}
+			if seen[tag] {
+				tagged = true
+				continue
+			}
+			if strings.Contains(tag, ",") || utf8.RuneCountInString(tag) > domain.MaxKeyTagLength || len(tags) >= domain.MaxKeyTags {
+				skipped[tag] = true
+				result.SkippedTags++
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+			tagged, changed = true, true
+		}
+		if tagged {
+			result.TaggedKeys++
+		}
+		if !changed {
+			continue
+		}
+
+		keyMetadata.Tags = strings.Join(tags, ",")
+		if _, err := s.translationRepo.UpdateKeyMetadata(ctx, projectID, keyName, keyMetadata, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxSpreadsheetPreviewRows 表格导入结果中最多返回的行数，计数不受影响
+const maxSpreadsheetPreviewRows = 1000
+
+// ImportSpreadsheet 导入第一列为键名、其余列表头为语言代码的 CSV/XLSX 表格
+// 语言代码的匹配规则与 XLIFF 导入相同，无法匹配的列跳过；只写入新增或有变化的译文，已有的上下文保持不变。
+// 同一键名出现多次时以最后一行为准；DryRun 时只返回每一行将新建、更新还是跳过，不写入
+func (s *TranslationService) ImportSpreadsheet(ctx context.Context, projectID uint64, params domain.SpreadsheetUploadParams) (*domain.SpreadsheetUploadResult, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	format, rows, err := ParseSpreadsheet(params.Data, params.Format)
+	if err != nil {
+		return nil, err
+	}
+	header, err := spreadsheetHeader(rows, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64, len(languages))
+	languageIDToCode := make(map[uint64]string, len(languages))
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+		languageIDToCode[lang.ID] = lang.Code
+	}
+
+	result := &domain.SpreadsheetUploadResult{
+		Format:            format,
+		DryRun:            params.DryRun,
+		UnmappedLanguages: []string{},
+		Rows:              []domain.SpreadsheetRowPreview{},
+	}
+
+	// 第一列为键名，其余列按表头匹配语言
+	type languageColumn struct {
+		index      int
+		languageID uint64
+	}
+	var columns []languageColumn
+	columnByLanguage := make(map[uint64]string)
+	for i := 1; i < len(header); i++ {
+		code := strings.TrimSpace(header[i])
+		if code == "" {
+			continue
+		}
+		languageID, ok := ResolveLanguageCode(code, languageCodeToID)
+		if !ok {
+			result.UnmappedLanguages = append(result.UnmappedLanguages, code)
+			continue
+		}
+		if previous, exists := columnByLanguage[languageID]; exists {
+			return nil, invalidSpreadsheet(fmt.Sprintf("列 %s 和 %s 对应同一语言", previous, code))
+		}
+		columnByLanguage[languageID] = code
+		columns = append(columns, languageColumn{index: i, languageID: languageID})
+	}
+	if len(columns) == 0 {
+		return nil, invalidSpreadsheet("表头中没有可识别的语言代码，第一列应为键名，其余列为语言代码")
+	}
+
+	// 同一键名以最后一行为准
+	lastRow := make(map[string]int)
+	for i := 1; i < len(rows); i++ {
+		if keyName := strings.TrimSpace(spreadsheetCell(rows[i], 0)); keyName != "" {
+			lastRow[keyName] = i
+		}
+	}
+	keyNames := make([]string, 0, len(lastRow))
+	for keyName := range lastRow {
+		keyNames = append(keyNames, keyName)
+	}
+	existingKeys := make(map[string]bool)
+	found, err := s.translationRepo.FindExistingKeyNames(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+	for _, keyName := range found {
+		existingKeys[keyName] = true
+	}
+	existing := make(map[uint64]map[string]*domain.Translation, len(columns))
+	for _, column := range columns {
+		translations, err := s.translationRepo.GetByProjectAndLanguage(ctx, projectID, column.languageID)
+		if err != nil {
+			return nil, err
+		}
+		existing[column.languageID] = make(map[string]*domain.Translation, len(translations))
+		for _, t := range translations {
+			existing[column.languageID][t.KeyName] = t
+		}
+	}
+
+	var inputs []domain.TranslationInput
+	for i := 1; i < len(rows); i++ {
+		row := rows[i]
+		if isBlankSpreadsheetRow(row) {
+			continue
+		}
+
+		keyName := strings.TrimSpace(spreadsheetCell(row, 0))
+		preview := domain.SpreadsheetRowPreview{Row: i + 1, Key: keyName, Action: domain.SpreadsheetRowSkip}
+		switch {
+		case keyName == "":
+			preview.Reason = "键名为空"
+		case lastRow[keyName] != i:
+			preview.Reason = fmt.Sprintf("与第 %d 行的键名重复，以后出现的行为准", lastRow[keyName]+1)
+		default:
+			hasValue := false
+			for _, column := range columns {
+				value := strings.TrimSpace(spreadsheetCell(row, column.index))
+				if value == "" {
+					continue
+				}
+				hasValue = true
+
+				input := domain.TranslationInput{
+					ProjectID:  projectID,
+					KeyName:    keyName,
+					LanguageID: column.languageID,
+					Value:      value,
+				}
+				if current, ok := existing[column.languageID][keyName]; ok {
+					if current.Value == value {
+						continue
+					}
+					input.Context = current.Context
+				}
+				inputs = append(inputs, input)
+				preview.Languages = append(preview.Languages, languageIDToCode[column.languageID])
+			}
+
+			switch {
+			case len(preview.Languages) > 0 && existingKeys[keyName]:
+				preview.Action = domain.SpreadsheetRowUpdate
+			case len(preview.Languages) > 0:
+				preview.Action = domain.SpreadsheetRowCreate
+			case hasValue:
+				preview.Reason = "译文没有变化"
+			default:
+				preview.Reason = "没有译文"
+			}
+		}
+
+		switch preview.Action {
+		case domain.SpreadsheetRowCreate:
+			result.Created++
+		case domain.SpreadsheetRowUpdate:
+			result.Updated++
+		default:
+			result.Skipped++
+		}
+		if len(result.Rows) < maxSpreadsheetPreviewRows {
+			result.Rows = append(result.Rows, preview)
+		} else {
+			result.Truncated = true
+		}
+	}
+	result.Translations = len(inputs)
+
+	if params.DryRun || len(inputs) == 0 {
+		return result, nil
+	}
+
+	// 导入的翻译与导入完成事件在同一事务中提交
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.UpsertBatch(ctx, inputs); err != nil {
+			return err
+		}
+		return publishEvent(ctx, s.eventBus, domain.EventImportCompleted, projectID, domain.ImportCompletedPayload{
+			Source:       domain.ImportSourceFile,
+			Format:       format,
+			Keys:         result.Created + result.Updated,
+			Translations: result.Translations,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// publishTranslationsUpdated 按项目分组发布翻译变更事件
+func (s *TranslationService) publishTranslationsUpdated(ctx context.Context, action string, translations []*domain.Translation) error {
+	return publishTranslationsUpdated(ctx, s.eventBus, action, translations)
+}
+
+// publishTranslationsUpdated 按项目分组发布翻译变更事件，bus 为 nil 时不发布
+func publishTranslationsUpdated(ctx context.Context, bus domain.EventBus, action string, translations []*domain.Translation) error {
+	if bus == nil || len(translations) == 0 {
+		return nil
+	}
+
+	type projectChanges struct {
+		keys      map[string]bool
+		languages map[uint64]bool
+		count     int
+	}
+	changes := make(map[uint64]*projectChanges)
+	var projectIDs []uint64
+	for _, t := range translations {
+		c, ok := changes[t.ProjectID]
+		if !ok {
+			c = &projectChanges{keys: make(map[string]bool), languages: make(map[uint64]bool)}
+			changes[t.ProjectID] = c
+			projectIDs = append(projectIDs, t.ProjectID)
+		}
+		c.keys[t.KeyName] = true
+		c.languages[t.LanguageID] = true
+		c.count++
+	}
+
+	for _, projectID := range projectIDs {
+		c := changes[projectID]
+		payload := domain.TranslationUpdatedPayload{Action: action, Count: c.count}
+		for key := range c.keys {
+			payload.KeyNames = append(payload.KeyNames, key)
+		}
+		sort.Strings(payload.KeyNames)
+		for languageID := range c.languages {
+			payload.LanguageIDs = append(payload.LanguageIDs, languageID)
+		}
+		sort.Slice(payload.LanguageIDs, func(i, j int) bool { return payload.LanguageIDs[i] < payload.LanguageIDs[j] })
+
+		if err := publishEvent(ctx, bus, domain.EventTranslationUpdated, projectID, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importFromJSON 解析 JSON 导入文件，返回要导入的翻译
+// 值为数组或对象的键按 json 类型导入；已是 json 类型的键的所有值（包括字符串）都序列化为 JSON 文本，与导出的内容对应
+func (s *TranslationService) importFromJSON(ctx context.Context, projectID uint64, data []byte) ([]domain.TranslationInput, error) {
+	var rawData map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rawData); err != nil {
+		return nil, fmt.Errorf("invalid JSON format: %w", err)
+	}
+
+	// 获取所有语言
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建语言代码到ID的映射
+	languageCodeToID := make(map[string]uint64)
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	// 转换为翻译请求
 	var inputs []domain.TranslationInput
 
 	// 检测数据格式并转换
 	matrix := s.normalizeImportData(rawData)
 
+	keyNames := make([]string, 0, len(matrix))
+	for key := range matrix {
+		keyNames = append(keyNames, key)
+	}
+	types, err := s.translationRepo.GetKeyValueTypes(ctx, projectID, keyNames)
+	if err != nil {
+		return nil, err
+	}
+
 	for key, translations := range matrix {
+		valueType := ""
+		if types[key].ValueType == domain.ValueTypeJSON {
+			valueType = domain.ValueTypeJSON
+		}
+		for _, value := range translations {
+			switch value.(type) {
+			case []interface{}, map[string]interface{}:
+				valueType = domain.ValueTypeJSON
+			}
+		}
+
 		for langCode, value := range translations {
-			if langID, exists := languageCodeToID[langCode]; exists {
-				inputs = append(inputs, domain.TranslationInput{
-					ProjectID:  projectID,
-					KeyName:    key,
-					LanguageID: langID,
-					Value:      value,
-				})
+			langID, exists := languageCodeToID[langCode]
+			if !exists {
+				continue
+			}
+			text, isString := value.(string)
+			if valueType == domain.ValueTypeJSON {
+				if text, err = marshalJSONValue(value); err != nil {
+					return nil, err
+				}
+			} else if !isString {
+				continue
+			}
+			inputs = append(inputs, domain.TranslationInput{
+				ProjectID:  projectID,
+				KeyName:    key,
+				LanguageID: langID,
+				Value:      text,
+				ValueType:  valueType,
+			})
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no valid translations found in import data")
+	}
+
+	return inputs, nil
+}
+
+// importFromXLIFF 解析 XLIFF 1.2 或 2.0 文件，返回要导入的目标语言译文
+// 目标语言按文件的 target-language（2.0 为 trgLang）匹配，备注写入上下文；
+// 源文以本系统的默认语言为准，不会导入；没有译文或译文为空的单元跳过
+func (s *TranslationService) importFromXLIFF(ctx context.Context, projectID uint64, data []byte) ([]domain.TranslationInput, error) {
+	files, err := ParseXLIFF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64)
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	var unmapped []string
+	keys := make(map[string]bool)
+	// 同一键和语言出现多次时以最后一次为准
+	inputsByCell := make(map[string]int)
+	var inputs []domain.TranslationInput
+	for _, file := range files {
+		languageID, ok := ResolveLanguageCode(file.TargetLanguage, languageCodeToID)
+		if !ok {
+			unmapped = append(unmapped, file.TargetLanguage)
+			continue
+		}
+		for _, unit := range file.Units {
+			keyName := strings.TrimSpace(unit.Key)
+			if keyName == "" || !unit.HasTarget || unit.Target == "" {
+				continue
+			}
+			input := domain.TranslationInput{
+				ProjectID:  projectID,
+				KeyName:    keyName,
+				LanguageID: languageID,
+				Context:    truncateRunes(unit.Note, 500),
+				Value:      unit.Target,
+			}
+			cell := fmt.Sprintf("%s:%d", keyName, languageID)
+			if idx, exists := inputsByCell[cell]; exists {
+				inputs[idx] = input
+				continue
+			}
+			inputsByCell[cell] = len(inputs)
+			inputs = append(inputs, input)
+			keys[keyName] = true
+		}
+	}
+
+	if len(inputs) == 0 {
+		if len(unmapped) > 0 {
+			return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrXLIFFNoTranslations.Code,
+				domain.ErrXLIFFNoTranslations.Message, "目标语言不存在："+strings.Join(unmapped, "、"))
+		}
+		return nil, domain.ErrXLIFFNoTranslations
+	}
+
+	return inputs, nil
+}
+
+// importFromYAML 解析 Rails 或 Symfony 风格的 YAML 文件，返回要导入的翻译
+// 嵌套的键以 . 连接为平铺的键名，空值跳过
+func (s *TranslationService) importFromYAML(ctx context.Context, projectID uint64, data []byte, language string) ([]domain.TranslationInput, error) {
+	localeValues, err := ParseLocaleYAML(data, language)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64)
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+
+	locales := make([]string, 0, len(localeValues))
+	for locale := range localeValues {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	var unmapped []string
+	keys := make(map[string]bool)
+	// 不同写法的语言代码（如 zh-CN 和 zh_CN）指向同一语言时以后出现的为准
+	inputsByCell := make(map[string]int)
+	var inputs []domain.TranslationInput
+	for _, locale := range locales {
+		languageID, ok := ResolveLanguageCode(locale, languageCodeToID)
+		if !ok {
+			unmapped = append(unmapped, locale)
+			continue
+		}
+		for key, value := range localeValues[locale] {
+			keyName := strings.TrimSpace(key)
+			if keyName == "" || value == "" {
+				continue
+			}
+			input := domain.TranslationInput{
+				ProjectID:  projectID,
+				KeyName:    keyName,
+				LanguageID: languageID,
+				Value:      value,
 			}
+			cell := fmt.Sprintf("%s:%d", keyName, languageID)
+			if idx, exists := inputsByCell[cell]; exists {
+				inputs[idx] = input
+				continue
+			}
+			inputsByCell[cell] = len(inputs)
+			inputs = append(inputs, input)
+			keys[keyName] = true
+		}
+	}
+
+	if len(inputs) == 0 {
+		if len(unmapped) > 0 {
+			return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrYAMLNoTranslations.Code,
+				domain.ErrYAMLNoTranslations.Message, "语言不存在："+strings.Join(unmapped, "、"))
 		}
+		return nil, domain.ErrYAMLNoTranslations
+	}
+
+	return inputs, nil
+}
+
+// importFromARB 解析 Flutter ARB 文件，返回要导入的一种语言的翻译
+// @key 元数据块的 description 写入上下文，空值跳过
+func (s *TranslationService) importFromARB(ctx context.Context, projectID uint64, data []byte, language string) ([]domain.TranslationInput, error) {
+	locale, values, contexts, err := ParseARB(data, language)
+	if err != nil {
+		return nil, err
+	}
+
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64)
+	for _, lang := range languages {
+		languageCodeToID[lang.Code] = lang.ID
+	}
+	languageID, ok := ResolveLanguageCode(locale, languageCodeToID)
+	if !ok {
+		return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation, domain.ErrARBNoTranslations.Code,
+			domain.ErrARBNoTranslations.Message, "语言不存在："+locale)
 	}
 
+	inputs := make([]domain.TranslationInput, 0, len(values))
+	for key, value := range values {
+		keyName := strings.TrimSpace(key)
+		if keyName == "" || value == "" {
+			continue
+		}
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  projectID,
+			KeyName:    keyName,
+			Context:    truncateRunes(contexts[key], 500),
+			LanguageID: languageID,
+			Value:      value,
+		})
+	}
 	if len(inputs) == 0 {
-		return fmt.Errorf("no valid translations found in import data")
+		return nil, domain.ErrARBNoTranslations
 	}
 
-	return s.CreateBatch(ctx, inputs)
+	return inputs, nil
 }
 
-// normalizeImportData 标准化导入数据格式
+// normalizeImportData 标准化导入数据格式，返回 key -> language -> 值（字符串或 JSON 值），null 值忽略
 // 支持两种格式：
 // 1. key -> {language: value} (标准格式)
 // 2. language -> {key: value} (前端格式)
-func (s *TranslationService) normalizeImportData(rawData map[string]interface{}) map[string]map[string]string {
-	matrix := make(map[string]map[string]string)
+func (s *TranslationService) normalizeImportData(rawData map[string]interface{}) map[string]map[string]interface{} {
+	matrix := make(map[string]map[string]interface{})
 
 	// 检测数据格式
 	if s.isLanguageToKeyFormat(rawData) {
 		// 前端格式: language -> {key: value}
 		for langCode, keysInterface := range rawData {
 			if keys, ok := keysInterface.(map[string]interface{}); ok {
-				for key, valueInterface := range keys {
-					if value, ok := valueInterface.(string); ok {
-						if matrix[key] == nil {
-							matrix[key] = make(map[string]string)
-						}
-						matrix[key][langCode] = value
+				for key, value := range keys {
+					if value == nil {
+						continue
+					}
+					if matrix[key] == nil {
+						matrix[key] = make(map[string]interface{})
 					}
+					matrix[key][langCode] = value
 				}
 			}
 		}
@@ -519,9 +2723,9 @@ func (s *TranslationService) normalizeImportData(rawData map[string]interface{})
 		// 标准格式: key -> {language: value}
 		for key, languagesInterface := range rawData {
 			if languages, ok := languagesInterface.(map[string]interface{}); ok {
-				matrix[key] = make(map[string]string)
-				for langCode, valueInterface := range languages {
-					if value, ok := valueInterface.(string); ok {
+				matrix[key] = make(map[string]interface{})
+				for langCode, value := range languages {
+					if value != nil {
 						matrix[key][langCode] = value
 					}
 				}
@@ -2,8 +2,9 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"yflow/internal/domain"
 	"strconv"
 	"time"
@@ -107,9 +108,16 @@ type TranslationCacheResult struct {
 }
 
 // GetByProjectID 根据项目ID获取翻译（使用缓存）
-func (s *CachedTranslationService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int) ([]*domain.Translation, int64, error) {
-	// 生成缓存键
-	cacheKey := fmt.Sprintf("%s:%d:%d", s.cacheService.GetTranslationKey(projectID), limit, offset)
+func (s *CachedTranslationService) GetByProjectID(ctx context.Context, projectID uint64, limit, offset int, reviewStatus string, sort []domain.SortField) ([]*domain.Translation, int64, error) {
+	// 生成缓存键，按审核状态筛选和指定排序时区分
+	parts := []string{strconv.Itoa(limit), strconv.Itoa(offset)}
+	if reviewStatus != "" {
+		parts = append(parts, reviewStatus)
+	}
+	for _, field := range sort {
+		parts = append(parts, field.String())
+	}
+	cacheKey := domain.CacheKeys.Project(projectID).Translations(parts...)
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.mutexManager.GetMutex(cacheKey)
@@ -127,7 +135,7 @@ func (s *CachedTranslationService) GetByProjectID(ctx context.Context, projectID
 	}
 
 	// 缓存未命中，从数据库获取
-	translations, total, err := s.translationService.GetByProjectID(ctx, projectID, limit, offset)
+	translations, total, err := s.translationService.GetByProjectID(ctx, projectID, limit, offset, reviewStatus, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -146,6 +154,46 @@ func (s *CachedTranslationService) GetByProjectID(ctx context.Context, projectID
 	return translations, total, nil
 }
 
+// GetPageByProjectID 按 ID 顺序游标分页获取项目的翻译
+func (s *CachedTranslationService) GetPageByProjectID(ctx context.Context, query domain.TranslationPageQuery) (*domain.TranslationPage, error) {
+	// 游标分页用于遍历大量翻译，每页只会读取一次，不缓存
+	return s.translationService.GetPageByProjectID(ctx, query)
+}
+
+// GetProjectStats 获取项目各语言的翻译完成情况（使用缓存）
+func (s *CachedTranslationService) GetProjectStats(ctx context.Context, projectID uint64) (*domain.ProjectStats, error) {
+	cacheKey := domain.CacheKeys.Project(projectID).Stats()
+
+	// 使用互斥锁防止缓存击穿
+	mutex := s.mutexManager.GetMutex(cacheKey)
+	mutex.Lock()
+	defer func() {
+		mutex.Unlock()
+		s.mutexManager.RemoveMutex(cacheKey) // 请求完成后移除锁
+	}()
+
+	// 尝试从缓存获取
+	var stats *domain.ProjectStats
+	err := s.cacheService.GetJSONWithEmptyCheck(ctx, cacheKey, &stats)
+	if err == nil {
+		return stats, nil
+	}
+
+	// 缓存未命中，从数据库获取
+	stats, err = s.translationService.GetProjectStats(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 更新缓存，添加随机过期时间防止雪崩
+	expiration := s.cacheService.AddRandomExpiration(domain.DefaultExpiration)
+	if err := s.cacheService.SetJSONWithEmptyCache(ctx, cacheKey, stats, expiration); err != nil {
+		// 缓存更新失败，但不影响返回结果
+	}
+
+	return stats, nil
+}
+
 // MatrixCacheResult 定义缓存结果结构体
 type MatrixCacheResult struct {
 	Matrix map[string]map[string]domain.TranslationCell `json:"matrix"`
@@ -155,13 +203,14 @@ type MatrixCacheResult struct {
 // GetMatrix 获取翻译矩阵（使用缓存）
 func (s *CachedTranslationService) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
 	// 优化缓存键生成，区分搜索和非搜索查询
+	keys := domain.CacheKeys.Project(projectID)
 	var cacheKey string
 	if keyword != "" {
 		// 搜索查询使用较短的缓存时间
-		cacheKey = fmt.Sprintf("%s:search:%s:%d:%d", s.cacheService.GetTranslationMatrixKey(projectID, ""), s.hashKeyword(keyword), limit, offset)
+		cacheKey = keys.Matrix("search", s.hashKeyword(keyword), strconv.Itoa(limit), strconv.Itoa(offset))
 	} else {
 		// 非搜索查询使用较长的缓存时间
-		cacheKey = fmt.Sprintf("%s:all:%d:%d", s.cacheService.GetTranslationMatrixKey(projectID, ""), limit, offset)
+		cacheKey = keys.Matrix("all", strconv.Itoa(limit), strconv.Itoa(offset))
 	}
 
 	// 使用互斥锁防止缓存击穿
@@ -208,6 +257,47 @@ func (s *CachedTranslationService) GetMatrix(ctx context.Context, projectID uint
 	return matrix, total, nil
 }
 
+// GetMatrixPage 按指定排序规则分页获取翻译矩阵（使用缓存）
+func (s *CachedTranslationService) GetMatrixPage(ctx context.Context, query domain.TranslationMatrixQuery) (*domain.TranslationMatrixPage, error) {
+	// 排序和搜索参数都参与缓存键，搜索查询使用较短的缓存时间
+	params, _ := json.Marshal(query)
+	sum := sha256.Sum256(params)
+	cacheKey := domain.CacheKeys.Project(query.ProjectID).Matrix("page", hex.EncodeToString(sum[:16]))
+
+	// 使用互斥锁防止缓存击穿
+	mutex := s.mutexManager.GetMutex(cacheKey)
+	mutex.Lock()
+	defer func() {
+		mutex.Unlock()
+		s.mutexManager.RemoveMutex(cacheKey)
+	}()
+
+	var cached domain.TranslationMatrixPage
+	if err := s.cacheService.GetJSONWithEmptyCheck(ctx, cacheKey, &cached); err == nil {
+		return &cached, nil
+	}
+
+	page, err := s.translationService.GetMatrixPage(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration := s.cacheService.AddRandomExpiration(domain.DefaultExpiration)
+	if query.Keyword != "" {
+		expiration = s.cacheService.AddRandomExpiration(5 * time.Minute)
+	}
+	if err := s.cacheService.SetJSONWithEmptyCache(ctx, cacheKey, page, expiration); err != nil {
+		// 缓存更新失败，但不影响返回结果
+	}
+
+	return page, nil
+}
+
+// GetKeyPage 按键名分页获取翻译（不缓存，CLI 拉取由 HTTP 响应缓存处理）
+func (s *CachedTranslationService) GetKeyPage(ctx context.Context, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	return s.translationService.GetKeyPage(ctx, query)
+}
+
 // Update 更新翻译（更新缓存）
 func (s *CachedTranslationService) Update(ctx context.Context, id uint64, input domain.TranslationInput, userID uint64) (*domain.Translation, error) {
 	// 先获取原始翻译，用于后续清除缓存
@@ -270,80 +360,143 @@ func (s *CachedTranslationService) DeleteBatch(ctx context.Context, ids []uint64
 		s.invalidateProjectCache(ctx, projectID)
 	}
 
-	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
-
 	return nil
 }
 
-// Export 导出翻译
-func (s *CachedTranslationService) Export(ctx context.Context, projectID uint64, format string) ([]byte, error) {
-	// 使用缓存的矩阵数据
-	matrix, _, err := s.GetMatrix(ctx, projectID, -1, 0, "")
+// SetValueType 修改键的值类型（更新缓存）
+func (s *CachedTranslationService) SetValueType(ctx context.Context, projectID uint64, params domain.SetValueTypeParams, userID uint64) (*domain.SetValueTypeResult, error) {
+	result, err := s.translationService.SetValueType(ctx, projectID, params, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// 转换为简单格式 (key -> language -> value)
-	simpleMatrix := make(map[string]map[string]string)
-	for key, langs := range matrix {
-		simpleMatrix[key] = make(map[string]string)
-		for lang, cell := range langs {
-			simpleMatrix[key][lang] = cell.Value
-		}
+	// 清除相关缓存
+	s.invalidateProjectCache(ctx, projectID)
+
+	return result, nil
+}
+
+// SetKeyMetadata 修改键的元数据（更新缓存）
+func (s *CachedTranslationService) SetKeyMetadata(ctx context.Context, projectID uint64, params domain.SetKeyMetadataParams, userID uint64) (*domain.SetKeyMetadataResult, error) {
+	result, err := s.translationService.SetKeyMetadata(ctx, projectID, params, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	switch format {
-	case "json":
-		return json.MarshalIndent(simpleMatrix, "", "  ")
-	default:
-		return nil, fmt.Errorf("unsupported format: %s", format)
+	// 清除相关缓存
+	s.invalidateProjectCache(ctx, projectID)
+
+	return result, nil
+}
+
+// SubmitForReview 提交翻译审核（更新缓存）
+func (s *CachedTranslationService) SubmitForReview(ctx context.Context, projectID uint64, params domain.ReviewTransitionParams, userID uint64) (*domain.ReviewTransitionResult, error) {
+	result, err := s.translationService.SubmitForReview(ctx, projectID, params, userID)
+	if err != nil {
+		return nil, err
 	}
+
+	// 清除相关缓存
+	s.invalidateProjectCache(ctx, projectID)
+
+	return result, nil
 }
 
-// Import 导入翻译（更新缓存）
-func (s *CachedTranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string) error {
-	err := s.translationService.Import(ctx, projectID, data, format)
+// ApproveTranslations 审核通过翻译（更新缓存）
+func (s *CachedTranslationService) ApproveTranslations(ctx context.Context, projectID uint64, params domain.ReviewTransitionParams, userID uint64) (*domain.ReviewTransitionResult, error) {
+	result, err := s.translationService.ApproveTranslations(ctx, projectID, params, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 清除相关缓存
 	s.invalidateProjectCache(ctx, projectID)
 
-	return nil
+	return result, nil
 }
 
-// invalidateProjectCache 清除项目相关的所有缓存
-func (s *CachedTranslationService) invalidateProjectCache(ctx context.Context, projectID uint64) {
-	// 使用管道操作提高性能
-	// 清除翻译列表缓存
-	s.cacheService.DeleteByPattern(ctx, s.cacheService.GetTranslationKey(projectID)+"*")
+// RejectTranslations 驳回翻译（更新缓存）
+func (s *CachedTranslationService) RejectTranslations(ctx context.Context, projectID uint64, params domain.ReviewTransitionParams, userID uint64) (*domain.ReviewTransitionResult, error) {
+	result, err := s.translationService.RejectTranslations(ctx, projectID, params, userID)
+	if err != nil {
+		return nil, err
+	}
 
-	// 清除翻译矩阵缓存
-	s.cacheService.DeleteByPattern(ctx, s.cacheService.GetTranslationMatrixKey(projectID, "")+"*")
+	// 清除相关缓存
+	s.invalidateProjectCache(ctx, projectID)
+
+	return result, nil
+}
+
+// GetExportValues 按导出选项获取项目翻译（不缓存，导出始终读取最新数据）
+func (s *CachedTranslationService) GetExportValues(ctx context.Context, projectID uint64, options domain.ExportOptions) (map[string]map[string]string, error) {
+	return s.translationService.GetExportValues(ctx, projectID, options)
+}
+
+// Export 导出翻译（不缓存）
+func (s *CachedTranslationService) Export(ctx context.Context, projectID uint64, format string, options domain.ExportOptions) ([]byte, error) {
+	return s.translationService.Export(ctx, projectID, format, options)
+}
 
-	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+// ExportFiles 按语言拆分导出翻译文件（不缓存）
+func (s *CachedTranslationService) ExportFiles(ctx context.Context, projectID uint64, format string, options domain.ExportOptions) ([]*domain.ExportFile, error) {
+	return s.translationService.ExportFiles(ctx, projectID, format, options)
 }
 
-// invalidateLanguageCache 清除语言相关的缓存（当语言被修改时调用）
-func (s *CachedTranslationService) invalidateLanguageCache(ctx context.Context) {
-	// 清除所有项目的翻译矩阵缓存，因为语言变更可能影响所有项目
-	s.cacheService.DeleteByPattern(ctx, domain.TranslationMatrixPrefix+"*")
+// Import 导入翻译（写入时更新缓存）
+func (s *CachedTranslationService) Import(ctx context.Context, projectID uint64, data []byte, format string, options domain.ImportOptions) (*domain.ImportResult, error) {
+	result, err := s.translationService.Import(ctx, projectID, data, format, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除相关缓存
+	if result.Translations > 0 {
+		s.invalidateProjectCache(ctx, projectID)
+	}
+
+	return result, nil
+}
+
+// ImportSpreadsheet 导入表格（写入时更新缓存）
+func (s *CachedTranslationService) ImportSpreadsheet(ctx context.Context, projectID uint64, params domain.SpreadsheetUploadParams) (*domain.SpreadsheetUploadResult, error) {
+	result, err := s.translationService.ImportSpreadsheet(ctx, projectID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.DryRun && result.Translations > 0 {
+		s.invalidateProjectCache(ctx, projectID)
+	}
 
-	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	return result, nil
 }
 
-// invalidateSpecificTranslationCache 清除特定翻译键的缓存
-func (s *CachedTranslationService) invalidateSpecificTranslationCache(ctx context.Context, projectID uint64, keyName string) {
-	// 清除包含特定键名的翻译矩阵缓存
-	pattern := fmt.Sprintf("%s%d:*%s*", domain.TranslationMatrixPrefix, projectID, keyName)
-	s.cacheService.DeleteByPattern(ctx, pattern)
+// ImportMigration 从外部 TMS 迁移翻译（清除缓存）
+func (s *CachedTranslationService) ImportMigration(ctx context.Context, projectID uint64, params domain.MigrationImportParams) (*domain.MigrationImportResult, error) {
+	result, err := s.translationService.ImportMigration(ctx, projectID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除相关缓存
+	s.invalidateProjectCache(ctx, projectID)
+
+	return result, nil
+}
+
+// invalidateProjectCache 清除项目的翻译缓存
+// HTTP 响应缓存和仪表板缓存由 translation.updated 事件的订阅方清除
+func (s *CachedTranslationService) invalidateProjectCache(ctx context.Context, projectID uint64) {
+	// 使用管道操作提高性能
+	// 清除翻译列表缓存
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(projectID).TranslationsPattern())
+
+	// 清除翻译矩阵缓存
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(projectID).MatrixPattern())
 
-	// 清除仪表板缓存
-	s.cacheService.Delete(ctx, s.cacheService.GetDashboardStatsKey())
+	// 清除各语言翻译完成情况的统计缓存
+	s.cacheService.DeleteByPattern(ctx, domain.CacheKeys.Project(projectID).StatsPattern())
 }
 
 // hashKeyword 对关键词进行简单哈希，避免缓存键过长
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// trashPurgeBatchSize 每次永久删除的翻译条数
+	trashPurgeBatchSize = 1000
+	// trashPurgeTimeout 定期清除的超时时间
+	trashPurgeTimeout = 10 * time.Minute
+)
+
+// TranslationTrashService 翻译回收站服务实现
+// 删除的翻译保留 retention 后永久删除；interval 大于 0 时随应用启动定期清除
+type TranslationTrashService struct {
+	translationRepo domain.TranslationRepository
+	projectRepo     domain.ProjectRepository
+	eventBus        domain.EventBus
+	transactor      domain.Transactor
+	retention       time.Duration
+	interval        time.Duration
+	logger          *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTranslationTrashService 创建翻译回收站服务实例
+// retention 为 0 时不清除已删除的翻译，interval 为 0 时不定期清除
+func NewTranslationTrashService(
+	translationRepo domain.TranslationRepository,
+	projectRepo domain.ProjectRepository,
+	eventBus domain.EventBus,
+	transactor domain.Transactor,
+	retention time.Duration,
+	interval time.Duration,
+	logger *zap.Logger,
+) *TranslationTrashService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &TranslationTrashService{
+		translationRepo: translationRepo,
+		projectRepo:     projectRepo,
+		eventBus:        eventBus,
+		transactor:      transactor,
+		retention:       retention,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+// GetTrash 分页获取项目中已删除的翻译，最近删除的在前
+func (s *TranslationTrashService) GetTrash(ctx context.Context, projectID uint64, limit, offset int) (*domain.TranslationTrash, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	translations, total, err := s.translationRepo.GetDeleted(ctx, projectID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TranslationTrash{
+		Translations: translations,
+		Total:        total,
+		Retention:    s.retention,
+	}, nil
+}
+
+// Restore 恢复已删除的翻译，翻译值和状态保持删除时的内容
+// 该键在其他语言还有翻译时，值类型、最大长度、标签、平台和命名空间使用其他语言的设置
+func (s *TranslationTrashService) Restore(ctx context.Context, translationID, userID uint64) (*domain.Translation, error) {
+	translation, err := s.translationRepo.GetDeletedByID(ctx, translationID)
+	if err != nil {
+		return nil, err
+	}
+	siblings, err := s.translationRepo.GetByProjectKey(ctx, translation.ProjectID, translation.KeyName)
+	if err != nil {
+		return nil, err
+	}
+	if len(siblings) > 0 {
+		sibling := siblings[0]
+		translation.ValueType = sibling.ValueType
+		translation.ValueSchema = sibling.ValueSchema
+		translation.MaxLength = sibling.MaxLength
+		translation.Tags = sibling.Tags
+		translation.Platform = sibling.Platform
+		translation.NamespaceID = sibling.NamespaceID
+	}
+
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.translationRepo.Restore(ctx, translation, userID); err != nil {
+			return err
+		}
+		return publishTranslationsUpdated(ctx, s.eventBus, domain.TranslationActionRestored, []*domain.Translation{translation})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
+
+// Purge 永久删除超过保留期限的已删除翻译，分批删除，返回删除的条数
+func (s *TranslationTrashService) Purge(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	before := time.Now().Add(-s.retention)
+	var total int64
+	for {
+		purged, err := s.translationRepo.PurgeDeleted(ctx, before, trashPurgeBatchSize)
+		total += purged
+		if err != nil {
+			return total, err
+		}
+		if purged < trashPurgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// Start 启动定期清除
+func (s *TranslationTrashService) Start(ctx context.Context) error {
+	if s.interval <= 0 || s.retention <= 0 {
+		return nil
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期清除并等待当前清除完成
+func (s *TranslationTrashService) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 清除循环，启动后立即清除一次
+func (s *TranslationTrashService) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		purgeCtx, cancel := context.WithTimeout(ctx, trashPurgeTimeout)
+		purged, err := s.Purge(purgeCtx)
+		cancel()
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("Scheduled trash purge failed", zap.Int64("purged", purged), zap.Error(err))
+		} else if purged > 0 {
+			s.logger.Info("Scheduled trash purge completed", zap.Int64("purged", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// digestKeysPerLanguage 摘要中每个语言列出的待翻译键数量
+	digestKeysPerLanguage = 10
+	// digestProjectPageSize 分页读取项目的每页数量
+	digestProjectPageSize = 100
+)
+
+// UntranslatedDigest 待翻译键摘要邮件
+// 定期统计每个活跃项目在各启用语言中待翻译的键，向项目的所有者和编辑者各发送一封摘要，没有待翻译键的项目不发送
+type UntranslatedDigest struct {
+	projectRepo     domain.ProjectRepository
+	memberRepo      domain.ProjectMemberRepository
+	userRepo        domain.UserRepository
+	languageRepo    domain.LanguageRepository
+	translationRepo domain.TranslationRepository
+	notifier        domain.EmailNotifier
+	frontendURL     string
+	interval        time.Duration
+	logger          *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewUntranslatedDigest 创建待翻译键摘要，interval 为 0 时不定期发送
+func NewUntranslatedDigest(
+	projectRepo domain.ProjectRepository,
+	memberRepo domain.ProjectMemberRepository,
+	userRepo domain.UserRepository,
+	languageRepo domain.LanguageRepository,
+	translationRepo domain.TranslationRepository,
+	notifier domain.EmailNotifier,
+	frontendURL string,
+	interval time.Duration,
+	logger *zap.Logger,
+) *UntranslatedDigest {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &UntranslatedDigest{
+		projectRepo:     projectRepo,
+		memberRepo:      memberRepo,
+		userRepo:        userRepo,
+		languageRepo:    languageRepo,
+		translationRepo: translationRepo,
+		notifier:        notifier,
+		frontendURL:     frontendURL,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+// Start 启动定期发送，未配置邮件服务时不启动
+func (d *UntranslatedDigest) Start(ctx context.Context) error {
+	if d.interval <= 0 || !d.notifier.Enabled() {
+		return nil
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期发送并等待当前发送完成
+func (d *UntranslatedDigest) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 发送循环，第一次在启动一个间隔后发送，避免每次重启都发送摘要
+func (d *UntranslatedDigest) run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		sent, err := d.Send(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.Error("Untranslated digest failed", zap.Error(err))
+			continue
+		}
+		d.logger.Info("Untranslated digest sent", zap.Int("emails", sent))
+	}
+}
+
+// Send 向所有活跃项目的所有者和编辑者发送摘要，返回发送成功的邮件数
+// 单封邮件发送失败时记录日志并继续发送其他邮件
+func (d *UntranslatedDigest) Send(ctx context.Context) (int, error) {
+	if !d.notifier.Enabled() {
+		return 0, domain.ErrMailNotConfigured
+	}
+
+	// 各组织的语言不同，按项目所属的组织分别获取
+	activeByOrganization := make(map[uint64][]*domain.Language)
+	sent := 0
+	for offset := 0; ; offset += digestProjectPageSize {
+		projects, total, err := d.projectRepo.GetAll(ctx, digestProjectPageSize, offset, "", nil)
+		if err != nil {
+			return sent, err
+		}
+		for _, project := range projects {
+			if project.Status != "active" {
+				continue
+			}
+			active, ok := activeByOrganization[project.OrganizationID]
+			if !ok {
+				active, err = d.activeLanguages(domain.WithOrganization(ctx, project.OrganizationID))
+				if err != nil {
+					return sent, err
+				}
+				activeByOrganization[project.OrganizationID] = active
+			}
+			n, err := d.sendProject(ctx, project, active)
+			sent += n
+			if err != nil {
+				return sent, err
+			}
+		}
+		if len(projects) == 0 || int64(offset+len(projects)) >= total {
+			return sent, nil
+		}
+	}
+}
+
+// activeLanguages 获取当前组织启用的语言，按代码排序
+func (d *UntranslatedDigest) activeLanguages(ctx context.Context) ([]*domain.Language, error) {
+	languages, err := d.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]*domain.Language, 0, len(languages))
+	for _, language := range languages {
+		if language.Status == "active" {
+			active = append(active, language)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Code < active[j].Code })
+	return active, nil
+}
+
+// sendProject 统计单个项目的待翻译键并发送摘要
+func (d *UntranslatedDigest) sendProject(ctx context.Context, project *domain.Project, languages []*domain.Language) (int, error) {
+	digest := domain.UntranslatedDigestEmailData{
+		ProjectName: project.Name,
+		ProjectURL:  d.frontendURLOrDefault() + "/translations",
+	}
+	for _, language := range languages {
+		pending, keys, err := d.translationRepo.GetPendingKeys(ctx, domain.PendingKeyQuery{
+			ProjectID:  project.ID,
+			LanguageID: language.ID,
+			Limit:      digestKeysPerLanguage,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if pending == 0 {
+			continue
+		}
+		digest.Total += int(pending)
+		digest.Languages = append(digest.Languages, domain.UntranslatedLanguageDigest{
+			Code:    language.Code,
+			Name:    language.Name,
+			Pending: int(pending),
+			Keys:    keys,
+		})
+	}
+	if digest.Total == 0 {
+		return 0, nil
+	}
+
+	members, err := d.memberRepo.GetByProjectID(ctx, project.ID)
+	if err != nil {
+		return 0, err
+	}
+	userIDs := make([]uint64, 0, len(members))
+	for _, member := range members {
+		if member.Role == "owner" || member.Role == "editor" {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	users, err := d.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, user := range users {
+		if user.Email == "" || user.Status != "active" {
+			continue
+		}
+		digest.Username = user.Username
+		if err := d.notifier.Notify(ctx, user.Email, domain.EmailEventUntranslatedDigest, digest); err != nil {
+			d.logger.Warn("Failed to send untranslated digest",
+				zap.Uint64("project_id", project.ID),
+				zap.Uint64("user_id", user.ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// frontendURLOrDefault 摘要中项目链接使用的前端地址
+func (d *UntranslatedDigest) frontendURLOrDefault() string {
+	if d.frontendURL == "" {
+		return "http://localhost:3000"
+	}
+	return d.frontendURL
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+const (
+	// usageFlushTimeout 单次写入用量计数的超时时间
+	usageFlushTimeout = 30 * time.Second
+	// usageLookbackDays 每次写入检查的最近天数，服务停止期间 Redis 中累积的计数在此期间内仍会写入
+	usageLookbackDays = 7
+	// usageCounterTTL Redis 中计数哈希表的过期时间，超过回看期后自动清除
+	usageCounterTTL = (usageLookbackDays + 1) * 24 * time.Hour
+	// defaultUsageReportDays 用量报告默认的统计天数
+	defaultUsageReportDays = 30
+	// maxUsageReportDays 用量报告最多统计的天数
+	maxUsageReportDays = 365
+	// usageFieldSeparator 计数哈希表字段中项目、渠道、语言和调用方的分隔符
+	usageFieldSeparator = "|"
+)
+
+// UsageService 翻译拉取用量统计服务
+// 每次拉取在 Redis 中按 UTC 日期、项目、渠道、语言和调用方累加计数，多个实例共享；
+// 定期将计数累加到数据库的日汇总表。写入时先从 Redis 扣减领取计数再写入数据库，写入失败时退回，
+// 多个实例同时写入也不会重复计数。
+type UsageService struct {
+	cache        domain.CacheService
+	usageRepo    domain.UsageStatRepository
+	projectRepo  domain.ProjectRepository
+	languageRepo domain.LanguageRepository
+	interval     time.Duration
+	logger       *zap.Logger
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewUsageService 创建翻译拉取用量统计服务
+// cache 为空时不记录用量；interval 为计数写入数据库的间隔
+func NewUsageService(
+	cache domain.CacheService,
+	usageRepo domain.UsageStatRepository,
+	projectRepo domain.ProjectRepository,
+	languageRepo domain.LanguageRepository,
+	interval time.Duration,
+	logger *zap.Logger,
+) *UsageService {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &UsageService{
+		cache:        cache,
+		usageRepo:    usageRepo,
+		projectRepo:  projectRepo,
+		languageRepo: languageRepo,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+// usageDay 返回 t 所在的 UTC 日期
+// 以本地时区的零点表示，数据库连接使用本地时区，写入 date 列时不会跨日
+func usageDay(t time.Time) time.Time {
+	utc := t.UTC()
+	return time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// usageField 计数哈希表的字段名，语言和调用方中的分隔符会被移除
+func usageField(record domain.UsageRecord) string {
+	locale := truncateRunes(strings.ReplaceAll(strings.TrimSpace(record.Locale), usageFieldSeparator, ""), 20)
+	consumer := truncateRunes(strings.ReplaceAll(record.Consumer, usageFieldSeparator, ""), 100)
+	return strings.Join([]string{strconv.FormatUint(record.ProjectID, 10), record.Channel, locale, consumer}, usageFieldSeparator)
+}
+
+// parseUsageField 解析计数哈希表的字段名
+func parseUsageField(field string, day time.Time) (*domain.UsageStat, bool) {
+	parts := strings.SplitN(field, usageFieldSeparator, 4)
+	if len(parts) != 4 {
+		return nil, false
+	}
+	projectID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || projectID == 0 {
+		return nil, false
+	}
+	return &domain.UsageStat{
+		ProjectID: projectID,
+		Date:      day,
+		Channel:   parts[1],
+		Locale:    parts[2],
+		Consumer:  parts[3],
+	}, true
+}
+
+// Record 记录一次翻译拉取，Redis 不可用时只记录日志
+func (s *UsageService) Record(ctx context.Context, record domain.UsageRecord) {
+	if s.cache == nil || record.ProjectID == 0 {
+		return
+	}
+	key := domain.CacheKeys.UsageCounters(usageDay(time.Now()).Format("20060102"))
+	if _, err := s.cache.HIncrBy(ctx, key, usageField(record), 1); err != nil {
+		s.logger.Warn("Failed to record usage", zap.Uint64("project_id", record.ProjectID), zap.Error(err))
+	}
+}
+
+// Flush 将 Redis 中最近几天的计数写入数据库
+func (s *UsageService) Flush(ctx context.Context) error {
+	if s.cache == nil {
+		return nil
+	}
+	today := usageDay(time.Now())
+	for i := usageLookbackDays; i >= 0; i-- {
+		if err := s.flushDay(ctx, today.AddDate(0, 0, -i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushDay 写入一天的计数
+// 每个字段先扣减读到的计数，扣减后为负说明其他实例已领取了这部分计数，退回后跳过
+func (s *UsageService) flushDay(ctx context.Context, day time.Time) error {
+	key := domain.CacheKeys.UsageCounters(day.Format("20060102"))
+	counters, err := s.cache.HGetAll(ctx, key)
+	if err == domain.ErrCacheMiss {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	claimed := make(map[string]int64)
+	var stats []*domain.UsageStat
+	for field, value := range counters {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || count <= 0 {
+			continue
+		}
+		stat, ok := parseUsageField(field, day)
+		if !ok {
+			continue
+		}
+		remaining, err := s.cache.HIncrBy(ctx, key, field, -count)
+		if err != nil {
+			s.release(ctx, key, claimed)
+			return err
+		}
+		if remaining < 0 {
+			if _, err := s.cache.HIncrBy(ctx, key, field, count); err != nil {
+				s.release(ctx, key, claimed)
+				return err
+			}
+			continue
+		}
+		claimed[field] = count
+		stat.Requests = count
+		stats = append(stats, stat)
+	}
+
+	if err := s.usageRepo.Increment(ctx, stats); err != nil {
+		s.release(ctx, key, claimed)
+		return err
+	}
+	return s.cache.Expire(ctx, key, usageCounterTTL)
+}
+
+// release 将已领取但未写入数据库的计数退回 Redis
+func (s *UsageService) release(ctx context.Context, key string, claimed map[string]int64) {
+	for field, count := range claimed {
+		if _, err := s.cache.HIncrBy(ctx, key, field, count); err != nil {
+			s.logger.Error("Failed to release usage counter", zap.String("field", field), zap.Int64("count", count), zap.Error(err))
+		}
+	}
+}
+
+// Start 启动定期写入
+func (s *UsageService) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止定期写入，并写入尚未保存的计数
+func (s *UsageService) Stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return s.Flush(ctx)
+}
+
+// run 写入循环
+func (s *UsageService) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flushCtx, cancel := context.WithTimeout(ctx, usageFlushTimeout)
+			if err := s.Flush(flushCtx); err != nil && ctx.Err() == nil {
+				s.logger.Error("Failed to flush usage counters", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
+
+// GetReport 获取项目最近 days 天（含今天，按 UTC 日期）的拉取用量，days 为 0 时统计 30 天
+// 报告前先写入 Redis 中的计数；请求的语言代码按启用的语言归并（zh_CN 与 zh-CN 视为同一语言）
+func (s *UsageService) GetReport(ctx context.Context, projectID uint64, days int) (*domain.UsageReport, error) {
+	if days == 0 {
+		days = defaultUsageReportDays
+	}
+	if days < 0 || days > maxUsageReportDays {
+		return nil, domain.ErrInvalidUsageDays
+	}
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+	if err := s.Flush(ctx); err != nil {
+		s.logger.Warn("Failed to flush usage counters before report", zap.Error(err))
+	}
+
+	today := usageDay(time.Now())
+	since := today.AddDate(0, 0, -(days - 1))
+	stats, err := s.usageRepo.GetByProjectSince(ctx, projectID, since)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := s.languageRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	languageCodeToID := make(map[string]uint64, len(languages))
+	languageCodes := make(map[uint64]string, len(languages))
+	for _, language := range languages {
+		languageCodeToID[language.Code] = language.ID
+		languageCodes[language.ID] = language.Code
+	}
+
+	report := &domain.UsageReport{
+		Days:  days,
+		Since: since.Format("2006-01-02"),
+	}
+	locales := make(map[string]*domain.UsageBreakdown)
+	consumers := make(map[string]*domain.UsageBreakdown)
+	channels := make(map[string]*domain.UsageBreakdown)
+	daily := make(map[string]int64)
+	pulled := make(map[uint64]bool)
+	for _, stat := range stats {
+		date := stat.Date.Format("2006-01-02")
+		locale := stat.Locale
+		if languageID, ok := ResolveLanguageCode(locale, languageCodeToID); ok && locale != "" {
+			locale = languageCodes[languageID]
+			pulled[languageID] = true
+		}
+		report.Requests += stat.Requests
+		daily[date] += stat.Requests
+		addUsage(locales, locale, stat.Requests, date)
+		addUsage(consumers, stat.Consumer, stat.Requests, date)
+		addUsage(channels, stat.Channel, stat.Requests, date)
+	}
+
+	report.Locales = sortedUsage(locales)
+	report.Consumers = sortedUsage(consumers)
+	report.Channels = sortedUsage(channels)
+	report.Daily = make([]*domain.UsageDailyPoint, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		report.Daily = append(report.Daily, &domain.UsageDailyPoint{Date: date, Requests: daily[date]})
+	}
+	report.UnusedLocales = []string{}
+	for _, language := range languages {
+		if language.Status == "active" && !pulled[language.ID] {
+			report.UnusedLocales = append(report.UnusedLocales, language.Code)
+		}
+	}
+	sort.Strings(report.UnusedLocales)
+	return report, nil
+}
+
+// addUsage 累加某一维度的拉取次数
+func addUsage(breakdowns map[string]*domain.UsageBreakdown, key string, requests int64, date string) {
+	breakdown, ok := breakdowns[key]
+	if !ok {
+		breakdown = &domain.UsageBreakdown{Key: key}
+		breakdowns[key] = breakdown
+	}
+	breakdown.Requests += requests
+	if date > breakdown.LastPulledAt {
+		breakdown.LastPulledAt = date
+	}
+}
+
+// sortedUsage 按拉取次数从多到少排序，次数相同时按键排序
+func sortedUsage(breakdowns map[string]*domain.UsageBreakdown) []*domain.UsageBreakdown {
+	result := make([]*domain.UsageBreakdown, 0, len(breakdowns))
+	for _, breakdown := range breakdowns {
+		result = append(result, breakdown)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Requests != result[j].Requests {
+			return result[i].Requests > result[j].Requests
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// OffboardUser 用户离职处理：转移项目所有权、改写内容引用、移除成员关系、撤销访问令牌并删除用户
+// 全部在一个事务中完成，并以系统级审计日志（project_id 为 0）记录。
+// 未指定接收人时内容的创建人、修改人等引用置为 0；翻译历史和审计日志中的操作人默认保留，
+// 审计日志记录用户ID与用户名的对应关系，Anonymize 时这些操作人同样置为 0，审计日志不记录用户名和邮箱。
+// 删除用户后其登录令牌和刷新令牌在下一次请求时因用户不存在而失效
+func (s *UserService) OffboardUser(ctx context.Context, id uint64, params domain.OffboardUserParams) (*domain.OffboardUserResult, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 不能删除管理员用户
+	if strings.ToLower(user.Role) == "admin" {
+		return nil, domain.ErrCannotDeleteAdmin
+	}
+
+	// 邀请码的邀请人必须是存在的用户，没有接收人时转给执行操作的管理员
+	operatorID := domain.ActorFromContext(ctx)
+	inviterID := operatorID
+	if params.ReassignTo != 0 {
+		target, err := s.userRepo.GetByID(ctx, params.ReassignTo)
+		if err != nil || target.ID == id || target.Status != "active" {
+			return nil, domain.ErrInvalidReassignTarget
+		}
+		inviterID = target.ID
+	}
+
+	result := &domain.OffboardUserResult{
+		UserID:               id,
+		ReassignedTo:         params.ReassignTo,
+		Anonymized:           params.Anonymize,
+		OwnershipTransferred: []uint64{},
+		ProjectsWithoutOwner: []uint64{},
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.transferOwnership(ctx, id, params.ReassignTo, operatorID, result); err != nil {
+			return err
+		}
+		var err error
+		if result.MembershipsRemoved, err = s.offboardingRepo.RemoveMemberships(ctx, id); err != nil {
+			return err
+		}
+		if result.AccessTokensRevoked, err = s.offboardingRepo.DeleteAccessTokens(ctx, id); err != nil {
+			return err
+		}
+		if result.References, err = s.offboardingRepo.ReassignReferences(ctx, id, params.ReassignTo, inviterID); err != nil {
+			return err
+		}
+		if params.Anonymize {
+			if result.ActivityAnonymized, err = s.offboardingRepo.AnonymizeActivity(ctx, id, user.Email); err != nil {
+				return err
+			}
+		}
+		if err := s.userRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		details := map[string]interface{}{
+			"user_id":                id,
+			"reassigned_to":          params.ReassignTo,
+			"anonymized":             params.Anonymize,
+			"reason":                 params.Reason,
+			"references":             result.References,
+			"memberships_removed":    result.MembershipsRemoved,
+			"ownership_transferred":  result.OwnershipTransferred,
+			"projects_without_owner": result.ProjectsWithoutOwner,
+			"access_tokens_revoked":  result.AccessTokensRevoked,
+			"activity_anonymized":    result.ActivityAnonymized,
+		}
+		if !params.Anonymize {
+			details["username"] = user.Username
+			details["email"] = user.Email
+		}
+		return recordAudit(ctx, s.auditRepo, 0, operatorID, domain.AuditActionUserOffboard, details)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// transferOwnership 处理用户作为 owner 的项目：指定接收人时接收人成为 owner（已有成员关系时升级），
+// 否则记录移除后没有其他 owner 的项目
+func (s *UserService) transferOwnership(ctx context.Context, id, reassignTo, operatorID uint64, result *domain.OffboardUserResult) error {
+	memberships, err := s.memberRepo.GetByUserID(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, membership := range memberships {
+		if membership.Role != "owner" {
+			continue
+		}
+
+		if reassignTo != 0 {
+			existing, err := s.memberRepo.GetByProjectAndUser(ctx, membership.ProjectID, reassignTo)
+			if err != nil && !errors.Is(err, domain.ErrMemberNotFound) {
+				return err
+			}
+			if existing != nil && existing.Role == "owner" {
+				continue
+			}
+			if err := s.memberRepo.CreateOrRestore(ctx, &domain.ProjectMember{
+				ProjectID: membership.ProjectID,
+				UserID:    reassignTo,
+				Role:      "owner",
+				CreatedBy: operatorID,
+				UpdatedBy: operatorID,
+			}); err != nil {
+				return err
+			}
+			result.OwnershipTransferred = append(result.OwnershipTransferred, membership.ProjectID)
+			continue
+		}
+
+		members, err := s.memberRepo.GetByProjectID(ctx, membership.ProjectID)
+		if err != nil {
+			return err
+		}
+		hasOwner := false
+		for _, member := range members {
+			hasOwner = hasOwner || (member.UserID != id && member.Role == "owner")
+		}
+		if !hasOwner {
+			result.ProjectsWithoutOwner = append(result.ProjectsWithoutOwner, membership.ProjectID)
+		}
+	}
+	return nil
+}
+
+// DeactivateUser 停用用户：状态改为 disabled 并撤销全部登录会话，用户不能再登录或刷新令牌，
+// 认证中间件拒绝其个人访问令牌。用户、内容引用和项目成员关系都保留，通过 UpdateUser 将状态改回 active 即可恢复。
+// 以系统级审计日志记录；用户已停用时直接返回
+func (s *UserService) DeactivateUser(ctx context.Context, id uint64, params domain.DeactivateUserParams) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 不能停用管理员用户，避免管理员停用自己或最后一个管理员
+	if strings.ToLower(user.Role) == "admin" {
+		return nil, domain.ErrCannotDeactivateAdmin
+	}
+	if user.Status != "active" {
+		user.Password = ""
+		return user, nil
+	}
+
+	operatorID := domain.ActorFromContext(ctx)
+	user.Status = "disabled"
+	user.UpdatedBy = operatorID
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return recordAudit(ctx, s.auditRepo, 0, operatorID, domain.AuditActionUserDeactivate, map[string]interface{}{
+			"user_id":  id,
+			"username": user.Username,
+			"reason":   params.Reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 已签发的登录令牌在认证时因用户状态被拒绝，撤销会话失败不影响停用
+	if s.sessions != nil {
+		s.sessions.RevokeAll(ctx, id)
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// TransferOwnership 将用户作为 owner 的项目和作为邀请人的全部邀请码转给 ToUserID，用于停用或删除用户之前。
+// 接收人成为这些项目的 owner（已有成员关系时升级），原 owner 降为 editor；
+// 全部在一个事务中完成，有转移时以系统级审计日志记录
+func (s *UserService) TransferOwnership(ctx context.Context, id uint64, params domain.TransferOwnershipParams) (*domain.OwnershipTransferResult, error) {
+	if _, err := s.userRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	target, err := s.userRepo.GetByID(ctx, params.ToUserID)
+	if err != nil || target.ID == id || target.Status != "active" {
+		return nil, domain.ErrInvalidReassignTarget
+	}
+
+	operatorID := domain.ActorFromContext(ctx)
+	result := &domain.OwnershipTransferResult{
+		UserID:        id,
+		TransferredTo: target.ID,
+		Projects:      []uint64{},
+	}
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		memberships, err := s.memberRepo.GetByUserID(ctx, id)
+		if err != nil {
+			return err
+		}
+		for _, membership := range memberships {
+			if membership.Role != "owner" {
+				continue
+			}
+			existing, err := s.memberRepo.GetByProjectAndUser(ctx, membership.ProjectID, target.ID)
+			if err != nil && !errors.Is(err, domain.ErrMemberNotFound) {
+				return err
+			}
+			if existing == nil || existing.Role != "owner" {
+				if err := s.memberRepo.CreateOrRestore(ctx, &domain.ProjectMember{
+					ProjectID: membership.ProjectID,
+					UserID:    target.ID,
+					Role:      "owner",
+					CreatedBy: operatorID,
+					UpdatedBy: operatorID,
+				}); err != nil {
+					return err
+				}
+			}
+			membership.Role = "editor"
+			membership.UpdatedBy = operatorID
+			if err := s.memberRepo.Update(ctx, membership); err != nil {
+				return err
+			}
+			result.Projects = append(result.Projects, membership.ProjectID)
+		}
+
+		if result.InvitationsReassigned, err = s.offboardingRepo.ReassignInvitations(ctx, id, target.ID); err != nil {
+			return err
+		}
+		if len(result.Projects) == 0 && result.InvitationsReassigned == 0 {
+			return nil
+		}
+		return recordAudit(ctx, s.auditRepo, 0, operatorID, domain.AuditActionUserTransfer, map[string]interface{}{
+			"user_id":                id,
+			"transferred_to":         target.ID,
+			"projects":               result.Projects,
+			"invitations_reassigned": result.InvitationsReassigned,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkNoOwnedResources 检查用户不是任何项目唯一的 owner，也没有有效的邀请码，否则返回 ErrUserOwnsResources
+func (s *UserService) checkNoOwnedResources(ctx context.Context, id uint64) error {
+	memberships, err := s.memberRepo.GetByUserID(ctx, id)
+	if err != nil {
+		return err
+	}
+	var soleOwned []string
+	for _, membership := range memberships {
+		if membership.Role != "owner" {
+			continue
+		}
+		members, err := s.memberRepo.GetByProjectID(ctx, membership.ProjectID)
+		if err != nil {
+			return err
+		}
+		hasOwner := false
+		for _, member := range members {
+			hasOwner = hasOwner || (member.UserID != id && member.Role == "owner")
+		}
+		if !hasOwner {
+			soleOwned = append(soleOwned, strconv.FormatUint(membership.ProjectID, 10))
+		}
+	}
+	invitations, err := s.offboardingRepo.CountActiveInvitations(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(soleOwned) == 0 && invitations == 0 {
+		return nil
+	}
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeConflict, domain.ErrUserOwnsResources.Code, domain.ErrUserOwnsResources.Message,
+		fmt.Sprintf("唯一 owner 的项目：[%s]，有效的邀请码：%d", strings.Join(soleOwned, ", "), invitations))
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"yflow/internal/domain"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	// MaxAvatarSize 头像的最大字节数
+	MaxAvatarSize = 1 << 20
+	// maxDisplayNameLength 显示名称的最大长度
+	maxDisplayNameLength = 100
+	// maxProfileLanguageLength 界面语言标签的最大长度，与 User.Language 列一致
+	maxProfileLanguageLength = 10
+)
+
+// UserProfileService 个人资料服务实现
+// 头像与翻译键附件使用同一个附件存储，保存在 avatars/ 目录下
+type UserProfileService struct {
+	userRepo     domain.UserRepository
+	storage      domain.AttachmentStorage
+	cacheService domain.CacheService
+}
+
+// NewUserProfileService 创建个人资料服务实例
+// cacheService 不为空时，修改个人资料后清除用户信息缓存
+func NewUserProfileService(
+	userRepo domain.UserRepository,
+	storage domain.AttachmentStorage,
+	cacheService domain.CacheService,
+) *UserProfileService {
+	return &UserProfileService{
+		userRepo:     userRepo,
+		storage:      storage,
+		cacheService: cacheService,
+	}
+}
+
+// GetProfile 获取个人资料
+func (s *UserProfileService) GetProfile(ctx context.Context, userID uint64) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = ""
+	return user, nil
+}
+
+// UpdateProfile 更新显示名称、界面语言和时区，语言标签保存为规范形式
+func (s *UserProfileService) UpdateProfile(ctx context.Context, userID uint64, params domain.UpdateUserProfileParams) (*domain.User, error) {
+	displayName := strings.TrimSpace(params.DisplayName)
+	if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+		return nil, invalidUserProfile(fmt.Sprintf("显示名称不能超过 %d 个字符", maxDisplayNameLength))
+	}
+	lang, err := normalizeProfileLanguage(params.Language)
+	if err != nil {
+		return nil, err
+	}
+	timezone := strings.TrimSpace(params.Timezone)
+	if timezone != "" {
+		// Local 取决于服务器配置，不能作为用户的时区
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			return nil, invalidUserProfile("无效的时区：" + timezone)
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.DisplayName = displayName
+	user.Language = lang
+	user.Timezone = timezone
+	if err := s.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+	s.invalidateUserCache(ctx, userID)
+
+	user.Password = ""
+	return user, nil
+}
+
+// UploadAvatar 上传头像，只支持 PNG、JPEG、GIF 和 WebP 格式的图片
+// 新头像保存成功后才删除原有头像文件
+func (s *UserProfileService) UploadAvatar(ctx context.Context, userID uint64, data []byte) (*domain.User, error) {
+	if len(data) == 0 {
+		return nil, invalidAvatar("头像内容为空")
+	}
+	if len(data) > MaxAvatarSize {
+		return nil, invalidAvatar(fmt.Sprintf("头像不能超过 %d MB", MaxAvatarSize>>20))
+	}
+	contentType := http.DetectContentType(data)
+	extension, ok := attachmentExtensions[contentType]
+	if !ok {
+		return nil, invalidAvatar("只支持 PNG、JPEG、GIF 和 WebP 格式的图片")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	storageKey, err := avatarStorageKey(userID, extension)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(ctx, storageKey, data, contentType); err != nil {
+		return nil, fmt.Errorf("保存头像文件失败: %w", err)
+	}
+
+	previousKey := user.AvatarKey
+	user.AvatarKey = storageKey
+	if err := s.userRepo.UpdateProfile(ctx, user); err != nil {
+		s.storage.Delete(ctx, storageKey)
+		return nil, err
+	}
+	if previousKey != "" {
+		s.storage.Delete(ctx, previousKey)
+	}
+	s.invalidateUserCache(ctx, userID)
+
+	user.Password = ""
+	return user, nil
+}
+
+// DeleteAvatar 删除头像，没有头像时直接返回
+func (s *UserProfileService) DeleteAvatar(ctx context.Context, userID uint64) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if previousKey := user.AvatarKey; previousKey != "" {
+		user.AvatarKey = ""
+		if err := s.userRepo.UpdateProfile(ctx, user); err != nil {
+			return nil, err
+		}
+		s.storage.Delete(ctx, previousKey)
+		s.invalidateUserCache(ctx, userID)
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// GetAvatar 获取头像的文件内容和格式
+func (s *UserProfileService) GetAvatar(ctx context.Context, userID uint64) ([]byte, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if user.AvatarKey == "" {
+		return nil, "", domain.ErrAvatarNotFound
+	}
+	data, err := s.storage.Get(ctx, user.AvatarKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, http.DetectContentType(data), nil
+}
+
+// invalidateUserCache 清除用户信息缓存，用户信息中包含个人资料
+func (s *UserProfileService) invalidateUserCache(ctx context.Context, userID uint64) {
+	if s.cacheService == nil {
+		return
+	}
+	s.cacheService.Delete(ctx, domain.CacheKeys.User(userID))
+}
+
+// normalizeProfileLanguage 校验界面语言并转换为规范的 BCP 47 语言标签，空值表示跟随浏览器
+func normalizeProfileLanguage(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	tag, err := language.Parse(value)
+	if err != nil || len(tag.String()) > maxProfileLanguageLength {
+		return "", invalidUserProfile("无效的界面语言：" + value)
+	}
+	return tag.String(), nil
+}
+
+// avatarStorageKey 生成头像在存储中的随机路径，每次上传使用新的路径，保存失败时不影响原有头像
+func avatarStorageKey(userID uint64, extension string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("avatars/%d/%s.%s", userID, hex.EncodeToString(b), extension), nil
+}
+
+func invalidUserProfile(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidUserProfile.Code, domain.ErrInvalidUserProfile.Message, details)
+}
+
+func invalidAvatar(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidAvatar.Code, domain.ErrInvalidAvatar.Message, details)
+}
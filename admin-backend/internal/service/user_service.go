@@ -2,23 +2,45 @@ package service
 
 import (
 	"context"
+	"errors"
 	"yflow/internal/domain"
-	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 // UserService 用户服务实现
 type UserService struct {
-	userRepo    domain.UserRepository
-	authService domain.AuthService
+	userRepo        domain.UserRepository
+	authService     domain.AuthService
+	roleSync        domain.RoleSyncService
+	transactor      domain.Transactor
+	memberRepo      domain.ProjectMemberRepository
+	offboardingRepo domain.UserOffboardingRepository
+	auditRepo       domain.AuditLogRepository
+	sessions        domain.SessionService
 }
 
 // NewUserService 创建用户服务实例
-func NewUserService(userRepo domain.UserRepository, authService domain.AuthService) *UserService {
+// roleSync 不为空时，全局角色降级会将用户的项目 owner 成员关系加入复核队列
+func NewUserService(
+	userRepo domain.UserRepository,
+	authService domain.AuthService,
+	roleSync domain.RoleSyncService,
+	transactor domain.Transactor,
+	memberRepo domain.ProjectMemberRepository,
+	offboardingRepo domain.UserOffboardingRepository,
+	auditRepo domain.AuditLogRepository,
+	sessions domain.SessionService,
+) *UserService {
 	return &UserService{
-		userRepo:    userRepo,
-		authService: authService,
+		userRepo:        userRepo,
+		authService:     authService,
+		roleSync:        roleSync,
+		transactor:      transactor,
+		memberRepo:      memberRepo,
+		offboardingRepo: offboardingRepo,
+		auditRepo:       auditRepo,
+		sessions:        sessions,
 	}
 }
 
@@ -36,6 +58,17 @@ func (s *UserService) Login(ctx context.Context, params domain.LoginParams) (*do
 		return nil, domain.ErrInvalidPassword
 	}
 
+	// 已停用的用户不能登录
+	if user.Status != "active" {
+		return nil, domain.ErrUserDisabled
+	}
+
+	// 创建登录会话，令牌中记录会话ID
+	ctx, err = startSession(ctx, s.sessions, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成JWT token
 	token, err := s.authService.GenerateToken(ctx, user)
 	if err != nil {
@@ -67,11 +100,29 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, domain.ErrInvalidToken
 	}
 
-	// 查询用户确保用户仍然存在
+	// 查询用户确保用户仍然存在且未被停用
 	user, err := s.userRepo.GetByID(ctx, userFromToken.ID)
 	if err != nil {
 		return nil, domain.ErrUserNotFound
 	}
+	if user.Status != "active" {
+		return nil, domain.ErrUserDisabled
+	}
+
+	// 沿用刷新令牌的登录会话，会话已撤销时拒绝刷新
+	if s.sessions != nil {
+		sessionID, err := s.authService.RefreshSessionID(ctx, refreshToken)
+		if err != nil {
+			return nil, domain.ErrInvalidToken
+		}
+		if err := s.sessions.Validate(ctx, user.ID, sessionID); err != nil {
+			if errors.Is(err, domain.ErrSessionRevoked) {
+				return nil, domain.ErrInvalidToken
+			}
+			return nil, err
+		}
+		ctx = domain.WithSessionID(ctx, sessionID)
+	}
 
 	// 生成新token
 	token, err := s.authService.GenerateToken(ctx, user)
@@ -146,8 +197,8 @@ func (s *UserService) CreateUser(ctx context.Context, params domain.CreateUserPa
 }
 
 // GetAllUsers 获取用户列表
-func (s *UserService) GetAllUsers(ctx context.Context, limit, offset int, keyword string) ([]*domain.User, int64, error) {
-	users, total, err := s.userRepo.GetAll(ctx, limit, offset, keyword)
+func (s *UserService) GetAllUsers(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.User, int64, error) {
+	users, total, err := s.userRepo.GetAll(ctx, limit, offset, keyword, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -196,6 +247,7 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint64, params domain.U
 		user.Email = params.Email
 	}
 
+	previousRole := user.Role
 	if params.Role != "" {
 		user.Role = params.Role
 	}
@@ -204,7 +256,18 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint64, params domain.U
 		user.Status = params.Status
 	}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	// 角色降级与 owner 成员关系的复核在同一事务中提交
+	err = withinTransaction(ctx, s.transactor, func(ctx context.Context) error {
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		if s.roleSync == nil {
+			return nil
+		}
+		_, err := s.roleSync.QueueDowngradeReviews(ctx, user.ID, previousRole, user.Role)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -253,16 +316,12 @@ func (s *UserService) ResetPassword(ctx context.Context, userID uint64, newPassw
 }
 
 // DeleteUser 删除用户
+// 用户仍是项目唯一的 owner 或有有效的邀请码时返回 ErrUserOwnsResources，需要先通过 TransferOwnership 转移；
+// 按默认方式进行离职处理：内容引用置为 0，保留翻译历史和审计日志中的操作人
 func (s *UserService) DeleteUser(ctx context.Context, id uint64) error {
-	user, err := s.userRepo.GetByID(ctx, id)
-	if err != nil {
+	if err := s.checkNoOwnedResources(ctx, id); err != nil {
 		return err
 	}
-
-	// 不能删除管理员用户
-	if strings.ToLower(user.Role) == "admin" {
-		return domain.ErrCannotDeleteAdmin
-	}
-
-	return s.userRepo.Delete(ctx, id)
+	_, err := s.OffboardUser(ctx, id, domain.OffboardUserParams{})
+	return err
 }
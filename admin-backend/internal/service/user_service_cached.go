@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"yflow/internal/domain"
 )
 
@@ -39,7 +38,7 @@ func (s *CachedUserService) RefreshToken(ctx context.Context, refreshToken strin
 
 // GetUserInfo 获取用户信息（使用缓存）
 func (s *CachedUserService) GetUserInfo(ctx context.Context, userID uint64) (*domain.User, error) {
-	cacheKey := fmt.Sprintf("user:%d", userID)
+	cacheKey := domain.CacheKeys.User(userID)
 
 	// 使用互斥锁防止缓存击穿
 	mutex := s.mutexManager.GetMutex(cacheKey)
@@ -77,8 +76,8 @@ func (s *CachedUserService) CreateUser(ctx context.Context, params domain.Create
 }
 
 // GetAllUsers 获取用户列表（不缓存）
-func (s *CachedUserService) GetAllUsers(ctx context.Context, limit, offset int, keyword string) ([]*domain.User, int64, error) {
-	return s.userService.GetAllUsers(ctx, limit, offset, keyword)
+func (s *CachedUserService) GetAllUsers(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.User, int64, error) {
+	return s.userService.GetAllUsers(ctx, limit, offset, keyword, sort)
 }
 
 // GetUserByID 根据ID获取用户（使用缓存）
@@ -95,7 +94,7 @@ func (s *CachedUserService) UpdateUser(ctx context.Context, id uint64, params do
 	}
 
 	// 清除用户缓存
-	cacheKey := fmt.Sprintf("user:%d", id)
+	cacheKey := domain.CacheKeys.User(id)
 	s.cacheService.Delete(ctx, cacheKey)
 
 	return user, nil
@@ -111,6 +110,39 @@ func (s *CachedUserService) ResetPassword(ctx context.Context, userID uint64, ne
 	return s.userService.ResetPassword(ctx, userID, newPassword)
 }
 
+// OffboardUser 用户离职处理（清除缓存，用户的登录令牌随之失效）
+func (s *CachedUserService) OffboardUser(ctx context.Context, id uint64, params domain.OffboardUserParams) (*domain.OffboardUserResult, error) {
+	result, err := s.userService.OffboardUser(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除用户缓存
+	cacheKey := domain.CacheKeys.User(id)
+	s.cacheService.Delete(ctx, cacheKey)
+
+	return result, nil
+}
+
+// DeactivateUser 停用用户（清除缓存，认证中间件随即拒绝该用户的令牌）
+func (s *CachedUserService) DeactivateUser(ctx context.Context, id uint64, params domain.DeactivateUserParams) (*domain.User, error) {
+	user, err := s.userService.DeactivateUser(ctx, id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// 清除用户缓存
+	cacheKey := domain.CacheKeys.User(id)
+	s.cacheService.Delete(ctx, cacheKey)
+
+	return user, nil
+}
+
+// TransferOwnership 转移用户所有权（不缓存）
+func (s *CachedUserService) TransferOwnership(ctx context.Context, id uint64, params domain.TransferOwnershipParams) (*domain.OwnershipTransferResult, error) {
+	return s.userService.TransferOwnership(ctx, id, params)
+}
+
 // DeleteUser 删除用户（清除缓存）
 func (s *CachedUserService) DeleteUser(ctx context.Context, id uint64) error {
 	err := s.userService.DeleteUser(ctx, id)
@@ -119,7 +151,7 @@ func (s *CachedUserService) DeleteUser(ctx context.Context, id uint64) error {
 	}
 
 	// 清除用户缓存
-	cacheKey := fmt.Sprintf("user:%d", id)
+	cacheKey := domain.CacheKeys.User(id)
 	s.cacheService.Delete(ctx, cacheKey)
 
 	return nil
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+const (
+	// watchBufferSize 每个监听连接缓冲的变更数量，缓冲满时断开连接并要求客户端全量拉取
+	watchBufferSize = 64
+	// watchChannel 跨实例广播变更的 Redis 频道（自动加 Redis 键前缀）
+	watchChannel = "cli-watch"
+)
+
+// watcher 一个监听连接的订阅
+type watcher struct {
+	locales map[string]bool
+	events  chan domain.WatchEvent
+}
+
+// WatchHub CLI 监听模式和编辑器实时事件流的变更分发中心
+// 订阅 translation.updated 事件，将变更推送给监听对应项目和语言的连接。
+// Redis 事件总线的每个事件只由一个实例处理，因此配置 Redis 时变更通过 Pub/Sub 广播给所有实例，
+// 否则直接分发给本实例的连接。
+type WatchHub struct {
+	languageRepo domain.LanguageRepository
+	client       *redis.Client
+	channel      string
+	logger       *zap.Logger
+
+	mu       sync.Mutex
+	watchers map[uint64]map[*watcher]struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWatchHub 创建 CLI 监听模式的变更分发中心，client 为空时只分发给本实例的连接
+func NewWatchHub(languageRepo domain.LanguageRepository, client *repository.RedisClient, logger *zap.Logger) *WatchHub {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	hub := &WatchHub{
+		languageRepo: languageRepo,
+		logger:       logger,
+		watchers:     make(map[uint64]map[*watcher]struct{}),
+	}
+	if client != nil {
+		hub.client = client.GetClient()
+		hub.channel = client.GetKey(watchChannel)
+	}
+	return hub
+}
+
+// Watch 订阅项目的翻译变更
+func (h *WatchHub) Watch(projectID uint64, locales []string) (<-chan domain.WatchEvent, func()) {
+	w := &watcher{events: make(chan domain.WatchEvent, watchBufferSize)}
+	if len(locales) > 0 {
+		w.locales = make(map[string]bool, len(locales))
+		for _, locale := range locales {
+			w.locales[locale] = true
+		}
+	}
+
+	h.mu.Lock()
+	if h.watchers[projectID] == nil {
+		h.watchers[projectID] = make(map[*watcher]struct{})
+	}
+	h.watchers[projectID][w] = struct{}{}
+	h.mu.Unlock()
+
+	return w.events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(projectID, w)
+	}
+}
+
+// HandleEvent 处理 translation.updated 事件
+func (h *WatchHub) HandleEvent(ctx context.Context, event domain.Event) error {
+	var payload domain.TranslationUpdatedPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		return err
+	}
+
+	watchEvent := domain.WatchEvent{
+		Type:       domain.WatchMessageChanged,
+		EventID:    event.ID,
+		ProjectID:  event.ProjectID,
+		UserID:     event.ActorID,
+		Action:     payload.Action,
+		KeyNames:   payload.KeyNames,
+		Count:      payload.Count,
+		OccurredAt: event.OccurredAt,
+	}
+	if len(payload.LanguageIDs) > 0 {
+		languages, err := h.languageRepo.GetByIDs(ctx, payload.LanguageIDs)
+		if err != nil {
+			return err
+		}
+		for _, language := range languages {
+			watchEvent.Locales = append(watchEvent.Locales, language.Code)
+		}
+	}
+
+	if h.client == nil {
+		h.broadcast(watchEvent)
+		return nil
+	}
+	data, err := json.Marshal(watchEvent)
+	if err != nil {
+		return err
+	}
+	return h.client.Publish(ctx, h.channel, data).Err()
+}
+
+// Start 配置 Redis 时订阅广播频道
+func (h *WatchHub) Start(ctx context.Context) error {
+	if h.client == nil {
+		return nil
+	}
+	pubsub := h.client.Subscribe(ctx, h.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				var watchEvent domain.WatchEvent
+				if err := json.Unmarshal([]byte(message.Payload), &watchEvent); err != nil {
+					h.logger.Error("Failed to decode watch event", zap.Error(err))
+					continue
+				}
+				h.broadcast(watchEvent)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop 停止订阅并关闭所有监听连接，客户端重新连接后全量拉取
+func (h *WatchHub) Stop(ctx context.Context) error {
+	if h.cancel != nil {
+		h.cancel()
+		h.wg.Wait()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for projectID, watchers := range h.watchers {
+		for w := range watchers {
+			h.remove(projectID, w)
+		}
+	}
+	return nil
+}
+
+// broadcast 将变更分发给监听对应项目和语言的连接
+func (h *WatchHub) broadcast(event domain.WatchEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for w := range h.watchers[event.ProjectID] {
+		if !w.matches(event.Locales) {
+			continue
+		}
+		select {
+		case w.events <- event:
+		default:
+			// 缓冲已满，继续推送会丢失变更，断开连接让客户端全量拉取
+			h.logger.Warn("Watch buffer full, closing watcher", zap.Uint64("project_id", event.ProjectID))
+			h.remove(event.ProjectID, w)
+		}
+	}
+}
+
+// remove 移除订阅并关闭通道，调用方需持有锁
+func (h *WatchHub) remove(projectID uint64, w *watcher) {
+	watchers := h.watchers[projectID]
+	if _, ok := watchers[w]; !ok {
+		return
+	}
+	delete(watchers, w)
+	if len(watchers) == 0 {
+		delete(h.watchers, projectID)
+	}
+	close(w.events)
+}
+
+// matches 变更涉及的语言是否在监听范围内，语言未知时总是推送
+func (w *watcher) matches(locales []string) bool {
+	if w.locales == nil || len(locales) == 0 {
+		return true
+	}
+	for _, locale := range locales {
+		if w.locales[locale] {
+			return true
+		}
+	}
+	return false
+}
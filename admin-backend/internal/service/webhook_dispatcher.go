@@ -0,0 +1,321 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+)
+
+// 出站 Webhook 推送请求头
+const (
+	WebhookEventHeader     = "X-YFlow-Event"     // 事件名称
+	WebhookDeliveryHeader  = "X-YFlow-Delivery"  // 推送记录ID，重试时不变
+	WebhookSignatureHeader = "X-YFlow-Signature" // 请求体的 HMAC-SHA256 签名，格式为 sha256=<hex>
+)
+
+const (
+	// webhookMaxAttempts 最大推送次数，超过后标记为 failed
+	webhookMaxAttempts = 8
+	// webhookLease 领取推送后的租约时间，超时未完成时允许重新领取
+	webhookLease = time.Minute
+	// webhookPollInterval 投递器轮询间隔
+	webhookPollInterval = 5 * time.Second
+	// webhookBatchSize 每次轮询处理的最大推送数
+	webhookBatchSize = 50
+	// webhookRetention 推送成功的记录保留时间
+	webhookRetention = 7 * 24 * time.Hour
+	// webhookCleanupInterval 清理推送记录的间隔
+	webhookCleanupInterval = time.Hour
+	// maxWebhookResponseSize 读取响应体用于错误信息的上限
+	maxWebhookResponseSize = 1 << 10
+)
+
+// errWebhookAddressNotAllowed Webhook 地址解析到的 IP 不允许连接
+var errWebhookAddressNotAllowed = errors.New("webhook address not allowed")
+
+// WebhookDeliveryOptions 出站 Webhook 推送的限制
+type WebhookDeliveryOptions struct {
+	Timeout              time.Duration // 单次推送的超时时间
+	AllowPrivateNetworks bool          // 是否允许推送到回环、内网和链路本地地址
+}
+
+// WebhookDispatcher 出站 Webhook 投递器
+// 订阅领域事件，为订阅了对应事件的 Webhook 写入推送记录，再在后台异步推送，失败时按退避间隔重试
+type WebhookDispatcher struct {
+	webhookRepo domain.WebhookRepository
+	projectRepo domain.ProjectRepository
+	httpClient  *http.Client
+	logger      *zap.Logger
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWebhookDispatcher 创建出站 Webhook 投递器
+func NewWebhookDispatcher(webhookRepo domain.WebhookRepository, projectRepo domain.ProjectRepository, options WebhookDeliveryOptions, logger *zap.Logger) *WebhookDispatcher {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &WebhookDispatcher{
+		webhookRepo: webhookRepo,
+		projectRepo: projectRepo,
+		httpClient:  newOutboundHTTPClient(options.Timeout, options.AllowPrivateNetworks, errWebhookAddressNotAllowed),
+		logger:      logger,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// HandleEvent 为订阅了该事件的 Webhook 写入推送记录，请求体按各 Webhook 的消息模板渲染
+// 同一事件重复投递时推送记录不会重复写入
+func (d *WebhookDispatcher) HandleEvent(ctx context.Context, event domain.Event) error {
+	name := webhookEventName(event)
+	if name == "" {
+		return nil
+	}
+
+	webhooks, err := d.webhookRepo.GetByProjectID(ctx, event.ProjectID)
+	if err != nil {
+		return err
+	}
+	var subscribed []*domain.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Status == domain.WebhookStatusActive && webhookSubscribes(webhook, name) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	project, err := d.projectRepo.GetByID(ctx, event.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]*domain.WebhookDelivery, 0, len(subscribed))
+	for _, webhook := range subscribed {
+		delivery := &domain.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			ProjectID:     event.ProjectID,
+			Event:         name,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+		}
+		body, err := RenderWebhookBody(webhook.Template, event, name, project)
+		if err != nil {
+			// 渲染失败时重试没有意义，直接记录为失败，便于在推送记录中排查模板
+			delivery.Status = domain.WebhookDeliveryFailed
+			delivery.LastError = truncateRunes("渲染消息模板失败: "+webhookRenderError(err), 500)
+		}
+		delivery.Payload = string(body)
+		deliveries = append(deliveries, delivery)
+	}
+	if err := d.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return err
+	}
+
+	// 唤醒后台推送，不等待轮询间隔
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// webhookEventName 领域事件对应的 Webhook 事件名称，不支持的事件返回空字符串
+// 翻译变更按动作区分为新增、修改和删除，从回收站恢复视为新增
+func webhookEventName(event domain.Event) string {
+	switch event.Type {
+	case domain.EventTranslationUpdated:
+		var payload domain.TranslationUpdatedPayload
+		if err := event.DecodePayload(&payload); err != nil {
+			return ""
+		}
+		switch payload.Action {
+		case domain.TranslationActionCreated, domain.TranslationActionRestored:
+			return domain.WebhookEventTranslationCreated
+		case domain.TranslationActionDeleted:
+			return domain.WebhookEventTranslationDeleted
+		default:
+			return domain.WebhookEventTranslationUpdated
+		}
+	case domain.EventImportCompleted:
+		return domain.WebhookEventImportCompleted
+	case domain.EventReleasePublished:
+		return domain.WebhookEventReleasePublished
+	}
+	return ""
+}
+
+// webhookRenderError 模板渲染错误的描述，优先使用错误详情
+func webhookRenderError(err error) string {
+	if appErr, ok := domain.IsAppError(err); ok && appErr.Details != "" {
+		return appErr.Details
+	}
+	return err.Error()
+}
+
+// Start 启动后台推送
+func (d *WebhookDispatcher) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(runCtx)
+	}()
+	return nil
+}
+
+// Stop 停止后台推送并等待当前批次完成
+func (d *WebhookDispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 轮询循环，有新的推送记录时立即处理
+func (d *WebhookDispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+	lastCleanup := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+
+		if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Error("Webhook delivery failed", zap.Error(err))
+		}
+
+		if time.Since(lastCleanup) >= webhookCleanupInterval {
+			lastCleanup = time.Now()
+			if _, err := d.webhookRepo.DeleteDeliveredBefore(ctx, time.Now().Add(-webhookRetention)); err != nil && ctx.Err() == nil {
+				d.logger.Error("Webhook delivery cleanup failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// DeliverDue 推送一批到期的记录，返回推送成功的数量
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	deliveries, err := d.webhookRepo.GetDueDeliveries(ctx, now, webhookBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		claimed, err := d.webhookRepo.ClaimDelivery(ctx, delivery.ID, delivery.Attempts, now.Add(webhookLease))
+		if err != nil {
+			return delivered, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if d.deliver(ctx, delivery, delivery.Attempts+1) {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver 推送单条记录并保存结果，attempts 为包括本次在内的推送次数
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery, attempts int) bool {
+	responseStatus, err := d.send(ctx, delivery)
+	if err == nil {
+		if markErr := d.webhookRepo.MarkDelivered(ctx, delivery.ID, responseStatus); markErr != nil {
+			d.logger.Warn("Failed to mark webhook delivery delivered", zap.Uint64("delivery_id", delivery.ID), zap.Error(markErr))
+		}
+		return true
+	}
+
+	status := domain.WebhookDeliveryPending
+	if attempts >= webhookMaxAttempts || errors.Is(err, domain.ErrWebhookNotFound) || errors.Is(err, domain.ErrWebhookDisabled) {
+		status = domain.WebhookDeliveryFailed
+	}
+	d.logger.Warn("Webhook delivery attempt failed",
+		zap.Uint64("delivery_id", delivery.ID),
+		zap.Uint64("webhook_id", delivery.WebhookID),
+		zap.String("event", delivery.Event),
+		zap.Int("attempts", attempts),
+		zap.Int("response_status", responseStatus),
+		zap.String("status", status),
+		zap.Error(err),
+	)
+	// 与发件箱相同的退避间隔
+	lastError := truncateRunes(err.Error(), 500)
+	if markErr := d.webhookRepo.MarkFailed(ctx, delivery.ID, status, time.Now().Add(outboxBackoff(attempts)), responseStatus, lastError); markErr != nil {
+		d.logger.Error("Failed to record webhook delivery failure", zap.Uint64("delivery_id", delivery.ID), zap.Error(markErr))
+	}
+	return false
+}
+
+// send 发送签名的推送请求，2xx 响应视为成功；返回响应状态码，未收到响应时为 0
+// 每次推送都读取 Webhook 的当前配置，停用或删除后不再推送，地址和密钥的修改对重试生效
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery) (int, error) {
+	webhook, err := d.webhookRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return 0, err
+	}
+	if webhook.Status != domain.WebhookStatusActive {
+		return 0, domain.ErrWebhookDisabled
+	}
+
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "YFlow-Webhook")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(delivery.ID, 10))
+	// 签名格式与入站 Webhook 一致
+	req.Header.Set(WebhookSignatureHeader, SignInboundWebhookPayload(webhook.Secret, body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseSize))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseSize))
+	return resp.StatusCode, nil
+}
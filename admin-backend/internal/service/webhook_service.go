@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+	"yflow/internal/domain"
+	"yflow/internal/utils"
+)
+
+// maxWebhookURLLength Webhook 地址最大长度，与 webhooks.url 列长度一致
+const maxWebhookURLLength = 500
+
+// WebhookService 出站 Webhook 服务实现
+type WebhookService struct {
+	webhookRepo   domain.WebhookRepository
+	projectRepo   domain.ProjectRepository
+	securityUtils *utils.SecurityUtils
+}
+
+// NewWebhookService 创建出站 Webhook 服务实例
+func NewWebhookService(webhookRepo domain.WebhookRepository, projectRepo domain.ProjectRepository) *WebhookService {
+	return &WebhookService{
+		webhookRepo:   webhookRepo,
+		projectRepo:   projectRepo,
+		securityUtils: utils.NewSecurityUtils(),
+	}
+}
+
+// Create 创建 Webhook 并生成签名密钥，未指定事件时订阅全部事件
+func (s *WebhookService) Create(ctx context.Context, projectID uint64, params domain.WebhookParams, userID uint64) (*domain.Webhook, error) {
+	if _, err := s.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	webhook := &domain.Webhook{
+		ProjectID: projectID,
+		Events:    strings.Join(domain.WebhookEvents, ","),
+		Status:    domain.WebhookStatusActive,
+		CreatedBy: userID,
+		UpdatedBy: userID,
+	}
+	if err := applyWebhookParams(webhook, params); err != nil {
+		return nil, err
+	}
+	if webhook.Name == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if webhook.URL == "" {
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// GetByID 获取 Webhook 并确认其属于该项目
+func (s *WebhookService) GetByID(ctx context.Context, projectID, webhookID uint64) (*domain.Webhook, error) {
+	webhook, err := s.webhookRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.ProjectID != projectID {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// GetByProjectID 获取项目下的 Webhook 列表
+func (s *WebhookService) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Webhook, error) {
+	return s.webhookRepo.GetByProjectID(ctx, projectID)
+}
+
+// Update 更新 Webhook 配置，空字段保持不变
+func (s *WebhookService) Update(ctx context.Context, projectID, webhookID uint64, params domain.WebhookParams, userID uint64) (*domain.Webhook, error) {
+	webhook, err := s.GetByID(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyWebhookParams(webhook, params); err != nil {
+		return nil, err
+	}
+	webhook.UpdatedBy = userID
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Delete 删除 Webhook，尚未完成的推送一并删除
+func (s *WebhookService) Delete(ctx context.Context, projectID, webhookID uint64) error {
+	if _, err := s.GetByID(ctx, projectID, webhookID); err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(ctx, webhookID)
+}
+
+// RotateSecret 重新生成签名密钥，旧密钥立即失效（包括尚未完成的重试）
+func (s *WebhookService) RotateSecret(ctx context.Context, projectID, webhookID uint64, userID uint64) (*domain.Webhook, error) {
+	webhook, err := s.GetByID(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.securityUtils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = secret
+	webhook.UpdatedBy = userID
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// GetDeliveries 分页获取推送记录，最新的在前
+func (s *WebhookService) GetDeliveries(ctx context.Context, projectID, webhookID uint64, limit, offset int) ([]*domain.WebhookDelivery, int64, error) {
+	if _, err := s.GetByID(ctx, projectID, webhookID); err != nil {
+		return nil, 0, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	return s.webhookRepo.GetDeliveries(ctx, webhookID, limit, offset)
+}
+
+// applyWebhookParams 将参数合并到 Webhook 配置
+func applyWebhookParams(webhook *domain.Webhook, params domain.WebhookParams) error {
+	if name := strings.TrimSpace(params.Name); name != "" {
+		if utf8.RuneCountInString(name) > 100 {
+			return domain.ErrInvalidInput
+		}
+		webhook.Name = name
+	}
+	if rawURL := strings.TrimSpace(params.URL); rawURL != "" {
+		if !isValidWebhookURL(rawURL) {
+			return domain.ErrInvalidWebhookURL
+		}
+		webhook.URL = rawURL
+	}
+	if params.Events != nil {
+		events, err := normalizeWebhookEvents(params.Events)
+		if err != nil {
+			return err
+		}
+		webhook.Events = strings.Join(events, ",")
+	}
+	if params.Status != "" {
+		if params.Status != domain.WebhookStatusActive && params.Status != domain.WebhookStatusDisabled {
+			return domain.ErrInvalidInput
+		}
+		webhook.Status = params.Status
+	}
+	if params.Preset != "" && params.Template != nil {
+		return domain.ErrInvalidInput
+	}
+	if params.Preset != "" {
+		text, err := WebhookPresetTemplate(params.Preset)
+		if err != nil {
+			return err
+		}
+		webhook.Template = text
+	}
+	if params.Template != nil {
+		// 空模板表示使用内置 generic 模板
+		text := strings.TrimSpace(*params.Template)
+		if text != "" {
+			if err := ValidateWebhookTemplate(text); err != nil {
+				return err
+			}
+		}
+		webhook.Template = text
+	}
+	return nil
+}
+
+// isValidWebhookURL 检查地址是否为带主机名的 http 或 https 地址
+// 地址解析到的 IP 在推送时检查，见 newOutboundHTTPClient
+func isValidWebhookURL(rawURL string) bool {
+	if len(rawURL) > maxWebhookURLLength {
+		return false
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" || parsed.User != nil {
+		return false
+	}
+	return parsed.Scheme == "http" || parsed.Scheme == "https"
+}
+
+// normalizeWebhookEvents 校验订阅的事件，去重后按 domain.WebhookEvents 的顺序返回
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	selected := make(map[string]bool, len(events))
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !isWebhookEvent(event) {
+			return nil, domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+				domain.ErrInvalidWebhookEvents.Code, domain.ErrInvalidWebhookEvents.Message,
+				"可选值："+strings.Join(domain.WebhookEvents, "、"))
+		}
+		selected[event] = true
+	}
+	if len(selected) == 0 {
+		return nil, domain.ErrInvalidWebhookEvents
+	}
+
+	normalized := make([]string, 0, len(selected))
+	for _, event := range domain.WebhookEvents {
+		if selected[event] {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// isWebhookEvent 判断是否为可订阅的事件
+func isWebhookEvent(event string) bool {
+	for _, candidate := range domain.WebhookEvents {
+		if candidate == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWebhookEvents 解析存储的订阅事件列表
+func ParseWebhookEvents(raw string) []string {
+	events := make([]string, 0, len(domain.WebhookEvents))
+	for _, event := range strings.Split(raw, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// webhookSubscribes 判断 Webhook 是否订阅了该事件
+func webhookSubscribes(webhook *domain.Webhook, event string) bool {
+	for _, subscribed := range ParseWebhookEvents(webhook.Events) {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
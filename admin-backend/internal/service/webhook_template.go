@@ -0,0 +1,397 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"yflow/internal/domain"
+)
+
+// Webhook 消息模板的长度限制
+const (
+	maxWebhookTemplateSize = 16 << 10 // 模板
+	maxWebhookBodySize     = 64 << 10 // 渲染结果
+)
+
+// 内置 Webhook 消息模板
+const (
+	WebhookPresetGeneric = "generic"
+	WebhookPresetSlack   = "slack"
+	WebhookPresetTeams   = "teams"
+)
+
+// webhookPresets 内置模板：通用 JSON、Slack Block Kit 消息和 Teams 消息卡片
+var webhookPresets = map[string]string{
+	WebhookPresetGeneric: `{
+  "id": {{json .EventID}},
+  "type": {{json .EventType}},
+  "occurred_at": {{json .OccurredAt}},
+  "project": {{json .Project}},
+  "summary": {{json .Summary}},
+  "payload": {{json .Payload}}
+}`,
+	WebhookPresetSlack: `{
+  "text": {{json .Summary}},
+  "blocks": [
+    {"type": "section", "text": {"type": "mrkdwn", "text": {{json (printf "*%s*\n%s" .Project.Name .Summary)}}}},
+    {"type": "context", "elements": [{"type": "mrkdwn", "text": {{json (printf "%s · %s" .EventType (formatTime "2006-01-02 15:04 MST" .OccurredAt))}}}]}
+  ]
+}`,
+	WebhookPresetTeams: `{
+  "@type": "MessageCard",
+  "@context": "https://schema.org/extensions",
+  "summary": {{json .Summary}},
+  "themeColor": "0078D7",
+  "title": {{json .Project.Name}},
+  "text": {{json .Summary}},
+  "sections": [{"facts": [
+    {"name": "事件", "value": {{json .EventType}}},
+    {"name": "项目", "value": {{json .Project.Slug}}},
+    {"name": "时间", "value": {{json (formatTime "2006-01-02 15:04 MST" .OccurredAt)}}}
+  ]}]
+}`,
+}
+
+// webhookSample 预览模板时使用的示例事件
+type webhookSample struct {
+	eventType domain.EventType // 对应的领域事件类型
+	payload   interface{}
+}
+
+// webhookSamples 预览模板时使用的示例事件，按事件名称（领域事件或 Webhook 事件名称）查找
+var webhookSamples = map[domain.EventType]webhookSample{
+	domain.EventTranslationUpdated:        {domain.EventTranslationUpdated, sampleTranslationPayload(domain.TranslationActionUpdated)},
+	domain.WebhookEventTranslationCreated: {domain.EventTranslationUpdated, sampleTranslationPayload(domain.TranslationActionCreated)},
+	domain.WebhookEventTranslationDeleted: {domain.EventTranslationUpdated, sampleTranslationPayload(domain.TranslationActionDeleted)},
+	domain.EventProjectCreated: {domain.EventProjectCreated, domain.ProjectCreatedPayload{
+		Name:      "Web",
+		Slug:      "web",
+		CreatedBy: 1,
+	}},
+	domain.EventImportCompleted: {domain.EventImportCompleted, domain.ImportCompletedPayload{
+		Source:       domain.ImportSourceFile,
+		Format:       "json",
+		Keys:         120,
+		Translations: 360,
+	}},
+	domain.EventReleasePublished: {domain.EventReleasePublished, domain.ReleasePublishedPayload{
+		ReleaseID:        1,
+		Version:          "v1.2.0",
+		KeyCount:         120,
+		TranslationCount: 360,
+		CreatedBy:        1,
+	}},
+}
+
+// sampleTranslationPayload 示例翻译变更事件内容
+func sampleTranslationPayload(action string) domain.TranslationUpdatedPayload {
+	return domain.TranslationUpdatedPayload{
+		Action:      action,
+		KeyNames:    []string{"home.title", "home.subtitle"},
+		LanguageIDs: []uint64{1, 2},
+		Count:       2,
+	}
+}
+
+// webhookTemplateFuncs 模板可用的函数，只包含没有副作用的格式化函数
+var webhookTemplateFuncs = template.FuncMap{
+	"json":       templateJSON,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"join":       templateJoin,
+	"truncate":   templateTruncate,
+	"default":    templateDefault,
+	"formatTime": templateFormatTime,
+}
+
+// WebhookTemplateProject 模板中的项目信息
+type WebhookTemplateProject struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// WebhookTemplateData 渲染 Webhook 消息模板时可用的数据
+type WebhookTemplateData struct {
+	EventID    string
+	EventType  string
+	OccurredAt time.Time
+	Project    WebhookTemplateProject
+	Summary    string                 // 事件的简短描述，适合直接作为聊天消息
+	Payload    map[string]interface{} // 事件内容，字段名与事件 JSON 一致
+}
+
+// NewWebhookTemplateData 根据事件和所属项目构造模板数据
+func NewWebhookTemplateData(event domain.Event, project *domain.Project) (*WebhookTemplateData, error) {
+	data := &WebhookTemplateData{
+		EventID:    event.ID,
+		EventType:  string(event.Type),
+		OccurredAt: event.OccurredAt,
+		Payload:    map[string]interface{}{},
+	}
+	if project != nil {
+		data.Project = WebhookTemplateProject{ID: project.ID, Name: project.Name, Slug: project.Slug}
+	}
+	if err := event.DecodePayload(&data.Payload); err != nil {
+		return nil, err
+	}
+	data.Summary = summarizeEvent(event, data.Project.Name)
+	return data, nil
+}
+
+// RenderWebhookBody 渲染出站 Webhook 推送的请求体，text 为空时使用内置 generic 模板
+// name 为 Webhook 事件名称，作为模板中的 .EventType
+func RenderWebhookBody(text string, event domain.Event, name string, project *domain.Project) ([]byte, error) {
+	if text == "" {
+		text = webhookPresets[WebhookPresetGeneric]
+	}
+	data, err := NewWebhookTemplateData(event, project)
+	if err != nil {
+		return nil, err
+	}
+	data.EventType = name
+	return RenderWebhookTemplate(text, data)
+}
+
+// ValidateWebhookTemplate 校验模板的长度和语法
+func ValidateWebhookTemplate(text string) error {
+	_, err := parseWebhookTemplate(text)
+	return err
+}
+
+// RenderWebhookTemplate 渲染 Webhook 消息模板，渲染结果必须是合法的 JSON
+func RenderWebhookTemplate(text string, data *WebhookTemplateData) ([]byte, error) {
+	tmpl, err := parseWebhookTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &limitedBuffer{limit: maxWebhookBodySize}
+	if err := tmpl.Execute(out, data); err != nil {
+		return nil, invalidWebhookTemplate(err.Error())
+	}
+	if !json.Valid(out.Bytes()) {
+		return nil, invalidWebhookTemplate("渲染结果不是合法的 JSON，字符串值请使用 json 函数输出以正确转义")
+	}
+	return out.Bytes(), nil
+}
+
+// WebhookPresetTemplate 获取内置模板
+func WebhookPresetTemplate(name string) (string, error) {
+	text, ok := webhookPresets[name]
+	if !ok {
+		return "", domain.ErrUnknownWebhookPreset
+	}
+	return text, nil
+}
+
+// parseWebhookTemplate 解析模板
+func parseWebhookTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, invalidWebhookTemplate("模板不能为空")
+	}
+	if len(text) > maxWebhookTemplateSize {
+		return nil, invalidWebhookTemplate(fmt.Sprintf("模板不能超过 %d 字节", maxWebhookTemplateSize))
+	}
+	tmpl, err := template.New("webhook").Funcs(webhookTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, invalidWebhookTemplate(err.Error())
+	}
+	return tmpl, nil
+}
+
+// invalidWebhookTemplate 带错误详情的模板无效错误
+func invalidWebhookTemplate(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidWebhookTemplate.Code, domain.ErrInvalidWebhookTemplate.Message, details)
+}
+
+// summarizeEvent 生成事件的简短描述
+func summarizeEvent(event domain.Event, projectName string) string {
+	switch event.Type {
+	case domain.EventTranslationUpdated:
+		var payload domain.TranslationUpdatedPayload
+		if err := event.DecodePayload(&payload); err == nil {
+			verb := map[string]string{
+				domain.TranslationActionCreated:  "新增",
+				domain.TranslationActionUpdated:  "更新",
+				domain.TranslationActionUpserted: "写入",
+				domain.TranslationActionDeleted:  "删除",
+				domain.TranslationActionRenamed:  "重命名",
+				domain.TranslationActionReviewed: "审核",
+				domain.TranslationActionRestored: "恢复",
+			}[payload.Action]
+			if verb == "" {
+				verb = "修改"
+			}
+			summary := fmt.Sprintf("%s了 %d 条翻译", verb, payload.Count)
+			if len(payload.KeyNames) > 0 {
+				keys := payload.KeyNames
+				if len(keys) > 5 {
+					keys = keys[:5]
+				}
+				summary += "：" + strings.Join(keys, "、")
+				if len(payload.KeyNames) > len(keys) {
+					summary += " 等"
+				}
+			}
+			return summary
+		}
+	case domain.EventProjectCreated:
+		return "创建了项目 " + projectName
+	case domain.EventImportCompleted:
+		var payload domain.ImportCompletedPayload
+		if err := event.DecodePayload(&payload); err == nil {
+			return fmt.Sprintf("从 %s 导入了 %d 个键、%d 条翻译", payload.Source, payload.Keys, payload.Translations)
+		}
+	case domain.EventReleasePublished:
+		var payload domain.ReleasePublishedPayload
+		if err := event.DecodePayload(&payload); err == nil {
+			return fmt.Sprintf("发布了版本 %s，包含 %d 个键、%d 条翻译", payload.Version, payload.KeyCount, payload.TranslationCount)
+		}
+	}
+	return string(event.Type)
+}
+
+// templateJSON 将值序列化为 JSON，用于在模板中安全地输出字符串和对象
+func templateJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// templateJoin 用分隔符连接列表中的元素
+func templateJoin(sep string, list interface{}) string {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+	items := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		items = append(items, fmt.Sprint(value.Index(i).Interface()))
+	}
+	return strings.Join(items, sep)
+}
+
+// templateTruncate 截断到最多 n 个字符，截断时追加省略号
+func templateTruncate(n int, s string) string {
+	runes := []rune(s)
+	if n < 0 || len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
+
+// templateDefault 值为空时返回默认值
+func templateDefault(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return def
+		}
+	}
+	return value
+}
+
+// templateFormatTime 按 Go 时间格式输出时间
+func templateFormatTime(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
+// errWebhookBodyTooLarge 渲染结果超过长度限制
+var errWebhookBodyTooLarge = fmt.Errorf("渲染结果不能超过 %d 字节", maxWebhookBodySize)
+
+// limitedBuffer 超过长度限制时返回错误的缓冲区，避免模板生成过大的消息
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+// Write 写入缓冲区
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errWebhookBodyTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// WebhookTemplateService Webhook 消息模板服务
+type WebhookTemplateService struct {
+	projectRepo domain.ProjectRepository
+	webhookRepo domain.WebhookRepository
+}
+
+// NewWebhookTemplateService 创建 Webhook 消息模板服务
+func NewWebhookTemplateService(projectRepo domain.ProjectRepository, webhookRepo domain.WebhookRepository) *WebhookTemplateService {
+	return &WebhookTemplateService{projectRepo: projectRepo, webhookRepo: webhookRepo}
+}
+
+// Preview 用示例事件渲染模板
+// 模板依次取 Template、Preset 和指定 Webhook 保存的模板，都未指定时使用内置 generic 模板；未指定事件类型时使用 translation.updated
+func (s *WebhookTemplateService) Preview(ctx context.Context, projectID uint64, params domain.WebhookTemplatePreviewParams) (*domain.WebhookTemplatePreview, error) {
+	text := params.Template
+	if text == "" && params.Preset != "" {
+		var err error
+		if text, err = WebhookPresetTemplate(params.Preset); err != nil {
+			return nil, err
+		}
+	}
+	if text == "" && params.WebhookID != 0 {
+		webhook, err := s.webhookRepo.GetByID(ctx, params.WebhookID)
+		if err != nil {
+			return nil, err
+		}
+		if webhook.ProjectID != projectID {
+			return nil, domain.ErrWebhookNotFound
+		}
+		text = webhook.Template
+	}
+
+	eventType := params.EventType
+	if eventType == "" {
+		eventType = domain.EventTranslationUpdated
+	}
+	sample, ok := webhookSamples[eventType]
+	if !ok {
+		return nil, domain.ErrUnsupportedEventType
+	}
+	var payload interface{} = sample.payload
+	if len(params.Payload) > 0 {
+		if !json.Valid(params.Payload) {
+			return nil, domain.ErrInvalidInput
+		}
+		payload = params.Payload
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := domain.NewEvent(sample.eventType, project.ID, payload)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := NewWebhookTemplateData(event, project); err != nil {
+		// 自定义示例内容不是 JSON 对象
+		return nil, domain.ErrInvalidInput
+	}
+
+	body, err := RenderWebhookBody(text, event, string(eventType), project)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.WebhookTemplatePreview{EventType: eventType, Body: body}, nil
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"yflow/internal/domain"
+)
+
+// 支持的 XLIFF 版本
+const (
+	XLIFFVersion12 = "1.2"
+	XLIFFVersion20 = "2.0"
+)
+
+// XLIFF 命名空间
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+)
+
+// XLIFFFile XLIFF 文档中的一个文件，对应一种目标语言
+// 导出时语言代码使用本系统的代码，序列化时转换为 BCP 47 格式；解析结果保留文件中的原始代码
+type XLIFFFile struct {
+	Original       string // 原始文件名，导出时为项目标识
+	SourceLanguage string
+	TargetLanguage string
+	Units          []XLIFFUnit
+}
+
+// XLIFFUnit 一个翻译单元，对应一个翻译键
+type XLIFFUnit struct {
+	Key       string
+	Source    string
+	Target    string
+	HasTarget bool   // 是否包含 target，没有 target 的单元在 CAT 工具中显示为待翻译
+	Note      string // 上下文说明
+}
+
+// xliffText 源文和译文内容
+// 解析时保留所有层级的文本，丢弃 CAT 工具可能插入的行内标记（如 <g>、<mrk>）
+type xliffText struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// UnmarshalXML 拼接元素内全部文本
+func (t *xliffText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "state" {
+			t.State = attr.Value
+		}
+	}
+
+	var text strings.Builder
+	depth := 1
+	for depth > 0 {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			text.Write(token)
+		}
+	}
+	t.Text = text.String()
+	return nil
+}
+
+// xliff12Document XLIFF 1.2 文档
+type xliff12Document struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Version string        `xml:"version,attr"`
+	Files   []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Body           xliff12Body `xml:"body"`
+}
+
+type xliff12Body struct {
+	Units []xliff12Unit `xml:"trans-unit"`
+}
+
+// UnmarshalXML 按文档顺序读取翻译单元，分组中的单元展开到同一层
+func (b *xliff12Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeXLIFFUnits(d, "trans-unit", func(start xml.StartElement) error {
+		var unit xliff12Unit
+		if err := d.DecodeElement(&unit, &start); err != nil {
+			return err
+		}
+		b.Units = append(b.Units, unit)
+		return nil
+	})
+}
+
+type xliff12Unit struct {
+	ID      string      `xml:"id,attr"`
+	Resname string      `xml:"resname,attr,omitempty"`
+	Source  xliffText   `xml:"source"`
+	Target  *xliffText  `xml:"target"`
+	Notes   []xliffText `xml:"note"`
+}
+
+// xliff20Document XLIFF 2.0 文档，整个文档只有一对源语言和目标语言
+type xliff20Document struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Version string        `xml:"version,attr"`
+	SrcLang string        `xml:"srcLang,attr"`
+	TrgLang string        `xml:"trgLang,attr,omitempty"`
+	Files   []xliff20File `xml:"file"`
+}
+
+type xliff20File struct {
+	ID       string        `xml:"id,attr"`
+	Original string        `xml:"original,attr,omitempty"`
+	Units    []xliff20Unit `xml:"unit"`
+}
+
+// UnmarshalXML 按文档顺序读取翻译单元，分组中的单元展开到同一层
+func (f *xliff20File) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			f.ID = attr.Value
+		case "original":
+			f.Original = attr.Value
+		}
+	}
+	return decodeXLIFFUnits(d, "unit", func(start xml.StartElement) error {
+		var unit xliff20Unit
+		if err := d.DecodeElement(&unit, &start); err != nil {
+			return err
+		}
+		f.Units = append(f.Units, unit)
+		return nil
+	})
+}
+
+type xliff20Unit struct {
+	ID       string           `xml:"id,attr"`
+	Name     string           `xml:"name,attr,omitempty"` // 翻译键，id 必须是 NMTOKEN，不能直接使用键名
+	Notes    *xliff20Notes    `xml:"notes"`
+	Segments []xliff20Segment `xml:"segment"`
+}
+
+type xliff20Notes struct {
+	Notes []xliffText `xml:"note"`
+}
+
+type xliff20Segment struct {
+	State  string     `xml:"state,attr,omitempty"`
+	Source xliffText  `xml:"source"`
+	Target *xliffText `xml:"target"`
+}
+
+// decodeXLIFFUnits 读取当前元素的子元素直到元素结束，对名为 unitName 的元素调用 decode，
+// 递归进入 group 元素，跳过其他元素（如 1.2 的 header、2.0 的 skeleton）
+func decodeXLIFFUnits(d *xml.Decoder, unitName string, decode func(start xml.StartElement) error) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case unitName:
+				err = decode(token)
+			case "group":
+				err = decodeXLIFFUnits(d, unitName, decode)
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXLIFF 将文件序列化为 XLIFF 文档
+// XLIFF 2.0 的一个文档只能有一种目标语言，多种目标语言需要拆分为多个文档
+func MarshalXLIFF(version string, files []*XLIFFFile) ([]byte, error) {
+	var document interface{}
+	switch version {
+	case "", XLIFFVersion12:
+		document = newXLIFF12Document(files)
+	case XLIFFVersion20:
+		for _, file := range files {
+			if file.SourceLanguage != files[0].SourceLanguage || file.TargetLanguage != files[0].TargetLanguage {
+				return nil, domain.ErrXLIFFMultipleTargets
+			}
+		}
+		document = newXLIFF20Document(files)
+	default:
+		return nil, domain.ErrUnsupportedXLIFFVersion
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// newXLIFF12Document 构造 XLIFF 1.2 文档，目标译文的状态为 translated
+func newXLIFF12Document(files []*XLIFFFile) *xliff12Document {
+	document := &xliff12Document{Xmlns: xliff12Namespace, Version: XLIFFVersion12}
+	for _, file := range files {
+		out := xliff12File{
+			Original:       file.Original,
+			SourceLanguage: xliffLanguageCode(file.SourceLanguage),
+			TargetLanguage: xliffLanguageCode(file.TargetLanguage),
+			Datatype:       "plaintext",
+		}
+		for _, unit := range file.Units {
+			u := xliff12Unit{ID: unit.Key, Resname: unit.Key, Source: xliffText{Text: unit.Source}}
+			if unit.HasTarget {
+				u.Target = &xliffText{State: "translated", Text: unit.Target}
+			}
+			if unit.Note != "" {
+				u.Notes = []xliffText{{Text: unit.Note}}
+			}
+			out.Body.Units = append(out.Body.Units, u)
+		}
+		document.Files = append(document.Files, out)
+	}
+	return document
+}
+
+// newXLIFF20Document 构造 XLIFF 2.0 文档，单元 ID 按顺序编号，键名写入 name 属性
+func newXLIFF20Document(files []*XLIFFFile) *xliff20Document {
+	document := &xliff20Document{Xmlns: xliff20Namespace, Version: XLIFFVersion20}
+	for i, file := range files {
+		document.SrcLang = xliffLanguageCode(file.SourceLanguage)
+		document.TrgLang = xliffLanguageCode(file.TargetLanguage)
+		out := xliff20File{ID: fmt.Sprintf("f%d", i+1), Original: file.Original}
+		for j, unit := range file.Units {
+			segment := xliff20Segment{State: "initial", Source: xliffText{Text: unit.Source}}
+			if unit.HasTarget {
+				segment.State = "translated"
+				segment.Target = &xliffText{Text: unit.Target}
+			}
+			u := xliff20Unit{ID: fmt.Sprintf("u%d", j+1), Name: unit.Key, Segments: []xliff20Segment{segment}}
+			if unit.Note != "" {
+				u.Notes = &xliff20Notes{Notes: []xliffText{{Text: unit.Note}}}
+			}
+			out.Units = append(out.Units, u)
+		}
+		document.Files = append(document.Files, out)
+	}
+	return document
+}
+
+// ParseXLIFF 解析 XLIFF 1.2 或 2.0 文档，版本由根元素的 version 属性决定
+// 1.2 的键名取 resname（没有时取 id），2.0 取 name（没有时取 id）；多条备注以换行连接
+func ParseXLIFF(data []byte) ([]*XLIFFFile, error) {
+	version, err := detectXLIFFVersion(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*XLIFFFile
+	switch {
+	case strings.HasPrefix(version, "1."):
+		var document xliff12Document
+		if err := xml.Unmarshal(data, &document); err != nil {
+			return nil, invalidXLIFF(err.Error())
+		}
+		for _, file := range document.Files {
+			out := &XLIFFFile{Original: file.Original, SourceLanguage: file.SourceLanguage, TargetLanguage: file.TargetLanguage}
+			collectXLIFF12Units(out, file.Body.Units)
+			files = append(files, out)
+		}
+	case strings.HasPrefix(version, "2."):
+		var document xliff20Document
+		if err := xml.Unmarshal(data, &document); err != nil {
+			return nil, invalidXLIFF(err.Error())
+		}
+		for _, file := range document.Files {
+			out := &XLIFFFile{Original: file.Original, SourceLanguage: document.SrcLang, TargetLanguage: document.TrgLang}
+			collectXLIFF20Units(out, file.Units)
+			files = append(files, out)
+		}
+	default:
+		return nil, domain.ErrUnsupportedXLIFFVersion
+	}
+
+	if len(files) == 0 {
+		return nil, invalidXLIFF("文档中没有 file 元素")
+	}
+	return files, nil
+}
+
+// detectXLIFFVersion 读取根元素的 version 属性
+func detectXLIFFVersion(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", invalidXLIFF("文档为空")
+		}
+		if err != nil {
+			return "", invalidXLIFF(err.Error())
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "xliff" {
+			return "", invalidXLIFF("根元素不是 xliff")
+		}
+		for _, attr := range start.Attr {
+			if attr.Name.Local == "version" && attr.Name.Space == "" {
+				return attr.Value, nil
+			}
+		}
+		return "", invalidXLIFF("缺少 version 属性")
+	}
+}
+
+// collectXLIFF12Units 收集翻译单元
+func collectXLIFF12Units(file *XLIFFFile, units []xliff12Unit) {
+	for _, unit := range units {
+		key := unit.Resname
+		if key == "" {
+			key = unit.ID
+		}
+		out := XLIFFUnit{Key: key, Source: unit.Source.Text, Note: joinXLIFFNotes(unit.Notes)}
+		if unit.Target != nil {
+			out.Target = unit.Target.Text
+			out.HasTarget = true
+		}
+		file.Units = append(file.Units, out)
+	}
+}
+
+// collectXLIFF20Units 收集翻译单元，同一单元的多个片段拼接为一条翻译
+func collectXLIFF20Units(file *XLIFFFile, units []xliff20Unit) {
+	for _, unit := range units {
+		key := unit.Name
+		if key == "" {
+			key = unit.ID
+		}
+		out := XLIFFUnit{Key: key}
+		if unit.Notes != nil {
+			out.Note = joinXLIFFNotes(unit.Notes.Notes)
+		}
+		var source, target strings.Builder
+		for _, segment := range unit.Segments {
+			source.WriteString(segment.Source.Text)
+			if segment.Target != nil {
+				target.WriteString(segment.Target.Text)
+				out.HasTarget = true
+			}
+		}
+		out.Source = source.String()
+		out.Target = target.String()
+		file.Units = append(file.Units, out)
+	}
+}
+
+// joinXLIFFNotes 以换行连接非空备注
+func joinXLIFFNotes(notes []xliffText) string {
+	parts := make([]string, 0, len(notes))
+	for _, note := range notes {
+		if text := strings.TrimSpace(note.Text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// xliffLanguageCode 将语言代码转换为 XLIFF 使用的 BCP 47 格式，如 zh_CN -> zh-CN
+func xliffLanguageCode(code string) string {
+	return strings.ReplaceAll(code, "_", "-")
+}
+
+// invalidXLIFF 带错误详情的无效 XLIFF 错误
+func invalidXLIFF(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidXLIFF.Code, domain.ErrInvalidXLIFF.Message, details)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"yflow/internal/domain"
+)
+
+// 支持的 YAML 文件风格
+const (
+	YAMLStyleRails   = "rails"   // 以语言代码为根节点，如 en: {home: {title: ...}}
+	YAMLStyleSymfony = "symfony" // 没有语言根节点，语言由文件名决定
+)
+
+// yamlNode 按键名中的 . 拆分得到的嵌套节点
+// 同一路径既有值又有子键时（如 a 和 a.b），子键以完整的相对键名平铺在同一层，导入时仍还原为原键名
+type yamlNode struct {
+	value    *string
+	children map[string]*yamlNode
+}
+
+// MarshalLocaleYAML 将多种语言的翻译序列化为 Rails 风格的 YAML 文档，每种语言一个根节点
+// 键名按 . 重新嵌套，同一层的键按字母顺序排列
+func MarshalLocaleYAML(localeValues map[string]map[string]string) ([]byte, error) {
+	locales := make([]string, 0, len(localeValues))
+	for locale := range localeValues {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, locale := range locales {
+		root.Content = append(root.Content, yamlStringNode(locale), nestYAMLValues(localeValues[locale]))
+	}
+	return encodeYAML(root)
+}
+
+// MarshalFlatLocaleYAML 将一种语言的翻译序列化为没有语言根节点的 YAML 文档（Symfony 风格）
+func MarshalFlatLocaleYAML(values map[string]string) ([]byte, error) {
+	return encodeYAML(nestYAMLValues(values))
+}
+
+// ParseLocaleYAML 解析 YAML 翻译文件，返回 语言 -> 键名 -> 值
+// language 为空时文档的根节点必须是语言代码（Rails 风格），否则整个文档视为该语言的翻译（Symfony 风格）；
+// 嵌套的键以 . 连接为平铺的键名，数字和布尔值按原文导入，空值和序列跳过
+func ParseLocaleYAML(data []byte, language string) (map[string]map[string]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, invalidYAML(err.Error())
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, invalidYAML("文档为空")
+	}
+	root := resolveYAMLAlias(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, invalidYAML("文档的根节点必须是映射")
+	}
+
+	result := make(map[string]map[string]string)
+	if language != "" {
+		values := make(map[string]string)
+		flattenYAMLMapping(root, "", values)
+		result[language] = values
+		return result, nil
+	}
+
+	for _, pair := range yamlMappingPairs(root) {
+		locale, node := pair[0].Value, resolveYAMLAlias(pair[1])
+		if node.Kind != yaml.MappingNode {
+			return nil, invalidYAML(fmt.Sprintf("根节点 %q 不是语言节点，没有语言根节点的文件请指定 language 参数", locale))
+		}
+		if result[locale] == nil {
+			result[locale] = make(map[string]string)
+		}
+		flattenYAMLMapping(node, "", result[locale])
+	}
+	return result, nil
+}
+
+// flattenYAMLMapping 递归展开映射节点，合并键（<<）先展开，显式的键覆盖合并的值
+func flattenYAMLMapping(node *yaml.Node, prefix string, values map[string]string) {
+	for _, pair := range yamlMappingPairs(node) {
+		keyNode, valueNode := pair[0], resolveYAMLAlias(pair[1])
+
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch valueNode.Kind {
+		case yaml.MappingNode:
+			flattenYAMLMapping(valueNode, key, values)
+		case yaml.ScalarNode:
+			if valueNode.Tag == "!!null" {
+				continue
+			}
+			values[key] = valueNode.Value
+		}
+	}
+}
+
+// yamlMappingPairs 返回映射节点的键值对，合并键（<<）引用的映射排在最前面
+func yamlMappingPairs(node *yaml.Node) [][2]*yaml.Node {
+	var merged, pairs [][2]*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Tag != "!!merge" {
+			pairs = append(pairs, [2]*yaml.Node{keyNode, valueNode})
+			continue
+		}
+
+		sources := []*yaml.Node{valueNode}
+		if resolved := resolveYAMLAlias(valueNode); resolved.Kind == yaml.SequenceNode {
+			sources = resolved.Content
+		}
+		for _, source := range sources {
+			if source = resolveYAMLAlias(source); source.Kind == yaml.MappingNode {
+				merged = append(merged, yamlMappingPairs(source)...)
+			}
+		}
+	}
+	return append(merged, pairs...)
+}
+
+// resolveYAMLAlias 返回别名引用的节点
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// nestYAMLValues 将平铺的键名按 . 拆分为嵌套的映射节点
+func nestYAMLValues(values map[string]string) *yaml.Node {
+	root := &yamlNode{}
+	for key, value := range values {
+		value := value
+		node := root
+		segments := strings.Split(key, ".")
+		// 含有空段的键名（如 a..b、.a）无法嵌套，原样作为根节点的键
+		for _, segment := range segments {
+			if segment == "" {
+				segments = []string{key}
+				break
+			}
+		}
+		for _, segment := range segments {
+			if node.children == nil {
+				node.children = make(map[string]*yamlNode)
+			}
+			child, ok := node.children[segment]
+			if !ok {
+				child = &yamlNode{}
+				node.children[segment] = child
+			}
+			node = child
+		}
+		node.value = &value
+	}
+	return root.toYAML()
+}
+
+// toYAML 将嵌套节点转换为 YAML 映射节点
+func (n *yamlNode) toYAML() *yaml.Node {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for _, segment := range sortedYAMLChildren(n.children) {
+		child := n.children[segment]
+		if child.value == nil {
+			mapping.Content = append(mapping.Content, yamlStringNode(segment), child.toYAML())
+			continue
+		}
+
+		mapping.Content = append(mapping.Content, yamlStringNode(segment), yamlStringNode(*child.value))
+		flat := make(map[string]string)
+		child.flatten(segment, flat)
+		keys := make([]string, 0, len(flat))
+		for key := range flat {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			mapping.Content = append(mapping.Content, yamlStringNode(key), yamlStringNode(flat[key]))
+		}
+	}
+	return mapping
+}
+
+// flatten 将子节点展开为相对于当前层的平铺键名
+func (n *yamlNode) flatten(prefix string, values map[string]string) {
+	for segment, child := range n.children {
+		key := prefix + "." + segment
+		if child.value != nil {
+			values[key] = *child.value
+		}
+		child.flatten(key, values)
+	}
+}
+
+func sortedYAMLChildren(children map[string]*yamlNode) []string {
+	segments := make([]string, 0, len(children))
+	for segment := range children {
+		segments = append(segments, segment)
+	}
+	sort.Strings(segments)
+	return segments
+}
+
+// yaml11Booleans YAML 1.1 中的布尔值写法，Rails 使用的 Psych 会将未加引号的这些值（包括挪威语代码 no）解析为布尔值
+var yaml11Booleans = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+}
+
+// yamlStringNode 字符串节点，像数字、布尔值的字符串由编码器自动加引号，多行文本使用块格式
+func yamlStringNode(value string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if yaml11Booleans[strings.ToLower(value)] {
+		node.Style = yaml.DoubleQuotedStyle
+	} else if strings.Contains(strings.TrimRight(value, "\n"), "\n") {
+		node.Style = yaml.LiteralStyle
+	}
+	return node
+}
+
+func encodeYAML(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// invalidYAML 带解析失败原因的 YAML 格式错误
+func invalidYAML(details string) error {
+	return domain.NewAppErrorWithDetails(domain.ErrorTypeValidation,
+		domain.ErrInvalidYAML.Code, domain.ErrInvalidYAML.Message, details)
+}
@@ -0,0 +1,456 @@
+package contract_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"yflow/internal/api/handlers"
+	"yflow/internal/api/routes"
+	"yflow/internal/apispec"
+	"yflow/internal/domain"
+)
+
+// 契约测试：从处理器注释生成 OpenAPI 规范，并与实际注册的路由和响应进行比对，
+// 保证 swag 注释与实现一致。
+//
+// 测试服务器不连接数据库，处理器的服务依赖为空：
+// 需要认证的接口在认证中间件处即返回，公开接口使用无效输入在校验阶段返回。
+
+// backendRoot admin-backend 根目录（相对于本测试包）
+const backendRoot = "../.."
+
+// authErrorCodes 认证失败时允许的错误码
+var authErrorCodes = []string{"UNAUTHORIZED", "INVALID_TOKEN", "TOKEN_EXPIRED"}
+
+// publicRequests 公开接口的测试请求：使用无效输入，使请求在调用服务前返回
+var publicRequests = map[string]struct {
+	path string
+	body string
+}{
+	"POST /login":                         {body: `{}`},
+	"POST /refresh":                       {body: `{}`},
+	"POST /register":                      {body: `{}`},
+	"POST /password-reset":                {body: `{}`},
+	"POST /password-reset/confirm":        {body: `{}`},
+	"GET /invitations/{code}/validate":    {path: "/invitations/unknown/validate"},
+	"GET /auth/oidc/providers":            {},
+	"GET /auth/oidc/login":                {},
+	"GET /auth/oidc/callback":             {},
+	"POST /webhooks/inbound/{webhook_id}": {path: "/webhooks/inbound/not-a-number", body: `{}`},
+}
+
+// operation 规范中的一个接口
+type operation struct {
+	method string
+	path   string // 规范中的路径，如 /projects/{project_id}
+	op     *spec.Operation
+}
+
+func (o operation) String() string {
+	return o.method + " " + o.path
+}
+
+// stubInvitationService 邀请码校验始终返回不存在，其余方法未实现
+type stubInvitationService struct {
+	domain.InvitationService
+}
+
+func (stubInvitationService) ValidateInvitation(ctx context.Context, code string) (*domain.Invitation, error) {
+	return nil, domain.ErrInvitationNotFound
+}
+
+// stubSSOService 没有配置身份提供方，其余方法未实现
+type stubSSOService struct {
+	domain.SSOService
+}
+
+func (stubSSOService) Providers() []domain.SSOProvider {
+	return []domain.SSOProvider{}
+}
+
+// loadSpec 生成 OpenAPI 规范
+func loadSpec(t *testing.T) *spec.Swagger {
+	t.Helper()
+	swagger, err := apispec.Build(backendRoot)
+	require.NoError(t, err, "swag 注释解析失败")
+	return swagger
+}
+
+// operations 按方法和路径排序的全部接口
+func operations(swagger *spec.Swagger) []operation {
+	var ops []operation
+	for path, item := range swagger.Paths.Paths {
+		for method, op := range map[string]*spec.Operation{
+			http.MethodGet:    item.Get,
+			http.MethodPost:   item.Post,
+			http.MethodPut:    item.Put,
+			http.MethodPatch:  item.Patch,
+			http.MethodDelete: item.Delete,
+		} {
+			if op != nil {
+				ops = append(ops, operation{method: method, path: path, op: op})
+			}
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].String() < ops[j].String() })
+	return ops
+}
+
+// newTestEngine 创建注册了全部路由、不连接外部依赖的测试服务器
+func newTestEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	logger := zap.NewNop()
+
+	router := routes.NewRouter(routes.RouterDeps{
+		UserHandler:              handlers.NewUserHandler(nil, logger),
+		ProjectHandler:           handlers.NewProjectHandler(nil, logger),
+		LanguageHandler:          handlers.NewLanguageHandler(nil),
+		TranslationHandler:       handlers.NewTranslationHandler(nil, nil, nil, nil, logger),
+		DashboardHandler:         handlers.NewDashboardHandler(nil),
+		ProjectMemberHandler:     handlers.NewProjectMemberHandler(nil),
+		CLIHandler:               handlers.NewCLIHandler(nil, nil, nil, 0),
+		InvitationHandler:        handlers.NewInvitationHandler(stubInvitationService{}, nil, logger),
+		SSOHandler:               handlers.NewSSOHandler(stubSSOService{}, "", logger),
+		SessionHandler:           handlers.NewSessionHandler(nil, nil, logger),
+		InboundWebhookHandler:    handlers.NewInboundWebhookHandler(nil, logger),
+		KeyPrefixHandler:         handlers.NewKeyPrefixHandler(nil, logger),
+		AuditLogHandler:          handlers.NewAuditLogHandler(nil),
+		PromotionHandler:         handlers.NewPromotionHandler(nil, logger),
+		StorageHandler:           handlers.NewStorageHandler(nil),
+		AccessTokenHandler:       handlers.NewAccessTokenHandler(nil, logger),
+		ProjectConfigHandler:     handlers.NewProjectConfigHandler(nil, logger),
+		BulkDeleteHandler:        handlers.NewBulkDeleteHandler(nil, logger),
+		CLIWatchHandler:          handlers.NewCLIWatchHandler(nil, nil, logger),
+		CLIDeltaHandler:          handlers.NewCLIDeltaHandler(nil, nil, 0),
+		ProjectEventHandler:      handlers.NewProjectEventHandler(nil, logger),
+		KeyLockHandler:           handlers.NewKeyLockHandler(nil, logger),
+		ComplianceHandler:        handlers.NewComplianceHandler(nil, logger),
+		ProjectActivityHandler:   handlers.NewProjectActivityHandler(nil, logger),
+		WebhookTemplateHandler:   handlers.NewWebhookTemplateHandler(nil),
+		DeadLetterHandler:        handlers.NewDeadLetterHandler(nil, logger),
+		ImportProfileHandler:     handlers.NewImportProfileHandler(nil, logger),
+		ReleaseGateHandler:       handlers.NewReleaseGateHandler(nil, logger),
+		FigmaHandler:             handlers.NewFigmaHandler(nil, logger),
+		RoleSyncHandler:          handlers.NewRoleSyncHandler(nil, logger),
+		UsageHandler:             handlers.NewUsageHandler(nil, logger),
+		QAHandler:                handlers.NewQAHandler(nil, logger),
+		GlossaryHandler:          handlers.NewGlossaryHandler(nil, logger),
+		TranslationMemoryHandler: handlers.NewTranslationMemoryHandler(nil, logger),
+		OrganizationHandler:      handlers.NewOrganizationHandler(nil, nil, nil, logger),
+		DeliveryHandler:          handlers.NewDeliveryHandler(nil, 60),
+		Logger:                   logger,
+	})
+
+	engine := gin.New()
+	// 处理器访问到空的服务依赖说明请求没有在预期的阶段返回
+	engine.Use(gin.CustomRecovery(func(c *gin.Context, err any) {
+		t.Errorf("%s %s 调用了未提供的服务: %v", c.Request.Method, c.Request.URL.Path, err)
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.SetupRoutes(engine, nil)
+	return engine
+}
+
+// ginPathPattern gin 路由参数，如 :project_id 或 *any
+var ginPathPattern = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// specPathParam 规范路径参数，如 {project_id}
+var specPathParam = regexp.MustCompile(`\{([A-Za-z_]+)\}`)
+
+// registeredRoutes 返回 basePath 下注册的路由，路径转换为规范格式
+func registeredRoutes(engine *gin.Engine, basePath string) map[string]bool {
+	result := make(map[string]bool)
+	for _, route := range engine.Routes() {
+		if !strings.HasPrefix(route.Path, basePath+"/") {
+			continue
+		}
+		path := ginPathPattern.ReplaceAllString(strings.TrimPrefix(route.Path, basePath), "{$1}")
+		result[route.Method+" "+path] = true
+	}
+	return result
+}
+
+// envelope 统一响应格式
+type envelope struct {
+	Success *bool            `json:"success"`
+	Data    json.RawMessage  `json:"data"`
+	Error   *envelopeError   `json:"error"`
+	Meta    *json.RawMessage `json:"meta"`
+}
+
+type envelopeError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// assertEnvelope 校验响应体符合统一响应格式，返回错误码
+func assertEnvelope(t *testing.T, name string, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body envelope
+	if !assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body), "%s 响应不是 JSON: %s", name, rec.Body.String()) {
+		return ""
+	}
+	if !assert.NotNil(t, body.Success, "%s 响应缺少 success 字段", name) {
+		return ""
+	}
+
+	if rec.Code < http.StatusBadRequest {
+		assert.True(t, *body.Success, "%s 状态码 %d 但 success=false", name, rec.Code)
+		assert.Nil(t, body.Error, "%s 成功响应不应包含 error", name)
+		return ""
+	}
+
+	assert.False(t, *body.Success, "%s 状态码 %d 但 success=true", name, rec.Code)
+	if assert.NotNil(t, body.Error, "%s 错误响应缺少 error 字段", name) {
+		assert.NotEmpty(t, body.Error.Code, "%s 错误响应缺少 error.code", name)
+		assert.NotEmpty(t, body.Error.Message, "%s 错误响应缺少 error.message", name)
+		return body.Error.Code
+	}
+	return ""
+}
+
+// documentedStatus 检查状态码是否在接口的响应声明中
+func documentedStatus(op *spec.Operation, status int) bool {
+	if op.Responses == nil {
+		return false
+	}
+	_, ok := op.Responses.StatusCodeResponses[status]
+	return ok
+}
+
+// fillPath 将规范路径参数替换为示例值
+func fillPath(path string) string {
+	return specPathParam.ReplaceAllString(path, "1")
+}
+
+func TestContract_SecurityDefinitions(t *testing.T) {
+	swagger := loadSpec(t)
+
+	for _, op := range operations(swagger) {
+		for _, requirement := range op.op.Security {
+			for name := range requirement {
+				assert.Contains(t, swagger.SecurityDefinitions, name, "%s 使用了未定义的认证方式 %s", op, name)
+			}
+		}
+	}
+}
+
+func TestContract_DocumentedEndpointsAreRouted(t *testing.T) {
+	swagger := loadSpec(t)
+	routed := registeredRoutes(newTestEngine(t), swagger.BasePath)
+
+	documented := make(map[string]bool)
+	for _, op := range operations(swagger) {
+		documented[op.String()] = true
+		assert.True(t, routed[op.String()], "文档中的接口 %s 没有注册路由", op)
+	}
+
+	for route := range routed {
+		assert.True(t, documented[route], "路由 %s 缺少 swag 注释", route)
+	}
+}
+
+func TestContract_EndpointsDocumentSuccessResponse(t *testing.T) {
+	swagger := loadSpec(t)
+
+	for _, op := range operations(swagger) {
+		hasSuccess := false
+		if op.op.Responses != nil {
+			for status := range op.op.Responses.StatusCodeResponses {
+				// WebSocket 接口升级连接成功时返回 101，单点登录跳转成功时返回 302
+				if status >= 200 && status < 300 || status == http.StatusSwitchingProtocols || status == http.StatusFound {
+					hasSuccess = true
+				}
+			}
+		}
+		assert.True(t, hasSuccess, "%s 没有声明成功响应", op)
+	}
+}
+
+func TestContract_SecuredEndpointsRejectAnonymous(t *testing.T) {
+	swagger := loadSpec(t)
+	engine := newTestEngine(t)
+
+	for _, op := range operations(swagger) {
+		if len(op.op.Security) == 0 {
+			continue
+		}
+		name := op.String()
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(op.method, swagger.BasePath+fillPath(op.path), strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			// 声明了 @Security 即隐含 401，无需在每个接口重复声明
+			require.Equal(t, http.StatusUnauthorized, rec.Code, "%s 未认证时应返回 401: %s", name, rec.Body.String())
+			code := assertEnvelope(t, name, rec)
+			assert.Contains(t, authErrorCodes, code)
+		})
+	}
+}
+
+func TestContract_PublicEndpoints(t *testing.T) {
+	swagger := loadSpec(t)
+	engine := newTestEngine(t)
+
+	for _, op := range operations(swagger) {
+		if len(op.op.Security) > 0 {
+			continue
+		}
+		name := op.String()
+		t.Run(name, func(t *testing.T) {
+			request, ok := publicRequests[name]
+			require.True(t, ok, "公开接口 %s 缺少契约测试请求，请在 publicRequests 中补充；若接口需要认证请添加 @Security", name)
+
+			path := request.path
+			if path == "" {
+				path = fillPath(op.path)
+			}
+			req := httptest.NewRequest(op.method, swagger.BasePath+path, strings.NewReader(request.body))
+			if request.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			assert.NotEqual(t, http.StatusUnauthorized, rec.Code, "%s 未声明 @Security 但要求认证", name)
+			assert.True(t, documentedStatus(op.op, rec.Code), "%s 返回了未声明的状态码 %s", name, strconv.Itoa(rec.Code))
+			assertEnvelope(t, name, rec)
+		})
+	}
+}
+
+func TestContract_RouteAccessMatchesSecurity(t *testing.T) {
+	swagger := loadSpec(t)
+	access, err := apispec.RouteAccess(backendRoot)
+	require.NoError(t, err, "解析路由注册代码失败")
+
+	for _, op := range operations(swagger) {
+		level, ok := access[op.method+" "+swagger.BasePath+op.path]
+		if !assert.True(t, ok, "%s 没有解析到路由的访问级别", op) {
+			continue
+		}
+
+		security := make(map[string]bool)
+		for _, requirement := range op.op.Security {
+			for name := range requirement {
+				security[name] = true
+			}
+		}
+		switch level {
+		case apispec.AccessPublic:
+			assert.Empty(t, security, "%s 是公开路由但声明了 @Security", op)
+		case apispec.AccessCLI:
+			assert.True(t, security["ApiKeyAuth"], "%s 使用 API Key 认证但没有声明 @Security ApiKeyAuth", op)
+		default:
+			assert.True(t, security["BearerAuth"], "%s 需要登录但没有声明 @Security BearerAuth", op)
+		}
+	}
+}
+
+func TestContract_AudienceSpecs(t *testing.T) {
+	swagger := loadSpec(t)
+	access, err := apispec.RouteAccess(backendRoot)
+	require.NoError(t, err)
+
+	counts := make(map[string]int)
+	for _, audience := range apispec.Audiences {
+		filtered, err := apispec.Filter(swagger, access, audience)
+		require.NoError(t, err)
+
+		allowed := make(map[string]bool)
+		for _, level := range audience.Access {
+			allowed[level] = true
+		}
+		for _, op := range operations(filtered) {
+			assert.True(t, allowed[access[op.method+" "+swagger.BasePath+op.path]], "%s 规范包含了 %s", audience.Name, op)
+			if audience.ReadOnly {
+				assert.Equal(t, http.MethodGet, op.method, "%s 规范只应包含只读接口", audience.Name)
+			}
+		}
+		// 引用的模型定义都保留
+		data, err := json.Marshal(filtered)
+		require.NoError(t, err)
+		for _, match := range regexp.MustCompile(`"#/definitions/([^"]+)"`).FindAllStringSubmatch(string(data), -1) {
+			assert.Contains(t, filtered.Definitions, match[1], "%s 规范缺少模型定义", audience.Name)
+		}
+		counts[audience.Name] = len(operations(filtered))
+	}
+
+	assert.Len(t, operations(swagger), counts["cli"]+counts["admin"], "CLI 和管理员规范应覆盖全部接口")
+	assert.Less(t, counts["viewer"], counts["editor"])
+	assert.Less(t, counts["editor"], counts["owner"])
+	assert.Less(t, counts["owner"], counts["admin"])
+
+	// 服务按名称返回生成的规范
+	engine := newTestEngine(t)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi/cli.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var served spec.Swagger
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	assert.Contains(t, served.SecurityDefinitions, "ApiKeyAuth")
+	assert.NotContains(t, served.SecurityDefinitions, "BearerAuth")
+
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi/unknown.json", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// 公开分发接口位于 /api 之外，不需要认证，不是 {locale}.json 的路径在调用服务前返回 404
+func TestContract_DeliveryRoutes(t *testing.T) {
+	engine := newTestEngine(t)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/delivery/demo/en", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "NOT_FOUND", assertEnvelope(t, "GET /delivery/demo/en", rec))
+}
+
+// 每个 API 版本注册相同的接口，访问级别与文档中的 v1 一致；未带版本号的 /api 为 v1 的别名
+func TestContract_APIVersions(t *testing.T) {
+	swagger := loadSpec(t)
+	require.Equal(t, "/api/v1", swagger.BasePath)
+	engine := newTestEngine(t)
+	access, err := apispec.RouteAccess(backendRoot)
+	require.NoError(t, err)
+
+	v1 := registeredRoutes(engine, "/api/v1")
+	for _, basePath := range []string{"/api", "/api/v2"} {
+		routed := registeredRoutes(engine, basePath)
+		for route := range v1 {
+			assert.True(t, routed[route], "%s 下没有注册 %s", basePath, route)
+
+			method, path, _ := strings.Cut(route, " ")
+			assert.Equal(t, access[method+" /api/v1"+path], access[method+" "+basePath+path], "%s%s 的访问级别与 v1 不同", basePath, route)
+		}
+		for route := range routed {
+			// /api 下包含各版本的路由
+			if basePath == "/api" && (strings.Contains(route, " /v1/") || strings.Contains(route, " /v2/")) {
+				continue
+			}
+			assert.True(t, v1[route], "%s%s 没有在 v1 中注册", basePath, route)
+		}
+	}
+
+	// 各版本的限流、认证中间件相同，未认证的请求在所有版本中都返回 401
+	for _, path := range []string{"/api/translations/matrix/by-project/1", "/api/v1/translations/matrix/by-project/1", "/api/v2/translations/matrix/by-project/1"} {
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, path)
+	}
+}
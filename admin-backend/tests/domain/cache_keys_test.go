@@ -0,0 +1,83 @@
+package domain_test
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+)
+
+// matchesPattern 按 Redis SCAN MATCH 的 glob 规则匹配（测试中的键不包含 /）
+func matchesPattern(t *testing.T, pattern, key string) bool {
+	t.Helper()
+	matched, err := path.Match(pattern, key)
+	require.NoError(t, err)
+	return matched
+}
+
+// projectKeys 项目命名空间下的代表性缓存键
+func projectKeys(projectID uint64) []string {
+	keys := domain.CacheKeys.Project(projectID)
+	return []string{
+		keys.Info(),
+		keys.Translations("10", "0"),
+		keys.Matrix("all", "10", "0"),
+		keys.Matrix("search", "12345", "10", "0"),
+	}
+}
+
+func TestCacheKeys_ProjectPatternsDoNotOverlap(t *testing.T) {
+	// 覆盖 1 与 10、12、100 等前缀相同的项目 ID
+	ids := []uint64{1, 2, 10, 11, 12, 21, 100, 101, 110, 1000}
+
+	for _, id := range ids {
+		keys := domain.CacheKeys.Project(id)
+		patterns := []string{keys.Pattern(), keys.TranslationsPattern(), keys.MatrixPattern()}
+
+		for _, other := range ids {
+			for _, key := range projectKeys(other) {
+				for _, pattern := range patterns {
+					if other != id {
+						assert.False(t, matchesPattern(t, pattern, key), "项目 %d 的模式 %q 不应匹配 %q", id, pattern, key)
+					}
+				}
+			}
+		}
+
+		// 项目模式匹配自己的全部缓存
+		for _, key := range projectKeys(id) {
+			assert.True(t, matchesPattern(t, keys.Pattern(), key), key)
+		}
+	}
+}
+
+func TestCacheKeys_SectionPatterns(t *testing.T) {
+	keys := domain.CacheKeys.Project(7)
+
+	assert.True(t, matchesPattern(t, keys.TranslationsPattern(), keys.Translations("10", "0")))
+	assert.False(t, matchesPattern(t, keys.TranslationsPattern(), keys.Matrix("all", "10", "0")))
+	assert.True(t, matchesPattern(t, keys.MatrixPattern(), keys.Matrix("page", "abc")))
+	assert.False(t, matchesPattern(t, keys.MatrixPattern(), keys.Info()))
+
+	// 所有项目的矩阵缓存
+	allMatrices := domain.CacheKeys.AllProjectsPattern(domain.CacheSectionMatrix)
+	assert.True(t, matchesPattern(t, allMatrices, keys.Matrix("all", "10", "0")))
+	assert.True(t, matchesPattern(t, allMatrices, domain.CacheKeys.Project(12).Matrix("all", "10", "0")))
+	assert.False(t, matchesPattern(t, allMatrices, keys.Translations("10", "0")))
+}
+
+func TestCacheKeys_GlobalPatternsDoNotMatchProjectKeys(t *testing.T) {
+	for _, key := range projectKeys(1) {
+		assert.False(t, matchesPattern(t, domain.CacheKeys.ProjectListPattern(), key), key)
+		assert.False(t, matchesPattern(t, domain.CacheKeys.AllResponsesPattern(), key), key)
+	}
+	assert.True(t, matchesPattern(t, domain.CacheKeys.ProjectListPattern(), domain.CacheKeys.ProjectList("search", "web", "10", "0")))
+
+	// 项目响应缓存的作用域同样以分隔符结尾
+	response := domain.CacheKeys.Response(domain.ProjectResponseScope(12)) + "cli-translations"
+	assert.False(t, matchesPattern(t, domain.CacheKeys.ResponsePattern(domain.ProjectResponseScope(1)), response))
+	assert.True(t, matchesPattern(t, domain.CacheKeys.ResponsePattern(domain.ProjectResponseScope(12)), response))
+}
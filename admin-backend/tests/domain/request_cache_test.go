@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+)
+
+func TestRequestCache_CopiesAndInvalidates(t *testing.T) {
+	ctx := domain.WithRequestCache(context.Background())
+	cache := domain.RequestCacheFromContext(ctx)
+	require.NotNil(t, cache)
+	assert.Same(t, cache, domain.RequestCacheFromContext(domain.WithRequestCache(ctx)))
+
+	_, ok := cache.Languages(0)
+	assert.False(t, ok)
+
+	// 空列表也是有效的缓存结果
+	cache.SetLanguages(0, nil)
+	languages, ok := cache.Languages(0)
+	assert.True(t, ok)
+	assert.Empty(t, languages)
+
+	cache.SetLanguages(0, []*domain.Language{{ID: 1, Code: "en"}})
+	languages, _ = cache.Languages(0)
+	languages[0].Code = "changed"
+	languages, _ = cache.Languages(0)
+	assert.Equal(t, "en", languages[0].Code)
+
+	cache.InvalidateLanguages()
+	_, ok = cache.Languages(0)
+	assert.False(t, ok)
+
+	cache.SetProject(&domain.Project{ID: 7, Name: "web"})
+	project, ok := cache.Project(7)
+	require.True(t, ok)
+	project.Name = "changed"
+	project, _ = cache.Project(7)
+	assert.Equal(t, "web", project.Name)
+
+	cache.InvalidateProject(7)
+	_, ok = cache.Project(7)
+	assert.False(t, ok)
+}
+
+func TestRequestCache_LanguagesKeyedByOrganization(t *testing.T) {
+	ctx := domain.WithRequestCache(context.Background())
+	cache := domain.RequestCacheFromContext(ctx)
+	assert.Same(t, cache, domain.RequestCacheFromContext(domain.WithOrganization(ctx, 1)))
+
+	cache.SetLanguages(1, []*domain.Language{{ID: 1, Code: "en", OrganizationID: 1}})
+	_, ok := cache.Languages(2)
+	assert.False(t, ok)
+
+	cache.SetLanguages(2, []*domain.Language{{ID: 2, Code: "en", OrganizationID: 2}})
+	first, ok := cache.Languages(1)
+	require.True(t, ok)
+	second, ok := cache.Languages(2)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), first[0].ID)
+	assert.Equal(t, uint64(2), second[0].ID)
+
+	cache.InvalidateLanguages()
+	_, ok = cache.Languages(1)
+	assert.False(t, ok)
+	_, ok = cache.Languages(2)
+	assert.False(t, ok)
+}
+
+func TestRequestCache_NilSafe(t *testing.T) {
+	cache := domain.RequestCacheFromContext(context.Background())
+	assert.Nil(t, cache)
+
+	cache.SetLanguages(0, []*domain.Language{{ID: 1}})
+	cache.SetProject(&domain.Project{ID: 1})
+	cache.InvalidateLanguages()
+	cache.InvalidateProject(1)
+
+	_, ok := cache.Languages(0)
+	assert.False(t, ok)
+	_, ok = cache.Project(1)
+	assert.False(t, ok)
+}
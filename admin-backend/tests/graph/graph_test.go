@@ -0,0 +1,212 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+	"yflow/internal/graph"
+)
+
+// stubProjects 按ID或标识查找固定的项目，记录分页参数
+type stubProjects struct {
+	domain.ProjectService
+	projects []*domain.Project
+	limit    int
+	offset   int
+}
+
+func (s *stubProjects) GetByIdentifier(ctx context.Context, identifier string) (*domain.Project, error) {
+	for _, project := range s.projects {
+		if identifier == project.Slug || identifier == strconv.FormatUint(project.ID, 10) {
+			return project, nil
+		}
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (s *stubProjects) GetAccessibleProjects(ctx context.Context, userID uint64, limit, offset int, keyword string) ([]*domain.Project, int64, error) {
+	s.limit, s.offset = limit, offset
+	return s.projects, int64(len(s.projects)), nil
+}
+
+// stubMembers 用户 2 是项目 7 的查看者
+type stubMembers struct {
+	domain.ProjectMemberService
+}
+
+func (stubMembers) CheckPermission(ctx context.Context, userID, projectID uint64, requiredRole string) (bool, error) {
+	return userID == 2 && projectID == 7 && requiredRole == "viewer", nil
+}
+
+// stubLanguages 返回读取语言时所在组织的语言
+type stubLanguages struct {
+	domain.LanguageService
+}
+
+func (stubLanguages) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	if domain.OrganizationFromContext(ctx) != 3 {
+		return nil, nil
+	}
+	return []*domain.Language{{ID: 1, Code: "en", Name: "English", IsDefault: true}, {ID: 2, Code: "de", Name: "Deutsch"}}, nil
+}
+
+// stubTranslations 记录矩阵查询条件
+type stubTranslations struct {
+	domain.TranslationService
+	query domain.TranslationMatrixQuery
+	err   error
+}
+
+func (s *stubTranslations) GetMatrixPage(ctx context.Context, query domain.TranslationMatrixQuery) (*domain.TranslationMatrixPage, error) {
+	s.query = query
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &domain.TranslationMatrixPage{
+		Keys: []string{"home.title", "home.hidden", "home.menu"},
+		Matrix: map[string]map[string]domain.TranslationCell{
+			"home.title": {
+				"en": {ID: 11, Value: "Home", ReviewStatus: "approved", Version: 3, UpdatedAt: time.Unix(1700000000, 0)},
+				"de": {ID: 12, Value: "Start", ReviewStatus: "draft", Version: 1},
+			},
+			"home.menu": {"en": {ID: 13, Value: "Menu"}},
+		},
+		Total: 42,
+	}, nil
+}
+
+// gqlResponse GraphQL 响应
+type gqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newServer(translations *stubTranslations) (*stubProjects, http.Handler) {
+	projects := &stubProjects{projects: []*domain.Project{
+		{ID: 7, Name: "Web", Slug: "web", OrganizationID: 3},
+		{ID: 8, Name: "Mobile", Slug: "mobile", OrganizationID: 3},
+	}}
+	resolver := graph.NewResolver(projects, stubMembers{}, stubLanguages{}, translations)
+	return projects, graph.NewServer(resolver, zap.NewNop())
+}
+
+// query 以指定用户执行查询，userID 为 0 时不带用户
+func query(t *testing.T, h http.Handler, userID uint64, role, q string, variables map[string]interface{}) gqlResponse {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"query": q, "variables": variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if userID != 0 {
+		req = req.WithContext(graph.WithUser(req.Context(), userID, role))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp gqlResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp), rec.Body.String())
+	return resp
+}
+
+func TestProjectQuery_LanguagesAndMatrix(t *testing.T) {
+	translations := &stubTranslations{}
+	_, h := newServer(translations)
+
+	resp := query(t, h, 2, "user", `query($id: ID!) {
+		project(id: $id) {
+			id name organizationId
+			languages { code name isDefault }
+			matrix(page: 2, pageSize: 20, keyword: "home", namespace: "home", tags: ["web"]) {
+				total page pageSize
+				rows { keyName cells { languageCode id value reviewStatus version qaIssues } }
+			}
+		}
+	}`, map[string]interface{}{"id": "web"})
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"project": {
+		"id": "7", "name": "Web", "organizationId": "3",
+		"languages": [{"code": "en", "name": "English", "isDefault": true}, {"code": "de", "name": "Deutsch", "isDefault": false}],
+		"matrix": {"total": 42, "page": 2, "pageSize": 20, "rows": [
+			{"keyName": "home.title", "cells": [
+				{"languageCode": "de", "id": "12", "value": "Start", "reviewStatus": "draft", "version": 1, "qaIssues": []},
+				{"languageCode": "en", "id": "11", "value": "Home", "reviewStatus": "approved", "version": 3, "qaIssues": []}
+			]},
+			{"keyName": "home.menu", "cells": [
+				{"languageCode": "en", "id": "13", "value": "Menu", "reviewStatus": "", "version": 0, "qaIssues": []}
+			]}
+		]}
+	}}`, string(resp.Data))
+	assert.Equal(t, domain.TranslationMatrixQuery{
+		ProjectID: 7, Limit: 20, Offset: 20, Keyword: "home", Namespace: "home", Tags: []string{"web"},
+	}, translations.query)
+}
+
+func TestProjectQuery_Permission(t *testing.T) {
+	_, h := newServer(&stubTranslations{})
+
+	// 不是项目成员
+	resp := query(t, h, 5, "user", `{ project(id: "web") { name } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "INSUFFICIENT_PERMISSION", resp.Errors[0].Extensions["code"])
+	assert.JSONEq(t, `null`, string(resp.Data))
+
+	// 管理员可以访问所有项目
+	resp = query(t, h, 5, "admin", `{ project(id: "mobile") { name } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"project": {"name": "Mobile"}}`, string(resp.Data))
+
+	// 项目不存在
+	resp = query(t, h, 5, "admin", `{ project(id: "desktop") { name } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "PROJECT_NOT_FOUND", resp.Errors[0].Extensions["code"])
+
+	// 未登录
+	resp = query(t, h, 0, "", `{ projects { total } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "UNAUTHORIZED", resp.Errors[0].Extensions["code"])
+}
+
+func TestProjectsQuery_Pagination(t *testing.T) {
+	projects, h := newServer(&stubTranslations{})
+
+	resp := query(t, h, 2, "user", `{ projects(page: 3, pageSize: 500) { total page pageSize items { slug } } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"projects": {"total": 2, "page": 3, "pageSize": 10, "items": [{"slug": "web"}, {"slug": "mobile"}]}}`, string(resp.Data))
+	assert.Equal(t, 10, projects.limit)
+	assert.Equal(t, 20, projects.offset)
+}
+
+func TestQuery_Errors(t *testing.T) {
+	// 非业务错误不向客户端暴露细节
+	_, h := newServer(&stubTranslations{err: assert.AnError})
+	resp := query(t, h, 2, "user", `{ project(id: "web") { matrix { total } } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "INTERNAL_ERROR", resp.Errors[0].Extensions["code"])
+	assert.NotContains(t, resp.Errors[0].Message, assert.AnError.Error())
+
+	// 校验错误原样返回
+	resp = query(t, h, 2, "user", `{ project(id: "web") { unknownField } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.True(t, strings.Contains(resp.Errors[0].Message, "unknownField"), resp.Errors[0].Message)
+
+	// 每页 100 个键的完整矩阵乘以 100 个项目超过复杂度限制
+	resp = query(t, h, 2, "user", `{ projects(pageSize: 100) { items { matrix(pageSize: 100) { rows { keyName cells { value } } } } } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "complexity")
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", resp.Errors[0].Extensions["code"])
+}
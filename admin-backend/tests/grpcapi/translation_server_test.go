@@ -0,0 +1,309 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"yflow/internal/domain"
+	"yflow/internal/grpcapi"
+	"yflow/internal/grpcapi/yflowv1"
+)
+
+// stubProjects 按ID或标识查找固定的项目
+type stubProjects struct {
+	domain.ProjectService
+	projects []*domain.Project
+}
+
+func (s stubProjects) GetByIdentifier(ctx context.Context, identifier string) (*domain.Project, error) {
+	for _, project := range s.projects {
+		if identifier == project.Slug || identifier == strconv.FormatUint(project.ID, 10) {
+			return project, nil
+		}
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+// stubTranslations 项目 7 的翻译，记录读取时所在的组织
+type stubTranslations struct {
+	domain.TranslationService
+	values        map[string]map[string]string
+	organizations []uint64
+}
+
+func (s *stubTranslations) GetKeyPage(ctx context.Context, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	s.organizations = append(s.organizations, domain.OrganizationFromContext(ctx))
+	if query.Release != "" {
+		return nil, domain.ErrReleaseNotFound
+	}
+	var keyNames []string
+	for keyName := range s.values {
+		if keyName > query.AfterKey {
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	sort.Strings(keyNames)
+	page := &domain.TranslationKeyPage{Translations: map[string]map[string]string{}}
+	if len(keyNames) > query.Limit {
+		keyNames, page.HasMore = keyNames[:query.Limit], true
+	}
+	for _, keyName := range keyNames {
+		page.Translations[keyName] = s.values[keyName]
+		page.LastKey = keyName
+	}
+	return page, nil
+}
+
+func (s *stubTranslations) GetMatrixPage(ctx context.Context, query domain.TranslationMatrixQuery) (*domain.TranslationMatrixPage, error) {
+	return &domain.TranslationMatrixPage{
+		Keys: []string{"home.title"},
+		Matrix: map[string]map[string]domain.TranslationCell{
+			"home.title": {"en": {ID: 11, Value: "Home", ValueType: domain.ValueTypeString, ReviewStatus: "approved", Version: 3, UpdatedAt: time.Unix(1700000000, 0)}},
+		},
+		Total: int64(query.Offset + 1),
+	}, nil
+}
+
+// recordingPush 记录推送键参数
+type recordingPush struct {
+	params []domain.PushKeysParams
+}
+
+func (p *recordingPush) Push(ctx context.Context, params domain.PushKeysParams) (*domain.PushKeysResult, error) {
+	p.params = append(p.params, params)
+	return &domain.PushKeysResult{Added: params.Keys, Existed: []string{}, Failed: []string{}}, nil
+}
+
+// recordingUsage 记录拉取用量
+type recordingUsage struct {
+	domain.UsageService
+	records []domain.UsageRecord
+}
+
+func (u *recordingUsage) Record(ctx context.Context, record domain.UsageRecord) {
+	u.records = append(u.records, record)
+}
+
+// stubAPIKeys 只接受固定的 API Key
+type stubAPIKeys struct {
+	domain.APIKeyService
+	keys map[string]*domain.APIKey
+}
+
+func (s stubAPIKeys) Authenticate(ctx context.Context, key, clientIP string) (*domain.APIKey, error) {
+	if apiKey, ok := s.keys[key]; ok {
+		return apiKey, nil
+	}
+	return nil, domain.ErrInvalidToken
+}
+
+type testEnv struct {
+	client       yflowv1.TranslationServiceClient
+	translations *stubTranslations
+	push         *recordingPush
+	usage        *recordingUsage
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	t.Setenv("CLI_API_KEY", "legacy-cli-key-0123456789")
+
+	env := &testEnv{
+		translations: &stubTranslations{values: map[string]map[string]string{
+			"home.title": {"en": "Home", "de": "Start"},
+			"home.menu":  {"en": "Menu"},
+			"cart.total": {"en": "Total"},
+		}},
+		push:  &recordingPush{},
+		usage: &recordingUsage{},
+	}
+	projects := stubProjects{projects: []*domain.Project{
+		{ID: 7, Name: "Web", Slug: "web", Status: "active", OrganizationID: 3},
+		{ID: 8, Name: "App", Slug: "app", Status: "active"},
+	}}
+	apiKeys := stubAPIKeys{keys: map[string]*domain.APIKey{
+		"yfk_read":  {ID: 1, Scope: domain.APIKeyScopeRead, ProjectIDs: "7", OrganizationID: 3},
+		"yfk_write": {ID: 2, Scope: domain.APIKeyScopeWrite},
+		"yfk_org":   {ID: 3, Scope: domain.APIKeyScopeWrite, OrganizationID: 4},
+	}}
+	server := grpcapi.NewServer(projects, env.translations, env.push, env.usage, nil, zap.NewNop())
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpcapi.NewGRPCServer(server, apiKeys)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	env.client = yflowv1.NewTranslationServiceClient(conn)
+	return env
+}
+
+func withAPIKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+// errorReason 获取错误详情中的错误码
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestTranslationServer_Auth(t *testing.T) {
+	env := newTestEnv(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  func(ctx context.Context) error
+		code codes.Code
+	}{
+		{"缺少 API Key", context.Background(), func(ctx context.Context) error {
+			_, err := env.client.GetProject(ctx, &yflowv1.GetProjectRequest{Project: "web"})
+			return err
+		}, codes.Unauthenticated},
+		{"无效的 API Key", withAPIKey("wrong"), func(ctx context.Context) error {
+			_, err := env.client.GetProject(ctx, &yflowv1.GetProjectRequest{Project: "web"})
+			return err
+		}, codes.Unauthenticated},
+		{"未知的管理 API Key", withAPIKey("yfk_unknown"), func(ctx context.Context) error {
+			_, err := env.client.GetProject(ctx, &yflowv1.GetProjectRequest{Project: "web"})
+			return err
+		}, codes.Unauthenticated},
+		{"CLI_API_KEY 可以访问所有项目", withAPIKey("legacy-cli-key-0123456789"), func(ctx context.Context) error {
+			_, err := env.client.GetProject(ctx, &yflowv1.GetProjectRequest{Project: "app"})
+			return err
+		}, codes.OK},
+		{"read 权限可以拉取允许的项目", withAPIKey("yfk_read"), func(ctx context.Context) error {
+			_, err := env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{Project: "web"})
+			return err
+		}, codes.OK},
+		{"read 权限不能访问其他项目", withAPIKey("yfk_read"), func(ctx context.Context) error {
+			_, err := env.client.GetMatrix(ctx, &yflowv1.GetMatrixRequest{Project: "app"})
+			return err
+		}, codes.PermissionDenied},
+		{"read 权限不能推送", withAPIKey("yfk_read"), func(ctx context.Context) error {
+			_, err := env.client.PushKeys(ctx, &yflowv1.PushKeysRequest{Project: "web", Keys: []string{"home.new"}})
+			return err
+		}, codes.PermissionDenied},
+		{"组织的 API Key 不能访问其他组织的项目", withAPIKey("yfk_org"), func(ctx context.Context) error {
+			_, err := env.client.GetProject(ctx, &yflowv1.GetProjectRequest{Project: "web"})
+			return err
+		}, codes.PermissionDenied},
+		{"项目不存在", withAPIKey("yfk_write"), func(ctx context.Context) error {
+			_, err := env.client.GetProject(ctx, &yflowv1.GetProjectRequest{Project: "missing"})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, status.Code(tt.req(tt.ctx)))
+		})
+	}
+
+	_, err := env.client.PushKeys(withAPIKey("yfk_read"), &yflowv1.PushKeysRequest{Project: "web"})
+	assert.Equal(t, "API_KEY_SCOPE_DENIED", errorReason(t, err))
+	_, err = env.client.GetProject(withAPIKey("yfk_write"), &yflowv1.GetProjectRequest{Project: "missing"})
+	assert.Equal(t, "PROJECT_NOT_FOUND", errorReason(t, err))
+	assert.Empty(t, env.push.params)
+}
+
+func TestTranslationServer_PullTranslations(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := metadata.AppendToOutgoingContext(withAPIKey("yfk_read"), "x-yflow-client", "ci")
+
+	// 按键名分页，cursor 取自上一页
+	first, err := env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{Project: "7", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Translations, 2)
+	assert.Equal(t, "cart.total", first.Translations[0].KeyName)
+	assert.Equal(t, "home.menu", first.Translations[1].KeyName)
+	assert.True(t, first.HasMore)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{Project: "7", Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, second.Translations, 1)
+	assert.Equal(t, "home.title", second.Translations[0].KeyName)
+	assert.Equal(t, map[string]string{"en": "Home", "de": "Start"}, second.Translations[0].Values)
+	assert.False(t, second.HasMore)
+	assert.Empty(t, second.NextCursor)
+
+	// 读取翻译时使用项目所属的组织，拉取计入 gRPC 用量
+	assert.Equal(t, []uint64{3, 3}, env.translations.organizations)
+	require.Len(t, env.usage.records, 2)
+	assert.Equal(t, domain.UsageChannelGRPC, env.usage.records[0].Channel)
+	assert.Equal(t, uint64(7), env.usage.records[0].ProjectID)
+	assert.Regexp(t, `^api_key:[0-9a-f]{12}/ci$`, env.usage.records[0].Consumer)
+
+	// 无效的参数和不存在的发布版本
+	_, err = env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{Project: "web", Cursor: "%%"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{Project: "web", Limit: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = env.client.PullTranslations(ctx, &yflowv1.PullTranslationsRequest{Project: "web", Release: "v9.9.9"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "RELEASE_NOT_FOUND", errorReason(t, err))
+	assert.Len(t, env.usage.records, 2)
+}
+
+func TestTranslationServer_GetMatrixAndPushKeys(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := withAPIKey("yfk_write")
+
+	matrix, err := env.client.GetMatrix(ctx, &yflowv1.GetMatrixRequest{Project: "web", Page: 3, PageSize: 500})
+	require.NoError(t, err)
+	// 超出范围的每页数量使用默认值
+	assert.Equal(t, int32(10), matrix.PageSize)
+	assert.Equal(t, int64(21), matrix.Total)
+	require.Len(t, matrix.Rows, 1)
+	cell := matrix.Rows[0].Cells["en"]
+	require.NotNil(t, cell)
+	assert.Equal(t, "Home", cell.Value)
+	assert.Equal(t, "approved", cell.ReviewStatus)
+	assert.Equal(t, uint64(3), cell.Version)
+	assert.Equal(t, int64(1700000000), cell.UpdatedAt.GetSeconds())
+
+	resp, err := env.client.PushKeys(ctx, &yflowv1.PushKeysRequest{
+		Project: "web",
+		Keys:    []string{"home.new"},
+		Translations: map[string]*yflowv1.LocaleValues{
+			"en": {Values: map[string]string{"home.new": "New"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home.new"}, resp.Added)
+	require.Len(t, env.push.params, 1)
+	assert.Equal(t, domain.PushKeysParams{
+		ProjectID:    7,
+		Keys:         []string{"home.new"},
+		Translations: map[string]map[string]string{"en": {"home.new": "New"}},
+	}, env.push.params[0])
+}
@@ -0,0 +1,97 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func newAssignmentService() *service.AssignmentService {
+	return service.NewAssignmentService(
+		repository.NewAssignmentRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewProjectMemberRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestAssignment_UserTasks(t *testing.T) {
+	ctx := context.Background()
+	svc := newAssignmentService()
+	translations := newTranslationService()
+	project, _, target := seedExportTranslations(t)
+
+	users := make(map[string]*domain.User)
+	for _, role := range []string{"editor", "owner", "viewer"} {
+		user := &domain.User{Username: uniqueName("it-" + role), Email: uniqueName("it-"+role) + "@example.com", Password: "x", Status: "active"}
+		require.NoError(t, testDB.Create(user).Error)
+		require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: project.ID, UserID: user.ID, Role: role}).Error)
+		users[role] = user
+	}
+	translator, reviewer := users["editor"], users["owner"]
+
+	// 只能分配给编辑者或所有者，键必须存在
+	_, err := svc.Assign(ctx, project.ID, domain.AssignParams{UserID: users["viewer"].ID, LanguageCode: target.Code, Type: domain.AssignmentTypeTranslate}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidAssignment.Code, appErr.Code)
+	_, err = svc.Assign(ctx, project.ID, domain.AssignParams{UserID: translator.ID, LanguageCode: target.Code, KeyNames: []string{"missing"}, Type: domain.AssignmentTypeTranslate}, 1)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Contains(t, appErr.Details, "missing")
+
+	_, err = svc.Assign(ctx, project.ID, domain.AssignParams{UserID: translator.ID, LanguageCode: target.Code, Type: domain.AssignmentTypeTranslate}, 1)
+	require.NoError(t, err)
+	// 分配到具体键的优先：farewell 改由审核人翻译
+	assignments, err := svc.Assign(ctx, project.ID, domain.AssignParams{UserID: reviewer.ID, LanguageCode: target.Code, KeyNames: []string{"farewell", "farewell"}, Type: domain.AssignmentTypeTranslate}, 1)
+	require.NoError(t, err)
+	require.Len(t, assignments, 2)
+
+	tasks, err := svc.GetUserTasks(ctx, translator.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks.Tasks)
+	tasks, err = svc.GetUserTasks(ctx, reviewer.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, []string{"farewell"}, tasks.Tasks[0].PendingKeys)
+	assert.Equal(t, 1, tasks.Translate)
+
+	// 重新分配给翻译者后，草稿仍然待翻译，提交审核后转为审核任务
+	_, err = svc.Assign(ctx, project.ID, domain.AssignParams{UserID: translator.ID, LanguageCode: target.Code, KeyNames: []string{"farewell"}, Type: domain.AssignmentTypeTranslate}, 1)
+	require.NoError(t, err)
+	_, err = svc.Assign(ctx, project.ID, domain.AssignParams{UserID: reviewer.ID, LanguageCode: target.Code, Type: domain.AssignmentTypeReview}, 1)
+	require.NoError(t, err)
+	created, err := translations.Create(ctx, domain.TranslationInput{ProjectID: project.ID, KeyName: "farewell", LanguageID: target.ID, Value: "Tschüss"}, translator.ID)
+	require.NoError(t, err)
+	tasks, err = svc.GetUserTasks(ctx, translator.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, project.Name, tasks.Tasks[0].ProjectName)
+
+	_, err = translations.SubmitForReview(ctx, project.ID, domain.ReviewTransitionParams{TranslationIDs: []uint64{created.ID}}, translator.ID)
+	require.NoError(t, err)
+	tasks, err = svc.GetUserTasks(ctx, translator.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks.Tasks)
+	tasks, err = svc.GetUserTasks(ctx, reviewer.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks.Tasks, 1)
+	assert.Equal(t, domain.AssignmentTypeReview, tasks.Tasks[0].Type)
+	assert.Equal(t, 1, tasks.Review)
+
+	// 其他项目的分配视为不存在
+	other := createProject(t)
+	err = svc.Delete(ctx, other.ID, assignments[0].ID)
+	assert.Equal(t, domain.ErrAssignmentNotFound, err)
+	require.NoError(t, svc.Delete(ctx, project.ID, assignments[0].ID))
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestAttachment_UploadAndMatrixURLs(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewAttachmentService(
+		repository.NewAttachmentRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		service.NewLocalAttachmentStorage(t.TempDir()),
+		nil,
+	)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedPrefixTranslations(t, project.ID, languages, "checkout.title", "checkout.button")
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+
+	// 只接受图片，键必须存在
+	_, err := svc.Upload(ctx, project.ID, domain.AttachmentUploadParams{KeyName: "checkout.title", Data: []byte("not an image")}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidAttachment.Code, appErr.Code)
+	_, err = svc.Upload(ctx, project.ID, domain.AttachmentUploadParams{KeyName: "missing", Data: buf.Bytes()}, 1)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Contains(t, appErr.Details, "missing")
+
+	attachment, err := svc.Upload(ctx, project.ID, domain.AttachmentUploadParams{KeyName: "checkout.title", FileName: "../shots/checkout.png", Data: buf.Bytes()}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "checkout.png", attachment.FileName)
+	assert.Equal(t, "image/png", attachment.ContentType)
+
+	data, contentType, err := svc.GetContent(ctx, project.ID, attachment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), data)
+	assert.Equal(t, "image/png", contentType)
+	// 其他项目不能访问
+	_, _, err = svc.GetContent(ctx, project.ID+1000, attachment.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+
+	// 附件地址出现在该键每个语言的单元格中
+	cells, err := repository.NewTranslationRepository(testDB).GetMatrixCells(ctx, project.ID, []string{"checkout.title", "checkout.button"})
+	require.NoError(t, err)
+	url := domain.AttachmentContentURL(project.ID, attachment.ID)
+	for _, language := range languages {
+		assert.Equal(t, []string{url}, cells["checkout.title"][language.Code].AttachmentURLs)
+		assert.Empty(t, cells["checkout.button"][language.Code].AttachmentURLs)
+	}
+
+	require.NoError(t, svc.Delete(ctx, project.ID, attachment.ID))
+	attachments, err := svc.GetByProjectID(ctx, project.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, attachments)
+}
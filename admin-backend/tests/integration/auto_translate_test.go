@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// fakeMachineTranslation 在原文前加上目标语言代码
+type fakeMachineTranslation struct{}
+
+func (fakeMachineTranslation) Translate(ctx context.Context, text, sourceLang, targetLang string) (*domain.MachineTranslationResult, error) {
+	return &domain.MachineTranslationResult{TranslatedText: targetLang + ":" + text}, nil
+}
+
+func (f fakeMachineTranslation) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]*domain.MachineTranslationResult, error) {
+	results := make([]*domain.MachineTranslationResult, 0, len(texts))
+	for _, text := range texts {
+		result, _ := f.Translate(ctx, text, sourceLang, targetLang)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (fakeMachineTranslation) GetSupportedLanguages(ctx context.Context) ([]domain.MachineTranslationLanguage, error) {
+	return nil, nil
+}
+
+func (fakeMachineTranslation) IsAvailable(ctx context.Context) bool { return true }
+
+func TestAutoTranslate_FillsMissingOnly(t *testing.T) {
+	ctx := context.Background()
+	project, source, target := seedExportTranslations(t)
+	translationRepo := repository.NewTranslationRepository(testDB)
+	svc := service.NewAutoTranslateService(
+		fakeMachineTranslation{},
+		newTranslationService(),
+		translationRepo,
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		0,
+		0,
+	)
+
+	result, err := svc.FillMissing(ctx, project.ID, domain.AutoTranslateParams{TargetLanguages: []string{target.Code}})
+	require.NoError(t, err)
+	assert.Equal(t, source.Code, result.SourceLanguage)
+	// greeting 已有翻译，已废弃的 legacy 不覆盖，只填充 farewell
+	assert.Equal(t, 1, result.Translated)
+	require.Len(t, result.Languages, 1)
+	assert.Equal(t, 0, result.Languages[0].Skipped)
+
+	filled, err := translationRepo.GetByProjectKeyLanguage(ctx, project.ID, "farewell", target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, target.Code+":Bye", filled.Value)
+	greeting, err := translationRepo.GetByProjectKeyLanguage(ctx, project.ID, "greeting", target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", greeting.Value)
+
+	var history domain.TranslationHistory
+	require.NoError(t, testDB.Where("translation_id = ?", filled.ID).Order("id DESC").First(&history).Error)
+	assert.Equal(t, domain.HistoryOperationMachineTranslate, history.Operation)
+
+	_, err = svc.FillMissing(ctx, project.ID, domain.AutoTranslateParams{TargetLanguages: []string{source.Code}})
+	assert.ErrorIs(t, err, domain.ErrIsSourceLanguage)
+}
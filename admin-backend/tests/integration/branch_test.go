@@ -0,0 +1,108 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newBranchService 创建翻译分支服务
+func newBranchService() *service.BranchService {
+	transactor := repository.NewTransactor(testDB)
+	translationRepo := repository.NewTranslationRepository(testDB)
+	projectRepo := repository.NewProjectRepository(testDB)
+	languageRepo := repository.NewLanguageRepository(testDB)
+	translationService := service.NewTranslationService(translationRepo, projectRepo, languageRepo, repository.NewNamespaceRepository(testDB), repository.NewReleaseRepository(testDB), nil, transactor)
+
+	return service.NewBranchService(
+		repository.NewBranchRepository(testDB),
+		projectRepo,
+		languageRepo,
+		translationRepo,
+		translationService,
+		repository.NewAuditLogRepository(testDB),
+		transactor,
+	)
+}
+
+func TestBranch_CopyOnWriteDiffAndMerge(t *testing.T) {
+	ctx := context.Background()
+	svc := newBranchService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	code := languages[0].Code
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.old")
+	translationRepo := repository.NewTranslationRepository(testDB)
+
+	branch, err := svc.Create(ctx, project.ID, domain.BranchParams{Name: "release-2.0"}, 1)
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, project.ID, domain.BranchParams{Name: "release-2.0"}, 1)
+	assert.ErrorIs(t, err, domain.ErrBranchExists)
+
+	// 创建时不复制翻译，分支与主线相同
+	diff, err := svc.Diff(ctx, project.ID, branch.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, diff.Changes)
+
+	_, err = svc.SetTranslations(ctx, project.ID, branch.ID, []domain.BranchTranslationParams{
+		{KeyName: "home.title", LanguageCode: code, Value: "Release title"},
+		{KeyName: "home.new", LanguageCode: code, Value: "Added"},
+		{KeyName: "home.old", LanguageCode: code, Deleted: true},
+	}, 1)
+	require.NoError(t, err)
+	// 再次修改只更新值，保留首次修改时主线的值
+	_, err = svc.SetTranslations(ctx, project.ID, branch.ID, []domain.BranchTranslationParams{
+		{KeyName: "home.title", LanguageCode: code, Value: "Release title 2"},
+	}, 1)
+	require.NoError(t, err)
+
+	values, err := svc.GetValues(ctx, project.ID, branch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"home.title": {code: "Release title 2"},
+		"home.new":   {code: "Added"},
+	}, values)
+
+	// 另一个分支与该分支比较
+	other, err := svc.Create(ctx, project.ID, domain.BranchParams{Name: "hotfix"}, 1)
+	require.NoError(t, err)
+	diff, err = svc.Diff(ctx, project.ID, branch.ID, other.Name)
+	require.NoError(t, err)
+	assert.Len(t, diff.Changes, 3)
+	assert.Zero(t, diff.Conflicts)
+
+	// 主线在分支修改后也修改了 home.title
+	require.NoError(t, translationRepo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Main title", Status: "active"},
+	}))
+	diff, err = svc.Diff(ctx, project.ID, branch.ID, domain.BranchMain)
+	require.NoError(t, err)
+	assert.Equal(t, 1, diff.Conflicts)
+
+	_, err = svc.Merge(ctx, project.ID, branch.ID, domain.BranchMergeParams{}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrBranchMergeConflict.Code, appErr.Code)
+
+	result, err := svc.Merge(ctx, project.ID, branch.ID, domain.BranchMergeParams{Resolutions: []domain.BranchConflictResolution{
+		{Key: "home.title", Language: code, Resolution: domain.BranchResolutionMain},
+	}}, 1)
+	require.NoError(t, err)
+	assert.Len(t, result.Applied, 2)
+	assert.Equal(t, 1, result.Skipped)
+
+	main, err := newTranslationService().GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"home.title": {code: "Main title"},
+		"home.new":   {code: "Added"},
+	}, main)
+}
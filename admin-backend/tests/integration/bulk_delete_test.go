@@ -0,0 +1,92 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newBulkDeleteService 创建按条件批量删除翻译服务
+func newBulkDeleteService() *service.BulkDeleteService {
+	return service.NewBulkDeleteService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+		"bulk-delete-signing-key",
+	)
+}
+
+func TestBulkDelete_PreviewAndConfirm(t *testing.T) {
+	ctx := context.Background()
+	svc := newBulkDeleteService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedPrefixTranslations(t, project.ID, languages, "legacy.a", "legacy.b", "home.title")
+
+	params := domain.BulkDeleteParams{KeyPrefix: "legacy.*", Language: languages[0].Code}
+	preview, err := svc.Preview(ctx, project.ID, params, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), preview.Translations)
+	assert.Equal(t, int64(2), preview.Keys)
+	assert.Equal(t, []string{"legacy.a", "legacy.b"}, preview.SampleKeys)
+	require.NotEmpty(t, preview.ConfirmationToken)
+
+	// 令牌绑定操作人和筛选条件
+	params.ConfirmationToken = preview.ConfirmationToken
+	_, err = svc.Delete(ctx, project.ID, params, 2)
+	assert.ErrorIs(t, err, domain.ErrBulkDeleteTokenInvalid)
+	_, err = svc.Delete(ctx, project.ID, domain.BulkDeleteParams{KeyPrefix: "legacy.", ConfirmationToken: preview.ConfirmationToken}, 1)
+	assert.ErrorIs(t, err, domain.ErrBulkDeleteTokenInvalid)
+
+	result, err := svc.Delete(ctx, project.ID, params, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Deleted)
+	assert.Equal(t, 1, result.Batches)
+
+	translations, _, err := repository.NewTranslationRepository(testDB).CountByFilter(ctx, domain.TranslationFilter{ProjectID: project.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), translations)
+
+	logs, total, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, domain.AuditActionBulkDelete, logs[0].Action)
+
+	// 删除后匹配结果已变化，令牌不能重复使用
+	_, err = svc.Delete(ctx, project.ID, params, 1)
+	assert.ErrorIs(t, err, domain.ErrBulkDeleteTokenInvalid)
+}
+
+func TestBulkDelete_RejectsStalePreview(t *testing.T) {
+	ctx := context.Background()
+	svc := newBulkDeleteService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "old.a")
+
+	olderThan := time.Now().Add(time.Hour)
+	params := domain.BulkDeleteParams{OlderThan: &olderThan, Status: "active"}
+	preview, err := svc.Preview(ctx, project.ID, params, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), preview.Translations)
+
+	seedPrefixTranslations(t, project.ID, languages, "old.b")
+	params.ConfirmationToken = preview.ConfirmationToken
+	_, err = svc.Delete(ctx, project.ID, params, 1)
+	assert.ErrorIs(t, err, domain.ErrBulkDeleteTokenInvalid)
+
+	_, err = svc.Preview(ctx, project.ID, domain.BulkDeleteParams{}, 1)
+	assert.ErrorIs(t, err, domain.ErrInvalidBulkDeleteFilter)
+}
@@ -0,0 +1,180 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newCachedTranslationService 创建与生产环境相同装配的带缓存翻译服务
+func newCachedTranslationService() domain.TranslationService {
+	bus := service.NewInMemoryEventBus(nil)
+	service.RegisterCacheInvalidationSubscribers(bus, testCache)
+
+	base := service.NewTranslationService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewNamespaceRepository(testDB),
+		repository.NewReleaseRepository(testDB),
+		bus,
+		repository.NewTransactor(testDB),
+	)
+	return service.NewCachedTranslationService(base, testCache)
+}
+
+func TestCachedTranslationService_UpsertInvalidatesMatrix(t *testing.T) {
+	ctx := context.Background()
+	svc := newCachedTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 3)
+
+	// 第一次读取写入缓存
+	matrix, total, err := svc.GetMatrix(ctx, project.ID, 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, languages[0].Code+"-000", matrix["key.000"][languages[0].Code].Value)
+
+	// 绕过服务直接修改数据库，缓存仍返回旧值，证明读取确实命中了缓存
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ?", project.ID, "key.001").
+		Update("value", "stale-check").Error)
+	matrix, _, err = svc.GetMatrix(ctx, project.ID, 10, 0, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, "stale-check", matrix["key.001"][languages[0].Code].Value)
+
+	// 通过服务写入后缓存失效
+	require.NoError(t, svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "key.000", Value: "fresh"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "key.new", Value: "new"},
+	}))
+
+	matrix, total, err = svc.GetMatrix(ctx, project.ID, 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, "fresh", matrix["key.000"][languages[0].Code].Value)
+	assert.Equal(t, "stale-check", matrix["key.001"][languages[0].Code].Value)
+}
+
+func TestCachedTranslationService_DeleteInvalidatesMatrix(t *testing.T) {
+	ctx := context.Background()
+	svc := newCachedTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedTranslations(t, project.ID, languages, 2)
+
+	matrix, _, err := svc.GetMatrix(ctx, project.ID, 10, 0, "")
+	require.NoError(t, err)
+	require.Contains(t, matrix, "key.001")
+
+	require.NoError(t, svc.Delete(ctx, matrix["key.001"][languages[0].Code].ID))
+
+	matrix, total, err := svc.GetMatrix(ctx, project.ID, 10, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.NotContains(t, matrix, "key.001")
+}
+
+func TestCachedTranslationService_InvalidationIsProjectScoped(t *testing.T) {
+	ctx := context.Background()
+	svc := newCachedTranslationService()
+	languages := createLanguages(t, 1)
+	projectA := createProject(t)
+	projectB := createProject(t)
+	seedTranslations(t, projectA.ID, languages, 2)
+	seedTranslations(t, projectB.ID, languages, 2)
+
+	_, _, err := svc.GetMatrix(ctx, projectA.ID, 10, 0, "")
+	require.NoError(t, err)
+	_, _, err = svc.GetMatrix(ctx, projectB.ID, 10, 0, "")
+	require.NoError(t, err)
+
+	// 写入项目 A 不应清除项目 B 的缓存
+	require.NoError(t, svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: projectA.ID, LanguageID: languages[0].ID, KeyName: "key.000", Value: "a"},
+	}))
+
+	exists, err := testCache.Exists(ctx, domain.CacheKeys.Project(projectB.ID).Matrix("all", "10", "0"))
+	require.NoError(t, err)
+	assert.True(t, exists, "项目 B 的矩阵缓存不应被清除")
+
+	exists, err = testCache.Exists(ctx, domain.CacheKeys.Project(projectA.ID).Matrix("all", "10", "0"))
+	require.NoError(t, err)
+	assert.False(t, exists, "项目 A 的矩阵缓存应被清除")
+}
+
+func TestCachedLanguageService_UpdateInvalidatesList(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewCachedLanguageService(
+		service.NewLanguageService(repository.NewLanguageRepository(testDB)),
+		testCache,
+	)
+	language := createLanguages(t, 1)[0]
+
+	_, err := svc.GetAll(ctx)
+	require.NoError(t, err)
+
+	_, err = svc.Update(ctx, language.ID, domain.CreateLanguageParams{Code: language.Code, Name: "Renamed"}, 0)
+	require.NoError(t, err)
+
+	languages, err := svc.GetAll(ctx)
+	require.NoError(t, err)
+	for _, l := range languages {
+		if l.ID == language.ID {
+			assert.Equal(t, "Renamed", l.Name)
+			return
+		}
+	}
+	t.Fatalf("语言 %d 不在列表中", language.ID)
+}
+
+func TestCachedTranslationService_UpsertInvalidatesStats(t *testing.T) {
+	ctx := context.Background()
+	svc := newCachedTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 4)
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ? AND language_id = ?", project.ID, "key.000", languages[1].ID).
+		Update("value", "").Error)
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ? AND language_id = ?", project.ID, "key.001", languages[1].ID).
+		Update("review_status", domain.ReviewStatusOutdated).Error)
+
+	statsFor := func(stats *domain.ProjectStats, code string) domain.LanguageStats {
+		for _, language := range stats.Languages {
+			if language.LanguageCode == code {
+				return language
+			}
+		}
+		t.Fatalf("统计中缺少语言 %s", code)
+		return domain.LanguageStats{}
+	}
+
+	stats, err := svc.GetProjectStats(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.TotalKeys)
+	assert.Equal(t, domain.LanguageStats{LanguageCode: languages[0].Code, LanguageName: languages[0].Name, Translated: 4, Percentage: 100}, statsFor(stats, languages[0].Code))
+	assert.Equal(t, domain.LanguageStats{LanguageCode: languages[1].Code, LanguageName: languages[1].Name, Translated: 2, Outdated: 1, Untranslated: 1, Percentage: 50}, statsFor(stats, languages[1].Code))
+
+	// 通过服务写入后统计缓存失效
+	require.NoError(t, svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: project.ID, LanguageID: languages[1].ID, KeyName: "key.000", Value: "filled"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "key.new", Value: "new"},
+	}))
+	stats, err = svc.GetProjectStats(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalKeys)
+	assert.Equal(t, 3, statsFor(stats, languages[1].Code).Translated)
+	assert.Equal(t, 1, statsFor(stats, languages[1].Code).Untranslated)
+	assert.Equal(t, float64(60), statsFor(stats, languages[1].Code).Percentage)
+}
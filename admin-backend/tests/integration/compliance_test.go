@@ -0,0 +1,206 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newComplianceService 创建历史导出和合规报告服务
+func newComplianceService() *service.ComplianceService {
+	return service.NewComplianceService(
+		repository.NewTranslationHistoryRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewUserRepository(testDB),
+	)
+}
+
+// projectHistory 按 ID 顺序获取项目的变更历史
+func projectHistory(t *testing.T, projectID uint64) []*domain.TranslationHistory {
+	t.Helper()
+	var entries []*domain.TranslationHistory
+	require.NoError(t, testDB.Where("project_id = ?", projectID).Order("id ASC").Find(&entries).Error)
+	return entries
+}
+
+func TestTranslationHistory_RecordedOnWrites(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 7)
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	translation := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Hello", Status: "active"}
+	require.NoError(t, repo.Create(ctx, translation))
+
+	translation.Value = "Welcome"
+	translation.UpdatedBy = 3
+	require.NoError(t, repo.Update(ctx, translation))
+
+	_, err := repo.RenamePrefix(ctx, project.ID, "home.", "landing.", 4)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, translation.ID))
+
+	// 删除后重新创建同键翻译时恢复原记录
+	require.NoError(t, repo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "landing.title", LanguageID: languages[0].ID, Value: "Back", Status: "active"},
+	}))
+
+	entries := projectHistory(t, project.ID)
+	require.Len(t, entries, 5)
+	operations := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		operations = append(operations, entry.Operation)
+		assert.Equal(t, translation.ID, entry.TranslationID)
+	}
+	assert.Equal(t, []string{
+		domain.HistoryOperationCreate,
+		domain.HistoryOperationUpdate,
+		domain.HistoryOperationRename,
+		domain.HistoryOperationDelete,
+		domain.HistoryOperationRestore,
+	}, operations)
+
+	assert.Equal(t, uint64(7), entries[0].UserID) // 未显式指定时取 context 中的操作人
+	assert.Equal(t, "Welcome", entries[1].NewValue)
+	assert.Equal(t, uint64(3), entries[1].UserID)
+	assert.Equal(t, "landing.title", entries[2].KeyName)
+	assert.Equal(t, "home.title", entries[2].PreviousKey)
+	assert.Equal(t, uint64(4), entries[2].UserID)
+	assert.Equal(t, "Welcome", entries[3].OldValue)
+	assert.Equal(t, uint64(7), entries[3].UserID)
+	assert.Equal(t, "Welcome", entries[4].OldValue)
+	assert.Equal(t, "Back", entries[4].NewValue)
+}
+
+func TestComplianceService_ExportHistory(t *testing.T) {
+	ctx := context.Background()
+	svc := newComplianceService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "a.key", "b.key")
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+
+	var buf bytes.Buffer
+	require.NoError(t, svc.ExportHistory(ctx, domain.HistoryExportParams{
+		ProjectID: project.ID,
+		Source:    domain.HistorySourceTranslations,
+		Format:    domain.HistoryFormatCSV,
+		From:      from,
+		To:        to,
+	}, 1, &buf))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "key_name", records[0][4])
+	assert.Equal(t, "a.key", records[1][4])
+	assert.Equal(t, languages[0].Code, records[1][6])
+	assert.Equal(t, domain.HistoryOperationCreate, records[1][7])
+
+	// 导出操作记录在审计日志中，可以再以 JSONL 导出
+	buf.Reset()
+	require.NoError(t, svc.ExportHistory(ctx, domain.HistoryExportParams{
+		ProjectID: project.ID,
+		Source:    domain.HistorySourceAudit,
+		Format:    domain.HistoryFormatJSONL,
+		From:      from,
+		To:        to,
+	}, 1, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var row map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	assert.Equal(t, domain.AuditActionHistoryExport, row["action"])
+
+	// 参数无效时不写入任何内容
+	buf.Reset()
+	err = svc.ExportHistory(ctx, domain.HistoryExportParams{
+		ProjectID: project.ID,
+		Source:    domain.HistorySourceTranslations,
+		Format:    "xlsx",
+		From:      from,
+		To:        to,
+	}, 1, &buf)
+	assert.ErrorIs(t, err, domain.ErrInvalidHistoryFormat)
+	assert.Zero(t, buf.Len())
+}
+
+func TestComplianceService_Report(t *testing.T) {
+	ctx := context.Background()
+	svc := newComplianceService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedPrefixTranslations(t, project.ID, languages, "a.key")
+
+	_, err := repository.NewTranslationRepository(testDB).DeleteByPrefix(ctx, project.ID, "a.", 6)
+	require.NoError(t, err)
+	require.NoError(t, svc.ExportHistory(ctx, domain.HistoryExportParams{
+		ProjectID: project.ID,
+		Source:    domain.HistorySourceAudit,
+		Format:    domain.HistoryFormatCSV,
+		From:      time.Now().Add(-time.Hour),
+		To:        time.Now().Add(time.Hour),
+	}, 6, &bytes.Buffer{}))
+
+	month := time.Now().Format("2006-01")
+	report, err := svc.GetReport(ctx, domain.ComplianceReportParams{ProjectID: project.ID, From: month, To: month})
+	require.NoError(t, err)
+	require.Len(t, report.Projects, 1)
+	assert.Equal(t, project.Name, report.Projects[0].ProjectName)
+	require.Len(t, report.Projects[0].Months, 1)
+
+	// 未记录操作人的写入（如 CLI 和测试数据）归为用户 0
+	users := report.Projects[0].Months[0].Users
+	require.Len(t, users, 2)
+	assert.Equal(t, uint64(0), users[0].UserID)
+	assert.Equal(t, map[string]int64{domain.HistoryOperationCreate: 2}, users[0].Changes)
+	assert.Equal(t, uint64(6), users[1].UserID)
+	assert.Equal(t, map[string]int64{domain.HistoryOperationDelete: 2}, users[1].Changes)
+	assert.Equal(t, int64(1), users[1].Exports)
+
+	_, err = svc.GetReport(ctx, domain.ComplianceReportParams{From: "2026-06", To: "2026-01"})
+	assert.ErrorIs(t, err, domain.ErrInvalidHistoryRange)
+}
+
+func TestTranslationHistory_CountDailyActivity(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 7)
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	translation := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Hello", Status: "active"}
+	require.NoError(t, repo.Create(ctx, translation))
+	translation.Value = "Welcome"
+	require.NoError(t, repo.Update(ctx, translation))
+	_, err := repo.RenamePrefix(ctx, project.ID, "home.", "landing.", 7)
+	require.NoError(t, err)
+
+	now := time.Now()
+	counts, err := repository.NewTranslationHistoryRepository(testDB).CountDailyActivity(ctx, domain.HistoryQuery{
+		ProjectID: project.ID, From: now.Add(-time.Hour), To: now.Add(time.Hour),
+	}, []string{domain.HistoryOperationCreate, domain.HistoryOperationUpdate})
+	require.NoError(t, err)
+	require.Len(t, counts, 2, "只统计指定的操作类型")
+	for _, count := range counts {
+		assert.Equal(t, now.Format("2006-01-02"), count.Date)
+		assert.Equal(t, project.ID, count.ProjectID)
+		assert.Equal(t, uint64(7), count.UserID)
+		assert.Equal(t, int64(1), count.Count)
+	}
+	assert.Equal(t, domain.HistoryOperationCreate, counts[0].Action)
+	assert.Equal(t, domain.HistoryOperationUpdate, counts[1].Action)
+}
@@ -0,0 +1,97 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestTranslationRepository_GetPageByProjectID(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 12)
+
+	// 逐页读取直到没有下一页，每条翻译只出现一次且按 ID 递增
+	var afterID uint64
+	seen := 0
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "分页未结束")
+		page, err := repo.GetPageByProjectID(ctx, domain.TranslationPageQuery{ProjectID: project.ID, AfterID: afterID, Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(24), page.Total)
+		for _, translation := range page.Translations {
+			assert.Greater(t, translation.ID, afterID)
+			assert.NotNil(t, translation.Language)
+			afterID = translation.ID
+		}
+		seen += len(page.Translations)
+		if !page.HasMore {
+			break
+		}
+	}
+	assert.Equal(t, 24, seen)
+
+	// 按审核状态筛选时总数也只统计该状态
+	page, err := repo.GetPageByProjectID(ctx, domain.TranslationPageQuery{ProjectID: project.ID, ReviewStatus: domain.ReviewStatusApproved, Limit: 10})
+	require.NoError(t, err)
+	assert.Zero(t, page.Total)
+	assert.Empty(t, page.Translations)
+	assert.False(t, page.HasMore)
+}
+
+func TestAuditLogRepository_GetPage(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewAuditLogRepository(testDB)
+	project := createProject(t)
+
+	// 两条日志的创建时间相同，游标需要用 ID 区分
+	base := time.Now().UTC().Truncate(time.Second)
+	for _, offset := range []time.Duration{0, time.Second, time.Second, 2 * time.Second, 3 * time.Second} {
+		require.NoError(t, repo.Create(ctx, &domain.AuditLog{
+			ProjectID: project.ID,
+			Action:    domain.AuditActionBulkDelete,
+			Details:   []byte("{}"),
+			CreatedAt: base.Add(offset),
+		}))
+	}
+
+	var (
+		after *domain.AuditLogCursor
+		ids   []uint64
+		last  time.Time
+	)
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "分页未结束")
+		page, err := repo.GetPage(ctx, domain.AuditLogPageQuery{ProjectID: project.ID, After: after, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), page.Total)
+		for _, log := range page.Logs {
+			if !last.IsZero() {
+				assert.False(t, log.CreatedAt.After(last), "日志应按时间倒序")
+			}
+			last = log.CreatedAt
+			ids = append(ids, log.ID)
+		}
+		if !page.HasMore {
+			break
+		}
+		tail := page.Logs[len(page.Logs)-1]
+		after = &domain.AuditLogCursor{CreatedAt: tail.CreatedAt, ID: tail.ID}
+	}
+	assert.Len(t, ids, 5)
+	unique := make(map[uint64]bool)
+	for _, id := range ids {
+		unique[id] = true
+	}
+	assert.Len(t, unique, 5, "同一时间的日志不应重复或遗漏")
+}
@@ -0,0 +1,122 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestDelivery_ServesLatestReleaseOfEnabledProjects(t *testing.T) {
+	ctx := context.Background()
+	project, source, target := seedExportTranslations(t)
+	delivery := service.NewDeliveryService(repository.NewProjectRepository(testDB), repository.NewReleaseRepository(testDB), nil)
+	releases := newReleaseService()
+
+	_, _, err := releases.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0"}, 1)
+	require.NoError(t, err)
+
+	// 未开启公开分发时不分发
+	_, err = delivery.GetBundle(ctx, project.Slug, source.Code, "")
+	assert.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+
+	projects := newProjectService()
+	enabled, err := projects.SetDelivery(ctx, project.ID, true, 1)
+	require.NoError(t, err)
+	assert.True(t, enabled.DeliveryEnabled)
+
+	bundle, err := delivery.GetBundle(ctx, project.Slug, target.Code, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", bundle.Release)
+	// 已废弃的翻译没有冻结到发布版本中
+	assert.JSONEq(t, `{"greeting":"Hallo"}`, string(bundle.Body))
+
+	// 发布后修改的翻译在发布新版本后才分发
+	require.NoError(t, repository.NewTranslationRepository(testDB).UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "greeting", LanguageID: target.ID, Value: "Servus", Status: "active"},
+	}))
+	unchanged, err := delivery.GetBundle(ctx, project.Slug, target.Code, "")
+	require.NoError(t, err)
+	assert.Equal(t, bundle.ETag, unchanged.ETag)
+	_, _, err = releases.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.1.0", Override: true}, 1)
+	require.NoError(t, err)
+	latest, err := delivery.GetBundle(ctx, project.Slug, target.Code, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0", latest.Release)
+	assert.JSONEq(t, `{"greeting":"Servus"}`, string(latest.Body))
+
+	// 关闭后不再分发，开启和关闭都记录审计日志
+	_, err = projects.SetDelivery(ctx, project.ID, false, 1)
+	require.NoError(t, err)
+	_, err = delivery.GetBundle(ctx, project.Slug, target.Code, "")
+	assert.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+
+	logs, _, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	actions := make([]string, 0, len(logs))
+	for _, log := range logs {
+		actions = append(actions, log.Action)
+	}
+	assert.Contains(t, actions, domain.AuditActionDeliveryEnable)
+	assert.Contains(t, actions, domain.AuditActionDeliveryDisable)
+}
+
+func TestDelivery_RolloutServesCanaryToBucketedClients(t *testing.T) {
+	ctx := context.Background()
+	project, _, target := seedExportTranslations(t)
+	delivery := service.NewDeliveryService(repository.NewProjectRepository(testDB), repository.NewReleaseRepository(testDB), nil)
+	releases := newReleaseService()
+	_, err := newProjectService().SetDelivery(ctx, project.ID, true, 1)
+	require.NoError(t, err)
+
+	_, _, err = releases.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0"}, 1)
+	require.NoError(t, err)
+	require.NoError(t, repository.NewTranslationRepository(testDB).UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "greeting", LanguageID: target.ID, Value: "Servus", Status: "active"},
+	}))
+	canary, _, err := releases.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.1.0", Override: true, RolloutPercentage: 1}, 1)
+	require.NoError(t, err)
+
+	// 灰度进行中不能再发布
+	_, _, err = releases.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.2.0", Override: true}, 1)
+	assert.ErrorIs(t, err, domain.ErrRolloutActive)
+
+	// 百分比很低时大多数客户端仍获取全量版本；全量发布后所有客户端获取新版本
+	bundle, err := delivery.GetBundle(ctx, project.Slug, target.Code, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", bundle.Release)
+	completed, err := releases.AdvanceRollout(ctx, project.ID, canary.ID, 100, 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RolloutStatusCompleted, completed.RolloutStatus)
+	bundle, err = delivery.GetBundle(ctx, project.Slug, target.Code, "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0", bundle.Release)
+	assert.JSONEq(t, `{"greeting":"Servus"}`, string(bundle.Body))
+
+	// 中止的灰度版本不再分发
+	aborted, _, err := releases.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.2.0", Override: true, RolloutPercentage: 99}, 1)
+	require.NoError(t, err)
+	_, err = releases.AbortRollout(ctx, project.ID, aborted.ID, 1)
+	require.NoError(t, err)
+	for _, clientID := range []string{"a", "b", "c", "d", "e"} {
+		bundle, err = delivery.GetBundle(ctx, project.Slug, target.Code, clientID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1.1.0", bundle.Release)
+	}
+
+	logs, _, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 20, 0)
+	require.NoError(t, err)
+	actions := make([]string, 0, len(logs))
+	for _, log := range logs {
+		actions = append(actions, log.Action)
+	}
+	assert.Contains(t, actions, domain.AuditActionRolloutAdvance)
+	assert.Contains(t, actions, domain.AuditActionRolloutAbort)
+}
@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// pngHeader 能被识别为 PNG 的最小内容
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestFigma_SyncFrameAndReferences(t *testing.T) {
+	ctx := context.Background()
+	project, source, target := seedExportTranslations(t)
+	svc := service.NewFigmaService(
+		repository.NewFigmaRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		newTranslationService(),
+		repository.NewTransactor(testDB),
+	)
+
+	params := domain.FigmaFrameParams{
+		FileKey: "abc123",
+		NodeID:  "1:2",
+		Name:    "Home",
+		Layers: []domain.FigmaLayerParams{
+			{NodeID: "1:3", Name: "Title", KeyName: "greeting", Text: "Hello!"},
+			{NodeID: "1:4", Name: "CTA", KeyName: "home.cta", Text: "Get started", Context: "Primary button"},
+			{NodeID: "1:5", Name: "Footer", KeyName: "farewell", Text: "Bye"},
+		},
+	}
+	result, err := svc.SyncFrame(ctx, project.ID, params, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home.cta"}, result.CreatedKeys)
+	assert.Equal(t, []string{"farewell", "greeting"}, result.ExistingKeys)
+	// 已存在的键不修改翻译，只报告与图层文本不同的键
+	assert.Equal(t, []string{"greeting"}, result.ChangedKeys)
+	require.Len(t, result.Frame.Layers, 3)
+
+	values, err := svc.GetValues(ctx, project.ID, []string{"greeting", "home.cta", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting": {source.Code: "Hello", target.Code: "Hallo"},
+		"home.cta": {source.Code: "Get started"},
+	}, values)
+
+	// 重复同步同一画框时替换全部图层
+	params.Name = "Home v2"
+	params.Layers = params.Layers[1:2]
+	result, err = svc.SyncFrame(ctx, project.ID, params, 1)
+	require.NoError(t, err)
+	assert.Empty(t, result.CreatedKeys)
+	assert.Equal(t, "Home v2", result.Frame.Name)
+	require.Len(t, result.Frame.Layers, 1)
+
+	frames, err := svc.GetReferences(ctx, project.ID, "", "home.cta")
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	assert.Equal(t, result.Frame.ID, frames[0].ID)
+	frames, err = svc.GetReferences(ctx, project.ID, "", "greeting")
+	require.NoError(t, err)
+	assert.Empty(t, frames)
+
+	_, _, err = svc.GetScreenshot(ctx, project.ID, result.Frame.ID)
+	assert.ErrorIs(t, err, domain.ErrFigmaScreenshotMissing)
+	frame, err := svc.UploadScreenshot(ctx, project.ID, result.Frame.ID, pngHeader, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", frame.ScreenshotType)
+	data, mediaType, err := svc.GetScreenshot(ctx, project.ID, result.Frame.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mediaType)
+	assert.Equal(t, pngHeader, data)
+
+	_, err = svc.UploadScreenshot(ctx, project.ID, result.Frame.ID, []byte("not an image"), 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidFigmaScreenshot.Code, appErr.Code)
+
+	// 画框不属于该项目时视为不存在
+	other := createProject(t)
+	_, _, err = svc.GetScreenshot(ctx, other.ID, result.Frame.ID)
+	assert.ErrorIs(t, err, domain.ErrFigmaFrameNotFound)
+
+	require.NoError(t, svc.DeleteFrame(ctx, project.ID, result.Frame.ID))
+	frames, err = svc.GetReferences(ctx, project.ID, "abc123", "")
+	require.NoError(t, err)
+	assert.Empty(t, frames)
+}
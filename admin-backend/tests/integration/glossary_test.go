@@ -0,0 +1,81 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestGlossary_CRUDAndQAReport(t *testing.T) {
+	ctx := context.Background()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	source, target := languages[0], languages[1]
+	require.NoError(t, testDB.Model(&domain.Language{}).Where("is_default = ?", true).Update("is_default", false).Error)
+	require.NoError(t, testDB.Model(source).Update("is_default", true).Error)
+
+	glossaryRepo := repository.NewGlossaryRepository(testDB)
+	glossary := service.NewGlossaryService(glossaryRepo, repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB), repository.NewTransactor(testDB))
+
+	term, err := glossary.Create(ctx, project.ID, domain.GlossaryTermParams{
+		Term: "Workspace",
+		Translations: []domain.GlossaryTranslationParams{
+			{LanguageCode: target.Code, Translation: "Arbeitsbereich", Forbidden: []string{"Arbeitsplatz", "Arbeitsplatz"}},
+		},
+	}, 1)
+	require.NoError(t, err)
+	require.Len(t, term.Translations, 1)
+	assert.Equal(t, target.Code, term.Translations[0].Language.Code)
+	assert.Equal(t, []string{"Arbeitsplatz"}, service.ParseGlossaryForbidden(term.Translations[0].Forbidden))
+
+	// 术语不区分大小写不能重复
+	_, err = glossary.Create(ctx, project.ID, domain.GlossaryTermParams{Term: "workspace"}, 1)
+	assert.ErrorIs(t, err, domain.ErrGlossaryTermExists)
+
+	// 未提供译文时保持不变
+	definition := "用户的工作区"
+	term, err = glossary.Update(ctx, term.ID, domain.GlossaryTermParams{Definition: &definition}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, definition, term.Definition)
+	assert.Equal(t, uint64(2), term.UpdatedBy)
+	require.Len(t, term.Translations, 1)
+
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "nav.workspace", LanguageID: source.ID, Value: "Open workspace", Status: "active"},
+		{ProjectID: project.ID, KeyName: "nav.workspace", LanguageID: target.ID, Value: "Arbeitsplatz öffnen", Status: "active"},
+		{ProjectID: project.ID, KeyName: "nav.home", LanguageID: source.ID, Value: "Workspace home", Status: "active"},
+		{ProjectID: project.ID, KeyName: "nav.home", LanguageID: target.ID, Value: "Arbeitsbereich Startseite", Status: "active"},
+	}))
+
+	qa := service.NewQAService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		glossaryRepo,
+	)
+	report, err := qa.GetReport(ctx, project.ID, domain.QAReportQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Keys)
+	assert.Equal(t, 1, report.Counts[domain.QAIssueGlossaryMismatch])
+	assert.Equal(t, 1, report.Counts[domain.QAIssueForbiddenTerm])
+
+	report, err = qa.GetReport(ctx, project.ID, domain.QAReportQuery{Type: domain.QAIssueForbiddenTerm})
+	require.NoError(t, err)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, "nav.workspace", report.Issues[0].KeyName)
+	assert.Equal(t, "Arbeitsbereich öffnen", report.Issues[0].Suggestion)
+
+	require.NoError(t, glossary.Delete(ctx, term.ID))
+	terms, err := glossary.GetByProjectID(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Empty(t, terms)
+}
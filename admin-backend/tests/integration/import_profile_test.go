@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestImportProfile_SuggestAndImportCSV(t *testing.T) {
+	ctx := context.Background()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	svc := service.NewImportProfileService(
+		repository.NewImportProfileRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		newTranslationService(),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+
+	csv := []byte("Translations export\n" +
+		"Key,Notes," + languages[0].Code + "," + languages[1].Code + "\n" +
+		"home.title,Page title,Home,Startseite\n" +
+		",orphan,x,y\n" +
+		"\n" +
+		"home.subtitle,,Welcome,\n")
+
+	suggestion, err := svc.Suggest(ctx, project.ID, domain.ImportMappingSuggestParams{HeaderRow: 2, Data: csv})
+	require.NoError(t, err)
+	assert.Equal(t, "Key", suggestion.KeyColumn)
+	assert.Equal(t, "Notes", suggestion.ContextColumn)
+	assert.Equal(t, map[string]string{languages[0].Code: languages[0].Code, languages[1].Code: languages[1].Code}, suggestion.LanguageColumns)
+
+	// 建议结果直接保存为映射配置
+	profile, err := svc.Create(ctx, project.ID, domain.ImportProfileParams{
+		Name:            "Marketing sheet",
+		HeaderRow:       suggestion.HeaderRow,
+		KeyColumn:       suggestion.KeyColumn,
+		ContextColumn:   &suggestion.ContextColumn,
+		LanguageColumns: suggestion.LanguageColumns,
+	}, 1)
+	require.NoError(t, err)
+
+	result, err := svc.Import(ctx, profile.ID, domain.SpreadsheetImportParams{Data: csv})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Rows)
+	assert.Equal(t, 1, result.SkippedRows)
+	assert.Equal(t, 2, result.Keys)
+	assert.Equal(t, 3, result.Translations)
+
+	values, err := newTranslationService().GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"home.title":    {languages[0].Code: "Home", languages[1].Code: "Startseite"},
+		"home.subtitle": {languages[0].Code: "Welcome"},
+	}, values)
+
+	// 表头与映射配置不一致时不导入
+	_, err = svc.Import(ctx, profile.ID, domain.SpreadsheetImportParams{Data: []byte("Key\n")})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidSpreadsheet.Code, appErr.Code)
+
+	_, err = svc.Import(ctx, profile.ID, domain.SpreadsheetImportParams{Data: []byte("x\nKey," + languages[0].Code + "\n")})
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrImportColumnNotFound.Code, appErr.Code)
+	assert.Contains(t, appErr.Details, "Notes")
+}
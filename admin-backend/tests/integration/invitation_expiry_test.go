@@ -0,0 +1,56 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestInvitationRepository_ExpireActive(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInvitationRepository(testDB)
+	inviter := &domain.User{Username: uniqueName("it-inviter"), Email: uniqueName("it-inviter") + "@example.com", Password: "x", Status: "active"}
+	require.NoError(t, testDB.Create(inviter).Error)
+
+	// 使用很早的过期时间，不影响其他测试创建的邀请码
+	now := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	create := func(status string, expiresAt time.Time) *domain.Invitation {
+		invitation := &domain.Invitation{Code: uniqueName("it-code"), InviterID: inviter.ID, Status: status, ExpiresAt: expiresAt}
+		require.NoError(t, repo.Create(ctx, invitation))
+		return invitation
+	}
+	first := create(domain.InvitationStatusActive, now.Add(-2*time.Hour))
+	second := create(domain.InvitationStatusActive, now.Add(-time.Hour))
+	used := create(domain.InvitationStatusUsed, now.Add(-time.Hour))
+	valid := create(domain.InvitationStatusActive, now.Add(time.Hour))
+
+	expired, err := repo.ExpireActive(ctx, now, 1)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, first.ID, expired[0].ID)
+	assert.Equal(t, domain.InvitationStatusExpired, expired[0].Status)
+
+	expired, err = repo.ExpireActive(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, second.ID, expired[0].ID)
+
+	for id, status := range map[uint64]string{
+		first.ID:  domain.InvitationStatusExpired,
+		second.ID: domain.InvitationStatusExpired,
+		used.ID:   domain.InvitationStatusUsed,
+		valid.ID:  domain.InvitationStatusActive,
+	} {
+		stored, err := repo.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, status, stored.Status)
+	}
+}
@@ -0,0 +1,125 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestInvitationRepository_MarkAsUsedMultiUse(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInvitationRepository(testDB)
+	inviter := &domain.User{Username: uniqueName("it-inviter"), Email: uniqueName("it-inviter") + "@example.com", Password: "x", Status: "active"}
+	require.NoError(t, testDB.Create(inviter).Error)
+	invitation := &domain.Invitation{
+		Code:      uniqueName("it-team"),
+		InviterID: inviter.ID,
+		Status:    domain.InvitationStatusActive,
+		ExpiresAt: time.Now().Add(time.Hour),
+		MaxUses:   3,
+	}
+	require.NoError(t, repo.Create(ctx, invitation))
+
+	// 并发使用时只有 max_uses 次成功
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		exhausted int
+	)
+	for userID := uint64(1); userID <= 8; userID++ {
+		wg.Add(1)
+		go func(userID uint64) {
+			defer wg.Done()
+			err := repo.MarkAsUsed(ctx, invitation.Code, userID)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, domain.ErrInvitationUsed):
+				exhausted++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(userID)
+	}
+	wg.Wait()
+	assert.Equal(t, 3, succeeded)
+	assert.Equal(t, 5, exhausted)
+
+	stored, err := repo.GetByCode(ctx, invitation.Code)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.UseCount)
+	assert.Equal(t, domain.InvitationStatusUsed, stored.Status)
+	require.NotNil(t, stored.UsedAt)
+	require.NotNil(t, stored.UsedBy)
+}
+
+func TestInvitationRepository_MarkAsUsedKeepsActiveUntilExhausted(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInvitationRepository(testDB)
+	inviter := &domain.User{Username: uniqueName("it-inviter"), Email: uniqueName("it-inviter") + "@example.com", Password: "x", Status: "active"}
+	require.NoError(t, testDB.Create(inviter).Error)
+	invitation := &domain.Invitation{Code: uniqueName("it-team"), InviterID: inviter.ID, Status: domain.InvitationStatusActive, ExpiresAt: time.Now().Add(time.Hour), MaxUses: 2}
+	require.NoError(t, repo.Create(ctx, invitation))
+
+	require.NoError(t, repo.MarkAsUsed(ctx, invitation.Code, 11))
+	stored, err := repo.GetByCode(ctx, invitation.Code)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stored.UseCount)
+	assert.Equal(t, domain.InvitationStatusActive, stored.Status)
+	assert.Equal(t, uint64(11), *stored.UsedBy)
+
+	// 撤销后不能再使用
+	require.NoError(t, repo.Revoke(ctx, invitation.Code))
+	assert.ErrorIs(t, repo.MarkAsUsed(ctx, invitation.Code, 12), domain.ErrInvitationUsed)
+}
+
+func TestInvitationService_RegisterWithExhaustedCodeRollsBack(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewInvitationRepository(testDB)
+	userRepo := repository.NewUserRepository(testDB)
+	transactor := repository.NewTransactor(testDB)
+	userService := service.NewUserService(userRepo, nil, nil, transactor, nil, nil, nil, nil)
+	svc := service.NewInvitationService(repo, userRepo, userService, service.NewEmailNotifier(nil, nil), transactor, "")
+	inviter := &domain.User{Username: uniqueName("it-inviter"), Email: uniqueName("it-inviter") + "@example.com", Password: "x", Status: "active"}
+	require.NoError(t, testDB.Create(inviter).Error)
+
+	// 状态仍为 active 但次数已用完，模拟校验通过后被并发注册用完
+	invitation := &domain.Invitation{
+		Code:      uniqueName("it-full"),
+		InviterID: inviter.ID,
+		Role:      "member",
+		Status:    domain.InvitationStatusActive,
+		ExpiresAt: time.Now().Add(time.Hour),
+		MaxUses:   2,
+		UseCount:  2,
+	}
+	require.NoError(t, repo.Create(ctx, invitation))
+
+	username := uniqueName("it-late")
+	_, err := svc.RegisterWithInvitation(ctx, invitation.Code, domain.CreateUserParams{
+		Username: username,
+		Email:    username + "@example.com",
+		Password: "Passw0rd!",
+	})
+	assert.ErrorIs(t, err, domain.ErrInvitationUsed)
+	_, err = userRepo.GetByUsername(ctx, username)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound, "注册失败时不留下用户")
+
+	stored, err := repo.GetByCode(ctx, invitation.Code)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.UseCount)
+}
@@ -0,0 +1,135 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newKeyCopyService 创建跨项目复制键服务
+func newKeyCopyService() *service.KeyCopyService {
+	projectRepo := repository.NewProjectRepository(testDB)
+	return service.NewKeyCopyService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewKeyLinkRepository(testDB),
+		projectRepo,
+		service.NewProjectMemberService(repository.NewProjectMemberRepository(testDB), repository.NewUserRepository(testDB),
+			projectRepo, repository.NewOrganizationMemberRepository(testDB), nil),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+		nil,
+	)
+}
+
+func TestKeyCopy_CopiesAndSyncsLinkedKeys(t *testing.T) {
+	ctx := context.Background()
+	svc := newKeyCopyService()
+	translationRepo := repository.NewTranslationRepository(testDB)
+	user := createMemberUser(t, "it-key-copy")
+	library := createProject(t)
+	app := createProject(t)
+	languages := createLanguages(t, 2)
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: app.ID, UserID: user.ID, Role: "editor"}).Error)
+	seedPrefixTranslations(t, library.ID, languages, "button.save", "button.cancel")
+	seedPrefixTranslations(t, app.ID, languages[:1], "button.cancel")
+
+	// 不是源项目成员时不能复制
+	_, err := svc.Copy(ctx, app.ID, domain.KeyCopyParams{SourceProjectID: library.ID, KeyNames: []string{"button.save"}}, user.ID)
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: library.ID, UserID: user.ID, Role: "viewer"}).Error)
+
+	result, err := svc.Copy(ctx, app.ID, domain.KeyCopyParams{
+		SourceProjectID: library.ID,
+		KeyNames:        []string{"button.save", "button.cancel"},
+		Link:            true,
+	}, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"button.save"}, result.Copied)
+	assert.Equal(t, []string{"button.cancel"}, result.Skipped)
+	assert.Equal(t, int64(2), result.Translations)
+	assert.Equal(t, 1, result.Linked)
+
+	links, err := svc.ListLinks(ctx, library.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, app.ID, links[0].TargetProjectID)
+
+	// 源项目中修改链接的键后同步到目标项目
+	source, err := translationRepo.GetByProjectKeyLanguage(ctx, library.ID, "button.save", languages[1].ID)
+	require.NoError(t, err)
+	source.Value = "Save changes"
+	require.NoError(t, translationRepo.Update(ctx, source))
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, library.ID, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: []string{"button.save"},
+		Count:    1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(ctx, event))
+
+	synced, err := translationRepo.GetByProjectKeyLanguage(ctx, app.ID, "button.save", languages[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Save changes", synced.Value)
+
+	// 取消链接后不再同步
+	require.NoError(t, svc.Unlink(ctx, app.ID, "button.save", user.ID))
+	assert.ErrorIs(t, svc.Unlink(ctx, app.ID, "button.save", user.ID), domain.ErrKeyLinkNotFound)
+	source.Value = "Save all"
+	require.NoError(t, translationRepo.Update(ctx, source))
+	require.NoError(t, svc.HandleEvent(ctx, event))
+	synced, err = translationRepo.GetByProjectKeyLanguage(ctx, app.ID, "button.save", languages[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Save changes", synced.Value)
+}
+
+func TestKeyCopy_RejectsCrossOrganization(t *testing.T) {
+	ctx := context.Background()
+	svc := newKeyCopyService()
+	translationRepo := repository.NewTranslationRepository(testDB)
+	user := createMemberUser(t, "it-key-copy-org")
+	organization, err := newOrganizationService().Create(ctx, domain.OrganizationParams{Name: uniqueName("it-org")}, user.ID)
+	require.NoError(t, err)
+	library := createProject(t)
+	app := createProject(t)
+	require.NoError(t, testDB.Model(&domain.Project{}).Where("id = ?", app.ID).Update("organization_id", organization.ID).Error)
+	languages := createLanguages(t, 1)
+	for _, projectID := range []uint64{library.ID, app.ID} {
+		require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: projectID, UserID: user.ID, Role: "editor"}).Error)
+	}
+	seedPrefixTranslations(t, library.ID, languages, "button.save")
+
+	_, err = svc.Copy(ctx, app.ID, domain.KeyCopyParams{SourceProjectID: library.ID, KeyNames: []string{"button.save"}, Link: true}, user.ID)
+	assert.ErrorIs(t, err, domain.ErrCrossOrganizationCopy)
+	copied, err := translationRepo.GetByProjectKey(ctx, app.ID, "button.save")
+	require.NoError(t, err)
+	assert.Empty(t, copied)
+
+	// 已存在的跨组织链接不再同步，不向目标项目写入其他组织的语言
+	require.NoError(t, repository.NewKeyLinkRepository(testDB).Upsert(ctx, []*domain.KeyLink{{
+		SourceProjectID: library.ID,
+		TargetProjectID: app.ID,
+		KeyName:         "button.save",
+		CreatedBy:       user.ID,
+		CreatedAt:       time.Now(),
+	}}))
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, library.ID, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: []string{"button.save"},
+		Count:    1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(ctx, event))
+	copied, err = translationRepo.GetByProjectKey(ctx, app.ID, "button.save")
+	require.NoError(t, err)
+	assert.Empty(t, copied)
+}
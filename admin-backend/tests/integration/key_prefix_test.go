@@ -0,0 +1,111 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newKeyPrefixService 创建按键名前缀批量操作服务
+func newKeyPrefixService() *service.KeyPrefixService {
+	return service.NewKeyPrefixService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+// seedPrefixTranslations 为每种语言写入给定键的翻译
+func seedPrefixTranslations(t *testing.T, projectID uint64, languages []*domain.Language, keyNames ...string) {
+	t.Helper()
+	var translations []*domain.Translation
+	for _, keyName := range keyNames {
+		for _, language := range languages {
+			translations = append(translations, &domain.Translation{
+				ProjectID:  projectID,
+				LanguageID: language.ID,
+				KeyName:    keyName,
+				Value:      keyName + "@" + language.Code,
+				Status:     "active",
+			})
+		}
+	}
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(context.Background(), translations))
+}
+
+func TestKeyPrefix_RenamePreviewAndExecute(t *testing.T) {
+	ctx := context.Background()
+	svc := newKeyPrefixService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	// 前缀中的 _ 不能作为 LIKE 通配符匹配 checkoutX
+	seedPrefixTranslations(t, project.ID, languages, "check_out.title", "check_out.button", "checkXout.title", "payment.title")
+
+	preview, err := svc.Rename(ctx, project.ID, domain.KeyPrefixParams{Prefix: "check_out.*", NewPrefix: "payment.*", Preview: true}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, preview.Keys)
+	assert.Equal(t, int64(4), preview.Translations)
+	assert.Equal(t, []string{"check_out.button", "check_out.title"}, preview.SampleKeys)
+	assert.Equal(t, []string{"payment.title"}, preview.Conflicts)
+
+	// 存在冲突时拒绝执行
+	_, err = svc.Rename(ctx, project.ID, domain.KeyPrefixParams{Prefix: "check_out.", NewPrefix: "payment."}, 1)
+	assert.ErrorIs(t, err, domain.ErrKeyPrefixConflict)
+
+	result, err := svc.Rename(ctx, project.ID, domain.KeyPrefixParams{Prefix: "check_out.", NewPrefix: "billing."}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.Translations)
+
+	keyNames, err := repository.NewTranslationRepository(testDB).GetKeyNamesByPrefix(ctx, project.ID, "billing.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"billing.button", "billing.title"}, keyNames)
+
+	logs, total, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total, "预览和失败的操作不记录审计日志")
+	assert.Equal(t, domain.AuditActionKeyPrefixRename, logs[0].Action)
+	assert.Equal(t, uint64(7), logs[0].UserID)
+}
+
+func TestKeyPrefix_StatusDeleteAndExport(t *testing.T) {
+	ctx := context.Background()
+	svc := newKeyPrefixService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "legacy.a", "legacy.b", "home.title")
+
+	exported, err := svc.Export(ctx, project.ID, domain.KeyPrefixParams{Prefix: "legacy."}, 1)
+	require.NoError(t, err)
+	assert.Len(t, exported.Data, 2)
+	assert.Equal(t, "legacy.a@"+languages[0].Code, exported.Data["legacy.a"][languages[0].Code])
+
+	_, err = svc.ChangeStatus(ctx, project.ID, domain.KeyPrefixParams{Prefix: "legacy.", Status: "deprecated"}, 1)
+	require.NoError(t, err)
+
+	// 已废弃的翻译不再导出
+	exported, err = svc.Export(ctx, project.ID, domain.KeyPrefixParams{Prefix: "legacy."}, 1)
+	require.NoError(t, err)
+	assert.Empty(t, exported.Data)
+
+	deleted, err := svc.Delete(ctx, project.ID, domain.KeyPrefixParams{Prefix: "legacy.*"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted.Translations)
+
+	count, err := repository.NewTranslationRepository(testDB).CountByPrefix(ctx, project.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	_, total, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+}
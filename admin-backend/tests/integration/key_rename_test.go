@@ -0,0 +1,93 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newKeyRenameService 创建翻译键重命名服务
+func newKeyRenameService() *service.KeyRenameService {
+	return service.NewKeyRenameService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewBranchRepository(testDB),
+		repository.NewAttachmentRepository(testDB),
+		repository.NewAssignmentRepository(testDB),
+		repository.NewFigmaRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestKeyRename_RenamesTranslationsAndBranches(t *testing.T) {
+	ctx := context.Background()
+	svc := newKeyRenameService()
+	branches := newBranchService()
+	translationRepo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	code := languages[0].Code
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.cta", "home.old")
+	// 已删除的同名键不阻止重命名
+	old, err := translationRepo.GetByProjectKey(ctx, project.ID, "home.old")
+	require.NoError(t, err)
+	for _, translation := range old {
+		require.NoError(t, translationRepo.Delete(ctx, translation.ID))
+	}
+
+	branch, err := branches.Create(ctx, project.ID, domain.BranchParams{Name: "release"}, 1)
+	require.NoError(t, err)
+	_, err = branches.SetTranslations(ctx, project.ID, branch.ID, []domain.BranchTranslationParams{
+		{KeyName: "home.title", LanguageCode: code, Value: "Release title"},
+	}, 1)
+	require.NoError(t, err)
+
+	_, err = svc.Rename(ctx, project.ID, domain.KeyRenameParams{KeyName: "home.title", NewKeyName: "home.cta"}, 1)
+	assert.ErrorIs(t, err, domain.ErrKeyExists)
+	_, err = svc.Rename(ctx, project.ID, domain.KeyRenameParams{KeyName: "home.missing", NewKeyName: "home.other"}, 1)
+	assert.ErrorIs(t, err, domain.ErrKeyNotFound)
+
+	result, err := svc.Rename(ctx, project.ID, domain.KeyRenameParams{KeyName: "home.title", NewKeyName: "home.old"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Translations)
+	assert.Equal(t, int64(1), result.BranchTranslations)
+
+	renamed, err := translationRepo.GetByProjectKey(ctx, project.ID, "home.old")
+	require.NoError(t, err)
+	assert.Len(t, renamed, 2)
+	remaining, err := translationRepo.GetByProjectKey(ctx, project.ID, "home.title")
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	values, err := branches.GetValues(ctx, project.ID, branch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Release title", values["home.old"][code])
+	assert.NotContains(t, values, "home.title")
+
+	// 每种语言一条 rename 历史
+	var renames int
+	for _, entry := range projectHistory(t, project.ID) {
+		if entry.Operation == domain.HistoryOperationRename {
+			renames++
+			assert.Equal(t, "home.title", entry.PreviousKey)
+			assert.Equal(t, "home.old", entry.KeyName)
+			assert.Equal(t, uint64(7), entry.UserID)
+		}
+	}
+	assert.Equal(t, 2, renames)
+
+	logs, total, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total, "失败的重命名不记录审计日志")
+	assert.Equal(t, domain.AuditActionKeyRename, logs[0].Action)
+}
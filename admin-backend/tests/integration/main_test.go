@@ -0,0 +1,188 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+	internal_utils "yflow/internal/utils"
+)
+
+// 集成测试使用 testcontainers 启动真实的 MySQL 和 Redis，需要本机可用的 Docker：
+//
+//	go test -tags integration ./tests/integration/...
+//
+// Docker 不可用时整个包跳过。
+
+var (
+	testDB    *gorm.DB
+	testRedis *repository.RedisClient
+	testCache domain.CacheService
+
+	// uniqueSeq 生成测试间不冲突的名称
+	uniqueSeq atomic.Int64
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(runWithContainers(m))
+}
+
+// runWithContainers 启动容器、初始化数据库后运行测试，结束时清理容器
+func runWithContainers(m *testing.M) int {
+	ctx := context.Background()
+
+	if !dockerAvailable(ctx) {
+		log.Printf("跳过集成测试：Docker 不可用")
+		return 0
+	}
+
+	mysqlContainer, err := tcmysql.Run(ctx, "mysql:8.0",
+		tcmysql.WithDatabase("yflow_test"),
+		tcmysql.WithUsername("yflow"),
+		tcmysql.WithPassword("yflow"),
+	)
+	if err != nil {
+		log.Printf("跳过集成测试：无法启动 MySQL 容器: %v", err)
+		return 0
+	}
+	defer terminate(mysqlContainer)
+
+	redisContainer, err := tcredis.Run(ctx, "redis:7.2")
+	if err != nil {
+		log.Printf("跳过集成测试：无法启动 Redis 容器: %v", err)
+		return 0
+	}
+	defer terminate(redisContainer)
+
+	cfg, err := containerConfig(ctx, mysqlContainer, redisContainer)
+	if err != nil {
+		log.Printf("读取容器地址失败: %v", err)
+		return 1
+	}
+
+	logger := zap.NewNop()
+	testDB, err = repository.InitDB(cfg, logger, internal_utils.NewDBSecurityMonitor(logger))
+	if err != nil {
+		log.Printf("初始化数据库失败: %v", err)
+		return 1
+	}
+
+	testRedis = repository.NewRedisClient(&cfg.Redis)
+	defer testRedis.Close()
+	if err := testRedis.Ping(ctx); err != nil {
+		log.Printf("连接 Redis 失败: %v", err)
+		return 1
+	}
+	testCache = service.NewCacheService(testRedis)
+
+	return m.Run()
+}
+
+// containerConfig 根据容器映射端口生成应用配置
+func containerConfig(ctx context.Context, mysqlContainer *tcmysql.MySQLContainer, redisContainer *tcredis.RedisContainer) (*config.Config, error) {
+	mysqlHost, err := mysqlContainer.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mysqlPort, err := mysqlContainer.MappedPort(ctx, "3306/tcp")
+	if err != nil {
+		return nil, err
+	}
+	redisHost, err := redisContainer.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+	redisPort, err := redisContainer.MappedPort(ctx, "6379/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config.Config{}
+	cfg.DB = config.DBConfig{
+		Username: "yflow",
+		Password: "yflow",
+		Host:     mysqlHost,
+		Port:     mysqlPort.Int(),
+		DBName:   "yflow_test",
+	}
+	cfg.Redis = config.RedisConfig{
+		Host:   redisHost,
+		Port:   redisPort.Int(),
+		Prefix: "yflow_test:",
+	}
+	cfg.Search = config.SearchConfig{Backend: "fulltext"}
+	return cfg, nil
+}
+
+// dockerAvailable 检查 Docker 是否可用（找不到 Docker 时 testcontainers 会 panic）
+func dockerAvailable(ctx context.Context) (available bool) {
+	defer func() {
+		if recover() != nil {
+			available = false
+		}
+	}()
+
+	provider, err := testcontainers.NewDockerProvider()
+	if err != nil {
+		return false
+	}
+	defer provider.Close()
+	return provider.Health(ctx) == nil
+}
+
+// terminate 停止并删除容器
+func terminate(container testcontainers.Container) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := container.Terminate(ctx); err != nil {
+		log.Printf("清理容器失败: %v", err)
+	}
+}
+
+// uniqueName 生成带序号的唯一名称
+func uniqueName(prefix string) string {
+	return prefix + "-" + strconv.FormatInt(uniqueSeq.Add(1), 10) + "-" + strconv.FormatInt(time.Now().UnixNano()%1e6, 10)
+}
+
+// createProject 创建测试项目
+func createProject(t *testing.T) *domain.Project {
+	t.Helper()
+	name := uniqueName("it-project")
+	project := &domain.Project{Name: name, Slug: name, Status: "active"}
+	if err := testDB.Create(project).Error; err != nil {
+		t.Fatalf("创建项目失败: %v", err)
+	}
+	return project
+}
+
+// createLanguages 创建 n 种测试语言
+func createLanguages(t *testing.T, n int) []*domain.Language {
+	t.Helper()
+	languages := make([]*domain.Language, 0, n)
+	for i := 0; i < n; i++ {
+		code := fmt.Sprintf("t%d", uniqueSeq.Add(1))
+		language := &domain.Language{Code: code, Name: code, Status: "active"}
+		if err := testDB.Create(language).Error; err != nil {
+			t.Fatalf("创建语言失败: %v", err)
+		}
+		languages = append(languages, language)
+	}
+	return languages
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func newNamespaceService() *service.NamespaceService {
+	return service.NewNamespaceService(
+		repository.NewNamespaceRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestNamespace_ScopedMatrixExportAndImport(t *testing.T) {
+	ctx := context.Background()
+	namespaces := newNamespaceService()
+	translations := newTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedPrefixTranslations(t, project.ID, languages, "checkout.title", "checkout.pay", "settings.title")
+
+	checkout, err := namespaces.Create(ctx, project.ID, domain.NamespaceParams{Name: "checkout"}, 1)
+	require.NoError(t, err)
+	_, err = namespaces.Create(ctx, project.ID, domain.NamespaceParams{Name: "checkout"}, 1)
+	assert.ErrorIs(t, err, domain.ErrNamespaceExists)
+
+	// 键在所有语言中的翻译一起归入命名空间
+	result, err := namespaces.AssignKeys(ctx, project.ID, domain.AssignNamespaceParams{KeyNames: []string{"checkout.title", "checkout.pay"}, Namespace: "checkout"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), result.Translations)
+	_, err = namespaces.AssignKeys(ctx, project.ID, domain.AssignNamespaceParams{KeyNames: []string{"checkout.title", "missing"}, Namespace: "checkout"}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Contains(t, appErr.Details, "missing")
+
+	listed, err := namespaces.GetByProjectID(ctx, project.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, int64(2), listed[0].Keys)
+
+	page, err := translations.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Namespace: "checkout"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout.pay", "checkout.title"}, page.Keys)
+	_, err = translations.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Namespace: "unknown"})
+	assert.ErrorIs(t, err, domain.ErrNamespaceNotFound)
+
+	values, err := translations.GetExportValues(ctx, project.ID, domain.ExportOptions{Namespace: "checkout"})
+	require.NoError(t, err)
+	assert.Len(t, values, 2)
+	assert.NotContains(t, values, "settings.title")
+
+	// 导入时文件中的键（包括已有的键）归入命名空间，新语言的翻译继承键的命名空间
+	_, err = namespaces.Create(ctx, project.ID, domain.NamespaceParams{Name: "settings"}, 1)
+	require.NoError(t, err)
+	data := []byte(`{"` + languages[0].Code + `": {"settings.title": "Settings", "settings.theme": "Theme"}}`)
+	imported, err := translations.Import(ctx, project.ID, data, "json", domain.ImportOptions{Strategy: domain.ImportStrategyOverwrite, Namespace: "settings"})
+	require.NoError(t, err)
+	assert.Equal(t, "settings", imported.Namespace)
+	values, err = translations.GetExportValues(ctx, project.ID, domain.ExportOptions{Namespace: "settings"})
+	require.NoError(t, err)
+	assert.Equal(t, "Settings", values["settings.title"][languages[0].Code])
+	assert.Contains(t, values, "settings.theme")
+
+	// 删除命名空间后键回到未划分状态
+	require.NoError(t, namespaces.Delete(ctx, project.ID, checkout.ID))
+	keys, err := repository.NewTranslationRepository(testDB).GetKeyNamesByNamespace(ctx, project.ID, checkout.ID)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
@@ -0,0 +1,67 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newOrganizationService 创建使用真实仓储的组织服务
+func newOrganizationService() *service.OrganizationService {
+	return service.NewOrganizationService(
+		repository.NewOrganizationRepository(testDB),
+		repository.NewOrganizationMemberRepository(testDB),
+		repository.NewUserRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewProjectMemberRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestOrganization_LanguagesAreIsolated(t *testing.T) {
+	ctx := context.Background()
+	svc := newOrganizationService()
+	languageRepo := repository.NewLanguageRepository(testDB)
+	shared := createLanguages(t, 1)[0]
+	owner := createMemberUser(t, "it-org-owner")
+
+	first, err := svc.Create(ctx, domain.OrganizationParams{Name: uniqueName("it-org")}, owner.ID)
+	require.NoError(t, err)
+	second, err := svc.Create(ctx, domain.OrganizationParams{Name: uniqueName("it-org")}, owner.ID)
+	require.NoError(t, err)
+
+	// 两个组织都复制了共享语言，语言代码可以在组织间重复
+	firstCtx := domain.WithOrganization(ctx, first.ID)
+	secondCtx := domain.WithOrganization(ctx, second.ID)
+	firstCopy, err := languageRepo.GetByCode(firstCtx, shared.Code)
+	require.NoError(t, err)
+	secondCopy, err := languageRepo.GetByCode(secondCtx, shared.Code)
+	require.NoError(t, err)
+	assert.NotEqual(t, shared.ID, firstCopy.ID)
+	assert.NotEqual(t, firstCopy.ID, secondCopy.ID)
+
+	// 组织新增的语言不出现在其他组织和共享语言列表中
+	code := uniqueName("org")
+	require.NoError(t, languageRepo.Create(firstCtx, &domain.Language{OrganizationID: first.ID, Code: code, Name: code, Status: "active"}))
+	_, err = languageRepo.GetByCode(secondCtx, code)
+	assert.ErrorIs(t, err, domain.ErrLanguageNotFound)
+	_, err = languageRepo.GetByCode(ctx, code)
+	assert.ErrorIs(t, err, domain.ErrLanguageNotFound)
+
+	// 删除组织时删除组织的语言
+	require.NoError(t, svc.Delete(ctx, first.ID))
+	languages, err := languageRepo.GetAll(firstCtx)
+	require.NoError(t, err)
+	assert.Empty(t, languages)
+	_, err = languageRepo.GetByCode(secondCtx, shared.Code)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,180 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newOutboxTranslationService 创建通过事务发件箱发布事件的翻译服务
+func newOutboxTranslationService(delivery domain.EventBus) *service.TranslationService {
+	transactor := repository.NewTransactor(testDB)
+	outboxRepo := repository.NewOutboxRepository(testDB)
+	bus := service.NewOutboxEventBus(outboxRepo, transactor, delivery, nil)
+
+	return service.NewTranslationService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewNamespaceRepository(testDB),
+		repository.NewReleaseRepository(testDB),
+		bus,
+		transactor,
+	)
+}
+
+func TestOutbox_EventCommittedWithTranslations(t *testing.T) {
+	ctx := context.Background()
+	delivery := service.NewInMemoryEventBus(nil)
+	var received []domain.Event
+	delivery.Subscribe(domain.EventTranslationUpdated, "test", func(ctx context.Context, event domain.Event) error {
+		received = append(received, event)
+		return nil
+	})
+
+	svc := newOutboxTranslationService(delivery)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+
+	require.NoError(t, svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "outbox.key", Value: "v"},
+	}))
+
+	require.Len(t, received, 1)
+	var record domain.OutboxEvent
+	require.NoError(t, testDB.Where("event_id = ?", received[0].ID).First(&record).Error)
+	assert.Equal(t, domain.OutboxStatusDelivered, record.Status)
+	assert.Equal(t, project.ID, record.ProjectID)
+}
+
+func TestOutbox_RollbackDiscardsEvent(t *testing.T) {
+	ctx := context.Background()
+	transactor := repository.NewTransactor(testDB)
+	outboxRepo := repository.NewOutboxRepository(testDB)
+	delivery := service.NewInMemoryEventBus(nil)
+	delivered := false
+	delivery.Subscribe(domain.EventProjectCreated, "test", func(ctx context.Context, event domain.Event) error {
+		delivered = true
+		return nil
+	})
+	bus := service.NewOutboxEventBus(outboxRepo, transactor, delivery, nil)
+
+	event, err := domain.NewEvent(domain.EventProjectCreated, 0, nil)
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, bus.Publish(ctx, event))
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+
+	var count int64
+	require.NoError(t, testDB.Model(&domain.OutboxEvent{}).Where("event_id = ?", event.ID).Count(&count).Error)
+	assert.Zero(t, count, "回滚后发件箱中不应有事件")
+	assert.False(t, delivered, "回滚的事件不应投递")
+}
+
+func TestOutbox_DispatcherDeliversPendingEvents(t *testing.T) {
+	ctx := context.Background()
+	transactor := repository.NewTransactor(testDB)
+	outboxRepo := repository.NewOutboxRepository(testDB)
+
+	// 模拟提交后、同步投递前进程崩溃：事件已写入但投递失败
+	bus := service.NewOutboxEventBus(outboxRepo, transactor, failingEventBus{}, nil)
+	event, err := domain.NewEvent(domain.EventProjectCreated, 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return bus.Publish(ctx, event)
+	}))
+	require.NoError(t, testDB.Model(&domain.OutboxEvent{}).
+		Where("event_id = ?", event.ID).
+		Update("next_attempt_at", time.Now().Add(-time.Second)).Error)
+
+	delivery := service.NewInMemoryEventBus(nil)
+	var receivedID string
+	delivery.Subscribe(domain.EventProjectCreated, "test", func(ctx context.Context, e domain.Event) error {
+		if e.ID == event.ID {
+			receivedID = e.ID
+		}
+		return nil
+	})
+
+	_, err = service.NewOutboxDispatcher(outboxRepo, delivery, nil).DispatchDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, receivedID, "重新投递时事件 ID 不变")
+
+	var record domain.OutboxEvent
+	require.NoError(t, testDB.Where("event_id = ?", event.ID).First(&record).Error)
+	assert.Equal(t, domain.OutboxStatusDelivered, record.Status)
+	assert.Equal(t, 1, record.Attempts)
+}
+
+func TestOutbox_RequeueDeadLetters(t *testing.T) {
+	ctx := context.Background()
+	outboxRepo := repository.NewOutboxRepository(testDB)
+	svc := service.NewDeadLetterService(outboxRepo, nil)
+
+	// 写入两个已超过最大投递次数的事件
+	var records []*domain.OutboxEvent
+	for i := 0; i < 2; i++ {
+		event, err := domain.NewEvent(domain.EventImportCompleted, 0, nil)
+		require.NoError(t, err)
+		record := &domain.OutboxEvent{
+			EventID:       event.ID,
+			EventType:     string(event.Type),
+			Payload:       "{}",
+			Status:        domain.OutboxStatusFailed,
+			Attempts:      10,
+			NextAttemptAt: time.Now().Add(time.Hour),
+			LastError:     "bus unavailable",
+		}
+		require.NoError(t, outboxRepo.Create(ctx, record))
+		records = append(records, record)
+	}
+
+	events, total, err := svc.List(ctx, domain.DeadLetterListParams{EventType: string(domain.EventImportCompleted), Limit: 100})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, total, int64(2))
+	ids := make(map[uint64]bool, len(events))
+	for _, event := range events {
+		assert.Equal(t, domain.OutboxStatusFailed, event.Status)
+		ids[event.ID] = true
+	}
+	assert.True(t, ids[records[0].ID] && ids[records[1].ID])
+
+	// 只重新投递指定的事件，重复提交时不再计入
+	requeued, err := svc.Requeue(ctx, domain.RequeueDeadLettersParams{IDs: []uint64{records[0].ID}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), requeued)
+	requeued, err = svc.Requeue(ctx, domain.RequeueDeadLettersParams{IDs: []uint64{records[0].ID}}, 1)
+	require.NoError(t, err)
+	assert.Zero(t, requeued)
+
+	var reloaded domain.OutboxEvent
+	require.NoError(t, testDB.First(&reloaded, records[0].ID).Error)
+	assert.Equal(t, domain.OutboxStatusPending, reloaded.Status)
+	assert.Zero(t, reloaded.Attempts)
+	assert.False(t, reloaded.NextAttemptAt.After(time.Now()))
+	require.NoError(t, testDB.First(&reloaded, records[1].ID).Error)
+	assert.Equal(t, domain.OutboxStatusFailed, reloaded.Status)
+}
+
+// failingEventBus 投递总是失败的事件总线
+type failingEventBus struct{}
+
+func (failingEventBus) Publish(ctx context.Context, event domain.Event) error {
+	return errors.New("bus unavailable")
+}
+
+func (failingEventBus) Subscribe(eventType domain.EventType, name string, handler domain.EventHandler) {
+}
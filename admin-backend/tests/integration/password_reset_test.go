@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestPasswordReset_TokenCanBeUsedOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewPasswordResetRepository(testDB)
+	user := createMemberUser(t, "it-reset")
+
+	token := &domain.PasswordResetToken{UserID: user.ID, TokenHash: uniqueName("hash"), ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, repo.Create(ctx, token))
+	stored, err := repo.GetByHash(ctx, token.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, stored.UserID)
+	assert.Nil(t, stored.UsedAt)
+
+	// 并发使用同一令牌时只有一个成功
+	var claimed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := repo.MarkUsed(ctx, token.ID, time.Now())
+			assert.NoError(t, err)
+			if ok {
+				claimed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), claimed.Load())
+
+	_, err = repo.GetByHash(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+
+	// 清理过期令牌
+	expired := &domain.PasswordResetToken{UserID: user.ID, TokenHash: uniqueName("hash"), ExpiresAt: time.Now().Add(-48 * time.Hour)}
+	require.NoError(t, repo.Create(ctx, expired))
+	deleted, err := repo.DeleteExpiredBefore(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+	_, err = repo.GetByHash(ctx, expired.TokenHash)
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+	_, err = repo.GetByHash(ctx, token.TokenHash)
+	assert.NoError(t, err)
+}
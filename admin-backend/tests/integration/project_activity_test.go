@@ -0,0 +1,109 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newProjectActivityService 创建项目活动服务
+func newProjectActivityService() *service.ProjectActivityService {
+	return service.NewProjectActivityService(
+		repository.NewProjectRepository(testDB),
+		newProjectService(),
+		repository.NewAuditLogRepository(testDB),
+		30,
+		time.Minute,
+		nil,
+	)
+}
+
+// createIdleProject 创建 days 天前创建且没有变更记录的项目
+func createIdleProject(t *testing.T, days int) *domain.Project {
+	t.Helper()
+	project := createProject(t)
+	require.NoError(t, testDB.Exec("UPDATE projects SET created_at = ? WHERE id = ?",
+		time.Now().AddDate(0, 0, -days), project.ID).Error)
+	return project
+}
+
+// staleProjectIDs 闲置项目报告中的项目ID
+func staleProjectIDs(t *testing.T, svc *service.ProjectActivityService, days int) map[uint64]bool {
+	t.Helper()
+	report, err := svc.GetStaleProjects(context.Background(), domain.StaleProjectParams{Days: days, Limit: 1000})
+	require.NoError(t, err)
+	ids := make(map[uint64]bool, len(report.Projects))
+	for _, project := range report.Projects {
+		ids[project.ID] = true
+	}
+	return ids
+}
+
+func TestProjectActivity_StaleReport(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectActivityService()
+	idle := createIdleProject(t, 60)
+	active := createIdleProject(t, 60)
+	recent := createProject(t)
+
+	// 翻译变更事件和访问先在内存中合并，生成报告前写入数据库
+	require.NoError(t, svc.HandleEvent(ctx, domain.Event{Type: domain.EventTranslationUpdated, ProjectID: active.ID, OccurredAt: time.Now()}))
+	svc.RecordAccess(idle.ID)
+
+	ids := staleProjectIDs(t, svc, 30)
+	assert.True(t, ids[idle.ID])
+	assert.False(t, ids[active.ID])
+	assert.False(t, ids[recent.ID])
+
+	// 访问不影响闲置判断，但会记录访问时间
+	reloaded, err := repository.NewProjectRepository(testDB).GetByID(ctx, idle.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reloaded.LastChangedAt)
+	require.NotNil(t, reloaded.LastAccessedAt)
+
+	// 更新项目不会覆盖活动时间
+	_, err = newProjectService().Update(ctx, active.ID, domain.UpdateProjectParams{Description: "still used"}, 1)
+	require.NoError(t, err)
+	reloaded, err = repository.NewProjectRepository(testDB).GetByID(ctx, active.ID)
+	require.NoError(t, err)
+	require.NotNil(t, reloaded.LastChangedAt)
+
+	// 较早的变更时间不会覆盖已记录的时间
+	require.NoError(t, svc.HandleEvent(ctx, domain.Event{Type: domain.EventImportCompleted, ProjectID: active.ID, OccurredAt: time.Now().AddDate(0, 0, -45)}))
+	assert.False(t, staleProjectIDs(t, svc, 30)[active.ID])
+}
+
+func TestProjectActivity_ArchiveStaleProjects(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectActivityService()
+	idle := createIdleProject(t, 60)
+	changed := createIdleProject(t, 60)
+
+	// 报告生成后项目又有了变更，归档时跳过
+	require.NoError(t, svc.HandleEvent(ctx, domain.Event{Type: domain.EventTranslationUpdated, ProjectID: changed.ID, OccurredAt: time.Now()}))
+
+	result, err := svc.ArchiveStaleProjects(ctx, domain.ArchiveStaleProjectsParams{
+		ProjectIDs: []uint64{idle.ID, changed.ID, idle.ID, 999999999},
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{idle.ID}, result.Archived)
+	assert.Equal(t, []uint64{changed.ID, idle.ID, 999999999}, result.Skipped)
+
+	archived, err := repository.NewProjectRepository(testDB).GetByID(ctx, idle.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "archived", archived.Status)
+	assert.False(t, staleProjectIDs(t, svc, 30)[idle.ID]) // 已归档的项目不再出现在报告中
+
+	var logs []*domain.AuditLog
+	require.NoError(t, testDB.Where("project_id = ? AND action = ?", idle.ID, domain.AuditActionProjectArchive).Find(&logs).Error)
+	assert.Len(t, logs, 1)
+}
@@ -0,0 +1,97 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newProjectConfigService 创建项目配置导出/导入服务
+func newProjectConfigService() *service.ProjectConfigService {
+	transactor := repository.NewTransactor(testDB)
+	projectRepo := repository.NewProjectRepository(testDB)
+	userRepo := repository.NewUserRepository(testDB)
+	memberRepo := repository.NewProjectMemberRepository(testDB)
+	languageRepo := repository.NewLanguageRepository(testDB)
+	translationRepo := repository.NewTranslationRepository(testDB)
+	translationService := service.NewTranslationService(translationRepo, projectRepo, languageRepo, repository.NewNamespaceRepository(testDB), repository.NewReleaseRepository(testDB), nil, transactor)
+
+	return service.NewProjectConfigService(
+		service.NewProjectService(projectRepo, userRepo, memberRepo, nil, repository.NewLanguageRepository(testDB), repository.NewAuditLogRepository(testDB), nil, transactor),
+		languageRepo,
+		userRepo,
+		service.NewProjectMemberService(memberRepo, userRepo, projectRepo, repository.NewOrganizationMemberRepository(testDB), nil),
+		service.NewInboundWebhookService(repository.NewInboundWebhookRepository(testDB), projectRepo, languageRepo, translationRepo, translationService, nil, transactor),
+		repository.NewAuditLogRepository(testDB),
+		transactor,
+	)
+}
+
+func TestProjectConfig_ExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectConfigService()
+	source := createProject(t)
+	target := createProject(t)
+
+	user := &domain.User{Username: uniqueName("it-user"), Email: uniqueName("it-user") + "@example.com", Password: "x", Status: "active"}
+	require.NoError(t, testDB.Create(user).Error)
+
+	config := domain.ProjectConfig{
+		Version:  domain.ProjectConfigVersion,
+		Settings: domain.ProjectConfigSettings{Description: "Shared config", ExportFileTemplate: "{locale}.json"},
+		Webhooks: []domain.ProjectConfigWebhook{{Name: "tms", Provider: "generic", LanguageMapping: map[string]string{"en_US": "en"}}},
+		Members:  []domain.ProjectConfigMember{{Username: user.Username, Role: "editor"}, {Username: "it-nobody", Role: "viewer"}},
+	}
+	_, err := svc.Import(ctx, source.ID, domain.ProjectConfigImportParams{Config: config}, 1)
+	require.NoError(t, err)
+
+	exported, err := svc.Export(ctx, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, source.Slug, exported.Source)
+	require.Len(t, exported.Webhooks, 1)
+	assert.Equal(t, map[string]string{"en_US": "en"}, exported.Webhooks[0].LanguageMapping)
+	assert.Contains(t, exported.Members, domain.ProjectConfigMember{Username: user.Username, Role: "editor"})
+
+	// dry run 只返回变更计划
+	exported.Languages = append(exported.Languages, "xx-missing")
+	plan, err := svc.Import(ctx, target.ID, domain.ProjectConfigImportParams{Config: *exported, DryRun: true}, 1)
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+	assert.Equal(t, []string{"xx-missing"}, plan.MissingLanguages)
+	assert.Contains(t, plan.Changes, domain.ProjectConfigChange{Section: "webhooks", Target: "tms", Action: domain.ProjectConfigActionCreate})
+	assert.Contains(t, plan.Changes, domain.ProjectConfigChange{Section: "members", Target: user.Username, Action: domain.ProjectConfigActionCreate, New: "editor"})
+
+	targetConfig, err := svc.Export(ctx, target.ID)
+	require.NoError(t, err)
+	assert.Empty(t, targetConfig.Webhooks)
+
+	// 应用后再次导入没有变更
+	applied, err := svc.Import(ctx, target.ID, domain.ProjectConfigImportParams{Config: *exported}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, plan.Changes, applied.Changes)
+
+	again, err := svc.Import(ctx, target.ID, domain.ProjectConfigImportParams{Config: *exported}, 1)
+	require.NoError(t, err)
+	assert.Empty(t, again.Changes)
+}
+
+func TestProjectConfig_RejectsInvalidDocument(t *testing.T) {
+	svc := newProjectConfigService()
+	project := createProject(t)
+
+	_, err := svc.Import(context.Background(), project.ID, domain.ProjectConfigImportParams{Config: domain.ProjectConfig{
+		Version: domain.ProjectConfigVersion,
+		Members: []domain.ProjectConfigMember{{Username: "someone", Role: "superuser"}},
+	}}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidProjectConfig.Code, appErr.Code)
+}
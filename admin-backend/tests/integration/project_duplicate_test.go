@@ -0,0 +1,108 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newProjectDuplicateService 创建项目复制服务，syncLimit 控制同步复制的翻译数量上限
+func newProjectDuplicateService(syncLimit int) *service.ProjectDuplicateService {
+	return service.NewProjectDuplicateService(
+		newProjectService(),
+		repository.NewProjectRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		repository.NewNamespaceRepository(testDB),
+		repository.NewReleaseThresholdRepository(testDB),
+		repository.NewProjectMemberRepository(testDB),
+		repository.NewProjectDuplicateJobRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+		syncLimit,
+		nil,
+	)
+}
+
+func TestProjectDuplicate_CopiesInline(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectDuplicateService(1000)
+	owner := createMemberUser(t, "it-dup-owner")
+	member := createMemberUser(t, "it-dup-member")
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: project.ID, UserID: member.ID, Role: "editor"}).Error)
+
+	namespace := &domain.Namespace{ProjectID: project.ID, Name: "common"}
+	require.NoError(t, testDB.Create(namespace).Error)
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.cta")
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ?", project.ID, "home.title").
+		Update("namespace_id", namespace.ID).Error)
+
+	job, err := svc.Duplicate(ctx, project.ID, domain.ProjectDuplicateParams{Name: project.Name + " copy", IncludeMembers: true}, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectDuplicateStatusCompleted, job.Status)
+	assert.Equal(t, int64(4), job.Total)
+	assert.Equal(t, int64(4), job.Copied)
+	assert.NotEqual(t, project.ID, job.ProjectID)
+
+	translations, total, err := repository.NewTranslationRepository(testDB).GetByProjectID(ctx, job.ProjectID, 10, 0, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	var copied domain.Namespace
+	require.NoError(t, testDB.Where("project_id = ? AND name = ?", job.ProjectID, "common").First(&copied).Error)
+	for _, translation := range translations {
+		if translation.KeyName == "home.title" {
+			assert.Equal(t, copied.ID, translation.NamespaceID)
+		} else {
+			assert.Zero(t, translation.NamespaceID)
+		}
+	}
+
+	members, err := repository.NewProjectMemberRepository(testDB).GetByProjectID(ctx, job.ProjectID)
+	require.NoError(t, err)
+	roles := map[uint64]string{}
+	for _, m := range members {
+		roles[m.UserID] = m.Role
+	}
+	assert.Equal(t, map[uint64]string{owner.ID: "owner", member.ID: "editor"}, roles)
+
+	fetched, err := svc.GetJob(ctx, project.ID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ProjectID, fetched.ProjectID)
+	_, err = svc.GetJob(ctx, job.ProjectID, job.ID)
+	assert.ErrorIs(t, err, domain.ErrDuplicateJobNotFound)
+}
+
+func TestProjectDuplicate_CopiesInBackground(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectDuplicateService(1)
+	owner := createMemberUser(t, "it-dup-owner")
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.cta", "home.footer")
+
+	job, err := svc.Duplicate(ctx, project.ID, domain.ProjectDuplicateParams{Name: project.Name + " copy"}, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectDuplicateStatusRunning, job.Status)
+
+	require.Eventually(t, func() bool {
+		current, err := svc.GetJob(ctx, project.ID, job.ID)
+		return err == nil && current.Status == domain.ProjectDuplicateStatusCompleted
+	}, 10*time.Second, 50*time.Millisecond)
+	require.NoError(t, svc.Stop(ctx))
+
+	_, total, err := repository.NewTranslationRepository(testDB).GetByProjectID(ctx, job.ProjectID, 1, 0, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), total)
+}
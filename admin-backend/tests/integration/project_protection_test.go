@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestProjectProtection_BlocksDestructiveOperations(t *testing.T) {
+	ctx := context.Background()
+	projects := newProjectService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.button")
+
+	protected, err := projects.SetProtection(ctx, project.ID, true, 1)
+	require.NoError(t, err)
+	assert.True(t, protected.Protected)
+
+	// 项目删除、按 ID 批量删除和按前缀删除都被拒绝，预览不受影响
+	assert.ErrorIs(t, projects.Delete(ctx, project.ID), domain.ErrProjectProtected)
+
+	translations, _, err := repository.NewTranslationRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0, "", nil)
+	require.NoError(t, err)
+	require.Len(t, translations, 2)
+	err = newTranslationService().DeleteBatch(ctx, []uint64{translations[0].ID})
+	assert.ErrorIs(t, err, domain.ErrProjectProtected)
+
+	keyPrefix := newKeyPrefixService()
+	preview, err := keyPrefix.Delete(ctx, project.ID, domain.KeyPrefixParams{Prefix: "home.*", Preview: true}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, preview.Keys)
+	_, err = keyPrefix.Delete(ctx, project.ID, domain.KeyPrefixParams{Prefix: "home.*"}, 1)
+	assert.ErrorIs(t, err, domain.ErrProjectProtected)
+
+	// 重复开启不再记录审计日志；关闭保护后可以删除
+	_, err = projects.SetProtection(ctx, project.ID, true, 1)
+	require.NoError(t, err)
+	unprotected, err := projects.SetProtection(ctx, project.ID, false, 2)
+	require.NoError(t, err)
+	assert.False(t, unprotected.Protected)
+
+	logs, total, err := repository.NewAuditLogRepository(testDB).GetByProjectID(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	actions := make([]string, 0, len(logs))
+	for _, log := range logs {
+		actions = append(actions, log.Action)
+	}
+	assert.ElementsMatch(t, []string{domain.AuditActionProjectProtect, domain.AuditActionProjectUnprotect}, actions)
+
+	require.NoError(t, newTranslationService().DeleteBatch(ctx, []uint64{translations[0].ID}))
+	require.NoError(t, projects.Delete(ctx, project.ID))
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newProjectService 创建使用真实仓储的项目服务
+func newProjectService() *service.ProjectService {
+	return service.NewProjectService(
+		repository.NewProjectRepository(testDB),
+		repository.NewUserRepository(testDB),
+		repository.NewProjectMemberRepository(testDB),
+		nil,
+		repository.NewLanguageRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestProjectService_CreateWithAutoSuffix(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectService()
+	base := uniqueName("slug")
+
+	first, err := svc.Create(ctx, domain.CreateProjectParams{Name: base + " one", Slug: base}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, base, first.Slug)
+
+	// 未开启自动后缀时冲突返回错误
+	_, err = svc.Create(ctx, domain.CreateProjectParams{Name: base + " two", Slug: base}, 1)
+	assert.ErrorIs(t, err, domain.ErrSlugExists)
+
+	second, err := svc.Create(ctx, domain.CreateProjectParams{Name: base + " two", Slug: base, AutoSuffix: true}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, base+"-2", second.Slug)
+
+	third, err := svc.Create(ctx, domain.CreateProjectParams{Name: base + " three", Slug: base, AutoSuffix: true}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, base+"-3", third.Slug)
+}
+
+func TestProjectService_RenameSlugKeepsAlias(t *testing.T) {
+	ctx := context.Background()
+	svc := newProjectService()
+	project := createProject(t)
+	oldSlug := project.Slug
+	newSlug := uniqueName("renamed")
+
+	renamed, err := svc.RenameSlug(ctx, project.ID, newSlug, 1)
+	require.NoError(t, err)
+	assert.Equal(t, newSlug, renamed.Slug)
+
+	// 重复修改为相同标识是幂等的
+	_, err = svc.RenameSlug(ctx, project.ID, newSlug, 1)
+	require.NoError(t, err)
+
+	// 旧标识、新标识和ID都能解析到该项目
+	for _, identifier := range []string{oldSlug, newSlug, strconv.FormatUint(project.ID, 10)} {
+		found, err := svc.GetByIdentifier(ctx, identifier)
+		require.NoError(t, err, identifier)
+		assert.Equal(t, project.ID, found.ID, identifier)
+	}
+	found, err := svc.GetByIdentifier(ctx, "  "+newSlug+" ")
+	require.NoError(t, err)
+	assert.Equal(t, project.ID, found.ID)
+
+	// 旧标识作为别名被保留，其他项目不能占用
+	other := createProject(t)
+	_, err = svc.RenameSlug(ctx, other.ID, oldSlug, 1)
+	assert.ErrorIs(t, err, domain.ErrSlugExists)
+
+	// 改回旧标识时移除别名
+	_, err = svc.RenameSlug(ctx, project.ID, oldSlug, 1)
+	require.NoError(t, err)
+	var aliases []domain.ProjectSlugAlias
+	require.NoError(t, testDB.Where("project_id = ?", project.ID).Find(&aliases).Error)
+	require.Len(t, aliases, 1)
+	assert.Equal(t, newSlug, aliases[0].Slug)
+}
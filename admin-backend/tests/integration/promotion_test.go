@@ -0,0 +1,164 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newPromotionService 创建环境推送服务
+func newPromotionService() *service.PromotionService {
+	transactor := repository.NewTransactor(testDB)
+	translationRepo := repository.NewTranslationRepository(testDB)
+	projectRepo := repository.NewProjectRepository(testDB)
+	languageRepo := repository.NewLanguageRepository(testDB)
+	translationService := service.NewTranslationService(translationRepo, projectRepo, languageRepo, repository.NewNamespaceRepository(testDB), repository.NewReleaseRepository(testDB), nil, transactor)
+
+	return service.NewPromotionService(
+		repository.NewPromotionRepository(testDB),
+		projectRepo,
+		languageRepo,
+		translationRepo,
+		translationService,
+		repository.NewAuditLogRepository(testDB),
+		transactor,
+		"promotion-signing-key",
+		service.PromotionRemoteOptions{
+			AllowedHosts:         []string{"127.0.0.1"},
+			AllowPrivateNetworks: true,
+			Timeout:              5 * time.Second,
+		},
+	)
+}
+
+func TestPromotion_SnapshotDiffAndApply(t *testing.T) {
+	ctx := context.Background()
+	svc := newPromotionService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	code := languages[0].Code
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.old")
+
+	detail, err := svc.CreateDiff(ctx, project.ID, domain.PromotionSourceParams{
+		Snapshot: map[string]map[string]string{
+			"home.title": {code: "New title", "xx-missing": "ignored"},
+			"home.new":   {code: "Added"},
+		},
+		IncludeRemovals: true,
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "xx-missing", detail.Promotion.MissingLanguages)
+	require.Len(t, detail.Changes, 3)
+	assert.Equal(t, domain.PromotionChange{Key: "home.new", Language: code, Type: domain.PromotionChangeAdded, NewValue: "Added"}, detail.Changes[0])
+	assert.Equal(t, domain.PromotionChangeRemoved, detail.Changes[1].Type)
+	assert.Equal(t, domain.PromotionChangeModified, detail.Changes[2].Type)
+
+	// 只应用审核通过的差异
+	applied, err := svc.Apply(ctx, detail.Promotion.ID, domain.PromotionApplyParams{
+		Approved: []domain.PromotionChangeRef{{Key: "home.new", Language: code}, {Key: "home.old", Language: code}},
+	}, 2)
+	require.NoError(t, err)
+	assert.Len(t, applied.Applied, 2)
+
+	page, err := repository.NewTranslationRepository(testDB).GetKeyPage(ctx, domain.TranslationKeyPageQuery{ProjectID: project.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"home.new":   {code: "Added"},
+		"home.title": {code: "home.title@" + code},
+	}, page.Translations)
+
+	// 应用记录已签名，不能重复应用
+	stored, err := svc.GetByID(ctx, detail.Promotion.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.SignatureValid)
+	assert.Equal(t, uint64(2), stored.Promotion.AppliedBy)
+	_, err = svc.Apply(ctx, detail.Promotion.ID, domain.PromotionApplyParams{All: true}, 2)
+	assert.ErrorIs(t, err, domain.ErrPromotionApplied)
+
+	// 篡改记录后签名失效
+	require.NoError(t, testDB.Model(&domain.Promotion{}).Where("id = ?", detail.Promotion.ID).Update("applied_by", 3).Error)
+	stored, err = svc.GetByID(ctx, detail.Promotion.ID)
+	require.NoError(t, err)
+	assert.False(t, stored.SignatureValid)
+}
+
+func TestPromotion_RejectsStaleTarget(t *testing.T) {
+	ctx := context.Background()
+	svc := newPromotionService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	code := languages[0].Code
+	seedPrefixTranslations(t, project.ID, languages, "home.title")
+
+	detail, err := svc.CreateDiff(ctx, project.ID, domain.PromotionSourceParams{
+		Snapshot: map[string]map[string]string{"home.title": {code: "From staging"}},
+	}, 1)
+	require.NoError(t, err)
+
+	// 计算差异后目标环境被修改
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ?", project.ID, "home.title").
+		Update("value", "Hotfix").Error)
+
+	_, err = svc.Apply(ctx, detail.Promotion.ID, domain.PromotionApplyParams{All: true}, 1)
+	assert.ErrorIs(t, err, domain.ErrPromotionStale)
+}
+
+func TestPromotion_RemoteSource(t *testing.T) {
+	ctx := context.Background()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	code := languages[0].Code
+
+	// 模拟源实例的 CLI 分页接口
+	pages := map[string]map[string]interface{}{
+		"": {
+			"translations": map[string]map[string]string{"a.key": {code: "A"}},
+			"next_cursor":  "page2",
+			"has_more":     true,
+		},
+		"page2": {
+			"translations": map[string]map[string]string{"b.key": {code: "B"}},
+			"has_more":     false,
+		},
+	}
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/cli/translations" || r.Header.Get("X-API-Key") != "staging-key" || r.URL.Query().Get("project_id") != "web" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "data": pages[r.URL.Query().Get("cursor")]})
+	}))
+	defer remote.Close()
+
+	svc := newPromotionService()
+	detail, err := svc.CreateDiff(ctx, project.ID, domain.PromotionSourceParams{
+		RemoteURL:     remote.URL + "/",
+		APIKey:        "staging-key",
+		SourceProject: "web",
+	}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, remote.URL, detail.Promotion.Source)
+	assert.Len(t, detail.Changes, 2)
+
+	_, err = svc.CreateDiff(ctx, project.ID, domain.PromotionSourceParams{
+		RemoteURL:     remote.URL,
+		APIKey:        "wrong-key",
+		SourceProject: "web",
+	}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrPromotionSourceFailed.Code, appErr.Code)
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestQA_TermConsistency(t *testing.T) {
+	ctx := context.Background()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	source, target := languages[0], languages[1]
+	require.NoError(t, testDB.Model(&domain.Language{}).Where("is_default = ?", true).Update("is_default", false).Error)
+	require.NoError(t, testDB.Model(source).Update("is_default", true).Error)
+
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "cart.save", LanguageID: source.ID, Value: "Save", Status: "active"},
+		{ProjectID: project.ID, KeyName: "cart.save", LanguageID: target.ID, Value: "Speichern", Status: "active"},
+		{ProjectID: project.ID, KeyName: "profile.save", LanguageID: source.ID, Value: "Save ", Status: "active"},
+		{ProjectID: project.ID, KeyName: "profile.save", LanguageID: target.ID, Value: "Speichern", Status: "active"},
+		{ProjectID: project.ID, KeyName: "settings.save", LanguageID: source.ID, Value: "Save", Status: "active"},
+		{ProjectID: project.ID, KeyName: "settings.save", LanguageID: target.ID, Value: "Sichern", Status: "active"},
+		{ProjectID: project.ID, KeyName: "editor.save", LanguageID: source.ID, Value: "Save", Status: "active"},
+		{ProjectID: project.ID, KeyName: "dialog.cancel", LanguageID: source.ID, Value: "Cancel", Status: "active"},
+		{ProjectID: project.ID, KeyName: "dialog.cancel", LanguageID: target.ID, Value: "Abbrechen", Status: "active"},
+	}))
+
+	svc := service.NewQAService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewGlossaryRepository(testDB),
+	)
+	report, err := svc.GetConsistency(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, source.Code, report.SourceLanguage)
+	// 只有 Save 被多个键使用；editor.save 未翻译，不参与比较
+	assert.Equal(t, 1, report.Terms)
+	var result *domain.LanguageConsistency
+	for i := range report.Languages {
+		if report.Languages[i].LanguageCode == target.Code {
+			result = &report.Languages[i]
+		}
+	}
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.InconsistentTerms)
+	assert.Equal(t, 3, result.Occurrences)
+	assert.Equal(t, 2, result.Consistent)
+	assert.Equal(t, 66.67, result.Score)
+
+	detail, err := svc.GetInconsistencies(ctx, project.ID, target.Code)
+	require.NoError(t, err)
+	require.Len(t, detail.Terms, 1)
+	assert.Equal(t, "Save", detail.Terms[0].Source)
+	assert.Equal(t, "Speichern", detail.Terms[0].Suggestion)
+	assert.Equal(t, []domain.TermTranslation{
+		{Value: "Speichern", Keys: []string{"cart.save", "profile.save"}},
+		{Value: "Sichern", Keys: []string{"settings.save"}},
+	}, detail.Terms[0].Translations)
+
+	_, err = svc.GetInconsistencies(ctx, project.ID, source.Code)
+	assert.ErrorIs(t, err, domain.ErrIsSourceLanguage)
+}
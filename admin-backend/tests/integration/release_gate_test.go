@@ -0,0 +1,107 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestReleaseGate_ThresholdsBlockRelease(t *testing.T) {
+	ctx := context.Background()
+	project, source, target := seedExportTranslations(t)
+	svc := newReleaseGateService()
+
+	// 没有门槛时只统计，不阻止发布
+	readiness, err := svc.Check(ctx, project.ID)
+	require.NoError(t, err)
+	assert.True(t, readiness.Ready)
+	assert.Equal(t, 2, readiness.TotalKeys)
+
+	thresholds, err := svc.SetThresholds(ctx, project.ID, []domain.ReleaseThresholdParams{
+		{LanguageCode: source.Code, MinCompletion: 100, MinApproval: 100},
+		{LanguageCode: target.Code, MinCompletion: 80},
+	}, 1)
+	require.NoError(t, err)
+	require.Len(t, thresholds, 2)
+
+	readiness, err = svc.Check(ctx, project.ID)
+	require.NoError(t, err)
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, []string{target.Code}, readiness.Blocking)
+	for _, language := range readiness.Languages {
+		switch language.LanguageCode {
+		case source.Code:
+			assert.False(t, language.Blocking)
+			assert.Equal(t, 100.0, language.Completion)
+		case target.Code:
+			// 已废弃的 legacy 不计入，只有 farewell 缺少翻译
+			assert.True(t, language.Blocking)
+			assert.Equal(t, 50.0, language.Completion)
+			assert.Equal(t, []string{"farewell"}, language.MissingKeys)
+			assert.Empty(t, language.UnapprovedKeys)
+		}
+	}
+
+	readiness, err = svc.Ensure(ctx, project.ID, false)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrReleaseBlocked.Code, appErr.Code)
+	assert.Contains(t, appErr.Details, target.Code)
+	require.NotNil(t, readiness)
+
+	readiness, err = svc.Ensure(ctx, project.ID, true)
+	require.NoError(t, err)
+	assert.True(t, readiness.Overridden)
+
+	// 替换门槛：传入空列表表示不设门槛
+	_, err = svc.SetThresholds(ctx, project.ID, nil, 1)
+	require.NoError(t, err)
+	readiness, err = svc.Ensure(ctx, project.ID, false)
+	require.NoError(t, err)
+	assert.False(t, readiness.Overridden)
+
+	_, err = svc.SetThresholds(ctx, project.ID, []domain.ReleaseThresholdParams{
+		{LanguageCode: target.Code, MinCompletion: 50},
+		{LanguageCode: target.Code, MinCompletion: 60},
+	}, 1)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidReleaseThreshold.Code, appErr.Code)
+}
+
+func TestReleaseGate_BlocksPublishUnlessOverridden(t *testing.T) {
+	ctx := context.Background()
+	project, _, target := seedExportTranslations(t)
+	_, err := newReleaseGateService().SetThresholds(ctx, project.ID, []domain.ReleaseThresholdParams{
+		{LanguageCode: target.Code, MinCompletion: 100},
+	}, 1)
+	require.NoError(t, err)
+
+	release, readiness, err := newReleaseService().Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0"}, 1)
+	assert.Nil(t, release)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrReleaseBlocked.Code, appErr.Code)
+	require.NotNil(t, readiness)
+	assert.Equal(t, []string{target.Code}, readiness.Blocking)
+
+	// 被阻止时不创建发布版本
+	_, err = repository.NewReleaseRepository(testDB).GetByVersion(ctx, project.ID, "v1.0.0")
+	assert.ErrorIs(t, err, domain.ErrReleaseNotFound)
+
+	release, readiness, err = newReleaseService().Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0", Override: true}, 1)
+	require.NoError(t, err)
+	assert.True(t, readiness.Overridden)
+	assert.True(t, release.GateOverridden)
+
+	stored, err := repository.NewReleaseRepository(testDB).GetByID(ctx, release.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.GateOverridden)
+}
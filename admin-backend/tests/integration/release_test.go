@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newReleaseService 创建发布版本服务
+func newReleaseService() *service.ReleaseService {
+	return service.NewReleaseService(
+		repository.NewReleaseRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		newReleaseGateService(),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+// newReleaseGateService 创建发布门槛服务
+func newReleaseGateService() *service.ReleaseGateService {
+	return service.NewReleaseGateService(
+		repository.NewReleaseThresholdRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestRelease_SnapshotIsImmutable(t *testing.T) {
+	ctx := context.Background()
+	project, source, target := seedExportTranslations(t)
+
+	release, _, err := newReleaseService().Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0"}, 1)
+	require.NoError(t, err)
+	// 已废弃的翻译不冻结
+	assert.Equal(t, 2, release.KeyCount)
+	assert.Equal(t, 3, release.TranslationCount)
+
+	_, _, err = newReleaseService().Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0"}, 1)
+	assert.ErrorIs(t, err, domain.ErrReleaseExists)
+
+	// 发布后修改翻译
+	translationRepo := repository.NewTranslationRepository(testDB)
+	require.NoError(t, translationRepo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "greeting", LanguageID: target.ID, Value: "Servus", Status: "active"},
+		{ProjectID: project.ID, KeyName: "added", LanguageID: source.ID, Value: "New", Status: "active"},
+	}))
+
+	svc := newTranslationService()
+	values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{Release: "v1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting": {source.Code: "Hello", target.Code: "Hallo"},
+		"farewell": {source.Code: "Bye"},
+	}, values)
+
+	page, err := svc.GetKeyPage(ctx, domain.TranslationKeyPageQuery{ProjectID: project.ID, Release: "v1.0.0", Limit: 1})
+	require.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, "farewell", page.LastKey)
+	page, err = svc.GetKeyPage(ctx, domain.TranslationKeyPageQuery{ProjectID: project.ID, Release: "v1.0.0", Locale: target.Code, AfterKey: page.LastKey, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"greeting": {target.Code: "Hallo"}}, page.Translations)
+
+	// 未指定发布版本时读取当前翻译
+	values, err = svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Servus", values["greeting"][target.Code])
+	assert.Contains(t, values, "added")
+
+	_, err = svc.GetExportValues(ctx, project.ID, domain.ExportOptions{Release: "v2.0.0"})
+	assert.ErrorIs(t, err, domain.ErrReleaseNotFound)
+}
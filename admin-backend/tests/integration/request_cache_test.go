@@ -0,0 +1,117 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestRequestCache_RepositoriesQueryOncePerRequest(t *testing.T) {
+	ctx := domain.WithRequestCache(context.Background())
+	languageRepo := repository.NewLanguageRepository(testDB)
+	projectRepo := repository.NewProjectRepository(testDB)
+	project := createProject(t)
+	language := createLanguages(t, 1)[0]
+
+	languages, err := languageRepo.GetAll(ctx)
+	require.NoError(t, err)
+	count := len(languages)
+	_, err = projectRepo.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+
+	// 绕过仓储的修改在同一请求内不可见
+	require.NoError(t, testDB.Model(&domain.Project{}).Where("id = ?", project.ID).Update("description", "direct").Error)
+	createLanguages(t, 1)
+
+	languages, err = languageRepo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, languages, count)
+	cached, err := languageRepo.GetByCode(ctx, language.Code)
+	require.NoError(t, err)
+	assert.Equal(t, language.ID, cached.ID)
+	cachedProject, err := projectRepo.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Empty(t, cachedProject.Description)
+
+	// 通过仓储写入后失效
+	cachedProject.Name = project.Name + "-renamed"
+	require.NoError(t, projectRepo.Update(ctx, cachedProject))
+	fresh, err := projectRepo.GetByID(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "direct", fresh.Description)
+
+	cached.Name = "renamed"
+	require.NoError(t, languageRepo.Update(ctx, cached))
+	languages, err = languageRepo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, languages, count+1)
+}
+
+func TestRequestCache_TransactionDoesNotPopulate(t *testing.T) {
+	ctx := domain.WithRequestCache(context.Background())
+	projectRepo := repository.NewProjectRepository(testDB)
+	project := createProject(t)
+
+	err := repository.NewTransactor(testDB).WithinTransaction(ctx, func(ctx context.Context) error {
+		_, err := projectRepo.GetByID(ctx, project.ID)
+		return err
+	})
+	require.NoError(t, err)
+
+	_, ok := domain.RequestCacheFromContext(ctx).Project(project.ID)
+	assert.False(t, ok)
+}
+
+func TestRequestCache_LanguagesIsolatedAcrossOrganizations(t *testing.T) {
+	ctx := domain.WithRequestCache(context.Background())
+	svc := newOrganizationService()
+	languageRepo := repository.NewLanguageRepository(testDB)
+	shared := createLanguages(t, 1)[0]
+	owner := createMemberUser(t, "it-cache-org-owner")
+
+	first, err := svc.Create(context.Background(), domain.OrganizationParams{Name: uniqueName("it-org")}, owner.ID)
+	require.NoError(t, err)
+	second, err := svc.Create(context.Background(), domain.OrganizationParams{Name: uniqueName("it-org")}, owner.ID)
+	require.NoError(t, err)
+	firstCtx := domain.WithOrganization(ctx, first.ID)
+	secondCtx := domain.WithOrganization(ctx, second.ID)
+
+	// 同一请求内先读取第一个组织，第二个组织仍得到自己的语言
+	firstLanguages, err := languageRepo.GetAll(firstCtx)
+	require.NoError(t, err)
+	secondLanguages, err := languageRepo.GetAll(secondCtx)
+	require.NoError(t, err)
+	require.NotEmpty(t, firstLanguages)
+	require.NotEmpty(t, secondLanguages)
+	for _, language := range firstLanguages {
+		assert.Equal(t, first.ID, language.OrganizationID)
+	}
+	for _, language := range secondLanguages {
+		assert.Equal(t, second.ID, language.OrganizationID)
+	}
+
+	firstCopy, err := languageRepo.GetByCode(firstCtx, shared.Code)
+	require.NoError(t, err)
+	secondCopy, err := languageRepo.GetByCode(secondCtx, shared.Code)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, firstCopy.OrganizationID)
+	assert.Equal(t, second.ID, secondCopy.OrganizationID)
+	assert.NotEqual(t, firstCopy.ID, secondCopy.ID)
+
+	// 两个组织的语言列表都已缓存，绕过仓储的修改不可见
+	code := uniqueName("org")
+	require.NoError(t, testDB.Create(&domain.Language{OrganizationID: second.ID, Code: code, Name: code, Status: "active"}).Error)
+	cached, err := languageRepo.GetAll(secondCtx)
+	require.NoError(t, err)
+	assert.Len(t, cached, len(secondLanguages))
+	cached, err = languageRepo.GetAll(firstCtx)
+	require.NoError(t, err)
+	assert.Len(t, cached, len(firstLanguages))
+}
@@ -0,0 +1,67 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestRoleSync_AdminMembershipAndDowngradeReview(t *testing.T) {
+	ctx := context.Background()
+	transactor := repository.NewTransactor(testDB)
+	userRepo := repository.NewUserRepository(testDB)
+	memberRepo := repository.NewProjectMemberRepository(testDB)
+	reviewRepo := repository.NewRoleReviewRepository(testDB)
+	roleSync := service.NewRoleSyncService(userRepo, repository.NewProjectRepository(testDB), memberRepo, reviewRepo, transactor, 0, nil)
+	userService := service.NewUserService(userRepo, nil, roleSync, transactor, nil, nil, nil, nil)
+
+	project := createProject(t)
+	admin := &domain.User{Username: uniqueName("it-admin"), Email: uniqueName("it-admin") + "@example.com", Password: "x", Role: "admin", Status: "active"}
+	require.NoError(t, testDB.Create(admin).Error)
+
+	// 管理员以同步添加的 owner 成员出现在项目中
+	result, err := roleSync.Sync(ctx, 1)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.MembersAdded, 1)
+	member, err := memberRepo.GetByProjectAndUser(ctx, project.ID, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", member.Role)
+	assert.Equal(t, domain.ProjectMemberSourceRoleSync, member.Source)
+
+	// 降级为普通成员后 owner 成员关系进入复核队列，再次同步不会重复加入
+	_, err = userService.UpdateUser(ctx, admin.ID, domain.UpdateUserParams{Role: "member"})
+	require.NoError(t, err)
+	_, err = roleSync.Sync(ctx, 1)
+	require.NoError(t, err)
+
+	var reviews []*domain.RoleReview
+	require.NoError(t, testDB.Where("user_id = ? AND project_id = ?", admin.ID, project.ID).Find(&reviews).Error)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, domain.RoleReviewReasonDowngraded, reviews[0].Reason)
+	assert.Equal(t, "member", reviews[0].GlobalRole)
+	assert.Equal(t, domain.RoleReviewStatusPending, reviews[0].Status)
+
+	_, err = roleSync.ResolveReview(ctx, reviews[0].ID, domain.ResolveRoleReviewParams{Action: domain.RoleReviewActionDowngrade, Role: "owner"}, 1)
+	assert.ErrorIs(t, err, domain.ErrInvalidRoleReviewAction)
+
+	review, err := roleSync.ResolveReview(ctx, reviews[0].ID, domain.ResolveRoleReviewParams{Action: domain.RoleReviewActionDowngrade, Role: "editor"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleReviewStatusDowngraded, review.Status)
+	assert.NotNil(t, review.ResolvedAt)
+
+	member, err = memberRepo.GetByProjectAndUser(ctx, project.ID, admin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "editor", member.Role)
+	assert.Empty(t, member.Source)
+
+	_, err = roleSync.ResolveReview(ctx, reviews[0].ID, domain.ResolveRoleReviewParams{Action: domain.RoleReviewActionKeep}, 1)
+	assert.ErrorIs(t, err, domain.ErrRoleReviewResolved)
+}
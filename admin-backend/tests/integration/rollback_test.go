@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newRollbackService 创建翻译回滚服务
+func newRollbackService() *service.TranslationRollbackService {
+	return service.NewTranslationRollbackService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewTranslationHistoryRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestRollback_TranslationToHistoryEntry(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	translation := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Hello", Status: "active"}
+	require.NoError(t, repo.Create(ctx, translation))
+	translation.Value = "Welcome"
+	require.NoError(t, repo.Update(ctx, translation))
+
+	created := projectHistory(t, project.ID)[0]
+	rolledBack, err := newRollbackService().RollbackTranslation(ctx, translation.ID, created.ID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", rolledBack.Value)
+
+	stored, err := repo.GetByID(ctx, translation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", stored.Value)
+
+	// 回滚记录新的变更历史
+	entries := projectHistory(t, project.ID)
+	require.Len(t, entries, 3)
+	last := entries[2]
+	assert.Equal(t, domain.HistoryOperationRollback, last.Operation)
+	assert.Equal(t, "Welcome", last.OldValue)
+	assert.Equal(t, "Hello", last.NewValue)
+	assert.Equal(t, uint64(5), last.UserID)
+}
+
+func TestRollback_ProjectToTimestamp(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	title := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Hello", Status: "active"}
+	require.NoError(t, repo.Create(ctx, title))
+
+	// 回滚时刻之前的历史
+	to := time.Now().Add(-time.Hour)
+	require.NoError(t, testDB.Model(&domain.TranslationHistory{}).
+		Where("project_id = ?", project.ID).
+		Update("created_at", to.Add(-time.Hour)).Error)
+
+	title.Value = "Welcome"
+	require.NoError(t, repo.Update(ctx, title))
+	added := &domain.Translation{ProjectID: project.ID, KeyName: "home.added", LanguageID: languages[0].ID, Value: "Added", Status: "active"}
+	require.NoError(t, repo.Create(ctx, added))
+
+	result, err := newRollbackService().RollbackProject(ctx, project.ID, domain.ProjectRollbackParams{To: to}, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Translations)
+	assert.Equal(t, 1, result.Skipped)
+
+	stored, err := repo.GetByID(ctx, title.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", stored.Value)
+	// 之后才创建的翻译保留
+	stored, err = repo.GetByID(ctx, added.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Added", stored.Value)
+
+	entries := projectHistory(t, project.ID)
+	assert.Equal(t, domain.HistoryOperationRollback, entries[len(entries)-1].Operation)
+}
@@ -0,0 +1,109 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestTranslationSearch_FindCandidates(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationSearchRepository(testDB)
+	translations := repository.NewTranslationRepository(testDB)
+	project, other := createProject(t), createProject(t)
+	languages := createLanguages(t, 2)
+	require.NoError(t, translations.CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "checkout.pay", Value: "Pay now", Status: "active", UpdatedBy: 5},
+		{ProjectID: project.ID, LanguageID: languages[1].ID, KeyName: "checkout.pay", Value: "Jetzt bezahlen", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "cafe.title", Value: "Café menu", Status: "deprecated"},
+		{ProjectID: other.ID, LanguageID: languages[0].ID, KeyName: "checkout.pay", Value: "PAY NOW", Status: "active"},
+	}))
+	projectIDs := []uint64{project.ID, other.ID}
+
+	find := func(query domain.TranslationSearchQuery) []string {
+		query.ProjectIDs, query.Limit = projectIDs, 10
+		candidates, err := repo.FindCandidates(ctx, query)
+		require.NoError(t, err)
+		values := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			values = append(values, candidate.Value)
+		}
+		return values
+	}
+
+	// exact 区分大小写
+	assert.Equal(t, []string{"Pay now"}, find(domain.TranslationSearchQuery{Mode: domain.SearchModeExact, Text: "Pay"}))
+	// regex 忽略大小写，同时匹配键名
+	assert.ElementsMatch(t, []string{"Pay now", "PAY NOW", "Jetzt bezahlen"},
+		find(domain.TranslationSearchQuery{Mode: domain.SearchModeRegex, Text: `^checkout\.`}))
+	// fuzzy 忽略大小写和重音
+	assert.Equal(t, []string{"Café menu"}, find(domain.TranslationSearchQuery{Mode: domain.SearchModeFuzzy, Terms: []string{"CAFE"}}))
+
+	// 过滤条件
+	assert.ElementsMatch(t, []string{"Pay now", "PAY NOW"},
+		find(domain.TranslationSearchQuery{Mode: domain.SearchModeFuzzy, Terms: []string{"pay"}, LanguageCode: languages[0].Code, Status: "active"}))
+	assert.Equal(t, []string{"Pay now"},
+		find(domain.TranslationSearchQuery{Mode: domain.SearchModeFuzzy, Terms: []string{"pay"}, UpdatedBy: 5}))
+
+	candidates, err := repo.FindCandidates(ctx, domain.TranslationSearchQuery{Mode: domain.SearchModeExact, Text: "Pay now", ProjectIDs: projectIDs, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, project.Name, candidates[0].ProjectName)
+	assert.Equal(t, languages[0].Code, candidates[0].LanguageCode)
+}
+
+func TestTranslationSearch_FullText(t *testing.T) {
+	ctx := context.Background()
+	fullText := repository.NewFullTextSearchRepository(testDB)
+	like := repository.NewTranslationSearchRepository(testDB)
+	translations := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	require.NoError(t, translations.CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "cart.save", Value: "Save your cart for later", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "cart.save_changes", Value: "Save changes to cart", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "cart.title", Value: "购物车", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "cart.empty", Value: "Your cart is at zero", Status: "active"},
+	}))
+
+	keys := func(candidates []*domain.TranslationSearchCandidate) []string {
+		names := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			names = append(names, candidate.KeyName)
+		}
+		return names
+	}
+
+	// 通过全文索引得到的结果与逐行匹配一致，包括停用词、中文和无法使用索引的查询
+	for _, query := range []domain.TranslationSearchQuery{
+		{Mode: domain.SearchModeExact, Text: "Save changes"},
+		{Mode: domain.SearchModeExact, Text: "at zero"},
+		{Mode: domain.SearchModeExact, Text: "ve ch"},
+		{Mode: domain.SearchModeExact, Text: "cart.s"},
+		{Mode: domain.SearchModeFuzzy, Terms: []string{"购物"}},
+		{Mode: domain.SearchModeFuzzy, Terms: []string{"changes", "later"}},
+		{Mode: domain.SearchModeRegex, Text: `^save`},
+	} {
+		query.ProjectIDs, query.Limit = []uint64{project.ID}, 10
+		expected, err := like.FindCandidates(ctx, query)
+		require.NoError(t, err)
+		actual, err := fullText.FindCandidates(ctx, query)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, keys(expected), keys(actual), "%s %q %v", query.Mode, query.Text, query.Terms)
+	}
+
+	// fuzzy 模式按全文相关度排序：同时包含两个片段的在前
+	candidates, err := fullText.FindCandidates(ctx, domain.TranslationSearchQuery{
+		Mode: domain.SearchModeFuzzy, Terms: []string{"save", "changes"}, ProjectIDs: []uint64{project.ID}, Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "cart.save_changes", candidates[0].KeyName)
+}
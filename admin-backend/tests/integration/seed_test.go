@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/seed"
+	"yflow/internal/service"
+)
+
+func TestSeeder_RunIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := repository.NewProjectRepository(testDB)
+	languageRepo := repository.NewLanguageRepository(testDB)
+	transactor := repository.NewTransactor(testDB)
+	seeder := seed.NewSeeder(
+		newProjectService(),
+		service.NewLanguageService(languageRepo),
+		service.NewTranslationService(repository.NewTranslationRepository(testDB), projectRepo, languageRepo,
+			repository.NewNamespaceRepository(testDB), repository.NewReleaseRepository(testDB), nil, transactor),
+		repository.NewUserRepository(testDB),
+		zap.NewNop(),
+	)
+	user := createMemberUser(t, "it-seed")
+	opts := seed.Options{
+		Projects:      2,
+		Keys:          5,
+		Languages:     2,
+		FillRate:      1,
+		ProjectPrefix: uniqueName("it-seed"),
+		BatchSize:     3,
+		RandomSeed:    42,
+		Username:      user.Username,
+	}
+
+	// seedRows 返回生成的项目 ID 和每个项目的翻译条数
+	seedRows := func() ([]uint64, map[uint64]int64) {
+		var projects []*domain.Project
+		require.NoError(t, testDB.Where("name LIKE ?", opts.ProjectPrefix+" %").Order("name").Find(&projects).Error)
+		ids := make([]uint64, 0, len(projects))
+		counts := make(map[uint64]int64, len(projects))
+		for i, project := range projects {
+			assert.Equal(t, fmt.Sprintf("%s %02d", opts.ProjectPrefix, i+1), project.Name)
+			var count int64
+			require.NoError(t, testDB.Model(&domain.Translation{}).Where("project_id = ?", project.ID).Count(&count).Error)
+			ids = append(ids, project.ID)
+			counts[project.ID] = count
+		}
+		return ids, counts
+	}
+
+	require.NoError(t, seeder.Run(ctx, opts))
+	ids, counts := seedRows()
+	require.Len(t, ids, opts.Projects)
+	for _, id := range ids {
+		assert.Equal(t, int64(opts.Keys*opts.Languages), counts[id], "完成率为 1 时每个键都有全部语言的翻译")
+	}
+	languages, err := languageRepo.GetAll(ctx)
+	require.NoError(t, err)
+
+	// 再次运行复用已有的项目和语言，不产生重复数据
+	require.NoError(t, seeder.Run(ctx, opts))
+	rerunIDs, rerunCounts := seedRows()
+	assert.Equal(t, ids, rerunIDs)
+	assert.Equal(t, counts, rerunCounts)
+	rerunLanguages, err := languageRepo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rerunLanguages, len(languages))
+}
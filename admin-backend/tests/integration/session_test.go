@@ -0,0 +1,96 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newSessionUserService 创建启用了会话管理的用户服务
+func newSessionUserService(sessions domain.SessionService) (*service.UserService, *service.AuthService) {
+	auth := service.NewAuthService(config.JWTConfig{
+		Secret:                 "session-test-secret",
+		ExpirationHours:        1,
+		RefreshSecret:          "session-test-refresh-secret",
+		RefreshExpirationHours: 24,
+	})
+	return service.NewUserService(repository.NewUserRepository(testDB), auth, nil, repository.NewTransactor(testDB), nil, nil, nil, sessions), auth
+}
+
+func TestSession_LoginRefreshAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	sessions := service.NewSessionService(testCache, time.Hour, 24*time.Hour, nil)
+	users, auth := newSessionUserService(sessions)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &domain.User{Username: uniqueName("it-session"), Email: uniqueName("it-session") + "@example.com", Password: string(hashed), Role: "member", Status: "active"}
+	require.NoError(t, testDB.Create(user).Error)
+
+	laptop, err := users.Login(domain.WithClientInfo(ctx, "10.0.0.1", "Firefox"), domain.LoginParams{Username: user.Username, Password: "secret123"})
+	require.NoError(t, err)
+	phone, err := users.Login(domain.WithClientInfo(ctx, "10.0.0.2", "Safari"), domain.LoginParams{Username: user.Username, Password: "secret123"})
+	require.NoError(t, err)
+
+	laptopID, err := auth.SessionID(ctx, laptop.AccessToken)
+	require.NoError(t, err)
+	require.NotEmpty(t, laptopID)
+	phoneID, err := auth.SessionID(ctx, phone.AccessToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, laptopID, phoneID)
+	require.NoError(t, sessions.Validate(ctx, user.ID, laptopID))
+
+	list, err := sessions.List(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	agents := []string{list[0].UserAgent, list[1].UserAgent}
+	assert.ElementsMatch(t, []string{"Firefox", "Safari"}, agents)
+
+	// 刷新令牌沿用原会话
+	refreshed, err := users.RefreshToken(ctx, laptop.RefreshToken)
+	require.NoError(t, err)
+	refreshedID, err := auth.SessionID(ctx, refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, laptopID, refreshedID)
+
+	// 撤销后访问令牌和刷新令牌都失效，其他会话不受影响
+	require.NoError(t, sessions.Revoke(ctx, user.ID, laptopID))
+	assert.ErrorIs(t, sessions.Validate(ctx, user.ID, laptopID), domain.ErrSessionRevoked)
+	_, err = users.RefreshToken(ctx, refreshed.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	require.NoError(t, sessions.Validate(ctx, user.ID, phoneID))
+	assert.ErrorIs(t, sessions.Revoke(ctx, user.ID, laptopID), domain.ErrSessionNotFound)
+	// 只能撤销自己的会话
+	assert.ErrorIs(t, sessions.Revoke(ctx, user.ID+1, phoneID), domain.ErrSessionNotFound)
+
+	// 强制下线撤销全部会话
+	revoked, err := sessions.RevokeAll(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	assert.ErrorIs(t, sessions.Validate(ctx, user.ID, phoneID), domain.ErrSessionRevoked)
+	list, err = sessions.List(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+	revoked, err = sessions.RevokeAll(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, revoked)
+}
+
+func TestSession_RejectsTokensWithoutSession(t *testing.T) {
+	ctx := context.Background()
+	sessions := service.NewSessionService(testCache, time.Hour, 24*time.Hour, nil)
+
+	assert.ErrorIs(t, sessions.Validate(ctx, 1, ""), domain.ErrSessionRevoked)
+	assert.ErrorIs(t, sessions.Validate(ctx, 1, "unknown"), domain.ErrSessionRevoked)
+}
@@ -0,0 +1,94 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func newTagService() *service.TagService {
+	return service.NewTagService(
+		repository.NewTagRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewTranslationRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestTag_FiltersMatrixExportAndSearch(t *testing.T) {
+	ctx := context.Background()
+	tags := newTagService()
+	translations := newTranslationService()
+	translationRepo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedPrefixTranslations(t, project.ID, languages, "home.title", "home.body", "settings.title")
+
+	mobile, err := tags.Create(ctx, project.ID, domain.TagParams{Name: "mobile"}, 1)
+	require.NoError(t, err)
+	_, err = tags.Create(ctx, project.ID, domain.TagParams{Name: "mobile"}, 1)
+	assert.ErrorIs(t, err, domain.ErrTagExists)
+
+	result, err := tags.TagKeys(ctx, project.ID, mobile.ID, []string{"home.title", "home.body"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Changed)
+
+	// 通过键的元数据设置的标签与标签接口修改的是同一组关联，没有的标签自动创建
+	_, err = translations.SetKeyMetadata(ctx, project.ID, domain.SetKeyMetadataParams{KeyName: "settings.title", Tags: []string{"beta"}}, 1)
+	require.NoError(t, err)
+	listed, err := tags.GetByProjectID(ctx, project.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "beta", listed[0].Name)
+	assert.Equal(t, int64(1), listed[0].Keys)
+	assert.Equal(t, int64(2), listed[1].Keys)
+	metadata, err := translationRepo.GetKeyMetadata(ctx, project.ID, []string{"home.title"})
+	require.NoError(t, err)
+	assert.Equal(t, "mobile", metadata["home.title"].Tags)
+
+	page, err := translations.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Tags: []string{"mobile"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home.body", "home.title"}, page.Keys)
+	page, err = translations.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Tags: []string{"mobile", "beta"}})
+	require.NoError(t, err)
+	assert.Len(t, page.Keys, 3)
+
+	values, err := translations.GetExportValues(ctx, project.ID, domain.ExportOptions{Tags: []string{"beta", " "}})
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+	assert.Contains(t, values, "settings.title")
+
+	candidates, err := repository.NewTranslationSearchRepository(testDB).FindCandidates(ctx, domain.TranslationSearchQuery{
+		Mode:       domain.SearchModeExact,
+		Text:       "title",
+		ProjectIDs: []uint64{project.ID},
+		Tags:       []string{"mobile"},
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "home.title", candidates[0].KeyName)
+
+	// 改名后键的 tags 字段同步为新名称，删除后移除
+	_, err = tags.Update(ctx, project.ID, mobile.ID, domain.TagParams{Name: "app"}, 1)
+	require.NoError(t, err)
+	metadata, err = translationRepo.GetKeyMetadata(ctx, project.ID, []string{"home.title"})
+	require.NoError(t, err)
+	assert.Equal(t, "app", metadata["home.title"].Tags)
+	require.NoError(t, tags.Delete(ctx, project.ID, mobile.ID))
+	metadata, err = translationRepo.GetKeyMetadata(ctx, project.ID, []string{"home.title"})
+	require.NoError(t, err)
+	assert.Empty(t, metadata["home.title"].Tags)
+	page, err = translations.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Tags: []string{"app"}})
+	require.NoError(t, err)
+	assert.Empty(t, page.Keys)
+}
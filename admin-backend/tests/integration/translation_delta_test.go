@@ -0,0 +1,59 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestTranslationDelta_ReturnsKeysChangedSinceRelease(t *testing.T) {
+	ctx := context.Background()
+	project, source, target := seedExportTranslations(t)
+	translationRepo := repository.NewTranslationRepository(testDB)
+	delta := service.NewTranslationDeltaService(
+		repository.NewProjectRepository(testDB),
+		repository.NewReleaseRepository(testDB),
+		translationRepo,
+		repository.NewTranslationHistoryRepository(testDB),
+	)
+
+	// 变更历史的时间精度为毫秒，间隔一段时间保证先后顺序
+	time.Sleep(20 * time.Millisecond)
+	_, _, err := newReleaseService().Create(ctx, project.ID, domain.ReleaseParams{Version: "v1.0.0", Override: true}, 1)
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	unchanged, err := delta.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: project.ID, Since: "v1.0.0", MaxKeys: 100})
+	require.NoError(t, err)
+	assert.Empty(t, unchanged.Translations)
+	assert.Empty(t, unchanged.Deleted)
+
+	require.NoError(t, translationRepo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "greeting", LanguageID: target.ID, Value: "Servus", Status: "active"},
+	}))
+	_, err = translationRepo.RenameKey(ctx, project.ID, "farewell", "goodbye", 1)
+	require.NoError(t, err)
+
+	changed, err := delta.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: project.ID, Since: "v1.0.0", MaxKeys: 100})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting": {source.Code: "Hello", target.Code: "Servus"},
+		"goodbye":  {source.Code: "Bye"},
+	}, changed.Translations)
+	assert.Equal(t, []string{"farewell"}, changed.Deleted)
+
+	// 从 synced_at 继续同步时没有新的变更
+	next, err := delta.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: project.ID, Since: changed.SyncedAt.Format(time.RFC3339Nano), MaxKeys: 100})
+	require.NoError(t, err)
+	assert.Empty(t, next.Translations)
+	assert.Empty(t, next.Deleted)
+}
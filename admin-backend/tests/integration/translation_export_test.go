@@ -0,0 +1,625 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newTranslationService 创建不带缓存的翻译服务
+func newTranslationService() *service.TranslationService {
+	return service.NewTranslationService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewNamespaceRepository(testDB),
+		repository.NewReleaseRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+// seedExportTranslations 写入导出测试数据：greeting 有两种语言，farewell 只有源语言，legacy 已废弃
+func seedExportTranslations(t *testing.T) (*domain.Project, *domain.Language, *domain.Language) {
+	t.Helper()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	source, target := languages[0], languages[1]
+
+	// 将第一种语言设为唯一的默认语言
+	require.NoError(t, testDB.Model(&domain.Language{}).Where("is_default = ?", true).Update("is_default", false).Error)
+	require.NoError(t, testDB.Model(source).Update("is_default", true).Error)
+
+	approved := domain.ReviewStatusApproved
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(context.Background(), []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "greeting", LanguageID: source.ID, Value: "Hello", Status: "active", ReviewStatus: approved},
+		{ProjectID: project.ID, KeyName: "greeting", LanguageID: target.ID, Value: "Hallo", Status: "active", ReviewStatus: approved},
+		{ProjectID: project.ID, KeyName: "farewell", LanguageID: source.ID, Value: "Bye", Status: "active", ReviewStatus: approved},
+		{ProjectID: project.ID, KeyName: "legacy", LanguageID: target.ID, Value: "Alt", Status: "deprecated"},
+	}))
+	return project, source, target
+}
+
+func TestTranslationExport_MissingModes(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	// 默认不输出缺失的键，也不导出已废弃的翻译
+	values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{OnlyStatus: domain.ExportOnlyStatusApproved})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting": {source.Code: "Hello", target.Code: "Hallo"},
+		"farewell": {source.Code: "Bye"},
+	}, values)
+
+	values, err = svc.GetExportValues(ctx, project.ID, domain.ExportOptions{Missing: domain.ExportMissingEmpty})
+	require.NoError(t, err)
+	assert.Equal(t, "", values["farewell"][target.Code])
+	assert.NotContains(t, values, "legacy")
+
+	values, err = svc.GetExportValues(ctx, project.ID, domain.ExportOptions{Missing: domain.ExportMissingSourceFallback})
+	require.NoError(t, err)
+	assert.Equal(t, "Bye", values["farewell"][target.Code])
+	assert.Equal(t, "Hallo", values["greeting"][target.Code])
+
+	// 文件导出与 JSON 导出使用相同的选项
+	files, err := svc.ExportFiles(ctx, project.ID, "json", domain.ExportOptions{Missing: domain.ExportMissingSourceFallback})
+	require.NoError(t, err)
+	found := false
+	for _, file := range files {
+		if file.Locale != target.Code {
+			continue
+		}
+		found = true
+		var content map[string]string
+		require.NoError(t, json.Unmarshal(file.Content, &content))
+		assert.Equal(t, map[string]string{"greeting": "Hallo", "farewell": "Bye"}, content)
+	}
+	assert.True(t, found, "目标语言应生成导出文件")
+}
+
+func TestTranslationExport_InvalidOptions(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project := createProject(t)
+
+	_, err := svc.Export(ctx, project.ID, "json", domain.ExportOptions{OnlyStatus: "draft"})
+	assert.ErrorIs(t, err, domain.ErrInvalidExportStatus)
+
+	_, err = svc.ExportFiles(ctx, project.ID, "json", domain.ExportOptions{Missing: "placeholder"})
+	assert.ErrorIs(t, err, domain.ErrInvalidExportMissing)
+}
+
+func TestTranslationExport_XLIFFRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	data, err := svc.Export(ctx, project.ID, "xliff", domain.ExportOptions{XLIFFVersion: service.XLIFFVersion20, TargetLanguage: target.Code})
+	require.NoError(t, err)
+	files, err := service.ParseXLIFF(data)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, source.Code, files[0].SourceLanguage)
+	assert.Equal(t, target.Code, files[0].TargetLanguage)
+
+	// 模拟译者在 CAT 工具中修改译文并补充缺失的翻译
+	for i := range files[0].Units {
+		unit := &files[0].Units[i]
+		switch unit.Key {
+		case "greeting":
+			unit.Target = "Servus"
+		case "farewell":
+			assert.False(t, unit.HasTarget, "缺失的翻译不应输出 target")
+			unit.Target, unit.HasTarget = "Tschüss", true
+		}
+	}
+	data, err = service.MarshalXLIFF(service.XLIFFVersion12, files)
+	require.NoError(t, err)
+	_, err = svc.Import(ctx, project.ID, data, "xliff", domain.ImportOptions{})
+	require.NoError(t, err)
+
+	values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting": {source.Code: "Hello", target.Code: "Servus"},
+		"farewell": {source.Code: "Bye", target.Code: "Tschüss"},
+	}, values)
+
+	// 2.0 文档只能有一种目标语言
+	createLanguages(t, 1)
+	_, err = svc.Export(ctx, project.ID, "xliff", domain.ExportOptions{XLIFFVersion: service.XLIFFVersion20})
+	assert.ErrorIs(t, err, domain.ErrXLIFFMultipleTargets)
+}
+
+func TestTranslationExport_YAMLRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	data, err := svc.Export(ctx, project.ID, "yaml", domain.ExportOptions{})
+	require.NoError(t, err)
+	values, err := service.ParseLocaleYAML(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		source.Code: {"greeting": "Hello", "farewell": "Bye"},
+		target.Code: {"greeting": "Hallo"},
+	}, values)
+
+	// Rails 风格：嵌套的键平铺为 a.b 形式，未知的语言根节点跳过
+	rails := []byte(target.Code + ":\n  greeting: Servus\n  home:\n    title: Startseite\nxx-unknown:\n  greeting: x\n")
+	_, err = svc.Import(ctx, project.ID, rails, "yaml", domain.ImportOptions{})
+	require.NoError(t, err)
+
+	// Symfony 风格：没有语言根节点，由 language 指定语言
+	symfony := []byte("home:\n  title: Home\n")
+	_, err = svc.Import(ctx, project.ID, symfony, "yaml", domain.ImportOptions{Language: source.Code})
+	require.NoError(t, err)
+
+	exported, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting":   {source.Code: "Hello", target.Code: "Servus"},
+		"farewell":   {source.Code: "Bye"},
+		"home.title": {source.Code: "Home", target.Code: "Startseite"},
+	}, exported)
+
+	files, err := svc.ExportFiles(ctx, project.ID, "yaml", domain.ExportOptions{YAMLStyle: service.YAMLStyleSymfony})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, file := range files {
+		assert.Contains(t, file.Name, ".yaml")
+		if file.Locale == target.Code {
+			assert.Equal(t, "greeting: Servus\nhome:\n  title: Startseite\n", string(file.Content))
+		}
+	}
+
+	// 没有语言根节点又未指定语言时无法导入
+	_, err = svc.Import(ctx, project.ID, symfony, "yaml", domain.ImportOptions{})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrYAMLNoTranslations.Code, appErr.Code)
+}
+
+func TestTranslationExport_Gettext(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, _, target := seedExportTranslations(t)
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ?", project.ID, "greeting").
+		Update("context", "Home page").Error)
+
+	data, err := svc.Export(ctx, project.ID, "po", domain.ExportOptions{TargetLanguage: target.Code})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "\"Language: "+target.Code+"\\n\"")
+	assert.Contains(t, string(data), "#. greeting\nmsgctxt \"Home page\"\nmsgid \"Hello\"\nmsgstr \"Hallo\"\n")
+	// 目标语言缺少的翻译输出空的 msgstr，已废弃的翻译不导出
+	assert.Contains(t, string(data), "#. farewell\nmsgid \"Bye\"\nmsgstr \"\"\n")
+	assert.NotContains(t, string(data), "legacy")
+
+	template, err := svc.Export(ctx, project.ID, "pot", domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(template), "msgid \"Hello\"\nmsgstr \"\"\n")
+	assert.NotContains(t, string(template), "Hallo")
+
+	files, err := svc.ExportFiles(ctx, project.ID, "po", domain.ExportOptions{TargetLanguage: target.Code})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, files[0].Name, target.Code)
+	assert.Equal(t, data, files[0].Content)
+}
+
+func TestTranslationExport_Mobile(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	// 未指定语言时导出默认语言
+	data, err := svc.Export(ctx, project.ID, service.FormatAndroidXML, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<string name="greeting">Hello</string>`)
+	assert.Contains(t, string(data), `<string name="farewell">Bye</string>`)
+
+	data, err = svc.Export(ctx, project.ID, service.FormatAppleStrings, domain.ExportOptions{TargetLanguage: target.Code})
+	require.NoError(t, err)
+	assert.Equal(t, "\"greeting\" = \"Hallo\";\n", string(data))
+
+	files, err := svc.ExportFiles(ctx, project.ID, service.FormatAppleStrings, domain.ExportOptions{})
+	require.NoError(t, err)
+	locales := make(map[string]string, len(files))
+	for _, file := range files {
+		locales[file.Locale] = file.Name
+	}
+	assert.Equal(t, source.Code+".strings", locales[source.Code])
+	assert.Equal(t, target.Code+".strings", locales[target.Code])
+
+	_, err = svc.Export(ctx, project.ID, service.FormatAppleStringsDict, domain.ExportOptions{TargetLanguage: "xx-unknown"})
+	assert.ErrorIs(t, err, domain.ErrLanguageNotFound)
+}
+
+func TestTranslationExport_ARBRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	// 未指定语言时导出默认语言
+	data, err := svc.Export(ctx, project.ID, service.FormatARB, domain.ExportOptions{})
+	require.NoError(t, err)
+	locale, values, _, err := service.ParseARB(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, strings.ReplaceAll(source.Code, "-", "_"), locale)
+	assert.Equal(t, map[string]string{"greeting": "Hello", "farewell": "Bye"}, values)
+
+	// @@locale 为 ll_CC 形式也能对应到语言；description 写入上下文
+	arb := []byte(`{
+  "@@locale": "` + strings.ReplaceAll(target.Code, "-", "_") + `",
+  "farewell": "Tschüss {name}",
+  "@farewell": {"description": "Shown on logout", "placeholders": {"name": {"type": "String"}}},
+  "empty": ""
+}`)
+	_, err = svc.Import(ctx, project.ID, arb, service.FormatARB, domain.ImportOptions{})
+	require.NoError(t, err)
+
+	data, err = svc.Export(ctx, project.ID, service.FormatARB, domain.ExportOptions{TargetLanguage: target.Code})
+	require.NoError(t, err)
+	_, values, contexts, err := service.ParseARB(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"greeting": "Hallo", "farewell": "Tschüss {name}"}, values)
+	assert.Equal(t, "Shown on logout", contexts["farewell"])
+	assert.Contains(t, string(data), `"type": "String"`)
+
+	files, err := svc.ExportFiles(ctx, project.ID, service.FormatARB, domain.ExportOptions{})
+	require.NoError(t, err)
+	for _, file := range files {
+		assert.Equal(t, strings.ReplaceAll(file.Locale, "-", "_")+".arb", file.Name)
+	}
+
+	// 语言不存在时不导入
+	_, err = svc.Import(ctx, project.ID, []byte(`{"greeting": "x"}`), service.FormatARB, domain.ImportOptions{Language: "xx-unknown"})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrARBNoTranslations.Code, appErr.Code)
+}
+
+func TestTranslationImport_SpreadsheetDryRun(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	csv := []byte("key," + source.Code + "," + target.Code + ",xx-unknown\n" +
+		"greeting,Hello,Servus,x\n" +
+		"farewell,Bye,,\n" +
+		"home.title,Draft,,\n" +
+		",orphan,,\n" +
+		"home.title,Home,,\n" +
+		"todo,,,\n")
+
+	preview, err := svc.ImportSpreadsheet(ctx, project.ID, domain.SpreadsheetUploadParams{Data: csv, DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, preview.DryRun)
+	assert.Equal(t, 1, preview.Created)
+	assert.Equal(t, 1, preview.Updated)
+	assert.Equal(t, 4, preview.Skipped)
+	assert.Equal(t, 2, preview.Translations)
+	assert.Equal(t, []string{"xx-unknown"}, preview.UnmappedLanguages)
+	require.Len(t, preview.Rows, 6)
+	assert.Equal(t, domain.SpreadsheetRowPreview{Row: 2, Key: "greeting", Action: domain.SpreadsheetRowUpdate, Languages: []string{target.Code}}, preview.Rows[0])
+	assert.Equal(t, "译文没有变化", preview.Rows[1].Reason)
+	assert.Equal(t, domain.SpreadsheetRowSkip, preview.Rows[2].Action)
+	assert.Contains(t, preview.Rows[2].Reason, "第 6 行")
+	assert.Equal(t, domain.SpreadsheetRowCreate, preview.Rows[4].Action)
+	assert.Equal(t, "没有译文", preview.Rows[5].Reason)
+
+	// 预览不写入
+	values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", values["greeting"][target.Code])
+	assert.NotContains(t, values, "home.title")
+
+	result, err := svc.ImportSpreadsheet(ctx, project.ID, domain.SpreadsheetUploadParams{Data: csv})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Translations)
+
+	values, err = svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"greeting":   {source.Code: "Hello", target.Code: "Servus"},
+		"farewell":   {source.Code: "Bye"},
+		"home.title": {source.Code: "Home"},
+	}, values)
+
+	// 表头中没有可识别的语言时不导入
+	_, err = svc.ImportSpreadsheet(ctx, project.ID, domain.SpreadsheetUploadParams{Data: []byte("key,Notes\ngreeting,x\n")})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidSpreadsheet.Code, appErr.Code)
+}
+
+func TestTranslationImport_ConflictStrategies(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+
+	importJSON := func(t *testing.T, strategy string) (*domain.Project, *domain.Language, *domain.Language, *domain.ImportResult, error) {
+		project, source, target := seedExportTranslations(t)
+		data, err := json.Marshal(map[string]map[string]string{
+			"greeting": {source.Code: "Hi", target.Code: "Hallo"},
+			"farewell": {target.Code: "Tschüss"},
+			"welcome":  {source.Code: "Welcome"},
+		})
+		require.NoError(t, err)
+		result, err := svc.Import(ctx, project.ID, data, "json", domain.ImportOptions{Strategy: strategy})
+		return project, source, target, result, err
+	}
+	actions := func(result *domain.ImportResult) map[string]string {
+		actions := make(map[string]string, len(result.Keys))
+		for _, key := range result.Keys {
+			actions[key.Key] = key.Action
+		}
+		return actions
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		// json 格式默认为 fail：greeting 已有翻译，整个导入不写入
+		project, source, _, _, err := importJSON(t, "")
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok)
+		assert.Equal(t, domain.ErrImportConflict.Code, appErr.Code)
+		assert.Contains(t, appErr.Details, "greeting")
+
+		values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", values["greeting"][source.Code])
+		assert.NotContains(t, values, "welcome")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		project, source, target, result, err := importJSON(t, domain.ImportStrategySkip)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"farewell": domain.ImportKeySkip,
+			"greeting": domain.ImportKeySkip,
+			"welcome":  domain.ImportKeyCreate,
+		}, actions(result))
+		assert.Equal(t, 1, result.Translations)
+
+		values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", values["greeting"][source.Code])
+		assert.NotContains(t, values["farewell"], target.Code)
+		assert.Equal(t, "Welcome", values["welcome"][source.Code])
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		project, source, target, result, err := importJSON(t, domain.ImportStrategyMerge)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"farewell": domain.ImportKeyUpdate,
+			"greeting": domain.ImportKeySkip,
+			"welcome":  domain.ImportKeyCreate,
+		}, actions(result))
+
+		values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", values["greeting"][source.Code])
+		assert.Equal(t, "Tschüss", values["farewell"][target.Code])
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		project, source, target, result, err := importJSON(t, domain.ImportStrategyOverwrite)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"farewell": domain.ImportKeyUpdate,
+			"greeting": domain.ImportKeyUpdate,
+			"welcome":  domain.ImportKeyCreate,
+		}, actions(result))
+		// 译文没有变化的 Hallo 不写入
+		assert.Equal(t, 3, result.Translations)
+		assert.Equal(t, []string{target.Code}, result.Keys[1].SkippedLanguages)
+
+		values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Hi", values["greeting"][source.Code])
+	})
+
+	_, err := svc.Import(ctx, createProject(t).ID, []byte(`{}`), "json", domain.ImportOptions{Strategy: "replace"})
+	assert.Equal(t, domain.ErrInvalidImportStrategy, err)
+}
+
+func TestTranslationValueType_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	// 值为数组的键按 json 类型导入
+	data := []byte(`{"features": {"` + source.Code + `": ["Fast", "Safe"], "` + target.Code + `": ["Schnell"]}}`)
+	result, err := svc.Import(ctx, project.ID, data, "json", domain.ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Created)
+
+	page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Keyword: "features"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ValueTypeJSON, page.Matrix["features"][source.Code].ValueType)
+	assert.Equal(t, `["Fast","Safe"]`, page.Matrix["features"][source.Code].Value)
+
+	// 导出为数组，重新导入时译文没有变化
+	exported, err := svc.Export(ctx, project.ID, "json", domain.ExportOptions{})
+	require.NoError(t, err)
+	var document map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(exported, &document))
+	assert.Equal(t, []interface{}{"Fast", "Safe"}, document["features"][source.Code])
+	assert.Equal(t, "Hello", document["greeting"][source.Code])
+
+	result, err = svc.Import(ctx, project.ID, exported, "json", domain.ImportOptions{Strategy: domain.ImportStrategyOverwrite})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Translations)
+
+	// 已有的值不符合 JSON Schema 时不修改
+	_, err = svc.SetValueType(ctx, project.ID, domain.SetValueTypeParams{
+		KeyName: "features", ValueType: domain.ValueTypeJSON, ValueSchema: `{"type": "array", "maxItems": 1}`,
+	}, 0)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidJSONValue.Code, appErr.Code)
+
+	changed, err := svc.SetValueType(ctx, project.ID, domain.SetValueTypeParams{
+		KeyName: "features", ValueType: domain.ValueTypeJSON, ValueSchema: `{"type": "array", "items": {"type": "string"}}`,
+	}, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, changed.Translations)
+
+	// 新写入的值按键的 JSON Schema 校验
+	err = svc.UpsertBatch(ctx, []domain.TranslationInput{{ProjectID: project.ID, KeyName: "features", LanguageID: target.ID, Value: `[1]`}})
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidJSONValue.Code, appErr.Code)
+
+	// 普通文本不能改为 json 类型，已有的 string 键也不能写入 json 值
+	_, err = svc.SetValueType(ctx, project.ID, domain.SetValueTypeParams{KeyName: "greeting", ValueType: domain.ValueTypeJSON}, 0)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidJSONValue.Code, appErr.Code)
+
+	err = svc.UpsertBatch(ctx, []domain.TranslationInput{{ProjectID: project.ID, KeyName: "greeting", LanguageID: source.ID, Value: `["Hi"]`, ValueType: domain.ValueTypeJSON}})
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrValueTypeMismatch.Code, appErr.Code)
+}
+
+func TestTranslationKeyMetadata_MaxLength(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, _, target := seedExportTranslations(t)
+
+	// 已有的译文超过最大长度时不修改
+	_, err := svc.SetKeyMetadata(ctx, project.ID, domain.SetKeyMetadataParams{KeyName: "greeting", MaxLength: 4}, 0)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrValueTooLong.Code, appErr.Code)
+
+	result, err := svc.SetKeyMetadata(ctx, project.ID, domain.SetKeyMetadataParams{
+		KeyName: "greeting", MaxLength: 6, Tags: []string{" checkout ", "button", "checkout"}, Platform: "iOS",
+	}, 0)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, result.Translations)
+	assert.Equal(t, []string{"checkout", "button"}, result.Tags)
+	assert.Equal(t, domain.KeyPlatformIOS, result.Platform)
+
+	page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Keyword: "greeting"})
+	require.NoError(t, err)
+	assert.Equal(t, 6, page.Matrix["greeting"][target.Code].MaxLength)
+
+	// 之后写入的译文按字符数检查，新语言的翻译沿用键的元数据
+	err = svc.UpsertBatch(ctx, []domain.TranslationInput{{ProjectID: project.ID, KeyName: "greeting", LanguageID: target.ID, Value: "Grüß Gott"}})
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrValueTooLong.Code, appErr.Code)
+
+	third := createLanguages(t, 1)[0]
+	created, err := svc.Create(ctx, domain.TranslationInput{ProjectID: project.ID, KeyName: "greeting", LanguageID: third.ID, Value: "Ciào"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 6, created.MaxLength)
+	assert.Equal(t, "checkout,button", created.Tags)
+
+	_, err = svc.Update(ctx, created.ID, domain.TranslationInput{Value: "Buongiorno"}, 0)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrValueTooLong.Code, appErr.Code)
+
+	_, err = svc.SetKeyMetadata(ctx, project.ID, domain.SetKeyMetadataParams{KeyName: "greeting", Platform: "tv"}, 0)
+	assert.Equal(t, domain.ErrInvalidKeyPlatform, err)
+	_, err = svc.SetKeyMetadata(ctx, project.ID, domain.SetKeyMetadataParams{KeyName: "farewell", Tags: []string{"a,b"}}, 0)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidKeyTags.Code, appErr.Code)
+}
+
+func TestTranslationReview_Workflow(t *testing.T) {
+	ctx := context.Background()
+	svc := newTranslationService()
+	project, source, target := seedExportTranslations(t)
+
+	created, err := svc.Create(ctx, domain.TranslationInput{ProjectID: project.ID, KeyName: "farewell", LanguageID: target.ID, Value: "Tschüss"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusDraft, created.ReviewStatus)
+
+	// 草稿不能直接审核通过，其他项目的翻译视为不存在
+	_, err = svc.ApproveTranslations(ctx, project.ID, domain.ReviewTransitionParams{TranslationIDs: []uint64{created.ID}}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidReviewTransition.Code, appErr.Code)
+	other := createProject(t)
+	_, err = svc.SubmitForReview(ctx, other.ID, domain.ReviewTransitionParams{TranslationIDs: []uint64{created.ID}}, 1)
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrorTypeNotFound, appErr.Type)
+
+	result, err := svc.SubmitForReview(ctx, project.ID, domain.ReviewTransitionParams{TranslationIDs: []uint64{created.ID, created.ID}}, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, result.Translations)
+
+	page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, ReviewStatus: domain.ReviewStatusInReview})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"farewell"}, page.Keys)
+	assert.Equal(t, domain.ReviewStatusInReview, page.Matrix["farewell"][target.Code].ReviewStatus)
+
+	// 只导出审核通过的翻译
+	values, err := svc.GetExportValues(ctx, project.ID, domain.ExportOptions{OnlyStatus: domain.ExportOnlyStatusApproved})
+	require.NoError(t, err)
+	assert.NotContains(t, values["farewell"], target.Code)
+
+	_, err = svc.ApproveTranslations(ctx, project.ID, domain.ReviewTransitionParams{TranslationIDs: []uint64{created.ID}}, 1)
+	require.NoError(t, err)
+	approved, err := svc.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, approved.ReviewStatus)
+	assert.EqualValues(t, 1, approved.ReviewedBy)
+	assert.NotNil(t, approved.ReviewedAt)
+	values, err = svc.GetExportValues(ctx, project.ID, domain.ExportOptions{OnlyStatus: domain.ExportOnlyStatusApproved})
+	require.NoError(t, err)
+	assert.Equal(t, "Tschüss", values["farewell"][target.Code])
+
+	// 写入相同的值保持审核状态，修改译文后回到草稿
+	require.NoError(t, svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: project.ID, KeyName: "farewell", LanguageID: target.ID, Value: "Tschüss"},
+		{ProjectID: project.ID, KeyName: "farewell", LanguageID: source.ID, Value: "Goodbye"},
+	}))
+	page, err = svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Keyword: "farewell"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, page.Matrix["farewell"][target.Code].ReviewStatus)
+	assert.Equal(t, domain.ReviewStatusDraft, page.Matrix["farewell"][source.Code].ReviewStatus)
+
+	_, err = svc.RejectTranslations(ctx, project.ID, domain.ReviewTransitionParams{TranslationIDs: []uint64{created.ID}, Comment: " Zu informell "}, 1)
+	require.NoError(t, err)
+	rejected, err := svc.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusDraft, rejected.ReviewStatus)
+	assert.Equal(t, "Zu informell", rejected.ReviewComment)
+
+	// 每次审核状态变化记录一条 review 变更历史
+	var reviews []*domain.TranslationHistory
+	for _, entry := range projectHistory(t, project.ID) {
+		if entry.Operation == domain.HistoryOperationReview {
+			reviews = append(reviews, entry)
+		}
+	}
+	require.Len(t, reviews, 3)
+	assert.Equal(t, []string{domain.ReviewStatusDraft, domain.ReviewStatusInReview, domain.ReviewStatusApproved},
+		[]string{reviews[0].OldStatus, reviews[1].OldStatus, reviews[2].OldStatus})
+	assert.Equal(t, domain.ReviewStatusApproved, reviews[1].NewStatus)
+	assert.Equal(t, "Tschüss", reviews[1].NewValue)
+	assert.Equal(t, "Zu informell", reviews[2].Comment)
+
+	_, _, err = svc.GetByProjectID(ctx, project.ID, 10, 0, "reviewed", nil)
+	assert.Equal(t, domain.ErrInvalidReviewStatus, err)
+}
@@ -0,0 +1,174 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newMatrixTranslationService 创建不带缓存的翻译服务
+func newMatrixTranslationService() *service.TranslationService {
+	return service.NewTranslationService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		repository.NewNamespaceRepository(testDB),
+		repository.NewReleaseRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+	)
+}
+
+func TestTranslationMatrix_LocaleAwareKeySort(t *testing.T) {
+	ctx := context.Background()
+	svc := newMatrixTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "zebra", "Öl", "ameise", "Äpfel")
+
+	tests := []struct {
+		collation  string
+		descending bool
+		want       []string
+	}{
+		{"", false, []string{"ameise", "zebra", "Äpfel", "Öl"}},
+		{"de", false, []string{"ameise", "Äpfel", "Öl", "zebra"}},
+		{"sv", false, []string{"ameise", "zebra", "Äpfel", "Öl"}},
+		{"de", true, []string{"zebra", "Öl", "Äpfel", "ameise"}},
+	}
+	for _, tt := range tests {
+		page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{
+			ProjectID:  project.ID,
+			Limit:      10,
+			Collation:  tt.collation,
+			Descending: tt.descending,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, page.Keys, "collation=%q descending=%v", tt.collation, tt.descending)
+		assert.Equal(t, int64(4), page.Total)
+		assert.Len(t, page.Matrix, 4)
+	}
+
+	// 分页在排序之后进行
+	page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 2, Offset: 1, Collation: "de"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Äpfel", "Öl"}, page.Keys)
+
+	_, err = svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Collation: "not a locale!"})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrorTypeValidation, appErr.Type)
+}
+
+func TestTranslationMatrix_ValueSort(t *testing.T) {
+	ctx := context.Background()
+	svc := newMatrixTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	// missing 只有第二种语言的翻译
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "first", Value: "Zucker", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "second", Value: "Ärger", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "third", Value: "Apfel", Status: "active"},
+		{ProjectID: project.ID, LanguageID: languages[1].ID, KeyName: "missing", Value: "A", Status: "active"},
+	}))
+
+	page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{
+		ProjectID:    project.ID,
+		Limit:        10,
+		SortBy:       domain.MatrixSortByValue,
+		SortLanguage: languages[0].Code,
+		Collation:    "de",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"third", "second", "first", "missing"}, page.Keys)
+
+	_, err = svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, SortBy: domain.MatrixSortByValue})
+	_, ok := domain.IsAppError(err)
+	assert.True(t, ok, "按翻译值排序时必须指定语言")
+}
+
+func TestTranslationMatrix_SearchCollation(t *testing.T) {
+	ctx := context.Background()
+	svc := newMatrixTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "menu.Äpfel", "menu.apfelsaft", "menu.birne")
+
+	search := func(keyword, collation string) []string {
+		t.Helper()
+		page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{
+			ProjectID:       project.ID,
+			Limit:           10,
+			Keyword:         keyword,
+			SearchCollation: collation,
+		})
+		require.NoError(t, err)
+		return page.Keys
+	}
+
+	assert.Equal(t, []string{"menu.apfelsaft", "menu.Äpfel"}, search("APFEL", domain.SearchCollationAccentInsensitive))
+	assert.Equal(t, []string{"menu.Äpfel"}, search("äpfel", domain.SearchCollationCaseInsensitive))
+	assert.Equal(t, []string{"menu.apfelsaft"}, search("apfel", domain.SearchCollationExact))
+	assert.Empty(t, search("APFEL", domain.SearchCollationExact))
+
+	// LIKE 通配符按字面匹配
+	assert.Empty(t, search("menu_", domain.SearchCollationAccentInsensitive))
+
+	_, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10, Keyword: "a", SearchCollation: "latin1_swedish_ci"})
+	_, ok := domain.IsAppError(err)
+	assert.True(t, ok)
+}
+
+func TestTranslationMatrix_CellAttribution(t *testing.T) {
+	ctx := context.Background()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	editor := &domain.User{Username: uniqueName("it-editor"), Email: uniqueName("it-editor") + "@example.com", Password: "x", Status: "active"}
+	require.NoError(t, testDB.Create(editor).Error)
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, LanguageID: languages[0].ID, KeyName: "title", Value: "Title", Status: "active", UpdatedBy: editor.ID},
+		{ProjectID: project.ID, LanguageID: languages[1].ID, KeyName: "title", Value: "Titel", Status: "active"},
+	}))
+
+	cells, err := repository.NewTranslationRepository(testDB).GetMatrixCells(ctx, project.ID, []string{"title"})
+	require.NoError(t, err)
+	edited := cells["title"][languages[0].Code]
+	assert.Equal(t, "active", edited.Status)
+	assert.Equal(t, editor.ID, edited.UpdatedBy)
+	assert.Equal(t, editor.Username, edited.UpdatedByUsername)
+	// 没有记录修改人的单元格用户名为空
+	assert.Zero(t, cells["title"][languages[1].Code].UpdatedBy)
+	assert.Empty(t, cells["title"][languages[1].Code].UpdatedByUsername)
+}
+
+func TestTranslationMatrix_CellQAIssues(t *testing.T) {
+	ctx := context.Background()
+	svc := newMatrixTranslationService()
+	project := createProject(t)
+	languages := createLanguages(t, 3)
+	source, german, french := languages[0], languages[1], languages[2]
+	require.NoError(t, testDB.Model(&domain.Language{}).Where("is_default = ?", true).Update("is_default", false).Error)
+	require.NoError(t, testDB.Model(source).Update("is_default", true).Error)
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, LanguageID: source.ID, KeyName: "greeting", Value: "Hello <b>{{name}}</b> ", Status: "active"},
+		{ProjectID: project.ID, LanguageID: german.ID, KeyName: "greeting", Value: "Hallo <b>{{name}}</b> ", Status: "active"},
+		{ProjectID: project.ID, LanguageID: french.ID, KeyName: "greeting", Value: "Bonjour <b>{{nom}}", Status: "active"},
+	}))
+
+	page, err := svc.GetMatrixPage(ctx, domain.TranslationMatrixQuery{ProjectID: project.ID, Limit: 10})
+	require.NoError(t, err)
+	row := page.Matrix["greeting"]
+	assert.Empty(t, row[source.Code].QAIssues)
+	assert.Empty(t, row[german.Code].QAIssues)
+	assert.Equal(t, []string{domain.QAIssueMarkupInvalid, domain.QAIssuePlaceholderMismatch, domain.QAIssueTrailingWhitespace},
+		row[french.Code].QAIssues)
+}
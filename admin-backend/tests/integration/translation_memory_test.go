@@ -0,0 +1,72 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestTranslationMemory_FindCandidates(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationMemoryRepository(testDB)
+	translations := repository.NewTranslationRepository(testDB)
+	project, other := createProject(t), createProject(t)
+	languages := createLanguages(t, 2)
+	source, target := languages[0], languages[1]
+
+	pair := func(projectID uint64, keyName, sourceText, targetText, status string) []*domain.Translation {
+		return []*domain.Translation{
+			{ProjectID: projectID, LanguageID: source.ID, KeyName: keyName, Value: sourceText, Status: status},
+			{ProjectID: projectID, LanguageID: target.ID, KeyName: keyName, Value: targetText, Status: status},
+		}
+	}
+	var seed []*domain.Translation
+	seed = append(seed, pair(project.ID, "save", "Save changes", "Änderungen speichern", "active")...)
+	seed = append(seed, pair(project.ID, "save_all", "Save all changes", "Alle Änderungen speichern", "active")...)
+	seed = append(seed, pair(project.ID, "legacy", "Save changes now", "Jetzt speichern", "deprecated")...)
+	seed = append(seed, pair(project.ID, "empty", "Save changes?", "", "active")...)
+	seed = append(seed, pair(other.ID, "save", "Save changes", "Speichern", "active")...)
+	seed = append(seed, &domain.Translation{ProjectID: project.ID, LanguageID: source.ID, KeyName: "untranslated", Value: "Save changes!", Status: "active"})
+	require.NoError(t, translations.CreateBatch(ctx, seed))
+
+	query := domain.TMCandidateQuery{
+		SourceLanguageID: source.ID,
+		TargetLanguageID: target.ID,
+		Statuses:         []string{"active"},
+		ProjectIDs:       []uint64{project.ID},
+		MinLength:        6,
+		MaxLength:        24,
+		Terms:            []string{"changes"},
+		Text:             "Save changes",
+		Limit:            10,
+	}
+	candidates, err := repo.FindCandidates(ctx, query)
+	require.NoError(t, err)
+	// 只返回有效、译文不为空的翻译，完全相同的原文在前
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "save", candidates[0].KeyName)
+	assert.Equal(t, "Änderungen speichern", candidates[0].TargetText)
+	assert.Equal(t, "save_all", candidates[1].KeyName)
+
+	// 排除正在编辑的键
+	query.ExcludeProjectID, query.ExcludeKey = project.ID, "save"
+	candidates, err = repo.FindCandidates(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "save_all", candidates[0].KeyName)
+
+	// ProjectIDs 为 nil 时不限项目
+	query.ProjectIDs, query.MaxLength = nil, 12
+	candidates, err = repo.FindCandidates(ctx, query)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, other.ID, candidates[0].ProjectID)
+	assert.Equal(t, "Speichern", candidates[0].TargetText)
+}
@@ -0,0 +1,373 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+// seedTranslations 为项目写入 keys 个键 × 全部语言的翻译
+func seedTranslations(t *testing.T, projectID uint64, languages []*domain.Language, keys int) {
+	t.Helper()
+	translations := make([]*domain.Translation, 0, keys*len(languages))
+	for k := 0; k < keys; k++ {
+		for _, language := range languages {
+			translations = append(translations, &domain.Translation{
+				ProjectID:  projectID,
+				KeyName:    fmt.Sprintf("key.%03d", k),
+				LanguageID: language.ID,
+				Value:      fmt.Sprintf("%s-%03d", language.Code, k),
+				Status:     "active",
+			})
+		}
+	}
+	require.NoError(t, repository.NewTranslationRepository(testDB).CreateBatch(context.Background(), translations))
+}
+
+func TestTranslationRepository_GetMatrixPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 3)
+	seedTranslations(t, project.ID, languages, 25)
+
+	seen := make(map[string]bool)
+	for offset := 0; offset < 25; offset += 10 {
+		matrix, total, err := repo.GetMatrix(ctx, project.ID, 10, offset, "")
+		require.NoError(t, err)
+		assert.Equal(t, int64(25), total)
+
+		expected := 10
+		if offset == 20 {
+			expected = 5
+		}
+		assert.Len(t, matrix, expected, "offset=%d", offset)
+
+		for key, cells := range matrix {
+			assert.False(t, seen[key], "键 %s 在多个分页中重复出现", key)
+			seen[key] = true
+			assert.Len(t, cells, len(languages))
+		}
+	}
+	assert.Len(t, seen, 25, "所有分页合并后应覆盖全部键")
+
+	// 超出范围的分页返回空矩阵但保留总数
+	matrix, total, err := repo.GetMatrix(ctx, project.ID, 10, 100, "")
+	require.NoError(t, err)
+	assert.Empty(t, matrix)
+	assert.Equal(t, int64(25), total)
+}
+
+func TestTranslationRepository_GetMatrixKeyword(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 12)
+
+	// 按键名搜索
+	matrix, total, err := repo.GetMatrix(ctx, project.ID, 50, 0, "key.01")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Contains(t, matrix, "key.010")
+	assert.Contains(t, matrix, "key.011")
+
+	// 按翻译值搜索，匹配到的键返回所有语言的翻译
+	matrix, total, err = repo.GetMatrix(ctx, project.ID, 50, 0, languages[1].Code+"-005")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Contains(t, matrix, "key.005")
+	assert.Len(t, matrix["key.005"], 2)
+}
+
+func TestTranslationRepository_GetMatrixIsolatesProjects(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	languages := createLanguages(t, 1)
+	projectA := createProject(t)
+	projectB := createProject(t)
+	seedTranslations(t, projectA.ID, languages, 5)
+	seedTranslations(t, projectB.ID, languages, 7)
+
+	_, total, err := repo.GetMatrix(ctx, projectA.ID, 50, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+}
+
+func TestTranslationRepository_UpsertBatchConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 2)
+
+	err := repo.UpsertBatch(ctx, []*domain.Translation{
+		// 已存在：更新值和上下文
+		{ProjectID: project.ID, KeyName: "key.000", LanguageID: languages[0].ID, Value: "updated", Context: "ctx", Status: "active"},
+		// 不存在：新建
+		{ProjectID: project.ID, KeyName: "key.new", LanguageID: languages[0].ID, Value: "created", Status: "active"},
+	})
+	require.NoError(t, err)
+
+	updated, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.000", languages[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", updated.Value)
+	assert.Equal(t, "ctx", updated.Context)
+
+	// 同一键的其他语言不受影响
+	other, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.000", languages[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, languages[1].Code+"-000", other.Value)
+
+	created, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.new", languages[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "created", created.Value)
+
+	// 冲突更新不应产生重复行
+	var count int64
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ? AND language_id = ?", project.ID, "key.000", languages[0].ID).
+		Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTranslationRepository_GetKeyPageCursor(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 25)
+
+	seen := make(map[string]bool)
+	query := domain.TranslationKeyPageQuery{ProjectID: project.ID, Limit: 10}
+	for pages := 1; ; pages++ {
+		page, err := repo.GetKeyPage(ctx, query)
+		require.NoError(t, err)
+		for key, values := range page.Translations {
+			assert.False(t, seen[key], "键 %s 在多个分页中重复出现", key)
+			seen[key] = true
+			assert.Len(t, values, len(languages))
+		}
+		if !page.HasMore {
+			assert.Equal(t, 3, pages)
+			break
+		}
+		query.AfterKey = page.LastKey
+	}
+	assert.Len(t, seen, 25, "所有分页合并后应覆盖全部键")
+}
+
+func TestTranslationRepository_GetKeyPageScopes(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 3)
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "common.ok", LanguageID: languages[0].ID, Value: "OK", Status: "active"},
+		{ProjectID: project.ID, KeyName: "commonx", LanguageID: languages[0].ID, Value: "x", Status: "active"},
+	}))
+
+	// 前缀 "common." 不匹配 "commonx"
+	page, err := repo.GetKeyPage(ctx, domain.TranslationKeyPageQuery{ProjectID: project.ID, KeyPrefix: "common.", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"common.ok": {languages[0].Code: "OK"}}, page.Translations)
+
+	// 指定语言时只返回该语言有翻译的键
+	page, err = repo.GetKeyPage(ctx, domain.TranslationKeyPageQuery{ProjectID: project.ID, Locale: languages[1].Code, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, page.Translations, 3)
+	for _, values := range page.Translations {
+		assert.Len(t, values, 1)
+	}
+}
+
+func TestTranslationRepository_RecreateSoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+
+	original := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Old", Status: "deprecated"}
+	require.NoError(t, repo.Create(ctx, original))
+	require.NoError(t, repo.Delete(ctx, original.ID))
+
+	// 重新创建已删除的键时恢复原记录，而不是违反唯一索引
+	recreated := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "New"}
+	require.NoError(t, repo.Create(ctx, recreated))
+	assert.Equal(t, original.ID, recreated.ID)
+
+	stored, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "home.title", languages[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "New", stored.Value)
+	assert.Equal(t, "active", stored.Status)
+
+	// 批量创建时已删除的键和新键混合
+	require.NoError(t, repo.Delete(ctx, original.ID))
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Batch", Status: "active"},
+		{ProjectID: project.ID, KeyName: "home.subtitle", LanguageID: languages[0].ID, Value: "Sub", Status: "active"},
+	}))
+	page, err := repo.GetKeyPage(ctx, domain.TranslationKeyPageQuery{ProjectID: project.ID, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"home.title":    {languages[0].Code: "Batch"},
+		"home.subtitle": {languages[0].Code: "Sub"},
+	}, page.Translations)
+}
+
+func TestTranslationRepository_UpsertRestoresSoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedTranslations(t, project.ID, languages, 1)
+
+	existing, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.000", languages[0].ID)
+	require.NoError(t, err)
+	require.NoError(t, testDB.Model(existing).Update("status", "deprecated").Error)
+	require.NoError(t, repo.Delete(ctx, existing.ID))
+
+	require.NoError(t, repo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "key.000", LanguageID: languages[0].ID, Value: "imported", Status: "active"},
+	}))
+
+	restored, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.000", languages[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, restored.ID)
+	assert.Equal(t, "imported", restored.Value)
+	assert.Equal(t, "active", restored.Status, "恢复的记录使用导入的状态")
+}
+
+func TestTranslationRepository_RenameOntoSoftDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedPrefixTranslations(t, project.ID, languages, "old.title", "new.title", "draft.title")
+
+	deleted, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "new.title", languages[0].ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	// 修改键名为已删除的键
+	draft, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "draft.title", languages[0].ID)
+	require.NoError(t, err)
+	draft.KeyName = "new.title"
+	require.NoError(t, repo.Update(ctx, draft))
+
+	// 按前缀重命名到已删除的键
+	require.NoError(t, repo.Delete(ctx, draft.ID))
+	conflicts, err := repo.FindExistingKeyNames(ctx, project.ID, []string{"new.title"})
+	require.NoError(t, err)
+	assert.Empty(t, conflicts, "已删除的键不算冲突")
+
+	renamed, err := repo.RenamePrefix(ctx, project.ID, "old.", "new.", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), renamed)
+
+	stored, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "new.title", languages[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "old.title@"+languages[0].Code, stored.Value)
+}
+
+func TestTranslationRepository_MarkOutdated(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 3)
+	seedTranslations(t, project.ID, languages, 2)
+	require.NoError(t, testDB.Model(&domain.Translation{}).
+		Where("project_id = ? AND key_name = ? AND language_id = ?", project.ID, "key.000", languages[2].ID).
+		Update("value", "").Error)
+
+	marked, err := repo.MarkOutdated(ctx, project.ID, languages[0].ID, []string{"key.000"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked, "源语言和空译文不标记")
+
+	translations, err := repo.GetByProjectKeyLanguages(ctx, []domain.TranslationKey{
+		{ProjectID: project.ID, KeyName: "key.000", LanguageID: languages[0].ID},
+		{ProjectID: project.ID, KeyName: "key.000", LanguageID: languages[1].ID},
+		{ProjectID: project.ID, KeyName: "key.001", LanguageID: languages[1].ID},
+	})
+	require.NoError(t, err)
+	statuses := make(map[string]string)
+	for _, translation := range translations {
+		statuses[fmt.Sprintf("%s:%d", translation.KeyName, translation.LanguageID)] = translation.ReviewStatus
+	}
+	assert.NotEqual(t, domain.ReviewStatusOutdated, statuses[fmt.Sprintf("key.000:%d", languages[0].ID)])
+	assert.Equal(t, domain.ReviewStatusOutdated, statuses[fmt.Sprintf("key.000:%d", languages[1].ID)])
+	assert.NotEqual(t, domain.ReviewStatusOutdated, statuses[fmt.Sprintf("key.001:%d", languages[1].ID)])
+
+	// 已过期的译文不重复计数
+	marked, err = repo.MarkOutdated(ctx, project.ID, languages[0].ID, []string{"key.000"})
+	require.NoError(t, err)
+	assert.Zero(t, marked)
+}
+
+func TestTranslationRepository_UpdateVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	seedTranslations(t, project.ID, languages, 1)
+
+	// 两个译者读取同一版本
+	first, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.000", languages[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first.Version)
+	second, err := repo.GetByProjectKeyLanguage(ctx, project.ID, "key.000", languages[0].ID)
+	require.NoError(t, err)
+
+	first.Value = "first"
+	require.NoError(t, repo.Update(ctx, first))
+	assert.Equal(t, uint64(2), first.Version)
+
+	// 基于旧版本的更新被拒绝，不覆盖先提交的译文
+	second.Value = "second"
+	assert.ErrorIs(t, repo.Update(ctx, second), domain.ErrTranslationConflict)
+	stored, err := repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "first", stored.Value)
+	assert.Equal(t, uint64(2), stored.Version)
+
+	// 批量写入只在译文变化时增加版本号
+	require.NoError(t, repo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "key.000", LanguageID: languages[0].ID, Value: "first", Status: "active"},
+	}))
+	stored, err = repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), stored.Version)
+	require.NoError(t, repo.UpsertBatch(ctx, []*domain.Translation{
+		{ProjectID: project.ID, KeyName: "key.000", LanguageID: languages[0].ID, Value: "imported", Status: "active"},
+	}))
+	stored, err = repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), stored.Version)
+}
+
+func TestTranslationRepository_GetByProjectIDSort(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	seedTranslations(t, project.ID, languages, 6)
+
+	// 键名倒序，同一键的翻译按 ID 升序
+	sort := []domain.SortField{{Field: "key_name", Desc: true}}
+	translations, total, err := repo.GetByProjectID(ctx, project.ID, 4, 0, "", sort)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), total)
+	require.Len(t, translations, 4)
+	assert.Equal(t, []string{"key.005", "key.005", "key.004", "key.004"},
+		[]string{translations[0].KeyName, translations[1].KeyName, translations[2].KeyName, translations[3].KeyName})
+	assert.Less(t, translations[0].ID, translations[1].ID)
+}
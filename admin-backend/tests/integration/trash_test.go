@@ -0,0 +1,98 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newTrashService 创建翻译回收站服务，删除的翻译保留 retention
+func newTrashService(retention time.Duration) *service.TranslationTrashService {
+	return service.NewTranslationTrashService(
+		repository.NewTranslationRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		nil,
+		repository.NewTransactor(testDB),
+		retention,
+		0,
+		nil,
+	)
+}
+
+func TestTrash_ListAndRestore(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	translation := &domain.Translation{ProjectID: project.ID, KeyName: "home.title", LanguageID: languages[0].ID, Value: "Hello", Status: "active"}
+	require.NoError(t, repo.Create(ctx, translation))
+	_, err := repo.DeleteByIDs(ctx, []uint64{translation.ID}, 3)
+	require.NoError(t, err)
+
+	trash, err := newTrashService(24*time.Hour).GetTrash(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, trash.Translations, 1)
+	assert.Equal(t, int64(1), trash.Total)
+	assert.Equal(t, translation.ID, trash.Translations[0].ID)
+	assert.Equal(t, languages[0].Code, trash.Translations[0].Language.Code)
+	assert.Equal(t, uint64(3), trash.Translations[0].UpdatedBy)
+	assert.True(t, trash.Translations[0].DeletedAt.Valid)
+
+	restored, err := newTrashService(24*time.Hour).Restore(ctx, translation.ID, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", restored.Value)
+
+	stored, err := repo.GetByID(ctx, translation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", stored.Value)
+	assert.Equal(t, uint64(5), stored.UpdatedBy)
+
+	entries := projectHistory(t, project.ID)
+	last := entries[len(entries)-1]
+	assert.Equal(t, domain.HistoryOperationRestore, last.Operation)
+	assert.Equal(t, "Hello", last.NewValue)
+	assert.Equal(t, uint64(5), last.UserID)
+
+	// 已恢复的翻译不在回收站中，不能再次恢复
+	trash, err = newTrashService(24*time.Hour).GetTrash(ctx, project.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, trash.Translations)
+	_, err = newTrashService(24*time.Hour).Restore(ctx, translation.ID, 5)
+	assert.ErrorIs(t, err, domain.ErrTranslationNotFound)
+}
+
+func TestTrash_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewTranslationRepository(testDB)
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	expired := &domain.Translation{ProjectID: project.ID, KeyName: "old", LanguageID: languages[0].ID, Value: "Old", Status: "active"}
+	recent := &domain.Translation{ProjectID: project.ID, KeyName: "recent", LanguageID: languages[0].ID, Value: "Recent", Status: "active"}
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.Translation{expired, recent}))
+	_, err := repo.DeleteByIDs(ctx, []uint64{expired.ID, recent.ID}, 1)
+	require.NoError(t, err)
+	require.NoError(t, testDB.Unscoped().Model(&domain.Translation{}).
+		Where("id = ?", expired.ID).
+		Update("deleted_at", time.Now().Add(-48*time.Hour)).Error)
+
+	purged, err := newTrashService(24 * time.Hour).Purge(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, purged, int64(1))
+
+	_, err = repo.GetDeletedByID(ctx, expired.ID)
+	assert.ErrorIs(t, err, domain.ErrTranslationNotFound)
+	_, err = repo.GetDeletedByID(ctx, recent.ID)
+	assert.NoError(t, err)
+
+	// 变更历史保留
+	assert.NotEmpty(t, projectHistory(t, project.ID))
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+// newUsageService 创建用量统计服务
+func newUsageService() *service.UsageService {
+	return service.NewUsageService(
+		testCache,
+		repository.NewUsageStatRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewLanguageRepository(testDB),
+		time.Minute,
+		nil,
+	)
+}
+
+// usageByKey 按键索引汇总结果
+func usageByKey(breakdowns []*domain.UsageBreakdown) map[string]int64 {
+	result := make(map[string]int64, len(breakdowns))
+	for _, breakdown := range breakdowns {
+		result[breakdown.Key] = breakdown.Requests
+	}
+	return result
+}
+
+func TestUsage_Report(t *testing.T) {
+	ctx := context.Background()
+	svc := newUsageService()
+	project := createProject(t)
+	languages := createLanguages(t, 2)
+	pulled, unused := languages[0], languages[1]
+
+	for i := 0; i < 3; i++ {
+		svc.Record(ctx, domain.UsageRecord{ProjectID: project.ID, Channel: domain.UsageChannelCLI, Locale: pulled.Code, Consumer: "api_key:abc/web-app"})
+	}
+	svc.Record(ctx, domain.UsageRecord{ProjectID: project.ID, Channel: domain.UsageChannelExport, Consumer: "user:1"})
+	// 分隔符会被移除，不影响字段解析
+	svc.Record(ctx, domain.UsageRecord{ProjectID: project.ID, Channel: domain.UsageChannelCLI, Locale: "x|y", Consumer: "a|b"})
+
+	report, err := svc.GetReport(ctx, project.ID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Days)
+	assert.Equal(t, int64(5), report.Requests)
+	assert.Equal(t, map[string]int64{pulled.Code: 3, "": 1, "xy": 1}, usageByKey(report.Locales))
+	assert.Equal(t, map[string]int64{"api_key:abc/web-app": 3, "user:1": 1, "ab": 1}, usageByKey(report.Consumers))
+	assert.Equal(t, map[string]int64{domain.UsageChannelCLI: 4, domain.UsageChannelExport: 1}, usageByKey(report.Channels))
+	require.Len(t, report.Daily, 7)
+	assert.Equal(t, int64(5), report.Daily[6].Requests)
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), report.Daily[6].Date)
+	assert.Contains(t, report.UnusedLocales, unused.Code)
+	assert.NotContains(t, report.UnusedLocales, pulled.Code)
+
+	// 已写入的计数不会重复累加
+	report, err = svc.GetReport(ctx, project.ID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), report.Requests)
+
+	_, err = svc.GetReport(ctx, project.ID, 366)
+	assert.Equal(t, domain.ErrInvalidUsageDays, err)
+	_, err = svc.GetReport(ctx, 0, 7)
+	assert.Equal(t, domain.ErrProjectNotFound, err)
+}
+
+func TestUsage_ConcurrentFlush(t *testing.T) {
+	ctx := context.Background()
+	project := createProject(t)
+	recorder := newUsageService()
+	for i := 0; i < 50; i++ {
+		recorder.Record(ctx, domain.UsageRecord{ProjectID: project.ID, Channel: domain.UsageChannelCLI, Consumer: "token:1"})
+	}
+
+	// 多个实例同时写入，每个计数只写入一次
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, newUsageService().Flush(ctx))
+		}()
+	}
+	wg.Wait()
+
+	report, err := recorder.GetReport(ctx, project.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), report.Requests)
+}
@@ -0,0 +1,212 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func newOffboardingUserService() *service.UserService {
+	return service.NewUserService(
+		repository.NewUserRepository(testDB),
+		nil,
+		nil,
+		repository.NewTransactor(testDB),
+		repository.NewProjectMemberRepository(testDB),
+		repository.NewUserOffboardingRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		nil,
+	)
+}
+
+func createMemberUser(t *testing.T, prefix string) *domain.User {
+	t.Helper()
+	user := &domain.User{Username: uniqueName(prefix), Email: uniqueName(prefix) + "@example.com", Password: "x", Role: "member", Status: "active"}
+	require.NoError(t, testDB.Create(user).Error)
+	return user
+}
+
+func TestUserOffboarding_ReassignsReferencesAndOwnership(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 1)
+	svc := newOffboardingUserService()
+	project := createProject(t)
+	languages := createLanguages(t, 1)
+	leaving := createMemberUser(t, "it-leaving")
+	successor := createMemberUser(t, "it-successor")
+
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: project.ID, UserID: leaving.ID, Role: "owner"}).Error)
+	require.NoError(t, testDB.Create(&domain.PersonalAccessToken{UserID: leaving.ID, Name: "ci", TokenHash: uniqueName("hash"), TokenPrefix: "yft_x", ExpiresAt: time.Now().Add(time.Hour)}).Error)
+	translation := &domain.Translation{ProjectID: project.ID, KeyName: "offboard.key", LanguageID: languages[0].ID, Value: "v", CreatedBy: leaving.ID, UpdatedBy: leaving.ID}
+	require.NoError(t, testDB.Create(translation).Error)
+
+	result, err := svc.OffboardUser(ctx, leaving.ID, domain.OffboardUserParams{ReassignTo: successor.ID, Reason: "left the team"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{project.ID}, result.OwnershipTransferred)
+	assert.Equal(t, int64(1), result.MembershipsRemoved)
+	assert.Equal(t, int64(1), result.AccessTokensRevoked)
+	assert.Equal(t, int64(1), result.References["translations.created_by"])
+
+	var reloaded domain.Translation
+	require.NoError(t, testDB.First(&reloaded, translation.ID).Error)
+	assert.Equal(t, successor.ID, reloaded.CreatedBy)
+	assert.Equal(t, successor.ID, reloaded.UpdatedBy)
+
+	member, err := repository.NewProjectMemberRepository(testDB).GetByProjectAndUser(ctx, project.ID, successor.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "owner", member.Role)
+
+	_, err = repository.NewUserRepository(testDB).GetByID(ctx, leaving.ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	var audit domain.AuditLog
+	require.NoError(t, testDB.Where("project_id = 0 AND action = ?", domain.AuditActionUserOffboard).Order("id DESC").First(&audit).Error)
+	assert.Equal(t, uint64(1), audit.UserID)
+	assert.Contains(t, string(audit.Details), leaving.Username)
+}
+
+func TestUserOffboarding_AnonymizesActivity(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 1)
+	svc := newOffboardingUserService()
+	project := createProject(t)
+	leaving := createMemberUser(t, "it-gdpr")
+
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: project.ID, UserID: leaving.ID, Role: "owner"}).Error)
+	require.NoError(t, testDB.Create(&domain.AuditLog{ProjectID: project.ID, UserID: leaving.ID, Action: domain.AuditActionBulkDelete, Details: []byte("{}")}).Error)
+
+	_, err := svc.OffboardUser(ctx, leaving.ID, domain.OffboardUserParams{ReassignTo: leaving.ID})
+	assert.ErrorIs(t, err, domain.ErrInvalidReassignTarget)
+
+	result, err := svc.OffboardUser(ctx, leaving.ID, domain.OffboardUserParams{Anonymize: true})
+	require.NoError(t, err)
+	assert.Contains(t, result.ProjectsWithoutOwner, project.ID)
+	assert.Equal(t, int64(1), result.ActivityAnonymized)
+
+	var count int64
+	require.NoError(t, testDB.Model(&domain.AuditLog{}).Where("user_id = ?", leaving.ID).Count(&count).Error)
+	assert.Zero(t, count)
+
+	var audit domain.AuditLog
+	require.NoError(t, testDB.Where("project_id = 0 AND action = ?", domain.AuditActionUserOffboard).Order("id DESC").First(&audit).Error)
+	assert.NotContains(t, string(audit.Details), leaving.Username)
+}
+
+func TestUserDeactivation_DisablesLogin(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 1)
+	auth := service.NewAuthService(config.JWTConfig{
+		Secret:                 "deactivate-test-secret",
+		ExpirationHours:        1,
+		RefreshSecret:          "deactivate-test-refresh-secret",
+		RefreshExpirationHours: 24,
+	})
+	sessions := service.NewSessionService(testCache, time.Hour, 24*time.Hour, nil)
+	svc := service.NewUserService(
+		repository.NewUserRepository(testDB),
+		auth,
+		nil,
+		repository.NewTransactor(testDB),
+		repository.NewProjectMemberRepository(testDB),
+		repository.NewUserOffboardingRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		sessions,
+	)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &domain.User{Username: uniqueName("it-deactivate"), Email: uniqueName("it-deactivate") + "@example.com", Password: string(hashed), Role: "member", Status: "active"}
+	require.NoError(t, testDB.Create(user).Error)
+	login, err := svc.Login(ctx, domain.LoginParams{Username: user.Username, Password: "secret123"})
+	require.NoError(t, err)
+
+	deactivated, err := svc.DeactivateUser(ctx, user.ID, domain.DeactivateUserParams{Reason: "contract ended"})
+	require.NoError(t, err)
+	assert.Equal(t, "disabled", deactivated.Status)
+	assert.Empty(t, deactivated.Password)
+
+	// 不能再登录或刷新令牌，登录会话已撤销
+	_, err = svc.Login(ctx, domain.LoginParams{Username: user.Username, Password: "secret123"})
+	assert.ErrorIs(t, err, domain.ErrUserDisabled)
+	_, err = svc.RefreshToken(ctx, login.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrUserDisabled)
+	list, err := sessions.List(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	var audit domain.AuditLog
+	require.NoError(t, testDB.Where("project_id = 0 AND action = ?", domain.AuditActionUserDeactivate).Order("id DESC").First(&audit).Error)
+	assert.Equal(t, uint64(1), audit.UserID)
+	assert.Contains(t, string(audit.Details), "contract ended")
+
+	// 重新启用后可以登录
+	_, err = svc.UpdateUser(ctx, user.ID, domain.UpdateUserParams{Status: "active"})
+	require.NoError(t, err)
+	_, err = svc.Login(ctx, domain.LoginParams{Username: user.Username, Password: "secret123"})
+	require.NoError(t, err)
+
+	admin := &domain.User{Username: uniqueName("it-admin"), Email: uniqueName("it-admin") + "@example.com", Password: "x", Role: "admin", Status: "active"}
+	require.NoError(t, testDB.Create(admin).Error)
+	_, err = svc.DeactivateUser(ctx, admin.ID, domain.DeactivateUserParams{})
+	assert.ErrorIs(t, err, domain.ErrCannotDeactivateAdmin)
+}
+
+func TestUserOwnershipTransfer_RequiredBeforeDelete(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 1)
+	svc := newOffboardingUserService()
+	memberRepo := repository.NewProjectMemberRepository(testDB)
+	soleOwned := createProject(t)
+	shared := createProject(t)
+	leaving := createMemberUser(t, "it-owner")
+	successor := createMemberUser(t, "it-new-owner")
+	other := createMemberUser(t, "it-co-owner")
+
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: soleOwned.ID, UserID: leaving.ID, Role: "owner"}).Error)
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: soleOwned.ID, UserID: successor.ID, Role: "viewer"}).Error)
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: shared.ID, UserID: leaving.ID, Role: "owner"}).Error)
+	require.NoError(t, testDB.Create(&domain.ProjectMember{ProjectID: shared.ID, UserID: other.ID, Role: "owner"}).Error)
+	invitation := &domain.Invitation{Code: uniqueName("it-code"), InviterID: leaving.ID, Status: domain.InvitationStatusActive, ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, testDB.Create(invitation).Error)
+
+	// 唯一 owner 的项目和有效的邀请码需要先转移
+	err := svc.DeleteUser(ctx, leaving.ID)
+	assert.ErrorContains(t, err, domain.ErrUserOwnsResources.Code)
+	_, err = repository.NewUserRepository(testDB).GetByID(ctx, leaving.ID)
+	require.NoError(t, err)
+
+	_, err = svc.TransferOwnership(ctx, leaving.ID, domain.TransferOwnershipParams{ToUserID: leaving.ID})
+	assert.ErrorIs(t, err, domain.ErrInvalidReassignTarget)
+
+	result, err := svc.TransferOwnership(ctx, leaving.ID, domain.TransferOwnershipParams{ToUserID: successor.ID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{soleOwned.ID, shared.ID}, result.Projects)
+	assert.Equal(t, int64(1), result.InvitationsReassigned)
+
+	for _, projectID := range []uint64{soleOwned.ID, shared.ID} {
+		member, err := memberRepo.GetByProjectAndUser(ctx, projectID, successor.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "owner", member.Role)
+		member, err = memberRepo.GetByProjectAndUser(ctx, projectID, leaving.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "editor", member.Role)
+	}
+	var reloaded domain.Invitation
+	require.NoError(t, testDB.First(&reloaded, invitation.ID).Error)
+	assert.Equal(t, successor.ID, reloaded.InviterID)
+
+	var audit domain.AuditLog
+	require.NoError(t, testDB.Where("project_id = 0 AND action = ?", domain.AuditActionUserTransfer).Order("id DESC").First(&audit).Error)
+	assert.Equal(t, uint64(1), audit.UserID)
+
+	require.NoError(t, svc.DeleteUser(ctx, leaving.ID))
+	_, err = repository.NewUserRepository(testDB).GetByID(ctx, leaving.ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
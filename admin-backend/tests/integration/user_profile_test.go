@@ -0,0 +1,50 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+)
+
+func TestUserRepository_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewUserRepository(testDB)
+	user := &domain.User{Username: uniqueName("it-profile"), Email: uniqueName("it-profile") + "@example.com", Password: "x", Role: "member", Status: "active"}
+	require.NoError(t, repo.Create(ctx, user))
+
+	// 读取后管理员修改了角色，个人资料更新不覆盖该修改
+	stale, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	require.NoError(t, testDB.Model(&domain.User{}).Where("id = ?", user.ID).Update("role", "viewer").Error)
+
+	stale.DisplayName = "Profile User"
+	stale.AvatarKey = "avatars/1/a.png"
+	stale.Language = "zh-CN"
+	stale.Timezone = "Asia/Shanghai"
+	require.NoError(t, repo.UpdateProfile(ctx, stale))
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", stored.Role)
+	assert.Equal(t, "Profile User", stored.DisplayName)
+	assert.Equal(t, "avatars/1/a.png", stored.AvatarKey)
+	assert.Equal(t, "zh-CN", stored.Language)
+	assert.Equal(t, "Asia/Shanghai", stored.Timezone)
+
+	// 空值清除
+	stale.DisplayName = ""
+	stale.AvatarKey = ""
+	require.NoError(t, repo.UpdateProfile(ctx, stale))
+	stored, err = repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.DisplayName)
+	assert.Empty(t, stored.AvatarKey)
+	assert.Equal(t, "zh-CN", stored.Language)
+}
@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+)
+
+func TestWebhook_ReleasePublishedIsDeliveredOnce(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events = append(events, r.Header.Get(service.WebhookEventHeader))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	project := createProject(t)
+	webhookRepo := repository.NewWebhookRepository(testDB)
+	webhook, err := service.NewWebhookService(webhookRepo, repository.NewProjectRepository(testDB)).
+		Create(ctx, project.ID, domain.WebhookParams{Name: "CI", URL: server.URL, Events: []string{domain.WebhookEventReleasePublished}}, 1)
+	require.NoError(t, err)
+
+	dispatcher := service.NewWebhookDispatcher(webhookRepo, repository.NewProjectRepository(testDB), service.WebhookDeliveryOptions{
+		Timeout:              5 * time.Second,
+		AllowPrivateNetworks: true,
+	}, nil)
+	bus := service.NewInMemoryEventBus(nil)
+	bus.Subscribe(domain.EventReleasePublished, "webhooks", dispatcher.HandleEvent)
+	// 同一事件投递两次（至少一次投递）
+	bus.Subscribe(domain.EventReleasePublished, "webhooks-again", dispatcher.HandleEvent)
+
+	releaseService := service.NewReleaseService(
+		repository.NewReleaseRepository(testDB),
+		repository.NewProjectRepository(testDB),
+		repository.NewAuditLogRepository(testDB),
+		newReleaseGateService(),
+		bus,
+		repository.NewTransactor(testDB),
+	)
+	_, _, err = releaseService.Create(ctx, project.ID, domain.ReleaseParams{Version: "v1"}, 1)
+	require.NoError(t, err)
+
+	deliveries, total, err := webhookRepo.GetDeliveries(ctx, webhook.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, domain.WebhookEventReleasePublished, deliveries[0].Event)
+
+	delivered, err := dispatcher.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{domain.WebhookEventReleasePublished}, events)
+
+	deliveries, _, err = webhookRepo.GetDeliveries(ctx, webhook.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].ResponseStatus)
+
+	// 删除 Webhook 时一并删除推送记录
+	require.NoError(t, webhookRepo.Delete(ctx, webhook.ID))
+	var remaining int64
+	require.NoError(t, testDB.Model(&domain.WebhookDelivery{}).Where("webhook_id = ?", webhook.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
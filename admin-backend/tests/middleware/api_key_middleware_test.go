@@ -0,0 +1,103 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+)
+
+// stubAPIKeyService 只接受固定的 API Key
+type stubAPIKeyService struct {
+	domain.APIKeyService
+	keys map[string]*domain.APIKey
+}
+
+func (s stubAPIKeyService) Authenticate(ctx context.Context, key, clientIP string) (*domain.APIKey, error) {
+	if apiKey, ok := s.keys[key]; ok {
+		return apiKey, nil
+	}
+	return nil, domain.ErrInvalidToken
+}
+
+func newAPIKeyEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	t.Setenv("CLI_API_KEY", "legacy-cli-key-0123456789")
+	gin.SetMode(gin.TestMode)
+
+	factory := middleware.NewMiddlewareFactory(nil, nil, nil, nil, stubAPIKeyService{keys: map[string]*domain.APIKey{
+		"yfk_read": {ID: 1, Scope: domain.APIKeyScopeRead, ProjectIDs: "7"},
+		"yfk_all":  {ID: 2, Scope: domain.APIKeyScopeWrite},
+	}}, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	engine := gin.New()
+	engine.Use(factory.APIKeyAuthMiddleware())
+	engine.GET("/translations", factory.RequireAPIKeyScope(domain.APIKeyScopeRead, "project_id"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.POST("/keys", func(c *gin.Context) {
+		if err := middleware.AuthorizeAPIKey(c, 7, domain.APIKeyScopeWrite); err != nil {
+			c.Status(http.StatusForbidden)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+func TestAPIKeyAuth_Scopes(t *testing.T) {
+	engine := newAPIKeyEngine(t)
+
+	tests := []struct {
+		name   string
+		key    string
+		method string
+		path   string
+		want   int
+	}{
+		{"缺少 API Key", "", http.MethodGet, "/translations?project_id=7", http.StatusUnauthorized},
+		{"无效的 API Key", "yfk_unknown", http.MethodGet, "/translations?project_id=7", http.StatusUnauthorized},
+		{"CLI_API_KEY 不受限制", "legacy-cli-key-0123456789", http.MethodPost, "/keys", http.StatusOK},
+		{"只读 API Key 拉取允许的项目", "yfk_read", http.MethodGet, "/translations?project_id=7", http.StatusOK},
+		{"只读 API Key 拉取其他项目", "yfk_read", http.MethodGet, "/translations?project_id=8", http.StatusForbidden},
+		{"项目标识由处理器检查", "yfk_read", http.MethodGet, "/translations?project_id=web", http.StatusOK},
+		{"只读 API Key 不能推送", "yfk_read", http.MethodPost, "/keys", http.StatusForbidden},
+		{"读写 API Key 可以推送", "yfk_all", http.MethodPost, "/keys", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestAPIKeyAuth_ScopeDeniedResponse(t *testing.T) {
+	engine := newAPIKeyEngine(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/translations?project_id=8", nil)
+	req.Header.Set("X-API-Key", "yfk_read")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	var body response.APIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.NotNil(t, body.Error)
+	assert.Equal(t, "FORBIDDEN", body.Error.Code)
+	assert.Equal(t, domain.ErrAPIKeyScopeDenied.Message, body.Error.Message)
+}
@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/api/middleware"
+)
+
+func newGzipRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/data", middleware.GzipMiddleware(), func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.String(http.StatusOK, "hello world")
+	})
+	router.GET("/not-modified", middleware.GzipMiddleware(), func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusNotModified)
+	})
+	return router
+}
+
+func TestGzipMiddleware_CompressesWhenAccepted(t *testing.T) {
+	router := newGzipRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/data", nil)
+	req.Header.Set("Accept-Encoding", "br, gzip")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, `W/"abc"`, w.Header().Get("ETag"))
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(body))
+}
+
+func TestGzipMiddleware_PassThrough(t *testing.T) {
+	router := newGzipRouter()
+
+	// 未声明支持 gzip
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/data", nil))
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "hello world", w.Body.String())
+
+	// 明确拒绝 gzip
+	req := httptest.NewRequest(http.MethodGet, "/data", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	// 无响应体的 304 不压缩
+	req = httptest.NewRequest(http.MethodGet, "/not-modified", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Zero(t, w.Body.Len())
+}
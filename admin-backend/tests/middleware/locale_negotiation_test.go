@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
+)
+
+func TestNegotiateLocale(t *testing.T) {
+	available := []string{"en", "de-DE", "fr", "zh-CN", "zh-TW", "pt_BR"}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+		matched        bool
+	}{
+		{"完全匹配", "fr", "fr", true},
+		{"不区分大小写", "ZH-cn", "zh-CN", true},
+		{"按 q 值排序", "fr;q=0.5, de-DE;q=0.9", "de-DE", true},
+		{"跳过 q=0", "fr;q=0, en;q=0.1", "en", true},
+		{"去掉子标签回退", "en-GB", "en", true},
+		{"脚本子标签回退", "zh-Hant-HK", "zh-TW", true},
+		{"简体中文", "zh-Hans", "zh-CN", true},
+		{"主语言相同的其他地区", "de-CH", "de-DE", true},
+		{"下划线写法的语言代码", "pt-BR", "pt_BR", true},
+		{"依次尝试", "ja, fr;q=0.8", "fr", true},
+		{"通配符匹配默认语言", "ja, *;q=0.5", "en", true},
+		{"没有匹配时使用默认语言", "ja, ko", "en", false},
+		{"空请求头", "", "en", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, matched := middleware.NegotiateLocale(tt.acceptLanguage, available, "en")
+			assert.Equal(t, tt.want, locale)
+			assert.Equal(t, tt.matched, matched)
+		})
+	}
+}
+
+// stubLanguageService 返回固定的语言列表
+type stubLanguageService struct {
+	domain.LanguageService
+}
+
+func (stubLanguageService) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	return []*domain.Language{
+		{Code: "en", IsDefault: true, Status: "active"},
+		{Code: "de", Status: "active"},
+		{Code: "fr", Status: "inactive"},
+	}, nil
+}
+
+func TestLocaleNegotiationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/translations", middleware.LocaleNegotiation(stubLanguageService{}, "locale"), func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.NegotiatedLocale(c, "locale"))
+	})
+
+	serve := func(target, acceptLanguage string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("/translations?locale=auto", "de-AT, en;q=0.8")
+	assert.Equal(t, "de", w.Body.String())
+	assert.Equal(t, "de", w.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get(middleware.LocaleFallbackHeader))
+
+	// 未启用的语言不参与协商
+	w = serve("/translations?locale=auto", "fr")
+	assert.Equal(t, "en", w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(middleware.LocaleFallbackHeader))
+
+	// 指定语言时不协商
+	w = serve("/translations?locale=fr", "de")
+	assert.Equal(t, "fr", w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Language"))
+}
@@ -0,0 +1,223 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
+)
+
+// stubOrganizationProjects 按标识返回固定项目的项目服务
+type stubOrganizationProjects struct {
+	domain.ProjectService
+	projects map[string]*domain.Project
+}
+
+func (s stubOrganizationProjects) GetByIdentifier(ctx context.Context, identifier string) (*domain.Project, error) {
+	if project, ok := s.projects[identifier]; ok {
+		return project, nil
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+// stubOrganizationRoles 按固定角色检查组织权限的组织服务
+type stubOrganizationRoles struct {
+	domain.OrganizationService
+	roles map[uint64]string // 用户在组织 1 中的角色
+}
+
+func (s stubOrganizationRoles) CheckRole(ctx context.Context, id, userID uint64, requiredRole string) (bool, error) {
+	if id != 1 {
+		return false, domain.ErrOrganizationNotFound
+	}
+	return domain.OrganizationRoleLevel(s.roles[userID]) >= domain.OrganizationRoleLevel(requiredRole), nil
+}
+
+// stubOrganizationProjectMembers 按固定成员关系检查项目权限，projects 为用户所在的项目
+type stubOrganizationProjectMembers struct {
+	domain.ProjectMemberService
+	projects map[uint64][]uint64
+}
+
+func (s stubOrganizationProjectMembers) CheckPermission(ctx context.Context, userID, projectID uint64, requiredRole string) (bool, error) {
+	for _, id := range s.projects[userID] {
+		if id == projectID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newOrganizationEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	t.Setenv("CLI_API_KEY", "legacy-cli-key-0123456789")
+	gin.SetMode(gin.TestMode)
+
+	projects := stubOrganizationProjects{projects: map[string]*domain.Project{
+		"7":   {ID: 7, Slug: "web", OrganizationID: 1},
+		"web": {ID: 7, Slug: "web", OrganizationID: 1},
+		"8":   {ID: 8, Slug: "shared"},
+		"9":   {ID: 9, Slug: "mobile", OrganizationID: 1},
+	}}
+	members := stubOrganizationProjectMembers{projects: map[uint64][]uint64{3: {9}}}
+	organizations := stubOrganizationRoles{roles: map[uint64]string{
+		1: domain.OrganizationRoleOwner,
+		2: domain.OrganizationRoleMember,
+	}}
+	apiKeys := stubAPIKeyService{keys: map[string]*domain.APIKey{
+		"yfk_org":    {ID: 1, Scope: domain.APIKeyScopeWrite, OrganizationID: 1},
+		"yfk_shared": {ID: 2, Scope: domain.APIKeyScopeWrite},
+	}}
+	factory := middleware.NewMiddlewareFactory(nil, nil, members, nil, apiKeys, nil, nil, nil, nil, nil, projects, organizations, nil)
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if userID, err := strconv.ParseUint(c.GetHeader("X-User"), 10, 64); err == nil {
+			c.Set("userID", userID)
+			c.Set("userRole", c.GetHeader("X-Role"))
+		}
+		c.Next()
+	})
+	engine.GET("/languages", factory.OrganizationScope(), func(c *gin.Context) {
+		c.String(http.StatusOK, strconv.FormatUint(domain.OrganizationFromContext(c.Request.Context()), 10))
+	})
+	engine.POST("/languages", factory.OrganizationScope(), factory.RequireScopedOrganizationAdmin(), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+	engine.GET("/organizations/:org_id", factory.OrganizationScope(), factory.RequireOrganizationMember(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.PUT("/organizations/:org_id", factory.OrganizationScope(), factory.RequireOrganizationAdmin(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	cli := engine.Group("/cli", factory.APIKeyAuthMiddleware(), factory.OrganizationScope())
+	cli.GET("/translations", factory.RequireAPIKeyScope(domain.APIKeyScopeRead, "project_id"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+func TestOrganizationRole(t *testing.T) {
+	engine := newOrganizationEngine(t)
+
+	tests := []struct {
+		name   string
+		user   string
+		method string
+		path   string
+		want   int
+	}{
+		{"未登录", "", http.MethodGet, "/organizations/1", http.StatusUnauthorized},
+		{"无效的组织ID", "1", http.MethodGet, "/organizations/abc", http.StatusBadRequest},
+		{"组织不存在", "1", http.MethodGet, "/organizations/2", http.StatusNotFound},
+		{"成员可以查看", "2", http.MethodGet, "/organizations/1", http.StatusOK},
+		{"成员不能管理", "2", http.MethodPut, "/organizations/1", http.StatusForbidden},
+		{"owner 可以管理", "1", http.MethodPut, "/organizations/1", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.user != "" {
+				req.Header.Set("X-User", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestOrganizationAPIKey_LimitedToOrganizationProjects(t *testing.T) {
+	engine := newOrganizationEngine(t)
+
+	tests := []struct {
+		name string
+		key  string
+		path string
+		want int
+	}{
+		{"组织 API Key 访问组织项目", "yfk_org", "/cli/translations?project_id=7", http.StatusOK},
+		{"组织 API Key 按项目标识访问组织项目", "yfk_org", "/cli/translations?project_id=web", http.StatusOK},
+		{"组织 API Key 不能访问共享项目", "yfk_org", "/cli/translations?project_id=8", http.StatusForbidden},
+		{"共享 API Key 访问组织项目", "yfk_shared", "/cli/translations?project_id=7", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-API-Key", tt.key)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestOrganizationScope_RequiresMembership(t *testing.T) {
+	engine := newOrganizationEngine(t)
+
+	tests := []struct {
+		name  string
+		user  string
+		path  string
+		want  int
+		scope string
+	}{
+		{"组织成员按项目限定组织", "2", "/languages?project_id=web", http.StatusOK, "1"},
+		{"项目成员按项目限定组织", "3", "/languages?project_id=9", http.StatusOK, "1"},
+		{"非成员不能按组织项目限定组织", "4", "/languages?project_id=7", http.StatusForbidden, ""},
+		{"非成员不能按项目标识限定组织", "4", "/languages?project_id=web", http.StatusForbidden, ""},
+		{"项目成员不能访问组织的其他项目", "3", "/languages?project_id=7", http.StatusForbidden, ""},
+		{"共享项目不需要检查", "4", "/languages?project_id=8", http.StatusOK, "0"},
+		{"未指定项目时为共享范围", "4", "/languages", http.StatusOK, "0"},
+		{"非成员不能按组织ID限定组织", "4", "/organizations/1", http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-User", tt.user)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+			if tt.scope != "" {
+				assert.Equal(t, tt.scope, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestScopedOrganizationAdmin_ManagesOrganizationLanguages(t *testing.T) {
+	engine := newOrganizationEngine(t)
+
+	tests := []struct {
+		name string
+		user string
+		role string
+		path string
+		want int
+	}{
+		{"组织 owner 管理组织语言", "1", "member", "/languages?org_id=1", http.StatusCreated},
+		{"组织 owner 按项目管理组织语言", "1", "member", "/languages?project_id=web", http.StatusCreated},
+		{"组织成员不能管理组织语言", "2", "member", "/languages?org_id=1", http.StatusForbidden},
+		{"非成员不能管理组织语言", "4", "member", "/languages?org_id=1", http.StatusForbidden},
+		{"共享语言需要系统管理员", "1", "member", "/languages", http.StatusForbidden},
+		{"系统管理员管理共享语言", "4", "admin", "/languages", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("X-User", tt.user)
+			req.Header.Set("X-Role", tt.role)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code, rec.Body.String())
+		})
+	}
+}
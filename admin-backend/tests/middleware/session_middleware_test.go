@@ -0,0 +1,93 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/api/response"
+	"yflow/internal/domain"
+)
+
+// stubSessionAuth 令牌本身即会话ID
+type stubSessionAuth struct {
+	domain.AuthService
+}
+
+func (stubSessionAuth) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
+	return &domain.User{ID: 1, Username: "alice"}, nil
+}
+
+func (stubSessionAuth) SessionID(ctx context.Context, token string) (string, error) {
+	return token, nil
+}
+
+// stubSessionUsers 用户始终存在且有效
+type stubSessionUsers struct {
+	domain.UserService
+}
+
+func (stubSessionUsers) GetUserInfo(ctx context.Context, userID uint64) (*domain.User, error) {
+	return &domain.User{ID: userID, Username: "alice", Role: "member", Status: "active"}, nil
+}
+
+// stubSessions revoked 为已撤销的会话，broken 模拟 Redis 不可用
+type stubSessions struct {
+	domain.SessionService
+	validated []string
+}
+
+func (s *stubSessions) Validate(ctx context.Context, userID uint64, sessionID string) error {
+	s.validated = append(s.validated, domain.ClientInfoFromContext(ctx).IP)
+	switch sessionID {
+	case "revoked":
+		return domain.ErrSessionRevoked
+	case "broken":
+		return errors.New("redis: connection refused")
+	}
+	return nil
+}
+
+func TestJWTAuthMiddleware_RejectsRevokedSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sessions := &stubSessions{}
+	factory := middleware.NewMiddlewareFactory(stubSessionAuth{}, stubSessionUsers{}, nil, nil, nil, sessions, nil, nil, nil, nil, nil, nil, nil)
+
+	engine := gin.New()
+	engine.GET("/user/info", factory.JWTAuthMiddleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("sessionID"))
+	})
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/user/info", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request("active")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "active", rec.Body.String())
+	assert.Equal(t, []string{"10.0.0.1"}, sessions.validated, "记录会话最近访问的来源 IP")
+
+	rec = request("revoked")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	var body response.APIResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.NotNil(t, body.Error)
+	assert.Equal(t, "INVALID_TOKEN", body.Error.Code)
+	assert.Equal(t, domain.ErrSessionRevoked.Message, body.Error.Message)
+
+	// 会话存储不可用时不能当作令牌无效处理，避免前端清除登录状态
+	rec = request("broken")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,53 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
+)
+
+func TestParseSortFields(t *testing.T) {
+	allowed := []string{"id", "updated_at", "name"}
+
+	fields, err := middleware.ParseSortFields("updated_at:desc,id", allowed)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SortField{{Field: "updated_at", Desc: true}, {Field: "id"}}, fields)
+
+	// 兼容空格分隔的方向
+	fields, err = middleware.ParseSortFields("Name DESC", allowed)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SortField{{Field: "name", Desc: true}}, fields)
+
+	for _, value := range []string{"", "email", "id:down", "id:asc:desc", "id,", "id;drop"} {
+		_, err := middleware.ParseSortFields(value, allowed)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestSQLSecurityMiddleware_SortSyntax(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/items", middleware.SQLSecurityMiddleware(zap.NewNop()), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for query, status := range map[string]int{
+		"sort=updated_at:desc":    http.StatusOK,
+		"sort=key_name,id:desc":   http.StatusOK,
+		"sort=created_at%20asc":   http.StatusOK,
+		"sort=password:asc":       http.StatusBadRequest,
+		"sort=updated_at:sideway": http.StatusBadRequest,
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items?"+query, nil))
+		assert.Equal(t, status, w.Code, query)
+	}
+}
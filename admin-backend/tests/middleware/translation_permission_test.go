@@ -0,0 +1,141 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/api/middleware"
+	"yflow/internal/domain"
+)
+
+// stubTranslations 翻译 1 属于项目 1，翻译 2 属于项目 2，翻译 3 已删除且属于项目 2
+type stubTranslations struct {
+	domain.TranslationRepository
+}
+
+var stubTranslationProjects = map[uint64]uint64{1: 1, 2: 2}
+
+func (stubTranslations) GetByID(ctx context.Context, id uint64) (*domain.Translation, error) {
+	if projectID, ok := stubTranslationProjects[id]; ok {
+		return &domain.Translation{ID: id, ProjectID: projectID}, nil
+	}
+	return nil, domain.ErrTranslationNotFound
+}
+
+func (stubTranslations) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	for _, id := range ids {
+		if projectID, ok := stubTranslationProjects[id]; ok {
+			translations = append(translations, &domain.Translation{ID: id, ProjectID: projectID})
+		}
+	}
+	return translations, nil
+}
+
+func (stubTranslations) GetDeletedByID(ctx context.Context, id uint64) (*domain.Translation, error) {
+	if id == 3 {
+		return &domain.Translation{ID: id, ProjectID: 2}, nil
+	}
+	return nil, domain.ErrTranslationNotFound
+}
+
+// stubHistory 变更历史 ID 与所属项目ID相同，只有 1 和 2 存在
+type stubHistory struct {
+	domain.TranslationHistoryRepository
+}
+
+func (stubHistory) GetByID(ctx context.Context, id uint64) (*domain.TranslationHistory, error) {
+	if id == 1 || id == 2 {
+		return &domain.TranslationHistory{ID: id, ProjectID: id}, nil
+	}
+	return nil, domain.ErrHistoryNotFound
+}
+
+// stubProjectMembers 用户只是项目 1 的编辑者
+type stubProjectMembers struct {
+	domain.ProjectMemberService
+}
+
+func (stubProjectMembers) CheckPermission(ctx context.Context, userID, projectID uint64, requiredRole string) (bool, error) {
+	return projectID == 1, nil
+}
+
+func newTranslationPermissionEngine(role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	factory := middleware.NewMiddlewareFactory(nil, nil, stubProjectMembers{}, nil, nil, nil, nil, nil, nil, stubTranslations{}, nil, nil, stubHistory{})
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("userID", uint64(1))
+		c.Set("userRole", role)
+	})
+	// 处理器返回读到的请求体，确认中间件读取后放回
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	engine.GET("/translations/:id", factory.RequireTranslationViewer(), echo)
+	engine.POST("/translations/:id/restore", factory.RequireDeletedTranslationEditor(), echo)
+	engine.POST("/translations", factory.RequireTranslationBodyEditor(), echo)
+	engine.POST("/translations/batch-delete", factory.RequireTranslationIDsEditor(), echo)
+	engine.GET("/translation-history/:id/diff", factory.RequireTranslationHistoryViewer(), echo)
+	return engine
+}
+
+func TestRequireTranslationPermission_ChecksOwningProject(t *testing.T) {
+	engine := newTranslationPermissionEngine("member")
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"本项目的翻译", http.MethodGet, "/translations/1", "", http.StatusOK},
+		// 翻译ID不能被当作项目ID，翻译 2 属于其他项目
+		{"其他项目的翻译", http.MethodGet, "/translations/2", "", http.StatusForbidden},
+		{"翻译不存在", http.MethodGet, "/translations/9", "", http.StatusNotFound},
+		{"恢复其他项目已删除的翻译", http.MethodPost, "/translations/3/restore", "", http.StatusForbidden},
+		{"在本项目创建翻译", http.MethodPost, "/translations", `{"project_id":1,"key_name":"a"}`, http.StatusOK},
+		{"在其他项目创建翻译", http.MethodPost, "/translations", `{"project_id":2,"key_name":"a"}`, http.StatusForbidden},
+		{"批量创建包含其他项目", http.MethodPost, "/translations", `[{"project_id":1},{"project_id":2}]`, http.StatusForbidden},
+		{"批量删除本项目的翻译", http.MethodPost, "/translations/batch-delete", `[1,9]`, http.StatusOK},
+		{"批量删除包含其他项目的翻译", http.MethodPost, "/translations/batch-delete", `[1,2]`, http.StatusForbidden},
+		{"本项目的变更历史", http.MethodGet, "/translation-history/1/diff", "", http.StatusOK},
+		{"其他项目的变更历史", http.MethodGet, "/translation-history/2/diff", "", http.StatusForbidden},
+		{"变更历史不存在", http.MethodGet, "/translation-history/9/diff", "", http.StatusNotFound},
+		// 请求体格式不正确时交给处理器返回参数错误
+		{"请求体格式不正确", http.MethodPost, "/translations", `not json`, http.StatusOK},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+			assert.Equal(t, tc.status, rec.Code, rec.Body.String())
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.body, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireTranslationPermission_AdminBypassesLookup(t *testing.T) {
+	engine := newTranslationPermissionEngine("admin")
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/translations/2", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 管理员访问不存在的翻译由处理器返回 404
+	rec = httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/translations/9", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,34 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/api/middleware"
+)
+
+func TestUnversionedPath(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/login":                  "/api/login",
+		"/api/v2/webhooks/inbound/3":     "/api/webhooks/inbound/3",
+		"/api/v12/user/info":             "/api/user/info",
+		"/api/login":                     "/api/login",
+		"/api/v1":                        "/api",
+		"/api/validate":                  "/api/validate",
+		"/api/v1x/login":                 "/api/v1x/login",
+		"/delivery/web/en.json":          "/delivery/web/en.json",
+		"/api/projects/v1/translations/": "/api/projects/v1/translations/",
+	}
+	for path, want := range cases {
+		assert.Equal(t, want, middleware.UnversionedPath(path), path)
+	}
+}
+
+func TestShouldLogRequestBody_VersionedPaths(t *testing.T) {
+	// 各 API 版本的登录请求都不记录请求体
+	for _, path := range []string{"/api/login", "/api/v1/login", "/api/v2/refresh", "/api/v1/register"} {
+		assert.False(t, middleware.ShouldLogRequestBody(path), path)
+	}
+	assert.True(t, middleware.ShouldLogRequestBody("/api/v1/projects"))
+}
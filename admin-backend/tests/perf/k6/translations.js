@@ -0,0 +1,118 @@
+// yflow 翻译接口负载测试
+//
+// 用法：
+//   k6 run \
+//     -e BASE_URL=http://localhost:8080/api \
+//     -e USERNAME=admin -e PASSWORD=admin123 \
+//     -e API_KEY=your-cli-api-key \
+//     -e PROJECT_ID=1 -e LOCALES=en,zh-CN \
+//     tests/perf/k6/translations.js
+//
+// 阈值即性能预算，任一阈值不满足时 k6 以非零状态码退出。
+import http from 'k6/http';
+import { check, fail } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080/api';
+const PROJECT_ID = __ENV.PROJECT_ID || '1';
+const API_KEY = __ENV.API_KEY || '';
+const LOCALES = (__ENV.LOCALES || 'en').split(',');
+const PAGE_SIZE = Number(__ENV.PAGE_SIZE || 50);
+const MAX_PAGE = Number(__ENV.MAX_PAGE || 100);
+
+export const options = {
+  scenarios: {
+    // 翻译矩阵分页浏览（管理后台）
+    matrix: {
+      executor: 'constant-vus',
+      exec: 'browseMatrix',
+      vus: Number(__ENV.MATRIX_VUS || 10),
+      duration: __ENV.DURATION || '1m',
+    },
+    // CLI 拉取翻译（带 ETag 条件请求）
+    cli_pull: {
+      executor: 'constant-arrival-rate',
+      exec: 'pullTranslations',
+      rate: Number(__ENV.PULL_RATE || 20),
+      timeUnit: '1s',
+      duration: __ENV.DURATION || '1m',
+      preAllocatedVUs: 20,
+    },
+    // CLI 批量推送（批量 upsert）
+    cli_push: {
+      executor: 'constant-arrival-rate',
+      exec: 'pushTranslations',
+      rate: Number(__ENV.PUSH_RATE || 1),
+      timeUnit: '1s',
+      duration: __ENV.DURATION || '1m',
+      preAllocatedVUs: 5,
+    },
+  },
+  thresholds: {
+    'http_req_failed': ['rate<0.01'],
+    'http_req_duration{scenario:matrix}': ['p(95)<300'],
+    'http_req_duration{scenario:cli_pull}': ['p(95)<500'],
+    'http_req_duration{scenario:cli_push}': ['p(95)<2000'],
+  },
+};
+
+export function setup() {
+  const res = http.post(
+    `${BASE_URL}/login`,
+    JSON.stringify({
+      username: __ENV.USERNAME || 'admin',
+      password: __ENV.PASSWORD || 'admin123',
+    }),
+    { headers: { 'Content-Type': 'application/json' } },
+  );
+  if (res.status !== 200) {
+    fail(`登录失败: ${res.status} ${res.body}`);
+  }
+  return { token: res.json('data.token') };
+}
+
+export function browseMatrix(data) {
+  // 前几页访问最频繁，同时覆盖深分页
+  const page = Math.random() < 0.8 ? 1 + Math.floor(Math.random() * 5) : 1 + Math.floor(Math.random() * MAX_PAGE);
+  const res = http.get(
+    `${BASE_URL}/translations/matrix/by-project/${PROJECT_ID}?page=${page}&page_size=${PAGE_SIZE}`,
+    { headers: { Authorization: `Bearer ${data.token}` }, tags: { name: 'matrix' } },
+  );
+  check(res, { 'matrix 200': (r) => r.status === 200 });
+}
+
+// 每个 VU 记住上次的 ETag，模拟 CLI 重复拉取
+let lastETag = '';
+
+export function pullTranslations() {
+  const headers = { 'X-API-Key': API_KEY };
+  if (lastETag) {
+    headers['If-None-Match'] = lastETag;
+  }
+  const res = http.get(`${BASE_URL}/cli/translations?project_id=${PROJECT_ID}`, {
+    headers,
+    tags: { name: 'cli_translations' },
+  });
+  check(res, { 'pull 200/304': (r) => r.status === 200 || r.status === 304 });
+  if (res.headers.Etag) {
+    lastETag = res.headers.Etag;
+  }
+}
+
+export function pushTranslations() {
+  const translations = {};
+  for (const locale of LOCALES) {
+    translations[locale] = {};
+    for (let i = 0; i < 100; i++) {
+      translations[locale][`perf.k6.key${i}`] = `${locale} value ${i} @ ${Date.now()}`;
+    }
+  }
+  const res = http.post(
+    `${BASE_URL}/cli/keys`,
+    JSON.stringify({ project_id: PROJECT_ID, translations }),
+    {
+      headers: { 'Content-Type': 'application/json', 'X-API-Key': API_KEY },
+      tags: { name: 'cli_keys' },
+    },
+  );
+  check(res, { 'push 200': (r) => r.status === 200 });
+}
@@ -0,0 +1,209 @@
+package perf_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/repository"
+	"yflow/internal/service"
+	"yflow/tests/utils"
+)
+
+// 性能测试需要可用的 MySQL（见 utils.SetupTestDB），无法连接时自动跳过。
+// 数据集规模通过 PERF_PROJECTS、PERF_KEYS、PERF_LANGUAGES 调整，例如：
+//
+//	PERF_KEYS=50000 PERF_LANGUAGES=12 go test ./tests/perf -run '^$' -bench . -benchmem
+
+// perfEnv 性能测试环境
+type perfEnv struct {
+	dataset     *utils.Dataset
+	translation *repository.TranslationRepository
+	service     *service.TranslationService
+}
+
+// setupPerfEnv 创建测试数据库并生成数据集
+func setupPerfEnv(tb testing.TB) *perfEnv {
+	db := utils.SetupTestDB(tb)
+	dataset := utils.SeedDataset(tb, db, utils.DatasetConfigFromEnv())
+
+	translationRepo := repository.NewTranslationRepository(db)
+	return &perfEnv{
+		dataset:     dataset,
+		translation: translationRepo,
+		service: service.NewTranslationService(
+			translationRepo,
+			repository.NewProjectRepository(db),
+			repository.NewLanguageRepository(db),
+			repository.NewNamespaceRepository(db),
+			repository.NewReleaseRepository(db),
+			nil,
+			nil,
+		),
+	}
+}
+
+// matrixCases GetMatrix 的典型访问模式
+func matrixCases(dataset *utils.Dataset) []struct {
+	name    string
+	limit   int
+	offset  int
+	keyword string
+} {
+	deepOffset := dataset.Config.Keys - 50
+	if deepOffset < 0 {
+		deepOffset = 0
+	}
+	return []struct {
+		name    string
+		limit   int
+		offset  int
+		keyword string
+	}{
+		{name: "first_page", limit: 50, offset: 0},
+		{name: "deep_page", limit: 50, offset: deepOffset},
+		{name: "keyword", limit: 50, offset: 0, keyword: "section07"},
+		{name: "all", limit: -1, offset: 0},
+	}
+}
+
+func BenchmarkRepositoryGetMatrix(b *testing.B) {
+	env := setupPerfEnv(b)
+	ctx := context.Background()
+	projectID := env.dataset.ProjectIDs[0]
+
+	for _, tc := range matrixCases(env.dataset) {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := env.translation.GetMatrix(ctx, projectID, tc.limit, tc.offset, tc.keyword); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkServiceGetMatrix(b *testing.B) {
+	env := setupPerfEnv(b)
+	ctx := context.Background()
+	projectID := env.dataset.ProjectIDs[0]
+
+	// 使用子基准测试，保证数据集只生成一次
+	b.Run("first_page", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, _, err := env.service.GetMatrix(ctx, projectID, 50, 0, ""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkServiceUpsertBatch(b *testing.B) {
+	env := setupPerfEnv(b)
+	ctx := context.Background()
+	// 新键序号在多轮基准测试之间递增，避免重复写入同一批键
+	nextKey := env.dataset.Config.Keys
+
+	for _, size := range []int{100, 1000} {
+		// update：覆盖已有翻译；insert：全部为新键
+		b.Run(fmt.Sprintf("update_%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				inputs := upsertInputs(env.dataset, size, 0, fmt.Sprintf("updated %d", i))
+				if err := env.service.UpsertBatch(ctx, inputs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("insert_%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				inputs := upsertInputs(env.dataset, size, nextKey, "inserted")
+				nextKey += size
+				if err := env.service.UpsertBatch(ctx, inputs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// upsertInputs 生成 size 条写入第一个项目的翻译，键从 keyOffset 开始，语言轮流分配
+func upsertInputs(dataset *utils.Dataset, size, keyOffset int, value string) []domain.TranslationInput {
+	inputs := make([]domain.TranslationInput, 0, size)
+	languages := len(dataset.LanguageIDs)
+	for i := 0; i < size; i++ {
+		inputs = append(inputs, domain.TranslationInput{
+			ProjectID:  dataset.ProjectIDs[0],
+			KeyName:    utils.DatasetKeyName(keyOffset + i/languages),
+			LanguageID: dataset.LanguageIDs[i%languages],
+			Value:      value,
+		})
+	}
+	return inputs
+}
+
+// TestPerformanceBudget 检查关键操作的 p95 延迟是否在预算内
+// 仅在设置 PERF_BUDGET=1 时运行，预算（毫秒）可通过环境变量覆盖
+func TestPerformanceBudget(t *testing.T) {
+	if os.Getenv("PERF_BUDGET") == "" {
+		t.Skip("跳过性能预算检查：未设置 PERF_BUDGET")
+	}
+
+	env := setupPerfEnv(t)
+	ctx := context.Background()
+	projectID := env.dataset.ProjectIDs[0]
+
+	for _, tc := range matrixCases(env.dataset) {
+		if tc.limit < 0 {
+			continue
+		}
+		budget := budgetFromEnv("PERF_BUDGET_MATRIX_MS", 100)
+		p95 := measureP95(t, 50, func() error {
+			_, _, err := env.service.GetMatrix(ctx, projectID, tc.limit, tc.offset, tc.keyword)
+			return err
+		})
+		t.Logf("GetMatrix/%s p95=%v budget=%v", tc.name, p95, budget)
+		require.LessOrEqual(t, p95, budget, "GetMatrix/%s 超出性能预算", tc.name)
+	}
+
+	budget := budgetFromEnv("PERF_BUDGET_UPSERT_MS", 300)
+	iteration := 0
+	p95 := measureP95(t, 20, func() error {
+		iteration++
+		return env.service.UpsertBatch(ctx, upsertInputs(env.dataset, 100, 0, fmt.Sprintf("budget %d", iteration)))
+	})
+	t.Logf("UpsertBatch/100 p95=%v budget=%v", p95, budget)
+	require.LessOrEqual(t, p95, budget, "UpsertBatch/100 超出性能预算")
+}
+
+// measureP95 执行 fn n 次并返回 p95 耗时
+func measureP95(t *testing.T, n int, fn func() error) time.Duration {
+	durations := make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		require.NoError(t, fn())
+		durations = append(durations, time.Since(start))
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[(len(durations)*95-1)/100]
+}
+
+// budgetFromEnv 读取以毫秒为单位的预算
+func budgetFromEnv(key string, defaultMs int) time.Duration {
+	ms, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || ms <= 0 {
+		ms = defaultMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeAPIKeyRepository 内存中的 API Key 仓储
+type fakeAPIKeyRepository struct {
+	keys    map[uint64]*domain.APIKey
+	nextID  uint64
+	touches int
+}
+
+func newFakeAPIKeyRepository() *fakeAPIKeyRepository {
+	return &fakeAPIKeyRepository{keys: make(map[uint64]*domain.APIKey)}
+}
+
+func (r *fakeAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	r.nextID++
+	key.ID = r.nextID
+	key.CreatedAt = time.Now()
+	stored := *key
+	r.keys[key.ID] = &stored
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) GetByID(ctx context.Context, id uint64) (*domain.APIKey, error) {
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	copied := *key
+	return &copied, nil
+}
+
+func (r *fakeAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			copied := *key
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (r *fakeAPIKeyRepository) GetAll(ctx context.Context) ([]*domain.APIKey, error) {
+	keys := make([]*domain.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (r *fakeAPIKeyRepository) GetByOrganization(ctx context.Context, organizationID uint64) ([]*domain.APIKey, error) {
+	var keys []*domain.APIKey
+	for _, key := range r.keys {
+		if key.OrganizationID == organizationID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (r *fakeAPIKeyRepository) UpdateLastUsed(ctx context.Context, id uint64, usedAt time.Time, ip string) error {
+	r.touches++
+	r.keys[id].LastUsedAt = &usedAt
+	r.keys[id].LastUsedIP = ip
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) Delete(ctx context.Context, id uint64) error {
+	delete(r.keys, id)
+	return nil
+}
+
+// apiKeyProjects 只实现按ID批量查询的项目仓储，organizations 为项目所属的组织
+type apiKeyProjects struct {
+	domain.ProjectRepository
+	ids           map[uint64]bool
+	organizations map[uint64]uint64
+}
+
+func (r apiKeyProjects) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Project, error) {
+	var projects []*domain.Project
+	for _, id := range ids {
+		if r.ids[id] {
+			projects = append(projects, &domain.Project{ID: id, OrganizationID: r.organizations[id]})
+		}
+	}
+	return projects, nil
+}
+
+func TestAPIKey_CreateAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeAPIKeyRepository()
+	svc := service.NewAPIKeyService(repo, apiKeyProjects{ids: map[uint64]bool{1: true, 2: true}})
+
+	key, plain, err := svc.Create(ctx, domain.APIKeyParams{Name: " web CI ", ProjectIDs: []uint64{2, 1, 2}}, 9)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, domain.APIKeyPrefix))
+	assert.Equal(t, "web CI", key.Name)
+	assert.Equal(t, domain.APIKeyScopeRead, key.Scope, "默认只读")
+	assert.Equal(t, []uint64{1, 2}, key.Projects(), "项目去重并排序")
+	assert.Equal(t, plain[:len(key.KeyPrefix)], key.KeyPrefix)
+	assert.Equal(t, uint64(9), key.CreatedBy)
+
+	authenticated, err := svc.Authenticate(ctx, plain, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, authenticated.ID)
+	assert.Equal(t, "10.0.0.1", repo.keys[key.ID].LastUsedIP)
+
+	// 短时间内同一来源重复使用时不重复写入
+	_, err = svc.Authenticate(ctx, plain, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.touches)
+
+	_, err = svc.Authenticate(ctx, plain+"x", "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	_, err = svc.Authenticate(ctx, "yflow-cli-default-key", "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	require.NoError(t, svc.Revoke(ctx, key.ID))
+	_, err = svc.Authenticate(ctx, plain, "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	assert.ErrorIs(t, svc.Revoke(ctx, key.ID), domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKey_CreateValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewAPIKeyService(newFakeAPIKeyRepository(), apiKeyProjects{ids: map[uint64]bool{1: true}})
+
+	_, _, err := svc.Create(ctx, domain.APIKeyParams{Name: "  "}, 1)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	_, _, err = svc.Create(ctx, domain.APIKeyParams{Name: "ci", Scope: "admin"}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidInput.Code, appErr.Code)
+
+	_, _, err = svc.Create(ctx, domain.APIKeyParams{Name: "ci", ProjectIDs: []uint64{1, 3}}, 1)
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+
+	key, _, err := svc.Create(ctx, domain.APIKeyParams{Name: "ci", Scope: domain.APIKeyScopeWrite}, 1)
+	require.NoError(t, err)
+	assert.Empty(t, key.ProjectIDs, "未指定项目时可访问所有项目")
+}
+
+func TestAPIKey_OrganizationScope(t *testing.T) {
+	repo := newFakeAPIKeyRepository()
+	svc := service.NewAPIKeyService(repo, apiKeyProjects{
+		ids:           map[uint64]bool{1: true, 2: true},
+		organizations: map[uint64]uint64{1: 7},
+	})
+	orgCtx := domain.WithOrganization(context.Background(), 7)
+
+	key, _, err := svc.Create(orgCtx, domain.APIKeyParams{Name: "org ci", ProjectIDs: []uint64{1}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), key.OrganizationID)
+
+	// 组织的 API Key 不能限定到其他组织或不属于组织的项目
+	_, _, err = svc.Create(orgCtx, domain.APIKeyParams{Name: "org ci", ProjectIDs: []uint64{2}}, 1)
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+
+	shared, _, err := svc.Create(context.Background(), domain.APIKeyParams{Name: "shared"}, 1)
+	require.NoError(t, err)
+	assert.Zero(t, shared.OrganizationID)
+
+	keys, err := svc.GetAll(orgCtx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID, keys[0].ID)
+
+	all, err := svc.GetAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, all, 2, "不指定组织时获取所有 API Key")
+
+	assert.ErrorIs(t, svc.Revoke(orgCtx, shared.ID), domain.ErrAPIKeyNotFound, "不能撤销其他组织的 API Key")
+	require.NoError(t, svc.Revoke(orgCtx, key.ID))
+}
+
+func TestAPIKey_Permissions(t *testing.T) {
+	readOnly := &domain.APIKey{Scope: domain.APIKeyScopeRead, ProjectIDs: "3,5"}
+	assert.True(t, readOnly.AllowsScope(domain.APIKeyScopeRead))
+	assert.False(t, readOnly.AllowsScope(domain.APIKeyScopeWrite))
+	assert.True(t, readOnly.AllowsProject(5))
+	assert.False(t, readOnly.AllowsProject(4))
+
+	write := &domain.APIKey{Scope: domain.APIKeyScopeWrite}
+	assert.True(t, write.AllowsScope(domain.APIKeyScopeRead), "write 包含 read")
+	assert.True(t, write.AllowsProject(42))
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestMarshalARB(t *testing.T) {
+	values := map[string]string{
+		"cartCount": "{count, plural, =0{No items} one{{count} item} other{{count} items}}",
+		"greeting":  "Hello {name} <b>&</b>",
+		"plain":     "Plain",
+	}
+	contexts := map[string]string{"greeting": `Shown "on" home`}
+
+	data, err := service.MarshalARB("zh-CN", values, contexts)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "@@locale": "zh_CN",
+  "cartCount": "{count, plural, =0{No items} one{{count} item} other{{count} items}}",
+  "@cartCount": {
+    "placeholders": {
+      "count": {
+        "type": "num"
+      }
+    }
+  },
+  "greeting": "Hello {name} <b>&</b>",
+  "@greeting": {
+    "description": "Shown \"on\" home",
+    "placeholders": {
+      "name": {
+        "type": "String"
+      }
+    }
+  },
+  "plain": "Plain"
+}
+`, string(data))
+
+	locale, parsed, parsedContexts, err := service.ParseARB(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, "zh_CN", locale)
+	assert.Equal(t, values, parsed)
+	assert.Equal(t, contexts, parsedContexts)
+}
+
+func TestARBPlaceholders(t *testing.T) {
+	assert.Nil(t, service.ARBPlaceholders("No placeholders"))
+	assert.Equal(t, map[string]service.ARBPlaceholder{
+		"a": {Type: "String"},
+		"b": {Type: "num"},
+		"c": {Type: "String"},
+		"d": {Type: "DateTime", Format: "jm"},
+		"e": {Type: "DateTime", Format: "yMMMd"},
+		"n": {Type: "num"},
+	}, service.ARBPlaceholders("{a} {b, number} {c, select, male{he} other{they}} {d, time} {e, date, yMMMd} {n, selectordinal, one{#st} other{#th}}"))
+
+	// 不是参数的花括号和多余的 } 按普通文本处理
+	assert.Equal(t, map[string]service.ARBPlaceholder{"x": {Type: "String"}}, service.ARBPlaceholders("} { not a param } {x}"))
+}
+
+func TestParseARB_Errors(t *testing.T) {
+	// language 优先于 @@locale
+	locale, _, _, err := service.ParseARB([]byte(`{"@@locale": "en", "a": "A"}`), "de")
+	require.NoError(t, err)
+	assert.Equal(t, "de", locale)
+
+	_, _, _, err = service.ParseARB([]byte(`{"a": "A"}`), "")
+	assert.ErrorIs(t, err, domain.ErrARBLocaleMissing)
+
+	for _, data := range []string{`[1]`, `{"@@locale": "en", "a": 1}`, `{"@@locale": "en", "@a": "desc"}`} {
+		_, _, _, err = service.ParseARB([]byte(data), "")
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, data)
+		assert.Equal(t, domain.ErrInvalidARB.Code, appErr.Code, data)
+	}
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestLocalAttachmentStorage(t *testing.T) {
+	storage := service.NewLocalAttachmentStorage(t.TempDir())
+	ctx := context.Background()
+
+	require.NoError(t, storage.Put(ctx, "projects/1/a.png", []byte("png"), "image/png"))
+	data, err := storage.Get(ctx, "projects/1/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+
+	require.NoError(t, storage.Delete(ctx, "projects/1/a.png"))
+	_, err = storage.Get(ctx, "projects/1/a.png")
+	assert.ErrorIs(t, err, domain.ErrAttachmentFileMissing)
+	// 删除不存在的文件不报错
+	assert.NoError(t, storage.Delete(ctx, "projects/1/a.png"))
+
+	// 不允许访问存储目录之外的路径
+	assert.Error(t, storage.Put(ctx, "../escape.png", []byte("png"), "image/png"))
+	_, err = storage.Get(ctx, "projects/../../etc/passwd")
+	assert.Error(t, err)
+}
+
+func TestS3AttachmentStorage(t *testing.T) {
+	authorization := regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`)
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Regexp(t, authorization, r.Header.Get("Authorization"))
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Date"))
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		assert.Equal(t, hex.EncodeToString(sum[:]), r.Header.Get("X-Amz-Content-Sha256"))
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	storage := service.NewS3AttachmentStorage(&config.AttachmentConfig{
+		S3Endpoint: server.URL + "/", S3Region: "eu-west-1", S3Bucket: "shots", S3AccessKey: "AKID", S3SecretKey: "secret",
+	})
+	ctx := context.Background()
+
+	require.NoError(t, storage.Put(ctx, "projects/1/a.png", []byte("png"), "image/png"))
+	// 使用路径形式的地址
+	assert.Contains(t, objects, "/shots/projects/1/a.png")
+	data, err := storage.Get(ctx, "projects/1/a.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+
+	require.NoError(t, storage.Delete(ctx, "projects/1/a.png"))
+	_, err = storage.Get(ctx, "projects/1/a.png")
+	assert.ErrorIs(t, err, domain.ErrAttachmentFileMissing)
+}
@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// pageAuditLogs 记录游标分页查询的条件
+type pageAuditLogs struct {
+	domain.AuditLogRepository
+	queries []domain.AuditLogPageQuery
+}
+
+func (r *pageAuditLogs) GetPage(ctx context.Context, query domain.AuditLogPageQuery) (*domain.AuditLogPage, error) {
+	r.queries = append(r.queries, query)
+	return &domain.AuditLogPage{}, nil
+}
+
+// pageProjects 只有 ID 为 1 的项目存在
+type pageProjects struct {
+	domain.ProjectRepository
+}
+
+func (pageProjects) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	if id != 1 {
+		return nil, domain.ErrProjectNotFound
+	}
+	return &domain.Project{ID: id}, nil
+}
+
+func TestAuditLogService_GetPage(t *testing.T) {
+	ctx := context.Background()
+	logs := &pageAuditLogs{}
+	svc := service.NewAuditLogService(logs, pageProjects{})
+
+	// 项目不存在时不查询审计日志
+	_, err := svc.GetPage(ctx, domain.AuditLogPageQuery{ProjectID: 2, Limit: 10})
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+	assert.Empty(t, logs.queries)
+
+	// 系统级审计日志不检查项目，超出范围的 limit 使用默认值
+	_, err = svc.GetPage(ctx, domain.AuditLogPageQuery{Limit: 500})
+	require.NoError(t, err)
+	_, err = svc.GetPage(ctx, domain.AuditLogPageQuery{ProjectID: 1, Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AuditLogPageQuery{
+		{Limit: 10},
+		{ProjectID: 1, Limit: 20},
+	}, logs.queries)
+}
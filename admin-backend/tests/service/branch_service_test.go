@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// memoryBranches 内存中的分支和分支翻译
+type memoryBranches struct {
+	domain.BranchRepository
+	branches     map[uint64]*domain.Branch
+	translations map[uint64][]*domain.BranchTranslation
+}
+
+func (r *memoryBranches) GetByID(ctx context.Context, id uint64) (*domain.Branch, error) {
+	if branch, ok := r.branches[id]; ok {
+		return branch, nil
+	}
+	return nil, domain.ErrBranchNotFound
+}
+
+func (r *memoryBranches) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Branch, error) {
+	for _, branch := range r.branches {
+		if branch.ProjectID == projectID && branch.Name == name {
+			return branch, nil
+		}
+	}
+	return nil, domain.ErrBranchNotFound
+}
+
+func (r *memoryBranches) Update(ctx context.Context, branch *domain.Branch) error {
+	r.branches[branch.ID] = branch
+	return nil
+}
+
+func (r *memoryBranches) GetTranslations(ctx context.Context, branchID uint64) ([]*domain.BranchTranslation, error) {
+	return r.translations[branchID], nil
+}
+
+// UpsertTranslations 已有记录只更新值和删除标记
+func (r *memoryBranches) UpsertTranslations(ctx context.Context, translations []*domain.BranchTranslation) error {
+	for _, translation := range translations {
+		updated := false
+		for _, existing := range r.translations[translation.BranchID] {
+			if existing.KeyName == translation.KeyName && existing.LanguageID == translation.LanguageID {
+				existing.Value, existing.Deleted = translation.Value, translation.Deleted
+				updated = true
+			}
+		}
+		if !updated {
+			r.translations[translation.BranchID] = append(r.translations[translation.BranchID], translation)
+		}
+	}
+	return nil
+}
+
+// branchMainTranslations 主线的翻译
+type branchMainTranslations struct {
+	domain.TranslationRepository
+	values map[string]map[string]string
+}
+
+func (r *branchMainTranslations) GetKeyPage(ctx context.Context, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	return &domain.TranslationKeyPage{Translations: r.values}, nil
+}
+
+// branchMainWriter 记录合并写入主线的翻译
+type branchMainWriter struct {
+	domain.TranslationService
+	main   *branchMainTranslations
+	writes int
+}
+
+func (w *branchMainWriter) UpsertBatch(ctx context.Context, inputs []domain.TranslationInput) error {
+	codes := map[uint64]string{1: "en", 2: "de", 3: "fr"}
+	for _, input := range inputs {
+		w.writes++
+		if w.main.values[input.KeyName] == nil {
+			w.main.values[input.KeyName] = make(map[string]string)
+		}
+		w.main.values[input.KeyName][codes[input.LanguageID]] = input.Value
+	}
+	return nil
+}
+
+// noopAuditLogs 丢弃审计日志
+type noopAuditLogs struct {
+	domain.AuditLogRepository
+}
+
+func (noopAuditLogs) Create(ctx context.Context, log *domain.AuditLog) error {
+	return nil
+}
+
+func TestBranchService_DiffAndMergeConflicts(t *testing.T) {
+	ctx := context.Background()
+	main := &branchMainTranslations{values: map[string]map[string]string{
+		"home.title": {"en": "Home", "de": "Startseite"},
+		"home.cta":   {"en": "Buy"},
+	}}
+	branches := &memoryBranches{
+		branches:     map[uint64]*domain.Branch{1: {ID: 1, ProjectID: 1, Name: "release-2.0", Status: domain.BranchStatusOpen}},
+		translations: map[uint64][]*domain.BranchTranslation{},
+	}
+	writer := &branchMainWriter{main: main}
+	svc := service.NewBranchService(branches, preTranslateProjects{}, preTranslateLanguages{}, main, writer, noopAuditLogs{}, nil)
+
+	_, err := svc.SetTranslations(ctx, 1, 1, []domain.BranchTranslationParams{
+		{KeyName: "home.title", LanguageCode: "de", Value: "Start"},
+		{KeyName: "home.cta", LanguageCode: "de", Value: "Kaufen"},
+	}, 7)
+	require.NoError(t, err)
+
+	// 分支的翻译叠加在主线之上，主线不变
+	values, err := svc.GetValues(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Start", values["home.title"]["de"])
+	assert.Equal(t, "Buy", values["home.cta"]["en"])
+	assert.Equal(t, "Startseite", main.values["home.title"]["de"])
+
+	diff, err := svc.Diff(ctx, 1, 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, "main", diff.Target)
+	assert.Len(t, diff.Changes, 2)
+	assert.Zero(t, diff.Conflicts)
+
+	// 分支修改后主线也修改了同一翻译，合并时为冲突
+	main.values["home.title"]["de"] = "Startseite!"
+	diff, err = svc.Diff(ctx, 1, 1, domain.BranchMain)
+	require.NoError(t, err)
+	assert.Equal(t, 1, diff.Conflicts)
+	assert.Equal(t, domain.BranchChange{
+		Key: "home.title", Language: "de", Type: domain.PromotionChangeModified,
+		OldValue: "Startseite!", NewValue: "Start", Conflict: true, BaseValue: "Startseite",
+	}, diff.Changes[1])
+
+	// 未处理的冲突阻止合并，不写入主线
+	_, err = svc.Merge(ctx, 1, 1, domain.BranchMergeParams{}, 7)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrBranchMergeConflict.Code, appErr.Code)
+	assert.Contains(t, appErr.Details, "home.title (de)")
+	assert.Zero(t, writer.writes)
+
+	result, err := svc.Merge(ctx, 1, 1, domain.BranchMergeParams{Resolutions: []domain.BranchConflictResolution{
+		{Key: "home.title", Language: "de", Resolution: domain.BranchResolutionCustom, Value: "Start!"},
+	}}, 7)
+	require.NoError(t, err)
+	assert.Len(t, result.Applied, 2)
+	assert.Equal(t, "Start!", main.values["home.title"]["de"])
+	assert.Equal(t, "Kaufen", main.values["home.cta"]["de"])
+	assert.Equal(t, domain.BranchStatusMerged, result.Branch.Status)
+
+	// 已合并的分支不能再修改或合并
+	_, err = svc.Merge(ctx, 1, 1, domain.BranchMergeParams{}, 7)
+	assert.ErrorIs(t, err, domain.ErrBranchMerged)
+	_, err = svc.SetTranslations(ctx, 1, 1, []domain.BranchTranslationParams{{KeyName: "x", LanguageCode: "en", Value: "x"}}, 7)
+	assert.ErrorIs(t, err, domain.ErrBranchMerged)
+}
+
+func TestBranchService_CreateRejectsInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewBranchService(&memoryBranches{branches: map[uint64]*domain.Branch{}}, preTranslateProjects{}, nil, nil, nil, nil, nil)
+
+	for _, name := range []string{"", "main", "Main", "../x", "with space"} {
+		_, err := svc.Create(ctx, 1, domain.BranchParams{Name: name}, 1)
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, "name %q: unexpected error: %v", name, err)
+		assert.Equal(t, domain.ErrInvalidBranch.Code, appErr.Code, "name %q", name)
+	}
+}
@@ -93,34 +93,14 @@ func (m *MockCacheService) HDel(ctx context.Context, key string, fields ...strin
 	return args.Error(0)
 }
 
-func (m *MockCacheService) GetTranslationKey(projectID uint64) string {
-	args := m.Called(projectID)
-	return args.String(0)
+func (m *MockCacheService) HIncrBy(ctx context.Context, key, field string, incr int64) (int64, error) {
+	args := m.Called(ctx, key, field, incr)
+	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockCacheService) GetTranslationMatrixKey(projectID uint64, keyword string) string {
-	args := m.Called(projectID, keyword)
-	return args.String(0)
-}
-
-func (m *MockCacheService) GetDashboardStatsKey() string {
-	args := m.Called()
-	return args.String(0)
-}
-
-func (m *MockCacheService) GetLanguagesKey() string {
-	args := m.Called()
-	return args.String(0)
-}
-
-func (m *MockCacheService) GetProjectKey(projectID uint64) string {
-	args := m.Called(projectID)
-	return args.String(0)
-}
-
-func (m *MockCacheService) GetProjectsKey() string {
-	args := m.Called()
-	return args.String(0)
+func (m *MockCacheService) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	args := m.Called(ctx, key, expiration)
+	return args.Error(0)
 }
 
 func (m *MockCacheService) AddRandomExpiration(baseExpiration time.Duration) time.Duration {
@@ -136,10 +116,10 @@ func TestCachedTranslationService_GetMatrix(t *testing.T) {
 	projectID := uint64(1)
 	
 	// 设置模拟期望
-	mockCache.On("GetTranslationMatrixKey", projectID, "").Return("translation_matrix:1")
-	mockCache.On("GetJSONWithEmptyCheck", mock.Anything, "translation_matrix:1:all:10:0", mock.Anything).Return(domain.ErrCacheMiss)
+	cacheKey := domain.CacheKeys.Project(projectID).Matrix("all", "10", "0")
+	mockCache.On("GetJSONWithEmptyCheck", mock.Anything, cacheKey, mock.Anything).Return(domain.ErrCacheMiss)
 	mockCache.On("AddRandomExpiration", domain.DefaultExpiration).Return(domain.DefaultExpiration)
-	mockCache.On("SetJSONWithEmptyCache", mock.Anything, "translation_matrix:1:all:10:0", mock.Anything, domain.DefaultExpiration).Return(nil)
+	mockCache.On("SetJSONWithEmptyCache", mock.Anything, cacheKey, mock.Anything, domain.DefaultExpiration).Return(nil)
 	
 	// 创建带缓存的服务
 	cachedService := service.NewCachedTranslationService(nil, mockCache)
@@ -165,7 +145,6 @@ func TestCachedDashboardService_GetStats(t *testing.T) {
 	}
 
 	// 设置模拟期望
-	mockCache.On("GetDashboardStatsKey").Return("dashboard:stats")
 	mockCache.On("GetJSONWithEmptyCheck", mock.Anything, "dashboard:stats", mock.Anything).Return(domain.ErrCacheMiss)
 	mockCache.On("AddRandomExpiration", domain.LongExpiration).Return(domain.LongExpiration)
 	mockCache.On("SetJSONWithEmptyCache", mock.Anything, "dashboard:stats", stats, domain.LongExpiration).Return(nil)
@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// activityHistory 返回固定计数并记录查询条件的变更历史仓储
+type activityHistory struct {
+	domain.TranslationHistoryRepository
+	counts     []*domain.DailyActivityCount
+	query      domain.HistoryQuery
+	operations []string
+}
+
+func (r *activityHistory) CountDailyActivity(ctx context.Context, query domain.HistoryQuery, operations []string) ([]*domain.DailyActivityCount, error) {
+	r.query = query
+	r.operations = operations
+	return r.counts, nil
+}
+
+// activityUsers 按ID批量查询的用户仓储
+type activityUsers struct {
+	domain.UserRepository
+}
+
+func (activityUsers) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, id := range ids {
+		if id == 7 {
+			users = append(users, &domain.User{ID: 7, Username: "anna"})
+		}
+	}
+	return users, nil
+}
+
+func TestDashboardService_GetActivity(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now().Format("2006-01-02")
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	history := &activityHistory{counts: []*domain.DailyActivityCount{
+		{Date: yesterday, ProjectID: 1, UserID: 7, Action: domain.HistoryOperationCreate, Count: 5},
+		{Date: yesterday, ProjectID: 1, UserID: 7, Action: domain.HistoryOperationUpdate, Count: 2},
+		{Date: today, ProjectID: 2, UserID: 0, Action: domain.HistoryOperationMachineTranslate, Count: 10},
+		{Date: today, ProjectID: 1, UserID: 7, Action: domain.HistoryOperationRollback, Count: 1},
+	}}
+	projects := &sourceProjects{projects: map[uint64]*domain.Project{
+		1: {ID: 1, Name: "web"},
+		2: {ID: 2, Name: "mobile"},
+	}}
+	svc := service.NewDashboardService(projects, nil, nil, history, activityUsers{})
+
+	activity, err := svc.GetActivity(ctx, 30)
+	require.NoError(t, err)
+	assert.Equal(t, 30, activity.Days)
+	assert.Equal(t, today, activity.To)
+	require.Len(t, activity.Daily, 30, "每天一项")
+	assert.Equal(t, activity.From, activity.Daily[0].Date)
+	assert.Equal(t, domain.ActivityTrendCount{Added: 5, Updated: 2}, activity.Daily[28].ActivityTrendCount)
+	assert.Equal(t, domain.ActivityTrendCount{Updated: 11}, activity.Daily[29].ActivityTrendCount)
+	assert.Equal(t, domain.ActivityTrendCount{}, activity.Daily[0].ActivityTrendCount)
+	assert.Equal(t, domain.ActivityTrendCount{Added: 5, Updated: 13}, activity.Total)
+
+	// 按变更总数降序
+	assert.Equal(t, []domain.OperatorActivityTrend{
+		{UserID: 0, ActivityTrendCount: domain.ActivityTrendCount{Updated: 10}},
+		{UserID: 7, Username: "anna", ActivityTrendCount: domain.ActivityTrendCount{Added: 5, Updated: 3}},
+	}, activity.Operators)
+	assert.Equal(t, []domain.ProjectActivityTrend{
+		{ProjectID: 2, ProjectName: "mobile", ActivityTrendCount: domain.ActivityTrendCount{Updated: 10}},
+		{ProjectID: 1, ProjectName: "web", ActivityTrendCount: domain.ActivityTrendCount{Added: 5, Updated: 3}},
+	}, activity.Projects)
+
+	// 查询范围覆盖 30 个完整的自然日，不统计审核等不改变译文的操作
+	assert.Equal(t, 30*24*time.Hour, history.query.To.Sub(history.query.From).Round(time.Hour))
+	assert.NotContains(t, history.operations, domain.HistoryOperationReview)
+
+	_, err = svc.GetActivity(ctx, 7)
+	assert.ErrorIs(t, err, domain.ErrInvalidActivityDays)
+}
@@ -0,0 +1,204 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// deliveryProjects 按标识和旧标识查找项目
+type deliveryProjects struct {
+	domain.ProjectRepository
+	projects map[string]*domain.Project
+	aliases  map[string]*domain.Project
+}
+
+func (r deliveryProjects) GetBySlug(ctx context.Context, slug string) (*domain.Project, error) {
+	if project, ok := r.projects[slug]; ok {
+		return project, nil
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (r deliveryProjects) GetBySlugAlias(ctx context.Context, slug string) (*domain.Project, error) {
+	if project, ok := r.aliases[slug]; ok {
+		return project, nil
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (r *memoryReleases) GetLatest(ctx context.Context, projectID uint64) (*domain.Release, error) {
+	return r.last(projectID, "", domain.RolloutStatusCompleted)
+}
+
+func (r *memoryReleases) GetActiveRollout(ctx context.Context, projectID uint64) (*domain.Release, error) {
+	return r.last(projectID, domain.RolloutStatusActive)
+}
+
+func (r *memoryReleases) UpdateRollout(ctx context.Context, release *domain.Release) error {
+	r.releases[release.ID] = release
+	return nil
+}
+
+// last 灰度状态为 statuses 之一的最新发布版本
+func (r *memoryReleases) last(projectID uint64, statuses ...string) (*domain.Release, error) {
+	var latest *domain.Release
+	for _, release := range r.releases {
+		if release.ProjectID != projectID || (latest != nil && release.ID < latest.ID) {
+			continue
+		}
+		for _, status := range statuses {
+			if release.RolloutStatus == status {
+				latest = release
+			}
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrReleaseNotFound
+	}
+	return latest, nil
+}
+
+func (r *memoryReleases) GetLocaleTranslations(ctx context.Context, releaseID uint64, languageCode string) ([]*domain.ReleaseTranslation, error) {
+	var translations []*domain.ReleaseTranslation
+	for key, langs := range r.values[releaseID] {
+		if value, ok := langs[languageCode]; ok {
+			translations = append(translations, &domain.ReleaseTranslation{
+				KeyName:   key,
+				Value:     value,
+				ValueType: r.types[releaseID][key].ValueType,
+			})
+		}
+	}
+	return translations, nil
+}
+
+// jsonCache 内存中的 JSON 缓存
+type jsonCache struct {
+	domain.CacheService
+	entries map[string][]byte
+}
+
+func (c *jsonCache) GetJSON(ctx context.Context, key string, dest interface{}) error {
+	data, ok := c.entries[key]
+	if !ok {
+		return domain.ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (c *jsonCache) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.entries[key] = data
+	return nil
+}
+
+func TestDeliveryService_GetBundle(t *testing.T) {
+	ctx := context.Background()
+	project := &domain.Project{ID: 1, Slug: "shop", DeliveryEnabled: true}
+	projects := deliveryProjects{
+		projects: map[string]*domain.Project{"shop": project, "blog": {ID: 2, Slug: "blog"}},
+		aliases:  map[string]*domain.Project{"old-shop": project},
+	}
+	releases := newMemoryReleases(map[string]map[string]string{
+		"home.title": {"en": "Home", "de": "Start"},
+		"home.menu":  {"en": `["a","b"]`},
+	})
+	require.NoError(t, releases.Create(ctx, &domain.Release{ProjectID: 1, Version: "v1"}))
+	releases.types[1] = map[string]domain.KeyValueType{"home.menu": {ValueType: domain.ValueTypeJSON}}
+	cache := &jsonCache{entries: map[string][]byte{}}
+	svc := service.NewDeliveryService(projects, releases, cache)
+
+	bundle, err := svc.GetBundle(ctx, "shop", "en", "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", bundle.Release)
+	assert.JSONEq(t, `{"home.title":"Home","home.menu":["a","b"]}`, string(bundle.Body))
+	assert.NotEmpty(t, bundle.ETag)
+
+	// 旧标识仍可获取；语言包按发布版本缓存
+	releases.values[1]["home.title"]["en"] = "Changed"
+	cached, err := svc.GetBundle(ctx, "old-shop", "en", "")
+	require.NoError(t, err)
+	assert.Equal(t, bundle.ETag, cached.ETag)
+
+	// 发布新版本后分发新版本
+	releases.current["home.title"]["en"] = "Welcome"
+	require.NoError(t, releases.Create(ctx, &domain.Release{ProjectID: 1, Version: "v2"}))
+	latest, err := svc.GetBundle(ctx, "shop", "en", "")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", latest.Release)
+	assert.NotEqual(t, bundle.ETag, latest.ETag)
+	assert.Contains(t, string(latest.Body), "Welcome")
+
+	// 未开启公开分发、不存在的项目或语言都返回同一个错误
+	for _, request := range [][2]string{{"blog", "en"}, {"missing", "en"}, {"shop", "fr"}, {"shop", "../en"}, {"shop", ""}} {
+		_, err = svc.GetBundle(ctx, request[0], request[1], "")
+		assert.ErrorIs(t, err, domain.ErrDeliveryNotFound, "request %v", request)
+	}
+}
+
+func TestDeliveryService_GetBundleRollout(t *testing.T) {
+	ctx := context.Background()
+	project := &domain.Project{ID: 1, Slug: "shop", DeliveryEnabled: true}
+	current := map[string]map[string]string{"home.title": {"en": "Home"}}
+	releases := newMemoryReleases(current)
+	require.NoError(t, releases.Create(ctx, &domain.Release{ProjectID: 1, Version: "v1"}))
+	current["home.title"]["en"] = "Welcome"
+	canary := &domain.Release{ProjectID: 1, Version: "v2", RolloutStatus: domain.RolloutStatusActive, RolloutPercentage: 50}
+	require.NoError(t, releases.Create(ctx, canary))
+	svc := service.NewDeliveryService(deliveryProjects{projects: map[string]*domain.Project{"shop": project}}, releases, nil)
+
+	// 没有客户端ID时获取全量版本
+	bundle, err := svc.GetBundle(ctx, "shop", "en", "")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", bundle.Release)
+
+	// 按客户端ID分桶，约一半客户端获取灰度版本，同一客户端每次获取的版本相同
+	served := map[string]string{}
+	for i := 0; i < 1000; i++ {
+		clientID := fmt.Sprintf("device-%d", i)
+		bundle, err := svc.GetBundle(ctx, "shop", "en", clientID)
+		require.NoError(t, err)
+		again, err := svc.GetBundle(ctx, "shop", "en", clientID)
+		require.NoError(t, err)
+		assert.Equal(t, bundle.Release, again.Release)
+		served[clientID] = bundle.Release
+	}
+	canaries := 0
+	for _, release := range served {
+		if release == "v2" {
+			canaries++
+		}
+	}
+	assert.InDelta(t, 500, canaries, 100)
+
+	// 提高百分比后已获取灰度版本的客户端保持不变
+	canary.RolloutPercentage = 80
+	for clientID, release := range served {
+		if release != "v2" {
+			continue
+		}
+		bundle, err := svc.GetBundle(ctx, "shop", "en", clientID)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", bundle.Release, "client %s", clientID)
+	}
+
+	// 中止后所有客户端获取全量版本
+	canary.RolloutStatus = domain.RolloutStatusAborted
+	for clientID := range served {
+		bundle, err := svc.GetBundle(ctx, "shop", "en", clientID)
+		require.NoError(t, err)
+		assert.Equal(t, "v1", bundle.Release, "client %s", clientID)
+	}
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestEmailTemplates_Defaults(t *testing.T) {
+	templates, err := service.NewEmailTemplates("")
+	require.NoError(t, err)
+
+	expiresAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	subject, body, err := templates.Render(domain.EmailEventPasswordReset, domain.PasswordResetEmailData{
+		Username:  "alice",
+		ResetURL:  "https://yflow.example.com/reset-password?token=abc",
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "YFlow 重置密码", subject)
+	assert.Contains(t, body, "alice，您好")
+	assert.Contains(t, body, "https://yflow.example.com/reset-password?token=abc")
+	assert.Contains(t, body, "2026-03-01 12:00 UTC")
+
+	// 待翻译键超过列出的数量时以省略号结尾
+	subject, body, err = templates.Render(domain.EmailEventUntranslatedDigest, domain.UntranslatedDigestEmailData{
+		Username:    "bob",
+		ProjectName: "Web",
+		ProjectURL:  "https://yflow.example.com/translations",
+		Total:       5,
+		Languages: []domain.UntranslatedLanguageDigest{
+			{Code: "de", Name: "Deutsch", Pending: 3, Keys: []string{"home.title", "home.subtitle"}},
+			{Code: "fr", Name: "Français", Pending: 2, Keys: []string{"home.title", "home.subtitle"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "YFlow 待翻译摘要：Web（5 个键）", subject)
+	assert.Contains(t, body, "Deutsch（de）：3 个\n  - home.title\n  - home.subtitle\n  …\n")
+	assert.Contains(t, body, "Français（fr）：2 个\n  - home.title\n  - home.subtitle\n\n")
+	assert.Contains(t, body, "前往翻译：https://yflow.example.com/translations")
+}
+
+func TestEmailTemplates_OverrideFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "member_added.tmpl"), []byte(
+		`{{define "subject"}}
+Welcome to {{.ProjectName}}
+{{end}}{{define "body"}}You are now {{.Role}} of {{.ProjectName}}.{{end}}`), 0o644))
+
+	templates, err := service.NewEmailTemplates(dir)
+	require.NoError(t, err)
+	subject, body, err := templates.Render(domain.EmailEventMemberAdded, domain.MemberAddedEmailData{ProjectName: "Web", Role: "editor"})
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to Web", subject, "主题中的换行被替换")
+	assert.Equal(t, "You are now editor of Web.", body)
+
+	// 未覆盖的事件使用内置模板
+	subject, _, err = templates.Render(domain.EmailEventInvitation, domain.InvitationEmailData{})
+	require.NoError(t, err)
+	assert.Equal(t, "YFlow 邀请", subject)
+
+	// 模板缺少 subject 或 body、语法错误时启动失败
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invitation.tmpl"), []byte(`{{define "body"}}hi{{end}}`), 0o644))
+	_, err = service.NewEmailTemplates(dir)
+	assert.ErrorContains(t, err, `must define "subject"`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invitation.tmpl"), []byte(`{{define "subject"}}{{.Role}`), 0o644))
+	_, err = service.NewEmailTemplates(dir)
+	assert.Error(t, err)
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	mailer := &fakeMailer{}
+	notifier := service.NewEmailNotifier(mailer, nil)
+	err := notifier.Notify(context.Background(), "alice@example.com", domain.EmailEventMemberAdded, domain.MemberAddedEmailData{ProjectName: "Web", Role: "viewer"})
+	assert.ErrorIs(t, err, domain.ErrMailNotConfigured)
+
+	mailer.enabled = true
+	require.NoError(t, notifier.Notify(context.Background(), "alice@example.com", domain.EmailEventMemberAdded, domain.MemberAddedEmailData{ProjectName: "Web", Role: "viewer"}))
+	assert.Equal(t, "YFlow 项目成员通知", mailer.subjects["alice@example.com"])
+	assert.Contains(t, mailer.sent["alice@example.com"], "“Web”的成员（角色：viewer）")
+}
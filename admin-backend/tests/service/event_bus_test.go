@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestNewEvent_PayloadRoundTrip(t *testing.T) {
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, 7, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpserted,
+		KeyNames: []string{"home.title"},
+		Count:    1,
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, uint64(7), event.ProjectID)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	var payload domain.TranslationUpdatedPayload
+	require.NoError(t, event.DecodePayload(&payload))
+	assert.Equal(t, domain.TranslationActionUpserted, payload.Action)
+	assert.Equal(t, []string{"home.title"}, payload.KeyNames)
+}
+
+func TestInMemoryEventBus_DispatchesBySubscribedType(t *testing.T) {
+	bus := service.NewInMemoryEventBus(nil)
+
+	var received []string
+	bus.Subscribe(domain.EventProjectCreated, "first", func(ctx context.Context, event domain.Event) error {
+		received = append(received, "first")
+		return nil
+	})
+	bus.Subscribe(domain.EventProjectCreated, "second", func(ctx context.Context, event domain.Event) error {
+		received = append(received, "second")
+		return nil
+	})
+	bus.Subscribe(domain.EventImportCompleted, "other", func(ctx context.Context, event domain.Event) error {
+		received = append(received, "other")
+		return nil
+	})
+
+	event, err := domain.NewEvent(domain.EventProjectCreated, 1, nil)
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	assert.Equal(t, []string{"first", "second"}, received)
+}
+
+func TestInMemoryEventBus_IsolatesFailingSubscribers(t *testing.T) {
+	bus := service.NewInMemoryEventBus(nil)
+
+	called := false
+	bus.Subscribe(domain.EventImportCompleted, "failing", func(ctx context.Context, event domain.Event) error {
+		return errors.New("boom")
+	})
+	bus.Subscribe(domain.EventImportCompleted, "panicking", func(ctx context.Context, event domain.Event) error {
+		panic("boom")
+	})
+	bus.Subscribe(domain.EventImportCompleted, "healthy", func(ctx context.Context, event domain.Event) error {
+		called = true
+		return nil
+	})
+
+	event, err := domain.NewEvent(domain.EventImportCompleted, 1, domain.ImportCompletedPayload{Source: domain.ImportSourceFile})
+	require.NoError(t, err)
+
+	// 订阅方的错误和 panic 不返回给发布方，也不影响其他订阅方
+	assert.NoError(t, bus.Publish(context.Background(), event))
+	assert.True(t, called)
+}
+
+func TestCacheInvalidationSubscribers_TranslationUpdated(t *testing.T) {
+	mockCache := new(MockCacheService)
+	mockCache.On("DeleteByPattern", mock.Anything, "project:3:translations:*").Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, "project:3:matrix:*").Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, "project:3:stats:*").Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, "response:project:3:*").Return(nil)
+	mockCache.On("DeleteByPattern", mock.Anything, "dashboard:activity:*").Return(nil)
+	mockCache.On("Delete", mock.Anything, "dashboard:stats").Return(nil)
+
+	bus := service.NewInMemoryEventBus(nil)
+	service.RegisterCacheInvalidationSubscribers(bus, mockCache)
+
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, 3, domain.TranslationUpdatedPayload{Action: domain.TranslationActionDeleted})
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), event))
+
+	mockCache.AssertExpectations(t)
+}
@@ -0,0 +1,40 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestRenderExportFileName(t *testing.T) {
+	vars := service.ExportFileVars{Locale: "zh-CN", Project: "web", Ext: "json"}
+
+	assert.Equal(t, "zh-CN.json", service.RenderExportFileName("", vars))
+	assert.Equal(t, "zh-CN/messages.json", service.RenderExportFileName("{locale}/{namespace}.{ext}", vars))
+	assert.Equal(t, "web/zh-CN.json", service.RenderExportFileName("{project}/{locale}.{ext}", vars))
+	assert.Equal(t, "zh_CN.json", service.RenderExportFileName("{locale_underscore}.{ext}", vars))
+
+	// 占位符的值不能引入额外目录
+	vars.Locale = "../etc"
+	assert.Equal(t, ".._etc.json", service.RenderExportFileName("{locale}.{ext}", vars))
+}
+
+func TestValidateExportFileTemplate(t *testing.T) {
+	assert.NoError(t, service.ValidateExportFileTemplate(""))
+	assert.NoError(t, service.ValidateExportFileTemplate("{locale}/{namespace}.json"))
+	assert.NoError(t, service.ValidateExportFileTemplate("{locale_underscore}.json"))
+
+	invalid := []string{
+		"messages.json",
+		"/{locale}.json",
+		"../{locale}.json",
+		"{locale}//a.json",
+		"{locale}\\a.json",
+	}
+	for _, tpl := range invalid {
+		assert.ErrorIs(t, service.ValidateExportFileTemplate(tpl), domain.ErrInvalidExportTemplate, tpl)
+	}
+}
@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/service"
+)
+
+func TestBuildPOFile(t *testing.T) {
+	values := map[string]map[string]string{
+		"cart.items.one":   {"en": "%d item", "ru": "%d товар"},
+		"cart.items.few":   {"ru": "%d товара"},
+		"cart.items.other": {"en": "%d items", "ru": "%d товаров"},
+		"home.title":       {"en": "Say \"hi\"\nnow", "ru": "Привет"},
+		"nav.save":         {"en": "Save", "ru": "Сохранить"},
+		"toolbar.save":     {"en": "Save", "ru": "Записать"},
+		"ru.only":          {"ru": "Только"},
+	}
+	contexts := map[string]string{"cart.items.other": "Cart badge"}
+
+	file := service.BuildPOFile("web", values, contexts, "en", "ru")
+	// 俄语的 many 形式缺失时使用 other 的译文；msgid 相同的键合并为一个词条
+	assert.Equal(t, `msgid ""
+msgstr ""
+"Project-Id-Version: web\n"
+"Language: ru\n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#. cart.items.few, cart.items.one, cart.items.other
+msgctxt "Cart badge"
+msgid "%d item"
+msgid_plural "%d items"
+msgstr[0] "%d товар"
+msgstr[1] "%d товара"
+msgstr[2] "%d товаров"
+
+#. home.title
+msgid ""
+"Say \"hi\"\n"
+"now"
+msgstr "Привет"
+
+#. nav.save
+#. toolbar.save
+msgid "Save"
+msgstr "Сохранить"
+`, string(service.MarshalPO(file)))
+
+	template := service.MarshalPO(service.BuildPOFile("web", values, contexts, "en", ""))
+	assert.Contains(t, string(template), "Plural-Forms: nplurals=INTEGER; plural=EXPRESSION;")
+	assert.Contains(t, string(template), "msgid_plural \"%d items\"\nmsgstr[0] \"\"\nmsgstr[1] \"\"\n")
+	assert.NotContains(t, string(template), "Language:")
+}
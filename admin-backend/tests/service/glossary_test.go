@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// glossaryRule 创建只有一种语言译文的术语
+func glossaryRule(term, translation, forbidden string, caseSensitive bool) *domain.GlossaryTerm {
+	return &domain.GlossaryTerm{
+		Term:          term,
+		CaseSensitive: caseSensitive,
+		Translations:  []domain.GlossaryTranslation{{LanguageID: 2, Translation: translation, Forbidden: forbidden}},
+	}
+}
+
+func TestCheckGlossary(t *testing.T) {
+	terms := []*domain.GlossaryTerm{
+		glossaryRule("workspace", "Arbeitsbereich", `["Workspace","Arbeitsplatz"]`, false),
+		glossaryRule("API", "", `["Api"]`, true),
+		glossaryRule("购物车", "Warenkorb", "", false),
+	}
+
+	// 符合术语表，其他语言的译文和没有出现的术语不检查
+	assert.Empty(t, service.CheckGlossary(terms, 2, "Arbeitsbereich öffnen", "Open WORKSPACE"))
+	assert.Empty(t, service.CheckGlossary(terms, 3, "Ouvrir Workspace", "Open workspace"))
+	// 按单词匹配：workspaces 不包含术语 workspace
+	assert.Empty(t, service.CheckGlossary(terms, 2, "Bereiche", "Open workspaces"))
+	// 区分大小写的术语只匹配相同大小写
+	assert.Empty(t, service.CheckGlossary(terms, 2, "API-Schlüssel", "API key"))
+
+	issues := service.CheckGlossary(terms, 2, "Workspace öffnen", "Open workspace")
+	require.Len(t, issues, 2)
+	assert.Equal(t, domain.QAIssueGlossaryMismatch, issues[0].Type)
+	assert.Equal(t, "原文包含术语“workspace”，译文应使用“Arbeitsbereich”", issues[0].Message)
+	assert.Equal(t, domain.QAIssueForbiddenTerm, issues[1].Type)
+	assert.Equal(t, "Arbeitsbereich öffnen", issues[1].Suggestion)
+
+	// 默认语言自身只检查禁用译文；没有规定译文时不提供建议
+	issues = service.CheckGlossary(terms, 2, "Api key and api", "")
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.QAIssueForbiddenTerm, issues[0].Type)
+	assert.Empty(t, issues[0].Suggestion)
+
+	// 中文术语按字符匹配
+	issues = service.CheckGlossary(terms, 2, "Korb anzeigen", "查看购物车")
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.QAIssueGlossaryMismatch, issues[0].Type)
+}
+
+// glossaryRepository 内存中的术语表，只实现校验用到的方法
+type glossaryRepository struct {
+	domain.GlossaryRepository
+	terms []*domain.GlossaryTerm
+}
+
+func (r *glossaryRepository) GetByTerm(ctx context.Context, projectID uint64, term string) (*domain.GlossaryTerm, error) {
+	for _, existing := range r.terms {
+		if existing.ProjectID == projectID && existing.Term == term {
+			return existing, nil
+		}
+	}
+	return nil, domain.ErrGlossaryTermNotFound
+}
+
+func TestGlossaryService_Validation(t *testing.T) {
+	repo := &glossaryRepository{terms: []*domain.GlossaryTerm{{ID: 1, ProjectID: 1, Term: "Workspace"}}}
+	svc := service.NewGlossaryService(repo, preTranslateProjects{}, preTranslateLanguages{}, nil)
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, 1, domain.GlossaryTermParams{Term: " Workspace "}, 1)
+	assert.ErrorIs(t, err, domain.ErrGlossaryTermExists)
+
+	for name, translations := range map[string][]domain.GlossaryTranslationParams{
+		"unknown language":   {{LanguageCode: "ja", Translation: "ワークスペース"}},
+		"duplicate language": {{LanguageCode: "de", Translation: "Arbeitsbereich"}, {LanguageCode: "de", Forbidden: []string{"Arbeitsplatz"}}},
+		"empty rule":         {{LanguageCode: "de", Forbidden: []string{" "}}},
+		"forbidden approved": {{LanguageCode: "de", Translation: "Arbeitsbereich", Forbidden: []string{"arbeitsbereich"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.Create(ctx, 1, domain.GlossaryTermParams{Term: "Project", Translations: translations}, 1)
+			appErr, ok := domain.IsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrInvalidGlossaryTerm.Code, appErr.Code)
+		})
+	}
+}
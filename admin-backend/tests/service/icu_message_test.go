@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestParseICUMessage(t *testing.T) {
+	const message = "{gender, select, female {Sie hat} other {Er hat}} {count, plural, offset:1 =0 {keine Datei} one {# Datei} other {# Dateien '{#}'}} am {date, date, short} geteilt, it''s '{'done'}'"
+
+	elements, err := service.ParseICUMessage(message)
+	require.NoError(t, err)
+	require.Len(t, elements, 6)
+	assert.Equal(t, domain.ICUElementSelect, elements[0].Type)
+	assert.Equal(t, "gender", elements[0].Name)
+	assert.Equal(t, "female", elements[0].Options[0].Selector)
+
+	plural := elements[2]
+	assert.Equal(t, domain.ICUElementPlural, plural.Type)
+	assert.Equal(t, 1, plural.Offset)
+	assert.Equal(t, []domain.ICUElement{
+		{Type: domain.ICUElementPound},
+		{Type: domain.ICUElementText, Value: " Dateien {#}"},
+	}, plural.Options[2].Message)
+	assert.Equal(t, domain.ICUElement{Type: domain.ICUElementArgument, Name: "date", Format: "date", Style: "short"}, elements[4])
+	assert.Equal(t, " geteilt, it's {done}", elements[5].Value)
+
+	// 序列化后再解析得到相同的结构
+	formatted, err := service.FormatICUMessage(elements)
+	require.NoError(t, err)
+	reparsed, err := service.ParseICUMessage(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, elements, reparsed)
+
+	// 撇号只在后面是语法字符时开始引用
+	elements, err = service.ParseICUMessage("Don't {name}")
+	require.NoError(t, err)
+	assert.Equal(t, "Don't ", elements[0].Value)
+
+	for name, tc := range map[string]struct {
+		message string
+		offset  int
+		error   string
+	}{
+		"unclosed":         {message: "{count, plural, one {# item} other {# items}", offset: 0, error: "缺少 }"},
+		"missing other":    {message: "Hi {count, plural, one {# item}}", offset: 3, error: "缺少 other 选项"},
+		"invalid category": {message: "{count, plural, single {x} other {y}}", offset: 16, error: "single 不是复数类别"},
+		"duplicate option": {message: "{g, select, a {x} a {y} other {z}}", offset: 18, error: "选项 a 重复"},
+		"unknown type":     {message: "{n, currency}", offset: 4, error: "不支持的参数类型 currency"},
+		"stray brace":      {message: "ünbekannt }", offset: 10, error: "有多余的 }"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.ParseICUMessage(tc.message)
+			var syntaxErr *service.ICUSyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tc.offset, syntaxErr.Offset)
+			assert.Contains(t, syntaxErr.Message, tc.error)
+		})
+	}
+
+	_, err = service.FormatICUMessage([]domain.ICUElement{{Type: domain.ICUElementPlural, Name: "n", Options: []domain.ICUOption{{Selector: "one"}}}})
+	assert.Error(t, err)
+}
+
+func TestCheckICUMessage(t *testing.T) {
+	assert.Equal(t, []string{"one", "few", "many", "other"}, service.PluralCategories("ru-RU"))
+	assert.Equal(t, []string{"other"}, service.PluralCategories("zh_Hant"))
+	assert.Equal(t, []string{"one", "other"}, service.PluralCategories("de"))
+	assert.Equal(t, []string{"one", "two", "few", "other"}, service.OrdinalCategories("en"))
+
+	const source = "{gender, select, female {She has} male {He has} other {They have}} {count, plural, one {# file} other {# files}}"
+	assert.Empty(t, service.CheckICUMessage(source, "", "en"))
+	assert.Empty(t, service.CheckICUMessage("{count, plural, =1 {ein Ordner} other {# Ordner}}", "", "de"))
+	// 不包含复杂参数的文本不检查
+	assert.Empty(t, service.CheckICUMessage("Use {braces", "", "de"))
+
+	issues := service.CheckICUMessage("{gender, select, female {Она} other {Они}} {count, plural, one {# файл} other {# файлов}}", source, "ru")
+	require.Len(t, issues, 2)
+	assert.Equal(t, domain.QAIssuePluralCategory, issues[0].Type)
+	assert.Contains(t, issues[0].Message, "缺少复数类别 few、many")
+	assert.Equal(t, domain.QAIssueSelectMismatch, issues[1].Type)
+	assert.Contains(t, issues[1].Message, "缺少选项 male")
+
+	issues = service.CheckICUMessage("{count, plural, one {# 个文件} other {# 个文件}}", source, "zh")
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "zh 没有复数类别 one")
+
+	issues = service.CheckTranslation(domain.ValueTypeString, "{count, plural, one {# Datei} other {# Dateien}", "{count, plural, one {# file} other {# files}}", "de", 0)
+	require.Len(t, issues, 1)
+	assert.Equal(t, domain.QAIssueICUInvalid, issues[0].Type)
+
+	info := service.AnalyzeICUMessage("{count, plural, one {# plik} other {# plików}}", "pl")
+	assert.True(t, info.Valid)
+	assert.Equal(t, []string{"one", "few", "many", "other"}, info.PluralCategories)
+	require.Len(t, info.Issues, 1)
+	assert.Equal(t, domain.QAIssuePluralCategory, info.Issues[0].Type)
+
+	info = service.AnalyzeICUMessage("{count, plural, one {# plik}", "")
+	assert.False(t, info.Valid)
+	require.NotNil(t, info.ErrorOffset)
+	assert.NotEmpty(t, info.Error)
+}
+
+func TestICUPluralExport(t *testing.T) {
+	values := map[string]string{
+		"cart.summary": "Cart: {count, plural, =0 {empty} one {# item} other {# items}}!",
+		"cart.owner":   "{gender, select, female {hers} other {theirs}}",
+	}
+
+	// 只有一个 plural 参数的 ICU 消息导出为复数条目，=0 选项没有对应的复数类别；包含 select 的消息按普通字符串导出
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<resources>
+    <string name="cart_owner">{gender, select, female {hers} other {theirs}}</string>
+    <plurals name="cart_summary">
+        <item quantity="one">Cart: %d item!</item>
+        <item quantity="other">Cart: %d items!</item>
+    </plurals>
+</resources>
+`, string(service.MarshalMobileStrings(service.FormatAndroidXML, values, nil)))
+
+	file := service.BuildPOFile("shop", map[string]map[string]string{
+		"cart.summary": {
+			"en": "{count, plural, one {# item} other {# items}}",
+			"ru": "{count, plural, one {# товар} few {# товара} other {# товаров}}",
+		},
+	}, nil, "en", "ru")
+	require.Len(t, file.Entries, 1)
+	assert.Equal(t, "%d item", file.Entries[0].ID)
+	assert.Equal(t, "%d items", file.Entries[0].IDPlural)
+	// 俄语的 many 形式缺少时使用 other 形式
+	assert.Equal(t, []string{"%d товар", "%d товара", "%d товаров"}, file.Entries[0].Str)
+}
@@ -0,0 +1,42 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestVerifyInboundWebhookSignature(t *testing.T) {
+	secret := "test-secret"
+	body := []byte(`{"project_id":"ext-1","translations":[{"key":"home.title","language":"en","value":"Home"}]}`)
+	signature := service.SignInboundWebhookPayload(secret, body)
+
+	assert.True(t, service.VerifyInboundWebhookSignature(secret, body, signature))
+	// 兼容不带前缀的签名
+	assert.True(t, service.VerifyInboundWebhookSignature(secret, body, signature[len(service.InboundWebhookSignaturePrefix):]))
+
+	assert.False(t, service.VerifyInboundWebhookSignature("other-secret", body, signature))
+	assert.False(t, service.VerifyInboundWebhookSignature(secret, append(body, ' '), signature))
+	assert.False(t, service.VerifyInboundWebhookSignature(secret, body, ""))
+	assert.False(t, service.VerifyInboundWebhookSignature(secret, body, "sha256=not-hex"))
+	assert.False(t, service.VerifyInboundWebhookSignature("", body, signature))
+}
+
+func TestShouldApplyConflictStrategy(t *testing.T) {
+	assert.True(t, service.ShouldApplyConflictStrategy(domain.ConflictStrategyOverwrite, "existing"))
+	assert.False(t, service.ShouldApplyConflictStrategy(domain.ConflictStrategySkip, ""))
+	assert.True(t, service.ShouldApplyConflictStrategy(domain.ConflictStrategyFillEmpty, "  "))
+	assert.False(t, service.ShouldApplyConflictStrategy(domain.ConflictStrategyFillEmpty, "existing"))
+
+	assert.True(t, service.IsValidConflictStrategy(domain.ConflictStrategyFillEmpty))
+	assert.False(t, service.IsValidConflictStrategy("merge"))
+}
+
+func TestParseLanguageMapping(t *testing.T) {
+	assert.Equal(t, map[string]string{"zh-CN": "zh_CN"}, service.ParseLanguageMapping(`{"zh-CN":"zh_CN"}`))
+	assert.Empty(t, service.ParseLanguageMapping(""))
+	assert.Empty(t, service.ParseLanguageMapping("not json"))
+}
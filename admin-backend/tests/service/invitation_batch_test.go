@@ -0,0 +1,347 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeInvitationRepository 内存中的邀请码仓储，只实现批量相关的方法
+type fakeInvitationRepository struct {
+	domain.InvitationRepository
+	invitations []*domain.Invitation
+}
+
+func (r *fakeInvitationRepository) Create(ctx context.Context, invitation *domain.Invitation) error {
+	r.invitations = append(r.invitations, invitation)
+	return nil
+}
+
+func (r *fakeInvitationRepository) CreateBatch(ctx context.Context, invitations []*domain.Invitation) error {
+	r.invitations = append(r.invitations, invitations...)
+	return nil
+}
+
+func (r *fakeInvitationRepository) RevokeByBatch(ctx context.Context, batchLabel string) (int64, error) {
+	var revoked int64
+	for _, invitation := range r.invitations {
+		if invitation.BatchLabel == batchLabel && invitation.Status == domain.InvitationStatusActive {
+			invitation.Status = domain.InvitationStatusRevoked
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *fakeInvitationRepository) GetByCode(ctx context.Context, code string) (*domain.Invitation, error) {
+	for _, invitation := range r.invitations {
+		if invitation.Code == code {
+			return invitation, nil
+		}
+	}
+	return nil, domain.ErrInvitationNotFound
+}
+
+func (r *fakeInvitationRepository) MarkAsUsed(ctx context.Context, code string, userID uint64) error {
+	invitation, err := r.GetByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if invitation.Status != domain.InvitationStatusActive || invitation.UseCount >= invitation.MaxUses {
+		return domain.ErrInvitationUsed
+	}
+	invitation.UseCount++
+	invitation.UsedBy = &userID
+	if invitation.UseCount >= invitation.MaxUses {
+		invitation.Status = domain.InvitationStatusUsed
+	}
+	return nil
+}
+
+func (r *fakeInvitationRepository) CountByBatch(ctx context.Context, batchLabel string) (int64, error) {
+	var count int64
+	for _, invitation := range r.invitations {
+		if invitation.BatchLabel == batchLabel {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fakeMailer 记录发送的邮件，向 failFor 中的地址发送时返回错误
+type fakeMailer struct {
+	enabled  bool
+	failFor  string
+	sent     map[string]string
+	subjects map[string]string
+}
+
+func (m *fakeMailer) Enabled() bool { return m.enabled }
+
+func (m *fakeMailer) Send(ctx context.Context, to, subject, body string) error {
+	if to == m.failFor {
+		return errors.New("mailbox unavailable")
+	}
+	if m.sent == nil {
+		m.sent = make(map[string]string)
+		m.subjects = make(map[string]string)
+	}
+	m.sent[to] = body
+	m.subjects[to] = subject
+	return nil
+}
+
+func TestInvitationBatch_CreateAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	svc := service.NewInvitationService(repo, nil, nil, service.NewEmailNotifier(&fakeMailer{}, nil), nil, "https://yflow.example.com")
+
+	result, err := svc.CreateInvitationBatch(ctx, 1, domain.CreateInvitationBatchParams{
+		Count:      30,
+		Role:       "viewer",
+		BatchLabel: "team-de",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "team-de", result.BatchLabel)
+	require.Len(t, result.Invitations, 30)
+	codes := make(map[string]bool)
+	for _, item := range result.Invitations {
+		codes[item.Code] = true
+		assert.Equal(t, "viewer", item.Role)
+		assert.Equal(t, "https://yflow.example.com/register?code="+item.Code, item.InvitationURL)
+		assert.Empty(t, item.EmailStatus)
+	}
+	assert.Len(t, codes, 30, "邀请码不能重复")
+
+	var buf bytes.Buffer
+	require.NoError(t, service.WriteInvitationBatchCSV(&buf, result))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 31)
+	assert.Equal(t, []string{"code", "invitation_url", "email", "role", "expires_at", "batch_label", "email_status"}, records[0])
+	assert.Equal(t, result.Invitations[0].Code, records[1][0])
+	assert.Equal(t, "team-de", records[1][5])
+
+	// 已使用的邀请码不会被撤销
+	repo.invitations[0].Status = domain.InvitationStatusUsed
+	revoked, err := svc.RevokeInvitationBatch(ctx, "team-de")
+	require.NoError(t, err)
+	assert.Equal(t, int64(29), revoked)
+	assert.Equal(t, domain.InvitationStatusUsed, repo.invitations[0].Status)
+
+	revoked, err = svc.RevokeInvitationBatch(ctx, "team-de")
+	require.NoError(t, err)
+	assert.Zero(t, revoked)
+
+	_, err = svc.RevokeInvitationBatch(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrInvitationBatchNotFound)
+}
+
+func TestInvitationBatch_Emails(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	mailer := &fakeMailer{enabled: true, failFor: "bob@example.com"}
+	svc := service.NewInvitationService(repo, nil, nil, service.NewEmailNotifier(mailer, nil), nil, "https://yflow.example.com")
+
+	result, err := svc.CreateInvitationBatch(ctx, 1, domain.CreateInvitationBatchParams{
+		Emails: []string{"alice@example.com", " bob@example.com", "ALICE@example.com"},
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^batch-\d{8}-\d{6}$`, result.BatchLabel)
+	require.Len(t, result.Invitations, 2, "重复的邮箱只创建一个邀请码")
+	assert.Equal(t, 1, result.Emailed)
+	assert.Equal(t, 1, result.EmailFailed)
+
+	alice := result.Invitations[0]
+	assert.Equal(t, "alice@example.com", alice.Email)
+	assert.Equal(t, "member", alice.Role)
+	assert.Equal(t, domain.InvitationEmailSent, alice.EmailStatus)
+	assert.Contains(t, mailer.sent["alice@example.com"], alice.InvitationURL)
+	assert.Equal(t, domain.InvitationEmailFailed, result.Invitations[1].EmailStatus)
+	assert.Equal(t, "bob@example.com", repo.invitations[1].Email)
+}
+
+func TestInvitationBatch_RecipientRoles(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	mailer := &fakeMailer{enabled: true}
+	svc := service.NewInvitationService(repo, nil, nil, service.NewEmailNotifier(mailer, nil), nil, "https://yflow.example.com")
+
+	result, err := svc.CreateInvitationBatch(ctx, 1, domain.CreateInvitationBatchParams{
+		Emails: []string{"alice@example.com"},
+		Recipients: []domain.InvitationRecipient{
+			{Email: "bob@example.com", Role: "admin"},
+			{Email: "carol@example.com"},
+			{Email: "Alice@example.com", Role: "admin"},
+		},
+		Role: "viewer",
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Invitations, 3, "重复的邮箱只创建一个邀请码")
+	assert.Equal(t, 3, result.Emailed)
+	roles := make(map[string]string)
+	for i, item := range result.Invitations {
+		roles[item.Email] = item.Role
+		assert.Equal(t, item.Role, repo.invitations[i].Role)
+		assert.Contains(t, mailer.sent[item.Email], item.InvitationURL)
+	}
+	assert.Equal(t, map[string]string{
+		"alice@example.com": "viewer",
+		"bob@example.com":   "admin",
+		"carol@example.com": "viewer",
+	}, roles)
+}
+
+func TestInvitationBatch_SkipEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	// 不发送邮件时不需要配置邮件服务
+	svc := service.NewInvitationService(repo, nil, nil, service.NewEmailNotifier(&fakeMailer{}, nil), nil, "")
+
+	result, err := svc.CreateInvitationBatch(ctx, 1, domain.CreateInvitationBatchParams{
+		Recipients: []domain.InvitationRecipient{{Email: "dave@example.com", Role: "member"}},
+		SkipEmail:  true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Invitations, 1)
+	assert.Equal(t, "dave@example.com", result.Invitations[0].Email)
+	assert.Empty(t, result.Invitations[0].EmailStatus)
+	assert.Zero(t, result.Emailed)
+	assert.Equal(t, "dave@example.com", repo.invitations[0].Email)
+}
+
+func TestInvitationBatch_Validation(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewInvitationService(&fakeInvitationRepository{}, nil, nil, service.NewEmailNotifier(&fakeMailer{}, nil), nil, "")
+
+	tests := []struct {
+		name   string
+		params domain.CreateInvitationBatchParams
+		want   error
+	}{
+		{"缺少数量", domain.CreateInvitationBatchParams{}, domain.ErrInvalidInvitationCount},
+		{"超过上限", domain.CreateInvitationBatchParams{Count: 201}, domain.ErrInvalidInvitationCount},
+		{"数量与邮箱不一致", domain.CreateInvitationBatchParams{Count: 2, Emails: []string{"a@example.com"}}, domain.ErrInvalidInvitationCount},
+		{"未配置邮件", domain.CreateInvitationBatchParams{Emails: []string{"a@example.com"}}, domain.ErrMailNotConfigured},
+		{"标签包含空格", domain.CreateInvitationBatchParams{Count: 1, BatchLabel: "team de"}, domain.ErrInvalidBatchLabel},
+		{"无效角色", domain.CreateInvitationBatchParams{Count: 1, Role: "owner"}, domain.ErrInvalidRole},
+		{"收件人角色无效", domain.CreateInvitationBatchParams{Recipients: []domain.InvitationRecipient{{Email: "a@example.com", Role: "owner"}}, SkipEmail: true}, domain.ErrInvalidRole},
+		{"数量与收件人不一致", domain.CreateInvitationBatchParams{Count: 2, Recipients: []domain.InvitationRecipient{{Email: "a@example.com"}}, SkipEmail: true}, domain.ErrInvalidInvitationCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateInvitationBatch(ctx, 1, tt.params)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestInvitationService_CreateInvitationSendsEmail(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	mailer := &fakeMailer{enabled: true}
+	svc := service.NewInvitationService(repo, nil, nil, service.NewEmailNotifier(mailer, nil), nil, "https://yflow.example.com")
+
+	invitation, invitationURL, err := svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{
+		Email:       " carol@example.com ",
+		Description: "欢迎加入本地化团队",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "carol@example.com", invitation.Email)
+	assert.Equal(t, domain.InvitationEmailSent, invitation.EmailStatus)
+	assert.Equal(t, "YFlow 邀请", mailer.subjects["carol@example.com"])
+	assert.Contains(t, mailer.sent["carol@example.com"], invitationURL)
+	assert.Contains(t, mailer.sent["carol@example.com"], "欢迎加入本地化团队")
+
+	// 发送失败时邀请码仍然创建
+	mailer.failFor = "dave@example.com"
+	invitation, _, err = svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{Email: "dave@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.InvitationEmailFailed, invitation.EmailStatus)
+	assert.Len(t, repo.invitations, 2)
+
+	// 未指定邮箱时不发送邮件
+	invitation, _, err = svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{})
+	require.NoError(t, err)
+	assert.Empty(t, invitation.EmailStatus)
+
+	mailer.enabled = false
+	_, _, err = svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{Email: "erin@example.com"})
+	assert.ErrorIs(t, err, domain.ErrMailNotConfigured)
+}
+
+func TestInvitationService_MaxUses(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	svc := service.NewInvitationService(repo, nil, nil, service.NewEmailNotifier(&fakeMailer{}, nil), nil, "")
+
+	invitation, _, err := svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, invitation.MaxUses, "默认只能使用一次")
+
+	team, _, err := svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{MaxUses: 3})
+	require.NoError(t, err)
+	for userID := uint64(1); userID <= 3; userID++ {
+		validated, err := svc.ValidateInvitation(ctx, team.Code)
+		require.NoError(t, err)
+		assert.Equal(t, 4-int(userID), validated.RemainingUses())
+		require.NoError(t, svc.UseInvitation(ctx, team.Code, userID))
+	}
+	assert.Equal(t, 3, team.UseCount)
+	assert.Equal(t, domain.InvitationStatusUsed, team.Status)
+	assert.Zero(t, team.RemainingUses())
+	_, err = svc.ValidateInvitation(ctx, team.Code)
+	assert.ErrorIs(t, err, domain.ErrInvitationUsed)
+	assert.ErrorIs(t, svc.UseInvitation(ctx, team.Code, 4), domain.ErrInvitationUsed)
+
+	for _, maxUses := range []int{-1, 1001} {
+		_, _, err = svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{MaxUses: maxUses})
+		assert.ErrorIs(t, err, domain.ErrInvalidInvitationUses)
+	}
+}
+
+// fakeUserService 记录创建的用户，只实现注册相关的方法
+type fakeUserService struct {
+	domain.UserService
+	created []*domain.User
+}
+
+func (s *fakeUserService) CreateUser(ctx context.Context, params domain.CreateUserParams) (*domain.User, error) {
+	user := &domain.User{ID: uint64(len(s.created) + 1), Username: params.Username, Email: params.Email, Role: params.Role}
+	s.created = append(s.created, user)
+	return user, nil
+}
+
+func TestInvitationService_RegisterWithExhaustedCode(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeInvitationRepository{}
+	users := &fakeUserService{}
+	svc := service.NewInvitationService(repo, nil, users, service.NewEmailNotifier(&fakeMailer{}, nil), &fakeTransactor{}, "")
+
+	invitation, _, err := svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{Role: "viewer"})
+	require.NoError(t, err)
+	user, err := svc.RegisterWithInvitation(ctx, invitation.Code, domain.CreateUserParams{Username: "alice", Role: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, "viewer", user.Role, "使用邀请码指定的角色")
+	assert.Equal(t, domain.InvitationStatusUsed, invitation.Status)
+
+	// 已用完的邀请码不再创建用户
+	_, err = svc.RegisterWithInvitation(ctx, invitation.Code, domain.CreateUserParams{Username: "bob"})
+	assert.ErrorIs(t, err, domain.ErrInvitationUsed)
+	assert.Len(t, users.created, 1)
+
+	// 校验后被并发注册用完时，占用失败使注册返回错误，由事务回滚新建的用户
+	raced, _, err := svc.CreateInvitation(ctx, 1, domain.CreateInvitationParams{MaxUses: 2})
+	require.NoError(t, err)
+	raced.UseCount = raced.MaxUses
+	_, err = svc.RegisterWithInvitation(ctx, raced.Code, domain.CreateUserParams{Username: "carol"})
+	assert.ErrorIs(t, err, domain.ErrInvitationUsed)
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// expiringInvitations 内存中的邀请码，按 ExpireActive 的语义标记过期
+type expiringInvitations struct {
+	domain.InvitationRepository
+	invitations []*domain.Invitation
+}
+
+func (r *expiringInvitations) ExpireActive(ctx context.Context, now time.Time, limit int) ([]*domain.Invitation, error) {
+	var expired []*domain.Invitation
+	for _, invitation := range r.invitations {
+		if len(expired) == limit {
+			break
+		}
+		if invitation.Status == domain.InvitationStatusActive && !invitation.ExpiresAt.After(now) {
+			invitation.Status = domain.InvitationStatusExpired
+			expired = append(expired, invitation)
+		}
+	}
+	return expired, nil
+}
+
+// recordedAuditLogs 记录写入的审计日志
+type recordedAuditLogs struct {
+	domain.AuditLogRepository
+	logs []*domain.AuditLog
+}
+
+func (r *recordedAuditLogs) Create(ctx context.Context, log *domain.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+// recordedEventBus 记录发布的事件
+type recordedEventBus struct {
+	events []domain.Event
+}
+
+func (b *recordedEventBus) Publish(ctx context.Context, event domain.Event) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *recordedEventBus) Subscribe(eventType domain.EventType, name string, handler domain.EventHandler) {
+}
+
+func TestInvitationExpiry_Expire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	invitations := &expiringInvitations{}
+	// 超过一个批次的过期邀请码
+	for i := 1; i <= 501; i++ {
+		invitations.invitations = append(invitations.invitations, &domain.Invitation{
+			ID: uint64(i), InviterID: 7, Status: domain.InvitationStatusActive, ExpiresAt: now.Add(-time.Hour),
+		})
+	}
+	invitations.invitations = append(invitations.invitations,
+		&domain.Invitation{ID: 600, Status: domain.InvitationStatusActive, ExpiresAt: now.Add(time.Hour)},
+		&domain.Invitation{ID: 601, Status: domain.InvitationStatusUsed, ExpiresAt: now.Add(-time.Hour)},
+		&domain.Invitation{ID: 602, Status: domain.InvitationStatusRevoked, ExpiresAt: now.Add(-time.Hour)},
+	)
+	auditLogs := &recordedAuditLogs{}
+	bus := &recordedEventBus{}
+	expiry := service.NewInvitationExpiry(invitations, auditLogs, bus, nil, 0, nil)
+
+	expired, err := expiry.Expire(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 501, expired)
+	assert.Equal(t, domain.InvitationStatusExpired, invitations.invitations[0].Status)
+	assert.Equal(t, domain.InvitationStatusActive, invitations.invitations[501].Status)
+	assert.Equal(t, domain.InvitationStatusUsed, invitations.invitations[502].Status)
+	assert.Equal(t, domain.InvitationStatusRevoked, invitations.invitations[503].Status)
+
+	// 每批一条系统级审计日志和一个事件
+	require.Len(t, auditLogs.logs, 2)
+	assert.Equal(t, uint64(0), auditLogs.logs[0].ProjectID)
+	assert.Equal(t, domain.AuditActionInvitationExpire, auditLogs.logs[0].Action)
+	require.Len(t, bus.events, 2)
+	assert.Equal(t, domain.EventInvitationsExpired, bus.events[1].Type)
+	assert.Equal(t, uint64(0), bus.events[1].ProjectID)
+	var payload domain.InvitationsExpiredPayload
+	require.NoError(t, bus.events[1].DecodePayload(&payload))
+	assert.Equal(t, 1, payload.Count)
+	require.Len(t, payload.Invitations, 1)
+	assert.Equal(t, uint64(501), payload.Invitations[0].ID)
+	assert.Equal(t, uint64(7), payload.Invitations[0].InviterID)
+	assert.True(t, payload.Invitations[0].ExpiresAt.Equal(invitations.invitations[500].ExpiresAt))
+
+	// 没有过期的邀请码时不记录
+	expired, err = expiry.Expire(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expired)
+	assert.Len(t, auditLogs.logs, 2)
+	assert.Len(t, bus.events, 2)
+}
@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestValidateValueSchema(t *testing.T) {
+	assert.NoError(t, service.ValidateValueSchema(`{"type": "array", "items": {"type": "string"}, "minItems": 1}`))
+	assert.NoError(t, service.ValidateValueSchema(`{"type": ["object", "null"], "additionalProperties": false, "x-ui": "ignored"}`))
+
+	for name, schema := range map[string]string{
+		"not json":      `{"type":`,
+		"not object":    `["string"]`,
+		"unknown type":  `{"type": "text"}`,
+		"bad required":  `{"required": "title"}`,
+		"bad property":  `{"properties": {"title": "string"}}`,
+		"negative size": `{"maxItems": -1}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr, ok := domain.IsAppError(service.ValidateValueSchema(schema))
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrInvalidValueSchema.Code, appErr.Code)
+		})
+	}
+}
+
+func TestValidateJSONValue(t *testing.T) {
+	const legal = `{
+		"type": "object",
+		"required": ["title", "clauses"],
+		"additionalProperties": false,
+		"properties": {
+			"title": {"type": "string", "minLength": 1},
+			"version": {"type": "integer", "minimum": 1},
+			"level": {"enum": ["info", "warning"]},
+			"clauses": {"type": "array", "maxItems": 2, "items": {"type": "string"}}
+		}
+	}`
+
+	assert.NoError(t, service.ValidateJSONValue(`["Fast", "Safe"]`, ""))
+	assert.NoError(t, service.ValidateJSONValue(`{"title": "Terms", "version": 2, "level": "info", "clauses": ["a", "b"]}`, legal))
+
+	for name, tc := range map[string]struct {
+		value  string
+		schema string
+		detail string
+	}{
+		"invalid json":     {value: `[1, 2`},
+		"trailing content": {value: `[] []`, detail: "多余"},
+		"wrong type":       {value: `"Terms"`, schema: legal, detail: "$ 的类型应为 object"},
+		"missing required": {value: `{"title": "Terms"}`, schema: legal, detail: "缺少必需的属性 clauses"},
+		"extra property":   {value: `{"title": "Terms", "clauses": [], "note": "x"}`, schema: legal, detail: "$.note"},
+		"item type":        {value: `{"title": "Terms", "clauses": ["a", 1]}`, schema: legal, detail: "$.clauses[1] 的类型应为 string"},
+		"too many items":   {value: `{"title": "Terms", "clauses": ["a", "b", "c"]}`, schema: legal, detail: "$.clauses 最多只能有 2 个元素"},
+		"not integer":      {value: `{"title": "Terms", "clauses": [], "version": 1.5}`, schema: legal, detail: "$.version 的类型应为 integer"},
+		"below minimum":    {value: `{"title": "Terms", "clauses": [], "version": 0}`, schema: legal, detail: "$.version 不能小于 1"},
+		"not in enum":      {value: `{"title": "Terms", "clauses": [], "level": "error"}`, schema: legal, detail: "$.level 不是允许的取值之一"},
+		"empty string":     {value: `{"title": "", "clauses": []}`, schema: legal, detail: "$.title 至少需要 1 个字符"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr, ok := domain.IsAppError(service.ValidateJSONValue(tc.value, tc.schema))
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrInvalidJSONValue.Code, appErr.Code)
+			assert.Contains(t, appErr.Details, tc.detail)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// copyTranslations 内存中各项目的翻译：项目ID -> 键名 -> 语言ID -> 翻译值
+type copyTranslations struct {
+	domain.TranslationRepository
+	values  map[uint64]map[string]map[uint64]string
+	upserts int
+}
+
+func (r *copyTranslations) FindExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error) {
+	var existing []string
+	for _, name := range keyNames {
+		if _, ok := r.values[projectID][name]; ok {
+			existing = append(existing, name)
+		}
+	}
+	return existing, nil
+}
+
+func (r *copyTranslations) GetByProjectKey(ctx context.Context, projectID uint64, keyName string) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	for languageID, value := range r.values[projectID][keyName] {
+		translations = append(translations, &domain.Translation{ProjectID: projectID, KeyName: keyName, LanguageID: languageID, Value: value})
+	}
+	return translations, nil
+}
+
+func (r *copyTranslations) UpsertBatch(ctx context.Context, translations []*domain.Translation) error {
+	for _, translation := range translations {
+		if r.values[translation.ProjectID] == nil {
+			r.values[translation.ProjectID] = map[string]map[uint64]string{}
+		}
+		if r.values[translation.ProjectID][translation.KeyName] == nil {
+			r.values[translation.ProjectID][translation.KeyName] = map[uint64]string{}
+		}
+		r.values[translation.ProjectID][translation.KeyName][translation.LanguageID] = translation.Value
+		r.upserts++
+	}
+	return nil
+}
+
+// copyLinks 内存中的键链接
+type copyLinks struct {
+	domain.KeyLinkRepository
+	links []*domain.KeyLink
+}
+
+func (r *copyLinks) GetBySource(ctx context.Context, sourceProjectID uint64, keyNames []string) ([]*domain.KeyLink, error) {
+	var links []*domain.KeyLink
+	for _, link := range r.links {
+		if link.SourceProjectID != sourceProjectID {
+			continue
+		}
+		if len(keyNames) == 0 || containsString(keyNames, link.KeyName) {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (r *copyLinks) Upsert(ctx context.Context, links []*domain.KeyLink) error {
+	for _, link := range links {
+		link.ID = uint64(len(r.links) + 1)
+		r.links = append(r.links, link)
+	}
+	return nil
+}
+
+func (r *copyLinks) MarkSynced(ctx context.Context, ids []uint64, syncedAt time.Time) error {
+	for _, link := range r.links {
+		if containsID(ids, link.ID) {
+			link.LastSyncedAt = &syncedAt
+		}
+	}
+	return nil
+}
+
+// copyProjects 项目 1、2、3 存在，项目 4 属于组织 5
+type copyProjects struct {
+	domain.ProjectRepository
+}
+
+func (copyProjects) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	if id == 0 || id > 4 {
+		return nil, domain.ErrProjectNotFound
+	}
+	if id == 4 {
+		return &domain.Project{ID: id, OrganizationID: 5}, nil
+	}
+	return &domain.Project{ID: id}, nil
+}
+
+// copyMembers 用户只能查看 allowed 中的项目
+type copyMembers struct {
+	domain.ProjectMemberService
+	allowed map[uint64]bool
+}
+
+func (m copyMembers) CheckPermission(ctx context.Context, userID, projectID uint64, requiredRole string) (bool, error) {
+	return m.allowed[projectID], nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(values []uint64, value uint64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func newKeyCopyFixture() (*copyTranslations, *copyLinks) {
+	translations := &copyTranslations{values: map[uint64]map[string]map[uint64]string{
+		1: {
+			"button.save":   {1: "Save", 2: "保存"},
+			"button.cancel": {1: "Cancel", 2: "取消"},
+		},
+		2: {
+			"button.cancel": {1: "Dismiss"},
+		},
+	}}
+	return translations, &copyLinks{}
+}
+
+func TestKeyCopyService_CopySkipsExistingAndLinks(t *testing.T) {
+	ctx := context.Background()
+	translations, links := newKeyCopyFixture()
+	svc := service.NewKeyCopyService(translations, links, copyProjects{}, copyMembers{allowed: map[uint64]bool{1: true}},
+		noopAuditLogs{}, nil, nil, nil)
+
+	result, err := svc.Copy(ctx, 2, domain.KeyCopyParams{
+		SourceProjectID: 1,
+		KeyNames:        []string{" button.save", "button.cancel", "button.save"},
+		Link:            true,
+	}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"button.save"}, result.Copied)
+	assert.Equal(t, []string{"button.cancel"}, result.Skipped)
+	assert.Equal(t, int64(2), result.Translations)
+	assert.Equal(t, 1, result.Linked)
+	assert.Equal(t, map[uint64]string{1: "Save", 2: "保存"}, translations.values[2]["button.save"])
+	assert.Equal(t, map[uint64]string{1: "Dismiss"}, translations.values[2]["button.cancel"])
+	require.Len(t, links.links, 1)
+	assert.Equal(t, domain.KeyLink{ID: 1, SourceProjectID: 1, TargetProjectID: 2, KeyName: "button.save", CreatedBy: 7,
+		CreatedAt: links.links[0].CreatedAt, LastSyncedAt: links.links[0].LastSyncedAt}, *links.links[0])
+
+	// 覆盖时只写入值不同的翻译
+	result, err = svc.Copy(ctx, 2, domain.KeyCopyParams{SourceProjectID: 1, KeyNames: []string{"button.save", "button.cancel"}, Overwrite: true}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"button.save", "button.cancel"}, result.Copied)
+	assert.Equal(t, int64(2), result.Translations)
+	assert.Equal(t, map[uint64]string{1: "Cancel", 2: "取消"}, translations.values[2]["button.cancel"])
+}
+
+func TestKeyCopyService_CopyRejectsInvalidRequests(t *testing.T) {
+	ctx := context.Background()
+	translations, links := newKeyCopyFixture()
+	svc := service.NewKeyCopyService(translations, links, copyProjects{}, copyMembers{allowed: map[uint64]bool{1: true, 4: true}},
+		noopAuditLogs{}, nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		projectID uint64
+		params    domain.KeyCopyParams
+		want      error
+	}{
+		{"没有键名", 2, domain.KeyCopyParams{SourceProjectID: 1, KeyNames: []string{" "}}, domain.ErrInvalidKey},
+		{"源项目是目标项目", 1, domain.KeyCopyParams{SourceProjectID: 1, KeyNames: []string{"button.save"}}, domain.ErrInvalidCopySource},
+		{"源项目不存在", 2, domain.KeyCopyParams{SourceProjectID: 9, KeyNames: []string{"button.save"}}, domain.ErrProjectNotFound},
+		{"无权查看源项目", 1, domain.KeyCopyParams{SourceProjectID: 3, KeyNames: []string{"button.save"}}, domain.ErrForbidden},
+		{"源项目属于其他组织", 2, domain.KeyCopyParams{SourceProjectID: 4, KeyNames: []string{"button.save"}}, domain.ErrCrossOrganizationCopy},
+		{"目标项目属于其他组织", 4, domain.KeyCopyParams{SourceProjectID: 1, KeyNames: []string{"button.save"}}, domain.ErrCrossOrganizationCopy},
+		{"键在源项目中不存在", 2, domain.KeyCopyParams{SourceProjectID: 1, KeyNames: []string{"button.save", "button.missing"}}, domain.ErrKeyNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Copy(ctx, tt.projectID, tt.params, 7)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+	assert.Zero(t, translations.upserts)
+	assert.Empty(t, links.links)
+}
+
+func TestKeyCopyService_SyncsLinkedKeysWithoutLooping(t *testing.T) {
+	ctx := context.Background()
+	translations, links := newKeyCopyFixture()
+	bus := service.NewInMemoryEventBus(nil)
+	svc := service.NewKeyCopyService(translations, links, copyProjects{}, copyMembers{allowed: map[uint64]bool{1: true, 2: true}},
+		noopAuditLogs{}, bus, nil, nil)
+	bus.Subscribe(domain.EventTranslationUpdated, "key-links", svc.HandleEvent)
+
+	// 两个项目互相链接同一个键
+	_, err := svc.Copy(ctx, 2, domain.KeyCopyParams{SourceProjectID: 1, KeyNames: []string{"button.save"}, Link: true}, 7)
+	require.NoError(t, err)
+	_, err = svc.Copy(ctx, 1, domain.KeyCopyParams{SourceProjectID: 2, KeyNames: []string{"button.save"}, Link: true}, 7)
+	require.NoError(t, err)
+	upserts := translations.upserts
+
+	translations.values[1]["button.save"][2] = "存储"
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, 1, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: []string{"button.save"},
+		Count:    1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, event))
+
+	assert.Equal(t, "存储", translations.values[2]["button.save"][2])
+	// 目标项目的变更事件同步回源项目时值相同，不再写入
+	assert.Equal(t, upserts+1, translations.upserts)
+	assert.NotNil(t, links.links[0].LastSyncedAt)
+
+	// 删除不同步
+	delete(translations.values[1], "button.save")
+	event, err = domain.NewEvent(domain.EventTranslationUpdated, 1, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionDeleted,
+		KeyNames: []string{"button.save"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.HandleEvent(ctx, event))
+	assert.Contains(t, translations.values[2], "button.save")
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// hashCache 内存中的哈希表缓存
+type hashCache struct {
+	domain.CacheService
+	hashes  map[string]map[string]string
+	expires map[string]time.Duration
+}
+
+func newHashCache() *hashCache {
+	return &hashCache{hashes: map[string]map[string]string{}, expires: map[string]time.Duration{}}
+}
+
+func (c *hashCache) HSet(ctx context.Context, key, field string, value interface{}) error {
+	if c.hashes[key] == nil {
+		c.hashes[key] = map[string]string{}
+	}
+	c.hashes[key][field] = value.(string)
+	return nil
+}
+
+func (c *hashCache) HGet(ctx context.Context, key, field string) (string, error) {
+	value, ok := c.hashes[key][field]
+	if !ok {
+		return "", domain.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (c *hashCache) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	if len(c.hashes[key]) == 0 {
+		return nil, domain.ErrCacheMiss
+	}
+	return c.hashes[key], nil
+}
+
+func (c *hashCache) HDel(ctx context.Context, key string, fields ...string) error {
+	for _, field := range fields {
+		delete(c.hashes[key], field)
+	}
+	return nil
+}
+
+func (c *hashCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	c.expires[key] = expiration
+	return nil
+}
+
+func TestKeyLockService_AcquireHeartbeatRelease(t *testing.T) {
+	ctx := context.Background()
+	cache := newHashCache()
+	svc := service.NewKeyLockService(cache)
+
+	lock, err := svc.Acquire(ctx, 1, " home.title ", 7, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "home.title", lock.KeyName)
+	assert.Equal(t, "alice", lock.Username)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), lock.ExpiresAt, time.Second)
+	assert.Equal(t, time.Minute, cache.expires[domain.CacheKeys.KeyLocks(1)])
+
+	// 其他用户获取时返回持有者
+	holder, err := svc.Acquire(ctx, 1, "home.title", 8, "bob")
+	assert.ErrorIs(t, err, domain.ErrKeyLocked)
+	require.NotNil(t, holder)
+	assert.Equal(t, uint64(7), holder.UserID)
+	assert.Equal(t, "alice", holder.Username)
+	_, err = svc.Heartbeat(ctx, 1, "home.title", 8)
+	assert.ErrorIs(t, err, domain.ErrKeyLockNotHeld)
+
+	// 同一键在其他项目中不受影响
+	_, err = svc.Acquire(ctx, 2, "home.title", 8, "bob")
+	require.NoError(t, err)
+
+	// 持有者重新获取和续期时保留获取时间
+	again, err := svc.Acquire(ctx, 1, "home.title", 7, "alice")
+	require.NoError(t, err)
+	assert.True(t, again.AcquiredAt.Equal(lock.AcquiredAt))
+	renewed, err := svc.Heartbeat(ctx, 1, "home.title", 7)
+	require.NoError(t, err)
+	assert.True(t, renewed.AcquiredAt.Equal(lock.AcquiredAt))
+	assert.False(t, renewed.ExpiresAt.Before(lock.ExpiresAt))
+
+	// 其他用户不能释放持有者的锁
+	require.NoError(t, svc.Release(ctx, 1, "home.title", 8))
+	locks, err := svc.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+
+	require.NoError(t, svc.Release(ctx, 1, "home.title", 7))
+	locks, err = svc.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+	_, err = svc.Acquire(ctx, 1, "home.title", 8, "bob")
+	require.NoError(t, err)
+
+	// 键名无效
+	for _, keyName := range []string{"", "   "} {
+		_, err = svc.Acquire(ctx, 1, keyName, 7, "alice")
+		assert.ErrorIs(t, err, domain.ErrInvalidKey)
+	}
+}
+
+func TestKeyLockService_ExpiredLocks(t *testing.T) {
+	ctx := context.Background()
+	cache := newHashCache()
+	svc := service.NewKeyLockService(cache)
+
+	// 写入一个未续期而过期的锁
+	expired, err := json.Marshal(domain.KeyLock{
+		ProjectID: 1, KeyName: "home.title", UserID: 7, Username: "alice",
+		AcquiredAt: time.Now().Add(-2 * time.Minute), ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+	require.NoError(t, cache.HSet(ctx, domain.CacheKeys.KeyLocks(1), "home.title", string(expired)))
+	_, err = svc.Acquire(ctx, 1, "home.menu", 8, "bob")
+	require.NoError(t, err)
+
+	// 列表中不包含过期的锁，并清理它
+	locks, err := svc.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "home.menu", locks[0].KeyName)
+	assert.NotContains(t, cache.hashes[domain.CacheKeys.KeyLocks(1)], "home.title")
+
+	// 过期的锁不能续期，其他用户可以获取
+	require.NoError(t, cache.HSet(ctx, domain.CacheKeys.KeyLocks(1), "home.title", string(expired)))
+	_, err = svc.Heartbeat(ctx, 1, "home.title", 7)
+	assert.ErrorIs(t, err, domain.ErrKeyLockNotHeld)
+	lock, err := svc.Acquire(ctx, 1, "home.title", 8, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), lock.UserID)
+}
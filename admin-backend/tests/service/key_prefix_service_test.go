@@ -0,0 +1,46 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestKeyPrefixService_RejectsInvalidParams(t *testing.T) {
+	// 参数校验在访问仓储之前完成
+	svc := service.NewKeyPrefixService(nil, nil, nil, nil, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"空前缀", func() error {
+			_, err := svc.Delete(ctx, 1, domain.KeyPrefixParams{Prefix: " * "}, 1)
+			return err
+		}, domain.ErrInvalidKeyPrefix},
+		{"无效状态", func() error {
+			_, err := svc.ChangeStatus(ctx, 1, domain.KeyPrefixParams{Prefix: "a.", Status: "archived"}, 1)
+			return err
+		}, domain.ErrInvalidInput},
+		{"新前缀包含原前缀", func() error {
+			_, err := svc.Rename(ctx, 1, domain.KeyPrefixParams{Prefix: "checkout.*", NewPrefix: "checkout.v2.*"}, 1)
+			return err
+		}, domain.ErrInvalidKeyPrefix},
+		{"原前缀包含新前缀", func() error {
+			_, err := svc.Rename(ctx, 1, domain.KeyPrefixParams{Prefix: "checkout.v2.", NewPrefix: "checkout."}, 1)
+			return err
+		}, domain.ErrInvalidKeyPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.run(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// pushLanguages 项目所属组织的语言，en 为默认语言
+type pushLanguages struct {
+	domain.LanguageService
+}
+
+func (pushLanguages) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	return []*domain.Language{
+		{ID: 1, Code: "en", IsDefault: true},
+		{ID: 2, Code: "de"},
+	}, nil
+}
+
+// pushTranslations 记录创建和批量写入的翻译
+type pushTranslations struct {
+	domain.TranslationService
+	existing  map[string]map[string]domain.TranslationCell
+	created   []domain.TranslationInput
+	upserted  []domain.TranslationInput
+	failKey   string
+	upsertErr error
+}
+
+func (s *pushTranslations) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
+	return s.existing, int64(len(s.existing)), nil
+}
+
+func (s *pushTranslations) Create(ctx context.Context, input domain.TranslationInput, userID uint64) (*domain.Translation, error) {
+	if input.KeyName == s.failKey {
+		return nil, errors.New("insert failed")
+	}
+	s.created = append(s.created, input)
+	return &domain.Translation{KeyName: input.KeyName, LanguageID: input.LanguageID, Value: input.Value}, nil
+}
+
+func (s *pushTranslations) UpsertBatch(ctx context.Context, inputs []domain.TranslationInput) error {
+	s.upserted = append(s.upserted, inputs...)
+	return s.upsertErr
+}
+
+func TestKeyPushService_PushKeys(t *testing.T) {
+	ctx := context.Background()
+	translations := &pushTranslations{
+		existing: map[string]map[string]domain.TranslationCell{"home.title": {"en": {Value: "Home"}}},
+		failKey:  "home.broken",
+	}
+	svc := service.NewKeyPushService(translations, pushLanguages{})
+
+	// 新键在所有语言中创建，已存在的键不做修改
+	result, err := svc.Push(ctx, domain.PushKeysParams{
+		ProjectID:    1,
+		Keys:         []string{"home.title", "home.menu", "home.broken"},
+		Translations: map[string]map[string]string{"de": {"home.menu": "Menü"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home.menu"}, result.Added)
+	assert.Equal(t, []string{"home.title"}, result.Existed)
+	assert.Equal(t, []string{"home.broken"}, result.Failed)
+	assert.Equal(t, []domain.TranslationInput{
+		{ProjectID: 1, KeyName: "home.menu", LanguageID: 1},
+		{ProjectID: 1, KeyName: "home.menu", LanguageID: 2, Value: "Menü"},
+	}, translations.created)
+
+	// 没有提供 Translations 时 Defaults 作为默认语言的翻译值
+	translations.created = nil
+	_, err = svc.Push(ctx, domain.PushKeysParams{ProjectID: 1, Keys: []string{"cart.total"}, Defaults: map[string]string{"cart.total": "Total"}})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TranslationInput{
+		{ProjectID: 1, KeyName: "cart.total", LanguageID: 1, Value: "Total"},
+		{ProjectID: 1, KeyName: "cart.total", LanguageID: 2},
+	}, translations.created)
+}
+
+func TestKeyPushService_BulkImport(t *testing.T) {
+	ctx := context.Background()
+	translations := &pushTranslations{
+		existing: map[string]map[string]domain.TranslationCell{"home.title": {"en": {Value: "Home"}}},
+	}
+	svc := service.NewKeyPushService(translations, pushLanguages{})
+
+	// 未指定键名时批量写入翻译，跳过空值和未知语言
+	result, err := svc.Push(ctx, domain.PushKeysParams{
+		ProjectID: 1,
+		Translations: map[string]map[string]string{
+			"en": {"home.title": "Start page", "home.menu": "Menu", "home.empty": ""},
+			"fr": {"home.menu": "Menu"},
+		},
+	})
+	require.NoError(t, err)
+	sort.Strings(result.Added)
+	assert.Equal(t, []string{"home.menu"}, result.Added)
+	assert.Equal(t, []string{"home.title"}, result.Existed)
+	assert.Empty(t, result.Failed)
+	assert.Len(t, translations.upserted, 2)
+	assert.Empty(t, translations.created)
+
+	// 批量写入失败时新增的键列入 failed
+	translations.upsertErr = errors.New("deadlock")
+	result, err = svc.Push(ctx, domain.PushKeysParams{ProjectID: 1, Translations: map[string]map[string]string{"de": {"cart.total": "Summe"}}})
+	require.NoError(t, err)
+	assert.Empty(t, result.Added)
+	assert.Equal(t, []string{"cart.total"}, result.Failed)
+}
@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// renameTranslations 内存中项目的键名
+type renameTranslations struct {
+	domain.TranslationRepository
+	keys    map[string]int64 // 键名 -> 翻译条数
+	renamed []string
+}
+
+func (r *renameTranslations) FindExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error) {
+	var existing []string
+	for _, name := range keyNames {
+		if _, ok := r.keys[name]; ok {
+			existing = append(existing, name)
+		}
+	}
+	return existing, nil
+}
+
+func (r *renameTranslations) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string, userID uint64) (int64, error) {
+	count := r.keys[keyName]
+	delete(r.keys, keyName)
+	r.keys[newKeyName] = count
+	r.renamed = append(r.renamed, keyName+"->"+newKeyName)
+	return count, nil
+}
+
+// renameReferences 分支、附件、任务分配和设计稿图层中引用键的数量，err 为分支中重命名返回的错误
+type renameReferences struct {
+	count int64
+	err   error
+}
+
+type renameBranches struct {
+	domain.BranchRepository
+	refs *renameReferences
+}
+
+func (r renameBranches) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	return r.refs.count, r.refs.err
+}
+
+type renameAttachments struct {
+	domain.AttachmentRepository
+	refs *renameReferences
+}
+
+func (r renameAttachments) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	return r.refs.count, nil
+}
+
+type renameAssignments struct {
+	domain.AssignmentRepository
+	refs *renameReferences
+}
+
+func (r renameAssignments) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	return r.refs.count, nil
+}
+
+type renameFigmaLayers struct {
+	domain.FigmaRepository
+	refs *renameReferences
+}
+
+func (r renameFigmaLayers) RenameKey(ctx context.Context, projectID uint64, keyName, newKeyName string) (int64, error) {
+	return r.refs.count, nil
+}
+
+func newKeyRenameService(translations *renameTranslations, references *renameReferences) *service.KeyRenameService {
+	return service.NewKeyRenameService(translations,
+		renameBranches{refs: references}, renameAttachments{refs: references},
+		renameAssignments{refs: references}, renameFigmaLayers{refs: references},
+		preTranslateProjects{}, noopAuditLogs{}, nil, nil)
+}
+
+func TestKeyRenameService_Rename(t *testing.T) {
+	ctx := context.Background()
+	translations := &renameTranslations{keys: map[string]int64{"home.title": 3}}
+	svc := newKeyRenameService(translations, &renameReferences{count: 2})
+
+	result, err := svc.Rename(ctx, 1, domain.KeyRenameParams{KeyName: " home.title ", NewKeyName: "home.heading"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.KeyRenameResult{
+		KeyName:            "home.title",
+		NewKeyName:         "home.heading",
+		Translations:       3,
+		BranchTranslations: 2,
+		Attachments:        2,
+		Assignments:        2,
+		FigmaLayers:        2,
+	}, result)
+	assert.Equal(t, []string{"home.title->home.heading"}, translations.renamed)
+}
+
+func TestKeyRenameService_RejectsConflicts(t *testing.T) {
+	ctx := context.Background()
+	translations := &renameTranslations{keys: map[string]int64{"home.title": 2, "home.heading": 2}}
+	svc := newKeyRenameService(translations, &renameReferences{})
+
+	tests := []struct {
+		name   string
+		params domain.KeyRenameParams
+		want   error
+	}{
+		{"新旧键名相同", domain.KeyRenameParams{KeyName: "home.title", NewKeyName: " home.title"}, domain.ErrInvalidKey},
+		{"空键名", domain.KeyRenameParams{KeyName: "home.title", NewKeyName: " "}, domain.ErrInvalidKey},
+		{"键不存在", domain.KeyRenameParams{KeyName: "home.missing", NewKeyName: "home.other"}, domain.ErrKeyNotFound},
+		{"新键名已存在", domain.KeyRenameParams{KeyName: "home.title", NewKeyName: "home.heading"}, domain.ErrKeyExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Rename(ctx, 1, tt.params, 7)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+	assert.Empty(t, translations.renamed)
+}
+
+func TestKeyRenameService_BranchConflictReturnsKeyExists(t *testing.T) {
+	// 未合并分支中同一语言已有新键名的翻译时唯一索引冲突
+	svc := newKeyRenameService(&renameTranslations{keys: map[string]int64{"home.title": 1}},
+		&renameReferences{err: errors.New("Error 1062 (23000): Duplicate entry '3-home.heading-1' for key 'idx_branch_translation'")})
+
+	_, err := svc.Rename(context.Background(), 1, domain.KeyRenameParams{KeyName: "home.title", NewKeyName: "home.heading"}, 7)
+	assert.ErrorIs(t, err, domain.ErrKeyExists)
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/config"
+	"yflow/internal/service"
+)
+
+func TestDeepLService(t *testing.T) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DeepL-Auth-Key secret:fx", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/v2/translate":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			requests = append(requests, body)
+			translations := make([]map[string]string, 0)
+			for _, text := range body["text"].([]interface{}) {
+				translations = append(translations, map[string]string{"detected_source_language": "EN", "text": "zh:" + text.(string)})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"translations": translations})
+		case "/v2/languages":
+			assert.Equal(t, "target", r.URL.Query().Get("type"))
+			_, _ = w.Write([]byte(`[{"language": "DE", "name": "German"}, {"language": "ZH-HANT", "name": "Chinese (traditional)"}]`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	svc := service.NewDeepLService(&config.MachineTranslationConfig{DeepLAPIKey: "secret:fx", DeepLURL: server.URL})
+	ctx := context.Background()
+
+	texts := make([]string, 60)
+	for i := range texts {
+		texts[i] = "text"
+	}
+	results, err := svc.TranslateBatch(ctx, texts, "en_US", "zh_TW")
+	require.NoError(t, err)
+	require.Len(t, results, 60)
+	assert.Equal(t, "zh:text", results[59].TranslatedText)
+	assert.Equal(t, "en", results[0].DetectedSourceLang)
+	// 每次请求最多 50 条；源语言不区分变体，目标语言使用 DeepL 的变体代码
+	require.Len(t, requests, 2)
+	assert.Len(t, requests[0]["text"], 50)
+	assert.Equal(t, "EN", requests[0]["source_lang"])
+	assert.Equal(t, "ZH-HANT", requests[0]["target_lang"])
+
+	_, err = svc.Translate(ctx, "Hello", "auto", "en")
+	require.NoError(t, err)
+	assert.NotContains(t, requests[2], "source_lang")
+	assert.Equal(t, "EN-US", requests[2]["target_lang"])
+
+	languages, err := svc.GetSupportedLanguages(ctx)
+	require.NoError(t, err)
+	require.Len(t, languages, 2)
+	assert.Equal(t, "de", languages[0].Code)
+	assert.Equal(t, "zh-Hant", languages[1].Code)
+
+	assert.False(t, svc.IsAvailable(ctx))
+}
+
+func TestGoogleTranslateService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("key"))
+		if r.URL.Path != "/language/translate/v2" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"message": "API key not valid"}}`))
+			return
+		}
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "zh-CN", body["target"])
+		assert.Equal(t, "pt", body["source"])
+		_, _ = w.Write([]byte(`{"data": {"translations": [{"translatedText": "你好"}]}}`))
+	}))
+	defer server.Close()
+
+	svc := service.NewGoogleTranslateService(&config.MachineTranslationConfig{GoogleAPIKey: "secret", GoogleURL: server.URL})
+	result, err := svc.Translate(context.Background(), "Olá", "pt_BR", "zh_CN")
+	require.NoError(t, err)
+	assert.Equal(t, "你好", result.TranslatedText)
+
+	_, err = svc.GetSupportedLanguages(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API key not valid")
+}
@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestCheckMarkup(t *testing.T) {
+	const htmlSource = `Read the <a href="https://example.com/terms">terms</a>, {name}.`
+	const markdownSource = "Read the [terms](https://example.com/terms), {name}."
+
+	for name, tc := range map[string]struct {
+		valueType string
+		value     string
+		source    string
+	}{
+		"html":              {valueType: domain.ValueTypeHTML, value: `Lisez les <a href="https://example.com/terms" class="link">conditions</a>, {name}.`, source: htmlSource},
+		"html void element": {valueType: domain.ValueTypeHTML, value: `Ligne<br>suivante<img src="/logo.png" alt="">`},
+		"markdown":          {valueType: domain.ValueTypeMarkdown, value: "Lisez les [conditions](https://example.com/terms), {name}.", source: markdownSource},
+		"markdown code":     {valueType: domain.ValueTypeMarkdown, value: "Run `<script>` or\n```\n<div onclick=\"x\">\n```\n"},
+		"empty value":       {valueType: domain.ValueTypeHTML, value: "", source: htmlSource},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Empty(t, service.CheckMarkup(tc.valueType, tc.value, tc.source))
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		valueType  string
+		value      string
+		source     string
+		issueType  string
+		message    string
+		suggestion string
+	}{
+		"unclosed tag":        {valueType: domain.ValueTypeHTML, value: "<b>gras", issueType: domain.QAIssueMarkupInvalid, message: "<b> 没有关闭"},
+		"stray closing tag":   {valueType: domain.ValueTypeHTML, value: "gras</b>", issueType: domain.QAIssueMarkupInvalid, message: "</b> 没有对应的开始标签"},
+		"unclosed fence":      {valueType: domain.ValueTypeMarkdown, value: "```\ncode", issueType: domain.QAIssueMarkupInvalid, message: "代码块没有闭合"},
+		"broken link":         {valueType: domain.ValueTypeMarkdown, value: "[terms](https://example.com", issueType: domain.QAIssueMarkupInvalid, message: "链接语法不完整"},
+		"script":              {valueType: domain.ValueTypeHTML, value: `Bonjour<script>alert(1)</script> !`, issueType: domain.QAIssueDisallowedMarkup, message: "<script>", suggestion: "Bonjour !"},
+		"event attribute":     {valueType: domain.ValueTypeHTML, value: `<b onclick="x()">gras</b>`, issueType: domain.QAIssueDisallowedMarkup, message: "<b onclick>", suggestion: "<b>gras</b>"},
+		"javascript link":     {valueType: domain.ValueTypeMarkdown, value: "[clic](javascript:alert(1))", issueType: domain.QAIssueDisallowedMarkup, message: "链接 javascript:alert(1)", suggestion: "clic"},
+		"obfuscated scheme":   {valueType: domain.ValueTypeHTML, value: `<a href="java&#9;script:x">clic</a>`, issueType: domain.QAIssueDisallowedMarkup, suggestion: "<a>clic</a>"},
+		"html link changed":   {valueType: domain.ValueTypeHTML, value: `Lisez les <a href="https://example.fr/cgu">conditions</a>, {name}.`, source: htmlSource, issueType: domain.QAIssueLinkMismatch, message: "缺少链接 https://example.com/terms；多出链接 https://example.fr/cgu"},
+		"markdown link lost":  {valueType: domain.ValueTypeMarkdown, value: "Lisez les conditions, {name}.", source: markdownSource, issueType: domain.QAIssueLinkMismatch, message: "缺少链接 https://example.com/terms"},
+		"placeholder renamed": {valueType: domain.ValueTypeMarkdown, value: "Lisez les [conditions](https://example.com/terms), {nom}.", source: markdownSource, issueType: domain.QAIssuePlaceholderMismatch, message: "缺少占位符 name；多出占位符 nom"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			issues := service.CheckMarkup(tc.valueType, tc.value, tc.source)
+			require.Len(t, issues, 1)
+			assert.Equal(t, tc.issueType, issues[0].Type)
+			assert.Contains(t, issues[0].Message, tc.message)
+			assert.Equal(t, tc.suggestion, issues[0].Suggestion)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestParseMigrationBundle_LokaliseKeys(t *testing.T) {
+	data := []byte(`{"keys":[{"key_name":{"ios":"ios.title","web":"home.title"},"description":"Home page title","tags":["web","home"],
+		"translations":[{"language_iso":"en","translation":"Home"},{"language_iso":"zh_CN","translation":"首页"}]}]}`)
+
+	entries, err := service.ParseMigrationBundle(service.MigrationSourceLokalise, data, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "home.title", entries[0].Key)
+	assert.Equal(t, "en", entries[0].Language)
+	assert.Equal(t, "Home page title", entries[0].Context)
+	assert.Equal(t, []string{"web", "home"}, entries[0].Tags)
+	assert.Equal(t, "首页", entries[1].Value)
+}
+
+func TestParseMigrationBundle_POEditorTerms(t *testing.T) {
+	data := []byte(`[{"term":"app.items","definition":{"one":"1 item","other":"{n} items"},"context":"cart","comment":"keep short"}]`)
+
+	_, err := service.ParseMigrationBundle(service.MigrationSourcePOEditor, data, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidMigrationBundle)
+
+	entries, err := service.ParseMigrationBundle(service.MigrationSourcePOEditor, data, "fr")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "fr", entries[0].Language)
+	assert.Equal(t, `{"one":"1 item","other":"{n} items"}`, entries[0].Value)
+	assert.Equal(t, "cart\nkeep short", entries[0].Context)
+}
+
+func TestParseMigrationBundle_CrowdinZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := map[string]string{
+		"de/src/app.json":    `{"home":{"title":"Startseite"}}`,
+		"zh-CN/src/app.json": `{"home":{"title":"首页"}}`,
+		"README.md":          "ignored",
+	}
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	entries, err := service.ParseMigrationBundle(service.MigrationSourceCrowdin, buf.Bytes(), "")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	byLanguage := make(map[string]string)
+	for _, entry := range entries {
+		assert.Equal(t, "home.title", entry.Key)
+		byLanguage[entry.Language] = entry.Value
+	}
+	assert.Equal(t, map[string]string{"de": "Startseite", "zh-CN": "首页"}, byLanguage)
+}
+
+// migrationLanguages 在 sourceLanguages 的基础上提供语言列表
+type migrationLanguages struct {
+	sourceLanguages
+}
+
+func (migrationLanguages) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	return sourceLanguageList, nil
+}
+
+// migrationTranslations 在 sourceTranslations 的基础上记录键的元数据
+type migrationTranslations struct {
+	*sourceTranslations
+	metadata map[string]domain.KeyMetadata
+}
+
+func (r *migrationTranslations) GetKeyMetadata(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyMetadata, error) {
+	result := make(map[string]domain.KeyMetadata)
+	for _, keyName := range keyNames {
+		if metadata, ok := r.metadata[keyName]; ok {
+			result[keyName] = metadata
+		}
+	}
+	return result, nil
+}
+
+func (r *migrationTranslations) UpdateKeyMetadata(ctx context.Context, projectID uint64, keyName string, metadata domain.KeyMetadata, userID uint64) (int64, error) {
+	r.metadata[keyName] = metadata
+	return 1, nil
+}
+
+func newMigrationTranslationService(repo *migrationTranslations) *service.TranslationService {
+	projects := &sourceProjects{projects: map[uint64]*domain.Project{1: {ID: 1}}}
+	return service.NewTranslationService(repo, projects, migrationLanguages{}, nil, nil, nil, nil)
+}
+
+func TestImportMigration_StoresKeyTags(t *testing.T) {
+	repo := &migrationTranslations{
+		sourceTranslations: newSourceTranslations(),
+		metadata:           map[string]domain.KeyMetadata{"home.title": {MaxLength: 20, Tags: "legacy", Platform: domain.KeyPlatformWeb}},
+	}
+	repo.seed("home.title", 1, "Home")
+	data := []byte(`{"keys":[
+		{"key_name":"home.title","tags":["web","legacy","bad,tag"],"translations":[{"language_iso":"en","translation":"Home"},{"language_iso":"de","translation":"Startseite"}]},
+		{"key_name":"home.subtitle","tags":["web"],"translations":[{"language_iso":"en","translation":"Welcome"}]},
+		{"key_name":"home.footer","translations":[{"language_iso":"en","translation":"Footer"}]}]}`)
+
+	result, err := newMigrationTranslationService(repo).ImportMigration(context.Background(), 1, domain.MigrationImportParams{
+		Source: service.MigrationSourceLokalise, Data: data, UserID: 7,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Keys)
+	assert.Equal(t, 2, result.TaggedKeys)
+	assert.Equal(t, 1, result.SkippedTags)
+	assert.Empty(t, result.Warnings)
+	// 已有的标签和其他元数据保留，导入的标签追加在后面
+	assert.Equal(t, domain.KeyMetadata{MaxLength: 20, Tags: "legacy,web", Platform: domain.KeyPlatformWeb}, repo.metadata["home.title"])
+	assert.Equal(t, "web", repo.metadata["home.subtitle"].Tags)
+	assert.NotContains(t, repo.metadata, "home.footer")
+}
+
+func TestImportMigration_WarnsSkippedScreenshots(t *testing.T) {
+	repo := &migrationTranslations{sourceTranslations: newSourceTranslations(), metadata: map[string]domain.KeyMetadata{}}
+	data := []byte(`{"keys":[
+		{"key_name":"home.title","screenshots":[{"title":"home"},{"title":"home-dark"}],
+			"translations":[{"language_iso":"en","translation":"Home"},{"language_iso":"de","translation":"Startseite"}]},
+		{"key_name":"home.subtitle","translations":[{"language_iso":"en","translation":"Welcome"}]}]}`)
+
+	entries, err := service.ParseMigrationBundle(service.MigrationSourceLokalise, data, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, entries[0].Screenshots)
+
+	result, err := newMigrationTranslationService(repo).ImportMigration(context.Background(), 1, domain.MigrationImportParams{
+		Source: service.MigrationSourceLokalise, Data: data,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Translations)
+	// 截图属于键，不因键有多种语言而重复计数
+	assert.Equal(t, 2, result.Screenshots)
+	assert.Equal(t, []string{domain.MigrationWarningSkippedScreenshots}, result.Warnings)
+}
+
+func TestParseMigrationBundle_UnsupportedSource(t *testing.T) {
+	_, err := service.ParseMigrationBundle("transifex", []byte(`{}`), "en")
+	assert.ErrorIs(t, err, domain.ErrUnsupportedMigrationSource)
+}
+
+func TestResolveLanguageCode(t *testing.T) {
+	languages := map[string]uint64{"en": 1, "zh_CN": 2, "pt": 3}
+
+	id, ok := service.ResolveLanguageCode("zh-CN", languages)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), id)
+
+	id, ok = service.ResolveLanguageCode("pt-BR", languages)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), id)
+
+	_, ok = service.ResolveLanguageCode("ko", languages)
+	assert.False(t, ok)
+}
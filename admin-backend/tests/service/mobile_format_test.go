@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/service"
+)
+
+func TestMarshalMobileStrings(t *testing.T) {
+	values := map[string]string{
+		"cart.items.one":   "%d item",
+		"cart.items.other": "%ld items",
+		"home.title":       "It's <b>\"new\"</b> & \\ok\nnow",
+		"profile.handle":   "@user",
+		"profile_handle":   "duplicate",
+		"tab.one":          "Only one form",
+	}
+	contexts := map[string]string{"home.title": "Shown -- on the home page */"}
+
+	// 没有 other 形式的 tab.one 按普通键导出；替换非法字符后重名的键只保留排序在前的一个
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<resources>
+    <plurals name="cart_items">
+        <item quantity="one">%d item</item>
+        <item quantity="other">%ld items</item>
+    </plurals>
+    <!-- Shown - - on the home page */ -->
+    <string name="home_title">It\'s &lt;b&gt;\"new\"&lt;/b&gt; &amp; \\ok\nnow</string>
+    <string name="profile_handle">\@user</string>
+    <!-- profile_handle 与 profile.handle 的资源名相同，已跳过 -->
+    <string name="tab_one">Only one form</string>
+</resources>
+`, string(service.MarshalMobileStrings(service.FormatAndroidXML, values, contexts)))
+
+	assert.Equal(t, `/* Shown -- on the home page * / */
+"home.title" = "It's <b>\"new\"</b> & \\ok\nnow";
+
+"profile.handle" = "@user";
+
+"profile_handle" = "duplicate";
+
+"tab.one" = "Only one form";
+`, string(service.MarshalMobileStrings(service.FormatAppleStrings, values, contexts)))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>cart.items</key>
+	<dict>
+		<key>NSStringLocalizedFormatKey</key>
+		<string>%#@value@</string>
+		<key>value</key>
+		<dict>
+			<key>NSStringFormatSpecTypeKey</key>
+			<string>NSStringPluralRuleType</string>
+			<key>NSStringFormatValueTypeKey</key>
+			<string>ld</string>
+			<key>one</key>
+			<string>%d item</string>
+			<key>other</key>
+			<string>%ld items</string>
+		</dict>
+	</dict>
+</dict>
+</plist>
+`, string(service.MarshalMobileStrings(service.FormatAppleStringsDict, values, nil)))
+}
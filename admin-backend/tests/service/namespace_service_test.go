@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// memoryNamespaces 内存中的命名空间
+type memoryNamespaces struct {
+	domain.NamespaceRepository
+	namespaces map[uint64]*domain.Namespace
+}
+
+func (r *memoryNamespaces) GetByID(ctx context.Context, id uint64) (*domain.Namespace, error) {
+	if namespace, ok := r.namespaces[id]; ok {
+		return namespace, nil
+	}
+	return nil, domain.ErrNamespaceNotFound
+}
+
+func (r *memoryNamespaces) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Namespace, error) {
+	for _, namespace := range r.namespaces {
+		if namespace.ProjectID == projectID && namespace.Name == name {
+			return namespace, nil
+		}
+	}
+	return nil, domain.ErrNamespaceNotFound
+}
+
+func (r *memoryNamespaces) Create(ctx context.Context, namespace *domain.Namespace) error {
+	namespace.ID = uint64(len(r.namespaces) + 1)
+	r.namespaces[namespace.ID] = namespace
+	return nil
+}
+
+func (r *memoryNamespaces) Update(ctx context.Context, namespace *domain.Namespace) error {
+	r.namespaces[namespace.ID] = namespace
+	return nil
+}
+
+// namespaceTranslations 记录键的命名空间，只实现命名空间用到的方法
+type namespaceTranslations struct {
+	domain.TranslationRepository
+	keys    map[string]uint64
+	updates int
+}
+
+func (r *namespaceTranslations) FindExistingKeyNames(ctx context.Context, projectID uint64, keyNames []string) ([]string, error) {
+	var existing []string
+	for _, keyName := range keyNames {
+		if _, ok := r.keys[keyName]; ok {
+			existing = append(existing, keyName)
+		}
+	}
+	return existing, nil
+}
+
+func (r *namespaceTranslations) UpdateKeyNamespace(ctx context.Context, projectID uint64, keyNames []string, namespaceID, userID uint64) (int64, error) {
+	r.updates++
+	for _, keyName := range keyNames {
+		r.keys[keyName] = namespaceID
+	}
+	return int64(len(keyNames)), nil
+}
+
+func TestNamespaceService_CreateValidatesName(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewNamespaceService(&memoryNamespaces{namespaces: map[uint64]*domain.Namespace{}}, preTranslateProjects{}, nil, nil, nil)
+
+	namespace, err := svc.Create(ctx, 1, domain.NamespaceParams{Name: " checkout "}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "checkout", namespace.Name)
+
+	_, err = svc.Create(ctx, 1, domain.NamespaceParams{Name: "checkout"}, 1)
+	assert.ErrorIs(t, err, domain.ErrNamespaceExists)
+	// 其他项目可以使用相同名称
+	_, err = svc.Create(ctx, 2, domain.NamespaceParams{Name: "checkout"}, 1)
+	assert.NoError(t, err)
+
+	for _, name := range []string{"", "../etc", "with space", "-leading"} {
+		_, err = svc.Create(ctx, 1, domain.NamespaceParams{Name: name}, 1)
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, "name %q: unexpected error: %v", name, err)
+		assert.Equal(t, domain.ErrInvalidNamespace.Code, appErr.Code, "name %q", name)
+	}
+
+	// 改名为已有名称时冲突，只改说明时不检查名称
+	settings, err := svc.Create(ctx, 1, domain.NamespaceParams{Name: "settings"}, 1)
+	require.NoError(t, err)
+	_, err = svc.Update(ctx, 1, settings.ID, domain.NamespaceParams{Name: "checkout"}, 2)
+	assert.ErrorIs(t, err, domain.ErrNamespaceExists)
+	description := "Settings screens"
+	updated, err := svc.Update(ctx, 1, settings.ID, domain.NamespaceParams{Name: "settings", Description: &description}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, description, updated.Description)
+	_, err = svc.Update(ctx, 2, settings.ID, domain.NamespaceParams{Description: &description}, 2)
+	assert.ErrorIs(t, err, domain.ErrNamespaceNotFound)
+}
+
+func TestNamespaceService_AssignKeys(t *testing.T) {
+	ctx := context.Background()
+	namespaces := &memoryNamespaces{namespaces: map[uint64]*domain.Namespace{
+		5: {ID: 5, ProjectID: 1, Name: "checkout"},
+	}}
+	translations := &namespaceTranslations{keys: map[string]uint64{"checkout.title": 0, "checkout.pay": 0}}
+	svc := service.NewNamespaceService(namespaces, preTranslateProjects{}, translations, nil, nil)
+
+	// 任一键不存在时不做修改
+	_, err := svc.AssignKeys(ctx, 1, domain.AssignNamespaceParams{KeyNames: []string{"checkout.title", "missing"}, Namespace: "checkout"}, 1)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Contains(t, appErr.Details, "missing")
+	assert.Zero(t, translations.updates)
+
+	_, err = svc.AssignKeys(ctx, 1, domain.AssignNamespaceParams{KeyNames: []string{"checkout.title"}, Namespace: "unknown"}, 1)
+	assert.ErrorIs(t, err, domain.ErrNamespaceNotFound)
+
+	result, err := svc.AssignKeys(ctx, 1, domain.AssignNamespaceParams{KeyNames: []string{"checkout.title", " checkout.pay", "checkout.title"}, Namespace: "checkout"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout.pay", "checkout.title"}, result.KeyNames)
+	assert.Equal(t, uint64(5), translations.keys["checkout.pay"])
+
+	// 空命名空间表示移出命名空间
+	_, err = svc.AssignKeys(ctx, 1, domain.AssignNamespaceParams{KeyNames: []string{"checkout.pay"}}, 1)
+	require.NoError(t, err)
+	assert.Zero(t, translations.keys["checkout.pay"])
+	assert.Equal(t, uint64(5), translations.keys["checkout.title"])
+}
@@ -0,0 +1,303 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// memoryOrganizations 内存中的组织仓储
+type memoryOrganizations struct {
+	domain.OrganizationRepository
+	organizations map[uint64]*domain.Organization
+	nextID        uint64
+}
+
+func (r *memoryOrganizations) Create(ctx context.Context, organization *domain.Organization) error {
+	r.nextID++
+	organization.ID = r.nextID
+	r.organizations[organization.ID] = organization
+	return nil
+}
+
+func (r *memoryOrganizations) GetByID(ctx context.Context, id uint64) (*domain.Organization, error) {
+	organization, ok := r.organizations[id]
+	if !ok {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	return organization, nil
+}
+
+func (r *memoryOrganizations) GetByName(ctx context.Context, name string) (*domain.Organization, error) {
+	for _, organization := range r.organizations {
+		if organization.Name == name {
+			return organization, nil
+		}
+	}
+	return nil, domain.ErrOrganizationNotFound
+}
+
+func (r *memoryOrganizations) Delete(ctx context.Context, id uint64) error {
+	delete(r.organizations, id)
+	return nil
+}
+
+// memoryOrganizationMembers 内存中的组织成员仓储
+type memoryOrganizationMembers struct {
+	domain.OrganizationMemberRepository
+	members []*domain.OrganizationMember
+}
+
+func (r *memoryOrganizationMembers) Create(ctx context.Context, member *domain.OrganizationMember) error {
+	r.members = append(r.members, member)
+	return nil
+}
+
+func (r *memoryOrganizationMembers) Get(ctx context.Context, organizationID, userID uint64) (*domain.OrganizationMember, error) {
+	for _, member := range r.members {
+		if member.OrganizationID == organizationID && member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, domain.ErrOrganizationMemberNotFound
+}
+
+func (r *memoryOrganizationMembers) GetByOrganization(ctx context.Context, organizationID uint64) ([]*domain.OrganizationMember, error) {
+	var members []*domain.OrganizationMember
+	for _, member := range r.members {
+		if member.OrganizationID == organizationID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (r *memoryOrganizationMembers) Update(ctx context.Context, member *domain.OrganizationMember) error {
+	return nil
+}
+
+func (r *memoryOrganizationMembers) Delete(ctx context.Context, organizationID, userID uint64) error {
+	for i, member := range r.members {
+		if member.OrganizationID == organizationID && member.UserID == userID {
+			r.members = append(r.members[:i], r.members[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// organizationUsers 按ID和邮箱查询的用户仓储
+type organizationUsers struct {
+	domain.UserRepository
+	users map[uint64]*domain.User
+}
+
+func (r *organizationUsers) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *organizationUsers) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+// organizationProjects 按组织查询项目的项目仓储
+type organizationProjects struct {
+	domain.ProjectRepository
+	projects []*domain.Project
+}
+
+func (r *organizationProjects) GetByOrganization(ctx context.Context, organizationID uint64) ([]*domain.Project, error) {
+	var projects []*domain.Project
+	for _, project := range r.projects {
+		if project.OrganizationID == organizationID {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+// organizationProjectMembers 记录被删除的项目成员关系
+type organizationProjectMembers struct {
+	domain.ProjectMemberRepository
+	deleted []uint64
+}
+
+func (r *organizationProjectMembers) Delete(ctx context.Context, projectID, userID uint64) error {
+	r.deleted = append(r.deleted, projectID)
+	return nil
+}
+
+// organizationLanguages 按 context 中的组织读写的语言仓储
+type organizationLanguages struct {
+	domain.LanguageRepository
+	languages []*domain.Language
+}
+
+func (r *organizationLanguages) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	var languages []*domain.Language
+	for _, language := range r.languages {
+		if language.OrganizationID == domain.OrganizationFromContext(ctx) {
+			languages = append(languages, language)
+		}
+	}
+	return languages, nil
+}
+
+func (r *organizationLanguages) Create(ctx context.Context, language *domain.Language) error {
+	r.languages = append(r.languages, language)
+	return nil
+}
+
+// organizationFixture 组织服务及其内存仓储
+type organizationFixture struct {
+	service        *service.OrganizationService
+	organizations  *memoryOrganizations
+	members        *memoryOrganizationMembers
+	projects       *organizationProjects
+	projectMembers *organizationProjectMembers
+	languages      *organizationLanguages
+}
+
+func newOrganizationFixture() *organizationFixture {
+	f := &organizationFixture{
+		organizations:  &memoryOrganizations{organizations: make(map[uint64]*domain.Organization)},
+		members:        &memoryOrganizationMembers{},
+		projects:       &organizationProjects{},
+		projectMembers: &organizationProjectMembers{},
+		languages: &organizationLanguages{languages: []*domain.Language{
+			{ID: 1, Code: "en", Name: "English", IsDefault: true, Status: "active"},
+			{ID: 2, Code: "zh-CN", Name: "简体中文", Status: "active"},
+			{ID: 3, Code: "fr", Name: "Français", Status: "inactive"},
+		}},
+	}
+	users := &organizationUsers{users: map[uint64]*domain.User{
+		1: {ID: 1, Username: "owner", Email: "owner@example.com", Role: "member"},
+		2: {ID: 2, Username: "alice", Email: "alice@example.com", Role: "member"},
+		3: {ID: 3, Username: "root", Email: "root@example.com", Role: "admin"},
+	}}
+	f.service = service.NewOrganizationService(f.organizations, f.members, users, f.projects, f.projectMembers, f.languages, nil)
+	return f
+}
+
+func TestOrganization_CreateCopiesActiveLanguages(t *testing.T) {
+	ctx := context.Background()
+	f := newOrganizationFixture()
+
+	organization, err := f.service.Create(ctx, domain.OrganizationParams{Name: " Acme "}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", organization.Name)
+
+	member, err := f.members.Get(ctx, organization.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrganizationRoleOwner, member.Role, "创建者成为 owner")
+
+	languages, err := f.languages.GetAll(domain.WithOrganization(ctx, organization.ID))
+	require.NoError(t, err)
+	require.Len(t, languages, 2, "只复制启用的共享语言")
+	assert.Equal(t, "en", languages[0].Code)
+	assert.True(t, languages[0].IsDefault)
+	assert.Equal(t, "zh-CN", languages[1].Code)
+
+	_, err = f.service.Create(ctx, domain.OrganizationParams{Name: "Acme"}, 2)
+	assert.ErrorIs(t, err, domain.ErrOrganizationExists)
+}
+
+func TestOrganization_KeepsLastOwner(t *testing.T) {
+	ctx := context.Background()
+	f := newOrganizationFixture()
+	organization, err := f.service.Create(ctx, domain.OrganizationParams{Name: "Acme"}, 1)
+	require.NoError(t, err)
+
+	_, err = f.service.UpdateMemberRole(ctx, organization.ID, 1, domain.OrganizationRoleAdmin)
+	assert.ErrorIs(t, err, domain.ErrLastOrganizationOwner)
+	assert.ErrorIs(t, f.service.RemoveMember(ctx, organization.ID, 1), domain.ErrLastOrganizationOwner)
+
+	_, err = f.service.AddMember(ctx, organization.ID, domain.AddOrganizationMemberParams{Email: "alice@example.com", Role: domain.OrganizationRoleOwner}, 1)
+	require.NoError(t, err)
+	_, err = f.service.UpdateMemberRole(ctx, organization.ID, 1, domain.OrganizationRoleAdmin)
+	assert.NoError(t, err, "还有其他 owner 时可以降级")
+
+	_, err = f.service.AddMember(ctx, organization.ID, domain.AddOrganizationMemberParams{UserID: 2}, 1)
+	assert.ErrorIs(t, err, domain.ErrOrganizationMemberExists)
+	_, err = f.service.AddMember(ctx, organization.ID, domain.AddOrganizationMemberParams{UserID: 3, Role: "superuser"}, 1)
+	assert.ErrorIs(t, err, domain.ErrInvalidOrganizationRole)
+}
+
+func TestOrganization_RemoveMemberLeavesProjects(t *testing.T) {
+	ctx := context.Background()
+	f := newOrganizationFixture()
+	organization, err := f.service.Create(ctx, domain.OrganizationParams{Name: "Acme"}, 1)
+	require.NoError(t, err)
+	_, err = f.service.AddMember(ctx, organization.ID, domain.AddOrganizationMemberParams{UserID: 2}, 1)
+	require.NoError(t, err)
+	f.projects.projects = []*domain.Project{
+		{ID: 10, OrganizationID: organization.ID},
+		{ID: 11, OrganizationID: 0},
+		{ID: 12, OrganizationID: organization.ID},
+	}
+
+	require.NoError(t, f.service.RemoveMember(ctx, organization.ID, 2))
+	assert.Equal(t, []uint64{10, 12}, f.projectMembers.deleted, "只移除组织下项目的成员关系")
+	_, err = f.members.Get(ctx, organization.ID, 2)
+	assert.ErrorIs(t, err, domain.ErrOrganizationMemberNotFound)
+}
+
+func TestOrganization_CheckRole(t *testing.T) {
+	ctx := context.Background()
+	f := newOrganizationFixture()
+	organization, err := f.service.Create(ctx, domain.OrganizationParams{Name: "Acme"}, 1)
+	require.NoError(t, err)
+	_, err = f.service.AddMember(ctx, organization.ID, domain.AddOrganizationMemberParams{UserID: 2}, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		userID   uint64
+		role     string
+		expected bool
+	}{
+		{"owner 拥有 admin 权限", 1, domain.OrganizationRoleAdmin, true},
+		{"member 拥有 member 权限", 2, domain.OrganizationRoleMember, true},
+		{"member 没有 admin 权限", 2, domain.OrganizationRoleAdmin, false},
+		{"系统管理员拥有所有组织的权限", 3, domain.OrganizationRoleOwner, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := f.service.CheckRole(ctx, organization.ID, tt.userID, tt.role)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+
+	_, err = f.service.CheckRole(ctx, organization.ID+1, 1, domain.OrganizationRoleMember)
+	assert.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+}
+
+func TestOrganization_DeleteRequiresNoProjects(t *testing.T) {
+	ctx := context.Background()
+	f := newOrganizationFixture()
+	organization, err := f.service.Create(ctx, domain.OrganizationParams{Name: "Acme"}, 1)
+	require.NoError(t, err)
+	f.projects.projects = []*domain.Project{{ID: 10, OrganizationID: organization.ID}}
+
+	assert.ErrorIs(t, f.service.Delete(ctx, organization.ID), domain.ErrOrganizationNotEmpty)
+
+	f.projects.projects = nil
+	require.NoError(t, f.service.Delete(ctx, organization.ID))
+	_, err = f.service.GetByID(ctx, organization.ID)
+	assert.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+}
@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/service"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		name   string
+		ip     string
+		public bool
+	}{
+		{"public ipv4", "93.184.216.34", true},
+		{"public ipv6", "2606:2800:220:1:248:1893:25c8:1946", true},
+		{"loopback", "127.0.0.1", false},
+		{"private", "10.1.2.3", false},
+		{"link local metadata", "169.254.169.254", false},
+		{"unspecified", "0.0.0.0", false},
+		{"this network", "0.1.2.3", false},
+		{"shared address space", "100.64.0.1", false},
+		{"ietf protocol assignments", "192.0.0.8", false},
+		{"benchmarking", "198.18.0.1", false},
+		{"benchmarking upper half", "198.19.255.254", false},
+		{"after benchmarking", "198.20.0.1", true},
+		{"multicast", "224.0.0.1", false},
+		{"ipv6 loopback", "::1", false},
+		{"ipv6 unique local", "fd00::1", false},
+		{"ipv4 mapped loopback", "::ffff:127.0.0.1", false},
+		{"ipv4 mapped private", "::ffff:192.168.1.1", false},
+		{"ipv4 mapped benchmarking", "::ffff:198.18.0.1", false},
+		{"ipv4 mapped public", "::ffff:93.184.216.34", true},
+		{"nat64 loopback", "64:ff9b::7f00:1", false},
+		{"nat64 public", "64:ff9b::5db8:d822", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := net.ParseIP(tt.ip)
+			if assert.NotNil(t, ip) {
+				assert.Equal(t, tt.public, service.IsPublicIP(ip))
+			}
+		})
+	}
+}
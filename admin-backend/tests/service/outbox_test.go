@@ -0,0 +1,235 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// MockOutboxRepository 模拟事务发件箱仓储
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockOutboxRepository) Create(ctx context.Context, event *domain.OutboxEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*domain.OutboxEvent, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]*domain.OutboxEvent), args.Error(1)
+}
+
+func (m *MockOutboxRepository) Claim(ctx context.Context, id uint64, attempts int, leaseUntil time.Time) (bool, error) {
+	args := m.Called(ctx, id, attempts, leaseUntil)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockOutboxRepository) MarkDelivered(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id uint64, status string, nextAttemptAt time.Time, lastError string) error {
+	args := m.Called(ctx, id, status, nextAttemptAt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepository) DeleteDeliveredBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOutboxRepository) GetFailed(ctx context.Context, eventType string, limit, offset int) ([]*domain.OutboxEvent, int64, error) {
+	args := m.Called(ctx, eventType, limit, offset)
+	return args.Get(0).([]*domain.OutboxEvent), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOutboxRepository) Requeue(ctx context.Context, ids []uint64, nextAttemptAt time.Time) (int64, error) {
+	args := m.Called(ctx, ids, nextAttemptAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// fakeTransactor 记录提交后回调，由测试决定何时“提交”
+type fakeTransactor struct {
+	afterCommit []func(ctx context.Context)
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *fakeTransactor) AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	f.afterCommit = append(f.afterCommit, fn)
+}
+
+func (f *fakeTransactor) commit(ctx context.Context) {
+	for _, fn := range f.afterCommit {
+		fn(ctx)
+	}
+}
+
+// alertingEventBus 只接受死信告警事件的事件总线
+type alertingEventBus struct {
+	alerts []domain.OutboxDeadLetteredPayload
+}
+
+func (b *alertingEventBus) Publish(ctx context.Context, event domain.Event) error {
+	if event.Type != domain.EventOutboxDeadLettered {
+		return errors.New("bus unavailable")
+	}
+	var payload domain.OutboxDeadLetteredPayload
+	if err := event.DecodePayload(&payload); err != nil {
+		return err
+	}
+	b.alerts = append(b.alerts, payload)
+	return nil
+}
+
+func (b *alertingEventBus) Subscribe(eventType domain.EventType, name string, handler domain.EventHandler) {
+}
+
+func outboxRecord(t *testing.T, id uint64, attempts int) (*domain.OutboxEvent, domain.Event) {
+	t.Helper()
+	event, err := domain.NewEvent(domain.EventProjectCreated, 9, domain.ProjectCreatedPayload{Name: "demo"})
+	require.NoError(t, err)
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	return &domain.OutboxEvent{
+		ID:        id,
+		EventID:   event.ID,
+		EventType: string(event.Type),
+		Payload:   string(data),
+		Status:    domain.OutboxStatusPending,
+		Attempts:  attempts,
+	}, event
+}
+
+func TestOutboxEventBus_DeliversAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockOutboxRepository)
+	transactor := &fakeTransactor{}
+	delivery := service.NewInMemoryEventBus(nil)
+	bus := service.NewOutboxEventBus(repo, transactor, delivery, nil)
+
+	var received []string
+	bus.Subscribe(domain.EventProjectCreated, "test", func(ctx context.Context, event domain.Event) error {
+		received = append(received, event.ID)
+		return nil
+	})
+
+	event, err := domain.NewEvent(domain.EventProjectCreated, 1, nil)
+	require.NoError(t, err)
+
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(record *domain.OutboxEvent) bool {
+		record.ID = 42
+		return record.EventID == event.ID && record.Status == domain.OutboxStatusPending
+	})).Return(nil)
+	require.NoError(t, bus.Publish(ctx, event))
+
+	// 提交前不投递
+	assert.Empty(t, received)
+
+	repo.On("MarkDelivered", mock.Anything, uint64(42)).Return(nil)
+	transactor.commit(ctx)
+
+	assert.Equal(t, []string{event.ID}, received)
+	repo.AssertExpectations(t)
+}
+
+func TestOutboxEventBus_WriteFailureIsReturned(t *testing.T) {
+	repo := new(MockOutboxRepository)
+	repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+	bus := service.NewOutboxEventBus(repo, &fakeTransactor{}, service.NewInMemoryEventBus(nil), nil)
+
+	event, err := domain.NewEvent(domain.EventProjectCreated, 1, nil)
+	require.NoError(t, err)
+
+	// 写入失败必须返回给调用方，以便回滚业务数据
+	assert.Error(t, bus.Publish(context.Background(), event))
+}
+
+func TestOutboxDispatcher_RedeliversWithSameEventID(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockOutboxRepository)
+	delivery := service.NewInMemoryEventBus(nil)
+
+	var received []string
+	delivery.Subscribe(domain.EventProjectCreated, "test", func(ctx context.Context, event domain.Event) error {
+		received = append(received, event.ID)
+		return nil
+	})
+
+	record, event := outboxRecord(t, 1, 0)
+	taken, _ := outboxRecord(t, 2, 3)
+	repo.On("GetDue", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.OutboxEvent{record, taken}, nil)
+	repo.On("Claim", mock.Anything, uint64(1), 0, mock.Anything).Return(true, nil)
+	// 已被其他实例领取的事件跳过
+	repo.On("Claim", mock.Anything, uint64(2), 3, mock.Anything).Return(false, nil)
+	repo.On("MarkDelivered", mock.Anything, uint64(1)).Return(nil)
+
+	delivered, err := service.NewOutboxDispatcher(repo, delivery, nil).DispatchDue(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{event.ID}, received)
+	repo.AssertExpectations(t)
+}
+
+func TestOutboxDispatcher_RecordsFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockOutboxRepository)
+
+	retry, _ := outboxRecord(t, 1, 0)
+	exhausted, _ := outboxRecord(t, 2, 9)
+	repo.On("GetDue", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.OutboxEvent{retry, exhausted}, nil)
+	repo.On("Claim", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	repo.On("MarkFailed", mock.Anything, uint64(1), domain.OutboxStatusPending, mock.Anything, "bus unavailable").Return(nil)
+	repo.On("MarkFailed", mock.Anything, uint64(2), domain.OutboxStatusFailed, mock.Anything, "bus unavailable").Return(nil)
+
+	bus := &alertingEventBus{}
+	delivered, err := service.NewOutboxDispatcher(repo, bus, nil).DispatchDue(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, delivered)
+	repo.AssertExpectations(t)
+
+	// 只有进入死信状态的事件发布告警
+	require.Len(t, bus.alerts, 1)
+	assert.Equal(t, uint64(2), bus.alerts[0].OutboxID)
+	assert.Equal(t, exhausted.EventID, bus.alerts[0].EventID)
+	assert.Equal(t, 10, bus.alerts[0].Attempts)
+	assert.Equal(t, "bus unavailable", bus.alerts[0].LastError)
+}
+
+func TestDeadLetterService_Requeue(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockOutboxRepository)
+	svc := service.NewDeadLetterService(repo, nil)
+
+	// 必须指定事件或选择全部
+	_, err := svc.Requeue(ctx, domain.RequeueDeadLettersParams{}, 1)
+	assert.ErrorIs(t, err, domain.ErrNoDeadLettersSelected)
+
+	repo.On("Requeue", mock.Anything, []uint64{3, 4}, mock.Anything).Return(int64(1), nil).Once()
+	requeued, err := svc.Requeue(ctx, domain.RequeueDeadLettersParams{IDs: []uint64{3, 4}}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), requeued)
+
+	// 选择全部时忽略指定的事件
+	repo.On("Requeue", mock.Anything, []uint64(nil), mock.Anything).Return(int64(7), nil).Once()
+	requeued, err = svc.Requeue(ctx, domain.RequeueDeadLettersParams{IDs: []uint64{3}, All: true}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), requeued)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// resetUsers 内存中的用户，在 bulkMemberUsers 基础上支持按ID读取和更新
+type resetUsers struct {
+	bulkMemberUsers
+}
+
+func (r *resetUsers) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *resetUsers) Update(ctx context.Context, user *domain.User) error {
+	for i, existing := range r.users {
+		if existing.ID == user.ID {
+			r.users[i] = user
+		}
+	}
+	return nil
+}
+
+// memoryResetTokens 内存中的找回密码令牌
+type memoryResetTokens struct {
+	tokens []*domain.PasswordResetToken
+}
+
+func (r *memoryResetTokens) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	token.ID = uint64(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *memoryResetTokens) GetByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrInvalidResetToken
+}
+
+func (r *memoryResetTokens) MarkUsed(ctx context.Context, id uint64, usedAt time.Time) (bool, error) {
+	token := r.tokens[id-1]
+	if token.UsedAt != nil {
+		return false, nil
+	}
+	token.UsedAt = &usedAt
+	return true, nil
+}
+
+func (r *memoryResetTokens) DeleteExpiredBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// resetTokenPattern 从邮件正文中提取重置链接的令牌
+var resetTokenPattern = regexp.MustCompile(`/reset-password\?token=(\S+)`)
+
+func TestPasswordResetService_RequestAndConfirm(t *testing.T) {
+	ctx := context.Background()
+	users := &resetUsers{bulkMemberUsers{users: []*domain.User{
+		{ID: 1, Username: "alice", Email: "alice@example.com", Status: "active", Password: "old"},
+		{ID: 2, Username: "bob", Email: "bob@example.com", Status: "disabled", Password: "old"},
+	}}}
+	tokens := &memoryResetTokens{}
+	mailer := &fakeMailer{enabled: true}
+	svc := service.NewPasswordResetService(users, tokens, service.NewEmailNotifier(mailer, nil), "https://yflow.example.com", 30*time.Minute)
+
+	require.NoError(t, svc.RequestReset(ctx, " ALICE@example.com "))
+	require.Len(t, tokens.tokens, 1)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), tokens.tokens[0].ExpiresAt, time.Minute)
+	match := resetTokenPattern.FindStringSubmatch(mailer.sent["alice@example.com"])
+	require.NotNil(t, match, mailer.sent["alice@example.com"])
+	plain, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+	assert.NotEqual(t, plain, tokens.tokens[0].TokenHash, "只保存令牌的哈希")
+
+	// 未注册或已禁用的邮箱同样返回成功，但不发送邮件
+	require.NoError(t, svc.RequestReset(ctx, "nobody@example.com"))
+	require.NoError(t, svc.RequestReset(ctx, "bob@example.com"))
+	assert.Len(t, tokens.tokens, 1)
+	assert.Len(t, mailer.sent, 1)
+
+	require.NoError(t, svc.ConfirmReset(ctx, plain, "new-secret"))
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(users.users[0].Password), []byte("new-secret")))
+
+	// 令牌只能使用一次
+	assert.ErrorIs(t, svc.ConfirmReset(ctx, plain, "another-secret"), domain.ErrInvalidResetToken)
+	assert.ErrorIs(t, svc.ConfirmReset(ctx, "unknown", "another-secret"), domain.ErrInvalidResetToken)
+}
+
+func TestPasswordResetService_RejectsExpiredTokens(t *testing.T) {
+	ctx := context.Background()
+	users := &resetUsers{bulkMemberUsers{users: []*domain.User{{ID: 1, Username: "alice", Email: "alice@example.com", Status: "active"}}}}
+	tokens := &memoryResetTokens{}
+	mailer := &fakeMailer{enabled: true}
+	svc := service.NewPasswordResetService(users, tokens, service.NewEmailNotifier(mailer, nil), "", 0)
+
+	require.NoError(t, svc.RequestReset(ctx, "alice@example.com"))
+	match := resetTokenPattern.FindStringSubmatch(mailer.sent["alice@example.com"])
+	require.NotNil(t, match)
+	assert.Contains(t, mailer.sent["alice@example.com"], "http://localhost:3000/reset-password?token=")
+
+	tokens.tokens[0].ExpiresAt = time.Now().Add(-time.Second)
+	assert.ErrorIs(t, svc.ConfirmReset(ctx, match[1], "new-secret"), domain.ErrInvalidResetToken)
+
+	// 未配置邮件服务时不能申请重置
+	mailer.enabled = false
+	assert.ErrorIs(t, svc.RequestReset(ctx, "alice@example.com"), domain.ErrMailNotConfigured)
+}
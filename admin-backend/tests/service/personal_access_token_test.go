@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeAccessTokenRepository 内存中的个人访问令牌仓储
+type fakeAccessTokenRepository struct {
+	tokens  map[uint64]*domain.PersonalAccessToken
+	nextID  uint64
+	touches int
+}
+
+func newFakeAccessTokenRepository() *fakeAccessTokenRepository {
+	return &fakeAccessTokenRepository{tokens: make(map[uint64]*domain.PersonalAccessToken)}
+}
+
+func (r *fakeAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	r.nextID++
+	token.ID = r.nextID
+	token.CreatedAt = time.Now()
+	stored := *token
+	r.tokens[token.ID] = &stored
+	return nil
+}
+
+func (r *fakeAccessTokenRepository) GetByID(ctx context.Context, id uint64) (*domain.PersonalAccessToken, error) {
+	token, ok := r.tokens[id]
+	if !ok {
+		return nil, domain.ErrAccessTokenNotFound
+	}
+	copied := *token
+	return &copied, nil
+}
+
+func (r *fakeAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrAccessTokenNotFound
+}
+
+func (r *fakeAccessTokenRepository) GetByUserID(ctx context.Context, userID uint64) ([]*domain.PersonalAccessToken, error) {
+	var tokens []*domain.PersonalAccessToken
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *fakeAccessTokenRepository) CountByUserID(ctx context.Context, userID uint64) (int64, error) {
+	tokens, _ := r.GetByUserID(ctx, userID)
+	return int64(len(tokens)), nil
+}
+
+func (r *fakeAccessTokenRepository) UpdateLastUsed(ctx context.Context, id uint64, usedAt time.Time, ip string) error {
+	r.touches++
+	r.tokens[id].LastUsedAt = &usedAt
+	r.tokens[id].LastUsedIP = ip
+	return nil
+}
+
+func (r *fakeAccessTokenRepository) Delete(ctx context.Context, id uint64) error {
+	delete(r.tokens, id)
+	return nil
+}
+
+func TestPersonalAccessToken_CreateAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeAccessTokenRepository()
+	svc := service.NewPersonalAccessTokenService(repo)
+
+	token, plain, err := svc.Create(ctx, 7, domain.PersonalAccessTokenParams{Name: " CI sync "})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(plain, domain.PersonalAccessTokenPrefix))
+	assert.Equal(t, "CI sync", token.Name)
+	assert.Equal(t, plain[:len(token.TokenPrefix)], token.TokenPrefix)
+	assert.NotContains(t, token.TokenHash, plain[len(domain.PersonalAccessTokenPrefix):], "只保存令牌的哈希值")
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 90), token.ExpiresAt, time.Minute)
+
+	authenticated, err := svc.Authenticate(ctx, plain, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), authenticated.UserID)
+	require.NotNil(t, repo.tokens[token.ID].LastUsedAt)
+	assert.Equal(t, "10.0.0.1", repo.tokens[token.ID].LastUsedIP)
+
+	// 短时间内同一来源重复使用时不重复写入
+	_, err = svc.Authenticate(ctx, plain, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.touches)
+
+	_, err = svc.Authenticate(ctx, plain+"x", "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	// 过期令牌
+	repo.tokens[token.ID].ExpiresAt = time.Now().Add(-time.Second)
+	_, err = svc.Authenticate(ctx, plain, "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrAccessTokenExpired)
+}
+
+func TestPersonalAccessToken_RevokeOnlyOwnTokens(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeAccessTokenRepository()
+	svc := service.NewPersonalAccessTokenService(repo)
+
+	token, plain, err := svc.Create(ctx, 1, domain.PersonalAccessTokenParams{Name: "deploy", ExpiresInDays: 1000})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 365), token.ExpiresAt, time.Minute, "有效期不超过一年")
+
+	assert.ErrorIs(t, svc.Revoke(ctx, 2, token.ID), domain.ErrAccessTokenNotFound)
+	require.NoError(t, svc.Revoke(ctx, 1, token.ID))
+
+	_, err = svc.Authenticate(ctx, plain, "10.0.0.1")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestPersonalAccessToken_Limit(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewPersonalAccessTokenService(newFakeAccessTokenRepository())
+
+	_, _, err := svc.Create(ctx, 1, domain.PersonalAccessTokenParams{Name: "  "})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+
+	for i := 0; i < 20; i++ {
+		_, _, err := svc.Create(ctx, 1, domain.PersonalAccessTokenParams{Name: "script"})
+		require.NoError(t, err)
+	}
+	_, _, err = svc.Create(ctx, 1, domain.PersonalAccessTokenParams{Name: "script"})
+	assert.ErrorIs(t, err, domain.ErrAccessTokenLimit)
+}
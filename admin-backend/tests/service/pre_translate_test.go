@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// preTranslateProjects 任意项目都存在
+type preTranslateProjects struct {
+	domain.ProjectRepository
+}
+
+func (preTranslateProjects) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	return &domain.Project{ID: id}, nil
+}
+
+// preTranslateLanguages en 为默认语言
+type preTranslateLanguages struct {
+	domain.LanguageRepository
+}
+
+func (preTranslateLanguages) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	return []*domain.Language{
+		{ID: 1, Code: "en", Status: "active", IsDefault: true},
+		{ID: 2, Code: "de", Status: "active"},
+		{ID: 3, Code: "fr", Status: "active"},
+	}, nil
+}
+
+// preTranslateRepositories 内存中的翻译，只实现预翻译用到的方法
+type preTranslateRepositories struct {
+	domain.TranslationRepository
+	values     map[string]map[string]string // 键名 -> 语言代码 -> 有效翻译
+	deprecated map[string]map[string]string
+	types      map[string]domain.KeyValueType
+}
+
+func (r *preTranslateRepositories) GetValuesByStatus(ctx context.Context, projectID uint64, filter domain.TranslationStatusFilter) (map[string]map[string]string, error) {
+	values := make(map[string]map[string]string)
+	merge := func(source map[string]map[string]string) {
+		for key, byLanguage := range source {
+			if values[key] == nil {
+				values[key] = make(map[string]string)
+			}
+			for code, value := range byLanguage {
+				values[key][code] = value
+			}
+		}
+	}
+	merge(r.values)
+	for _, status := range filter.Statuses {
+		if status == "deprecated" {
+			merge(r.deprecated)
+		}
+	}
+	return values, nil
+}
+
+func (r *preTranslateRepositories) GetKeyValueTypes(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyValueType, error) {
+	return r.types, nil
+}
+
+// preTranslateWriter 记录每次写入的翻译
+type preTranslateWriter struct {
+	domain.TranslationService
+	upserted [][]domain.TranslationInput
+}
+
+func (w *preTranslateWriter) UpsertBatch(ctx context.Context, inputs []domain.TranslationInput) error {
+	w.upserted = append(w.upserted, inputs)
+	return nil
+}
+
+// batchMachineTranslation 记录每次调用的文本，第 failOn 次调用（从 1 开始）返回错误
+type batchMachineTranslation struct {
+	domain.MachineTranslationService
+	calls  [][]string
+	failOn int
+}
+
+func (m *batchMachineTranslation) TranslateBatch(ctx context.Context, texts []string, sourceLang, targetLang string) ([]*domain.MachineTranslationResult, error) {
+	m.calls = append(m.calls, texts)
+	if len(m.calls) == m.failOn {
+		return nil, errors.New("429 too many requests")
+	}
+	results := make([]*domain.MachineTranslationResult, 0, len(texts))
+	for _, text := range texts {
+		results = append(results, &domain.MachineTranslationResult{TranslatedText: targetLang + ":" + text})
+	}
+	return results, nil
+}
+
+func newPreTranslateRepositories() *preTranslateRepositories {
+	return &preTranslateRepositories{
+		values: map[string]map[string]string{
+			"a":      {"en": "A", "de": "A-de"},
+			"b":      {"en": "B"},
+			"c":      {"en": "C"},
+			"d":      {"en": "D"},
+			"e":      {"en": "E"},
+			"config": {"en": "{}"},
+			"orphan": {"fr": "Orphan"},
+		},
+		deprecated: map[string]map[string]string{
+			"legacy": {"en": "Legacy", "de": "Alt"},
+		},
+		types: map[string]domain.KeyValueType{
+			"config": {ValueType: domain.ValueTypeJSON},
+		},
+	}
+}
+
+func TestPreTranslate_FillsMissingCellsInBatches(t *testing.T) {
+	repos, writer := newPreTranslateRepositories(), &preTranslateWriter{}
+	mt := &batchMachineTranslation{failOn: 2}
+	svc := service.NewAutoTranslateService(mt, writer, repos, preTranslateProjects{}, preTranslateLanguages{}, 2, 0)
+
+	result, err := svc.PreTranslate(context.Background(), 1, domain.PreTranslateParams{TargetLanguages: []string{"de"}})
+	require.NoError(t, err)
+	assert.Equal(t, "en", result.SourceLanguage)
+
+	// de 缺少 b、c、d、e、config、orphan；已废弃的 legacy 不覆盖
+	require.Len(t, result.Languages, 1)
+	de := result.Languages[0]
+	assert.Equal(t, 6, de.Missing)
+	assert.Equal(t, 2, de.Skipped, "json 类型和源语言没有文本的键跳过")
+	assert.Equal(t, [][]string{{"B", "C"}, {"D", "E"}}, mt.calls, "每批最多 2 条")
+
+	// 第二批调用失败，第一批已写入
+	assert.Equal(t, []string{"b", "c"}, de.FilledKeys)
+	assert.Equal(t, []string{"d", "e"}, de.FailedKeys)
+	assert.Equal(t, []string{"429 too many requests"}, de.Errors)
+	assert.Equal(t, 2, result.Filled)
+	assert.Equal(t, 2, result.Failed)
+	require.Len(t, writer.upserted, 1)
+	assert.Equal(t, domain.TranslationInput{ProjectID: 1, LanguageID: 2, KeyName: "b", Value: "de:B"}, writer.upserted[0][0])
+}
+
+func TestPreTranslate_OnlySelectedLanguages(t *testing.T) {
+	repos, writer := newPreTranslateRepositories(), &preTranslateWriter{}
+	mt := &batchMachineTranslation{}
+	svc := service.NewAutoTranslateService(mt, writer, repos, preTranslateProjects{}, preTranslateLanguages{}, 50, 0)
+
+	result, err := svc.PreTranslate(context.Background(), 1, domain.PreTranslateParams{TargetLanguages: []string{"fr", "fr"}})
+	require.NoError(t, err)
+	require.Len(t, result.Languages, 1)
+	assert.Equal(t, "fr", result.Languages[0].LanguageCode)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, result.Languages[0].FilledKeys)
+	assert.Len(t, mt.calls, 1)
+
+	_, err = svc.PreTranslate(context.Background(), 1, domain.PreTranslateParams{TargetLanguages: []string{"en"}})
+	assert.ErrorIs(t, err, domain.ErrIsSourceLanguage)
+	_, err = svc.PreTranslate(context.Background(), 1, domain.PreTranslateParams{})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestPreTranslate_StopsWhenCancelled(t *testing.T) {
+	repos, writer := newPreTranslateRepositories(), &preTranslateWriter{}
+	mt := &batchMachineTranslation{}
+	svc := service.NewAutoTranslateService(mt, writer, repos, preTranslateProjects{}, preTranslateLanguages{}, 1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := svc.PreTranslate(ctx, 1, domain.PreTranslateParams{TargetLanguages: []string{"de"}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, mt.calls, 1, "等待间隔时请求取消，不再调用服务商")
+}
@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeActivityProjectRepository 记录写入的活动时间，可以模拟写入失败
+type fakeActivityProjectRepository struct {
+	domain.ProjectRepository
+	err     error
+	flushes [][]*domain.ProjectActivity
+}
+
+func (r *fakeActivityProjectRepository) RecordActivity(ctx context.Context, activities []*domain.ProjectActivity) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.flushes = append(r.flushes, activities)
+	return nil
+}
+
+func TestProjectActivityService_FlushMergesActivity(t *testing.T) {
+	repo := &fakeActivityProjectRepository{}
+	activity := service.NewProjectActivityService(repo, nil, nil, 90, time.Minute, nil)
+	ctx := context.Background()
+
+	changedAt := time.Now().Add(-time.Minute)
+	activity.RecordAccess(1)
+	activity.RecordAccess(1)
+	require.NoError(t, activity.HandleEvent(ctx, domain.Event{Type: domain.EventTranslationUpdated, ProjectID: 1, OccurredAt: changedAt}))
+	require.NoError(t, activity.HandleEvent(ctx, domain.Event{Type: domain.EventImportCompleted, ProjectID: 1, OccurredAt: changedAt.Add(-time.Hour)}))
+	activity.RecordAccess(0) // 未确定项目的访问不记录
+
+	require.NoError(t, activity.Flush(ctx))
+	require.Len(t, repo.flushes, 1)
+	require.Len(t, repo.flushes[0], 1)
+	assert.Equal(t, uint64(1), repo.flushes[0][0].ProjectID)
+	assert.Equal(t, changedAt, repo.flushes[0][0].LastChangedAt) // 保留最新的变更时间
+	assert.False(t, repo.flushes[0][0].LastAccessedAt.IsZero())
+
+	// 没有新的活动时不写入数据库
+	require.NoError(t, activity.Flush(ctx))
+	assert.Len(t, repo.flushes, 1)
+}
+
+func TestProjectActivityService_FlushRetriesAfterFailure(t *testing.T) {
+	repo := &fakeActivityProjectRepository{err: errors.New("database unavailable")}
+	activity := service.NewProjectActivityService(repo, nil, nil, 90, time.Minute, nil)
+	ctx := context.Background()
+
+	activity.RecordAccess(2)
+	assert.Error(t, activity.Flush(ctx))
+
+	// 写入失败的活动时间保留到下次写入
+	repo.err = nil
+	require.NoError(t, activity.Flush(ctx))
+	require.Len(t, repo.flushes, 1)
+	assert.Equal(t, uint64(2), repo.flushes[0][0].ProjectID)
+}
+
+func TestProjectActivityService_RejectsInvalidStaleDays(t *testing.T) {
+	activity := service.NewProjectActivityService(&fakeActivityProjectRepository{}, nil, nil, 90, time.Minute, nil)
+
+	_, err := activity.GetStaleProjects(context.Background(), domain.StaleProjectParams{Days: -1, Limit: 10})
+	assert.ErrorIs(t, err, domain.ErrInvalidStaleDays)
+}
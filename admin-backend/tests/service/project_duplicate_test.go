@@ -0,0 +1,287 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// duplicateProjects 内存中的项目
+type duplicateProjects struct {
+	domain.ProjectRepository
+	projects map[uint64]*domain.Project
+}
+
+func (r *duplicateProjects) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	if project, ok := r.projects[id]; ok {
+		return project, nil
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+// duplicateProjectService 创建项目，新项目的ID从 100 开始
+type duplicateProjectService struct {
+	domain.ProjectService
+	repo *duplicateProjects
+}
+
+func (s duplicateProjectService) Create(ctx context.Context, params domain.CreateProjectParams, userID uint64) (*domain.Project, error) {
+	r := s.repo
+	for _, project := range r.projects {
+		if project.Slug == params.Slug {
+			return nil, domain.ErrSlugExists
+		}
+	}
+	project := &domain.Project{
+		ID:             uint64(100 + len(r.projects)),
+		Name:           params.Name,
+		Description:    params.Description,
+		Slug:           params.Slug,
+		OrganizationID: params.OrganizationID,
+		SourceLanguage: params.SourceLanguage,
+		CreatedBy:      userID,
+	}
+	r.projects[project.ID] = project
+	return project, nil
+}
+
+func (r *duplicateProjects) Update(ctx context.Context, project *domain.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+// duplicateTranslations 内存中的翻译
+type duplicateTranslations struct {
+	domain.TranslationRepository
+	translations []*domain.Translation
+}
+
+func (r *duplicateTranslations) project(projectID uint64) []*domain.Translation {
+	var translations []*domain.Translation
+	for _, translation := range r.translations {
+		if translation.ProjectID == projectID {
+			translations = append(translations, translation)
+		}
+	}
+	return translations
+}
+
+func (r *duplicateTranslations) CountByPrefix(ctx context.Context, projectID uint64, prefix string) (int64, error) {
+	return int64(len(r.project(projectID))), nil
+}
+
+func (r *duplicateTranslations) StreamByProject(ctx context.Context, projectID uint64, batchSize int, fn func([]*domain.Translation) error) error {
+	translations := r.project(projectID)
+	for start := 0; start < len(translations); start += batchSize {
+		if err := fn(translations[start:min(start+batchSize, len(translations))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *duplicateTranslations) CreateBatch(ctx context.Context, translations []*domain.Translation) error {
+	r.translations = append(r.translations, translations...)
+	return nil
+}
+
+// duplicateSettings 内存中的命名空间
+type duplicateSettings struct {
+	domain.NamespaceRepository
+	namespaces []*domain.Namespace
+}
+
+func (r *duplicateSettings) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.Namespace, error) {
+	var namespaces []*domain.Namespace
+	for _, namespace := range r.namespaces {
+		if namespace.ProjectID == projectID {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+func (r *duplicateSettings) Create(ctx context.Context, namespace *domain.Namespace) error {
+	namespace.ID = uint64(len(r.namespaces) + 1)
+	r.namespaces = append(r.namespaces, namespace)
+	return nil
+}
+
+type duplicateThresholds struct {
+	domain.ReleaseThresholdRepository
+	thresholds map[uint64][]*domain.ReleaseThreshold
+}
+
+func (r *duplicateThresholds) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ReleaseThreshold, error) {
+	return r.thresholds[projectID], nil
+}
+
+func (r *duplicateThresholds) ReplaceByProjectID(ctx context.Context, projectID uint64, thresholds []*domain.ReleaseThreshold) error {
+	r.thresholds[projectID] = thresholds
+	return nil
+}
+
+type duplicateMembers struct {
+	domain.ProjectMemberRepository
+	members []*domain.ProjectMember
+}
+
+func (r *duplicateMembers) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ProjectMember, error) {
+	var members []*domain.ProjectMember
+	for _, member := range r.members {
+		if member.ProjectID == projectID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (r *duplicateMembers) CreateOrRestore(ctx context.Context, member *domain.ProjectMember) error {
+	r.members = append(r.members, member)
+	return nil
+}
+
+// duplicateJobs 内存中的复制任务，后台复制时并发访问
+type duplicateJobs struct {
+	mu   sync.Mutex
+	jobs map[uint64]domain.ProjectDuplicateJob
+}
+
+func (r *duplicateJobs) Create(ctx context.Context, job *domain.ProjectDuplicateJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job.ID = uint64(len(r.jobs) + 1)
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *duplicateJobs) GetByID(ctx context.Context, id uint64) (*domain.ProjectDuplicateJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domain.ErrDuplicateJobNotFound
+	}
+	return &job, nil
+}
+
+func (r *duplicateJobs) Update(ctx context.Context, job *domain.ProjectDuplicateJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+// duplicateFixture 项目 1 有 3 条翻译、一个命名空间、一条发布门槛和两个成员
+type duplicateFixture struct {
+	projects     *duplicateProjects
+	translations *duplicateTranslations
+	namespaces   *duplicateSettings
+	thresholds   *duplicateThresholds
+	members      *duplicateMembers
+	jobs         *duplicateJobs
+}
+
+func newDuplicateFixture() *duplicateFixture {
+	return &duplicateFixture{
+		projects: &duplicateProjects{projects: map[uint64]*domain.Project{
+			1: {ID: 1, Name: "App", Slug: "app", Description: "Mobile app", ExportFileTemplate: "{locale}.json", OrganizationID: 3, SourceLanguage: "en"},
+		}},
+		translations: &duplicateTranslations{translations: []*domain.Translation{
+			{ID: 1, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Home", NamespaceID: 5, ReviewStatus: domain.ReviewStatusApproved},
+			{ID: 2, ProjectID: 1, KeyName: "home.title", LanguageID: 2, Value: "Startseite", NamespaceID: 5},
+			{ID: 3, ProjectID: 1, KeyName: "cta", LanguageID: 1, Value: "Buy"},
+		}},
+		namespaces: &duplicateSettings{namespaces: []*domain.Namespace{{ID: 5, ProjectID: 1, Name: "home"}}},
+		thresholds: &duplicateThresholds{thresholds: map[uint64][]*domain.ReleaseThreshold{
+			1: {{ProjectID: 1, LanguageID: 2, MinCompletion: 90}},
+		}},
+		members: &duplicateMembers{members: []*domain.ProjectMember{
+			{ProjectID: 1, UserID: 7, Role: "owner"},
+			{ProjectID: 1, UserID: 8, Role: "editor"},
+		}},
+		jobs: &duplicateJobs{jobs: map[uint64]domain.ProjectDuplicateJob{}},
+	}
+}
+
+func (f *duplicateFixture) service(syncLimit int) *service.ProjectDuplicateService {
+	return service.NewProjectDuplicateService(duplicateProjectService{repo: f.projects}, f.projects, f.translations, f.namespaces, f.thresholds,
+		f.members, f.jobs, noopAuditLogs{}, nil, nil, syncLimit, nil)
+}
+
+func TestProjectDuplicateService_CopiesSmallProjectInline(t *testing.T) {
+	ctx := context.Background()
+	f := newDuplicateFixture()
+	svc := f.service(10)
+
+	job, err := svc.Duplicate(ctx, 1, domain.ProjectDuplicateParams{Name: "App copy", Slug: "app-copy"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectDuplicateStatusCompleted, job.Status)
+	assert.Equal(t, int64(3), job.Total)
+	assert.Equal(t, int64(3), job.Copied)
+	assert.NotNil(t, job.FinishedAt)
+
+	project := f.projects.projects[job.ProjectID]
+	assert.Equal(t, "app-copy", project.Slug)
+	assert.Equal(t, "Mobile app", project.Description)
+	assert.Equal(t, "{locale}.json", project.ExportFileTemplate)
+	assert.Equal(t, uint64(3), project.OrganizationID)
+	assert.Equal(t, "en", project.SourceLanguage)
+
+	copied := f.translations.project(job.ProjectID)
+	require.Len(t, copied, 3)
+	namespaces, _ := f.namespaces.GetByProjectID(ctx, job.ProjectID)
+	require.Len(t, namespaces, 1)
+	assert.Equal(t, namespaces[0].ID, copied[0].NamespaceID, "命名空间按名称对应到新项目")
+	assert.Equal(t, domain.ReviewStatusApproved, copied[0].ReviewStatus)
+	assert.Zero(t, copied[2].NamespaceID)
+	assert.Equal(t, 90, f.thresholds.thresholds[job.ProjectID][0].MinCompletion)
+
+	// 未指定 include_members 时只有发起人成为 owner
+	members, _ := f.members.GetByProjectID(ctx, job.ProjectID)
+	require.Len(t, members, 1)
+	assert.Equal(t, uint64(7), members[0].UserID)
+	assert.Equal(t, "owner", members[0].Role)
+
+	_, err = svc.Duplicate(ctx, 1, domain.ProjectDuplicateParams{Name: "Again", Slug: "app-copy"}, 7)
+	assert.ErrorIs(t, err, domain.ErrSlugExists)
+}
+
+func TestProjectDuplicateService_CopiesLargeProjectInBackground(t *testing.T) {
+	ctx := context.Background()
+	f := newDuplicateFixture()
+	svc := f.service(2)
+
+	job, err := svc.Duplicate(ctx, 1, domain.ProjectDuplicateParams{Name: "App copy", Slug: "app-copy", IncludeMembers: true}, 9)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectDuplicateStatusRunning, job.Status)
+	assert.Equal(t, int64(3), job.Total)
+
+	var finished *domain.ProjectDuplicateJob
+	require.Eventually(t, func() bool {
+		finished, err = svc.GetJob(ctx, 1, job.ID)
+		return err == nil && finished.Status != domain.ProjectDuplicateStatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, domain.ProjectDuplicateStatusCompleted, finished.Status)
+	assert.Equal(t, int64(3), finished.Copied)
+	require.NoError(t, svc.Stop(ctx))
+	assert.Len(t, f.translations.project(job.ProjectID), 3)
+
+	roles := map[uint64]string{}
+	members, _ := f.members.GetByProjectID(ctx, job.ProjectID)
+	for _, member := range members {
+		roles[member.UserID] = member.Role
+	}
+	assert.Equal(t, map[uint64]string{7: "owner", 8: "editor", 9: "owner"}, roles)
+
+	// 任务只能通过发起复制的项目查询
+	_, err = svc.GetJob(ctx, job.ProjectID, job.ID)
+	assert.ErrorIs(t, err, domain.ErrDuplicateJobNotFound)
+}
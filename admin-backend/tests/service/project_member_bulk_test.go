@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// bulkMemberUsers 内存中的用户，只实现批量添加用到的方法
+type bulkMemberUsers struct {
+	domain.UserRepository
+	users []*domain.User
+}
+
+func (r *bulkMemberUsers) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.User, error) {
+	var result []*domain.User
+	for _, user := range r.users {
+		for _, id := range ids {
+			if user.ID == id {
+				result = append(result, user)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *bulkMemberUsers) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+// bulkMemberRepository 内存中的成员关系，failFor 中的用户写入失败
+type bulkMemberRepository struct {
+	domain.ProjectMemberRepository
+	members []*domain.ProjectMember
+	failFor uint64
+}
+
+func (r *bulkMemberRepository) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ProjectMember, error) {
+	return r.members, nil
+}
+
+func (r *bulkMemberRepository) CreateOrRestore(ctx context.Context, member *domain.ProjectMember) error {
+	if member.UserID == r.failFor {
+		return errors.New("deadlock")
+	}
+	r.members = append(r.members, member)
+	return nil
+}
+
+func newBulkMemberService(mailer domain.Mailer) (*service.ProjectMemberService, *bulkMemberRepository) {
+	users := &bulkMemberUsers{users: []*domain.User{
+		{ID: 1, Username: "owner", Email: "owner@example.com", Status: "active"},
+		{ID: 2, Username: "alice", Email: "alice@example.com", Status: "active"},
+		{ID: 3, Username: "bob", Email: "bob@example.com", Status: "active"},
+		{ID: 4, Username: "carol", Email: "carol@example.com", Status: "disabled"},
+		{ID: 5, Username: "dave", Email: "dave@example.com", Status: "active"},
+	}}
+	members := &bulkMemberRepository{members: []*domain.ProjectMember{{ProjectID: 1, UserID: 1, Role: "owner"}}}
+	return service.NewProjectMemberService(members, users, preTranslateProjects{}, nil, service.NewEmailNotifier(mailer, nil)), members
+}
+
+func TestProjectMemberService_BulkAddMembers(t *testing.T) {
+	mailer := &fakeMailer{enabled: true, failFor: "bob@example.com"}
+	svc, members := newBulkMemberService(mailer)
+	members.failFor = 5
+
+	result, err := svc.BulkAddMembers(context.Background(), 1, domain.BulkAddMembersParams{
+		Members: []domain.BulkMemberEntry{
+			{UserID: 2, Role: "editor"},
+			{Email: " BOB@example.com ", Role: "Viewer"},
+			{UserID: 1, Role: "viewer"},
+			{Email: "alice@example.com", Role: "viewer"},
+			{UserID: 4, Role: "viewer"},
+			{UserID: 99, Role: "viewer"},
+			{Email: "nobody@example.com", Role: "viewer"},
+			{UserID: 5, Role: "admin"},
+			{UserID: 5},
+			{UserID: 5, Role: "viewer"},
+			{Role: "viewer"},
+		},
+		Notify: true,
+	}, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Added)
+	assert.Equal(t, 1, result.Existing)
+	assert.Equal(t, 8, result.Failed)
+	assert.Equal(t, 1, result.Emailed)
+	assert.Equal(t, 1, result.EmailFailed)
+	require.Len(t, result.Results, 11)
+
+	statuses := make([]string, 0, len(result.Results))
+	for _, item := range result.Results {
+		statuses = append(statuses, item.Status)
+	}
+	assert.Equal(t, []string{"added", "added", "existing", "failed", "failed", "failed", "failed", "failed", "failed", "failed", "failed"}, statuses)
+
+	// 按邮箱添加时返回用户信息，角色忽略大小写
+	assert.Equal(t, domain.BulkMemberResult{
+		Index: 2, UserID: 3, Username: "bob", Email: "bob@example.com", Role: "viewer",
+		Status: "added", EmailStatus: "failed",
+	}, result.Results[1])
+	assert.Equal(t, "sent", result.Results[0].EmailStatus)
+	assert.Contains(t, mailer.sent["alice@example.com"], "角色：editor")
+	assert.Equal(t, "用户已是项目成员（角色：owner）", result.Results[2].Message)
+	assert.Equal(t, "与第 1 项重复", result.Results[3].Message)
+	assert.Equal(t, "用户已禁用", result.Results[4].Message)
+	assert.Equal(t, "用户不存在", result.Results[5].Message)
+	assert.Equal(t, "用户不存在", result.Results[6].Message)
+	assert.Equal(t, "无效的角色：admin", result.Results[7].Message)
+	assert.Equal(t, "未指定角色", result.Results[8].Message)
+	assert.Equal(t, "添加成员失败", result.Results[9].Message)
+	assert.Equal(t, "未指定用户ID或邮箱", result.Results[10].Message)
+}
+
+func TestProjectMemberService_BulkAddMembersValidation(t *testing.T) {
+	svc, _ := newBulkMemberService(&fakeMailer{})
+	ctx := context.Background()
+
+	_, err := svc.BulkAddMembers(ctx, 1, domain.BulkAddMembersParams{}, 1)
+	assert.ErrorIs(t, err, domain.ErrInvalidBulkMembers)
+
+	_, err = svc.BulkAddMembers(ctx, 1, domain.BulkAddMembersParams{
+		Members: []domain.BulkMemberEntry{{UserID: 2, Role: "viewer"}},
+		Notify:  true,
+	}, 1)
+	assert.ErrorIs(t, err, domain.ErrMailNotConfigured)
+}
+
+func TestProjectMemberService_ImportMembers(t *testing.T) {
+	svc, _ := newBulkMemberService(&fakeMailer{})
+	ctx := context.Background()
+
+	csv := "User,Role\n2,editor\n,\nbob@example.com,\n"
+	result, err := svc.ImportMembers(ctx, 1, domain.MemberImportParams{Data: []byte(csv), DefaultRole: "viewer"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Added)
+	require.Len(t, result.Results, 2)
+	// 序号为表格中的行号，空行跳过
+	assert.Equal(t, 2, result.Results[0].Index)
+	assert.Equal(t, "editor", result.Results[0].Role)
+	assert.Equal(t, 4, result.Results[1].Index)
+	assert.Equal(t, "viewer", result.Results[1].Role)
+
+	for name, data := range map[string]string{
+		"missing user column": "name,role\nalice,viewer\n",
+		"invalid user id":     "user_id,role\nabc,viewer\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.ImportMembers(ctx, 1, domain.MemberImportParams{Data: []byte(data)}, 1)
+			appErr, ok := domain.IsAppError(err)
+			require.True(t, ok)
+			assert.Equal(t, domain.ErrInvalidSpreadsheet.Code, appErr.Code)
+		})
+	}
+}
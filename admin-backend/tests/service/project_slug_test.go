@@ -0,0 +1,32 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestValidateProjectSlug(t *testing.T) {
+	valid := []string{"web", "mobile-app", "2024-site", "docs_v2"}
+	for _, s := range valid {
+		assert.NoError(t, service.ValidateProjectSlug(s), s)
+	}
+
+	invalid := []string{
+		"",
+		"42",      // 与项目ID混淆
+		"Web",     // 大写
+		"-web",    // 首尾连字符
+		"web-",    // 首尾连字符
+		"web app", // 空格
+		"网页",      // 非 ASCII
+		strings.Repeat("a", 101),
+	}
+	for _, s := range invalid {
+		assert.ErrorIs(t, service.ValidateProjectSlug(s), domain.ErrInvalidSlug, s)
+	}
+}
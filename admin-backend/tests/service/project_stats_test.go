@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// statsTranslations 返回固定聚合计数的翻译仓储
+type statsTranslations struct {
+	domain.TranslationRepository
+	totalKeys int64
+	counts    []*domain.LanguageTranslationCount
+}
+
+func (r *statsTranslations) CountByLanguage(ctx context.Context, projectID uint64) (int64, []*domain.LanguageTranslationCount, error) {
+	return r.totalKeys, r.counts, nil
+}
+
+// statsLanguages 返回固定语言列表的语言仓储
+type statsLanguages struct {
+	domain.LanguageRepository
+}
+
+func (statsLanguages) GetAll(ctx context.Context) ([]*domain.Language, error) {
+	return sourceLanguageList, nil
+}
+
+func TestTranslationService_GetProjectStats(t *testing.T) {
+	ctx := context.Background()
+	projects := &sourceProjects{projects: map[uint64]*domain.Project{1: {ID: 1}}}
+	repo := &statsTranslations{totalKeys: 8, counts: []*domain.LanguageTranslationCount{
+		{LanguageID: 1, Translated: 8},
+		{LanguageID: 2, Translated: 5, Outdated: 2},
+		{LanguageID: 4, Translated: 3}, // 未启用的语言不统计
+	}}
+	svc := service.NewTranslationService(repo, projects, statsLanguages{}, nil, nil, nil, nil)
+
+	stats, err := svc.GetProjectStats(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 8, stats.TotalKeys)
+	assert.Equal(t, []domain.LanguageStats{
+		{LanguageCode: "en", Translated: 8, Percentage: 100},
+		{LanguageCode: "de", Translated: 5, Outdated: 2, Untranslated: 1, Percentage: 62.5},
+		{LanguageCode: "fr", Untranslated: 8, Percentage: 0},
+	}, stats.Languages)
+
+	_, err = svc.GetProjectStats(ctx, 2)
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+}
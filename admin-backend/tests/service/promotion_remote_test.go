@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// promotionProjects 任意项目都存在
+type promotionProjects struct {
+	domain.ProjectRepository
+}
+
+func (promotionProjects) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	return &domain.Project{ID: id}, nil
+}
+
+func newRemotePromotionService(options service.PromotionRemoteOptions) *service.PromotionService {
+	options.Timeout = 5 * time.Second
+	return service.NewPromotionService(nil, promotionProjects{}, nil, nil, nil, nil, nil, "key", options)
+}
+
+func TestPromotionService_RemoteSourceRestrictions(t *testing.T) {
+	ctx := context.Background()
+	requests := 0
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer remote.Close()
+	params := domain.PromotionSourceParams{RemoteURL: remote.URL, APIKey: "secret", SourceProject: "web"}
+
+	sourceError := func(t *testing.T, err error) string {
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, "unexpected error: %v", err)
+		assert.Equal(t, domain.ErrPromotionSourceFailed.Code, appErr.Code)
+		return appErr.Details
+	}
+
+	// 未配置允许的主机时不发起请求
+	_, err := newRemotePromotionService(service.PromotionRemoteOptions{}).CreateDiff(ctx, 1, params, 1)
+	assert.Contains(t, sourceError(t, err), "PROMOTION_ALLOWED_HOSTS")
+	_, err = newRemotePromotionService(service.PromotionRemoteOptions{AllowedHosts: []string{"127.0.0.1:1"}}).CreateDiff(ctx, 1, params, 1)
+	sourceError(t, err)
+	assert.Zero(t, requests)
+
+	// 主机名允许但解析为回环地址时拒绝连接，原因不返回给调用方
+	_, err = newRemotePromotionService(service.PromotionRemoteOptions{AllowedHosts: []string{"127.0.0.1"}}).CreateDiff(ctx, 1, params, 1)
+	details := sourceError(t, err)
+	assert.Zero(t, requests)
+	assert.NotContains(t, details, "127.0.0.1")
+
+	// 允许内网地址后可以连接，源实例的状态码不返回给调用方
+	_, err = newRemotePromotionService(service.PromotionRemoteOptions{
+		AllowedHosts:         []string{strings.TrimPrefix(remote.URL, "http://")},
+		AllowPrivateNetworks: true,
+	}).CreateDiff(ctx, 1, params, 1)
+	assert.Equal(t, details, sourceError(t, err))
+	assert.Equal(t, 1, requests)
+	assert.NotContains(t, details, "401")
+}
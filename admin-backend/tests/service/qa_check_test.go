@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestCheckTranslation(t *testing.T) {
+	const source = "Hello <b>{{name}}</b>, you have %d new messages in {folder}. "
+
+	for name, tc := range map[string]struct {
+		valueType string
+		value     string
+		source    string
+	}{
+		"consistent":        {valueType: domain.ValueTypeString, value: "Hallo <b>{{ name }}</b>, {folder} enthält %d neue Nachrichten. ", source: source},
+		"percent literal":   {valueType: domain.ValueTypeString, value: "100%% sicher, 50% off", source: "100%% sure, 50% off"},
+		"positional printf": {valueType: domain.ValueTypeString, value: "%2$s von %1$s", source: "%1$s of %2$s"},
+		"short source":      {valueType: domain.ValueTypeString, value: "Einverstanden", source: "Okay"},
+		"void tag":          {valueType: domain.ValueTypeString, value: "Zeile<br>zwei", source: "Line<br/>two"},
+		"source itself":     {valueType: domain.ValueTypeString, value: "Trailing "},
+		"json":              {valueType: domain.ValueTypeJSON, value: `{"a": 1}`, source: `{"a": 2} `},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Empty(t, service.CheckTranslation(tc.valueType, tc.value, tc.source, "de", service.DefaultQAMaxLengthRatio))
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		valueType  string
+		value      string
+		source     string
+		issueType  string
+		message    string
+		suggestion string
+	}{
+		"mustache renamed":   {valueType: domain.ValueTypeString, value: "Hallo <b>{{nom}}</b>, {folder} enthält %d neue Nachrichten. ", source: source, issueType: domain.QAIssuePlaceholderMismatch, message: "缺少占位符 {{name}}；多出占位符 {{nom}}"},
+		"printf lost":        {valueType: domain.ValueTypeString, value: "Hallo <b>{{name}}</b>, {folder} enthält neue Nachrichten. ", source: source, issueType: domain.QAIssuePlaceholderMismatch, message: "缺少占位符 %d"},
+		"icu index":          {valueType: domain.ValueTypeString, value: "Datei {1}", source: "File {0}", issueType: domain.QAIssuePlaceholderMismatch, message: "缺少占位符 0；多出占位符 1"},
+		"unclosed tag":       {valueType: domain.ValueTypeString, value: "Hallo <b>{{name}}, {folder} enthält %d neue Nachrichten. ", issueType: domain.QAIssueMarkupInvalid, message: "<b> 没有关闭"},
+		"tag changed":        {valueType: domain.ValueTypeString, value: "Hallo <i>{{name}}</i>, {folder} enthält %d neue Nachrichten. ", source: source, issueType: domain.QAIssueTagMismatch, message: "缺少标签 <b>；多出标签 <i>"},
+		"trailing lost":      {valueType: domain.ValueTypeString, value: "Hallo <b>{{name}}</b>, {folder} enthält %d neue Nachrichten.", source: source, issueType: domain.QAIssueTrailingWhitespace, message: "源语言末尾有空白，译文没有", suggestion: "Hallo <b>{{name}}</b>, {folder} enthält %d neue Nachrichten. "},
+		"trailing extra":     {valueType: domain.ValueTypeMarkdown, value: "Speichern\n", source: "Save", issueType: domain.QAIssueTrailingWhitespace, message: "译文末尾有多余的空白", suggestion: "Speichern"},
+		"too long":           {valueType: domain.ValueTypeString, value: "Die Datei konnte wegen eines unbekannten Fehlers nicht gespeichert werden", source: "Could not save the file", issueType: domain.QAIssueLengthExceeded, message: "译文 73 个字符，超过原文（23 个字符）的 2 倍"},
+		"markdown printf":    {valueType: domain.ValueTypeMarkdown, value: "**Gespeichert**", source: "**Saved** %s", issueType: domain.QAIssuePlaceholderMismatch, message: "缺少占位符 %s"},
+		"html still checked": {valueType: domain.ValueTypeHTML, value: "<b>gras", issueType: domain.QAIssueMarkupInvalid, message: "<b> 没有关闭"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			issues := service.CheckTranslation(tc.valueType, tc.value, tc.source, "de", service.DefaultQAMaxLengthRatio)
+			require.Len(t, issues, 1)
+			assert.Equal(t, tc.issueType, issues[0].Type)
+			assert.Contains(t, issues[0].Message, tc.message)
+			assert.Equal(t, tc.suggestion, issues[0].Suggestion)
+		})
+	}
+
+	// 长度倍数不大于 0 时不检查长度
+	assert.Empty(t, service.CheckTranslation(domain.ValueTypeString, "Die Datei konnte wegen eines unbekannten Fehlers nicht gespeichert werden", "Could not save the file", "de", 0))
+}
@@ -0,0 +1,245 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// memoryReleases 内存中的发布版本，创建时冻结 values
+type memoryReleases struct {
+	domain.ReleaseRepository
+	releases map[uint64]*domain.Release
+	values   map[uint64]map[string]map[string]string
+	types    map[uint64]map[string]domain.KeyValueType
+	current  map[string]map[string]string
+}
+
+func newMemoryReleases(current map[string]map[string]string) *memoryReleases {
+	return &memoryReleases{
+		releases: map[uint64]*domain.Release{},
+		values:   map[uint64]map[string]map[string]string{},
+		types:    map[uint64]map[string]domain.KeyValueType{},
+		current:  current,
+	}
+}
+
+func (r *memoryReleases) Create(ctx context.Context, release *domain.Release) error {
+	release.ID = uint64(len(r.releases) + 1)
+	snapshot := make(map[string]map[string]string, len(r.current))
+	for key, langs := range r.current {
+		snapshot[key] = make(map[string]string, len(langs))
+		for lang, value := range langs {
+			snapshot[key][lang] = value
+			release.TranslationCount++
+		}
+	}
+	release.KeyCount = len(snapshot)
+	r.releases[release.ID] = release
+	r.values[release.ID] = snapshot
+	return nil
+}
+
+func (r *memoryReleases) GetByID(ctx context.Context, id uint64) (*domain.Release, error) {
+	if release, ok := r.releases[id]; ok {
+		return release, nil
+	}
+	return nil, domain.ErrReleaseNotFound
+}
+
+func (r *memoryReleases) GetByVersion(ctx context.Context, projectID uint64, version string) (*domain.Release, error) {
+	for _, release := range r.releases {
+		if release.ProjectID == projectID && release.Version == version {
+			return release, nil
+		}
+	}
+	return nil, domain.ErrReleaseNotFound
+}
+
+func (r *memoryReleases) GetValues(ctx context.Context, releaseID uint64) (map[string]map[string]string, error) {
+	return r.values[releaseID], nil
+}
+
+func (r *memoryReleases) GetValueTypes(ctx context.Context, releaseID uint64) (map[string]domain.KeyValueType, error) {
+	return r.types[releaseID], nil
+}
+
+// releaseGate 按 blocking 返回发布门槛检查结果
+type releaseGate struct {
+	domain.ReleaseGateService
+	blocking []string
+}
+
+func (g releaseGate) Ensure(ctx context.Context, projectID uint64, override bool) (*domain.ReleaseReadiness, error) {
+	readiness := &domain.ReleaseReadiness{Ready: len(g.blocking) == 0, Blocking: g.blocking}
+	if readiness.Ready {
+		return readiness, nil
+	}
+	if !override {
+		return readiness, domain.ErrReleaseBlocked
+	}
+	readiness.Overridden = true
+	return readiness, nil
+}
+
+// releaseExportTranslations 项目当前的翻译
+type releaseExportTranslations struct {
+	domain.TranslationRepository
+	values map[string]map[string]string
+}
+
+func (r *releaseExportTranslations) GetValuesByStatus(ctx context.Context, projectID uint64, filter domain.TranslationStatusFilter) (map[string]map[string]string, error) {
+	return r.values, nil
+}
+
+func (r *releaseExportTranslations) GetKeyValueTypes(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyValueType, error) {
+	return map[string]domain.KeyValueType{}, nil
+}
+
+func TestReleaseService_CreateFreezesCurrentTranslations(t *testing.T) {
+	ctx := context.Background()
+	current := map[string]map[string]string{"home.title": {"en": "Home", "de": "Startseite"}}
+	releases := newMemoryReleases(current)
+	svc := service.NewReleaseService(releases, preTranslateProjects{}, noopAuditLogs{}, releaseGate{}, nil, nil)
+
+	release, _, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: " v1.2.0 ", Description: "spring"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", release.Version)
+	assert.Equal(t, 1, release.KeyCount)
+	assert.Equal(t, 2, release.TranslationCount)
+	assert.Equal(t, uint64(7), release.CreatedBy)
+
+	// 版本标签不能重复
+	_, _, err = svc.Create(ctx, 1, domain.ReleaseParams{Version: "v1.2.0"}, 7)
+	assert.ErrorIs(t, err, domain.ErrReleaseExists)
+
+	// 之后修改翻译不影响已发布的版本
+	current["home.title"]["en"] = "Start"
+	translationService := service.NewTranslationService(&releaseExportTranslations{values: current}, preTranslateProjects{}, nil, nil, releases, nil, nil)
+	data, err := translationService.Export(ctx, 1, "json", domain.ExportOptions{Release: "v1.2.0"})
+	require.NoError(t, err)
+	var document map[string]map[string]string
+	require.NoError(t, json.Unmarshal(data, &document))
+	assert.Equal(t, "Home", document["home.title"]["en"])
+
+	data, err = translationService.Export(ctx, 1, "json", domain.ExportOptions{})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &document))
+	assert.Equal(t, "Start", document["home.title"]["en"])
+
+	// 发布版本的快照不能再按状态、命名空间或标签筛选
+	_, err = translationService.Export(ctx, 1, "json", domain.ExportOptions{Release: "v1.2.0", Namespace: "web"})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrInvalidRelease.Code, appErr.Code)
+	_, err = translationService.Export(ctx, 1, "json", domain.ExportOptions{Release: "v1.2.0", Tags: []string{"mobile"}})
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrInvalidRelease.Code, appErr.Code)
+
+	_, err = translationService.Export(ctx, 1, "json", domain.ExportOptions{Release: "v9"})
+	assert.ErrorIs(t, err, domain.ErrReleaseNotFound)
+}
+
+func TestReleaseService_CreateRejectsInvalidVersions(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewReleaseService(newMemoryReleases(nil), preTranslateProjects{}, noopAuditLogs{}, releaseGate{}, nil, nil)
+
+	for _, version := range []string{"", "  ", "-v1", "v1 beta", "v1/2"} {
+		_, _, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: version}, 1)
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, "version %q: unexpected error: %v", version, err)
+		assert.Equal(t, domain.ErrInvalidRelease.Code, appErr.Code, "version %q", version)
+	}
+}
+
+func TestReleaseService_CreateChecksReleaseGate(t *testing.T) {
+	ctx := context.Background()
+	releases := newMemoryReleases(map[string]map[string]string{"home.title": {"en": "Home"}})
+	svc := service.NewReleaseService(releases, preTranslateProjects{}, noopAuditLogs{}, releaseGate{blocking: []string{"de"}}, nil, nil)
+
+	// 未达标时不发布，返回检查结果
+	release, readiness, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: "v1"}, 1)
+	assert.ErrorIs(t, err, domain.ErrReleaseBlocked)
+	assert.Nil(t, release)
+	require.NotNil(t, readiness)
+	assert.Equal(t, []string{"de"}, readiness.Blocking)
+	assert.Empty(t, releases.releases)
+
+	// 覆盖门槛后发布并记录
+	release, readiness, err = svc.Create(ctx, 1, domain.ReleaseParams{Version: "v1", Override: true}, 1)
+	require.NoError(t, err)
+	assert.True(t, readiness.Overridden)
+	assert.True(t, release.GateOverridden)
+}
+
+func TestReleaseService_Rollout(t *testing.T) {
+	ctx := context.Background()
+	releases := newMemoryReleases(map[string]map[string]string{"home.title": {"en": "Home"}})
+	svc := service.NewReleaseService(releases, preTranslateProjects{}, noopAuditLogs{}, releaseGate{}, nil, nil)
+
+	// 第一个版本不能灰度发布
+	_, _, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: "v1", RolloutPercentage: 10}, 7)
+	assertInvalidRelease(t, err)
+	_, _, err = svc.Create(ctx, 1, domain.ReleaseParams{Version: "v1", RolloutPercentage: 100}, 7)
+	assertInvalidRelease(t, err)
+
+	full, _, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: "v1"}, 7)
+	require.NoError(t, err)
+	assert.Empty(t, full.RolloutStatus)
+	_, err = svc.AdvanceRollout(ctx, 1, full.ID, 50, 7)
+	assert.ErrorIs(t, err, domain.ErrRolloutInactive)
+
+	canary, _, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: "v2", RolloutPercentage: 10}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RolloutStatusActive, canary.RolloutStatus)
+	assert.Equal(t, 10, canary.RolloutPercentage)
+
+	// 灰度进行中不能再发布
+	_, _, err = svc.Create(ctx, 1, domain.ReleaseParams{Version: "v3"}, 7)
+	assert.ErrorIs(t, err, domain.ErrRolloutActive)
+
+	// 百分比只能提高，达到 100 时全量发布
+	_, err = svc.AdvanceRollout(ctx, 1, canary.ID, 10, 7)
+	assertInvalidRelease(t, err)
+	_, err = svc.AdvanceRollout(ctx, 2, canary.ID, 50, 7)
+	assert.ErrorIs(t, err, domain.ErrReleaseNotFound)
+	advanced, err := svc.AdvanceRollout(ctx, 1, canary.ID, 50, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 50, advanced.RolloutPercentage)
+	completed, err := svc.AdvanceRollout(ctx, 1, canary.ID, 100, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RolloutStatusCompleted, completed.RolloutStatus)
+	latest, err := releases.GetLatest(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", latest.Version)
+
+	// 中止后恢复分发全量版本，可以再发布
+	aborting, _, err := svc.Create(ctx, 1, domain.ReleaseParams{Version: "v3", RolloutPercentage: 20}, 7)
+	require.NoError(t, err)
+	aborted, err := svc.AbortRollout(ctx, 1, aborting.ID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RolloutStatusAborted, aborted.RolloutStatus)
+	_, err = svc.AbortRollout(ctx, 1, aborting.ID, 7)
+	assert.ErrorIs(t, err, domain.ErrRolloutInactive)
+	latest, err = releases.GetLatest(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", latest.Version)
+	_, _, err = svc.Create(ctx, 1, domain.ReleaseParams{Version: "v4"}, 7)
+	require.NoError(t, err)
+}
+
+// assertInvalidRelease 参数无效时返回带详情的 INVALID_RELEASE
+func assertInvalidRelease(t *testing.T, err error) {
+	t.Helper()
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "%v", err)
+	assert.Equal(t, domain.ErrInvalidRelease.Code, appErr.Code)
+	assert.NotEmpty(t, appErr.Details)
+}
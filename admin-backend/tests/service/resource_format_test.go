@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/service"
+)
+
+func TestMarshalProperties(t *testing.T) {
+	values := map[string]string{
+		"app.title":    "你好 😀",
+		"key with=sep": " leading, trailing ",
+		"#hash":        "!bang #not-comment",
+		"multi":        "line1\nline2\\end",
+	}
+	contexts := map[string]string{"app.title": "首页标题\nsecond line"}
+
+	assert.Equal(t, `\#hash=\!bang #not-comment
+# \u9996\u9875\u6807\u9898
+# second line
+app.title=\u4F60\u597D \uD83D\uDE00
+key\ with\=sep=\ leading, trailing 
+multi=line1\nline2\\end
+`, string(service.MarshalProperties(values, contexts)))
+}
+
+func TestMarshalResx(t *testing.T) {
+	values := map[string]string{
+		"greeting": "Hello <b>\"{0}\"</b> & bye",
+		"multi":    "line1\nline2",
+	}
+	contexts := map[string]string{"greeting": "Shown on home"}
+
+	data := service.MarshalResx(values, contexts)
+	assert.Contains(t, string(data), "<value>text/microsoft-resx</value>")
+
+	var doc struct {
+		Data []struct {
+			Name    string `xml:"name,attr"`
+			Value   string `xml:"value"`
+			Comment string `xml:"comment"`
+		} `xml:"data"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+	require.Len(t, doc.Data, 2)
+	assert.Equal(t, "greeting", doc.Data[0].Name)
+	assert.Equal(t, values["greeting"], doc.Data[0].Value)
+	assert.Equal(t, "Shown on home", doc.Data[0].Comment)
+	assert.Equal(t, "multi", doc.Data[1].Name)
+	assert.Equal(t, values["multi"], doc.Data[1].Value)
+	assert.Empty(t, doc.Data[1].Comment)
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeSearchRepository 返回固定的候选翻译并记录查询条件
+type fakeSearchRepository struct {
+	candidates []*domain.TranslationSearchCandidate
+	query      domain.TranslationSearchQuery
+}
+
+func (r *fakeSearchRepository) FindCandidates(ctx context.Context, query domain.TranslationSearchQuery) ([]*domain.TranslationSearchCandidate, error) {
+	r.query = query
+	return r.candidates, nil
+}
+
+func TestSearchService_RanksByRelevance(t *testing.T) {
+	now := time.Now()
+	repo := &fakeSearchRepository{candidates: []*domain.TranslationSearchCandidate{
+		{TranslationID: 1, ProjectID: 3, KeyName: "settings.save_all", Value: "Save all changes", UpdatedAt: now},
+		{TranslationID: 2, ProjectID: 7, KeyName: "editor.save", Value: "Save changes", UpdatedAt: now.Add(-time.Hour)},
+		{TranslationID: 3, ProjectID: 3, KeyName: "changes.title", Value: "Recent", UpdatedAt: now.Add(-2 * time.Hour)},
+	}}
+	svc := service.NewSearchService(repo, tmUsers{}, tmMembers{})
+
+	result, err := svc.Search(context.Background(), 1, domain.SearchParams{Query: "save changes"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SearchModeFuzzy, repo.query.Mode)
+	assert.Nil(t, repo.query.ProjectIDs, "管理员不限项目")
+	assert.Equal(t, []string{"changes", "save"}, repo.query.Terms)
+
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, uint64(2), result.Items[0].TranslationID, "忽略大小写后完全相同的排在最前")
+	assert.Equal(t, 1.0, result.Items[0].Score)
+	assert.Equal(t, "value", result.Items[0].MatchedField)
+	assert.Equal(t, uint64(1), result.Items[1].TranslationID)
+	assert.Equal(t, "key", result.Items[2].MatchedField)
+
+	// 分页
+	result, err = svc.Search(context.Background(), 1, domain.SearchParams{Query: "save changes", Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, uint64(1), result.Items[0].TranslationID)
+	assert.Equal(t, 3, result.Total)
+}
+
+func TestSearchService_ExactAndRegex(t *testing.T) {
+	repo := &fakeSearchRepository{candidates: []*domain.TranslationSearchCandidate{
+		{TranslationID: 1, KeyName: "home.title", Value: "Welcome home"},
+		{TranslationID: 2, KeyName: "home.cta", Value: "Welcome"},
+	}}
+	svc := service.NewSearchService(repo, tmUsers{}, tmMembers{})
+
+	result, err := svc.Search(context.Background(), 1, domain.SearchParams{Query: "Welcome", Mode: domain.SearchModeExact})
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome", repo.query.Text)
+	assert.Empty(t, repo.query.Terms)
+	assert.Equal(t, uint64(2), result.Items[0].TranslationID)
+	assert.Equal(t, 1.0, result.Items[0].Score)
+	assert.Equal(t, 0.79, result.Items[1].Score)
+
+	result, err = svc.Search(context.Background(), 1, domain.SearchParams{Query: `^home\.`, Mode: domain.SearchModeRegex})
+	require.NoError(t, err)
+	assert.Equal(t, "key", result.Items[0].MatchedField)
+
+	_, err = svc.Search(context.Background(), 1, domain.SearchParams{Query: "(unclosed", Mode: domain.SearchModeRegex})
+	assert.ErrorIs(t, err, domain.ErrInvalidSearchRegex)
+	_, err = svc.Search(context.Background(), 1, domain.SearchParams{Query: "x", Mode: "semantic"})
+	assert.ErrorIs(t, err, domain.ErrInvalidSearchMode)
+	_, err = svc.Search(context.Background(), 1, domain.SearchParams{Query: "x", ReviewStatus: "done"})
+	assert.ErrorIs(t, err, domain.ErrInvalidReviewStatus)
+	_, err = svc.Search(context.Background(), 1, domain.SearchParams{Query: "  "})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestSearchService_MemberProjects(t *testing.T) {
+	repo := &fakeSearchRepository{}
+	svc := service.NewSearchService(repo, tmUsers{}, tmMembers{})
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 普通用户只搜索所参与的项目，过滤条件传给仓储
+	_, err := svc.Search(context.Background(), 2, domain.SearchParams{Query: "ok", Language: "de", UpdatedBy: 5, From: &from})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{7}, repo.query.ProjectIDs)
+	assert.Equal(t, []string{"ok"}, repo.query.Terms, "没有可用的单词时使用整个查询文本")
+	assert.Equal(t, "de", repo.query.LanguageCode)
+	assert.Equal(t, uint64(5), repo.query.UpdatedBy)
+	assert.Equal(t, &from, repo.query.From)
+
+	_, err = svc.Search(context.Background(), 2, domain.SearchParams{Query: "ok", ProjectID: 3})
+	assert.ErrorIs(t, err, domain.ErrInsufficientPerm)
+
+	// 没有参与任何项目时不查询
+	repo.query = domain.TranslationSearchQuery{}
+	result, err := svc.Search(context.Background(), 4, domain.SearchParams{Query: "ok"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Items)
+	assert.Empty(t, repo.query.Mode)
+}
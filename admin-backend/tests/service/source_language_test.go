@@ -0,0 +1,285 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// sourceLanguages en 为默认语言，ja 未启用
+type sourceLanguages struct {
+	domain.LanguageRepository
+}
+
+var sourceLanguageList = []*domain.Language{
+	{ID: 1, Code: "en", Status: "active", IsDefault: true},
+	{ID: 2, Code: "de", Status: "active"},
+	{ID: 3, Code: "fr", Status: "active"},
+	{ID: 4, Code: "ja", Status: "inactive"},
+}
+
+func (sourceLanguages) GetDefault(ctx context.Context) (*domain.Language, error) {
+	return sourceLanguageList[0], nil
+}
+
+func (sourceLanguages) GetByCode(ctx context.Context, code string) (*domain.Language, error) {
+	for _, language := range sourceLanguageList {
+		if language.Code == code {
+			return language, nil
+		}
+	}
+	return nil, domain.ErrLanguageNotFound
+}
+
+func (sourceLanguages) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Language, error) {
+	var languages []*domain.Language
+	for _, id := range ids {
+		for _, language := range sourceLanguageList {
+			if language.ID == id {
+				languages = append(languages, language)
+			}
+		}
+	}
+	return languages, nil
+}
+
+// sourceProjects 内存中的项目
+type sourceProjects struct {
+	domain.ProjectRepository
+	projects map[uint64]*domain.Project
+}
+
+func (r *sourceProjects) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	if project, ok := r.projects[id]; ok {
+		copied := *project
+		return &copied, nil
+	}
+	return nil, domain.ErrProjectNotFound
+}
+
+func (r *sourceProjects) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Project, error) {
+	var projects []*domain.Project
+	for _, id := range ids {
+		if project, err := r.GetByID(ctx, id); err == nil {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (r *sourceProjects) Update(ctx context.Context, project *domain.Project) error {
+	r.projects[project.ID] = project
+	return nil
+}
+
+// sourceTranslations 内存中的翻译，按键名和语言ID存储
+type sourceTranslations struct {
+	domain.TranslationRepository
+	rows   map[string]*domain.Translation
+	nextID uint64
+}
+
+func newSourceTranslations() *sourceTranslations {
+	return &sourceTranslations{rows: make(map[string]*domain.Translation)}
+}
+
+func sourceRowKey(keyName string, languageID uint64) string {
+	return fmt.Sprintf("%s:%d", keyName, languageID)
+}
+
+// seed 写入一条审核通过的译文
+func (r *sourceTranslations) seed(keyName string, languageID uint64, value string) *domain.Translation {
+	r.nextID++
+	translation := &domain.Translation{
+		ID: r.nextID, ProjectID: 1, KeyName: keyName, LanguageID: languageID, Value: value,
+		Status: "active", ReviewStatus: domain.ReviewStatusApproved,
+	}
+	r.rows[sourceRowKey(keyName, languageID)] = translation
+	return translation
+}
+
+func (r *sourceTranslations) reviewStatus(keyName string, languageID uint64) string {
+	return r.rows[sourceRowKey(keyName, languageID)].ReviewStatus
+}
+
+func (r *sourceTranslations) GetKeyValueTypes(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyValueType, error) {
+	return map[string]domain.KeyValueType{}, nil
+}
+
+func (r *sourceTranslations) GetKeyMetadata(ctx context.Context, projectID uint64, keyNames []string) (map[string]domain.KeyMetadata, error) {
+	return map[string]domain.KeyMetadata{}, nil
+}
+
+func (r *sourceTranslations) GetByProjectKeyLanguages(ctx context.Context, keys []domain.TranslationKey) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	for _, key := range keys {
+		if translation, ok := r.rows[sourceRowKey(key.KeyName, key.LanguageID)]; ok {
+			copied := *translation
+			translations = append(translations, &copied)
+		}
+	}
+	return translations, nil
+}
+
+func (r *sourceTranslations) GetByID(ctx context.Context, id uint64) (*domain.Translation, error) {
+	for _, translation := range r.rows {
+		if translation.ID == id {
+			copied := *translation
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrTranslationNotFound
+}
+
+func (r *sourceTranslations) MarkOutdated(ctx context.Context, projectID, sourceLanguageID uint64, keyNames []string) (int64, error) {
+	var marked int64
+	for _, translation := range r.rows {
+		for _, keyName := range keyNames {
+			if translation.KeyName == keyName && translation.LanguageID != sourceLanguageID &&
+				translation.Value != "" && translation.ReviewStatus != domain.ReviewStatusOutdated {
+				translation.ReviewStatus = domain.ReviewStatusOutdated
+				marked++
+			}
+		}
+	}
+	return marked, nil
+}
+
+// UpsertBatch 与数据库实现一致：值变化的译文回到 draft，值不变时保留审核状态
+func (r *sourceTranslations) UpsertBatch(ctx context.Context, translations []*domain.Translation) error {
+	for _, translation := range translations {
+		key := sourceRowKey(translation.KeyName, translation.LanguageID)
+		if existing, ok := r.rows[key]; ok {
+			if existing.Value != translation.Value {
+				existing.Value = translation.Value
+				existing.ReviewStatus = domain.ReviewStatusDraft
+			}
+			continue
+		}
+		r.nextID++
+		copied := *translation
+		copied.ID = r.nextID
+		r.rows[key] = &copied
+	}
+	return nil
+}
+
+// Update 与数据库实现一致：版本号与存储的不一致时拒绝更新，写入后版本号加 1
+func (r *sourceTranslations) Update(ctx context.Context, translation *domain.Translation) error {
+	key := sourceRowKey(translation.KeyName, translation.LanguageID)
+	if existing, ok := r.rows[key]; ok && existing.Version != translation.Version {
+		return domain.ErrTranslationConflict
+	}
+	translation.Version++
+	r.rows[key] = translation
+	return nil
+}
+
+func (r *sourceTranslations) GetMatrix(ctx context.Context, projectID uint64, limit, offset int, keyword string) (map[string]map[string]domain.TranslationCell, int64, error) {
+	matrix := make(map[string]map[string]domain.TranslationCell)
+	for _, translation := range r.rows {
+		if matrix[translation.KeyName] == nil {
+			matrix[translation.KeyName] = make(map[string]domain.TranslationCell)
+		}
+		code := sourceLanguageList[translation.LanguageID-1].Code
+		matrix[translation.KeyName][code] = domain.TranslationCell{ID: translation.ID, Value: translation.Value, ReviewStatus: translation.ReviewStatus}
+	}
+	return matrix, int64(len(matrix)), nil
+}
+
+func newSourceTranslationService(project *domain.Project, repo *sourceTranslations) *service.TranslationService {
+	projects := &sourceProjects{projects: map[uint64]*domain.Project{project.ID: project}}
+	return service.NewTranslationService(repo, projects, sourceLanguages{}, nil, nil, nil, nil)
+}
+
+func TestSourceLanguage_UpsertMarksTargetsOutdated(t *testing.T) {
+	ctx := context.Background()
+	repo := newSourceTranslations()
+	repo.seed("home.title", 1, "Welcome")
+	repo.seed("home.title", 2, "Willkommen")
+	repo.seed("home.title", 3, "Bienvenue")
+	repo.seed("home.cta", 1, "Buy")
+	repo.seed("home.cta", 2, "Kaufen")
+	repo.seed("home.empty", 1, "Empty")
+	repo.seed("home.empty", 2, "")
+	svc := newSourceTranslationService(&domain.Project{ID: 1}, repo)
+
+	err := svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Welcome!"},
+		{ProjectID: 1, KeyName: "home.title", LanguageID: 3, Value: "Bienvenue !"}, // 同时更新的译文回到 draft
+		{ProjectID: 1, KeyName: "home.cta", LanguageID: 1, Value: "Buy"},           // 值未变
+		{ProjectID: 1, KeyName: "home.empty", LanguageID: 1, Value: "Nothing"},
+		{ProjectID: 1, KeyName: "home.new", LanguageID: 1, Value: "New"}, // 新增的源语言译文
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ReviewStatusOutdated, repo.reviewStatus("home.title", 2))
+	assert.Equal(t, domain.ReviewStatusDraft, repo.reviewStatus("home.title", 3))
+	assert.Equal(t, domain.ReviewStatusDraft, repo.reviewStatus("home.title", 1), "源语言译文本身回到 draft")
+	assert.Equal(t, domain.ReviewStatusApproved, repo.reviewStatus("home.cta", 2))
+	assert.Equal(t, domain.ReviewStatusApproved, repo.reviewStatus("home.empty", 2), "空译文不标记为过期")
+
+	matrix, _, err := svc.GetMatrix(ctx, 1, -1, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, matrix["home.title"]["en"].Outdated)
+	assert.Zero(t, matrix["home.title"]["de"].Outdated, "只在源语言单元格上统计")
+	assert.Zero(t, matrix["home.cta"]["en"].Outdated)
+}
+
+func TestSourceLanguage_ProjectSourceLanguage(t *testing.T) {
+	ctx := context.Background()
+	repo := newSourceTranslations()
+	repo.seed("home.title", 1, "Welcome")
+	repo.seed("home.title", 2, "Willkommen")
+	repo.seed("home.title", 3, "Bienvenue")
+	svc := newSourceTranslationService(&domain.Project{ID: 1, SourceLanguage: "de"}, repo)
+
+	// 修改非源语言的译文不影响其他语言
+	require.NoError(t, svc.UpsertBatch(ctx, []domain.TranslationInput{
+		{ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Welcome!"},
+	}))
+	assert.Equal(t, domain.ReviewStatusApproved, repo.reviewStatus("home.title", 3))
+
+	german := repo.rows[sourceRowKey("home.title", 2)]
+	_, err := svc.Update(ctx, german.ID, domain.TranslationInput{Value: "Herzlich willkommen"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusOutdated, repo.reviewStatus("home.title", 1))
+	assert.Equal(t, domain.ReviewStatusOutdated, repo.reviewStatus("home.title", 3))
+	assert.Equal(t, domain.ReviewStatusDraft, repo.reviewStatus("home.title", 2))
+
+	// 修改过期的译文后回到 draft
+	english := repo.rows[sourceRowKey("home.title", 1)]
+	_, err = svc.Update(ctx, english.ID, domain.TranslationInput{Value: "A warm welcome"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusDraft, repo.reviewStatus("home.title", 1))
+	assert.Equal(t, domain.ReviewStatusOutdated, repo.reviewStatus("home.title", 3))
+}
+
+func TestSourceLanguage_ProjectValidation(t *testing.T) {
+	ctx := context.Background()
+	projects := &sourceProjects{projects: map[uint64]*domain.Project{1: {ID: 1, Name: "web"}}}
+	svc := service.NewProjectService(projects, nil, nil, nil, sourceLanguages{}, nil, nil, nil)
+
+	code := "de"
+	project, err := svc.Update(ctx, 1, domain.UpdateProjectParams{SourceLanguage: &code}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "de", project.SourceLanguage)
+
+	for _, invalid := range []string{"xx", "ja"} {
+		code := invalid
+		_, err = svc.Update(ctx, 1, domain.UpdateProjectParams{SourceLanguage: &code}, 7)
+		assert.ErrorIs(t, err, domain.ErrInvalidSourceLanguage, invalid)
+	}
+
+	// 空字符串改为使用默认语言
+	code = ""
+	project, err = svc.Update(ctx, 1, domain.UpdateProjectParams{SourceLanguage: &code}, 7)
+	require.NoError(t, err)
+	assert.Empty(t, project.SourceLanguage)
+}
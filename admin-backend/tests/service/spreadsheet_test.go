@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// buildXLSX 生成只包含必要部件的 XLSX 文件
+func buildXLSX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestParseSpreadsheet_CSV(t *testing.T) {
+	// Excel 导出的 CSV：带 BOM、分号分隔、单元格内换行
+	data := []byte("\xef\xbb\xbfKey;English;Deutsch\nhome.title;\"Hello;\nworld\";Hallo\n")
+
+	format, rows, err := service.ParseSpreadsheet(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, service.SpreadsheetFormatCSV, format)
+	assert.Equal(t, [][]string{
+		{"Key", "English", "Deutsch"},
+		{"home.title", "Hello;\nworld", "Hallo"},
+	}, rows)
+
+	_, _, err = service.ParseSpreadsheet([]byte("a,b\n\xff\xfe,c\n"), service.SpreadsheetFormatCSV)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidSpreadsheet.Code, appErr.Code)
+
+	_, _, err = service.ParseSpreadsheet(data, "ods")
+	assert.ErrorIs(t, err, domain.ErrUnsupportedSpreadsheetFormat)
+}
+
+func TestParseSpreadsheet_XLSX(t *testing.T) {
+	data := buildXLSX(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+  <sheets><sheet name="Strings" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships>
+  <Relationship Id="rId1" Target="worksheets/sheet1.xml"/>
+  <Relationship Id="rId3" Target="/xl/worksheets/strings.xml"/>
+</Relationships>`,
+		"xl/sharedStrings.xml":     `<sst><si><t>key</t></si><si><r><t>Hel</t></r><r><rPr><b/></rPr><t>lo</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>wrong sheet</t></is></c></row></sheetData></worksheet>`,
+		"xl/worksheets/strings.xml": `<worksheet><sheetData>
+  <row r="2"><c r="A2" t="s"><v>0</v></c><c r="C2" t="inlineStr"><is><t>zh-CN</t></is></c></row>
+  <row r="3"><c r="A3" t="inlineStr"><is><t>home.title</t></is></c><c r="B3" t="s"><v>1</v></c><c r="C3"><v>42</v></c></row>
+</sheetData></worksheet>`,
+	})
+
+	format, rows, err := service.ParseSpreadsheet(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, service.SpreadsheetFormatXLSX, format)
+	// 按工作簿顺序读取第一个工作表，缺失的行和单元格补齐为空
+	assert.Equal(t, [][]string{
+		nil,
+		{"key", "", "zh-CN"},
+		{"home.title", "Hello", "42"},
+	}, rows)
+
+	_, _, err = service.ParseSpreadsheet(buildXLSX(t, map[string]string{"docProps/app.xml": "<Properties/>"}), service.SpreadsheetFormatXLSX)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidSpreadsheet.Code, appErr.Code)
+}
+
+func TestSuggestImportMapping(t *testing.T) {
+	languages := []*domain.Language{
+		{ID: 1, Code: "en", Name: "English"},
+		{ID: 2, Code: "zh_CN", Name: "简体中文"},
+		{ID: 3, Code: "de", Name: "Deutsch"},
+		{ID: 4, Code: "id", Name: "Bahasa Indonesia"},
+	}
+
+	suggestion := service.SuggestImportMapping(
+		[]string{" String ID ", "Description", "English", "zh-CN", "German (de-DE)", "EN", "Status", ""},
+		languages)
+	assert.Equal(t, "String ID", suggestion.KeyColumn)
+	assert.Equal(t, "Description", suggestion.ContextColumn)
+	assert.Equal(t, map[string]string{"English": "en", "zh-CN": "zh_CN", "German (de-DE)": "de"}, suggestion.LanguageColumns)
+	// 同一语言只映射第一列
+	assert.Equal(t, []string{"EN", "Status"}, suggestion.UnmappedColumns)
+	assert.Len(t, suggestion.Columns, 7)
+
+	// 没有可识别的键名列时使用第一个未映射的列；id 列优先视为键名而不是印尼语
+	suggestion = service.SuggestImportMapping([]string{"Name", "简体中文", "id"}, languages)
+	assert.Equal(t, "id", suggestion.KeyColumn)
+	assert.Equal(t, []string{"Name"}, suggestion.UnmappedColumns)
+
+	suggestion = service.SuggestImportMapping([]string{"Name", "简体中文"}, languages)
+	assert.Equal(t, "Name", suggestion.KeyColumn)
+	assert.Empty(t, suggestion.UnmappedColumns)
+}
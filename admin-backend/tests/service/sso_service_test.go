@@ -0,0 +1,258 @@
+package service_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/config"
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// ssoUsers 内存中的用户，在 resetUsers 基础上支持按用户名读取和创建
+type ssoUsers struct {
+	resetUsers
+}
+
+func (r *ssoUsers) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *ssoUsers) Create(ctx context.Context, user *domain.User) error {
+	user.ID = uint64(len(r.users) + 1)
+	r.users = append(r.users, user)
+	return nil
+}
+
+// memoryIdentities 内存中的单点登录账号关联
+type memoryIdentities struct {
+	identities []*domain.UserIdentity
+}
+
+func (r *memoryIdentities) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			copied := *identity
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrSSOIdentityNotFound
+}
+
+func (r *memoryIdentities) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	identity.ID = uint64(len(r.identities) + 1)
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *memoryIdentities) Update(ctx context.Context, identity *domain.UserIdentity) error {
+	r.identities[identity.ID-1] = identity
+	return nil
+}
+
+// ssoTokens 按用户名生成可识别的令牌
+type ssoTokens struct {
+	domain.AuthService
+}
+
+func (ssoTokens) GenerateToken(ctx context.Context, user *domain.User) (string, error) {
+	return "access-" + user.Username, nil
+}
+
+func (ssoTokens) GenerateRefreshToken(ctx context.Context, user *domain.User) (string, error) {
+	return "refresh-" + user.Username, nil
+}
+
+// fakeIdP 测试用的 OIDC 身份提供方，令牌接口签发包含 claims 的 ID Token
+type fakeIdP struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	claims   jwt.MapClaims
+	verifier string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idp := &fakeIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		idp.verifier = r.FormValue("code_verifier")
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = "test"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "access_token": "at"})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+// login 发起登录并以 claims 完成回调
+func (idp *fakeIdP) login(t *testing.T, svc domain.SSOService, claims jwt.MapClaims) (*domain.LoginResult, error) {
+	t.Helper()
+	ctx := context.Background()
+	request, err := svc.Begin(ctx, "corp")
+	require.NoError(t, err)
+	authURL, err := url.Parse(request.AuthURL)
+	require.NoError(t, err)
+	query := authURL.Query()
+	assert.Equal(t, idp.server.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(t, "https://yflow.example.com/api/auth/oidc/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	idp.claims = jwt.MapClaims{
+		"iss":   idp.server.URL,
+		"aud":   "client",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": query.Get("nonce"),
+	}
+	for key, value := range claims {
+		idp.claims[key] = value
+	}
+	return svc.Complete(ctx, request.Session, query.Get("state"), "code")
+}
+
+func newTestSSOService(t *testing.T, idp *fakeIdP, users *ssoUsers, identities *memoryIdentities, mutate func(*config.SSOConfig)) domain.SSOService {
+	t.Helper()
+	cfg := config.SSOConfig{
+		Providers: []config.SSOProviderConfig{{
+			Name: "corp", Type: "oidc", DisplayName: "Corp", ClientID: "client", ClientSecret: "secret", Issuer: idp.server.URL,
+		}},
+		BaseURL:       "https://yflow.example.com",
+		DefaultRole:   "viewer",
+		AutoProvision: true,
+	}
+	if mutate != nil {
+		mutate(&cfg)
+	}
+	svc, err := service.NewSSOService(cfg, "jwt-secret", users, identities, ssoTokens{}, nil, nil)
+	require.NoError(t, err)
+	return svc
+}
+
+func TestSSOService_ProvisionsAndLinksUsers(t *testing.T) {
+	idp := newFakeIdP(t)
+	users := &ssoUsers{resetUsers{bulkMemberUsers{users: []*domain.User{
+		{ID: 1, Username: "alice", Email: "alice@example.com", Role: "member", Status: "active"},
+	}}}}
+	identities := &memoryIdentities{}
+	svc := newTestSSOService(t, idp, users, identities, nil)
+	assert.Equal(t, []domain.SSOProvider{{Name: "corp", Type: "oidc", DisplayName: "Corp"}}, svc.Providers())
+
+	// 没有对应用户时以默认角色创建，用户名取自邮箱且不与已有用户重复
+	result, err := idp.login(t, svc, jwt.MapClaims{"sub": "u-1", "email": "alice@other.com", "email_verified": true})
+	require.NoError(t, err)
+	assert.Equal(t, "alice-2", result.User.Username)
+	assert.Equal(t, "viewer", result.User.Role)
+	assert.Empty(t, result.User.Password)
+	assert.Equal(t, "refresh-alice-2", result.RefreshToken)
+	assert.NotEmpty(t, idp.verifier, "令牌请求携带 PKCE 校验码")
+	require.Len(t, users.users, 2)
+	assert.NotEmpty(t, users.users[1].Password, "自动创建的用户有随机密码")
+
+	// 已验证的邮箱与已有用户相同时关联该用户
+	result, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-2", "email": "alice@example.com", "email_verified": true})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), result.User.ID)
+	require.Len(t, identities.identities, 2)
+
+	// 已关联的账号按 subject 登录，邮箱变化时更新关联
+	result, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-2", "email": "alice@new.example.com", "email_verified": true})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), result.User.ID)
+	assert.Equal(t, "alice@new.example.com", identities.identities[1].Email)
+	assert.Len(t, users.users, 2)
+}
+
+func TestSSOService_RejectsUnverifiedAndForeignAccounts(t *testing.T) {
+	idp := newFakeIdP(t)
+	users := &ssoUsers{resetUsers{bulkMemberUsers{users: []*domain.User{
+		{ID: 1, Username: "bob", Email: "bob@example.com", Status: "disabled"},
+	}}}}
+	svc := newTestSSOService(t, idp, users, &memoryIdentities{}, func(cfg *config.SSOConfig) {
+		cfg.AllowedDomains = []string{"example.com"}
+		cfg.AutoProvision = false
+	})
+
+	_, err := idp.login(t, svc, jwt.MapClaims{"sub": "u-1", "email": "carol@example.com", "email_verified": false})
+	assert.ErrorIs(t, err, domain.ErrSSOEmailRequired)
+	_, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-1", "email": "carol@other.com", "email_verified": true})
+	assert.ErrorIs(t, err, domain.ErrSSODomainNotAllowed)
+	_, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-1", "email": "carol@example.com", "email_verified": true})
+	assert.ErrorIs(t, err, domain.ErrSSOUserNotProvisioned)
+	_, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-2", "email": "bob@example.com", "email_verified": true})
+	assert.ErrorIs(t, err, domain.ErrUserDisabled)
+}
+
+func TestSSOService_VerifiesSessionAndIDToken(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	svc := newTestSSOService(t, idp, &ssoUsers{}, &memoryIdentities{}, nil)
+
+	_, err := svc.Begin(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrSSOProviderNotFound)
+
+	request, err := svc.Begin(ctx, "corp")
+	require.NoError(t, err)
+	_, err = svc.Complete(ctx, request.Session, "forged-state", "code")
+	assert.ErrorIs(t, err, domain.ErrSSOStateMismatch)
+	_, err = svc.Complete(ctx, "", "", "code")
+	assert.ErrorIs(t, err, domain.ErrSSOStateMismatch)
+
+	// ID Token 的 nonce 与登录会话不一致时拒绝
+	_, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-1", "email": "dave@example.com", "email_verified": true, "nonce": "replayed"})
+	assertSSOLoginFailed(t, err)
+	// 发给其他客户端的 ID Token 同样拒绝
+	_, err = idp.login(t, svc, jwt.MapClaims{"sub": "u-1", "email": "dave@example.com", "email_verified": true, "aud": "other"})
+	assertSSOLoginFailed(t, err)
+}
+
+// assertSSOLoginFailed 身份提供方返回的令牌无效时返回 SSO_LOGIN_FAILED，原因保留在错误链中
+func assertSSOLoginFailed(t *testing.T, err error) {
+	t.Helper()
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "%v", err)
+	assert.Equal(t, domain.ErrSSOLoginFailed.Code, appErr.Code)
+	assert.Error(t, appErr.Cause)
+}
@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeStorageStatsRepository 返回预设统计信息的数据表统计仓储
+type fakeStorageStatsRepository struct {
+	stats []*domain.TableStats
+}
+
+func (r *fakeStorageStatsRepository) GetTableStats(ctx context.Context, tables []string) ([]*domain.TableStats, error) {
+	return r.stats, nil
+}
+
+func TestStorageMonitor_AlertsOncePerThresholdBreach(t *testing.T) {
+	repo := &fakeStorageStatsRepository{stats: []*domain.TableStats{
+		{Table: "translations", Rows: 100, DataBytes: 1 << 20},
+		{Table: "audit_logs", Rows: 2000, DataBytes: 3 << 20, IndexBytes: 1 << 20},
+	}}
+	bus := service.NewInMemoryEventBus(nil)
+	var alerts []domain.StorageThresholdExceededPayload
+	bus.Subscribe(domain.EventStorageThresholdExceeded, "test", func(ctx context.Context, event domain.Event) error {
+		var payload domain.StorageThresholdExceededPayload
+		require.NoError(t, event.DecodePayload(&payload))
+		alerts = append(alerts, payload)
+		return nil
+	})
+
+	monitor := service.NewStorageMonitor(repo, bus, service.StorageThresholds{
+		TranslationRows: 1000,
+		HistoryRows:     1000,
+		TableBytes:      2 << 20,
+	}, 0, nil)
+	ctx := context.Background()
+
+	report, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StorageStatusWarning, report.Status)
+	require.Len(t, report.Alerts, 2)
+	assert.Equal(t, domain.StorageMetricRows, report.Alerts[0].Metric)
+	assert.Contains(t, report.Alerts[0].Recommendation, "audit_logs 超过 1K 行")
+	assert.Equal(t, int64(4<<20), report.Alerts[1].Value)
+	assert.Len(t, alerts, 2)
+
+	// 告警持续存在时不重复通知，也不触发新的检查
+	cached, err := monitor.GetReport(ctx, false)
+	require.NoError(t, err)
+	assert.Same(t, report, cached)
+	_, err = monitor.GetReport(ctx, true)
+	require.NoError(t, err)
+	assert.Len(t, alerts, 2)
+
+	// 恢复后再次超过软限制时重新通知
+	repo.stats = []*domain.TableStats{{Table: "audit_logs", Rows: 10}}
+	report, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StorageStatusOK, report.Status)
+	assert.Empty(t, report.Alerts)
+
+	repo.stats = []*domain.TableStats{{Table: "audit_logs", Rows: 5000}}
+	_, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, alerts, 3)
+	assert.Equal(t, "audit_logs", alerts[2].Table)
+	assert.Equal(t, int64(1000), alerts[2].Threshold)
+}
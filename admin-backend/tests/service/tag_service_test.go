@@ -0,0 +1,196 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// memoryTags 内存中的标签及键与标签的关联
+type memoryTags struct {
+	domain.TagRepository
+	tags    map[uint64]*domain.Tag
+	keyTags map[string]map[uint64]bool // 键名 -> 标签ID
+}
+
+func newMemoryTags(tags ...*domain.Tag) *memoryTags {
+	r := &memoryTags{tags: map[uint64]*domain.Tag{}, keyTags: map[string]map[uint64]bool{}}
+	for _, tag := range tags {
+		r.tags[tag.ID] = tag
+	}
+	return r
+}
+
+func (r *memoryTags) GetByID(ctx context.Context, id uint64) (*domain.Tag, error) {
+	if tag, ok := r.tags[id]; ok {
+		return tag, nil
+	}
+	return nil, domain.ErrTagNotFound
+}
+
+func (r *memoryTags) GetByName(ctx context.Context, projectID uint64, name string) (*domain.Tag, error) {
+	for _, tag := range r.tags {
+		if tag.ProjectID == projectID && tag.Name == name {
+			return tag, nil
+		}
+	}
+	return nil, domain.ErrTagNotFound
+}
+
+func (r *memoryTags) Create(ctx context.Context, tag *domain.Tag) error {
+	tag.ID = uint64(len(r.tags) + 1)
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *memoryTags) Update(ctx context.Context, tag *domain.Tag) error {
+	r.tags[tag.ID] = tag
+	return nil
+}
+
+func (r *memoryTags) GetKeyNames(ctx context.Context, tagID uint64) ([]string, error) {
+	var keyNames []string
+	for keyName, tags := range r.keyTags {
+		if tags[tagID] {
+			keyNames = append(keyNames, keyName)
+		}
+	}
+	return keyNames, nil
+}
+
+func (r *memoryTags) CountKeyTags(ctx context.Context, projectID uint64, keyNames []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, keyName := range keyNames {
+		if len(r.keyTags[keyName]) > 0 {
+			counts[keyName] = len(r.keyTags[keyName])
+		}
+	}
+	return counts, nil
+}
+
+func (r *memoryTags) AddKeys(ctx context.Context, tag *domain.Tag, keyNames []string) (int64, error) {
+	var added int64
+	for _, keyName := range keyNames {
+		if r.keyTags[keyName] == nil {
+			r.keyTags[keyName] = map[uint64]bool{}
+		}
+		if !r.keyTags[keyName][tag.ID] {
+			r.keyTags[keyName][tag.ID] = true
+			added++
+		}
+	}
+	return added, nil
+}
+
+func (r *memoryTags) RemoveKeys(ctx context.Context, tag *domain.Tag, keyNames []string) (int64, error) {
+	var removed int64
+	for _, keyName := range keyNames {
+		if r.keyTags[keyName][tag.ID] {
+			delete(r.keyTags[keyName], tag.ID)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func TestTagService_CreateValidatesParams(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewTagService(newMemoryTags(), preTranslateProjects{}, nil, nil, nil)
+
+	color := "#FF8800"
+	tag, err := svc.Create(ctx, 1, domain.TagParams{Name: " mobile ", Color: &color}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "mobile", tag.Name)
+	assert.Equal(t, "#ff8800", tag.Color)
+
+	_, err = svc.Create(ctx, 1, domain.TagParams{Name: "mobile"}, 1)
+	assert.ErrorIs(t, err, domain.ErrTagExists)
+	// 其他项目可以使用相同名称
+	_, err = svc.Create(ctx, 2, domain.TagParams{Name: "mobile"}, 1)
+	assert.NoError(t, err)
+
+	invalidColor := "orange"
+	for _, params := range []domain.TagParams{
+		{Name: ""},
+		{Name: "mobile,beta"},
+		{Name: "a-very-long-tag-name-that-exceeds-forty-characters"},
+		{Name: "beta", Color: &invalidColor},
+	} {
+		_, err = svc.Create(ctx, 1, params, 1)
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, "params %+v: unexpected error: %v", params, err)
+		assert.Equal(t, domain.ErrInvalidTag.Code, appErr.Code, "params %+v", params)
+	}
+
+	_, err = svc.Update(ctx, 2, tag.ID, domain.TagParams{Name: "beta"}, 2)
+	assert.ErrorIs(t, err, domain.ErrTagNotFound)
+}
+
+func TestTagService_TagKeys(t *testing.T) {
+	ctx := context.Background()
+	tags := newMemoryTags(&domain.Tag{ID: 1, ProjectID: 1, Name: "mobile"})
+	translations := &namespaceTranslations{keys: map[string]uint64{"home.title": 0, "home.body": 0, "settings.title": 0}}
+	bus := service.NewInMemoryEventBus(nil)
+	var published []domain.TranslationUpdatedPayload
+	bus.Subscribe(domain.EventTranslationUpdated, "test", func(ctx context.Context, event domain.Event) error {
+		var payload domain.TranslationUpdatedPayload
+		require.NoError(t, event.DecodePayload(&payload))
+		published = append(published, payload)
+		return nil
+	})
+	svc := service.NewTagService(tags, preTranslateProjects{}, translations, bus, nil)
+
+	// 任一键不存在时不做修改
+	_, err := svc.TagKeys(ctx, 1, 1, []string{"home.title", "missing"})
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Contains(t, appErr.Details, "missing")
+	assert.Empty(t, tags.keyTags)
+
+	_, err = svc.TagKeys(ctx, 2, 1, []string{"home.title"})
+	assert.ErrorIs(t, err, domain.ErrTagNotFound)
+
+	result, err := svc.TagKeys(ctx, 1, 1, []string{"home.title", " home.body", "home.title"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home.body", "home.title"}, result.KeyNames)
+	assert.Equal(t, int64(2), result.Changed)
+	require.Len(t, published, 1)
+	assert.Equal(t, []string{"home.body", "home.title"}, published[0].KeyNames)
+
+	// 已带有该标签的键不变
+	result, err = svc.TagKeys(ctx, 1, 1, []string{"home.title"})
+	require.NoError(t, err)
+	assert.Zero(t, result.Changed)
+
+	// 键最多 MaxKeyTags 个标签
+	extra := uint64(domain.MaxKeyTags + 2)
+	for id := uint64(2); id <= extra; id++ {
+		tags.tags[id] = &domain.Tag{ID: id, ProjectID: 1, Name: fmt.Sprintf("tag-%d", id)}
+	}
+	for id := uint64(2); id < extra; id++ {
+		_, err = svc.TagKeys(ctx, 1, id, []string{"settings.title"})
+		require.NoError(t, err)
+	}
+	_, err = svc.TagKeys(ctx, 1, extra, []string{"settings.title", "home.body"})
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrInvalidKeyTags.Code, appErr.Code)
+	assert.Contains(t, appErr.Details, "settings.title")
+	assert.False(t, tags.keyTags["home.body"][extra])
+
+	// 移除没有的标签时不发布事件
+	count := len(published)
+	result, err = svc.UntagKeys(ctx, 1, 1, []string{"home.title", "settings.title"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Changed)
+	assert.Len(t, published, count+1)
+	_, err = svc.UntagKeys(ctx, 1, 1, []string{"home.title"})
+	require.NoError(t, err)
+	assert.Len(t, published, count+1)
+}
@@ -0,0 +1,38 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+)
+
+func TestTranslationService_UpdateRejectsStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := newSourceTranslations()
+	german := repo.seed("home.title", 2, "Willkommen")
+	german.Version = 3
+	svc := newSourceTranslationService(&domain.Project{ID: 1}, repo)
+
+	// 基于当前版本的更新成功，版本号加 1
+	updated, err := svc.Update(ctx, german.ID, domain.TranslationInput{Value: "Herzlich willkommen", Version: 3}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), updated.Version)
+
+	// 另一位译者基于旧版本提交，不覆盖已有的修改
+	_, err = svc.Update(ctx, german.ID, domain.TranslationInput{Value: "Hallo", Version: 3}, 8)
+	assert.ErrorIs(t, err, domain.ErrTranslationConflict)
+	current, err := svc.GetByID(ctx, german.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Herzlich willkommen", current.Value)
+	assert.Equal(t, uint64(7), current.UpdatedBy)
+
+	// 不传版本号时不检查
+	updated, err = svc.Update(ctx, german.ID, domain.TranslationInput{Value: "Hallo"}, 8)
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", updated.Value)
+	assert.Equal(t, uint64(5), updated.Version)
+}
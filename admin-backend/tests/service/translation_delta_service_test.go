@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// deltaHistory 按变更时间记录键名的变更历史
+type deltaHistory struct {
+	domain.TranslationHistoryRepository
+	entries []*domain.TranslationHistory
+	queries []domain.HistoryQuery
+}
+
+func (r *deltaHistory) GetChangedKeys(ctx context.Context, query domain.HistoryQuery) ([]string, error) {
+	r.queries = append(r.queries, query)
+	seen := map[string]bool{}
+	var keyNames []string
+	for _, entry := range r.entries {
+		if entry.CreatedAt.Before(query.From) || !entry.CreatedAt.Before(query.To) {
+			continue
+		}
+		for _, keyName := range []string{entry.KeyName, entry.PreviousKey} {
+			if keyName != "" && !seen[keyName] {
+				seen[keyName] = true
+				keyNames = append(keyNames, keyName)
+			}
+		}
+	}
+	return keyNames, nil
+}
+
+// deltaTranslations 项目当前的有效翻译
+type deltaTranslations struct {
+	domain.TranslationRepository
+	values map[string]map[string]string
+}
+
+func (r *deltaTranslations) GetKeyPage(ctx context.Context, query domain.TranslationKeyPageQuery) (*domain.TranslationKeyPage, error) {
+	page := &domain.TranslationKeyPage{Translations: map[string]map[string]string{}}
+	for _, keyName := range query.KeyNames {
+		for lang, value := range r.values[keyName] {
+			if query.Locale != "" && lang != query.Locale {
+				continue
+			}
+			if page.Translations[keyName] == nil {
+				page.Translations[keyName] = map[string]string{}
+			}
+			page.Translations[keyName][lang] = value
+		}
+	}
+	return page, nil
+}
+
+func TestTranslationDeltaService_GetDelta(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	history := &deltaHistory{entries: []*domain.TranslationHistory{
+		{KeyName: "home.title", CreatedAt: base.Add(-time.Hour)},
+		{KeyName: "home.menu", CreatedAt: base.Add(time.Minute)},
+		{KeyName: "home.subtitle", PreviousKey: "home.tagline", CreatedAt: base.Add(2 * time.Minute)},
+		{KeyName: "cart.total", CreatedAt: base.Add(3 * time.Minute)},
+		{KeyName: "cart.removed", CreatedAt: base.Add(4 * time.Minute)},
+	}}
+	translations := &deltaTranslations{values: map[string]map[string]string{
+		"home.title":    {"en": "Home", "de": "Start"},
+		"home.menu":     {"en": "Menu", "de": "Menü"},
+		"home.subtitle": {"en": "Welcome"},
+		"cart.total":    {"en": "Total", "de": "Summe"},
+	}}
+	releases := newMemoryReleases(map[string]map[string]string{})
+	require.NoError(t, releases.Create(ctx, &domain.Release{ProjectID: 1, Version: "v1.0.0", CreatedAt: base}))
+	svc := service.NewTranslationDeltaService(preTranslateProjects{}, releases, translations, history)
+
+	delta, err := svc.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: 1, Since: base.Format(time.RFC3339), MaxKeys: 100})
+	require.NoError(t, err)
+	assert.Equal(t, base, delta.Since)
+	assert.Equal(t, map[string]map[string]string{
+		"home.menu":     {"en": "Menu", "de": "Menü"},
+		"home.subtitle": {"en": "Welcome"},
+		"cart.total":    {"en": "Total", "de": "Summe"},
+	}, delta.Translations)
+	// 被重命名的旧键和已删除的键列入 deleted
+	assert.Equal(t, []string{"cart.removed", "home.tagline"}, delta.Deleted)
+	// 变更范围截止到 synced_at，下次从 synced_at 开始同步
+	assert.Equal(t, delta.SyncedAt, history.queries[0].To)
+
+	// 发布版本标签使用其发布时间；按语言和命名空间筛选，没有该语言翻译的键列入 deleted
+	delta, err = svc.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: 1, Since: "v1.0.0", Locale: "de", KeyPrefix: "home.", MaxKeys: 100})
+	require.NoError(t, err)
+	assert.Equal(t, base, delta.Since)
+	assert.Equal(t, map[string]map[string]string{"home.menu": {"de": "Menü"}}, delta.Translations)
+	assert.Equal(t, []string{"home.subtitle", "home.tagline"}, delta.Deleted)
+
+	// 没有变更时返回空结果
+	delta, err = svc.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: 1, Since: base.Add(time.Hour).Format(time.RFC3339), MaxKeys: 100})
+	require.NoError(t, err)
+	assert.Empty(t, delta.Translations)
+	assert.Empty(t, delta.Deleted)
+
+	// 变更过多时要求全量拉取
+	_, err = svc.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: 1, Since: base.Format(time.RFC3339), MaxKeys: 3})
+	assert.ErrorIs(t, err, domain.ErrTooManyChanges)
+
+	// 无效的起点和不存在的发布版本
+	for _, since := range []string{"", "yesterday?", "2026-03-01 12:00"} {
+		_, err = svc.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: 1, Since: since, MaxKeys: 100})
+		assert.ErrorIs(t, err, domain.ErrInvalidDeltaSince, "since %q", since)
+	}
+	_, err = svc.GetDelta(ctx, domain.TranslationDeltaQuery{ProjectID: 1, Since: "v9.9.9", MaxKeys: 100})
+	assert.ErrorIs(t, err, domain.ErrReleaseNotFound)
+}
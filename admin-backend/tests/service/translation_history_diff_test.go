@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// diffHistory 按 ID 顺序保存的变更历史
+type diffHistory struct {
+	domain.TranslationHistoryRepository
+	entries []*domain.TranslationHistory
+}
+
+func (r *diffHistory) GetByID(ctx context.Context, id uint64) (*domain.TranslationHistory, error) {
+	for _, entry := range r.entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return nil, domain.ErrHistoryNotFound
+}
+
+func (r *diffHistory) Stream(ctx context.Context, query domain.HistoryQuery, batchSize int, fn func([]*domain.TranslationHistory) error) error {
+	var batch []*domain.TranslationHistory
+	for _, entry := range r.entries {
+		if entry.ProjectID == query.ProjectID && !entry.CreatedAt.Before(query.From) && entry.CreatedAt.Before(query.To) {
+			batch = append(batch, entry)
+		}
+	}
+	return fn(batch)
+}
+
+// diffLanguages 语言 1 为 en，语言 2 为 zh
+type diffLanguages struct {
+	domain.LanguageRepository
+}
+
+func (diffLanguages) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Language, error) {
+	codes := map[uint64]string{1: "en", 2: "zh"}
+	var languages []*domain.Language
+	for _, id := range ids {
+		languages = append(languages, &domain.Language{ID: id, Code: codes[id]})
+	}
+	return languages, nil
+}
+
+// diffUsers 只有用户 7
+type diffUsers struct {
+	domain.UserRepository
+}
+
+func (diffUsers) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
+	if id != 7 {
+		return nil, domain.ErrUserNotFound
+	}
+	return &domain.User{ID: 7, Username: "anna", DisplayName: "Anna Li", AvatarKey: "avatars/7/a.png"}, nil
+}
+
+// segmentsText 拼接差异片段，返回旧值和新值
+func segmentsText(segments []domain.DiffSegment) (string, string) {
+	var oldValue, newValue string
+	for _, segment := range segments {
+		if segment.Op != domain.DiffOpInsert {
+			oldValue += segment.Text
+		}
+		if segment.Op != domain.DiffOpDelete {
+			newValue += segment.Text
+		}
+	}
+	return oldValue, newValue
+}
+
+func TestTranslationHistoryService_GetDiff(t *testing.T) {
+	ctx := context.Background()
+	history := &diffHistory{entries: []*domain.TranslationHistory{
+		{ID: 1, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Operation: domain.HistoryOperationUpdate, OldValue: "Welcome to the app", NewValue: "Welcome to our app", UserID: 7},
+		{ID: 2, ProjectID: 1, KeyName: "home.title", LanguageID: 2, Operation: domain.HistoryOperationUpdate, OldValue: "欢迎使用应用", NewValue: "欢迎使用我们的应用", UserID: 8},
+		{ID: 3, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Operation: domain.HistoryOperationReview, NewValue: "Welcome to our app"},
+	}}
+	svc := service.NewTranslationHistoryService(history, pageProjects{}, diffLanguages{}, diffUsers{})
+
+	diff, err := svc.GetDiff(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "en", diff.Language)
+	assert.Equal(t, []domain.DiffSegment{
+		{Op: domain.DiffOpEqual, Text: "Welcome to "},
+		{Op: domain.DiffOpDelete, Text: "the"},
+		{Op: domain.DiffOpInsert, Text: "our"},
+		{Op: domain.DiffOpEqual, Text: " app"},
+	}, diff.Segments)
+	assert.Contains(t, diff.Unified, "--- a/home.title@en\n+++ b/home.title@en\n")
+	assert.Contains(t, diff.Unified, "-Welcome to the app\n+Welcome to our app\n")
+	assert.Equal(t, &domain.UserSummary{ID: 7, Username: "anna", DisplayName: "Anna Li", AvatarURL: "/api/users/7/avatar"}, diff.Operator)
+
+	// 中文按字符比较
+	diff, err = svc.GetDiff(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DiffSegment{
+		{Op: domain.DiffOpEqual, Text: "欢迎使用"},
+		{Op: domain.DiffOpInsert, Text: "我们的"},
+		{Op: domain.DiffOpEqual, Text: "应用"},
+	}, diff.Segments)
+	oldValue, newValue := segmentsText(diff.Segments)
+	assert.Equal(t, "欢迎使用应用", oldValue)
+	assert.Equal(t, "欢迎使用我们的应用", newValue)
+	// 操作人已删除
+	assert.Equal(t, uint64(8), diff.UserID)
+	assert.Nil(t, diff.Operator)
+
+	// 审核操作没有修改值
+	diff, err = svc.GetDiff(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to our app", diff.OldValue)
+	assert.Empty(t, diff.Unified)
+	assert.Equal(t, []domain.DiffSegment{{Op: domain.DiffOpEqual, Text: "Welcome to our app"}}, diff.Segments)
+
+	_, err = svc.GetDiff(ctx, 9)
+	assert.ErrorIs(t, err, domain.ErrHistoryNotFound)
+}
+
+func TestTranslationHistoryService_GetChanges(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return day.Add(time.Duration(hours) * time.Hour) }
+	history := &diffHistory{entries: []*domain.TranslationHistory{
+		// 范围之前的修改不计入
+		{ID: 1, TranslationID: 10, ProjectID: 1, KeyName: "a", LanguageID: 1, Operation: domain.HistoryOperationUpdate, OldValue: "A0", NewValue: "A1", CreatedAt: at(-1)},
+		// 多次修改只比较首尾
+		{ID: 2, TranslationID: 10, ProjectID: 1, KeyName: "a", LanguageID: 1, Operation: domain.HistoryOperationUpdate, OldValue: "A1", NewValue: "A2", CreatedAt: at(1)},
+		{ID: 3, TranslationID: 10, ProjectID: 1, KeyName: "a", LanguageID: 1, Operation: domain.HistoryOperationUpdate, OldValue: "A2", NewValue: "A3", CreatedAt: at(2)},
+		{ID: 4, TranslationID: 11, ProjectID: 1, KeyName: "b", LanguageID: 2, Operation: domain.HistoryOperationCreate, NewValue: "乙", CreatedAt: at(1)},
+		{ID: 5, TranslationID: 12, ProjectID: 1, KeyName: "c", LanguageID: 1, Operation: domain.HistoryOperationDelete, OldValue: "C", CreatedAt: at(1)},
+		// 创建后又删除
+		{ID: 6, TranslationID: 13, ProjectID: 1, KeyName: "d", LanguageID: 1, Operation: domain.HistoryOperationCreate, NewValue: "D", CreatedAt: at(1)},
+		{ID: 7, TranslationID: 13, ProjectID: 1, KeyName: "d", LanguageID: 1, Operation: domain.HistoryOperationDelete, OldValue: "D", CreatedAt: at(2)},
+		// 修改后又改回原值
+		{ID: 8, TranslationID: 14, ProjectID: 1, KeyName: "e", LanguageID: 1, Operation: domain.HistoryOperationUpdate, OldValue: "E", NewValue: "E2", CreatedAt: at(1)},
+		{ID: 9, TranslationID: 14, ProjectID: 1, KeyName: "e", LanguageID: 1, Operation: domain.HistoryOperationUpdate, OldValue: "E2", NewValue: "E", CreatedAt: at(2)},
+		{ID: 10, TranslationID: 15, ProjectID: 1, KeyName: "g", PreviousKey: "f", LanguageID: 1, Operation: domain.HistoryOperationRename, OldValue: "F", NewValue: "F", CreatedAt: at(1)},
+		{ID: 11, TranslationID: 16, ProjectID: 1, KeyName: "h", LanguageID: 1, Operation: domain.HistoryOperationReview, NewValue: "H", CreatedAt: at(1)},
+	}}
+	svc := service.NewTranslationHistoryService(history, pageProjects{}, diffLanguages{}, diffUsers{})
+
+	report, err := svc.GetChanges(ctx, domain.TranslationChangesParams{ProjectID: 1, From: day, To: day.AddDate(0, 0, 1)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Added)
+	assert.Equal(t, 1, report.Modified)
+	assert.Equal(t, 1, report.Removed)
+	assert.Equal(t, 1, report.Renamed)
+	require.Len(t, report.Changes, 4)
+
+	assert.Equal(t, "a", report.Changes[0].KeyName)
+	assert.Equal(t, domain.TranslationChangeModified, report.Changes[0].Change)
+	assert.Equal(t, "A1", report.Changes[0].OldValue)
+	assert.Equal(t, "A3", report.Changes[0].NewValue)
+	assert.Equal(t, domain.TranslationChange{
+		TranslationID: 11, KeyName: "b", Language: "zh", Change: domain.TranslationChangeAdded, NewValue: "乙",
+		Segments: []domain.DiffSegment{{Op: domain.DiffOpInsert, Text: "乙"}},
+	}, report.Changes[1])
+	assert.Equal(t, domain.TranslationChangeRemoved, report.Changes[2].Change)
+	assert.Equal(t, "C", report.Changes[2].OldValue)
+	assert.Equal(t, "g", report.Changes[3].KeyName)
+	assert.Equal(t, "f", report.Changes[3].PreviousKey)
+	assert.Equal(t, domain.TranslationChangeRenamed, report.Changes[3].Change)
+
+	_, err = svc.GetChanges(ctx, domain.TranslationChangesParams{ProjectID: 1, From: day, To: day})
+	assert.ErrorIs(t, err, domain.ErrInvalidHistoryRange)
+	_, err = svc.GetChanges(ctx, domain.TranslationChangesParams{ProjectID: 2, From: day, To: day.AddDate(0, 0, 1)})
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeMemoryRepository 返回固定的候选翻译并记录查询条件
+type fakeMemoryRepository struct {
+	candidates []*domain.TMCandidate
+	query      domain.TMCandidateQuery
+}
+
+func (r *fakeMemoryRepository) FindCandidates(ctx context.Context, query domain.TMCandidateQuery) ([]*domain.TMCandidate, error) {
+	r.query = query
+	return r.candidates, nil
+}
+
+// tmUsers 用户 1 为管理员，其他用户为普通成员
+type tmUsers struct {
+	domain.UserRepository
+}
+
+func (tmUsers) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
+	if id == 1 {
+		return &domain.User{ID: 1, Role: "admin"}, nil
+	}
+	return &domain.User{ID: id, Role: "member"}, nil
+}
+
+// tmMembers 用户 2 参与项目 7
+type tmMembers struct {
+	domain.ProjectMemberRepository
+}
+
+func (tmMembers) GetByUserID(ctx context.Context, userID uint64) ([]*domain.ProjectMember, error) {
+	if userID == 2 {
+		return []*domain.ProjectMember{{ProjectID: 7, UserID: 2}}, nil
+	}
+	return nil, nil
+}
+
+func TestTranslationMemory_Suggest(t *testing.T) {
+	now := time.Now()
+	repo := &fakeMemoryRepository{candidates: []*domain.TMCandidate{
+		{ProjectID: 3, KeyName: "a.save", SourceText: "Save changes", TargetText: "Änderungen speichern", UpdatedAt: now},
+		{ProjectID: 7, KeyName: "b.save", SourceText: "Save  CHANGES", TargetText: "Änderungen speichern", UpdatedAt: now.Add(-time.Hour)},
+		{ProjectID: 7, KeyName: "b.save_all", SourceText: "Save all changes", TargetText: "Alle Änderungen speichern", UpdatedAt: now},
+		{ProjectID: 3, KeyName: "a.title", SourceText: "Settings page", TargetText: "Einstellungen", UpdatedAt: now},
+	}}
+	svc := service.NewTranslationMemoryService(repo, preTranslateLanguages{}, tmUsers{}, tmMembers{})
+
+	result, err := svc.Suggest(context.Background(), 1, domain.TMSuggestQuery{Text: "Save changes", TargetLanguage: "de"})
+	require.NoError(t, err)
+	assert.Equal(t, "en", result.SourceLanguage)
+	assert.Nil(t, repo.query.ProjectIDs, "管理员不限项目")
+	assert.Equal(t, uint64(1), repo.query.SourceLanguageID)
+	assert.Equal(t, uint64(2), repo.query.TargetLanguageID)
+	assert.Equal(t, 6, repo.query.MinLength)
+	assert.Equal(t, 24, repo.query.MaxLength)
+	assert.Equal(t, []string{"changes", "Save"}, repo.query.Terms)
+
+	// 忽略大小写和多余空白后相同的原文合并，不相似的原文不返回
+	require.Len(t, result.Suggestions, 2)
+	exact := result.Suggestions[0]
+	assert.Equal(t, 1.0, exact.Score)
+	assert.Equal(t, 2, exact.Occurrences)
+	assert.Equal(t, "a.save", exact.KeyName, "保留最近更新的键")
+	assert.Equal(t, "Alle Änderungen speichern", result.Suggestions[1].TargetText)
+	assert.Equal(t, 0.75, result.Suggestions[1].Score)
+
+	// 普通用户只使用所参与项目的翻译
+	_, err = svc.Suggest(context.Background(), 2, domain.TMSuggestQuery{Text: "Save changes", TargetLanguage: "de", MinScore: 0.8})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{7}, repo.query.ProjectIDs)
+	assert.Equal(t, 9, repo.query.MinLength)
+	assert.Equal(t, 15, repo.query.MaxLength)
+
+	// 没有参与任何项目时不查询
+	repo.query = domain.TMCandidateQuery{}
+	result, err = svc.Suggest(context.Background(), 3, domain.TMSuggestQuery{Text: "Save changes", TargetLanguage: "de"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Suggestions)
+	assert.Zero(t, repo.query.TargetLanguageID)
+}
+
+func TestTranslationMemory_SuggestValidation(t *testing.T) {
+	svc := service.NewTranslationMemoryService(&fakeMemoryRepository{}, preTranslateLanguages{}, tmUsers{}, tmMembers{})
+	ctx := context.Background()
+
+	_, err := svc.Suggest(ctx, 1, domain.TMSuggestQuery{Text: " ", TargetLanguage: "de"})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	_, err = svc.Suggest(ctx, 1, domain.TMSuggestQuery{Text: "Save", TargetLanguage: "ja"})
+	assert.ErrorIs(t, err, domain.ErrLanguageNotFound)
+	_, err = svc.Suggest(ctx, 1, domain.TMSuggestQuery{Text: "Save", SourceLanguage: "de", TargetLanguage: "de"})
+	assert.ErrorIs(t, err, domain.ErrIsSourceLanguage)
+}
+
+func TestTranslationMemory_CJKSearchTerms(t *testing.T) {
+	repo := &fakeMemoryRepository{}
+	svc := service.NewTranslationMemoryService(repo, preTranslateLanguages{}, tmUsers{}, tmMembers{})
+
+	_, err := svc.Suggest(context.Background(), 1, domain.TMSuggestQuery{Text: "保存更改", SourceLanguage: "de", TargetLanguage: "fr"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"保存", "存更", "更改"}, repo.query.Terms)
+}
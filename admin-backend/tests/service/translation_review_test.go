@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// reviewTranslations 内存中的翻译，只实现审核用到的方法
+type reviewTranslations struct {
+	domain.TranslationRepository
+	translations map[uint64]*domain.Translation
+	updates      int
+}
+
+func newReviewTranslations() *reviewTranslations {
+	return &reviewTranslations{translations: map[uint64]*domain.Translation{
+		1: {ID: 1, ProjectID: 1, KeyName: "home.title", Value: "Welcome", ReviewStatus: domain.ReviewStatusDraft},
+		2: {ID: 2, ProjectID: 1, KeyName: "home.empty", Value: "", ReviewStatus: domain.ReviewStatusDraft},
+		3: {ID: 3, ProjectID: 1, KeyName: "home.done", Value: "Done", ReviewStatus: domain.ReviewStatusApproved},
+		4: {ID: 4, ProjectID: 2, KeyName: "other", Value: "Other", ReviewStatus: domain.ReviewStatusDraft},
+	}}
+}
+
+func (r *reviewTranslations) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	for _, id := range ids {
+		if translation, ok := r.translations[id]; ok {
+			copied := *translation
+			translations = append(translations, &copied)
+		}
+	}
+	return translations, nil
+}
+
+func (r *reviewTranslations) UpdateReviewStatus(ctx context.Context, ids []uint64, reviewStatus string, userID uint64, comment string) (int64, error) {
+	r.updates++
+	for _, id := range ids {
+		r.translations[id].ReviewStatus = reviewStatus
+		r.translations[id].ReviewComment = comment
+	}
+	return int64(len(ids)), nil
+}
+
+func TestTranslationService_ReviewTransitions(t *testing.T) {
+	ctx := context.Background()
+	repo := newReviewTranslations()
+	svc := service.NewTranslationService(repo, preTranslateProjects{}, nil, nil, nil, nil, nil)
+
+	// 提交审核：草稿 → 待审核，重复的 ID 只计一次
+	result, err := svc.SubmitForReview(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1, 1}}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ReviewTransitionResult{ReviewStatus: domain.ReviewStatusInReview, Translations: 1}, result)
+
+	// 待审核 → 审核通过
+	result, err = svc.ApproveTranslations(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1}}, 8)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewStatusApproved, result.ReviewStatus)
+	assert.Equal(t, domain.ReviewStatusApproved, repo.translations[1].ReviewStatus)
+
+	// 审核通过的翻译可以驳回为草稿，保存驳回原因
+	result, err = svc.RejectTranslations(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1, 3}, Comment: "  wrong tone "}, 8)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Translations)
+	assert.Equal(t, domain.ReviewStatusDraft, repo.translations[3].ReviewStatus)
+	assert.Equal(t, "wrong tone", repo.translations[3].ReviewComment)
+	assert.Equal(t, 3, repo.updates)
+}
+
+func TestTranslationService_ReviewTransitionsRejectInvalid(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		run      func(svc *service.TranslationService) error
+		wantCode string
+		details  string
+	}{
+		{"空ID列表", func(svc *service.TranslationService) error {
+			_, err := svc.SubmitForReview(ctx, 1, domain.ReviewTransitionParams{}, 1)
+			return err
+		}, domain.ErrInvalidInput.Code, ""},
+		{"空译文不能提交", func(svc *service.TranslationService) error {
+			_, err := svc.SubmitForReview(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1, 2}}, 1)
+			return err
+		}, domain.ErrInvalidReviewTransition.Code, "home.empty"},
+		{"草稿不能直接通过", func(svc *service.TranslationService) error {
+			_, err := svc.ApproveTranslations(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1}}, 1)
+			return err
+		}, domain.ErrInvalidReviewTransition.Code, "home.title"},
+		{"草稿不能驳回", func(svc *service.TranslationService) error {
+			_, err := svc.RejectTranslations(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1}}, 1)
+			return err
+		}, domain.ErrInvalidReviewTransition.Code, "draft"},
+		{"审核通过的翻译不能再次提交", func(svc *service.TranslationService) error {
+			_, err := svc.SubmitForReview(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{3}}, 1)
+			return err
+		}, domain.ErrInvalidReviewTransition.Code, "approved"},
+		{"其他项目的翻译", func(svc *service.TranslationService) error {
+			_, err := svc.SubmitForReview(ctx, 1, domain.ReviewTransitionParams{TranslationIDs: []uint64{1, 4, 99}}, 1)
+			return err
+		}, domain.ErrTranslationNotFound.Code, "4, 99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newReviewTranslations()
+			err := tt.run(service.NewTranslationService(repo, preTranslateProjects{}, nil, nil, nil, nil, nil))
+			appErr, ok := domain.IsAppError(err)
+			require.True(t, ok, "unexpected error: %v", err)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+			assert.Contains(t, appErr.Details, tt.details)
+			// 任一翻译不满足条件时不做任何修改
+			assert.Zero(t, repo.updates)
+		})
+	}
+}
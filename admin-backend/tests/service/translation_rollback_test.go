@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// rollbackTranslations 内存中的翻译，记录写入时的变更历史操作类型
+type rollbackTranslations struct {
+	domain.TranslationRepository
+	translations map[uint64]*domain.Translation
+	operations   []string
+}
+
+func (r *rollbackTranslations) GetByID(ctx context.Context, id uint64) (*domain.Translation, error) {
+	if translation, ok := r.translations[id]; ok {
+		copied := *translation
+		return &copied, nil
+	}
+	return nil, domain.ErrTranslationNotFound
+}
+
+func (r *rollbackTranslations) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	for _, id := range ids {
+		if translation, ok := r.translations[id]; ok {
+			copied := *translation
+			translations = append(translations, &copied)
+		}
+	}
+	return translations, nil
+}
+
+func (r *rollbackTranslations) Update(ctx context.Context, translation *domain.Translation) error {
+	r.translations[translation.ID] = translation
+	r.operations = append(r.operations, domain.HistoryOperationFromContext(ctx))
+	return nil
+}
+
+// rollbackHistory 内存中的变更历史
+type rollbackHistory struct {
+	domain.TranslationHistoryRepository
+	entries  map[uint64]*domain.TranslationHistory
+	versions map[uint64]*domain.TranslationHistory
+}
+
+func (r *rollbackHistory) GetByID(ctx context.Context, id uint64) (*domain.TranslationHistory, error) {
+	if entry, ok := r.entries[id]; ok {
+		return entry, nil
+	}
+	return nil, domain.ErrHistoryNotFound
+}
+
+func (r *rollbackHistory) GetVersionsAt(ctx context.Context, projectID uint64, at time.Time) (map[uint64]*domain.TranslationHistory, error) {
+	return r.versions, nil
+}
+
+func newRollbackTranslations() *rollbackTranslations {
+	return &rollbackTranslations{translations: map[uint64]*domain.Translation{
+		1: {ID: 1, ProjectID: 1, KeyName: "home.title", Value: "Welcome!", ReviewStatus: domain.ReviewStatusApproved},
+		2: {ID: 2, ProjectID: 1, KeyName: "home.cta", Value: "Buy", ReviewStatus: domain.ReviewStatusApproved},
+		3: {ID: 3, ProjectID: 1, KeyName: "home.new", Value: "New"},
+	}}
+}
+
+func TestTranslationRollbackService_RollbackTranslation(t *testing.T) {
+	ctx := context.Background()
+	repo := newRollbackTranslations()
+	history := &rollbackHistory{entries: map[uint64]*domain.TranslationHistory{
+		10: {ID: 10, TranslationID: 1, Operation: domain.HistoryOperationCreate, NewValue: "Welcome"},
+		11: {ID: 11, TranslationID: 1, Operation: domain.HistoryOperationDelete},
+		12: {ID: 12, TranslationID: 2, Operation: domain.HistoryOperationCreate, NewValue: "Buy now"},
+	}}
+	svc := service.NewTranslationRollbackService(repo, history, preTranslateProjects{}, sourceLanguages{}, noopAuditLogs{}, nil, nil)
+
+	translation, err := svc.RollbackTranslation(ctx, 1, 10, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome", translation.Value)
+	assert.Equal(t, domain.ReviewStatusDraft, translation.ReviewStatus)
+	assert.Equal(t, uint64(7), translation.UpdatedBy)
+	assert.Equal(t, []string{domain.HistoryOperationRollback}, repo.operations)
+
+	// 值已相同时不写入
+	_, err = svc.RollbackTranslation(ctx, 1, 10, 7)
+	require.NoError(t, err)
+	assert.Len(t, repo.operations, 1)
+
+	// 变更历史必须属于该翻译
+	_, err = svc.RollbackTranslation(ctx, 1, 12, 7)
+	assert.ErrorIs(t, err, domain.ErrHistoryNotFound)
+
+	// 不能回滚到删除操作
+	_, err = svc.RollbackTranslation(ctx, 1, 11, 7)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrInvalidRollback.Code, appErr.Code)
+}
+
+func TestTranslationRollbackService_RollbackProject(t *testing.T) {
+	ctx := context.Background()
+	repo := newRollbackTranslations()
+	history := &rollbackHistory{versions: map[uint64]*domain.TranslationHistory{
+		1: {TranslationID: 1, Operation: domain.HistoryOperationUpdate, NewValue: "Welcome"},
+		2: {TranslationID: 2, Operation: domain.HistoryOperationCreate, NewValue: "Buy"},  // 值未变
+		3: nil,                                                                            // 之后才创建
+		4: {TranslationID: 4, Operation: domain.HistoryOperationCreate, NewValue: "Gone"}, // 当前已删除
+	}}
+	svc := service.NewTranslationRollbackService(repo, history, preTranslateProjects{}, sourceLanguages{}, noopAuditLogs{}, nil, nil)
+
+	result, err := svc.RollbackProject(ctx, 1, domain.ProjectRollbackParams{To: time.Now().Add(-time.Hour)}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Translations)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, "Welcome", repo.translations[1].Value)
+	assert.Equal(t, "New", repo.translations[3].Value)
+	assert.Equal(t, []string{domain.HistoryOperationRollback}, repo.operations)
+
+	// 回滚时刻不能晚于当前时间
+	_, err = svc.RollbackProject(ctx, 1, domain.ProjectRollbackParams{To: time.Now().Add(time.Hour)}, 7)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok, "unexpected error: %v", err)
+	assert.Equal(t, domain.ErrInvalidRollback.Code, appErr.Code)
+}
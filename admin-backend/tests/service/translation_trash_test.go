@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// trashTranslations 内存中的翻译，DeletedAt 有效的为已删除的翻译
+type trashTranslations struct {
+	domain.TranslationRepository
+	translations map[uint64]*domain.Translation
+	purgeBefore  time.Time
+}
+
+func (r *trashTranslations) GetDeletedByID(ctx context.Context, id uint64) (*domain.Translation, error) {
+	if translation, ok := r.translations[id]; ok && translation.DeletedAt.Valid {
+		copied := *translation
+		return &copied, nil
+	}
+	return nil, domain.ErrTranslationNotFound
+}
+
+func (r *trashTranslations) GetByProjectKey(ctx context.Context, projectID uint64, keyName string) ([]*domain.Translation, error) {
+	var translations []*domain.Translation
+	for _, translation := range r.translations {
+		if translation.ProjectID == projectID && translation.KeyName == keyName && !translation.DeletedAt.Valid {
+			translations = append(translations, translation)
+		}
+	}
+	return translations, nil
+}
+
+func (r *trashTranslations) Restore(ctx context.Context, translation *domain.Translation, userID uint64) error {
+	translation.DeletedAt = gorm.DeletedAt{}
+	translation.UpdatedBy = userID
+	r.translations[translation.ID] = translation
+	return nil
+}
+
+func (r *trashTranslations) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int64, error) {
+	r.purgeBefore = before
+	var purged int64
+	for id, translation := range r.translations {
+		if translation.DeletedAt.Valid && translation.DeletedAt.Time.Before(before) {
+			delete(r.translations, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// deletedAt 指定时间删除
+func deletedAt(at time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: at, Valid: true}
+}
+
+func TestTranslationTrashService_RestoreAlignsKeyMetadata(t *testing.T) {
+	ctx := context.Background()
+	repo := &trashTranslations{translations: map[uint64]*domain.Translation{
+		1: {ID: 1, ProjectID: 1, KeyName: "home.title", LanguageID: 1, Value: "Home", ValueType: domain.ValueTypeString, DeletedAt: deletedAt(time.Now())},
+		2: {ID: 2, ProjectID: 1, KeyName: "home.title", LanguageID: 2, Value: "Startseite", ValueType: domain.ValueTypeMarkdown, MaxLength: 40, NamespaceID: 3},
+		3: {ID: 3, ProjectID: 1, KeyName: "home.cta", LanguageID: 1, Value: "Buy"},
+	}}
+	svc := service.NewTranslationTrashService(repo, preTranslateProjects{}, nil, nil, 24*time.Hour, 0, nil)
+
+	restored, err := svc.Restore(ctx, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Home", restored.Value)
+	assert.Equal(t, domain.ValueTypeMarkdown, restored.ValueType)
+	assert.Equal(t, 40, restored.MaxLength)
+	assert.Equal(t, uint64(3), restored.NamespaceID)
+	assert.Equal(t, uint64(7), restored.UpdatedBy)
+	assert.False(t, repo.translations[1].DeletedAt.Valid)
+
+	// 未删除或不存在的翻译不能恢复
+	_, err = svc.Restore(ctx, 3, 7)
+	assert.ErrorIs(t, err, domain.ErrTranslationNotFound)
+	_, err = svc.Restore(ctx, 99, 7)
+	assert.ErrorIs(t, err, domain.ErrTranslationNotFound)
+}
+
+func TestTranslationTrashService_PurgeAfterRetention(t *testing.T) {
+	ctx := context.Background()
+	repo := &trashTranslations{translations: map[uint64]*domain.Translation{
+		1: {ID: 1, ProjectID: 1, KeyName: "old", DeletedAt: deletedAt(time.Now().Add(-48 * time.Hour))},
+		2: {ID: 2, ProjectID: 1, KeyName: "recent", DeletedAt: deletedAt(time.Now().Add(-time.Hour))},
+		3: {ID: 3, ProjectID: 1, KeyName: "active"},
+	}}
+
+	purged, err := service.NewTranslationTrashService(repo, preTranslateProjects{}, nil, nil, 24*time.Hour, 0, nil).Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	assert.NotContains(t, repo.translations, uint64(1))
+	assert.Contains(t, repo.translations, uint64(2))
+	assert.Contains(t, repo.translations, uint64(3))
+
+	// 保留期限为 0 时不清除
+	repo.purgeBefore = time.Time{}
+	purged, err = service.NewTranslationTrashService(repo, preTranslateProjects{}, nil, nil, 0, 0, nil).Purge(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	assert.True(t, repo.purgeBefore.IsZero())
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// digestProjects 固定的项目列表，分页返回
+type digestProjects struct {
+	domain.ProjectRepository
+	projects []*domain.Project
+}
+
+func (r *digestProjects) GetAll(ctx context.Context, limit, offset int, keyword string, sort []domain.SortField) ([]*domain.Project, int64, error) {
+	end := offset + limit
+	if end > len(r.projects) {
+		end = len(r.projects)
+	}
+	if offset >= end {
+		return nil, int64(len(r.projects)), nil
+	}
+	return r.projects[offset:end], int64(len(r.projects)), nil
+}
+
+// digestMembers 按项目划分的成员关系
+type digestMembers struct {
+	domain.ProjectMemberRepository
+	members map[uint64][]*domain.ProjectMember
+}
+
+func (r *digestMembers) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ProjectMember, error) {
+	return r.members[projectID], nil
+}
+
+// digestTranslations 按项目和语言ID给出待翻译的键
+type digestTranslations struct {
+	domain.TranslationRepository
+	pending map[uint64]map[uint64][]string
+}
+
+func (r *digestTranslations) GetPendingKeys(ctx context.Context, query domain.PendingKeyQuery) (int64, []string, error) {
+	keys := r.pending[query.ProjectID][query.LanguageID]
+	if len(keys) > query.Limit {
+		return int64(len(keys)), keys[:query.Limit], nil
+	}
+	return int64(len(keys)), keys, nil
+}
+
+func TestUntranslatedDigest_Send(t *testing.T) {
+	users := &bulkMemberUsers{users: []*domain.User{
+		{ID: 1, Username: "alice", Email: "alice@example.com", Status: "active"},
+		{ID: 2, Username: "bob", Email: "bob@example.com", Status: "active"},
+		{ID: 3, Username: "carol", Email: "carol@example.com", Status: "disabled"},
+		{ID: 4, Username: "dave", Email: "dave@example.com", Status: "active"},
+	}}
+	projects := &digestProjects{projects: []*domain.Project{
+		{ID: 1, Name: "Web", Status: "active"},
+		{ID: 2, Name: "Done", Status: "active"},
+		{ID: 3, Name: "Old", Status: "archived"},
+	}}
+	members := &digestMembers{members: map[uint64][]*domain.ProjectMember{
+		1: {{UserID: 1, Role: "owner"}, {UserID: 2, Role: "viewer"}, {UserID: 3, Role: "editor"}, {UserID: 4, Role: "editor"}},
+		2: {{UserID: 1, Role: "owner"}},
+		3: {{UserID: 1, Role: "owner"}},
+	}}
+	many := make([]string, 12)
+	for i := range many {
+		many[i] = "key." + string(rune('a'+i))
+	}
+	translations := &digestTranslations{pending: map[uint64]map[uint64][]string{
+		1: {2: many, 3: {"home.title"}},
+		3: {2: {"home.title"}},
+	}}
+	mailer := &fakeMailer{enabled: true, failFor: "dave@example.com"}
+	digest := service.NewUntranslatedDigest(projects, members, users, preTranslateLanguages{}, translations,
+		service.NewEmailNotifier(mailer, nil), "https://yflow.example.com", 0, nil)
+
+	// 只向活跃项目中启用的所有者和编辑者发送，没有待翻译键的项目不发送；单封发送失败不影响其他邮件
+	sent, err := digest.Send(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, mailer.sent, 1)
+	assert.Equal(t, "YFlow 待翻译摘要：Web（13 个键）", mailer.subjects["alice@example.com"])
+	body := mailer.sent["alice@example.com"]
+	assert.Contains(t, body, "alice，您好")
+	assert.Contains(t, body, "（de）：12 个")
+	assert.Contains(t, body, "  - key.j\n  …")
+	assert.NotContains(t, body, "key.k", "每个语言最多列出 10 个键")
+	assert.Contains(t, body, "（fr）：1 个\n  - home.title\n")
+	assert.NotContains(t, body, "（en）")
+
+	mailer.enabled = false
+	_, err = digest.Send(context.Background())
+	assert.ErrorIs(t, err, domain.ErrMailNotConfigured)
+}
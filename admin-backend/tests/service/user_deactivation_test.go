@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// transferUsers 内存中的用户
+type transferUsers struct {
+	domain.UserRepository
+	users map[uint64]*domain.User
+}
+
+func (r *transferUsers) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *transferUsers) Update(ctx context.Context, user *domain.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+// transferMembers 内存中的项目成员关系
+type transferMembers struct {
+	domain.ProjectMemberRepository
+	members []*domain.ProjectMember
+}
+
+func (r *transferMembers) GetByUserID(ctx context.Context, userID uint64) ([]*domain.ProjectMember, error) {
+	var members []*domain.ProjectMember
+	for _, member := range r.members {
+		if member.UserID == userID {
+			copied := *member
+			members = append(members, &copied)
+		}
+	}
+	return members, nil
+}
+
+func (r *transferMembers) GetByProjectID(ctx context.Context, projectID uint64) ([]*domain.ProjectMember, error) {
+	var members []*domain.ProjectMember
+	for _, member := range r.members {
+		if member.ProjectID == projectID {
+			copied := *member
+			members = append(members, &copied)
+		}
+	}
+	return members, nil
+}
+
+func (r *transferMembers) GetByProjectAndUser(ctx context.Context, projectID, userID uint64) (*domain.ProjectMember, error) {
+	for _, member := range r.members {
+		if member.ProjectID == projectID && member.UserID == userID {
+			copied := *member
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrMemberNotFound
+}
+
+func (r *transferMembers) CreateOrRestore(ctx context.Context, member *domain.ProjectMember) error {
+	if existing, _ := r.GetByProjectAndUser(ctx, member.ProjectID, member.UserID); existing != nil {
+		return r.Update(ctx, member)
+	}
+	copied := *member
+	r.members = append(r.members, &copied)
+	return nil
+}
+
+func (r *transferMembers) Update(ctx context.Context, member *domain.ProjectMember) error {
+	for _, existing := range r.members {
+		if existing.ProjectID == member.ProjectID && existing.UserID == member.UserID {
+			existing.Role = member.Role
+		}
+	}
+	return nil
+}
+
+func (r *transferMembers) role(projectID, userID uint64) string {
+	member, _ := r.GetByProjectAndUser(context.Background(), projectID, userID)
+	if member == nil {
+		return ""
+	}
+	return member.Role
+}
+
+// transferInvitations 记录邀请码的邀请人
+type transferInvitations struct {
+	domain.UserOffboardingRepository
+	inviters map[string]uint64 // 有效的邀请码 -> 邀请人
+}
+
+func (r *transferInvitations) ReassignInvitations(ctx context.Context, fromID, toID uint64) (int64, error) {
+	var count int64
+	for code, inviterID := range r.inviters {
+		if inviterID == fromID {
+			r.inviters[code] = toID
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *transferInvitations) CountActiveInvitations(ctx context.Context, inviterID uint64) (int64, error) {
+	var count int64
+	for _, id := range r.inviters {
+		if id == inviterID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestUserService_TransferOwnership(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 1)
+	users := &transferUsers{users: map[uint64]*domain.User{
+		2: {ID: 2, Username: "leaving", Role: "member", Status: "active"},
+		3: {ID: 3, Username: "successor", Role: "member", Status: "active"},
+		4: {ID: 4, Username: "disabled", Role: "member", Status: "disabled"},
+	}}
+	members := &transferMembers{members: []*domain.ProjectMember{
+		{ProjectID: 10, UserID: 2, Role: "owner"},
+		{ProjectID: 10, UserID: 3, Role: "viewer"},
+		{ProjectID: 11, UserID: 2, Role: "owner"},
+		{ProjectID: 11, UserID: 3, Role: "owner"},
+		{ProjectID: 12, UserID: 2, Role: "editor"},
+	}}
+	invitations := &transferInvitations{inviters: map[string]uint64{"a": 2, "b": 2, "c": 1}}
+	auditLogs := &recordedAuditLogs{}
+	svc := service.NewUserService(users, nil, nil, nil, members, invitations, auditLogs, nil)
+
+	// 唯一 owner 的项目和有效的邀请码需要先转移
+	err := svc.DeleteUser(ctx, 2)
+	require.Error(t, err)
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrUserOwnsResources.Code, appErr.Code)
+	assert.Equal(t, "唯一 owner 的项目：[10]，有效的邀请码：2", appErr.Details)
+
+	for _, target := range []uint64{2, 4, 99} {
+		_, err = svc.TransferOwnership(ctx, 2, domain.TransferOwnershipParams{ToUserID: target})
+		assert.ErrorIs(t, err, domain.ErrInvalidReassignTarget, "target %d", target)
+	}
+	_, err = svc.TransferOwnership(ctx, 99, domain.TransferOwnershipParams{ToUserID: 3})
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	result, err := svc.TransferOwnership(ctx, 2, domain.TransferOwnershipParams{ToUserID: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10, 11}, result.Projects)
+	assert.Equal(t, int64(2), result.InvitationsReassigned)
+	assert.Equal(t, "owner", members.role(10, 3))
+	assert.Equal(t, "editor", members.role(10, 2))
+	assert.Equal(t, "owner", members.role(11, 3))
+	assert.Equal(t, "editor", members.role(11, 2))
+	assert.Equal(t, "editor", members.role(12, 2))
+	assert.Equal(t, map[string]uint64{"a": 3, "b": 3, "c": 1}, invitations.inviters)
+
+	require.Len(t, auditLogs.logs, 1)
+	assert.Equal(t, uint64(0), auditLogs.logs[0].ProjectID)
+	assert.Equal(t, uint64(1), auditLogs.logs[0].UserID)
+	assert.Equal(t, domain.AuditActionUserTransfer, auditLogs.logs[0].Action)
+
+	// 没有可转移的内容时不记录审计日志
+	result, err = svc.TransferOwnership(ctx, 2, domain.TransferOwnershipParams{ToUserID: 3})
+	require.NoError(t, err)
+	assert.Empty(t, result.Projects)
+	assert.Len(t, auditLogs.logs, 1)
+}
+
+func TestUserService_DeactivateUser(t *testing.T) {
+	ctx := domain.WithActor(context.Background(), 1)
+	users := &transferUsers{users: map[uint64]*domain.User{
+		1: {ID: 1, Username: "admin", Role: "admin", Status: "active"},
+		2: {ID: 2, Username: "leaving", Password: "hash", Role: "member", Status: "active"},
+	}}
+	auditLogs := &recordedAuditLogs{}
+	svc := service.NewUserService(users, nil, nil, nil, nil, nil, auditLogs, nil)
+
+	user, err := svc.DeactivateUser(ctx, 2, domain.DeactivateUserParams{Reason: "contract ended"})
+	require.NoError(t, err)
+	assert.Equal(t, "disabled", user.Status)
+	assert.Empty(t, user.Password)
+	assert.Equal(t, "disabled", users.users[2].Status)
+	assert.Equal(t, uint64(1), users.users[2].UpdatedBy)
+	require.Len(t, auditLogs.logs, 1)
+	assert.Equal(t, domain.AuditActionUserDeactivate, auditLogs.logs[0].Action)
+	assert.Contains(t, string(auditLogs.logs[0].Details), "contract ended")
+
+	// 已停用时不重复记录
+	_, err = svc.DeactivateUser(ctx, 2, domain.DeactivateUserParams{})
+	require.NoError(t, err)
+	assert.Len(t, auditLogs.logs, 1)
+
+	_, err = svc.DeactivateUser(ctx, 1, domain.DeactivateUserParams{})
+	assert.ErrorIs(t, err, domain.ErrCannotDeactivateAdmin)
+}
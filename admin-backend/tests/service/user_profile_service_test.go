@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// profileUsers 内存中的单个用户，记录个人资料更新次数
+type profileUsers struct {
+	domain.UserRepository
+	user    domain.User
+	updates int
+}
+
+func (r *profileUsers) GetByID(ctx context.Context, id uint64) (*domain.User, error) {
+	if id != r.user.ID {
+		return nil, domain.ErrUserNotFound
+	}
+	user := r.user
+	return &user, nil
+}
+
+func (r *profileUsers) UpdateProfile(ctx context.Context, user *domain.User) error {
+	r.user.DisplayName = user.DisplayName
+	r.user.AvatarKey = user.AvatarKey
+	r.user.Language = user.Language
+	r.user.Timezone = user.Timezone
+	r.updates++
+	return nil
+}
+
+// pngImage 生成 1x1 的 PNG 图片
+func pngImage(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+	return buf.Bytes()
+}
+
+func TestUserProfileService_UpdateProfile(t *testing.T) {
+	ctx := context.Background()
+	users := &profileUsers{user: domain.User{ID: 7, Username: "anna", Password: "hash"}}
+	svc := service.NewUserProfileService(users, service.NewLocalAttachmentStorage(t.TempDir()), nil)
+
+	user, err := svc.UpdateProfile(ctx, 7, domain.UpdateUserProfileParams{
+		DisplayName: "  Anna Li ",
+		Language:    "zh-cn",
+		Timezone:    "Asia/Shanghai",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Anna Li", user.DisplayName)
+	assert.Equal(t, "zh-CN", user.Language)
+	assert.Equal(t, "Asia/Shanghai", user.Timezone)
+	assert.Empty(t, user.Password)
+	assert.Equal(t, "zh-CN", users.user.Language)
+
+	// 空值清除
+	_, err = svc.UpdateProfile(ctx, 7, domain.UpdateUserProfileParams{})
+	require.NoError(t, err)
+	assert.Empty(t, users.user.DisplayName)
+	assert.Empty(t, users.user.Language)
+	assert.Empty(t, users.user.Timezone)
+
+	for _, params := range []domain.UpdateUserProfileParams{
+		{Language: "not a language"},
+		{Timezone: "Mars/Olympus"},
+		{Timezone: "Local"},
+		{DisplayName: string(bytes.Repeat([]byte("a"), 101))},
+	} {
+		_, err = svc.UpdateProfile(ctx, 7, params)
+		assert.ErrorContains(t, err, domain.ErrInvalidUserProfile.Code, "%+v", params)
+	}
+	assert.Equal(t, 2, users.updates)
+
+	_, err = svc.UpdateProfile(ctx, 8, domain.UpdateUserProfileParams{})
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestUserProfileService_Avatar(t *testing.T) {
+	ctx := context.Background()
+	users := &profileUsers{user: domain.User{ID: 7, Username: "anna"}}
+	storage := service.NewLocalAttachmentStorage(t.TempDir())
+	svc := service.NewUserProfileService(users, storage, nil)
+
+	_, _, err := svc.GetAvatar(ctx, 7)
+	assert.ErrorIs(t, err, domain.ErrAvatarNotFound)
+
+	_, err = svc.UploadAvatar(ctx, 7, []byte("plain text"))
+	assert.ErrorContains(t, err, domain.ErrInvalidAvatar.Code)
+	_, err = svc.UploadAvatar(ctx, 7, make([]byte, service.MaxAvatarSize+1))
+	assert.ErrorContains(t, err, domain.ErrInvalidAvatar.Code)
+	assert.Equal(t, 0, users.updates)
+
+	data := pngImage(t)
+	user, err := svc.UploadAvatar(ctx, 7, data)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/users/7/avatar", user.AvatarURL())
+	firstKey := users.user.AvatarKey
+	assert.Regexp(t, `^avatars/7/[0-9a-f]{32}\.png$`, firstKey)
+
+	content, contentType, err := svc.GetAvatar(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, data, content)
+	assert.Equal(t, "image/png", contentType)
+
+	// 替换头像后删除原有文件
+	_, err = svc.UploadAvatar(ctx, 7, data)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstKey, users.user.AvatarKey)
+	_, err = storage.Get(ctx, firstKey)
+	assert.ErrorIs(t, err, domain.ErrAttachmentFileMissing)
+
+	secondKey := users.user.AvatarKey
+	user, err = svc.DeleteAvatar(ctx, 7)
+	require.NoError(t, err)
+	assert.Empty(t, user.AvatarURL())
+	assert.Empty(t, users.user.AvatarKey)
+	_, err = storage.Get(ctx, secondKey)
+	assert.ErrorIs(t, err, domain.ErrAttachmentFileMissing)
+
+	// 没有头像时删除不更新
+	updates := users.updates
+	_, err = svc.DeleteAvatar(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, updates, users.updates)
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeLanguageRepository 只实现按 ID 批量查询的语言仓储
+type fakeLanguageRepository struct {
+	domain.LanguageRepository
+	languages map[uint64]*domain.Language
+}
+
+func (r *fakeLanguageRepository) GetByIDs(ctx context.Context, ids []uint64) ([]*domain.Language, error) {
+	var languages []*domain.Language
+	for _, id := range ids {
+		if language, ok := r.languages[id]; ok {
+			languages = append(languages, language)
+		}
+	}
+	return languages, nil
+}
+
+// publishTranslationUpdated 通过事件总线发布翻译变更事件
+func publishTranslationUpdated(t *testing.T, bus domain.EventBus, projectID uint64, payload domain.TranslationUpdatedPayload) {
+	t.Helper()
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, projectID, payload)
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), event))
+}
+
+func TestWatchHub_FiltersByProjectAndLocale(t *testing.T) {
+	hub := service.NewWatchHub(&fakeLanguageRepository{languages: map[uint64]*domain.Language{
+		1: {ID: 1, Code: "en"},
+		2: {ID: 2, Code: "zh-CN"},
+	}}, nil, nil)
+	bus := service.NewInMemoryEventBus(nil)
+	bus.Subscribe(domain.EventTranslationUpdated, "cli-watch", hub.HandleEvent)
+
+	all, cancelAll := hub.Watch(10, nil)
+	defer cancelAll()
+	english, cancelEnglish := hub.Watch(10, []string{"en"})
+	defer cancelEnglish()
+
+	publishTranslationUpdated(t, bus, 10, domain.TranslationUpdatedPayload{Action: domain.TranslationActionUpdated, KeyNames: []string{"home.title"}, LanguageIDs: []uint64{2}, Count: 1})
+	publishTranslationUpdated(t, bus, 10, domain.TranslationUpdatedPayload{Action: domain.TranslationActionDeleted, KeyNames: []string{"home.old"}, Count: 2})
+	publishTranslationUpdated(t, bus, 11, domain.TranslationUpdatedPayload{Action: domain.TranslationActionCreated, Count: 1})
+
+	require.Len(t, all, 2)
+	event := <-all
+	assert.Equal(t, domain.WatchMessageChanged, event.Type)
+	assert.Equal(t, []string{"zh-CN"}, event.Locales)
+	assert.Equal(t, []string{"home.title"}, event.KeyNames)
+
+	// 语言未知的变更推送给所有监听连接
+	require.Len(t, english, 1)
+	event = <-english
+	assert.Equal(t, domain.TranslationActionDeleted, event.Action)
+}
+
+func TestWatchHub_ClosesSlowWatcher(t *testing.T) {
+	hub := service.NewWatchHub(&fakeLanguageRepository{}, nil, nil)
+	events, cancel := hub.Watch(1, nil)
+	defer cancel()
+
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, 1, domain.TranslationUpdatedPayload{Action: domain.TranslationActionUpdated})
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Less(t, received, 100)
+
+	// 通道关闭后取消订阅不会重复关闭
+	cancel()
+	require.NoError(t, hub.Stop(context.Background()))
+}
+
+func TestWatchHub_ReportsActor(t *testing.T) {
+	hub := service.NewWatchHub(&fakeLanguageRepository{}, nil, nil)
+	events, cancel := hub.Watch(1, nil)
+	defer cancel()
+
+	// 服务发布事件时记录 context 中的当前用户，编辑器据此区分自己和其他人的修改
+	bus := service.NewInMemoryEventBus(nil)
+	bus.Subscribe(domain.EventTranslationUpdated, "cli-watch", hub.HandleEvent)
+	releases := newMemoryReleases(map[string]map[string]string{})
+	published := make(chan domain.Event, 1)
+	bus.Subscribe(domain.EventReleasePublished, "test", func(ctx context.Context, event domain.Event) error {
+		published <- event
+		return nil
+	})
+	svc := service.NewReleaseService(releases, preTranslateProjects{}, noopAuditLogs{}, releaseGate{}, bus, nil)
+	_, _, err := svc.Create(domain.WithActor(context.Background(), 7), 1, domain.ReleaseParams{Version: "v1"}, 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), (<-published).ActorID)
+
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, 1, domain.TranslationUpdatedPayload{Action: domain.TranslationActionUpdated})
+	require.NoError(t, err)
+	event.ActorID = 7
+	require.NoError(t, bus.Publish(context.Background(), event))
+	assert.Equal(t, uint64(7), (<-events).UserID)
+}
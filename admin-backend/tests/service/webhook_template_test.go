@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+// fakeTemplateProjectRepository 只返回一个项目的项目仓储
+type fakeTemplateProjectRepository struct {
+	domain.ProjectRepository
+	project *domain.Project
+}
+
+func (r *fakeTemplateProjectRepository) GetByID(ctx context.Context, id uint64) (*domain.Project, error) {
+	if r.project == nil || r.project.ID != id {
+		return nil, domain.ErrProjectNotFound
+	}
+	return r.project, nil
+}
+
+func newTemplateData(t *testing.T, keys ...string) *service.WebhookTemplateData {
+	t.Helper()
+	event, err := domain.NewEvent(domain.EventTranslationUpdated, 1, domain.TranslationUpdatedPayload{
+		Action:   domain.TranslationActionUpdated,
+		KeyNames: keys,
+		Count:    len(keys),
+	})
+	require.NoError(t, err)
+	data, err := service.NewWebhookTemplateData(event, &domain.Project{ID: 1, Name: `Web "main"`, Slug: "web"})
+	require.NoError(t, err)
+	return data
+}
+
+func TestRenderWebhookTemplate_Presets(t *testing.T) {
+	data := newTemplateData(t, "a", "b", "c", "d", "e", "f")
+	assert.Equal(t, "更新了 6 条翻译：a、b、c、d、e 等", data.Summary)
+
+	for _, preset := range []string{service.WebhookPresetGeneric, service.WebhookPresetSlack, service.WebhookPresetTeams} {
+		text, err := service.WebhookPresetTemplate(preset)
+		require.NoError(t, err)
+		body, err := service.RenderWebhookTemplate(text, data)
+		require.NoError(t, err, preset)
+		assert.Contains(t, string(body), `Web \"main\"`, preset) // 项目名称中的引号被正确转义
+	}
+
+	_, err := service.WebhookPresetTemplate("discord")
+	assert.ErrorIs(t, err, domain.ErrUnknownWebhookPreset)
+}
+
+func TestRenderWebhookTemplate_Functions(t *testing.T) {
+	data := newTemplateData(t, "home.title", "home.subtitle")
+	body, err := service.RenderWebhookTemplate(
+		`{"keys": {{json (join ", " .Payload.key_names)}}, "project": {{json (upper .Project.Slug)}}, "short": {{json (truncate 3 .Summary)}}, "locale": {{json (default "all" .Payload.locale)}}}`,
+		data)
+	require.NoError(t, err)
+
+	var result map[string]string
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "home.title, home.subtitle", result["keys"])
+	assert.Equal(t, "WEB", result["project"])
+	assert.Equal(t, "更新了…", result["short"])
+	assert.Equal(t, "all", result["locale"])
+}
+
+func TestRenderWebhookTemplate_Invalid(t *testing.T) {
+	data := newTemplateData(t, "home.title")
+
+	cases := map[string]string{
+		"语法错误":     `{"text": {{json .Summary}`,
+		"未知函数":     `{"text": {{exec "ls"}}}`,
+		"结果不是JSON": `{"text": {{.Summary}}}`,
+		"结果过大":     `[{{range $i, $_ := .Summary}}{{range $j, $_ := $.Summary}}{{if or $i $j}},{{end}}"{{printf "%0500d" 0}}"{{end}}{{end}}]`,
+	}
+	for name, text := range cases {
+		_, err := service.RenderWebhookTemplate(text, data)
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, name)
+		assert.Equal(t, domain.ErrInvalidWebhookTemplate.Code, appErr.Code, name)
+		assert.NotEmpty(t, appErr.Details, name)
+	}
+
+	_, err := service.RenderWebhookTemplate(strings.Repeat(" ", 20<<10)+"{}", data)
+	assert.Error(t, err)
+}
+
+func TestWebhookTemplateService_Preview(t *testing.T) {
+	webhooks := newMemoryWebhooks()
+	svc := service.NewWebhookTemplateService(&fakeTemplateProjectRepository{project: &domain.Project{ID: 7, Name: "App", Slug: "app"}}, webhooks)
+	ctx := context.Background()
+
+	preview, err := svc.Preview(ctx, 7, domain.WebhookTemplatePreviewParams{Preset: service.WebhookPresetSlack})
+	require.NoError(t, err)
+	assert.Equal(t, domain.EventTranslationUpdated, preview.EventType)
+	assert.Contains(t, string(preview.Body), "*App*")
+
+	// 自定义示例事件内容
+	preview, err = svc.Preview(ctx, 7, domain.WebhookTemplatePreviewParams{
+		Template:  `{"source": {{json .Payload.source}}, "summary": {{json .Summary}}}`,
+		EventType: domain.EventImportCompleted,
+		Payload:   json.RawMessage(`{"source": "webhook", "keys": 3, "translations": 6}`),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"source": "webhook", "summary": "从 webhook 导入了 3 个键、6 条翻译"}`, string(preview.Body))
+
+	// 使用 Webhook 保存的模板和 Webhook 事件名称
+	require.NoError(t, webhooks.Create(ctx, &domain.Webhook{ProjectID: 7, Template: `{"event": {{json .EventType}}, "summary": {{json .Summary}}}`}))
+	preview, err = svc.Preview(ctx, 7, domain.WebhookTemplatePreviewParams{WebhookID: 1, EventType: domain.WebhookEventReleasePublished})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"event": "release.published", "summary": "发布了版本 v1.2.0，包含 120 个键、360 条翻译"}`, string(preview.Body))
+	_, err = svc.Preview(ctx, 8, domain.WebhookTemplatePreviewParams{WebhookID: 1})
+	assert.Error(t, err)
+
+	_, err = svc.Preview(ctx, 7, domain.WebhookTemplatePreviewParams{EventType: domain.EventStorageThresholdExceeded})
+	assert.ErrorIs(t, err, domain.ErrUnsupportedEventType)
+	_, err = svc.Preview(ctx, 7, domain.WebhookTemplatePreviewParams{Payload: json.RawMessage(`[1, 2]`)})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+	_, err = svc.Preview(ctx, 8, domain.WebhookTemplatePreviewParams{})
+	assert.ErrorIs(t, err, domain.ErrProjectNotFound)
+}
@@ -0,0 +1,121 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func xliffSampleFiles() []*service.XLIFFFile {
+	return []*service.XLIFFFile{{
+		Original:       "web",
+		SourceLanguage: "en",
+		TargetLanguage: "zh_CN",
+		Units: []service.XLIFFUnit{
+			{Key: "home.title", Source: "Hello <b>{name}</b> & welcome", Target: "你好 <b>{name}</b>，欢迎", HasTarget: true, Note: "首页标题"},
+			{Key: "home.empty key", Source: "Nothing here"},
+		},
+	}}
+}
+
+func TestXLIFF_RoundTrip(t *testing.T) {
+	for _, version := range []string{service.XLIFFVersion12, service.XLIFFVersion20} {
+		data, err := service.MarshalXLIFF(version, xliffSampleFiles())
+		require.NoError(t, err, version)
+		assert.Contains(t, string(data), `"zh-CN"`, version) // 语言代码转换为 BCP 47 格式
+
+		files, err := service.ParseXLIFF(data)
+		require.NoError(t, err, version)
+		require.Len(t, files, 1, version)
+		assert.Equal(t, "en", files[0].SourceLanguage, version)
+		assert.Equal(t, "zh-CN", files[0].TargetLanguage, version)
+		assert.Equal(t, []service.XLIFFUnit{
+			{Key: "home.title", Source: "Hello <b>{name}</b> & welcome", Target: "你好 <b>{name}</b>，欢迎", HasTarget: true, Note: "首页标题"},
+			{Key: "home.empty key", Source: "Nothing here"},
+		}, files[0].Units, version)
+	}
+}
+
+func TestXLIFF_MultipleTargets(t *testing.T) {
+	files := append(xliffSampleFiles(), &service.XLIFFFile{Original: "web", SourceLanguage: "en", TargetLanguage: "de"})
+
+	data, err := service.MarshalXLIFF(service.XLIFFVersion12, files)
+	require.NoError(t, err)
+	parsed, err := service.ParseXLIFF(data)
+	require.NoError(t, err)
+	assert.Len(t, parsed, 2)
+
+	// XLIFF 2.0 文档只能有一种目标语言
+	_, err = service.MarshalXLIFF(service.XLIFFVersion20, files)
+	assert.ErrorIs(t, err, domain.ErrXLIFFMultipleTargets)
+	_, err = service.MarshalXLIFF("1.1", files)
+	assert.ErrorIs(t, err, domain.ErrUnsupportedXLIFFVersion)
+}
+
+func TestXLIFF_ParseCATToolOutput(t *testing.T) {
+	// CAT 工具保存的文件：带分组、行内标记、多个片段和多条备注
+	xliff12 := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file original="web" source-language="en-US" target-language="de-DE" datatype="plaintext">
+    <body>
+      <group id="home">
+        <trans-unit id="1" resname="home.title">
+          <source>Hello <g id="1">world</g></source>
+          <target state="final">Hallo <g id="1">Welt</g></target>
+          <note>Shown on top</note>
+          <note from="reviewer">Keep it short</note>
+        </trans-unit>
+      </group>
+      <trans-unit id="home.subtitle">
+        <source>Sub</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>`
+	files, err := service.ParseXLIFF([]byte(xliff12))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "de-DE", files[0].TargetLanguage)
+	assert.Equal(t, []service.XLIFFUnit{
+		{Key: "home.title", Source: "Hello world", Target: "Hallo Welt", HasTarget: true, Note: "Shown on top\nKeep it short"},
+		{Key: "home.subtitle", Source: "Sub"},
+	}, files[0].Units)
+
+	xliff20 := `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="fr">
+  <file id="f1">
+    <group id="g1">
+      <unit id="u1" name="intro">
+        <segment><source>One. </source><target>Un. </target></segment>
+        <segment><source>Two.</source><target>Deux.</target></segment>
+      </unit>
+    </group>
+  </file>
+</xliff>`
+	files, err = service.ParseXLIFF([]byte(xliff20))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "fr", files[0].TargetLanguage)
+	assert.Equal(t, []service.XLIFFUnit{{Key: "intro", Source: "One. Two.", Target: "Un. Deux.", HasTarget: true}}, files[0].Units)
+}
+
+func TestXLIFF_ParseInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"不是 XML":   `{"home.title": "Hello"}`,
+		"根元素错误":    `<resources><string name="a">b</string></resources>`,
+		"缺少版本":     `<xliff><file/></xliff>`,
+		"没有 file":  `<xliff version="1.2"></xliff>`,
+		"XML 格式错误": `<xliff version="1.2"><file><body></file></xliff>`,
+	} {
+		_, err := service.ParseXLIFF([]byte(data))
+		appErr, ok := domain.IsAppError(err)
+		require.True(t, ok, name)
+		assert.Equal(t, domain.ErrInvalidXLIFF.Code, appErr.Code, name)
+	}
+
+	_, err := service.ParseXLIFF([]byte(`<xliff version="3.0"><file/></xliff>`))
+	assert.ErrorIs(t, err, domain.ErrUnsupportedXLIFFVersion)
+}
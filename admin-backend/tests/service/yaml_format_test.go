@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"yflow/internal/domain"
+	"yflow/internal/service"
+)
+
+func TestMarshalLocaleYAML(t *testing.T) {
+	data, err := service.MarshalLocaleYAML(map[string]map[string]string{
+		"zh_CN": {"home.title": "首页"},
+		"en": {
+			"home.title":    "Home",
+			"home.subtitle": "Line 1\nLine 2",
+			"nav":           "Menu",
+			"nav.open":      "Open",
+			"count":         "10",
+			"empty..key":    "yes",
+		},
+	})
+	require.NoError(t, err)
+	// 键名按 . 嵌套；同一路径既有值又有子键时子键平铺；像数字和布尔值的字符串加引号
+	assert.Equal(t, `en:
+  count: "10"
+  empty..key: "yes"
+  home:
+    subtitle: |-
+      Line 1
+      Line 2
+    title: Home
+  nav: Menu
+  nav.open: Open
+zh_CN:
+  home:
+    title: 首页
+`, string(data))
+
+	values, err := service.ParseLocaleYAML(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Line 1\nLine 2", values["en"]["home.subtitle"])
+	assert.Equal(t, "Open", values["en"]["nav.open"])
+	assert.Equal(t, "yes", values["en"]["empty..key"])
+	assert.Len(t, values["en"], 6)
+}
+
+func TestParseLocaleYAML(t *testing.T) {
+	data := []byte(`
+defaults: &defaults
+  title: Default
+  count: 3
+en:
+  <<: *defaults
+  title: Welcome
+  enabled: true
+  missing: ~
+  days: [Mon, Tue]
+  errors:
+    messages:
+      blank: can't be blank
+`)
+	values, err := service.ParseLocaleYAML(data, "")
+	require.NoError(t, err)
+	// 根节点 defaults 也视为语言，由调用方按已有的语言代码过滤；合并键先展开，显式的键覆盖合并的值
+	assert.Contains(t, values, "defaults")
+	assert.Equal(t, map[string]string{
+		"title":                 "Welcome",
+		"count":                 "3",
+		"enabled":               "true",
+		"errors.messages.blank": "can't be blank",
+	}, values["en"])
+
+	// Symfony 风格：整个文档属于指定语言
+	values, err = service.ParseLocaleYAML([]byte("form:\n  submit: Senden\n"), "de")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"de": {"form.submit": "Senden"}}, values)
+
+	_, err = service.ParseLocaleYAML([]byte("en: Hello\n"), "")
+	appErr, ok := domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidYAML.Code, appErr.Code)
+
+	_, err = service.ParseLocaleYAML([]byte("en: [unclosed\n"), "")
+	appErr, ok = domain.IsAppError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.ErrInvalidYAML.Code, appErr.Code)
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"yflow/internal/domain"
+)
+
+// DatasetConfig 性能测试数据集规模：Projects 个项目 × Keys 个键 × Languages 种语言
+type DatasetConfig struct {
+	Projects  int
+	Keys      int
+	Languages int
+}
+
+// Dataset 已生成的数据集
+type Dataset struct {
+	Config      DatasetConfig
+	ProjectIDs  []uint64
+	LanguageIDs []uint64
+}
+
+// datasetBatchSize 批量插入翻译的批次大小
+const datasetBatchSize = 1000
+
+// DatasetConfigFromEnv 从环境变量读取数据集规模，未设置时使用默认值
+// PERF_PROJECTS、PERF_KEYS、PERF_LANGUAGES
+func DatasetConfigFromEnv() DatasetConfig {
+	return DatasetConfig{
+		Projects:  getEnvInt("PERF_PROJECTS", 2),
+		Keys:      getEnvInt("PERF_KEYS", 2000),
+		Languages: getEnvInt("PERF_LANGUAGES", 5),
+	}
+}
+
+// SeedDataset 直接写入数据库生成数据集，绕过服务层以加快生成速度
+func SeedDataset(t testing.TB, db *gorm.DB, config DatasetConfig) *Dataset {
+	dataset := &Dataset{Config: config}
+
+	for i := 0; i < config.Languages; i++ {
+		language := &domain.Language{
+			Code:      fmt.Sprintf("l%d", i),
+			Name:      fmt.Sprintf("Language %d", i),
+			IsDefault: i == 0,
+			Status:    "active",
+		}
+		require.NoError(t, db.Create(language).Error)
+		dataset.LanguageIDs = append(dataset.LanguageIDs, language.ID)
+	}
+
+	for p := 0; p < config.Projects; p++ {
+		project := &domain.Project{
+			Name:   fmt.Sprintf("Perf Project %d", p),
+			Slug:   fmt.Sprintf("perf-project-%d", p),
+			Status: "active",
+		}
+		require.NoError(t, db.Create(project).Error)
+		dataset.ProjectIDs = append(dataset.ProjectIDs, project.ID)
+
+		batch := make([]*domain.Translation, 0, datasetBatchSize)
+		for k := 0; k < config.Keys; k++ {
+			keyName := DatasetKeyName(k)
+			for _, languageID := range dataset.LanguageIDs {
+				batch = append(batch, &domain.Translation{
+					ProjectID:  project.ID,
+					KeyName:    keyName,
+					LanguageID: languageID,
+					Value:      fmt.Sprintf("Value of %s in language %d", keyName, languageID),
+					Status:     "active",
+				})
+				if len(batch) == datasetBatchSize {
+					require.NoError(t, db.CreateInBatches(batch, datasetBatchSize).Error)
+					batch = batch[:0]
+				}
+			}
+		}
+		if len(batch) > 0 {
+			require.NoError(t, db.CreateInBatches(batch, datasetBatchSize).Error)
+		}
+	}
+
+	return dataset
+}
+
+// DatasetKeyName 生成第 i 个翻译键名，按模块分组以模拟真实的键名分布
+func DatasetKeyName(i int) string {
+	return fmt.Sprintf("module%03d.section%02d.key%06d", i%100, i%17, i)
+}
+
+// getEnvInt 获取整数环境变量或默认值
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
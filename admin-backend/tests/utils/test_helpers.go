@@ -33,7 +33,7 @@ func SetupTestLogger(t *testing.T) (*zap.Logger, *observer.ObservedLogs) {
 }
 
 // SetupTestDB 创建测试数据库
-func SetupTestDB(t *testing.T) *gorm.DB {
+func SetupTestDB(t testing.TB) *gorm.DB {
 	// 使用环境变量或默认测试配置
 	dbUser := getEnvOrDefault("TEST_DB_USER", "root")
 	dbPass := getEnvOrDefault("TEST_DB_PASS", "")
@@ -70,6 +70,7 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&domain.Project{},
 		&domain.Language{},
 		&domain.Translation{},
+		&domain.TranslationHistory{},
 	)
 	require.NoError(t, err)
 
@@ -0,0 +1,8 @@
+//go:build tools
+
+// Package tools 记录代码生成工具的依赖，go mod tidy 时保留在 go.mod 中
+package tools
+
+import (
+	_ "github.com/99designs/gqlgen"
+)
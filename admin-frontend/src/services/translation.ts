@@ -43,7 +43,14 @@ export const getTranslationMatrix = async (
   projectId: number,
   page: number = 1,
   pageSize: number = 20,
-  keyword?: string
+  keyword?: string,
+  options?: {
+    sort?: 'key' | 'value'
+    sortLanguage?: string
+    order?: 'asc' | 'desc'
+    collation?: string
+    searchCollation?: 'accent_insensitive' | 'case_insensitive' | 'exact'
+  }
 ): Promise<TranslationMatrix> => {
   const params: Record<string, any> = {
     page,
@@ -52,6 +59,11 @@ export const getTranslationMatrix = async (
   if (keyword) {
     params.keyword = keyword
   }
+  if (options?.sort) params.sort = options.sort
+  if (options?.sortLanguage) params.sort_language = options.sortLanguage
+  if (options?.order) params.order = options.order
+  if (options?.collation) params.collation = options.collation
+  if (options?.searchCollation) params.search_collation = options.searchCollation
 
   return api.get(`/translations/matrix/by-project/${projectId}`, { params })
 }
@@ -114,11 +126,22 @@ export const batchDeleteTranslations = async (ids: number[]): Promise<void> => {
   return api.post('/translations/batch-delete', ids)
 }
 
+/**
+ * 导出选项
+ */
+export interface ExportOptions {
+  only_status?: 'approved'
+  missing?: 'skip' | 'empty' | 'source_fallback'
+}
+
 /**
  * 导出项目翻译
  */
-export const exportTranslations = async (projectId: number): Promise<any> => {
-  return api.get(`/exports/project/${projectId}`)
+export const exportTranslations = async (
+  projectId: number,
+  options: ExportOptions = {}
+): Promise<any> => {
+  return api.get(`/exports/project/${projectId}`, { params: options })
 }
 
 /**
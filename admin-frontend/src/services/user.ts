@@ -61,3 +61,45 @@ export const deleteUser = async (id: number): Promise<void> => {
 export const resetUserPassword = async (id: number, data: ResetPasswordRequest): Promise<void> => {
   return api.post(`/users/${id}/reset-password`, data)
 }
+
+/**
+ * 个人访问令牌
+ */
+export interface AccessToken {
+  id: number
+  name: string
+  token_prefix: string
+  expires_at: string
+  expired: boolean
+  last_used_at?: string
+  last_used_ip?: string
+  created_at: string
+}
+
+/**
+ * 获取当前用户的个人访问令牌
+ * @returns 令牌列表（不包含令牌本身）
+ */
+export const getAccessTokens = async (): Promise<AccessToken[]> => {
+  return api.get('/user/tokens')
+}
+
+/**
+ * 创建个人访问令牌
+ * @param data 令牌名称和有效天数
+ * @returns 创建的令牌，token 只在创建时返回
+ */
+export const createAccessToken = async (data: {
+  name: string
+  expires_in_days?: number
+}): Promise<AccessToken & { token: string }> => {
+  return api.post('/user/tokens', data)
+}
+
+/**
+ * 撤销个人访问令牌
+ * @param id 令牌 ID
+ */
+export const revokeAccessToken = async (id: number): Promise<void> => {
+  return api.delete(`/user/tokens/${id}`)
+}